@@ -12,20 +12,27 @@ import (
 
 	"github.com/weaveworks/eksctl/pkg/actions/anywhere"
 	"github.com/weaveworks/eksctl/pkg/ctl/associate"
+	"github.com/weaveworks/eksctl/pkg/ctl/bootstrap"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/ctl/completion"
 	"github.com/weaveworks/eksctl/pkg/ctl/create"
 	"github.com/weaveworks/eksctl/pkg/ctl/delete"
 	"github.com/weaveworks/eksctl/pkg/ctl/disassociate"
 	"github.com/weaveworks/eksctl/pkg/ctl/drain"
+	"github.com/weaveworks/eksctl/pkg/ctl/e2e"
 	"github.com/weaveworks/eksctl/pkg/ctl/enable"
+	"github.com/weaveworks/eksctl/pkg/ctl/gc"
 	"github.com/weaveworks/eksctl/pkg/ctl/get"
+	"github.com/weaveworks/eksctl/pkg/ctl/rollback"
 	"github.com/weaveworks/eksctl/pkg/ctl/scale"
 	"github.com/weaveworks/eksctl/pkg/ctl/set"
+	"github.com/weaveworks/eksctl/pkg/ctl/top"
 	"github.com/weaveworks/eksctl/pkg/ctl/unset"
 	"github.com/weaveworks/eksctl/pkg/ctl/update"
 	"github.com/weaveworks/eksctl/pkg/ctl/upgrade"
 	"github.com/weaveworks/eksctl/pkg/ctl/utils"
+	"github.com/weaveworks/eksctl/pkg/integrations"
+	"github.com/weaveworks/eksctl/pkg/utils/exitcode"
 )
 
 func addCommands(rootCmd *cobra.Command, flagGrouping *cmdutils.FlagGrouping) {
@@ -35,11 +42,13 @@ func addCommands(rootCmd *cobra.Command, flagGrouping *cmdutils.FlagGrouping) {
 	rootCmd.AddCommand(get.Command(flagGrouping))
 	rootCmd.AddCommand(update.Command(flagGrouping))
 	rootCmd.AddCommand(upgrade.Command(flagGrouping))
+	rootCmd.AddCommand(rollback.Command(flagGrouping))
 	rootCmd.AddCommand(delete.Command(flagGrouping))
 	rootCmd.AddCommand(set.Command(flagGrouping))
 	rootCmd.AddCommand(unset.Command(flagGrouping))
 	rootCmd.AddCommand(scale.Command(flagGrouping))
 	rootCmd.AddCommand(drain.Command(flagGrouping))
+	rootCmd.AddCommand(top.Command(flagGrouping))
 	rootCmd.AddCommand(enable.Command(flagGrouping))
 	rootCmd.AddCommand(register.Command(flagGrouping))
 	rootCmd.AddCommand(deregister.Command(flagGrouping))
@@ -50,6 +59,9 @@ func addCommands(rootCmd *cobra.Command, flagGrouping *cmdutils.FlagGrouping) {
 
 	cmdutils.AddResourceCmd(flagGrouping, rootCmd, infoCmd)
 	cmdutils.AddResourceCmd(flagGrouping, rootCmd, versionCmd)
+	cmdutils.AddResourceCmd(flagGrouping, rootCmd, gc.Command)
+	cmdutils.AddResourceCmd(flagGrouping, rootCmd, bootstrap.Command)
+	cmdutils.AddResourceCmd(flagGrouping, rootCmd, e2e.Command)
 }
 
 func main() {
@@ -84,13 +96,40 @@ func main() {
 	addCommands(rootCmd, flagGrouping)
 	checkCommand(rootCmd)
 
+	integrationRegistry, err := integrations.Default()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", err.Error())
+	}
+	integrations.AddCommands(rootCmd, integrationRegistry)
+
 	rootCmd.PersistentFlags().BoolP("help", "h", false, "help for this command")
 
 	loggerLevel := rootCmd.PersistentFlags().IntP("verbose", "v", 3, "set log level, use 0 to silence, 4 for debugging and 5 for debugging with AWS debug logging")
 	colorValue := rootCmd.PersistentFlags().StringP("color", "C", "true", "toggle colorized logs (valid options: true, false, fabulous)")
 
+	cmdutils.AddInteractionFlags(rootCmd.PersistentFlags())
+
 	dumpLogsValue := rootCmd.PersistentFlags().BoolP("dumpLogs", "d", false, "dump logs to disk on failure if set to true")
 
+	errorFormatValue := rootCmd.PersistentFlags().String("error-format", string(exitcode.FormatText), "format of the error printed when a command fails (valid options: text, json)")
+	// the format is read before the flags are parsed, so that errors parsing them are written in that format too,
+	// and an invalid format is reported once the flags are parsed
+	errorFormat := exitcode.FormatText
+	if format, err := exitcode.ParseFormat(exitcode.FormatFromArgs(os.Args[1:])); err == nil {
+		errorFormat = format
+	}
+	// the error is written as JSON once the command returns
+	rootCmd.SilenceErrors = errorFormat == exitcode.FormatJSON
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return exitcode.WithCode(err, exitcode.ValidationError)
+	})
+	rootCmd.PersistentPreRunE = func(c *cobra.Command, _ []string) error {
+		if _, err := exitcode.ParseFormat(*errorFormatValue); err != nil {
+			return exitcode.WithCode(err, exitcode.ValidationError)
+		}
+		return nil
+	}
+
 	logBuffer := new(bytes.Buffer)
 
 	cobra.OnInitialize(func() {
@@ -107,7 +146,12 @@ func main() {
 			}
 		}
 
-		os.Exit(1)
+		if errorFormat == exitcode.FormatJSON {
+			if writeErr := exitcode.WriteJSON(os.Stderr, err); writeErr != nil {
+				logger.Debug("failed to write error as JSON: %v", writeErr)
+			}
+		}
+		os.Exit(int(exitcode.Classify(err)))
 	}
 }
 
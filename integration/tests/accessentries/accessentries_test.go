@@ -336,6 +336,7 @@ var _ = Describe("(Integration) [AccessEntries Test]", func() {
 				WithArgs(
 					"cluster",
 					"--name", apiEnabledCluster,
+					"--approve",
 					"--wait",
 				).Run()
 			Expect(session.ExitCode()).To(Equal(1))
@@ -513,6 +514,7 @@ var _ = SynchronizedAfterSuite(func() {}, func() {
 		WithArgs(
 			"cluster",
 			"--name", apiDisabledCluster,
+			"--approve",
 			"--disable-nodegroup-eviction",
 			"--wait",
 		)).To(RunSuccessfully())
@@ -521,6 +523,7 @@ var _ = SynchronizedAfterSuite(func() {}, func() {
 		WithArgs(
 			"cluster",
 			"--name", apiEnabledCluster,
+			"--approve",
 			"--disable-nodegroup-eviction",
 			"--wait",
 		)).To(RunSuccessfully())
@@ -841,6 +841,7 @@ var _ = Describe("(Integration) [EKS Addons test]", func() {
 var _ = AfterSuite(func() {
 	cmd := params.EksctlDeleteCmd.WithArgs(
 		"cluster", params.ClusterName,
+		"--approve",
 		"--disable-nodegroup-eviction",
 		"--verbose", "2",
 	)
@@ -141,6 +141,7 @@ var _ = Describe("(Integration) Create and Update Cluster with Endpoint Configs"
 				deleteCmd := params.EksctlDeleteCmd.WithArgs(
 					"cluster",
 					"--name", clName,
+					"--approve",
 				)
 				Expect(deleteCmd).Should(RunSuccessfully())
 				awsSession := NewConfig(params.Region)
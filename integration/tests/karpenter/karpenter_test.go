@@ -46,6 +46,7 @@ var _ = Describe("(Integration) Karpenter", func() {
 	AfterEach(func() {
 		cmd := params.EksctlDeleteCmd.WithArgs(
 			"cluster", clusterName,
+			"--approve",
 			"--verbose", "4",
 		)
 		Expect(cmd).To(RunSuccessfully())
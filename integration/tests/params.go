@@ -123,7 +123,7 @@ func (p *Params) GenerateCommands() {
 		WithTimeout(30 * time.Minute)
 
 	p.EksctlDeleteClusterCmd = p.EksctlDeleteCmd.
-		WithArgs("cluster", "--verbose", "4").
+		WithArgs("cluster", "--approve", "--verbose", "4").
 		WithTimeout(40 * time.Minute)
 
 	p.EksctlDrainNodeGroupCmd = p.EksctlCmd.
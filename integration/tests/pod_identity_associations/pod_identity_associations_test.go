@@ -550,10 +550,12 @@ var _ = SynchronizedAfterSuite(func() {}, func() {
 
 	Expect(params.EksctlDeleteCmd.WithArgs(
 		"cluster", clusterIRSAv1,
+		"--approve",
 	)).To(RunSuccessfully())
 
 	Expect(params.EksctlDeleteCmd.WithArgs(
 		"cluster", clusterIRSAv2,
+		"--approve",
 	)).To(RunSuccessfully())
 
 	_, err := ctl.AWSProvider.IAM().DeleteRole(context.Background(), &iam.DeleteRoleInput{
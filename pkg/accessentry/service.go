@@ -60,7 +60,7 @@ func ValidateAPIServerAccess(clusterConfig *api.ClusterConfig) error {
 		}
 		logger.Warning("%s; %s", bootstrapFalseMsg, apiServerConnectivityMsg)
 	default:
-		if len(clusterConfig.AccessConfig.AccessEntries) == 0 {
+		if len(clusterConfig.AccessConfig.AccessEntriesToCreate()) == 0 {
 			if len(clusterConfig.NodeGroups) > 0 {
 				return fmt.Errorf("cannot create self-managed nodegroups when %s and no access entries are configured", bootstrapFalseMsg)
 			}
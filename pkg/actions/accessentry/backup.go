@@ -0,0 +1,192 @@
+package accessentry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+)
+
+// AuthBackupVersion is the version of the auth backup format written by BackupAuth.
+// It must be bumped whenever a change to AuthBackup is not backwards compatible.
+const AuthBackupVersion = 1
+
+// nodeGroup is the Kubernetes group of node IAM roles, which EKS manages for EC2 and Fargate access entries
+const nodeGroup = "system:nodes"
+
+// AuthBackup is a point-in-time copy of the IAM principals that can access a cluster.
+type AuthBackup struct {
+	Version     int       `json:"version"`
+	ClusterName string    `json:"clusterName"`
+	CreatedAt   time.Time `json:"createdAt"`
+	// AuthConfigMap holds the data of the aws-auth ConfigMap, it is nil if the ConfigMap did not exist
+	AuthConfigMap map[string]string `json:"authConfigMap,omitempty"`
+	AccessEntries []Summary         `json:"accessEntries,omitempty"`
+}
+
+// AuthBackupManager backs up and restores the aws-auth ConfigMap and access entries of a cluster.
+type AuthBackupManager struct {
+	ClusterName string
+	AuthMode    ekstypes.AuthenticationMode
+	ClientSet   kubernetes.Interface
+	Getter      GetterInterface
+	Creator     CreatorInterface
+}
+
+// BackupAuth reads the aws-auth ConfigMap and the access entries of the cluster, skipping whichever
+// is not used by the cluster's authentication mode.
+func (m *AuthBackupManager) BackupAuth(ctx context.Context) (*AuthBackup, error) {
+	backup := &AuthBackup{
+		Version:     AuthBackupVersion,
+		ClusterName: m.ClusterName,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if m.AuthMode != ekstypes.AuthenticationModeApi {
+		cm, err := m.ClientSet.CoreV1().ConfigMaps(authconfigmap.ObjectNamespace).Get(ctx, authconfigmap.ObjectName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			logger.Warning("%s ConfigMap not found in cluster %q", authconfigmap.ObjectName, m.ClusterName)
+		case err != nil:
+			return nil, fmt.Errorf("getting %s ConfigMap: %w", authconfigmap.ObjectName, err)
+		default:
+			backup.AuthConfigMap = cm.Data
+		}
+	}
+
+	if m.AuthMode != ekstypes.AuthenticationModeConfigMap {
+		accessEntries, err := m.Getter.Get(ctx, api.ARN{})
+		if err != nil {
+			return nil, fmt.Errorf("fetching access entries: %w", err)
+		}
+		backup.AccessEntries = accessEntries
+	}
+	return backup, nil
+}
+
+// RestoreAuth restores the aws-auth ConfigMap from backup, replacing its current data, and creates the access
+// entries in backup that no longer exist in the cluster. Existing access entries are left untouched.
+func (m *AuthBackupManager) RestoreAuth(ctx context.Context, backup *AuthBackup) error {
+	if backup.AuthConfigMap != nil {
+		if m.AuthMode == ekstypes.AuthenticationModeApi {
+			logger.Warning("not restoring %s ConfigMap as cluster authentication mode is %s", authconfigmap.ObjectName, m.AuthMode)
+		} else if err := m.restoreAuthConfigMap(ctx, backup.AuthConfigMap); err != nil {
+			return err
+		}
+	}
+
+	if len(backup.AccessEntries) == 0 {
+		return nil
+	}
+	if m.AuthMode == ekstypes.AuthenticationModeConfigMap {
+		logger.Warning("not restoring access entries as cluster authentication mode is %s", m.AuthMode)
+		return nil
+	}
+	accessEntries, err := m.missingAccessEntries(ctx, backup.AccessEntries)
+	if err != nil {
+		return err
+	}
+	if len(accessEntries) == 0 {
+		logger.Info("all access entries in backup exist already")
+		return nil
+	}
+	return m.Creator.Create(ctx, accessEntries)
+}
+
+func (m *AuthBackupManager) restoreAuthConfigMap(ctx context.Context, data map[string]string) error {
+	client := m.ClientSet.CoreV1().ConfigMaps(authconfigmap.ObjectNamespace)
+	cm, err := client.Get(ctx, authconfigmap.ObjectName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		logger.Info("creating %s ConfigMap", authconfigmap.ObjectName)
+		_, err = client.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: authconfigmap.ObjectMeta(),
+			Data:       data,
+		}, metav1.CreateOptions{})
+	case err != nil:
+		return fmt.Errorf("getting %s ConfigMap: %w", authconfigmap.ObjectName, err)
+	default:
+		logger.Info("replacing data of %s ConfigMap", authconfigmap.ObjectName)
+		cm.Data = data
+		_, err = client.Update(ctx, cm, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("restoring %s ConfigMap: %w", authconfigmap.ObjectName, err)
+	}
+	return nil
+}
+
+func (m *AuthBackupManager) missingAccessEntries(ctx context.Context, summaries []Summary) ([]api.AccessEntry, error) {
+	existing, err := m.Getter.Get(ctx, api.ARN{})
+	if err != nil {
+		return nil, fmt.Errorf("fetching existing access entries: %w", err)
+	}
+	existingARNs := map[string]struct{}{}
+	for _, ae := range existing {
+		existingARNs[ae.PrincipalARN] = struct{}{}
+	}
+
+	var accessEntries []api.AccessEntry
+	for _, s := range summaries {
+		if _, ok := existingARNs[s.PrincipalARN]; ok {
+			continue
+		}
+		if isNodeAccessEntry(s) {
+			logger.Warning("skipping access entry for node role %s, it is recreated along with its nodegroup", s.PrincipalARN)
+			continue
+		}
+		var principalARN api.ARN
+		if err := principalARN.UnmarshalText([]byte(s.PrincipalARN)); err != nil {
+			return nil, fmt.Errorf("parsing principal ARN of access entry: %w", err)
+		}
+		accessEntries = append(accessEntries, api.AccessEntry{
+			PrincipalARN:       principalARN,
+			Type:               s.Type,
+			KubernetesUsername: s.Username,
+			KubernetesGroups:   s.KubernetesGroups,
+			AccessPolicies:     s.AccessPolicies,
+		})
+	}
+	return accessEntries, nil
+}
+
+func isNodeAccessEntry(s Summary) bool {
+	switch api.AccessEntryType(s.Type) {
+	case api.AccessEntryTypeLinux, api.AccessEntryTypeWindows, api.AccessEntryTypeFargateLinux:
+		return true
+	}
+	// backups taken before the type was recorded only have the groups EKS assigns to node roles
+	for _, group := range s.KubernetesGroups {
+		if group == nodeGroup {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalAuthBackup serializes backup to YAML.
+func MarshalAuthBackup(backup *AuthBackup) ([]byte, error) {
+	return yaml.Marshal(backup)
+}
+
+// UnmarshalAuthBackup parses a backup written by MarshalAuthBackup.
+func UnmarshalAuthBackup(data []byte) (*AuthBackup, error) {
+	var backup AuthBackup
+	if err := yaml.UnmarshalStrict(data, &backup); err != nil {
+		return nil, fmt.Errorf("parsing auth backup: %w", err)
+	}
+	if backup.Version != AuthBackupVersion {
+		return nil, fmt.Errorf("unsupported auth backup version %d, expected version %d", backup.Version, AuthBackupVersion)
+	}
+	return &backup, nil
+}
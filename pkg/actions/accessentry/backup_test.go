@@ -0,0 +1,149 @@
+package accessentry_test
+
+import (
+	"context"
+
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/accessentry/fakes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+)
+
+var _ = Describe("Auth backup", func() {
+	const (
+		clusterName = "test-cluster"
+		mapRoles    = "- rolearn: arn:aws:iam::111122223333:role/admin\n  username: admin\n  groups:\n  - system:masters\n"
+	)
+
+	var (
+		clientSet *fake.Clientset
+		getter    *fakes.FakeGetterInterface
+		creator   *fakes.FakeCreatorInterface
+		manager   *accessentry.AuthBackupManager
+	)
+
+	BeforeEach(func() {
+		clientSet = fake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: authconfigmap.ObjectMeta(),
+			Data:       map[string]string{"mapRoles": mapRoles},
+		})
+		getter = &fakes.FakeGetterInterface{}
+		creator = &fakes.FakeCreatorInterface{}
+		manager = &accessentry.AuthBackupManager{
+			ClusterName: clusterName,
+			AuthMode:    ekstypes.AuthenticationModeApiAndConfigMap,
+			ClientSet:   clientSet,
+			Getter:      getter,
+			Creator:     creator,
+		}
+	})
+
+	It("backs up the aws-auth ConfigMap and access entries", func() {
+		getter.GetReturns([]accessentry.Summary{{PrincipalARN: "arn:aws:iam::111122223333:role/viewer", KubernetesGroups: []string{"viewers"}}}, nil)
+
+		backup, err := manager.BackupAuth(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backup.Version).To(Equal(accessentry.AuthBackupVersion))
+		Expect(backup.ClusterName).To(Equal(clusterName))
+		Expect(backup.AuthConfigMap).To(Equal(map[string]string{"mapRoles": mapRoles}))
+		Expect(backup.AccessEntries).To(HaveLen(1))
+
+		data, err := accessentry.MarshalAuthBackup(backup)
+		Expect(err).NotTo(HaveOccurred())
+		restored, err := accessentry.UnmarshalAuthBackup(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored.AuthConfigMap).To(Equal(backup.AuthConfigMap))
+		Expect(restored.AccessEntries).To(Equal(backup.AccessEntries))
+	})
+
+	It("does not back up access entries when the authentication mode is CONFIG_MAP", func() {
+		manager.AuthMode = ekstypes.AuthenticationModeConfigMap
+
+		backup, err := manager.BackupAuth(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backup.AuthConfigMap).NotTo(BeNil())
+		Expect(backup.AccessEntries).To(BeEmpty())
+		Expect(getter.GetCallCount()).To(Equal(0))
+	})
+
+	It("restores the aws-auth ConfigMap and access entries that no longer exist", func() {
+		Expect(clientSet.CoreV1().ConfigMaps(authconfigmap.ObjectNamespace).Delete(context.Background(), authconfigmap.ObjectName, metav1.DeleteOptions{})).To(Succeed())
+		getter.GetReturns([]accessentry.Summary{{PrincipalARN: "arn:aws:iam::111122223333:role/existing"}}, nil)
+
+		err := manager.RestoreAuth(context.Background(), &accessentry.AuthBackup{
+			Version:       accessentry.AuthBackupVersion,
+			ClusterName:   clusterName,
+			AuthConfigMap: map[string]string{"mapRoles": mapRoles},
+			AccessEntries: []accessentry.Summary{
+				{PrincipalARN: "arn:aws:iam::111122223333:role/existing"},
+				{PrincipalARN: "arn:aws:iam::111122223333:role/node", KubernetesGroups: []string{"system:bootstrappers", "system:nodes"}},
+				{PrincipalARN: "arn:aws:iam::111122223333:role/viewer", KubernetesGroups: []string{"viewers"}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		cm, err := clientSet.CoreV1().ConfigMaps(authconfigmap.ObjectNamespace).Get(context.Background(), authconfigmap.ObjectName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cm.Data).To(Equal(map[string]string{"mapRoles": mapRoles}))
+
+		Expect(creator.CreateCallCount()).To(Equal(1))
+		_, accessEntries := creator.CreateArgsForCall(0)
+		Expect(accessEntries).To(Equal([]api.AccessEntry{
+			{
+				PrincipalARN:     api.MustParseARN("arn:aws:iam::111122223333:role/viewer"),
+				KubernetesGroups: []string{"viewers"},
+			},
+		}))
+	})
+
+	It("restores the type and username of access entries from a backup file", func() {
+		getter.GetReturnsOnCall(0, []accessentry.Summary{
+			{
+				PrincipalARN:     "arn:aws:iam::111122223333:role/viewer",
+				Type:             "STANDARD",
+				Username:         "viewer",
+				KubernetesGroups: []string{"viewers"},
+			},
+			{
+				PrincipalARN: "arn:aws:iam::111122223333:role/node",
+				Type:         "EC2_LINUX",
+				Username:     "system:node:{{EC2PrivateDNSName}}",
+			},
+		}, nil)
+		getter.GetReturnsOnCall(1, nil, nil)
+
+		backup, err := manager.BackupAuth(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		data, err := accessentry.MarshalAuthBackup(backup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("username: viewer"))
+		restored, err := accessentry.UnmarshalAuthBackup(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored.AccessEntries).To(Equal(backup.AccessEntries))
+
+		Expect(manager.RestoreAuth(context.Background(), restored)).To(Succeed())
+		Expect(creator.CreateCallCount()).To(Equal(1))
+		_, accessEntries := creator.CreateArgsForCall(0)
+		Expect(accessEntries).To(Equal([]api.AccessEntry{
+			{
+				PrincipalARN:       api.MustParseARN("arn:aws:iam::111122223333:role/viewer"),
+				Type:               "STANDARD",
+				KubernetesUsername: "viewer",
+				KubernetesGroups:   []string{"viewers"},
+			},
+		}))
+	})
+
+	It("rejects backups with an unsupported version", func() {
+		_, err := accessentry.UnmarshalAuthBackup([]byte("version: 2\nclusterName: test-cluster\n"))
+		Expect(err).To(MatchError("unsupported auth backup version 2, expected version 1"))
+	})
+})
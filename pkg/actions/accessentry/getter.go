@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
@@ -30,6 +31,8 @@ func NewGetter(clusterName string, eksAPI awsapi.EKS) *Getter {
 
 type Summary struct {
 	PrincipalARN     string             `json:"principalARN"`
+	Type             string             `json:"type,omitempty"`
+	Username         string             `json:"username,omitempty"`
 	KubernetesGroups []string           `json:"kubernetesGroups,omitempty"`
 	AccessPolicies   []api.AccessPolicy `json:"accessPolicies,omitempty"`
 }
@@ -65,7 +68,7 @@ func (aeg *Getter) getIndividualEntry(ctx context.Context, principalARN string)
 		AccessPolicies: []api.AccessPolicy{},
 	}
 
-	// fetch kubernetes groups
+	// fetch type, username and kubernetes groups
 	entry, err := aeg.eksAPI.DescribeAccessEntry(ctx, &eks.DescribeAccessEntryInput{
 		ClusterName:  &aeg.clusterName,
 		PrincipalArn: &principalARN,
@@ -73,6 +76,8 @@ func (aeg *Getter) getIndividualEntry(ctx context.Context, principalARN string)
 	if err != nil {
 		return Summary{}, fmt.Errorf("calling EKS API to describe access entry with principal ARN %s: %w", principalARN, err)
 	}
+	summary.Type = aws.ToString(entry.AccessEntry.Type)
+	summary.Username = aws.ToString(entry.AccessEntry.Username)
 	summary.KubernetesGroups = entry.AccessEntry.KubernetesGroups
 
 	// fetch associated polices
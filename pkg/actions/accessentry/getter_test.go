@@ -118,6 +118,8 @@ var _ = Describe("Get", func() {
 					}).
 					Return(&eks.DescribeAccessEntryOutput{
 						AccessEntry: &ekstypes.AccessEntry{
+							Type:             aws.String("STANDARD"),
+							Username:         aws.String("user-1"),
 							KubernetesGroups: []string{kGroup1, kGroup2},
 						},
 					}, nil)
@@ -143,6 +145,8 @@ var _ = Describe("Get", func() {
 			expectedOutput: []accessentry.Summary{
 				{
 					PrincipalARN:     mockPrincipalArn1,
+					Type:             "STANDARD",
+					Username:         "user-1",
 					KubernetesGroups: []string{kGroup1, kGroup2},
 					AccessPolicies: []api.AccessPolicy{
 						{
@@ -123,7 +123,7 @@ func (m *Migrator) MigrateToAccessEntry(ctx context.Context, options MigrationOp
 		}
 	}
 
-	return runAllTasks(&taskTree)
+	return runAllTasks(ctx, &taskTree)
 }
 
 func (m *Migrator) doUpdateAuthenticationMode(ctx context.Context, authMode ekstypes.AuthenticationMode, timeout time.Duration) error {
@@ -303,12 +303,13 @@ func doBuildAccessEntry(cme iam.Identity) *api.AccessEntry {
 		if strings.HasPrefix(group, "system:") {
 			containsSys = true
 			if group == "system:masters" { // Cluster Admin Role
+				principalARN := api.MustParseARN(cme.ARN())
 				return &api.AccessEntry{
-					PrincipalARN: api.MustParseARN(cme.ARN()),
+					PrincipalARN: principalARN,
 					Type:         "STANDARD",
 					AccessPolicies: []api.AccessPolicy{
 						{
-							PolicyARN: api.MustParseARN("arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy"),
+							PolicyARN: api.MustParseARN(fmt.Sprintf("arn:%s:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy", principalARN.Partition)),
 							AccessScope: api.AccessScope{
 								Type: ekstypes.AccessScopeTypeCluster,
 							},
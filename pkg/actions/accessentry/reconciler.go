@@ -0,0 +1,400 @@
+package accessentry
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/iam"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+// SourceOfTruth is the authentication system reconcile-auth converges the other one to
+type SourceOfTruth string
+
+const (
+	// SourceOfTruthAccessEntries updates the aws-auth ConfigMap to match the access entries
+	SourceOfTruthAccessEntries SourceOfTruth = "access-entries"
+	// SourceOfTruthAWSAuth updates the access entries to match the aws-auth ConfigMap
+	SourceOfTruthAWSAuth SourceOfTruth = "aws-auth"
+
+	clusterAdminGroup  = "system:masters"
+	clusterAdminPolicy = "cluster-access-policy/AmazonEKSClusterAdminPolicy"
+	windowsNodeGroup   = "eks:kube-proxy-windows"
+	// systemGroupPrefix is the prefix of the Kubernetes groups that access entries cannot grant
+	systemGroupPrefix = "system:"
+
+	// sessionNodeUsername is the username of Fargate pod execution roles and hybrid node roles in the aws-auth ConfigMap
+	sessionNodeUsername = "system:node:{{SessionName}}"
+	// fargateNodeGroup is the group Fargate pod execution roles are mapped to, on top of the node groups
+	fargateNodeGroup = "system:node-proxier"
+
+	// access entry types of EKS Auto Mode nodes and of hybrid nodes, which eksctl does not create
+	accessEntryTypeEC2         = "EC2"
+	accessEntryTypeHybridLinux = "HYBRID_LINUX"
+)
+
+// AuthConflict is a principal mapped both in the aws-auth ConfigMap and by an access entry, with differing permissions
+type AuthConflict struct {
+	Identity    iam.Identity
+	AccessEntry Summary
+	Differences []string
+}
+
+func (c AuthConflict) String() string {
+	return fmt.Sprintf("%s: %s", c.AccessEntry.PrincipalARN, strings.Join(c.Differences, "; "))
+}
+
+// FindAuthConflicts returns the principals mapped both in the aws-auth ConfigMap and by an access entry whose
+// permissions differ. As eksctl maps roles without their path in the aws-auth ConfigMap, principals are matched
+// regardless of the path of their ARN.
+func FindAuthConflicts(identities []iam.Identity, accessEntries []Summary) []AuthConflict {
+	accessEntriesByARN := map[string]Summary{}
+	for _, ae := range accessEntries {
+		accessEntriesByARN[principalARNWithoutPath(ae.PrincipalARN)] = ae
+	}
+	var conflicts []AuthConflict
+	for _, identity := range identities {
+		if identity.Type() == iam.ResourceTypeAccount {
+			continue
+		}
+		ae, ok := accessEntriesByARN[principalARNWithoutPath(identity.ARN())]
+		if !ok {
+			continue
+		}
+		if differences := authDifferences(identity, ae); len(differences) > 0 {
+			conflicts = append(conflicts, AuthConflict{Identity: identity, AccessEntry: ae, Differences: differences})
+		}
+	}
+	return conflicts
+}
+
+// WarnAuthConflicts logs a warning for each principal whose permissions differ between the aws-auth ConfigMap and its access entry
+func WarnAuthConflicts(clusterName string, conflicts []AuthConflict) {
+	for _, c := range conflicts {
+		logger.Warning("principal %s has different permissions in the aws-auth ConfigMap and in its access entry: %s",
+			c.AccessEntry.PrincipalARN, strings.Join(c.Differences, "; "))
+	}
+	if len(conflicts) > 0 {
+		logger.Warning("run 'eksctl utils reconcile-auth --cluster=%s --source-of-truth=<access-entries|aws-auth>' to converge them", clusterName)
+	}
+}
+
+func authDifferences(identity iam.Identity, ae Summary) []string {
+	nodeTypes := nodeAccessEntryTypes(identity)
+	isNodeEntry := ae.Type != string(api.AccessEntryTypeStandard)
+	switch {
+	case len(nodeTypes) == 0 && isNodeEntry:
+		return []string{fmt.Sprintf("mapped as a user in the aws-auth ConfigMap, access entry of type %s", ae.Type)}
+	case len(nodeTypes) > 0 && !isNodeEntry:
+		return []string{fmt.Sprintf("mapped as a node in the aws-auth ConfigMap, access entry of type %s", ae.Type)}
+	case isNodeEntry:
+		if !slices.Contains(nodeTypes, ae.Type) {
+			return []string{fmt.Sprintf("mapped as a node of type %s in the aws-auth ConfigMap, access entry of type %s", strings.Join(nodeTypes, " or "), ae.Type)}
+		}
+		return nil
+	}
+
+	var differences []string
+	groups, isAdmin := splitClusterAdminGroup(identity.Groups())
+	// access entries cannot grant system groups, so they are not compared
+	groups, _ = splitSystemGroups(groups)
+	if hasAdminPolicy := hasClusterAdminPolicy(ae.AccessPolicies); isAdmin != hasAdminPolicy {
+		differences = append(differences, fmt.Sprintf("cluster admin in the aws-auth ConfigMap: %t, in the access entry: %t", isAdmin, hasAdminPolicy))
+	}
+	if !equalGroups(groups, ae.KubernetesGroups) {
+		differences = append(differences, fmt.Sprintf("Kubernetes groups [%s] in the aws-auth ConfigMap, [%s] in the access entry",
+			strings.Join(groups, ", "), strings.Join(ae.KubernetesGroups, ", ")))
+	}
+	if identity.Username() != "" && ae.Username != "" && identity.Username() != ae.Username {
+		differences = append(differences, fmt.Sprintf("username %q in the aws-auth ConfigMap, %q in the access entry", identity.Username(), ae.Username))
+	}
+	return differences
+}
+
+// AuthReconciler converges the permissions of the principals mapped both in the aws-auth ConfigMap and by an access entry
+type AuthReconciler struct {
+	clusterName string
+	eksAPI      awsapi.EKS
+	clientSet   kubernetes.Interface
+	aeGetter    GetterInterface
+}
+
+// NewAuthReconciler creates an AuthReconciler
+func NewAuthReconciler(clusterName string, eksAPI awsapi.EKS, clientSet kubernetes.Interface, aeGetter GetterInterface) *AuthReconciler {
+	return &AuthReconciler{
+		clusterName: clusterName,
+		eksAPI:      eksAPI,
+		clientSet:   clientSet,
+		aeGetter:    aeGetter,
+	}
+}
+
+// FindConflicts returns the principals whose permissions differ between the aws-auth ConfigMap and their access entry
+func (r *AuthReconciler) FindConflicts(ctx context.Context) ([]AuthConflict, error) {
+	acm, err := authconfigmap.NewFromClientSet(r.clientSet)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := acm.GetIdentities()
+	if err != nil {
+		return nil, err
+	}
+	accessEntries, err := r.aeGetter.Get(ctx, api.ARN{})
+	if err != nil {
+		return nil, fmt.Errorf("fetching existing access entries: %w", err)
+	}
+	return FindAuthConflicts(identities, accessEntries), nil
+}
+
+// Reconcile converges the permissions of the conflicting principals to their permissions in sourceOfTruth
+func (r *AuthReconciler) Reconcile(ctx context.Context, conflicts []AuthConflict, sourceOfTruth SourceOfTruth) error {
+	switch sourceOfTruth {
+	case SourceOfTruthAccessEntries:
+		return r.updateAuthConfigMap(conflicts)
+	case SourceOfTruthAWSAuth:
+		for _, c := range conflicts {
+			if err := r.updateAccessEntry(ctx, c); err != nil {
+				return fmt.Errorf("updating access entry for principal ARN %s: %w", c.AccessEntry.PrincipalARN, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid source of truth %q, must be either %s or %s", sourceOfTruth, SourceOfTruthAccessEntries, SourceOfTruthAWSAuth)
+	}
+}
+
+// updateAuthConfigMap replaces the mappings of the conflicting principals with mappings granting the permissions of their access entry
+func (r *AuthReconciler) updateAuthConfigMap(conflicts []AuthConflict) error {
+	acm, err := authconfigmap.NewFromClientSet(r.clientSet)
+	if err != nil {
+		return err
+	}
+	for _, c := range conflicts {
+		ae := c.AccessEntry
+		username, groups := ae.Username, ae.KubernetesGroups
+		switch ae.Type {
+		case string(api.AccessEntryTypeLinux), accessEntryTypeEC2:
+			username, groups = authconfigmap.RoleNodeGroupUsername, authconfigmap.RoleNodeGroupGroups
+		case string(api.AccessEntryTypeWindows):
+			username, groups = authconfigmap.RoleNodeGroupUsername, append([]string{windowsNodeGroup}, authconfigmap.RoleNodeGroupGroups...)
+		case string(api.AccessEntryTypeFargateLinux):
+			username, groups = sessionNodeUsername, append(append([]string{}, authconfigmap.RoleNodeGroupGroups...), fargateNodeGroup)
+		case accessEntryTypeHybridLinux:
+			username, groups = sessionNodeUsername, authconfigmap.RoleNodeGroupGroups
+		default:
+			if hasClusterAdminPolicy(ae.AccessPolicies) {
+				groups = append([]string{clusterAdminGroup}, groups...)
+			}
+		}
+		identity, err := iam.NewIdentity(c.Identity.ARN(), username, groups)
+		if err != nil {
+			return err
+		}
+		if err := acm.RemoveIdentity(c.Identity.ARN(), true); err != nil {
+			return err
+		}
+		if err := acm.AddIdentity(identity); err != nil {
+			return err
+		}
+	}
+	return acm.Save()
+}
+
+// updateAccessEntry updates the access entry of a conflicting principal to grant its permissions in the aws-auth ConfigMap
+func (r *AuthReconciler) updateAccessEntry(ctx context.Context, c AuthConflict) error {
+	ae := c.AccessEntry
+	// the type of an access entry cannot be updated
+	if nodeTypes := nodeAccessEntryTypes(c.Identity); len(nodeTypes) > 0 {
+		return r.recreateAccessEntry(ctx, ae.PrincipalARN, nodeTypes[0])
+	}
+	if ae.Type != string(api.AccessEntryTypeStandard) {
+		if err := r.recreateAccessEntry(ctx, ae.PrincipalARN, string(api.AccessEntryTypeStandard)); err != nil {
+			return err
+		}
+		ae.AccessPolicies = nil
+	}
+
+	groups, isAdmin := splitClusterAdminGroup(c.Identity.Groups())
+	groups, systemGroups := splitSystemGroups(groups)
+	if len(systemGroups) > 0 {
+		logger.Warning("not granting Kubernetes groups [%s] to the access entry for principal ARN %s, as access entries cannot grant groups starting with %q",
+			strings.Join(systemGroups, ", "), ae.PrincipalARN, systemGroupPrefix)
+	}
+	input := &awseks.UpdateAccessEntryInput{
+		ClusterName:      aws.String(r.clusterName),
+		PrincipalArn:     aws.String(ae.PrincipalARN),
+		KubernetesGroups: groups,
+	}
+	if input.KubernetesGroups == nil {
+		input.KubernetesGroups = []string{}
+	}
+	if c.Identity.Username() != "" {
+		input.Username = aws.String(c.Identity.Username())
+	}
+	logger.Info("updating access entry for principal ARN %s", ae.PrincipalARN)
+	if _, err := r.eksAPI.UpdateAccessEntry(ctx, input); err != nil {
+		return err
+	}
+
+	if hasAdminPolicy := hasClusterAdminPolicy(ae.AccessPolicies); isAdmin && !hasAdminPolicy {
+		policyARN, err := clusterAdminPolicyARN(ae.PrincipalARN)
+		if err != nil {
+			return err
+		}
+		_, err = r.eksAPI.AssociateAccessPolicy(ctx, &awseks.AssociateAccessPolicyInput{
+			ClusterName:  aws.String(r.clusterName),
+			PrincipalArn: aws.String(ae.PrincipalARN),
+			PolicyArn:    aws.String(policyARN),
+			AccessScope:  &ekstypes.AccessScope{Type: ekstypes.AccessScopeTypeCluster},
+		})
+		return err
+	} else if !isAdmin && hasAdminPolicy {
+		for _, p := range ae.AccessPolicies {
+			if !isClusterAdminPolicy(p) {
+				continue
+			}
+			if _, err := r.eksAPI.DisassociateAccessPolicy(ctx, &awseks.DisassociateAccessPolicyInput{
+				ClusterName:  aws.String(r.clusterName),
+				PrincipalArn: aws.String(ae.PrincipalARN),
+				PolicyArn:    aws.String(p.PolicyARN.String()),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recreateAccessEntry deletes the access entry of a principal and creates it again with entryType
+func (r *AuthReconciler) recreateAccessEntry(ctx context.Context, principalARN, entryType string) error {
+	logger.Info("recreating access entry for principal ARN %s with type %s", principalARN, entryType)
+	if _, err := r.eksAPI.DeleteAccessEntry(ctx, &awseks.DeleteAccessEntryInput{
+		ClusterName:  aws.String(r.clusterName),
+		PrincipalArn: aws.String(principalARN),
+	}); err != nil {
+		return err
+	}
+	_, err := r.eksAPI.CreateAccessEntry(ctx, &awseks.CreateAccessEntryInput{
+		ClusterName:  aws.String(r.clusterName),
+		PrincipalArn: aws.String(principalARN),
+		Type:         aws.String(entryType),
+	})
+	return err
+}
+
+// nodeAccessEntryTypes returns the types of the access entries granting the permissions of a node mapped in the
+// aws-auth ConfigMap, the one eksctl creates first, or nothing when the identity is not mapped as a node
+func nodeAccessEntryTypes(identity iam.Identity) []string {
+	switch identity.Username() {
+	case authconfigmap.RoleNodeGroupUsername:
+		if containsGroup(identity.Groups(), windowsNodeGroup) {
+			return []string{string(api.AccessEntryTypeWindows)}
+		}
+		return []string{string(api.AccessEntryTypeLinux), accessEntryTypeEC2}
+	case sessionNodeUsername:
+		if containsGroup(identity.Groups(), fargateNodeGroup) {
+			return []string{string(api.AccessEntryTypeFargateLinux)}
+		}
+		return []string{accessEntryTypeHybridLinux, string(api.AccessEntryTypeFargateLinux)}
+	}
+	return nil
+}
+
+// principalARNWithoutPath strips the path from the ARN of a role or user
+func principalARNWithoutPath(principalARN string) string {
+	parsed, err := arn.Parse(principalARN)
+	if err != nil {
+		return principalARN
+	}
+	for _, resourceType := range []string{"role/", "user/"} {
+		if strings.HasPrefix(parsed.Resource, resourceType) {
+			parsed.Resource = resourceType + parsed.Resource[strings.LastIndex(parsed.Resource, "/")+1:]
+			return parsed.String()
+		}
+	}
+	return principalARN
+}
+
+func clusterAdminPolicyARN(principalARN string) (string, error) {
+	parsed, err := arn.Parse(principalARN)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("arn:%s:eks::aws:%s", parsed.Partition, clusterAdminPolicy), nil
+}
+
+func isClusterAdminPolicy(p api.AccessPolicy) bool {
+	return p.PolicyARN.Resource == clusterAdminPolicy && p.AccessScope.Type == ekstypes.AccessScopeTypeCluster
+}
+
+func hasClusterAdminPolicy(policies []api.AccessPolicy) bool {
+	for _, p := range policies {
+		if isClusterAdminPolicy(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitClusterAdminGroup returns groups without system:masters, and whether it contained it
+func splitClusterAdminGroup(groups []string) ([]string, bool) {
+	var others []string
+	isAdmin := false
+	for _, g := range groups {
+		if g == clusterAdminGroup {
+			isAdmin = true
+			continue
+		}
+		others = append(others, g)
+	}
+	return others, isAdmin
+}
+
+// splitSystemGroups returns the groups not starting with system:, and those that do
+func splitSystemGroups(groups []string) ([]string, []string) {
+	var others, system []string
+	for _, g := range groups {
+		if strings.HasPrefix(g, systemGroupPrefix) {
+			system = append(system, g)
+			continue
+		}
+		others = append(others, g)
+	}
+	return others, system
+}
+
+func containsGroup(groups []string, group string) bool {
+	for _, g := range groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+func equalGroups(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,193 @@
+package accessentry_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/accessentry/fakes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/iam"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Auth reconciler", func() {
+	const (
+		clusterName = "test-cluster"
+		roleARN     = "arn:aws:iam::111122223333:role/team/admin"
+		adminPolicy = "arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy"
+	)
+
+	newIdentity := func(arn, username string, groups ...string) iam.Identity {
+		identity, err := iam.NewIdentity(arn, username, groups)
+		Expect(err).NotTo(HaveOccurred())
+		return identity
+	}
+	adminAccessPolicies := []api.AccessPolicy{{
+		PolicyARN:   api.MustParseARN(adminPolicy),
+		AccessScope: api.AccessScope{Type: ekstypes.AccessScopeTypeCluster},
+	}}
+
+	DescribeTable("finding conflicts", func(identity iam.Identity, ae accessentry.Summary, expectedDifferences []string) {
+		conflicts := accessentry.FindAuthConflicts([]iam.Identity{identity}, []accessentry.Summary{ae})
+		if expectedDifferences == nil {
+			Expect(conflicts).To(BeEmpty())
+			return
+		}
+		Expect(conflicts).To(HaveLen(1))
+		Expect(conflicts[0].Differences).To(Equal(expectedDifferences))
+	},
+		Entry("same permissions, role mapped without its path",
+			newIdentity("arn:aws:iam::111122223333:role/admin", "admin", "system:masters", "viewers"),
+			accessentry.Summary{PrincipalARN: roleARN, Type: "STANDARD", Username: "admin", KubernetesGroups: []string{"viewers"}, AccessPolicies: adminAccessPolicies},
+			nil),
+		Entry("principal without an access entry",
+			newIdentity("arn:aws:iam::111122223333:role/other", "other", "viewers"),
+			accessentry.Summary{PrincipalARN: roleARN, Type: "STANDARD"},
+			nil),
+		Entry("cluster admin in aws-auth only",
+			newIdentity(roleARN, "", "system:masters"),
+			accessentry.Summary{PrincipalARN: roleARN, Type: "STANDARD"},
+			[]string{"cluster admin in the aws-auth ConfigMap: true, in the access entry: false"}),
+		Entry("differing groups and username",
+			newIdentity(roleARN, "admin", "editors"),
+			accessentry.Summary{PrincipalARN: roleARN, Type: "STANDARD", Username: "ops", KubernetesGroups: []string{"viewers"}},
+			[]string{
+				"Kubernetes groups [editors] in the aws-auth ConfigMap, [viewers] in the access entry",
+				`username "admin" in the aws-auth ConfigMap, "ops" in the access entry`,
+			}),
+		Entry("node in aws-auth, standard access entry",
+			newIdentity(roleARN, authconfigmap.RoleNodeGroupUsername, authconfigmap.RoleNodeGroupGroups...),
+			accessentry.Summary{PrincipalARN: roleARN, Type: "STANDARD"},
+			[]string{"mapped as a node in the aws-auth ConfigMap, access entry of type STANDARD"}),
+		Entry("Linux node in both",
+			newIdentity(roleARN, authconfigmap.RoleNodeGroupUsername, authconfigmap.RoleNodeGroupGroups...),
+			accessentry.Summary{PrincipalARN: roleARN, Type: "EC2_LINUX"},
+			nil),
+		Entry("node in aws-auth, EKS Auto Mode access entry",
+			newIdentity(roleARN, authconfigmap.RoleNodeGroupUsername, authconfigmap.RoleNodeGroupGroups...),
+			accessentry.Summary{PrincipalARN: roleARN, Type: "EC2"},
+			nil),
+		Entry("Fargate pod execution role in both",
+			newIdentity(roleARN, "system:node:{{SessionName}}", "system:bootstrappers", "system:nodes", "system:node-proxier"),
+			accessentry.Summary{PrincipalARN: roleARN, Type: "FARGATE_LINUX"},
+			nil),
+		Entry("Fargate pod execution role in aws-auth, EC2 Linux access entry",
+			newIdentity(roleARN, "system:node:{{SessionName}}", "system:bootstrappers", "system:nodes", "system:node-proxier"),
+			accessentry.Summary{PrincipalARN: roleARN, Type: "EC2_LINUX"},
+			[]string{"mapped as a node of type FARGATE_LINUX in the aws-auth ConfigMap, access entry of type EC2_LINUX"}),
+		Entry("user in aws-auth, Fargate access entry",
+			newIdentity(roleARN, "admin", "viewers"),
+			accessentry.Summary{PrincipalARN: roleARN, Type: "FARGATE_LINUX"},
+			[]string{"mapped as a user in the aws-auth ConfigMap, access entry of type FARGATE_LINUX"}),
+		Entry("system groups in aws-auth only",
+			newIdentity(roleARN, "admin", "viewers", "system:authenticated"),
+			accessentry.Summary{PrincipalARN: roleARN, Type: "STANDARD", Username: "admin", KubernetesGroups: []string{"viewers"}},
+			nil),
+	)
+
+	Context("reconciling", func() {
+		var (
+			provider   *mockprovider.MockProvider
+			clientSet  *fake.Clientset
+			reconciler *accessentry.AuthReconciler
+		)
+
+		BeforeEach(func() {
+			provider = mockprovider.NewMockProvider()
+			clientSet = fake.NewSimpleClientset()
+			acm, err := authconfigmap.NewFromClientSet(clientSet)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(acm.AddIdentity(newIdentity("arn:aws:iam::111122223333:role/admin", "admin", "system:masters"))).To(Succeed())
+			Expect(acm.AddIdentity(newIdentity("arn:aws:iam::111122223333:role/unrelated", "unrelated", "viewers"))).To(Succeed())
+			Expect(acm.Save()).To(Succeed())
+
+			getter := &fakes.FakeGetterInterface{}
+			getter.GetReturns([]accessentry.Summary{{PrincipalARN: roleARN, Type: "STANDARD", Username: "admin", KubernetesGroups: []string{"viewers"}}}, nil)
+			reconciler = accessentry.NewAuthReconciler(clusterName, provider.MockEKS(), clientSet, getter)
+		})
+
+		It("updates the aws-auth ConfigMap to match the access entries", func() {
+			conflicts, err := reconciler.FindConflicts(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conflicts).To(HaveLen(1))
+			Expect(reconciler.Reconcile(context.Background(), conflicts, accessentry.SourceOfTruthAccessEntries)).To(Succeed())
+
+			acm, err := authconfigmap.NewFromClientSet(clientSet)
+			Expect(err).NotTo(HaveOccurred())
+			identities, err := acm.GetIdentities()
+			Expect(err).NotTo(HaveOccurred())
+			groups := map[string][]string{}
+			for _, identity := range identities {
+				groups[identity.ARN()] = identity.Groups()
+			}
+			Expect(groups).To(Equal(map[string][]string{
+				"arn:aws:iam::111122223333:role/unrelated": {"viewers"},
+				"arn:aws:iam::111122223333:role/admin":     {"viewers"},
+			}))
+		})
+
+		It("updates the access entries to match the aws-auth ConfigMap", func() {
+			provider.MockEKS().On("UpdateAccessEntry", mock.Anything, &awseks.UpdateAccessEntryInput{
+				ClusterName:      aws.String(clusterName),
+				PrincipalArn:     aws.String(roleARN),
+				KubernetesGroups: []string{},
+				Username:         aws.String("admin"),
+			}).Return(&awseks.UpdateAccessEntryOutput{}, nil)
+			provider.MockEKS().On("AssociateAccessPolicy", mock.Anything, &awseks.AssociateAccessPolicyInput{
+				ClusterName:  aws.String(clusterName),
+				PrincipalArn: aws.String(roleARN),
+				PolicyArn:    aws.String(adminPolicy),
+				AccessScope:  &ekstypes.AccessScope{Type: ekstypes.AccessScopeTypeCluster},
+			}).Return(&awseks.AssociateAccessPolicyOutput{}, nil)
+
+			conflicts, err := reconciler.FindConflicts(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Reconcile(context.Background(), conflicts, accessentry.SourceOfTruthAWSAuth)).To(Succeed())
+			provider.MockEKS().AssertExpectations(GinkgoT())
+		})
+
+		It("does not grant system groups to access entries", func() {
+			conflicts := []accessentry.AuthConflict{{
+				Identity:    newIdentity(roleARN, "admin", "editors", "system:authenticated"),
+				AccessEntry: accessentry.Summary{PrincipalARN: roleARN, Type: "STANDARD", Username: "admin"},
+			}}
+			provider.MockEKS().On("UpdateAccessEntry", mock.Anything, &awseks.UpdateAccessEntryInput{
+				ClusterName:      aws.String(clusterName),
+				PrincipalArn:     aws.String(roleARN),
+				KubernetesGroups: []string{"editors"},
+				Username:         aws.String("admin"),
+			}).Return(&awseks.UpdateAccessEntryOutput{}, nil)
+
+			Expect(reconciler.Reconcile(context.Background(), conflicts, accessentry.SourceOfTruthAWSAuth)).To(Succeed())
+			provider.MockEKS().AssertExpectations(GinkgoT())
+		})
+
+		It("recreates the access entry of a Fargate pod execution role with its type", func() {
+			conflicts := []accessentry.AuthConflict{{
+				Identity:    newIdentity(roleARN, "system:node:{{SessionName}}", "system:bootstrappers", "system:nodes", "system:node-proxier"),
+				AccessEntry: accessentry.Summary{PrincipalARN: roleARN, Type: "STANDARD"},
+			}}
+			provider.MockEKS().On("DeleteAccessEntry", mock.Anything, &awseks.DeleteAccessEntryInput{
+				ClusterName:  aws.String(clusterName),
+				PrincipalArn: aws.String(roleARN),
+			}).Return(&awseks.DeleteAccessEntryOutput{}, nil)
+			provider.MockEKS().On("CreateAccessEntry", mock.Anything, &awseks.CreateAccessEntryInput{
+				ClusterName:  aws.String(clusterName),
+				PrincipalArn: aws.String(roleARN),
+				Type:         aws.String("FARGATE_LINUX"),
+			}).Return(&awseks.CreateAccessEntryOutput{}, nil)
+
+			Expect(reconciler.Reconcile(context.Background(), conflicts, accessentry.SourceOfTruthAWSAuth)).To(Succeed())
+			provider.MockEKS().AssertExpectations(GinkgoT())
+		})
+	})
+})
@@ -27,13 +27,13 @@ func NewRemover(clusterName string, stackRemover StackRemover, eksAPI awsapi.EKS
 }
 
 func (aer *Remover) Delete(ctx context.Context, accessEntries []api.AccessEntry) error {
-	tasks, err := aer.DeleteTasks(ctx, accessEntries)
+	taskTree, err := aer.DeleteTasks(ctx, accessEntries)
 	if err != nil {
 		return err
 	}
 
-	logger.Info(tasks.Describe())
-	if errs := tasks.DoAllSync(); len(errs) > 0 {
+	logger.Info(taskTree.Describe())
+	if errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{}); len(errs) > 0 {
 		return handleErrors(errs, "accessentry(ies)")
 	}
 	return nil
@@ -129,9 +129,9 @@ func (t *deleteOwnedAccessEntryTask) Do(errorCh chan error) error {
 	return nil
 }
 
-func runAllTasks(taskTree *tasks.TaskTree) error {
+func runAllTasks(ctx context.Context, taskTree *tasks.TaskTree) error {
 	logger.Info(taskTree.Describe())
-	if errs := taskTree.DoAllSync(); len(errs) > 0 {
+	if errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{}); len(errs) > 0 {
 		var allErrs []string
 		for _, err := range errs {
 			allErrs = append(allErrs, err.Error())
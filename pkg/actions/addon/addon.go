@@ -3,12 +3,12 @@ package addon
 import (
 	"context"
 	"fmt"
-	"sort"
 	"strings"
 	"time"
 
 	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"github.com/hashicorp/go-version"
 	"github.com/kris-nova/logger"
 	kubeclient "k8s.io/client-go/kubernetes"
@@ -30,6 +30,7 @@ type StackManager interface {
 	DeleteStackBySpecSync(ctx context.Context, s *cfntypes.Stack, errs chan error) error
 	DescribeStack(ctx context.Context, i *cfntypes.Stack) (*cfntypes.Stack, error)
 	GetIAMAddonsStacks(ctx context.Context) ([]*cfntypes.Stack, error)
+	GetStackTemplate(ctx context.Context, stackName string) (string, error)
 	UpdateStack(ctx context.Context, options manager.UpdateStackOptions) error
 }
 
@@ -44,6 +45,8 @@ type Manager struct {
 	stackManager        StackManager
 	createClientSet     CreateClientSet
 	DisableAWSNodePatch bool
+	// configurationSchemas caches the configuration schemas of addon versions, keyed by name@version
+	configurationSchemas map[string]string
 }
 
 func New(clusterConfig *api.ClusterConfig, eksAPI awsapi.EKS, stackManager StackManager, withOIDC bool, oidcManager *iamoidc.OpenIDConnectManager, createClientSet CreateClientSet) (*Manager, error) {
@@ -103,53 +106,63 @@ func (v *versionNotFoundError) Error() string {
 }
 
 func (a *Manager) getLatestMatchingVersion(ctx context.Context, addon *api.Addon) (string, bool, error) {
-	addonInfos, err := a.describeVersions(ctx, addon)
+	versionInfo, _, err := a.latestMatchingVersion(ctx, addon)
 	if err != nil {
 		return "", false, err
 	}
+	return *versionInfo.AddonVersion, versionInfo.RequiresIamPermissions, nil
+}
+
+// latestMatchingVersion returns the default version of the addon if addon.Version is not set, or else the latest
+// version matching it, along with the addon it is a version of
+func (a *Manager) latestMatchingVersion(ctx context.Context, addon *api.Addon) (ekstypes.AddonVersionInfo, ekstypes.AddonInfo, error) {
+	addonInfos, err := a.describeVersions(ctx, addon)
+	if err != nil {
+		return ekstypes.AddonVersionInfo{}, ekstypes.AddonInfo{}, err
+	}
 	if len(addonInfos.Addons) == 0 || len(addonInfos.Addons[0].AddonVersions) == 0 {
-		return "", false, fmt.Errorf("no versions available for %q", addon.Name)
+		return ekstypes.AddonVersionInfo{}, ekstypes.AddonInfo{}, fmt.Errorf("no versions available for %q", addon.Name)
 	}
+	addonInfo := addonInfos.Addons[0]
 
 	addonVersion := addon.Version
-	var versions []*version.Version
-	for _, addonVersionInfo := range addonInfos.Addons[0].AddonVersions {
+	if addonVersion == "" {
 		// if not specified, will install default version
-		if addonVersion == "" && len(addonVersionInfo.Compatibilities) > 0 &&
-			addonVersionInfo.Compatibilities[0].DefaultVersion {
-			return *addonVersionInfo.AddonVersion, addonVersionInfo.RequiresIamPermissions, nil
-		} else if addonVersion == "" {
-			continue
+		for _, addonVersionInfo := range addonInfo.AddonVersions {
+			if len(addonVersionInfo.Compatibilities) > 0 && addonVersionInfo.Compatibilities[0].DefaultVersion {
+				return addonVersionInfo, addonInfo, nil
+			}
 		}
+		// third-party addons may not have a default version
+		logger.Info("%q addon has no default version, using the latest version", addon.Name)
+		addonVersion = "latest"
+	}
 
+	var (
+		latest        *ekstypes.AddonVersionInfo
+		latestVersion *version.Version
+	)
+	for i, addonVersionInfo := range addonInfo.AddonVersions {
 		v, err := a.parseVersion(*addonVersionInfo.AddonVersion)
 		if err != nil {
-			return "", false, err
+			return ekstypes.AddonVersionInfo{}, ekstypes.AddonInfo{}, err
 		}
 
-		if addonVersion == "latest" || strings.Contains(*addonVersionInfo.AddonVersion, addonVersion) {
-			versions = append(versions, v)
+		if addonVersion != "latest" && !strings.Contains(*addonVersionInfo.AddonVersion, addonVersion) {
+			continue
+		}
+		if latest == nil || latestVersion.LessThan(v) {
+			latest, latestVersion = &addonInfo.AddonVersions[i], v
 		}
 	}
 
-	if len(versions) == 0 {
-		return "", false, &versionNotFoundError{
+	if latest == nil {
+		return ekstypes.AddonVersionInfo{}, ekstypes.AddonInfo{}, &versionNotFoundError{
 			addonName:    addon.Name,
 			addonVersion: addonVersion,
 		}
 	}
-
-	sort.SliceStable(versions, func(i, j int) bool {
-		return versions[j].LessThan(versions[i])
-	})
-
-	requireIAMPermissions := false
-	for _, addonVersionInfo := range addonInfos.Addons[0].AddonVersions {
-		if *addonVersionInfo.AddonVersion == versions[0].Original() {
-			requireIAMPermissions = addonVersionInfo.RequiresIamPermissions
-		}
-	}
-	return versions[0].Original(), requireIAMPermissions, nil
+	return *latest, addonInfo, nil
 }
 
 func (a *Manager) makeAddonName(name string) string {
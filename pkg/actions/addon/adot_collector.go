@@ -0,0 +1,327 @@
+package addon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+
+	"github.com/weaveworks/eksctl/pkg/actions/monitoring"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+)
+
+const (
+	// ADOTCollectorName is the name of the OpenTelemetryCollector, and of its service account, rendered for the adot addon.
+	ADOTCollectorName = "adot-collector"
+	// ADOTCollectorNamespace is the namespace of the OpenTelemetryCollector rendered for the adot addon.
+	ADOTCollectorNamespace = "adot-collector"
+
+	iamPolicyAmazonPrometheusRemoteWriteAccess = "AmazonPrometheusRemoteWriteAccess"
+	iamPolicyAWSXrayWriteOnlyAccess            = "AWSXrayWriteOnlyAccess"
+
+	openTelemetryCollectorAPIVersion = "opentelemetry.io/v1alpha1"
+)
+
+// applyADOTCollector creates or updates the OpenTelemetryCollector of the adot addon, along with its service account
+// and IAM role
+func (a *Manager) applyADOTCollector(ctx context.Context, addon *api.Addon, waitTimeout time.Duration) error {
+	if a.createClientSet == nil {
+		return fmt.Errorf("cannot apply the collector of %q addon without access to the cluster", addon.Name)
+	}
+	// the OpenTelemetryCollector CRD is installed by the addon, so the addon must be active before applying the collector
+	if waitTimeout == 0 {
+		waitTimeout = api.DefaultWaitTimeout
+	}
+	if err := a.waitForAddonToBeActive(ctx, addon, waitTimeout); err != nil {
+		return err
+	}
+
+	roleARN := addon.Collector.ServiceAccountRoleARN
+	if roleARN == "" {
+		var err error
+		if roleARN, err = a.ensureADOTCollectorRole(ctx, addon); err != nil {
+			return err
+		}
+	}
+	cluster, err := a.eksAPI.DescribeCluster(ctx, &eks.DescribeClusterInput{
+		Name: aws.String(a.clusterConfig.Metadata.Name),
+	})
+	if err != nil {
+		return fmt.Errorf("describing cluster %q: %w", a.clusterConfig.Metadata.Name, err)
+	}
+	collector, err := makeOpenTelemetryCollector(addon.Collector, aws.ToString(cluster.Cluster.Arn), a.clusterConfig.Metadata.Region)
+	if err != nil {
+		return err
+	}
+
+	clientSet, err := a.createClientSet()
+	if err != nil {
+		return err
+	}
+	if err := applyADOTCollectorServiceAccount(ctx, clientSet, roleARN, addon.Collector.AMP != nil); err != nil {
+		return fmt.Errorf("applying the service account of the collector of %q addon: %w", addon.Name, err)
+	}
+	if err := applyOpenTelemetryCollector(ctx, clientSet.Discovery().RESTClient(), collector); err != nil {
+		return fmt.Errorf("applying the collector of %q addon: %w", addon.Name, err)
+	}
+	logger.Info("applied OpenTelemetryCollector %s/%s", ADOTCollectorNamespace, ADOTCollectorName)
+	return nil
+}
+
+// ensureADOTCollectorRole returns the IAM role of the collector, creating its stack if it does not exist.
+// The stack is tagged with the name of the addon, so that it is deleted along with the addon.
+func (a *Manager) ensureADOTCollectorRole(ctx context.Context, addon *api.Addon) (string, error) {
+	if !a.withOIDC {
+		return "", fmt.Errorf("the collector of %q addon requires an IAM OIDC provider, or collector.serviceAccountRoleARN to be set", addon.Name)
+	}
+	partition := api.Partitions.ForRegion(a.clusterConfig.Metadata.Region)
+	var policyARNs []string
+	if addon.Collector.AMP != nil {
+		policyARNs = append(policyARNs, fmt.Sprintf("arn:%s:iam::aws:policy/%s", partition, iamPolicyAmazonPrometheusRemoteWriteAccess))
+	}
+	if addon.Collector.XRay != nil {
+		policyARNs = append(policyARNs, fmt.Sprintf("arn:%s:iam::aws:policy/%s", partition, iamPolicyAWSXrayWriteOnlyAccess))
+	}
+	resourceSet := builder.NewIAMRoleResourceSetWithAttachPolicyARNs(addon.Name, ADOTCollectorNamespace, ADOTCollectorName, addon.PermissionsBoundary, policyARNs, a.oidcManager)
+	if err := resourceSet.AddAllResources(); err != nil {
+		return "", err
+	}
+
+	stackName := a.makeAddonName(ADOTCollectorName)
+	stack, err := a.stackManager.DescribeStack(ctx, &manager.Stack{StackName: aws.String(stackName)})
+	switch {
+	case err == nil:
+		if err := resourceSet.GetAllOutputs(*stack); err != nil {
+			return "", fmt.Errorf("collecting outputs of stack %q: %w", stackName, err)
+		}
+	case manager.IsStackDoesNotExistError(err):
+		logger.Info("creating IAM role for the collector of %q addon", addon.Name)
+		if err := a.createStack(ctx, resourceSet, addon.Name, stackName); err != nil {
+			return "", err
+		}
+	default:
+		return "", err
+	}
+	return resourceSet.OutputRole, nil
+}
+
+// applyADOTCollectorServiceAccount creates or updates the namespace and the service account of the collector, and the
+// RBAC resources the prometheus receiver needs to discover and scrape the nodes, endpoints and pods of the cluster
+func applyADOTCollectorServiceAccount(ctx context.Context, clientSet kubeclient.Interface, roleARN string, withPrometheus bool) error {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: ADOTCollectorNamespace},
+	}
+	if _, err := clientSet.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	serviceAccounts := clientSet.CoreV1().ServiceAccounts(ADOTCollectorNamespace)
+	serviceAccount, err := serviceAccounts.Get(ctx, ADOTCollectorName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		serviceAccount = &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        ADOTCollectorName,
+				Namespace:   ADOTCollectorNamespace,
+				Annotations: map[string]string{api.AnnotationEKSRoleARN: roleARN},
+			},
+		}
+		if _, err := serviceAccounts.Create(ctx, serviceAccount, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	case serviceAccount.Annotations[api.AnnotationEKSRoleARN] != roleARN:
+		if serviceAccount.Annotations == nil {
+			serviceAccount.Annotations = map[string]string{}
+		}
+		serviceAccount.Annotations[api.AnnotationEKSRoleARN] = roleARN
+		if _, err := serviceAccounts.Update(ctx, serviceAccount, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	if !withPrometheus {
+		return nil
+	}
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: ADOTCollectorName},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"nodes", "nodes/proxy", "nodes/metrics", "services", "endpoints", "pods"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				NonResourceURLs: []string{"/metrics", "/metrics/cadvisor"},
+				Verbs:           []string{"get"},
+			},
+		},
+	}
+	clusterRoles := clientSet.RbacV1().ClusterRoles()
+	if _, err := clusterRoles.Create(ctx, clusterRole, metav1.CreateOptions{}); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		if _, err := clusterRoles.Update(ctx, clusterRole, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: ADOTCollectorName},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     ADOTCollectorName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      ADOTCollectorName,
+				Namespace: ADOTCollectorNamespace,
+			},
+		},
+	}
+	if _, err := clientSet.RbacV1().ClusterRoleBindings().Create(ctx, clusterRoleBinding, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// applyOpenTelemetryCollector merges collector into the existing OpenTelemetryCollector, or creates it.
+// OpenTelemetryCollector is a custom resource, which the typed clients cannot decode, so it is sent as raw JSON.
+func applyOpenTelemetryCollector(ctx context.Context, restClient rest.Interface, collector map[string]interface{}) error {
+	data, err := json.Marshal(collector)
+	if err != nil {
+		return err
+	}
+	collectorsPath := fmt.Sprintf("/apis/%s/namespaces/%s/opentelemetrycollectors", openTelemetryCollectorAPIVersion, ADOTCollectorNamespace)
+	err = restClient.Patch(types.MergePatchType).AbsPath(collectorsPath, ADOTCollectorName).Body(data).Do(ctx).Error()
+	if apierrors.IsNotFound(err) {
+		err = restClient.Post().AbsPath(collectorsPath).Body(data).Do(ctx).Error()
+	}
+	return err
+}
+
+// makeOpenTelemetryCollector returns the OpenTelemetryCollector run by the operator of the adot addon
+func makeOpenTelemetryCollector(collector *api.ADOTCollector, clusterARN, region string) (map[string]interface{}, error) {
+	config, err := makeADOTCollectorConfig(collector, clusterARN, region)
+	if err != nil {
+		return nil, err
+	}
+	configData, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"apiVersion": openTelemetryCollectorAPIVersion,
+		"kind":       "OpenTelemetryCollector",
+		"metadata": map[string]interface{}{
+			"name":      ADOTCollectorName,
+			"namespace": ADOTCollectorNamespace,
+		},
+		"spec": map[string]interface{}{
+			"mode":           "deployment",
+			"serviceAccount": ADOTCollectorName,
+			"config":         string(configData),
+		},
+	}, nil
+}
+
+// makeADOTCollectorConfig returns the configuration of the collector, with a metrics pipeline from the prometheus
+// receiver to AMP and a traces pipeline from the OTLP receiver to X-Ray
+func makeADOTCollectorConfig(collector *api.ADOTCollector, clusterARN, region string) (map[string]interface{}, error) {
+	var (
+		extensions = map[string]interface{}{
+			"health_check": map[string]interface{}{},
+		}
+		receivers = map[string]interface{}{}
+		exporters = map[string]interface{}{}
+		pipelines = map[string]interface{}{}
+	)
+
+	if amp := collector.AMP; amp != nil {
+		prometheusConfig, err := monitoring.PrometheusReceiverConfig(monitoring.ScrapeConfiguration(amp.ScrapeConfiguration, clusterARN))
+		if err != nil {
+			return nil, err
+		}
+		endpoint, err := api.PrometheusWorkspaceEndpoint(amp.WorkspaceARN)
+		if err != nil {
+			return nil, err
+		}
+		workspaceARN, err := arn.Parse(amp.WorkspaceARN)
+		if err != nil {
+			return nil, err
+		}
+		extensions["sigv4auth"] = map[string]interface{}{
+			"region":  workspaceARN.Region,
+			"service": "aps",
+		}
+		receivers["prometheus"] = map[string]interface{}{
+			"config": prometheusConfig,
+		}
+		exporters["prometheusremotewrite"] = map[string]interface{}{
+			"endpoint": endpoint + "api/v1/remote_write",
+			"auth": map[string]interface{}{
+				"authenticator": "sigv4auth",
+			},
+		}
+		pipelines["metrics"] = map[string]interface{}{
+			"receivers": []string{"prometheus"},
+			"exporters": []string{"prometheusremotewrite"},
+		}
+	}
+
+	if xray := collector.XRay; xray != nil {
+		xrayRegion := xray.Region
+		if xrayRegion == "" {
+			xrayRegion = region
+		}
+		receivers["otlp"] = map[string]interface{}{
+			"protocols": map[string]interface{}{
+				"grpc": map[string]interface{}{
+					"endpoint": "0.0.0.0:4317",
+				},
+				"http": map[string]interface{}{
+					"endpoint": "0.0.0.0:4318",
+				},
+			},
+		}
+		exporters["awsxray"] = map[string]interface{}{
+			"region": xrayRegion,
+		}
+		pipelines["traces"] = map[string]interface{}{
+			"receivers": []string{"otlp"},
+			"exporters": []string{"awsxray"},
+		}
+	}
+
+	extensionNames := []string{"health_check"}
+	if _, ok := extensions["sigv4auth"]; ok {
+		extensionNames = append(extensionNames, "sigv4auth")
+	}
+	return map[string]interface{}{
+		"extensions": extensions,
+		"receivers":  receivers,
+		"exporters":  exporters,
+		"service": map[string]interface{}{
+			"extensions": extensionNames,
+			"pipelines":  pipelines,
+		},
+	}, nil
+}
@@ -0,0 +1,113 @@
+package addon_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("ADOT collector", func() {
+	const (
+		clusterARN   = "arn:aws:eks:us-west-2:111122223333:cluster/my-cluster"
+		workspaceARN = "arn:aws:aps:us-east-1:111122223333:workspace/ws-abcd-1234"
+		roleARN      = "arn:aws:iam::111122223333:role/adot-collector"
+	)
+
+	collectorConfig := func(collector map[string]interface{}) map[string]interface{} {
+		spec := collector["spec"].(map[string]interface{})
+		Expect(spec["mode"]).To(Equal("deployment"))
+		Expect(spec["serviceAccount"]).To(Equal(addon.ADOTCollectorName))
+		var config map[string]interface{}
+		Expect(yaml.Unmarshal([]byte(spec["config"].(string)), &config)).To(Succeed())
+		return config
+	}
+
+	It("renders a metrics pipeline writing to AMP", func() {
+		collector, err := addon.MakeOpenTelemetryCollector(&api.ADOTCollector{
+			AMP: &api.ADOTAMPExporter{WorkspaceARN: workspaceARN},
+		}, clusterARN, "us-west-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(collector["kind"]).To(Equal("OpenTelemetryCollector"))
+
+		config := collectorConfig(collector)
+		Expect(config["exporters"]).To(Equal(map[string]interface{}{
+			"prometheusremotewrite": map[string]interface{}{
+				"endpoint": "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-abcd-1234/api/v1/remote_write",
+				"auth": map[string]interface{}{
+					"authenticator": "sigv4auth",
+				},
+			},
+		}))
+		Expect(config["extensions"]).To(HaveKeyWithValue("sigv4auth", map[string]interface{}{
+			"region":  "us-east-1",
+			"service": "aps",
+		}))
+		Expect(config["service"]).To(HaveKeyWithValue("pipelines", map[string]interface{}{
+			"metrics": map[string]interface{}{
+				"receivers": []interface{}{"prometheus"},
+				"exporters": []interface{}{"prometheusremotewrite"},
+			},
+		}))
+
+		scrapeConfig, err := yaml.Marshal(config["receivers"])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(scrapeConfig)).To(ContainSubstring("clusterArn: " + clusterARN))
+		Expect(string(scrapeConfig)).To(ContainSubstring("replacement: $$1:$$2"))
+	})
+
+	It("renders a traces pipeline sending to X-Ray", func() {
+		collector, err := addon.MakeOpenTelemetryCollector(&api.ADOTCollector{
+			XRay: &api.ADOTXRayExporter{},
+		}, clusterARN, "us-west-2")
+		Expect(err).NotTo(HaveOccurred())
+
+		config := collectorConfig(collector)
+		Expect(config["receivers"]).To(HaveKey("otlp"))
+		Expect(config["exporters"]).To(Equal(map[string]interface{}{
+			"awsxray": map[string]interface{}{
+				"region": "us-west-2",
+			},
+		}))
+		Expect(config["extensions"]).NotTo(HaveKey("sigv4auth"))
+		Expect(config["service"]).To(HaveKeyWithValue("pipelines", HaveKey("traces")))
+		Expect(config["service"]).To(HaveKeyWithValue("pipelines", Not(HaveKey("metrics"))))
+	})
+
+	It("creates the service account of the collector and the RBAC resources of the prometheus receiver", func() {
+		clientSet := fake.NewSimpleClientset()
+		Expect(addon.ApplyADOTCollectorServiceAccount(context.Background(), clientSet, roleARN, true)).To(Succeed())
+
+		serviceAccount, err := clientSet.CoreV1().ServiceAccounts(addon.ADOTCollectorNamespace).Get(context.Background(), addon.ADOTCollectorName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(serviceAccount.Annotations).To(HaveKeyWithValue(api.AnnotationEKSRoleARN, roleARN))
+		_, err = clientSet.RbacV1().ClusterRoles().Get(context.Background(), addon.ADOTCollectorName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = clientSet.RbacV1().ClusterRoleBindings().Get(context.Background(), addon.ADOTCollectorName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("updates the role of an existing service account", func() {
+		clientSet := fake.NewSimpleClientset(&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      addon.ADOTCollectorName,
+				Namespace: addon.ADOTCollectorNamespace,
+			},
+		})
+		Expect(addon.ApplyADOTCollectorServiceAccount(context.Background(), clientSet, roleARN, false)).To(Succeed())
+
+		serviceAccount, err := clientSet.CoreV1().ServiceAccounts(addon.ADOTCollectorNamespace).Get(context.Background(), addon.ADOTCollectorName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(serviceAccount.Annotations).To(HaveKeyWithValue(api.AnnotationEKSRoleARN, roleARN))
+		clusterRoles, err := clientSet.RbacV1().ClusterRoles().List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusterRoles.Items).To(BeEmpty())
+	})
+})
@@ -0,0 +1,119 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/smithy-go"
+	"github.com/hashicorp/go-version"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// marketplaceOwner is the owner of the addons published through AWS Marketplace
+const marketplaceOwner = "aws-marketplace"
+
+// AvailableAddon is an addon that can be installed on the cluster.
+type AvailableAddon struct {
+	Name          string
+	Type          string
+	Publisher     string
+	Owner         string
+	LatestVersion string
+	// MarketplaceProductURL is the AWS Marketplace page of the addon, where the account subscribes to it.
+	// It is only set for AWS Marketplace addons.
+	MarketplaceProductURL string `json:",omitempty"`
+}
+
+// ListAvailable lists the addons available for the Kubernetes version of the cluster, including the addons
+// published by third parties, filtered by the publishers, types and owners set in filter.
+func (a *Manager) ListAvailable(ctx context.Context, filter *api.Addon) ([]AvailableAddon, error) {
+	input := makeDescribeAddonVersionsInput(&api.Addon{
+		Publishers: filter.Publishers,
+		Types:      filter.Types,
+		Owners:     filter.Owners,
+	}, a.clusterConfig.Metadata.Version)
+
+	var available []AvailableAddon
+	paginator := eks.NewDescribeAddonVersionsPaginator(a.eksAPI, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe addon versions: %w", err)
+		}
+		for _, addonInfo := range output.Addons {
+			latestVersion := a.latestVersion(addonInfo)
+			if latestVersion == "" {
+				continue
+			}
+			availableAddon := AvailableAddon{
+				Name:          aws.ToString(addonInfo.AddonName),
+				Type:          aws.ToString(addonInfo.Type),
+				Publisher:     aws.ToString(addonInfo.Publisher),
+				Owner:         aws.ToString(addonInfo.Owner),
+				LatestVersion: latestVersion,
+			}
+			if isMarketplaceAddon(addonInfo) {
+				availableAddon.MarketplaceProductURL = marketplaceProductURL(addonInfo)
+			}
+			available = append(available, availableAddon)
+		}
+	}
+
+	sort.Slice(available, func(i, j int) bool {
+		return available[i].Name < available[j].Name
+	})
+	return available, nil
+}
+
+// latestVersion returns the latest version of an addon, skipping the versions that are not semantic versions
+func (a *Manager) latestVersion(addonInfo ekstypes.AddonInfo) string {
+	var (
+		latest        string
+		latestVersion *version.Version
+	)
+	for _, versionInfo := range addonInfo.AddonVersions {
+		addonVersion := aws.ToString(versionInfo.AddonVersion)
+		v, err := a.parseVersion(addonVersion)
+		if err != nil {
+			logger.Debug("skipping version of %q addon: %v", aws.ToString(addonInfo.AddonName), err)
+			continue
+		}
+		if latestVersion == nil || latestVersion.LessThan(v) {
+			latest, latestVersion = addonVersion, v
+		}
+	}
+	return latest
+}
+
+// isMarketplaceAddon reports whether an addon is published through AWS Marketplace, which requires the account
+// to be subscribed to it
+func isMarketplaceAddon(addonInfo ekstypes.AddonInfo) bool {
+	return aws.ToString(addonInfo.Owner) == marketplaceOwner || addonInfo.MarketplaceInformation != nil
+}
+
+// marketplaceProductURL returns the AWS Marketplace page of an addon
+func marketplaceProductURL(addonInfo ekstypes.AddonInfo) string {
+	if addonInfo.MarketplaceInformation != nil && addonInfo.MarketplaceInformation.ProductUrl != nil {
+		return *addonInfo.MarketplaceInformation.ProductUrl
+	}
+	return "https://aws.amazon.com/marketplace"
+}
+
+// isSubscriptionError reports whether creating an addon failed because the account is not subscribed to it,
+// or not entitled to use it, in AWS Marketplace
+func isSubscriptionError(err error) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	message := strings.ToLower(apiErr.ErrorMessage())
+	return strings.Contains(message, "subscri") || strings.Contains(message, "entitle")
+}
@@ -0,0 +1,139 @@
+package addon_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("ListAvailable", func() {
+	var (
+		addonManager *addon.Manager
+		mockProvider *mockprovider.MockProvider
+	)
+
+	BeforeEach(func() {
+		mockProvider = mockprovider.NewMockProvider()
+		var err error
+		addonManager, err = addon.New(&api.ClusterConfig{Metadata: &api.ClusterMeta{
+			Version: "1.29",
+			Name:    "my-cluster",
+		}}, mockProvider.EKS(), nil, false, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("lists the third-party addons matching the filter with their latest version, skipping unparsable versions", func() {
+		mockProvider.MockEKS().On("DescribeAddonVersions", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				input := args[1].(*awseks.DescribeAddonVersionsInput)
+				Expect(*input.KubernetesVersion).To(Equal("1.29"))
+				Expect(input.AddonName).To(BeNil())
+				Expect(input.Publishers).To(ConsistOf("upbound"))
+				Expect(input.Owners).To(ConsistOf("aws-marketplace"))
+			}).
+			Return(&awseks.DescribeAddonVersionsOutput{
+				Addons: []ekstypes.AddonInfo{
+					{
+						AddonName: aws.String("upbound_universal-crossplane"),
+						Type:      aws.String("infra-management"),
+						Publisher: aws.String("upbound"),
+						Owner:     aws.String("aws-marketplace"),
+						MarketplaceInformation: &ekstypes.MarketplaceInformation{
+							ProductUrl: aws.String("https://aws.amazon.com/marketplace/pp/prodview-2m5tp4zrrzv6q"),
+						},
+						AddonVersions: []ekstypes.AddonVersionInfo{
+							{AddonVersion: aws.String("v1.14.5-eksbuild.1")},
+							{AddonVersion: aws.String("v1.15.0-eksbuild.1")},
+							{AddonVersion: aws.String("v1.9.1-eksbuild.0")},
+							{AddonVersion: aws.String("nightly")},
+						},
+					},
+					{
+						AddonName:     aws.String("upbound_unparsable-versions"),
+						Publisher:     aws.String("upbound"),
+						Owner:         aws.String("aws-marketplace"),
+						AddonVersions: []ekstypes.AddonVersionInfo{{AddonVersion: aws.String("nightly")}},
+					},
+					{
+						AddonName: aws.String("upbound_no-versions"),
+						Publisher: aws.String("upbound"),
+						Owner:     aws.String("aws-marketplace"),
+					},
+				},
+			}, nil)
+
+		available, err := addonManager.ListAvailable(context.Background(), &api.Addon{
+			Publishers: []string{"upbound"},
+			Owners:     []string{"aws-marketplace"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(available).To(Equal([]addon.AvailableAddon{
+			{
+				Name:                  "upbound_universal-crossplane",
+				Type:                  "infra-management",
+				Publisher:             "upbound",
+				Owner:                 "aws-marketplace",
+				LatestVersion:         "v1.15.0-eksbuild.1",
+				MarketplaceProductURL: "https://aws.amazon.com/marketplace/pp/prodview-2m5tp4zrrzv6q",
+			},
+		}))
+	})
+
+	It("sorts addons by name and only sets the product URL of AWS Marketplace addons", func() {
+		mockProvider.MockEKS().On("DescribeAddonVersions", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonVersionsOutput{
+			Addons: []ekstypes.AddonInfo{
+				{
+					AddonName:     aws.String("vpc-cni"),
+					Type:          aws.String("networking"),
+					Publisher:     aws.String("eks"),
+					Owner:         aws.String("aws"),
+					AddonVersions: []ekstypes.AddonVersionInfo{{AddonVersion: aws.String("v1.16.0-eksbuild.1")}},
+				},
+				{
+					AddonName:     aws.String("kubecost_kubecost"),
+					Type:          aws.String("cost-management"),
+					Publisher:     aws.String("kubecost"),
+					Owner:         aws.String("aws-marketplace"),
+					AddonVersions: []ekstypes.AddonVersionInfo{{AddonVersion: aws.String("v2.1.0-eksbuild.1")}},
+				},
+			},
+		}, nil)
+
+		available, err := addonManager.ListAvailable(context.Background(), &api.Addon{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(available).To(Equal([]addon.AvailableAddon{
+			{
+				Name:                  "kubecost_kubecost",
+				Type:                  "cost-management",
+				Publisher:             "kubecost",
+				Owner:                 "aws-marketplace",
+				LatestVersion:         "v2.1.0-eksbuild.1",
+				MarketplaceProductURL: "https://aws.amazon.com/marketplace",
+			},
+			{
+				Name:          "vpc-cni",
+				Type:          "networking",
+				Publisher:     "eks",
+				Owner:         "aws",
+				LatestVersion: "v1.16.0-eksbuild.1",
+			},
+		}))
+	})
+
+	It("returns an error when describing addon versions fails", func() {
+		mockProvider.MockEKS().On("DescribeAddonVersions", mock.Anything, mock.Anything).Return(nil, errors.New("ERR"))
+
+		_, err := addonManager.ListAvailable(context.Background(), &api.Addon{})
+		Expect(err).To(MatchError(ContainSubstring("failed to describe addon versions: ERR")))
+	})
+})
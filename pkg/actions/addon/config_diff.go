@@ -0,0 +1,128 @@
+package addon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// ConfigDiff is the difference between the version and configuration values an addon is deployed with
+// and those set in the config file
+type ConfigDiff struct {
+	Name            string
+	DeployedVersion string
+	DesiredVersion  string
+	// ValueChanges lists the configuration values that differ, sorted by path
+	ValueChanges []ValueChange
+}
+
+// ValueChange is a configuration value that differs between the deployed addon and the config file.
+// Deployed or Desired is empty if the value is only set on the other side
+type ValueChange struct {
+	Path     string
+	Deployed string
+	Desired  string
+}
+
+// HasChanges reports whether the deployed addon differs from the config file
+func (d ConfigDiff) HasChanges() bool {
+	return d.DeployedVersion != d.DesiredVersion || len(d.ValueChanges) > 0
+}
+
+// DiffConfig compares the version and configuration values the addon is deployed with against those in the config.
+// The version and configuration values are only compared if they are set in the config, as updating the addon
+// keeps them otherwise. It returns nil if the addon is not installed
+func (a *Manager) DiffConfig(ctx context.Context, addon *api.Addon) (*ConfigDiff, error) {
+	deployed, err := a.describeRevision(ctx, addon.Name)
+	if err != nil || deployed == nil {
+		return nil, err
+	}
+
+	diff := &ConfigDiff{
+		Name:            addon.Name,
+		DeployedVersion: deployed.Version,
+		DesiredVersion:  deployed.Version,
+	}
+	if addon.Version != "" {
+		if diff.DesiredVersion, _, err = a.getLatestMatchingVersion(ctx, addon); err != nil {
+			return nil, err
+		}
+	}
+
+	desiredValues, err := makeConfigurationValues(addon)
+	if err != nil || desiredValues == nil {
+		return diff, err
+	}
+	if diff.ValueChanges, err = diffConfigurationValues(deployed.ConfigurationValues, *desiredValues); err != nil {
+		return nil, fmt.Errorf("comparing configuration values of %q addon: %w", addon.Name, err)
+	}
+	return diff, nil
+}
+
+// diffConfigurationValues compares configuration values by the path of each value
+func diffConfigurationValues(deployed, desired string) ([]ValueChange, error) {
+	deployedValues, err := flattenConfigurationValues(deployed)
+	if err != nil {
+		return nil, err
+	}
+	desiredValues, err := flattenConfigurationValues(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []ValueChange
+	for path, value := range deployedValues {
+		if desiredValue := desiredValues[path]; desiredValue != value {
+			changes = append(changes, ValueChange{Path: path, Deployed: value, Desired: desiredValue})
+		}
+	}
+	for path, value := range desiredValues {
+		if _, ok := deployedValues[path]; !ok {
+			changes = append(changes, ValueChange{Path: path, Desired: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// flattenConfigurationValues maps the dot-separated path of each value in the configuration values
+// to its JSON representation; lists are compared as a whole
+func flattenConfigurationValues(configurationValues string) (map[string]string, error) {
+	values, err := parseConfigurationValues(configurationValues)
+	if err != nil {
+		return nil, err
+	}
+	flattened := map[string]string{}
+	var flatten func(prefix string, value interface{}) error
+	flatten = func(prefix string, value interface{}) error {
+		if object, ok := value.(map[string]interface{}); ok && len(object) > 0 {
+			for k, v := range object {
+				path := k
+				if prefix != "" {
+					path = prefix + "." + k
+				}
+				if err := flatten(path, v); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		flattened[prefix] = string(data)
+		return nil
+	}
+	for k, v := range values {
+		if err := flatten(k, v); err != nil {
+			return nil, err
+		}
+	}
+	return flattened, nil
+}
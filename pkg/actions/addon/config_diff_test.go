@@ -0,0 +1,94 @@
+package addon_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("DiffConfig", func() {
+	var (
+		addonManager *addon.Manager
+		mockProvider *mockprovider.MockProvider
+	)
+
+	BeforeEach(func() {
+		mockProvider = mockprovider.NewMockProvider()
+		mockProvider.MockEKS().On("DescribeAddonVersions", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonVersionsOutput{
+			Addons: []ekstypes.AddonInfo{
+				{
+					AddonName: aws.String("coredns"),
+					AddonVersions: []ekstypes.AddonVersionInfo{
+						{AddonVersion: aws.String("v1.10.1-eksbuild.1")},
+						{AddonVersion: aws.String("v1.11.1-eksbuild.4")},
+					},
+				},
+			},
+		}, nil)
+		var err error
+		addonManager, err = addon.New(&api.ClusterConfig{Metadata: &api.ClusterMeta{
+			Version: "1.29",
+			Name:    "my-cluster",
+		}}, mockProvider.EKS(), nil, false, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("compares the deployed version and configuration values against the config", func() {
+		mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonOutput{
+			Addon: &ekstypes.Addon{
+				AddonName:           aws.String("coredns"),
+				AddonVersion:        aws.String("v1.10.1-eksbuild.1"),
+				ConfigurationValues: aws.String(`{"replicaCount":2,"resources":{"limits":{"memory":"170Mi"}}}`),
+			},
+		}, nil)
+
+		diff, err := addonManager.DiffConfig(context.Background(), &api.Addon{
+			Name:                "coredns",
+			Version:             "v1.11",
+			ConfigurationValues: "replicaCount: 3\nautoScaling:\n  enabled: true\n",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.HasChanges()).To(BeTrue())
+		Expect(*diff).To(Equal(addon.ConfigDiff{
+			Name:            "coredns",
+			DeployedVersion: "v1.10.1-eksbuild.1",
+			DesiredVersion:  "v1.11.1-eksbuild.4",
+			ValueChanges: []addon.ValueChange{
+				{Path: "autoScaling.enabled", Desired: "true"},
+				{Path: "replicaCount", Deployed: "2", Desired: "3"},
+				{Path: "resources.limits.memory", Deployed: `"170Mi"`},
+			},
+		}))
+	})
+
+	It("ignores the version and configuration values that are not set in the config", func() {
+		mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonOutput{
+			Addon: &ekstypes.Addon{
+				AddonName:           aws.String("coredns"),
+				AddonVersion:        aws.String("v1.10.1-eksbuild.1"),
+				ConfigurationValues: aws.String(`{"replicaCount":2}`),
+			},
+		}, nil)
+
+		diff, err := addonManager.DiffConfig(context.Background(), &api.Addon{Name: "coredns"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.HasChanges()).To(BeFalse())
+	})
+
+	It("returns nil if the addon is not installed", func() {
+		mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.Anything).Return(nil, &ekstypes.ResourceNotFoundException{})
+
+		diff, err := addonManager.DiffConfig(context.Background(), &api.Addon{Name: "coredns"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff).To(BeNil())
+	})
+})
@@ -0,0 +1,115 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/xeipuuv/gojsonschema"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// ValidateConfigurationValues validates the configuration values of an addon against the configuration schema of the
+// version it is created with or updated to, so that invalid values are reported before any addon is changed.
+func (a *Manager) ValidateConfigurationValues(ctx context.Context, addon *api.Addon) error {
+	configurationValues, err := makeConfigurationValues(addon)
+	if err != nil || configurationValues == nil {
+		return err
+	}
+
+	var addonVersion string
+	if addon.Version == "" {
+		// an existing addon is updated keeping its version, a new one is created with the default version
+		output, err := a.eksAPI.DescribeAddon(ctx, &eks.DescribeAddonInput{
+			AddonName:   &addon.Name,
+			ClusterName: &a.clusterConfig.Metadata.Name,
+		})
+		var notFoundErr *ekstypes.ResourceNotFoundException
+		if err != nil && !errors.As(err, &notFoundErr) {
+			return fmt.Errorf("failed to describe addon: %w", err)
+		}
+		if err == nil && output.Addon.AddonVersion != nil {
+			addonVersion = *output.Addon.AddonVersion
+		}
+	}
+	if addonVersion == "" {
+		if addonVersion, _, err = a.getLatestMatchingVersion(ctx, addon); err != nil {
+			return err
+		}
+	}
+	return a.validateConfigurationValues(ctx, addon.Name, addonVersion, *configurationValues)
+}
+
+// ValidateNewAddonConfigurationValues validates the configuration values of an addon that does not exist yet, e.g.
+// before the cluster it is created with, against the configuration schema of the version it will be created with.
+func (a *Manager) ValidateNewAddonConfigurationValues(ctx context.Context, addon *api.Addon) error {
+	configurationValues, err := makeConfigurationValues(addon)
+	if err != nil || configurationValues == nil {
+		return err
+	}
+	addonVersion, _, err := a.getLatestMatchingVersion(ctx, addon)
+	if err != nil {
+		return err
+	}
+	return a.validateConfigurationValues(ctx, addon.Name, addonVersion, *configurationValues)
+}
+
+// validateConfigurationValues validates configuration values against the configuration schema of an addon version
+func (a *Manager) validateConfigurationValues(ctx context.Context, addonName, addonVersion, configurationValues string) error {
+	schema, err := a.getConfigurationSchema(ctx, addonName, addonVersion)
+	if err != nil {
+		return err
+	}
+	if schema == "" {
+		return nil
+	}
+
+	values, err := yaml.YAMLToJSON([]byte(configurationValues))
+	if err != nil {
+		return fmt.Errorf("parsing configuration values of %q addon: %w", addonName, err)
+	}
+	result, err := gojsonschema.Validate(gojsonschema.NewStringLoader(schema), gojsonschema.NewBytesLoader(values))
+	if err != nil {
+		return fmt.Errorf("validating configuration values of %q addon: %w", addonName, err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	var validationErrors []string
+	for _, e := range result.Errors() {
+		validationErrors = append(validationErrors, "- "+e.String())
+	}
+	return fmt.Errorf("configurationValues of %q addon do not match the configuration schema of version %s, "+
+		"run `eksctl utils describe-addon-configuration --name %s --version %s` to view the schema:\n%s",
+		addonName, addonVersion, addonName, addonVersion, strings.Join(validationErrors, "\n"))
+}
+
+// getConfigurationSchema returns the JSON configuration schema of an addon version, or an empty string if the
+// version has none
+func (a *Manager) getConfigurationSchema(ctx context.Context, addonName, addonVersion string) (string, error) {
+	key := addonName + "@" + addonVersion
+	if schema, ok := a.configurationSchemas[key]; ok {
+		return schema, nil
+	}
+	output, err := a.eksAPI.DescribeAddonConfiguration(ctx, &eks.DescribeAddonConfigurationInput{
+		AddonName:    &addonName,
+		AddonVersion: &addonVersion,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe configuration for %q addon: %w", addonName, err)
+	}
+	var schema string
+	if output.ConfigurationSchema != nil {
+		schema = *output.ConfigurationSchema
+	}
+	if a.configurationSchemas == nil {
+		a.configurationSchemas = map[string]string{}
+	}
+	a.configurationSchemas[key] = schema
+	return schema, nil
+}
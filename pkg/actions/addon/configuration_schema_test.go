@@ -0,0 +1,73 @@
+package addon_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("ValidateNewAddonConfigurationValues", func() {
+	var (
+		mockProvider *mockprovider.MockProvider
+		manager      *addon.Manager
+	)
+
+	BeforeEach(func() {
+		mockProvider = mockprovider.NewMockProvider()
+		var err error
+		manager, err = addon.New(&api.ClusterConfig{Metadata: &api.ClusterMeta{
+			Name:    "my-cluster",
+			Version: api.DefaultVersion,
+		}}, mockProvider.MockEKS(), nil, false, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		mockProvider.MockEKS().On("DescribeAddonVersions", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonVersionsOutput{
+			Addons: []ekstypes.AddonInfo{{
+				AddonName: aws.String("coredns"),
+				AddonVersions: []ekstypes.AddonVersionInfo{{
+					AddonVersion: aws.String("v1.11.1-eksbuild.4"),
+					Compatibilities: []ekstypes.Compatibility{{
+						ClusterVersion: aws.String(api.DefaultVersion),
+						DefaultVersion: true,
+					}},
+				}},
+			}},
+		}, nil)
+		mockProvider.MockEKS().On("DescribeAddonConfiguration", mock.Anything, &awseks.DescribeAddonConfigurationInput{
+			AddonName:    aws.String("coredns"),
+			AddonVersion: aws.String("v1.11.1-eksbuild.4"),
+		}).Return(&awseks.DescribeAddonConfigurationOutput{
+			ConfigurationSchema: aws.String(`{"type": "object", "properties": {"replicaCount": {"type": "integer"}}, "additionalProperties": false}`),
+		}, nil)
+	})
+
+	It("validates the values against the schema of the default version without describing the addon", func() {
+		Expect(manager.ValidateNewAddonConfigurationValues(context.Background(), &api.Addon{
+			Name:                "coredns",
+			ConfigurationValues: `{"replicaCount": 3}`,
+		})).To(Succeed())
+		mockProvider.MockEKS().AssertNotCalled(GinkgoT(), "DescribeAddon", mock.Anything, mock.Anything)
+	})
+
+	It("returns an error when the values do not match the schema", func() {
+		err := manager.ValidateNewAddonConfigurationValues(context.Background(), &api.Addon{
+			Name:                "coredns",
+			ConfigurationValues: `{"replicas": 3}`,
+		})
+		Expect(err).To(MatchError(ContainSubstring(`configurationValues of "coredns" addon do not match the configuration schema of version v1.11.1-eksbuild.4`)))
+	})
+
+	It("does not describe the addon versions of an addon without configuration values", func() {
+		Expect(manager.ValidateNewAddonConfigurationValues(context.Background(), &api.Addon{Name: "coredns"})).To(Succeed())
+		mockProvider.MockEKS().AssertNotCalled(GinkgoT(), "DescribeAddonVersions", mock.Anything, mock.Anything)
+	})
+})
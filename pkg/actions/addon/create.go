@@ -20,6 +20,7 @@ import (
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/utils/progress"
 )
 
 var (
@@ -96,17 +97,30 @@ func (a *Manager) Create(ctx context.Context, addon *api.Addon, iamRoleCreator I
 	// if the addon already exists AND it is not in CREATE_FAILED state
 	if err == nil && summary.Addon.Status != ekstypes.AddonStatusCreateFailed {
 		logger.Info("%q addon is already present on the cluster, as an EKS managed addon, skipping creation", addon.Name)
+		if addon.Collector != nil {
+			return a.applyADOTCollector(ctx, addon, waitTimeout)
+		}
 		return nil
 	}
 
-	version, requiresIAMPermissions, err := a.getLatestMatchingVersion(ctx, addon)
+	versionInfo, addonInfo, err := a.latestMatchingVersion(ctx, addon)
+	if err != nil {
+		return err
+	}
+	version, requiresIAMPermissions := *versionInfo.AddonVersion, versionInfo.RequiresIamPermissions
 	addon.Version = version
+	if isMarketplaceAddon(addonInfo) {
+		logger.Info("%q addon is published by %s through AWS Marketplace; the account must be subscribed to it before it can be created (%s)",
+			addon.Name, aws.ToString(addonInfo.Publisher), marketplaceProductURL(addonInfo))
+	}
+	configurationValues, err := makeConfigurationValues(addon)
 	if err != nil {
 		return err
 	}
-	var configurationValues *string
-	if addon.ConfigurationValues != "" {
-		configurationValues = &addon.ConfigurationValues
+	if configurationValues != nil {
+		if err := a.validateConfigurationValues(ctx, addon.Name, version, *configurationValues); err != nil {
+			return err
+		}
 	}
 	createAddonInput := &eks.CreateAddonInput{
 		AddonName:           &addon.Name,
@@ -132,6 +146,8 @@ func (a *Manager) Create(ctx context.Context, addon *api.Addon, iamRoleCreator I
 		createAddonInput.Tags = addon.Tags
 	}
 
+	a.setMountpointS3Permissions(addon)
+
 	if requiresIAMPermissions {
 		podIDConfig, supportsPodIDs, err := a.getRecommendedPoliciesForPodID(ctx, addon)
 		if err != nil {
@@ -260,29 +276,28 @@ func (a *Manager) Create(ctx context.Context, addon *api.Addon, iamRoleCreator I
 	if err != nil {
 		var resourceInUse *ekstypes.ResourceInUseException
 		if errors.As(err, &resourceInUse) {
-			defer func() {
-				deleteAddonIAMTasks, err := NewRemover(a.stackManager).DeleteAddonIAMTasksFiltered(ctx, addon.Name, false)
-				if err != nil {
-					logger.Warning("failed to cleanup IAM role stacks: %w; please remove any remaining stacks manually", err)
-					return
-				}
-				if err := runAllTasks(deleteAddonIAMTasks); err != nil {
-					logger.Warning("failed to cleanup IAM role stacks: %w; please remove any remaining stacks manually", err)
-				}
-			}()
+			defer a.cleanupAddonIAMStacks(ctx, addon.Name)
 			var addonServiceAccounts []string
 			for _, pia := range createAddonInput.PodIdentityAssociations {
 				addonServiceAccounts = append(addonServiceAccounts, fmt.Sprintf("%q", *pia.ServiceAccount))
 			}
 			return fmt.Errorf("creating addon: one or more service accounts corresponding to %q addon is already associated with a different IAM role; please delete all pre-existing pod identity associations corresponding to %s service account(s) in the addon's namespace, then re-try creating the addon", addon.Name, strings.Join(addonServiceAccounts, ","))
 		}
+		if isMarketplaceAddon(addonInfo) && isSubscriptionError(err) {
+			defer a.cleanupAddonIAMStacks(ctx, addon.Name)
+			return fmt.Errorf("failed to create %q addon, make sure the account is subscribed to it in AWS Marketplace (%s): %w", addon.Name, marketplaceProductURL(addonInfo), err)
+		}
 		return fmt.Errorf("failed to create %q addon: %w", addon.Name, err)
 	}
 
-	if output != nil {
+	if output != nil && output.Addon != nil {
 		logger.Debug("EKS Create Addon output: %s", *output.Addon)
+		progress.RecordResource("EKS addon", addon.Name, aws.ToString(output.Addon.AddonArn))
 	}
 
+	if addon.Collector != nil {
+		return a.applyADOTCollector(ctx, addon, waitTimeout)
+	}
 	if waitTimeout > 0 {
 		return a.waitForAddonToBeActive(ctx, addon, waitTimeout)
 	}
@@ -290,6 +305,18 @@ func (a *Manager) Create(ctx context.Context, addon *api.Addon, iamRoleCreator I
 	return nil
 }
 
+// cleanupAddonIAMStacks deletes the IAM role stacks created for an addon that could not be created
+func (a *Manager) cleanupAddonIAMStacks(ctx context.Context, addonName string) {
+	deleteAddonIAMTasks, err := NewRemover(a.stackManager).DeleteAddonIAMTasksFiltered(ctx, addonName, false)
+	if err != nil {
+		logger.Warning("failed to cleanup IAM role stacks: %w; please remove any remaining stacks manually", err)
+		return
+	}
+	if err := runAllTasks(ctx, deleteAddonIAMTasks); err != nil {
+		logger.Warning("failed to cleanup IAM role stacks: %w; please remove any remaining stacks manually", err)
+	}
+}
+
 func (a *Manager) patchAWSNodeSA(ctx context.Context) error {
 	clientSet, err := a.createClientSet()
 	if err != nil {
@@ -397,6 +424,8 @@ func (a *Manager) getKnownServiceAccountLocation(addon *api.Addon) (string, stri
 	case api.VPCCNIAddon:
 		logger.Debug("found known service account location %s/%s", api.AWSNodeMeta.Namespace, api.AWSNodeMeta.Name)
 		return api.AWSNodeMeta.Namespace, api.AWSNodeMeta.Name
+	case api.AWSMountpointS3CSIDriverAddon:
+		return kubeSystemNamespace, mountpointS3ServiceAccount
 	default:
 		return "", ""
 	}
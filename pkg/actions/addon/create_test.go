@@ -17,6 +17,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+	"github.com/tidwall/gjson"
 
 	"github.com/weaveworks/eksctl/pkg/actions/addon"
 	"github.com/weaveworks/eksctl/pkg/actions/addon/fakes"
@@ -120,6 +121,28 @@ var _ = Describe("Create", func() {
 			Once()
 	}
 
+	mockDescribeMarketplaceAddonVersions := func(mockEKS *mocksv2.EKS) {
+		mockEKS.
+			On("DescribeAddonVersions", mock.Anything, mock.Anything).
+			Return(&awseks.DescribeAddonVersionsOutput{
+				Addons: []ekstypes.AddonInfo{
+					{
+						AddonName: aws.String("my-addon"),
+						Publisher: aws.String("upbound"),
+						Owner:     aws.String("aws-marketplace"),
+						MarketplaceInformation: &ekstypes.MarketplaceInformation{
+							ProductUrl: aws.String("https://aws.amazon.com/marketplace/pp/prodview-2m5tp4zrrzv6q"),
+						},
+						AddonVersions: []ekstypes.AddonVersionInfo{
+							{AddonVersion: aws.String("v1.14.5-eksbuild.1")},
+							{AddonVersion: aws.String("v1.15.0-eksbuild.1")},
+						},
+					},
+				},
+			}, nil).
+			Once()
+	}
+
 	mockDescribeAddonConfiguration := func(mockEKS *mocksv2.EKS, serviceAccountNames []string, err error) {
 		podIDConfig := []ekstypes.AddonPodIdentityConfiguration{}
 		for _, sa := range serviceAccountNames {
@@ -265,6 +288,34 @@ var _ = Describe("Create", func() {
 			expectedErr: "failed to create \"my-addon\" addon",
 		}),
 
+		Entry("[Marketplace] uses the latest version when there is no default and hints at the subscription on failure", createAddonEntry{
+			mockEKS: func(provider *mockprovider.MockProvider) {
+				mockDescribeAddon(provider.MockEKS(), nil)
+				mockDescribeMarketplaceAddonVersions(provider.MockEKS())
+				mockDescribeAddonConfiguration(provider.MockEKS(), []string{}, nil)
+				provider.MockEKS().
+					On("CreateAddon", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						Expect(*args[1].(*awseks.CreateAddonInput).AddonVersion).To(Equal("v1.15.0-eksbuild.1"))
+					}).
+					Return(nil, &ekstypes.InvalidRequestException{
+						Message: aws.String("The account is not subscribed to the add-on in AWS Marketplace"),
+					}).
+					Once()
+			},
+			expectedErr: "failed to create \"my-addon\" addon, make sure the account is subscribed to it in AWS Marketplace (https://aws.amazon.com/marketplace/pp/prodview-2m5tp4zrrzv6q)",
+		}),
+
+		Entry("[Marketplace] does not hint at the subscription on other failures", createAddonEntry{
+			mockEKS: func(provider *mockprovider.MockProvider) {
+				mockDescribeAddon(provider.MockEKS(), nil)
+				mockDescribeMarketplaceAddonVersions(provider.MockEKS())
+				mockDescribeAddonConfiguration(provider.MockEKS(), []string{}, nil)
+				mockCreateAddon(provider.MockEKS(), genericErr)
+			},
+			expectedErr: "failed to create \"my-addon\" addon: ERR",
+		}),
+
 		Entry("[API Error] fails to create IAM role for podID", createAddonEntry{
 			addon: api.Addon{
 				PodIdentityAssociations: &[]api.PodIdentityAssociation{
@@ -503,6 +554,7 @@ var _ = Describe("Create", func() {
 			mockEKS: func(provider *mockprovider.MockProvider) {
 				mockDescribeAddon(provider.MockEKS(), nil)
 				mockDescribeAddonVersions(provider.MockEKS(), nil)
+				mockDescribeAddonConfiguration(provider.MockEKS(), []string{}, nil)
 				mockCreateAddon(provider.MockEKS(), nil)
 			},
 			validateCreateAddonInput: func(input *awseks.CreateAddonInput) {
@@ -510,6 +562,103 @@ var _ = Describe("Create", func() {
 			},
 		}),
 
+		Entry("[ConfigurationValues] do not match the configuration schema", createAddonEntry{
+			addon: api.Addon{
+				Version:             "1.0.0",
+				ConfigurationValues: "{\"replicaCount\":\"three\"}",
+			},
+			mockEKS: func(provider *mockprovider.MockProvider) {
+				mockDescribeAddon(provider.MockEKS(), nil)
+				mockDescribeAddonVersions(provider.MockEKS(), nil)
+				provider.MockEKS().
+					On("DescribeAddonConfiguration", mock.Anything, mock.Anything).
+					Return(&awseks.DescribeAddonConfigurationOutput{
+						ConfigurationSchema: aws.String(`{"type":"object","properties":{"replicaCount":{"type":"integer"}}}`),
+					}, nil).
+					Once()
+			},
+			expectedErr: `configurationValues of "my-addon" addon do not match the configuration schema of version v1.0.0-eksbuild.1`,
+		}),
+
+		Entry("[ConfigurationValues] are merged over the size preset", createAddonEntry{
+			addon: api.Addon{
+				Name:                api.CoreDNSAddon,
+				Version:             "1.0.0",
+				SizePreset:          api.AddonSizePresetLarge,
+				ConfigurationValues: "autoScaling:\n  maxReplicas: 20\n",
+			},
+			mockEKS: func(provider *mockprovider.MockProvider) {
+				mockDescribeAddon(provider.MockEKS(), nil)
+				mockDescribeAddonVersions(provider.MockEKS(), nil)
+				mockDescribeAddonConfiguration(provider.MockEKS(), []string{}, nil)
+				mockCreateAddon(provider.MockEKS(), nil)
+			},
+			validateCreateAddonInput: func(input *awseks.CreateAddonInput) {
+				Expect(gjson.Get(*input.ConfigurationValues, "autoScaling.enabled").Bool()).To(BeTrue())
+				Expect(gjson.Get(*input.ConfigurationValues, "autoScaling.minReplicas").Int()).To(Equal(int64(3)))
+				Expect(gjson.Get(*input.ConfigurationValues, "autoScaling.maxReplicas").Int()).To(Equal(int64(20)))
+				Expect(gjson.Get(*input.ConfigurationValues, "podDisruptionBudget.maxUnavailable").Int()).To(Equal(int64(1)))
+				Expect(gjson.Get(*input.ConfigurationValues, "topologySpreadConstraints.#").Int()).To(Equal(int64(2)))
+			},
+		}),
+
+		Entry("[SizePreset] small runs a single coredns replica", createAddonEntry{
+			addon: api.Addon{
+				Name:       api.CoreDNSAddon,
+				Version:    "1.0.0",
+				SizePreset: api.AddonSizePresetSmall,
+			},
+			mockEKS: func(provider *mockprovider.MockProvider) {
+				mockDescribeAddon(provider.MockEKS(), nil)
+				mockDescribeAddonVersions(provider.MockEKS(), nil)
+				mockDescribeAddonConfiguration(provider.MockEKS(), []string{}, nil)
+				mockCreateAddon(provider.MockEKS(), nil)
+			},
+			validateCreateAddonInput: func(input *awseks.CreateAddonInput) {
+				Expect(gjson.Get(*input.ConfigurationValues, "replicaCount").Int()).To(Equal(int64(1)))
+				Expect(gjson.Get(*input.ConfigurationValues, "podDisruptionBudget.enabled").Bool()).To(BeFalse())
+			},
+		}),
+
+		Entry("[VPCCNI] is rendered into the configuration values", createAddonEntry{
+			addon: api.Addon{
+				Name:    api.VPCCNIAddon,
+				Version: "1.0.0",
+				VPCCNI: &api.VPCCNIConfig{
+					WarmIPTarget:     aws.Int(5),
+					MinimumIPTarget:  aws.Int(10),
+					PrefixDelegation: true,
+					PodENI:           true,
+					NetworkPolicy:    true,
+				},
+				ConfigurationValues: `{"env":{"WARM_IP_TARGET":"2","AWS_VPC_K8S_CNI_LOGLEVEL":"DEBUG"}}`,
+			},
+			mockK8s: true,
+			mockEKS: func(provider *mockprovider.MockProvider) {
+				mockDescribeAddon(provider.MockEKS(), nil)
+				mockDescribeAddonVersions(provider.MockEKS(), nil)
+				mockDescribeAddonConfiguration(provider.MockEKS(), []string{}, nil)
+				mockCreateAddon(provider.MockEKS(), nil)
+			},
+			validateCreateAddonInput: func(input *awseks.CreateAddonInput) {
+				Expect(*input.ConfigurationValues).To(MatchJSON(`{
+					"enableNetworkPolicy": "true",
+					"env": {
+						"AWS_VPC_K8S_CNI_LOGLEVEL": "DEBUG",
+						"ENABLE_POD_ENI": "true",
+						"ENABLE_PREFIX_DELEGATION": "true",
+						"MINIMUM_IP_TARGET": "10",
+						"WARM_IP_TARGET": "2"
+					},
+					"init": {
+						"env": {
+							"DISABLE_TCP_EARLY_DEMUX": "true"
+						}
+					}
+				}`))
+			},
+		}),
+
 		Entry("[Tags] are set", createAddonEntry{
 			addon: api.Addon{
 				Version: "1.0.0",
@@ -1124,6 +1273,87 @@ var _ = Describe("Create", func() {
 			},
 		}),
 
+		Entry("[RequiresIAMPermissions] IRSA policy generated from s3Buckets (aws-mountpoint-s3-csi-driver)", createAddonEntry{
+			addon: api.Addon{
+				Name: api.AWSMountpointS3CSIDriverAddon,
+				S3Buckets: []api.S3BucketAccess{
+					{
+						Name:     "my-bucket",
+						Prefixes: []string{"data/"},
+						ReadOnly: true,
+					},
+				},
+			},
+			withOIDC: true,
+			mockEKS: func(provider *mockprovider.MockProvider) {
+				mockDescribeAddon(mockProvider.MockEKS(), nil)
+				mockDescribeAddonVersions(provider.MockEKS(), nil)
+				mockDescribeAddonConfiguration(mockProvider.MockEKS(), []string{}, nil)
+				mockCreateAddon(mockProvider.MockEKS(), nil)
+			},
+			mockCFN: func(stackManager *fakes.FakeStackManager) {
+				stackManager.CreateStackStub = func(ctx context.Context, s string, rsr builder.ResourceSetReader, m1, m2 map[string]string, c chan error) error {
+					go func() {
+						c <- nil
+					}()
+					Expect(rsr).To(BeAssignableToTypeOf(&builder.IAMRoleResourceSet{}))
+					output, err := rsr.(*builder.IAMRoleResourceSet).RenderJSON()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(output)).To(ContainSubstring("system:serviceaccount:kube-system:s3-csi-driver-sa"))
+					Expect(string(output)).To(ContainSubstring("arn:aws:s3:::my-bucket/data/*"))
+					Expect(string(output)).NotTo(ContainSubstring("s3:PutObject"))
+					rsr.(*builder.IAMRoleResourceSet).OutputRole = "arn:aws:iam::111122223333:role/role-name-1"
+					return nil
+				}
+			},
+			validateCreateAddonInput: func(input *awseks.CreateAddonInput) {
+				Expect(input.PodIdentityAssociations).To(HaveLen(0))
+				Expect(*input.ServiceAccountRoleArn).To(Equal("arn:aws:iam::111122223333:role/role-name-1"))
+			},
+			validateCFNCalls: func(stackManager *fakes.FakeStackManager) {
+				Expect(stackManager.CreateStackCallCount()).To(Equal(1))
+			},
+		}),
+
+		Entry("[RequiresIAMPermissions] podID policy generated from s3Buckets (aws-mountpoint-s3-csi-driver)", createAddonEntry{
+			addon: api.Addon{
+				Name: api.AWSMountpointS3CSIDriverAddon,
+				S3Buckets: []api.S3BucketAccess{
+					{
+						Name: "my-bucket",
+					},
+				},
+			},
+			mockClusterConfig: func(clusterConfig *api.ClusterConfig) {
+				clusterConfig.AddonsConfig.AutoApplyPodIdentityAssociations = true
+			},
+			mockEKS: func(provider *mockprovider.MockProvider) {
+				mockDescribeAddon(mockProvider.MockEKS(), nil)
+				mockDescribeAddonVersions(provider.MockEKS(), nil)
+				mockDescribeAddonConfiguration(mockProvider.MockEKS(), []string{"s3-csi-driver-sa"}, nil)
+				mockCreateAddon(mockProvider.MockEKS(), nil)
+			},
+			mockIAM: func(mockIAMRoleCreator *addonmocks.IAMRoleCreator) {
+				mockIAMRoleCreator.
+					On("Create", mock.Anything, mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						Expect(args).To(HaveLen(3))
+						pia := args[1].(*api.PodIdentityAssociation)
+						Expect(pia.Namespace).To(Equal("kube-system"))
+						Expect(pia.ServiceAccountName).To(Equal("s3-csi-driver-sa"))
+						Expect(pia.PermissionPolicyARNs).To(BeEmpty())
+						Expect(pia.PermissionPolicy["Statement"]).To(ContainElement(HaveKeyWithValue("Resource", []string{"arn:aws:s3:::my-bucket/*"})))
+					}).
+					Return("arn:aws:iam::111122223333:role/s3-csi-driver-sa", nil).
+					Once()
+			},
+			validateCreateAddonInput: func(input *awseks.CreateAddonInput) {
+				Expect(input.PodIdentityAssociations).To(HaveLen(1))
+				Expect(*input.PodIdentityAssociations[0].ServiceAccount).To(Equal("s3-csi-driver-sa"))
+				Expect(*input.PodIdentityAssociations[0].RoleArn).To(Equal("arn:aws:iam::111122223333:role/s3-csi-driver-sa"))
+			},
+		}),
+
 		Entry("[RequiresIAMPermissions is false] podIDs set", createAddonEntry{
 			addon: api.Addon{
 				Version: "1.0.0",
@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/hashicorp/go-version"
 
 	"github.com/kris-nova/logger"
 
@@ -34,8 +37,23 @@ func (a *Manager) DescribeAllVersions(ctx context.Context, addon *api.Addon) (st
 }
 
 func (a *Manager) describeVersions(ctx context.Context, addon *api.Addon) (*eks.DescribeAddonVersionsOutput, error) {
+	return a.describeVersionsForKubernetesVersion(ctx, addon, a.clusterConfig.Metadata.Version)
+}
+
+func (a *Manager) describeVersionsForKubernetesVersion(ctx context.Context, addon *api.Addon, kubernetesVersion string) (*eks.DescribeAddonVersionsOutput, error) {
+	output, err := a.eksAPI.DescribeAddonVersions(ctx, makeDescribeAddonVersionsInput(addon, kubernetesVersion))
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe addon versions: %v", err)
+	}
+
+	return output, nil
+}
+
+// makeDescribeAddonVersionsInput returns the input to describe the versions of the addons matching the name,
+// publishers, types and owners of addon
+func makeDescribeAddonVersionsInput(addon *api.Addon, kubernetesVersion string) *eks.DescribeAddonVersionsInput {
 	input := &eks.DescribeAddonVersionsInput{
-		KubernetesVersion: &a.clusterConfig.Metadata.Version,
+		KubernetesVersion: &kubernetesVersion,
 	}
 
 	if addon.Name != "" {
@@ -50,13 +68,7 @@ func (a *Manager) describeVersions(ctx context.Context, addon *api.Addon) (*eks.
 	if len(addon.Owners) != 0 {
 		input.Owners = addon.Owners
 	}
-
-	output, err := a.eksAPI.DescribeAddonVersions(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("failed to describe addon versions: %v", err)
-	}
-
-	return output, nil
+	return input
 }
 
 func addonVersionsToString(output *eks.DescribeAddonVersionsOutput) (string, error) {
@@ -70,3 +82,157 @@ func addonVersionsToString(output *eks.DescribeAddonVersionsOutput) (string, err
 	}
 	return string(data), nil
 }
+
+// VersionSummary describes an addon version in the context of the cluster's Kubernetes version.
+type VersionSummary struct {
+	AddonName string `json:"addonName"`
+	Version   string `json:"version"`
+	// Default reports whether the version is installed by default for the Kubernetes version.
+	Default bool `json:"default"`
+	// UnavailableInNextKubernetesVersion reports whether the version is not supported by the next Kubernetes
+	// version, i.e. the addon must be upgraded before the cluster can be. EKS may still support the version.
+	UnavailableInNextKubernetesVersion bool `json:"unavailableInNextKubernetesVersion"`
+	RequiresIAMPermissions             bool `json:"requiresIAMPermissions"`
+	// SupportsPodIdentity is only set when the summary includes the addon configurations.
+	SupportsPodIdentity *bool `json:"supportsPodIdentity,omitempty"`
+}
+
+// SummarizeVersions returns a summary of the versions of the matching addons, from newest to oldest.
+// The configuration of each version is only fetched, to report Pod Identity support, when withConfigurations is set,
+// as that takes one API call per version.
+func (a *Manager) SummarizeVersions(ctx context.Context, addon *api.Addon, withConfigurations bool) ([]VersionSummary, error) {
+	output, err := a.describeVersions(ctx, addon)
+	if err != nil {
+		return nil, err
+	}
+	nextVersions, err := a.describeVersionsForNextKubernetesVersion(ctx, addon)
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []VersionSummary
+	for _, addonInfo := range output.Addons {
+		addonName := aws.ToString(addonInfo.AddonName)
+		var addonSummaries []VersionSummary
+		for _, versionInfo := range addonInfo.AddonVersions {
+			summary := VersionSummary{
+				AddonName:              addonName,
+				Version:                aws.ToString(versionInfo.AddonVersion),
+				RequiresIAMPermissions: versionInfo.RequiresIamPermissions,
+			}
+			for _, compatibility := range versionInfo.Compatibilities {
+				if aws.ToString(compatibility.ClusterVersion) == a.clusterConfig.Metadata.Version && compatibility.DefaultVersion {
+					summary.Default = true
+				}
+			}
+			if next, ok := nextVersions[addonName]; ok {
+				_, supported := next[summary.Version]
+				summary.UnavailableInNextKubernetesVersion = !supported
+			}
+			if withConfigurations {
+				supportsPodIdentity, err := a.supportsPodIdentity(ctx, addonName, summary.Version)
+				if err != nil {
+					return nil, err
+				}
+				summary.SupportsPodIdentity = &supportsPodIdentity
+			}
+			addonSummaries = append(addonSummaries, summary)
+		}
+		sortVersionsDescending(addonSummaries)
+		summaries = append(summaries, addonSummaries...)
+	}
+	return summaries, nil
+}
+
+// describeVersionsForNextKubernetesVersion returns the versions of each addon that are supported by the Kubernetes
+// version after the cluster's; addons are omitted if the next Kubernetes version is not yet supported
+func (a *Manager) describeVersionsForNextKubernetesVersion(ctx context.Context, addon *api.Addon) (map[string]map[string]struct{}, error) {
+	clusterVersion, err := a.parseVersion(a.clusterConfig.Metadata.Version)
+	if err != nil {
+		return nil, err
+	}
+	segments := clusterVersion.Segments()
+	nextVersion := fmt.Sprintf("%d.%d", segments[0], segments[1]+1)
+
+	output, err := a.describeVersionsForKubernetesVersion(ctx, addon, nextVersion)
+	if err != nil {
+		return nil, err
+	}
+	versions := map[string]map[string]struct{}{}
+	for _, addonInfo := range output.Addons {
+		if len(addonInfo.AddonVersions) == 0 {
+			continue
+		}
+		addonVersions := map[string]struct{}{}
+		for _, versionInfo := range addonInfo.AddonVersions {
+			addonVersions[aws.ToString(versionInfo.AddonVersion)] = struct{}{}
+		}
+		versions[aws.ToString(addonInfo.AddonName)] = addonVersions
+	}
+	return versions, nil
+}
+
+func (a *Manager) supportsPodIdentity(ctx context.Context, addonName, addonVersion string) (bool, error) {
+	output, err := a.eksAPI.DescribeAddonConfiguration(ctx, &eks.DescribeAddonConfigurationInput{
+		AddonName:    &addonName,
+		AddonVersion: &addonVersion,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to describe configuration for addon %s@%s: %w", addonName, addonVersion, err)
+	}
+	return len(output.PodIdentityConfiguration) > 0, nil
+}
+
+// sortVersionsDescending sorts summaries from newest to oldest, leaving them as they are if any version cannot be parsed
+func sortVersionsDescending(summaries []VersionSummary) {
+	versions := map[string]*version.Version{}
+	for _, s := range summaries {
+		v, err := version.NewVersion(s.Version)
+		if err != nil {
+			logger.Debug("could not parse version %q, skipping sorting versions: %v", s.Version, err)
+			return
+		}
+		versions[s.Version] = v
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return versions[summaries[j].Version].LessThan(versions[summaries[i].Version])
+	})
+}
+
+// UpgradePath returns the versions to upgrade an addon through to get from installedVersion to the newest of
+// addonVersions, moving to the newest patch release of one minor version at a time.
+func UpgradePath(installedVersion string, addonVersions []string) ([]string, error) {
+	installed, err := version.NewVersion(installedVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse installed version %q: %w", installedVersion, err)
+	}
+
+	newestByMinor := map[[2]int]*version.Version{}
+	for _, v := range addonVersions {
+		parsed, err := version.NewVersion(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse version %q: %w", v, err)
+		}
+		if !parsed.GreaterThan(installed) {
+			continue
+		}
+		segments := parsed.Segments()
+		minor := [2]int{segments[0], segments[1]}
+		if newest, ok := newestByMinor[minor]; !ok || parsed.GreaterThan(newest) {
+			newestByMinor[minor] = parsed
+		}
+	}
+
+	var path []*version.Version
+	for _, v := range newestByMinor {
+		path = append(path, v)
+	}
+	sort.Slice(path, func(i, j int) bool {
+		return path[i].LessThan(path[j])
+	})
+	var steps []string
+	for _, v := range path {
+		steps = append(steps, v.Original())
+	}
+	return steps, nil
+}
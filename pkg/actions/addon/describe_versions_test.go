@@ -290,4 +290,79 @@ var _ = Describe("DescribeVersions", func() {
 			Expect(describeAddonVersonsInput.Owners).To(Equal([]string{"aws-marketplace"}))
 		})
 	})
+
+	Describe("SummarizeVersions", func() {
+		mockDescribeAddonVersions := func(kubernetesVersion string, versions ...ekstypes.AddonVersionInfo) {
+			mockProvider.MockEKS().On("DescribeAddonVersions", mock.Anything, mock.MatchedBy(func(input *awseks.DescribeAddonVersionsInput) bool {
+				return *input.KubernetesVersion == kubernetesVersion
+			})).Return(&awseks.DescribeAddonVersionsOutput{
+				Addons: []ekstypes.AddonInfo{
+					{
+						AddonName:     aws.String("vpc-cni"),
+						AddonVersions: versions,
+					},
+				},
+			}, nil)
+		}
+		compatibleWith := func(kubernetesVersion string, isDefault bool) []ekstypes.Compatibility {
+			return []ekstypes.Compatibility{{ClusterVersion: aws.String(kubernetesVersion), DefaultVersion: isDefault}}
+		}
+
+		BeforeEach(func() {
+			mockDescribeAddonVersions("1.18",
+				ekstypes.AddonVersionInfo{AddonVersion: aws.String("v1.10.1-eksbuild.1"), Compatibilities: compatibleWith("1.18", false)},
+				ekstypes.AddonVersionInfo{AddonVersion: aws.String("v1.12.0-eksbuild.1"), Compatibilities: compatibleWith("1.18", true), RequiresIamPermissions: true},
+				ekstypes.AddonVersionInfo{AddonVersion: aws.String("v1.11.2-eksbuild.1"), Compatibilities: compatibleWith("1.18", false)},
+			)
+			mockDescribeAddonVersions("1.19",
+				ekstypes.AddonVersionInfo{AddonVersion: aws.String("v1.11.2-eksbuild.1")},
+				ekstypes.AddonVersionInfo{AddonVersion: aws.String("v1.12.0-eksbuild.1")},
+			)
+		})
+
+		It("marks default versions and versions unavailable in the next Kubernetes version", func() {
+			summaries, err := manager.SummarizeVersions(context.Background(), &api.Addon{}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summaries).To(Equal([]addon.VersionSummary{
+				{AddonName: "vpc-cni", Version: "v1.12.0-eksbuild.1", Default: true, RequiresIAMPermissions: true},
+				{AddonName: "vpc-cni", Version: "v1.11.2-eksbuild.1"},
+				{AddonName: "vpc-cni", Version: "v1.10.1-eksbuild.1", UnavailableInNextKubernetesVersion: true},
+			}))
+			mockProvider.MockEKS().AssertNotCalled(GinkgoT(), "DescribeAddonConfiguration", mock.Anything, mock.Anything)
+		})
+
+		It("does not fetch configurations unless requested", func() {
+			summaries, err := manager.SummarizeVersions(context.Background(), &api.Addon{Name: "vpc-cni"}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summaries).To(HaveLen(3))
+			Expect(summaries[0].SupportsPodIdentity).To(BeNil())
+			mockProvider.MockEKS().AssertNotCalled(GinkgoT(), "DescribeAddonConfiguration", mock.Anything, mock.Anything)
+		})
+
+		It("reports Pod Identity support when configurations are requested", func() {
+			mockProvider.MockEKS().On("DescribeAddonConfiguration", mock.Anything, mock.MatchedBy(func(input *awseks.DescribeAddonConfigurationInput) bool {
+				return *input.AddonVersion == "v1.12.0-eksbuild.1"
+			})).Return(&awseks.DescribeAddonConfigurationOutput{
+				PodIdentityConfiguration: []ekstypes.AddonPodIdentityConfiguration{{ServiceAccount: aws.String("aws-node")}},
+			}, nil)
+			mockProvider.MockEKS().On("DescribeAddonConfiguration", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonConfigurationOutput{}, nil)
+
+			summaries, err := manager.SummarizeVersions(context.Background(), &api.Addon{Name: "vpc-cni"}, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summaries).To(HaveLen(3))
+			Expect(*summaries[0].SupportsPodIdentity).To(BeTrue())
+			Expect(*summaries[1].SupportsPodIdentity).To(BeFalse())
+		})
+	})
+
+	DescribeTable("UpgradePath", func(installedVersion string, versions []string, expectedPath []string) {
+		path, err := addon.UpgradePath(installedVersion, versions)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal(expectedPath))
+	},
+		Entry("steps through the newest patch of each minor version", "v1.10.1-eksbuild.1",
+			[]string{"v1.12.0-eksbuild.1", "v1.11.2-eksbuild.1", "v1.11.1-eksbuild.1", "v1.10.4-eksbuild.1", "v1.10.1-eksbuild.1", "v1.9.0-eksbuild.1"},
+			[]string{"v1.10.4-eksbuild.1", "v1.11.2-eksbuild.1", "v1.12.0-eksbuild.1"}),
+		Entry("already at the newest version", "v1.12.0-eksbuild.1", []string{"v1.12.0-eksbuild.1", "v1.11.2-eksbuild.1"}, nil),
+	)
 })
@@ -0,0 +1,6 @@
+package addon
+
+var (
+	MakeOpenTelemetryCollector       = makeOpenTelemetryCollector
+	ApplyADOTCollectorServiceAccount = applyADOTCollectorServiceAccount
+)
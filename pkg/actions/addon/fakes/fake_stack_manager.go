@@ -82,6 +82,20 @@ type FakeStackManager struct {
 		result1 []*types.Stack
 		result2 error
 	}
+	GetStackTemplateStub        func(context.Context, string) (string, error)
+	getStackTemplateMutex       sync.RWMutex
+	getStackTemplateArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getStackTemplateReturns struct {
+		result1 string
+		result2 error
+	}
+	getStackTemplateReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	UpdateStackStub        func(context.Context, manager.UpdateStackOptions) error
 	updateStackMutex       sync.RWMutex
 	updateStackArgsForCall []struct {
@@ -421,6 +435,71 @@ func (fake *FakeStackManager) GetIAMAddonsStacksReturnsOnCall(i int, result1 []*
 	}{result1, result2}
 }
 
+func (fake *FakeStackManager) GetStackTemplate(arg1 context.Context, arg2 string) (string, error) {
+	fake.getStackTemplateMutex.Lock()
+	ret, specificReturn := fake.getStackTemplateReturnsOnCall[len(fake.getStackTemplateArgsForCall)]
+	fake.getStackTemplateArgsForCall = append(fake.getStackTemplateArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetStackTemplateStub
+	fakeReturns := fake.getStackTemplateReturns
+	fake.recordInvocation("GetStackTemplate", []interface{}{arg1, arg2})
+	fake.getStackTemplateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStackManager) GetStackTemplateCallCount() int {
+	fake.getStackTemplateMutex.RLock()
+	defer fake.getStackTemplateMutex.RUnlock()
+	return len(fake.getStackTemplateArgsForCall)
+}
+
+func (fake *FakeStackManager) GetStackTemplateCalls(stub func(context.Context, string) (string, error)) {
+	fake.getStackTemplateMutex.Lock()
+	defer fake.getStackTemplateMutex.Unlock()
+	fake.GetStackTemplateStub = stub
+}
+
+func (fake *FakeStackManager) GetStackTemplateArgsForCall(i int) (context.Context, string) {
+	fake.getStackTemplateMutex.RLock()
+	defer fake.getStackTemplateMutex.RUnlock()
+	argsForCall := fake.getStackTemplateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStackManager) GetStackTemplateReturns(result1 string, result2 error) {
+	fake.getStackTemplateMutex.Lock()
+	defer fake.getStackTemplateMutex.Unlock()
+	fake.GetStackTemplateStub = nil
+	fake.getStackTemplateReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStackManager) GetStackTemplateReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getStackTemplateMutex.Lock()
+	defer fake.getStackTemplateMutex.Unlock()
+	fake.GetStackTemplateStub = nil
+	if fake.getStackTemplateReturnsOnCall == nil {
+		fake.getStackTemplateReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getStackTemplateReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStackManager) UpdateStack(arg1 context.Context, arg2 manager.UpdateStackOptions) error {
 	fake.updateStackMutex.Lock()
 	ret, specificReturn := fake.updateStackReturnsOnCall[len(fake.updateStackArgsForCall)]
@@ -496,6 +575,8 @@ func (fake *FakeStackManager) Invocations() map[string][][]interface{} {
 	defer fake.describeStackMutex.RUnlock()
 	fake.getIAMAddonsStacksMutex.RLock()
 	defer fake.getIAMAddonsStacksMutex.RUnlock()
+	fake.getStackTemplateMutex.RLock()
+	defer fake.getStackTemplateMutex.RUnlock()
 	fake.updateStackMutex.RLock()
 	defer fake.updateStackMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
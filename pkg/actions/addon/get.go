@@ -25,6 +25,8 @@ type Summary struct {
 	Name                    string
 	Version                 string
 	NewerVersion            string
+	Publisher               string `json:",omitempty"`
+	Owner                   string `json:",omitempty"`
 	IAMRole                 string
 	Status                  string
 	ConfigurationValues     string
@@ -110,6 +112,8 @@ func (a *Manager) Get(ctx context.Context, addon *api.Addon) (Summary, error) {
 		IAMRole:                 serviceAccountRoleARN,
 		Status:                  string(output.Addon.Status),
 		NewerVersion:            newerVersion,
+		Publisher:               aws.ToString(output.Addon.Publisher),
+		Owner:                   aws.ToString(output.Addon.Owner),
 		ConfigurationValues:     configurationValues,
 		PodIdentityAssociations: podIdentityAssociations,
 		Issues:                  issues,
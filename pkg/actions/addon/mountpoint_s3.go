@@ -0,0 +1,75 @@
+package addon
+
+import (
+	"fmt"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// mountpointS3ServiceAccount is the service account of the CSI controller of the aws-mountpoint-s3-csi-driver addon
+const mountpointS3ServiceAccount = "s3-csi-driver-sa"
+
+// setMountpointS3Permissions turns the s3Buckets of the aws-mountpoint-s3-csi-driver addon into a pod identity
+// association, if pod identity associations are enabled for the addon, or into an IRSA policy otherwise, so that
+// the IAM role of the addon is created like one configured explicitly
+func (a *Manager) setMountpointS3Permissions(addon *api.Addon) {
+	if len(addon.S3Buckets) == 0 {
+		return
+	}
+	policy := makeMountpointS3PolicyDocument(api.Partitions.ForRegion(a.clusterConfig.Metadata.Region), addon.S3Buckets)
+	if a.clusterConfig.AddonsConfig.AutoApplyPodIdentityAssociations || addon.UseDefaultPodIdentityAssociations {
+		addon.PodIdentityAssociations = &[]api.PodIdentityAssociation{
+			{
+				Namespace:          kubeSystemNamespace,
+				ServiceAccountName: mountpointS3ServiceAccount,
+				PermissionPolicy:   policy,
+			},
+		}
+		// the recommended pod identity associations grant access to every bucket of the account
+		addon.UseDefaultPodIdentityAssociations = false
+		return
+	}
+	addon.AttachPolicy = policy
+}
+
+// makeMountpointS3PolicyDocument allows listing the buckets, and reading and, unless read-only, writing the objects
+// under their prefixes
+func makeMountpointS3PolicyDocument(partition string, buckets []api.S3BucketAccess) map[string]interface{} {
+	var statements []map[string]interface{}
+	for _, bucket := range buckets {
+		bucketARN := fmt.Sprintf("arn:%s:s3:::%s", partition, bucket.Name)
+		listBucket := map[string]interface{}{
+			"Effect":   "Allow",
+			"Action":   []string{"s3:ListBucket"},
+			"Resource": bucketARN,
+		}
+		objectARNs := []string{bucketARN + "/*"}
+		if len(bucket.Prefixes) > 0 {
+			var keyPatterns []string
+			objectARNs = nil
+			for _, prefix := range bucket.Prefixes {
+				keyPatterns = append(keyPatterns, prefix+"*")
+				objectARNs = append(objectARNs, bucketARN+"/"+prefix+"*")
+			}
+			listBucket["Condition"] = map[string]interface{}{
+				"StringLike": map[string]interface{}{
+					"s3:prefix": keyPatterns,
+				},
+			}
+		}
+
+		objectActions := []string{"s3:GetObject"}
+		if !bucket.ReadOnly {
+			objectActions = append(objectActions, "s3:PutObject", "s3:AbortMultipartUpload", "s3:DeleteObject")
+		}
+		statements = append(statements, listBucket, map[string]interface{}{
+			"Effect":   "Allow",
+			"Action":   objectActions,
+			"Resource": objectARNs,
+		})
+	}
+	return map[string]interface{}{
+		"Version":   "2012-10-17",
+		"Statement": statements,
+	}
+}
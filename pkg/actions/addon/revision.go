@@ -0,0 +1,172 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/google/uuid"
+	"github.com/kris-nova/logger"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+)
+
+// WithRevision runs change and, if it changed the version or configuration values of the addon, records the
+// revision the addon was deployed with before in the addon revision stack, so that it can be rolled back
+func (a *Manager) WithRevision(ctx context.Context, name string, change func() error) error {
+	previous, err := a.describeRevision(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := change(); err != nil {
+		return err
+	}
+	if previous == nil {
+		return nil
+	}
+	current, err := a.describeRevision(ctx, name)
+	if err != nil {
+		return err
+	}
+	if current == nil || sameRevision(*previous, *current) {
+		return nil
+	}
+	logger.Info("recording version %s of addon %q as its previous revision", previous.Version, name)
+	if err := a.recordRevision(ctx, name, *previous); err != nil {
+		logger.Warning("failed to record the previous revision of addon %q, it cannot be rolled back: %v", name, err)
+	}
+	return nil
+}
+
+// Rollback restores the version and configuration values an addon was deployed with before its last change.
+// The revision rolled back from is recorded in turn, so rolling back again restores it
+func (a *Manager) Rollback(ctx context.Context, name string, waitTimeout time.Duration) error {
+	previous, err := a.previousRevision(ctx, name)
+	if err != nil {
+		return err
+	}
+	if previous == nil {
+		return fmt.Errorf("no previous revision of addon %q is recorded", name)
+	}
+
+	return a.WithRevision(ctx, name, func() error {
+		logger.Info("rolling back addon %q to version %s", name, previous.Version)
+		configurationValues := previous.ConfigurationValues
+		if configurationValues == "" {
+			// an empty object resets the configuration values to the defaults of the addon
+			configurationValues = "{}"
+		}
+		if _, err := a.eksAPI.UpdateAddon(ctx, &eks.UpdateAddonInput{
+			AddonName:           &name,
+			ClusterName:         &a.clusterConfig.Metadata.Name,
+			AddonVersion:        &previous.Version,
+			ConfigurationValues: &configurationValues,
+			// the recorded configuration values take precedence over changes made to the addon resources since
+			ResolveConflicts: ekstypes.ResolveConflictsOverwrite,
+		}); err != nil {
+			return fmt.Errorf("failed to roll back addon %q: %w", name, err)
+		}
+		if waitTimeout > 0 {
+			return a.waitForAddonToBeActive(ctx, &api.Addon{Name: name}, waitTimeout)
+		}
+		return nil
+	})
+}
+
+// describeRevision returns the revision the addon is deployed with, or nil if the addon is not installed
+func (a *Manager) describeRevision(ctx context.Context, name string) (*builder.AddonRevision, error) {
+	output, err := a.eksAPI.DescribeAddon(ctx, &eks.DescribeAddonInput{
+		AddonName:   &name,
+		ClusterName: &a.clusterConfig.Metadata.Name,
+	})
+	if err != nil {
+		var notFoundErr *ekstypes.ResourceNotFoundException
+		if errors.As(err, &notFoundErr) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to describe addon %q: %w", name, err)
+	}
+	return &builder.AddonRevision{
+		Version:             aws.ToString(output.Addon.AddonVersion),
+		ConfigurationValues: aws.ToString(output.Addon.ConfigurationValues),
+	}, nil
+}
+
+// previousRevision returns the revision recorded in the addon revision stack, or nil if there is none
+func (a *Manager) previousRevision(ctx context.Context, name string) (*builder.AddonRevision, error) {
+	stackName := manager.MakeAddonRevisionStackName(a.clusterConfig.Metadata.Name, name)
+	stack, err := a.stackManager.DescribeStack(ctx, &manager.Stack{StackName: aws.String(stackName)})
+	if err != nil {
+		if manager.IsStackDoesNotExistError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stack: %w", err)
+	}
+	if stack == nil {
+		return nil, nil
+	}
+	templateBody, err := a.stackManager.GetStackTemplate(ctx, stackName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template of stack %q: %w", stackName, err)
+	}
+	return builder.ParseAddonRevision(templateBody)
+}
+
+// recordRevision records revision in the addon revision stack, creating the stack if it doesn't exist
+func (a *Manager) recordRevision(ctx context.Context, name string, revision builder.AddonRevision) error {
+	stackName := manager.MakeAddonRevisionStackName(a.clusterConfig.Metadata.Name, name)
+	resourceSet := builder.NewAddonRevisionResourceSet(name, revision)
+	stack, err := a.stackManager.DescribeStack(ctx, &manager.Stack{StackName: aws.String(stackName)})
+	if err != nil && !manager.IsStackDoesNotExistError(err) {
+		return fmt.Errorf("failed to get stack: %w", err)
+	}
+	if stack == nil {
+		// the stack is tagged with the addon name, so it is deleted along with the IAM stacks of the addon
+		return a.createStack(ctx, resourceSet, name, stackName)
+	}
+
+	templateBody, err := resourceSet.RenderJSON()
+	if err != nil {
+		return err
+	}
+	return a.stackManager.UpdateStack(ctx, manager.UpdateStackOptions{
+		Stack:         stack,
+		ChangeSetName: fmt.Sprintf("updating-revision-%s", uuid.NewString()),
+		Description:   fmt.Sprintf("recording the previous revision of addon %q", name),
+		TemplateData:  manager.TemplateBody(templateBody),
+		Wait:          true,
+	})
+}
+
+// sameRevision reports whether two revisions have the same version and equivalent configuration values
+func sameRevision(r1, r2 builder.AddonRevision) bool {
+	if r1.Version != r2.Version {
+		return false
+	}
+	values1, err1 := parseConfigurationValues(r1.ConfigurationValues)
+	values2, err2 := parseConfigurationValues(r2.ConfigurationValues)
+	if err1 != nil || err2 != nil {
+		return r1.ConfigurationValues == r2.ConfigurationValues
+	}
+	return reflect.DeepEqual(values1, values2)
+}
+
+// parseConfigurationValues parses JSON or YAML configuration values, treating empty values as an empty object
+func parseConfigurationValues(configurationValues string) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if configurationValues == "" {
+		return values, nil
+	}
+	if err := yaml.Unmarshal([]byte(configurationValues), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
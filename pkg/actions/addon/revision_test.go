@@ -0,0 +1,126 @@
+package addon_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/addon/fakes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Revisions", func() {
+	var (
+		addonManager     *addon.Manager
+		mockProvider     *mockprovider.MockProvider
+		fakeStackManager *fakes.FakeStackManager
+	)
+
+	mockDeployedRevision := func(addonVersion, configurationValues string) {
+		mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonOutput{
+			Addon: &ekstypes.Addon{
+				AddonName:           aws.String("coredns"),
+				AddonVersion:        aws.String(addonVersion),
+				ConfigurationValues: aws.String(configurationValues),
+			},
+		}, nil).Once()
+	}
+
+	recordedRevision := func(resourceSet builder.ResourceSetReader) *builder.AddonRevision {
+		templateBody, err := resourceSet.RenderJSON()
+		Expect(err).NotTo(HaveOccurred())
+		revision, err := builder.ParseAddonRevision(string(templateBody))
+		Expect(err).NotTo(HaveOccurred())
+		return revision
+	}
+
+	BeforeEach(func() {
+		mockProvider = mockprovider.NewMockProvider()
+		fakeStackManager = new(fakes.FakeStackManager)
+		fakeStackManager.CreateStackStub = func(_ context.Context, _ string, _ builder.ResourceSetReader, _, _ map[string]string, errs chan error) error {
+			go func() {
+				errs <- nil
+			}()
+			return nil
+		}
+		var err error
+		addonManager, err = addon.New(&api.ClusterConfig{Metadata: &api.ClusterMeta{
+			Version: "1.29",
+			Name:    "my-cluster",
+		}}, mockProvider.EKS(), fakeStackManager, false, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("records the revision the addon was deployed with before it changed", func() {
+		mockDeployedRevision("v1.10.1-eksbuild.1", `{"replicaCount":2}`)
+		mockDeployedRevision("v1.11.1-eksbuild.4", `{"replicaCount":3}`)
+
+		Expect(addonManager.WithRevision(context.Background(), "coredns", func() error {
+			return nil
+		})).To(Succeed())
+
+		Expect(fakeStackManager.CreateStackCallCount()).To(Equal(1))
+		_, stackName, resourceSet, tags, _, _ := fakeStackManager.CreateStackArgsForCall(0)
+		Expect(stackName).To(Equal("eksctl-my-cluster-addon-coredns-revision"))
+		Expect(tags).To(Equal(map[string]string{api.AddonNameTag: "coredns"}))
+		Expect(recordedRevision(resourceSet)).To(Equal(&builder.AddonRevision{
+			Version:             "v1.10.1-eksbuild.1",
+			ConfigurationValues: `{"replicaCount":2}`,
+		}))
+	})
+
+	It("does not record a revision if the version and configuration values did not change", func() {
+		mockDeployedRevision("v1.10.1-eksbuild.1", `{"replicaCount":2}`)
+		mockDeployedRevision("v1.10.1-eksbuild.1", "replicaCount: 2\n")
+
+		Expect(addonManager.WithRevision(context.Background(), "coredns", func() error {
+			return nil
+		})).To(Succeed())
+
+		Expect(fakeStackManager.CreateStackCallCount()).To(Equal(0))
+		Expect(fakeStackManager.UpdateStackCallCount()).To(Equal(0))
+	})
+
+	It("rolls back to the recorded revision and records the revision rolled back from", func() {
+		revisionStack := &manager.Stack{StackName: aws.String("eksctl-my-cluster-addon-coredns-revision")}
+		fakeStackManager.DescribeStackReturns(revisionStack, nil)
+		templateBody, err := builder.NewAddonRevisionResourceSet("coredns", builder.AddonRevision{Version: "v1.10.1-eksbuild.1"}).RenderJSON()
+		Expect(err).NotTo(HaveOccurred())
+		fakeStackManager.GetStackTemplateReturns(string(templateBody), nil)
+
+		mockDeployedRevision("v1.11.1-eksbuild.4", `{"replicaCount":3}`)
+		mockDeployedRevision("v1.10.1-eksbuild.1", "{}")
+		mockProvider.MockEKS().On("UpdateAddon", mock.Anything, mock.Anything).Return(&awseks.UpdateAddonOutput{}, nil)
+
+		Expect(addonManager.Rollback(context.Background(), "coredns", 0)).To(Succeed())
+
+		updateInput := mockProvider.MockEKS().Calls[1].Arguments[1].(*awseks.UpdateAddonInput)
+		Expect(*updateInput.AddonVersion).To(Equal("v1.10.1-eksbuild.1"))
+		Expect(*updateInput.ConfigurationValues).To(Equal("{}"))
+		Expect(updateInput.ResolveConflicts).To(Equal(ekstypes.ResolveConflictsOverwrite))
+
+		Expect(fakeStackManager.UpdateStackCallCount()).To(Equal(1))
+		_, options := fakeStackManager.UpdateStackArgsForCall(0)
+		Expect(options.Stack).To(Equal(revisionStack))
+		revision, err := builder.ParseAddonRevision(string(options.TemplateData.(manager.TemplateBody)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revision).To(Equal(&builder.AddonRevision{
+			Version:             "v1.11.1-eksbuild.4",
+			ConfigurationValues: `{"replicaCount":3}`,
+		}))
+	})
+
+	It("fails to roll back if no previous revision is recorded", func() {
+		Expect(addonManager.Rollback(context.Background(), "coredns", 0)).To(MatchError(`no previous revision of addon "coredns" is recorded`))
+		mockProvider.MockEKS().AssertNotCalled(GinkgoT(), "UpdateAddon", mock.Anything, mock.Anything)
+	})
+})
@@ -0,0 +1,107 @@
+package addon
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// coreDNSSizePresets holds the configuration values of the coredns addon for each size preset
+var coreDNSSizePresets = map[string]map[string]interface{}{
+	// a single replica without a pod disruption budget, so that draining the only node is never blocked
+	api.AddonSizePresetSmall: {
+		"replicaCount": 1,
+		"autoScaling": map[string]interface{}{
+			"enabled": false,
+		},
+		"podDisruptionBudget": map[string]interface{}{
+			"enabled": false,
+		},
+		"topologySpreadConstraints": []interface{}{},
+	},
+	api.AddonSizePresetLarge: {
+		"autoScaling": map[string]interface{}{
+			"enabled":     true,
+			"minReplicas": 3,
+			"maxReplicas": 10,
+		},
+		"podDisruptionBudget": map[string]interface{}{
+			"enabled":        true,
+			"maxUnavailable": 1,
+		},
+		"topologySpreadConstraints": []interface{}{
+			makeKubeDNSSpreadConstraint("topology.kubernetes.io/zone"),
+			makeKubeDNSSpreadConstraint("kubernetes.io/hostname"),
+		},
+	},
+}
+
+func makeKubeDNSSpreadConstraint(topologyKey string) map[string]interface{} {
+	return map[string]interface{}{
+		"maxSkew":           1,
+		"topologyKey":       topologyKey,
+		"whenUnsatisfiable": "ScheduleAnyway",
+		"labelSelector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				"k8s-app": "kube-dns",
+			},
+		},
+	}
+}
+
+// makeConfigurationValues returns the configuration values of addon, merged over the values of its size preset or
+// vpcCNI config if any
+func makeConfigurationValues(addon *api.Addon) (*string, error) {
+	var base map[string]interface{}
+	switch {
+	case addon.SizePreset != "":
+		preset, ok := coreDNSSizePresets[addon.SizePreset]
+		if !ok || addon.CanonicalName() != api.CoreDNSAddon {
+			return nil, fmt.Errorf("size preset %q is not supported for %q addon", addon.SizePreset, addon.Name)
+		}
+		base = preset
+	case addon.VPCCNI != nil:
+		if addon.CanonicalName() != api.VPCCNIAddon {
+			return nil, fmt.Errorf("vpcCNI is not supported for %q addon", addon.Name)
+		}
+		base = makeVPCCNIValues(addon.VPCCNI)
+	default:
+		if addon.ConfigurationValues == "" {
+			return nil, nil
+		}
+		return &addon.ConfigurationValues, nil
+	}
+	values := map[string]interface{}{}
+	if addon.ConfigurationValues != "" {
+		if err := yaml.Unmarshal([]byte(addon.ConfigurationValues), &values); err != nil {
+			return nil, fmt.Errorf("parsing configuration values of %q addon: %w", addon.Name, err)
+		}
+	}
+	data, err := json.Marshal(mergeValues(base, values))
+	if err != nil {
+		return nil, err
+	}
+	configurationValues := string(data)
+	return &configurationValues, nil
+}
+
+// mergeValues returns the values of base overridden by the values of override, merging nested objects
+func mergeValues(base, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		if overrideMap, ok := v.(map[string]interface{}); ok {
+			if baseMap, ok := merged[k].(map[string]interface{}); ok {
+				merged[k] = mergeValues(baseMap, overrideMap)
+				continue
+			}
+		}
+		merged[k] = v
+	}
+	return merged
+}
@@ -232,9 +232,9 @@ func (t *deleteAddonIAMTask) Do(errorCh chan error) error {
 	return nil
 }
 
-func runAllTasks(taskTree *tasks.TaskTree) error {
+func runAllTasks(ctx context.Context, taskTree *tasks.TaskTree) error {
 	logger.Debug(taskTree.Describe())
-	if errs := taskTree.DoAllSync(); len(errs) > 0 {
+	if errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{}); len(errs) > 0 {
 		var allErrs []string
 		for _, err := range errs {
 			allErrs = append(allErrs, err.Error())
@@ -28,9 +28,9 @@ type PodIdentityIAMUpdater interface {
 func (a *Manager) Update(ctx context.Context, addon *api.Addon, podIdentityIAMUpdater PodIdentityIAMUpdater, waitTimeout time.Duration) error {
 	logger.Debug("addon: %v", addon)
 
-	var configurationValues *string
-	if addon.ConfigurationValues != "" {
-		configurationValues = &addon.ConfigurationValues
+	configurationValues, err := makeConfigurationValues(addon)
+	if err != nil {
+		return err
 	}
 	updateAddonInput := &eks.UpdateAddonInput{
 		AddonName:           &addon.Name,
@@ -76,6 +76,14 @@ func (a *Manager) Update(ctx context.Context, addon *api.Addon, podIdentityIAMUp
 		updateAddonInput.AddonVersion = &latestVersion
 	}
 
+	if configurationValues != nil {
+		if err := a.validateConfigurationValues(ctx, addon.Name, *updateAddonInput.AddonVersion, *configurationValues); err != nil {
+			return err
+		}
+	}
+
+	a.setMountpointS3Permissions(addon)
+
 	var deleteServiceAccountIAMResources []string
 	if len(summary.PodIdentityAssociations) > 0 && !addon.UseDefaultPodIdentityAssociations && !a.clusterConfig.AddonsConfig.AutoApplyPodIdentityAssociations {
 		if addon.PodIdentityAssociations == nil {
@@ -169,6 +177,9 @@ func (a *Manager) Update(ctx context.Context, addon *api.Addon, podIdentityIAMUp
 			logger.Info("deleted IAM resources for addon %s", addon.Name)
 		}
 	}
+	if addon.Collector != nil {
+		return a.applyADOTCollector(ctx, addon, waitTimeout)
+	}
 	if waitTimeout > 0 {
 		return a.waitForAddonToBeActive(ctx, addon, waitTimeout)
 	}
@@ -432,6 +432,15 @@ var _ = Describe("Update", func() {
 			})
 
 			When("configurationValues is configured", func() {
+				BeforeEach(func() {
+					mockProvider.MockEKS().On("DescribeAddonConfiguration", mock.Anything, &awseks.DescribeAddonConfigurationInput{
+						AddonName:    aws.String("my-addon"),
+						AddonVersion: aws.String("v1.0.0-eksbuild.2"),
+					}).Return(&awseks.DescribeAddonConfigurationOutput{
+						ConfigurationSchema: aws.String(`{"type":"object","properties":{"replicaCount":{"type":"integer"}},"additionalProperties":false}`),
+					}, nil)
+				})
+
 				It("AWS EKS configuration values matches the value from cluster config", func() {
 					err := addonManager.Update(context.Background(), &api.Addon{
 						Name:                "my-addon",
@@ -442,6 +451,19 @@ var _ = Describe("Update", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(aws.ToString(updateAddonInput.ConfigurationValues)).To(Equal("{\"replicaCount\":3}"))
 				})
+
+				It("returns an error without updating the addon if the values do not match the configuration schema", func() {
+					err := addonManager.Update(context.Background(), &api.Addon{
+						Name:                "my-addon",
+						Version:             "v1.0.0-eksbuild.2",
+						ConfigurationValues: "replicaCount: three\nreplicas: 3\n",
+					}, &podIdentityIAMUpdater, 0)
+
+					Expect(err).To(MatchError(ContainSubstring(`configurationValues of "my-addon" addon do not match the configuration schema of version v1.0.0-eksbuild.2`)))
+					Expect(err).To(MatchError(ContainSubstring("replicaCount")))
+					Expect(err).To(MatchError(ContainSubstring("eksctl utils describe-addon-configuration --name my-addon --version v1.0.0-eksbuild.2")))
+					mockProvider.MockEKS().AssertNotCalled(GinkgoT(), "UpdateAddon", mock.Anything, mock.Anything)
+				})
 			})
 
 			When("wellKnownPolicies are configured", func() {
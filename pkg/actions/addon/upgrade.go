@@ -0,0 +1,217 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/hashicorp/go-version"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// addonUpgradeOrder lists the addons other addons depend on, in the order they are upgraded.
+// Pod networking comes first, then service routing and DNS, then the agent addons rely on for
+// pod identity; all other addons are upgraded after them.
+var addonUpgradeOrder = []string{
+	api.VPCCNIAddon,
+	api.KubeProxyAddon,
+	api.CoreDNSAddon,
+	api.PodIdentityAgentAddon,
+}
+
+// PlannedUpgrade is the upgrade of an addon to the version chosen by its upgrade policy.
+type PlannedUpgrade struct {
+	Name           string
+	Policy         string
+	CurrentVersion string
+	TargetVersion  string
+}
+
+// PlanUpgrades computes the versions the given installed addons, or all installed addons if none are given,
+// are upgraded to following their upgrade policies, and returns the upgrades in dependency order.
+// Addons that are already at their target version are left out.
+func (a *Manager) PlanUpgrades(ctx context.Context, names []string) ([]PlannedUpgrade, error) {
+	if len(names) == 0 {
+		output, err := a.eksAPI.ListAddons(ctx, &eks.ListAddonsInput{
+			ClusterName: &a.clusterConfig.Metadata.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list addons: %w", err)
+		}
+		names = output.Addons
+	}
+
+	var upgrades []PlannedUpgrade
+	for _, name := range names {
+		addon := a.configuredAddon(name)
+		summary, err := a.Get(ctx, &api.Addon{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		policy := a.clusterConfig.AddonsConfig.UpgradePolicy(addon)
+		targetVersion, err := a.targetVersion(ctx, addon, policy, summary.Version)
+		if err != nil {
+			return nil, fmt.Errorf("computing the version to upgrade addon %q to: %w", name, err)
+		}
+		if targetVersion == "" {
+			logger.Info("addon %q is pinned to version %s", name, summary.Version)
+			continue
+		}
+		newer, err := a.isNewerVersion(targetVersion, summary.Version)
+		if err != nil {
+			return nil, err
+		}
+		if !newer {
+			logger.Info("addon %q is already at version %s, the version its %s upgrade policy allows", name, summary.Version, policy)
+			continue
+		}
+		upgrades = append(upgrades, PlannedUpgrade{
+			Name:           name,
+			Policy:         policy,
+			CurrentVersion: summary.Version,
+			TargetVersion:  targetVersion,
+		})
+	}
+
+	sortInUpgradeOrder(upgrades)
+	return upgrades, nil
+}
+
+// Upgrade upgrades the addons to their planned versions, one at a time, waiting for each addon
+// to become active before upgrading the next one.
+func (a *Manager) Upgrade(ctx context.Context, upgrades []PlannedUpgrade, podIdentityIAMUpdater PodIdentityIAMUpdater, waitTimeout time.Duration) error {
+	for _, upgrade := range upgrades {
+		logger.Info("upgrading addon %q from version %s to %s", upgrade.Name, upgrade.CurrentVersion, upgrade.TargetVersion)
+		if configured := a.findConfiguredAddon(upgrade.Name); configured != nil {
+			addon := *configured
+			addon.Version = upgrade.TargetVersion
+			if err := a.Update(ctx, &addon, podIdentityIAMUpdater, waitTimeout); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := a.updateVersion(ctx, upgrade.Name, upgrade.TargetVersion, waitTimeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateVersion upgrades an addon that isn't in the config, keeping its configuration, IAM role and pod
+// identity associations as they are.
+func (a *Manager) updateVersion(ctx context.Context, name, addonVersion string, waitTimeout time.Duration) error {
+	if _, err := a.eksAPI.UpdateAddon(ctx, &eks.UpdateAddonInput{
+		AddonName:    &name,
+		ClusterName:  &a.clusterConfig.Metadata.Name,
+		AddonVersion: &addonVersion,
+	}); err != nil {
+		return fmt.Errorf("failed to update addon %q: %w", name, err)
+	}
+	if waitTimeout > 0 {
+		return a.waitForAddonToBeActive(ctx, &api.Addon{Name: name}, waitTimeout)
+	}
+	return nil
+}
+
+// targetVersion returns the version an addon is upgraded to under the given policy,
+// or an empty string if the addon is pinned to its current version
+func (a *Manager) targetVersion(ctx context.Context, addon *api.Addon, policy, currentVersion string) (string, error) {
+	switch policy {
+	case api.AddonUpgradePolicyPinned:
+		if addon.Version == "" || addon.Version == "latest" {
+			return "", nil
+		}
+		targetVersion, _, err := a.getLatestMatchingVersion(ctx, &api.Addon{Name: addon.Name, Version: addon.Version})
+		return targetVersion, err
+
+	case api.AddonUpgradePolicyPatchOnly:
+		return a.latestPatchVersion(ctx, addon.Name, currentVersion)
+
+	default:
+		targetVersion, _, err := a.getLatestMatchingVersion(ctx, &api.Addon{Name: addon.Name, Version: "latest"})
+		return targetVersion, err
+	}
+}
+
+// latestPatchVersion returns the latest version of an addon compatible with the cluster that has the same
+// major and minor version as currentVersion
+func (a *Manager) latestPatchVersion(ctx context.Context, name, currentVersion string) (string, error) {
+	current, err := a.parseVersion(currentVersion)
+	if err != nil {
+		return "", err
+	}
+	output, err := a.describeVersions(ctx, &api.Addon{Name: name})
+	if err != nil {
+		return "", err
+	}
+
+	latest := current
+	for _, addonInfo := range output.Addons {
+		for _, versionInfo := range addonInfo.AddonVersions {
+			v, err := a.parseVersion(aws.ToString(versionInfo.AddonVersion))
+			if err != nil {
+				return "", err
+			}
+			if sameMinorVersion(v, current) && latest.LessThan(v) {
+				latest = v
+			}
+		}
+	}
+	return latest.Original(), nil
+}
+
+func sameMinorVersion(v1, v2 *version.Version) bool {
+	s1, s2 := v1.Segments(), v2.Segments()
+	return s1[0] == s2[0] && s1[1] == s2[1]
+}
+
+func (a *Manager) isNewerVersion(targetVersion, currentVersion string) (bool, error) {
+	target, err := a.parseVersion(targetVersion)
+	if err != nil {
+		return false, err
+	}
+	current, err := a.parseVersion(currentVersion)
+	if err != nil {
+		return false, err
+	}
+	return current.LessThan(target), nil
+}
+
+// configuredAddon returns the config of the named addon, or an addon with only its name if it isn't in the config
+func (a *Manager) configuredAddon(name string) *api.Addon {
+	if addon := a.findConfiguredAddon(name); addon != nil {
+		return addon
+	}
+	return &api.Addon{Name: name}
+}
+
+func (a *Manager) findConfiguredAddon(name string) *api.Addon {
+	for _, addon := range a.clusterConfig.Addons {
+		if addon.CanonicalName() == name {
+			return addon
+		}
+	}
+	return nil
+}
+
+func sortInUpgradeOrder(upgrades []PlannedUpgrade) {
+	rank := func(name string) int {
+		if i := slices.Index(addonUpgradeOrder, name); i >= 0 {
+			return i
+		}
+		return len(addonUpgradeOrder)
+	}
+	sort.SliceStable(upgrades, func(i, j int) bool {
+		ri, rj := rank(upgrades[i].Name), rank(upgrades[j].Name)
+		if ri != rj {
+			return ri < rj
+		}
+		return upgrades[i].Name < upgrades[j].Name
+	})
+}
@@ -0,0 +1,128 @@
+package addon_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Upgrade", func() {
+	var (
+		cfg          *api.ClusterConfig
+		mockProvider *mockprovider.MockProvider
+	)
+
+	mockAddon := func(name, installedVersion string, availableVersions ...string) {
+		var versions []ekstypes.AddonVersionInfo
+		for _, v := range availableVersions {
+			versions = append(versions, ekstypes.AddonVersionInfo{AddonVersion: aws.String(v)})
+		}
+		mockProvider.MockEKS().On("DescribeAddonVersions", mock.Anything, mock.MatchedBy(func(input *awseks.DescribeAddonVersionsInput) bool {
+			return aws.ToString(input.AddonName) == name
+		})).Return(&awseks.DescribeAddonVersionsOutput{
+			Addons: []ekstypes.AddonInfo{
+				{
+					AddonName:     aws.String(name),
+					AddonVersions: versions,
+				},
+			},
+		}, nil)
+		mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.MatchedBy(func(input *awseks.DescribeAddonInput) bool {
+			return aws.ToString(input.AddonName) == name
+		})).Return(&awseks.DescribeAddonOutput{
+			Addon: &ekstypes.Addon{
+				AddonName:    aws.String(name),
+				AddonVersion: aws.String(installedVersion),
+				Status:       ekstypes.AddonStatusActive,
+			},
+		}, nil)
+	}
+
+	planUpgrades := func(names ...string) []addon.PlannedUpgrade {
+		manager, err := addon.New(cfg, mockProvider.EKS(), nil, false, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		upgrades, err := manager.PlanUpgrades(context.Background(), names)
+		Expect(err).NotTo(HaveOccurred())
+		return upgrades
+	}
+
+	BeforeEach(func() {
+		mockProvider = mockprovider.NewMockProvider()
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Metadata.Version = "1.29"
+	})
+
+	It("upgrades all addons to their latest versions in dependency order", func() {
+		mockProvider.MockEKS().On("ListAddons", mock.Anything, mock.Anything).Return(&awseks.ListAddonsOutput{
+			Addons: []string{"aws-ebs-csi-driver", "coredns", "vpc-cni"},
+		}, nil)
+		mockAddon("aws-ebs-csi-driver", "v1.26.0-eksbuild.1", "v1.26.0-eksbuild.1", "v1.27.0-eksbuild.1")
+		mockAddon("coredns", "v1.10.1-eksbuild.1", "v1.10.1-eksbuild.1", "v1.11.1-eksbuild.4")
+		mockAddon("vpc-cni", "v1.15.0-eksbuild.2", "v1.15.0-eksbuild.2", "v1.16.0-eksbuild.1")
+
+		Expect(planUpgrades()).To(Equal([]addon.PlannedUpgrade{
+			{Name: "vpc-cni", Policy: api.AddonUpgradePolicyLatest, CurrentVersion: "v1.15.0-eksbuild.2", TargetVersion: "v1.16.0-eksbuild.1"},
+			{Name: "coredns", Policy: api.AddonUpgradePolicyLatest, CurrentVersion: "v1.10.1-eksbuild.1", TargetVersion: "v1.11.1-eksbuild.4"},
+			{Name: "aws-ebs-csi-driver", Policy: api.AddonUpgradePolicyLatest, CurrentVersion: "v1.26.0-eksbuild.1", TargetVersion: "v1.27.0-eksbuild.1"},
+		}))
+	})
+
+	It("only upgrades the patch version of addons with the patch-only policy", func() {
+		cfg.AddonsConfig.AutoUpgradePolicy = api.AddonUpgradePolicyPatchOnly
+		mockAddon("coredns", "v1.10.1-eksbuild.1", "v1.10.1-eksbuild.1", "v1.10.1-eksbuild.6", "v1.11.1-eksbuild.4")
+
+		Expect(planUpgrades("coredns")).To(Equal([]addon.PlannedUpgrade{
+			{Name: "coredns", Policy: api.AddonUpgradePolicyPatchOnly, CurrentVersion: "v1.10.1-eksbuild.1", TargetVersion: "v1.10.1-eksbuild.6"},
+		}))
+	})
+
+	It("upgrades pinned addons to the version in the config only", func() {
+		cfg.Addons = []*api.Addon{
+			{Name: "coredns", AutoUpgradePolicy: api.AddonUpgradePolicyPinned},
+			{Name: "vpc-cni", Version: "v1.15.1", AutoUpgradePolicy: api.AddonUpgradePolicyPinned},
+		}
+		mockAddon("coredns", "v1.10.1-eksbuild.1", "v1.10.1-eksbuild.1", "v1.11.1-eksbuild.4")
+		mockAddon("vpc-cni", "v1.15.0-eksbuild.2", "v1.15.0-eksbuild.2", "v1.15.1-eksbuild.1", "v1.16.0-eksbuild.1")
+
+		Expect(planUpgrades("coredns", "vpc-cni")).To(Equal([]addon.PlannedUpgrade{
+			{Name: "vpc-cni", Policy: api.AddonUpgradePolicyPinned, CurrentVersion: "v1.15.0-eksbuild.2", TargetVersion: "v1.15.1-eksbuild.1"},
+		}))
+	})
+
+	It("never downgrades an addon", func() {
+		cfg.Addons = []*api.Addon{
+			{Name: "vpc-cni", Version: "v1.15.0", AutoUpgradePolicy: api.AddonUpgradePolicyPinned},
+		}
+		mockAddon("vpc-cni", "v1.16.0-eksbuild.1", "v1.15.0-eksbuild.2", "v1.16.0-eksbuild.1")
+
+		Expect(planUpgrades("vpc-cni")).To(BeEmpty())
+	})
+
+	It("upgrades the addons one at a time in the planned order", func() {
+		mockProvider.MockEKS().On("UpdateAddon", mock.Anything, mock.Anything).Return(&awseks.UpdateAddonOutput{}, nil)
+		manager, err := addon.New(cfg, mockProvider.EKS(), nil, false, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(manager.Upgrade(context.Background(), []addon.PlannedUpgrade{
+			{Name: "vpc-cni", TargetVersion: "v1.16.0-eksbuild.1"},
+			{Name: "coredns", TargetVersion: "v1.11.1-eksbuild.4"},
+		}, nil, 0)).To(Succeed())
+
+		Expect(mockProvider.MockEKS().Calls).To(HaveLen(2))
+		for i, name := range []string{"vpc-cni", "coredns"} {
+			input := mockProvider.MockEKS().Calls[i].Arguments[1].(*awseks.UpdateAddonInput)
+			Expect(*input.AddonName).To(Equal(name))
+		}
+	})
+})
@@ -0,0 +1,49 @@
+package addon
+
+import (
+	"strconv"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// eniConfigLabelZone makes the vpc-cni pick the ENIConfig named after the availability zone of the node
+const eniConfigLabelZone = "topology.kubernetes.io/zone"
+
+// makeVPCCNIValues returns the configuration values of the vpc-cni addon for its vpcCNI config
+func makeVPCCNIValues(config *api.VPCCNIConfig) map[string]interface{} {
+	// the addon configuration schema only accepts strings for the environment of the aws-node container
+	env := map[string]interface{}{}
+	setTarget := func(name string, target *int) {
+		if target != nil {
+			env[name] = strconv.Itoa(*target)
+		}
+	}
+	setTarget("WARM_IP_TARGET", config.WarmIPTarget)
+	setTarget("MINIMUM_IP_TARGET", config.MinimumIPTarget)
+	setTarget("WARM_PREFIX_TARGET", config.WarmPrefixTarget)
+	if config.PrefixDelegation {
+		env["ENABLE_PREFIX_DELEGATION"] = "true"
+	}
+	if config.CustomNetworking {
+		env["AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG"] = "true"
+		env["ENI_CONFIG_LABEL_DEF"] = eniConfigLabelZone
+	}
+
+	values := map[string]interface{}{}
+	if config.PodENI {
+		env["ENABLE_POD_ENI"] = "true"
+		// liveness and readiness probes of pods using security groups fail unless TCP early demux is disabled
+		values["init"] = map[string]interface{}{
+			"env": map[string]interface{}{
+				"DISABLE_TCP_EARLY_DEMUX": "true",
+			},
+		}
+	}
+	if config.NetworkPolicy {
+		values["enableNetworkPolicy"] = "true"
+	}
+	if len(env) > 0 {
+		values["env"] = env
+	}
+	return values
+}
@@ -0,0 +1,13 @@
+package albcontroller_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestALBController(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AWS Load Balancer Controller Suite")
+}
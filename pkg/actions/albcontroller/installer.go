@@ -0,0 +1,217 @@
+package albcontroller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/addons"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers"
+	"github.com/weaveworks/eksctl/pkg/utils"
+)
+
+const (
+	// Namespace is the namespace the controller is installed in
+	Namespace = "kube-system"
+	// ServiceAccountName is the name of the service account of the controller
+	ServiceAccountName = "aws-load-balancer-controller"
+
+	// PublicSubnetTag tags the subnets of internet-facing load balancers
+	PublicSubnetTag = "kubernetes.io/role/elb"
+	// PrivateSubnetTag tags the subnets of internal load balancers
+	PrivateSubnetTag = "kubernetes.io/role/internal-elb"
+
+	helmChartName = "aws-load-balancer-controller"
+	helmRepoURL   = "https://aws.github.io/eks-charts"
+	releaseName   = "aws-load-balancer-controller"
+)
+
+// chartVersions are the newest chart versions supporting a minimum Kubernetes version, newest first
+var chartVersions = []struct {
+	minKubernetesVersion string
+	chartVersion         string
+}{
+	// controller v2.8.1
+	{minKubernetesVersion: "1.22", chartVersion: "1.8.1"},
+	// controller v2.4.7
+	{minKubernetesVersion: "1.19", chartVersion: "1.4.8"},
+}
+
+// Installer installs the AWS Load Balancer Controller in a cluster
+type Installer struct {
+	ClusterConfig *api.ClusterConfig
+	// Cluster is the description of the cluster, used for its version and subnets
+	Cluster       *ekstypes.Cluster
+	EC2           awsapi.EC2
+	IRSA          addons.IRSAHelper
+	HelmInstaller providers.HelmInstaller
+	// ChartVersion overrides the chart version compatible with the version of the cluster
+	ChartVersion string
+}
+
+// Install creates the IAM role of the controller, tags the subnets of the cluster for load balancer discovery and
+// installs the controller; it is safe to run again to update the controller
+func (i *Installer) Install(ctx context.Context) error {
+	chartVersion, err := i.chartVersion()
+	if err != nil {
+		return err
+	}
+
+	supported, err := i.IRSA.IsSupported(ctx)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("no IAM OIDC provider associated with cluster, try 'eksctl utils associate-iam-oidc-provider --region=%s --cluster=%s'", i.ClusterConfig.Metadata.Region, i.ClusterConfig.Metadata.Name)
+	}
+	logger.Info("creating the IAM role of the AWS Load Balancer Controller")
+	if err := i.IRSA.CreateOrUpdate(ctx, &api.ClusterIAMServiceAccount{
+		ClusterIAMMeta: api.ClusterIAMMeta{
+			Name:      ServiceAccountName,
+			Namespace: Namespace,
+		},
+		WellKnownPolicies: api.WellKnownPolicies{
+			AWSLoadBalancerController: true,
+		},
+	}); err != nil {
+		return fmt.Errorf("creating the IAM role of the AWS Load Balancer Controller: %w", err)
+	}
+
+	if err := i.tagSubnets(ctx); err != nil {
+		return err
+	}
+
+	logger.Info("installing the AWS Load Balancer Controller chart version %s", chartVersion)
+	if err := i.HelmInstaller.InstallChart(ctx, providers.InstallChartOpts{
+		ChartName:   helmChartName,
+		RepoURL:     helmRepoURL,
+		Namespace:   Namespace,
+		ReleaseName: releaseName,
+		Version:     chartVersion,
+		Values: map[string]interface{}{
+			"clusterName": i.ClusterConfig.Metadata.Name,
+			"region":      i.ClusterConfig.Metadata.Region,
+			"vpcId":       aws.ToString(i.Cluster.ResourcesVpcConfig.VpcId),
+			"serviceAccount": map[string]interface{}{
+				"create": false,
+				"name":   ServiceAccountName,
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to install AWS Load Balancer Controller chart: %w", err)
+	}
+	return nil
+}
+
+func (i *Installer) chartVersion() (string, error) {
+	if i.ChartVersion != "" {
+		return i.ChartVersion, nil
+	}
+	kubernetesVersion := aws.ToString(i.Cluster.Version)
+	for _, v := range chartVersions {
+		supported, err := utils.IsMinVersion(v.minKubernetesVersion, kubernetesVersion)
+		if err != nil {
+			return "", err
+		}
+		if supported {
+			return v.chartVersion, nil
+		}
+	}
+	return "", fmt.Errorf("the AWS Load Balancer Controller does not support Kubernetes version %s", kubernetesVersion)
+}
+
+// tagSubnets tags the subnets of the cluster routed to an internet gateway with PublicSubnetTag, and the others
+// with PrivateSubnetTag, unless they are already tagged
+func (i *Installer) tagSubnets(ctx context.Context) error {
+	vpcConfig := i.Cluster.ResourcesVpcConfig
+	subnets, err := i.EC2.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: vpcConfig.SubnetIds,
+	})
+	if err != nil {
+		return fmt.Errorf("describing the subnets of the cluster: %w", err)
+	}
+	public, err := i.publicSubnets(ctx, aws.ToString(vpcConfig.VpcId))
+	if err != nil {
+		return err
+	}
+
+	for _, subnet := range subnets.Subnets {
+		if hasTag(subnet.Tags, PublicSubnetTag) || hasTag(subnet.Tags, PrivateSubnetTag) {
+			continue
+		}
+		subnetID := aws.ToString(subnet.SubnetId)
+		key := PrivateSubnetTag
+		if public[subnetID] {
+			key = PublicSubnetTag
+		}
+		if _, err := i.EC2.CreateTags(ctx, &ec2.CreateTagsInput{
+			Resources: []string{subnetID},
+			Tags:      []ec2types.Tag{{Key: aws.String(key), Value: aws.String("1")}},
+		}); err != nil {
+			return fmt.Errorf("tagging subnet %q: %w", subnetID, err)
+		}
+		logger.Info("tagged subnet %q with %q", subnetID, key)
+	}
+	return nil
+}
+
+// publicSubnets returns the subnets of the VPC whose route table, or the main route table of the VPC for subnets
+// without one, routes to an internet gateway
+func (i *Installer) publicSubnets(ctx context.Context, vpcID string) (map[string]bool, error) {
+	output, err := i.EC2.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcID},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing the route tables of VPC %q: %w", vpcID, err)
+	}
+
+	public := map[string]bool{}
+	associated := map[string]bool{}
+	mainIsPublic := false
+	for _, routeTable := range output.RouteTables {
+		routesToInternet := false
+		for _, route := range routeTable.Routes {
+			if strings.HasPrefix(aws.ToString(route.GatewayId), "igw-") {
+				routesToInternet = true
+			}
+		}
+		for _, association := range routeTable.Associations {
+			if aws.ToBool(association.Main) {
+				mainIsPublic = routesToInternet
+				continue
+			}
+			if subnetID := aws.ToString(association.SubnetId); subnetID != "" {
+				associated[subnetID] = true
+				public[subnetID] = routesToInternet
+			}
+		}
+	}
+	for _, subnetID := range i.Cluster.ResourcesVpcConfig.SubnetIds {
+		if !associated[subnetID] {
+			public[subnetID] = mainIsPublic
+		}
+	}
+	return public, nil
+}
+
+func hasTag(tags []ec2types.Tag, key string) bool {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package albcontroller_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/albcontroller"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+type fakeIRSA struct {
+	supported       bool
+	serviceAccounts []*api.ClusterIAMServiceAccount
+}
+
+func (f *fakeIRSA) IsSupported(_ context.Context) (bool, error) {
+	return f.supported, nil
+}
+
+func (f *fakeIRSA) CreateOrUpdate(_ context.Context, sa *api.ClusterIAMServiceAccount) error {
+	f.serviceAccounts = append(f.serviceAccounts, sa)
+	return nil
+}
+
+var _ = Describe("AWS Load Balancer Controller installer", func() {
+	var (
+		provider      *mockprovider.MockProvider
+		irsaHelper    *fakeIRSA
+		helmInstaller *fakes.FakeHelmInstaller
+		installer     *albcontroller.Installer
+	)
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		irsaHelper = &fakeIRSA{supported: true}
+		helmInstaller = &fakes.FakeHelmInstaller{}
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Metadata.Region = "us-west-2"
+		installer = &albcontroller.Installer{
+			ClusterConfig: cfg,
+			Cluster: &ekstypes.Cluster{
+				Version: aws.String("1.30"),
+				ResourcesVpcConfig: &ekstypes.VpcConfigResponse{
+					VpcId:     aws.String("vpc-1"),
+					SubnetIds: []string{"subnet-public", "subnet-private", "subnet-main", "subnet-tagged"},
+				},
+			},
+			EC2:           provider.EC2(),
+			IRSA:          irsaHelper,
+			HelmInstaller: helmInstaller,
+		}
+
+		provider.MockEC2().On("DescribeSubnets", mock.Anything, mock.Anything).Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []ec2types.Subnet{
+				{SubnetId: aws.String("subnet-public")},
+				{SubnetId: aws.String("subnet-private")},
+				{SubnetId: aws.String("subnet-main")},
+				{SubnetId: aws.String("subnet-tagged"), Tags: []ec2types.Tag{{Key: aws.String(albcontroller.PrivateSubnetTag), Value: aws.String("1")}}},
+			},
+		}, nil)
+		provider.MockEC2().On("DescribeRouteTables", mock.Anything, mock.Anything).Return(&ec2.DescribeRouteTablesOutput{
+			RouteTables: []ec2types.RouteTable{
+				{
+					Routes:       []ec2types.Route{{GatewayId: aws.String("local")}, {GatewayId: aws.String("igw-1")}},
+					Associations: []ec2types.RouteTableAssociation{{SubnetId: aws.String("subnet-public")}, {Main: aws.Bool(true)}},
+				},
+				{
+					Routes:       []ec2types.Route{{GatewayId: aws.String("local")}, {NatGatewayId: aws.String("nat-1")}},
+					Associations: []ec2types.RouteTableAssociation{{SubnetId: aws.String("subnet-private")}},
+				},
+			},
+		}, nil)
+		provider.MockEC2().On("CreateTags", mock.Anything, mock.Anything).Return(&ec2.CreateTagsOutput{}, nil)
+	})
+
+	It("creates the IAM role, tags the subnets and installs the chart compatible with the cluster", func() {
+		Expect(installer.Install(context.Background())).To(Succeed())
+
+		Expect(irsaHelper.serviceAccounts).To(HaveLen(1))
+		sa := irsaHelper.serviceAccounts[0]
+		Expect(sa.NameString()).To(Equal("kube-system/aws-load-balancer-controller"))
+		Expect(sa.WellKnownPolicies.AWSLoadBalancerController).To(BeTrue())
+
+		var tagged []string
+		for _, call := range provider.MockEC2().Calls {
+			if call.Method == "CreateTags" {
+				input := call.Arguments[1].(*ec2.CreateTagsInput)
+				tagged = append(tagged, input.Resources[0]+"="+aws.ToString(input.Tags[0].Key))
+			}
+		}
+		Expect(tagged).To(ConsistOf(
+			"subnet-public=kubernetes.io/role/elb",
+			"subnet-private=kubernetes.io/role/internal-elb",
+			"subnet-main=kubernetes.io/role/elb",
+		))
+
+		Expect(helmInstaller.InstallChartCallCount()).To(Equal(1))
+		_, opts := helmInstaller.InstallChartArgsForCall(0)
+		Expect(opts.ChartName).To(Equal("aws-load-balancer-controller"))
+		Expect(opts.Namespace).To(Equal("kube-system"))
+		Expect(opts.Version).To(Equal("1.8.1"))
+		Expect(opts.Values).To(HaveKeyWithValue("clusterName", "my-cluster"))
+		Expect(opts.Values).To(HaveKeyWithValue("vpcId", "vpc-1"))
+		Expect(opts.Values).To(HaveKeyWithValue("serviceAccount", map[string]interface{}{
+			"create": false,
+			"name":   "aws-load-balancer-controller",
+		}))
+	})
+
+	It("installs the given chart version", func() {
+		installer.ChartVersion = "1.7.2"
+		Expect(installer.Install(context.Background())).To(Succeed())
+		_, opts := helmInstaller.InstallChartArgsForCall(0)
+		Expect(opts.Version).To(Equal("1.7.2"))
+	})
+
+	It("fails for unsupported Kubernetes versions", func() {
+		installer.Cluster.Version = aws.String("1.18")
+		Expect(installer.Install(context.Background())).To(MatchError(ContainSubstring("does not support Kubernetes version 1.18")))
+		Expect(irsaHelper.serviceAccounts).To(BeEmpty())
+	})
+
+	It("fails without an IAM OIDC provider", func() {
+		irsaHelper.supported = false
+		Expect(installer.Install(context.Background())).To(MatchError(ContainSubstring("no IAM OIDC provider associated with cluster")))
+		Expect(helmInstaller.InstallChartCallCount()).To(Equal(0))
+	})
+})
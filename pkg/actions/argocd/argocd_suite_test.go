@@ -0,0 +1,13 @@
+package argocd_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestArgoCD(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Argo CD Suite")
+}
@@ -0,0 +1,227 @@
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers/helm"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	// Namespace is the namespace Argo CD is installed in
+	Namespace = "argocd"
+
+	// DefaultVersion is the version of the argo-cd chart installed by default
+	DefaultVersion = "7.7.0"
+	// DefaultRevision is the revision of the repository synced by default
+	DefaultRevision = "HEAD"
+
+	helmRepoURL        = "https://argoproj.github.io/argo-helm"
+	helmChartName      = "argo-cd"
+	releaseName        = "argo-cd"
+	appsHelmChartName  = "argocd-apps"
+	appsHelmVersion    = "2.0.2"
+	appsReleaseName    = "argocd-apps"
+	repositoryName     = "cluster-repository"
+	defaultUsername    = "git"
+	inClusterServer    = "https://kubernetes.default.svc"
+	secretsManagerPath = "/aws/reference/secretsmanager/"
+
+	// secretTypeLabel makes Argo CD pick up a Secret as the configuration of a repository
+	secretTypeLabel      = "argocd.argoproj.io/secret-type"
+	secretTypeRepository = "repository"
+)
+
+// Installer installs Argo CD in a cluster, configures the repository of the cluster and creates an app-of-apps
+// Application syncing the Applications of the cluster from it
+type Installer struct {
+	ClusterConfig *api.ClusterConfig
+	// SSM reads the credentials of the repository, Secrets Manager secrets are read through SSM parameter references
+	SSM awsapi.SSM
+	// ClientSet creates the Secret holding the repository and its credentials, so that they are not stored in the
+	// values of the Helm release
+	ClientSet     kubernetes.Interface
+	HelmInstaller providers.HelmInstaller
+}
+
+// New returns an Installer installing the charts with Helm through the given kubeconfig
+func New(clusterConfig *api.ClusterConfig, ssmAPI awsapi.SSM, kubeConfig []byte) (*Installer, error) {
+	restClientGetter := kubernetes.NewRESTClientGetter(Namespace, string(kubeConfig))
+	helmInstaller, err := helm.NewInstaller(helm.Options{
+		Namespace:        Namespace,
+		RESTClientGetter: restClientGetter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	restConfig, err := restClientGetter.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientSet, err := kubeclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &Installer{
+		ClusterConfig: clusterConfig,
+		SSM:           ssmAPI,
+		ClientSet:     clientSet,
+		HelmInstaller: helmInstaller,
+	}, nil
+}
+
+// Install installs Argo CD and the app-of-apps Application; it is safe to run again to update Argo CD
+func (i *Installer) Install(ctx context.Context) error {
+	argoCD := i.ClusterConfig.GitOps.ArgoCD
+	repository, err := i.repository(ctx, argoCD)
+	if err != nil {
+		return err
+	}
+	if err := i.applyRepositorySecret(ctx, repository); err != nil {
+		return err
+	}
+
+	version := argoCD.Version
+	if version == "" {
+		version = DefaultVersion
+	}
+	logger.Info("installing Argo CD chart version %s", version)
+	if err := i.HelmInstaller.InstallChart(ctx, providers.InstallChartOpts{
+		ChartName:       helmChartName,
+		RepoURL:         helmRepoURL,
+		Namespace:       Namespace,
+		ReleaseName:     releaseName,
+		Version:         version,
+		CreateNamespace: true,
+	}); err != nil {
+		return fmt.Errorf("failed to install Argo CD chart: %w", err)
+	}
+
+	revision := argoCD.Revision
+	if revision == "" {
+		revision = DefaultRevision
+	}
+	path := argoCD.PathOrDefault(i.ClusterConfig.Metadata.Name)
+	logger.Info("creating app-of-apps Application syncing %s at %s", path, revision)
+	if err := i.HelmInstaller.InstallChart(ctx, providers.InstallChartOpts{
+		ChartName:   appsHelmChartName,
+		RepoURL:     helmRepoURL,
+		Namespace:   Namespace,
+		ReleaseName: appsReleaseName,
+		Version:     appsHelmVersion,
+		Values: map[string]interface{}{
+			"applications": map[string]interface{}{
+				i.ClusterConfig.Metadata.Name: map[string]interface{}{
+					"namespace": Namespace,
+					"project":   "default",
+					"source": map[string]interface{}{
+						"repoURL":        argoCD.RepoURL,
+						"targetRevision": revision,
+						"path":           path,
+					},
+					"destination": map[string]interface{}{
+						"server":    inClusterServer,
+						"namespace": Namespace,
+					},
+					"syncPolicy": map[string]interface{}{
+						"automated": map[string]interface{}{
+							"prune":    true,
+							"selfHeal": true,
+						},
+					},
+				},
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to install argocd-apps chart: %w", err)
+	}
+	return nil
+}
+
+// repository returns the Argo CD configuration of the repository, along with its credentials: a password or token
+// for HTTPS repositories, an SSH private key otherwise
+func (i *Installer) repository(ctx context.Context, argoCD *api.ArgoCD) (map[string]string, error) {
+	repository := map[string]string{
+		"url":  argoCD.RepoURL,
+		"type": "git",
+	}
+	credentials := argoCD.Credentials
+	if credentials == nil {
+		return repository, nil
+	}
+	secret, err := i.readCredentials(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+	if !argoCD.IsHTTPS() {
+		repository["sshPrivateKey"] = secret
+		return repository, nil
+	}
+	username := credentials.Username
+	if username == "" {
+		username = defaultUsername
+	}
+	repository["username"] = username
+	repository["password"] = secret
+	return repository, nil
+}
+
+// applyRepositorySecret creates or updates the Secret Argo CD reads the repository and its credentials from
+func (i *Installer) applyRepositorySecret(ctx context.Context, repository map[string]string) error {
+	if err := kubernetes.MaybeCreateNamespace(i.ClientSet, Namespace); err != nil {
+		return fmt.Errorf("creating namespace %q: %w", Namespace, err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      repositoryName,
+			Namespace: Namespace,
+			Labels: map[string]string{
+				secretTypeLabel: secretTypeRepository,
+			},
+		},
+		StringData: repository,
+	}
+	secrets := i.ClientSet.CoreV1().Secrets(Namespace)
+	existing, err := secrets.Get(ctx, repositoryName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+	case err == nil:
+		secret.ResourceVersion = existing.ResourceVersion
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("applying Secret of the repository: %w", err)
+	}
+	return nil
+}
+
+func (i *Installer) readCredentials(ctx context.Context, credentials *api.ArgoCDCredentials) (string, error) {
+	name, source := credentials.SSMParameter, "SSM parameter"
+	if credentials.SecretID != "" {
+		name, source = secretsManagerPath+credentials.SecretID, "Secrets Manager secret"
+	}
+	output, err := i.SSM.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("reading the credentials of the repository from %s %q: %w", source, name, err)
+	}
+	if output.Parameter == nil || aws.ToString(output.Parameter.Value) == "" {
+		return "", fmt.Errorf("%s %q has no value", source, name)
+	}
+	return *output.Parameter.Value, nil
+}
@@ -0,0 +1,181 @@
+package backups
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers"
+)
+
+const (
+	// Namespace is the namespace Velero is installed in.
+	Namespace = "velero"
+	// ServiceAccountName is the name of the service account of the Velero server.
+	ServiceAccountName = "velero-server"
+
+	// DefaultTTL is how long scheduled backups are kept by default.
+	DefaultTTL = "720h"
+	// DefaultAWSPluginVersion is the default version of the Velero plugin for AWS.
+	DefaultAWSPluginVersion = "v1.10.0"
+
+	helmChartName = "velero"
+	helmRepoURL   = "https://vmware-tanzu.github.io/helm-charts"
+	releaseName   = "velero"
+	awsPluginRepo = "velero/velero-plugin-for-aws"
+)
+
+// Installer sets up the S3 bucket and IAM role for the backups of a cluster, and installs Velero.
+type Installer struct {
+	StackManager  manager.StackManager
+	OIDC          *iamoidc.OpenIDConnectManager
+	HelmInstaller providers.HelmInstaller
+	ClusterConfig *api.ClusterConfig
+}
+
+// MakeStackName returns the name of the backups stack of a cluster.
+func MakeStackName(clusterName string) string {
+	return fmt.Sprintf("eksctl-%s-backups", clusterName)
+}
+
+// Enable creates the backups stack, unless it already exists, and installs Velero.
+func (i *Installer) Enable(ctx context.Context) error {
+	backups := i.ClusterConfig.Backups
+	resourceSet := builder.NewBackupsResourceSet(backups, i.OIDC, Namespace, ServiceAccountName)
+	if err := resourceSet.AddAllResources(); err != nil {
+		return err
+	}
+
+	stackName := MakeStackName(i.ClusterConfig.Metadata.Name)
+	stack, err := i.StackManager.DescribeStack(ctx, &manager.Stack{StackName: &stackName})
+	switch {
+	case err == nil:
+		logger.Info("using the bucket and IAM role of existing stack %q", stackName)
+		if err := resourceSet.GetAllOutputs(*stack); err != nil {
+			return errors.Wrapf(err, "collecting outputs of stack %q", stackName)
+		}
+	case manager.IsStackDoesNotExistError(err):
+		errs := make(chan error)
+		if err := i.StackManager.CreateStack(ctx, stackName, resourceSet, nil, nil, errs); err != nil {
+			return err
+		}
+		if err := <-errs; err != nil {
+			return errors.Wrapf(err, "creating stack %q", stackName)
+		}
+	default:
+		return err
+	}
+
+	logger.Info("installing Velero, backing up to bucket %q", resourceSet.BucketName)
+	if err := i.HelmInstaller.InstallChart(ctx, providers.InstallChartOpts{
+		ChartName:       helmChartName,
+		RepoURL:         helmRepoURL,
+		CreateNamespace: true,
+		Namespace:       Namespace,
+		ReleaseName:     releaseName,
+		Values:          i.makeValues(resourceSet.BucketName, resourceSet.RoleARN),
+		Version:         backups.Version,
+	}); err != nil {
+		return fmt.Errorf("failed to install Velero chart: %w", err)
+	}
+	return nil
+}
+
+func (i *Installer) makeValues(bucketName, roleARN string) map[string]interface{} {
+	backups := i.ClusterConfig.Backups
+	prefix := backups.Prefix
+	if prefix == "" {
+		prefix = i.ClusterConfig.Metadata.Name
+	}
+	pluginVersion := backups.AWSPluginVersion
+	if pluginVersion == "" {
+		pluginVersion = DefaultAWSPluginVersion
+	}
+	awsConfig := map[string]interface{}{
+		"region": i.ClusterConfig.Metadata.Region,
+	}
+
+	values := map[string]interface{}{
+		"configuration": map[string]interface{}{
+			"backupStorageLocation": []interface{}{
+				map[string]interface{}{
+					"name":     "default",
+					"provider": "aws",
+					"bucket":   bucketName,
+					"prefix":   prefix,
+					"config":   awsConfig,
+				},
+			},
+			"volumeSnapshotLocation": []interface{}{
+				map[string]interface{}{
+					"name":     "default",
+					"provider": "aws",
+					"config":   awsConfig,
+				},
+			},
+		},
+		"credentials": map[string]interface{}{
+			"useSecret": false,
+		},
+		"serviceAccount": map[string]interface{}{
+			"server": map[string]interface{}{
+				"create": true,
+				"name":   ServiceAccountName,
+				"annotations": map[string]interface{}{
+					api.AnnotationEKSRoleARN: roleARN,
+				},
+			},
+		},
+		"initContainers": []interface{}{
+			map[string]interface{}{
+				"name":  "velero-plugin-for-aws",
+				"image": fmt.Sprintf("%s:%s", awsPluginRepo, pluginVersion),
+				"volumeMounts": []interface{}{
+					map[string]interface{}{
+						"mountPath": "/target",
+						"name":      "plugins",
+					},
+				},
+			},
+		},
+	}
+
+	if backups.Schedule != "" {
+		ttl := backups.TTL
+		if ttl == "" {
+			ttl = DefaultTTL
+		}
+		values["schedules"] = map[string]interface{}{
+			"eksctl": map[string]interface{}{
+				"schedule": backups.Schedule,
+				"template": map[string]interface{}{
+					"ttl": ttl,
+				},
+			},
+		}
+	}
+	return values
+}
+
+// RunVelero runs the velero CLI with args against the cluster in kubeconfigPath.
+func RunVelero(ctx context.Context, kubeconfigPath string, args []string) error {
+	veleroPath, err := exec.LookPath("velero")
+	if err != nil {
+		return fmt.Errorf("velero binary is required to manage backups: %w", err)
+	}
+	args = append(args, "--kubeconfig", kubeconfigPath, "--namespace", Namespace)
+	logger.Debug("running %s %v", veleroPath, args)
+	cmd := exec.CommandContext(ctx, veleroPath, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
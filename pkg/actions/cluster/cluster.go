@@ -15,7 +15,30 @@ import (
 
 type Cluster interface {
 	Upgrade(ctx context.Context, dryRun bool) error
-	Delete(ctx context.Context, waitInterval, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int) error
+	Delete(ctx context.Context, options DeleteOptions) error
+	DeletionReport(ctx context.Context) (*DeletionReport, error)
+	DeleteKubernetesResources(ctx context.Context, kinds []string) error
+}
+
+// DeleteOptions are the options of Cluster.Delete
+type DeleteOptions struct {
+	// WaitInterval is how often the deletion of nodegroups not created by eksctl is checked
+	WaitInterval time.Duration
+	// PodEvictionWaitPeriod is how long to wait for pods to be evicted when draining nodegroups
+	PodEvictionWaitPeriod time.Duration
+	// Wait for the deletion of the cluster to complete
+	Wait bool
+	// Force continues the deletion when draining nodegroups or deleting some resources fails
+	Force bool
+	// ForceCleanup deletes the resources left behind in the VPC by Kubernetes controllers when they keep the
+	// cluster stack from being deleted
+	ForceCleanup bool
+	// DisableNodegroupEviction deletes nodegroups without draining them
+	DisableNodegroupEviction bool
+	// Parallel is the number of nodes drained in parallel
+	Parallel int
+	// StackParallelism is the number of stacks deleted in parallel
+	StackParallelism int
 }
 
 func New(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider) (Cluster, error) {
@@ -28,8 +28,19 @@ import (
 	ssh "github.com/weaveworks/eksctl/pkg/ssh/client"
 	"github.com/weaveworks/eksctl/pkg/utils/apierrors"
 	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 )
 
+// ProtectedClusterStack returns the name of the cluster stack if termination protection is enabled on it,
+// and an empty string otherwise
+func ProtectedClusterStack(ctx context.Context, stackManager manager.StackManager) (string, error) {
+	stack, err := stackManager.GetClusterStackIfExists(ctx)
+	if err != nil || stack == nil || !aws.ToBool(stack.EnableTerminationProtection) {
+		return "", err
+	}
+	return aws.ToString(stack.StackName), nil
+}
+
 type NodeGroupDrainer interface {
 	Drain(ctx context.Context, input *nodegroup.DrainInput) error
 }
@@ -55,17 +66,29 @@ func deleteSharedResources(ctx context.Context, cfg *api.ClusterConfig, ctl *eks
 
 	// only need to cleanup ELBs if the cluster has already been created.
 	if clusterOperable {
-		ctx, cleanup := context.WithTimeout(context.Background(), 10*time.Minute)
-		defer cleanup()
+		logger.Info("cleaning up AWS load balancers created by Kubernetes objects of Kind Service or Ingress")
+		return cleanupLoadBalancers(context.Background(), cfg, ctl, clientSet)
+	}
+	return nil
+}
 
-		cfg.Metadata.Version = *ctl.Status.ClusterInfo.Cluster.Version
+// cleanupLoadBalancers deletes the Services of type LoadBalancer and the Ingresses of the cluster, and waits for
+// their load balancers to be deleted
+func cleanupLoadBalancers(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider, clientSet kubernetes.Interface) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
 
-		logger.Info("cleaning up AWS load balancers created by Kubernetes objects of Kind Service or Ingress")
-		if err := elb.Cleanup(ctx, ctl.AWSProvider.EC2(), ctl.AWSProvider.ELB(), ctl.AWSProvider.ELBV2(), clientSet, cfg); err != nil {
-			return err
+	cfg.Metadata.Version = *ctl.Status.ClusterInfo.Cluster.Version
+	return elb.Cleanup(ctx, ctl.AWSProvider.EC2(), ctl.AWSProvider.ELB(), ctl.AWSProvider.ELBV2(), clientSet, cfg)
+}
+
+func hasNodeGroupStack(stacks []manager.NodeGroupStack, nodeGroupName string) bool {
+	for _, stack := range stacks {
+		if stack.NodeGroupName == nodeGroupName {
+			return true
 		}
 	}
-	return nil
+	return false
 }
 
 func handleErrors(errs []error, subject string) error {
@@ -126,13 +149,13 @@ func deleteFargateProfiles(ctx context.Context, clusterMeta *api.ClusterMeta, ct
 }
 
 func deleteDeprecatedStacks(ctx context.Context, stackManager manager.StackManager) (bool, error) {
-	tasks, err := stackManager.DeleteTasksForDeprecatedStacks(ctx)
+	taskTree, err := stackManager.DeleteTasksForDeprecatedStacks(ctx)
 	if err != nil {
 		return true, err
 	}
-	if count := tasks.Len(); count > 0 {
-		logger.Info(tasks.Describe())
-		if errs := tasks.DoAllSync(); len(errs) > 0 {
+	if count := taskTree.Len(); count > 0 {
+		logger.Info(taskTree.Describe())
+		if errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{}); len(errs) > 0 {
 			return true, handleErrors(errs, "deprecated stacks")
 		}
 		logger.Success("deleted all %s deprecated stacks", count)
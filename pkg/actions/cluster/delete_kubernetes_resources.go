@@ -0,0 +1,171 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+// Kinds of Kubernetes resources backed by AWS resources that can be deleted before the cluster is torn down.
+const (
+	KubernetesResourcesLoadBalancers = "load-balancers"
+	KubernetesResourcesVolumes       = "volumes"
+)
+
+// KubernetesResourceKinds lists the kinds accepted by Cluster.DeleteKubernetesResources.
+var KubernetesResourceKinds = []string{KubernetesResourcesLoadBalancers, KubernetesResourcesVolumes}
+
+const (
+	efsCSIDriver            = "efs.csi.aws.com"
+	provisionedByAnnotation = "pv.kubernetes.io/provisioned-by"
+)
+
+// ValidateKubernetesResourceKinds validates kinds passed to Cluster.DeleteKubernetesResources.
+func ValidateKubernetesResourceKinds(kinds []string) error {
+	for _, kind := range kinds {
+		if !sets.New(KubernetesResourceKinds...).Has(kind) {
+			return fmt.Errorf("invalid Kubernetes resource kind %q, valid values are: %s", kind, strings.Join(KubernetesResourceKinds, ", "))
+		}
+	}
+	return nil
+}
+
+// deleteKubernetesResources deletes the Kubernetes resources of the given kinds while the nodes, and the controllers
+// running on them, are still available to delete the AWS resources that back them.
+func deleteKubernetesResources(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider, newClientSet func() (kubernetes.Interface, error), kinds []string) error {
+	clusterOperable, err := ctl.CanOperate(cfg)
+	if err != nil {
+		logger.Debug("failed to check if cluster is operable: %v", err)
+	}
+	if !clusterOperable {
+		logger.Warning("cluster %q is not operable, skipping deletion of Kubernetes resources", cfg.Metadata.Name)
+		return nil
+	}
+	clientSet, err := newClientSet()
+	if err != nil {
+		return err
+	}
+
+	kindSet := sets.New(kinds...)
+	if kindSet.Has(KubernetesResourcesLoadBalancers) {
+		logger.Info("deleting Kubernetes Services of type LoadBalancer and Ingresses, and waiting for their load balancers to be deleted")
+		if err := cleanupLoadBalancers(ctx, cfg, ctl, clientSet); err != nil {
+			return err
+		}
+	}
+	if kindSet.Has(KubernetesResourcesVolumes) {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+		defer cancel()
+
+		logger.Info("deleting PersistentVolumeClaims backed by EBS or EFS, and waiting for their volumes to be deleted")
+		volumeDeleter := &VolumeDeleter{
+			ClientSet:    clientSet,
+			PollInterval: 5 * time.Second,
+		}
+		if err := volumeDeleter.Delete(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VolumeDeleter deletes PersistentVolumeClaims bound to EBS or EFS volumes, so that the CSI drivers
+// delete the volumes.
+type VolumeDeleter struct {
+	ClientSet    kubernetes.Interface
+	PollInterval time.Duration
+}
+
+// Delete deletes the claims of all dynamically provisioned EBS and EFS PersistentVolumes, along with the pods
+// using them, and waits until the volumes are deleted or ctx is done. Volumes with a Retain reclaim policy and
+// statically provisioned volumes are not deleted by the CSI drivers and are left alone.
+func (d *VolumeDeleter) Delete(ctx context.Context) error {
+	pvs, err := d.ClientSet.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing persistent volumes: %w", err)
+	}
+
+	var pending []string
+	for _, pv := range pvs.Items {
+		if !isAWSVolume(pv) || pv.Annotations[provisionedByAnnotation] == "" || pv.Spec.ClaimRef == nil {
+			continue
+		}
+		if pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimDelete {
+			logger.Info("leaving persistent volume %q and its claim %s/%s alone, as its reclaim policy is %s", pv.Name, pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name, pv.Spec.PersistentVolumeReclaimPolicy)
+			continue
+		}
+		if err := d.deleteClaim(ctx, pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name); err != nil {
+			return err
+		}
+		pending = append(pending, pv.Name)
+	}
+
+	for len(pending) > 0 {
+		var remaining []string
+		for _, name := range pending {
+			_, err := d.ClientSet.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err):
+				logger.Debug("persistent volume %q was deleted", name)
+			case err != nil:
+				return fmt.Errorf("getting persistent volume %q: %w", name, err)
+			default:
+				remaining = append(remaining, name)
+			}
+		}
+		if pending = remaining; len(pending) == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for persistent volumes to be deleted: %s", strings.Join(pending, ", "))
+		case <-time.After(d.PollInterval):
+		}
+	}
+	return nil
+}
+
+// deleteClaim deletes a PersistentVolumeClaim and the pods mounting it, which otherwise keep the claim
+// from being removed.
+func (d *VolumeDeleter) deleteClaim(ctx context.Context, namespace, name string) error {
+	logger.Debug("deleting PersistentVolumeClaim %s/%s", namespace, name)
+	if err := d.ClientSet.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting PersistentVolumeClaim %s/%s: %w", namespace, name, err)
+	}
+
+	pods, err := d.ClientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing pods in namespace %q: %w", namespace, err)
+	}
+	for _, pod := range pods.Items {
+		for _, v := range pod.Spec.Volumes {
+			if v.PersistentVolumeClaim == nil || v.PersistentVolumeClaim.ClaimName != name {
+				continue
+			}
+			logger.Debug("deleting pod %s/%s using PersistentVolumeClaim %s", namespace, pod.Name, name)
+			if err := d.ClientSet.CoreV1().Pods(namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("deleting pod %s/%s: %w", namespace, pod.Name, err)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func isAWSVolume(pv corev1.PersistentVolume) bool {
+	if pv.Spec.AWSElasticBlockStore != nil {
+		return true
+	}
+	return pv.Spec.CSI != nil && (pv.Spec.CSI.Driver == ebsCSIDriver || pv.Spec.CSI.Driver == efsCSIDriver)
+}
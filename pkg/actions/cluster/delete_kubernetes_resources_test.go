@@ -0,0 +1,120 @@
+package cluster_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+)
+
+var _ = Describe("VolumeDeleter", func() {
+	var (
+		clientSet *fake.Clientset
+		deleter   *cluster.VolumeDeleter
+	)
+
+	newPV := func(name, driver, claim string, reclaimPolicy corev1.PersistentVolumeReclaimPolicy, dynamic bool) *corev1.PersistentVolume {
+		pv := &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PersistentVolumeSpec{
+				PersistentVolumeSource: corev1.PersistentVolumeSource{
+					CSI: &corev1.CSIPersistentVolumeSource{Driver: driver, VolumeHandle: name},
+				},
+				ClaimRef:                      &corev1.ObjectReference{Namespace: "default", Name: claim},
+				PersistentVolumeReclaimPolicy: reclaimPolicy,
+			},
+		}
+		if dynamic {
+			pv.Annotations = map[string]string{"pv.kubernetes.io/provisioned-by": driver}
+		}
+		return pv
+	}
+	newPVC := func(name string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+
+	BeforeEach(func() {
+		clientSet = fake.NewSimpleClientset(
+			newPV("ebs-1", "ebs.csi.aws.com", "data-db-0", corev1.PersistentVolumeReclaimDelete, true),
+			newPV("efs-1", "efs.csi.aws.com", "shared", corev1.PersistentVolumeReclaimRetain, true),
+			newPV("ebs-static", "ebs.csi.aws.com", "static", corev1.PersistentVolumeReclaimRetain, false),
+			newPV("other-1", "other.csi.example.com", "other", corev1.PersistentVolumeReclaimDelete, true),
+			newPVC("data-db-0"), newPVC("shared"), newPVC("static"), newPVC("other"),
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db-0"},
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{{
+						Name: "data",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-db-0"},
+						},
+					}},
+				},
+			},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}},
+		)
+		deleter = &cluster.VolumeDeleter{
+			ClientSet:    clientSet,
+			PollInterval: time.Millisecond,
+		}
+	})
+
+	When("the CSI drivers delete the volumes", func() {
+		BeforeEach(func() {
+			// simulate the PV controller and the CSI driver deleting the volume once its claim is deleted.
+			clientSet.PrependReactor("delete", "persistentvolumeclaims", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				claim := action.(k8stesting.DeleteAction).GetName()
+				pvs, err := clientSet.Tracker().List(corev1.SchemeGroupVersion.WithResource("persistentvolumes"), corev1.SchemeGroupVersion.WithKind("PersistentVolume"), "")
+				Expect(err).NotTo(HaveOccurred())
+				for _, pv := range pvs.(*corev1.PersistentVolumeList).Items {
+					if pv.Spec.ClaimRef.Name == claim {
+						Expect(clientSet.Tracker().Delete(corev1.SchemeGroupVersion.WithResource("persistentvolumes"), "", pv.Name)).To(Succeed())
+					}
+				}
+				return false, nil, nil
+			})
+		})
+
+		It("deletes the claims of dynamically provisioned EBS and EFS volumes and the pods using them", func() {
+			Expect(deleter.Delete(context.Background())).To(Succeed())
+
+			claims, err := clientSet.CoreV1().PersistentVolumeClaims("default").List(context.Background(), metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			var claimNames []string
+			for _, c := range claims.Items {
+				claimNames = append(claimNames, c.Name)
+			}
+			Expect(claimNames).To(ConsistOf("shared", "static", "other"))
+
+			_, err = clientSet.CoreV1().Pods("default").Get(context.Background(), "db-0", metav1.GetOptions{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			_, err = clientSet.CoreV1().Pods("default").Get(context.Background(), "web", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the volumes are not deleted", func() {
+		It("leaves Retain volumes alone and times out", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			Expect(deleter.Delete(ctx)).To(MatchError(ContainSubstring("timed out waiting for persistent volumes to be deleted: ebs-1")))
+
+			for _, name := range []string{"efs-1", "ebs-static"} {
+				pv, err := clientSet.CoreV1().PersistentVolumes().Get(context.Background(), name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pv.Spec.PersistentVolumeReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimRetain))
+			}
+			_, err := clientSet.CoreV1().PersistentVolumeClaims("default").Get(context.Background(), "shared", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
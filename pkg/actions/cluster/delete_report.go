@@ -0,0 +1,223 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const ebsCSIDriver = "ebs.csi.aws.com"
+
+// DeletionReport lists the resources that are deleted along with a cluster, and the resources
+// that are not managed by eksctl and are left behind once the cluster is gone.
+type DeletionReport struct {
+	ClusterName string
+
+	Stacks             []string
+	NodeGroups         []string
+	Addons             []string
+	IAMServiceAccounts []string
+	OIDCProviderARN    string
+
+	LogGroups            []string
+	LoadBalancerServices []string
+	EBSPersistentVolumes []string
+}
+
+// DeletionReporter builds a DeletionReport for a cluster.
+type DeletionReporter struct {
+	ClusterConfig  *api.ClusterConfig
+	EKS            awsapi.EKS
+	CloudWatchLogs awsapi.CloudWatchLogs
+	StackManager   manager.StackManager
+	NewOIDCManager func() (*iamoidc.OpenIDConnectManager, error)
+	// ClusterExists is false when only the stacks of the cluster remain, e.g. after the cluster failed to create.
+	ClusterExists bool
+	// ClientSet is used to find Kubernetes resources that depend on AWS resources,
+	// it is nil when the cluster is not operable.
+	ClientSet kubernetes.Interface
+}
+
+// Report lists the resources that deleting the cluster destroys and leaves behind.
+func (r *DeletionReporter) Report(ctx context.Context) (*DeletionReport, error) {
+	clusterName := r.ClusterConfig.Metadata.Name
+	report := &DeletionReport{ClusterName: clusterName}
+
+	stacks, err := r.StackManager.ListStacks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing stacks: %w", err)
+	}
+	for _, s := range stacks {
+		report.Stacks = append(report.Stacks, *s.StackName)
+	}
+
+	nodeGroupStacks, err := r.StackManager.ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing nodegroup stacks: %w", err)
+	}
+	nodeGroups := map[string]struct{}{}
+	for _, s := range nodeGroupStacks {
+		nodeGroups[s.NodeGroupName] = struct{}{}
+	}
+	if r.ClusterExists {
+		nodeGroupsPaginator := awseks.NewListNodegroupsPaginator(r.EKS, &awseks.ListNodegroupsInput{
+			ClusterName: aws.String(clusterName),
+		})
+		for nodeGroupsPaginator.HasMorePages() {
+			out, err := nodeGroupsPaginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("listing nodegroups: %w", err)
+			}
+			for _, ng := range out.Nodegroups {
+				nodeGroups[ng] = struct{}{}
+			}
+		}
+
+		addonsPaginator := awseks.NewListAddonsPaginator(r.EKS, &awseks.ListAddonsInput{
+			ClusterName: aws.String(clusterName),
+		})
+		for addonsPaginator.HasMorePages() {
+			out, err := addonsPaginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("listing addons: %w", err)
+			}
+			report.Addons = append(report.Addons, out.Addons...)
+		}
+	}
+	for ng := range nodeGroups {
+		report.NodeGroups = append(report.NodeGroups, ng)
+	}
+	sort.Strings(report.NodeGroups)
+
+	if report.IAMServiceAccounts, err = r.StackManager.ListIAMServiceAccountStacks(ctx); err != nil {
+		return nil, fmt.Errorf("listing iamserviceaccount stacks: %w", err)
+	}
+
+	if r.ClusterExists && r.NewOIDCManager != nil {
+		if oidc, err := r.NewOIDCManager(); err != nil {
+			logger.Debug("failed to get OIDC manager: %v", err)
+		} else if exists, err := oidc.CheckProviderExists(ctx); err != nil {
+			return nil, fmt.Errorf("checking OIDC provider: %w", err)
+		} else if exists {
+			report.OIDCProviderARN = oidc.ProviderARN
+		}
+	}
+
+	logGroupsPaginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(r.CloudWatchLogs, &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(fmt.Sprintf("/aws/eks/%s/", clusterName)),
+	})
+	for logGroupsPaginator.HasMorePages() {
+		out, err := logGroupsPaginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing log groups: %w", err)
+		}
+		for _, lg := range out.LogGroups {
+			report.LogGroups = append(report.LogGroups, *lg.LogGroupName)
+		}
+	}
+
+	if r.ClientSet != nil {
+		if err := r.addKubernetesDependents(ctx, report); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+func newDeletionReporter(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider, stackManager manager.StackManager, newClientSet func() (kubernetes.Interface, error)) *DeletionReporter {
+	reporter := &DeletionReporter{
+		ClusterConfig:  cfg,
+		EKS:            ctl.AWSProvider.EKS(),
+		CloudWatchLogs: ctl.AWSProvider.CloudWatchLogs(),
+		StackManager:   stackManager,
+		NewOIDCManager: func() (*iamoidc.OpenIDConnectManager, error) {
+			return ctl.NewOpenIDConnectManager(ctx, cfg)
+		},
+		ClusterExists: ctl.Status != nil && ctl.Status.ClusterInfo != nil,
+	}
+
+	if clusterOperable, err := ctl.CanOperate(cfg); err != nil {
+		logger.Debug("failed to check if cluster is operable: %v", err)
+	} else if clusterOperable {
+		clientSet, err := newClientSet()
+		if err != nil {
+			logger.Warning("unable to list Kubernetes resources that depend on AWS resources: %v", err)
+		} else {
+			reporter.ClientSet = clientSet
+		}
+	}
+	return reporter
+}
+
+func (r *DeletionReporter) addKubernetesDependents(ctx context.Context, report *DeletionReport) error {
+	services, err := r.ClientSet.CoreV1().Services(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing services: %w", err)
+	}
+	for _, svc := range services.Items {
+		if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			report.LoadBalancerServices = append(report.LoadBalancerServices, fmt.Sprintf("%s/%s", svc.Namespace, svc.Name))
+		}
+	}
+
+	pvs, err := r.ClientSet.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing persistent volumes: %w", err)
+	}
+	for _, pv := range pvs.Items {
+		var volumeID string
+		switch {
+		case pv.Spec.CSI != nil && pv.Spec.CSI.Driver == ebsCSIDriver:
+			volumeID = pv.Spec.CSI.VolumeHandle
+		case pv.Spec.AWSElasticBlockStore != nil:
+			volumeID = pv.Spec.AWSElasticBlockStore.VolumeID
+		default:
+			continue
+		}
+		report.EBSPersistentVolumes = append(report.EBSPersistentVolumes, fmt.Sprintf("%s (%s)", pv.Name, volumeID))
+	}
+	return nil
+}
+
+// Log logs the report.
+func (r *DeletionReport) Log() {
+	logger.Info("deleting cluster %q will delete the following resources:", r.ClusterName)
+	logResources("CloudFormation stack(s)", r.Stacks)
+	logResources("nodegroup(s)", r.NodeGroups)
+	logResources("addon(s)", r.Addons)
+	logResources("IAM role(s) for service accounts", r.IAMServiceAccounts)
+	if r.OIDCProviderARN != "" {
+		logResources("OIDC provider", []string{r.OIDCProviderARN})
+	}
+
+	if len(r.LogGroups) == 0 && len(r.LoadBalancerServices) == 0 && len(r.EBSPersistentVolumes) == 0 {
+		return
+	}
+	logger.Warning("the following resources are not managed by eksctl and may be left behind:")
+	logResources("CloudWatch log group(s), which are not deleted", r.LogGroups)
+	logResources("Service(s) of type LoadBalancer, whose load balancers are cleaned up on a best-effort basis", r.LoadBalancerServices)
+	logResources("EBS-backed PersistentVolume(s), whose volumes are not deleted", r.EBSPersistentVolumes)
+}
+
+func logResources(kind string, resources []string) {
+	if len(resources) == 0 {
+		return
+	}
+	logger.Info("  - %d %s: %s", len(resources), kind, strings.Join(resources, ", "))
+}
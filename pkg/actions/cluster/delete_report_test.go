@@ -0,0 +1,126 @@
+package cluster_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("DeletionReporter", func() {
+	var (
+		p                *mockprovider.MockProvider
+		cfg              *api.ClusterConfig
+		fakeStackManager *fakes.FakeStackManager
+		reporter         *cluster.DeletionReporter
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		fakeStackManager = new(fakes.FakeStackManager)
+
+		fakeStackManager.ListStacksReturns([]*manager.Stack{
+			{StackName: aws.String("eksctl-my-cluster-cluster")},
+			{StackName: aws.String("eksctl-my-cluster-nodegroup-ng-1")},
+		}, nil)
+		fakeStackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+			{NodeGroupName: "ng-1", Type: api.NodeGroupTypeUnmanaged},
+		}, nil)
+		fakeStackManager.ListIAMServiceAccountStacksReturns([]string{"kube-system/aws-load-balancer-controller"}, nil)
+
+		p.MockCloudWatchLogs().On("DescribeLogGroups", mock.Anything, &cloudwatchlogs.DescribeLogGroupsInput{
+			LogGroupNamePrefix: aws.String("/aws/eks/my-cluster/"),
+		}, mock.Anything).Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+			LogGroups: []cwltypes.LogGroup{{LogGroupName: aws.String("/aws/eks/my-cluster/cluster")}},
+		}, nil)
+
+		reporter = &cluster.DeletionReporter{
+			ClusterConfig:  cfg,
+			EKS:            p.EKS(),
+			CloudWatchLogs: p.CloudWatchLogs(),
+			StackManager:   fakeStackManager,
+		}
+	})
+
+	When("the cluster exists and is operable", func() {
+		BeforeEach(func() {
+			p.MockEKS().On("ListNodegroups", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListNodegroupsOutput{
+				Nodegroups: []string{"mng-1", "ng-1"},
+			}, nil)
+			p.MockEKS().On("ListAddons", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListAddonsOutput{
+				Addons: []string{"vpc-cni"},
+			}, nil)
+
+			reporter.ClusterExists = true
+			reporter.ClientSet = fake.NewSimpleClientset(
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "default"},
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+				},
+				&corev1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+					Spec: corev1.PersistentVolumeSpec{
+						PersistentVolumeSource: corev1.PersistentVolumeSource{
+							CSI: &corev1.CSIPersistentVolumeSource{Driver: "ebs.csi.aws.com", VolumeHandle: "vol-1"},
+						},
+					},
+				},
+				&corev1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: "pvc-2"},
+					Spec: corev1.PersistentVolumeSpec{
+						PersistentVolumeSource: corev1.PersistentVolumeSource{
+							CSI: &corev1.CSIPersistentVolumeSource{Driver: "efs.csi.aws.com", VolumeHandle: "fs-1"},
+						},
+					},
+				},
+			)
+		})
+
+		It("reports the resources that will be deleted and the ones left behind", func() {
+			report, err := reporter.Report(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*report).To(Equal(cluster.DeletionReport{
+				ClusterName:          "my-cluster",
+				Stacks:               []string{"eksctl-my-cluster-cluster", "eksctl-my-cluster-nodegroup-ng-1"},
+				NodeGroups:           []string{"mng-1", "ng-1"},
+				Addons:               []string{"vpc-cni"},
+				IAMServiceAccounts:   []string{"kube-system/aws-load-balancer-controller"},
+				LogGroups:            []string{"/aws/eks/my-cluster/cluster"},
+				LoadBalancerServices: []string{"default/web"},
+				EBSPersistentVolumes: []string{"pvc-1 (vol-1)"},
+			}))
+		})
+	})
+
+	When("only the stacks of the cluster exist", func() {
+		It("does not list EKS or Kubernetes resources", func() {
+			report, err := reporter.Report(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.NodeGroups).To(Equal([]string{"ng-1"}))
+			Expect(report.Addons).To(BeEmpty())
+			Expect(report.LoadBalancerServices).To(BeEmpty())
+			p.MockEKS().AssertNotCalled(GinkgoT(), "ListNodegroups", mock.Anything, mock.Anything, mock.Anything)
+		})
+	})
+})
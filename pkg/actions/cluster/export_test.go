@@ -1,6 +1,9 @@
 package cluster
 
 import (
+	"context"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
 )
 
@@ -31,3 +34,11 @@ func SetProviderConstructor(f ProviderConstructor) {
 func SetStackManagerConstructor(f StackManagerConstructor) {
 	newStackCollection = f
 }
+
+func SetOrganizationAccountsLister(f func(ctx context.Context, provider api.ClusterProvider) ([]string, error)) {
+	listOrganizationAccounts = f
+}
+
+func ListActiveAccounts(ctx context.Context, client OrganizationsAPI) ([]string, error) {
+	return listActiveAccounts(ctx, client)
+}
@@ -0,0 +1,93 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// GCOptions selects the clusters that are eligible for garbage collection;
+// a cluster must match all of the options that are set
+type GCOptions struct {
+	// Expired selects clusters whose expiry time, recorded in api.ClusterExpiresAtTag, has passed
+	Expired bool
+	// Selector selects clusters that have all of the given tags
+	Selector map[string]string
+	// OlderThan selects clusters that were created at least OlderThan ago
+	OlderThan time.Duration
+	// Now is the time that expiry and age are checked against
+	Now time.Time
+}
+
+// IsEmpty reports whether no selection criteria are set, in which case all clusters would be selected
+func (o GCOptions) IsEmpty() bool {
+	return !o.Expired && len(o.Selector) == 0 && o.OlderThan == 0
+}
+
+func (o GCOptions) matches(cluster *ekstypes.Cluster, expiresAt time.Time, hasExpiry bool) bool {
+	if o.Expired && (!hasExpiry || !expiresAt.Before(o.Now)) {
+		return false
+	}
+	for k, v := range o.Selector {
+		if value, ok := cluster.Tags[k]; !ok || value != v {
+			return false
+		}
+	}
+	if o.OlderThan > 0 && (cluster.CreatedAt == nil || cluster.CreatedAt.After(o.Now.Add(-o.OlderThan))) {
+		return false
+	}
+	return true
+}
+
+// GCCandidate is an eksctl-owned cluster selected for garbage collection
+type GCCandidate struct {
+	Name      string
+	Region    string
+	CreatedAt time.Time
+	// ExpiresAt is zero for clusters created without a TTL
+	ExpiresAt time.Time
+}
+
+// FindGCCandidates returns the eksctl-owned clusters in the provider's region that match options;
+// clusters that were not created by eksctl are never selected
+func FindGCCandidates(ctx context.Context, provider api.ClusterProvider, chunkSize int, options GCOptions) ([]GCCandidate, error) {
+	clusters, err := listClusters(ctx, provider, int32(chunkSize))
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []GCCandidate
+	for _, c := range clusters {
+		if c.Owned != eksctlCreatedTrue {
+			continue
+		}
+		output, err := provider.EKS().DescribeCluster(ctx, &awseks.DescribeClusterInput{
+			Name: &c.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe cluster %q: %w", c.Name, err)
+		}
+
+		expiresAt, hasExpiry, err := api.ClusterExpiry(output.Cluster.Tags)
+		if err != nil {
+			logger.Warning("ignoring expiry of cluster %q: %v", c.Name, err)
+		}
+		if !options.matches(output.Cluster, expiresAt, hasExpiry) {
+			continue
+		}
+		candidates = append(candidates, GCCandidate{
+			Name:      c.Name,
+			Region:    c.Region,
+			CreatedAt: aws.ToTime(output.Cluster.CreatedAt),
+			ExpiresAt: expiresAt,
+		})
+	}
+	return candidates, nil
+}
@@ -0,0 +1,169 @@
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	"github.com/weaveworks/eksctl/pkg/actions/cluster/fakes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	mgrfakes "github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("GC", func() {
+	DescribeTable("GCOptions.IsEmpty", func(options cluster.GCOptions, expected bool) {
+		Expect(options.IsEmpty()).To(Equal(expected))
+	},
+		Entry("no options", cluster.GCOptions{Now: time.Now()}, true),
+		Entry("expired", cluster.GCOptions{Expired: true}, false),
+		Entry("selector", cluster.GCOptions{Selector: map[string]string{"purpose": "ci"}}, false),
+		Entry("older than", cluster.GCOptions{OlderThan: time.Hour}, false),
+	)
+
+	var (
+		provider     *mockprovider.MockProvider
+		stackManager *mgrfakes.FakeStackManager
+		now          time.Time
+	)
+
+	mockDescribeCluster := func(name string, tags map[string]string) {
+		provider.MockEKS().On("DescribeCluster", mock.Anything, mock.MatchedBy(func(input *awseks.DescribeClusterInput) bool {
+			return *input.Name == name
+		})).Return(&awseks.DescribeClusterOutput{
+			Cluster: &ekstypes.Cluster{
+				Name: aws.String(name),
+				Tags: tags,
+			},
+		}, nil)
+	}
+
+	BeforeEach(func() {
+		now = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		provider = mockprovider.NewMockProvider()
+		provider.SetRegion("us-west-2")
+
+		stackCollectionProvider := new(fakes.FakeStackManagerConstructor)
+		stackManager = new(mgrfakes.FakeStackManager)
+		stackCollectionProvider.Returns(stackManager)
+		cluster.SetStackManagerConstructor(stackCollectionProvider.Spy)
+
+		provider.MockEKS().On("ListClusters", mock.Anything, &awseks.ListClustersInput{
+			MaxResults: aws.Int32(100),
+			Include:    []string{"all"},
+		}, mock.Anything).Return(&awseks.ListClustersOutput{
+			Clusters: []string{"expired", "not-expired", "no-ttl", "unowned"},
+		}, nil)
+
+		stackManager.ListClusterStackNamesReturns(nil, nil)
+		stackManager.HasClusterStackFromListStub = func(_ context.Context, _ []string, clusterName string) (bool, error) {
+			return clusterName != "unowned", nil
+		}
+	})
+
+	It("returns only eksctl-owned clusters that are past their expiry", func() {
+		mockDescribeCluster("expired", map[string]string{api.ClusterExpiresAtTag: "2024-05-01T09:00:00Z"})
+		mockDescribeCluster("not-expired", map[string]string{api.ClusterExpiresAtTag: "2024-05-01T11:00:00Z"})
+		mockDescribeCluster("no-ttl", nil)
+
+		candidates, err := cluster.FindGCCandidates(context.Background(), provider, 100, cluster.GCOptions{
+			Expired: true,
+			Now:     now,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(candidates).To(ConsistOf(cluster.GCCandidate{
+			Name:      "expired",
+			Region:    "us-west-2",
+			ExpiresAt: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+		}))
+		provider.MockEKS().AssertNotCalled(GinkgoT(), "DescribeCluster", mock.Anything, mock.MatchedBy(func(input *awseks.DescribeClusterInput) bool {
+			return *input.Name == "unowned"
+		}))
+	})
+
+	It("selects clusters by tags and age", func() {
+		describe := func(name string, tags map[string]string, createdAt time.Time) {
+			provider.MockEKS().On("DescribeCluster", mock.Anything, mock.MatchedBy(func(input *awseks.DescribeClusterInput) bool {
+				return *input.Name == name
+			})).Return(&awseks.DescribeClusterOutput{
+				Cluster: &ekstypes.Cluster{
+					Name:      aws.String(name),
+					Tags:      tags,
+					CreatedAt: aws.Time(createdAt),
+				},
+			}, nil)
+		}
+		describe("expired", map[string]string{"purpose": "ci"}, now.Add(-48*time.Hour))
+		describe("not-expired", map[string]string{"purpose": "ci"}, now.Add(-time.Hour))
+		describe("no-ttl", map[string]string{"purpose": "prod"}, now.Add(-48*time.Hour))
+
+		candidates, err := cluster.FindGCCandidates(context.Background(), provider, 100, cluster.GCOptions{
+			Selector:  map[string]string{"purpose": "ci"},
+			OlderThan: 24 * time.Hour,
+			Now:       now,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(candidates).To(ConsistOf(cluster.GCCandidate{
+			Name:      "expired",
+			Region:    "us-west-2",
+			CreatedAt: now.Add(-48 * time.Hour),
+		}))
+	})
+
+	It("skips clusters with an invalid expiry tag", func() {
+		mockDescribeCluster("expired", map[string]string{api.ClusterExpiresAtTag: "yesterday"})
+		mockDescribeCluster("not-expired", nil)
+		mockDescribeCluster("no-ttl", nil)
+
+		candidates, err := cluster.FindGCCandidates(context.Background(), provider, 100, cluster.GCOptions{
+			Expired: true,
+			Now:     now,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(candidates).To(BeEmpty())
+	})
+
+	It("ignores an invalid expiry tag unless --expired is set", func() {
+		tags := map[string]string{api.ClusterExpiresAtTag: "yesterday", "purpose": "ci"}
+		mockDescribeCluster("expired", tags)
+		mockDescribeCluster("not-expired", nil)
+		mockDescribeCluster("no-ttl", nil)
+
+		candidates, err := cluster.FindGCCandidates(context.Background(), provider, 100, cluster.GCOptions{
+			Selector: map[string]string{"purpose": "ci"},
+			Now:      now,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(candidates).To(ConsistOf(cluster.GCCandidate{
+			Name:   "expired",
+			Region: "us-west-2",
+		}))
+
+		candidates, err = cluster.FindGCCandidates(context.Background(), provider, 100, cluster.GCOptions{
+			Expired:  true,
+			Selector: map[string]string{"purpose": "ci"},
+			Now:      now,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(candidates).To(BeEmpty())
+	})
+
+	It("errors when a cluster cannot be described", func() {
+		provider.MockEKS().On("DescribeCluster", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("foo"))
+
+		_, err := cluster.FindGCCandidates(context.Background(), provider, 100, cluster.GCOptions{
+			Expired: true,
+			Now:     now,
+		})
+		Expect(err).To(MatchError(`failed to describe cluster "expired": foo`))
+	})
+})
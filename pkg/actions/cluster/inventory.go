@@ -0,0 +1,216 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// Support statuses of the Kubernetes version of a cluster
+const (
+	SupportStatusStandard = "standard"
+	SupportStatusExtended = "extended"
+	SupportStatusEnded    = "ended"
+	SupportStatusUnknown  = "unknown"
+)
+
+// extendedSupportMonths is how long EKS supports a Kubernetes version after the end of its standard support
+const extendedSupportMonths = 12
+
+// endOfStandardSupport are the dates EKS ends the standard support of Kubernetes versions, see
+// https://docs.aws.amazon.com/eks/latest/userguide/kubernetes-versions.html
+var endOfStandardSupport = map[string]time.Time{
+	api.Version1_23: time.Date(2023, time.October, 11, 0, 0, 0, 0, time.UTC),
+	api.Version1_24: time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC),
+	api.Version1_25: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC),
+	api.Version1_26: time.Date(2024, time.June, 11, 0, 0, 0, 0, time.UTC),
+	api.Version1_27: time.Date(2024, time.July, 24, 0, 0, 0, 0, time.UTC),
+	api.Version1_28: time.Date(2024, time.November, 26, 0, 0, 0, 0, time.UTC),
+	api.Version1_29: time.Date(2025, time.March, 23, 0, 0, 0, 0, time.UTC),
+	api.Version1_30: time.Date(2025, time.July, 23, 0, 0, 0, 0, time.UTC),
+	api.Version1_31: time.Date(2025, time.November, 26, 0, 0, 0, 0, time.UTC),
+	api.Version1_32: time.Date(2026, time.March, 23, 0, 0, 0, 0, time.UTC),
+}
+
+// SupportStatus returns whether the Kubernetes version is in standard or extended support by EKS at the given time
+func SupportStatus(version string, now time.Time) string {
+	if api.IsDeprecatedVersion(version) {
+		return SupportStatusEnded
+	}
+	end, ok := endOfStandardSupport[version]
+	switch {
+	case !ok:
+		return SupportStatusUnknown
+	case now.Before(end):
+		return SupportStatusStandard
+	case now.Before(end.AddDate(0, extendedSupportMonths, 0)):
+		return SupportStatusExtended
+	default:
+		return SupportStatusEnded
+	}
+}
+
+// InventoryOptions select the accounts and regions whose clusters are inventoried
+type InventoryOptions struct {
+	// RolePerAccount is the name of the IAM role assumed in each account, e.g. OrganizationAccountAccessRole;
+	// only the account of the current credentials is inventoried when it is empty
+	RolePerAccount string
+	// Accounts are the IDs of the accounts to inventory with RolePerAccount, defaulting to the active accounts
+	// of the organization of the current credentials
+	Accounts   []string
+	AllRegions bool
+	ChunkSize  int
+}
+
+// InventoryEntry describes a cluster of a fleet inventory; Name, Region and Owned are serialized under the same keys
+// as Description, so that the JSON and YAML output of get clusters is unchanged for existing fields
+type InventoryEntry struct {
+	Account       string `json:",omitempty"`
+	Region        string
+	Name          string
+	Version       string `json:",omitempty"`
+	Status        string `json:",omitempty"`
+	SupportStatus string `json:",omitempty"`
+	NodeGroups    int
+	Owned         api.EKSCTLCreated
+	// Error is set when the cluster could not be described
+	Error string `json:",omitempty"`
+}
+
+// GetInventory describes the clusters of the accounts and regions selected by options, with their Kubernetes
+// version, its support status and their number of nodegroups. Accounts and regions that cannot be accessed are
+// logged and skipped, so that a single misconfigured account does not fail a fleet audit.
+func GetInventory(ctx context.Context, provider api.ClusterProvider, options InventoryOptions) ([]InventoryEntry, error) {
+	if options.RolePerAccount == "" {
+		return inventoryAccount(ctx, provider, "", options)
+	}
+
+	accounts := options.Accounts
+	if len(accounts) == 0 {
+		var err error
+		if accounts, err = listOrganizationAccounts(ctx, provider); err != nil {
+			return nil, fmt.Errorf("listing the accounts of the organization, set the accounts to inventory explicitly if the credentials cannot list them: %w", err)
+		}
+	}
+	var inventory []InventoryEntry
+	for _, account := range accounts {
+		entries, err := inventoryAccount(ctx, provider, account, options)
+		if err != nil {
+			logger.Critical("error listing clusters in account %s: %v", account, err)
+			continue
+		}
+		inventory = append(inventory, entries...)
+	}
+	return inventory, nil
+}
+
+func inventoryAccount(ctx context.Context, provider api.ClusterProvider, account string, options InventoryOptions) ([]InventoryEntry, error) {
+	providerConfig := api.ProviderConfig{
+		Region:      provider.Region(),
+		Profile:     provider.Profile(),
+		WaitTimeout: provider.WaitTimeout(),
+	}
+	accountProvider := provider
+	if account != "" {
+		providerConfig.AssumeRole.RoleARN = fmt.Sprintf("arn:%s:iam::%s:role/%s", api.Partitions.ForRegion(provider.Region()), account, options.RolePerAccount)
+		ctl, err := newClusterProvider(ctx, &providerConfig, nil)
+		if err != nil {
+			return nil, fmt.Errorf("assuming role %s: %w", providerConfig.AssumeRole.RoleARN, err)
+		}
+		accountProvider = ctl.AWSProvider
+	}
+
+	regionalProviders := []api.ClusterProvider{accountProvider}
+	if options.AllRegions {
+		output, err := accountProvider.EC2().DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe regions: %w", err)
+		}
+		authorizedRegions := map[string]struct{}{}
+		for _, r := range output.Regions {
+			authorizedRegions[aws.ToString(r.RegionName)] = struct{}{}
+		}
+		regionalProviders = nil
+		for _, region := range api.SupportedRegions() {
+			if _, authorized := authorizedRegions[region]; !authorized {
+				continue
+			}
+			regionalConfig := providerConfig
+			regionalConfig.Region = region
+			ctl, err := newClusterProvider(ctx, &regionalConfig, nil)
+			if err != nil {
+				logger.Critical("error creating provider in %q region: %v", region, err)
+				continue
+			}
+			regionalProviders = append(regionalProviders, ctl.AWSProvider)
+		}
+	}
+
+	var inventory []InventoryEntry
+	for _, regionalProvider := range regionalProviders {
+		clusters, err := listClusters(ctx, regionalProvider, int32(options.ChunkSize))
+		if err != nil {
+			if !options.AllRegions && account == "" {
+				return nil, err
+			}
+			logger.Critical("error listing clusters in %q region: %v", regionalProvider.Region(), err)
+			continue
+		}
+		for _, c := range clusters {
+			inventory = append(inventory, describeInventoryEntry(ctx, regionalProvider, account, c))
+		}
+	}
+	return inventory, nil
+}
+
+func describeInventoryEntry(ctx context.Context, provider api.ClusterProvider, account string, c Description) InventoryEntry {
+	entry := InventoryEntry{
+		Account: account,
+		Region:  c.Region,
+		Name:    c.Name,
+		Owned:   c.Owned,
+	}
+	output, err := provider.EKS().DescribeCluster(ctx, &awseks.DescribeClusterInput{
+		Name: aws.String(c.Name),
+	})
+	if err != nil {
+		entry.Error = fmt.Sprintf("describing cluster: %v", err)
+		return entry
+	}
+	entry.Version = aws.ToString(output.Cluster.Version)
+	entry.Status = string(output.Cluster.Status)
+	entry.SupportStatus = SupportStatus(entry.Version, time.Now())
+
+	paginator := awseks.NewListNodegroupsPaginator(provider.EKS(), &awseks.ListNodegroupsInput{
+		ClusterName: aws.String(c.Name),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			entry.Error = fmt.Sprintf("listing nodegroups: %v", err)
+			return entry
+		}
+		entry.NodeGroups += len(page.Nodegroups)
+	}
+	if c.Owned != eksctlCreatedTrue {
+		return entry
+	}
+	stacks, err := newStackCollection(provider, &api.ClusterConfig{Metadata: &api.ClusterMeta{Name: c.Name}}).ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		entry.Error = fmt.Sprintf("listing nodegroup stacks: %v", err)
+		return entry
+	}
+	for _, s := range stacks {
+		if s.Type == api.NodeGroupTypeUnmanaged {
+			entry.NodeGroups++
+		}
+	}
+	return entry
+}
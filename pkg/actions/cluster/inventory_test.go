@@ -0,0 +1,174 @@
+package cluster_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	"github.com/weaveworks/eksctl/pkg/actions/cluster/fakes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	mgrfakes "github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+type fakeOrganizations struct {
+	pages  []*organizations.ListAccountsOutput
+	tokens []*string
+}
+
+func (f *fakeOrganizations) ListAccounts(_ context.Context, params *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+	f.tokens = append(f.tokens, params.NextToken)
+	return f.pages[len(f.tokens)-1], nil
+}
+
+var _ = Describe("Inventory", func() {
+	DescribeTable("SupportStatus", func(version string, now time.Time, expected string) {
+		Expect(cluster.SupportStatus(version, now)).To(Equal(expected))
+	},
+		Entry("standard support", api.Version1_30, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), cluster.SupportStatusStandard),
+		Entry("extended support", api.Version1_27, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), cluster.SupportStatusExtended),
+		Entry("end of extended support", api.Version1_23, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), cluster.SupportStatusEnded),
+		Entry("deprecated version", api.Version1_22, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), cluster.SupportStatusEnded),
+		Entry("unknown version", "1.99", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), cluster.SupportStatusUnknown),
+		Entry("twelve months of extended support", api.Version1_27, time.Date(2025, time.July, 23, 0, 0, 0, 0, time.UTC), cluster.SupportStatusExtended),
+		Entry("end of twelve months of extended support", api.Version1_27, time.Date(2025, time.July, 24, 0, 0, 0, 0, time.UTC), cluster.SupportStatusEnded),
+	)
+
+	It("knows the end of standard support of every supported version", func() {
+		for _, version := range append(api.SupportedVersions(), api.Version1_32) {
+			Expect(cluster.SupportStatus(version, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))).NotTo(Equal(cluster.SupportStatusUnknown), version)
+		}
+	})
+
+	It("serializes the fields of cluster descriptions under the same keys", func() {
+		data, err := json.Marshal(cluster.InventoryEntry{Region: "us-west-2", Name: "cluster1", Owned: "True"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(MatchJSON(`{"Region": "us-west-2", "Name": "cluster1", "NodeGroups": 0, "Owned": "True"}`))
+	})
+
+	It("lists the active accounts of the organization", func() {
+		client := &fakeOrganizations{pages: []*organizations.ListAccountsOutput{
+			{
+				Accounts: []orgtypes.Account{
+					{Id: aws.String("111122223333"), Status: orgtypes.AccountStatusActive},
+					{Id: aws.String("444455556666"), Status: orgtypes.AccountStatusSuspended},
+				},
+				NextToken: aws.String("next"),
+			},
+			{
+				Accounts: []orgtypes.Account{{Id: aws.String("777788889999"), Status: orgtypes.AccountStatusActive}},
+			},
+		}}
+		accounts, err := cluster.ListActiveAccounts(context.Background(), client)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(accounts).To(Equal([]string{"111122223333", "777788889999"}))
+		Expect(client.tokens).To(Equal([]*string{nil, aws.String("next")}))
+	})
+
+	var (
+		awsProvider             *fakes.FakeProviderConstructor
+		stackCollectionProvider *fakes.FakeStackManagerConstructor
+		stackManager            *mgrfakes.FakeStackManager
+		initialProvider         *mockprovider.MockProvider
+		accountProvider         *mockprovider.MockProvider
+	)
+
+	BeforeEach(func() {
+		initialProvider = mockprovider.NewMockProvider()
+		initialProvider.SetRegion("us-west-2")
+		accountProvider = mockprovider.NewMockProvider()
+		accountProvider.SetRegion("us-west-2")
+		awsProvider = new(fakes.FakeProviderConstructor)
+		stackCollectionProvider = new(fakes.FakeStackManagerConstructor)
+		stackManager = new(mgrfakes.FakeStackManager)
+		stackCollectionProvider.Returns(stackManager)
+		cluster.SetProviderConstructor(awsProvider.Spy)
+		cluster.SetStackManagerConstructor(stackCollectionProvider.Spy)
+
+		accountProvider.MockEKS().On("ListClusters", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListClustersOutput{
+			Clusters: []string{"cluster1"},
+		}, nil)
+		accountProvider.MockEKS().On("DescribeCluster", mock.Anything, &awseks.DescribeClusterInput{Name: aws.String("cluster1")}).Return(&awseks.DescribeClusterOutput{
+			Cluster: &ekstypes.Cluster{
+				Name:    aws.String("cluster1"),
+				Version: aws.String(api.Version1_23),
+				Status:  ekstypes.ClusterStatusActive,
+			},
+		}, nil)
+		accountProvider.MockEKS().On("ListNodegroups", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListNodegroupsOutput{
+			Nodegroups: []string{"mng-1"},
+		}, nil)
+		stackManager.HasClusterStackFromListReturns(true, nil)
+		stackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+			{NodeGroupName: "mng-1", Type: api.NodeGroupTypeManaged},
+			{NodeGroupName: "ng-1", Type: api.NodeGroupTypeUnmanaged},
+		}, nil)
+	})
+
+	It("inventories the clusters of the accounts, skipping those whose role cannot be assumed", func() {
+		awsProvider.ReturnsOnCall(0, &eks.ClusterProvider{AWSProvider: accountProvider}, nil)
+		awsProvider.ReturnsOnCall(1, nil, fmt.Errorf("access denied"))
+
+		inventory, err := cluster.GetInventory(context.Background(), initialProvider, cluster.InventoryOptions{
+			RolePerAccount: "Audit",
+			Accounts:       []string{"111122223333", "444455556666"},
+			ChunkSize:      100,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inventory).To(Equal([]cluster.InventoryEntry{
+			{
+				Account:       "111122223333",
+				Region:        "us-west-2",
+				Name:          "cluster1",
+				Version:       api.Version1_23,
+				Status:        "ACTIVE",
+				SupportStatus: cluster.SupportStatusEnded,
+				NodeGroups:    2,
+				Owned:         "True",
+			},
+		}))
+
+		Expect(awsProvider.CallCount()).To(Equal(2))
+		_, spec, _ := awsProvider.ArgsForCall(0)
+		Expect(spec.AssumeRole.RoleARN).To(Equal("arn:aws:iam::111122223333:role/Audit"))
+		_, spec, _ = awsProvider.ArgsForCall(1)
+		Expect(spec.AssumeRole.RoleARN).To(Equal("arn:aws:iam::444455556666:role/Audit"))
+	})
+
+	It("inventories the accounts of the organization by default", func() {
+		cluster.SetOrganizationAccountsLister(func(_ context.Context, _ api.ClusterProvider) ([]string, error) {
+			return []string{"111122223333"}, nil
+		})
+		awsProvider.Returns(&eks.ClusterProvider{AWSProvider: accountProvider}, nil)
+
+		inventory, err := cluster.GetInventory(context.Background(), initialProvider, cluster.InventoryOptions{
+			RolePerAccount: "Audit",
+			ChunkSize:      100,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inventory).To(HaveLen(1))
+		Expect(inventory[0].Account).To(Equal("111122223333"))
+	})
+
+	It("inventories the account of the credentials without a role", func() {
+		inventory, err := cluster.GetInventory(context.Background(), accountProvider, cluster.InventoryOptions{ChunkSize: 100})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inventory).To(HaveLen(1))
+		Expect(inventory[0].Account).To(BeEmpty())
+		Expect(inventory[0].NodeGroups).To(Equal(2))
+		Expect(awsProvider.CallCount()).To(BeZero())
+	})
+})
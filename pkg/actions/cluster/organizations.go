@@ -0,0 +1,38 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// OrganizationsAPI is the subset of the AWS Organizations API used to list the accounts of an organization
+type OrganizationsAPI interface {
+	ListAccounts(ctx context.Context, params *organizations.ListAccountsInput, optFns ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error)
+}
+
+// listOrganizationAccounts returns the IDs of the active accounts of the organization of the credentials of provider
+var listOrganizationAccounts = func(ctx context.Context, provider api.ClusterProvider) ([]string, error) {
+	return listActiveAccounts(ctx, organizations.NewFromConfig(provider.AWSConfig()))
+}
+
+func listActiveAccounts(ctx context.Context, client OrganizationsAPI) ([]string, error) {
+	var accounts []string
+	paginator := organizations.NewListAccountsPaginator(client, &organizations.ListAccountsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range output.Accounts {
+			if a.Status == orgtypes.AccountStatusActive {
+				accounts = append(accounts, aws.ToString(a.Id))
+			}
+		}
+	}
+	return accounts, nil
+}
@@ -3,8 +3,10 @@ package cluster
 import (
 	"context"
 	"fmt"
-	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
 	"github.com/kris-nova/logger"
 
 	"github.com/weaveworks/eksctl/pkg/actions/addon"
@@ -69,7 +71,15 @@ func (c *OwnedCluster) Upgrade(ctx context.Context, dryRun bool) error {
 	return nil
 }
 
-func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int) error {
+func (c *OwnedCluster) DeletionReport(ctx context.Context) (*DeletionReport, error) {
+	return newDeletionReporter(ctx, c.cfg, c.ctl, c.stackManager, c.newClientSet).Report(ctx)
+}
+
+func (c *OwnedCluster) DeleteKubernetesResources(ctx context.Context, kinds []string) error {
+	return deleteKubernetesResources(ctx, c.cfg, c.ctl, c.newClientSet, kinds)
+}
+
+func (c *OwnedCluster) Delete(ctx context.Context, options DeleteOptions) error {
 	clusterOperable, err := c.ctl.CanOperate(c.cfg)
 	if err != nil {
 		logger.Debug("failed to check if cluster is operable: %v", err)
@@ -86,7 +96,7 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 		var err error
 		clientSet, err = c.newClientSet()
 		if err != nil {
-			if force {
+			if options.Force {
 				logger.Warning("error occurred during deletion: %v", err)
 			} else {
 				return err
@@ -94,10 +104,10 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 		}
 
 		drainer := c.newNodeGroupDrainer(clientSet)
-		if err := drainAllNodeGroups(ctx, c.cfg, c.ctl, clientSet, allStacks, disableNodegroupEviction, parallel, drainer, func(clusterConfig *api.ClusterConfig, ctl *eks.ClusterProvider, clientSet kubernetes.Interface) {
+		if err := drainAllNodeGroups(ctx, c.cfg, c.ctl, clientSet, allStacks, options.DisableNodegroupEviction, options.Parallel, drainer, func(clusterConfig *api.ClusterConfig, ctl *eks.ClusterProvider, clientSet kubernetes.Interface) {
 			attemptVpcCniDeletion(ctx, clusterConfig, ctl, clientSet)
-		}, podEvictionWaitPeriod); err != nil {
-			if !force {
+		}, options.PodEvictionWaitPeriod); err != nil {
+			if !options.Force {
 				return err
 			}
 
@@ -107,7 +117,7 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 
 	if err := deleteSharedResources(ctx, c.cfg, c.ctl, c.stackManager, clusterOperable, clientSet); err != nil {
 		if err != nil {
-			if force {
+			if options.Force {
 				logger.Warning("error occurred during deletion: %v", err)
 			} else {
 				return err
@@ -115,6 +125,10 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 		}
 	}
 
+	if err := c.deleteStacklessNodeGroups(ctx, allStacks, options.StackParallelism); err != nil {
+		return err
+	}
+
 	newOIDCManager := func() (*iamoidc.OpenIDConnectManager, error) {
 		return c.ctl.NewOpenIDConnectManager(ctx, c.cfg)
 	}
@@ -125,7 +139,7 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 		}
 		clientSet, err = c.newClientSet()
 		if err != nil {
-			if force {
+			if options.Force {
 				logger.Warning("error occurred while deleting IAM Role stacks for pod identity associations: %v; force=true so proceeding with cluster deletion", err)
 				return &tasks.TaskTree{}, nil
 			}
@@ -135,7 +149,16 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 			DeleteTasks(ctx, []podidentityassociation.Identifier{})
 	}
 
-	tasks, err := c.stackManager.NewTasksToDeleteClusterWithNodeGroups(ctx, c.clusterStack, allStacks, clusterOperable, newOIDCManager, newTasksToDeleteAddonIAM, newTasksToDeletePodIdentityRoles, c.ctl.Status.ClusterInfo.Cluster, kubernetes.NewCachedClientSet(clientSet), wait, force, func(errs chan error, _ string) error {
+	if options.ForceCleanup {
+		// the VPC is loaded before the cluster is deleted, as the cleanup needs it once the cluster stack failed to be deleted
+		if err := c.ctl.LoadClusterVPC(ctx, c.cfg, c.clusterStack); err != nil {
+			return fmt.Errorf("getting VPC configuration for cluster %q: %w", c.cfg.Metadata.Name, err)
+		}
+		// the deletion of the cluster stack has to be waited for to find out whether it failed
+		options.Wait = true
+	}
+
+	taskTree, err := c.stackManager.NewTasksToDeleteClusterWithNodeGroups(ctx, c.clusterStack, allStacks, clusterOperable, newOIDCManager, newTasksToDeleteAddonIAM, newTasksToDeletePodIdentityRoles, c.ctl.Status.ClusterInfo.Cluster, kubernetes.NewCachedClientSet(clientSet), options.Wait, options.Force, func(errs chan error, _ string) error {
 		logger.Info("trying to cleanup dangling network interfaces")
 		stack, err := c.stackManager.DescribeClusterStack(ctx)
 		if err != nil {
@@ -156,14 +179,20 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 		return err
 	}
 
-	if tasks.Len() == 0 {
+	if taskTree.Len() == 0 {
 		logger.Warning("no cluster resources were found for %q", c.cfg.Metadata.Name)
 		return nil
 	}
 
-	logger.Info(tasks.Describe())
-	if errs := tasks.DoAllSync(); len(errs) > 0 {
-		return handleErrors(errs, "cluster with nodegroup(s)")
+	taskTree.LimitParallelism(options.StackParallelism)
+	logger.Info(taskTree.Describe())
+	if errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{}); len(errs) > 0 {
+		if !options.ForceCleanup {
+			return handleErrors(errs, "cluster with nodegroup(s)")
+		}
+		if err := c.forceCleanupClusterStack(ctx, errs); err != nil {
+			return err
+		}
 	}
 
 	if err := c.deleteKarpenterStackIfExists(ctx); err != nil {
@@ -179,6 +208,65 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 	return nil
 }
 
+// deleteStacklessNodeGroups deletes the managed nodegroups created without CloudFormation stacks by an infra engine,
+// and the IAM roles created for them, as the cluster stack cannot be deleted while they exist
+func (c *OwnedCluster) deleteStacklessNodeGroups(ctx context.Context, allStacks []manager.NodeGroupStack, parallelism int) error {
+	output, err := c.ctl.AWSProvider.EKS().ListNodegroups(ctx, &awseks.ListNodegroupsInput{
+		ClusterName: aws.String(c.cfg.Metadata.Name),
+	})
+	if err != nil {
+		return fmt.Errorf("listing nodegroups of cluster %q: %w", c.cfg.Metadata.Name, err)
+	}
+
+	engines := nodegroup.NewStacklessEngines(c.cfg, c.ctl.AWSProvider)
+	taskTree := &tasks.TaskTree{
+		Parallel: true,
+		Limit:    parallelism,
+	}
+	for _, name := range output.Nodegroups {
+		if hasNodeGroupStack(allStacks, name) {
+			continue
+		}
+		engine, err := nodegroup.FindStacklessEngine(ctx, engines, name)
+		if err != nil {
+			return err
+		}
+		if engine != nil {
+			taskTree.Append(nodegroup.NewTaskToDeleteStacklessNodeGroup(ctx, engine, name, false))
+		}
+	}
+	if taskTree.Len() == 0 {
+		return nil
+	}
+	logger.Info(taskTree.Describe())
+	if errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{}); len(errs) > 0 {
+		return handleErrors(errs, "nodegroup(s)")
+	}
+	return nil
+}
+
+// forceCleanupClusterStack deletes the resources left behind in the VPC of the cluster by Kubernetes controllers
+// and deletes the cluster stack again, if its deletion failed; errs are the errors that occurred deleting the cluster
+func (c *OwnedCluster) forceCleanupClusterStack(ctx context.Context, errs []error) error {
+	stack, err := c.stackManager.DescribeClusterStackIfExists(ctx)
+	if err != nil {
+		return err
+	}
+	if stack == nil || stack.StackStatus != types.StackStatusDeleteFailed {
+		return handleErrors(errs, "cluster with nodegroup(s)")
+	}
+	logger.Warning("failed to delete cluster stack %q: %s", aws.ToString(stack.StackName), aws.ToString(stack.StackStatusReason))
+
+	if err := vpc.ForceCleanup(ctx, c.ctl.AWSProvider, c.cfg); err != nil {
+		return fmt.Errorf("cleaning up the VPC of cluster %q: %w", c.cfg.Metadata.Name, err)
+	}
+	logger.Info("deleting cluster stack %q again", aws.ToString(stack.StackName))
+	if err := c.stackManager.DeleteStackSync(ctx, stack); err != nil {
+		return fmt.Errorf("deleting cluster stack %q: %w", aws.ToString(stack.StackName), err)
+	}
+	return nil
+}
+
 func (c *OwnedCluster) deleteKarpenterStackIfExists(ctx context.Context) error {
 	stack, err := c.stackManager.GetKarpenterStack(ctx)
 	if err != nil {
@@ -8,6 +8,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -23,6 +25,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/eks"
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
+	"github.com/weaveworks/eksctl/pkg/sdkengine"
 	"github.com/weaveworks/eksctl/pkg/testutils"
 	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
 	"github.com/weaveworks/eksctl/pkg/utils/strings"
@@ -48,6 +51,7 @@ var _ = Describe("Delete", func() {
 		ranDeleteClusterTasks    bool
 		ctl                      *eks.ClusterProvider
 		fakeClientSet            *fake.Clientset
+		nodeGroupNames           []string
 	)
 
 	BeforeEach(func() {
@@ -63,6 +67,10 @@ var _ = Describe("Delete", func() {
 				Cluster: testutils.NewFakeCluster(clusterName, ekstypes.ClusterStatusActive),
 			},
 		}}
+		nodeGroupNames = nil
+		p.MockEKS().On("ListNodegroups", mock.Anything, mock.Anything).Return(func(context.Context, *awseks.ListNodegroupsInput, ...func(*awseks.Options)) (*awseks.ListNodegroupsOutput, error) {
+			return &awseks.ListNodegroupsOutput{Nodegroups: nodeGroupNames}, nil
+		})
 	})
 
 	Context("when the cluster is operable", func() {
@@ -124,7 +132,7 @@ var _ = Describe("Delete", func() {
 				return mockedDrainer
 			})
 
-			err := c.Delete(context.Background(), time.Microsecond, 0, false, false, false, 1)
+			err := c.Delete(context.Background(), cluster.DeleteOptions{WaitInterval: time.Microsecond, Parallel: 1})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeStackManager.DeleteTasksForDeprecatedStacksCallCount()).To(Equal(1))
 			Expect(ranDeleteDeprecatedTasks).To(BeTrue())
@@ -135,6 +143,66 @@ var _ = Describe("Delete", func() {
 			Expect(*stack.StackName).To(Equal("karpenter"))
 		})
 
+		It("deletes the managed nodegroups created by the sdk infra engine and their IAM roles", func() {
+			const roleName = "eksctl-my-cluster-mng-sdk-NodeRole"
+			nodeGroupNames = []string{"ng-1", "mng-sdk"}
+			fakeStackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{{NodeGroupName: "ng-1"}}, nil)
+
+			p.MockEKS().On("DescribeCluster", mock.Anything, mock.Anything).Return(&awseks.DescribeClusterOutput{
+				Cluster: testutils.NewFakeCluster(clusterName, ekstypes.ClusterStatusActive),
+			}, nil)
+			p.MockEKS().On("ListFargateProfiles", mock.Anything, mock.Anything).Return(&awseks.ListFargateProfilesOutput{}, nil)
+			p.MockEC2().On("DescribeKeyPairs", mock.Anything, mock.Anything).Return(&ec2.DescribeKeyPairsOutput{}, nil)
+			p.MockEC2().On("DescribeSecurityGroups", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+			fakeStackManager.DeleteTasksForDeprecatedStacksReturns(&tasks.TaskTree{}, nil)
+			fakeStackManager.NewTasksToDeleteClusterWithNodeGroupsReturns(&tasks.TaskTree{
+				Tasks: []tasks.Task{&tasks.GenericTask{Doer: func() error {
+					ranDeleteClusterTasks = true
+					return nil
+				}}},
+			}, nil)
+
+			p.MockEKS().On("DescribeNodegroup", mock.Anything, &awseks.DescribeNodegroupInput{
+				ClusterName:   aws.String(clusterName),
+				NodegroupName: aws.String("mng-sdk"),
+			}).Return(&awseks.DescribeNodegroupOutput{
+				Nodegroup: &ekstypes.Nodegroup{Tags: map[string]string{sdkengine.EngineTag: sdkengine.EngineName}},
+			}, nil)
+			p.MockEKS().On("DeleteNodegroup", mock.Anything, mock.Anything).Return(&awseks.DeleteNodegroupOutput{
+				Nodegroup: &ekstypes.Nodegroup{NodeRole: aws.String("arn:aws:iam::123456789012:role/" + roleName)},
+			}, nil)
+			p.MockEKS().On("DescribeNodegroup", mock.Anything, mock.Anything, mock.Anything).Return(nil, &ekstypes.ResourceNotFoundException{})
+			p.MockIAM().On("GetRole", mock.Anything, mock.Anything).Return(&iam.GetRoleOutput{
+				Role: &iamtypes.Role{
+					Tags: []iamtypes.Tag{
+						{Key: aws.String(sdkengine.EngineTag), Value: aws.String(sdkengine.EngineName)},
+						{Key: aws.String(api.NodeGroupNameTag), Value: aws.String("mng-sdk")},
+					},
+				},
+			}, nil)
+			p.MockIAM().On("ListAttachedRolePolicies", mock.Anything, mock.Anything, mock.Anything).Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+			p.MockIAM().On("DeleteRole", mock.Anything, mock.Anything).Return(&iam.DeleteRoleOutput{}, nil)
+
+			c := cluster.NewOwnedCluster(cfg, ctl, nil, fakeStackManager)
+			c.SetNewClientSet(func() (kubernetes.Interface, error) {
+				return fake.NewSimpleClientset(), nil
+			})
+			c.SetNewNodeGroupDrainer(func(clientSet kubernetes.Interface) cluster.NodeGroupDrainer {
+				mockedDrainer := &drainerMockOwned{}
+				mockedDrainer.On("Drain", mock.Anything).Return(nil)
+				return mockedDrainer
+			})
+
+			Expect(c.Delete(context.Background(), cluster.DeleteOptions{WaitInterval: time.Microsecond, Parallel: 1})).To(Succeed())
+			p.MockEKS().AssertCalled(GinkgoT(), "DeleteNodegroup", mock.Anything, &awseks.DeleteNodegroupInput{
+				ClusterName:   aws.String(clusterName),
+				NodegroupName: aws.String("mng-sdk"),
+			})
+			p.MockEKS().AssertNumberOfCalls(GinkgoT(), "DeleteNodegroup", 1)
+			p.MockIAM().AssertCalled(GinkgoT(), "DeleteRole", mock.Anything, &iam.DeleteRoleInput{RoleName: aws.String(roleName)})
+			Expect(ranDeleteClusterTasks).To(BeTrue())
+		})
+
 		When("force flag is set to true", func() {
 			It("ignoring nodes draining error", func() {
 				ctl.Status = &eks.ProviderStatus{
@@ -199,7 +267,7 @@ var _ = Describe("Delete", func() {
 					return mockedDrainer
 				})
 
-				err := c.Delete(context.Background(), time.Microsecond, 0, false, true, false, 1)
+				err := c.Delete(context.Background(), cluster.DeleteOptions{WaitInterval: time.Microsecond, Force: true, Parallel: 1})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeStackManager.DeleteTasksForDeprecatedStacksCallCount()).To(Equal(1))
 				Expect(ranDeleteDeprecatedTasks).To(BeFalse())
@@ -272,7 +340,7 @@ var _ = Describe("Delete", func() {
 					return mockedDrainer
 				})
 
-				err := c.Delete(context.Background(), time.Microsecond, time.Second*0, false, false, false, 1)
+				err := c.Delete(context.Background(), cluster.DeleteOptions{WaitInterval: time.Microsecond, Parallel: 1})
 				Expect(err).To(MatchError(errorMessage))
 				Expect(fakeStackManager.DeleteTasksForDeprecatedStacksCallCount()).To(Equal(0))
 				Expect(ranDeleteDeprecatedTasks).To(BeFalse())
@@ -325,7 +393,7 @@ var _ = Describe("Delete", func() {
 				return fake.NewSimpleClientset(), nil
 			})
 
-			err := c.Delete(context.Background(), time.Microsecond, time.Second*0, false, false, false, 1)
+			err := c.Delete(context.Background(), cluster.DeleteOptions{WaitInterval: time.Microsecond, Parallel: 1})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeStackManager.DeleteTasksForDeprecatedStacksCallCount()).To(Equal(1))
 			Expect(ranDeleteDeprecatedTasks).To(BeTrue())
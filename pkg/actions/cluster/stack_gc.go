@@ -0,0 +1,284 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/smithy-go"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+)
+
+// nodeGroupResourceName is the logical ID of the autoscaling group in the stack of an unmanaged nodegroup
+const nodeGroupResourceName = "NodeGroup"
+
+// OrphanedStack is an eksctl-owned CloudFormation stack whose cluster, or nodegroup, no longer exists
+type OrphanedStack struct {
+	Name        string
+	ID          string
+	ClusterName string
+	// NodeGroupName is empty for stacks that do not belong to a nodegroup
+	NodeGroupName string
+	Status        cfntypes.StackStatus
+	Reason        string
+	// TerminationProtected stacks cannot be deleted until termination protection is disabled
+	TerminationProtected bool
+}
+
+func (s OrphanedStack) isClusterStack() bool {
+	return s.Name == "eksctl-"+s.ClusterName+"-cluster"
+}
+
+// FindOrphanedStacks returns the eksctl-owned stacks in the provider's region whose cluster no longer exists,
+// or whose nodegroup was deleted without eksctl; stacks whose status is in progress are never selected
+func FindOrphanedStacks(ctx context.Context, provider api.ClusterProvider) ([]OrphanedStack, error) {
+	finder := &orphanFinder{
+		provider:   provider,
+		clusters:   map[string]bool{},
+		nodeGroups: map[string]map[string]bool{},
+	}
+
+	var orphans []OrphanedStack
+	paginator := cloudformation.NewDescribeStacksPaginator(provider.CloudFormation(), &cloudformation.DescribeStacksInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stacks in region %q: %w", provider.Region(), err)
+		}
+		for _, s := range output.Stacks {
+			clusterName := getClusterNameTag(s.Tags)
+			if clusterName == "" || strings.HasSuffix(string(s.StackStatus), "_IN_PROGRESS") {
+				continue
+			}
+			orphan, err := finder.check(ctx, s, clusterName)
+			if err != nil {
+				return nil, err
+			}
+			if orphan != nil {
+				orphans = append(orphans, *orphan)
+			}
+		}
+	}
+	sort.SliceStable(orphans, func(i, j int) bool {
+		if orphans[i].ClusterName != orphans[j].ClusterName {
+			return orphans[i].ClusterName < orphans[j].ClusterName
+		}
+		return orphans[i].Name < orphans[j].Name
+	})
+	return orphans, nil
+}
+
+// DeleteOrphanedStacks deletes orphans, skipping termination-protected stacks; the stacks of nodegroups and
+// addons are deleted before the cluster stacks, whose VPC they may depend on. The deletion of these stacks is
+// always waited for before their cluster stack is deleted, and a cluster stack is not deleted when they fail to be
+// deleted; wait only controls whether the deletion of the other stacks is waited for.
+func DeleteOrphanedStacks(ctx context.Context, provider api.ClusterProvider, orphans []OrphanedStack, wait bool) error {
+	var clusterStacks, otherStacks []OrphanedStack
+	deletingCluster := map[string]bool{}
+	for _, o := range orphans {
+		if o.TerminationProtected {
+			logger.Warning("skipping stack %q as termination protection is enabled", o.Name)
+			continue
+		}
+		if o.isClusterStack() {
+			clusterStacks = append(clusterStacks, o)
+			deletingCluster[o.ClusterName] = true
+		} else {
+			otherStacks = append(otherStacks, o)
+		}
+	}
+
+	var failed []string
+	failedClusters := map[string]bool{}
+	waiter := cloudformation.NewStackDeleteCompleteWaiter(provider.CloudFormation())
+	deleteStacks := func(stacks []OrphanedStack, mustWait func(OrphanedStack) bool) {
+		var deleted []OrphanedStack
+		for _, s := range stacks {
+			if failedClusters[s.ClusterName] && s.isClusterStack() {
+				failed = append(failed, fmt.Sprintf("not deleting stack %q as other stacks of cluster %q failed to be deleted", s.Name, s.ClusterName))
+				continue
+			}
+			if err := deleteStack(ctx, provider, s.ID); err != nil {
+				failed = append(failed, fmt.Sprintf("deleting stack %q: %v", s.Name, err))
+				failedClusters[s.ClusterName] = true
+				continue
+			}
+			logger.Info("deleting stack %q", s.Name)
+			deleted = append(deleted, s)
+		}
+		for _, s := range deleted {
+			if !mustWait(s) {
+				continue
+			}
+			if err := waiter.Wait(ctx, &cloudformation.DescribeStacksInput{StackName: aws.String(s.ID)}, provider.WaitTimeout()); err != nil {
+				failed = append(failed, fmt.Sprintf("waiting for stack %q to be deleted: %v", s.Name, err))
+				failedClusters[s.ClusterName] = true
+				continue
+			}
+			logger.Success("deleted stack %q", s.Name)
+		}
+	}
+	deleteStacks(otherStacks, func(s OrphanedStack) bool {
+		return wait || deletingCluster[s.ClusterName]
+	})
+	deleteStacks(clusterStacks, func(OrphanedStack) bool {
+		return wait
+	})
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to delete %d stack(s): %s", len(failed), strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func deleteStack(ctx context.Context, provider api.ClusterProvider, stackID string) error {
+	input := &cloudformation.DeleteStackInput{
+		StackName: aws.String(stackID),
+	}
+	if roleARN := provider.CloudFormationRoleARN(); roleARN != "" {
+		input.RoleARN = aws.String(roleARN)
+	}
+	_, err := provider.CloudFormation().DeleteStack(ctx, input)
+	return err
+}
+
+func getClusterNameTag(tags []cfntypes.Tag) string {
+	for _, tag := range tags {
+		switch aws.ToString(tag.Key) {
+		case api.ClusterNameTag, api.OldClusterNameTag:
+			return aws.ToString(tag.Value)
+		}
+	}
+	return ""
+}
+
+// orphanFinder caches the clusters and managed nodegroups that exist, as a cluster usually has several stacks
+type orphanFinder struct {
+	provider   api.ClusterProvider
+	clusters   map[string]bool
+	nodeGroups map[string]map[string]bool
+}
+
+func (f *orphanFinder) check(ctx context.Context, s cfntypes.Stack, clusterName string) (*OrphanedStack, error) {
+	orphan := &OrphanedStack{
+		Name:                 aws.ToString(s.StackName),
+		ID:                   aws.ToString(s.StackId),
+		ClusterName:          clusterName,
+		NodeGroupName:        manager.GetNodegroupTagName(s.Tags),
+		Status:               s.StackStatus,
+		TerminationProtected: aws.ToBool(s.EnableTerminationProtection),
+	}
+
+	exists, err := f.clusterExists(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		orphan.Reason = fmt.Sprintf("cluster %q does not exist", clusterName)
+		return orphan, nil
+	}
+	if orphan.NodeGroupName == "" {
+		return nil, nil
+	}
+
+	nodeGroupType, err := manager.GetNodeGroupType(s.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if nodeGroupType == api.NodeGroupTypeManaged {
+		exists, err = f.managedNodeGroupExists(ctx, clusterName, orphan.NodeGroupName)
+	} else {
+		exists, err = f.autoScalingGroupExists(ctx, orphan.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, nil
+	}
+	if nodeGroupType == api.NodeGroupTypeManaged {
+		orphan.Reason = fmt.Sprintf("managed nodegroup %q does not exist", orphan.NodeGroupName)
+	} else {
+		orphan.Reason = fmt.Sprintf("the autoscaling group of nodegroup %q does not exist", orphan.NodeGroupName)
+	}
+	return orphan, nil
+}
+
+func (f *orphanFinder) clusterExists(ctx context.Context, clusterName string) (bool, error) {
+	if exists, ok := f.clusters[clusterName]; ok {
+		return exists, nil
+	}
+	_, err := f.provider.EKS().DescribeCluster(ctx, &awseks.DescribeClusterInput{Name: aws.String(clusterName)})
+	if err != nil {
+		var notFound *ekstypes.ResourceNotFoundException
+		if !errors.As(err, &notFound) {
+			return false, fmt.Errorf("failed to describe cluster %q: %w", clusterName, err)
+		}
+	}
+	f.clusters[clusterName] = err == nil
+	return err == nil, nil
+}
+
+func (f *orphanFinder) managedNodeGroupExists(ctx context.Context, clusterName, nodeGroupName string) (bool, error) {
+	if nodeGroups, ok := f.nodeGroups[clusterName]; ok {
+		return nodeGroups[nodeGroupName], nil
+	}
+	nodeGroups := map[string]bool{}
+	paginator := awseks.NewListNodegroupsPaginator(f.provider.EKS(), &awseks.ListNodegroupsInput{ClusterName: aws.String(clusterName)})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to list nodegroups of cluster %q: %w", clusterName, err)
+		}
+		for _, name := range output.Nodegroups {
+			nodeGroups[name] = true
+		}
+	}
+	f.nodeGroups[clusterName] = nodeGroups
+	return nodeGroups[nodeGroupName], nil
+}
+
+func (f *orphanFinder) autoScalingGroupExists(ctx context.Context, stackName string) (bool, error) {
+	resource, err := f.provider.CloudFormation().DescribeStackResource(ctx, &cloudformation.DescribeStackResourceInput{
+		StackName:         aws.String(stackName),
+		LogicalResourceId: aws.String(nodeGroupResourceName),
+	})
+	if err != nil {
+		// a stack whose NodeGroup resource was removed, e.g. by a failed update, no longer manages a nodegroup
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ValidationError" && strings.Contains(apiErr.ErrorMessage(), "does not exist") {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to describe the autoscaling group of stack %q: %w", stackName, err)
+	}
+	asgName := resource.StackResourceDetail.PhysicalResourceId
+	if asgName == nil || resource.StackResourceDetail.ResourceStatus == cfntypes.ResourceStatusDeleteComplete {
+		return false, nil
+	}
+	output, err := f.provider.ASG().DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []string{*asgName},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to describe autoscaling group %q: %w", *asgName, err)
+	}
+	return len(output.AutoScalingGroups) > 0, nil
+}
+
+// DescribeOrphanedStack describes why a stack is orphaned
+func DescribeOrphanedStack(s OrphanedStack) string {
+	desc := fmt.Sprintf("stack %q (%s): %s", s.Name, s.Status, s.Reason)
+	if s.TerminationProtected {
+		desc += ", termination protection is enabled"
+	}
+	return desc
+}
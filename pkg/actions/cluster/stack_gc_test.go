@@ -0,0 +1,150 @@
+package cluster_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/smithy-go"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Stack GC", func() {
+	var provider *mockprovider.MockProvider
+
+	makeStack := func(name string, status cfntypes.StackStatus, tags map[string]string) cfntypes.Stack {
+		stack := cfntypes.Stack{
+			StackName:   aws.String(name),
+			StackId:     aws.String("arn:aws:cloudformation:us-west-2:123456789012:stack/" + name + "/1"),
+			StackStatus: status,
+		}
+		for k, v := range tags {
+			stack.Tags = append(stack.Tags, cfntypes.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+		return stack
+	}
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+
+		provider.MockCloudFormation().On("DescribeStacks", mock.Anything, &cloudformation.DescribeStacksInput{}, mock.Anything).Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []cfntypes.Stack{
+				makeStack("eksctl-gone-cluster", cfntypes.StackStatusRollbackComplete, map[string]string{api.ClusterNameTag: "gone"}),
+				makeStack("eksctl-gone-nodegroup-ng-1", cfntypes.StackStatusCreateComplete, map[string]string{
+					api.ClusterNameTag:   "gone",
+					api.NodeGroupNameTag: "ng-1",
+				}),
+				makeStack("eksctl-live-cluster", cfntypes.StackStatusCreateComplete, map[string]string{api.ClusterNameTag: "live"}),
+				makeStack("eksctl-live-nodegroup-mng-1", cfntypes.StackStatusCreateComplete, map[string]string{
+					api.ClusterNameTag:   "live",
+					api.NodeGroupNameTag: "mng-1",
+					api.NodeGroupTypeTag: string(api.NodeGroupTypeManaged),
+				}),
+				makeStack("eksctl-live-nodegroup-mng-2", cfntypes.StackStatusCreateComplete, map[string]string{
+					api.ClusterNameTag:   "live",
+					api.NodeGroupNameTag: "mng-2",
+					api.NodeGroupTypeTag: string(api.NodeGroupTypeManaged),
+				}),
+				makeStack("eksctl-live-nodegroup-ng-1", cfntypes.StackStatusUpdateComplete, map[string]string{
+					api.ClusterNameTag:   "live",
+					api.NodeGroupNameTag: "ng-1",
+					api.NodeGroupTypeTag: string(api.NodeGroupTypeUnmanaged),
+				}),
+				makeStack("eksctl-live-nodegroup-ng-2", cfntypes.StackStatusUpdateRollbackComplete, map[string]string{
+					api.ClusterNameTag:   "live",
+					api.NodeGroupNameTag: "ng-2",
+					api.NodeGroupTypeTag: string(api.NodeGroupTypeUnmanaged),
+				}),
+				makeStack("eksctl-creating-cluster", cfntypes.StackStatusCreateInProgress, map[string]string{api.ClusterNameTag: "creating"}),
+				makeStack("not-eksctl", cfntypes.StackStatusCreateComplete, nil),
+			},
+		}, nil)
+
+		provider.MockEKS().On("DescribeCluster", mock.Anything, &awseks.DescribeClusterInput{Name: aws.String("gone")}).
+			Return(nil, &ekstypes.ResourceNotFoundException{}).Once()
+		provider.MockEKS().On("DescribeCluster", mock.Anything, &awseks.DescribeClusterInput{Name: aws.String("live")}).
+			Return(&awseks.DescribeClusterOutput{Cluster: &ekstypes.Cluster{Name: aws.String("live")}}, nil).Once()
+		provider.MockEKS().On("ListNodegroups", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListNodegroupsOutput{
+			Nodegroups: []string{"mng-1"},
+		}, nil).Once()
+		provider.MockCloudFormation().On("DescribeStackResource", mock.Anything, &cloudformation.DescribeStackResourceInput{
+			StackName:         aws.String("eksctl-live-nodegroup-ng-1"),
+			LogicalResourceId: aws.String("NodeGroup"),
+		}).Return(&cloudformation.DescribeStackResourceOutput{
+			StackResourceDetail: &cfntypes.StackResourceDetail{PhysicalResourceId: aws.String("asg-ng-1")},
+		}, nil)
+		provider.MockCloudFormation().On("DescribeStackResource", mock.Anything, &cloudformation.DescribeStackResourceInput{
+			StackName:         aws.String("eksctl-live-nodegroup-ng-2"),
+			LogicalResourceId: aws.String("NodeGroup"),
+		}).Return(nil, &smithy.GenericAPIError{Code: "ValidationError", Message: "Resource NodeGroup does not exist for stack eksctl-live-nodegroup-ng-2"})
+		provider.MockASG().On("DescribeAutoScalingGroups", mock.Anything, &autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: []string{"asg-ng-1"},
+		}).Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+			AutoScalingGroups: []asgtypes.AutoScalingGroup{{AutoScalingGroupName: aws.String("asg-ng-1")}},
+		}, nil)
+	})
+
+	It("finds the stacks of deleted clusters and nodegroups", func() {
+		orphans, err := cluster.FindOrphanedStacks(context.Background(), provider)
+		Expect(err).NotTo(HaveOccurred())
+
+		var names []string
+		for _, o := range orphans {
+			names = append(names, o.Name)
+		}
+		Expect(names).To(Equal([]string{"eksctl-gone-cluster", "eksctl-gone-nodegroup-ng-1", "eksctl-live-nodegroup-mng-2", "eksctl-live-nodegroup-ng-2"}))
+		Expect(orphans[2].Reason).To(Equal(`managed nodegroup "mng-2" does not exist`))
+		Expect(orphans[3].Reason).To(Equal(`the autoscaling group of nodegroup "ng-2" does not exist`))
+	})
+
+	deletedStacks := func() []string {
+		var deleted []string
+		for _, call := range provider.MockCloudFormation().Calls {
+			if call.Method == "DeleteStack" {
+				deleted = append(deleted, *call.Arguments[1].(*cloudformation.DeleteStackInput).StackName)
+			}
+		}
+		return deleted
+	}
+
+	It("deletes the cluster stacks last, once their other stacks are deleted, and skips termination-protected stacks", func() {
+		provider.MockCloudFormation().On("DeleteStack", mock.Anything, mock.Anything).Return(&cloudformation.DeleteStackOutput{}, nil)
+
+		orphans, err := cluster.FindOrphanedStacks(context.Background(), provider)
+		Expect(err).NotTo(HaveOccurred())
+		provider.MockCloudFormation().On("DescribeStacks", mock.Anything, &cloudformation.DescribeStacksInput{StackName: aws.String(orphans[1].ID)}, mock.Anything).
+			Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []cfntypes.Stack{makeStack("eksctl-gone-nodegroup-ng-1", cfntypes.StackStatusDeleteComplete, nil)},
+			}, nil).Once()
+		orphans[2].TerminationProtected = true
+		Expect(cluster.DeleteOrphanedStacks(context.Background(), provider, orphans, false)).To(Succeed())
+
+		Expect(deletedStacks()).To(Equal([]string{orphans[1].ID, orphans[3].ID, orphans[0].ID}))
+		provider.MockCloudFormation().AssertExpectations(GinkgoT())
+	})
+
+	It("does not delete a cluster stack when its other stacks fail to be deleted", func() {
+		orphans, err := cluster.FindOrphanedStacks(context.Background(), provider)
+		Expect(err).NotTo(HaveOccurred())
+		provider.MockCloudFormation().On("DeleteStack", mock.Anything, &cloudformation.DeleteStackInput{StackName: aws.String(orphans[1].ID)}).
+			Return(nil, errors.New("access denied"))
+		provider.MockCloudFormation().On("DeleteStack", mock.Anything, mock.Anything).Return(&cloudformation.DeleteStackOutput{}, nil)
+
+		err = cluster.DeleteOrphanedStacks(context.Background(), provider, orphans[:2], false)
+		Expect(err).To(MatchError(ContainSubstring(`not deleting stack "eksctl-gone-cluster" as other stacks of cluster "gone" failed to be deleted`)))
+		Expect(deletedStacks()).To(Equal([]string{orphans[1].ID}))
+	})
+})
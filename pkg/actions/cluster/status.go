@@ -0,0 +1,72 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+// Status describes a cluster as returned by DescribeCluster, along with the updates to it that are in progress,
+// including platform version updates initiated by EKS.
+type Status struct {
+	*ekstypes.Cluster
+	PendingUpdates []ekstypes.Update `json:"PendingUpdates,omitempty"`
+}
+
+// Updating reports whether the cluster is being updated.
+func (s *Status) Updating() bool {
+	return s.Cluster.Status == ekstypes.ClusterStatusUpdating
+}
+
+// HealthIssues returns the health issues reported by EKS for the cluster.
+func (s *Status) HealthIssues() []ekstypes.ClusterIssue {
+	if s.Health == nil {
+		return nil
+	}
+	return s.Health.Issues
+}
+
+// GetStatus describes the cluster and, while it is being updated, its in-progress updates.
+func GetStatus(ctx context.Context, eksAPI awsapi.EKS, clusterName string) (*Status, error) {
+	// the status must be fresh, e.g. for get cluster --watch
+	ctx = eks.WithoutResponseCache(ctx)
+	out, err := eksAPI.DescribeCluster(ctx, &awseks.DescribeClusterInput{
+		Name: aws.String(clusterName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe control plane %q: %w", clusterName, err)
+	}
+	status := &Status{Cluster: out.Cluster}
+	if !status.Updating() {
+		return status, nil
+	}
+
+	paginator := awseks.NewListUpdatesPaginator(eksAPI, &awseks.ListUpdatesInput{
+		Name: aws.String(clusterName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing updates for cluster %q: %w", clusterName, err)
+		}
+		for _, updateID := range page.UpdateIds {
+			update, err := eksAPI.DescribeUpdate(ctx, &awseks.DescribeUpdateInput{
+				Name:     aws.String(clusterName),
+				UpdateId: aws.String(updateID),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("describing update %q for cluster %q: %w", updateID, clusterName, err)
+			}
+			if update.Update.Status == ekstypes.UpdateStatusInProgress {
+				status.PendingUpdates = append(status.PendingUpdates, *update.Update)
+			}
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,85 @@
+package cluster_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("GetStatus", func() {
+	var p *mockprovider.MockProvider
+
+	mockDescribeCluster := func(status ekstypes.ClusterStatus, issues ...ekstypes.ClusterIssue) {
+		p.MockEKS().On("DescribeCluster", mock.Anything, &awseks.DescribeClusterInput{
+			Name: aws.String("my-cluster"),
+		}).Return(&awseks.DescribeClusterOutput{
+			Cluster: &ekstypes.Cluster{
+				Name:            aws.String("my-cluster"),
+				Status:          status,
+				Version:         aws.String("1.30"),
+				PlatformVersion: aws.String("eks.8"),
+				Health:          &ekstypes.ClusterHealth{Issues: issues},
+			},
+		}, nil)
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+	})
+
+	It("does not list updates when the cluster is active", func() {
+		issue := ekstypes.ClusterIssue{
+			Code:        ekstypes.ClusterIssueCodeSubnetNotFound,
+			Message:     aws.String("subnet not found"),
+			ResourceIds: []string{"subnet-1"},
+		}
+		mockDescribeCluster(ekstypes.ClusterStatusActive, issue)
+
+		status, err := cluster.GetStatus(context.Background(), p.EKS(), "my-cluster")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*status.PlatformVersion).To(Equal("eks.8"))
+		Expect(status.Updating()).To(BeFalse())
+		Expect(status.HealthIssues()).To(ConsistOf(issue))
+		Expect(status.PendingUpdates).To(BeEmpty())
+		p.MockEKS().AssertNotCalled(GinkgoT(), "ListUpdates", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("reports the updates in progress when the cluster is updating", func() {
+		mockDescribeCluster(ekstypes.ClusterStatusUpdating)
+		p.MockEKS().On("ListUpdates", mock.Anything, &awseks.ListUpdatesInput{
+			Name: aws.String("my-cluster"),
+		}, mock.Anything).Return(&awseks.ListUpdatesOutput{
+			UpdateIds: []string{"old", "current"},
+		}, nil)
+		for id, updateStatus := range map[string]ekstypes.UpdateStatus{
+			"old":     ekstypes.UpdateStatusSuccessful,
+			"current": ekstypes.UpdateStatusInProgress,
+		} {
+			p.MockEKS().On("DescribeUpdate", mock.Anything, &awseks.DescribeUpdateInput{
+				Name:     aws.String("my-cluster"),
+				UpdateId: aws.String(id),
+			}).Return(&awseks.DescribeUpdateOutput{
+				Update: &ekstypes.Update{
+					Id:     aws.String(id),
+					Status: updateStatus,
+					Type:   ekstypes.UpdateTypeVersionUpdate,
+				},
+			}, nil)
+		}
+
+		status, err := cluster.GetStatus(context.Background(), p.EKS(), "my-cluster")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.Updating()).To(BeTrue())
+		Expect(status.PendingUpdates).To(HaveLen(1))
+		Expect(*status.PendingUpdates[0].Id).To(Equal("current"))
+	})
+})
@@ -55,12 +55,23 @@ func (c *UnownedCluster) Upgrade(ctx context.Context, dryRun bool) error {
 	return nil
 }
 
-func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int) error {
+func (c *UnownedCluster) DeletionReport(ctx context.Context) (*DeletionReport, error) {
+	return newDeletionReporter(ctx, c.cfg, c.ctl, c.stackManager, c.newClientSet).Report(ctx)
+}
+
+func (c *UnownedCluster) DeleteKubernetesResources(ctx context.Context, kinds []string) error {
+	return deleteKubernetesResources(ctx, c.cfg, c.ctl, c.newClientSet, kinds)
+}
+
+func (c *UnownedCluster) Delete(ctx context.Context, options DeleteOptions) error {
 	clusterName := c.cfg.Metadata.Name
 
 	if err := c.checkClusterExists(ctx, clusterName); err != nil {
 		return err
 	}
+	if options.ForceCleanup {
+		logger.Warning("ignoring --force-cleanup as cluster %q was not created by eksctl, so its VPC is not deleted", clusterName)
+	}
 
 	clusterOperable, err := c.ctl.CanOperate(c.cfg)
 	if err != nil {
@@ -80,10 +91,10 @@ func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWa
 		}
 
 		drainer := c.newNodeGroupDrainer(clientSet)
-		if err := drainAllNodeGroups(ctx, c.cfg, c.ctl, clientSet, allStacks, disableNodegroupEviction, parallel, drainer, func(clusterConfig *api.ClusterConfig, ctl *eks.ClusterProvider, clientSet kubernetes.Interface) {
+		if err := drainAllNodeGroups(ctx, c.cfg, c.ctl, clientSet, allStacks, options.DisableNodegroupEviction, options.Parallel, drainer, func(clusterConfig *api.ClusterConfig, ctl *eks.ClusterProvider, clientSet kubernetes.Interface) {
 			attemptVpcCniDeletion(ctx, clusterConfig, ctl, clientSet)
-		}, podEvictionWaitPeriod); err != nil {
-			if !force {
+		}, options.PodEvictionWaitPeriod); err != nil {
+			if !options.Force {
 				return err
 			}
 
@@ -93,7 +104,7 @@ func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWa
 
 	if err := deleteSharedResources(ctx, c.cfg, c.ctl, c.stackManager, clusterOperable, clientSet); err != nil {
 		if err != nil {
-			if force {
+			if options.Force {
 				logger.Warning("error occurred during deletion: %v", err)
 			} else {
 				return err
@@ -107,13 +118,13 @@ func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWa
 
 	// we have to wait for nodegroups to delete before deleting the cluster
 	// so the `wait` value is ignored here
-	if err := c.deleteAndWaitForNodegroupsDeletion(ctx, waitInterval, allStacks); err != nil {
+	if err := c.deleteAndWaitForNodegroupsDeletion(ctx, options.WaitInterval, allStacks, options.StackParallelism); err != nil {
 		return err
 	}
 
-	if err := c.deleteIAMAndOIDC(ctx, wait, clusterOperable, clientSet, force); err != nil {
+	if err := c.deleteIAMAndOIDC(ctx, options.Wait, clusterOperable, clientSet, options.Force, options.StackParallelism); err != nil {
 		if err != nil {
-			if force {
+			if options.Force {
 				logger.Warning("error occurred during deletion: %v", err)
 			} else {
 				return err
@@ -121,7 +132,11 @@ func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWa
 		}
 	}
 
-	if err := c.deleteCluster(ctx, wait); err != nil {
+	if err := c.deleteAuxiliaryStacks(ctx); err != nil {
+		return err
+	}
+
+	if err := c.deleteCluster(ctx, options.Wait); err != nil {
 		return err
 	}
 
@@ -149,6 +164,31 @@ func (c *UnownedCluster) deleteFargateRoleIfExists(ctx context.Context) error {
 	return nil
 }
 
+// deleteAuxiliaryStacks deletes the monitoring and EFS stacks, whose resources use the subnets of the cluster
+func (c *UnownedCluster) deleteAuxiliaryStacks(ctx context.Context) error {
+	for _, stackName := range []string{
+		manager.MakeMonitoringStackName(c.cfg.Metadata.Name),
+		manager.MakeEFSStackName(c.cfg.Metadata.Name),
+	} {
+		stack, err := c.stackManager.DescribeStack(ctx, &manager.Stack{StackName: &stackName})
+		if err != nil {
+			if manager.IsStackDoesNotExistError(err) {
+				continue
+			}
+			return err
+		}
+		if stack == nil {
+			continue
+		}
+
+		logger.Info("deleting stack %q", stackName)
+		if err := c.stackManager.DeleteStackSync(ctx, stack); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *UnownedCluster) checkClusterExists(ctx context.Context, clusterName string) error {
 	_, err := c.ctl.AWSProvider.EKS().DescribeCluster(ctx, &awseks.DescribeClusterInput{
 		Name: &c.cfg.Metadata.Name,
@@ -162,7 +202,7 @@ func (c *UnownedCluster) checkClusterExists(ctx context.Context, clusterName str
 	return nil
 }
 
-func (c *UnownedCluster) deleteIAMAndOIDC(ctx context.Context, wait bool, clusterOperable bool, clientSet kubernetes.Interface, force bool) error {
+func (c *UnownedCluster) deleteIAMAndOIDC(ctx context.Context, wait bool, clusterOperable bool, clientSet kubernetes.Interface, force bool, stackParallelism int) error {
 	tasksTree := &tasks.TaskTree{Parallel: false}
 
 	if clusterOperable {
@@ -196,8 +236,9 @@ func (c *UnownedCluster) deleteIAMAndOIDC(ctx context.Context, wait bool, cluste
 		return nil
 	}
 
+	tasksTree.LimitParallelism(stackParallelism)
 	logger.Info(tasksTree.Describe())
-	if errs := tasksTree.DoAllSync(); len(errs) > 0 {
+	if errs := tasksTree.DoAllSyncContext(ctx, tasks.RunOptions{}); len(errs) > 0 {
 		return handleErrors(errs, "cluster IAM and OIDC")
 	}
 
@@ -233,7 +274,7 @@ func (c *UnownedCluster) deleteCluster(ctx context.Context, wait bool) error {
 	}, c.ctl.AWSProvider.WaitTimeout())
 }
 
-func (c *UnownedCluster) deleteAndWaitForNodegroupsDeletion(ctx context.Context, waitInterval time.Duration, allStacks []manager.NodeGroupStack) error {
+func (c *UnownedCluster) deleteAndWaitForNodegroupsDeletion(ctx context.Context, waitInterval time.Duration, allStacks []manager.NodeGroupStack, stackParallelism int) error {
 	clusterName := c.cfg.Metadata.Name
 	eksAPI := c.ctl.AWSProvider.EKS()
 
@@ -251,31 +292,34 @@ func (c *UnownedCluster) deleteAndWaitForNodegroupsDeletion(ctx context.Context,
 	}
 
 	// we kill every nodegroup with a stack the standard way. wait is always true
-	tasks, err := c.stackManager.NewTasksToDeleteNodeGroups(allStacks, func(_ string) bool { return true }, true, nil)
+	taskTree, err := c.stackManager.NewTasksToDeleteNodeGroups(allStacks, func(_ string) bool { return true }, true, nil)
 	if err != nil {
 		return err
 	}
 
+	engines := nodegroup.NewStacklessEngines(c.cfg, c.ctl.AWSProvider)
 	for _, n := range nodeGroups.Nodegroups {
-		isUnowned := func() bool {
-			for _, stack := range allStacks {
-				if stack.NodeGroupName == n {
-					return false
-				}
-			}
-			return true
+		if hasNodeGroupStack(allStacks, n) {
+			continue
 		}
-
-		if isUnowned() {
-			// if a managed ng does not have a stack, we queue it for deletion via api
-			tasks.Append(c.stackManager.NewTaskToDeleteUnownedNodeGroup(ctx, clusterName, n, eksAPI, c.waitForUnownedNgsDeletion(ctx, waitInterval)))
+		// nodegroups created by an infra engine without a stack are deleted along with the IAM roles created for them
+		engine, err := nodegroup.FindStacklessEngine(ctx, engines, n)
+		if err != nil {
+			return err
+		}
+		if engine != nil {
+			taskTree.Append(nodegroup.NewTaskToDeleteStacklessNodeGroup(ctx, engine, n, false))
+			continue
 		}
+		// if a managed ng does not have a stack, we queue it for deletion via api
+		taskTree.Append(c.stackManager.NewTaskToDeleteUnownedNodeGroup(ctx, clusterName, n, eksAPI, c.waitForUnownedNgsDeletion(ctx, waitInterval)))
 	}
 
 	// TODO what dis?
-	tasks.PlanMode = false
-	logger.Info(tasks.Describe())
-	if errs := tasks.DoAllSync(); len(errs) > 0 {
+	taskTree.PlanMode = false
+	taskTree.LimitParallelism(stackParallelism)
+	logger.Info(taskTree.Describe())
+	if errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{}); len(errs) > 0 {
 		return handleErrors(errs, "nodegroup(s)")
 	}
 	return nil
@@ -58,6 +58,10 @@ var _ = Describe("Delete", func() {
 		fakeStackManager = new(fakes.FakeStackManager)
 		ranDeleteDeprecatedTasks = false
 		ctl = &eks.ClusterProvider{AWSProvider: p, Status: &eks.ProviderStatus{}}
+		// the nodegroups without stacks were not created by the sdk infra engine
+		p.MockEKS().On("DescribeNodegroup", mock.Anything, mock.Anything).Return(&awseks.DescribeNodegroupOutput{
+			Nodegroup: &ekstypes.Nodegroup{},
+		}, nil)
 	})
 
 	Context("when the cluster is operable", func() {
@@ -125,6 +129,9 @@ var _ = Describe("Delete", func() {
 				Nodegroups: []string{"ng-1", "ng-2"},
 			}, nil)
 
+			fakeStackManager.DescribeStackReturnsOnCall(0, &types.Stack{StackName: aws.String("eksctl-my-cluster-monitoring")}, nil)
+			fakeStackManager.DescribeStackReturnsOnCall(1, &types.Stack{StackName: aws.String("eksctl-my-cluster-efs")}, nil)
+
 			fakeStackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{{NodeGroupName: "ng-1"}}, nil)
 
 			var deleteCallCount int
@@ -154,7 +161,7 @@ var _ = Describe("Delete", func() {
 				return fakeClientSet, nil
 			})
 
-			err := c.Delete(context.Background(), time.Microsecond, time.Second*0, false, false, false, 1)
+			err := c.Delete(context.Background(), cluster.DeleteOptions{WaitInterval: time.Microsecond, Parallel: 1})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(deleteCallCount).To(Equal(1))
 			Expect(unownedDeleteCallCount).To(Equal(1))
@@ -163,6 +170,11 @@ var _ = Describe("Delete", func() {
 			Expect(fakeStackManager.DeleteStackBySpecCallCount()).To(Equal(1))
 			_, stack := fakeStackManager.DeleteStackBySpecArgsForCall(0)
 			Expect(*stack.StackName).To(Equal("fargate-role"))
+			Expect(fakeStackManager.DeleteStackSyncCallCount()).To(Equal(2))
+			_, stack = fakeStackManager.DeleteStackSyncArgsForCall(0)
+			Expect(*stack.StackName).To(Equal("eksctl-my-cluster-monitoring"))
+			_, stack = fakeStackManager.DeleteStackSyncArgsForCall(1)
+			Expect(*stack.StackName).To(Equal("eksctl-my-cluster-efs"))
 		})
 
 		When("force flag is set to true", func() {
@@ -257,7 +269,7 @@ var _ = Describe("Delete", func() {
 					return mockedDrainer
 				})
 
-				err := c.Delete(context.Background(), time.Microsecond, time.Second*0, false, true, false, 1)
+				err := c.Delete(context.Background(), cluster.DeleteOptions{WaitInterval: time.Microsecond, Force: true, Parallel: 1})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(deleteCallCount).To(Equal(0))
 				Expect(unownedDeleteCallCount).To(Equal(0))
@@ -360,7 +372,7 @@ var _ = Describe("Delete", func() {
 					return mockedDrainer
 				})
 
-				err := c.Delete(context.Background(), time.Microsecond, time.Second*0, false, false, false, 1)
+				err := c.Delete(context.Background(), cluster.DeleteOptions{WaitInterval: time.Microsecond, Parallel: 1})
 				Expect(err).To(MatchError(errorMessage))
 				Expect(deleteCallCount).To(Equal(0))
 				Expect(unownedDeleteCallCount).To(Equal(0))
@@ -424,7 +436,7 @@ var _ = Describe("Delete", func() {
 			p.MockEKS().On("DeleteCluster", mock.Anything, mock.Anything).Return(&awseks.DeleteClusterOutput{}, nil)
 
 			c := cluster.NewUnownedCluster(cfg, ctl, fakeStackManager)
-			err := c.Delete(context.Background(), time.Microsecond, time.Second*0, false, false, false, 1)
+			err := c.Delete(context.Background(), cluster.DeleteOptions{WaitInterval: time.Microsecond, Parallel: 1})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeStackManager.DeleteTasksForDeprecatedStacksCallCount()).To(Equal(1))
 			Expect(deleteCallCount).To(Equal(1))
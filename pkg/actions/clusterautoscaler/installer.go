@@ -0,0 +1,211 @@
+package clusterautoscaler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/addons"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers"
+)
+
+const (
+	// Namespace is the namespace Cluster Autoscaler is installed in
+	Namespace = "kube-system"
+	// ServiceAccountName is the name of the service account of Cluster Autoscaler
+	ServiceAccountName = "cluster-autoscaler"
+
+	// EnabledTag is the tag Cluster Autoscaler discovers the Auto Scaling groups of any cluster by
+	EnabledTag = "k8s.io/cluster-autoscaler/enabled"
+
+	helmChartName = "cluster-autoscaler"
+	helmRepoURL   = "https://kubernetes.github.io/autoscaler"
+	releaseName   = "cluster-autoscaler"
+	imageRepo     = "registry.k8s.io/autoscaling/cluster-autoscaler"
+)
+
+// ClusterTag returns the tag Cluster Autoscaler discovers the Auto Scaling groups of a cluster by
+func ClusterTag(clusterName string) string {
+	return "k8s.io/cluster-autoscaler/" + clusterName
+}
+
+// Installer installs Cluster Autoscaler in a cluster and tags the Auto Scaling groups of its nodegroups for discovery
+type Installer struct {
+	ClusterConfig *api.ClusterConfig
+	// KubernetesVersion is the version of the cluster, Cluster Autoscaler is released for each Kubernetes minor version
+	KubernetesVersion string
+	StackManager      manager.StackManager
+	ASG               awsapi.ASG
+	IRSA              addons.IRSAHelper
+	HelmInstaller     providers.HelmInstaller
+	// ImageTag overrides the Cluster Autoscaler release matching the version of the cluster
+	ImageTag string
+}
+
+// NodeGroupTags is the outcome of the audit of the discovery tags of the Auto Scaling group of a nodegroup
+type NodeGroupTags struct {
+	NodeGroup        string
+	AutoScalingGroup string
+	// MissingTags are the discovery tags the Auto Scaling group lacks
+	MissingTags []string
+}
+
+// Install creates the IAM role of Cluster Autoscaler, adds the missing discovery tags to the Auto Scaling groups of
+// the nodegroups and installs Cluster Autoscaler; it is safe to run again to update Cluster Autoscaler
+func (i *Installer) Install(ctx context.Context) error {
+	imageTag, err := i.imageTag()
+	if err != nil {
+		return err
+	}
+
+	supported, err := i.IRSA.IsSupported(ctx)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("no IAM OIDC provider associated with cluster, try 'eksctl utils associate-iam-oidc-provider --region=%s --cluster=%s'", i.ClusterConfig.Metadata.Region, i.ClusterConfig.Metadata.Name)
+	}
+	logger.Info("creating the IAM role of Cluster Autoscaler")
+	if err := i.IRSA.CreateOrUpdate(ctx, &api.ClusterIAMServiceAccount{
+		ClusterIAMMeta: api.ClusterIAMMeta{
+			Name:      ServiceAccountName,
+			Namespace: Namespace,
+		},
+		WellKnownPolicies: api.WellKnownPolicies{
+			AutoScaler: true,
+		},
+	}); err != nil {
+		return fmt.Errorf("creating the IAM role of Cluster Autoscaler: %w", err)
+	}
+
+	audit, err := i.AuditTags(ctx)
+	if err != nil {
+		return err
+	}
+	if err := i.addMissingTags(ctx, audit); err != nil {
+		return err
+	}
+
+	logger.Info("installing Cluster Autoscaler %s", imageTag)
+	if err := i.HelmInstaller.InstallChart(ctx, providers.InstallChartOpts{
+		ChartName:   helmChartName,
+		RepoURL:     helmRepoURL,
+		Namespace:   Namespace,
+		ReleaseName: releaseName,
+		Values: map[string]interface{}{
+			"awsRegion": i.ClusterConfig.Metadata.Region,
+			"autoDiscovery": map[string]interface{}{
+				"clusterName": i.ClusterConfig.Metadata.Name,
+			},
+			"image": map[string]interface{}{
+				"repository": imageRepo,
+				"tag":        imageTag,
+			},
+			"rbac": map[string]interface{}{
+				"serviceAccount": map[string]interface{}{
+					"create": false,
+					"name":   ServiceAccountName,
+				},
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to install Cluster Autoscaler chart: %w", err)
+	}
+	return nil
+}
+
+// imageTag returns the first release of Cluster Autoscaler for the minor version of the cluster, unless overridden
+func (i *Installer) imageTag() (string, error) {
+	if i.ImageTag != "" {
+		return i.ImageTag, nil
+	}
+	parts := strings.Split(i.KubernetesVersion, ".")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("invalid Kubernetes version %q", i.KubernetesVersion)
+	}
+	return fmt.Sprintf("v%s.%s.0", parts[0], parts[1]), nil
+}
+
+// AuditTags returns the discovery tags missing from the Auto Scaling group of each nodegroup created by eksctl
+func (i *Installer) AuditTags(ctx context.Context) ([]NodeGroupTags, error) {
+	stacks, err := i.StackManager.ListNodeGroupStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	requiredTags := []string{EnabledTag, ClusterTag(i.ClusterConfig.Metadata.Name)}
+
+	var audit []NodeGroupTags
+	for _, stack := range stacks {
+		nodeGroupName := i.StackManager.GetNodeGroupName(stack)
+		asgNames, err := i.StackManager.GetAutoScalingGroupName(ctx, stack)
+		if err != nil {
+			return nil, fmt.Errorf("getting the Auto Scaling group of nodegroup %q: %w", nodeGroupName, err)
+		}
+		if asgNames == "" {
+			continue
+		}
+		// managed nodegroups can have several Auto Scaling groups
+		output, err := i.ASG.DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: strings.Split(asgNames, ","),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describing the Auto Scaling group of nodegroup %q: %w", nodeGroupName, err)
+		}
+		for _, asg := range output.AutoScalingGroups {
+			tags := map[string]bool{}
+			for _, tag := range asg.Tags {
+				tags[aws.ToString(tag.Key)] = true
+			}
+			ngTags := NodeGroupTags{
+				NodeGroup:        nodeGroupName,
+				AutoScalingGroup: aws.ToString(asg.AutoScalingGroupName),
+			}
+			for _, key := range requiredTags {
+				if !tags[key] {
+					ngTags.MissingTags = append(ngTags.MissingTags, key)
+				}
+			}
+			audit = append(audit, ngTags)
+		}
+	}
+	sort.Slice(audit, func(a, b int) bool {
+		return audit[a].NodeGroup < audit[b].NodeGroup
+	})
+	return audit, nil
+}
+
+func (i *Installer) addMissingTags(ctx context.Context, audit []NodeGroupTags) error {
+	values := map[string]string{
+		EnabledTag: "true",
+		ClusterTag(i.ClusterConfig.Metadata.Name): "owned",
+	}
+	for _, ngTags := range audit {
+		if len(ngTags.MissingTags) == 0 {
+			continue
+		}
+		var tags []asgtypes.Tag
+		for _, key := range ngTags.MissingTags {
+			tags = append(tags, asgtypes.Tag{
+				ResourceId:        aws.String(ngTags.AutoScalingGroup),
+				ResourceType:      aws.String("auto-scaling-group"),
+				Key:               aws.String(key),
+				Value:             aws.String(values[key]),
+				PropagateAtLaunch: aws.Bool(true),
+			})
+		}
+		if _, err := i.ASG.CreateOrUpdateTags(ctx, &autoscaling.CreateOrUpdateTagsInput{Tags: tags}); err != nil {
+			return fmt.Errorf("tagging the Auto Scaling group of nodegroup %q: %w", ngTags.NodeGroup, err)
+		}
+		logger.Info("added tags %s to Auto Scaling group %q of nodegroup %q", strings.Join(ngTags.MissingTags, ", "), ngTags.AutoScalingGroup, ngTags.NodeGroup)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package clusterautoscaler_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/clusterautoscaler"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	providerfakes "github.com/weaveworks/eksctl/pkg/karpenter/providers/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+type fakeIRSA struct {
+	serviceAccounts []*api.ClusterIAMServiceAccount
+}
+
+func (f *fakeIRSA) IsSupported(_ context.Context) (bool, error) {
+	return true, nil
+}
+
+func (f *fakeIRSA) CreateOrUpdate(_ context.Context, sa *api.ClusterIAMServiceAccount) error {
+	f.serviceAccounts = append(f.serviceAccounts, sa)
+	return nil
+}
+
+var _ = Describe("Cluster Autoscaler installer", func() {
+	var (
+		provider      *mockprovider.MockProvider
+		irsaHelper    *fakeIRSA
+		helmInstaller *providerfakes.FakeHelmInstaller
+		installer     *clusterautoscaler.Installer
+	)
+
+	tag := func(key, value string) asgtypes.TagDescription {
+		return asgtypes.TagDescription{Key: aws.String(key), Value: aws.String(value)}
+	}
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		irsaHelper = &fakeIRSA{}
+		helmInstaller = &providerfakes.FakeHelmInstaller{}
+		stackManager := &fakes.FakeStackManager{}
+		stackManager.ListNodeGroupStacksReturns([]*cfntypes.Stack{
+			{StackName: aws.String("eksctl-my-cluster-nodegroup-ng-2")},
+			{StackName: aws.String("eksctl-my-cluster-nodegroup-ng-1")},
+		}, nil)
+		stackManager.GetNodeGroupNameStub = func(s *cfntypes.Stack) string {
+			return aws.ToString(s.StackName)[len("eksctl-my-cluster-nodegroup-"):]
+		}
+		stackManager.GetAutoScalingGroupNameStub = func(_ context.Context, s *cfntypes.Stack) (string, error) {
+			return "asg-" + stackManager.GetNodeGroupName(s), nil
+		}
+
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Metadata.Region = "us-west-2"
+		installer = &clusterautoscaler.Installer{
+			ClusterConfig:     cfg,
+			KubernetesVersion: "1.30",
+			StackManager:      stackManager,
+			ASG:               provider.ASG(),
+			IRSA:              irsaHelper,
+			HelmInstaller:     helmInstaller,
+		}
+
+		provider.MockASG().On("DescribeAutoScalingGroups", mock.Anything, &autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: []string{"asg-ng-1"},
+		}).Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+			AutoScalingGroups: []asgtypes.AutoScalingGroup{{
+				AutoScalingGroupName: aws.String("asg-ng-1"),
+				Tags: []asgtypes.TagDescription{
+					tag("k8s.io/cluster-autoscaler/enabled", "true"),
+					tag("k8s.io/cluster-autoscaler/my-cluster", "owned"),
+				},
+			}},
+		}, nil)
+		provider.MockASG().On("DescribeAutoScalingGroups", mock.Anything, &autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: []string{"asg-ng-2"},
+		}).Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+			AutoScalingGroups: []asgtypes.AutoScalingGroup{{
+				AutoScalingGroupName: aws.String("asg-ng-2"),
+				Tags:                 []asgtypes.TagDescription{tag("k8s.io/cluster-autoscaler/enabled", "true")},
+			}},
+		}, nil)
+		provider.MockASG().On("CreateOrUpdateTags", mock.Anything, mock.Anything).Return(&autoscaling.CreateOrUpdateTagsOutput{}, nil)
+	})
+
+	It("audits the discovery tags of the nodegroups", func() {
+		audit, err := installer.AuditTags(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(audit).To(Equal([]clusterautoscaler.NodeGroupTags{
+			{NodeGroup: "ng-1", AutoScalingGroup: "asg-ng-1"},
+			{NodeGroup: "ng-2", AutoScalingGroup: "asg-ng-2", MissingTags: []string{"k8s.io/cluster-autoscaler/my-cluster"}},
+		}))
+	})
+
+	It("creates the IAM role, adds the missing tags and installs the release of the cluster's minor version", func() {
+		Expect(installer.Install(context.Background())).To(Succeed())
+
+		Expect(irsaHelper.serviceAccounts).To(HaveLen(1))
+		Expect(irsaHelper.serviceAccounts[0].NameString()).To(Equal("kube-system/cluster-autoscaler"))
+		Expect(irsaHelper.serviceAccounts[0].WellKnownPolicies.AutoScaler).To(BeTrue())
+
+		provider.MockASG().AssertNumberOfCalls(GinkgoT(), "CreateOrUpdateTags", 1)
+		input := provider.MockASG().Calls[len(provider.MockASG().Calls)-1].Arguments[1].(*autoscaling.CreateOrUpdateTagsInput)
+		Expect(input.Tags).To(ConsistOf(asgtypes.Tag{
+			ResourceId:        aws.String("asg-ng-2"),
+			ResourceType:      aws.String("auto-scaling-group"),
+			Key:               aws.String("k8s.io/cluster-autoscaler/my-cluster"),
+			Value:             aws.String("owned"),
+			PropagateAtLaunch: aws.Bool(true),
+		}))
+
+		Expect(helmInstaller.InstallChartCallCount()).To(Equal(1))
+		_, opts := helmInstaller.InstallChartArgsForCall(0)
+		Expect(opts.ChartName).To(Equal("cluster-autoscaler"))
+		Expect(opts.Values).To(HaveKeyWithValue("image", map[string]interface{}{
+			"repository": "registry.k8s.io/autoscaling/cluster-autoscaler",
+			"tag":        "v1.30.0",
+		}))
+		Expect(opts.Values).To(HaveKeyWithValue("autoDiscovery", map[string]interface{}{"clusterName": "my-cluster"}))
+	})
+
+	It("installs the given version", func() {
+		installer.ImageTag = "v1.30.2"
+		Expect(installer.Install(context.Background())).To(Succeed())
+		_, opts := helmInstaller.InstallChartArgsForCall(0)
+		Expect(opts.Values["image"]).To(HaveKeyWithValue("tag", "v1.30.2"))
+	})
+})
@@ -0,0 +1,267 @@
+package clusterbootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	// ManagedByLabel marks the resources created by eksctl from clusterBootstrap
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	managedBy      = "eksctl"
+
+	// defaultName is the name of the ResourceQuota and the LimitRange of a namespace
+	defaultName = "default"
+)
+
+// Applier creates the resources of the clusterBootstrap section of a cluster, updating the existing ones
+type Applier struct {
+	ClientSet kubeclient.Interface
+}
+
+// Apply creates or updates the namespaces, cluster role bindings and priority classes; it is safe to run again
+func (a *Applier) Apply(ctx context.Context, bootstrap *api.ClusterBootstrap) error {
+	for _, pc := range bootstrap.PriorityClasses {
+		if err := a.applyPriorityClass(ctx, pc); err != nil {
+			return fmt.Errorf("applying priority class %q: %w", pc.Name, err)
+		}
+	}
+	for _, ns := range bootstrap.Namespaces {
+		if err := a.applyNamespace(ctx, ns, bootstrap.NamespaceLabels(ns)); err != nil {
+			return fmt.Errorf("applying namespace %q: %w", ns.Name, err)
+		}
+	}
+	for _, binding := range bootstrap.ClusterRoleBindings {
+		if err := a.applyClusterRoleBinding(ctx, binding); err != nil {
+			return fmt.Errorf("applying cluster role binding %q: %w", binding.Name, err)
+		}
+	}
+	return nil
+}
+
+func (a *Applier) applyNamespace(ctx context.Context, ns api.BootstrapNamespace, labels map[string]string) error {
+	namespaces := a.ClientSet.CoreV1().Namespaces()
+	existing, err := namespaces.Get(ctx, ns.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		namespace := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   ns.Name,
+				Labels: withManagedBy(labels),
+			},
+		}
+		if _, err := namespaces.Create(ctx, namespace, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		logger.Info("created namespace %q", ns.Name)
+	case err != nil:
+		return err
+	case !hasLabels(existing.Labels, labels):
+		// labels set outside of eksctl are kept
+		if existing.Labels == nil {
+			existing.Labels = map[string]string{}
+		}
+		for k, v := range labels {
+			existing.Labels[k] = v
+		}
+		if _, err := namespaces.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		logger.Info("updated the labels of namespace %q", ns.Name)
+	}
+
+	if len(ns.ResourceQuota) > 0 {
+		hard, err := toResourceList(ns.ResourceQuota)
+		if err != nil {
+			return err
+		}
+		if err := a.applyResourceQuota(ctx, &corev1.ResourceQuota{
+			ObjectMeta: objectMeta(defaultName, ns.Name),
+			Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+		}); err != nil {
+			return fmt.Errorf("applying ResourceQuota: %w", err)
+		}
+	}
+	if ns.LimitRange != nil {
+		limitRange, err := makeLimitRange(ns.Name, ns.LimitRange)
+		if err != nil {
+			return err
+		}
+		if err := a.applyLimitRange(ctx, limitRange); err != nil {
+			return fmt.Errorf("applying LimitRange: %w", err)
+		}
+	}
+	return nil
+}
+
+func (a *Applier) applyResourceQuota(ctx context.Context, quota *corev1.ResourceQuota) error {
+	quotas := a.ClientSet.CoreV1().ResourceQuotas(quota.Namespace)
+	existing, err := quotas.Get(ctx, quota.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		_, err = quotas.Create(ctx, quota, metav1.CreateOptions{})
+		return err
+	case err != nil:
+		return err
+	default:
+		existing.Spec = quota.Spec
+		_, err = quotas.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+func (a *Applier) applyLimitRange(ctx context.Context, limitRange *corev1.LimitRange) error {
+	limitRanges := a.ClientSet.CoreV1().LimitRanges(limitRange.Namespace)
+	existing, err := limitRanges.Get(ctx, limitRange.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		_, err = limitRanges.Create(ctx, limitRange, metav1.CreateOptions{})
+		return err
+	case err != nil:
+		return err
+	default:
+		existing.Spec = limitRange.Spec
+		_, err = limitRanges.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+func (a *Applier) applyClusterRoleBinding(ctx context.Context, binding api.BootstrapClusterRoleBinding) error {
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: objectMeta(binding.Name, ""),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     binding.ClusterRole,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				APIGroup: rbacv1.GroupName,
+				Kind:     rbacv1.GroupKind,
+				Name:     binding.Group,
+			},
+		},
+	}
+	clusterRoleBindings := a.ClientSet.RbacV1().ClusterRoleBindings()
+	existing, err := clusterRoleBindings.Get(ctx, binding.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		if _, err := clusterRoleBindings.Create(ctx, clusterRoleBinding, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		logger.Info("bound cluster role %q to group %q", binding.ClusterRole, binding.Group)
+		return nil
+	case err != nil:
+		return err
+	case existing.RoleRef != clusterRoleBinding.RoleRef:
+		// the role of a binding cannot be changed
+		if err := clusterRoleBindings.Delete(ctx, binding.Name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+		_, err = clusterRoleBindings.Create(ctx, clusterRoleBinding, metav1.CreateOptions{})
+		return err
+	default:
+		existing.Subjects = clusterRoleBinding.Subjects
+		_, err = clusterRoleBindings.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+func (a *Applier) applyPriorityClass(ctx context.Context, pc api.BootstrapPriorityClass) error {
+	priorityClass := &schedulingv1.PriorityClass{
+		ObjectMeta:    objectMeta(pc.Name, ""),
+		Value:         pc.Value,
+		GlobalDefault: pc.GlobalDefault,
+		Description:   pc.Description,
+	}
+	priorityClasses := a.ClientSet.SchedulingV1().PriorityClasses()
+	existing, err := priorityClasses.Get(ctx, pc.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		if _, err := priorityClasses.Create(ctx, priorityClass, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		logger.Info("created priority class %q", pc.Name)
+		return nil
+	case err != nil:
+		return err
+	case existing.Value != pc.Value:
+		return fmt.Errorf("the value of an existing priority class cannot be changed from %d to %d, delete it first", existing.Value, pc.Value)
+	default:
+		existing.GlobalDefault = pc.GlobalDefault
+		existing.Description = pc.Description
+		_, err = priorityClasses.Update(ctx, existing, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+func makeLimitRange(namespace string, limits *api.BootstrapLimitRange) (*corev1.LimitRange, error) {
+	item := corev1.LimitRangeItem{Type: corev1.LimitTypeContainer}
+	var err error
+	if item.Default, err = toResourceList(limits.Default); err != nil {
+		return nil, err
+	}
+	if item.DefaultRequest, err = toResourceList(limits.DefaultRequest); err != nil {
+		return nil, err
+	}
+	if item.Max, err = toResourceList(limits.Max); err != nil {
+		return nil, err
+	}
+	return &corev1.LimitRange{
+		ObjectMeta: objectMeta(defaultName, namespace),
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{item},
+		},
+	}, nil
+}
+
+func toResourceList(quantities map[string]string) (corev1.ResourceList, error) {
+	if len(quantities) == 0 {
+		return nil, nil
+	}
+	resources := corev1.ResourceList{}
+	for name, value := range quantities {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q of %s: %w", value, name, err)
+		}
+		resources[corev1.ResourceName(name)] = quantity
+	}
+	return resources, nil
+}
+
+func objectMeta(name, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    withManagedBy(nil),
+	}
+}
+
+func withManagedBy(labels map[string]string) map[string]string {
+	merged := map[string]string{ManagedByLabel: managedBy}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	return merged
+}
+
+func hasLabels(existing, labels map[string]string) bool {
+	for k, v := range labels {
+		if existing[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+package clusterbootstrap_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/clusterbootstrap"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("Cluster bootstrap applier", func() {
+	var (
+		clientSet *fake.Clientset
+		applier   *clusterbootstrap.Applier
+		bootstrap *api.ClusterBootstrap
+		ctx       context.Context
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		clientSet = fake.NewSimpleClientset()
+		applier = &clusterbootstrap.Applier{ClientSet: clientSet}
+		bootstrap = &api.ClusterBootstrap{
+			Namespaces: []api.BootstrapNamespace{
+				{
+					Name:          "team-a",
+					Labels:        map[string]string{"team": "a"},
+					ResourceQuota: map[string]string{"requests.cpu": "10", "pods": "50"},
+					LimitRange: &api.BootstrapLimitRange{
+						Default:        map[string]string{"memory": "512Mi"},
+						DefaultRequest: map[string]string{"cpu": "100m"},
+					},
+				},
+			},
+			ClusterRoleBindings: []api.BootstrapClusterRoleBinding{
+				{Name: "developers-view", ClusterRole: "view", Group: "developers"},
+			},
+			PriorityClasses: []api.BootstrapPriorityClass{
+				{Name: "critical-apps", Value: 100000, Description: "business critical applications"},
+			},
+		}
+	})
+
+	It("creates the namespaces, their quotas and limits, cluster role bindings and priority classes", func() {
+		Expect(applier.Apply(ctx, bootstrap)).To(Succeed())
+
+		ns, err := clientSet.CoreV1().Namespaces().Get(ctx, "team-a", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ns.Labels).To(Equal(map[string]string{"team": "a", clusterbootstrap.ManagedByLabel: "eksctl"}))
+
+		quota, err := clientSet.CoreV1().ResourceQuotas("team-a").Get(ctx, "default", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quota.Spec.Hard).To(HaveKeyWithValue(corev1.ResourceName("requests.cpu"), resource.MustParse("10")))
+		Expect(quota.Spec.Hard).To(HaveKeyWithValue(corev1.ResourcePods, resource.MustParse("50")))
+
+		limitRange, err := clientSet.CoreV1().LimitRanges("team-a").Get(ctx, "default", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(limitRange.Spec.Limits).To(HaveLen(1))
+		Expect(limitRange.Spec.Limits[0].Type).To(Equal(corev1.LimitTypeContainer))
+		Expect(limitRange.Spec.Limits[0].Default).To(HaveKeyWithValue(corev1.ResourceMemory, resource.MustParse("512Mi")))
+		Expect(limitRange.Spec.Limits[0].DefaultRequest).To(HaveKeyWithValue(corev1.ResourceCPU, resource.MustParse("100m")))
+
+		binding, err := clientSet.RbacV1().ClusterRoleBindings().Get(ctx, "developers-view", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(binding.RoleRef.Name).To(Equal("view"))
+		Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: "developers"}))
+
+		pc, err := clientSet.SchedulingV1().PriorityClasses().Get(ctx, "critical-apps", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pc.Value).To(Equal(int32(100000)))
+	})
+
+	It("updates the existing resources, keeping the labels set outside of eksctl", func() {
+		_, err := clientSet.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"owner": "platform", "team": "b"}},
+		}, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applier.Apply(ctx, bootstrap)).To(Succeed())
+
+		bootstrap.ClusterRoleBindings[0].ClusterRole = "edit"
+		bootstrap.Namespaces[0].ResourceQuota["pods"] = "100"
+		Expect(applier.Apply(ctx, bootstrap)).To(Succeed())
+
+		ns, err := clientSet.CoreV1().Namespaces().Get(ctx, "team-a", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ns.Labels).To(Equal(map[string]string{"owner": "platform", "team": "a"}))
+		quota, err := clientSet.CoreV1().ResourceQuotas("team-a").Get(ctx, "default", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quota.Spec.Hard).To(HaveKeyWithValue(corev1.ResourcePods, resource.MustParse("100")))
+		binding, err := clientSet.RbacV1().ClusterRoleBindings().Get(ctx, "developers-view", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(binding.RoleRef.Name).To(Equal("edit"))
+	})
+
+	It("labels the namespaces with their Pod Security Admission levels", func() {
+		bootstrap.PodSecurity = &api.PodSecurity{Enforce: api.PodSecurityBaseline, Warn: api.PodSecurityRestricted, Version: "v1.30"}
+		bootstrap.Namespaces = append(bootstrap.Namespaces, api.BootstrapNamespace{
+			Name:        "monitoring",
+			PodSecurity: &api.PodSecurity{Enforce: api.PodSecurityPrivileged},
+		})
+		Expect(applier.Apply(ctx, bootstrap)).To(Succeed())
+
+		ns, err := clientSet.CoreV1().Namespaces().Get(ctx, "team-a", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ns.Labels).To(Equal(map[string]string{
+			"team":                                       "a",
+			clusterbootstrap.ManagedByLabel:              "eksctl",
+			"pod-security.kubernetes.io/enforce":         "baseline",
+			"pod-security.kubernetes.io/enforce-version": "v1.30",
+			"pod-security.kubernetes.io/warn":            "restricted",
+			"pod-security.kubernetes.io/warn-version":    "v1.30",
+		}))
+		ns, err = clientSet.CoreV1().Namespaces().Get(ctx, "monitoring", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ns.Labels).To(HaveKeyWithValue("pod-security.kubernetes.io/enforce", "privileged"))
+		Expect(ns.Labels).To(HaveKeyWithValue("pod-security.kubernetes.io/warn", "restricted"))
+	})
+
+	It("does not change the value of an existing priority class", func() {
+		_, err := clientSet.SchedulingV1().PriorityClasses().Create(ctx, &schedulingv1.PriorityClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "critical-apps"},
+			Value:      1000,
+		}, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applier.Apply(ctx, bootstrap)).To(MatchError(ContainSubstring("cannot be changed from 1000 to 100000")))
+	})
+})
@@ -0,0 +1,218 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/addons"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers"
+)
+
+const (
+	// ExternalDNSNamespace is the namespace external-dns is installed in
+	ExternalDNSNamespace = "external-dns"
+	// ExternalDNSServiceAccountName is the name of the service account of external-dns
+	ExternalDNSServiceAccountName = "external-dns"
+	// CertManagerNamespace is the namespace cert-manager is installed in
+	CertManagerNamespace = "cert-manager"
+	// CertManagerServiceAccountName is the name of the service account of cert-manager
+	CertManagerServiceAccountName = "cert-manager"
+
+	defaultExternalDNSVersion = "1.15.0"
+	externalDNSChartName      = "external-dns"
+	externalDNSRepoURL        = "https://kubernetes-sigs.github.io/external-dns/"
+
+	defaultCertManagerVersion = "v1.16.1"
+	certManagerChartName      = "cert-manager"
+	certManagerRepoURL        = "https://charts.jetstack.io"
+)
+
+// Installer installs external-dns and cert-manager in a cluster, with IAM roles allowed to change the records of the
+// configured hosted zones only
+type Installer struct {
+	ClusterConfig            *api.ClusterConfig
+	IRSA                     addons.IRSAHelper
+	ExternalDNSHelmInstaller providers.HelmInstaller
+	CertManagerHelmInstaller providers.HelmInstaller
+}
+
+// Install creates the IAM roles and installs the configured integrations; it is safe to run again to update them
+func (i *Installer) Install(ctx context.Context) error {
+	supported, err := i.IRSA.IsSupported(ctx)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("no IAM OIDC provider associated with cluster, try 'eksctl utils associate-iam-oidc-provider --region=%s --cluster=%s'", i.ClusterConfig.Metadata.Region, i.ClusterConfig.Metadata.Name)
+	}
+
+	dns := i.ClusterConfig.DNS
+	if dns.ExternalDNS != nil {
+		if err := i.installExternalDNS(ctx, dns); err != nil {
+			return err
+		}
+	}
+	if dns.CertManager != nil {
+		if err := i.installCertManager(ctx, dns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Installer) installExternalDNS(ctx context.Context, dns *api.DNS) error {
+	logger.Info("creating the IAM role of external-dns")
+	if err := i.IRSA.CreateOrUpdate(ctx, &api.ClusterIAMServiceAccount{
+		ClusterIAMMeta: api.ClusterIAMMeta{
+			Name:      ExternalDNSServiceAccountName,
+			Namespace: ExternalDNSNamespace,
+		},
+		AttachPolicy: makeExternalDNSPolicyDocument(i.partition(), dns.HostedZoneIDs),
+	}); err != nil {
+		return fmt.Errorf("creating the IAM role of external-dns: %w", err)
+	}
+
+	version := dns.ExternalDNS.Version
+	if version == "" {
+		version = defaultExternalDNSVersion
+	}
+	var zoneIDFilters []string
+	for _, id := range dns.HostedZoneIDs {
+		zoneIDFilters = append(zoneIDFilters, "--zone-id-filter="+id)
+	}
+	logger.Info("installing the external-dns chart version %s", version)
+	if err := i.ExternalDNSHelmInstaller.InstallChart(ctx, providers.InstallChartOpts{
+		ChartName:   externalDNSChartName,
+		RepoURL:     externalDNSRepoURL,
+		Namespace:   ExternalDNSNamespace,
+		ReleaseName: externalDNSChartName,
+		Version:     version,
+		Values: map[string]interface{}{
+			"provider": map[string]interface{}{
+				"name": "aws",
+			},
+			"env": []interface{}{
+				map[string]interface{}{
+					"name":  "AWS_DEFAULT_REGION",
+					"value": i.ClusterConfig.Metadata.Region,
+				},
+			},
+			"policy":        dns.ExternalDNS.PolicyOrDefault(),
+			"txtOwnerId":    i.ClusterConfig.Metadata.Name,
+			"domainFilters": dns.ExternalDNS.DomainFilters,
+			"extraArgs":     zoneIDFilters,
+			"serviceAccount": map[string]interface{}{
+				"create": false,
+				"name":   ExternalDNSServiceAccountName,
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to install external-dns chart: %w", err)
+	}
+	return nil
+}
+
+func (i *Installer) installCertManager(ctx context.Context, dns *api.DNS) error {
+	logger.Info("creating the IAM role of cert-manager")
+	if err := i.IRSA.CreateOrUpdate(ctx, &api.ClusterIAMServiceAccount{
+		ClusterIAMMeta: api.ClusterIAMMeta{
+			Name:      CertManagerServiceAccountName,
+			Namespace: CertManagerNamespace,
+		},
+		AttachPolicy: makeCertManagerPolicyDocument(i.partition(), dns.HostedZoneIDs),
+	}); err != nil {
+		return fmt.Errorf("creating the IAM role of cert-manager: %w", err)
+	}
+
+	version := dns.CertManager.Version
+	if version == "" {
+		version = defaultCertManagerVersion
+	}
+	logger.Info("installing the cert-manager chart version %s", version)
+	if err := i.CertManagerHelmInstaller.InstallChart(ctx, providers.InstallChartOpts{
+		ChartName:   certManagerChartName,
+		RepoURL:     certManagerRepoURL,
+		Namespace:   CertManagerNamespace,
+		ReleaseName: certManagerChartName,
+		Version:     version,
+		Values: map[string]interface{}{
+			"crds": map[string]interface{}{
+				"enabled": true,
+			},
+			"serviceAccount": map[string]interface{}{
+				"create": false,
+				"name":   CertManagerServiceAccountName,
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to install cert-manager chart: %w", err)
+	}
+	return nil
+}
+
+func (i *Installer) partition() string {
+	return api.Partitions.ForRegion(i.ClusterConfig.Metadata.Region)
+}
+
+func hostedZoneARNs(partition string, hostedZoneIDs []string) []string {
+	var arns []string
+	for _, id := range hostedZoneIDs {
+		arns = append(arns, fmt.Sprintf("arn:%s:route53:::hostedzone/%s", partition, id))
+	}
+	return arns
+}
+
+// makeExternalDNSPolicyDocument allows changing the records of the hosted zones, and listing the hosted zones and
+// records external-dns filters
+func makeExternalDNSPolicyDocument(partition string, hostedZoneIDs []string) map[string]interface{} {
+	return map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{
+			{
+				"Effect":   "Allow",
+				"Action":   []string{"route53:ChangeResourceRecordSets"},
+				"Resource": hostedZoneARNs(partition, hostedZoneIDs),
+			},
+			{
+				"Effect": "Allow",
+				"Action": []string{
+					"route53:ListHostedZones",
+					"route53:ListResourceRecordSets",
+					"route53:ListTagsForResource",
+				},
+				"Resource": "*",
+			},
+		},
+	}
+}
+
+// makeCertManagerPolicyDocument allows the DNS-01 solver of cert-manager to change the records of the hosted zones and
+// to wait for the changes to propagate
+func makeCertManagerPolicyDocument(partition string, hostedZoneIDs []string) map[string]interface{} {
+	return map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{
+			{
+				"Effect":   "Allow",
+				"Action":   []string{"route53:GetChange"},
+				"Resource": fmt.Sprintf("arn:%s:route53:::change/*", partition),
+			},
+			{
+				"Effect": "Allow",
+				"Action": []string{
+					"route53:ChangeResourceRecordSets",
+					"route53:ListResourceRecordSets",
+				},
+				"Resource": hostedZoneARNs(partition, hostedZoneIDs),
+			},
+			{
+				"Effect":   "Allow",
+				"Action":   []string{"route53:ListHostedZonesByName"},
+				"Resource": "*",
+			},
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package dns_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/dns"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers/fakes"
+)
+
+type fakeIRSA struct {
+	supported       bool
+	serviceAccounts []*api.ClusterIAMServiceAccount
+}
+
+func (f *fakeIRSA) IsSupported(_ context.Context) (bool, error) {
+	return f.supported, nil
+}
+
+func (f *fakeIRSA) CreateOrUpdate(_ context.Context, sa *api.ClusterIAMServiceAccount) error {
+	f.serviceAccounts = append(f.serviceAccounts, sa)
+	return nil
+}
+
+var _ = Describe("DNS installer", func() {
+	var (
+		irsaHelper         *fakeIRSA
+		externalDNSHelm    *fakes.FakeHelmInstaller
+		certManagerHelm    *fakes.FakeHelmInstaller
+		cfg                *api.ClusterConfig
+		installer          *dns.Installer
+		hostedZoneResource = []string{"arn:aws:route53:::hostedzone/Z1", "arn:aws:route53:::hostedzone/Z2"}
+	)
+
+	BeforeEach(func() {
+		irsaHelper = &fakeIRSA{supported: true}
+		externalDNSHelm = &fakes.FakeHelmInstaller{}
+		certManagerHelm = &fakes.FakeHelmInstaller{}
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Metadata.Region = "us-west-2"
+		cfg.DNS = &api.DNS{
+			HostedZoneIDs: []string{"Z1", "Z2"},
+			ExternalDNS: &api.ExternalDNS{
+				DomainFilters: []string{"example.com"},
+			},
+			CertManager: &api.CertManager{},
+		}
+		installer = &dns.Installer{
+			ClusterConfig:            cfg,
+			IRSA:                     irsaHelper,
+			ExternalDNSHelmInstaller: externalDNSHelm,
+			CertManagerHelmInstaller: certManagerHelm,
+		}
+	})
+
+	It("creates IAM roles scoped to the hosted zones and installs external-dns and cert-manager", func() {
+		Expect(installer.Install(context.Background())).To(Succeed())
+
+		Expect(irsaHelper.serviceAccounts).To(HaveLen(2))
+		externalDNSSA := irsaHelper.serviceAccounts[0]
+		Expect(externalDNSSA.Namespace).To(Equal(dns.ExternalDNSNamespace))
+		Expect(externalDNSSA.Name).To(Equal(dns.ExternalDNSServiceAccountName))
+		Expect(externalDNSSA.AttachPolicy["Statement"]).To(ContainElement(And(
+			HaveKeyWithValue("Action", []string{"route53:ChangeResourceRecordSets"}),
+			HaveKeyWithValue("Resource", hostedZoneResource),
+		)))
+		certManagerSA := irsaHelper.serviceAccounts[1]
+		Expect(certManagerSA.Namespace).To(Equal(dns.CertManagerNamespace))
+		Expect(certManagerSA.AttachPolicy["Statement"]).To(ContainElement(And(
+			HaveKeyWithValue("Action", []string{"route53:ChangeResourceRecordSets", "route53:ListResourceRecordSets"}),
+			HaveKeyWithValue("Resource", hostedZoneResource),
+		)))
+
+		Expect(externalDNSHelm.InstallChartCallCount()).To(Equal(1))
+		_, opts := externalDNSHelm.InstallChartArgsForCall(0)
+		Expect(opts.ChartName).To(Equal("external-dns"))
+		Expect(opts.Namespace).To(Equal(dns.ExternalDNSNamespace))
+		Expect(opts.Version).To(Equal("1.15.0"))
+		Expect(opts.Values).To(HaveKeyWithValue("policy", api.ExternalDNSPolicyUpsertOnly))
+		Expect(opts.Values).To(HaveKeyWithValue("txtOwnerId", "my-cluster"))
+		Expect(opts.Values).To(HaveKeyWithValue("domainFilters", []string{"example.com"}))
+		Expect(opts.Values).To(HaveKeyWithValue("extraArgs", []string{"--zone-id-filter=Z1", "--zone-id-filter=Z2"}))
+
+		Expect(certManagerHelm.InstallChartCallCount()).To(Equal(1))
+		_, opts = certManagerHelm.InstallChartArgsForCall(0)
+		Expect(opts.ChartName).To(Equal("cert-manager"))
+		Expect(opts.Namespace).To(Equal(dns.CertManagerNamespace))
+		Expect(opts.Version).To(Equal("v1.16.1"))
+	})
+
+	It("installs only the configured integrations", func() {
+		cfg.DNS.CertManager = nil
+		cfg.DNS.ExternalDNS.Version = "1.14.5"
+		cfg.DNS.ExternalDNS.Policy = api.ExternalDNSPolicySync
+		installer.CertManagerHelmInstaller = nil
+		Expect(installer.Install(context.Background())).To(Succeed())
+
+		Expect(irsaHelper.serviceAccounts).To(HaveLen(1))
+		_, opts := externalDNSHelm.InstallChartArgsForCall(0)
+		Expect(opts.Version).To(Equal("1.14.5"))
+		Expect(opts.Values).To(HaveKeyWithValue("policy", api.ExternalDNSPolicySync))
+	})
+
+	It("requires an IAM OIDC provider", func() {
+		irsaHelper.supported = false
+		Expect(installer.Install(context.Background())).To(MatchError(ContainSubstring("no IAM OIDC provider associated with cluster")))
+		Expect(externalDNSHelm.InstallChartCallCount()).To(Equal(0))
+	})
+})
@@ -0,0 +1,13 @@
+package ebs_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestEBS(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "EBS Suite")
+}
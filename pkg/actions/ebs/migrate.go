@@ -0,0 +1,126 @@
+package ebs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	// DefaultStorageClassName is the name of the gp3 StorageClass of the EBS CSI driver
+	DefaultStorageClassName = "gp3"
+
+	csiProvisioner    = "ebs.csi.aws.com"
+	inTreeProvisioner = "kubernetes.io/aws-ebs"
+
+	provisionedByAnnotation = "pv.kubernetes.io/provisioned-by"
+)
+
+// AddonCreator creates EKS addons
+type AddonCreator interface {
+	Create(ctx context.Context, addon *api.Addon, iamRoleCreator addon.IAMRoleCreator, waitTimeout time.Duration) error
+}
+
+// Migrator moves a cluster from the in-tree EBS provisioner to the EBS CSI driver
+type Migrator struct {
+	Addons    AddonCreator
+	ClientSet kubeclient.Interface
+
+	StorageClassName string
+	// DefaultStorageClass makes the gp3 StorageClass the default one of the cluster
+	DefaultStorageClass bool
+	WaitTimeout         time.Duration
+}
+
+// InTreeVolume is a PersistentVolume provisioned by the in-tree EBS provisioner
+type InTreeVolume struct {
+	Name         string
+	Claim        string
+	StorageClass string
+	VolumeID     string
+	Capacity     string
+}
+
+// Migrate installs the aws-ebs-csi-driver addon and creates the gp3 StorageClass; it returns the PersistentVolumes
+// still provisioned by the in-tree provisioner
+func (m *Migrator) Migrate(ctx context.Context) ([]InTreeVolume, error) {
+	if err := m.Addons.Create(ctx, &api.Addon{
+		Name: api.AWSEBSCSIDriverAddon,
+		WellKnownPolicies: api.WellKnownPolicies{
+			EBSCSIController: true,
+		},
+	}, nil, m.WaitTimeout); err != nil {
+		return nil, err
+	}
+
+	storageClassName := m.StorageClassName
+	if storageClassName == "" {
+		storageClassName = DefaultStorageClassName
+	}
+	volumeBindingMode := storagev1.VolumeBindingWaitForFirstConsumer
+	allowVolumeExpansion := true
+	if err := kubernetes.MaybeCreateStorageClass(ctx, m.ClientSet, &storagev1.StorageClass{
+		ObjectMeta:           metav1.ObjectMeta{Name: storageClassName},
+		Provisioner:          csiProvisioner,
+		VolumeBindingMode:    &volumeBindingMode,
+		AllowVolumeExpansion: &allowVolumeExpansion,
+		Parameters: map[string]string{
+			"type":      "gp3",
+			"encrypted": "true",
+		},
+	}); err != nil {
+		return nil, err
+	}
+	if m.DefaultStorageClass {
+		if err := kubernetes.SetDefaultStorageClass(ctx, m.ClientSet, storageClassName); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.InTreeVolumes(ctx)
+}
+
+// InTreeVolumes returns the PersistentVolumes provisioned by the in-tree EBS provisioner; they keep working through
+// CSI migration, but are not managed with the features of the EBS CSI driver, e.g. gp3 volumes or snapshots
+func (m *Migrator) InTreeVolumes(ctx context.Context) ([]InTreeVolume, error) {
+	pvs, err := m.ClientSet.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing PersistentVolumes: %w", err)
+	}
+	var volumes []InTreeVolume
+	for _, pv := range pvs.Items {
+		if pv.Spec.AWSElasticBlockStore == nil && pv.Annotations[provisionedByAnnotation] != inTreeProvisioner {
+			continue
+		}
+		volume := InTreeVolume{
+			Name:         pv.Name,
+			StorageClass: pv.Spec.StorageClassName,
+		}
+		if pv.Spec.AWSElasticBlockStore != nil {
+			volume.VolumeID = pv.Spec.AWSElasticBlockStore.VolumeID
+		}
+		if claim := pv.Spec.ClaimRef; claim != nil {
+			volume.Claim = claim.Namespace + "/" + claim.Name
+		}
+		if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+			volume.Capacity = capacity.String()
+		}
+		volumes = append(volumes, volume)
+	}
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Name < volumes[j].Name
+	})
+	logger.Debug("found %d PersistentVolume(s) provisioned by %s", len(volumes), inTreeProvisioner)
+	return volumes, nil
+}
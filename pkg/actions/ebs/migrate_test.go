@@ -0,0 +1,123 @@
+package ebs_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/ebs"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+type fakeAddons struct {
+	addons []*api.Addon
+}
+
+func (f *fakeAddons) Create(_ context.Context, a *api.Addon, _ addon.IAMRoleCreator, _ time.Duration) error {
+	f.addons = append(f.addons, a)
+	return nil
+}
+
+var _ = Describe("Migrate to the EBS CSI driver", func() {
+	var (
+		addons    *fakeAddons
+		clientSet *fake.Clientset
+		migrator  *ebs.Migrator
+	)
+
+	BeforeEach(func() {
+		addons = &fakeAddons{}
+		clientSet = fake.NewSimpleClientset(
+			&storagev1.StorageClass{
+				ObjectMeta:  metav1.ObjectMeta{Name: "gp2", Annotations: map[string]string{kubernetes.DefaultStorageClassAnnotation: "true"}},
+				Provisioner: "kubernetes.io/aws-ebs",
+			},
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pvc-in-tree"},
+				Spec: corev1.PersistentVolumeSpec{
+					StorageClassName: "gp2",
+					Capacity:         corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+					ClaimRef:         &corev1.ObjectReference{Namespace: "default", Name: "data"},
+					PersistentVolumeSource: corev1.PersistentVolumeSource{
+						AWSElasticBlockStore: &corev1.AWSElasticBlockStoreVolumeSource{VolumeID: "aws://us-west-2a/vol-1"},
+					},
+				},
+			},
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pvc-csi"},
+				Spec: corev1.PersistentVolumeSpec{
+					StorageClassName: "gp3",
+					PersistentVolumeSource: corev1.PersistentVolumeSource{
+						CSI: &corev1.CSIPersistentVolumeSource{Driver: "ebs.csi.aws.com", VolumeHandle: "vol-2"},
+					},
+				},
+			},
+		)
+		migrator = &ebs.Migrator{
+			Addons:    addons,
+			ClientSet: clientSet,
+		}
+	})
+
+	It("installs the CSI driver addon and the gp3 StorageClass, and reports in-tree volumes", func() {
+		volumes, err := migrator.Migrate(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(volumes).To(Equal([]ebs.InTreeVolume{{
+			Name:         "pvc-in-tree",
+			Claim:        "default/data",
+			StorageClass: "gp2",
+			VolumeID:     "aws://us-west-2a/vol-1",
+			Capacity:     "10Gi",
+		}}))
+
+		Expect(addons.addons).To(HaveLen(1))
+		Expect(addons.addons[0].Name).To(Equal(api.AWSEBSCSIDriverAddon))
+		Expect(addons.addons[0].WellKnownPolicies.EBSCSIController).To(BeTrue())
+
+		sc, err := clientSet.StorageV1().StorageClasses().Get(context.Background(), ebs.DefaultStorageClassName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sc.Provisioner).To(Equal("ebs.csi.aws.com"))
+		Expect(sc.Parameters).To(HaveKeyWithValue("type", "gp3"))
+		Expect(*sc.VolumeBindingMode).To(Equal(storagev1.VolumeBindingWaitForFirstConsumer))
+		Expect(sc.Annotations).NotTo(HaveKey(kubernetes.DefaultStorageClassAnnotation))
+	})
+
+	It("makes the gp3 StorageClass the default one", func() {
+		migrator.DefaultStorageClass = true
+		_, err := migrator.Migrate(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		sc, err := clientSet.StorageV1().StorageClasses().Get(context.Background(), ebs.DefaultStorageClassName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sc.Annotations).To(HaveKeyWithValue(kubernetes.DefaultStorageClassAnnotation, "true"))
+		gp2, err := clientSet.StorageV1().StorageClasses().Get(context.Background(), "gp2", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gp2.Annotations).To(HaveKeyWithValue(kubernetes.DefaultStorageClassAnnotation, "false"))
+	})
+
+	It("reports volumes provisioned by the in-tree provisioner and migrated to CSI", func() {
+		_, err := clientSet.CoreV1().PersistentVolumes().Create(context.Background(), &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pvc-migrated",
+				Annotations: map[string]string{"pv.kubernetes.io/provisioned-by": "kubernetes.io/aws-ebs"},
+			},
+			Spec: corev1.PersistentVolumeSpec{StorageClassName: "gp2"},
+		}, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		volumes, err := migrator.InTreeVolumes(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(volumes).To(HaveLen(2))
+		Expect(volumes[0].Name).To(Equal("pvc-in-tree"))
+		Expect(volumes[1].Name).To(Equal("pvc-migrated"))
+	})
+})
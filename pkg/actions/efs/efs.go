@@ -0,0 +1,210 @@
+package efs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	// DefaultStorageClassName is the name of the StorageClass provisioning volumes on the filesystem
+	DefaultStorageClassName = "efs-sc"
+
+	provisioner = "efs.csi.aws.com"
+)
+
+// AddonCreator creates EKS addons
+type AddonCreator interface {
+	Create(ctx context.Context, addon *api.Addon, iamRoleCreator addon.IAMRoleCreator, waitTimeout time.Duration) error
+}
+
+// Creator sets up an EFS filesystem for a cluster, along with the EFS CSI driver and a StorageClass
+type Creator struct {
+	StackManager  manager.StackManager
+	ClusterConfig *api.ClusterConfig
+	// Cluster is the description of the cluster, used for its VPC and subnets
+	Cluster   *ekstypes.Cluster
+	EC2       awsapi.EC2
+	Addons    AddonCreator
+	ClientSet kubeclient.Interface
+
+	StorageClassName string
+	// DefaultStorageClass makes the StorageClass the default one of the cluster
+	DefaultStorageClass bool
+	WaitTimeout         time.Duration
+}
+
+// MakeStackName returns the name of the EFS stack of a cluster
+func MakeStackName(clusterName string) string {
+	return manager.MakeEFSStackName(clusterName)
+}
+
+// Create creates or updates the EFS stack, installs the EFS CSI driver addon and creates the
+// StorageClass; it returns the ID of the filesystem
+func (c *Creator) Create(ctx context.Context) (string, error) {
+	vpcID := aws.ToString(c.Cluster.ResourcesVpcConfig.VpcId)
+	cidrs, ipv6CIDRs, err := c.vpcCIDRs(ctx, vpcID)
+	if err != nil {
+		return "", err
+	}
+	subnetIDs, err := c.mountTargetSubnets(ctx)
+	if err != nil {
+		return "", err
+	}
+	resourceSet := builder.NewEFSResourceSet(builder.EFSResourceSetOptions{
+		ClusterName: c.ClusterConfig.Metadata.Name,
+		VPCID:       vpcID,
+		CIDRs:       cidrs,
+		IPv6CIDRs:   ipv6CIDRs,
+		SubnetIDs:   subnetIDs,
+	})
+	if err := resourceSet.AddAllResources(); err != nil {
+		return "", err
+	}
+
+	stackName := MakeStackName(c.ClusterConfig.Metadata.Name)
+	stack, err := c.StackManager.DescribeStack(ctx, &manager.Stack{StackName: &stackName})
+	switch {
+	case err == nil:
+		// stacks created by earlier versions neither retain the filesystem nor allow IPv6 traffic
+		template, err := resourceSet.RenderJSON()
+		if err != nil {
+			return "", err
+		}
+		if err := c.StackManager.UpdateStack(ctx, manager.UpdateStackOptions{
+			Stack:         stack,
+			ChangeSetName: c.StackManager.MakeChangeSetName("update-efs"),
+			Description:   fmt.Sprintf("updating the filesystem of stack %q", stackName),
+			TemplateData:  manager.TemplateBody(template),
+			Wait:          true,
+		}); err != nil {
+			return "", errors.Wrapf(err, "updating stack %q", stackName)
+		}
+		if stack, err = c.StackManager.DescribeStack(ctx, stack); err != nil {
+			return "", err
+		}
+		if err := resourceSet.GetAllOutputs(*stack); err != nil {
+			return "", errors.Wrapf(err, "collecting outputs of stack %q", stackName)
+		}
+	case manager.IsStackDoesNotExistError(err):
+		errs := make(chan error)
+		if err := c.StackManager.CreateStack(ctx, stackName, resourceSet, nil, nil, errs); err != nil {
+			return "", err
+		}
+		if err := <-errs; err != nil {
+			return "", errors.Wrapf(err, "creating stack %q", stackName)
+		}
+	default:
+		return "", err
+	}
+
+	if err := c.Addons.Create(ctx, &api.Addon{
+		Name: api.AWSEFSCSIDriverAddon,
+		WellKnownPolicies: api.WellKnownPolicies{
+			EFSCSIController: true,
+		},
+	}, nil, c.WaitTimeout); err != nil {
+		return "", err
+	}
+
+	storageClassName := c.StorageClassName
+	if storageClassName == "" {
+		storageClassName = DefaultStorageClassName
+	}
+	if err := kubernetes.MaybeCreateStorageClass(ctx, c.ClientSet, &storagev1.StorageClass{
+		ObjectMeta:  metav1.ObjectMeta{Name: storageClassName},
+		Provisioner: provisioner,
+		Parameters: map[string]string{
+			"provisioningMode": "efs-ap",
+			"fileSystemId":     resourceSet.FileSystemID,
+			"directoryPerms":   "700",
+		},
+	}); err != nil {
+		return "", err
+	}
+	if c.DefaultStorageClass {
+		if err := kubernetes.SetDefaultStorageClass(ctx, c.ClientSet, storageClassName); err != nil {
+			return "", err
+		}
+	}
+	return resourceSet.FileSystemID, nil
+}
+
+// vpcCIDRs returns the IPv4 and IPv6 CIDR blocks of the VPC, the nodes and pods of the cluster mount the filesystem from
+func (c *Creator) vpcCIDRs(ctx context.Context, vpcID string) ([]string, []string, error) {
+	output, err := c.EC2.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{
+		VpcIds: []string{vpcID},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("describing VPC %q: %w", vpcID, err)
+	}
+	if len(output.Vpcs) == 0 {
+		return nil, nil, fmt.Errorf("VPC %q not found", vpcID)
+	}
+	var cidrs, ipv6CIDRs []string
+	for _, association := range output.Vpcs[0].CidrBlockAssociationSet {
+		if association.CidrBlockState != nil && association.CidrBlockState.State == ec2types.VpcCidrBlockStateCodeAssociated {
+			cidrs = append(cidrs, aws.ToString(association.CidrBlock))
+		}
+	}
+	for _, association := range output.Vpcs[0].Ipv6CidrBlockAssociationSet {
+		if association.Ipv6CidrBlockState != nil && association.Ipv6CidrBlockState.State == ec2types.VpcCidrBlockStateCodeAssociated {
+			ipv6CIDRs = append(ipv6CIDRs, aws.ToString(association.Ipv6CidrBlock))
+		}
+	}
+	return cidrs, ipv6CIDRs, nil
+}
+
+// mountTargetSubnets returns a subnet of the cluster in each of its availability zones, as a filesystem has at most
+// one mount target per availability zone; private subnets are preferred
+func (c *Creator) mountTargetSubnets(ctx context.Context) ([]string, error) {
+	output, err := c.EC2.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: c.Cluster.ResourcesVpcConfig.SubnetIds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing the subnets of the cluster: %w", err)
+	}
+	subnets := output.Subnets
+	sort.Slice(subnets, func(i, j int) bool {
+		iPublic, jPublic := aws.ToBool(subnets[i].MapPublicIpOnLaunch), aws.ToBool(subnets[j].MapPublicIpOnLaunch)
+		if iPublic != jPublic {
+			return !iPublic
+		}
+		return aws.ToString(subnets[i].SubnetId) < aws.ToString(subnets[j].SubnetId)
+	})
+
+	byZone := map[string]string{}
+	var zones []string
+	for _, subnet := range subnets {
+		zone := aws.ToString(subnet.AvailabilityZone)
+		if _, ok := byZone[zone]; ok {
+			continue
+		}
+		byZone[zone] = aws.ToString(subnet.SubnetId)
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	var subnetIDs []string
+	for _, zone := range zones {
+		subnetIDs = append(subnetIDs, byZone[zone])
+	}
+	return subnetIDs, nil
+}
@@ -0,0 +1,13 @@
+package efs_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestEFS(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "EFS Suite")
+}
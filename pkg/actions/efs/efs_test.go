@@ -0,0 +1,165 @@
+package efs_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/efs"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+type fakeAddons struct {
+	addons []*api.Addon
+}
+
+func (f *fakeAddons) Create(_ context.Context, a *api.Addon, _ addon.IAMRoleCreator, _ time.Duration) error {
+	f.addons = append(f.addons, a)
+	return nil
+}
+
+var _ = Describe("EFS", func() {
+	var (
+		provider     *mockprovider.MockProvider
+		stackManager *fakes.FakeStackManager
+		addons       *fakeAddons
+		clientSet    *fake.Clientset
+		creator      *efs.Creator
+		template     map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		stackManager = &fakes.FakeStackManager{}
+		addons = &fakeAddons{}
+		clientSet = fake.NewSimpleClientset(&storagev1.StorageClass{
+			ObjectMeta:  metav1.ObjectMeta{Name: "gp2", Annotations: map[string]string{kubernetes.DefaultStorageClassAnnotation: "true"}},
+			Provisioner: "kubernetes.io/aws-ebs",
+		})
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "shared"
+		creator = &efs.Creator{
+			StackManager:  stackManager,
+			ClusterConfig: cfg,
+			Cluster: &ekstypes.Cluster{
+				ResourcesVpcConfig: &ekstypes.VpcConfigResponse{
+					VpcId:     aws.String("vpc-1"),
+					SubnetIds: []string{"subnet-public-a", "subnet-private-a", "subnet-private-b"},
+				},
+			},
+			EC2:       provider.EC2(),
+			Addons:    addons,
+			ClientSet: clientSet,
+		}
+
+		provider.MockEC2().On("DescribeVpcs", mock.Anything, mock.Anything).Return(&ec2.DescribeVpcsOutput{
+			Vpcs: []ec2types.Vpc{{
+				CidrBlockAssociationSet: []ec2types.VpcCidrBlockAssociation{
+					{CidrBlock: aws.String("192.168.0.0/16"), CidrBlockState: &ec2types.VpcCidrBlockState{State: ec2types.VpcCidrBlockStateCodeAssociated}},
+					{CidrBlock: aws.String("10.0.0.0/16"), CidrBlockState: &ec2types.VpcCidrBlockState{State: ec2types.VpcCidrBlockStateCodeDisassociated}},
+				},
+				Ipv6CidrBlockAssociationSet: []ec2types.VpcIpv6CidrBlockAssociation{
+					{Ipv6CidrBlock: aws.String("2600:1f14:abc:de00::/56"), Ipv6CidrBlockState: &ec2types.VpcCidrBlockState{State: ec2types.VpcCidrBlockStateCodeAssociated}},
+				},
+			}},
+		}, nil)
+		provider.MockEC2().On("DescribeSubnets", mock.Anything, mock.Anything).Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []ec2types.Subnet{
+				{SubnetId: aws.String("subnet-public-a"), AvailabilityZone: aws.String("us-west-2a"), MapPublicIpOnLaunch: aws.Bool(true)},
+				{SubnetId: aws.String("subnet-private-b"), AvailabilityZone: aws.String("us-west-2b")},
+				{SubnetId: aws.String("subnet-private-a"), AvailabilityZone: aws.String("us-west-2a")},
+			},
+		}, nil)
+
+		stackManager.DescribeStackReturns(nil, &smithy.OperationError{Err: fmt.Errorf("ValidationError")})
+		stackManager.CreateStackStub = func(_ context.Context, _ string, rs builder.ResourceSetReader, _, _ map[string]string, errs chan error) error {
+			templateBody, err := rs.RenderJSON()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(json.Unmarshal(templateBody, &template)).To(Succeed())
+			Expect(rs.GetAllOutputs(cfntypes.Stack{
+				Outputs: []cfntypes.Output{{OutputKey: aws.String(builder.EFSFileSystem), OutputValue: aws.String("fs-1")}},
+			})).To(Succeed())
+			go func() {
+				errs <- nil
+			}()
+			return nil
+		}
+	})
+
+	It("creates the filesystem, the CSI driver addon and the StorageClass", func() {
+		fileSystemID, err := creator.Create(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fileSystemID).To(Equal("fs-1"))
+
+		Expect(stackManager.CreateStackCallCount()).To(Equal(1))
+		_, stackName, _, _, _, _ := stackManager.CreateStackArgsForCall(0)
+		Expect(stackName).To(Equal("eksctl-shared-efs"))
+		resources := template["Resources"].(map[string]interface{})
+		Expect(resources["EFSMountTarget0"]).To(HaveKeyWithValue("Properties", HaveKeyWithValue("SubnetId", "subnet-private-a")))
+		Expect(resources["EFSMountTarget1"]).To(HaveKeyWithValue("Properties", HaveKeyWithValue("SubnetId", "subnet-private-b")))
+		Expect(resources).NotTo(HaveKey("EFSMountTarget2"))
+		ingress := resources[builder.EFSSecurityGroup].(map[string]interface{})["Properties"].(map[string]interface{})["SecurityGroupIngress"]
+		Expect(ingress).To(ConsistOf(
+			HaveKeyWithValue("CidrIp", "192.168.0.0/16"),
+			HaveKeyWithValue("CidrIpv6", "2600:1f14:abc:de00::/56"),
+		))
+
+		Expect(addons.addons).To(HaveLen(1))
+		Expect(addons.addons[0].Name).To(Equal(api.AWSEFSCSIDriverAddon))
+		Expect(addons.addons[0].WellKnownPolicies.EFSCSIController).To(BeTrue())
+
+		sc, err := clientSet.StorageV1().StorageClasses().Get(context.Background(), efs.DefaultStorageClassName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sc.Provisioner).To(Equal("efs.csi.aws.com"))
+		Expect(sc.Parameters).To(HaveKeyWithValue("fileSystemId", "fs-1"))
+		Expect(sc.Annotations).NotTo(HaveKey(kubernetes.DefaultStorageClassAnnotation))
+	})
+
+	It("makes the StorageClass the default one", func() {
+		creator.StorageClassName = "shared-files"
+		creator.DefaultStorageClass = true
+		_, err := creator.Create(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		sc, err := clientSet.StorageV1().StorageClasses().Get(context.Background(), "shared-files", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sc.Annotations).To(HaveKeyWithValue(kubernetes.DefaultStorageClassAnnotation, "true"))
+		gp2, err := clientSet.StorageV1().StorageClasses().Get(context.Background(), "gp2", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gp2.Annotations).To(HaveKeyWithValue(kubernetes.DefaultStorageClassAnnotation, "false"))
+	})
+
+	It("updates the existing stack and reuses its filesystem", func() {
+		stackManager.DescribeStackReturns(&cfntypes.Stack{
+			StackName: aws.String("eksctl-shared-efs"),
+			Outputs:   []cfntypes.Output{{OutputKey: aws.String(builder.EFSFileSystem), OutputValue: aws.String("fs-existing")}},
+		}, nil)
+		fileSystemID, err := creator.Create(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fileSystemID).To(Equal("fs-existing"))
+		Expect(stackManager.CreateStackCallCount()).To(Equal(0))
+		Expect(stackManager.UpdateStackCallCount()).To(Equal(1))
+		_, options := stackManager.UpdateStackArgsForCall(0)
+		Expect(*options.Stack.StackName).To(Equal("eksctl-shared-efs"))
+		Expect(options.Wait).To(BeTrue())
+	})
+})
@@ -26,6 +26,8 @@ func (m *Manager) Create(ctx context.Context) error {
 	}
 
 	fargateRoleNeeded := false
+	// only a pod execution role created by this command is allowed to write to the destination of the logs
+	fargateRoleCreated := false
 
 	for _, profile := range cfg.FargateProfiles {
 		if profile.PodExecutionRoleARN == "" {
@@ -37,7 +39,7 @@ func (m *Manager) Create(ctx context.Context) error {
 	if fargateRoleNeeded {
 		if clusterStack != nil {
 			if !m.fargateRoleExistsOnClusterStack(clusterStack) {
-				err := ensureFargateRoleStackExists(ctx, cfg, ctl.AWSProvider, m.stackManager)
+				fargateRoleCreated, err = ensureFargateRoleStackExists(ctx, cfg, ctl.AWSProvider, m.stackManager)
 				if err != nil {
 					return errors.Wrap(err, "couldn't ensure fargate role exists")
 				}
@@ -46,7 +48,8 @@ func (m *Manager) Create(ctx context.Context) error {
 				return errors.Wrap(err, "couldn't load cluster into spec")
 			}
 		} else {
-			if err := ensureFargateRoleStackExists(ctx, cfg, ctl.AWSProvider, m.stackManager); err != nil {
+			fargateRoleCreated, err = ensureFargateRoleStackExists(ctx, cfg, ctl.AWSProvider, m.stackManager)
+			if err != nil {
 				return errors.Wrap(err, "couldn't ensure unowned cluster is ready for fargate")
 			}
 		}
@@ -59,6 +62,8 @@ func (m *Manager) Create(ctx context.Context) error {
 		}
 	}
 
+	fargate.WarnLoggingPermissions(cfg, fargateRoleCreated)
+
 	fargateClient := fargate.NewFromProvider(cfg.Metadata.Name, ctl.AWSProvider, m.stackManager)
 	if err := eks.DoCreateFargateProfiles(ctx, cfg, &fargateClient); err != nil {
 		return errors.Wrap(err, "could not create fargate profiles")
@@ -67,6 +72,9 @@ func (m *Manager) Create(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "couldn't create kubernetes client")
 	}
+	if err := fargate.ConfigureLogging(ctx, cfg, clientSet); err != nil {
+		return errors.Wrap(err, "couldn't configure Fargate logging")
+	}
 	return eks.ScheduleCoreDNSOnFargateIfRelevant(cfg, ctl, clientSet)
 }
 
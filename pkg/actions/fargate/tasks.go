@@ -31,15 +31,15 @@ func (t *createFargateStackTask) Do(errs chan error) error {
 	return t.stackManager.CreateStack(context.TODO(), makeClusterStackName(t.cfg.Metadata.Name), rs, nil, nil, errs)
 }
 
-// ensureFargateRoleStackExists creates fargate IAM resources if they
-func ensureFargateRoleStackExists(ctx context.Context, cfg *api.ClusterConfig, provider api.ClusterProvider, stackManager manager.StackManager) error {
+// ensureFargateRoleStackExists creates fargate IAM resources if they do not exist yet, and reports whether it created them
+func ensureFargateRoleStackExists(ctx context.Context, cfg *api.ClusterConfig, provider api.ClusterProvider, stackManager manager.StackManager) (bool, error) {
 	if api.IsSetAndNonEmptyString(cfg.IAM.FargatePodExecutionRoleARN) {
-		return nil
+		return false, nil
 	}
 
 	fargateStack, err := stackManager.GetFargateStack(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if fargateStack == nil {
@@ -50,14 +50,15 @@ func ensureFargateRoleStackExists(ctx context.Context, cfg *api.ClusterConfig, p
 			stackManager: stackManager,
 		})
 
-		errs := taskTree.DoAllSync()
+		errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{})
 		for _, e := range errs {
 			logger.Critical("%s\n", e.Error())
 		}
 
 		if len(errs) > 0 {
-			return errors.New("couldn't create fargate stack")
+			return false, errors.New("couldn't create fargate stack")
 		}
+		return true, nil
 	}
-	return nil
+	return false, nil
 }
@@ -2,16 +2,35 @@ package flux
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/flux"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	kubeclient "k8s.io/client-go/kubernetes"
 )
 
-const allNamespaces = ""
+const (
+	allNamespaces = ""
+
+	// fluxSystemName is the name of the Kustomization created by Flux bootstrap, and its default namespace
+	fluxSystemName = "flux-system"
+	// fieldManager owns the patches of the flux-system Kustomization, so that kustomize-controller, which applies
+	// the Kustomization from the repository with its own field manager, leaves them in place
+	fieldManager = "eksctl"
+)
+
+// kustomizationResource is the Kustomization resource of kustomize-controller, served since Flux 2.0
+var kustomizationResource = schema.GroupVersionResource{
+	Group:    "kustomize.toolkit.fluxcd.io",
+	Version:  "v1",
+	Resource: "kustomizations",
+}
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fakes/fake_flux_client.go . InstallerClient
@@ -21,12 +40,13 @@ type InstallerClient interface {
 }
 
 type Installer struct {
-	opts       *api.Flux
-	kubeClient kubeclient.Interface
-	fluxClient InstallerClient
+	opts          *api.Flux
+	kubeClient    kubeclient.Interface
+	dynamicClient dynamic.Interface
+	fluxClient    InstallerClient
 }
 
-func New(k8sClientSet kubeclient.Interface, opts *api.GitOps) (*Installer, error) {
+func New(k8sClientSet kubeclient.Interface, dynamicClient dynamic.Interface, opts *api.GitOps) (*Installer, error) {
 	if opts.Flux == nil {
 		return nil, errors.New("expected gitops.flux in cluster configuration but found nil")
 	}
@@ -37,9 +57,10 @@ func New(k8sClientSet kubeclient.Interface, opts *api.GitOps) (*Installer, error
 	}
 
 	installer := &Installer{
-		opts:       opts.Flux,
-		kubeClient: k8sClientSet,
-		fluxClient: fluxClient,
+		opts:          opts.Flux,
+		kubeClient:    k8sClientSet,
+		dynamicClient: dynamicClient,
+		fluxClient:    fluxClient,
 	}
 
 	return installer, nil
@@ -70,12 +91,38 @@ func (ti *Installer) Run() error {
 		return errors.Wrap(err, "running Flux Bootstrap")
 	}
 
+	if len(ti.opts.Patches) > 0 {
+		logger.Info("adding %d patch(es) to the flux-system Kustomization", len(ti.opts.Patches))
+		if err := ti.patchKustomization(); err != nil {
+			return errors.Wrap(err, "patching the flux-system Kustomization")
+		}
+	}
+
 	logger.Success("Flux v2 installed successfully")
 	logger.Success("see https://toolkit.fluxcd.io/ for usage instructions")
 
 	return nil
 }
 
+// patchKustomization sets the patches of the flux-system Kustomization, replacing the ones set by a previous run
+func (ti *Installer) patchKustomization() error {
+	data, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"patches": ti.opts.Patches,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	namespace := fluxSystemName
+	if ns, ok := ti.opts.Flags["namespace"]; ok && ns != "" {
+		namespace = ns
+	}
+	_, err = ti.dynamicClient.Resource(kustomizationResource).Namespace(namespace).Patch(context.Background(), fluxSystemName,
+		types.MergePatchType, data, metav1.PatchOptions{FieldManager: fieldManager})
+	return err
+}
+
 func (ti *Installer) checkV1() (string, error) {
 	deployments, err := ti.kubeClient.AppsV1().Deployments(allNamespaces).List(context.Background(), metav1.ListOptions{})
 	if err != nil {
@@ -2,12 +2,17 @@ package flux_test
 
 import (
 	"context"
+	"encoding/json"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/weaveworks/eksctl/pkg/actions/flux"
@@ -31,7 +36,7 @@ var _ = Describe("Gitops", func() {
 
 	JustBeforeEach(func() {
 		var err error
-		installer, err = flux.New(fakeClientSet, opts)
+		installer, err = flux.New(fakeClientSet, nil, opts)
 		Expect(err).NotTo(HaveOccurred())
 		installer.SetFluxClient(fakeFluxClient)
 	})
@@ -62,6 +67,54 @@ var _ = Describe("Gitops", func() {
 		})
 	})
 
+	Context("patches are set", func() {
+		var fakeDynamicClient *dynamicfake.FakeDynamicClient
+
+		BeforeEach(func() {
+			opts.Flux.Patches = []api.FluxPatch{
+				{
+					Patch:  `[{"op": "add", "path": "/spec/template/spec/nodeSelector", "value": {"role": "system"}}]`,
+					Target: &api.FluxPatchTarget{Kind: "Deployment", LabelSelector: "app.kubernetes.io/part-of=flux"},
+				},
+			}
+			kustomization := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+				"kind":       "Kustomization",
+				"metadata": map[string]interface{}{
+					"name":      "flux-system",
+					"namespace": "flux-system",
+				},
+				"spec": map[string]interface{}{
+					"path": "./clusters/my-cluster",
+				},
+			}}
+			fakeDynamicClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), kustomization)
+		})
+
+		JustBeforeEach(func() {
+			installer.SetDynamicClient(fakeDynamicClient)
+		})
+
+		It("adds them to the flux-system Kustomization after bootstrap", func() {
+			Expect(installer.Run()).To(Succeed())
+			kustomization, err := fakeDynamicClient.Resource(schema.GroupVersionResource{
+				Group:    "kustomize.toolkit.fluxcd.io",
+				Version:  "v1",
+				Resource: "kustomizations",
+			}).Namespace("flux-system").Get(context.Background(), "flux-system", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			data, err := json.Marshal(kustomization.Object["spec"])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`{
+				"path": "./clusters/my-cluster",
+				"patches": [{
+					"patch": "[{\"op\": \"add\", \"path\": \"/spec/template/spec/nodeSelector\", \"value\": {\"role\": \"system\"}}]",
+					"target": {"kind": "Deployment", "labelSelector": "app.kubernetes.io/part-of=flux"}
+				}]
+			}`))
+		})
+	})
+
 	Context("Flux v1 components are already installed", func() {
 		BeforeEach(func() {
 			_, err := fakeClientSet.AppsV1().Deployments("flux-system").Create(context.Background(), &v1.Deployment{
@@ -1,5 +1,11 @@
 package flux
 
+import "k8s.io/client-go/dynamic"
+
 func (ti *Installer) SetFluxClient(client InstallerClient) {
 	ti.fluxClient = client
 }
+
+func (ti *Installer) SetDynamicClient(client dynamic.Interface) {
+	ti.dynamicClient = client
+}
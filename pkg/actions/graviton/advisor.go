@@ -0,0 +1,268 @@
+package graviton
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+const (
+	archARM64 = "arm64"
+
+	// CanaryNodeGroupSuffix is appended to the name of a nodegroup to name its Graviton canary nodegroup
+	CanaryNodeGroupSuffix = "-arm64"
+)
+
+// Advisor inspects the workloads running on the nodegroups of a cluster to recommend Graviton instance types
+type Advisor struct {
+	ClusterName string
+	Region      string
+	ClientSet   kubernetes.Interface
+	EKS         awsapi.EKS
+	EC2         awsapi.EC2
+	// Pricing is optional, prices are not looked up without it
+	Pricing PricingAPI
+	Images  ImageInspector
+}
+
+// Recommendation is the Graviton instance type recommended for a nodegroup running on x86 instances
+type Recommendation struct {
+	NodeGroup string
+	Managed   bool
+	// InstanceType is the instance type of most of the nodes of the nodegroup
+	InstanceType string
+	// GravitonInstanceType is empty when no equivalent Graviton instance type is offered in the region
+	GravitonInstanceType string
+	// Price and GravitonPrice are the hourly on-demand prices in USD, zero when unknown
+	Price         float64
+	GravitonPrice float64
+	// Images are the images of the pods running on the nodegroup
+	Images []string
+	// IncompatibleImages are not published for arm64
+	IncompatibleImages []string
+	// UnknownImages could not be inspected, e.g. because of missing registry credentials
+	UnknownImages []string
+
+	labels map[string]string
+}
+
+// Ready reports whether the workloads of the nodegroup can run on the recommended Graviton instance type
+func (r Recommendation) Ready() bool {
+	return r.GravitonInstanceType != "" && len(r.IncompatibleImages) == 0 && len(r.UnknownImages) == 0
+}
+
+// PriceDelta returns the difference in percent between the price of the Graviton instance type and the price
+// of the current instance type, and false when either price is unknown
+func (r Recommendation) PriceDelta() (float64, bool) {
+	if r.Price == 0 || r.GravitonPrice == 0 {
+		return 0, false
+	}
+	return (r.GravitonPrice - r.Price) / r.Price * 100, true
+}
+
+type nodeGroupNodes struct {
+	managed       bool
+	instanceTypes map[string]int
+	images        map[string]struct{}
+}
+
+// Advise returns a recommendation for each nodegroup of the cluster that runs on x86 instances;
+// nodes that do not belong to a nodegroup, e.g. Fargate or Karpenter nodes, are ignored
+func (a *Advisor) Advise(ctx context.Context) ([]Recommendation, error) {
+	nodes, err := a.ClientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing nodes: %w", err)
+	}
+	nodeGroups := map[string]*nodeGroupNodes{}
+	nodeGroupOfNode := map[string]*nodeGroupNodes{}
+	for _, node := range nodes.Items {
+		name, managed := nodeGroupName(node)
+		if name == "" || node.Labels[corev1.LabelArchStable] == archARM64 {
+			continue
+		}
+		ng, ok := nodeGroups[name]
+		if !ok {
+			ng = &nodeGroupNodes{managed: managed, instanceTypes: map[string]int{}, images: map[string]struct{}{}}
+			nodeGroups[name] = ng
+		}
+		ng.instanceTypes[node.Labels[corev1.LabelInstanceTypeStable]]++
+		nodeGroupOfNode[node.Name] = ng
+	}
+
+	pods, err := a.ClientSet.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "status.phase=Running",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		ng, ok := nodeGroupOfNode[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		for _, c := range pod.Spec.InitContainers {
+			ng.images[c.Image] = struct{}{}
+		}
+		for _, c := range pod.Spec.Containers {
+			ng.images[c.Image] = struct{}{}
+		}
+	}
+
+	var names []string
+	for name := range nodeGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	imageArchitectures := map[string][]string{}
+	var recommendations []Recommendation
+	for _, name := range names {
+		ng := nodeGroups[name]
+		r := Recommendation{
+			NodeGroup:    name,
+			Managed:      ng.managed,
+			InstanceType: mostCommon(ng.instanceTypes),
+		}
+		for image := range ng.images {
+			r.Images = append(r.Images, image)
+		}
+		sort.Strings(r.Images)
+		for _, image := range r.Images {
+			architectures, ok := imageArchitectures[image]
+			if !ok {
+				if architectures, err = a.Images.Architectures(ctx, image); err != nil {
+					logger.Debug("unable to inspect image %q: %v", image, err)
+					r.UnknownImages = append(r.UnknownImages, image)
+					continue
+				}
+				imageArchitectures[image] = architectures
+			}
+			if !slices.Contains(architectures, archARM64) {
+				r.IncompatibleImages = append(r.IncompatibleImages, image)
+			}
+		}
+
+		if r.GravitonInstanceType, err = a.gravitonInstanceType(ctx, r.InstanceType); err != nil {
+			return nil, err
+		}
+		a.lookUpPrices(ctx, &r)
+		if r.Managed {
+			output, err := a.EKS.DescribeNodegroup(ctx, &awseks.DescribeNodegroupInput{
+				ClusterName:   aws.String(a.ClusterName),
+				NodegroupName: aws.String(name),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("describing nodegroup %q: %w", name, err)
+			}
+			r.labels = output.Nodegroup.Labels
+		}
+		recommendations = append(recommendations, r)
+	}
+	return recommendations, nil
+}
+
+// gravitonInstanceType returns the newest Graviton instance type equivalent to instanceType offered in the region
+func (a *Advisor) gravitonInstanceType(ctx context.Context, instanceType string) (string, error) {
+	candidates := GravitonCandidates(instanceType)
+	if len(candidates) == 0 {
+		return "", nil
+	}
+	output, err := a.EC2.DescribeInstanceTypeOfferings(ctx, &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: ec2types.LocationTypeRegion,
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("instance-type"),
+				Values: candidates,
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("describing the offerings of instance types %v: %w", candidates, err)
+	}
+	offered := map[string]bool{}
+	for _, offering := range output.InstanceTypeOfferings {
+		offered[string(offering.InstanceType)] = true
+	}
+	for _, candidate := range candidates {
+		if offered[candidate] {
+			return candidate, nil
+		}
+	}
+	return "", nil
+}
+
+func (a *Advisor) lookUpPrices(ctx context.Context, r *Recommendation) {
+	if a.Pricing == nil || r.GravitonInstanceType == "" {
+		return
+	}
+	var err error
+	if r.Price, err = onDemandPrice(ctx, a.Pricing, a.Region, r.InstanceType); err != nil {
+		logger.Warning("unable to look up the price of instance type %q: %v", r.InstanceType, err)
+		return
+	}
+	if r.GravitonPrice, err = onDemandPrice(ctx, a.Pricing, a.Region, r.GravitonInstanceType); err != nil {
+		logger.Warning("unable to look up the price of instance type %q: %v", r.GravitonInstanceType, err)
+	}
+}
+
+// CanaryConfig returns a config file creating a Graviton nodegroup of a single node next to each nodegroup whose
+// workloads can run on arm64, copying the labels of managed nodegroups, so that workloads can be moved gradually
+func CanaryConfig(meta *api.ClusterMeta, recommendations []Recommendation) *api.ClusterConfig {
+	cfg := &api.ClusterConfig{
+		TypeMeta: api.ClusterConfigTypeMeta(),
+		Metadata: &api.ClusterMeta{
+			Name:   meta.Name,
+			Region: meta.Region,
+		},
+	}
+	for _, r := range recommendations {
+		if !r.Ready() {
+			continue
+		}
+		base := &api.NodeGroupBase{
+			Name:         r.NodeGroup + CanaryNodeGroupSuffix,
+			InstanceType: r.GravitonInstanceType,
+			ScalingConfig: &api.ScalingConfig{
+				DesiredCapacity: aws.Int(1),
+				MinSize:         aws.Int(1),
+			},
+			Labels: r.labels,
+		}
+		if r.Managed {
+			cfg.ManagedNodeGroups = append(cfg.ManagedNodeGroups, &api.ManagedNodeGroup{NodeGroupBase: base})
+		} else {
+			cfg.NodeGroups = append(cfg.NodeGroups, &api.NodeGroup{NodeGroupBase: base})
+		}
+	}
+	return cfg
+}
+
+func nodeGroupName(node corev1.Node) (string, bool) {
+	if name, ok := node.Labels[api.EKSNodeGroupNameLabel]; ok {
+		return name, true
+	}
+	return node.Labels[api.NodeGroupNameLabel], false
+}
+
+func mostCommon(counts map[string]int) string {
+	var result string
+	for value, count := range counts {
+		if count > counts[result] || (count == counts[result] && value < result) {
+			result = value
+		}
+	}
+	return result
+}
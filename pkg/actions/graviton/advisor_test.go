@@ -0,0 +1,160 @@
+package graviton_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/graviton"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+type fakeInspector map[string][]string
+
+func (f fakeInspector) Architectures(_ context.Context, image string) ([]string, error) {
+	architectures, ok := f[image]
+	if !ok {
+		return nil, errors.New("unauthorized")
+	}
+	return architectures, nil
+}
+
+type fakePricing map[string]string
+
+func (f fakePricing) GetProducts(_ context.Context, params *pricing.GetProductsInput, _ ...func(*pricing.Options)) (*pricing.GetProductsOutput, error) {
+	price := f[aws.ToString(params.Filters[0].Value)]
+	return &pricing.GetProductsOutput{
+		PriceList: []string{fmt.Sprintf(`{"terms":{"OnDemand":{"A":{"priceDimensions":{"B":{"pricePerUnit":{"USD":%q}}}}}}}`, price)},
+	}, nil
+}
+
+var _ = Describe("Graviton advisor", func() {
+	DescribeTable("GravitonCandidates", func(instanceType string, expected []string) {
+		Expect(graviton.GravitonCandidates(instanceType)).To(Equal(expected))
+	},
+		Entry("general purpose", "m5.xlarge", []string{"m7g.xlarge", "m6g.xlarge"}),
+		Entry("AMD", "c6a.2xlarge", []string{"c7g.2xlarge", "c6g.2xlarge"}),
+		Entry("local storage", "r6id.large", []string{"r7gd.large", "r6gd.large"}),
+		Entry("network optimized", "c5n.4xlarge", []string{"c7gn.4xlarge", "c6gn.4xlarge"}),
+		Entry("burstable", "t3.medium", []string{"t4g.medium"}),
+		Entry("already Graviton", "m6g.large", nil),
+		Entry("GPU", "p3.2xlarge", nil),
+	)
+
+	var (
+		provider *mockprovider.MockProvider
+		advisor  *graviton.Advisor
+	)
+
+	newNode := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	newPod := func(name, nodeName string, images ...string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		for _, image := range images {
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Image: image})
+		}
+		return pod
+	}
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		clientSet := fake.NewSimpleClientset(
+			newNode("node-1", map[string]string{
+				api.EKSNodeGroupNameLabel:      "mng-1",
+				corev1.LabelInstanceTypeStable: "m5.large",
+				corev1.LabelArchStable:         "amd64",
+			}),
+			newNode("node-2", map[string]string{
+				api.NodeGroupNameLabel:         "ng-1",
+				corev1.LabelInstanceTypeStable: "c5.xlarge",
+				corev1.LabelArchStable:         "amd64",
+			}),
+			newNode("node-3", map[string]string{
+				api.EKSNodeGroupNameLabel:      "arm",
+				corev1.LabelInstanceTypeStable: "m7g.large",
+				corev1.LabelArchStable:         "arm64",
+			}),
+			newPod("web", "node-1", "nginx:1.25"),
+			newPod("legacy", "node-2", "nginx:1.25", "legacy:1.0"),
+		)
+		advisor = &graviton.Advisor{
+			ClusterName: "my-cluster",
+			Region:      "us-west-2",
+			ClientSet:   clientSet,
+			EKS:         provider.EKS(),
+			EC2:         provider.EC2(),
+			Pricing:     fakePricing{"m5.large": "0.096", "m7g.large": "0.0816"},
+			Images: fakeInspector{
+				"nginx:1.25": {"amd64", "arm64"},
+				"legacy:1.0": {"amd64"},
+			},
+		}
+
+		provider.MockEC2().On("DescribeInstanceTypeOfferings", mock.Anything, mock.Anything).Return(&ec2.DescribeInstanceTypeOfferingsOutput{
+			InstanceTypeOfferings: []ec2types.InstanceTypeOffering{
+				{InstanceType: "m7g.large"},
+				{InstanceType: "m6g.large"},
+				{InstanceType: "c6g.xlarge"},
+			},
+		}, nil)
+		provider.MockEKS().On("DescribeNodegroup", mock.Anything, &awseks.DescribeNodegroupInput{
+			ClusterName:   aws.String("my-cluster"),
+			NodegroupName: aws.String("mng-1"),
+		}).Return(&awseks.DescribeNodegroupOutput{
+			Nodegroup: &ekstypes.Nodegroup{Labels: map[string]string{"role": "web"}},
+		}, nil)
+	})
+
+	It("recommends Graviton instance types for the nodegroups running on x86", func() {
+		recommendations, err := advisor.Advise(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recommendations).To(HaveLen(2))
+
+		mng := recommendations[0]
+		Expect(mng.NodeGroup).To(Equal("mng-1"))
+		Expect(mng.GravitonInstanceType).To(Equal("m7g.large"))
+		Expect(mng.Ready()).To(BeTrue())
+		delta, ok := mng.PriceDelta()
+		Expect(ok).To(BeTrue())
+		Expect(delta).To(BeNumerically("~", -15, 0.01))
+
+		ng := recommendations[1]
+		Expect(ng.NodeGroup).To(Equal("ng-1"))
+		Expect(ng.GravitonInstanceType).To(Equal("c6g.xlarge"))
+		Expect(ng.IncompatibleImages).To(Equal([]string{"legacy:1.0"}))
+		Expect(ng.Ready()).To(BeFalse())
+	})
+
+	It("generates canary nodegroups for the nodegroups that are ready", func() {
+		recommendations, err := advisor.Advise(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg := graviton.CanaryConfig(&api.ClusterMeta{Name: "my-cluster", Region: "us-west-2"}, recommendations)
+		Expect(cfg.NodeGroups).To(BeEmpty())
+		Expect(cfg.ManagedNodeGroups).To(HaveLen(1))
+		ng := cfg.ManagedNodeGroups[0]
+		Expect(ng.Name).To(Equal("mng-1-arm64"))
+		Expect(ng.InstanceType).To(Equal("m7g.large"))
+		Expect(*ng.ScalingConfig.DesiredCapacity).To(Equal(1))
+		Expect(ng.Labels).To(Equal(map[string]string{"role": "web"}))
+	})
+})
@@ -0,0 +1,13 @@
+package graviton_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGraviton(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Graviton Suite")
+}
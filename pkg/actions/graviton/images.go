@@ -0,0 +1,59 @@
+package graviton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// ImageInspector returns the architectures an image is published for
+type ImageInspector interface {
+	Architectures(ctx context.Context, image string) ([]string, error)
+}
+
+// RegistryInspector reads the manifests of images from their registry, authenticating with the credentials
+// of the Docker configuration, e.g. those of docker-credential-ecr-login for ECR repositories
+type RegistryInspector struct{}
+
+// Architectures implements ImageInspector
+func (RegistryInspector) Architectures(ctx context.Context, image string) ([]string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, fmt.Errorf("parsing image %q: %w", image, err)
+	}
+	desc, err := remote.Get(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, fmt.Errorf("fetching the manifest of image %q: %w", image, err)
+	}
+
+	if !desc.MediaType.IsIndex() {
+		img, err := desc.Image()
+		if err != nil {
+			return nil, fmt.Errorf("reading image %q: %w", image, err)
+		}
+		config, err := img.ConfigFile()
+		if err != nil {
+			return nil, fmt.Errorf("reading the configuration of image %q: %w", image, err)
+		}
+		return []string{config.Architecture}, nil
+	}
+
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return nil, fmt.Errorf("reading the index of image %q: %w", image, err)
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("reading the index of image %q: %w", image, err)
+	}
+	var architectures []string
+	for _, m := range manifest.Manifests {
+		if m.Platform != nil && m.Platform.OS == "linux" {
+			architectures = append(architectures, m.Platform.Architecture)
+		}
+	}
+	return architectures, nil
+}
@@ -0,0 +1,63 @@
+package graviton
+
+import (
+	"regexp"
+	"strings"
+)
+
+// instanceTypeRE splits an instance type, e.g. m6id.2xlarge, into its class (m), generation (6),
+// attributes (id) and size (2xlarge)
+var instanceTypeRE = regexp.MustCompile(`^([a-z]+)(\d+)([a-z-]*)\.(.+)$`)
+
+// gravitonFamilies are the Graviton families equivalent to the x86 instance classes, newest first
+var gravitonFamilies = map[string][]string{
+	"m": {"m7g", "m6g"},
+	"c": {"c7g", "c6g"},
+	"r": {"r7g", "r6g"},
+	"t": {"t4g"},
+	"i": {"i4g"},
+	"x": {"x2gd"},
+}
+
+// gravitonFamiliesWithLocalStorage are used instead of gravitonFamilies for instance types with NVMe instance storage
+var gravitonFamiliesWithLocalStorage = map[string][]string{
+	"m": {"m7gd", "m6gd"},
+	"c": {"c7gd", "c6gd"},
+	"r": {"r7gd", "r6gd"},
+	"i": {"i4g"},
+	"x": {"x2gd"},
+}
+
+// gravitonFamiliesWithNetworking are used instead of gravitonFamilies for network optimized instance types
+var gravitonFamiliesWithNetworking = map[string][]string{
+	"c": {"c7gn", "c6gn"},
+}
+
+// GravitonCandidates returns the Graviton instance types of the same size as instanceType, newest generation first;
+// it returns nothing for Graviton instance types and for instance types without a Graviton equivalent, e.g. GPU ones
+func GravitonCandidates(instanceType string) []string {
+	match := instanceTypeRE.FindStringSubmatch(instanceType)
+	if match == nil {
+		return nil
+	}
+	class, attributes, size := match[1], match[3], match[4]
+	if strings.Contains(attributes, "g") {
+		return nil
+	}
+
+	families := gravitonFamilies[class]
+	switch {
+	case strings.Contains(attributes, "d"):
+		families = gravitonFamiliesWithLocalStorage[class]
+	case strings.Contains(attributes, "n"):
+		if networking, ok := gravitonFamiliesWithNetworking[class]; ok {
+			families = networking
+		}
+	}
+
+	var candidates []string
+	for _, family := range families {
+		candidates = append(candidates, family+"."+size)
+	}
+	return candidates
+}
@@ -0,0 +1,77 @@
+package graviton
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	pricingtypes "github.com/aws/aws-sdk-go-v2/service/pricing/types"
+)
+
+// PricingRegion is the region of the endpoint of the AWS Price List API
+const PricingRegion = "us-east-1"
+
+// PricingAPI is the subset of the AWS Price List API used to look up the price of instance types
+type PricingAPI interface {
+	GetProducts(ctx context.Context, params *pricing.GetProductsInput, optFns ...func(*pricing.Options)) (*pricing.GetProductsOutput, error)
+}
+
+// NewPricingAPI returns a client of the AWS Price List API, which is only served from a few regions
+func NewPricingAPI(cfg aws.Config) PricingAPI {
+	return pricing.NewFromConfig(cfg, func(o *pricing.Options) {
+		o.Region = PricingRegion
+	})
+}
+
+type priceList struct {
+	Terms struct {
+		OnDemand map[string]struct {
+			PriceDimensions map[string]struct {
+				PricePerUnit map[string]string `json:"pricePerUnit"`
+			} `json:"priceDimensions"`
+		} `json:"OnDemand"`
+	} `json:"terms"`
+}
+
+// onDemandPrice returns the hourly on-demand price in USD of a Linux instance of instanceType in region
+func onDemandPrice(ctx context.Context, pricingAPI PricingAPI, region, instanceType string) (float64, error) {
+	filter := func(field, value string) pricingtypes.Filter {
+		return pricingtypes.Filter{
+			Type:  pricingtypes.FilterTypeTermMatch,
+			Field: aws.String(field),
+			Value: aws.String(value),
+		}
+	}
+	output, err := pricingAPI.GetProducts(ctx, &pricing.GetProductsInput{
+		ServiceCode: aws.String("AmazonEC2"),
+		Filters: []pricingtypes.Filter{
+			filter("instanceType", instanceType),
+			filter("regionCode", region),
+			filter("operatingSystem", "Linux"),
+			filter("tenancy", "Shared"),
+			filter("preInstalledSw", "NA"),
+			filter("capacitystatus", "Used"),
+		},
+		MaxResults: aws.Int32(1),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("looking up the price of instance type %q: %w", instanceType, err)
+	}
+	for _, product := range output.PriceList {
+		var prices priceList
+		if err := json.Unmarshal([]byte(product), &prices); err != nil {
+			return 0, fmt.Errorf("parsing the price of instance type %q: %w", instanceType, err)
+		}
+		for _, term := range prices.Terms.OnDemand {
+			for _, dimension := range term.PriceDimensions {
+				if usd, ok := dimension.PricePerUnit["USD"]; ok {
+					return strconv.ParseFloat(usd, 64)
+				}
+			}
+		}
+	}
+	return 0, fmt.Errorf("no on-demand price found for instance type %q in region %q", instanceType, region)
+}
@@ -30,7 +30,14 @@ func (m *Manager) Create(ctx context.Context, mapping *api.IAMIdentityMapping) e
 	}
 
 	if mapping.Account == "" {
-		id, err := iam.NewIdentity(mapping.ARN, mapping.Username, mapping.Groups)
+		mappingARN := mapping.ARN
+		if mapping.SSOPermissionSet != "" {
+			if mappingARN, err = m.ResolveSSOPermissionSetRoleARN(ctx, mapping.SSOPermissionSet); err != nil {
+				return err
+			}
+			logger.Info("mapping role %s of IAM Identity Center permission set %q", mappingARN, mapping.SSOPermissionSet)
+		}
+		id, err := iam.NewIdentity(mappingARN, mapping.Username, mapping.Groups)
 		if err != nil {
 			return err
 		}
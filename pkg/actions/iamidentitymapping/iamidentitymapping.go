@@ -4,6 +4,7 @@ import (
 	kubeclient "k8s.io/client-go/kubernetes"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
 )
 
@@ -11,14 +12,16 @@ type Manager struct {
 	clusterConfig *api.ClusterConfig
 	clientSet     kubeclient.Interface
 	rawClient     *kubernetes.RawClient
+	iamAPI        awsapi.IAM
 	region        string
 }
 
-func New(clusterConfig *api.ClusterConfig, clientSet kubeclient.Interface, rawClient *kubernetes.RawClient, region string) (*Manager, error) {
+func New(clusterConfig *api.ClusterConfig, clientSet kubeclient.Interface, rawClient *kubernetes.RawClient, iamAPI awsapi.IAM, region string) (*Manager, error) {
 	return &Manager{
 		clusterConfig: clusterConfig,
 		clientSet:     clientSet,
 		rawClient:     rawClient,
+		iamAPI:        iamAPI,
 		region:        region,
 	}, nil
 }
@@ -0,0 +1,11 @@
+package iamidentitymapping_test
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestIAMIdentityMapping(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
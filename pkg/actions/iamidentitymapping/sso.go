@@ -0,0 +1,58 @@
+package iamidentitymapping
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+)
+
+const (
+	// ssoRolePathPrefix is the path of the roles IAM Identity Center provisions for permission sets
+	ssoRolePathPrefix = "/aws-reserved/sso.amazonaws.com/"
+	// ssoRoleNamePrefix is the prefix of the name of those roles, which is followed by
+	// the permission set name, an underscore and a random suffix
+	ssoRoleNamePrefix = "AWSReservedSSO_"
+)
+
+// ResolveSSOPermissionSetRoleARN returns the ARN of the role provisioned by IAM Identity Center for permissionSet
+// in the account of the IAM API. The path of the role is omitted from the ARN, as neither the aws-auth ConfigMap
+// nor access entries match role ARNs with a path.
+func (m *Manager) ResolveSSOPermissionSetRoleARN(ctx context.Context, permissionSet string) (string, error) {
+	namePrefix := ssoRoleNamePrefix + permissionSet + "_"
+	var matches []string
+	paginator := iam.NewListRolesPaginator(m.iamAPI, &iam.ListRolesInput{
+		PathPrefix: aws.String(ssoRolePathPrefix),
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return "", fmt.Errorf("listing IAM Identity Center roles: %w", err)
+		}
+		for _, role := range out.Roles {
+			roleName := aws.ToString(role.RoleName)
+			// permission set names may contain underscores, but the random suffix does not
+			if !strings.HasPrefix(roleName, namePrefix) || strings.Contains(strings.TrimPrefix(roleName, namePrefix), "_") {
+				continue
+			}
+			parsed, err := arn.Parse(aws.ToString(role.Arn))
+			if err != nil {
+				return "", fmt.Errorf("parsing ARN of role %q: %w", roleName, err)
+			}
+			parsed.Resource = "role/" + roleName
+			matches = append(matches, parsed.String())
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no role found for IAM Identity Center permission set %q; ensure the permission set is provisioned to this account", permissionSet)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("found multiple roles for IAM Identity Center permission set %q: %s", permissionSet, strings.Join(matches, ", "))
+	}
+}
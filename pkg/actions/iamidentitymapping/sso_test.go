@@ -0,0 +1,67 @@
+package iamidentitymapping_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/iamidentitymapping"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("ResolveSSOPermissionSetRoleARN", func() {
+	var (
+		p       *mockprovider.MockProvider
+		manager *iamidentitymapping.Manager
+	)
+
+	ssoRole := func(name string) iamtypes.Role {
+		return iamtypes.Role{
+			RoleName: aws.String(name),
+			Arn:      aws.String("arn:aws:iam::111122223333:role/aws-reserved/sso.amazonaws.com/us-west-2/" + name),
+		}
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		p.MockIAM().On("ListRoles", mock.Anything, mock.MatchedBy(func(input *iam.ListRolesInput) bool {
+			return aws.ToString(input.PathPrefix) == "/aws-reserved/sso.amazonaws.com/"
+		}), mock.Anything).Return(&iam.ListRolesOutput{
+			Roles: []iamtypes.Role{
+				ssoRole("AWSReservedSSO_AdministratorAccess_0123456789abcdef"),
+				ssoRole("AWSReservedSSO_ReadOnly_0123456789abcdef"),
+				ssoRole("AWSReservedSSO_ReadOnly_Extended_fedcba9876543210"),
+			},
+		}, nil)
+		var err error
+		manager, err = iamidentitymapping.New(api.NewClusterConfig(), nil, nil, p.IAM(), "us-west-2")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("resolves the role of a permission set without its path", func() {
+		roleARN, err := manager.ResolveSSOPermissionSetRoleARN(context.Background(), "AdministratorAccess")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleARN).To(Equal("arn:aws:iam::111122223333:role/AWSReservedSSO_AdministratorAccess_0123456789abcdef"))
+	})
+
+	It("does not match permission sets sharing a prefix", func() {
+		roleARN, err := manager.ResolveSSOPermissionSetRoleARN(context.Background(), "ReadOnly")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleARN).To(Equal("arn:aws:iam::111122223333:role/AWSReservedSSO_ReadOnly_0123456789abcdef"))
+
+		roleARN, err = manager.ResolveSSOPermissionSetRoleARN(context.Background(), "ReadOnly_Extended")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roleARN).To(Equal("arn:aws:iam::111122223333:role/AWSReservedSSO_ReadOnly_Extended_fedcba9876543210"))
+	})
+
+	It("errors when the permission set is not provisioned to the account", func() {
+		_, err := manager.ResolveSSOPermissionSetRoleARN(context.Background(), "PowerUserAccess")
+		Expect(err).To(MatchError(ContainSubstring(`no role found for IAM Identity Center permission set "PowerUserAccess"`)))
+	})
+})
@@ -57,7 +57,7 @@ func (m *Manager) Associate(ctx context.Context, options AssociateIdentityProvid
 		}
 	}
 
-	errs := taskTree.DoAllSync()
+	errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{})
 	for _, err := range errs {
 		logger.Critical(err.Error())
 	}
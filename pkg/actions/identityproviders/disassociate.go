@@ -79,7 +79,7 @@ func (m *Manager) Disassociate(ctx context.Context, options DisassociateIdentity
 		})
 	}
 
-	errs := taskTree.DoAllSync()
+	errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{})
 	for _, err := range errs {
 		logger.Critical(err.Error())
 	}
@@ -1,17 +1,48 @@
 package irsa
 
 import (
+	"context"
+	"fmt"
+
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
 )
 
 func (a *Manager) CreateIAMServiceAccount(iamServiceAccounts []*api.ClusterIAMServiceAccount, plan bool) error {
-	taskTree := a.stackManager.NewTasksToCreateIAMServiceAccounts(iamServiceAccounts, a.oidcManager, kubernetes.NewCachedClientSet(a.clientSet))
+	clientSet := kubernetes.NewCachedClientSet(a.clientSet)
+
+	serviceAccounts, targetAccountRoles, targetAccountGroups := PartitionByTargetAccount(iamServiceAccounts)
+
+	taskTree := a.stackManager.NewTasksToCreateIAMServiceAccounts(serviceAccounts, a.oidcManager, clientSet)
+	for _, roleARN := range targetAccountRoles {
+		if a.newTargetAccount == nil {
+			return fmt.Errorf("creating iamserviceaccounts with targetAccountRoleARN %q is not supported", roleARN)
+		}
+		taskTree.Append(NewTasksToCreateTargetAccountIAMServiceAccounts(context.TODO(), a.newTargetAccount(roleARN), targetAccountGroups[roleARN], clientSet))
+	}
+	taskTree.Limit = a.parallelism
 	taskTree.PlanMode = plan
 
-	err := doTasks(taskTree, actionCreate)
+	err := doTasks(context.TODO(), taskTree, actionCreate)
 
 	logPlanModeWarning(plan && len(iamServiceAccounts) > 0)
 
 	return err
 }
+
+// PartitionByTargetAccount separates iamserviceaccounts whose role is created in the cluster's account
+// from those that set targetAccountRoleARN, which are grouped by role ARN in order of appearance
+func PartitionByTargetAccount(iamServiceAccounts []*api.ClusterIAMServiceAccount) (serviceAccounts []*api.ClusterIAMServiceAccount, roleARNs []string, byRoleARN map[string][]*api.ClusterIAMServiceAccount) {
+	byRoleARN = map[string][]*api.ClusterIAMServiceAccount{}
+	for _, sa := range iamServiceAccounts {
+		if sa.TargetAccountRoleARN == "" {
+			serviceAccounts = append(serviceAccounts, sa)
+			continue
+		}
+		if _, ok := byRoleARN[sa.TargetAccountRoleARN]; !ok {
+			roleARNs = append(roleARNs, sa.TargetAccountRoleARN)
+		}
+		byRoleARN[sa.TargetAccountRoleARN] = append(byRoleARN[sa.TargetAccountRoleARN], sa)
+	}
+	return serviceAccounts, roleARNs, byRoleARN
+}
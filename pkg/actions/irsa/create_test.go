@@ -0,0 +1,45 @@
+package irsa_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/irsa"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+var _ = Describe("Create", func() {
+	var (
+		fakeStackManager *fakes.FakeStackManager
+		taskTree         *tasks.TaskTree
+		serviceAccounts  []*api.ClusterIAMServiceAccount
+	)
+
+	BeforeEach(func() {
+		fakeStackManager = new(fakes.FakeStackManager)
+		taskTree = &tasks.TaskTree{Parallel: true}
+		fakeStackManager.NewTasksToCreateIAMServiceAccountsReturns(taskTree)
+		serviceAccounts = []*api.ClusterIAMServiceAccount{
+			{
+				ClusterIAMMeta: api.ClusterIAMMeta{
+					Name:      "test-sa",
+					Namespace: "default",
+				},
+				AttachPolicyARNs: []string{"arn-123"},
+			},
+		}
+	})
+
+	It("does not limit parallelism by default", func() {
+		Expect(irsa.New("my-cluster", fakeStackManager, nil, nil).CreateIAMServiceAccount(serviceAccounts, true)).To(Succeed())
+		Expect(taskTree.Limit).To(Equal(0))
+		Expect(taskTree.PlanMode).To(BeTrue())
+	})
+
+	It("limits the number of iamserviceaccounts created in parallel", func() {
+		Expect(irsa.New("my-cluster", fakeStackManager, nil, nil).WithParallelism(5).CreateIAMServiceAccount(serviceAccounts, true)).To(Succeed())
+		Expect(taskTree.Limit).To(Equal(5))
+	})
+})
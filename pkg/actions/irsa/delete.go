@@ -3,17 +3,32 @@ package irsa
 import (
 	"context"
 
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
 )
 
 func (m *Manager) Delete(ctx context.Context, serviceAccounts []string, plan, wait bool) error {
-	taskTree, err := m.stackManager.NewTasksToDeleteIAMServiceAccounts(ctx, serviceAccounts, kubernetes.NewCachedClientSet(m.clientSet), wait)
+	return m.delete(ctx, m.stackManager, serviceAccounts, plan, wait)
+}
+
+// DeleteInTargetAccount deletes serviceAccounts whose roles were created in the AWS account of roleARN
+func (m *Manager) DeleteInTargetAccount(ctx context.Context, roleARN string, serviceAccounts []string, plan, wait bool) error {
+	stackManager, err := m.stackManagerFor(roleARN)
+	if err != nil {
+		return err
+	}
+	return m.delete(ctx, stackManager, serviceAccounts, plan, wait)
+}
+
+func (m *Manager) delete(ctx context.Context, stackManager manager.StackManager, serviceAccounts []string, plan, wait bool) error {
+	taskTree, err := stackManager.NewTasksToDeleteIAMServiceAccounts(ctx, serviceAccounts, kubernetes.NewCachedClientSet(m.clientSet), wait)
 	if err != nil {
 		return err
 	}
 	taskTree.PlanMode = plan
+	taskTree.LimitParallelism(m.parallelism)
 
-	err = doTasks(taskTree, actionDelete)
+	err = doTasks(ctx, taskTree, actionDelete)
 
 	logPlanModeWarning(plan && taskTree.Len() > 0)
 	return err
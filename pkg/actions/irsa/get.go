@@ -11,10 +11,16 @@ import (
 type GetOptions struct {
 	Name      string
 	Namespace string
+	// TargetAccountRoleARN lists the iamserviceaccounts whose roles were created in the account of this role
+	TargetAccountRoleARN string
 }
 
 func (m *Manager) Get(ctx context.Context, options GetOptions) ([]*api.ClusterIAMServiceAccount, error) {
-	remoteServiceAccounts, err := m.stackManager.GetIAMServiceAccounts(ctx)
+	stackManager, err := m.stackManagerFor(options.TargetAccountRoleARN)
+	if err != nil {
+		return nil, err
+	}
+	remoteServiceAccounts, err := stackManager.GetIAMServiceAccounts(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "getting iamserviceaccounts")
 	}
@@ -170,4 +170,27 @@ var _ = Describe("Get", func() {
 			}))
 		})
 	})
+	When("a target account is specified", func() {
+		It("returns the service accounts whose roles are in that account", func() {
+			targetStackManager := new(fakes.FakeStackManager)
+			targetStackManager.GetIAMServiceAccountsReturns([]*api.ClusterIAMServiceAccount{
+				{
+					ClusterIAMMeta: api.ClusterIAMMeta{
+						Name:      "test-sa",
+						Namespace: "default",
+					},
+				},
+			}, nil)
+			irsaManager.WithTargetAccounts(func(roleARN string) irsa.TargetAccount {
+				return irsa.TargetAccount{RoleARN: roleARN, StackManager: targetStackManager}
+			})
+
+			serviceAccounts, err := irsaManager.Get(context.Background(), irsa.GetOptions{TargetAccountRoleARN: "arn:aws:iam::111122223333:role/irsa-deployer"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeStackManager.GetIAMServiceAccountsCallCount()).To(Equal(0))
+			Expect(targetStackManager.GetIAMServiceAccountsCallCount()).To(Equal(1))
+			Expect(serviceAccounts).To(HaveLen(1))
+		})
+	})
 })
@@ -1,6 +1,7 @@
 package irsa
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/kris-nova/logger"
@@ -15,6 +16,19 @@ type Manager struct {
 	oidcManager  *iamoidc.OpenIDConnectManager
 	stackManager manager.StackManager
 	clientSet    kubeclient.Interface
+
+	newTargetAccount func(roleARN string) TargetAccount
+	parallelism      int
+}
+
+// TargetAccount holds the clients needed to create iamserviceaccount roles
+// in an AWS account other than the cluster's
+type TargetAccount struct {
+	// RoleARN is the role assumed to act in the target account
+	RoleARN      string
+	StackManager manager.StackManager
+	// NewOIDCManager returns an OIDC manager for the cluster's issuer in the target account
+	NewOIDCManager func(ctx context.Context) (*iamoidc.OpenIDConnectManager, error)
 }
 
 type action string
@@ -34,9 +48,32 @@ func New(clusterName string, stackManager manager.StackManager, oidcManager *iam
 	}
 }
 
-func doTasks(taskTree *tasks.TaskTree, action action) error {
+// WithTargetAccounts sets the function used to resolve iamserviceaccounts' targetAccountRoleARN
+func (a *Manager) WithTargetAccounts(newTargetAccount func(roleARN string) TargetAccount) *Manager {
+	a.newTargetAccount = newTargetAccount
+	return a
+}
+
+// stackManagerFor returns the stack manager of the AWS account of roleARN, or of the cluster's account if roleARN is empty
+func (a *Manager) stackManagerFor(roleARN string) (manager.StackManager, error) {
+	if roleARN == "" {
+		return a.stackManager, nil
+	}
+	if a.newTargetAccount == nil {
+		return nil, fmt.Errorf("managing iamserviceaccounts with targetAccountRoleARN %q is not supported", roleARN)
+	}
+	return a.newTargetAccount(roleARN).StackManager, nil
+}
+
+// WithParallelism limits the number of iamserviceaccounts that are created, updated or deleted in parallel; 0 means no limit
+func (a *Manager) WithParallelism(parallelism int) *Manager {
+	a.parallelism = parallelism
+	return a
+}
+
+func doTasks(ctx context.Context, taskTree *tasks.TaskTree, action action) error {
 	logger.Info(taskTree.Describe())
-	if errs := taskTree.DoAllSync(); len(errs) > 0 {
+	if errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{}); len(errs) > 0 {
 		logger.Info("%d error(s) occurred and IAM Role stacks haven't been %sd properly, you may wish to check CloudFormation console", len(errs), action)
 		for _, err := range errs {
 			logger.Critical("%s\n", err.Error())
@@ -5,14 +5,55 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/kris-nova/logger"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
 	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 )
 
+// NewTasksToCreateTargetAccountIAMServiceAccounts defines tasks required to create the roles of serviceAccounts
+// in the AWS account described by target, associating the cluster's IAM OIDC provider with that account first
+func NewTasksToCreateTargetAccountIAMServiceAccounts(ctx context.Context, target TargetAccount, serviceAccounts []*api.ClusterIAMServiceAccount, clientSetGetter kubernetes.ClientSetGetter) *tasks.TaskTree {
+	// the OIDC manager can only be built once the cluster exists, so hand out
+	// a placeholder that gets populated when the first task runs
+	oidcPlaceholder := &iamoidc.OpenIDConnectManager{}
+
+	taskTree := &tasks.TaskTree{
+		Parallel:  false,
+		IsSubTask: true,
+	}
+	taskTree.Append(&tasks.GenericTask{
+		Description: fmt.Sprintf("associate IAM OIDC provider with the account of %q", target.RoleARN),
+		Doer: func() error {
+			oidc, err := target.NewOIDCManager(ctx)
+			if err != nil {
+				return err
+			}
+			exists, err := oidc.CheckProviderExists(ctx)
+			if err != nil {
+				return fmt.Errorf("checking IAM OIDC provider using %q: %w", target.RoleARN, err)
+			}
+			if !exists {
+				logger.Info("creating IAM OIDC provider using %q", target.RoleARN)
+				if err := oidc.CreateProvider(ctx); err != nil {
+					return fmt.Errorf("creating IAM OIDC provider using %q: %w", target.RoleARN, err)
+				}
+			}
+			*oidcPlaceholder = *oidc
+			return nil
+		},
+	})
+
+	saTasks := target.StackManager.NewTasksToCreateIAMServiceAccounts(serviceAccounts, oidcPlaceholder, clientSetGetter)
+	saTasks.IsSubTask = true
+	taskTree.Append(saTasks)
+	return taskTree
+}
+
 func NewUpdateIAMServiceAccountTask(clusterName string, sa *api.ClusterIAMServiceAccount, stackManager manager.StackManager, oidcManager *iamoidc.OpenIDConnectManager) (*tasks.TaskTree, error) {
 	rs := builder.NewIAMRoleResourceSetForServiceAccount(sa, oidcManager)
 	err := rs.AddAllResources()
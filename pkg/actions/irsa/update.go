@@ -23,7 +23,7 @@ const (
 
 func (a *Manager) UpdateIAMServiceAccounts(ctx context.Context, iamServiceAccounts []*api.ClusterIAMServiceAccount, existingIAMStacks []*manager.Stack, plan bool) error {
 	var nonExistingSAs []string
-	updateTasks := &tasks.TaskTree{Parallel: true}
+	updateTasks := &tasks.TaskTree{Parallel: true, Limit: a.parallelism}
 
 	existingIAMStacksMap := listToSet(existingIAMStacks)
 
@@ -58,7 +58,7 @@ func (a *Manager) UpdateIAMServiceAccounts(ctx context.Context, iamServiceAccoun
 	}
 
 	defer logPlanModeWarning(plan && len(iamServiceAccounts) > 0)
-	return doTasks(updateTasks, actionUpdate)
+	return doTasks(ctx, updateTasks, actionUpdate)
 }
 
 // getRoleNameFromStackTemplate returns the role if the initial stack's template contained it.
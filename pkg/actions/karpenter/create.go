@@ -36,7 +36,7 @@ func (i *Installer) Create(ctx context.Context) error {
 
 	// Create IAM roles
 	taskTree := newTasksToInstallKarpenterIAMRoles(ctx, i.Config, i.StackManager, i.CTL.AWSProvider.EC2(), instanceProfileName)
-	if err := doTasks(taskTree); err != nil {
+	if err := doTasks(ctx, taskTree); err != nil {
 		return err
 	}
 
@@ -60,7 +60,7 @@ func (i *Installer) Create(ctx context.Context) error {
 	}
 	karpenterServiceAccountTaskTree := i.StackManager.NewTasksToCreateIAMServiceAccounts([]*api.ClusterIAMServiceAccount{iamServiceAccount}, i.OIDC, clientSetGetter)
 	logger.Info(karpenterServiceAccountTaskTree.Describe())
-	if err := doTasks(karpenterServiceAccountTaskTree); err != nil {
+	if err := doTasks(ctx, karpenterServiceAccountTaskTree); err != nil {
 		return fmt.Errorf("failed to create/attach service account: %w", err)
 	}
 
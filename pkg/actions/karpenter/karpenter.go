@@ -80,9 +80,9 @@ func NewInstaller(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterP
 	}, nil
 }
 
-func doTasks(taskTree *tasks.TaskTree) error {
+func doTasks(ctx context.Context, taskTree *tasks.TaskTree) error {
 	logger.Info(taskTree.Describe())
-	if errs := taskTree.DoAllSync(); len(errs) > 0 {
+	if errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{}); len(errs) > 0 {
 		logger.Info("%d error(s) occurred while installing Karpenter, you may wish to check your Cluster for further information", len(errs))
 		for _, err := range errs {
 			logger.Critical("%s\n", err.Error())
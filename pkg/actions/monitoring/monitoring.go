@@ -0,0 +1,267 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+
+	// For go:embed
+	_ "embed"
+
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/iam"
+	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+//go:embed assets/managed-scraper-rbac.yaml
+var managedScraperRBAC []byte
+
+const (
+	// Namespace is the namespace the ADOT collector is installed in.
+	Namespace = "adot-collector"
+	// ServiceAccountName is the name of the service account of the ADOT collector.
+	ServiceAccountName = "adot-collector"
+
+	// DefaultADOTImageRepository is the repository of the ADOT collector image.
+	DefaultADOTImageRepository = "public.ecr.aws/aws-observability/aws-otel-collector"
+
+	helmChartName = "opentelemetry-collector"
+	helmRepoURL   = "https://open-telemetry.github.io/opentelemetry-helm-charts"
+	releaseName   = "adot-collector"
+)
+
+// Installer sets up the AMP workspace of a cluster and the collector sending it the metrics of the cluster.
+type Installer struct {
+	StackManager  manager.StackManager
+	ClusterConfig *api.ClusterConfig
+	// Cluster is the description of the cluster, used for the network configuration of managed scrapers.
+	Cluster *ekstypes.Cluster
+	// AccessEntryEnabled reports whether the cluster supports access entries, otherwise managed scrapers are mapped
+	// in the aws-auth ConfigMap.
+	AccessEntryEnabled bool
+
+	// RawClient is used with the managed-scraper collector.
+	RawClient *kubernetes.RawClient
+	// OIDC and HelmInstaller are used with the adot collector.
+	OIDC          *iamoidc.OpenIDConnectManager
+	HelmInstaller providers.HelmInstaller
+}
+
+// MakeStackName returns the name of the monitoring stack of a cluster.
+func MakeStackName(clusterName string) string {
+	return manager.MakeMonitoringStackName(clusterName)
+}
+
+// Enable creates or updates the monitoring stack, and sets up the collector.
+// It returns the Prometheus endpoint of the workspace.
+func (i *Installer) Enable(ctx context.Context) (string, error) {
+	prometheus := i.ClusterConfig.Monitoring.Prometheus
+	clusterARN := i.ClusterConfig.Metadata.Name
+	if i.Cluster != nil && i.Cluster.Arn != nil {
+		clusterARN = *i.Cluster.Arn
+	}
+
+	options := builder.MonitoringResourceSetOptions{
+		ClusterName:         i.ClusterConfig.Metadata.Name,
+		Prometheus:          prometheus,
+		ScrapeConfiguration: ScrapeConfiguration(prometheus.ScrapeConfiguration, clusterARN),
+		OIDC:                i.OIDC,
+		Namespace:           Namespace,
+		ServiceAccount:      ServiceAccountName,
+	}
+	if prometheus.CollectorOrDefault() == api.PrometheusCollectorManagedScraper {
+		source, err := i.makeScraperSource(clusterARN)
+		if err != nil {
+			return "", err
+		}
+		options.ScraperSource = source
+	}
+	resourceSet := builder.NewMonitoringResourceSet(options)
+	if err := resourceSet.AddAllResources(); err != nil {
+		return "", err
+	}
+
+	stackName := MakeStackName(i.ClusterConfig.Metadata.Name)
+	stack, err := i.StackManager.DescribeStack(ctx, &manager.Stack{StackName: &stackName})
+	switch {
+	case err == nil:
+		// the resources of the collector differ between collector types, so the stack is updated to the current config
+		template, err := resourceSet.RenderJSON()
+		if err != nil {
+			return "", err
+		}
+		if err := i.StackManager.UpdateStack(ctx, manager.UpdateStackOptions{
+			Stack:         stack,
+			ChangeSetName: i.StackManager.MakeChangeSetName("update-monitoring"),
+			Description:   fmt.Sprintf("updating the workspace and collector of stack %q", stackName),
+			TemplateData:  manager.TemplateBody(template),
+			Wait:          true,
+		}); err != nil {
+			return "", errors.Wrapf(err, "updating stack %q", stackName)
+		}
+		if stack, err = i.StackManager.DescribeStack(ctx, stack); err != nil {
+			return "", err
+		}
+		if err := resourceSet.GetAllOutputs(*stack); err != nil {
+			return "", errors.Wrapf(err, "collecting outputs of stack %q", stackName)
+		}
+	case manager.IsStackDoesNotExistError(err):
+		errs := make(chan error)
+		if err := i.StackManager.CreateStack(ctx, stackName, resourceSet, nil, nil, errs); err != nil {
+			return "", err
+		}
+		if err := <-errs; err != nil {
+			return "", errors.Wrapf(err, "creating stack %q", stackName)
+		}
+	default:
+		return "", err
+	}
+
+	if prometheus.CollectorOrDefault() == api.PrometheusCollectorADOT {
+		if err := i.installADOTCollector(ctx, options.ScrapeConfiguration, resourceSet.Endpoint, resourceSet.CollectorRoleARN); err != nil {
+			return "", err
+		}
+	} else if err := i.authorizeManagedScraper(resourceSet.ScraperRoleARN); err != nil {
+		return "", err
+	}
+	return resourceSet.Endpoint, nil
+}
+
+func (i *Installer) makeScraperSource(clusterARN string) (builder.PrometheusScraperSource, error) {
+	if i.Cluster == nil || i.Cluster.ResourcesVpcConfig == nil {
+		return builder.PrometheusScraperSource{}, errors.New("the VPC configuration of the cluster is required for a managed scraper")
+	}
+	vpcConfig := i.Cluster.ResourcesVpcConfig
+	source := builder.PrometheusScraperSource{
+		ClusterARN:      clusterARN,
+		SubnetIDs:       vpcConfig.SubnetIds,
+		WithAccessEntry: i.AccessEntryEnabled,
+	}
+	if vpcConfig.ClusterSecurityGroupId != nil {
+		source.SecurityGroupIDs = []string{*vpcConfig.ClusterSecurityGroupId}
+	}
+	return source, nil
+}
+
+// authorizeManagedScraper grants the Kubernetes user of the managed scraper read access to the metrics of the cluster
+func (i *Installer) authorizeManagedScraper(scraperRoleARN string) error {
+	logger.Info("granting the managed scraper access to the metrics of the cluster")
+	if err := i.RawClient.CreateOrReplace(managedScraperRBAC, false); err != nil {
+		return errors.Wrap(err, "applying the RBAC resources of the managed scraper")
+	}
+	if i.AccessEntryEnabled {
+		return nil
+	}
+
+	acm, err := authconfigmap.NewFromClientSet(i.RawClient.ClientSet())
+	if err != nil {
+		return err
+	}
+	identity, err := iam.NewIdentity(scraperRoleARN, builder.PrometheusScraperUsername, nil)
+	if err != nil {
+		return err
+	}
+	if err := acm.AddIdentityIfNotPresent(identity, func(existing iam.Identity) bool {
+		return existing.ARN() == identity.ARN() && existing.Username() == identity.Username()
+	}); err != nil {
+		return err
+	}
+	return errors.Wrap(acm.Save(), "mapping the role of the managed scraper")
+}
+
+func (i *Installer) installADOTCollector(ctx context.Context, scrapeConfiguration, endpoint, roleARN string) error {
+	logger.Info("installing the ADOT collector, writing to %q", endpoint)
+	values, err := i.makeValues(scrapeConfiguration, endpoint, roleARN)
+	if err != nil {
+		return err
+	}
+	if err := i.HelmInstaller.InstallChart(ctx, providers.InstallChartOpts{
+		ChartName:       helmChartName,
+		RepoURL:         helmRepoURL,
+		CreateNamespace: true,
+		Namespace:       Namespace,
+		ReleaseName:     releaseName,
+		Values:          values,
+	}); err != nil {
+		return fmt.Errorf("failed to install ADOT collector chart: %w", err)
+	}
+	return nil
+}
+
+func (i *Installer) makeValues(scrapeConfiguration, endpoint, roleARN string) (map[string]interface{}, error) {
+	prometheusConfig, err := PrometheusReceiverConfig(scrapeConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"mode": "deployment",
+		"image": map[string]interface{}{
+			"repository": DefaultADOTImageRepository,
+			"tag":        i.ClusterConfig.Monitoring.Prometheus.ADOTVersion,
+		},
+		"command": map[string]interface{}{
+			"name": "awscollector",
+		},
+		"serviceAccount": map[string]interface{}{
+			"create": true,
+			"name":   ServiceAccountName,
+			"annotations": map[string]interface{}{
+				api.AnnotationEKSRoleARN: roleARN,
+			},
+		},
+		"clusterRole": map[string]interface{}{
+			"create": true,
+			"rules": []interface{}{
+				map[string]interface{}{
+					"apiGroups": []string{""},
+					"resources": []string{"nodes", "nodes/proxy", "nodes/metrics", "services", "endpoints", "pods"},
+					"verbs":     []string{"get", "list", "watch"},
+				},
+				map[string]interface{}{
+					"nonResourceURLs": []string{"/metrics", "/metrics/cadvisor"},
+					"verbs":           []string{"get"},
+				},
+			},
+		},
+		"config": map[string]interface{}{
+			"extensions": map[string]interface{}{
+				"health_check": map[string]interface{}{},
+				"sigv4auth": map[string]interface{}{
+					"region":  i.ClusterConfig.Metadata.Region,
+					"service": "aps",
+				},
+			},
+			"receivers": map[string]interface{}{
+				"prometheus": map[string]interface{}{
+					"config": prometheusConfig,
+				},
+			},
+			"exporters": map[string]interface{}{
+				"prometheusremotewrite": map[string]interface{}{
+					"endpoint": endpoint + "api/v1/remote_write",
+					"auth": map[string]interface{}{
+						"authenticator": "sigv4auth",
+					},
+				},
+			},
+			"service": map[string]interface{}{
+				"extensions": []string{"health_check", "sigv4auth"},
+				"pipelines": map[string]interface{}{
+					"metrics": map[string]interface{}{
+						"receivers": []string{"prometheus"},
+						"exporters": []string{"prometheusremotewrite"},
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,103 @@
+package monitoring
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// defaultScrapeConfiguration scrapes the API server, the kubelets and cAdvisor through the API server, and the pods
+// annotated with prometheus.io/scrape. It is valid for both EKS managed scrapers and the ADOT collector.
+const defaultScrapeConfiguration = `global:
+  scrape_interval: 30s
+  external_labels:
+    clusterArn: %s
+scrape_configs:
+  - job_name: kubernetes-apiservers
+    scheme: https
+    kubernetes_sd_configs:
+      - role: endpoints
+    tls_config:
+      ca_file: /var/run/secrets/kubernetes.io/serviceaccount/ca.crt
+      insecure_skip_verify: true
+    bearer_token_file: /var/run/secrets/kubernetes.io/serviceaccount/token
+    relabel_configs:
+      - source_labels: [__meta_kubernetes_namespace, __meta_kubernetes_service_name, __meta_kubernetes_endpoint_port_name]
+        action: keep
+        regex: default;kubernetes;https
+  - job_name: kubernetes-kubelet
+    scheme: https
+    kubernetes_sd_configs:
+      - role: node
+    tls_config:
+      ca_file: /var/run/secrets/kubernetes.io/serviceaccount/ca.crt
+      insecure_skip_verify: true
+    bearer_token_file: /var/run/secrets/kubernetes.io/serviceaccount/token
+    relabel_configs:
+      - action: labelmap
+        regex: __meta_kubernetes_node_label_(.+)
+      - target_label: __address__
+        replacement: kubernetes.default.svc:443
+      - source_labels: [__meta_kubernetes_node_name]
+        regex: (.+)
+        target_label: __metrics_path__
+        replacement: /api/v1/nodes/$1/proxy/metrics
+  - job_name: kubernetes-cadvisor
+    scheme: https
+    kubernetes_sd_configs:
+      - role: node
+    tls_config:
+      ca_file: /var/run/secrets/kubernetes.io/serviceaccount/ca.crt
+      insecure_skip_verify: true
+    bearer_token_file: /var/run/secrets/kubernetes.io/serviceaccount/token
+    relabel_configs:
+      - action: labelmap
+        regex: __meta_kubernetes_node_label_(.+)
+      - target_label: __address__
+        replacement: kubernetes.default.svc:443
+      - source_labels: [__meta_kubernetes_node_name]
+        regex: (.+)
+        target_label: __metrics_path__
+        replacement: /api/v1/nodes/$1/proxy/metrics/cadvisor
+  - job_name: kubernetes-pods
+    kubernetes_sd_configs:
+      - role: pod
+    relabel_configs:
+      - source_labels: [__meta_kubernetes_pod_annotation_prometheus_io_scrape]
+        action: keep
+        regex: "true"
+      - source_labels: [__meta_kubernetes_pod_annotation_prometheus_io_path]
+        action: replace
+        target_label: __metrics_path__
+        regex: (.+)
+      - source_labels: [__address__, __meta_kubernetes_pod_annotation_prometheus_io_port]
+        action: replace
+        regex: ([^:]+)(?::\d+)?;(\d+)
+        replacement: $1:$2
+        target_label: __address__
+      - source_labels: [__meta_kubernetes_namespace]
+        target_label: namespace
+      - source_labels: [__meta_kubernetes_pod_name]
+        target_label: pod
+`
+
+// ScrapeConfiguration returns scrapeConfiguration, or the default scrape configuration, labelling the metrics with the
+// ARN of the cluster, if it is empty
+func ScrapeConfiguration(scrapeConfiguration, clusterARN string) string {
+	if scrapeConfiguration != "" {
+		return scrapeConfiguration
+	}
+	return fmt.Sprintf(defaultScrapeConfiguration, clusterARN)
+}
+
+// PrometheusReceiverConfig returns the configuration of the prometheus receiver of an OpenTelemetry collector running
+// scrapeConfiguration
+func PrometheusReceiverConfig(scrapeConfiguration string) (map[string]interface{}, error) {
+	// the collector expands environment variables in its configuration, a literal $ is written $$
+	var config map[string]interface{}
+	if err := yaml.Unmarshal([]byte(strings.ReplaceAll(scrapeConfiguration, "$", "$$")), &config); err != nil {
+		return nil, fmt.Errorf("parsing the scrape configuration: %w", err)
+	}
+	return config, nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
 	"github.com/weaveworks/eksctl/pkg/outposts"
 	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/utils/exitcode"
 	instanceutils "github.com/weaveworks/eksctl/pkg/utils/instance"
 	"github.com/weaveworks/eksctl/pkg/utils/nodes"
 	"github.com/weaveworks/eksctl/pkg/utils/tasks"
@@ -39,6 +40,12 @@ type CreateOpts struct {
 	SkipOutdatedAddonsCheck   bool
 	ConfigFileProvided        bool
 	Parallelism               int
+	// WaitForReadyNodes is the number of Ready nodes to wait for in each nodegroup;
+	// a negative value waits for the minimum size of the nodegroup
+	WaitForReadyNodes int
+	TaskGraph         TaskGraphSettings
+	// InfraEngine provisions the nodegroup resources, it defaults to CloudFormation
+	InfraEngine InfraEngine
 }
 
 type DryRunSettings struct {
@@ -46,6 +53,12 @@ type DryRunSettings struct {
 	OutStream io.Writer
 }
 
+// TaskGraphSettings holds the format of the task graphs to write to OutStream before running the tasks
+type TaskGraphSettings struct {
+	Format    tasks.GraphFormat
+	OutStream io.Writer
+}
+
 // Create creates a new nodegroup with the given options.
 func (m *Manager) Create(ctx context.Context, options CreateOpts, nodegroupFilter filter.NodegroupFilter) error {
 	cfg := m.cfg
@@ -59,6 +72,13 @@ func (m *Manager) Create(ctx context.Context, options CreateOpts, nodegroupFilte
 		}
 		return errors.New(msg)
 	}
+	engine, err := m.newEngine(options.InfraEngine)
+	if err != nil {
+		return err
+	}
+	if err := engine.Validate(cfg); err != nil {
+		return err
+	}
 	if m.accessEntry.IsAWSAuthDisabled() && options.UpdateAuthConfigMap != nil {
 		return errors.New("--update-auth-configmap is not supported when authenticationMode is set to API")
 	}
@@ -153,7 +173,7 @@ func (m *Manager) Create(ctx context.Context, options CreateOpts, nodegroupFilte
 	logFiltered := cmdutils.ApplyFilter(cfg, nodegroupFilter)
 	logFiltered()
 	logMsg := func(resource string, count int) {
-		logger.Info("will create a CloudFormation stack for each of %d %s in cluster %q", count, resource, meta.Name)
+		logger.Info("%s in cluster %q", engine.DescribeCreation(resource, count), meta.Name)
 	}
 	if len(m.cfg.NodeGroups) > 0 {
 		logMsg("nodegroups", len(cfg.NodeGroups))
@@ -174,13 +194,14 @@ func (m *Manager) Create(ctx context.Context, options CreateOpts, nodegroupFilte
 		return cmdutils.PrintNodeGroupDryRunConfig(clusterConfigCopy, options.DryRunSettings.OutStream)
 	}
 
-	if err := m.nodeCreationTasks(ctx, isOwnedCluster, skipEgressRules, options.UpdateAuthConfigMap, options.Parallelism); err != nil {
+	if err := m.nodeCreationTasks(ctx, engine, isOwnedCluster, skipEgressRules, options); err != nil {
 		return err
 	}
 
 	if err := m.postNodeCreationTasks(ctx, m.clientSet, options); err != nil {
 		return err
 	}
+	RecordCreation(ctx, ctl.AWSProvider, cfg, cmdutils.CommandLine())
 
 	if err := eks.ValidateExistingNodeGroupsForCompatibility(ctx, cfg, m.stackManager); err != nil {
 		logger.Critical("failed checking nodegroups", err.Error())
@@ -206,78 +227,39 @@ func makeOutpostsService(clusterConfig *api.ClusterConfig, provider api.ClusterP
 	}
 }
 
-func (m *Manager) nodeCreationTasks(ctx context.Context, isOwnedCluster, skipEgressRules bool, updateAuthConfigMap *bool, parallelism int) error {
-	cfg := m.cfg
-	meta := cfg.Metadata
-
-	taskTree := &tasks.TaskTree{
-		Parallel: false,
-	}
-
-	if isOwnedCluster {
-		taskTree.Append(&tasks.GenericTask{
-			Doer: func() error {
-				if err := m.stackManager.FixClusterCompatibility(ctx); err != nil {
-					return err
-				}
-				hasDedicatedVPC, err := m.stackManager.ClusterHasDedicatedVPC(ctx)
-				if err != nil {
-					return fmt.Errorf("error checking if cluster has a dedicated VPC: %w", err)
-				}
-				if !hasDedicatedVPC {
-					return nil
-				}
-				clusterExtender := &outposts.ClusterExtender{
-					StackUpdater: m.stackManager,
-					EC2API:       m.ctl.AWSProvider.EC2(),
-					OutpostsAPI:  m.ctl.AWSProvider.Outposts(),
-				}
-				if err := clusterExtender.ExtendWithOutpostSubnetsIfRequired(ctx, m.cfg, m.cfg.VPC); err != nil {
-					return fmt.Errorf("error extending cluster with Outpost subnets: %w", err)
-				}
-				return nil
-			},
-			Description: "fix cluster compatibility",
-		})
-	}
-
+func (m *Manager) nodeCreationTasks(ctx context.Context, engine Engine, isOwnedCluster, skipEgressRules bool, options CreateOpts) error {
 	awsNodeUsesIRSA, err := eks.DoesAWSNodeUseIRSA(ctx, m.ctl.AWSProvider, m.clientSet)
 	if err != nil {
 		return errors.Wrap(err, "couldn't check aws-node for annotation")
 	}
 
-	if !awsNodeUsesIRSA && api.IsEnabled(cfg.IAM.WithOIDC) {
+	if !awsNodeUsesIRSA && api.IsEnabled(m.cfg.IAM.WithOIDC) {
 		logger.Debug("cluster has withOIDC enabled but is not using IRSA for CNI, will add CNI policy to node role")
 	}
 
-	var vpcImporter vpc.Importer
-	if isOwnedCluster {
-		vpcImporter = vpc.NewStackConfigImporter(m.stackManager.MakeClusterStackName())
-	} else {
-		vpcImporter = vpc.NewSpecConfigImporter(*m.ctl.Status.ClusterInfo.Cluster.ResourcesVpcConfig.ClusterSecurityGroupId, cfg.VPC)
-	}
-
-	allNodeGroupTasks := &tasks.TaskTree{
-		Parallel: true,
-	}
-	disableAccessEntryCreation := !m.accessEntry.IsEnabled() || updateAuthConfigMap != nil
-	if nodeGroupTasks := m.stackManager.NewUnmanagedNodeGroupTask(ctx, cfg.NodeGroups, !awsNodeUsesIRSA, skipEgressRules,
-		disableAccessEntryCreation, vpcImporter, parallelism); nodeGroupTasks.Len() > 0 {
-		allNodeGroupTasks.Append(nodeGroupTasks)
+	taskTree, err := engine.NewTasksToCreateNodeGroups(ctx, CreateTasksInput{
+		IsOwnedCluster:             isOwnedCluster,
+		SkipEgressRules:            skipEgressRules,
+		ForceAddCNIPolicy:          !awsNodeUsesIRSA,
+		DisableAccessEntryCreation: !m.accessEntry.IsEnabled() || options.UpdateAuthConfigMap != nil,
+		Parallelism:                options.Parallelism,
+	})
+	if err != nil {
+		return err
 	}
-	managedTasks := m.stackManager.NewManagedNodeGroupTask(ctx, cfg.ManagedNodeGroups, !awsNodeUsesIRSA, vpcImporter, parallelism)
-	if managedTasks.Len() > 0 {
-		allNodeGroupTasks.Append(managedTasks)
+	if err := taskTree.WriteGraph(options.TaskGraph.OutStream, options.TaskGraph.Format); err != nil {
+		return err
 	}
-
-	taskTree.Append(allNodeGroupTasks)
-	return eks.DoAllNodegroupStackTasks(taskTree, meta.Region, meta.Name)
+	return engine.RunCreateTasks(ctx, taskTree)
 }
 
 func (m *Manager) postNodeCreationTasks(ctx context.Context, clientSet kubernetes.Interface, options CreateOpts) error {
-	tasks := m.ctl.ClusterTasksForNodeGroups(m.cfg, options.InstallNeuronDevicePlugin, options.InstallNvidiaDevicePlugin)
-	logger.Info(tasks.Describe())
-	errs := tasks.DoAllSync()
+	taskTree := m.ctl.ClusterTasksForNodeGroups(m.cfg, options.InstallNeuronDevicePlugin, options.InstallNvidiaDevicePlugin)
+	logger.Info(taskTree.Describe())
+	if err := taskTree.WriteGraph(options.TaskGraph.OutStream, options.TaskGraph.Format); err != nil {
+		return err
+	}
+	errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{})
 	if len(errs) > 0 {
 		logger.Info("%d error(s) occurred and nodegroups haven't been created properly, you may wish to check CloudFormation console", len(errs))
 		logger.Info("to cleanup resources, run 'eksctl delete nodegroup --region=%s --cluster=%s --name=<name>' for each of the failed nodegroups", m.cfg.Metadata.Region, m.cfg.Metadata.Name)
@@ -286,7 +268,7 @@ func (m *Manager) postNodeCreationTasks(ctx context.Context, clientSet kubernete
 				logger.Critical("%s\n", err.Error())
 			}
 		}
-		return fmt.Errorf("failed to create nodegroups for cluster %q", m.cfg.Metadata.Name)
+		return exitcode.WithCause(fmt.Errorf("failed to create nodegroups for cluster %q", m.cfg.Metadata.Name), errs)
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, m.ctl.AWSProvider.WaitTimeout())
@@ -305,7 +287,7 @@ func (m *Manager) postNodeCreationTasks(ctx context.Context, clientSet kubernete
 	// only wait for self-managed nodes to join if either authorization method is being used
 	if !api.IsDisabled(options.UpdateAuthConfigMap) {
 		for _, ng := range m.cfg.NodeGroups {
-			if err := eks.WaitForNodes(timeoutCtx, clientSet, ng); err != nil {
+			if err := eks.WaitForReadyNodes(timeoutCtx, clientSet, ng, options.WaitForReadyNodes); err != nil {
 				return err
 			}
 		}
@@ -313,7 +295,7 @@ func (m *Manager) postNodeCreationTasks(ctx context.Context, clientSet kubernete
 	logger.Success("created %d nodegroup(s) in cluster %q", len(m.cfg.NodeGroups), m.cfg.Metadata.Name)
 
 	for _, ng := range m.cfg.ManagedNodeGroups {
-		if err := eks.WaitForNodes(timeoutCtx, clientSet, ng); err != nil {
+		if err := eks.WaitForReadyNodes(timeoutCtx, clientSet, ng, options.WaitForReadyNodes); err != nil {
 			if m.cfg.PrivateCluster.Enabled {
 				logger.Info("error waiting for nodes to join the cluster; this command was likely run from outside the cluster's VPC as the API server is not reachable, nodegroup(s) should still be able to join the cluster, underlying error is: %v", err)
 				break
@@ -12,6 +12,8 @@ import (
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -139,6 +141,10 @@ var _ = DescribeTable("Create", func(t ngEntry) {
 			clientset:       clientset,
 		})
 	}
+	// the creation of the nodegroups is recorded in their history
+	p.MockSSM().On("GetParameter", mock.Anything, mock.Anything).Return(nil, &ssmtypes.ParameterNotFound{})
+	p.MockSSM().On("PutParameter", mock.Anything, mock.Anything).Return(&ssm.PutParameterOutput{}, nil)
+	p.MockEKS().On("DescribeNodegroup", mock.Anything, mock.Anything).Return(nil, errors.New("nodegroup not found"))
 	if t.refreshCluster {
 		err := ctl.RefreshClusterStatus(context.Background(), cfg)
 		if t.expectedRefreshErr != "" {
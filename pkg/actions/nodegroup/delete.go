@@ -9,6 +9,7 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/utils/exitcode"
 	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 )
 
@@ -36,6 +37,8 @@ type Deleter struct {
 	NodeGroupDeleter     manager.NodeGroupDeleter
 	ClusterName          string
 	AuthConfigMapUpdater AuthConfigMapUpdater
+	// StacklessEngines delete the managed nodegroups they created without CloudFormation stacks
+	StacklessEngines []StacklessEngine
 }
 
 // DeleteOptions represents the options for deleting nodegroups.
@@ -43,6 +46,8 @@ type DeleteOptions struct {
 	Wait                bool
 	Plan                bool
 	UpdateAuthConfigMap bool
+	// Parallelism is the number of nodegroups to delete in parallel, 0 means no limit
+	Parallelism int
 }
 
 // Delete deletes the specified nodegroups.
@@ -64,9 +69,17 @@ func (d *Deleter) Delete(ctx context.Context, nodeGroups []*api.NodeGroup, manag
 	for _, n := range managedNodeGroups {
 		if findStack(stacks, n.Name) != nil {
 			nodeGroupsWithStacks[n.NameString()] = struct{}{}
-		} else {
-			taskTree.Append(d.StackHelper.NewTaskToDeleteUnownedNodeGroup(ctx, d.ClusterName, n.Name, d.NodeGroupDeleter, nil))
+			continue
+		}
+		engine, err := FindStacklessEngine(ctx, d.StacklessEngines, n.Name)
+		if err != nil {
+			return err
+		}
+		if engine != nil {
+			taskTree.Append(NewTaskToDeleteStacklessNodeGroup(ctx, engine, n.Name, options.Plan))
+			continue
 		}
+		taskTree.Append(d.StackHelper.NewTaskToDeleteUnownedNodeGroup(ctx, d.ClusterName, n.Name, d.NodeGroupDeleter, nil))
 	}
 
 	var deleteTasks tasks.Task
@@ -97,8 +110,9 @@ func (d *Deleter) Delete(ctx context.Context, nodeGroups []*api.NodeGroup, manag
 		taskTree.Append(deleteTasks)
 	}
 
+	taskTree.LimitParallelism(options.Parallelism)
 	logger.Info(taskTree.Describe())
-	if errs := taskTree.DoAllSync(); len(errs) > 0 {
+	if errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{}); len(errs) > 0 {
 		return handleErrors(errs, "nodegroup(s)")
 	}
 	return nil
@@ -140,5 +154,5 @@ func handleErrors(errs []error, subject string) error {
 	for _, err := range errs {
 		logger.Critical("%s\n", err.Error())
 	}
-	return fmt.Errorf("failed to delete %s", subject)
+	return exitcode.WithCause(fmt.Errorf("failed to delete %s", subject), errs)
 }
@@ -2,8 +2,14 @@ package nodegroup
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/kris-nova/logger"
@@ -20,11 +26,21 @@ type DrainInput struct {
 	MaxGracePeriod        time.Duration
 	NodeDrainWaitPeriod   time.Duration
 	PodEvictionWaitPeriod time.Duration
-	Undo                  bool
-	DisableEviction       bool
-	Parallel              int
+	// GracePeriod is how long cordoned nodes soak before their pods are evicted
+	GracePeriod time.Duration
+	Undo        bool
+	// WaitForDeployments lists the deployments that must be available again, with their pods rescheduled
+	// on other nodes, before draining is considered complete
+	WaitForDeployments []types.NamespacedName
+	// WaitForDeploymentsTimeout bounds how long to wait for WaitForDeployments, 0 for no limit other than ctx's
+	WaitForDeploymentsTimeout time.Duration
+	DisableEviction           bool
+	Parallel                  int
 }
 
+// deploymentPollInterval is how often deployments are checked while waiting for them to be available
+var deploymentPollInterval = 5 * time.Second
+
 // A Drainer drains nodegroups.
 type Drainer struct {
 	ClientSet kubernetes.Interface
@@ -40,20 +56,70 @@ func (d *Drainer) Drain(ctx context.Context, input *DrainInput) error {
 		return nil
 	}
 
-	g, ctx := errgroup.WithContext(ctx)
+	g, drainCtx := errgroup.WithContext(ctx)
 	for _, nodegroup := range input.NodeGroups {
 		nodegroup := nodegroup
 		g.Go(func() error {
-			nodeGroupDrainer := drain.NewNodeGroupDrainer(d.ClientSet, nodegroup, input.MaxGracePeriod, input.NodeDrainWaitPeriod, input.PodEvictionWaitPeriod, input.Undo, input.DisableEviction, input.Parallel)
-			return nodeGroupDrainer.Drain(ctx, sem)
+			nodeGroupDrainer := drain.NewNodeGroupDrainer(d.ClientSet, nodegroup, input.MaxGracePeriod, input.NodeDrainWaitPeriod, input.PodEvictionWaitPeriod, input.GracePeriod, input.Undo, input.DisableEviction, input.Parallel)
+			return nodeGroupDrainer.Drain(drainCtx, sem)
 		})
 	}
 	err := g.Wait()
 	if err != nil {
 		logger.Critical("Node group drain failed: %v", err)
 	}
-	waitForAllRoutinesToFinish(ctx, sem, parallelLimit)
-	return err
+	waitForAllRoutinesToFinish(drainCtx, sem, parallelLimit)
+	if err != nil || input.Undo {
+		return err
+	}
+	return d.waitForDeployments(ctx, input.WaitForDeployments, input.WaitForDeploymentsTimeout)
+}
+
+// waitForDeployments waits until all replicas of deployments are updated and available. It is called once the
+// nodegroups are drained, so the available replicas are running on other nodes. Deployments that do not exist
+// fail the wait straight away, other errors getting them are retried until timeout.
+func (d *Drainer) waitForDeployments(ctx context.Context, deployments []types.NamespacedName, timeout time.Duration) error {
+	if len(deployments) == 0 {
+		return nil
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	for _, deployment := range deployments {
+		logger.Info("waiting for deployment %q to be available", deployment)
+		err := wait.PollUntilContextCancel(ctx, deploymentPollInterval, true, func(ctx context.Context) (bool, error) {
+			dep, err := d.ClientSet.AppsV1().Deployments(deployment.Namespace).Get(ctx, deployment.Name, metav1.GetOptions{})
+			switch {
+			case apierrors.IsNotFound(err):
+				return false, err
+			case err != nil:
+				logger.Warning("failed to get deployment %q, will retry: %v", deployment, err)
+				return false, nil
+			}
+			return isDeploymentAvailable(dep), nil
+		})
+		if wait.Interrupted(err) {
+			return fmt.Errorf("timed out waiting for deployment %q to be available", deployment)
+		}
+		if err != nil {
+			return fmt.Errorf("waiting for deployment %q to be available: %w", deployment, err)
+		}
+	}
+	return nil
+}
+
+func isDeploymentAvailable(dep *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if dep.Spec.Replicas != nil {
+		replicas = *dep.Spec.Replicas
+	}
+	status := dep.Status
+	return status.ObservedGeneration >= dep.Generation &&
+		status.UpdatedReplicas == replicas &&
+		status.AvailableReplicas == replicas &&
+		status.UnavailableReplicas == 0
 }
 
 func waitForAllRoutinesToFinish(ctx context.Context, sem *semaphore.Weighted, size int64) {
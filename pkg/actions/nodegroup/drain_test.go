@@ -0,0 +1,111 @@
+package nodegroup_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	kubeclienttesting "k8s.io/client-go/testing"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+)
+
+var _ = Describe("Drain", func() {
+	var (
+		fakeClientSet *fake.Clientset
+		deployment    *appsv1.Deployment
+		replicas      int32 = 2
+		waitTimeout   time.Duration
+	)
+
+	BeforeEach(func() {
+		DeferCleanup(nodegroup.SetDeploymentPollInterval(10 * time.Millisecond))
+		waitTimeout = 0
+		deployment = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "web",
+				Namespace:  "apps",
+				Generation: 2,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+			},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				UpdatedReplicas:    2,
+				AvailableReplicas:  2,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		fakeClientSet = fake.NewSimpleClientset(deployment)
+	})
+
+	drain := func(timeout time.Duration) error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return (&nodegroup.Drainer{ClientSet: fakeClientSet}).Drain(ctx, &nodegroup.DrainInput{
+			Parallel:                  1,
+			WaitForDeployments:        []types.NamespacedName{{Namespace: "apps", Name: "web"}},
+			WaitForDeploymentsTimeout: waitTimeout,
+		})
+	}
+
+	When("the deployments to wait for are available", func() {
+		It("does not error", func() {
+			Expect(drain(time.Second)).To(Succeed())
+		})
+	})
+
+	When("a deployment to wait for never becomes available", func() {
+		BeforeEach(func() {
+			deployment.Status.AvailableReplicas = 1
+			deployment.Status.UnavailableReplicas = 1
+		})
+
+		It("times out and errors", func() {
+			Expect(drain(100 * time.Millisecond)).To(MatchError(ContainSubstring(`waiting for deployment "apps/web" to be available`)))
+		})
+
+		It("stops waiting after the wait timeout", func() {
+			waitTimeout = 100 * time.Millisecond
+			Expect(drain(time.Minute)).To(MatchError(`timed out waiting for deployment "apps/web" to be available`))
+		})
+	})
+
+	When("getting a deployment to wait for fails temporarily", func() {
+		JustBeforeEach(func() {
+			failures := 2
+			fakeClientSet.PrependReactor("get", "deployments", func(_ kubeclienttesting.Action) (bool, runtime.Object, error) {
+				if failures == 0 {
+					return false, nil, nil
+				}
+				failures--
+				return true, nil, apierrors.NewServiceUnavailable("etcd is unavailable")
+			})
+		})
+
+		It("retries and does not error", func() {
+			Expect(drain(time.Second)).To(Succeed())
+		})
+	})
+
+	When("a deployment to wait for does not exist", func() {
+		BeforeEach(func() {
+			deployment.Name = "api"
+		})
+
+		It("errors", func() {
+			Expect(drain(time.Second)).To(MatchError(ContainSubstring(`deployments.apps "web" not found`)))
+		})
+	})
+})
@@ -0,0 +1,256 @@
+package nodegroup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/outposts"
+	"github.com/weaveworks/eksctl/pkg/sdkengine"
+	"github.com/weaveworks/eksctl/pkg/utils/exitcode"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+// InfraEngine is the engine used to provision the resources of nodegroups
+type InfraEngine string
+
+const (
+	// InfraEngineCloudFormation provisions nodegroups with CloudFormation stacks
+	InfraEngineCloudFormation InfraEngine = "cloudformation"
+	// InfraEngineSDK provisions managed nodegroups with direct API calls, tagging the resources it creates
+	InfraEngineSDK InfraEngine = "sdk"
+)
+
+// An Engine provisions the resources of nodegroups.
+type Engine interface {
+	// Validate returns an error if the nodegroups of cfg use settings that the engine does not support
+	Validate(cfg *api.ClusterConfig) error
+	// DescribeCreation returns the message logged before creating count nodegroups of the given kind
+	DescribeCreation(kind string, count int) string
+	// NewTasksToCreateNodeGroups returns the tasks creating the nodegroups of the cluster config
+	NewTasksToCreateNodeGroups(ctx context.Context, input CreateTasksInput) (*tasks.TaskTree, error)
+	// RunCreateTasks runs the tasks returned by NewTasksToCreateNodeGroups
+	RunCreateTasks(ctx context.Context, taskTree *tasks.TaskTree) error
+}
+
+// A StacklessEngine is an Engine that provisions nodegroups without CloudFormation stacks. As there is no stack
+// to delete, it finds the nodegroups it created and deletes them along with the resources it created for them.
+type StacklessEngine interface {
+	Engine
+	// OwnsNodeGroup returns true if the nodegroup was created by the engine
+	OwnsNodeGroup(ctx context.Context, name string) (bool, error)
+	// DeleteNodeGroup deletes the nodegroup and the resources the engine created for it
+	DeleteNodeGroup(ctx context.Context, name string) error
+}
+
+// CreateTasksInput holds the settings of the cluster that the engines need to create nodegroups
+type CreateTasksInput struct {
+	IsOwnedCluster             bool
+	SkipEgressRules            bool
+	ForceAddCNIPolicy          bool
+	DisableAccessEntryCreation bool
+	Parallelism                int
+}
+
+// NewStacklessEngines returns the engines provisioning nodegroups without CloudFormation stacks
+func NewStacklessEngines(cfg *api.ClusterConfig, provider api.ClusterProvider) []StacklessEngine {
+	return []StacklessEngine{newSDKEngine(cfg, provider)}
+}
+
+// FindStacklessEngine returns the engine that created the nodegroup, or nil if it was not created by any of engines
+func FindStacklessEngine(ctx context.Context, engines []StacklessEngine, name string) (StacklessEngine, error) {
+	for _, engine := range engines {
+		owned, err := engine.OwnsNodeGroup(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("checking infra engine of nodegroup %q: %w", name, err)
+		}
+		if owned {
+			return engine, nil
+		}
+	}
+	return nil, nil
+}
+
+// NewTaskToDeleteStacklessNodeGroup returns a task deleting a nodegroup created by engine
+func NewTaskToDeleteStacklessNodeGroup(ctx context.Context, engine StacklessEngine, name string, plan bool) tasks.Task {
+	return &tasks.GenericTask{
+		Description: fmt.Sprintf("delete managed nodegroup %q and the resources created for it", name),
+		Doer: func() error {
+			cmdutils.LogIntendedAction(plan, "delete managed nodegroup %q created without a CloudFormation stack", name)
+			if plan {
+				return nil
+			}
+			return engine.DeleteNodeGroup(ctx, name)
+		},
+	}
+}
+
+func (m *Manager) newEngine(name InfraEngine) (Engine, error) {
+	switch name {
+	case "", InfraEngineCloudFormation:
+		return &cloudFormationEngine{m: m}, nil
+	case InfraEngineSDK:
+		return newSDKEngine(m.cfg, m.ctl.AWSProvider), nil
+	default:
+		return nil, fmt.Errorf("unknown infra engine %q", name)
+	}
+}
+
+// cloudFormationEngine creates a CloudFormation stack for each nodegroup
+type cloudFormationEngine struct {
+	m *Manager
+}
+
+func (*cloudFormationEngine) Validate(*api.ClusterConfig) error {
+	return nil
+}
+
+func (*cloudFormationEngine) DescribeCreation(kind string, count int) string {
+	return fmt.Sprintf("will create a CloudFormation stack for each of %d %s", count, kind)
+}
+
+func (e *cloudFormationEngine) NewTasksToCreateNodeGroups(ctx context.Context, input CreateTasksInput) (*tasks.TaskTree, error) {
+	m := e.m
+	taskTree := &tasks.TaskTree{
+		Parallel: false,
+	}
+
+	if input.IsOwnedCluster {
+		taskTree.Append(&tasks.GenericTask{
+			Doer: func() error {
+				if err := m.stackManager.FixClusterCompatibility(ctx); err != nil {
+					return err
+				}
+				hasDedicatedVPC, err := m.stackManager.ClusterHasDedicatedVPC(ctx)
+				if err != nil {
+					return fmt.Errorf("error checking if cluster has a dedicated VPC: %w", err)
+				}
+				if !hasDedicatedVPC {
+					return nil
+				}
+				clusterExtender := &outposts.ClusterExtender{
+					StackUpdater: m.stackManager,
+					EC2API:       m.ctl.AWSProvider.EC2(),
+					OutpostsAPI:  m.ctl.AWSProvider.Outposts(),
+				}
+				if err := clusterExtender.ExtendWithOutpostSubnetsIfRequired(ctx, m.cfg, m.cfg.VPC); err != nil {
+					return fmt.Errorf("error extending cluster with Outpost subnets: %w", err)
+				}
+				return nil
+			},
+			Description: "fix cluster compatibility",
+		})
+	}
+
+	var vpcImporter vpc.Importer
+	if input.IsOwnedCluster {
+		vpcImporter = vpc.NewStackConfigImporter(m.stackManager.MakeClusterStackName())
+	} else {
+		vpcImporter = vpc.NewSpecConfigImporter(*m.ctl.Status.ClusterInfo.Cluster.ResourcesVpcConfig.ClusterSecurityGroupId, m.cfg.VPC)
+	}
+
+	allNodeGroupTasks := &tasks.TaskTree{
+		Parallel: true,
+	}
+	if nodeGroupTasks := m.stackManager.NewUnmanagedNodeGroupTask(ctx, m.cfg.NodeGroups, input.ForceAddCNIPolicy, input.SkipEgressRules,
+		input.DisableAccessEntryCreation, vpcImporter, input.Parallelism); nodeGroupTasks.Len() > 0 {
+		allNodeGroupTasks.Append(nodeGroupTasks)
+	}
+	managedTasks := m.stackManager.NewManagedNodeGroupTask(ctx, m.cfg.ManagedNodeGroups, input.ForceAddCNIPolicy, vpcImporter, input.Parallelism)
+	if managedTasks.Len() > 0 {
+		allNodeGroupTasks.Append(managedTasks)
+	}
+
+	taskTree.Append(allNodeGroupTasks)
+	return taskTree, nil
+}
+
+func (e *cloudFormationEngine) RunCreateTasks(_ context.Context, taskTree *tasks.TaskTree) error {
+	return eks.DoAllNodegroupStackTasks(taskTree, e.m.cfg.Metadata.Region, e.m.cfg.Metadata.Name)
+}
+
+// sdkEngine creates managed nodegroups and their IAM roles with the EKS and IAM APIs
+type sdkEngine struct {
+	cfg      *api.ClusterConfig
+	provider api.ClusterProvider
+}
+
+func newSDKEngine(cfg *api.ClusterConfig, provider api.ClusterProvider) *sdkEngine {
+	return &sdkEngine{
+		cfg:      cfg,
+		provider: provider,
+	}
+}
+
+func (e *sdkEngine) managedNodeGroupEngine(attachCNIPolicy bool) *sdkengine.ManagedNodeGroupEngine {
+	return &sdkengine.ManagedNodeGroupEngine{
+		ClusterConfig:   e.cfg,
+		EKSAPI:          e.provider.EKS(),
+		IAMAPI:          e.provider.IAM(),
+		EC2API:          e.provider.EC2(),
+		WaitTimeout:     e.provider.WaitTimeout(),
+		AttachCNIPolicy: attachCNIPolicy,
+	}
+}
+
+func (*sdkEngine) Validate(cfg *api.ClusterConfig) error {
+	if len(cfg.NodeGroups) > 0 {
+		return fmt.Errorf("the %s infra engine only supports managed nodegroups", InfraEngineSDK)
+	}
+	for _, ng := range cfg.ManagedNodeGroups {
+		if err := sdkengine.ValidateManagedNodeGroup(ng); err != nil {
+			return err
+		}
+	}
+	logger.Warning("the %s infra engine is experimental, nodegroup resources will be created without CloudFormation", InfraEngineSDK)
+	return nil
+}
+
+func (*sdkEngine) DescribeCreation(kind string, count int) string {
+	return fmt.Sprintf("will create %d %s using the %s infra engine", count, kind, InfraEngineSDK)
+}
+
+// NewTasksToCreateNodeGroups returns the tasks creating the managed nodegroups; unlike with CloudFormation,
+// the cluster stack is not updated
+func (e *sdkEngine) NewTasksToCreateNodeGroups(ctx context.Context, input CreateTasksInput) (*tasks.TaskTree, error) {
+	engine := e.managedNodeGroupEngine(!api.IsEnabled(e.cfg.IAM.WithOIDC) || input.ForceAddCNIPolicy)
+	taskTree := &tasks.TaskTree{
+		Parallel: true,
+		Limit:    input.Parallelism,
+	}
+	for _, ng := range e.cfg.ManagedNodeGroups {
+		ng := ng
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("create managed nodegroup %q and its IAM role", ng.Name),
+			Doer: func() error {
+				return engine.Create(ctx, ng)
+			},
+		})
+	}
+	return taskTree, nil
+}
+
+func (e *sdkEngine) RunCreateTasks(ctx context.Context, taskTree *tasks.TaskTree) error {
+	logger.Info(taskTree.Describe())
+	if errs := taskTree.DoAllSyncContext(ctx, tasks.RunOptions{}); len(errs) > 0 {
+		logger.Info("%d error(s) occurred creating managed nodegroups for cluster %q", len(errs), e.cfg.Metadata.Name)
+		for _, err := range errs {
+			logger.Critical("%s\n", err.Error())
+		}
+		return exitcode.WithCause(fmt.Errorf("failed to create nodegroups for cluster %q", e.cfg.Metadata.Name), errs)
+	}
+	return nil
+}
+
+func (e *sdkEngine) OwnsNodeGroup(ctx context.Context, name string) (bool, error) {
+	return e.managedNodeGroupEngine(false).IsManaged(ctx, name)
+}
+
+func (e *sdkEngine) DeleteNodeGroup(ctx context.Context, name string) error {
+	return e.managedNodeGroupEngine(false).Delete(ctx, name)
+}
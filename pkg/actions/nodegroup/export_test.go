@@ -1,6 +1,11 @@
 package nodegroup
 
 import (
+	"context"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/eks"
 )
@@ -21,3 +26,32 @@ func (m *Manager) GetStackManager() manager.StackManager {
 func (m *Manager) MockKubeProvider(k eks.KubeProvider) {
 	m.ctl.KubeProvider = k
 }
+
+func SetDeploymentPollInterval(interval time.Duration) func() {
+	previous := deploymentPollInterval
+	deploymentPollInterval = interval
+	return func() {
+		deploymentPollInterval = previous
+	}
+}
+
+// PodEvictionForcer force-evicts the pods blocking the drain of the nodes of a nodegroup.
+type PodEvictionForcer struct {
+	forcer *podEvictionForcer
+}
+
+// NewPodEvictionForcer returns a PodEvictionForcer reading the time from now.
+func NewPodEvictionForcer(clientSet kubernetes.Interface, nodeGroupName string, grace time.Duration, now func() time.Time) *PodEvictionForcer {
+	forcer := newPodEvictionForcer(clientSet, nodeGroupName, grace)
+	forcer.now = now
+	return &PodEvictionForcer{forcer: forcer}
+}
+
+// ForceEvict force-evicts the pods of the nodes drained for longer than the grace period and returns all the
+// pods force-evicted so far.
+func (f *PodEvictionForcer) ForceEvict(ctx context.Context) ([]string, error) {
+	if err := f.forcer.forceEvict(ctx); err != nil {
+		return nil, err
+	}
+	return f.forcer.evictedPods(), nil
+}
@@ -0,0 +1,179 @@
+package nodegroup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/drain/evictor"
+)
+
+const (
+	// forceEvictionInterval is how often the nodes being drained are looked for
+	forceEvictionInterval = 30 * time.Second
+	// forceEvictionMaxGracePeriod caps the termination grace period of the force-evicted pods
+	forceEvictionMaxGracePeriod = 30 * time.Second
+	// eksPodEvictionTimeout is how long EKS tries to evict the pods of a node before failing the upgrade with
+	// PodEvictionFailure, the eviction grace period must be shorter for the forced evictions to happen in time
+	eksPodEvictionTimeout = 15 * time.Minute
+)
+
+// podEvictionForcer deletes the pods left on a node of a managed nodegroup once the node has been drained
+// for longer than the eviction grace period. EKS cordons all the old nodes at once but drains them one at a time,
+// evicting pods respecting PodDisruptionBudgets, so the pods still running on a node after it has been drained
+// for that long are the ones blocking the upgrade
+type podEvictionForcer struct {
+	clientSet     kubernetes.Interface
+	nodeGroupName string
+	grace         time.Duration
+	interval      time.Duration
+	now           func() time.Time
+
+	// podCounts is the number of pods on each cordoned node when it was last looked at
+	podCounts map[string]int
+	// drainStarted is when each node was first seen being drained
+	drainStarted map[string]time.Time
+
+	mu      sync.Mutex
+	evicted []string
+}
+
+func newPodEvictionForcer(clientSet kubernetes.Interface, nodeGroupName string, grace time.Duration) *podEvictionForcer {
+	return &podEvictionForcer{
+		clientSet:     clientSet,
+		nodeGroupName: nodeGroupName,
+		grace:         grace,
+		interval:      forceEvictionInterval,
+		now:           time.Now,
+		podCounts:     map[string]int{},
+		drainStarted:  map[string]time.Time{},
+	}
+}
+
+// run force-evicts the pods blocking the drain of each node every interval, until ctx is done
+func (f *podEvictionForcer) run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		if err := f.forceEvict(ctx); err != nil {
+			logger.Warning("failed to force-evict pods from nodegroup %q: %v", f.nodeGroupName, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// forceEvict deletes the pods running on the nodes of the nodegroup that have been drained for longer than the
+// grace period, bypassing PodDisruptionBudgets. A cordoned node is being drained once pods are terminating on it,
+// or it runs fewer pods than when it was last looked at
+func (f *podEvictionForcer) forceEvict(ctx context.Context) error {
+	nodes, err := f.clientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{api.EKSNodeGroupNameLabel: f.nodeGroupName}.String(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing nodes")
+	}
+
+	podEvictor := evictor.New(f.clientSet, forceEvictionMaxGracePeriod, nil, true)
+	if err := podEvictor.CanUseEvictions(); err != nil {
+		return err
+	}
+	now := f.now()
+	for _, node := range nodes.Items {
+		if !node.Spec.Unschedulable {
+			continue
+		}
+		list, errs := podEvictor.GetPodsForEviction(node.Name)
+		if list == nil {
+			return fmt.Errorf("listing pods on node %q: %v", node.Name, errs)
+		}
+		var pods []corev1.Pod
+		terminating := false
+		for _, pod := range list.Pods() {
+			if pod.Spec.NodeName != node.Name {
+				continue
+			}
+			if pod.DeletionTimestamp != nil {
+				terminating = true
+				continue
+			}
+			pods = append(pods, pod)
+		}
+
+		lastCount, seen := f.podCounts[node.Name]
+		f.podCounts[node.Name] = len(pods)
+		started, draining := f.drainStarted[node.Name]
+		if !draining {
+			if !terminating && (!seen || len(pods) >= lastCount) {
+				continue
+			}
+			logger.Debug("node %q of nodegroup %q is being drained", node.Name, f.nodeGroupName)
+			f.drainStarted[node.Name], started = now, now
+		}
+		if len(pods) == 0 || now.Sub(started) < f.grace {
+			continue
+		}
+
+		logger.Warning("node %q has not been drained within %s, force-evicting the pods blocking the upgrade", node.Name, f.grace)
+		for _, pod := range pods {
+			if err := podEvictor.EvictOrDeletePod(pod); err != nil {
+				return errors.Wrapf(err, "deleting pod %s/%s", pod.Namespace, pod.Name)
+			}
+			logger.Warning("force-evicted pod %s/%s from node %q", pod.Namespace, pod.Name, node.Name)
+			f.mu.Lock()
+			f.evicted = append(f.evicted, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+			f.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// evictedPods returns the namespaced names of the pods that were force-evicted
+func (f *podEvictionForcer) evictedPods() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.evicted...)
+}
+
+// report logs the pods that were force-evicted during the upgrade
+func (f *podEvictionForcer) report() {
+	evicted := f.evictedPods()
+	if len(evicted) == 0 {
+		logger.Info("no pods were force-evicted from nodegroup %q", f.nodeGroupName)
+		return
+	}
+	logger.Warning("%d pod(s) were force-evicted from nodegroup %q, bypassing their PodDisruptionBudgets: %s", len(evicted), f.nodeGroupName, strings.Join(evicted, ", "))
+}
+
+// withPodEvictionGrace runs upgrade while force-evicting the pods blocking it once options.PodEvictionGrace has passed
+func (m *Manager) withPodEvictionGrace(ctx context.Context, options UpgradeOptions, upgrade func() error) error {
+	if options.PodEvictionGrace == 0 {
+		return upgrade()
+	}
+	forcer := newPodEvictionForcer(m.clientSet, options.NodegroupName, options.PodEvictionGrace)
+	forcerCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		forcer.run(forcerCtx)
+	}()
+
+	err := upgrade()
+	cancel()
+	<-done
+	forcer.report()
+	return err
+}
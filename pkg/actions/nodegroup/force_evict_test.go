@@ -0,0 +1,135 @@
+package nodegroup_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("Force evicting pods", func() {
+	newNode := func(name, nodeGroupName string, cordoned bool) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{api.EKSNodeGroupNameLabel: nodeGroupName},
+			},
+			Spec: corev1.NodeSpec{Unschedulable: cordoned},
+		}
+	}
+	newPod := func(name, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "apps/v1",
+						Kind:       "ReplicaSet",
+						Name:       "app",
+						Controller: aws.Bool(true),
+					},
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+
+	var (
+		clientSet *fake.Clientset
+		now       time.Time
+		forcer    *nodegroup.PodEvictionForcer
+	)
+
+	BeforeEach(func() {
+		terminating := newPod("terminating", "draining")
+		terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		clientSet = fake.NewSimpleClientset(
+			newNode("draining", "ng-1", true),
+			newNode("cordoned", "ng-1", true),
+			newNode("running", "ng-1", false),
+			newNode("other", "ng-2", true),
+			terminating,
+			newPod("blocked", "draining"),
+			newPod("waiting", "cordoned"),
+			newPod("blocked-2", "cordoned"),
+			newPod("unaffected", "running"),
+			newPod("other-nodegroup", "other"),
+		)
+		now = time.Now()
+		forcer = nodegroup.NewPodEvictionForcer(clientSet, "ng-1", 5*time.Minute, func() time.Time {
+			return now
+		})
+	})
+
+	podNames := func() []string {
+		pods, err := clientSet.CoreV1().Pods("default").List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, pod := range pods.Items {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	It("force-evicts only the pods on the node being drained once the grace period has passed", func() {
+		evicted, err := forcer.ForceEvict(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evicted).To(BeEmpty())
+
+		now = now.Add(5 * time.Minute)
+		evicted, err = forcer.ForceEvict(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evicted).To(ConsistOf("default/blocked"))
+		Expect(podNames()).To(ConsistOf("terminating", "waiting", "blocked-2", "unaffected", "other-nodegroup"))
+	})
+
+	It("times the grace period from when each node starts being drained", func() {
+		evicted, err := forcer.ForceEvict(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evicted).To(BeEmpty())
+
+		// EKS moves on to the next node, evicting one of its pods
+		now = now.Add(4 * time.Minute)
+		Expect(clientSet.CoreV1().Pods("default").Delete(context.Background(), "blocked", metav1.DeleteOptions{})).To(Succeed())
+		Expect(clientSet.CoreV1().Pods("default").Delete(context.Background(), "terminating", metav1.DeleteOptions{})).To(Succeed())
+		Expect(clientSet.CoreV1().Pods("default").Delete(context.Background(), "waiting", metav1.DeleteOptions{})).To(Succeed())
+		evicted, err = forcer.ForceEvict(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evicted).To(BeEmpty())
+
+		now = now.Add(4 * time.Minute)
+		evicted, err = forcer.ForceEvict(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evicted).To(BeEmpty())
+
+		now = now.Add(time.Minute)
+		evicted, err = forcer.ForceEvict(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evicted).To(ConsistOf("default/blocked-2"))
+	})
+
+	It("does not force-evict anything when no node is being drained", func() {
+		clientSet = fake.NewSimpleClientset(
+			newNode("cordoned", "ng-1", true),
+			newPod("waiting", "cordoned"),
+		)
+		forcer = nodegroup.NewPodEvictionForcer(clientSet, "ng-1", 0, time.Now)
+
+		for i := 0; i < 2; i++ {
+			evicted, err := forcer.ForceEvict(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(evicted).To(BeEmpty())
+		}
+	})
+})
@@ -0,0 +1,215 @@
+package nodegroup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+const (
+	// maxHistoryEntries is the number of changes kept in the history of a nodegroup
+	maxHistoryEntries = 50
+	// maxHistorySize is the maximum size of the value of a standard SSM parameter
+	maxHistorySize = 4096
+	// maxDeleteParameters is the maximum number of parameters SSM deletes in a single call
+	maxDeleteParameters = 10
+)
+
+// HistoryEntry is a change eksctl made to the nodes of a nodegroup
+type HistoryEntry struct {
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+
+	KubernetesVersion     string `json:"kubernetesVersion,omitempty"`
+	ReleaseVersion        string `json:"releaseVersion,omitempty"`
+	LaunchTemplateVersion string `json:"launchTemplateVersion,omitempty"`
+	AMI                   string `json:"ami,omitempty"`
+}
+
+// HistoryParameterName returns the name of the SSM parameter the history of a nodegroup is recorded in
+func HistoryParameterName(clusterName, nodeGroupName string) string {
+	return fmt.Sprintf("/eksctl/%s/nodegroups/%s/history", clusterName, nodeGroupName)
+}
+
+// GetHistory returns the changes recorded for a nodegroup, oldest first
+func GetHistory(ctx context.Context, ssmAPI awsapi.SSM, clusterName, nodeGroupName string) ([]HistoryEntry, error) {
+	name := HistoryParameterName(clusterName, nodeGroupName)
+	output, err := ssmAPI.GetParameter(ctx, &ssm.GetParameterInput{Name: aws.String(name)})
+	if err != nil {
+		var notFound *ssmtypes.ParameterNotFound
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading the history of nodegroup %q from SSM parameter %q: %w", nodeGroupName, name, err)
+	}
+	var history []HistoryEntry
+	if err := json.Unmarshal([]byte(aws.ToString(output.Parameter.Value)), &history); err != nil {
+		return nil, fmt.Errorf("parsing the history of nodegroup %q in SSM parameter %q: %w", nodeGroupName, name, err)
+	}
+	return history, nil
+}
+
+// RecordHistory appends entry to the history of a nodegroup, dropping the oldest entries so that the history
+// fits in a standard SSM parameter
+func RecordHistory(ctx context.Context, ssmAPI awsapi.SSM, clusterName, nodeGroupName string, entry HistoryEntry) error {
+	history, err := GetHistory(ctx, ssmAPI, clusterName, nodeGroupName)
+	if err != nil {
+		return err
+	}
+	history = append(history, entry)
+	if len(history) > maxHistoryEntries {
+		history = history[len(history)-maxHistoryEntries:]
+	}
+	var value []byte
+	for {
+		if value, err = json.Marshal(history); err != nil {
+			return err
+		}
+		if len(value) <= maxHistorySize || len(history) == 1 {
+			break
+		}
+		history = history[1:]
+	}
+
+	name := HistoryParameterName(clusterName, nodeGroupName)
+	if _, err := ssmAPI.PutParameter(ctx, &ssm.PutParameterInput{
+		Name:      aws.String(name),
+		Value:     aws.String(string(value)),
+		Type:      ssmtypes.ParameterTypeString,
+		Overwrite: aws.Bool(true),
+	}); err != nil {
+		return fmt.Errorf("recording the history of nodegroup %q in SSM parameter %q: %w", nodeGroupName, name, err)
+	}
+	return nil
+}
+
+// DeleteHistory deletes the history of a nodegroup
+func DeleteHistory(ctx context.Context, ssmAPI awsapi.SSM, clusterName, nodeGroupName string) error {
+	name := HistoryParameterName(clusterName, nodeGroupName)
+	if _, err := ssmAPI.DeleteParameter(ctx, &ssm.DeleteParameterInput{Name: aws.String(name)}); err != nil {
+		var notFound *ssmtypes.ParameterNotFound
+		if errors.As(err, &notFound) {
+			return nil
+		}
+		return fmt.Errorf("deleting the history of nodegroup %q in SSM parameter %q: %w", nodeGroupName, name, err)
+	}
+	return nil
+}
+
+// DeleteClusterHistory deletes the history of all the nodegroups of a cluster, including the nodegroups deleted along
+// with the cluster
+func DeleteClusterHistory(ctx context.Context, ssmAPI awsapi.SSM, clusterName string) error {
+	path := fmt.Sprintf("/eksctl/%s/nodegroups", clusterName)
+	var names []string
+	paginator := ssm.NewGetParametersByPathPaginator(ssmAPI, &ssm.GetParametersByPathInput{
+		Path:      aws.String(path),
+		Recursive: aws.Bool(true),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("listing the SSM parameters under %q: %w", path, err)
+		}
+		for _, parameter := range output.Parameters {
+			if name := aws.ToString(parameter.Name); strings.HasSuffix(name, "/history") {
+				names = append(names, name)
+			}
+		}
+	}
+	for len(names) > 0 {
+		batch := names[:min(len(names), maxDeleteParameters)]
+		names = names[len(batch):]
+		if _, err := ssmAPI.DeleteParameters(ctx, &ssm.DeleteParametersInput{Names: batch}); err != nil {
+			return fmt.Errorf("deleting the history of the nodegroups of cluster %q: %w", clusterName, err)
+		}
+	}
+	return nil
+}
+
+// RecordCreation records the creation of the nodegroups of clusterConfig by command in their history, along with the
+// versions they were created with. Nodegroups whose creation cannot be recorded are logged with a warning.
+func RecordCreation(ctx context.Context, provider api.ClusterProvider, clusterConfig *api.ClusterConfig, command string) {
+	clusterName := clusterConfig.Metadata.Name
+	now := time.Now().UTC()
+	record := func(name string, entry HistoryEntry) {
+		if err := RecordHistory(ctx, provider.SSM(), clusterName, name, entry); err != nil {
+			logger.Warning("failed to record the creation in the history of nodegroup %q: %v", name, err)
+		}
+	}
+	for _, ng := range clusterConfig.NodeGroups {
+		record(ng.Name, HistoryEntry{
+			Time:              now,
+			Command:           command,
+			KubernetesVersion: clusterConfig.Metadata.Version,
+			AMI:               ng.AMI,
+		})
+	}
+	launchTemplateFetcher := builder.NewLaunchTemplateFetcher(provider.EC2())
+	for _, ng := range clusterConfig.ManagedNodeGroups {
+		entry := HistoryEntry{
+			Time:    now,
+			Command: command,
+		}
+		if err := setCurrentVersions(ctx, provider.EKS(), launchTemplateFetcher, clusterName, ng.Name, &entry); err != nil {
+			logger.Warning("failed to record the creation in the history of nodegroup %q: %v", ng.Name, err)
+			continue
+		}
+		record(ng.Name, entry)
+	}
+}
+
+// setCurrentVersions sets the Kubernetes version, release version, launch template version and AMI a managed
+// nodegroup currently runs in entry
+func setCurrentVersions(ctx context.Context, eksAPI awsapi.EKS, launchTemplateFetcher *builder.LaunchTemplateFetcher, clusterName, nodeGroupName string, entry *HistoryEntry) error {
+	output, err := eksAPI.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
+		ClusterName:   aws.String(clusterName),
+		NodegroupName: aws.String(nodeGroupName),
+	})
+	if err != nil {
+		return err
+	}
+	ng := output.Nodegroup
+	entry.KubernetesVersion = aws.ToString(ng.Version)
+	entry.ReleaseVersion = aws.ToString(ng.ReleaseVersion)
+	if lt := ng.LaunchTemplate; lt != nil && lt.Id != nil {
+		entry.LaunchTemplateVersion = aws.ToString(lt.Version)
+		launchTemplate, err := launchTemplateFetcher.Fetch(ctx, &api.LaunchTemplate{ID: *lt.Id, Version: lt.Version})
+		if err != nil {
+			return fmt.Errorf("fetching launch template data: %w", err)
+		}
+		entry.AMI = aws.ToString(launchTemplate.ImageId)
+	}
+	return nil
+}
+
+// RecordUpgrade records an upgrade of a nodegroup made by command in its history. Once the upgrade has
+// completed, the resulting versions of the nodegroup are recorded, otherwise the requested ones.
+func (m *Manager) RecordUpgrade(ctx context.Context, options UpgradeOptions, command string) error {
+	entry := HistoryEntry{
+		Time:                  time.Now().UTC(),
+		Command:               command,
+		KubernetesVersion:     options.KubernetesVersion,
+		ReleaseVersion:        options.ReleaseVersion,
+		LaunchTemplateVersion: options.LaunchTemplateVersion,
+		AMI:                   options.AMI,
+	}
+	if options.Wait {
+		if err := setCurrentVersions(ctx, m.ctl.AWSProvider.EKS(), m.launchTemplateFetcher, m.cfg.Metadata.Name, options.NodegroupName, &entry); err != nil {
+			return err
+		}
+	}
+	return RecordHistory(ctx, m.ctl.AWSProvider.SSM(), m.cfg.Metadata.Name, options.NodegroupName, entry)
+}
@@ -0,0 +1,156 @@
+package nodegroup_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("History", func() {
+	const parameterName = "/eksctl/my-cluster/nodegroups/ng-1/history"
+
+	var (
+		provider *mockprovider.MockProvider
+		now      time.Time
+	)
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		now = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	})
+
+	mockHistory := func(history []nodegroup.HistoryEntry) {
+		value, err := json.Marshal(history)
+		Expect(err).NotTo(HaveOccurred())
+		provider.MockSSM().On("GetParameter", mock.Anything, &ssm.GetParameterInput{Name: aws.String(parameterName)}).
+			Return(&ssm.GetParameterOutput{Parameter: &ssmtypes.Parameter{Value: aws.String(string(value))}}, nil)
+	}
+
+	putHistory := func() []nodegroup.HistoryEntry {
+		var history []nodegroup.HistoryEntry
+		provider.MockSSM().AssertNumberOfCalls(GinkgoT(), "PutParameter", 1)
+		input := provider.MockSSM().Calls[len(provider.MockSSM().Calls)-1].Arguments[1].(*ssm.PutParameterInput)
+		Expect(*input.Name).To(Equal(parameterName))
+		Expect(*input.Overwrite).To(BeTrue())
+		Expect(json.Unmarshal([]byte(*input.Value), &history)).To(Succeed())
+		return history
+	}
+
+	It("returns no history when none is recorded", func() {
+		provider.MockSSM().On("GetParameter", mock.Anything, mock.Anything).Return(nil, &ssmtypes.ParameterNotFound{})
+		history, err := nodegroup.GetHistory(context.Background(), provider.SSM(), "my-cluster", "ng-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(history).To(BeEmpty())
+	})
+
+	It("fails when the history cannot be read", func() {
+		provider.MockSSM().On("GetParameter", mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+		_, err := nodegroup.GetHistory(context.Background(), provider.SSM(), "my-cluster", "ng-1")
+		Expect(err).To(MatchError(ContainSubstring("access denied")))
+	})
+
+	It("appends an entry to the history", func() {
+		previous := nodegroup.HistoryEntry{Time: now.Add(-time.Hour), Command: "eksctl upgrade nodegroup --name ng-1", ReleaseVersion: "1.30.0-20240101"}
+		mockHistory([]nodegroup.HistoryEntry{previous})
+		provider.MockSSM().On("PutParameter", mock.Anything, mock.Anything).Return(&ssm.PutParameterOutput{}, nil)
+
+		entry := nodegroup.HistoryEntry{Time: now, Command: "eksctl upgrade nodegroup --name ng-1 --ami ami-123", AMI: "ami-123"}
+		Expect(nodegroup.RecordHistory(context.Background(), provider.SSM(), "my-cluster", "ng-1", entry)).To(Succeed())
+		Expect(putHistory()).To(Equal([]nodegroup.HistoryEntry{previous, entry}))
+	})
+
+	It("drops the oldest entries to fit in an SSM parameter", func() {
+		var history []nodegroup.HistoryEntry
+		for i := 0; i < 60; i++ {
+			history = append(history, nodegroup.HistoryEntry{
+				Time:    now.Add(time.Duration(i) * time.Minute),
+				Command: fmt.Sprintf("eksctl upgrade nodegroup --cluster my-cluster --name ng-1 --launch-template-version %d", i),
+			})
+		}
+		mockHistory(history)
+		provider.MockSSM().On("PutParameter", mock.Anything, mock.Anything).Return(&ssm.PutParameterOutput{}, nil)
+
+		entry := nodegroup.HistoryEntry{Time: now.Add(time.Hour), Command: "eksctl upgrade nodegroup --name ng-1"}
+		Expect(nodegroup.RecordHistory(context.Background(), provider.SSM(), "my-cluster", "ng-1", entry)).To(Succeed())
+		recorded := putHistory()
+		Expect(len(recorded)).To(BeNumerically("<", 50))
+		Expect(recorded[len(recorded)-1]).To(Equal(entry))
+		value, err := json.Marshal(recorded)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(len(value)).To(BeNumerically("<=", 4096))
+	})
+
+	It("ignores a missing history when deleting it", func() {
+		provider.MockSSM().On("DeleteParameter", mock.Anything, &ssm.DeleteParameterInput{Name: aws.String(parameterName)}).
+			Return(nil, &ssmtypes.ParameterNotFound{})
+		Expect(nodegroup.DeleteHistory(context.Background(), provider.SSM(), "my-cluster", "ng-1")).To(Succeed())
+	})
+
+	It("deletes the history of all the nodegroups of a cluster", func() {
+		provider.MockSSM().On("GetParametersByPath", mock.Anything, mock.Anything).Return(&ssm.GetParametersByPathOutput{
+			Parameters: []ssmtypes.Parameter{
+				{Name: aws.String(parameterName)},
+				{Name: aws.String("/eksctl/my-cluster/nodegroups/ng-2/history")},
+				{Name: aws.String("/eksctl/my-cluster/nodegroups/ng-2/other")},
+			},
+		}, nil)
+		provider.MockSSM().On("DeleteParameters", mock.Anything, mock.Anything).Return(&ssm.DeleteParametersOutput{}, nil)
+
+		Expect(nodegroup.DeleteClusterHistory(context.Background(), provider.SSM(), "my-cluster")).To(Succeed())
+		listInput := provider.MockSSM().Calls[0].Arguments[1].(*ssm.GetParametersByPathInput)
+		Expect(*listInput.Path).To(Equal("/eksctl/my-cluster/nodegroups"))
+		Expect(*listInput.Recursive).To(BeTrue())
+		provider.MockSSM().AssertCalled(GinkgoT(), "DeleteParameters", mock.Anything, &ssm.DeleteParametersInput{
+			Names: []string{parameterName, "/eksctl/my-cluster/nodegroups/ng-2/history"},
+		})
+	})
+
+	It("records the creation of the nodegroups", func() {
+		provider.MockSSM().On("GetParameter", mock.Anything, mock.Anything).Return(nil, &ssmtypes.ParameterNotFound{})
+		provider.MockSSM().On("PutParameter", mock.Anything, mock.Anything).Return(&ssm.PutParameterOutput{}, nil)
+		provider.MockEKS().On("DescribeNodegroup", mock.Anything, mock.Anything).Return(&awseks.DescribeNodegroupOutput{
+			Nodegroup: &ekstypes.Nodegroup{
+				Version:        aws.String("1.30"),
+				ReleaseVersion: aws.String("1.30.0-20240101"),
+			},
+		}, nil)
+
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Metadata.Version = "1.30"
+		cfg.NodeGroups = []*api.NodeGroup{{NodeGroupBase: &api.NodeGroupBase{Name: "ng-1", AMI: "ami-123"}}}
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{{NodeGroupBase: &api.NodeGroupBase{Name: "mng-1"}}}
+		nodegroup.RecordCreation(context.Background(), provider, cfg, "eksctl create nodegroup -f cluster.yaml")
+
+		var recorded []nodegroup.HistoryEntry
+		for _, call := range provider.MockSSM().Calls {
+			if call.Method != "PutParameter" {
+				continue
+			}
+			var history []nodegroup.HistoryEntry
+			Expect(json.Unmarshal([]byte(*call.Arguments[1].(*ssm.PutParameterInput).Value), &history)).To(Succeed())
+			recorded = append(recorded, history...)
+		}
+		Expect(recorded).To(HaveLen(2))
+		Expect(recorded[0].Command).To(Equal("eksctl create nodegroup -f cluster.yaml"))
+		Expect(recorded[0].KubernetesVersion).To(Equal("1.30"))
+		Expect(recorded[0].AMI).To(Equal("ami-123"))
+		Expect(recorded[1].Command).To(Equal("eksctl create nodegroup -f cluster.yaml"))
+		Expect(recorded[1].ReleaseVersion).To(Equal("1.30.0-20240101"))
+	})
+})
@@ -0,0 +1,248 @@
+package nodegroup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/kris-nova/logger"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	eksNodeGroupNameTag = "eks:nodegroup-name"
+	nodeMetricsPath     = "/apis/metrics.k8s.io/v1beta1/nodes"
+)
+
+// Utilization represents the resource utilization of the nodes of a nodegroup
+type Utilization struct {
+	Cluster string
+	Name    string
+	// Instances is the number of running EC2 instances of the nodegroup
+	Instances int
+	// Nodes is the number of nodes of the nodegroup registered with the cluster
+	Nodes int
+
+	CPUAllocatable    resource.Quantity
+	CPURequests       resource.Quantity
+	CPUUsage          *resource.Quantity `json:",omitempty"`
+	MemoryAllocatable resource.Quantity
+	MemoryRequests    resource.Quantity
+	MemoryUsage       *resource.Quantity `json:",omitempty"`
+}
+
+// NodeMetricsLister lists the current resource usage of nodes, keyed by node name
+type NodeMetricsLister func(ctx context.Context) (map[string]corev1.ResourceList, error)
+
+// NewMetricsServerLister returns a NodeMetricsLister that queries the resource metrics API served by metrics-server
+func NewMetricsServerLister(clientSet kubernetes.Interface) NodeMetricsLister {
+	return func(ctx context.Context) (map[string]corev1.ResourceList, error) {
+		body, err := clientSet.Discovery().RESTClient().Get().AbsPath(nodeMetricsPath).DoRaw(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var metrics struct {
+			Items []struct {
+				Metadata metav1.ObjectMeta   `json:"metadata"`
+				Usage    corev1.ResourceList `json:"usage"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(body, &metrics); err != nil {
+			return nil, fmt.Errorf("decoding node metrics: %w", err)
+		}
+		usage := make(map[string]corev1.ResourceList, len(metrics.Items))
+		for _, item := range metrics.Items {
+			usage[item.Metadata.Name] = item.Usage
+		}
+		return usage, nil
+	}
+}
+
+// Top returns the resource requests, allocatable resources and usage of the nodes of each nodegroup.
+// Usage is omitted if the metrics API is not available in the cluster.
+func (m *Manager) Top(ctx context.Context, listNodeMetrics NodeMetricsLister) ([]*Utilization, error) {
+	nodes, err := m.clientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing nodes: %w", err)
+	}
+	podRequests, err := m.podRequestsByNode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := listNodeMetrics(ctx)
+	if err != nil {
+		logger.Warning("resource usage is not available, ensure metrics-server is installed in the cluster: %v", err)
+		usage = nil
+	}
+	instances, err := m.runningInstancesByNodeGroup(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	utilizations := map[string]*Utilization{}
+	getUtilization := func(name string) *Utilization {
+		u, ok := utilizations[name]
+		if !ok {
+			u = &Utilization{Cluster: m.cfg.Metadata.Name, Name: name}
+			if usage != nil {
+				u.CPUUsage, u.MemoryUsage = resource.NewQuantity(0, resource.DecimalSI), resource.NewQuantity(0, resource.BinarySI)
+			}
+			utilizations[name] = u
+		}
+		return u
+	}
+
+	for _, node := range nodes.Items {
+		name := nodeGroupNameFromLabels(node.Labels)
+		if name == "" {
+			continue
+		}
+		u := getUtilization(name)
+		u.Nodes++
+		addQuantity(&u.CPUAllocatable, node.Status.Allocatable, corev1.ResourceCPU)
+		addQuantity(&u.MemoryAllocatable, node.Status.Allocatable, corev1.ResourceMemory)
+		addQuantity(&u.CPURequests, podRequests[node.Name], corev1.ResourceCPU)
+		addQuantity(&u.MemoryRequests, podRequests[node.Name], corev1.ResourceMemory)
+		if usage != nil {
+			addQuantity(u.CPUUsage, usage[node.Name], corev1.ResourceCPU)
+			addQuantity(u.MemoryUsage, usage[node.Name], corev1.ResourceMemory)
+		}
+	}
+	for name, count := range instances {
+		getUtilization(name).Instances = count
+	}
+
+	var result []*Utilization
+	for _, u := range utilizations {
+		result = append(result, u)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// podRequestsByNode sums the resource requests of the non-terminated pods scheduled on each node
+func (m *Manager) podRequestsByNode(ctx context.Context) (map[string]corev1.ResourceList, error) {
+	pods, err := m.clientSet.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+		).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+	requests := map[string]corev1.ResourceList{}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		nodeRequests, ok := requests[pod.Spec.NodeName]
+		if !ok {
+			nodeRequests = corev1.ResourceList{}
+			requests[pod.Spec.NodeName] = nodeRequests
+		}
+		for name, quantity := range podRequests(pod) {
+			total := nodeRequests[name]
+			total.Add(quantity)
+			nodeRequests[name] = total
+		}
+	}
+	return requests, nil
+}
+
+// podRequests returns the effective requests of a pod, the way the scheduler computes them:
+// the sum of the requests of its containers or the largest request of its init containers,
+// whichever is higher, plus the pod overhead.
+func podRequests(pod corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	for name, quantity := range pod.Spec.Overhead {
+		total := requests[name]
+		total.Add(quantity)
+		requests[name] = total
+	}
+	return requests
+}
+
+// runningInstancesByNodeGroup counts the running EC2 instances of each nodegroup of the cluster
+func (m *Manager) runningInstancesByNodeGroup(ctx context.Context) (map[string]int, error) {
+	counts := map[string]int{}
+	paginator := ec2.NewDescribeInstancesPaginator(m.ctl.AWSProvider.EC2(), &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("tag-key"),
+				Values: []string{"kubernetes.io/cluster/" + m.cfg.Metadata.Name},
+			},
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: []string{string(ec2types.InstanceStateNameRunning)},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing instances: %w", err)
+		}
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				if name := nodeGroupNameFromTags(instance.Tags); name != "" {
+					counts[name]++
+				}
+			}
+		}
+	}
+	return counts, nil
+}
+
+func nodeGroupNameFromLabels(labels map[string]string) string {
+	if name := labels[api.NodeGroupNameLabel]; name != "" {
+		return name
+	}
+	return labels[api.EKSNodeGroupNameLabel]
+}
+
+func nodeGroupNameFromTags(tags []ec2types.Tag) string {
+	var name string
+	for _, tag := range tags {
+		switch aws.ToString(tag.Key) {
+		case api.NodeGroupNameTag:
+			return aws.ToString(tag.Value)
+		case eksNodeGroupNameTag:
+			name = aws.ToString(tag.Value)
+		}
+	}
+	return name
+}
+
+func addQuantity(total *resource.Quantity, resources corev1.ResourceList, name corev1.ResourceName) {
+	if quantity, ok := resources[name]; ok {
+		total.Add(quantity)
+	}
+}
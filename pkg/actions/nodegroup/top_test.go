@@ -0,0 +1,140 @@
+package nodegroup_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Top", func() {
+	var (
+		p             *mockprovider.MockProvider
+		m             *nodegroup.Manager
+		fakeClientSet *fake.Clientset
+	)
+
+	newNode := func(name string, labels map[string]string, cpu, memory string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+		}
+	}
+
+	newPod := func(name, nodeName string, phase corev1.PodPhase, cpu, memory string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{
+					{
+						Name: "app",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(cpu),
+								corev1.ResourceMemory: resource.MustParse(memory),
+							},
+						},
+					},
+				},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	BeforeEach(func() {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		p = mockprovider.NewMockProvider()
+		fakeClientSet = fake.NewSimpleClientset(
+			newNode("node-1", map[string]string{api.NodeGroupNameLabel: "ng-1"}, "2", "4Gi"),
+			newNode("node-2", map[string]string{api.NodeGroupNameLabel: "ng-1"}, "2", "4Gi"),
+			newNode("node-3", map[string]string{api.EKSNodeGroupNameLabel: "mng-1"}, "4", "8Gi"),
+			newNode("node-4", nil, "4", "8Gi"),
+			newPod("pod-1", "node-1", corev1.PodRunning, "500m", "1Gi"),
+			newPod("pod-2", "node-2", corev1.PodRunning, "250m", "512Mi"),
+			newPod("pod-3", "node-3", corev1.PodRunning, "1", "2Gi"),
+			newPod("pod-4", "node-3", corev1.PodSucceeded, "1", "2Gi"),
+		)
+		m = nodegroup.New(cfg, &eks.ClusterProvider{AWSProvider: p}, fakeClientSet, nil)
+
+		p.MockEC2().On("DescribeInstances", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeInstancesInput) bool {
+			return len(input.Filters) == 2 && input.Filters[0].Values[0] == "kubernetes.io/cluster/my-cluster"
+		}), mock.Anything).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{
+				{
+					Instances: []ec2types.Instance{
+						{Tags: []ec2types.Tag{{Key: aws.String(api.NodeGroupNameTag), Value: aws.String("ng-1")}}},
+						{Tags: []ec2types.Tag{{Key: aws.String(api.NodeGroupNameTag), Value: aws.String("ng-1")}}},
+						{Tags: []ec2types.Tag{{Key: aws.String(api.NodeGroupNameTag), Value: aws.String("ng-1")}}},
+						{Tags: []ec2types.Tag{{Key: aws.String("eks:nodegroup-name"), Value: aws.String("mng-1")}}},
+					},
+				},
+			},
+		}, nil)
+	})
+
+	It("aggregates requests, allocatable resources and usage per nodegroup", func() {
+		utilizations, err := m.Top(context.Background(), func(context.Context) (map[string]corev1.ResourceList, error) {
+			return map[string]corev1.ResourceList{
+				"node-1": {corev1.ResourceCPU: resource.MustParse("300m"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+				"node-2": {corev1.ResourceCPU: resource.MustParse("200m"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+				"node-3": {corev1.ResourceCPU: resource.MustParse("1500m"), corev1.ResourceMemory: resource.MustParse("3Gi")},
+			}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(utilizations).To(HaveLen(2))
+
+		mng := utilizations[0]
+		Expect(mng.Name).To(Equal("mng-1"))
+		Expect(mng.Instances).To(Equal(1))
+		Expect(mng.Nodes).To(Equal(1))
+		Expect(mng.CPURequests.String()).To(Equal("1"))
+		Expect(mng.MemoryRequests.String()).To(Equal("2Gi"))
+		Expect(mng.CPUUsage.String()).To(Equal("1500m"))
+
+		ng := utilizations[1]
+		Expect(ng.Cluster).To(Equal("my-cluster"))
+		Expect(ng.Name).To(Equal("ng-1"))
+		Expect(ng.Instances).To(Equal(3))
+		Expect(ng.Nodes).To(Equal(2))
+		Expect(ng.CPUAllocatable.String()).To(Equal("4"))
+		Expect(ng.MemoryAllocatable.String()).To(Equal("8Gi"))
+		Expect(ng.CPURequests.String()).To(Equal("750m"))
+		Expect(ng.MemoryRequests.String()).To(Equal("1536Mi"))
+		Expect(ng.CPUUsage.String()).To(Equal("500m"))
+		Expect(ng.MemoryUsage.String()).To(Equal("2Gi"))
+	})
+
+	It("omits usage when the metrics API is not available", func() {
+		utilizations, err := m.Top(context.Background(), func(context.Context) (map[string]corev1.ResourceList, error) {
+			return nil, errors.New("the server could not find the requested resource")
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(utilizations).To(HaveLen(2))
+		for _, u := range utilizations {
+			Expect(u.CPUUsage).To(BeNil())
+			Expect(u.MemoryUsage).To(BeNil())
+		}
+	})
+})
@@ -45,22 +45,13 @@ func (m *Manager) updateNodegroup(ctx context.Context, ng *api.ManagedNodeGroup,
 		return fmt.Errorf("the submitted config does not contain an 'updateConfig' field for nodegroup %s", ng.Name)
 	}
 
-	updateConfig, err := updateUpdateConfig(ng)
+	output, err := m.updateNodegroupConfig(ctx, ng.Name, ng.UpdateConfig)
 	if err != nil {
 		return err
 	}
 
-	output, err := m.ctl.AWSProvider.EKS().UpdateNodegroupConfig(ctx, &eks.UpdateNodegroupConfigInput{
-		UpdateConfig:  updateConfig,
-		ClusterName:   &m.cfg.Metadata.Name,
-		NodegroupName: &ng.Name,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to update nodegroup %s: %w", ng.Name, err)
-	}
-
 	if wait {
-		if status, err := waiter.WaitForNodegroupUpdate(ctx, string(output.Update.Status), m.ctl.AWSProvider.EKS(), m.ctl.AWSProvider.WaitTimeout(), func(attempts int) time.Duration {
+		if status, err := waiter.WaitForNodegroupUpdate(ctx, string(output.Status), m.ctl.AWSProvider.EKS(), m.ctl.AWSProvider.WaitTimeout(), func(attempts int) time.Duration {
 			return 30 * time.Second
 		}); err != nil {
 			return fmt.Errorf("failed to wait for nodegroup %s to update; last observed status was %s with error: %w", ng.Name, status, err)
@@ -71,17 +62,42 @@ func (m *Manager) updateNodegroup(ctx context.Context, ng *api.ManagedNodeGroup,
 	return nil
 }
 
-func updateUpdateConfig(ng *api.ManagedNodeGroup) (*ekstypes.NodegroupUpdateConfig, error) {
-	logger.Info("updating nodegroup %s's UpdateConfig", ng.Name)
+// updateNodegroupConfig sets the update config of a managed nodegroup through the EKS API
+func (m *Manager) updateNodegroupConfig(ctx context.Context, ngName string, ngUpdateConfig *api.NodeGroupUpdateConfig) (*ekstypes.Update, error) {
+	logger.Info("updating nodegroup %s's UpdateConfig", ngName)
+	output, err := m.ctl.AWSProvider.EKS().UpdateNodegroupConfig(ctx, &eks.UpdateNodegroupConfigInput{
+		UpdateConfig:  toEKSUpdateConfig(ngUpdateConfig),
+		ClusterName:   &m.cfg.Metadata.Name,
+		NodegroupName: &ngName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update nodegroup %s: %w", ngName, err)
+	}
+	if output == nil || output.Update == nil {
+		return &ekstypes.Update{}, nil
+	}
+	return output.Update, nil
+}
+
+func toEKSUpdateConfig(ngUpdateConfig *api.NodeGroupUpdateConfig) *ekstypes.NodegroupUpdateConfig {
 	updateConfig := &ekstypes.NodegroupUpdateConfig{}
 
-	if ng.UpdateConfig.MaxUnavailable != nil {
-		updateConfig.MaxUnavailable = aws.Int32(int32(*ng.UpdateConfig.MaxUnavailable))
+	if ngUpdateConfig.MaxUnavailable != nil {
+		updateConfig.MaxUnavailable = aws.Int32(int32(*ngUpdateConfig.MaxUnavailable))
 	}
 
-	if ng.UpdateConfig.MaxUnavailablePercentage != nil {
-		updateConfig.MaxUnavailablePercentage = aws.Int32(int32(*ng.UpdateConfig.MaxUnavailablePercentage))
+	if ngUpdateConfig.MaxUnavailablePercentage != nil {
+		updateConfig.MaxUnavailablePercentage = aws.Int32(int32(*ngUpdateConfig.MaxUnavailablePercentage))
 	}
 
-	return updateConfig, nil
+	return updateConfig
+}
+
+// updateConfigChanged reports whether the desired update config differs from the one the nodegroup currently uses
+func updateConfigChanged(desired *api.NodeGroupUpdateConfig, current *ekstypes.NodegroupUpdateConfig) bool {
+	if current == nil {
+		return true
+	}
+	return aws.ToInt(desired.MaxUnavailable) != int(aws.ToInt32(current.MaxUnavailable)) ||
+		aws.ToInt(desired.MaxUnavailablePercentage) != int(aws.ToInt32(current.MaxUnavailablePercentage))
 }
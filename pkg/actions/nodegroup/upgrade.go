@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/weaveworks/eksctl/pkg/eks/waiter"
 
@@ -43,14 +44,30 @@ type UpgradeOptions struct {
 	LaunchTemplateVersion string
 	//ForceUpgrade enables force upgrade
 	ForceUpgrade bool
+	// PodEvictionGrace is how long pods are evicted respecting PodDisruptionBudgets during a force upgrade,
+	// before the pods still blocking the upgrade are force-evicted
+	PodEvictionGrace time.Duration
 	// ReleaseVersion AMI version of the EKS optimized AMI to use
 	ReleaseVersion string
+	// AMI is the custom AMI to upgrade a nodegroup that uses a custom AMI to
+	AMI string
+	// RollbackAMI upgrades a nodegroup that uses a custom AMI to the AMI it used before the last AMI upgrade
+	RollbackAMI bool
+	// UpdateConfig is the update config applied to the nodegroup before upgrading it,
+	// controlling how many nodes are replaced at once
+	UpdateConfig *api.NodeGroupUpdateConfig
 	// Wait for the upgrade to finish
 	Wait bool
 	// Stack to upgrade
 	Stack *manager.NodeGroupStack
 }
 
+// forceUpdate reports whether EKS should force the upgrade, ignoring PodDisruptionBudgets from the start;
+// with a pod eviction grace period, eksctl force-evicts the blocking pods itself once it has passed
+func (o UpgradeOptions) forceUpdate() bool {
+	return o.ForceUpgrade && o.PodEvictionGrace == 0
+}
+
 func (m *Manager) Upgrade(ctx context.Context, options UpgradeOptions) error {
 	stacks, err := m.stackManager.ListNodeGroupStacksWithStatuses(ctx)
 	if err != nil {
@@ -63,6 +80,39 @@ func (m *Manager) Upgrade(ctx context.Context, options UpgradeOptions) error {
 		}
 	}
 
+	if options.AMI != "" || options.RollbackAMI {
+		if options.AMI != "" && options.RollbackAMI {
+			return errors.New("only one of ami or rollback-ami can be specified")
+		}
+		if options.KubernetesVersion != "" || options.ReleaseVersion != "" || options.LaunchTemplateVersion != "" {
+			return errors.New("ami and rollback-ami cannot be used with kubernetes-version, release-version or launch-template-version")
+		}
+		if options.AMI != "" && !strings.HasPrefix(options.AMI, "ami-") {
+			return fmt.Errorf("invalid AMI %q, expected an AMI ID", options.AMI)
+		}
+	}
+
+	if options.PodEvictionGrace != 0 {
+		if options.PodEvictionGrace < 0 {
+			return fmt.Errorf("invalid pod eviction grace %s, must be positive", options.PodEvictionGrace)
+		}
+		if options.PodEvictionGrace >= eksPodEvictionTimeout {
+			return fmt.Errorf("invalid pod eviction grace %s, must be shorter than the %s EKS waits for the pods of a node to be evicted before failing the upgrade", options.PodEvictionGrace, eksPodEvictionTimeout)
+		}
+		if !options.ForceUpgrade {
+			return errors.New("pod-eviction-grace can only be used with force-upgrade")
+		}
+		if !options.Wait {
+			return errors.New("pod-eviction-grace requires waiting for the upgrade to complete")
+		}
+	}
+
+	if options.UpdateConfig != nil {
+		if err := api.ValidateNodeGroupUpdateConfig(options.UpdateConfig); err != nil {
+			return err
+		}
+	}
+
 	nodegroupOutput, err := m.ctl.AWSProvider.EKS().DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
 		ClusterName:   &m.cfg.Metadata.Name,
 		NodegroupName: &options.NodegroupName,
@@ -87,16 +137,40 @@ func (m *Manager) Upgrade(ctx context.Context, options UpgradeOptions) error {
 
 	if stack := findStack(stacks, options.NodegroupName); stack != nil {
 		options.Stack = stack
+		if options.AMI != "" || options.RollbackAMI {
+			return m.upgradeAMIUsingStack(ctx, options)
+		}
 		return m.upgradeUsingStack(ctx, options, nodegroupOutput.Nodegroup)
 	}
 
+	if options.AMI != "" || options.RollbackAMI {
+		return m.upgradeAMIUsingAPI(ctx, options, nodegroupOutput.Nodegroup)
+	}
 	return m.upgradeUsingAPI(ctx, options, nodegroupOutput.Nodegroup)
 }
 
 func (m *Manager) upgradeUsingAPI(ctx context.Context, options UpgradeOptions, nodegroup *ekstypes.Nodegroup) error {
+	if options.UpdateConfig != nil && updateConfigChanged(options.UpdateConfig, nodegroup.UpdateConfig) {
+		update, err := m.updateNodegroupConfig(ctx, options.NodegroupName, options.UpdateConfig)
+		if err != nil {
+			return err
+		}
+		// the nodegroup cannot be upgraded while its config is being updated
+		if update.Id != nil {
+			updateWaiter := waiter.NewUpdateWaiter(m.ctl.AWSProvider.EKS())
+			if err := updateWaiter.Wait(ctx, &eks.DescribeUpdateInput{
+				Name:          aws.String(m.cfg.Metadata.Name),
+				UpdateId:      update.Id,
+				NodegroupName: &options.NodegroupName,
+			}, m.ctl.AWSProvider.WaitTimeout()); err != nil {
+				return errors.Wrapf(err, "waiting for UpdateConfig of nodegroup %q to be updated", options.NodegroupName)
+			}
+		}
+	}
+
 	input := &eks.UpdateNodegroupVersionInput{
 		ClusterName:   &m.cfg.Metadata.Name,
-		Force:         options.ForceUpgrade,
+		Force:         options.forceUpdate(),
 		NodegroupName: &options.NodegroupName,
 	}
 
@@ -149,7 +223,9 @@ func (m *Manager) upgradeUsingAPI(ctx context.Context, options UpgradeOptions, n
 	logger.Info("upgrade of nodegroup %q in progress", options.NodegroupName)
 
 	if options.Wait {
-		return m.waitForUpgrade(ctx, options, upgradeResponse.Update)
+		return m.withPodEvictionGrace(ctx, options, func() error {
+			return m.waitForUpgrade(ctx, options, upgradeResponse.Update)
+		})
 	}
 
 	return nil
@@ -221,9 +297,24 @@ func (m *Manager) upgradeUsingStack(ctx context.Context, options UpgradeOptions,
 		}
 	}
 
-	if ngResource.ForceUpdateEnabled == nil || strings.ToLower(ngResource.ForceUpdateEnabled.String()) != strconv.FormatBool(options.ForceUpgrade) {
-		ngResource.ForceUpdateEnabled = gfnt.NewBoolean(options.ForceUpgrade)
-		logger.Info("setting ForceUpdateEnabled value to %t", options.ForceUpgrade)
+	if ngResource.ForceUpdateEnabled == nil || strings.ToLower(ngResource.ForceUpdateEnabled.String()) != strconv.FormatBool(options.forceUpdate()) {
+		ngResource.ForceUpdateEnabled = gfnt.NewBoolean(options.forceUpdate())
+		logger.Info("setting ForceUpdateEnabled value to %t", options.forceUpdate())
+		if err := updateStack(stack, true); err != nil {
+			return err
+		}
+	}
+
+	if options.UpdateConfig != nil && updateConfigChanged(options.UpdateConfig, nodegroup.UpdateConfig) {
+		updateConfig := &gfneks.Nodegroup_UpdateConfig{}
+		if options.UpdateConfig.MaxUnavailable != nil {
+			updateConfig.MaxUnavailable = gfnt.NewInteger(*options.UpdateConfig.MaxUnavailable)
+		}
+		if options.UpdateConfig.MaxUnavailablePercentage != nil {
+			updateConfig.MaxUnavailablePercentage = gfnt.NewInteger(*options.UpdateConfig.MaxUnavailablePercentage)
+		}
+		ngResource.UpdateConfig = updateConfig
+		logger.Info("setting UpdateConfig of nodegroup %q", options.NodegroupName)
 		if err := updateStack(stack, true); err != nil {
 			return err
 		}
@@ -285,12 +376,14 @@ func (m *Manager) upgradeUsingStack(ctx context.Context, options UpgradeOptions,
 		ngResource.LaunchTemplate.Version = gfnt.NewString(options.LaunchTemplateVersion)
 	}
 
-	ngResource.ForceUpdateEnabled = gfnt.NewBoolean(options.ForceUpgrade)
+	ngResource.ForceUpdateEnabled = gfnt.NewBoolean(options.forceUpdate())
 
 	logger.Debug("nodegroup resources for upgrade: %+v", ngResource)
 
 	logger.Info("upgrading nodegroup version")
-	if err := updateStack(stack, options.Wait); err != nil {
+	if err := m.withPodEvictionGrace(ctx, options, func() error {
+		return updateStack(stack, options.Wait)
+	}); err != nil {
 		return err
 	}
 	logger.Info("nodegroup successfully upgraded")
@@ -0,0 +1,186 @@
+package nodegroup
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	"github.com/weaveworks/goformation/v4"
+	gfnt "github.com/weaveworks/goformation/v4/cloudformation/types"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+)
+
+// maxAMIHistory is the number of previous AMIs recorded in the NodeGroupAMIHistoryTag of a nodegroup stack.
+const maxAMIHistory = 5
+
+// upgradeAMIUsingStack updates the image of the launch template in the nodegroup stack, which creates a new
+// version of the launch template and rolls the nodes of the nodegroup to it. The previous AMI is recorded in
+// the stack tags so that the upgrade can be rolled back.
+func (m *Manager) upgradeAMIUsingStack(ctx context.Context, options UpgradeOptions) error {
+	template, err := m.stackManager.GetManagedNodeGroupTemplate(ctx, manager.GetNodegroupOption{
+		Stack:         options.Stack,
+		NodeGroupName: options.NodegroupName,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error fetching nodegroup template")
+	}
+	stack, err := goformation.ParseJSON([]byte(template))
+	if err != nil {
+		return errors.Wrap(err, "unexpected error parsing nodegroup template")
+	}
+	ngResource, ok := stack.GetAllEKSNodegroupResources()[builder.ManagedNodeGroupResourceName]
+	if !ok {
+		return errors.New("unexpected error: failed to find nodegroup resource in nodegroup stack")
+	}
+	lt, ok := stack.GetAllEC2LaunchTemplateResources()["LaunchTemplate"]
+	if !ok || lt.LaunchTemplateData == nil || lt.LaunchTemplateData.ImageId == nil {
+		return errors.New("ami can only be set for nodegroups that use a custom AMI")
+	}
+
+	ngStack, err := m.stackManager.DescribeNodeGroupStack(ctx, options.NodegroupName)
+	if err != nil {
+		return err
+	}
+	history := GetAMIHistory(ngStack.Tags)
+	currentAMI := lt.LaunchTemplateData.ImageId.String()
+	if options.RollbackAMI {
+		if len(history) == 0 {
+			return fmt.Errorf("no previous AMI is recorded for nodegroup %q", options.NodegroupName)
+		}
+		options.AMI, history = history[0], history[1:]
+		logger.Info("rolling back nodegroup %q from AMI %s to %s", options.NodegroupName, currentAMI, options.AMI)
+	} else {
+		if currentAMI == options.AMI {
+			logger.Info("nodegroup %q already uses AMI %s", options.NodegroupName, options.AMI)
+			return nil
+		}
+		history = append([]string{currentAMI}, history...)
+	}
+	if len(history) > maxAMIHistory {
+		history = history[:maxAMIHistory]
+	}
+
+	lt.LaunchTemplateData.ImageId = gfnt.NewString(options.AMI)
+	ngResource.ForceUpdateEnabled = gfnt.NewBoolean(options.forceUpdate())
+	ngStack.Tags = setAMIHistory(ngStack.Tags, history)
+
+	templateBody, err := stack.JSON()
+	if err != nil {
+		return err
+	}
+	if err := m.withPodEvictionGrace(ctx, options, func() error {
+		return m.stackManager.UpdateStack(ctx, manager.UpdateStackOptions{
+			Stack:         ngStack,
+			ChangeSetName: m.stackManager.MakeChangeSetName("upgrade-nodegroup-ami"),
+			Description:   fmt.Sprintf("upgrading nodegroup %q to AMI %s", options.NodegroupName, options.AMI),
+			TemplateData:  manager.TemplateBody(templateBody),
+			Wait:          options.Wait,
+		})
+	}); err != nil {
+		return errors.Wrap(err, "error updating nodegroup stack")
+	}
+	if options.Wait {
+		logger.Info("nodegroup successfully upgraded")
+	} else {
+		logger.Info("upgrade of nodegroup %q in progress", options.NodegroupName)
+	}
+	return nil
+}
+
+// upgradeAMIUsingAPI creates a new version of the launch template of a nodegroup not created by eksctl with the
+// given AMI, and updates the nodegroup to it. The previous AMI is recorded in the description of the version.
+func (m *Manager) upgradeAMIUsingAPI(ctx context.Context, options UpgradeOptions, nodegroup *ekstypes.Nodegroup) error {
+	if options.RollbackAMI {
+		return errors.New("rollback-ami is only supported for nodegroups created by eksctl, use --ami with the previous AMI instead")
+	}
+	if nodegroup.LaunchTemplate == nil || nodegroup.LaunchTemplate.Id == nil {
+		return errors.New("ami can only be set for nodegroups that use a launch template with a custom AMI")
+	}
+	currentLaunchTemplate, err := m.launchTemplateFetcher.Fetch(ctx, &api.LaunchTemplate{
+		ID:      *nodegroup.LaunchTemplate.Id,
+		Version: nodegroup.LaunchTemplate.Version,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error fetching launch template data")
+	}
+	if currentLaunchTemplate.ImageId == nil {
+		return errors.New("ami can only be set for nodegroups that use a launch template with a custom AMI")
+	}
+	if *currentLaunchTemplate.ImageId == options.AMI {
+		logger.Info("nodegroup %q already uses AMI %s", options.NodegroupName, options.AMI)
+		return nil
+	}
+
+	versionOutput, err := m.ctl.AWSProvider.EC2().CreateLaunchTemplateVersion(ctx, &ec2.CreateLaunchTemplateVersionInput{
+		LaunchTemplateId:   nodegroup.LaunchTemplate.Id,
+		SourceVersion:      nodegroup.LaunchTemplate.Version,
+		VersionDescription: aws.String(fmt.Sprintf("eksctl: AMI %s, previously %s", options.AMI, *currentLaunchTemplate.ImageId)),
+		LaunchTemplateData: &ec2types.RequestLaunchTemplateData{
+			ImageId: aws.String(options.AMI),
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "error creating launch template version")
+	}
+	newVersion := strconv.FormatInt(*versionOutput.LaunchTemplateVersion.VersionNumber, 10)
+	logger.Info("created version %s of launch template %q with AMI %s", newVersion, *nodegroup.LaunchTemplate.Id, options.AMI)
+
+	upgradeResponse, err := m.ctl.AWSProvider.EKS().UpdateNodegroupVersion(ctx, &eks.UpdateNodegroupVersionInput{
+		ClusterName:   &m.cfg.Metadata.Name,
+		NodegroupName: &options.NodegroupName,
+		Force:         options.forceUpdate(),
+		LaunchTemplate: &ekstypes.LaunchTemplateSpecification{
+			Id:      nodegroup.LaunchTemplate.Id,
+			Version: aws.String(newVersion),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	logger.Info("upgrade of nodegroup %q in progress", options.NodegroupName)
+	if options.Wait {
+		return m.withPodEvictionGrace(ctx, options, func() error {
+			return m.waitForUpgrade(ctx, options, upgradeResponse.Update)
+		})
+	}
+	return nil
+}
+
+// GetAMIHistory returns the AMIs previously used by a nodegroup, most recent first, recorded in the tags of its stack.
+func GetAMIHistory(tags []cfntypes.Tag) []string {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == api.NodeGroupAMIHistoryTag {
+			return strings.Fields(aws.ToString(tag.Value))
+		}
+	}
+	return nil
+}
+
+func setAMIHistory(tags []cfntypes.Tag, history []string) []cfntypes.Tag {
+	var updated []cfntypes.Tag
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) != api.NodeGroupAMIHistoryTag {
+			updated = append(updated, tag)
+		}
+	}
+	if len(history) == 0 {
+		return updated
+	}
+	return append(updated, cfntypes.Tag{
+		Key:   aws.String(api.NodeGroupAMIHistoryTag),
+		Value: aws.String(strings.Join(history, " ")),
+	})
+}
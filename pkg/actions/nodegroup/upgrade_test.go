@@ -3,6 +3,7 @@ package nodegroup_test
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
@@ -86,6 +87,37 @@ var _ = Describe("Upgrade", func() {
 					},
 				}, nil)
 			})
+			It("returns an error if pod eviction grace is used without force upgrade", func() {
+				options.PodEvictionGrace = time.Minute
+				err := m.Upgrade(context.Background(), options)
+				Expect(err).To(MatchError("pod-eviction-grace can only be used with force-upgrade"))
+			})
+			It("returns an error if pod eviction grace is not shorter than the EKS pod eviction timeout", func() {
+				options.PodEvictionGrace = 15 * time.Minute
+				options.ForceUpgrade = true
+				options.Wait = true
+				err := m.Upgrade(context.Background(), options)
+				Expect(err).To(MatchError(ContainSubstring("invalid pod eviction grace 15m0s, must be shorter than the 15m0s EKS waits")))
+			})
+			It("returns an error if pod eviction grace is used without waiting for the upgrade", func() {
+				options.PodEvictionGrace = time.Minute
+				options.ForceUpgrade = true
+				err := m.Upgrade(context.Background(), options)
+				Expect(err).To(MatchError("pod-eviction-grace requires waiting for the upgrade to complete"))
+			})
+			It("does not force the upgrade through EKS when a pod eviction grace is set", func() {
+				p.MockEKS().On("UpdateNodegroupVersion", mock.Anything, mock.MatchedBy(func(input *awseks.UpdateNodegroupVersionInput) bool {
+					return !input.Force
+				})).Return(&awseks.UpdateNodegroupVersionOutput{Update: &ekstypes.Update{Id: aws.String("update-1")}}, nil).Once()
+				p.MockEKS().On("DescribeUpdate", mock.Anything, mock.Anything).Return(&awseks.DescribeUpdateOutput{
+					Update: &ekstypes.Update{Id: aws.String("update-1"), Status: ekstypes.UpdateStatusSuccessful},
+				}, nil)
+				options.KubernetesVersion = ""
+				options.PodEvictionGrace = time.Minute
+				options.ForceUpgrade = true
+				options.Wait = true
+				Expect(m.Upgrade(context.Background(), options)).To(Succeed())
+			})
 			It("returns an error if launch template version is specified", func() {
 				options.LaunchTemplateVersion = "2"
 				err := m.Upgrade(context.Background(), options)
@@ -185,6 +217,28 @@ var _ = Describe("Upgrade", func() {
 					options.ReleaseVersion = *eksReleaseVersion
 					Expect(m.Upgrade(context.Background(), options)).To(Succeed())
 				})
+				It("updates the nodegroup update config before upgrading the nodegroup", func() {
+					p.MockEKS().On("UpdateNodegroupConfig", mock.Anything, &awseks.UpdateNodegroupConfigInput{
+						UpdateConfig: &ekstypes.NodegroupUpdateConfig{
+							MaxUnavailablePercentage: aws.Int32(25),
+						},
+						ClusterName:   aws.String(clusterName),
+						NodegroupName: aws.String(ngName),
+					}).Return(&awseks.UpdateNodegroupConfigOutput{Update: &ekstypes.Update{}}, nil).Once()
+					p.MockEKS().On("UpdateNodegroupVersion", mock.Anything, mock.Anything).Return(&awseks.UpdateNodegroupVersionOutput{}, nil)
+					options.UpdateConfig = &api.NodeGroupUpdateConfig{
+						MaxUnavailablePercentage: aws.Int(25),
+					}
+					Expect(m.Upgrade(context.Background(), options)).To(Succeed())
+					p.MockEKS().AssertNumberOfCalls(GinkgoT(), "UpdateNodegroupConfig", 1)
+				})
+				It("returns an error if the update config is invalid", func() {
+					options.UpdateConfig = &api.NodeGroupUpdateConfig{
+						MaxUnavailable: aws.Int(0),
+					}
+					err := m.Upgrade(context.Background(), options)
+					Expect(err).To(MatchError(ContainSubstring("maxUnavailable must be between 1 and 100")))
+				})
 			})
 		})
 	})
@@ -305,6 +359,19 @@ var _ = Describe("Upgrade", func() {
 					Expect(template).To(Equal(al2FullyUpdatedTemplate))
 					Expect(wait).To(BeTrue())
 				})
+
+				It("sets the nodegroup update config in the stack before upgrading the nodegroup", func() {
+					options.UpdateConfig = &api.NodeGroupUpdateConfig{
+						MaxUnavailable: aws.Int(3),
+					}
+					Expect(m.Upgrade(context.Background(), options)).To(Succeed())
+					Expect(fakeStackManager.UpdateNodeGroupStackCallCount()).To(Equal(2))
+					By("updating the UpdateConfig first")
+					_, ng, template, wait := fakeStackManager.UpdateNodeGroupStackArgsForCall(0)
+					Expect(ng).To(Equal(ngName))
+					Expect(template).To(MatchRegexp(`"UpdateConfig": {\s+"MaxUnavailable": 3\s+}`))
+					Expect(wait).To(BeTrue())
+				})
 			})
 		})
 
@@ -415,4 +482,132 @@ var _ = Describe("Upgrade", func() {
 			})
 		})
 	})
+	Context("upgrading a nodegroup with a custom AMI", func() {
+		BeforeEach(func() {
+			options.KubernetesVersion = ""
+			options.AMI = "ami-new"
+		})
+
+		It("returns an error when combined with a Kubernetes version", func() {
+			options.KubernetesVersion = latestEKSVersion
+			Expect(m.Upgrade(context.Background(), options)).To(MatchError(ContainSubstring("ami and rollback-ami cannot be used with kubernetes-version")))
+		})
+
+		When("the nodegroup has a stack", func() {
+			const customAMITemplate = `{
+  "Resources": {
+    "LaunchTemplate": {
+      "Type": "AWS::EC2::LaunchTemplate",
+      "Properties": {"LaunchTemplateData": {"ImageId": "ami-current"}}
+    },
+    "ManagedNodeGroup": {
+      "Type": "AWS::EKS::Nodegroup",
+      "Properties": {"ClusterName": "my-cluster", "NodegroupName": "my-nodegroup"}
+    }
+  }
+}`
+			BeforeEach(func() {
+				fakeStackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{{NodeGroupName: ngName}}, nil)
+				fakeStackManager.GetManagedNodeGroupTemplateReturns(customAMITemplate, nil)
+				fakeStackManager.MakeChangeSetNameReturns("upgrade-nodegroup-ami")
+				fakeStackManager.DescribeNodeGroupStackReturns(&manager.Stack{
+					Tags: []types.Tag{
+						{Key: aws.String(api.EksctlVersionTag), Value: aws.String(version.GetVersion())},
+						{Key: aws.String(api.NodeGroupAMIHistoryTag), Value: aws.String("ami-previous")},
+					},
+				}, nil)
+				p.MockEKS().On("DescribeNodegroup", mock.Anything, mock.Anything).Return(&awseks.DescribeNodegroupOutput{
+					Nodegroup: &ekstypes.Nodegroup{
+						NodegroupName: aws.String(ngName),
+						ClusterName:   aws.String(clusterName),
+						Status:        ekstypes.NodegroupStatusActive,
+						AmiType:       ekstypes.AMITypesCustom,
+						Version:       eksVersion,
+					},
+				}, nil)
+			})
+
+			It("updates the image of the launch template and records the previous AMI in the stack tags", func() {
+				Expect(m.Upgrade(context.Background(), options)).To(Succeed())
+				Expect(fakeStackManager.UpdateStackCallCount()).To(Equal(1))
+				_, updateOptions := fakeStackManager.UpdateStackArgsForCall(0)
+				Expect(string(updateOptions.TemplateData.(manager.TemplateBody))).To(ContainSubstring(`"ImageId": "ami-new"`))
+				Expect(nodegroup.GetAMIHistory(updateOptions.Stack.Tags)).To(Equal([]string{"ami-current", "ami-previous"}))
+				Expect(updateOptions.Stack.Tags).To(ContainElement(types.Tag{Key: aws.String(api.EksctlVersionTag), Value: aws.String(version.GetVersion())}))
+			})
+
+			It("rolls back to the previous AMI", func() {
+				options.AMI = ""
+				options.RollbackAMI = true
+				Expect(m.Upgrade(context.Background(), options)).To(Succeed())
+				_, updateOptions := fakeStackManager.UpdateStackArgsForCall(0)
+				Expect(string(updateOptions.TemplateData.(manager.TemplateBody))).To(ContainSubstring(`"ImageId": "ami-previous"`))
+				Expect(nodegroup.GetAMIHistory(updateOptions.Stack.Tags)).To(BeEmpty())
+			})
+
+			It("does nothing if the nodegroup already uses the AMI", func() {
+				options.AMI = "ami-current"
+				Expect(m.Upgrade(context.Background(), options)).To(Succeed())
+				Expect(fakeStackManager.UpdateStackCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the nodegroup does not have a stack", func() {
+			BeforeEach(func() {
+				p.MockEKS().On("DescribeNodegroup", mock.Anything, mock.Anything).Return(&awseks.DescribeNodegroupOutput{
+					Nodegroup: &ekstypes.Nodegroup{
+						NodegroupName: aws.String(ngName),
+						ClusterName:   aws.String(clusterName),
+						Status:        ekstypes.NodegroupStatusActive,
+						AmiType:       ekstypes.AMITypesCustom,
+						Version:       eksVersion,
+						LaunchTemplate: &ekstypes.LaunchTemplateSpecification{
+							Id:      aws.String("id-123"),
+							Version: aws.String("2"),
+						},
+					},
+				}, nil)
+				p.MockEC2().On("DescribeLaunchTemplateVersions", mock.Anything, &ec2.DescribeLaunchTemplateVersionsInput{
+					LaunchTemplateId: aws.String("id-123"),
+					Versions:         []string{"2"},
+				}).Return(&ec2.DescribeLaunchTemplateVersionsOutput{LaunchTemplateVersions: []ec2types.LaunchTemplateVersion{
+					{
+						LaunchTemplateData: &ec2types.ResponseLaunchTemplateData{
+							ImageId: aws.String("ami-current"),
+						},
+						VersionNumber: aws.Int64(2),
+					},
+				}}, nil)
+			})
+
+			It("creates a new launch template version and updates the nodegroup to it", func() {
+				p.MockEC2().On("CreateLaunchTemplateVersion", mock.Anything, &ec2.CreateLaunchTemplateVersionInput{
+					LaunchTemplateId:   aws.String("id-123"),
+					SourceVersion:      aws.String("2"),
+					VersionDescription: aws.String("eksctl: AMI ami-new, previously ami-current"),
+					LaunchTemplateData: &ec2types.RequestLaunchTemplateData{
+						ImageId: aws.String("ami-new"),
+					},
+				}).Return(&ec2.CreateLaunchTemplateVersionOutput{
+					LaunchTemplateVersion: &ec2types.LaunchTemplateVersion{VersionNumber: aws.Int64(3)},
+				}, nil)
+				p.MockEKS().On("UpdateNodegroupVersion", mock.Anything, &awseks.UpdateNodegroupVersionInput{
+					ClusterName:   aws.String(clusterName),
+					NodegroupName: aws.String(ngName),
+					LaunchTemplate: &ekstypes.LaunchTemplateSpecification{
+						Id:      aws.String("id-123"),
+						Version: aws.String("3"),
+					},
+				}).Return(&awseks.UpdateNodegroupVersionOutput{}, nil)
+
+				Expect(m.Upgrade(context.Background(), options)).To(Succeed())
+			})
+
+			It("does not support rolling back", func() {
+				options.AMI = ""
+				options.RollbackAMI = true
+				Expect(m.Upgrade(context.Background(), options)).To(MatchError(ContainSubstring("rollback-ami is only supported for nodegroups created by eksctl")))
+			})
+		})
+	})
 })
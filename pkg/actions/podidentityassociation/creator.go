@@ -38,7 +38,7 @@ func NewCreator(clusterName string, stackCreator StackCreator, eksAPI awsapi.EKS
 }
 
 func (c *Creator) CreatePodIdentityAssociations(ctx context.Context, podIdentityAssociations []api.PodIdentityAssociation) error {
-	return runAllTasks(c.CreateTasks(ctx, podIdentityAssociations, false))
+	return runAllTasks(ctx, c.CreateTasks(ctx, podIdentityAssociations, false))
 }
 
 func (c *Creator) CreateTasks(ctx context.Context, podIdentityAssociations []api.PodIdentityAssociation, ignorePodIdentityExistsErr bool) *tasks.TaskTree {
@@ -211,7 +211,7 @@ func (m *Migrator) MigrateToPodIdentity(ctx context.Context, options PodIdentity
 		len(toBeCreated), addonMigrationTasks.Len())
 	defer cmdutils.LogPlanModeWarning(taskTree.PlanMode)
 
-	return runAllTasks(&taskTree)
+	return runAllTasks(ctx, &taskTree)
 }
 
 func IsPodIdentityAgentInstalled(ctx context.Context, eksAPI awsapi.EKS, clusterName string) (bool, error) {
@@ -84,7 +84,7 @@ func (u *Updater) Update(ctx context.Context, podIdentityAssociations []api.PodI
 			},
 		})
 	}
-	return runAllTasks(taskTree)
+	return runAllTasks(ctx, taskTree)
 }
 
 func (u *Updater) update(ctx context.Context, updateConfig *UpdateConfig, podIdentityAssociationID string) error {
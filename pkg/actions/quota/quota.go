@@ -0,0 +1,428 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	servicequotastypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+// DefaultPollInterval is how often the status of quota increase requests is checked when watching them
+const DefaultPollInterval = time.Minute
+
+// ServiceQuotasAPI is the subset of the Service Quotas API used to check quotas and request increases
+type ServiceQuotasAPI interface {
+	GetServiceQuota(ctx context.Context, params *servicequotas.GetServiceQuotaInput, optFns ...func(*servicequotas.Options)) (*servicequotas.GetServiceQuotaOutput, error)
+	RequestServiceQuotaIncrease(ctx context.Context, params *servicequotas.RequestServiceQuotaIncreaseInput, optFns ...func(*servicequotas.Options)) (*servicequotas.RequestServiceQuotaIncreaseOutput, error)
+	GetRequestedServiceQuotaChange(ctx context.Context, params *servicequotas.GetRequestedServiceQuotaChangeInput, optFns ...func(*servicequotas.Options)) (*servicequotas.GetRequestedServiceQuotaChangeOutput, error)
+	ListRequestedServiceQuotaChangeHistoryByQuota(ctx context.Context, params *servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaInput, optFns ...func(*servicequotas.Options)) (*servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput, error)
+}
+
+// NewServiceQuotasAPI returns a client of the Service Quotas API
+func NewServiceQuotasAPI(cfg aws.Config) ServiceQuotasAPI {
+	return servicequotas.NewFromConfig(cfg)
+}
+
+// Quota identifies a service quota
+type Quota struct {
+	ServiceCode string
+	QuotaCode   string
+	Name        string
+}
+
+var (
+	// OnDemandStandardVCPUs is the number of vCPUs of the running on-demand instances of the standard families
+	OnDemandStandardVCPUs = Quota{ServiceCode: "ec2", QuotaCode: "L-1216C47A", Name: "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances"}
+	// VPCsPerRegion is the number of VPCs of a region
+	VPCsPerRegion = Quota{ServiceCode: "vpc", QuotaCode: "L-F678F1CE", Name: "VPCs per Region"}
+	// ElasticIPs is the number of Elastic IP addresses of a region
+	ElasticIPs = Quota{ServiceCode: "ec2", QuotaCode: "L-0263D0A3", Name: "EC2-VPC Elastic IPs"}
+)
+
+// standardInstanceFamilies are the first letters of the instance families counted in OnDemandStandardVCPUs
+const standardInstanceFamilies = "acdhimrtz"
+
+// Requirement is the usage of a quota and the amount a cluster needs on top of it
+type Requirement struct {
+	Quota
+	Usage    float64
+	Required float64
+	Limit    float64
+	// RequestID and Status are set once an increase of the quota has been requested
+	RequestID string `json:",omitempty"`
+	Status    string `json:",omitempty"`
+}
+
+// DesiredValue is the value the quota must be increased to for the cluster to be created
+func (r Requirement) DesiredValue() float64 {
+	return r.Usage + r.Required
+}
+
+// Exceeded reports whether creating the cluster would exceed the quota
+func (r Requirement) Exceeded() bool {
+	return r.DesiredValue() > r.Limit
+}
+
+// Resolved reports whether the increase request of the quota was approved or rejected
+func (r Requirement) Resolved() bool {
+	switch servicequotastypes.RequestStatus(r.Status) {
+	case servicequotastypes.RequestStatusApproved, servicequotastypes.RequestStatusDenied, servicequotastypes.RequestStatusCaseClosed,
+		servicequotastypes.RequestStatusNotApproved, servicequotastypes.RequestStatusInvalidRequest:
+		return true
+	}
+	return false
+}
+
+// Approved reports whether the increase request of the quota was approved
+func (r Requirement) Approved() bool {
+	return servicequotastypes.RequestStatus(r.Status) == servicequotastypes.RequestStatusApproved
+}
+
+// Checker computes the quotas a cluster needs and requests their increase
+type Checker struct {
+	EC2           awsapi.EC2
+	ServiceQuotas ServiceQuotasAPI
+	// PollInterval is how often Watch checks the status of the increase requests, defaults to DefaultPollInterval
+	PollInterval time.Duration
+}
+
+// Check returns the usage, limit and amount the cluster needs of the EC2 on-demand vCPU, VPC and Elastic IP quotas
+func (c *Checker) Check(ctx context.Context, cfg *api.ClusterConfig) ([]Requirement, error) {
+	vCPUs, err := c.requiredVCPUs(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	vCPUUsage, err := c.vCPUUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vpcUsage, err := c.vpcUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := c.EC2.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{})
+	if err != nil {
+		return nil, fmt.Errorf("describing Elastic IP addresses: %w", err)
+	}
+
+	requirements := []Requirement{
+		{Quota: OnDemandStandardVCPUs, Usage: vCPUUsage, Required: vCPUs},
+		{Quota: VPCsPerRegion, Usage: vpcUsage, Required: requiredVPCs(cfg)},
+		{Quota: ElasticIPs, Usage: float64(len(addresses.Addresses)), Required: requiredElasticIPs(cfg)},
+	}
+	for i := range requirements {
+		r := &requirements[i]
+		output, err := c.ServiceQuotas.GetServiceQuota(ctx, &servicequotas.GetServiceQuotaInput{
+			ServiceCode: aws.String(r.ServiceCode),
+			QuotaCode:   aws.String(r.QuotaCode),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting quota %q: %w", r.Name, err)
+		}
+		r.Limit = aws.ToFloat64(output.Quota.Value)
+	}
+	return requirements, nil
+}
+
+// RequestIncreases requests the increase of the exceeded quotas to the value the cluster needs. Quotas that already
+// have a pending increase request are not requested again, the pending request is returned instead.
+func (c *Checker) RequestIncreases(ctx context.Context, requirements []Requirement) ([]Requirement, error) {
+	var requested []Requirement
+	for _, r := range requirements {
+		if !r.Exceeded() {
+			continue
+		}
+		pending, err := c.pendingRequest(ctx, r.Quota)
+		if err != nil {
+			return nil, err
+		}
+		if pending != nil {
+			logger.Info("an increase of quota %q to %v is already pending (request %s)", r.Name, aws.ToFloat64(pending.DesiredValue), aws.ToString(pending.Id))
+			r.RequestID, r.Status = aws.ToString(pending.Id), string(pending.Status)
+			requested = append(requested, r)
+			continue
+		}
+		output, err := c.ServiceQuotas.RequestServiceQuotaIncrease(ctx, &servicequotas.RequestServiceQuotaIncreaseInput{
+			ServiceCode:  aws.String(r.ServiceCode),
+			QuotaCode:    aws.String(r.QuotaCode),
+			DesiredValue: aws.Float64(r.DesiredValue()),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("requesting the increase of quota %q to %v: %w", r.Name, r.DesiredValue(), err)
+		}
+		logger.Success("requested the increase of quota %q from %v to %v (request %s)", r.Name, r.Limit, r.DesiredValue(), aws.ToString(output.RequestedQuota.Id))
+		r.RequestID, r.Status = aws.ToString(output.RequestedQuota.Id), string(output.RequestedQuota.Status)
+		requested = append(requested, r)
+	}
+	return requested, nil
+}
+
+// Watch polls the status of the increase requests until all of them are approved or rejected, or ctx is done,
+// and returns the requests with their last status
+func (c *Checker) Watch(ctx context.Context, requests []Requirement) ([]Requirement, error) {
+	interval := c.PollInterval
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+	requests = append([]Requirement(nil), requests...)
+	for {
+		pending := 0
+		for i := range requests {
+			r := &requests[i]
+			if r.Resolved() {
+				continue
+			}
+			output, err := c.ServiceQuotas.GetRequestedServiceQuotaChange(ctx, &servicequotas.GetRequestedServiceQuotaChangeInput{
+				RequestId: aws.String(r.RequestID),
+			})
+			if err != nil {
+				return requests, fmt.Errorf("getting the status of the increase request of quota %q: %w", r.Name, err)
+			}
+			if status := string(output.RequestedQuota.Status); status != r.Status {
+				logger.Info("increase request of quota %q is %s", r.Name, status)
+				r.Status = status
+			}
+			if !r.Resolved() {
+				pending++
+			}
+		}
+		if pending == 0 {
+			return requests, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return requests, fmt.Errorf("timed out waiting for %d quota increase request(s) to be resolved: %w", pending, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Checker) pendingRequest(ctx context.Context, quota Quota) (*servicequotastypes.RequestedServiceQuotaChange, error) {
+	for _, status := range []servicequotastypes.RequestStatus{servicequotastypes.RequestStatusPending, servicequotastypes.RequestStatusCaseOpened} {
+		output, err := c.ServiceQuotas.ListRequestedServiceQuotaChangeHistoryByQuota(ctx, &servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaInput{
+			ServiceCode: aws.String(quota.ServiceCode),
+			QuotaCode:   aws.String(quota.QuotaCode),
+			Status:      status,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing the increase requests of quota %q: %w", quota.Name, err)
+		}
+		if len(output.RequestedQuotas) > 0 {
+			return &output.RequestedQuotas[0], nil
+		}
+	}
+	return nil, nil
+}
+
+// requiredVCPUs returns the vCPUs of the on-demand instances of the standard families the nodegroups run at their
+// maximum size, assuming the largest of their instance types
+func (c *Checker) requiredVCPUs(ctx context.Context, cfg *api.ClusterConfig) (float64, error) {
+	type nodeGroupInstances struct {
+		name          string
+		instanceTypes []string
+		onDemand      int
+	}
+	var nodeGroups []nodeGroupInstances
+	for _, ng := range cfg.NodeGroups {
+		var instanceTypes []string
+		if ng.InstancesDistribution != nil && len(ng.InstancesDistribution.InstanceTypes) > 0 {
+			instanceTypes = ng.InstancesDistribution.InstanceTypes
+		} else {
+			instanceTypes = []string{defaultInstanceType(ng.NodeGroupBase)}
+		}
+		nodeGroups = append(nodeGroups, nodeGroupInstances{
+			name:          ng.Name,
+			instanceTypes: instanceTypes,
+			onDemand:      onDemandInstances(maxSize(ng.NodeGroupBase), ng.InstancesDistribution),
+		})
+	}
+	for _, ng := range cfg.ManagedNodeGroups {
+		if ng.Spot {
+			continue
+		}
+		instanceTypes := ng.InstanceTypes
+		if len(instanceTypes) == 0 {
+			instanceTypes = []string{defaultInstanceType(ng.NodeGroupBase)}
+		}
+		nodeGroups = append(nodeGroups, nodeGroupInstances{
+			name:          ng.Name,
+			instanceTypes: instanceTypes,
+			onDemand:      maxSize(ng.NodeGroupBase),
+		})
+	}
+
+	var instanceTypes []ec2types.InstanceType
+	seen := map[string]bool{}
+	for _, ng := range nodeGroups {
+		for _, instanceType := range ng.instanceTypes {
+			if isStandardInstanceType(instanceType) && !seen[instanceType] {
+				seen[instanceType] = true
+				instanceTypes = append(instanceTypes, ec2types.InstanceType(instanceType))
+			}
+		}
+	}
+	if len(instanceTypes) == 0 {
+		return 0, nil
+	}
+	vCPUsByType := map[string]int32{}
+	paginator := ec2.NewDescribeInstanceTypesPaginator(c.EC2, &ec2.DescribeInstanceTypesInput{InstanceTypes: instanceTypes})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("describing instance types: %w", err)
+		}
+		for _, it := range output.InstanceTypes {
+			if it.VCpuInfo != nil {
+				vCPUsByType[string(it.InstanceType)] = aws.ToInt32(it.VCpuInfo.DefaultVCpus)
+			}
+		}
+	}
+
+	var vCPUs float64
+	for _, ng := range nodeGroups {
+		var largest int32
+		for _, instanceType := range ng.instanceTypes {
+			if vCPUsByType[instanceType] > largest {
+				largest = vCPUsByType[instanceType]
+			}
+		}
+		if largest == 0 {
+			logger.Debug("nodegroup %q does not run instances of the standard families", ng.name)
+			continue
+		}
+		vCPUs += float64(largest) * float64(ng.onDemand)
+	}
+	return vCPUs, nil
+}
+
+// vCPUUsage returns the vCPUs of the running on-demand instances of the standard families
+func (c *Checker) vCPUUsage(ctx context.Context) (float64, error) {
+	var vCPUs float64
+	paginator := ec2.NewDescribeInstancesPaginator(c.EC2, &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: []string{string(ec2types.InstanceStateNamePending), string(ec2types.InstanceStateNameRunning)},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("describing instances: %w", err)
+		}
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				if instance.InstanceLifecycle != "" || !isStandardInstanceType(string(instance.InstanceType)) || instance.CpuOptions == nil {
+					continue
+				}
+				vCPUs += float64(aws.ToInt32(instance.CpuOptions.CoreCount) * aws.ToInt32(instance.CpuOptions.ThreadsPerCore))
+			}
+		}
+	}
+	return vCPUs, nil
+}
+
+func (c *Checker) vpcUsage(ctx context.Context) (float64, error) {
+	var vpcs int
+	paginator := ec2.NewDescribeVpcsPaginator(c.EC2, &ec2.DescribeVpcsInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("describing VPCs: %w", err)
+		}
+		vpcs += len(output.Vpcs)
+	}
+	return float64(vpcs), nil
+}
+
+func requiredVPCs(cfg *api.ClusterConfig) float64 {
+	if cfg.VPC != nil && cfg.VPC.ID != "" {
+		return 0
+	}
+	return 1
+}
+
+// requiredElasticIPs returns the Elastic IP addresses of the NAT gateways of the VPC created for the cluster
+func requiredElasticIPs(cfg *api.ClusterConfig) float64 {
+	if cfg.VPC == nil || cfg.VPC.ID != "" || cfg.IPv6Enabled() {
+		return 0
+	}
+	gateway := api.ClusterSingleNAT
+	if cfg.VPC.NAT != nil && cfg.VPC.NAT.Gateway != nil {
+		gateway = *cfg.VPC.NAT.Gateway
+	}
+	switch gateway {
+	case api.ClusterDisableNAT:
+		return 0
+	case api.ClusterHighlyAvailableNAT:
+		if len(cfg.AvailabilityZones) > 0 {
+			return float64(len(cfg.AvailabilityZones))
+		}
+		return api.RecommendedAvailabilityZones
+	default:
+		return 1
+	}
+}
+
+// defaultInstanceType returns the instance type a nodegroup without instance types gets, or nothing when its
+// instance types are picked by an instance selector
+func defaultInstanceType(ng *api.NodeGroupBase) string {
+	if ng.InstanceType != "" {
+		return ng.InstanceType
+	}
+	if ng.InstanceSelector != nil && !ng.InstanceSelector.IsZero() {
+		logger.Warning("not counting the vCPUs of nodegroup %q, its instance types are picked by an instance selector", ng.Name)
+		return ""
+	}
+	return api.DefaultNodeType
+}
+
+func maxSize(ng *api.NodeGroupBase) int {
+	if ng.ScalingConfig != nil {
+		if ng.MaxSize != nil {
+			return *ng.MaxSize
+		}
+		if ng.DesiredCapacity != nil {
+			return *ng.DesiredCapacity
+		}
+	}
+	return api.DefaultNodeCount
+}
+
+// onDemandInstances returns how many of the size instances of a nodegroup are on-demand instances
+func onDemandInstances(size int, distribution *api.NodeGroupInstancesDistribution) int {
+	if distribution == nil {
+		return size
+	}
+	base := 0
+	if distribution.OnDemandBaseCapacity != nil {
+		base = *distribution.OnDemandBaseCapacity
+	}
+	if base >= size {
+		return size
+	}
+	percentage := 100
+	if distribution.OnDemandPercentageAboveBaseCapacity != nil {
+		percentage = *distribution.OnDemandPercentageAboveBaseCapacity
+	}
+	// rounded up, as the autoscaling group does
+	return base + ((size-base)*percentage+99)/100
+}
+
+func isStandardInstanceType(instanceType string) bool {
+	return instanceType != "" && strings.ContainsRune(standardInstanceFamilies, rune(instanceType[0]))
+}
@@ -0,0 +1,224 @@
+package quota_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	servicequotastypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/quota"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+type fakeServiceQuotas struct {
+	limits map[string]float64
+	// pending are the pending increase requests by quota code
+	pending map[string]string
+	// statuses are the successive statuses returned for a request
+	statuses  map[string][]servicequotastypes.RequestStatus
+	requested []*servicequotas.RequestServiceQuotaIncreaseInput
+}
+
+func (f *fakeServiceQuotas) GetServiceQuota(_ context.Context, params *servicequotas.GetServiceQuotaInput, _ ...func(*servicequotas.Options)) (*servicequotas.GetServiceQuotaOutput, error) {
+	return &servicequotas.GetServiceQuotaOutput{
+		Quota: &servicequotastypes.ServiceQuota{Value: aws.Float64(f.limits[aws.ToString(params.QuotaCode)])},
+	}, nil
+}
+
+func (f *fakeServiceQuotas) RequestServiceQuotaIncrease(_ context.Context, params *servicequotas.RequestServiceQuotaIncreaseInput, _ ...func(*servicequotas.Options)) (*servicequotas.RequestServiceQuotaIncreaseOutput, error) {
+	f.requested = append(f.requested, params)
+	return &servicequotas.RequestServiceQuotaIncreaseOutput{
+		RequestedQuota: &servicequotastypes.RequestedServiceQuotaChange{
+			Id:     aws.String(fmt.Sprintf("request-%s", aws.ToString(params.QuotaCode))),
+			Status: servicequotastypes.RequestStatusPending,
+		},
+	}, nil
+}
+
+func (f *fakeServiceQuotas) GetRequestedServiceQuotaChange(_ context.Context, params *servicequotas.GetRequestedServiceQuotaChangeInput, _ ...func(*servicequotas.Options)) (*servicequotas.GetRequestedServiceQuotaChangeOutput, error) {
+	id := aws.ToString(params.RequestId)
+	statuses := f.statuses[id]
+	status := statuses[0]
+	if len(statuses) > 1 {
+		f.statuses[id] = statuses[1:]
+	}
+	return &servicequotas.GetRequestedServiceQuotaChangeOutput{
+		RequestedQuota: &servicequotastypes.RequestedServiceQuotaChange{Id: params.RequestId, Status: status},
+	}, nil
+}
+
+func (f *fakeServiceQuotas) ListRequestedServiceQuotaChangeHistoryByQuota(_ context.Context, params *servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaInput, _ ...func(*servicequotas.Options)) (*servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput, error) {
+	output := &servicequotas.ListRequestedServiceQuotaChangeHistoryByQuotaOutput{}
+	if id, ok := f.pending[aws.ToString(params.QuotaCode)]; ok && params.Status == servicequotastypes.RequestStatusCaseOpened {
+		output.RequestedQuotas = []servicequotastypes.RequestedServiceQuotaChange{
+			{Id: aws.String(id), Status: servicequotastypes.RequestStatusCaseOpened, DesiredValue: aws.Float64(10)},
+		}
+	}
+	return output, nil
+}
+
+var _ = Describe("Quota", func() {
+	var (
+		provider      *mockprovider.MockProvider
+		serviceQuotas *fakeServiceQuotas
+		checker       *quota.Checker
+		cfg           *api.ClusterConfig
+	)
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		serviceQuotas = &fakeServiceQuotas{
+			limits: map[string]float64{
+				quota.OnDemandStandardVCPUs.QuotaCode: 32,
+				quota.VPCsPerRegion.QuotaCode:         5,
+				quota.ElasticIPs.QuotaCode:            5,
+			},
+			statuses: map[string][]servicequotastypes.RequestStatus{},
+		}
+		checker = &quota.Checker{
+			EC2:           provider.EC2(),
+			ServiceQuotas: serviceQuotas,
+			PollInterval:  time.Millisecond,
+		}
+
+		cfg = api.NewClusterConfig()
+		cfg.AvailabilityZones = []string{"us-west-2a", "us-west-2b", "us-west-2c"}
+		cfg.VPC.NAT = &api.ClusterNAT{Gateway: aws.String(api.ClusterHighlyAvailableNAT)}
+
+		ng := api.NewNodeGroup()
+		ng.Name = "ng"
+		ng.InstanceType = "m5.xlarge"
+		ng.MaxSize = aws.Int(10)
+		mixed := api.NewNodeGroup()
+		mixed.Name = "mixed"
+		mixed.MaxSize = aws.Int(5)
+		mixed.InstancesDistribution = &api.NodeGroupInstancesDistribution{
+			InstanceTypes:                       []string{"m5.large", "m5.xlarge"},
+			OnDemandBaseCapacity:                aws.Int(1),
+			OnDemandPercentageAboveBaseCapacity: aws.Int(50),
+		}
+		gpu := api.NewNodeGroup()
+		gpu.Name = "gpu"
+		gpu.InstanceType = "g4dn.xlarge"
+		cfg.NodeGroups = []*api.NodeGroup{ng, mixed, gpu}
+
+		mng := api.NewManagedNodeGroup()
+		mng.Name = "mng"
+		mng.InstanceTypes = []string{"c5.large"}
+		mng.DesiredCapacity = aws.Int(3)
+		spot := api.NewManagedNodeGroup()
+		spot.Name = "spot"
+		spot.InstanceTypes = []string{"m5.large"}
+		spot.Spot = true
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{mng, spot}
+
+		provider.MockEC2().On("DescribeInstanceTypes", mock.Anything, &ec2.DescribeInstanceTypesInput{
+			InstanceTypes: []ec2types.InstanceType{"m5.xlarge", "m5.large", "c5.large"},
+		}, mock.Anything).Return(&ec2.DescribeInstanceTypesOutput{
+			InstanceTypes: []ec2types.InstanceTypeInfo{
+				{InstanceType: "m5.xlarge", VCpuInfo: &ec2types.VCpuInfo{DefaultVCpus: aws.Int32(4)}},
+				{InstanceType: "m5.large", VCpuInfo: &ec2types.VCpuInfo{DefaultVCpus: aws.Int32(2)}},
+				{InstanceType: "c5.large", VCpuInfo: &ec2types.VCpuInfo{DefaultVCpus: aws.Int32(2)}},
+			},
+		}, nil)
+		cpuOptions := &ec2types.CpuOptions{CoreCount: aws.Int32(1), ThreadsPerCore: aws.Int32(2)}
+		provider.MockEC2().On("DescribeInstances", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{
+				{
+					Instances: []ec2types.Instance{
+						{InstanceType: "m5.large", CpuOptions: cpuOptions},
+						{InstanceType: "m5.large", CpuOptions: cpuOptions, InstanceLifecycle: ec2types.InstanceLifecycleTypeSpot},
+						{InstanceType: "g4dn.xlarge", CpuOptions: cpuOptions},
+					},
+				},
+			},
+		}, nil)
+		provider.MockEC2().On("DescribeVpcs", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeVpcsOutput{
+			Vpcs: make([]ec2types.Vpc, 4),
+		}, nil)
+		provider.MockEC2().On("DescribeAddresses", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeAddressesOutput{
+			Addresses: make([]ec2types.Address, 3),
+		}, nil)
+	})
+
+	It("computes the quotas the cluster needs", func() {
+		requirements, err := checker.Check(context.Background(), cfg)
+		Expect(err).NotTo(HaveOccurred())
+		// 10 m5.xlarge, 3 of 5 mixed nodes on-demand as m5.xlarge and 3 c5.large
+		Expect(requirements).To(Equal([]quota.Requirement{
+			{Quota: quota.OnDemandStandardVCPUs, Usage: 2, Required: 58, Limit: 32},
+			{Quota: quota.VPCsPerRegion, Usage: 4, Required: 1, Limit: 5},
+			{Quota: quota.ElasticIPs, Usage: 3, Required: 3, Limit: 5},
+		}))
+		Expect(requirements[0].Exceeded()).To(BeTrue())
+		Expect(requirements[1].Exceeded()).To(BeFalse())
+		Expect(requirements[2].Exceeded()).To(BeTrue())
+	})
+
+	It("does not need a VPC or Elastic IPs for an existing VPC", func() {
+		cfg.VPC.ID = "vpc-1234"
+		requirements, err := checker.Check(context.Background(), cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requirements[1].Required).To(BeZero())
+		Expect(requirements[2].Required).To(BeZero())
+	})
+
+	It("requests the increase of the exceeded quotas only", func() {
+		requirements, err := checker.Check(context.Background(), cfg)
+		Expect(err).NotTo(HaveOccurred())
+		requests, err := checker.RequestIncreases(context.Background(), requirements)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(HaveLen(2))
+		Expect(serviceQuotas.requested).To(Equal([]*servicequotas.RequestServiceQuotaIncreaseInput{
+			{ServiceCode: aws.String("ec2"), QuotaCode: aws.String(quota.OnDemandStandardVCPUs.QuotaCode), DesiredValue: aws.Float64(60)},
+			{ServiceCode: aws.String("ec2"), QuotaCode: aws.String(quota.ElasticIPs.QuotaCode), DesiredValue: aws.Float64(6)},
+		}))
+		Expect(requests[0].RequestID).To(Equal("request-L-1216C47A"))
+		Expect(requests[0].Status).To(Equal("PENDING"))
+	})
+
+	It("reuses a pending increase request", func() {
+		serviceQuotas.pending = map[string]string{quota.ElasticIPs.QuotaCode: "existing"}
+		requirements, err := checker.Check(context.Background(), cfg)
+		Expect(err).NotTo(HaveOccurred())
+		requests, err := checker.RequestIncreases(context.Background(), requirements)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(serviceQuotas.requested).To(HaveLen(1))
+		Expect(requests[1].RequestID).To(Equal("existing"))
+		Expect(requests[1].Status).To(Equal("CASE_OPENED"))
+	})
+
+	It("watches the increase requests until they are resolved", func() {
+		serviceQuotas.statuses["vcpus"] = []servicequotastypes.RequestStatus{"PENDING", "CASE_OPENED", "APPROVED"}
+		serviceQuotas.statuses["eips"] = []servicequotastypes.RequestStatus{"DENIED"}
+		requests, err := checker.Watch(context.Background(), []quota.Requirement{
+			{Quota: quota.OnDemandStandardVCPUs, RequestID: "vcpus", Status: "PENDING"},
+			{Quota: quota.ElasticIPs, RequestID: "eips", Status: "PENDING"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests[0].Status).To(Equal("APPROVED"))
+		Expect(requests[0].Approved()).To(BeTrue())
+		Expect(requests[1].Status).To(Equal("DENIED"))
+		Expect(requests[1].Approved()).To(BeFalse())
+	})
+
+	It("stops watching when the context is done", func() {
+		serviceQuotas.statuses["vcpus"] = []servicequotastypes.RequestStatus{"PENDING"}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		requests, err := checker.Watch(ctx, []quota.Requirement{
+			{Quota: quota.OnDemandStandardVCPUs, RequestID: "vcpus", Status: "PENDING"},
+		})
+		Expect(err).To(MatchError(ContainSubstring("timed out waiting for 1 quota increase request(s)")))
+		Expect(requests[0].Status).To(Equal("PENDING"))
+	})
+})
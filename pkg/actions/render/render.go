@@ -0,0 +1,284 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	"github.com/weaveworks/eksctl/pkg/ami"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+	"github.com/weaveworks/eksctl/pkg/nodebootstrap"
+	"github.com/weaveworks/eksctl/pkg/utils/nodes"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+// Placeholders for the values that are only known once the cluster exists. They are used in the
+// rendered templates, and must be substituted before the templates are submitted.
+const (
+	PlaceholderAccountID            = "ACCOUNT_ID"
+	PlaceholderOIDCIssuerID         = "OIDC_ISSUER_ID"
+	PlaceholderClusterEndpoint      = "https://CLUSTER_ENDPOINT"
+	PlaceholderCertificateAuthority = "CLUSTER_CERTIFICATE_AUTHORITY"
+)
+
+const defaultServiceIPv4CIDR = "10.100.0.0/16"
+
+// ErrOffline is returned for AWS API calls made while rendering templates.
+var ErrOffline = errors.New("AWS API calls are not made when rendering templates offline")
+
+// Renderer renders the CloudFormation templates of a cluster, its nodegroups and the IAM roles
+// of its service accounts without calling the AWS API.
+type Renderer struct {
+	ClusterConfig *api.ClusterConfig
+	// OutputDir is the directory the templates are written to, one file per stack.
+	OutputDir string
+
+	ec2API awsapi.EC2
+	iamAPI awsapi.IAM
+}
+
+// New returns a Renderer whose AWS API clients fail every call with ErrOffline.
+func New(clusterConfig *api.ClusterConfig, outputDir string) *Renderer {
+	cfg := aws.Config{
+		Region:      clusterConfig.Metadata.Region,
+		Credentials: aws.AnonymousCredentials{},
+		APIOptions: []func(*middleware.Stack) error{
+			func(stack *middleware.Stack) error {
+				return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("Offline", func(context.Context, middleware.InitializeInput, middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+					return middleware.InitializeOutput{}, middleware.Metadata{}, ErrOffline
+				}), middleware.Before)
+			},
+		},
+	}
+	return &Renderer{
+		ClusterConfig: clusterConfig,
+		OutputDir:     outputDir,
+		ec2API:        ec2.NewFromConfig(cfg),
+		iamAPI:        iam.NewFromConfig(cfg),
+	}
+}
+
+// Render writes the templates to OutputDir and returns the paths of the written files.
+func (r *Renderer) Render(ctx context.Context) ([]string, error) {
+	cfg := r.ClusterConfig
+	if err := validate(cfg); err != nil {
+		return nil, err
+	}
+	if err := vpc.SetSubnets(cfg.VPC, cfg.AvailabilityZones, cfg.LocalZones); err != nil {
+		return nil, err
+	}
+	setPlaceholders(cfg)
+
+	if err := os.MkdirAll(r.OutputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating output directory: %w", err)
+	}
+
+	var files []string
+	write := func(stackName string, resourceSet builder.ResourceSetReader) error {
+		templateBody, err := resourceSet.RenderJSON()
+		if err != nil {
+			return errors.Wrapf(err, "rendering template for stack %q", stackName)
+		}
+		path := filepath.Join(r.OutputDir, stackName+".json")
+		if err := os.WriteFile(path, templateBody, 0o644); err != nil {
+			return fmt.Errorf("writing template for stack %q: %w", stackName, err)
+		}
+		files = append(files, path)
+		return nil
+	}
+
+	clusterStackName := fmt.Sprintf("eksctl-%s-cluster", cfg.Metadata.Name)
+	clusterStack := builder.NewClusterResourceSet(r.ec2API, cfg.Metadata.Region, cfg, nil, false)
+	if err := clusterStack.AddAllResources(ctx); err != nil {
+		return nil, err
+	}
+	if err := write(clusterStackName, clusterStack); err != nil {
+		return nil, err
+	}
+
+	vpcImporter := vpc.NewStackConfigImporter(clusterStackName)
+	for _, ng := range cfg.NodeGroups {
+		if err := resolveAMI(cfg, ng); err != nil {
+			return nil, err
+		}
+		if ng.InstanceType == "" {
+			if api.HasMixedInstances(ng) {
+				ng.InstanceType = "mixed"
+			} else {
+				ng.InstanceType = api.DefaultNodeType
+			}
+		}
+		bootstrapper, err := nodebootstrap.NewBootstrapper(cfg, ng)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating bootstrapper")
+		}
+		stack := builder.NewNodeGroupResourceSet(r.ec2API, r.iamAPI, builder.NodeGroupOptions{
+			ClusterConfig:      cfg,
+			NodeGroup:          ng,
+			Bootstrapper:       bootstrapper,
+			VPCImporter:        vpcImporter,
+			DisableAccessEntry: cfg.AccessConfig.AuthenticationMode == ekstypes.AuthenticationModeConfigMap,
+		})
+		if err := stack.AddAllResources(ctx); err != nil {
+			return nil, err
+		}
+		if err := write(makeNodeGroupStackName(cfg.Metadata.Name, ng.Name), stack); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, ng := range cfg.ManagedNodeGroups {
+		if ng.LaunchTemplate == nil && ng.InstanceType == "" && len(ng.InstanceTypes) == 0 {
+			ng.InstanceType = api.DefaultNodeType
+		}
+		hasNativeAMIFamilySupport := ng.AMIFamily == api.NodeImageFamilyAmazonLinux2023 ||
+			ng.AMIFamily == api.NodeImageFamilyAmazonLinux2 ||
+			ng.AMIFamily == api.NodeImageFamilyBottlerocket ||
+			api.IsWindowsImage(ng.AMIFamily)
+		if !hasNativeAMIFamilySupport {
+			if err := resolveAMI(cfg, ng); err != nil {
+				return nil, err
+			}
+		}
+		bootstrapper, err := nodebootstrap.NewManagedBootstrapper(cfg, ng)
+		if err != nil {
+			return nil, err
+		}
+		stack := builder.NewManagedNodeGroup(r.ec2API, cfg, ng, builder.NewLaunchTemplateFetcher(r.ec2API), bootstrapper, false, vpcImporter)
+		if err := stack.AddAllResources(ctx); err != nil {
+			return nil, err
+		}
+		if err := write(makeNodeGroupStackName(cfg.Metadata.Name, ng.Name), stack); err != nil {
+			return nil, err
+		}
+	}
+
+	if api.IsEnabled(cfg.IAM.WithOIDC) {
+		oidc, err := makeOIDCManager(cfg)
+		if err != nil {
+			return nil, err
+		}
+		for _, sa := range cfg.IAM.ServiceAccounts {
+			if sa.AttachRoleARN != "" {
+				continue
+			}
+			stack := builder.NewIAMRoleResourceSetForServiceAccount(sa, oidc)
+			if err := stack.AddAllResources(); err != nil {
+				return nil, err
+			}
+			stackName := fmt.Sprintf("eksctl-%s-addon-iamserviceaccount-%s-%s", cfg.Metadata.Name, sa.Namespace, sa.Name)
+			if err := write(stackName, stack); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i := range cfg.IAM.PodIdentityAssociations {
+		pia := &cfg.IAM.PodIdentityAssociations[i]
+		if pia.RoleARN != "" {
+			continue
+		}
+		stack := builder.NewIAMRoleResourceSetForPodIdentity(pia)
+		if err := stack.AddAllResources(); err != nil {
+			return nil, err
+		}
+		if err := write(podidentityassociation.MakeStackName(cfg.Metadata.Name, pia.Namespace, pia.ServiceAccountName), stack); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// validate returns an error for configurations whose templates depend on existing AWS resources.
+func validate(cfg *api.ClusterConfig) error {
+	if cfg.Metadata.Region == "" {
+		return errors.New("metadata.region must be set when rendering templates offline")
+	}
+	if cfg.HasAnySubnets() || cfg.VPC.ID != "" {
+		return errors.New("rendering templates offline is only supported for clusters with a dedicated VPC created by eksctl")
+	}
+	if cfg.IsControlPlaneOnOutposts() {
+		return errors.New("rendering templates offline is not supported for clusters on Outposts")
+	}
+	if len(cfg.AvailabilityZones) < api.MinRequiredAvailabilityZones {
+		return fmt.Errorf("at least %d availabilityZones must be set when rendering templates offline", api.MinRequiredAvailabilityZones)
+	}
+	for _, np := range nodes.ToNodePools(cfg) {
+		ng := np.BaseNodeGroup()
+		if ng.InstanceSelector != nil && !ng.InstanceSelector.IsZero() {
+			return fmt.Errorf("nodegroup %q: instanceSelector is not supported when rendering templates offline", ng.Name)
+		}
+		if api.IsEnabled(ng.SSH.Allow) && ng.SSH.PublicKeyName == nil {
+			return fmt.Errorf("nodegroup %q: ssh.publicKeyName must be used instead of a public key when rendering templates offline", ng.Name)
+		}
+	}
+	return nil
+}
+
+// setPlaceholders sets the account and the status of the cluster, which are only known once the cluster exists.
+func setPlaceholders(cfg *api.ClusterConfig) {
+	cfg.Metadata.AccountID = PlaceholderAccountID
+	serviceIPv4CIDR := defaultServiceIPv4CIDR
+	if cfg.KubernetesNetworkConfig != nil && cfg.KubernetesNetworkConfig.ServiceIPv4CIDR != "" {
+		serviceIPv4CIDR = cfg.KubernetesNetworkConfig.ServiceIPv4CIDR
+	}
+	cfg.Status = &api.ClusterStatus{
+		Endpoint:                 PlaceholderClusterEndpoint,
+		CertificateAuthorityData: []byte(PlaceholderCertificateAuthority),
+		KubernetesNetworkConfig: &api.KubernetesNetworkConfig{
+			ServiceIPv4CIDR: serviceIPv4CIDR,
+		},
+	}
+}
+
+// resolveAMI references the SSM parameter of the EKS-optimized AMI with a CloudFormation dynamic reference,
+// so that CloudFormation resolves the AMI when the stack is created.
+func resolveAMI(cfg *api.ClusterConfig, np api.NodePool) error {
+	ng := np.BaseNodeGroup()
+	if api.IsAMI(ng.AMI) {
+		return nil
+	}
+	if ng.AMI != "" && ng.AMI != api.NodeImageResolverAutoSSM {
+		return fmt.Errorf("nodegroup %q: ami must be an AMI ID or %q when rendering templates offline", ng.Name, api.NodeImageResolverAutoSSM)
+	}
+	parameterName, err := ami.MakeSSMParameterName(cfg.Metadata.Version, api.SelectInstanceType(np), ng.AMIFamily)
+	if err != nil {
+		return errors.Wrapf(err, "nodegroup %q", ng.Name)
+	}
+	ng.AMI = fmt.Sprintf("{{resolve:ssm:%s}}", parameterName)
+	logger.Info("nodegroup %q will use the AMI in SSM parameter %q", ng.Name, parameterName)
+	return nil
+}
+
+func makeOIDCManager(cfg *api.ClusterConfig) (*iamoidc.OpenIDConnectManager, error) {
+	dnsSuffix, err := api.Partitions.DNSSuffixForRegion(cfg.Metadata.Region)
+	if err != nil {
+		return nil, err
+	}
+	issuerHost := fmt.Sprintf("oidc.eks.%s.%s/id/%s", cfg.Metadata.Region, dnsSuffix, PlaceholderOIDCIssuerID)
+	partition := api.Partitions.ForRegion(cfg.Metadata.Region)
+	oidc, err := iamoidc.NewOpenIDConnectManager(nil, PlaceholderAccountID, "https://"+issuerHost, partition, nil)
+	if err != nil {
+		return nil, err
+	}
+	oidc.ProviderARN = fmt.Sprintf("arn:%s:iam::%s:oidc-provider/%s", partition, PlaceholderAccountID, issuerHost)
+	return oidc, nil
+}
+
+func makeNodeGroupStackName(clusterName, ngName string) string {
+	return fmt.Sprintf("eksctl-%s-nodegroup-%s", clusterName, ngName)
+}
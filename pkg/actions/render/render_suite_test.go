@@ -0,0 +1,11 @@
+package render_test
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestRender(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
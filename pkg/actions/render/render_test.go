@@ -0,0 +1,93 @@
+package render_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/tidwall/gjson"
+
+	"github.com/weaveworks/eksctl/pkg/actions/render"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("Render", func() {
+	var (
+		cfg       *api.ClusterConfig
+		outputDir string
+	)
+
+	BeforeEach(func() {
+		outputDir = GinkgoT().TempDir()
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "offline"
+		cfg.Metadata.Region = "us-west-2"
+		cfg.Metadata.Version = api.DefaultVersion
+		cfg.AvailabilityZones = []string{"us-west-2a", "us-west-2b"}
+		cfg.IAM.WithOIDC = api.Enabled()
+		cfg.IAM.ServiceAccounts = []*api.ClusterIAMServiceAccount{
+			{
+				ClusterIAMMeta:   api.ClusterIAMMeta{Name: "app", Namespace: "default"},
+				AttachPolicyARNs: []string{"arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"},
+			},
+		}
+
+		ng := cfg.NewNodeGroup()
+		ng.Name = "unmanaged"
+		ng.AMIFamily = api.NodeImageFamilyAmazonLinux2023
+		mng := api.NewManagedNodeGroup()
+		mng.Name = "managed"
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{mng}
+
+		api.SetClusterConfigDefaults(cfg)
+		api.SetNodeGroupDefaults(ng, cfg.Metadata, false)
+		api.SetManagedNodeGroupDefaults(mng, cfg.Metadata, false)
+	})
+
+	readTemplate := func(stackName string) []byte {
+		template, err := os.ReadFile(filepath.Join(outputDir, stackName+".json"))
+		Expect(err).NotTo(HaveOccurred())
+		return template
+	}
+
+	It("writes a template for each stack without calling the AWS API", func() {
+		files, err := render.New(cfg, outputDir).Render(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(ConsistOf(
+			filepath.Join(outputDir, "eksctl-offline-cluster.json"),
+			filepath.Join(outputDir, "eksctl-offline-nodegroup-unmanaged.json"),
+			filepath.Join(outputDir, "eksctl-offline-nodegroup-managed.json"),
+			filepath.Join(outputDir, "eksctl-offline-addon-iamserviceaccount-default-app.json"),
+		))
+
+		clusterTemplate := readTemplate("eksctl-offline-cluster")
+		Expect(gjson.GetBytes(clusterTemplate, "Resources.ControlPlane.Type").String()).To(Equal("AWS::EKS::Cluster"))
+
+		nodeGroupTemplate := readTemplate("eksctl-offline-nodegroup-unmanaged")
+		Expect(gjson.GetBytes(nodeGroupTemplate, "Resources.NodeGroupLaunchTemplate.Properties.LaunchTemplateData.ImageId").String()).
+			To(HavePrefix("{{resolve:ssm:/aws/service/eks/optimized-ami/"))
+
+		serviceAccountTemplate := readTemplate("eksctl-offline-addon-iamserviceaccount-default-app")
+		Expect(string(serviceAccountTemplate)).To(ContainSubstring("arn:aws:iam::ACCOUNT_ID:oidc-provider/oidc.eks.us-west-2.amazonaws.com/id/OIDC_ISSUER_ID"))
+	})
+
+	It("requires the availability zones to be set", func() {
+		cfg.AvailabilityZones = nil
+		_, err := render.New(cfg, outputDir).Render(context.Background())
+		Expect(err).To(MatchError("at least 2 availabilityZones must be set when rendering templates offline"))
+	})
+
+	It("fails configurations that depend on existing AWS resources", func() {
+		cfg.VPC.ID = "vpc-1234"
+		_, err := render.New(cfg, outputDir).Render(context.Background())
+		Expect(err).To(MatchError(ContainSubstring("only supported for clusters with a dedicated VPC")))
+	})
+
+	It("fails when the configuration requires an AWS API call", func() {
+		cfg.PrivateCluster = &api.PrivateCluster{Enabled: true}
+		_, err := render.New(cfg, outputDir).Render(context.Background())
+		Expect(err).To(MatchError(ContainSubstring(render.ErrOffline.Error())))
+	})
+})
@@ -0,0 +1,290 @@
+package securityscan
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/iam"
+)
+
+// Severity is the severity of a failed check
+type Severity string
+
+const (
+	SeverityHigh   Severity = "high"
+	SeverityMedium Severity = "medium"
+	SeverityLow    Severity = "low"
+)
+
+// weight is the share of the score of a check of the severity
+func (s Severity) weight() int {
+	switch s {
+	case SeverityHigh:
+		return 10
+	case SeverityMedium:
+		return 5
+	default:
+		return 2
+	}
+}
+
+// Status is the outcome of a check
+type Status string
+
+const (
+	StatusPass Status = "PASS"
+	StatusFail Status = "FAIL"
+	// StatusUnknown is reported when the check could not be run, e.g. for lack of permissions
+	StatusUnknown Status = "UNKNOWN"
+)
+
+// IDs of the checks
+const (
+	CheckPublicEndpoint      = "public-endpoint"
+	CheckIMDSv1              = "imdsv1"
+	CheckSecretsEncryption   = "secrets-encryption"
+	CheckOutdatedAddons      = "outdated-addons"
+	CheckNodePublicIPs       = "node-public-ips"
+	CheckBroadAWSAuthMapping = "broad-aws-auth-mappings"
+)
+
+const systemMasters = "system:masters"
+
+// Finding is the outcome of a check
+type Finding struct {
+	Check       string   `json:"check"`
+	Description string   `json:"description"`
+	Severity    Severity `json:"severity"`
+	Status      Status   `json:"status"`
+	// Resources are the resources failing the check
+	Resources []string `json:"resources,omitempty"`
+	// Message explains why the check failed or could not be run
+	Message string `json:"message,omitempty"`
+}
+
+// Report is the outcome of the checks of a cluster
+type Report struct {
+	ClusterName string `json:"clusterName"`
+	// Score is the percentage of the checks that passed, weighted by severity; checks that could not be run are
+	// not counted
+	Score    int       `json:"score"`
+	Findings []Finding `json:"findings"`
+}
+
+// AddonGetter returns the addons of a cluster along with their newer versions
+type AddonGetter interface {
+	GetAll(ctx context.Context) ([]addon.Summary, error)
+}
+
+// Scanner runs the built-in security checks against a cluster
+type Scanner struct {
+	ClusterName string
+	// Cluster is the description of the cluster
+	Cluster   *ekstypes.Cluster
+	ClientSet kubernetes.Interface
+	EC2       awsapi.EC2
+	Addons    AddonGetter
+}
+
+type check struct {
+	id          string
+	description string
+	severity    Severity
+	// run returns the resources failing the check
+	run func(ctx context.Context) ([]string, error)
+}
+
+// Scan runs all the checks and scores their outcome
+func (s *Scanner) Scan(ctx context.Context) *Report {
+	instances := &nodeInstances{scanner: s}
+	checks := []check{
+		{CheckPublicEndpoint, "the API server endpoint is not open to 0.0.0.0/0", SeverityHigh, s.checkPublicEndpoint},
+		{CheckSecretsEncryption, "secrets are envelope encrypted with a KMS key", SeverityHigh, s.checkSecretsEncryption},
+		{CheckBroadAWSAuthMapping, "aws-auth does not map IAM identities to system:masters or whole accounts", SeverityHigh, s.checkAWSAuthMappings},
+		{CheckIMDSv1, "nodes require IMDSv2", SeverityMedium, instances.checkIMDSv1},
+		{CheckNodePublicIPs, "nodes have no public IP address", SeverityMedium, instances.checkPublicIPs},
+		{CheckOutdatedAddons, "addons run their latest version", SeverityLow, s.checkOutdatedAddons},
+	}
+
+	report := &Report{ClusterName: s.ClusterName}
+	var total, passed int
+	for _, c := range checks {
+		finding := Finding{
+			Check:       c.id,
+			Description: c.description,
+			Severity:    c.severity,
+		}
+		resources, err := c.run(ctx)
+		switch {
+		case err != nil:
+			finding.Status = StatusUnknown
+			finding.Message = err.Error()
+		case len(resources) > 0:
+			finding.Status = StatusFail
+			finding.Resources = resources
+			total += c.severity.weight()
+		default:
+			finding.Status = StatusPass
+			total += c.severity.weight()
+			passed += c.severity.weight()
+		}
+		report.Findings = append(report.Findings, finding)
+	}
+	if total > 0 {
+		report.Score = passed * 100 / total
+	}
+	return report
+}
+
+func (s *Scanner) checkPublicEndpoint(_ context.Context) ([]string, error) {
+	vpcConfig := s.Cluster.ResourcesVpcConfig
+	if vpcConfig == nil || !vpcConfig.EndpointPublicAccess {
+		return nil, nil
+	}
+	if len(vpcConfig.PublicAccessCidrs) == 0 || slices.Contains(vpcConfig.PublicAccessCidrs, "0.0.0.0/0") {
+		return []string{fmt.Sprintf("cluster/%s", s.ClusterName)}, nil
+	}
+	return nil, nil
+}
+
+func (s *Scanner) checkSecretsEncryption(_ context.Context) ([]string, error) {
+	for _, config := range s.Cluster.EncryptionConfig {
+		if slices.Contains(config.Resources, "secrets") {
+			return nil, nil
+		}
+	}
+	return []string{fmt.Sprintf("cluster/%s", s.ClusterName)}, nil
+}
+
+func (s *Scanner) checkAWSAuthMappings(_ context.Context) ([]string, error) {
+	if s.Cluster.AccessConfig != nil && s.Cluster.AccessConfig.AuthenticationMode == ekstypes.AuthenticationModeApi {
+		return nil, nil
+	}
+	acm, err := authconfigmap.NewFromClientSet(s.ClientSet)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := acm.GetIdentities()
+	if err != nil {
+		return nil, err
+	}
+	var resources []string
+	for _, identity := range identities {
+		switch {
+		case identity.Type() == iam.ResourceTypeAccount:
+			resources = append(resources, fmt.Sprintf("account/%s", identity.Account()))
+		case slices.Contains(identity.Groups(), systemMasters):
+			resources = append(resources, identity.ARN())
+		}
+	}
+	return resources, nil
+}
+
+func (s *Scanner) checkOutdatedAddons(ctx context.Context) ([]string, error) {
+	summaries, err := s.Addons.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resources []string
+	for _, summary := range summaries {
+		if summary.NewerVersion != "" {
+			resources = append(resources, fmt.Sprintf("addon/%s@%s", summary.Name, summary.Version))
+		}
+	}
+	return resources, nil
+}
+
+// nodeInstances looks up the EC2 instances of the nodes of the cluster once for the checks that need them
+type nodeInstances struct {
+	scanner   *Scanner
+	instances []ec2types.Instance
+	err       error
+	done      bool
+}
+
+// describeInstancesBatchSize is the number of instance IDs described per call
+const describeInstancesBatchSize = 200
+
+func (n *nodeInstances) get(ctx context.Context) ([]ec2types.Instance, error) {
+	if n.done {
+		return n.instances, n.err
+	}
+	n.done = true
+	n.instances, n.err = n.describe(ctx)
+	return n.instances, n.err
+}
+
+func (n *nodeInstances) describe(ctx context.Context) ([]ec2types.Instance, error) {
+	nodes, err := n.scanner.ClientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing nodes: %w", err)
+	}
+	var instanceIDs []string
+	for _, node := range nodes.Items {
+		// the provider ID of EC2 nodes is aws:///<zone>/<instance ID>, Fargate nodes are skipped
+		id := node.Spec.ProviderID[strings.LastIndex(node.Spec.ProviderID, "/")+1:]
+		if strings.HasPrefix(id, "i-") {
+			instanceIDs = append(instanceIDs, id)
+		}
+	}
+
+	var instances []ec2types.Instance
+	for start := 0; start < len(instanceIDs); start += describeInstancesBatchSize {
+		end := min(start+describeInstancesBatchSize, len(instanceIDs))
+		output, err := n.scanner.EC2.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+			InstanceIds: instanceIDs[start:end],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describing the instances of the nodes: %w", err)
+		}
+		for _, reservation := range output.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return aws.ToString(instances[i].InstanceId) < aws.ToString(instances[j].InstanceId)
+	})
+	return instances, nil
+}
+
+func (n *nodeInstances) checkIMDSv1(ctx context.Context) ([]string, error) {
+	instances, err := n.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resources []string
+	for _, instance := range instances {
+		if instance.MetadataOptions != nil && instance.MetadataOptions.HttpTokens == ec2types.HttpTokensStateOptional &&
+			instance.MetadataOptions.HttpEndpoint != ec2types.InstanceMetadataEndpointStateDisabled {
+			resources = append(resources, fmt.Sprintf("instance/%s", aws.ToString(instance.InstanceId)))
+		}
+	}
+	return resources, nil
+}
+
+func (n *nodeInstances) checkPublicIPs(ctx context.Context) ([]string, error) {
+	instances, err := n.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resources []string
+	for _, instance := range instances {
+		if instance.PublicIpAddress != nil {
+			resources = append(resources, fmt.Sprintf("instance/%s (%s)", aws.ToString(instance.InstanceId), *instance.PublicIpAddress))
+		}
+	}
+	return resources, nil
+}
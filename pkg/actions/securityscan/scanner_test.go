@@ -0,0 +1,180 @@
+package securityscan_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/securityscan"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+type fakeAddons struct {
+	summaries []addon.Summary
+	err       error
+}
+
+func (f fakeAddons) GetAll(_ context.Context) ([]addon.Summary, error) {
+	return f.summaries, f.err
+}
+
+var _ = Describe("Security scan", func() {
+	var (
+		provider *mockprovider.MockProvider
+		scanner  *securityscan.Scanner
+	)
+
+	newNode := func(name, providerID string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{ProviderID: providerID},
+		}
+	}
+
+	findings := func(report *securityscan.Report) map[string]securityscan.Finding {
+		byCheck := map[string]securityscan.Finding{}
+		for _, f := range report.Findings {
+			byCheck[f.Check] = f
+		}
+		return byCheck
+	}
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		scanner = &securityscan.Scanner{
+			ClusterName: "my-cluster",
+			Cluster: &ekstypes.Cluster{
+				ResourcesVpcConfig: &ekstypes.VpcConfigResponse{
+					EndpointPublicAccess: true,
+					PublicAccessCidrs:    []string{"203.0.113.0/24"},
+				},
+				EncryptionConfig: []ekstypes.EncryptionConfig{
+					{Resources: []string{"secrets"}},
+				},
+			},
+			ClientSet: fake.NewSimpleClientset(
+				newNode("node-1", "aws:///us-west-2a/i-1"),
+				newNode("fargate-node", "aws:///us-west-2a/a1b2c3/fargate-ip-10-0-0-1"),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: authconfigmap.ObjectName, Namespace: authconfigmap.ObjectNamespace},
+					Data: map[string]string{
+						"mapRoles": `- rolearn: arn:aws:iam::123456789012:role/node
+  username: system:node:{{EC2PrivateDNSName}}
+  groups:
+  - system:bootstrappers
+  - system:nodes
+`,
+					},
+				},
+			),
+			EC2:    provider.EC2(),
+			Addons: fakeAddons{summaries: []addon.Summary{{Name: "vpc-cni", Version: "v1.18.0"}}},
+		}
+		provider.MockEC2().On("DescribeInstances", mock.Anything, &ec2.DescribeInstancesInput{
+			InstanceIds: []string{"i-1"},
+		}).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{
+				{
+					Instances: []ec2types.Instance{
+						{
+							InstanceId:      aws.String("i-1"),
+							MetadataOptions: &ec2types.InstanceMetadataOptionsResponse{HttpTokens: ec2types.HttpTokensStateRequired},
+						},
+					},
+				},
+			},
+		}, nil)
+	})
+
+	It("scores a cluster passing every check 100", func() {
+		report := scanner.Scan(context.Background())
+		Expect(report.Findings).To(HaveLen(6))
+		for _, f := range report.Findings {
+			Expect(f.Status).To(Equal(securityscan.StatusPass), f.Check)
+		}
+		Expect(report.Score).To(Equal(100))
+	})
+
+	It("reports the resources failing each check", func() {
+		scanner.Cluster.ResourcesVpcConfig.PublicAccessCidrs = []string{"0.0.0.0/0"}
+		scanner.Cluster.EncryptionConfig = nil
+		scanner.Addons = fakeAddons{summaries: []addon.Summary{
+			{Name: "vpc-cni", Version: "v1.18.0", NewerVersion: "v1.18.1"},
+			{Name: "coredns", Version: "v1.11.1"},
+		}}
+		cm, err := scanner.ClientSet.CoreV1().ConfigMaps(authconfigmap.ObjectNamespace).Get(context.Background(), authconfigmap.ObjectName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		cm.Data["mapRoles"] += `- rolearn: arn:aws:iam::123456789012:role/admin
+  username: admin
+  groups:
+  - system:masters
+`
+		cm.Data["mapAccounts"] = "- \"123456789012\"\n"
+		_, err = scanner.ClientSet.CoreV1().ConfigMaps(authconfigmap.ObjectNamespace).Update(context.Background(), cm, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		provider = mockprovider.NewMockProvider()
+		scanner.EC2 = provider.EC2()
+		provider.MockEC2().On("DescribeInstances", mock.Anything, mock.Anything).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []ec2types.Reservation{
+				{
+					Instances: []ec2types.Instance{
+						{
+							InstanceId:      aws.String("i-1"),
+							PublicIpAddress: aws.String("198.51.100.1"),
+							MetadataOptions: &ec2types.InstanceMetadataOptionsResponse{HttpTokens: ec2types.HttpTokensStateOptional},
+						},
+					},
+				},
+			},
+		}, nil)
+
+		report := scanner.Scan(context.Background())
+		byCheck := findings(report)
+		Expect(byCheck[securityscan.CheckPublicEndpoint].Resources).To(ConsistOf("cluster/my-cluster"))
+		Expect(byCheck[securityscan.CheckSecretsEncryption].Resources).To(ConsistOf("cluster/my-cluster"))
+		Expect(byCheck[securityscan.CheckBroadAWSAuthMapping].Resources).To(ConsistOf(
+			"arn:aws:iam::123456789012:role/admin",
+			"account/123456789012",
+		))
+		Expect(byCheck[securityscan.CheckIMDSv1].Resources).To(ConsistOf("instance/i-1"))
+		Expect(byCheck[securityscan.CheckNodePublicIPs].Resources).To(ConsistOf("instance/i-1 (198.51.100.1)"))
+		Expect(byCheck[securityscan.CheckOutdatedAddons].Resources).To(ConsistOf("addon/vpc-cni@v1.18.0"))
+		for _, f := range report.Findings {
+			Expect(f.Status).To(Equal(securityscan.StatusFail), f.Check)
+		}
+		Expect(report.Score).To(Equal(0))
+	})
+
+	It("does not score checks that cannot be run", func() {
+		scanner.Cluster.EncryptionConfig = nil
+		scanner.Addons = fakeAddons{err: errors.New("access denied")}
+
+		report := scanner.Scan(context.Background())
+		byCheck := findings(report)
+		Expect(byCheck[securityscan.CheckOutdatedAddons].Status).To(Equal(securityscan.StatusUnknown))
+		Expect(byCheck[securityscan.CheckOutdatedAddons].Message).To(Equal("access denied"))
+		// secrets-encryption fails with a weight of 10 out of 40
+		Expect(report.Score).To(Equal(75))
+	})
+
+	It("skips aws-auth when the cluster only uses access entries", func() {
+		scanner.Cluster.AccessConfig = &ekstypes.AccessConfigResponse{AuthenticationMode: ekstypes.AuthenticationModeApi}
+		Expect(scanner.ClientSet.CoreV1().ConfigMaps(authconfigmap.ObjectNamespace).Delete(context.Background(), authconfigmap.ObjectName, metav1.DeleteOptions{})).To(Succeed())
+
+		report := scanner.Scan(context.Background())
+		Expect(findings(report)[securityscan.CheckBroadAWSAuthMapping].Status).To(Equal(securityscan.StatusPass))
+	})
+})
@@ -0,0 +1,13 @@
+package securityscan_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSecurityScan(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Security Scan Suite")
+}
@@ -0,0 +1,10 @@
+package snapshot
+
+import "github.com/aws/aws-sdk-go-v2/aws"
+
+// SetS3Client replaces the function returning the S3 client of a region, and returns the previous one
+func SetS3Client(newClient func(awsConfig aws.Config, region string) S3API) func(awsConfig aws.Config, region string) S3API {
+	previous := newS3Client
+	newS3Client = newClient
+	return previous
+}
@@ -0,0 +1,449 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+)
+
+// Version is the version of the snapshot format written by Take.
+// It must be bumped whenever a change to Snapshot is not backwards compatible.
+const Version = 1
+
+// Snapshot is a point-in-time archive of a cluster, from which an equivalent cluster can be created
+type Snapshot struct {
+	Version     int       `json:"version"`
+	ClusterName string    `json:"clusterName"`
+	Region      string    `json:"region"`
+	Account     string    `json:"account,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	// ClusterConfig describes the cluster, its nodegroups, addons and access entries, leaving out the resources
+	// that belong to its account and region, e.g. its VPC, KMS keys and IAM roles
+	ClusterConfig *api.ClusterConfig `json:"clusterConfig"`
+	// StackTemplates are the templates of the CloudFormation stacks of the cluster, by stack name
+	StackTemplates map[string]string `json:"stackTemplates,omitempty"`
+	// Auth holds the aws-auth ConfigMap and the access entries of the cluster
+	Auth *accessentryactions.AuthBackup `json:"auth,omitempty"`
+}
+
+// NodeGroupLister lists the nodegroups of a cluster
+type NodeGroupLister interface {
+	GetAll(ctx context.Context) ([]*nodegroup.Summary, error)
+}
+
+// AuthBackuper reads the aws-auth ConfigMap and access entries of a cluster
+type AuthBackuper interface {
+	BackupAuth(ctx context.Context) (*accessentryactions.AuthBackup, error)
+}
+
+// Snapshotter takes snapshots of a cluster
+type Snapshotter struct {
+	ClusterName  string
+	Region       string
+	EKS          awsapi.EKS
+	StackManager manager.StackManager
+	NodeGroups   NodeGroupLister
+	Auth         AuthBackuper
+	// ClientSet reads the labels, taints and OS image of the nodes of unmanaged nodegroups,
+	// which are set in their user data rather than returned by the EKS API
+	ClientSet kubernetes.Interface
+}
+
+// Take returns a snapshot of the cluster
+func (s *Snapshotter) Take(ctx context.Context) (*Snapshot, error) {
+	output, err := s.EKS.DescribeCluster(ctx, &awseks.DescribeClusterInput{
+		Name: aws.String(s.ClusterName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing cluster %q: %w", s.ClusterName, err)
+	}
+	snapshot := &Snapshot{
+		Version:       Version,
+		ClusterName:   s.ClusterName,
+		Region:        s.Region,
+		CreatedAt:     time.Now().UTC(),
+		ClusterConfig: exportCluster(output.Cluster, s.Region),
+	}
+	if clusterARN, err := arn.Parse(aws.ToString(output.Cluster.Arn)); err == nil {
+		snapshot.Account = clusterARN.AccountID
+	}
+
+	if snapshot.Auth, err = s.Auth.BackupAuth(ctx); err != nil {
+		return nil, err
+	}
+	snapshot.ClusterConfig.AccessConfig.AccessEntries = exportAccessEntries(snapshot.Auth.AccessEntries)
+	if err := s.exportNodeGroups(ctx, snapshot.ClusterConfig); err != nil {
+		return nil, err
+	}
+	if err := s.exportAddons(ctx, snapshot.ClusterConfig); err != nil {
+		return nil, err
+	}
+	if snapshot.StackTemplates, err = s.stackTemplates(ctx); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// ClusterConfigFor returns the ClusterConfig of the snapshot for a cluster named name in region of account,
+// keeping the name and region of the snapshotted cluster when they are empty.
+// The access entries of IAM principals in the account of the snapshotted cluster are moved to account,
+// as the same principals don't exist in another account.
+func (s *Snapshot) ClusterConfigFor(name, region, account string) *api.ClusterConfig {
+	cfg := s.ClusterConfig.DeepCopy()
+	if name != "" {
+		cfg.Metadata.Name = name
+	}
+	if region != "" {
+		cfg.Metadata.Region = region
+	}
+	if account != "" && s.Account != "" && account != s.Account && cfg.AccessConfig != nil {
+		for i, accessEntry := range cfg.AccessConfig.AccessEntries {
+			if accessEntry.PrincipalARN.AccountID != s.Account {
+				continue
+			}
+			accessEntry.PrincipalARN.AccountID = account
+			logger.Info("moving the access entry of %q from account %s to account %s",
+				accessEntry.PrincipalARN.Resource, s.Account, account)
+			cfg.AccessConfig.AccessEntries[i] = accessEntry
+		}
+	}
+	return cfg
+}
+
+// Marshal serializes a snapshot to YAML
+func Marshal(snapshot *Snapshot) ([]byte, error) {
+	return yaml.Marshal(snapshot)
+}
+
+// Unmarshal parses a snapshot written by Marshal
+func Unmarshal(data []byte) (*Snapshot, error) {
+	var snapshot Snapshot
+	if err := yaml.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("parsing cluster snapshot: %w", err)
+	}
+	if snapshot.Version != Version {
+		return nil, fmt.Errorf("unsupported cluster snapshot version %d, expected version %d", snapshot.Version, Version)
+	}
+	if snapshot.ClusterConfig == nil || snapshot.ClusterConfig.Metadata == nil {
+		return nil, fmt.Errorf("cluster snapshot does not contain a ClusterConfig")
+	}
+	return &snapshot, nil
+}
+
+func exportCluster(cluster *ekstypes.Cluster, region string) *api.ClusterConfig {
+	cfg := &api.ClusterConfig{
+		TypeMeta: api.ClusterConfigTypeMeta(),
+		Metadata: &api.ClusterMeta{
+			Name:    aws.ToString(cluster.Name),
+			Region:  region,
+			Version: aws.ToString(cluster.Version),
+			Tags:    userTags(cluster.Tags),
+		},
+		AccessConfig: &api.AccessConfig{},
+	}
+
+	if vpcConfig := cluster.ResourcesVpcConfig; vpcConfig != nil {
+		cfg.VPC = &api.ClusterVPC{
+			ClusterEndpoints: &api.ClusterEndpoints{
+				PrivateAccess: aws.Bool(vpcConfig.EndpointPrivateAccess),
+				PublicAccess:  aws.Bool(vpcConfig.EndpointPublicAccess),
+			},
+		}
+		if !(len(vpcConfig.PublicAccessCidrs) == 1 && vpcConfig.PublicAccessCidrs[0] == "0.0.0.0/0") {
+			cfg.VPC.PublicAccessCIDRs = vpcConfig.PublicAccessCidrs
+		}
+	}
+	if networkConfig := cluster.KubernetesNetworkConfig; networkConfig != nil {
+		cfg.KubernetesNetworkConfig = &api.KubernetesNetworkConfig{
+			IPFamily:        api.IPV4Family,
+			ServiceIPv4CIDR: aws.ToString(networkConfig.ServiceIpv4Cidr),
+		}
+		if networkConfig.IpFamily == ekstypes.IpFamilyIpv6 {
+			cfg.KubernetesNetworkConfig.IPFamily = api.IPV6Family
+		}
+	}
+	if cluster.Logging != nil {
+		var enableTypes []string
+		for _, setup := range cluster.Logging.ClusterLogging {
+			if aws.ToBool(setup.Enabled) {
+				for _, logType := range setup.Types {
+					enableTypes = append(enableTypes, string(logType))
+				}
+			}
+		}
+		if len(enableTypes) > 0 {
+			cfg.CloudWatch = &api.ClusterCloudWatch{
+				ClusterLogging: &api.ClusterCloudWatchLogging{EnableTypes: enableTypes},
+			}
+		}
+	}
+	if cluster.AccessConfig != nil {
+		cfg.AccessConfig.AuthenticationMode = cluster.AccessConfig.AuthenticationMode
+	}
+	if len(cluster.EncryptionConfig) > 0 {
+		logger.Warning("the KMS key encrypting the secrets of cluster %q is not part of the snapshot, set secretsEncryption when creating a cluster from it", aws.ToString(cluster.Name))
+	}
+	return cfg
+}
+
+// userTags drops the tags set by AWS and eksctl, which are set again when a cluster is created from the snapshot
+func userTags(tags map[string]string) map[string]string {
+	result := map[string]string{}
+	for k, v := range tags {
+		if strings.HasPrefix(k, "aws:") || strings.HasPrefix(k, "alpha.eksctl.io/") || strings.HasPrefix(k, "eksctl.cluster.k8s.io/") {
+			continue
+		}
+		result[k] = v
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// exportAccessEntries returns the access entries of IAM users and roles, leaving out those of nodes,
+// which are created along with the nodegroups
+func exportAccessEntries(summaries []accessentryactions.Summary) []api.AccessEntry {
+	var accessEntries []api.AccessEntry
+	for _, s := range summaries {
+		if s.Type != "" && s.Type != "STANDARD" {
+			continue
+		}
+		principalARN, err := arn.Parse(s.PrincipalARN)
+		if err != nil {
+			logger.Warning("skipping access entry with invalid principal ARN %q: %v", s.PrincipalARN, err)
+			continue
+		}
+		accessEntries = append(accessEntries, api.AccessEntry{
+			PrincipalARN:       api.ARN(principalARN),
+			KubernetesGroups:   s.KubernetesGroups,
+			KubernetesUsername: s.Username,
+			AccessPolicies:     s.AccessPolicies,
+		})
+	}
+	return accessEntries
+}
+
+func (s *Snapshotter) exportNodeGroups(ctx context.Context, cfg *api.ClusterConfig) error {
+	summaries, err := s.NodeGroups.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("listing nodegroups: %w", err)
+	}
+	for _, summary := range summaries {
+		if summary.NodeGroupType != api.NodeGroupTypeManaged {
+			ng := &api.NodeGroup{
+				NodeGroupBase: &api.NodeGroupBase{
+					Name:          summary.Name,
+					InstanceType:  summary.InstanceType,
+					ScalingConfig: exportScalingConfig(summary.DesiredCapacity, summary.MinSize, summary.MaxSize),
+				},
+			}
+			if err := s.exportNodeSettings(ctx, ng); err != nil {
+				return err
+			}
+			cfg.NodeGroups = append(cfg.NodeGroups, ng)
+			continue
+		}
+
+		output, err := s.EKS.DescribeNodegroup(ctx, &awseks.DescribeNodegroupInput{
+			ClusterName:   aws.String(s.ClusterName),
+			NodegroupName: aws.String(summary.Name),
+		})
+		if err != nil {
+			return fmt.Errorf("describing nodegroup %q: %w", summary.Name, err)
+		}
+		cfg.ManagedNodeGroups = append(cfg.ManagedNodeGroups, exportManagedNodeGroup(output.Nodegroup))
+	}
+	return nil
+}
+
+// exportNodeSettings reads the AMI family, labels and taints of an unmanaged nodegroup from one of its nodes
+func (s *Snapshotter) exportNodeSettings(ctx context.Context, ng *api.NodeGroup) error {
+	nodes, err := s.ClientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", api.NodeGroupNameLabel, ng.Name),
+	})
+	if err != nil {
+		return fmt.Errorf("listing nodes of nodegroup %q: %w", ng.Name, err)
+	}
+	if len(nodes.Items) == 0 {
+		logger.Warning("nodegroup %q has no nodes, its AMI family, labels and taints are not part of the snapshot", ng.Name)
+		return nil
+	}
+	node := nodes.Items[0]
+
+	if ng.AMIFamily = nodeAMIFamily(node.Status.NodeInfo.OSImage); ng.AMIFamily == "" {
+		logger.Warning("the AMI family of nodegroup %q running %q is not part of the snapshot", ng.Name, node.Status.NodeInfo.OSImage)
+	}
+	for key, value := range node.Labels {
+		if !isSystemKey(key) {
+			if ng.Labels == nil {
+				ng.Labels = map[string]string{}
+			}
+			ng.Labels[key] = value
+		}
+	}
+	for _, taint := range node.Spec.Taints {
+		if !isSystemKey(taint.Key) && !strings.HasSuffix(taint.Key, "ByClusterAutoscaler") {
+			ng.Taints = append(ng.Taints, api.NodeGroupTaint{
+				Key:    taint.Key,
+				Value:  taint.Value,
+				Effect: taint.Effect,
+			})
+		}
+	}
+	return nil
+}
+
+// nodeAMIFamily returns the AMI family of the OS image reported by a node
+func nodeAMIFamily(osImage string) string {
+	switch {
+	case strings.HasPrefix(osImage, "Amazon Linux 2023"):
+		return api.NodeImageFamilyAmazonLinux2023
+	case strings.HasPrefix(osImage, "Amazon Linux 2"):
+		return api.NodeImageFamilyAmazonLinux2
+	case strings.HasPrefix(osImage, "Bottlerocket"):
+		return api.NodeImageFamilyBottlerocket
+	case strings.HasPrefix(osImage, "Ubuntu 22.04"):
+		return api.NodeImageFamilyUbuntu2204
+	case strings.HasPrefix(osImage, "Ubuntu 20.04"):
+		return api.NodeImageFamilyUbuntu2004
+	default:
+		return ""
+	}
+}
+
+// isSystemKey reports whether a label or taint key is set by Kubernetes, AWS or eksctl rather than by the user
+func isSystemKey(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	for _, domain := range []string{"kubernetes.io", "k8s.io", "amazonaws.com", "eksctl.io"} {
+		if prefix == domain || strings.HasSuffix(prefix, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func exportManagedNodeGroup(ng *ekstypes.Nodegroup) *api.ManagedNodeGroup {
+	mng := &api.ManagedNodeGroup{
+		NodeGroupBase: &api.NodeGroupBase{
+			Name:      aws.ToString(ng.NodegroupName),
+			Labels:    ng.Labels,
+			AMIFamily: amiFamily(ng.AmiType),
+		},
+		InstanceTypes: ng.InstanceTypes,
+		Spot:          ng.CapacityType == ekstypes.CapacityTypesSpot,
+	}
+	if ng.DiskSize != nil {
+		mng.VolumeSize = aws.Int(int(*ng.DiskSize))
+	}
+	if sc := ng.ScalingConfig; sc != nil {
+		mng.ScalingConfig = exportScalingConfig(int(aws.ToInt32(sc.DesiredSize)), int(aws.ToInt32(sc.MinSize)), int(aws.ToInt32(sc.MaxSize)))
+	}
+	for _, taint := range ng.Taints {
+		mng.Taints = append(mng.Taints, api.NodeGroupTaint{
+			Key:    aws.ToString(taint.Key),
+			Value:  aws.ToString(taint.Value),
+			Effect: taintEffect(taint.Effect),
+		})
+	}
+	if ng.LaunchTemplate != nil {
+		logger.Warning("the launch template of nodegroup %q is not part of the snapshot", mng.Name)
+	}
+	return mng
+}
+
+func exportScalingConfig(desiredCapacity, minSize, maxSize int) *api.ScalingConfig {
+	return &api.ScalingConfig{
+		DesiredCapacity: aws.Int(desiredCapacity),
+		MinSize:         aws.Int(minSize),
+		MaxSize:         aws.Int(maxSize),
+	}
+}
+
+func amiFamily(amiType ekstypes.AMITypes) string {
+	switch t := string(amiType); {
+	case strings.HasPrefix(t, "AL2023_"):
+		return api.NodeImageFamilyAmazonLinux2023
+	case strings.HasPrefix(t, "AL2_"):
+		return api.NodeImageFamilyAmazonLinux2
+	case strings.HasPrefix(t, "BOTTLEROCKET_"):
+		return api.NodeImageFamilyBottlerocket
+	default:
+		return ""
+	}
+}
+
+func taintEffect(effect ekstypes.TaintEffect) corev1.TaintEffect {
+	switch effect {
+	case ekstypes.TaintEffectNoExecute:
+		return corev1.TaintEffectNoExecute
+	case ekstypes.TaintEffectPreferNoSchedule:
+		return corev1.TaintEffectPreferNoSchedule
+	default:
+		return corev1.TaintEffectNoSchedule
+	}
+}
+
+func (s *Snapshotter) exportAddons(ctx context.Context, cfg *api.ClusterConfig) error {
+	paginator := awseks.NewListAddonsPaginator(s.EKS, &awseks.ListAddonsInput{
+		ClusterName: aws.String(s.ClusterName),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("listing addons: %w", err)
+		}
+		for _, name := range output.Addons {
+			addon, err := s.EKS.DescribeAddon(ctx, &awseks.DescribeAddonInput{
+				ClusterName: aws.String(s.ClusterName),
+				AddonName:   aws.String(name),
+			})
+			if err != nil {
+				return fmt.Errorf("describing addon %q: %w", name, err)
+			}
+			cfg.Addons = append(cfg.Addons, &api.Addon{
+				Name:                name,
+				Version:             aws.ToString(addon.Addon.AddonVersion),
+				ConfigurationValues: aws.ToString(addon.Addon.ConfigurationValues),
+			})
+		}
+	}
+	return nil
+}
+
+func (s *Snapshotter) stackTemplates(ctx context.Context) (map[string]string, error) {
+	stacks, err := s.StackManager.ListStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	templates := map[string]string{}
+	for _, stack := range stacks {
+		stackName := aws.ToString(stack.StackName)
+		template, err := s.StackManager.GetStackTemplate(ctx, stackName)
+		if err != nil {
+			return nil, fmt.Errorf("getting template of stack %q: %w", stackName, err)
+		}
+		templates[stackName] = template
+	}
+	return templates, nil
+}
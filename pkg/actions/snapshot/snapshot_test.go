@@ -0,0 +1,221 @@
+package snapshot_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	"github.com/weaveworks/eksctl/pkg/actions/snapshot"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+type fakeNodeGroupLister []*nodegroup.Summary
+
+func (f fakeNodeGroupLister) GetAll(_ context.Context) ([]*nodegroup.Summary, error) {
+	return f, nil
+}
+
+type fakeAuthBackuper accessentryactions.AuthBackup
+
+func (f *fakeAuthBackuper) BackupAuth(_ context.Context) (*accessentryactions.AuthBackup, error) {
+	backup := accessentryactions.AuthBackup(*f)
+	return &backup, nil
+}
+
+var _ = Describe("Cluster snapshot", func() {
+	const clusterName = "my-cluster"
+
+	var (
+		provider     *mockprovider.MockProvider
+		stackManager *fakes.FakeStackManager
+		snapshotter  *snapshot.Snapshotter
+	)
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		stackManager = &fakes.FakeStackManager{}
+		snapshotter = &snapshot.Snapshotter{
+			ClusterName:  clusterName,
+			Region:       "us-west-2",
+			EKS:          provider.EKS(),
+			StackManager: stackManager,
+			NodeGroups: fakeNodeGroupLister{
+				{Name: "ng-1", InstanceType: "m5.large", DesiredCapacity: 2, MinSize: 1, MaxSize: 3, NodeGroupType: api.NodeGroupTypeUnmanaged},
+				{Name: "mng-1", NodeGroupType: api.NodeGroupTypeManaged},
+			},
+			Auth: &fakeAuthBackuper{
+				Version:       accessentryactions.AuthBackupVersion,
+				ClusterName:   clusterName,
+				AuthConfigMap: map[string]string{"mapRoles": "[]"},
+				AccessEntries: []accessentryactions.Summary{
+					{PrincipalARN: "arn:aws:iam::111122223333:role/viewer", Type: "STANDARD", KubernetesGroups: []string{"viewers"}},
+					{PrincipalARN: "arn:aws:iam::111122223333:role/node", Type: "EC2_LINUX"},
+				},
+			},
+		}
+
+		snapshotter.ClientSet = fake.NewSimpleClientset(&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ip-192-168-1-1.us-west-2.compute.internal",
+				Labels: map[string]string{
+					api.NodeGroupNameLabel:   "ng-1",
+					"kubernetes.io/hostname": "ip-192-168-1-1",
+					"role":                   "batch",
+				},
+			},
+			Spec: corev1.NodeSpec{
+				Taints: []corev1.Taint{
+					{Key: "dedicated", Value: "batch", Effect: corev1.TaintEffectNoExecute},
+					{Key: "node.kubernetes.io/not-ready", Effect: corev1.TaintEffectNoSchedule},
+				},
+			},
+			Status: corev1.NodeStatus{
+				NodeInfo: corev1.NodeSystemInfo{OSImage: "Amazon Linux 2023.5.20240701"},
+			},
+		})
+		provider.MockEKS().On("DescribeCluster", mock.Anything, &awseks.DescribeClusterInput{Name: aws.String(clusterName)}).Return(&awseks.DescribeClusterOutput{
+			Cluster: &ekstypes.Cluster{
+				Name:    aws.String(clusterName),
+				Arn:     aws.String("arn:aws:eks:us-west-2:111122223333:cluster/my-cluster"),
+				Version: aws.String("1.30"),
+				Tags: map[string]string{
+					"team":                 "platform",
+					api.ClusterNameTag:     clusterName,
+					"aws:cloudformation:x": "y",
+				},
+				ResourcesVpcConfig: &ekstypes.VpcConfigResponse{
+					EndpointPrivateAccess: true,
+					EndpointPublicAccess:  true,
+					PublicAccessCidrs:     []string{"0.0.0.0/0"},
+				},
+				KubernetesNetworkConfig: &ekstypes.KubernetesNetworkConfigResponse{
+					IpFamily:        ekstypes.IpFamilyIpv4,
+					ServiceIpv4Cidr: aws.String("10.100.0.0/16"),
+				},
+				Logging: &ekstypes.Logging{
+					ClusterLogging: []ekstypes.LogSetup{
+						{Enabled: aws.Bool(true), Types: []ekstypes.LogType{ekstypes.LogTypeApi, ekstypes.LogTypeAudit}},
+						{Enabled: aws.Bool(false), Types: []ekstypes.LogType{ekstypes.LogTypeScheduler}},
+					},
+				},
+				AccessConfig: &ekstypes.AccessConfigResponse{AuthenticationMode: ekstypes.AuthenticationModeApiAndConfigMap},
+			},
+		}, nil)
+		provider.MockEKS().On("DescribeNodegroup", mock.Anything, &awseks.DescribeNodegroupInput{
+			ClusterName:   aws.String(clusterName),
+			NodegroupName: aws.String("mng-1"),
+		}).Return(&awseks.DescribeNodegroupOutput{
+			Nodegroup: &ekstypes.Nodegroup{
+				NodegroupName: aws.String("mng-1"),
+				InstanceTypes: []string{"m5.large", "m5a.large"},
+				CapacityType:  ekstypes.CapacityTypesSpot,
+				AmiType:       ekstypes.AMITypesAl2023X8664Standard,
+				DiskSize:      aws.Int32(50),
+				Labels:        map[string]string{"role": "web"},
+				Taints:        []ekstypes.Taint{{Key: aws.String("dedicated"), Value: aws.String("web"), Effect: ekstypes.TaintEffectNoSchedule}},
+				ScalingConfig: &ekstypes.NodegroupScalingConfig{DesiredSize: aws.Int32(3), MinSize: aws.Int32(2), MaxSize: aws.Int32(5)},
+			},
+		}, nil)
+		provider.MockEKS().On("ListAddons", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.ListAddonsOutput{
+			Addons: []string{"vpc-cni"},
+		}, nil)
+		provider.MockEKS().On("DescribeAddon", mock.Anything, mock.Anything).Return(&awseks.DescribeAddonOutput{
+			Addon: &ekstypes.Addon{
+				AddonName:             aws.String("vpc-cni"),
+				AddonVersion:          aws.String("v1.18.1-eksbuild.1"),
+				ConfigurationValues:   aws.String(`{"env":{"ENABLE_PREFIX_DELEGATION":"true"}}`),
+				ServiceAccountRoleArn: aws.String("arn:aws:iam::111122223333:role/vpc-cni"),
+			},
+		}, nil)
+		stackManager.ListStacksReturns([]*cfntypes.Stack{{StackName: aws.String("eksctl-my-cluster-cluster")}}, nil)
+		stackManager.GetStackTemplateReturns(`{"Resources":{}}`, nil)
+	})
+
+	It("archives the cluster, its nodegroups, addons, access entries and stack templates", func() {
+		s, err := snapshotter.Take(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Version).To(Equal(snapshot.Version))
+		Expect(s.Account).To(Equal("111122223333"))
+		Expect(s.StackTemplates).To(Equal(map[string]string{"eksctl-my-cluster-cluster": `{"Resources":{}}`}))
+		Expect(s.Auth.AuthConfigMap).To(Equal(map[string]string{"mapRoles": "[]"}))
+
+		cfg := s.ClusterConfig
+		Expect(cfg.Metadata.Name).To(Equal(clusterName))
+		Expect(cfg.Metadata.Version).To(Equal("1.30"))
+		Expect(cfg.Metadata.Tags).To(Equal(map[string]string{"team": "platform"}))
+		Expect(cfg.VPC.PublicAccessCIDRs).To(BeEmpty())
+		Expect(*cfg.VPC.ClusterEndpoints.PrivateAccess).To(BeTrue())
+		Expect(cfg.KubernetesNetworkConfig.IPFamily).To(Equal(api.IPV4Family))
+		Expect(cfg.CloudWatch.ClusterLogging.EnableTypes).To(ConsistOf("api", "audit"))
+		Expect(cfg.AccessConfig.AuthenticationMode).To(Equal(ekstypes.AuthenticationModeApiAndConfigMap))
+		Expect(cfg.AccessConfig.AccessEntries).To(HaveLen(1))
+		Expect(cfg.AccessConfig.AccessEntries[0].PrincipalARN.String()).To(Equal("arn:aws:iam::111122223333:role/viewer"))
+
+		Expect(cfg.NodeGroups).To(HaveLen(1))
+		Expect(cfg.NodeGroups[0].InstanceType).To(Equal("m5.large"))
+		Expect(*cfg.NodeGroups[0].ScalingConfig.MaxSize).To(Equal(3))
+		Expect(cfg.NodeGroups[0].AMIFamily).To(Equal(api.NodeImageFamilyAmazonLinux2023))
+		Expect(cfg.NodeGroups[0].Labels).To(Equal(map[string]string{"role": "batch"}))
+		Expect(cfg.NodeGroups[0].Taints).To(Equal([]api.NodeGroupTaint{{Key: "dedicated", Value: "batch", Effect: corev1.TaintEffectNoExecute}}))
+
+		Expect(cfg.ManagedNodeGroups).To(HaveLen(1))
+		mng := cfg.ManagedNodeGroups[0]
+		Expect(mng.InstanceTypes).To(Equal([]string{"m5.large", "m5a.large"}))
+		Expect(mng.Spot).To(BeTrue())
+		Expect(mng.AMIFamily).To(Equal(api.NodeImageFamilyAmazonLinux2023))
+		Expect(*mng.VolumeSize).To(Equal(50))
+		Expect(mng.Taints).To(Equal([]api.NodeGroupTaint{{Key: "dedicated", Value: "web", Effect: corev1.TaintEffectNoSchedule}}))
+		Expect(*mng.ScalingConfig.DesiredCapacity).To(Equal(3))
+
+		Expect(cfg.Addons).To(HaveLen(1))
+		Expect(cfg.Addons[0].Version).To(Equal("v1.18.1-eksbuild.1"))
+		Expect(cfg.Addons[0].ServiceAccountRoleARN).To(BeEmpty())
+	})
+
+	It("round-trips through Marshal and Unmarshal", func() {
+		s, err := snapshotter.Take(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		data, err := snapshot.Marshal(s)
+		Expect(err).NotTo(HaveOccurred())
+
+		restored, err := snapshot.Unmarshal(data)
+		Expect(err).NotTo(HaveOccurred())
+		cfg := restored.ClusterConfigFor("copy", "eu-west-1", "111122223333")
+		Expect(cfg.Metadata.Name).To(Equal("copy"))
+		Expect(cfg.Metadata.Region).To(Equal("eu-west-1"))
+		Expect(cfg.ManagedNodeGroups[0].Name).To(Equal("mng-1"))
+		Expect(restored.ClusterConfig.Metadata.Name).To(Equal(clusterName))
+	})
+
+	It("moves the access entries of the snapshotted account to the account of the new cluster", func() {
+		s, err := snapshotter.Take(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		s.ClusterConfig.AccessConfig.AccessEntries = append(s.ClusterConfig.AccessConfig.AccessEntries, api.AccessEntry{
+			PrincipalARN: api.MustParseARN("arn:aws:iam::444455556666:role/auditor"),
+		})
+
+		cfg := s.ClusterConfigFor("copy", "eu-west-1", "777788889999")
+		Expect(cfg.AccessConfig.AccessEntries).To(HaveLen(2))
+		Expect(cfg.AccessConfig.AccessEntries[0].PrincipalARN.String()).To(Equal("arn:aws:iam::777788889999:role/viewer"))
+		Expect(cfg.AccessConfig.AccessEntries[1].PrincipalARN.String()).To(Equal("arn:aws:iam::444455556666:role/auditor"))
+		Expect(s.ClusterConfig.AccessConfig.AccessEntries[0].PrincipalARN.String()).To(Equal("arn:aws:iam::111122223333:role/viewer"))
+	})
+
+	It("rejects snapshots of an unsupported version", func() {
+		_, err := snapshot.Unmarshal([]byte("version: 2\nclusterConfig:\n  metadata:\n    name: x\n"))
+		Expect(err).To(MatchError(ContainSubstring("unsupported cluster snapshot version 2")))
+	})
+})
@@ -0,0 +1,125 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/kris-nova/logger"
+)
+
+const s3Scheme = "s3://"
+
+// S3API is the subset of the S3 API used to store snapshots
+type S3API interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+}
+
+// newS3Client returns an S3 client for the buckets of region
+var newS3Client = func(awsConfig aws.Config, region string) S3API {
+	return s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		o.Region = region
+	})
+}
+
+// Write writes data to location, which is either the path of a file, - for stdout, or an S3 URI of the form
+// s3://<bucket>/<key>; S3 objects are written with the credentials of awsConfig
+func Write(ctx context.Context, awsConfig aws.Config, location string, data []byte) error {
+	switch {
+	case location == "-":
+		_, err := os.Stdout.Write(data)
+		return err
+	case strings.HasPrefix(location, s3Scheme):
+		client, bucket, key, err := s3ClientFor(ctx, awsConfig, location)
+		if err != nil {
+			return err
+		}
+		if _, err := client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Body:   bytes.NewReader(data),
+		}); err != nil {
+			return fmt.Errorf("writing cluster snapshot to %s: %w", location, err)
+		}
+		return nil
+	default:
+		if err := os.WriteFile(location, data, 0600); err != nil {
+			return fmt.Errorf("writing cluster snapshot: %w", err)
+		}
+		return nil
+	}
+}
+
+// Read reads the data written to location by Write
+func Read(ctx context.Context, awsConfig aws.Config, location string) ([]byte, error) {
+	switch {
+	case location == "-":
+		return io.ReadAll(os.Stdin)
+	case strings.HasPrefix(location, s3Scheme):
+		client, bucket, key, err := s3ClientFor(ctx, awsConfig, location)
+		if err != nil {
+			return nil, err
+		}
+		output, err := client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reading cluster snapshot from %s: %w", location, err)
+		}
+		defer output.Body.Close()
+		return io.ReadAll(output.Body)
+	default:
+		data, err := os.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("reading cluster snapshot: %w", err)
+		}
+		return data, nil
+	}
+}
+
+// s3ClientFor parses an S3 URI and returns a client for the region of its bucket, which may differ from the
+// region of awsConfig
+func s3ClientFor(ctx context.Context, awsConfig aws.Config, location string) (S3API, string, string, error) {
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(location, s3Scheme), "/")
+	if !ok || bucket == "" || key == "" {
+		return nil, "", "", fmt.Errorf("invalid S3 URI %q, expected s3://<bucket>/<key>", location)
+	}
+	client := newS3Client(awsConfig, awsConfig.Region)
+	region, err := bucketRegion(ctx, client, awsConfig.Region, bucket)
+	if err != nil {
+		logger.Debug("failed to find the region of bucket %q, using region %q: %v", bucket, awsConfig.Region, err)
+		return client, bucket, key, nil
+	}
+	if region != awsConfig.Region {
+		client = newS3Client(awsConfig, region)
+	}
+	return client, bucket, key, nil
+}
+
+// bucketRegion returns the region of bucket; S3 rejects requests for buckets of other regions, reporting the region
+// of the bucket in the x-amz-bucket-region header, which is also set when the bucket cannot be listed
+func bucketRegion(ctx context.Context, client S3API, region, bucket string) (string, error) {
+	_, err := client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err == nil {
+		return region, nil
+	}
+	var responseErr *awshttp.ResponseError
+	if errors.As(err, &responseErr) && responseErr.Response != nil {
+		if bucketRegion := responseErr.Response.Header.Get("X-Amz-Bucket-Region"); bucketRegion != "" {
+			return bucketRegion, nil
+		}
+	}
+	return "", err
+}
@@ -0,0 +1,132 @@
+package snapshot_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/snapshot"
+)
+
+var _ = Describe("Snapshot store", func() {
+	var awsConfig aws.Config
+
+	BeforeEach(func() {
+		awsConfig = aws.Config{
+			Region:      "us-west-2",
+			Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		}
+	})
+
+	It("writes and reads a file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "snapshot.yaml")
+		Expect(snapshot.Write(context.Background(), awsConfig, path, []byte("data"))).To(Succeed())
+		data, err := snapshot.Read(context.Background(), awsConfig, path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("data"))
+	})
+
+	When("the location is an S3 URI", func() {
+		var (
+			store    *fakeS3Store
+			previous func(awsConfig aws.Config, region string) snapshot.S3API
+		)
+
+		BeforeEach(func() {
+			store = &fakeS3Store{bucketRegion: "eu-west-1", objects: map[string]string{}}
+			previous = snapshot.SetS3Client(func(_ aws.Config, region string) snapshot.S3API {
+				return &fakeS3{store: store, region: region}
+			})
+		})
+
+		AfterEach(func() {
+			snapshot.SetS3Client(previous)
+		})
+
+		It("writes and reads the object in the region of the bucket", func() {
+			Expect(snapshot.Write(context.Background(), awsConfig, "s3://bucket/snapshots/my-cluster.yaml", []byte("data"))).To(Succeed())
+			Expect(store.objects).To(HaveKeyWithValue("bucket/snapshots/my-cluster.yaml", "data"))
+			Expect(store.regions).To(Equal([]string{"us-west-2", "eu-west-1"}))
+
+			data, err := snapshot.Read(context.Background(), awsConfig, "s3://bucket/snapshots/my-cluster.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("data"))
+		})
+
+		It("keeps the dots of bucket names", func() {
+			store.bucketRegion = "us-west-2"
+			Expect(snapshot.Write(context.Background(), awsConfig, "s3://snapshots.example.com/my-cluster.yaml", []byte("data"))).To(Succeed())
+			Expect(store.objects).To(HaveKeyWithValue("snapshots.example.com/my-cluster.yaml", "data"))
+			Expect(store.regions).To(Equal([]string{"us-west-2", "us-west-2"}))
+		})
+
+		It("returns the error of S3", func() {
+			_, err := snapshot.Read(context.Background(), awsConfig, "s3://bucket/missing.yaml")
+			Expect(err).To(MatchError(ContainSubstring("NoSuchKey")))
+		})
+
+		It("rejects URIs without a key", func() {
+			_, err := snapshot.Read(context.Background(), awsConfig, "s3://bucket")
+			Expect(err).To(MatchError(ContainSubstring("invalid S3 URI")))
+		})
+	})
+})
+
+type fakeS3Store struct {
+	bucketRegion string
+	objects      map[string]string
+	// regions are the regions of the clients that sent requests
+	regions []string
+}
+
+type fakeS3 struct {
+	store  *fakeS3Store
+	region string
+}
+
+func (f *fakeS3) HeadBucket(_ context.Context, _ *s3.HeadBucketInput, _ ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+	f.store.regions = append(f.store.regions, f.region)
+	if f.region != f.store.bucketRegion {
+		return nil, &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{
+				Response: &smithyhttp.Response{Response: &http.Response{
+					StatusCode: http.StatusMovedPermanently,
+					Header:     http.Header{"X-Amz-Bucket-Region": []string{f.store.bucketRegion}},
+				}},
+				Err: errors.New("MovedPermanently"),
+			},
+		}
+	}
+	return &s3.HeadBucketOutput{}, nil
+}
+
+func (f *fakeS3) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	f.store.regions = append(f.store.regions, f.region)
+	Expect(f.region).To(Equal(f.store.bucketRegion))
+	body, err := io.ReadAll(params.Body)
+	Expect(err).NotTo(HaveOccurred())
+	f.store.objects[*params.Bucket+"/"+*params.Key] = string(body)
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	f.store.regions = append(f.store.regions, f.region)
+	Expect(f.region).To(Equal(f.store.bucketRegion))
+	object, ok := f.store.objects[*params.Bucket+"/"+*params.Key]
+	if !ok {
+		return nil, &s3types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(strings.NewReader(object))}, nil
+}
@@ -0,0 +1,336 @@
+// Package tags updates the tags of the resources of an existing cluster to match the tags in its ClusterConfig.
+package tags
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+)
+
+// reservedTagPrefixes are the prefixes of the tags set by eksctl, Kubernetes or AWS, which are never removed
+var reservedTagPrefixes = []string{"aws:", "alpha.eksctl.io/", "eksctl.io/", "eksctl.cluster.k8s.io/", "kubernetes.io/", "k8s.io/"}
+
+// IsUserTag reports whether key is the key of a tag that can be set in metadata.tags or the tags of a nodegroup
+func IsUserTag(key string) bool {
+	if key == "Name" {
+		return false
+	}
+	for _, prefix := range reservedTagPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// Change is a change of the tags of a resource
+type Change struct {
+	// Resource describes the resource, e.g. `EKS cluster "dev"`
+	Resource string
+	Add      map[string]string
+	Remove   []string
+
+	apply func(ctx context.Context) error
+}
+
+// String describes the change
+func (c Change) String() string {
+	var changes []string
+	for _, k := range sortedKeys(c.Add) {
+		changes = append(changes, fmt.Sprintf("+%s=%s", k, c.Add[k]))
+	}
+	for _, k := range c.Remove {
+		changes = append(changes, "-"+k)
+	}
+	return fmt.Sprintf("%s: %s", c.Resource, strings.Join(changes, ", "))
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Diff returns the tags to add to or update in current and the keys of the user tags to remove from it, so that
+// its user tags match desired
+func Diff(current, desired map[string]string) (map[string]string, []string) {
+	add := map[string]string{}
+	for k, v := range desired {
+		if value, ok := current[k]; !ok || value != v {
+			add[k] = v
+		}
+	}
+	var remove []string
+	for k := range current {
+		if _, ok := desired[k]; !ok && IsUserTag(k) {
+			remove = append(remove, k)
+		}
+	}
+	slices.Sort(remove)
+	return add, remove
+}
+
+// Updater updates the tags of the EKS cluster, its nodegroups, their autoscaling groups and the CloudFormation
+// stacks of the cluster
+type Updater struct {
+	clusterConfig     *api.ClusterConfig
+	stackManager      manager.StackManager
+	eksAPI            awsapi.EKS
+	asgAPI            awsapi.ASG
+	cloudFormationAPI awsapi.CloudFormation
+	waitTimeout       time.Duration
+}
+
+// New returns an Updater applying the tags of clusterConfig
+func New(clusterConfig *api.ClusterConfig, stackManager manager.StackManager, eksAPI awsapi.EKS, asgAPI awsapi.ASG,
+	cloudFormationAPI awsapi.CloudFormation, waitTimeout time.Duration) *Updater {
+	return &Updater{
+		clusterConfig:     clusterConfig,
+		stackManager:      stackManager,
+		eksAPI:            eksAPI,
+		asgAPI:            asgAPI,
+		cloudFormationAPI: cloudFormationAPI,
+		waitTimeout:       waitTimeout,
+	}
+}
+
+// desiredNodeGroupTags returns the tags of the resources of nodegroup name, its own tags take precedence over metadata.tags
+func (u *Updater) desiredNodeGroupTags(name string) map[string]string {
+	desired := maps.Clone(u.clusterConfig.Metadata.Tags)
+	if desired == nil {
+		desired = map[string]string{}
+	}
+	for _, ng := range u.clusterConfig.AllNodeGroups() {
+		if ng.Name == name {
+			maps.Copy(desired, ng.Tags)
+		}
+	}
+	return desired
+}
+
+// Plan returns the changes needed for the tags of the resources to match the ClusterConfig
+func (u *Updater) Plan(ctx context.Context) ([]Change, error) {
+	clusterName := u.clusterConfig.Metadata.Name
+	cluster, err := u.eksAPI.DescribeCluster(ctx, &eks.DescribeClusterInput{Name: aws.String(clusterName)})
+	if err != nil {
+		return nil, fmt.Errorf("describing cluster %q: %w", clusterName, err)
+	}
+	var changes []Change
+	if change, ok := u.eksResourceChange(fmt.Sprintf("EKS cluster %q", clusterName), *cluster.Cluster.Arn, cluster.Cluster.Tags, u.clusterConfig.Metadata.Tags); ok {
+		changes = append(changes, change)
+	}
+
+	var asgNames []string
+	nodeGroups, err := u.eksAPI.ListNodegroups(ctx, &eks.ListNodegroupsInput{ClusterName: aws.String(clusterName)})
+	if err != nil {
+		return nil, fmt.Errorf("listing nodegroups of cluster %q: %w", clusterName, err)
+	}
+	desiredASGTags := map[string]map[string]string{}
+	for _, name := range nodeGroups.Nodegroups {
+		ng, err := u.eksAPI.DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{ClusterName: aws.String(clusterName), NodegroupName: aws.String(name)})
+		if err != nil {
+			return nil, fmt.Errorf("describing nodegroup %q: %w", name, err)
+		}
+		desired := u.desiredNodeGroupTags(name)
+		if change, ok := u.eksResourceChange(fmt.Sprintf("managed nodegroup %q", name), *ng.Nodegroup.NodegroupArn, ng.Nodegroup.Tags, desired); ok {
+			changes = append(changes, change)
+		}
+		if ng.Nodegroup.Resources != nil {
+			for _, asg := range ng.Nodegroup.Resources.AutoScalingGroups {
+				asgNames = append(asgNames, *asg.Name)
+				desiredASGTags[*asg.Name] = desired
+			}
+		}
+	}
+
+	stacks, err := u.stackManager.ListStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, stack := range stacks {
+		desired := u.clusterConfig.Metadata.Tags
+		if ngName := manager.GetNodegroupTagName(stack.Tags); ngName != "" {
+			desired = u.desiredNodeGroupTags(ngName)
+			if ngType, err := manager.GetNodeGroupType(stack.Tags); err == nil && ngType == api.NodeGroupTypeUnmanaged {
+				asgName, err := u.stackManager.GetUnmanagedNodeGroupAutoScalingGroupName(ctx, stack)
+				if err != nil {
+					return nil, fmt.Errorf("getting the autoscaling group of nodegroup %q: %w", ngName, err)
+				}
+				asgNames = append(asgNames, asgName)
+				desiredASGTags[asgName] = desired
+			}
+		}
+		if change, ok := u.stackChange(stack, desired); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	if len(asgNames) > 0 {
+		asgChanges, err := u.asgChanges(ctx, asgNames, desiredASGTags)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, asgChanges...)
+	}
+	return changes, nil
+}
+
+// Apply applies changes
+func (u *Updater) Apply(ctx context.Context, changes []Change) error {
+	for _, change := range changes {
+		logger.Info("updating tags of %s", change)
+		if err := change.apply(ctx); err != nil {
+			return fmt.Errorf("updating tags of %s: %w", change.Resource, err)
+		}
+	}
+	return nil
+}
+
+func (u *Updater) eksResourceChange(resource, arn string, current, desired map[string]string) (Change, bool) {
+	add, remove := Diff(current, desired)
+	if len(add) == 0 && len(remove) == 0 {
+		return Change{}, false
+	}
+	return Change{
+		Resource: resource,
+		Add:      add,
+		Remove:   remove,
+		apply: func(ctx context.Context) error {
+			if len(add) > 0 {
+				if _, err := u.eksAPI.TagResource(ctx, &eks.TagResourceInput{ResourceArn: aws.String(arn), Tags: add}); err != nil {
+					return err
+				}
+			}
+			if len(remove) > 0 {
+				if _, err := u.eksAPI.UntagResource(ctx, &eks.UntagResourceInput{ResourceArn: aws.String(arn), TagKeys: remove}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}, true
+}
+
+// stackChange returns the change of the tags of stack, CloudFormation propagates them to the resources of the stack
+func (u *Updater) stackChange(stack *manager.Stack, desired map[string]string) (Change, bool) {
+	current := map[string]string{}
+	for _, tag := range stack.Tags {
+		current[*tag.Key] = *tag.Value
+	}
+	add, remove := Diff(current, desired)
+	if len(add) == 0 && len(remove) == 0 {
+		return Change{}, false
+	}
+
+	updated := maps.Clone(current)
+	maps.Copy(updated, add)
+	for _, k := range remove {
+		delete(updated, k)
+	}
+	var tags []cfntypes.Tag
+	for _, k := range sortedKeys(updated) {
+		tags = append(tags, cfntypes.Tag{Key: aws.String(k), Value: aws.String(updated[k])})
+	}
+	var parameters []cfntypes.Parameter
+	for _, p := range stack.Parameters {
+		parameters = append(parameters, cfntypes.Parameter{ParameterKey: p.ParameterKey, UsePreviousValue: aws.Bool(true)})
+	}
+
+	return Change{
+		Resource: fmt.Sprintf("CloudFormation stack %q", *stack.StackName),
+		Add:      add,
+		Remove:   remove,
+		apply: func(ctx context.Context) error {
+			// the template and parameters are kept as they are, so that no resource is replaced
+			if _, err := u.cloudFormationAPI.UpdateStack(ctx, &cloudformation.UpdateStackInput{
+				StackName:           stack.StackName,
+				UsePreviousTemplate: aws.Bool(true),
+				Parameters:          parameters,
+				Capabilities:        stack.Capabilities,
+				Tags:                tags,
+				NotificationARNs:    u.clusterConfig.StackNotificationARNs(),
+			}); err != nil {
+				return err
+			}
+			waiter := cloudformation.NewStackUpdateCompleteWaiter(u.cloudFormationAPI)
+			return waiter.Wait(ctx, &cloudformation.DescribeStacksInput{StackName: stack.StackName}, u.waitTimeout)
+		},
+	}, true
+}
+
+// asgChanges returns the changes of the tags of the autoscaling groups, which are propagated to the instances they launch
+func (u *Updater) asgChanges(ctx context.Context, asgNames []string, desiredTags map[string]map[string]string) ([]Change, error) {
+	output, err := u.asgAPI.DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{AutoScalingGroupNames: asgNames})
+	if err != nil {
+		return nil, fmt.Errorf("describing autoscaling groups: %w", err)
+	}
+	var changes []Change
+	for _, asg := range output.AutoScalingGroups {
+		name := *asg.AutoScalingGroupName
+		current := map[string]string{}
+		for _, tag := range asg.Tags {
+			current[*tag.Key] = *tag.Value
+		}
+		add, remove := Diff(current, desiredTags[name])
+		if len(add) == 0 && len(remove) == 0 {
+			continue
+		}
+
+		makeTag := func(key string) asgtypes.Tag {
+			return asgtypes.Tag{
+				ResourceId:        aws.String(name),
+				ResourceType:      aws.String("auto-scaling-group"),
+				Key:               aws.String(key),
+				Value:             aws.String(add[key]),
+				PropagateAtLaunch: aws.Bool(true),
+			}
+		}
+		var addTags, removeTags []asgtypes.Tag
+		for _, k := range sortedKeys(add) {
+			addTags = append(addTags, makeTag(k))
+		}
+		for _, k := range remove {
+			tag := makeTag(k)
+			tag.Value = aws.String(current[k])
+			removeTags = append(removeTags, tag)
+		}
+
+		changes = append(changes, Change{
+			Resource: fmt.Sprintf("autoscaling group %q", name),
+			Add:      add,
+			Remove:   remove,
+			apply: func(ctx context.Context) error {
+				if len(addTags) > 0 {
+					if _, err := u.asgAPI.CreateOrUpdateTags(ctx, &autoscaling.CreateOrUpdateTagsInput{Tags: addTags}); err != nil {
+						return err
+					}
+				}
+				if len(removeTags) > 0 {
+					if _, err := u.asgAPI.DeleteTags(ctx, &autoscaling.DeleteTagsInput{Tags: removeTags}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		})
+	}
+	return changes, nil
+}
@@ -0,0 +1,128 @@
+package tags_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/tags"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Update tags", func() {
+	var (
+		provider         *mockprovider.MockProvider
+		fakeStackManager *fakes.FakeStackManager
+		clusterConfig    *api.ClusterConfig
+	)
+
+	cfnTags := func(tags map[string]string) []cfntypes.Tag {
+		var cfnTags []cfntypes.Tag
+		for k, v := range tags {
+			cfnTags = append(cfnTags, cfntypes.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+		return cfnTags
+	}
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		fakeStackManager = &fakes.FakeStackManager{}
+		clusterConfig = api.NewClusterConfig()
+		clusterConfig.Metadata.Name = "dev"
+		clusterConfig.Metadata.Tags = map[string]string{"team": "payments"}
+		clusterConfig.ManagedNodeGroups = []*api.ManagedNodeGroup{
+			{NodeGroupBase: &api.NodeGroupBase{Name: "mng", Tags: map[string]string{"workload": "batch"}}},
+		}
+
+		provider.MockEKS().On("DescribeCluster", mock.Anything, &eks.DescribeClusterInput{Name: aws.String("dev")}).Return(&eks.DescribeClusterOutput{
+			Cluster: &ekstypes.Cluster{
+				Arn:  aws.String("arn:aws:eks:us-west-2:123456789012:cluster/dev"),
+				Tags: map[string]string{"team": "payments", api.ClusterNameTag: "dev"},
+			},
+		}, nil)
+		provider.MockEKS().On("ListNodegroups", mock.Anything, mock.Anything).Return(&eks.ListNodegroupsOutput{Nodegroups: []string{"mng"}}, nil)
+		provider.MockEKS().On("DescribeNodegroup", mock.Anything, mock.Anything).Return(&eks.DescribeNodegroupOutput{
+			Nodegroup: &ekstypes.Nodegroup{
+				NodegroupArn: aws.String("arn:aws:eks:us-west-2:123456789012:nodegroup/dev/mng/1"),
+				Tags:         map[string]string{"team": "payments", "owner": "alice"},
+				Resources: &ekstypes.NodegroupResources{
+					AutoScalingGroups: []ekstypes.AutoScalingGroup{{Name: aws.String("eks-mng-asg")}},
+				},
+			},
+		}, nil)
+		provider.MockASG().On("DescribeAutoScalingGroups", mock.Anything, mock.Anything).Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+			AutoScalingGroups: []asgtypes.AutoScalingGroup{{
+				AutoScalingGroupName: aws.String("eks-mng-asg"),
+				Tags: []asgtypes.TagDescription{
+					{Key: aws.String("team"), Value: aws.String("payments")},
+					{Key: aws.String("workload"), Value: aws.String("batch")},
+					{Key: aws.String("k8s.io/cluster-autoscaler/enabled"), Value: aws.String("true")},
+				},
+			}},
+		}, nil)
+		fakeStackManager.ListStacksReturns([]*manager.Stack{
+			{
+				StackName: aws.String("eksctl-dev-cluster"),
+				Tags:      cfnTags(map[string]string{"team": "platform", api.ClusterNameTag: "dev"}),
+				Parameters: []cfntypes.Parameter{
+					{ParameterKey: aws.String("VpcCidr"), ParameterValue: aws.String("10.0.0.0/16")},
+				},
+				Capabilities: []cfntypes.Capability{cfntypes.CapabilityCapabilityIam},
+			},
+		}, nil)
+	})
+
+	It("computes the differences", func() {
+		add, remove := tags.Diff(
+			map[string]string{"team": "a", "owner": "bob", "Name": "node", api.ClusterNameTag: "dev", "aws:cloudformation:stack-name": "s"},
+			map[string]string{"team": "b", "env": "prod"},
+		)
+		Expect(add).To(Equal(map[string]string{"team": "b", "env": "prod"}))
+		Expect(remove).To(Equal([]string{"owner"}))
+	})
+
+	It("plans and applies the changes of the tags of all resources", func() {
+		updater := tags.New(clusterConfig, fakeStackManager, provider.EKS(), provider.ASG(), provider.CloudFormation(), time.Minute)
+		changes, err := updater.Plan(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		var descriptions []string
+		for _, change := range changes {
+			descriptions = append(descriptions, change.String())
+		}
+		Expect(descriptions).To(ConsistOf(
+			`managed nodegroup "mng": +workload=batch, -owner`,
+			`CloudFormation stack "eksctl-dev-cluster": +team=payments`,
+		))
+
+		provider.MockEKS().On("TagResource", mock.Anything, mock.Anything).Return(&eks.TagResourceOutput{}, nil)
+		provider.MockEKS().On("UntagResource", mock.Anything, mock.Anything).Return(&eks.UntagResourceOutput{}, nil)
+		provider.MockCloudFormation().On("UpdateStack", mock.Anything, mock.Anything).Return(&cloudformation.UpdateStackOutput{}, nil)
+		provider.MockCloudFormation().On("DescribeStacks", mock.Anything, mock.Anything).Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []cfntypes.Stack{{StackName: aws.String("eksctl-dev-cluster"), StackStatus: cfntypes.StackStatusUpdateComplete}},
+		}, nil)
+		Expect(updater.Apply(context.Background(), changes)).To(Succeed())
+
+		provider.MockEKS().AssertCalled(GinkgoT(), "UntagResource", mock.Anything, &eks.UntagResourceInput{
+			ResourceArn: aws.String("arn:aws:eks:us-west-2:123456789012:nodegroup/dev/mng/1"),
+			TagKeys:     []string{"owner"},
+		})
+		updateStackInput := provider.MockCloudFormation().Calls[0].Arguments[1].(*cloudformation.UpdateStackInput)
+		Expect(*updateStackInput.UsePreviousTemplate).To(BeTrue())
+		Expect(updateStackInput.Parameters).To(Equal([]cfntypes.Parameter{{ParameterKey: aws.String("VpcCidr"), UsePreviousValue: aws.Bool(true)}}))
+		Expect(updateStackInput.Capabilities).To(Equal([]cfntypes.Capability{cfntypes.CapabilityCapabilityIam}))
+		Expect(updateStackInput.Tags).To(ConsistOf(cfnTags(map[string]string{"team": "payments", api.ClusterNameTag: "dev"})))
+	})
+})
@@ -0,0 +1,200 @@
+// Package tunnel forwards a local port to the private API server endpoint of a cluster
+// through an SSM port-forwarding session on an instance in the cluster's VPC.
+package tunnel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/kris-nova/logger"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+)
+
+const (
+	// SessionManagerPluginBinary is the binary that handles the data channel of SSM sessions.
+	SessionManagerPluginBinary = "session-manager-plugin"
+
+	portForwardingDocument = "AWS-StartPortForwardingSessionToRemoteHost"
+	// managedNodeGroupNameTag is the tag EKS adds to instances of managed nodegroups
+	managedNodeGroupNameTag = "eks:nodegroup-name"
+)
+
+// ClusterStackDescriber describes the cluster stack.
+type ClusterStackDescriber interface {
+	DescribeClusterStack(ctx context.Context) (*manager.Stack, error)
+}
+
+// A Tunnel starts SSM port-forwarding sessions to the API server endpoint of a cluster.
+type Tunnel struct {
+	ClusterName    string
+	Region         string
+	Profile        string
+	EC2API         awsapi.EC2
+	SSMAPI         awsapi.SSM
+	StackDescriber ClusterStackDescriber
+}
+
+// FindTargetInstance returns the ID of the instance to forward traffic through. If nodeGroupName is empty,
+// the bastion created with privateCluster.adminAccess is used, otherwise a running instance of the nodegroup.
+func (t *Tunnel) FindTargetInstance(ctx context.Context, nodeGroupName string) (string, error) {
+	if nodeGroupName == "" {
+		return t.findBastion(ctx)
+	}
+
+	clusterTag := fmt.Sprintf("tag:kubernetes.io/cluster/%s", t.ClusterName)
+	output, err := t.EC2API.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String(clusterTag),
+				Values: []string{"owned", "shared"},
+			},
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: []string{string(ec2types.InstanceStateNameRunning)},
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("describing instances of cluster %q: %w", t.ClusterName, err)
+	}
+
+	var instanceIDs []string
+	for _, reservation := range output.Reservations {
+		for _, instance := range reservation.Instances {
+			for _, tag := range instance.Tags {
+				if key := aws.ToString(tag.Key); (key == api.NodeGroupNameTag || key == managedNodeGroupNameTag) && aws.ToString(tag.Value) == nodeGroupName {
+					instanceIDs = append(instanceIDs, aws.ToString(instance.InstanceId))
+					break
+				}
+			}
+		}
+	}
+	if len(instanceIDs) == 0 {
+		return "", fmt.Errorf("no running instances found in nodegroup %q", nodeGroupName)
+	}
+	sort.Strings(instanceIDs)
+	return instanceIDs[0], nil
+}
+
+func (t *Tunnel) findBastion(ctx context.Context) (string, error) {
+	stack, err := t.StackDescriber.DescribeClusterStack(ctx)
+	if err != nil {
+		return "", fmt.Errorf("describing cluster stack: %w", err)
+	}
+	for _, output := range stack.Outputs {
+		if aws.ToString(output.OutputKey) == outputs.ClusterBastionInstanceID {
+			return aws.ToString(output.OutputValue), nil
+		}
+	}
+	return "", fmt.Errorf("cluster %q does not have a bastion; create one with privateCluster.adminAccess or specify a nodegroup or instance to use", t.ClusterName)
+}
+
+// Run starts a port-forwarding session from localPort to the API server endpoint through instanceID,
+// and blocks until the session ends.
+func (t *Tunnel) Run(ctx context.Context, instanceID, endpoint string, localPort int) error {
+	pluginPath, err := exec.LookPath(SessionManagerPluginBinary)
+	if err != nil {
+		return fmt.Errorf("%s is required to start a tunnel, see https://docs.aws.amazon.com/systems-manager/latest/userguide/session-manager-working-with-install-plugin.html: %w",
+			SessionManagerPluginBinary, err)
+	}
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Host == "" {
+		return fmt.Errorf("invalid cluster endpoint %q", endpoint)
+	}
+	ssmEndpointURL, err := ssmEndpoint(t.Region)
+	if err != nil {
+		return err
+	}
+
+	input := &ssm.StartSessionInput{
+		Target:       aws.String(instanceID),
+		DocumentName: aws.String(portForwardingDocument),
+		Parameters: map[string][]string{
+			"host":            {endpointURL.Hostname()},
+			"portNumber":      {"443"},
+			"localPortNumber": {strconv.Itoa(localPort)},
+		},
+	}
+	session, err := t.SSMAPI.StartSession(ctx, input)
+	if err != nil {
+		return fmt.Errorf("starting SSM session on instance %q: %w", instanceID, err)
+	}
+	defer func() {
+		if _, err := t.SSMAPI.TerminateSession(context.Background(), &ssm.TerminateSessionInput{
+			SessionId: session.SessionId,
+		}); err != nil {
+			logger.Warning("failed to terminate SSM session %s: %v", aws.ToString(session.SessionId), err)
+		}
+	}()
+
+	// session-manager-plugin takes the same arguments as when it is invoked by the AWS CLI
+	sessionJSON, err := json.Marshal(map[string]string{
+		"SessionId":  aws.ToString(session.SessionId),
+		"TokenValue": aws.ToString(session.TokenValue),
+		"StreamUrl":  aws.ToString(session.StreamUrl),
+	})
+	if err != nil {
+		return err
+	}
+	requestJSON, err := json.Marshal(map[string]interface{}{
+		"Target":       instanceID,
+		"DocumentName": portForwardingDocument,
+		"Parameters":   input.Parameters,
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("forwarding 127.0.0.1:%d to %s through instance %s", localPort, endpointURL.Hostname(), instanceID)
+	cmd := exec.Command(pluginPath, string(sessionJSON), t.Region, "StartSession", t.Profile, string(requestJSON), ssmEndpointURL)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && !exitErr.Exited() {
+			// the plugin was stopped by a signal, e.g. Ctrl+C, which is how the tunnel is meant to be closed
+			return nil
+		}
+		return fmt.Errorf("running %s: %w", SessionManagerPluginBinary, err)
+	}
+	return nil
+}
+
+func ssmEndpoint(region string) (string, error) {
+	dnsSuffix, err := api.Partitions.DNSSuffixForRegion(region)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://ssm.%s.%s", region, dnsSuffix), nil
+}
+
+// UseTunnel points the clusters in config at a tunnel on localPort. The original hostname is kept
+// as the TLS server name, so that the API server certificate can still be verified.
+func UseTunnel(config *clientcmdapi.Config, localPort int) error {
+	for name, cluster := range config.Clusters {
+		serverURL, err := url.Parse(cluster.Server)
+		if err != nil || serverURL.Host == "" {
+			return fmt.Errorf("invalid server %q for cluster %q in kubeconfig", cluster.Server, name)
+		}
+		cluster.TLSServerName = serverURL.Hostname()
+		cluster.Server = fmt.Sprintf("https://127.0.0.1:%d", localPort)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package tunnel_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/weaveworks/eksctl/pkg/actions/tunnel"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+type fakeStackDescriber struct {
+	stack *manager.Stack
+	err   error
+}
+
+func (f *fakeStackDescriber) DescribeClusterStack(_ context.Context) (*manager.Stack, error) {
+	return f.stack, f.err
+}
+
+func makeInstance(id, tagKey, nodeGroupName string) ec2types.Instance {
+	return ec2types.Instance{
+		InstanceId: aws.String(id),
+		Tags: []ec2types.Tag{
+			{
+				Key:   aws.String(tagKey),
+				Value: aws.String(nodeGroupName),
+			},
+		},
+	}
+}
+
+var _ = Describe("Tunnel", func() {
+	var (
+		provider       *mockprovider.MockProvider
+		stackDescriber *fakeStackDescriber
+		t              *tunnel.Tunnel
+	)
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		stackDescriber = &fakeStackDescriber{
+			stack: &manager.Stack{},
+		}
+		t = &tunnel.Tunnel{
+			ClusterName:    "private-cluster",
+			Region:         "us-west-2",
+			EC2API:         provider.EC2(),
+			SSMAPI:         provider.SSM(),
+			StackDescriber: stackDescriber,
+		}
+	})
+
+	Context("FindTargetInstance", func() {
+		It("uses the bastion when no nodegroup is specified", func() {
+			stackDescriber.stack.Outputs = []cfntypes.Output{
+				{
+					OutputKey:   aws.String(outputs.ClusterBastionInstanceID),
+					OutputValue: aws.String("i-bastion"),
+				},
+			}
+			instanceID, err := t.FindTargetInstance(context.Background(), "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instanceID).To(Equal("i-bastion"))
+		})
+
+		It("returns an error if the cluster does not have a bastion", func() {
+			_, err := t.FindTargetInstance(context.Background(), "")
+			Expect(err).To(MatchError(ContainSubstring(`cluster "private-cluster" does not have a bastion`)))
+		})
+
+		It("returns an error if the cluster stack cannot be described", func() {
+			stackDescriber.err = errors.New("stack not found")
+			_, err := t.FindTargetInstance(context.Background(), "")
+			Expect(err).To(MatchError(ContainSubstring("stack not found")))
+		})
+
+		It("uses a running instance of the nodegroup", func() {
+			provider.MockEC2().On("DescribeInstances", mock.Anything, mock.MatchedBy(func(input *ec2.DescribeInstancesInput) bool {
+				return len(input.Filters) == 2 && aws.ToString(input.Filters[0].Name) == "tag:kubernetes.io/cluster/private-cluster"
+			})).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []ec2types.Reservation{
+					{
+						Instances: []ec2types.Instance{
+							makeInstance("i-2", api.NodeGroupNameTag, "ng-1"),
+							makeInstance("i-3", api.NodeGroupNameTag, "ng-2"),
+						},
+					},
+					{
+						Instances: []ec2types.Instance{
+							makeInstance("i-1", "eks:nodegroup-name", "ng-1"),
+						},
+					},
+				},
+			}, nil)
+
+			instanceID, err := t.FindTargetInstance(context.Background(), "ng-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instanceID).To(Equal("i-1"))
+		})
+
+		It("returns an error if the nodegroup has no running instances", func() {
+			provider.MockEC2().On("DescribeInstances", mock.Anything, mock.Anything).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []ec2types.Reservation{
+					{
+						Instances: []ec2types.Instance{
+							makeInstance("i-3", api.NodeGroupNameTag, "ng-2"),
+						},
+					},
+				},
+			}, nil)
+
+			_, err := t.FindTargetInstance(context.Background(), "ng-1")
+			Expect(err).To(MatchError(`no running instances found in nodegroup "ng-1"`))
+		})
+	})
+
+	Context("UseTunnel", func() {
+		It("points clusters at the local port and keeps the original hostname for TLS", func() {
+			config := &clientcmdapi.Config{
+				Clusters: map[string]*clientcmdapi.Cluster{
+					"private-cluster.us-west-2.eksctl.io": {
+						Server: "https://ABCDEF.gr7.us-west-2.eks.amazonaws.com",
+					},
+				},
+			}
+			Expect(tunnel.UseTunnel(config, 8443)).To(Succeed())
+			cluster := config.Clusters["private-cluster.us-west-2.eksctl.io"]
+			Expect(cluster.Server).To(Equal("https://127.0.0.1:8443"))
+			Expect(cluster.TLSServerName).To(Equal("ABCDEF.gr7.us-west-2.eks.amazonaws.com"))
+		})
+
+		It("returns an error for an invalid server", func() {
+			config := &clientcmdapi.Config{
+				Clusters: map[string]*clientcmdapi.Cluster{
+					"private-cluster": {},
+				},
+			}
+			Expect(tunnel.UseTunnel(config, 8443)).To(MatchError(ContainSubstring("invalid server")))
+		})
+	})
+})
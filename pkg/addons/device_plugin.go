@@ -5,6 +5,8 @@ import (
 	// For go:embed
 	_ "embed"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/kris-nova/logger"
@@ -19,6 +21,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
 	clientappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	"sigs.k8s.io/yaml"
 )
 
 //go:embed assets/efa-device-plugin.yaml
@@ -32,7 +35,7 @@ var nvidiaDevicePluginYaml []byte
 
 func useRegionalImage(spec *corev1.PodTemplateSpec, region string, account string) error {
 	imageFormat := spec.Spec.Containers[0].Image
-	dnsSuffix, err := awsDNSSuffixForRegion(region)
+	dnsSuffix, err := api.Partitions.DNSSuffixForRegion(region)
 	if err != nil {
 		return err
 	}
@@ -202,9 +205,22 @@ func (n *NvidiaDevicePlugin) Manifest() []byte {
 	return nvidiaDevicePluginYaml
 }
 
-// Deploy deploys the Nvidia device plugin to the specified cluster
+// Deploy deploys the Nvidia device plugin to the specified cluster, along with a device plugin
+// configured for GPU sharing for each nodegroup that sets gpu
 func (n *NvidiaDevicePlugin) Deploy() error {
-	return applyDevicePlugin(n)
+	if err := applyDevicePlugin(n); err != nil {
+		return err
+	}
+	for _, ng := range gpuSharingNodeGroups(n.spec) {
+		manifest, err := makeGPUSharingManifest(ng)
+		if err != nil {
+			return errors.Wrapf(err, "rendering Nvidia device plugin for nodegroup %q", ng.Name)
+		}
+		if err := applyDevicePlugin(&gpuSharingDevicePlugin{NvidiaDevicePlugin: n, manifest: manifest}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // SetTolerations sets given tolerations on the DaemonSet if they don't already exist.
@@ -224,7 +240,7 @@ func (n *NvidiaDevicePlugin) SetTolerations(spec *corev1.PodTemplateSpec) error
 	taints := make(map[string]api.NodeGroupTaint)
 	for _, ng := range n.spec.NodeGroups {
 		if api.HasInstanceType(ng, instance.IsNvidiaInstanceType) &&
-			ng.GetAMIFamily() == api.NodeImageFamilyAmazonLinux2 {
+			(ng.GetAMIFamily() == api.NodeImageFamilyAmazonLinux2 || ng.GPU != nil) {
 			for _, taint := range ng.Taints {
 				if _, ok := taints[taint.Key]; !ok {
 					taints[taint.Key] = taint
@@ -234,7 +250,7 @@ func (n *NvidiaDevicePlugin) SetTolerations(spec *corev1.PodTemplateSpec) error
 	}
 	for _, ng := range n.spec.ManagedNodeGroups {
 		if api.HasInstanceTypeManaged(ng, instance.IsNvidiaInstanceType) &&
-			ng.GetAMIFamily() == api.NodeImageFamilyAmazonLinux2 {
+			(ng.GetAMIFamily() == api.NodeImageFamilyAmazonLinux2 || ng.GPU != nil) {
 			for _, taint := range ng.Taints {
 				if _, ok := taints[taint.Key]; !ok {
 					taints[taint.Key] = taint
@@ -254,6 +270,146 @@ func (n *NvidiaDevicePlugin) SetTolerations(spec *corev1.PodTemplateSpec) error
 	return nil
 }
 
+const nvidiaDevicePluginConfigDir = "/etc/nvidia-device-plugin"
+
+// A gpuSharingDevicePlugin deploys the Nvidia device plugin with the time-slicing and MIG
+// configuration of a nodegroup to the nodes of that nodegroup
+type gpuSharingDevicePlugin struct {
+	*NvidiaDevicePlugin
+	manifest []byte
+}
+
+func (g *gpuSharingDevicePlugin) Manifest() []byte {
+	return g.manifest
+}
+
+func gpuSharingNodeGroups(spec *api.ClusterConfig) []*api.NodeGroupBase {
+	var nodeGroups []*api.NodeGroupBase
+	for _, ng := range spec.NodeGroups {
+		if ng.GPU != nil {
+			nodeGroups = append(nodeGroups, ng.NodeGroupBase)
+		}
+	}
+	for _, ng := range spec.ManagedNodeGroups {
+		if ng.GPU != nil {
+			nodeGroups = append(nodeGroups, ng.NodeGroupBase)
+		}
+	}
+	return nodeGroups
+}
+
+// makeGPUSharingManifest renders a ConfigMap holding the device plugin config of ng, and a copy of
+// the Nvidia device plugin DaemonSet that uses it on the nodes of ng
+func makeGPUSharingManifest(ng *api.NodeGroupBase) ([]byte, error) {
+	config, err := yaml.Marshal(makeNvidiaDevicePluginConfig(ng.GPU))
+	if err != nil {
+		return nil, err
+	}
+	name := "nvidia-device-plugin-" + strings.ToLower(ng.Name)
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceSystem,
+		},
+		Data: map[string]string{
+			"config.yaml": string(config),
+		},
+	}
+
+	var daemonSet appsv1.DaemonSet
+	if err := yaml.Unmarshal(nvidiaDevicePluginYaml, &daemonSet); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling Nvidia device plugin daemonset")
+	}
+	labels := map[string]string{"name": name}
+	daemonSet.Name = name
+	daemonSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	pod := &daemonSet.Spec.Template
+	pod.Labels = labels
+	pod.Spec.Affinity = nil
+	pod.Spec.NodeSelector = map[string]string{
+		api.NodeGroupNameLabel: ng.Name,
+		api.GPUSharingLabel:    "true",
+	}
+	container := &pod.Spec.Containers[0]
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  "CONFIG_FILE",
+		Value: nvidiaDevicePluginConfigDir + "/config.yaml",
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      "config",
+		MountPath: nvidiaDevicePluginConfigDir,
+	})
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: "config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			},
+		},
+	})
+
+	var manifest []byte
+	for _, obj := range []interface{}{configMap, &daemonSet} {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		manifest = append(manifest, "---\n"...)
+		manifest = append(manifest, data...)
+	}
+	return manifest, nil
+}
+
+// makeNvidiaDevicePluginConfig returns the config file of the Nvidia device plugin, see
+// https://github.com/NVIDIA/k8s-device-plugin#configuration-option-details
+func makeNvidiaDevicePluginConfig(gpu *api.NodeGroupGPU) map[string]interface{} {
+	config := map[string]interface{}{
+		"version": "v1",
+	}
+	resourceNames := []string{"nvidia.com/gpu"}
+	if mig := gpu.MIG; mig != nil {
+		config["flags"] = map[string]interface{}{
+			"migStrategy": mig.Strategy,
+		}
+		if mig.Strategy == api.MIGStrategyMixed {
+			resourceNames = migResourceNames(mig.Profiles)
+		}
+	}
+	if gpu.TimeSlicing != nil {
+		var resources []map[string]interface{}
+		for _, name := range resourceNames {
+			resources = append(resources, map[string]interface{}{
+				"name":     name,
+				"replicas": gpu.TimeSlicing.Replicas,
+			})
+		}
+		config["sharing"] = map[string]interface{}{
+			"timeSlicing": map[string]interface{}{
+				"resources": resources,
+			},
+		}
+	}
+	return config
+}
+
+func migResourceNames(profiles []string) []string {
+	seen := map[string]struct{}{}
+	var names []string
+	for _, profile := range profiles {
+		name := "nvidia.com/mig-" + profile
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // A EFADevicePlugin deploys the EFA Device Plugin to a cluster
 type EFADevicePlugin struct {
 	rawClient kubernetes.RawClientInterface
@@ -57,6 +57,39 @@ const (
 	AccessEntryTypeStandard AccessEntryType = "STANDARD"
 )
 
+// ClusterAdminAccessPolicy is the name of the access policy that grants cluster admin permissions.
+const ClusterAdminAccessPolicy = "AmazonEKSClusterAdminPolicy"
+
+// AccessEntriesToCreate returns the access entries to create along with the cluster, including an access entry
+// granting cluster admin permissions to BootstrapAdminPrincipalARN if it is set.
+func (a *AccessConfig) AccessEntriesToCreate() []AccessEntry {
+	if a.BootstrapAdminPrincipalARN == "" {
+		return a.AccessEntries
+	}
+	principalARN, err := arn.Parse(a.BootstrapAdminPrincipalARN)
+	if err != nil {
+		// the ARN is checked during validation
+		return a.AccessEntries
+	}
+	adminEntry := AccessEntry{
+		PrincipalARN: ARN(principalARN),
+		AccessPolicies: []AccessPolicy{
+			{
+				PolicyARN: ARN(arn.ARN{
+					Partition: principalARN.Partition,
+					Service:   "eks",
+					AccountID: "aws",
+					Resource:  "cluster-access-policy/" + ClusterAdminAccessPolicy,
+				}),
+				AccessScope: AccessScope{
+					Type: ekstypes.AccessScopeTypeCluster,
+				},
+			},
+		},
+	}
+	return append(append([]AccessEntry{}, a.AccessEntries...), adminEntry)
+}
+
 // GetAccessEntryType returns the access entry type for the specified AMI family.
 func GetAccessEntryType(ng *NodeGroup) AccessEntryType {
 	if IsWindowsImage(ng.GetAMIFamily()) {
@@ -298,3 +298,54 @@ var _ = DescribeTable("Access Entry validation", func(aet accessEntryTest) {
 		},
 	}),
 )
+
+var _ = Describe("Bootstrap admin principal", func() {
+	DescribeTable("validation", func(authenticationMode ekstypes.AuthenticationMode, principalARN, expectedErr string) {
+		clusterConfig := api.NewClusterConfig()
+		clusterConfig.AccessConfig = &api.AccessConfig{
+			AuthenticationMode:         authenticationMode,
+			BootstrapAdminPrincipalARN: principalARN,
+			AccessEntries: []api.AccessEntry{
+				{
+					PrincipalARN: api.MustParseARN("arn:aws:iam::111122223333:role/role-1"),
+				},
+			},
+		}
+		err := api.ValidateClusterConfig(clusterConfig)
+		if expectedErr != "" {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+	},
+		Entry("valid role ARN", ekstypes.AuthenticationModeApi, "arn:aws:iam::111122223333:role/admin", ""),
+		Entry("CONFIG_MAP authentication mode", ekstypes.AuthenticationModeConfigMap, "arn:aws:iam::111122223333:role/admin",
+			"accessConfig.authenticationMode must be set to either API_AND_CONFIG_MAP or API to use accessConfig.bootstrapAdminPrincipalARN"),
+		Entry("invalid ARN", ekstypes.AuthenticationModeApi, "admin",
+			`accessConfig.bootstrapAdminPrincipalARN must be a valid IAM principal ARN, got "admin"`),
+		Entry("non-IAM ARN", ekstypes.AuthenticationModeApi, "arn:aws:s3:::bucket",
+			`accessConfig.bootstrapAdminPrincipalARN must be a valid IAM principal ARN, got "arn:aws:s3:::bucket"`),
+		Entry("principal with an existing access entry", ekstypes.AuthenticationModeApi, "arn:aws:iam::111122223333:role/role-1",
+			`duplicate access entry accessEntries[1] with principal ARN "arn:aws:iam::111122223333:role/role-1"`),
+	)
+
+	It("grants cluster admin permissions to the principal", func() {
+		accessConfig := &api.AccessConfig{
+			BootstrapAdminPrincipalARN: "arn:aws-cn:iam::111122223333:role/admin",
+		}
+		Expect(accessConfig.AccessEntriesToCreate()).To(Equal([]api.AccessEntry{
+			{
+				PrincipalARN: api.MustParseARN("arn:aws-cn:iam::111122223333:role/admin"),
+				AccessPolicies: []api.AccessPolicy{
+					{
+						PolicyARN: api.MustParseARN("arn:aws-cn:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy"),
+						AccessScope: api.AccessScope{
+							Type: ekstypes.AccessScopeTypeCluster,
+						},
+					},
+				},
+			},
+		}))
+		Expect(accessConfig.AccessEntries).To(BeEmpty())
+	})
+})
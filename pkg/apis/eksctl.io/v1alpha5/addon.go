@@ -2,6 +2,7 @@ package v1alpha5
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -19,6 +20,27 @@ const (
 	PodIdentityAgentAddon = "eks-pod-identity-agent"
 	AWSEBSCSIDriverAddon  = "aws-ebs-csi-driver"
 	AWSEFSCSIDriverAddon  = "aws-efs-csi-driver"
+	ADOTAddon             = "adot"
+
+	AWSMountpointS3CSIDriverAddon = "aws-mountpoint-s3-csi-driver"
+)
+
+// Values for `Addon.SizePreset`
+const (
+	// AddonSizePresetSmall runs a single coredns replica that does not block draining, for clusters of one or two nodes
+	AddonSizePresetSmall = "small"
+	// AddonSizePresetLarge autoscales coredns and spreads its replicas across zones and nodes
+	AddonSizePresetLarge = "large"
+)
+
+// Values for `Addon.AutoUpgradePolicy` and `AddonsConfig.AutoUpgradePolicy`
+const (
+	// AddonUpgradePolicyLatest upgrades an addon to the latest version compatible with the cluster
+	AddonUpgradePolicyLatest = "latest"
+	// AddonUpgradePolicyPatchOnly upgrades an addon to the latest compatible version with the same major and minor version
+	AddonUpgradePolicyPatchOnly = "patch-only"
+	// AddonUpgradePolicyPinned only upgrades an addon to the version set in its config
+	AddonUpgradePolicyPinned = "pinned"
 )
 
 // Addon holds the EKS addon configuration
@@ -59,6 +81,29 @@ type Addon struct {
 	// and have to respect the schema from DescribeAddonConfiguration.
 	// +optional
 	ConfigurationValues string `json:"configurationValues,omitempty"`
+	// SizePreset tunes the replicas, autoscaling, pod disruption budget and topology spread of the
+	// coredns addon for the size of the cluster (valid options: small, large).
+	// Values set in configurationValues take precedence over the preset.
+	// +optional
+	SizePreset string `json:"sizePreset,omitempty"`
+	// VPCCNI configures the IP address management, security groups for pods, network policies and custom networking
+	// of the vpc-cni addon. eksctl renders it into the configuration values of the addon; values set in
+	// configurationValues take precedence.
+	// +optional
+	VPCCNI *VPCCNIConfig `json:"vpcCNI,omitempty"`
+	// Collector renders an OpenTelemetryCollector for the adot addon, so that the operator installed by
+	// the addon runs a collector sending telemetry to the configured AWS services.
+	// +optional
+	Collector *ADOTCollector `json:"collector,omitempty"`
+	// S3Buckets are the buckets the aws-mountpoint-s3-csi-driver addon can mount. eksctl generates an IAM policy
+	// scoped to these buckets and attaches it with a pod identity association if pod identity associations are
+	// enabled for the addon, or with IRSA otherwise.
+	// +optional
+	S3Buckets []S3BucketAccess `json:"s3Buckets,omitempty"`
+	// AutoUpgradePolicy controls the version `eksctl upgrade addon` upgrades the addon to
+	// (valid options: latest, patch-only, pinned). Defaults to `addonsConfig.autoUpgradePolicy`.
+	// +optional
+	AutoUpgradePolicy string `json:"autoUpgradePolicy,omitempty"`
 	// Force overwrites an existing self-managed add-on with an EKS managed add-on.
 	// Force is intended to be used when migrating an existing self-managed add-on to an EKS managed add-on.
 	Force bool `json:"-"`
@@ -82,6 +127,105 @@ type AddonsConfig struct {
 	// By default, all default addons are installed as EKS addons.
 	// +optional
 	DisableDefaultAddons bool `json:"disableDefaultAddons,omitempty"`
+
+	// AutoUpgradePolicy controls the version `eksctl upgrade addon` upgrades addons to, unless set
+	// for an addon (valid options: latest, patch-only, pinned). Defaults to `latest`.
+	// +optional
+	AutoUpgradePolicy string `json:"autoUpgradePolicy,omitempty"`
+}
+
+// UpgradePolicy returns the upgrade policy of the given addon.
+func (c AddonsConfig) UpgradePolicy(addon *Addon) string {
+	if addon != nil && addon.AutoUpgradePolicy != "" {
+		return addon.AutoUpgradePolicy
+	}
+	if c.AutoUpgradePolicy != "" {
+		return c.AutoUpgradePolicy
+	}
+	return AddonUpgradePolicyLatest
+}
+
+// ValidateAddonUpgradePolicy validates an addon upgrade policy.
+func ValidateAddonUpgradePolicy(policy string) error {
+	switch policy {
+	case "", AddonUpgradePolicyLatest, AddonUpgradePolicyPatchOnly, AddonUpgradePolicyPinned:
+		return nil
+	}
+	return fmt.Errorf("autoUpgradePolicy: %q is not valid, valid values are: %s, %s, %s", policy, AddonUpgradePolicyLatest, AddonUpgradePolicyPatchOnly, AddonUpgradePolicyPinned)
+}
+
+// VPCCNIConfig holds the configuration of the vpc-cni addon
+type VPCCNIConfig struct {
+	// WarmIPTarget is the number of free IP addresses each node keeps for new pods (`WARM_IP_TARGET`)
+	// +optional
+	WarmIPTarget *int `json:"warmIPTarget,omitempty"`
+	// MinimumIPTarget is the number of IP addresses each node allocates when it starts (`MINIMUM_IP_TARGET`)
+	// +optional
+	MinimumIPTarget *int `json:"minimumIPTarget,omitempty"`
+	// PrefixDelegation assigns /28 prefixes instead of individual IP addresses to the network interfaces of nodes,
+	// increasing the number of pods each node can run (`ENABLE_PREFIX_DELEGATION`)
+	// +optional
+	PrefixDelegation bool `json:"prefixDelegation,omitempty"`
+	// WarmPrefixTarget is the number of free prefixes each node keeps, requires prefixDelegation
+	// (`WARM_PREFIX_TARGET`)
+	// +optional
+	WarmPrefixTarget *int `json:"warmPrefixTarget,omitempty"`
+	// PodENI enables security groups for pods (`ENABLE_POD_ENI`)
+	// +optional
+	PodENI bool `json:"podENI,omitempty"`
+	// NetworkPolicy enforces Kubernetes network policies, requires vpc-cni v1.14.0 or later
+	// +optional
+	NetworkPolicy bool `json:"networkPolicy,omitempty"`
+	// CustomNetworking runs pods in the subnets and security groups of the ENIConfig named after the availability
+	// zone of their node (`AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG`)
+	// +optional
+	CustomNetworking bool `json:"customNetworking,omitempty"`
+}
+
+// ADOTCollector holds the configuration of the OpenTelemetryCollector run by the adot addon
+type ADOTCollector struct {
+	// ServiceAccountRoleARN is the IAM role of the collector. Defaults to a role created with IRSA, allowed to
+	// write to the configured exporters.
+	// +optional
+	ServiceAccountRoleARN string `json:"serviceAccountRoleARN,omitempty"`
+	// AMP scrapes the metrics of the cluster and writes them to an Amazon Managed Service for Prometheus workspace
+	// +optional
+	AMP *ADOTAMPExporter `json:"amp,omitempty"`
+	// XRay receives traces over OTLP and sends them to AWS X-Ray
+	// +optional
+	XRay *ADOTXRayExporter `json:"xray,omitempty"`
+}
+
+// ADOTAMPExporter holds the configuration of the metrics pipeline of the ADOT collector
+type ADOTAMPExporter struct {
+	// WorkspaceARN is the ARN of the AMP workspace the metrics are written to
+	// +required
+	WorkspaceARN string `json:"workspaceARN"`
+	// ScrapeConfiguration is the Prometheus scrape configuration of the collector, in YAML. Defaults to
+	// scraping the API server, the kubelets, cAdvisor and the pods annotated with prometheus.io/scrape.
+	// +optional
+	ScrapeConfiguration string `json:"scrapeConfiguration,omitempty"`
+}
+
+// S3BucketAccess holds the access of the aws-mountpoint-s3-csi-driver addon to a bucket
+type S3BucketAccess struct {
+	// Name of the bucket
+	// +required
+	Name string `json:"name"`
+	// Prefixes restricts the access to the objects whose keys start with one of the prefixes, e.g. `data/`.
+	// Defaults to all the objects of the bucket.
+	// +optional
+	Prefixes []string `json:"prefixes,omitempty"`
+	// ReadOnly denies writing and deleting objects
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// ADOTXRayExporter holds the configuration of the traces pipeline of the ADOT collector
+type ADOTXRayExporter struct {
+	// Region traces are sent to, defaults to the region of the cluster
+	// +optional
+	Region string `json:"region,omitempty"`
 }
 
 func (a Addon) CanonicalName() string {
@@ -103,6 +247,54 @@ func (a Addon) Validate() error {
 		}
 	}
 
+	if a.SizePreset != "" {
+		if a.CanonicalName() != CoreDNSAddon {
+			return invalidAddonConfigErr(fmt.Sprintf("sizePreset is only supported for the %q addon", CoreDNSAddon))
+		}
+		if a.SizePreset != AddonSizePresetSmall && a.SizePreset != AddonSizePresetLarge {
+			return invalidAddonConfigErr(fmt.Sprintf("sizePreset: %q is not valid, valid values are: %s, %s", a.SizePreset, AddonSizePresetSmall, AddonSizePresetLarge))
+		}
+	}
+
+	if a.VPCCNI != nil {
+		if a.CanonicalName() != VPCCNIAddon {
+			return invalidAddonConfigErr(fmt.Sprintf("vpcCNI is only supported for the %q addon", VPCCNIAddon))
+		}
+		if err := a.VPCCNI.validate(); err != nil {
+			return invalidAddonConfigErr(err.Error())
+		}
+	}
+
+	if err := ValidateAddonUpgradePolicy(a.AutoUpgradePolicy); err != nil {
+		return invalidAddonConfigErr(err.Error())
+	}
+
+	if a.Collector != nil {
+		if a.CanonicalName() != ADOTAddon {
+			return invalidAddonConfigErr(fmt.Sprintf("collector is only supported for the %q addon", ADOTAddon))
+		}
+		if err := a.Collector.validate(); err != nil {
+			return invalidAddonConfigErr(err.Error())
+		}
+	}
+
+	if len(a.S3Buckets) > 0 {
+		if a.CanonicalName() != AWSMountpointS3CSIDriverAddon {
+			return invalidAddonConfigErr(fmt.Sprintf("s3Buckets is only supported for the %q addon", AWSMountpointS3CSIDriverAddon))
+		}
+		if a.HasIRSASet() || a.HasPodIDsSet() {
+			return invalidAddonConfigErr("cannot set s3Buckets along with IRSA config or pod identity associations, the IAM permissions of the addon are generated from s3Buckets")
+		}
+		for i, bucket := range a.S3Buckets {
+			if bucket.Name == "" {
+				return invalidAddonConfigErr(fmt.Sprintf("s3Buckets[%d].name must be set", i))
+			}
+			if strings.ContainsAny(bucket.Name, "/*") {
+				return invalidAddonConfigErr(fmt.Sprintf("s3Buckets[%d].name: %q is not a valid bucket name", i, bucket.Name))
+			}
+		}
+	}
+
 	if a.HasIRSASet() {
 		if a.HasPodIDsSet() {
 			return invalidAddonConfigErr("cannot set IRSA config (`addon.ServiceAccountRoleARN`, `addon.AttachPolicyARNs`, `addon.AttachPolicy`, `addon.WellKnownPolicies`) and pod identity associations at the same time")
@@ -201,3 +393,52 @@ func (a Addon) HasIRSASet() bool {
 func (a Addon) HasPodIDsSet() bool {
 	return a.PodIdentityAssociations != nil && len(*a.PodIdentityAssociations) > 0
 }
+
+func (c *VPCCNIConfig) validate() error {
+	for _, target := range []struct {
+		field string
+		value *int
+	}{
+		{"warmIPTarget", c.WarmIPTarget},
+		{"minimumIPTarget", c.MinimumIPTarget},
+		{"warmPrefixTarget", c.WarmPrefixTarget},
+	} {
+		if target.value != nil && *target.value < 0 {
+			return fmt.Errorf("vpcCNI.%s must not be negative", target.field)
+		}
+	}
+	if c.WarmPrefixTarget != nil {
+		if !c.PrefixDelegation {
+			return errors.New("vpcCNI.warmPrefixTarget requires vpcCNI.prefixDelegation to be enabled")
+		}
+		// the vpc-cni ignores WARM_PREFIX_TARGET when WARM_IP_TARGET or MINIMUM_IP_TARGET is set
+		if c.WarmIPTarget != nil || c.MinimumIPTarget != nil {
+			return errors.New("vpcCNI.warmPrefixTarget cannot be set along with vpcCNI.warmIPTarget or vpcCNI.minimumIPTarget, which take precedence over it")
+		}
+	}
+	if c.WarmIPTarget != nil && *c.WarmIPTarget == 0 && (c.MinimumIPTarget == nil || *c.MinimumIPTarget == 0) {
+		return errors.New("vpcCNI.warmIPTarget cannot be 0 unless vpcCNI.minimumIPTarget is set, or nodes would not allocate any IP addresses for pods")
+	}
+	return nil
+}
+
+func (c *ADOTCollector) validate() error {
+	if c.AMP == nil && c.XRay == nil {
+		return errors.New("collector must have at least one of amp and xray set")
+	}
+	if c.AMP != nil {
+		if c.AMP.WorkspaceARN == "" {
+			return errors.New("collector.amp.workspaceARN must be set")
+		}
+		if err := validatePrometheusWorkspaceARN("collector.amp.workspaceARN", c.AMP.WorkspaceARN); err != nil {
+			return err
+		}
+		if c.AMP.ScrapeConfiguration != "" {
+			var scrapeConfiguration map[string]interface{}
+			if err := yaml.Unmarshal([]byte(c.AMP.ScrapeConfiguration), &scrapeConfiguration); err != nil {
+				return fmt.Errorf("collector.amp.scrapeConfiguration is not valid YAML: %w", err)
+			}
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package v1alpha5_test
 import (
 	"fmt"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -46,6 +47,87 @@ var _ = Describe("Addon", func() {
 			Entry("non-empty yaml", "replicaCount: 3"),
 		)
 
+		DescribeTable("sizePreset",
+			func(addon api.Addon, expectedErr string) {
+				err := addon.Validate()
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				}
+			},
+			Entry("small coredns", api.Addon{Name: api.CoreDNSAddon, SizePreset: api.AddonSizePresetSmall}, ""),
+			Entry("large coredns", api.Addon{Name: api.CoreDNSAddon, SizePreset: api.AddonSizePresetLarge}, ""),
+			Entry("unknown preset", api.Addon{Name: api.CoreDNSAddon, SizePreset: "medium"}, `sizePreset: "medium" is not valid, valid values are: small, large`),
+			Entry("other addon", api.Addon{Name: api.VPCCNIAddon, SizePreset: api.AddonSizePresetSmall}, `sizePreset is only supported for the "coredns" addon`),
+		)
+
+		DescribeTable("vpcCNI",
+			func(addon api.Addon, expectedErr string) {
+				err := addon.Validate()
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				}
+			},
+			Entry("IP targets", api.Addon{Name: api.VPCCNIAddon, VPCCNI: &api.VPCCNIConfig{WarmIPTarget: aws.Int(5), MinimumIPTarget: aws.Int(10)}}, ""),
+			Entry("warm prefix target", api.Addon{Name: api.VPCCNIAddon, VPCCNI: &api.VPCCNIConfig{PrefixDelegation: true, WarmPrefixTarget: aws.Int(1)}}, ""),
+			Entry("other addon", api.Addon{Name: api.CoreDNSAddon, VPCCNI: &api.VPCCNIConfig{PodENI: true}}, `vpcCNI is only supported for the "vpc-cni" addon`),
+			Entry("negative target", api.Addon{Name: api.VPCCNIAddon, VPCCNI: &api.VPCCNIConfig{MinimumIPTarget: aws.Int(-1)}}, "vpcCNI.minimumIPTarget must not be negative"),
+			Entry("warm prefix target without prefix delegation", api.Addon{Name: api.VPCCNIAddon, VPCCNI: &api.VPCCNIConfig{WarmPrefixTarget: aws.Int(1)}},
+				"vpcCNI.warmPrefixTarget requires vpcCNI.prefixDelegation to be enabled"),
+			Entry("warm prefix target with IP targets", api.Addon{Name: api.VPCCNIAddon, VPCCNI: &api.VPCCNIConfig{PrefixDelegation: true, WarmPrefixTarget: aws.Int(1), WarmIPTarget: aws.Int(5)}},
+				"vpcCNI.warmPrefixTarget cannot be set along with vpcCNI.warmIPTarget or vpcCNI.minimumIPTarget"),
+			Entry("no IP addresses", api.Addon{Name: api.VPCCNIAddon, VPCCNI: &api.VPCCNIConfig{WarmIPTarget: aws.Int(0)}},
+				"vpcCNI.warmIPTarget cannot be 0 unless vpcCNI.minimumIPTarget is set"),
+		)
+
+		DescribeTable("collector",
+			func(addon api.Addon, expectedErr string) {
+				err := addon.Validate()
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				}
+			},
+			Entry("AMP and X-Ray", api.Addon{Name: api.ADOTAddon, Collector: &api.ADOTCollector{
+				AMP:  &api.ADOTAMPExporter{WorkspaceARN: "arn:aws:aps:us-west-2:111122223333:workspace/ws-abcd-1234"},
+				XRay: &api.ADOTXRayExporter{},
+			}}, ""),
+			Entry("other addon", api.Addon{Name: api.CoreDNSAddon, Collector: &api.ADOTCollector{XRay: &api.ADOTXRayExporter{}}}, `collector is only supported for the "adot" addon`),
+			Entry("no exporters", api.Addon{Name: api.ADOTAddon, Collector: &api.ADOTCollector{}}, "collector must have at least one of amp and xray set"),
+			Entry("AMP without workspace", api.Addon{Name: api.ADOTAddon, Collector: &api.ADOTCollector{
+				AMP: &api.ADOTAMPExporter{},
+			}}, "collector.amp.workspaceARN must be set"),
+			Entry("invalid workspace ARN", api.Addon{Name: api.ADOTAddon, Collector: &api.ADOTCollector{
+				AMP: &api.ADOTAMPExporter{WorkspaceARN: "ws-abcd-1234"},
+			}}, "collector.amp.workspaceARN must be the ARN of an AMP workspace"),
+		)
+
+		DescribeTable("s3Buckets",
+			func(addon api.Addon, expectedErr string) {
+				err := addon.Validate()
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				}
+			},
+			Entry("bucket with prefixes", api.Addon{Name: api.AWSMountpointS3CSIDriverAddon, S3Buckets: []api.S3BucketAccess{
+				{Name: "my-bucket", Prefixes: []string{"data/"}},
+			}}, ""),
+			Entry("other addon", api.Addon{Name: api.AWSEBSCSIDriverAddon, S3Buckets: []api.S3BucketAccess{{Name: "my-bucket"}}}, `s3Buckets is only supported for the "aws-mountpoint-s3-csi-driver" addon`),
+			Entry("no bucket name", api.Addon{Name: api.AWSMountpointS3CSIDriverAddon, S3Buckets: []api.S3BucketAccess{{}}}, "s3Buckets[0].name must be set"),
+			Entry("invalid bucket name", api.Addon{Name: api.AWSMountpointS3CSIDriverAddon, S3Buckets: []api.S3BucketAccess{{Name: "my-bucket/data"}}}, `s3Buckets[0].name: "my-bucket/data" is not a valid bucket name`),
+			Entry("IRSA config", api.Addon{
+				Name:             api.AWSMountpointS3CSIDriverAddon,
+				AttachPolicyARNs: []string{"arn:aws:iam::aws:policy/AmazonS3FullAccess"},
+				S3Buckets:        []api.S3BucketAccess{{Name: "my-bucket"}},
+			}, "cannot set s3Buckets along with IRSA config or pod identity associations"),
+		)
+
 		When("specifying more than one of serviceAccountRoleARN, attachPolicyARNs, attachPolicy, wellKnownPolicies", func() {
 			It("errors", func() {
 				err := api.Addon{
@@ -0,0 +1,37 @@
+package v1alpha5
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PathOrDefault returns the directory of the repository synced by the app-of-apps Application, defaulting to
+// `clusters/<cluster name>`.
+func (a *ArgoCD) PathOrDefault(clusterName string) string {
+	if a.Path == "" {
+		return fmt.Sprintf("clusters/%s", clusterName)
+	}
+	return a.Path
+}
+
+// IsHTTPS reports whether the repository is accessed over HTTPS rather than SSH.
+func (a *ArgoCD) IsHTTPS() bool {
+	return strings.HasPrefix(a.RepoURL, "https://") || strings.HasPrefix(a.RepoURL, "http://")
+}
+
+// validateArgoCD validates the Argo CD configuration.
+func validateArgoCD(argoCD *ArgoCD) error {
+	if argoCD.RepoURL == "" {
+		return errors.New("gitops.argocd.repoURL must be set")
+	}
+	if credentials := argoCD.Credentials; credentials != nil {
+		if (credentials.SSMParameter == "") == (credentials.SecretID == "") {
+			return errors.New("exactly one of gitops.argocd.credentials.ssmParameter and gitops.argocd.credentials.secretID must be set")
+		}
+		if credentials.Username != "" && !argoCD.IsHTTPS() {
+			return errors.New("gitops.argocd.credentials.username can only be set for HTTPS repositories")
+		}
+	}
+	return nil
+}
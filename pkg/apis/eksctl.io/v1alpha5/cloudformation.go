@@ -0,0 +1,97 @@
+package v1alpha5
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// ClusterCloudFormation holds the settings of the CloudFormation stacks of the cluster
+type ClusterCloudFormation struct {
+	// TerminationProtection enables termination protection on the cluster stack, so that
+	// `eksctl delete cluster` only deletes the cluster with `--disable-protection`.
+	// Defaults to `false`.
+	// +optional
+	TerminationProtection *bool `json:"terminationProtection,omitempty"`
+
+	// StackPolicy is a [stack policy](https://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/protect-stack-resources.html)
+	// set on every stack eksctl creates or updates, e.g. to deny `Update:Replace` on the `VPC` or `ControlPlaneSecurityGroup`
+	// resources of the cluster stack
+	// +optional
+	StackPolicy InlineDocument `json:"stackPolicy,omitempty"`
+
+	// NotificationARNs are the ARNs of up to 5 SNS topics, in the region of the cluster, CloudFormation
+	// publishes the events of the stacks eksctl creates or updates to
+	// +optional
+	NotificationARNs []string `json:"notificationARNs,omitempty"`
+}
+
+// maxStackNotificationARNs is the number of SNS topics CloudFormation can publish the events of a stack to
+const maxStackNotificationARNs = 5
+
+// IsTerminationProtectionEnabled returns true if termination protection is enabled on the cluster stack
+func (c *ClusterConfig) IsTerminationProtectionEnabled() bool {
+	return c.CloudFormation != nil && IsEnabled(c.CloudFormation.TerminationProtection)
+}
+
+// StackPolicyBody returns the stack policy to set on the stacks of the cluster, or an empty string if there is none
+func (c *ClusterConfig) StackPolicyBody() (string, error) {
+	if c.CloudFormation == nil || len(c.CloudFormation.StackPolicy) == 0 {
+		return "", nil
+	}
+	body, err := json.Marshal(c.CloudFormation.StackPolicy)
+	if err != nil {
+		return "", fmt.Errorf("serializing cloudFormation.stackPolicy: %w", err)
+	}
+	return string(body), nil
+}
+
+// StackNotificationARNs returns the SNS topics to publish the events of the stacks of the cluster to
+func (c *ClusterConfig) StackNotificationARNs() []string {
+	if c.CloudFormation == nil {
+		return nil
+	}
+	return c.CloudFormation.NotificationARNs
+}
+
+// ValidateCloudFormation validates the cloudFormation section of the config file.
+func ValidateCloudFormation(cfn *ClusterCloudFormation) error {
+	if cfn == nil {
+		return nil
+	}
+	if len(cfn.NotificationARNs) > maxStackNotificationARNs {
+		return fmt.Errorf("cloudFormation.notificationARNs must contain at most %d topics", maxStackNotificationARNs)
+	}
+	seen := map[string]bool{}
+	for i, topicARN := range cfn.NotificationARNs {
+		parsed, err := arn.Parse(topicARN)
+		if err != nil || parsed.Service != "sns" {
+			return fmt.Errorf("cloudFormation.notificationARNs[%d] %q is not the ARN of an SNS topic", i, topicARN)
+		}
+		if seen[topicARN] {
+			return fmt.Errorf("cloudFormation.notificationARNs[%d] %q is not unique", i, topicARN)
+		}
+		seen[topicARN] = true
+	}
+	if cfn.StackPolicy == nil {
+		return nil
+	}
+	statements, ok := cfn.StackPolicy["Statement"].([]interface{})
+	if !ok || len(statements) == 0 {
+		return errors.New("cloudFormation.stackPolicy must contain a non-empty Statement list")
+	}
+	for i, s := range statements {
+		statement, ok := s.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cloudFormation.stackPolicy.Statement[%d] must be an object", i)
+		}
+		switch statement["Effect"] {
+		case "Allow", "Deny":
+		default:
+			return fmt.Errorf("cloudFormation.stackPolicy.Statement[%d].Effect must be either Allow or Deny", i)
+		}
+	}
+	return nil
+}
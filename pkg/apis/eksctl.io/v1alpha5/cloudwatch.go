@@ -31,6 +31,18 @@ type ClusterCloudWatchLogging struct {
 	// 1827, and 3653.
 	//+optional
 	LogRetentionInDays int `json:"logRetentionInDays,omitempty"`
+	// LogGroupKMSKeyARN is the ARN of the KMS key used to encrypt the control plane logs in CloudWatch. The key policy
+	// must allow the CloudWatch Logs service principal of the region to use the key
+	//+optional
+	LogGroupKMSKeyARN string `json:"logGroupKMSKeyARN,omitempty"`
+	// LogGroupTags are applied to the CloudWatch log group of the control plane logs
+	//+optional
+	LogGroupTags map[string]string `json:"logGroupTags,omitempty"`
+}
+
+// HasLogGroupSettings reports whether the CloudWatch log group of the control plane logs has settings to apply
+func (l *ClusterCloudWatchLogging) HasLogGroupSettings() bool {
+	return l != nil && (l.LogRetentionInDays != 0 || l.LogGroupKMSKeyARN != "" || len(l.LogGroupTags) > 0)
 }
 
 // SupportedCloudWatchClusterLogTypes returns all supported logging facilities
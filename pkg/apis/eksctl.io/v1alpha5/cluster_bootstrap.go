@@ -0,0 +1,236 @@
+package v1alpha5
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// maxUserPriority is the highest value of a priority class not reserved for the system
+const maxUserPriority = 1000000000
+
+// Pod Security Admission levels
+const (
+	PodSecurityPrivileged = "privileged"
+	PodSecurityBaseline   = "baseline"
+	PodSecurityRestricted = "restricted"
+
+	// PodSecurityLabelPrefix is the prefix of the namespace labels holding the Pod Security Admission levels
+	PodSecurityLabelPrefix = "pod-security.kubernetes.io/"
+)
+
+var podSecurityVersionRegex = regexp.MustCompile(`^(latest|v1\.\d+)$`)
+
+// ValidateClusterBootstrap validates the clusterBootstrap configuration.
+func ValidateClusterBootstrap(bootstrap *ClusterBootstrap) error {
+	if bootstrap == nil {
+		return nil
+	}
+
+	if err := validatePodSecurity("clusterBootstrap.podSecurity", bootstrap.PodSecurity); err != nil {
+		return err
+	}
+
+	namespaces := map[string]bool{}
+	for i, ns := range bootstrap.Namespaces {
+		path := fmt.Sprintf("clusterBootstrap.namespaces[%d]", i)
+		if ns.Name == "" {
+			return fmt.Errorf("%s.name must be set", path)
+		}
+		if namespaces[ns.Name] {
+			return fmt.Errorf("namespace %q is defined more than once in clusterBootstrap.namespaces", ns.Name)
+		}
+		namespaces[ns.Name] = true
+		if err := validateQuantities(path+".resourceQuota", ns.ResourceQuota); err != nil {
+			return err
+		}
+		if ns.LimitRange != nil {
+			for field, quantities := range map[string]map[string]string{
+				"default":        ns.LimitRange.Default,
+				"defaultRequest": ns.LimitRange.DefaultRequest,
+				"max":            ns.LimitRange.Max,
+			} {
+				if err := validateQuantities(path+".limitRange."+field, quantities); err != nil {
+					return err
+				}
+			}
+		}
+		if err := validatePodSecurity(path+".podSecurity", ns.PodSecurity); err != nil {
+			return err
+		}
+		// aws-node, kube-proxy, the CSI node plugins and the device plugins run privileged pods in kube-system
+		if enforce := bootstrap.NamespacePodSecurity(ns).Enforce; ns.Name == "kube-system" && enforce != "" && enforce != PodSecurityPrivileged {
+			return fmt.Errorf("namespace kube-system must enforce the %s Pod Security Standard, as it runs the privileged pods of aws-node, kube-proxy and the CSI and device plugins, got %q", PodSecurityPrivileged, enforce)
+		}
+		if bootstrap.NamespacePodSecurity(ns) != (PodSecurity{}) {
+			for label := range ns.Labels {
+				if strings.HasPrefix(label, PodSecurityLabelPrefix) {
+					return fmt.Errorf("%s.labels.%s cannot be set along with podSecurity, set the level in podSecurity instead", path, label)
+				}
+			}
+		}
+	}
+
+	for i, binding := range bootstrap.ClusterRoleBindings {
+		path := fmt.Sprintf("clusterBootstrap.clusterRoleBindings[%d]", i)
+		if binding.Name == "" || binding.ClusterRole == "" || binding.Group == "" {
+			return fmt.Errorf("%s.name, %s.clusterRole and %s.group must be set", path, path, path)
+		}
+		if strings.HasPrefix(binding.Group, "system:") {
+			return fmt.Errorf("%s.group cannot be a system group, got %q", path, binding.Group)
+		}
+		for _, roleARN := range binding.IAMRoleARNs {
+			if _, err := arn.Parse(roleARN); err != nil {
+				return fmt.Errorf("%s.iamRoleARNs: invalid ARN %q: %w", path, roleARN, err)
+			}
+		}
+	}
+
+	for i, pc := range bootstrap.PriorityClasses {
+		path := fmt.Sprintf("clusterBootstrap.priorityClasses[%d]", i)
+		if pc.Name == "" {
+			return fmt.Errorf("%s.name must be set", path)
+		}
+		if strings.HasPrefix(pc.Name, "system-") {
+			return fmt.Errorf("%s.name cannot start with the reserved prefix \"system-\"", path)
+		}
+		if pc.Value > maxUserPriority {
+			return fmt.Errorf("%s.value must be at most %d", path, maxUserPriority)
+		}
+	}
+	return nil
+}
+
+func validatePodSecurity(path string, podSecurity *PodSecurity) error {
+	if podSecurity == nil {
+		return nil
+	}
+	for mode, level := range map[string]string{
+		"enforce": podSecurity.Enforce,
+		"audit":   podSecurity.Audit,
+		"warn":    podSecurity.Warn,
+	} {
+		switch level {
+		case "", PodSecurityPrivileged, PodSecurityBaseline, PodSecurityRestricted:
+		default:
+			return fmt.Errorf("%s.%s must be one of %s, %s or %s, got %q", path, mode, PodSecurityPrivileged, PodSecurityBaseline, PodSecurityRestricted, level)
+		}
+	}
+	if podSecurity.Version != "" && !podSecurityVersionRegex.MatchString(podSecurity.Version) {
+		return fmt.Errorf("%s.version must be either latest or a Kubernetes minor version, e.g. v1.30, got %q", path, podSecurity.Version)
+	}
+	return nil
+}
+
+// NamespacePodSecurity returns the Pod Security Admission levels of a namespace, its own levels overriding the
+// default levels of clusterBootstrap.podSecurity.
+func (c *ClusterBootstrap) NamespacePodSecurity(ns BootstrapNamespace) PodSecurity {
+	var levels PodSecurity
+	for _, podSecurity := range []*PodSecurity{c.PodSecurity, ns.PodSecurity} {
+		if podSecurity == nil {
+			continue
+		}
+		if podSecurity.Enforce != "" {
+			levels.Enforce = podSecurity.Enforce
+		}
+		if podSecurity.Audit != "" {
+			levels.Audit = podSecurity.Audit
+		}
+		if podSecurity.Warn != "" {
+			levels.Warn = podSecurity.Warn
+		}
+		if podSecurity.Version != "" {
+			levels.Version = podSecurity.Version
+		}
+	}
+	return levels
+}
+
+// NamespaceLabels returns the labels of a namespace, along with the labels of its Pod Security Admission levels.
+func (c *ClusterBootstrap) NamespaceLabels(ns BootstrapNamespace) map[string]string {
+	labels := map[string]string{}
+	for k, v := range ns.Labels {
+		labels[k] = v
+	}
+	levels := c.NamespacePodSecurity(ns)
+	for _, mode := range []struct{ name, level string }{
+		{"enforce", levels.Enforce},
+		{"audit", levels.Audit},
+		{"warn", levels.Warn},
+	} {
+		if mode.level == "" {
+			continue
+		}
+		labels[PodSecurityLabelPrefix+mode.name] = mode.level
+		if levels.Version != "" {
+			labels[PodSecurityLabelPrefix+mode.name+"-version"] = levels.Version
+		}
+	}
+	return labels
+}
+
+func validateQuantities(path string, quantities map[string]string) error {
+	for name, value := range quantities {
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return fmt.Errorf("%s.%s: invalid quantity %q: %w", path, name, value, err)
+		}
+	}
+	return nil
+}
+
+// AddClusterBootstrapIAMMappings maps the IAM roles of clusterBootstrap.clusterRoleBindings to their groups, with
+// access entries, or with IAM identity mappings when access entries are disabled, so that they are created along
+// with the other access entries and IAM identity mappings of the cluster.
+func (c *ClusterConfig) AddClusterBootstrapIAMMappings() {
+	if c.ClusterBootstrap == nil {
+		return
+	}
+	withAccessEntries := c.AccessConfig == nil || c.AccessConfig.AuthenticationMode != ekstypes.AuthenticationModeConfigMap
+	for _, binding := range c.ClusterBootstrap.ClusterRoleBindings {
+		for _, roleARN := range binding.IAMRoleARNs {
+			if withAccessEntries {
+				c.addAccessEntryGroup(roleARN, binding.Group)
+			} else {
+				c.addIAMIdentityMappingGroup(roleARN, binding.Group)
+			}
+		}
+	}
+}
+
+func (c *ClusterConfig) addAccessEntryGroup(roleARN, group string) {
+	if c.AccessConfig == nil {
+		c.AccessConfig = &AccessConfig{}
+	}
+	for i, ae := range c.AccessConfig.AccessEntries {
+		if ae.PrincipalARN.String() == roleARN {
+			if !slices.Contains(ae.KubernetesGroups, group) {
+				c.AccessConfig.AccessEntries[i].KubernetesGroups = append(ae.KubernetesGroups, group)
+			}
+			return
+		}
+	}
+	c.AccessConfig.AccessEntries = append(c.AccessConfig.AccessEntries, AccessEntry{
+		PrincipalARN:     MustParseARN(roleARN),
+		KubernetesGroups: []string{group},
+	})
+}
+
+func (c *ClusterConfig) addIAMIdentityMappingGroup(roleARN, group string) {
+	for _, mapping := range c.IAMIdentityMappings {
+		if mapping.ARN == roleARN {
+			if !slices.Contains(mapping.Groups, group) {
+				mapping.Groups = append(mapping.Groups, group)
+			}
+			return
+		}
+	}
+	c.IAMIdentityMappings = append(c.IAMIdentityMappings, &IAMIdentityMapping{
+		ARN:    roleARN,
+		Groups: []string{group},
+	})
+}
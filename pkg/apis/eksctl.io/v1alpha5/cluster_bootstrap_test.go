@@ -0,0 +1,175 @@
+package v1alpha5_test
+
+import (
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("ClusterBootstrap", func() {
+	type clusterBootstrapEntry struct {
+		clusterBootstrap *api.ClusterBootstrap
+		expectedError    string
+	}
+
+	DescribeTable("ValidateClusterBootstrap", func(e clusterBootstrapEntry) {
+		err := api.ValidateClusterBootstrap(e.clusterBootstrap)
+		if e.expectedError != "" {
+			Expect(err).To(MatchError(ContainSubstring(e.expectedError)))
+			return
+		}
+		Expect(err).NotTo(HaveOccurred())
+	},
+		Entry("valid configuration", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{
+				Namespaces: []api.BootstrapNamespace{{
+					Name:          "team-a",
+					ResourceQuota: map[string]string{"requests.cpu": "10", "limits.memory": "20Gi"},
+					LimitRange:    &api.BootstrapLimitRange{Default: map[string]string{"cpu": "500m"}},
+				}},
+				ClusterRoleBindings: []api.BootstrapClusterRoleBinding{{
+					Name:        "team-a-view",
+					ClusterRole: "view",
+					Group:       "team-a-viewers",
+					IAMRoleARNs: []string{"arn:aws:iam::123456789012:role/team-a"},
+				}},
+				PriorityClasses: []api.BootstrapPriorityClass{{Name: "critical", Value: 1000000}},
+			},
+		}),
+		Entry("Pod Security Admission levels", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{
+				PodSecurity: &api.PodSecurity{Enforce: "baseline", Warn: "restricted", Version: "v1.30"},
+				Namespaces: []api.BootstrapNamespace{
+					{Name: "monitoring", PodSecurity: &api.PodSecurity{Enforce: "privileged"}},
+				},
+			},
+		}),
+		Entry("invalid Pod Security Admission level", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{PodSecurity: &api.PodSecurity{Enforce: "strict"}},
+			expectedError:    `clusterBootstrap.podSecurity.enforce must be one of privileged, baseline or restricted, got "strict"`,
+		}),
+		Entry("invalid Pod Security Standards version", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{Namespaces: []api.BootstrapNamespace{{
+				Name:        "team-a",
+				PodSecurity: &api.PodSecurity{Audit: "restricted", Version: "1.30"},
+			}}},
+			expectedError: "clusterBootstrap.namespaces[0].podSecurity.version must be either latest or a Kubernetes minor version",
+		}),
+		Entry("Pod Security Admission label along with podSecurity", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{
+				PodSecurity: &api.PodSecurity{Enforce: "baseline"},
+				Namespaces: []api.BootstrapNamespace{{
+					Name:   "team-a",
+					Labels: map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+				}},
+			},
+			expectedError: "clusterBootstrap.namespaces[0].labels.pod-security.kubernetes.io/enforce cannot be set along with podSecurity",
+		}),
+		Entry("kube-system enforcing the privileged level", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{
+				PodSecurity: &api.PodSecurity{Enforce: "baseline"},
+				Namespaces: []api.BootstrapNamespace{
+					{Name: "kube-system", PodSecurity: &api.PodSecurity{Enforce: "privileged"}},
+				},
+			},
+		}),
+		Entry("kube-system enforcing the default baseline level", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{
+				PodSecurity: &api.PodSecurity{Enforce: "baseline"},
+				Namespaces:  []api.BootstrapNamespace{{Name: "kube-system"}},
+			},
+			expectedError: `namespace kube-system must enforce the privileged Pod Security Standard, as it runs the privileged pods of aws-node, kube-proxy and the CSI and device plugins, got "baseline"`,
+		}),
+		Entry("namespace without name", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{Namespaces: []api.BootstrapNamespace{{}}},
+			expectedError:    "clusterBootstrap.namespaces[0].name must be set",
+		}),
+		Entry("duplicate namespace", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{Namespaces: []api.BootstrapNamespace{{Name: "team-a"}, {Name: "team-a"}}},
+			expectedError:    `namespace "team-a" is defined more than once`,
+		}),
+		Entry("invalid quota", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{Namespaces: []api.BootstrapNamespace{{
+				Name:          "team-a",
+				ResourceQuota: map[string]string{"requests.cpu": "ten"},
+			}}},
+			expectedError: `clusterBootstrap.namespaces[0].resourceQuota.requests.cpu: invalid quantity "ten"`,
+		}),
+		Entry("invalid limit", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{Namespaces: []api.BootstrapNamespace{{
+				Name:       "team-a",
+				LimitRange: &api.BootstrapLimitRange{Max: map[string]string{"memory": "lots"}},
+			}}},
+			expectedError: `clusterBootstrap.namespaces[0].limitRange.max.memory: invalid quantity "lots"`,
+		}),
+		Entry("system group", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{ClusterRoleBindings: []api.BootstrapClusterRoleBinding{{
+				Name:        "admins",
+				ClusterRole: "cluster-admin",
+				Group:       "system:masters",
+			}}},
+			expectedError: "clusterBootstrap.clusterRoleBindings[0].group cannot be a system group",
+		}),
+		Entry("invalid IAM role ARN", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{ClusterRoleBindings: []api.BootstrapClusterRoleBinding{{
+				Name:        "team-a-view",
+				ClusterRole: "view",
+				Group:       "team-a-viewers",
+				IAMRoleARNs: []string{"team-a"},
+			}}},
+			expectedError: `invalid ARN "team-a"`,
+		}),
+		Entry("reserved priority class name", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{PriorityClasses: []api.BootstrapPriorityClass{{Name: "system-critical", Value: 1}}},
+			expectedError:    `cannot start with the reserved prefix "system-"`,
+		}),
+		Entry("priority class value too high", clusterBootstrapEntry{
+			clusterBootstrap: &api.ClusterBootstrap{PriorityClasses: []api.BootstrapPriorityClass{{Name: "critical", Value: 2000000000}}},
+			expectedError:    "clusterBootstrap.priorityClasses[0].value must be at most 1000000000",
+		}),
+	)
+
+	Describe("NamespacePodSecurity", func() {
+		It("overrides the default levels with the levels of the namespace", func() {
+			bootstrap := &api.ClusterBootstrap{
+				PodSecurity: &api.PodSecurity{Enforce: "restricted", Audit: "restricted", Version: "v1.30"},
+			}
+			ns := api.BootstrapNamespace{Name: "monitoring", PodSecurity: &api.PodSecurity{Enforce: "privileged"}}
+			Expect(bootstrap.NamespacePodSecurity(ns)).To(Equal(api.PodSecurity{Enforce: "privileged", Audit: "restricted", Version: "v1.30"}))
+			Expect(bootstrap.NamespacePodSecurity(api.BootstrapNamespace{Name: "team-a"})).To(Equal(*bootstrap.PodSecurity))
+		})
+	})
+
+	Describe("AddClusterBootstrapIAMMappings", func() {
+		const roleARN = "arn:aws:iam::123456789012:role/team-a"
+		var cfg *api.ClusterConfig
+
+		BeforeEach(func() {
+			cfg = api.NewClusterConfig()
+			cfg.ClusterBootstrap = &api.ClusterBootstrap{
+				ClusterRoleBindings: []api.BootstrapClusterRoleBinding{
+					{Name: "team-a-view", ClusterRole: "view", Group: "team-a-viewers", IAMRoleARNs: []string{roleARN}},
+					{Name: "team-a-edit", ClusterRole: "edit", Group: "team-a-editors", IAMRoleARNs: []string{roleARN}},
+				},
+			}
+		})
+
+		It("adds the groups to an access entry of the role", func() {
+			cfg.AddClusterBootstrapIAMMappings()
+			Expect(cfg.AccessConfig.AccessEntries).To(HaveLen(1))
+			Expect(cfg.AccessConfig.AccessEntries[0].PrincipalARN.String()).To(Equal(roleARN))
+			Expect(cfg.AccessConfig.AccessEntries[0].KubernetesGroups).To(ConsistOf("team-a-viewers", "team-a-editors"))
+		})
+
+		It("adds the groups to an IAM identity mapping when access entries are disabled", func() {
+			cfg.AccessConfig.AuthenticationMode = ekstypes.AuthenticationModeConfigMap
+			cfg.AddClusterBootstrapIAMMappings()
+			Expect(cfg.AccessConfig.AccessEntries).To(BeEmpty())
+			Expect(cfg.IAMIdentityMappings).To(HaveLen(1))
+			Expect(cfg.IAMIdentityMappings[0].ARN).To(Equal(roleARN))
+			Expect(cfg.IAMIdentityMappings[0].Groups).To(ConsistOf("team-a-viewers", "team-a-editors"))
+		})
+	})
+})
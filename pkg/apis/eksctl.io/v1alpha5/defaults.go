@@ -49,8 +49,18 @@ func SetClusterConfigDefaults(cfg *ClusterConfig) {
 		if sa.Namespace == "" {
 			sa.Namespace = metav1.NamespaceDefault
 		}
+		if sa.AttachRoleARN == "" {
+			if sa.PermissionsBoundary == "" {
+				sa.PermissionsBoundary = cfg.IAM.WithPermissionsBoundary
+			}
+			if sa.RolePath == "" {
+				sa.RolePath = cfg.IAM.RolePath
+			}
+		}
 	}
 
+	setPermissionsBoundaryDefaults(cfg)
+
 	if cfg.HasClusterCloudWatchLogging() && cfg.ContainsWildcardCloudWatchLogging() {
 		cfg.CloudWatch.ClusterLogging.EnableTypes = SupportedCloudWatchClusterLogTypes()
 	}
@@ -66,6 +76,9 @@ func SetClusterConfigDefaults(cfg *ClusterConfig) {
 	if cfg.PrivateCluster == nil {
 		cfg.PrivateCluster = &PrivateCluster{}
 	}
+	if adminAccess := cfg.PrivateCluster.AdminAccess; adminAccess != nil && adminAccess.Type == PrivateClusterAdminAccessBastion && adminAccess.InstanceType == "" {
+		adminAccess.InstanceType = DefaultBastionInstanceType
+	}
 
 	if cfg.VPC != nil && cfg.VPC.ManageSharedNodeSecurityGroupRules == nil {
 		cfg.VPC.ManageSharedNodeSecurityGroupRules = Enabled()
@@ -74,6 +87,40 @@ func SetClusterConfigDefaults(cfg *ClusterConfig) {
 	if cfg.Karpenter != nil && cfg.Karpenter.CreateServiceAccount == nil {
 		cfg.Karpenter.CreateServiceAccount = Disabled()
 	}
+
+	if cfg.IsAutoModeEnabled() {
+		if cfg.AutoModeConfig.NodePools == nil {
+			cfg.AutoModeConfig.NodePools = &[]string{AutoModeNodePoolGeneralPurpose, AutoModeNodePoolSystem}
+		}
+		// networking, DNS and storage are built into Auto Mode nodes
+		cfg.AddonsConfig.DisableDefaultAddons = true
+	}
+}
+
+// setPermissionsBoundaryDefaults applies iam.withPermissionsBoundary to the roles that don't set their own;
+// nodegroup roles pick it up when they are built, as instanceRolePermissionsBoundary conflicts with an existing role
+func setPermissionsBoundaryDefaults(cfg *ClusterConfig) {
+	boundary := cfg.IAM.WithPermissionsBoundary
+	if boundary == "" {
+		return
+	}
+	if !IsSetAndNonEmptyString(cfg.IAM.ServiceRolePermissionsBoundary) {
+		cfg.IAM.ServiceRolePermissionsBoundary = &boundary
+	}
+	if !IsSetAndNonEmptyString(cfg.IAM.FargatePodExecutionRolePermissionsBoundary) {
+		cfg.IAM.FargatePodExecutionRolePermissionsBoundary = &boundary
+	}
+	for i := range cfg.IAM.PodIdentityAssociations {
+		pia := &cfg.IAM.PodIdentityAssociations[i]
+		if pia.RoleARN == "" && pia.PermissionsBoundaryARN == "" {
+			pia.PermissionsBoundaryARN = boundary
+		}
+	}
+	for _, addon := range cfg.Addons {
+		if addon.ServiceAccountRoleARN == "" && addon.PermissionsBoundary == "" {
+			addon.PermissionsBoundary = boundary
+		}
+	}
 }
 
 // IAMServiceAccountsWithImplicitServiceAccounts adds implicitly created
@@ -177,6 +224,9 @@ func setNodeGroupBaseDefaults(ng *NodeGroupBase, meta *ClusterMeta) {
 		ng.Labels = make(map[string]string)
 	}
 	setDefaultNodeLabels(ng.Labels, meta.Name, ng.Name)
+	if ng.GPU != nil {
+		setGPUDefaults(ng)
+	}
 
 	if ng.DisableIMDSv1 == nil {
 		ng.DisableIMDSv1 = Enabled()
@@ -341,6 +391,19 @@ func setDefaultNodeLabels(labels map[string]string, clusterName, nodeGroupName s
 	labels[NodeGroupNameLabel] = nodeGroupName
 }
 
+func setGPUDefaults(ng *NodeGroupBase) {
+	ng.Labels[GPUSharingLabel] = "true"
+	if mig := ng.GPU.MIG; mig != nil && mig.Strategy == "" {
+		mig.Strategy = MIGStrategySingle
+		for _, profile := range mig.Profiles {
+			if profile != mig.Profiles[0] {
+				mig.Strategy = MIGStrategyMixed
+				break
+			}
+		}
+	}
+}
+
 func setBottlerocketNodeGroupDefaults(ng *NodeGroupBase) {
 	// Initialize config object if not present.
 	if ng.Bottlerocket == nil {
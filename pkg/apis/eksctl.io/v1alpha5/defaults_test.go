@@ -252,6 +252,37 @@ var _ = Describe("ClusterConfig validation", func() {
 		})
 	})
 
+	Context("GPU sharing settings", func() {
+		It("labels the nodes and defaults the MIG strategy to single for identical profiles", func() {
+			testNodeGroup := NodeGroup{
+				NodeGroupBase: &NodeGroupBase{
+					GPU: &NodeGroupGPU{
+						MIG: &GPUMIG{Profiles: []string{"3g.20gb", "3g.20gb"}},
+					},
+				},
+			}
+
+			SetNodeGroupDefaults(&testNodeGroup, &ClusterMeta{}, false)
+
+			Expect(testNodeGroup.Labels).To(HaveKeyWithValue(GPUSharingLabel, "true"))
+			Expect(testNodeGroup.GPU.MIG.Strategy).To(Equal(MIGStrategySingle))
+		})
+
+		It("defaults the MIG strategy to mixed for different profiles", func() {
+			testNodeGroup := ManagedNodeGroup{
+				NodeGroupBase: &NodeGroupBase{
+					GPU: &NodeGroupGPU{
+						MIG: &GPUMIG{Profiles: []string{"4g.40gb", "2g.20gb", "1g.10gb"}},
+					},
+				},
+			}
+
+			SetManagedNodeGroupDefaults(&testNodeGroup, &ClusterMeta{}, false)
+
+			Expect(testNodeGroup.GPU.MIG.Strategy).To(Equal(MIGStrategyMixed))
+		})
+	})
+
 	Context("Cluster NAT settings", func() {
 
 		It("Cluster NAT defaults to single NAT gateway mode", func() {
@@ -340,6 +371,54 @@ var _ = Describe("ClusterConfig validation", func() {
 
 	})
 
+	Describe("IAM permissions boundary and role path", func() {
+		const boundary = "arn:aws:iam::123456789012:policy/boundary"
+		var cfg *ClusterConfig
+
+		BeforeEach(func() {
+			cfg = NewClusterConfig()
+			cfg.IAM.WithPermissionsBoundary = boundary
+			cfg.IAM.RolePath = "/eksctl/"
+		})
+
+		It("applies them to roles that don't set their own", func() {
+			cfg.IAM.ServiceAccounts = []*ClusterIAMServiceAccount{
+				{ClusterIAMMeta: ClusterIAMMeta{Name: "sa-1"}},
+				{ClusterIAMMeta: ClusterIAMMeta{Name: "sa-2"}, PermissionsBoundary: "arn:aws:iam::123456789012:policy/other", RolePath: "/other/"},
+				{ClusterIAMMeta: ClusterIAMMeta{Name: "sa-3"}, AttachRoleARN: "arn:aws:iam::123456789012:role/existing"},
+			}
+			cfg.IAM.PodIdentityAssociations = []PodIdentityAssociation{
+				{Namespace: "default", ServiceAccountName: "pia-1"},
+				{Namespace: "default", ServiceAccountName: "pia-2", RoleARN: "arn:aws:iam::123456789012:role/existing"},
+			}
+			cfg.Addons = []*Addon{{Name: "vpc-cni"}}
+
+			SetClusterConfigDefaults(cfg)
+
+			Expect(*cfg.IAM.ServiceRolePermissionsBoundary).To(Equal(boundary))
+			Expect(*cfg.IAM.FargatePodExecutionRolePermissionsBoundary).To(Equal(boundary))
+
+			Expect(cfg.IAM.ServiceAccounts[0].PermissionsBoundary).To(Equal(boundary))
+			Expect(cfg.IAM.ServiceAccounts[0].RolePath).To(Equal("/eksctl/"))
+			Expect(cfg.IAM.ServiceAccounts[1].PermissionsBoundary).To(Equal("arn:aws:iam::123456789012:policy/other"))
+			Expect(cfg.IAM.ServiceAccounts[1].RolePath).To(Equal("/other/"))
+			Expect(cfg.IAM.ServiceAccounts[2].PermissionsBoundary).To(BeEmpty())
+			Expect(cfg.IAM.ServiceAccounts[2].RolePath).To(BeEmpty())
+
+			Expect(cfg.IAM.PodIdentityAssociations[0].PermissionsBoundaryARN).To(Equal(boundary))
+			Expect(cfg.IAM.PodIdentityAssociations[1].PermissionsBoundaryARN).To(BeEmpty())
+
+			Expect(cfg.Addons[0].PermissionsBoundary).To(Equal(boundary))
+		})
+
+		It("keeps role-specific permissions boundaries", func() {
+			cfg.IAM.ServiceRolePermissionsBoundary = aws.String("arn:aws:iam::123456789012:policy/service")
+			SetClusterConfigDefaults(cfg)
+			Expect(*cfg.IAM.ServiceRolePermissionsBoundary).To(Equal("arn:aws:iam::123456789012:policy/service"))
+			Expect(*cfg.IAM.FargatePodExecutionRolePermissionsBoundary).To(Equal(boundary))
+		})
+	})
+
 	Context("Authentication Mode", func() {
 		var (
 			cfg *ClusterConfig
@@ -0,0 +1,55 @@
+package v1alpha5
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Values for `dns.externalDNS.policy`
+const (
+	// ExternalDNSPolicyUpsertOnly never deletes records.
+	ExternalDNSPolicyUpsertOnly = "upsert-only"
+	// ExternalDNSPolicySync deletes the records of deleted services and ingresses.
+	ExternalDNSPolicySync = "sync"
+)
+
+// HasDNS reports whether external-dns or cert-manager is configured.
+func (c *ClusterConfig) HasDNS() bool {
+	return c.DNS != nil && (c.DNS.ExternalDNS != nil || c.DNS.CertManager != nil)
+}
+
+// PolicyOrDefault returns the policy of external-dns, defaulting to upsert-only.
+func (e *ExternalDNS) PolicyOrDefault() string {
+	if e.Policy == "" {
+		return ExternalDNSPolicyUpsertOnly
+	}
+	return e.Policy
+}
+
+// ValidateDNS validates the DNS configuration.
+func ValidateDNS(dns *DNS) error {
+	if dns == nil {
+		return nil
+	}
+	if dns.ExternalDNS == nil && dns.CertManager == nil {
+		return errors.New("at least one of dns.externalDNS and dns.certManager must be set")
+	}
+	if len(dns.HostedZoneIDs) == 0 {
+		return errors.New("dns.hostedZoneIDs must be set")
+	}
+	for i, id := range dns.HostedZoneIDs {
+		if id == "" || strings.Contains(id, "/") {
+			return fmt.Errorf("dns.hostedZoneIDs[%d]: %q is not a valid hosted zone ID, use the ID without the /hostedzone/ prefix", i, id)
+		}
+	}
+	if dns.ExternalDNS != nil {
+		switch dns.ExternalDNS.PolicyOrDefault() {
+		case ExternalDNSPolicyUpsertOnly, ExternalDNSPolicySync:
+		default:
+			return fmt.Errorf("invalid value %q for dns.externalDNS.policy; supported values are %q and %q",
+				dns.ExternalDNS.Policy, ExternalDNSPolicyUpsertOnly, ExternalDNSPolicySync)
+		}
+	}
+	return nil
+}
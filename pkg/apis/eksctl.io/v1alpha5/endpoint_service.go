@@ -92,6 +92,18 @@ func RequiredEndpointServices(controlPlaneOnOutposts bool) []EndpointService {
 	return requiredServices
 }
 
+// SSMEndpointServices returns the endpoint services required to connect to instances with SSM Session Manager.
+func SSMEndpointServices() []EndpointService {
+	var ssmServices []EndpointService
+	for _, es := range EndpointServices {
+		switch es.Name {
+		case "ssm", "ssmmessages", "ec2messages":
+			ssmServices = append(ssmServices, es)
+		}
+	}
+	return ssmServices
+}
+
 // MapOptionalEndpointServices maps a list of endpoint service names to []EndpointService.
 func MapOptionalEndpointServices(endpointServiceNames []string, cloudWatchLoggingEnabled bool) ([]EndpointService, error) {
 	optionalServices := getOptionalEndpointServices()
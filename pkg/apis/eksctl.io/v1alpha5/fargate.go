@@ -0,0 +1,73 @@
+package v1alpha5
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// fargateNamespaceRegex matches the namespaces of Fargate profile selectors, which can hold the wildcards `*`,
+// matching any number of characters, and `?`, matching a single character
+var fargateNamespaceRegex = regexp.MustCompile(`^[a-z0-9*?]([-a-z0-9*?]*[a-z0-9*?])?$`)
+
+// maxNamespaceLength is the maximum length of the name of a Kubernetes namespace
+const maxNamespaceLength = 63
+
+func validateFargateSelectorNamespace(namespace string) error {
+	if len(namespace) > maxNamespaceLength || !fargateNamespaceRegex.MatchString(namespace) {
+		return fmt.Errorf("invalid namespace %q: must consist of lower case alphanumeric characters, '-' and the wildcards '*' and '?'", namespace)
+	}
+	return nil
+}
+
+// MatchesNamespace returns true if the namespace of the selector, which may hold wildcards, matches the given namespace.
+func (fps FargateProfileSelector) MatchesNamespace(namespace string) bool {
+	return matchWildcard(fps.Namespace, namespace)
+}
+
+// Matches returns true if the selector selects the pods of the given namespace with the given labels, the namespace
+// and the labels of the selector may hold wildcards.
+func (fps FargateProfileSelector) Matches(namespace string, labels map[string]string) bool {
+	if !fps.MatchesNamespace(namespace) {
+		return false
+	}
+	for selectorKey, selectorValue := range fps.Labels {
+		matched := false
+		for key, value := range labels {
+			if matchWildcard(selectorKey, key) && matchWildcard(selectorValue, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// matchWildcard matches s against pattern, where `*` matches any number of characters and `?` a single character
+func matchWildcard(pattern, s string) bool {
+	// position of the last `*` in pattern, and of the character of s it is matched up to
+	star, starMatch := -1, 0
+	p := 0
+	for i := 0; i < len(s); {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, starMatch = p, i
+			p++
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == s[i]):
+			p++
+			i++
+		case star != -1:
+			p = star + 1
+			starMatch++
+			i = starMatch
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
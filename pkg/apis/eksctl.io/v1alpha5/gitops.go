@@ -0,0 +1,46 @@
+package v1alpha5
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ValidateGitOps validates the GitOps configuration.
+func ValidateGitOps(gitOps *GitOps) error {
+	if gitOps == nil {
+		return nil
+	}
+	if gitOps.Flux != nil && gitOps.ArgoCD != nil {
+		return errors.New("gitops.flux and gitops.argocd cannot be set at the same time")
+	}
+	if gitOps.Flux != nil {
+		return validateFlux(gitOps.Flux)
+	}
+	if gitOps.ArgoCD != nil {
+		return validateArgoCD(gitOps.ArgoCD)
+	}
+	return nil
+}
+
+// validateFlux validates the Flux configuration.
+func validateFlux(flux *Flux) error {
+	for _, f := range []struct {
+		flag, field string
+		set         bool
+	}{
+		{"components", "components", len(flux.Components) > 0},
+		{"components-extra", "componentsExtra", len(flux.ComponentsExtra) > 0},
+		{"registry", "registry", flux.Registry != ""},
+		{"image-pull-secret", "imagePullSecret", flux.ImagePullSecret != ""},
+	} {
+		if _, ok := flux.Flags[f.flag]; ok && f.set {
+			return fmt.Errorf("gitops.flux.flags.%s and gitops.flux.%s cannot be set at the same time", f.flag, f.field)
+		}
+	}
+	for i, patch := range flux.Patches {
+		if patch.Patch == "" {
+			return fmt.Errorf("gitops.flux.patches[%d].patch must be set", i)
+		}
+	}
+	return nil
+}
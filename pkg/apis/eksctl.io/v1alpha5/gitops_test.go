@@ -0,0 +1,79 @@
+package v1alpha5_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("GitOps validation", func() {
+	type gitOpsEntry struct {
+		gitOps        *api.GitOps
+		expectedError string
+	}
+
+	DescribeTable("ValidateGitOps", func(e gitOpsEntry) {
+		err := api.ValidateGitOps(e.gitOps)
+		if e.expectedError != "" {
+			Expect(err).To(MatchError(ContainSubstring(e.expectedError)))
+			return
+		}
+		Expect(err).NotTo(HaveOccurred())
+	},
+		Entry("Argo CD with an SSM parameter", gitOpsEntry{
+			gitOps: &api.GitOps{ArgoCD: &api.ArgoCD{
+				RepoURL:     "https://github.com/org/fleet.git",
+				Credentials: &api.ArgoCDCredentials{SSMParameter: "/fleet/token", Username: "bot"},
+			}},
+		}),
+		Entry("Flux with components and patches", gitOpsEntry{
+			gitOps: &api.GitOps{Flux: &api.Flux{
+				GitProvider:     "gitlab",
+				Flags:           api.FluxFlags{"owner": "org"},
+				ComponentsExtra: []string{"image-reflector-controller", "image-automation-controller"},
+				Patches:         []api.FluxPatch{{Patch: "- op: remove\n  path: /spec/replicas"}},
+			}},
+		}),
+		Entry("Flux registry in flags and config", gitOpsEntry{
+			gitOps: &api.GitOps{Flux: &api.Flux{
+				GitProvider: "github",
+				Flags:       api.FluxFlags{"registry": "registry.example.com/fluxcd"},
+				Registry:    "mirror.example.com/fluxcd",
+			}},
+			expectedError: "gitops.flux.flags.registry and gitops.flux.registry cannot be set at the same time",
+		}),
+		Entry("Flux patch without patch", gitOpsEntry{
+			gitOps: &api.GitOps{Flux: &api.Flux{
+				GitProvider: "github",
+				Patches:     []api.FluxPatch{{Target: &api.FluxPatchTarget{Kind: "Deployment"}}},
+			}},
+			expectedError: "gitops.flux.patches[0].patch must be set",
+		}),
+		Entry("Argo CD and Flux", gitOpsEntry{
+			gitOps: &api.GitOps{
+				Flux:   &api.Flux{GitProvider: "github"},
+				ArgoCD: &api.ArgoCD{RepoURL: "https://github.com/org/fleet.git"},
+			},
+			expectedError: "gitops.flux and gitops.argocd cannot be set at the same time",
+		}),
+		Entry("Argo CD without repository", gitOpsEntry{
+			gitOps:        &api.GitOps{ArgoCD: &api.ArgoCD{}},
+			expectedError: "gitops.argocd.repoURL must be set",
+		}),
+		Entry("credentials in SSM and Secrets Manager", gitOpsEntry{
+			gitOps: &api.GitOps{ArgoCD: &api.ArgoCD{
+				RepoURL:     "https://github.com/org/fleet.git",
+				Credentials: &api.ArgoCDCredentials{SSMParameter: "/fleet/token", SecretID: "fleet"},
+			}},
+			expectedError: "exactly one of",
+		}),
+		Entry("username of an SSH repository", gitOpsEntry{
+			gitOps: &api.GitOps{ArgoCD: &api.ArgoCD{
+				RepoURL:     "git@github.com:org/fleet.git",
+				Credentials: &api.ArgoCDCredentials{SecretID: "fleet", Username: "bot"},
+			}},
+			expectedError: "can only be set for HTTPS repositories",
+		}),
+	)
+})
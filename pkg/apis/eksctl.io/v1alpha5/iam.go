@@ -44,6 +44,17 @@ type ClusterIAM struct {
 	// +optional
 	FargatePodExecutionRolePermissionsBoundary *string `json:"fargatePodExecutionRolePermissionsBoundary,omitempty"`
 
+	// permissions boundary for every IAM role created by eksctl, used unless a more specific
+	// permissions boundary is set for the role.
+	// See [Permissions Boundary](/usage/iam-permissions-boundary/)
+	// +optional
+	WithPermissionsBoundary string `json:"withPermissionsBoundary,omitempty"`
+
+	// path for every IAM role created by eksctl, e.g. `/eksctl/`; it must begin and end with `/`.
+	// See [IAM identifiers](https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_identifiers.html#identifiers-friendly-names)
+	// +optional
+	RolePath string `json:"rolePath,omitempty"`
+
 	// enables the IAM OIDC provider as well as IRSA for the Amazon CNI plugin
 	// +optional
 	WithOIDC *bool `json:"withOIDC,omitempty"`
@@ -106,6 +117,12 @@ type ClusterIAMServiceAccount struct {
 	// ARN of the role to attach to the service account
 	AttachRoleARN string `json:"attachRoleARN,omitempty"`
 
+	// ARN of a role in another AWS account that eksctl assumes to create the
+	// service account's IAM role (and the cluster's IAM OIDC provider, if missing) in that account.
+	// See [Cross-account IAM roles](/usage/iamserviceaccounts/#cross-account-iam-roles)
+	// +optional
+	TargetAccountRoleARN string `json:"targetAccountRoleARN,omitempty"`
+
 	// ARN of the permissions boundary to associate with the service account
 	// +optional
 	PermissionsBoundary string `json:"permissionsBoundary,omitempty"`
@@ -117,6 +134,10 @@ type ClusterIAMServiceAccount struct {
 	// +optional
 	RoleName string `json:"roleName,omitempty"`
 
+	// path of the IAM role, defaults to `iam.rolePath`
+	// +optional
+	RolePath string `json:"rolePath,omitempty"`
+
 	// Specify if only the IAM Service Account role should be created without creating/annotating the service account
 	// +optional
 	RoleOnly *bool `json:"roleOnly,omitempty"`
@@ -15,6 +15,10 @@ type IAMIdentityMapping struct {
 	ServiceName     string   `json:"serviceName,omitempty"`
 	Namespace       string   `json:"namespace,omitempty"`
 	NoDuplicateARNs bool     `json:"noDuplicateARNs,omitempty"`
+	// name of an IAM Identity Center permission set, whose role in the cluster's account is mapped
+	// instead of ARN
+	// +optional
+	SSOPermissionSet string `json:"ssoPermissionSet,omitempty"`
 }
 
 func (im *IAMIdentityMapping) hasARNOptions() bool {
@@ -48,6 +52,10 @@ func (im *IAMIdentityMapping) Validate() error {
 		}
 	}
 
+	if im.SSOPermissionSet != "" && (im.hasARN() || im.Account != "" || im.ServiceName != "") {
+		return errors.New("ssoPermissionSet cannot be used with arn, account or serviceName")
+	}
+
 	if im.Account != "" && (im.hasARN() || im.hasUsername() || im.hasGroups() || im.ServiceName != "" || im.Namespace != "") {
 		return errors.New("account cannot be configured with any other options")
 	}
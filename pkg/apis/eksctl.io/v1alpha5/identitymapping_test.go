@@ -47,4 +47,20 @@ var _ = Describe("IAMIdentityMapping", func() {
 		err := m.Validate()
 		Expect(err).To(HaveOccurred())
 	})
+	It("errors when ARN is configured with ssoPermissionSet", func() {
+		m := v1alpha5.IAMIdentityMapping{
+			SSOPermissionSet: "AdministratorAccess",
+			ARN:              "a:fake:arn:hasbeenconfigured",
+		}
+		err := m.Validate()
+		Expect(err).To(MatchError("ssoPermissionSet cannot be used with arn, account or serviceName"))
+	})
+	It("accepts ssoPermissionSet with username and groups", func() {
+		m := v1alpha5.IAMIdentityMapping{
+			SSOPermissionSet: "AdministratorAccess",
+			Username:         "admin:{{SessionName}}",
+			Groups:           []string{"system:masters"},
+		}
+		Expect(m.Validate()).To(Succeed())
+	})
 })
@@ -0,0 +1,66 @@
+package v1alpha5
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultKubeconfigContextName is the template of the name of the contexts eksctl writes by default.
+const DefaultKubeconfigContextName = "{{.Username}}@{{.ClusterName}}.{{.Region}}.eksctl.io"
+
+// KubeconfigContextData holds the fields available to the context name templates of KubeconfigConfig.
+type KubeconfigContextData struct {
+	ClusterName string
+	Region      string
+	Username    string
+}
+
+// RenderKubeconfigContextName executes the context name template tmpl with data.
+func RenderKubeconfigContextName(tmpl string, data KubeconfigContextData) (string, error) {
+	t, err := template.New("context").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parsing context name template %q: %w", tmpl, err)
+	}
+	var name strings.Builder
+	if err := t.Execute(&name, data); err != nil {
+		return "", fmt.Errorf("executing context name template %q: %w", tmpl, err)
+	}
+	if strings.TrimSpace(name.String()) == "" {
+		return "", fmt.Errorf("context name template %q renders an empty name", tmpl)
+	}
+	return name.String(), nil
+}
+
+// ValidateKubeconfig validates the kubeconfig section of the config file.
+func ValidateKubeconfig(kc *KubeconfigConfig) error {
+	if kc == nil {
+		return nil
+	}
+	sample := KubeconfigContextData{ClusterName: "cluster", Region: "us-west-2", Username: "user"}
+	contextName := kc.ContextName
+	if contextName == "" {
+		contextName = DefaultKubeconfigContextName
+	}
+	name, err := RenderKubeconfigContextName(contextName, sample)
+	if err != nil {
+		return fmt.Errorf("kubeconfig.contextName: %w", err)
+	}
+	names := map[string]bool{name: true}
+	for i, alias := range kc.AliasContexts {
+		name, err := RenderKubeconfigContextName(alias, sample)
+		if err != nil {
+			return fmt.Errorf("kubeconfig.aliasContexts[%d]: %w", i, err)
+		}
+		if names[name] {
+			return fmt.Errorf("kubeconfig.aliasContexts[%d] %q names the same context as another context", i, alias)
+		}
+		names[name] = true
+	}
+	for name := range kc.ExecEnv {
+		if name == "" || strings.Contains(name, "=") {
+			return fmt.Errorf("kubeconfig.execEnv contains an invalid environment variable name %q", name)
+		}
+	}
+	return nil
+}
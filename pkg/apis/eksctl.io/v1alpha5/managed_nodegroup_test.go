@@ -251,5 +251,13 @@ var _ = Describe("Managed Nodegroup Validation", func() {
 		Entry("returns an error if both maxUnavailable and maxUnavailablePercentage are not set", updateConfigEntry{
 			valid: false,
 		}),
+		Entry("returns an error if max unavailable is zero", updateConfigEntry{
+			unavailable: aws.Int(0),
+			valid:       false,
+		}),
+		Entry("returns an error if max unavailable percentage is greater than 100", updateConfigEntry{
+			unavailablePercentage: aws.Int(150),
+			valid:                 false,
+		}),
 	)
 })
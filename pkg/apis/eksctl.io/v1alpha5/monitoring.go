@@ -0,0 +1,79 @@
+package v1alpha5
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// Values for `monitoring.prometheus.collector`
+const (
+	// PrometheusCollectorManagedScraper is an EKS managed scraper, which runs outside of the cluster.
+	PrometheusCollectorManagedScraper = "managed-scraper"
+	// PrometheusCollectorADOT is the AWS Distro for OpenTelemetry collector, installed in the cluster.
+	PrometheusCollectorADOT = "adot"
+)
+
+// HasPrometheus reports whether the metrics of the cluster are sent to Amazon Managed Service for Prometheus.
+func (c *ClusterConfig) HasPrometheus() bool {
+	return c.Monitoring != nil && c.Monitoring.Prometheus != nil
+}
+
+// CollectorOrDefault returns the collector of the metrics, defaulting to an EKS managed scraper.
+func (p *PrometheusMonitoring) CollectorOrDefault() string {
+	if p.Collector == "" {
+		return PrometheusCollectorManagedScraper
+	}
+	return p.Collector
+}
+
+// ValidateMonitoring validates the monitoring configuration.
+func ValidateMonitoring(monitoring *Monitoring) error {
+	if monitoring == nil || monitoring.Prometheus == nil {
+		return nil
+	}
+	prometheus := monitoring.Prometheus
+	switch prometheus.CollectorOrDefault() {
+	case PrometheusCollectorManagedScraper:
+		if prometheus.ADOTVersion != "" {
+			return errors.New("monitoring.prometheus.adotVersion can only be set with the adot collector")
+		}
+	case PrometheusCollectorADOT:
+		if prometheus.ADOTVersion == "" {
+			return errors.New("monitoring.prometheus.adotVersion must be set with the adot collector")
+		}
+	default:
+		return fmt.Errorf("invalid value %q for monitoring.prometheus.collector; supported values are %q and %q",
+			prometheus.Collector, PrometheusCollectorManagedScraper, PrometheusCollectorADOT)
+	}
+	if prometheus.WorkspaceARN != "" {
+		if prometheus.WorkspaceAlias != "" {
+			return errors.New("monitoring.prometheus.workspaceAlias cannot be set with an existing workspace")
+		}
+		return validatePrometheusWorkspaceARN("monitoring.prometheus.workspaceARN", prometheus.WorkspaceARN)
+	}
+	return nil
+}
+
+func validatePrometheusWorkspaceARN(path, workspaceARN string) error {
+	parsed, err := arn.Parse(workspaceARN)
+	if err != nil || parsed.Service != "aps" || !strings.HasPrefix(parsed.Resource, "workspace/") {
+		return fmt.Errorf("%s must be the ARN of an AMP workspace, got %q", path, workspaceARN)
+	}
+	return nil
+}
+
+// PrometheusWorkspaceEndpoint returns the Prometheus endpoint of the AMP workspace with the given ARN.
+func PrometheusWorkspaceEndpoint(workspaceARN string) (string, error) {
+	parsed, err := arn.Parse(workspaceARN)
+	if err != nil {
+		return "", err
+	}
+	dnsSuffix, err := Partitions.DNSSuffixForRegion(parsed.Region)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://aps-workspaces.%s.%s/workspaces/%s/", parsed.Region, dnsSuffix, strings.TrimPrefix(parsed.Resource, "workspace/")), nil
+}
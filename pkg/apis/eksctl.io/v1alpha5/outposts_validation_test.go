@@ -169,6 +169,56 @@ var _ = Describe("Outposts validation", func() {
 			expectedErr: "iam.withOIDC is not supported on Outposts",
 		}),
 
+		Entry("Outpost in a different region", outpostsEntry{
+			updateDefaultConfig: func(c *api.ClusterConfig) {
+				c.Metadata.Region = "us-east-1"
+			},
+
+			expectedErr: `outpost.controlPlaneOutpostARN must be in the cluster's region "us-east-1"; got "us-west-2"`,
+		}),
+
+		Entry("controlPlanePlacement without groupName", outpostsEntry{
+			updateDefaultConfig: func(c *api.ClusterConfig) {
+				c.Outpost.ControlPlanePlacement = &api.Placement{}
+			},
+
+			expectedErr: "outpost.controlPlanePlacement.groupName must be set when outpost.controlPlanePlacement is specified",
+		}),
+
+		Entry("iam.podIdentityAssociations", outpostsEntry{
+			updateDefaultConfig: func(c *api.ClusterConfig) {
+				c.IAM.PodIdentityAssociations = []api.PodIdentityAssociation{
+					{
+						Namespace:          "default",
+						ServiceAccountName: "sa",
+					},
+				}
+			},
+
+			expectedErr: "Pod Identity Associations are not supported on Outposts",
+		}),
+
+		Entry("addonsConfig.autoApplyPodIdentityAssociations", outpostsEntry{
+			updateDefaultConfig: func(c *api.ClusterConfig) {
+				c.AddonsConfig.AutoApplyPodIdentityAssociations = true
+			},
+
+			expectedErr: "Pod Identity Associations are not supported on Outposts",
+		}),
+
+		Entry("privateCluster.adminAccess", outpostsEntry{
+			updateDefaultConfig: func(c *api.ClusterConfig) {
+				c.PrivateCluster = &api.PrivateCluster{
+					Enabled: true,
+					AdminAccess: &api.PrivateClusterAdminAccess{
+						Type: api.PrivateClusterAdminAccessBastion,
+					},
+				}
+			},
+
+			expectedErr: "privateCluster.adminAccess is not supported on Outposts",
+		}),
+
 		Entry("nodeGroup.outpostARN set in a fully-private cluster", outpostsEntry{
 			updateDefaultConfig: func(c *api.ClusterConfig) {
 				c.Outpost = nil
@@ -1,6 +1,9 @@
 package v1alpha5
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
 // Partitions.
 const (
@@ -17,6 +20,7 @@ type partition struct {
 	serviceMappings             map[string]string
 	regions                     []string
 	endpointServiceDomainPrefix string
+	dnsSuffix                   string
 }
 
 type partitions []partition
@@ -27,12 +31,16 @@ var standardServiceMappings = map[string]string{
 	"EKSFargatePods": "eks-fargate-pods.amazonaws.com",
 }
 
-const standardPartitionServiceDomainPrefix = "com.amazonaws"
+const (
+	standardPartitionServiceDomainPrefix = "com.amazonaws"
+	standardPartitionDNSSuffix           = "amazonaws.com"
+)
 
 var awsPartition = partition{
 	name:                        PartitionAWS,
 	serviceMappings:             standardServiceMappings,
 	endpointServiceDomainPrefix: standardPartitionServiceDomainPrefix,
+	dnsSuffix:                   standardPartitionDNSSuffix,
 }
 
 // Partitions is a list of supported AWS partitions.
@@ -43,6 +51,7 @@ var Partitions = partitions{
 		serviceMappings:             standardServiceMappings,
 		regions:                     []string{RegionUSGovEast1, RegionUSGovWest1},
 		endpointServiceDomainPrefix: standardPartitionServiceDomainPrefix,
+		dnsSuffix:                   standardPartitionDNSSuffix,
 	},
 	{
 		name: PartitionChina,
@@ -53,6 +62,7 @@ var Partitions = partitions{
 		},
 		regions:                     []string{RegionCNNorth1, RegionCNNorthwest1},
 		endpointServiceDomainPrefix: fmt.Sprintf("cn.%s", standardPartitionServiceDomainPrefix),
+		dnsSuffix:                   "amazonaws.com.cn",
 	},
 	{
 		name: PartitionISO,
@@ -63,6 +73,7 @@ var Partitions = partitions{
 		},
 		regions:                     []string{RegionUSISOEast1, RegionUSISOWest1},
 		endpointServiceDomainPrefix: "gov.ic.c2s",
+		dnsSuffix:                   "c2s.ic.gov",
 	},
 	{
 		name: PartitionISOB,
@@ -73,19 +84,45 @@ var Partitions = partitions{
 		},
 		regions:                     []string{RegionUSISOBEast1},
 		endpointServiceDomainPrefix: "gov.sgov.sc2s",
+		dnsSuffix:                   "sc2s.sgov.gov",
 	},
 }
 
 // ForRegion returns the partition a region belongs to.
 func (p partitions) ForRegion(region string) string {
+	return p.forRegion(region).name
+}
+
+// forRegion returns the partition a region belongs to.
+func (p partitions) forRegion(region string) partition {
 	for _, pt := range p {
 		for _, r := range pt.regions {
 			if r == region {
-				return pt.name
+				return pt
 			}
 		}
 	}
-	return PartitionAWS
+	return awsPartition
+}
+
+// ServicePrincipalForRegion returns the principal of service, a key of the service mappings such as EC2,
+// in the partition region belongs to.
+func (p partitions) ServicePrincipalForRegion(service, region string) string {
+	return p.forRegion(region).serviceMappings[service]
+}
+
+// DNSSuffixForRegion returns the DNS suffix of the service endpoints in region, e.g. amazonaws.com.cn for China regions.
+// It fails for regions eksctl does not know, as it cannot tell which partition, and therefore which suffix, they use.
+func (p partitions) DNSSuffixForRegion(region string) (string, error) {
+	for _, pt := range p {
+		if slices.Contains(pt.regions, region) {
+			return pt.dnsSuffix, nil
+		}
+	}
+	if slices.Contains(SupportedRegions(), region) {
+		return awsPartition.dnsSuffix, nil
+	}
+	return "", fmt.Errorf("unable to determine the DNS suffix of unknown region %q", region)
 }
 
 // GetEndpointServiceDomainPrefix returns the domain prefix for the endpoint service.
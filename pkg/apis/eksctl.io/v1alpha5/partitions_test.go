@@ -0,0 +1,60 @@
+package v1alpha5_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("Partitions", func() {
+	type partitionEntry struct {
+		region            string
+		expectedPartition string
+		expectedDNSSuffix string
+	}
+
+	DescribeTable("resolving partition and DNS suffix for a region", func(e partitionEntry) {
+		Expect(api.Partitions.ForRegion(e.region)).To(Equal(e.expectedPartition))
+		dnsSuffix, err := api.Partitions.DNSSuffixForRegion(e.region)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dnsSuffix).To(Equal(e.expectedDNSSuffix))
+	},
+		Entry("standard region", partitionEntry{
+			region:            api.RegionUSWest2,
+			expectedPartition: api.PartitionAWS,
+			expectedDNSSuffix: "amazonaws.com",
+		}),
+		Entry("GovCloud region", partitionEntry{
+			region:            api.RegionUSGovWest1,
+			expectedPartition: api.PartitionUSGov,
+			expectedDNSSuffix: "amazonaws.com",
+		}),
+		Entry("China region", partitionEntry{
+			region:            api.RegionCNNorthwest1,
+			expectedPartition: api.PartitionChina,
+			expectedDNSSuffix: "amazonaws.com.cn",
+		}),
+		Entry("ISO region", partitionEntry{
+			region:            api.RegionUSISOEast1,
+			expectedPartition: api.PartitionISO,
+			expectedDNSSuffix: "c2s.ic.gov",
+		}),
+		Entry("ISO-B region", partitionEntry{
+			region:            api.RegionUSISOBEast1,
+			expectedPartition: api.PartitionISOB,
+			expectedDNSSuffix: "sc2s.sgov.gov",
+		}),
+	)
+
+	It("returns the service principals of the region's partition", func() {
+		Expect(api.Partitions.ServicePrincipalForRegion("EC2", api.RegionUSWest2)).To(Equal("ec2.amazonaws.com"))
+		Expect(api.Partitions.ServicePrincipalForRegion("EC2", api.RegionCNNorth1)).To(Equal("ec2.amazonaws.com.cn"))
+		Expect(api.Partitions.ServicePrincipalForRegion("EKS", api.RegionCNNorth1)).To(Equal("eks.amazonaws.com"))
+	})
+
+	It("fails to resolve the DNS suffix of an unknown region", func() {
+		_, err := api.Partitions.DNSSuffixForRegion("xy-central-9")
+		Expect(err).To(MatchError(`unable to determine the DNS suffix of unknown region "xy-central-9"`))
+	})
+})
@@ -0,0 +1,152 @@
+package v1alpha5
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// PullThroughCacheUpstreamRegistries maps the well-known aliases of upstream registries to their hostnames.
+var PullThroughCacheUpstreamRegistries = map[string]string{
+	"docker-hub": "registry-1.docker.io",
+	"quay":       "quay.io",
+	"ghcr":       "ghcr.io",
+	"k8s":        "registry.k8s.io",
+	"ecr-public": "public.ecr.aws",
+	"gitlab":     "registry.gitlab.com",
+}
+
+// ecrUpstreamRegistries maps the hostnames of the upstream registries to the upstream registry types of ECR.
+var ecrUpstreamRegistries = map[string]string{
+	"registry-1.docker.io": "docker-hub",
+	"quay.io":              "quay",
+	"ghcr.io":              "github-container-registry",
+	"registry.k8s.io":      "k8s",
+	"public.ecr.aws":       "ecr-public",
+	"registry.gitlab.com":  "gitlab-container-registry",
+}
+
+// upstreamRegistriesRequiringCredentials is the set of upstream registry types ECR only caches with credentials.
+var upstreamRegistriesRequiringCredentials = map[string]bool{
+	"docker-hub":                true,
+	"github-container-registry": true,
+	"gitlab-container-registry": true,
+	"azure-container-registry":  true,
+}
+
+const azureContainerRegistrySuffix = ".azurecr.io"
+
+const pullThroughCacheSecretPrefix = "ecr-pullthroughcache/"
+
+var ecrRepositoryPrefixPattern = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*$`)
+
+// UpstreamRegistryHost returns the hostname of the upstream registry of the rule.
+func (r PullThroughCacheRule) UpstreamRegistryHost() string {
+	if host, ok := PullThroughCacheUpstreamRegistries[r.UpstreamRegistry]; ok {
+		return host
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(r.UpstreamRegistry, "https://"), "/")
+}
+
+// ECRUpstreamRegistry returns the upstream registry type ECR expects for the rule, or an empty string if
+// the upstream registry is not one ECR knows about.
+func (r PullThroughCacheRule) ECRUpstreamRegistry() string {
+	host := r.UpstreamRegistryHost()
+	if strings.HasSuffix(host, azureContainerRegistrySuffix) {
+		return "azure-container-registry"
+	}
+	return ecrUpstreamRegistries[host]
+}
+
+// RepositoryPrefix returns the ECR repository prefix of the rule, defaulting to the alias of the upstream registry.
+func (r PullThroughCacheRule) RepositoryPrefix() string {
+	if r.ECRRepositoryPrefix != "" {
+		return r.ECRRepositoryPrefix
+	}
+	if _, ok := PullThroughCacheUpstreamRegistries[r.UpstreamRegistry]; ok {
+		return r.UpstreamRegistry
+	}
+	return strings.NewReplacer(".", "-", ":", "-").Replace(r.UpstreamRegistryHost())
+}
+
+// HasPullThroughCache returns true if ECR pull-through cache rules are configured.
+func (c *ClusterConfig) HasPullThroughCache() bool {
+	return c.PullThroughCache != nil && len(c.PullThroughCache.Rules) > 0
+}
+
+// ECRRegistryHost returns the hostname of the private ECR registry of the account in the cluster's region.
+func (c *ClusterConfig) ECRRegistryHost() (string, error) {
+	dnsSuffix, err := Partitions.DNSSuffixForRegion(c.Metadata.Region)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s.dkr.ecr.%s.%s", c.Metadata.AccountID, c.Metadata.Region, dnsSuffix), nil
+}
+
+// ValidatePullThroughCache validates the ECR pull-through cache rules.
+func ValidatePullThroughCache(ptc *PullThroughCache) error {
+	if ptc == nil {
+		return nil
+	}
+	if len(ptc.Rules) == 0 {
+		return errors.New("pullThroughCache.rules must contain at least one rule")
+	}
+	prefixes := map[string]bool{}
+	for i, rule := range ptc.Rules {
+		path := fmt.Sprintf("pullThroughCache.rules[%d]", i)
+		if rule.UpstreamRegistry == "" {
+			return fmt.Errorf("%s.upstreamRegistry must be set", path)
+		}
+		prefix := rule.RepositoryPrefix()
+		if len(prefix) < 2 || len(prefix) > 30 || !ecrRepositoryPrefixPattern.MatchString(prefix) {
+			return fmt.Errorf("%s.ecrRepositoryPrefix %q must be 2 to 30 lowercase letters, numbers, hyphens, underscores or periods", path, prefix)
+		}
+		if prefixes[prefix] {
+			return fmt.Errorf("%s.ecrRepositoryPrefix %q is used by more than one rule", path, prefix)
+		}
+		prefixes[prefix] = true
+
+		if rule.CredentialARN == "" {
+			if upstreamRegistriesRequiringCredentials[rule.ECRUpstreamRegistry()] {
+				return fmt.Errorf("%s.credentialARN must be set for upstream registry %s", path, rule.UpstreamRegistryHost())
+			}
+			continue
+		}
+		parsed, err := arn.Parse(rule.CredentialARN)
+		if err != nil || parsed.Service != "secretsmanager" {
+			return fmt.Errorf("%s.credentialARN must be the ARN of a Secrets Manager secret, got %q", path, rule.CredentialARN)
+		}
+		if !strings.HasPrefix(parsed.Resource, "secret:"+pullThroughCacheSecretPrefix) {
+			return fmt.Errorf("%s.credentialARN must reference a secret whose name starts with %q", path, pullThroughCacheSecretPrefix)
+		}
+	}
+	return nil
+}
+
+// validatePullThroughCacheNodeGroups rejects nodegroups whose AMI family can't be configured to pull through
+// the ECR pull-through cache.
+func validatePullThroughCacheNodeGroups(cfg *ClusterConfig) error {
+	if !cfg.HasPullThroughCache() {
+		return nil
+	}
+	validateAMIFamily := func(path, amiFamily string) error {
+		if amiFamily == NodeImageFamilyBottlerocket || IsWindowsImage(amiFamily) {
+			return fmt.Errorf("%s.amiFamily %s is not supported with pullThroughCache, as its nodes can't be configured to pull through ECR", path, amiFamily)
+		}
+		return nil
+	}
+	for i, ng := range cfg.NodeGroups {
+		if err := validateAMIFamily(fmt.Sprintf("nodeGroups[%d]", i), ng.AMIFamily); err != nil {
+			return err
+		}
+	}
+	for i, ng := range cfg.ManagedNodeGroups {
+		if err := validateAMIFamily(fmt.Sprintf("managedNodeGroups[%d]", i), ng.AMIFamily); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -280,6 +280,9 @@ const (
 	// ClusterNameTag defines the tag of the cluster name
 	ClusterNameTag = "alpha.eksctl.io/cluster-name"
 
+	// ClusterExpiresAtTag defines the tag holding the time after which a cluster created with a TTL may be deleted
+	ClusterExpiresAtTag = "alpha.eksctl.io/expires-at"
+
 	// ClusterOIDCEnabledTag determines whether OIDC is enabled or not.
 	ClusterOIDCEnabledTag = "alpha.eksctl.io/cluster-oidc-enabled"
 
@@ -292,6 +295,10 @@ const (
 	// NodeGroupTypeTag defines the nodegroup type as managed or unmanaged
 	NodeGroupTypeTag = "alpha.eksctl.io/nodegroup-type"
 
+	// NodeGroupAMIHistoryTag defines the tag of the AMIs previously used by a nodegroup with a custom AMI,
+	// most recent first, recorded by `eksctl upgrade nodegroup --ami`
+	NodeGroupAMIHistoryTag = "alpha.eksctl.io/nodegroup-ami-history"
+
 	// OldNodeGroupNameTag defines the tag of the nodegroup name
 	OldNodeGroupNameTag = "eksctl.io/v1alpha2/nodegroup-name"
 
@@ -316,6 +323,9 @@ const (
 	// NodeGroupNameLabel defines the label of the nodegroup name
 	NodeGroupNameLabel = "alpha.eksctl.io/nodegroup-name"
 
+	// GPUSharingLabel defines the label of nodes whose GPUs are shared with time-slicing or MIG
+	GPUSharingLabel = "alpha.eksctl.io/gpu-sharing"
+
 	// KarpenterNameTag defines the tag of the Karpenter stack name
 	KarpenterNameTag = "alpha.eksctl.io/karpenter-name"
 
@@ -705,11 +715,44 @@ type ClusterMeta struct {
 	// Annotations are arbitrary metadata ignored by `eksctl`.
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// TTL is how long the cluster is meant to live for, e.g. `4h`; the expiry time is recorded
+	// in the `alpha.eksctl.io/expires-at` tag, and `eksctl gc --expired` deletes clusters past it
+	// +optional
+	TTL string `json:"ttl,omitempty"`
 	// Internal fields
 	// AccountID the ID of the account hosting this cluster
 	AccountID string `json:"-"`
 }
 
+// SetExpiryTag records the time at which the cluster expires, based on TTL, in ClusterExpiresAtTag
+func (c *ClusterMeta) SetExpiryTag(now time.Time) error {
+	if c.TTL == "" {
+		return nil
+	}
+	ttl, err := time.ParseDuration(c.TTL)
+	if err != nil {
+		return fmt.Errorf("invalid metadata.ttl %q: %w", c.TTL, err)
+	}
+	if c.Tags == nil {
+		c.Tags = map[string]string{}
+	}
+	c.Tags[ClusterExpiresAtTag] = now.Add(ttl).UTC().Format(time.RFC3339)
+	return nil
+}
+
+// ClusterExpiry returns the expiry time recorded in ClusterExpiresAtTag, and false if the tag is not set
+func ClusterExpiry(tags map[string]string) (time.Time, bool, error) {
+	value, ok := tags[ClusterExpiresAtTag]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid value %q for tag %s: %w", value, ClusterExpiresAtTag, err)
+	}
+	return expiresAt, true, nil
+}
+
 // KubernetesNetworkConfig contains cluster networking options
 type KubernetesNetworkConfig struct {
 	// Valid variants are `IPFamily` constants
@@ -869,6 +912,50 @@ type ProviderConfig struct {
 	Region      string
 	Profile     Profile
 	WaitTimeout time.Duration
+
+	// UseFIPSEndpoint selects the FIPS endpoints of the AWS APIs.
+	UseFIPSEndpoint bool
+	// ServiceEndpoints overrides the endpoints of individual AWS APIs.
+	ServiceEndpoints *ServiceEndpoints
+	// Proxy holds the HTTPS proxy and CA bundle used by the AWS clients.
+	Proxy ProxyConfig
+	// AssumeRole, when its RoleARN is set, is assumed using the credentials of Profile.
+	AssumeRole AssumeRoleConfig
+	// DebugRequests, when set, logs every AWS API call in the given format, either `text` or `json`.
+	DebugRequests string
+	// Retry configures how AWS API calls are retried and rate limited.
+	Retry RetryConfig
+	// ResponseCache configures the caching of the responses of read-only AWS API calls on disk.
+	ResponseCache ResponseCacheConfig
+	// PageSize, when set, is the number of items requested per call of the EKS list operations.
+	PageSize int
+}
+
+// ResponseCacheConfig holds the settings for caching the responses of read-only AWS API calls.
+type ResponseCacheConfig struct {
+	// Disabled bypasses the cache.
+	Disabled bool
+	// Operations are the cached operations, in the form `<service ID>.<operation>`, e.g. `EKS.DescribeCluster`.
+	// Defaults to the operations used for resolving AMIs.
+	Operations []string
+}
+
+// Retry modes of the AWS clients.
+const (
+	RetryModeStandard = "standard"
+	RetryModeAdaptive = "adaptive"
+)
+
+// RetryConfig holds the retry and rate limiting settings of the AWS clients.
+type RetryConfig struct {
+	// Mode is the retry mode, either `standard` or `adaptive`.
+	// Adaptive mode additionally slows down requests to an API once it throttles them.
+	Mode string
+	// MaxAttempts is the maximum number of attempts of an API call, including the first one.
+	MaxAttempts int
+	// MaxRequestsPerSecond limits the rate of AWS API calls made by eksctl, including retries.
+	// The rate is not limited if zero.
+	MaxRequestsPerSecond float64
 }
 
 // Profile is the AWS profile to use.
@@ -877,6 +964,17 @@ type Profile struct {
 	SourceIsEnvVar bool
 }
 
+// AssumeRoleConfig holds the settings for assuming an IAM role.
+type AssumeRoleConfig struct {
+	RoleARN         string
+	ExternalID      string
+	RoleSessionName string
+	// SessionTags are passed as session tags to AssumeRole.
+	SessionTags map[string]string
+	// MFASerial is the serial number or ARN of the MFA device, the token code is read from stdin.
+	MFASerial string
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -931,10 +1029,17 @@ type ClusterConfig struct {
 	// +optional
 	FargateProfiles []*FargateProfile `json:"fargateProfiles,omitempty"`
 
+	// FargateLogging configures the log router built into Fargate to ship the logs of the
+	// pods running on Fargate to CloudWatch, Kinesis Data Firehose or OpenSearch.
+	// For more information, see [Fargate logging](/usage/fargate-support/#logging)
+	// +optional
+	FargateLogging *FargateLogging `json:"fargateLogging,omitempty"`
+
 	// +optional
 	AvailabilityZones []string `json:"availabilityZones,omitempty"`
 
 	// LocalZones specifies a list of local zones where the subnets should be created.
+	// Wavelength Zones are supported as well.
 	// Only self-managed nodegroups can be launched in local zones. These subnets are not passed to EKS.
 	// +optional
 	LocalZones []string `json:"localZones,omitempty"`
@@ -943,6 +1048,10 @@ type ClusterConfig struct {
 	// +optional
 	CloudWatch *ClusterCloudWatch `json:"cloudWatch,omitempty"`
 
+	// CloudFormation configures the CloudFormation stacks of the cluster
+	// +optional
+	CloudFormation *ClusterCloudFormation `json:"cloudFormation,omitempty"`
+
 	// +optional
 	SecretsEncryption *SecretsEncryption `json:"secretsEncryption,omitempty"`
 
@@ -959,6 +1068,399 @@ type ClusterConfig struct {
 	// Outpost specifies the Outpost configuration.
 	// +optional
 	Outpost *Outpost `json:"outpost,omitempty"`
+
+	// AutoModeConfig specifies the configuration for EKS Auto Mode.
+	// For more information and examples, see [Auto Mode](/usage/auto-mode/)
+	// +optional
+	AutoModeConfig *AutoModeConfig `json:"autoModeConfig,omitempty"`
+
+	// ServiceEndpoints overrides the endpoints eksctl uses to call AWS APIs,
+	// e.g. for PrivateLink-only environments or testing against a local emulator.
+	// +optional
+	ServiceEndpoints *ServiceEndpoints `json:"serviceEndpoints,omitempty"`
+
+	// Proxy configures the HTTPS proxy and CA bundle eksctl uses to reach the AWS and
+	// Kubernetes APIs, and optionally the proxy settings of the nodes.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
+	// Integrations configures third-party integrations, which are applied once the cluster
+	// has been created. For more information, see [Integrations](/usage/integrations/)
+	// +optional
+	Integrations []IntegrationConfig `json:"integrations,omitempty"`
+
+	// Backups configures Velero to back up the cluster to S3, see `eksctl enable backups`.
+	// For more information, see [Backups](/usage/backups/)
+	// +optional
+	Backups *Backups `json:"backups,omitempty"`
+
+	// OrganizationDefaults declares where the defaults and guardrails of the AWS account are stored,
+	// e.g. by the account vending process of AWS Control Tower.
+	// For more information, see [Organization defaults](/usage/organization-defaults/)
+	// +optional
+	OrganizationDefaults *OrganizationDefaults `json:"organizationDefaults,omitempty"`
+
+	// PullThroughCache creates ECR pull-through cache rules for upstream registries and
+	// configures containerd on the nodes to pull images through them.
+	// For more information, see [ECR pull-through cache](/usage/pull-through-cache/)
+	// +optional
+	PullThroughCache *PullThroughCache `json:"pullThroughCache,omitempty"`
+
+	// Kubeconfig configures the kubeconfig written by `create cluster` and `utils write-kubeconfig`,
+	// so that everyone using the config file gets the same contexts.
+	// For more information, see [Kubeconfig](/usage/kubeconfig/)
+	// +optional
+	Kubeconfig *KubeconfigConfig `json:"kubeconfig,omitempty"`
+
+	// Monitoring configures the monitoring of the cluster, see `eksctl enable monitoring`.
+	// For more information, see [Monitoring](/usage/monitoring/)
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// DNS installs external-dns and cert-manager, allowed to change the records of Route53 hosted zones,
+	// see `eksctl enable dns`.
+	// For more information, see [DNS](/usage/dns/)
+	// +optional
+	DNS *DNS `json:"dns,omitempty"`
+
+	// ClusterBootstrap holds the namespaces, cluster role bindings and priority classes
+	// eksctl applies right after creating the cluster, and on `eksctl bootstrap`.
+	// For more information, see [Cluster bootstrap](/usage/cluster-bootstrap/)
+	// +optional
+	ClusterBootstrap *ClusterBootstrap `json:"clusterBootstrap,omitempty"`
+}
+
+// KubeconfigConfig holds the settings of the kubeconfig of the cluster.
+type KubeconfigConfig struct {
+	// Path is the kubeconfig file to write to. `--kubeconfig` and `--auto-kubeconfig` take precedence over it.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// SetContext sets the current context of the kubeconfig to the context of the cluster.
+	// `--set-kubeconfig-context` takes precedence over it. Defaults to `true`.
+	// +optional
+	SetContext *bool `json:"setContext,omitempty"`
+	// ContextName is a Go template for the name of the context, with the fields `.ClusterName`,
+	// `.Region` and `.Username`, e.g. `{{.ClusterName}}-{{.Region}}`.
+	// Defaults to `{{.Username}}@{{.ClusterName}}.{{.Region}}.eksctl.io`.
+	// +optional
+	ContextName string `json:"contextName,omitempty"`
+	// AliasContexts are the names of additional contexts for the cluster and user of the context,
+	// as Go templates with the same fields as `contextName`.
+	// +optional
+	AliasContexts []string `json:"aliasContexts,omitempty"`
+	// ExecEnv are environment variables passed to the authenticator, e.g. `AWS_PROFILE`.
+	// +optional
+	ExecEnv map[string]string `json:"execEnv,omitempty"`
+}
+
+// PullThroughCache holds the ECR pull-through cache rules of the cluster.
+type PullThroughCache struct {
+	// Rules is the list of upstream registries to cache in ECR.
+	// +required
+	Rules []PullThroughCacheRule `json:"rules"`
+}
+
+// PullThroughCacheRule holds the configuration of an ECR pull-through cache rule.
+type PullThroughCacheRule struct {
+	// UpstreamRegistry is the hostname of the upstream registry, or one of the well-known
+	// aliases `docker-hub`, `quay`, `ghcr`, `k8s`, `ecr-public` and `gitlab`.
+	// +required
+	UpstreamRegistry string `json:"upstreamRegistry"`
+	// ECRRepositoryPrefix is the prefix of the ECR repositories images are cached in.
+	// Defaults to the alias of the upstream registry.
+	// +optional
+	ECRRepositoryPrefix string `json:"ecrRepositoryPrefix,omitempty"`
+	// CredentialARN is the ARN of the Secrets Manager secret holding the credentials of
+	// the upstream registry. The name of the secret must start with `ecr-pullthroughcache/`.
+	// Required for Docker Hub, GitHub Container Registry and GitLab Container Registry.
+	// +optional
+	CredentialARN string `json:"credentialARN,omitempty"`
+}
+
+// Monitoring holds the monitoring configuration of the cluster.
+type Monitoring struct {
+	// Prometheus sends the metrics of the cluster to Amazon Managed Service for Prometheus.
+	// +optional
+	Prometheus *PrometheusMonitoring `json:"prometheus,omitempty"`
+}
+
+// PrometheusMonitoring holds the configuration of the Amazon Managed Service for Prometheus (AMP) workspace
+// the metrics of the cluster are sent to, and of the collector scraping them.
+type PrometheusMonitoring struct {
+	// WorkspaceARN is the ARN of an existing AMP workspace to send the metrics to.
+	// If unset, eksctl creates a workspace.
+	// +optional
+	WorkspaceARN string `json:"workspaceARN,omitempty"`
+	// WorkspaceAlias is the alias of the workspace created by eksctl.
+	// Defaults to the cluster name.
+	// +optional
+	WorkspaceAlias string `json:"workspaceAlias,omitempty"`
+	// Collector scrapes the metrics of the cluster, either `managed-scraper`, an agentless
+	// EKS managed scraper, or `adot`, the AWS Distro for OpenTelemetry collector running in the cluster.
+	// Defaults to `managed-scraper`.
+	// +optional
+	Collector string `json:"collector,omitempty"`
+	// ScrapeConfiguration is the Prometheus scrape configuration of the collector, in YAML.
+	// Defaults to scraping the API server, the kubelets, cAdvisor and the pods annotated with `prometheus.io/scrape`.
+	// +optional
+	ScrapeConfiguration string `json:"scrapeConfiguration,omitempty"`
+	// ADOTVersion is the version of the AWS Distro for OpenTelemetry collector image installed when
+	// the collector is `adot`, e.g. `v0.40.0`.
+	// +optional
+	ADOTVersion string `json:"adotVersion,omitempty"`
+	// Tags are applied to the workspace and the scraper.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// DNS holds the configuration of the DNS integrations of the cluster, which manage the records of Route53 hosted zones.
+type DNS struct {
+	// HostedZoneIDs are the IDs of the Route53 hosted zones external-dns and cert-manager are allowed to change,
+	// e.g. `Z0123456789ABCDEFGHIJ`.
+	// +required
+	HostedZoneIDs []string `json:"hostedZoneIDs"`
+	// ExternalDNS installs external-dns, which creates records in the hosted zones for services and ingresses.
+	// +optional
+	ExternalDNS *ExternalDNS `json:"externalDNS,omitempty"`
+	// CertManager installs cert-manager, allowed to solve ACME DNS-01 challenges in the hosted zones.
+	// +optional
+	CertManager *CertManager `json:"certManager,omitempty"`
+}
+
+// ExternalDNS holds the configuration of external-dns.
+type ExternalDNS struct {
+	// Version of the external-dns Helm chart.
+	// Defaults to `1.15.0`.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// DomainFilters limits the records to the given domains.
+	// +optional
+	DomainFilters []string `json:"domainFilters,omitempty"`
+	// Policy is either `upsert-only`, which never deletes records, or `sync`, which also deletes the records
+	// of deleted services and ingresses.
+	// Defaults to `upsert-only`.
+	// +optional
+	Policy string `json:"policy,omitempty"`
+}
+
+// CertManager holds the configuration of cert-manager.
+type CertManager struct {
+	// Version of the cert-manager Helm chart.
+	// Defaults to `v1.16.1`.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// ClusterBootstrap holds the Kubernetes resources eksctl applies right after creating the cluster.
+type ClusterBootstrap struct {
+	// Namespaces to create, along with their quotas and limits
+	// +optional
+	Namespaces []BootstrapNamespace `json:"namespaces,omitempty"`
+	// ClusterRoleBindings bind cluster roles to Kubernetes groups, which IAM roles are mapped to
+	// +optional
+	ClusterRoleBindings []BootstrapClusterRoleBinding `json:"clusterRoleBindings,omitempty"`
+	// PriorityClasses to create
+	// +optional
+	PriorityClasses []BootstrapPriorityClass `json:"priorityClasses,omitempty"`
+	// PodSecurity holds the default Pod Security Admission levels of the namespaces,
+	// applied as namespace labels
+	// +optional
+	PodSecurity *PodSecurity `json:"podSecurity,omitempty"`
+}
+
+// BootstrapNamespace holds the configuration of a namespace created by eksctl.
+type BootstrapNamespace struct {
+	// +required
+	Name string `json:"name"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// ResourceQuota holds the hard limits of the `default` ResourceQuota of the namespace,
+	// e.g. `requests.cpu: "10"`
+	// +optional
+	ResourceQuota map[string]string `json:"resourceQuota,omitempty"`
+	// LimitRange holds the container limits of the `default` LimitRange of the namespace
+	// +optional
+	LimitRange *BootstrapLimitRange `json:"limitRange,omitempty"`
+	// PodSecurity overrides the default Pod Security Admission levels of `clusterBootstrap.podSecurity`
+	// for the namespace
+	// +optional
+	PodSecurity *PodSecurity `json:"podSecurity,omitempty"`
+}
+
+// PodSecurity holds the Pod Security Admission levels of a namespace, each of `privileged`,
+// `baseline` or `restricted`.
+type PodSecurity struct {
+	// Enforce is the level of the Pod Security Standard pods are rejected for violating
+	// +optional
+	Enforce string `json:"enforce,omitempty"`
+	// Audit is the level of the Pod Security Standard violations are recorded in the audit log for
+	// +optional
+	Audit string `json:"audit,omitempty"`
+	// Warn is the level of the Pod Security Standard violations are returned to the user as warnings for
+	// +optional
+	Warn string `json:"warn,omitempty"`
+	// Version of the Pod Security Standards, e.g. `v1.30`. Defaults to `latest`.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// BootstrapLimitRange holds the limits of the containers of a namespace, e.g. `cpu: 500m`.
+type BootstrapLimitRange struct {
+	// Default are the limits of the containers which do not set them
+	// +optional
+	Default map[string]string `json:"default,omitempty"`
+	// DefaultRequest are the requests of the containers which do not set them
+	// +optional
+	DefaultRequest map[string]string `json:"defaultRequest,omitempty"`
+	// Max are the maximum limits of a container
+	// +optional
+	Max map[string]string `json:"max,omitempty"`
+}
+
+// BootstrapClusterRoleBinding binds a cluster role to a Kubernetes group, and maps IAM roles to the group.
+type BootstrapClusterRoleBinding struct {
+	// +required
+	Name string `json:"name"`
+	// ClusterRole is the name of the cluster role, e.g. `view`
+	// +required
+	ClusterRole string `json:"clusterRole"`
+	// Group is the Kubernetes group bound to the cluster role
+	// +required
+	Group string `json:"group"`
+	// IAMRoleARNs are the IAM roles mapped to the group, with access entries, or in the aws-auth
+	// ConfigMap when `accessConfig.authenticationMode` is `CONFIG_MAP`
+	// +optional
+	IAMRoleARNs []string `json:"iamRoleARNs,omitempty"`
+}
+
+// BootstrapPriorityClass holds the configuration of a priority class created by eksctl.
+type BootstrapPriorityClass struct {
+	// +required
+	Name string `json:"name"`
+	// Value is the priority of the pods of the class, at most `1000000000`
+	// +required
+	Value int32 `json:"value"`
+	// GlobalDefault makes the class the priority class of the pods which do not set one
+	// +optional
+	GlobalDefault bool `json:"globalDefault,omitempty"`
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// OrganizationDefaults holds the location of the defaults of an AWS account.
+type OrganizationDefaults struct {
+	// SSMParameter is the name or ARN of the SSM parameter holding the defaults.
+	// +required
+	SSMParameter string `json:"ssmParameter"`
+}
+
+// Backups holds the configuration for backing up the cluster with Velero.
+type Backups struct {
+	// Version of the Velero Helm chart.
+	// +required
+	Version string `json:"version"`
+	// Bucket is the name of an existing S3 bucket to store backups in.
+	// If unset, eksctl creates a bucket, which is retained when the cluster is deleted.
+	// +optional
+	Bucket string `json:"bucket,omitempty"`
+	// Prefix is the path in the bucket under which backups are stored.
+	// Defaults to the cluster name.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// Schedule is a cron expression for a scheduled backup of all namespaces, e.g. `0 3 * * *`.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// TTL is how long scheduled backups are kept, e.g. `720h`.
+	// Defaults to `720h`.
+	// +optional
+	TTL string `json:"ttl,omitempty"`
+	// AWSPluginVersion is the version of the Velero plugin for AWS.
+	// Defaults to `v1.10.0`.
+	// +optional
+	AWSPluginVersion string `json:"awsPluginVersion,omitempty"`
+}
+
+// IntegrationConfig holds the configuration of an integration, which is passed to the
+// integration as is.
+type IntegrationConfig struct {
+	// Name of the integration, e.g. `velero` for the `eksctl-velero` integration.
+	// +required
+	Name string `json:"name"`
+	// Config is the integration-specific configuration.
+	// +optional
+	Config InlineDocument `json:"config,omitempty"`
+}
+
+// ProxyConfig holds the proxy settings for environments where outbound traffic
+// goes through a corporate proxy.
+type ProxyConfig struct {
+	// HTTPSProxy is the URL of the proxy used for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy lists the hosts, domains and CIDRs that are reached without the proxy.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+	// CABundle is the path to a PEM file of additional certificate authorities to trust,
+	// e.g. the one of a TLS-intercepting proxy.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+	// PropagateToNodes configures containerd and kubelet on the nodes to use `httpsProxy` and `noProxy`.
+	// Defaults to `false`.
+	// +optional
+	PropagateToNodes *bool `json:"propagateToNodes,omitempty"`
+}
+
+// ServiceEndpoints holds custom endpoint URLs for AWS APIs. An endpoint set here takes
+// precedence over the corresponding `AWS_<SERVICE>_ENDPOINT` environment variable.
+type ServiceEndpoints struct {
+	// +optional
+	EKS string `json:"eks,omitempty"`
+	// +optional
+	EC2 string `json:"ec2,omitempty"`
+	// +optional
+	CloudFormation string `json:"cloudFormation,omitempty"`
+	// +optional
+	STS string `json:"sts,omitempty"`
+	// +optional
+	IAM string `json:"iam,omitempty"`
+	// +optional
+	ELB string `json:"elb,omitempty"`
+	// +optional
+	ELBV2 string `json:"elbv2,omitempty"`
+	// +optional
+	CloudTrail string `json:"cloudTrail,omitempty"`
+}
+
+// AutoModeConfig holds the configuration for EKS Auto Mode.
+type AutoModeConfig struct {
+	// Enabled enables or disables EKS Auto Mode, which lets EKS manage compute, block storage
+	// and load balancing for the cluster.
+	Enabled *bool `json:"enabled,omitempty"`
+	// NodeRoleARN specifies the IAM role used by nodes launched by Auto Mode.
+	// If unset and node pools are enabled, eksctl creates a node role.
+	// +optional
+	NodeRoleARN string `json:"nodeRoleARN,omitempty"`
+	// NodePools specifies the built-in node pools to enable.
+	// Valid entries are `general-purpose` and `system`; defaults to both.
+	// Set to an empty list to create custom node pools only.
+	// +optional
+	NodePools *[]string `json:"nodePools,omitempty"`
+}
+
+// Built-in node pools for EKS Auto Mode.
+const (
+	AutoModeNodePoolGeneralPurpose = "general-purpose"
+	AutoModeNodePoolSystem         = "system"
+)
+
+// HasNodePools reports whether any built-in node pools are enabled.
+func (a *AutoModeConfig) HasNodePools() bool {
+	return a.NodePools != nil && len(*a.NodePools) > 0
+}
+
+// IsAutoModeEnabled returns true if EKS Auto Mode is enabled.
+func (c *ClusterConfig) IsAutoModeEnabled() bool {
+	return c.AutoModeConfig != nil && IsEnabled(c.AutoModeConfig.Enabled)
 }
 
 // Outpost holds the Outpost configuration.
@@ -1329,6 +1831,8 @@ func (n *NodeGroup) SetInstanceType(instanceType string) {
 type GitOps struct {
 	// Flux holds options to enable Flux v2 on your cluster
 	Flux *Flux `json:"flux,omitempty"`
+	// ArgoCD holds options to enable Argo CD on your cluster, as an alternative to Flux
+	ArgoCD *ArgoCD `json:"argocd,omitempty"`
 }
 
 // Flux groups all configuration options related to a Git repository used for
@@ -1340,16 +1844,118 @@ type Flux struct {
 	// Flags is an arbitrary map of string to string to pass any flags to Flux bootstrap
 	// via eksctl see https://fluxcd.io/docs/ for information on all flags
 	Flags FluxFlags `json:"flags,omitempty"`
+
+	// ExtraArgs are passed to Flux bootstrap as is, after Flags, for flags that
+	// cannot be set in Flags, e.g. boolean flags like `--token-auth` or repeated flags
+	// +optional
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+
+	// Components are the Flux controllers to install.
+	// Defaults to the components installed by Flux bootstrap
+	// +optional
+	Components []string `json:"components,omitempty"`
+
+	// ComponentsExtra are the optional Flux controllers to install, e.g.
+	// `image-reflector-controller` and `image-automation-controller`
+	// +optional
+	ComponentsExtra []string `json:"componentsExtra,omitempty"`
+
+	// Registry the Flux images are pulled from, e.g. a mirror of `ghcr.io/fluxcd`
+	// +optional
+	Registry string `json:"registry,omitempty"`
+
+	// ImagePullSecret is the name of the secret in the flux-system namespace
+	// holding the credentials of Registry
+	// +optional
+	ImagePullSecret string `json:"imagePullSecret,omitempty"`
+
+	// Patches are kustomize patches added to the flux-system Kustomization after
+	// bootstrap, e.g. to customize the Flux controllers
+	// +optional
+	Patches []FluxPatch `json:"patches,omitempty"`
 }
 
 // FluxFlags is a map of string for passing arbitrary flags to Flux bootstrap
 type FluxFlags map[string]string
 
+// FluxPatch is a kustomize patch of the resources reconciled by the flux-system Kustomization
+type FluxPatch struct {
+	// Patch is a strategic merge patch or a JSON 6902 patch
+	// +required
+	Patch string `json:"patch"`
+
+	// Target selects the resources to patch
+	// +optional
+	Target *FluxPatchTarget `json:"target,omitempty"`
+}
+
+// FluxPatchTarget selects the resources a FluxPatch applies to
+type FluxPatchTarget struct {
+	// +optional
+	Group string `json:"group,omitempty"`
+	// +optional
+	Version string `json:"version,omitempty"`
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// +optional
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// +optional
+	AnnotationSelector string `json:"annotationSelector,omitempty"`
+}
+
 // HasGitOpsFluxConfigured returns true if gitops.flux configuration is not nil
 func (c *ClusterConfig) HasGitOpsFluxConfigured() bool {
 	return c.GitOps != nil && c.GitOps.Flux != nil
 }
 
+// ArgoCD groups all configuration options related to the Git repository Argo CD
+// syncs the cluster from.
+type ArgoCD struct {
+	// Version of the argo-cd Helm chart.
+	// Defaults to `7.7.0`.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// RepoURL is the URL of the Git repository, either over HTTPS, e.g. `https://github.com/org/fleet.git`,
+	// or over SSH, e.g. `git@github.com:org/fleet.git`.
+	// +required
+	RepoURL string `json:"repoURL"`
+	// Revision is the branch, tag or commit Argo CD syncs.
+	// Defaults to `HEAD`.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// Path is the directory of the repository holding the Applications of the cluster, synced by
+	// an app-of-apps Application. Defaults to `clusters/<cluster name>`.
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Credentials of the repository, for private repositories
+	// +optional
+	Credentials *ArgoCDCredentials `json:"credentials,omitempty"`
+}
+
+// ArgoCDCredentials holds the location of the credentials of the Git repository of Argo CD,
+// either a password or token for HTTPS repositories, or an SSH private key.
+type ArgoCDCredentials struct {
+	// SSMParameter is the name of the SecureString SSM parameter holding the credentials
+	// +optional
+	SSMParameter string `json:"ssmParameter,omitempty"`
+	// SecretID is the name or ARN of the Secrets Manager secret holding the credentials
+	// +optional
+	SecretID string `json:"secretID,omitempty"`
+	// Username for HTTPS repositories.
+	// Defaults to `git`.
+	// +optional
+	Username string `json:"username,omitempty"`
+}
+
+// HasGitOpsArgoCDConfigured returns true if gitops.argocd configuration is not nil
+func (c *ClusterConfig) HasGitOpsArgoCDConfigured() bool {
+	return c.GitOps != nil && c.GitOps.ArgoCD != nil
+}
+
 type (
 	// NodeGroupSGs controls security groups for this nodegroup
 	NodeGroupSGs struct {
@@ -1697,8 +2303,52 @@ type NodeGroupBase struct {
 	// OutpostARN specifies the Outpost ARN in which the nodegroup should be created.
 	// +optional
 	OutpostARN string `json:"outpostARN,omitempty"`
+
+	// GPU configures how NVIDIA GPUs of nodes in this group are shared between pods.
+	// Only supported on AmazonLinux2 and AmazonLinux2023
+	// +optional
+	GPU *NodeGroupGPU `json:"gpu,omitempty"`
+}
+
+// NodeGroupGPU configures sharing of NVIDIA GPUs with time-slicing or
+// Multi-Instance GPU (MIG) partitions
+type NodeGroupGPU struct {
+	// TimeSlicing lets pods share each GPU, or each MIG device, by time-slicing
+	// +optional
+	TimeSlicing *GPUTimeSlicing `json:"timeSlicing,omitempty"`
+
+	// MIG partitions each GPU into MIG devices when the node boots
+	// +optional
+	MIG *GPUMIG `json:"mig,omitempty"`
+}
+
+// GPUTimeSlicing configures time-slicing of GPUs in the NVIDIA device plugin
+type GPUTimeSlicing struct {
+	// Replicas is the number of pods that can share each GPU
+	// +required
+	Replicas int `json:"replicas"`
 }
 
+// GPUMIG configures the MIG partitions created on each GPU
+type GPUMIG struct {
+	// Profiles lists the GPU instance profiles to create on each GPU,
+	// e.g. `["3g.20gb", "3g.20gb"]`
+	// +required
+	Profiles []string `json:"profiles"`
+
+	// Strategy is the MIG strategy of the NVIDIA device plugin, either `single` or `mixed`.
+	// Defaults to `single` if all profiles are the same, otherwise `mixed`
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+}
+
+const (
+	// MIGStrategySingle exposes all MIG devices as `nvidia.com/gpu`
+	MIGStrategySingle = "single"
+	// MIGStrategyMixed exposes each MIG profile as a separate `nvidia.com/mig-<profile>` resource
+	MIGStrategyMixed = "mixed"
+)
+
 // CapacityReservation defines a nodegroup's Capacity Reservation targeting option
 // +optional
 type CapacityReservation struct {
@@ -1883,14 +2533,62 @@ type FargateProfile struct {
 	Status string `json:"status"`
 }
 
+// Values for `FargateLogging.Destination`
+const (
+	FargateLoggingCloudWatch = "cloudwatch"
+	FargateLoggingFirehose   = "firehose"
+	FargateLoggingOpenSearch = "opensearch"
+)
+
+// FargateLogging holds the configuration of the `aws-logging` ConfigMap, which configures
+// the Fluent Bit log router of the pods running on Fargate.
+type FargateLogging struct {
+	// Destination is where the logs are sent, valid options are `cloudwatch`, `firehose` and `opensearch`.
+	// +required
+	Destination string `json:"destination"`
+
+	// LogGroupName is the CloudWatch log group the logs are sent to.
+	// Defaults to `/aws/eks/<cluster name>/fargate`.
+	// +optional
+	LogGroupName string `json:"logGroupName,omitempty"`
+
+	// LogStreamPrefix is the prefix of the CloudWatch log streams. Defaults to `fargate-`.
+	// +optional
+	LogStreamPrefix string `json:"logStreamPrefix,omitempty"`
+
+	// DeliveryStream is the name of the Kinesis Data Firehose delivery stream
+	// the logs are sent to, required when the destination is `firehose`.
+	// +optional
+	DeliveryStream string `json:"deliveryStream,omitempty"`
+
+	// OpenSearchEndpoint is the host name of the endpoint of the OpenSearch domain
+	// the logs are sent to, required when the destination is `opensearch`.
+	// +optional
+	OpenSearchEndpoint string `json:"openSearchEndpoint,omitempty"`
+
+	// OpenSearchIndex is the index the logs are written to. Defaults to `fargate`.
+	// +optional
+	OpenSearchIndex string `json:"openSearchIndex,omitempty"`
+
+	// Filters holds Fluent Bit `[FILTER]` sections, stored as `filters.conf`.
+	// +optional
+	Filters string `json:"filters,omitempty"`
+
+	// Parsers holds Fluent Bit `[PARSER]` sections, stored as `parsers.conf`.
+	// +optional
+	Parsers string `json:"parsers,omitempty"`
+}
+
 // FargateProfileSelector defines rules to select workload to schedule onto Fargate.
 type FargateProfileSelector struct {
 
 	// Namespace is the Kubernetes namespace from which to select workload.
+	// It can hold the wildcards `*` and `?`, e.g. `prod-*`.
 	// +required
 	Namespace string `json:"namespace"`
 
 	// Labels are the Kubernetes label selectors to use to select workload.
+	// Their keys and values can hold the wildcards `*` and `?`.
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
 }
@@ -1914,6 +2612,32 @@ type PrivateCluster struct {
 	// must be enabled for private access.
 	// Valid entries are "cloudformation", "autoscaling" and "logs".
 	AdditionalEndpointServices []string `json:"additionalEndpointServices,omitempty"`
+
+	// AdminAccess provisions a way for operators to reach the private API server from within the VPC.
+	// +optional
+	AdminAccess *PrivateClusterAdminAccess `json:"adminAccess,omitempty"`
+}
+
+// Values for `PrivateClusterAdminAccess.Type`
+const (
+	// PrivateClusterAdminAccessInstanceConnectEndpoint creates an EC2 Instance Connect Endpoint
+	PrivateClusterAdminAccessInstanceConnectEndpoint = "InstanceConnectEndpoint"
+	// PrivateClusterAdminAccessBastion creates a bastion instance managed by SSM
+	PrivateClusterAdminAccessBastion = "Bastion"
+
+	// DefaultBastionInstanceType is the default instance type of the bastion
+	DefaultBastionInstanceType = "t3.micro"
+)
+
+// PrivateClusterAdminAccess holds the configuration for reaching a fully-private cluster from within its VPC
+type PrivateClusterAdminAccess struct {
+	// Valid variants are `InstanceConnectEndpoint`, to create an EC2 Instance Connect Endpoint, and `Bastion`,
+	// to create an instance in a private subnet that can be reached through SSM Session Manager
+	Type string `json:"type"`
+
+	// InstanceType of the bastion, only valid with type `Bastion`. Defaults to `t3.micro`
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
 }
 
 // InstanceSelector holds EC2 instance selector options
@@ -1977,6 +2701,12 @@ type AccessConfig struct {
 	// admin access entry during cluster creation time.
 	BootstrapClusterCreatorAdminPermissions *bool `json:"bootstrapClusterCreatorAdminPermissions,omitempty"`
 
+	// BootstrapAdminPrincipalARN specifies an IAM principal that is granted cluster admin permissions via an access entry
+	// when the cluster is created. Combined with `bootstrapClusterCreatorAdminPermissions: false`, it allows creating a
+	// cluster with credentials that do not retain cluster admin permissions.
+	// +optional
+	BootstrapAdminPrincipalARN string `json:"bootstrapAdminPrincipalARN,omitempty"`
+
 	// AccessEntries specifies a list of access entries for the cluster.
 	// +optional
 	AccessEntries []AccessEntry `json:"accessEntries,omitempty"`
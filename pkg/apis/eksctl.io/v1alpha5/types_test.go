@@ -1,6 +1,10 @@
 package v1alpha5
 
 import (
+	"reflect"
+	"strings"
+	"time"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -82,4 +86,51 @@ var _ = Describe("Types", func() {
 		})
 	})
 
+	Describe("cluster expiry", func() {
+		now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+		It("records the expiry time when a TTL is set", func() {
+			meta := &ClusterMeta{Name: "preview", TTL: "4h"}
+			Expect(meta.SetExpiryTag(now)).To(Succeed())
+			Expect(meta.Tags).To(HaveKeyWithValue(ClusterExpiresAtTag, "2024-05-01T14:00:00Z"))
+
+			expiresAt, ok, err := ClusterExpiry(meta.Tags)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(expiresAt).To(Equal(now.Add(4 * time.Hour)))
+		})
+
+		It("does not tag the cluster when no TTL is set", func() {
+			meta := &ClusterMeta{Name: "preview"}
+			Expect(meta.SetExpiryTag(now)).To(Succeed())
+			Expect(meta.Tags).NotTo(HaveKey(ClusterExpiresAtTag))
+
+			_, ok, err := ClusterExpiry(meta.Tags)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("errors on an invalid expiry tag", func() {
+			_, _, err := ClusterExpiry(map[string]string{ClusterExpiresAtTag: "tomorrow"})
+			Expect(err).To(MatchError(ContainSubstring(`invalid value "tomorrow" for tag alpha.eksctl.io/expires-at`)))
+		})
+	})
+
+	Describe("well-known policy templates", func() {
+		It("has a template for every well-known policy", func() {
+			var names []string
+			for _, t := range WellKnownPolicyTemplates() {
+				Expect(t.Version).To(BeNumerically(">", 0))
+				Expect(t.Description).NotTo(BeEmpty())
+				names = append(names, t.Name)
+			}
+
+			var fields []string
+			policies := reflect.TypeOf(WellKnownPolicies{})
+			for i := 0; i < policies.NumField(); i++ {
+				fields = append(fields, strings.Split(policies.Field(i).Tag.Get("json"), ",")[0])
+			}
+			Expect(names).To(ConsistOf(fields))
+		})
+	})
 })
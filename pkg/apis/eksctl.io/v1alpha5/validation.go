@@ -3,9 +3,11 @@ package v1alpha5
 import (
 	"fmt"
 	"net"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
@@ -67,6 +69,10 @@ var (
 	SupportedUbuntuImages      = supportedAMIFamiliesForOS(IsUbuntuImage)
 )
 
+var iamRolePathRegex = regexp.MustCompile(`^/([\x21-\x7E]*/)?$`)
+
+var migProfileRegex = regexp.MustCompile(`^[1-7]g\.[0-9]+gb(\+me)?$`)
+
 // NOTE: we don't use k8s.io/apimachinery/pkg/util/sets here to keep API package free of dependencies
 type nameSet map[string]struct{}
 
@@ -88,6 +94,17 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 		return fmt.Errorf("iam.withOIDC must be enabled explicitly for iam.serviceAccounts to be created")
 	}
 
+	if cfg.IAM.RolePath != "" {
+		if err := validateIAMRolePath(cfg.IAM.RolePath); err != nil {
+			return fmt.Errorf("iam.rolePath is invalid: %w", err)
+		}
+	}
+	if cfg.IAM.WithPermissionsBoundary != "" {
+		if _, err := arn.Parse(cfg.IAM.WithPermissionsBoundary); err != nil {
+			return fmt.Errorf("iam.withPermissionsBoundary is invalid: %w", err)
+		}
+	}
+
 	saNames := nameSet{}
 	for i, sa := range cfg.IAM.ServiceAccounts {
 		path := fmt.Sprintf("iam.serviceAccounts[%d]", i)
@@ -100,12 +117,39 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 		if !sa.WellKnownPolicies.HasPolicy() && len(sa.AttachPolicyARNs) == 0 && sa.AttachPolicy == nil && sa.AttachRoleARN == "" {
 			return fmt.Errorf("%[1]s.wellKnownPolicies, %[1]s.attachPolicyARNs,%[1]s.attachRoleARN  or %[1]s.attachPolicy must be set", path)
 		}
+		if sa.RolePath != "" {
+			if err := validateIAMRolePath(sa.RolePath); err != nil {
+				return fmt.Errorf("%s.rolePath is invalid: %w", path, err)
+			}
+		}
+		if sa.TargetAccountRoleARN != "" {
+			if sa.AttachRoleARN != "" {
+				return fmt.Errorf("%[1]s.targetAccountRoleARN cannot be used in conjunction with %[1]s.attachRoleARN", path)
+			}
+			if err := validateIAMRoleARN(sa.TargetAccountRoleARN); err != nil {
+				return fmt.Errorf("%s.targetAccountRoleARN is invalid: %w", path, err)
+			}
+		}
 	}
 
 	if err := cfg.validateKubernetesNetworkConfig(); err != nil {
 		return err
 	}
 
+	if err := cfg.validateVPCCNIConfig(); err != nil {
+		return err
+	}
+
+	if cfg.Metadata.TTL != "" {
+		ttl, err := time.ParseDuration(cfg.Metadata.TTL)
+		if err != nil {
+			return fmt.Errorf("invalid metadata.ttl %q: %w", cfg.Metadata.TTL, err)
+		}
+		if ttl <= 0 {
+			return fmt.Errorf("metadata.ttl must be a positive duration, got %q", cfg.Metadata.TTL)
+		}
+	}
+
 	// names must be unique across both managed and unmanaged nodegroups
 	ngNames := nameSet{}
 	validateNg := func(ng *NodeGroupBase, path string) error {
@@ -161,6 +205,12 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 		if err := validateOutpostARN(cfg.Outpost.ControlPlaneOutpostARN); err != nil {
 			return err
 		}
+		if parsed, _ := arn.Parse(cfg.Outpost.ControlPlaneOutpostARN); cfg.Metadata.Region != "" && parsed.Region != cfg.Metadata.Region {
+			return fmt.Errorf("outpost.controlPlaneOutpostARN must be in the cluster's region %q; got %q", cfg.Metadata.Region, parsed.Region)
+		}
+		if cfg.Outpost.HasPlacementGroup() && cfg.Outpost.ControlPlanePlacement.GroupName == "" {
+			return errors.New("outpost.controlPlanePlacement.groupName must be set when outpost.controlPlanePlacement is specified")
+		}
 
 		if cfg.AccessConfig.AuthenticationMode != ekstypes.AuthenticationModeConfigMap {
 			return fmt.Errorf("accessConfig.AuthenticationMode must be set to %s on Outposts", ekstypes.AuthenticationModeConfigMap)
@@ -199,6 +249,12 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 		if cfg.IAM != nil && IsEnabled(cfg.IAM.WithOIDC) {
 			return errors.New("iam.withOIDC is not supported on Outposts")
 		}
+		if (cfg.IAM != nil && len(cfg.IAM.PodIdentityAssociations) > 0) || cfg.AddonsConfig.AutoApplyPodIdentityAssociations {
+			return errors.New("Pod Identity Associations are not supported on Outposts")
+		}
+		if cfg.PrivateCluster != nil && cfg.PrivateCluster.AdminAccess != nil {
+			return errors.New("privateCluster.adminAccess is not supported on Outposts")
+		}
 		if cfg.VPC != nil {
 			if IsEnabled(cfg.VPC.AutoAllocateIPv6) {
 				return errors.New("autoAllocateIPv6 is not supported on Outposts")
@@ -225,14 +281,27 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 	if err := validateAddonPodIdentityAssociations(cfg.Addons); err != nil {
 		return err
 	}
+	if err := ValidateAddonUpgradePolicy(cfg.AddonsConfig.AutoUpgradePolicy); err != nil {
+		return fmt.Errorf("invalid addonsConfig: %w", err)
+	}
+
+	if principalARN := cfg.AccessConfig.BootstrapAdminPrincipalARN; principalARN != "" {
+		if cfg.AccessConfig.AuthenticationMode == ekstypes.AuthenticationModeConfigMap {
+			return fmt.Errorf("accessConfig.authenticationMode must be set to either %s or %s to use accessConfig.bootstrapAdminPrincipalARN",
+				ekstypes.AuthenticationModeApiAndConfigMap, ekstypes.AuthenticationModeApi)
+		}
+		if parsed, err := arn.Parse(principalARN); err != nil || parsed.Service != "iam" {
+			return fmt.Errorf("accessConfig.bootstrapAdminPrincipalARN must be a valid IAM principal ARN, got %q", principalARN)
+		}
+	}
 
-	if len(cfg.AccessConfig.AccessEntries) > 0 {
+	if accessEntries := cfg.AccessConfig.AccessEntriesToCreate(); len(accessEntries) > 0 {
 		switch cfg.AccessConfig.AuthenticationMode {
 		case ekstypes.AuthenticationModeConfigMap:
 			return fmt.Errorf("accessConfig.authenticationMode must be set to either %s or %s to use access entries",
 				ekstypes.AuthenticationModeApiAndConfigMap, ekstypes.AuthenticationModeApi)
 		}
-		if err := validateAccessEntries(cfg.AccessConfig.AccessEntries); err != nil {
+		if err := validateAccessEntries(accessEntries); err != nil {
 			return err
 		}
 	}
@@ -241,6 +310,169 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 		return fmt.Errorf("failed to validate Karpenter config: %w", err)
 	}
 
+	if err := validateAutoModeConfig(cfg); err != nil {
+		return err
+	}
+
+	if err := validateServiceEndpoints(cfg.ServiceEndpoints); err != nil {
+		return err
+	}
+	if err := validateProxyConfig(cfg.Proxy); err != nil {
+		return err
+	}
+	if err := ValidateFargateLogging(cfg.FargateLogging); err != nil {
+		return err
+	}
+	if err := validateIntegrations(cfg.Integrations); err != nil {
+		return err
+	}
+	if err := ValidateBackups(cfg.Backups); err != nil {
+		return err
+	}
+	if cfg.OrganizationDefaults != nil && cfg.OrganizationDefaults.SSMParameter == "" {
+		return errors.New("organizationDefaults.ssmParameter must be set")
+	}
+	if err := ValidatePullThroughCache(cfg.PullThroughCache); err != nil {
+		return err
+	}
+	if err := validatePullThroughCacheNodeGroups(cfg); err != nil {
+		return err
+	}
+	if err := ValidateMonitoring(cfg.Monitoring); err != nil {
+		return err
+	}
+	if err := ValidateKubeconfig(cfg.Kubeconfig); err != nil {
+		return err
+	}
+	if err := ValidateCloudFormation(cfg.CloudFormation); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateAutoModeConfig(cfg *ClusterConfig) error {
+	autoModeConfig := cfg.AutoModeConfig
+	if autoModeConfig == nil {
+		return nil
+	}
+	if !cfg.IsAutoModeEnabled() {
+		if autoModeConfig.NodeRoleARN != "" || autoModeConfig.NodePools != nil {
+			return errors.New("autoModeConfig.nodeRoleARN and autoModeConfig.nodePools can only be set when autoModeConfig.enabled is true")
+		}
+		return nil
+	}
+
+	if cfg.IsControlPlaneOnOutposts() {
+		return errors.New("Auto Mode is not supported on Outposts")
+	}
+	if cfg.AccessConfig.AuthenticationMode == ekstypes.AuthenticationModeConfigMap {
+		return fmt.Errorf("accessConfig.authenticationMode must be set to either %s or %s when Auto Mode is enabled",
+			ekstypes.AuthenticationModeApiAndConfigMap, ekstypes.AuthenticationModeApi)
+	}
+	if len(cfg.NodeGroups) > 0 || len(cfg.ManagedNodeGroups) > 0 {
+		return errors.New("nodeGroups and managedNodeGroups cannot be specified when Auto Mode is enabled; " +
+			"Auto Mode launches nodes through its node pools")
+	}
+	if cfg.Karpenter != nil {
+		return errors.New("karpenter cannot be installed when Auto Mode is enabled; Auto Mode runs Karpenter as part of the control plane")
+	}
+
+	if autoModeConfig.NodeRoleARN != "" {
+		if err := validateIAMRoleARN(autoModeConfig.NodeRoleARN); err != nil {
+			return fmt.Errorf("autoModeConfig.nodeRoleARN is invalid: %w", err)
+		}
+	}
+	if autoModeConfig.NodePools != nil {
+		seen := nameSet{}
+		for _, nodePool := range *autoModeConfig.NodePools {
+			switch nodePool {
+			case AutoModeNodePoolGeneralPurpose, AutoModeNodePoolSystem:
+			default:
+				return fmt.Errorf("invalid value %q for autoModeConfig.nodePools; supported values: %s, %s", nodePool,
+					AutoModeNodePoolGeneralPurpose, AutoModeNodePoolSystem)
+			}
+			if ok, err := seen.checkUnique("autoModeConfig.nodePools", nodePool); !ok {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateServiceEndpoints(endpoints *ServiceEndpoints) error {
+	if endpoints == nil {
+		return nil
+	}
+	for _, e := range []struct {
+		name     string
+		endpoint string
+	}{
+		{"eks", endpoints.EKS},
+		{"ec2", endpoints.EC2},
+		{"cloudFormation", endpoints.CloudFormation},
+		{"sts", endpoints.STS},
+		{"iam", endpoints.IAM},
+		{"elb", endpoints.ELB},
+		{"elbv2", endpoints.ELBV2},
+		{"cloudTrail", endpoints.CloudTrail},
+	} {
+		if e.endpoint == "" {
+			continue
+		}
+		if u, err := url.Parse(e.endpoint); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("serviceEndpoints.%s must be an absolute http or https URL, got %q", e.name, e.endpoint)
+		}
+	}
+	return nil
+}
+
+func validateProxyConfig(proxy *ProxyConfig) error {
+	if proxy == nil {
+		return nil
+	}
+	if proxy.HTTPSProxy != "" {
+		if u, err := url.Parse(proxy.HTTPSProxy); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("proxy.httpsProxy must be an absolute http or https URL, got %q", proxy.HTTPSProxy)
+		}
+	}
+	if IsEnabled(proxy.PropagateToNodes) && proxy.HTTPSProxy == "" {
+		return errors.New("proxy.httpsProxy must be set when proxy.propagateToNodes is enabled")
+	}
+	return nil
+}
+
+func validateIntegrations(integrations []IntegrationConfig) error {
+	seen := map[string]struct{}{}
+	for i, integration := range integrations {
+		path := fmt.Sprintf("integrations[%d]", i)
+		if integration.Name == "" {
+			return fmt.Errorf("%s.name must be set", path)
+		}
+		if _, ok := seen[integration.Name]; ok {
+			return fmt.Errorf("%s: integration %q is configured more than once", path, integration.Name)
+		}
+		seen[integration.Name] = struct{}{}
+	}
+	return nil
+}
+
+// ValidateBackups validates the backups configuration.
+func ValidateBackups(backups *Backups) error {
+	if backups == nil {
+		return nil
+	}
+	if backups.Version == "" {
+		return errors.New("backups.version must be set")
+	}
+	if backups.TTL != "" {
+		if _, err := time.ParseDuration(backups.TTL); err != nil {
+			return fmt.Errorf("backups.ttl must be a duration, e.g. 720h: %w", err)
+		}
+	}
+	if backups.Schedule != "" && len(strings.Fields(backups.Schedule)) != 5 && !strings.HasPrefix(backups.Schedule, "@") {
+		return fmt.Errorf("backups.schedule must be a cron expression with 5 fields, got %q", backups.Schedule)
+	}
 	return nil
 }
 
@@ -285,10 +517,16 @@ func validateKarpenterConfig(cfg *ClusterConfig) error {
 
 func validateCloudWatchLogging(clusterConfig *ClusterConfig) error {
 	if !clusterConfig.HasClusterCloudWatchLogging() {
-		if clusterConfig.CloudWatch != nil &&
-			clusterConfig.CloudWatch.ClusterLogging != nil &&
-			clusterConfig.CloudWatch.ClusterLogging.LogRetentionInDays != 0 {
-			return errors.New("cannot set cloudWatch.clusterLogging.logRetentionInDays without enabling log types")
+		if clusterConfig.CloudWatch != nil && clusterConfig.CloudWatch.ClusterLogging != nil {
+			logging := clusterConfig.CloudWatch.ClusterLogging
+			switch {
+			case logging.LogRetentionInDays != 0:
+				return errors.New("cannot set cloudWatch.clusterLogging.logRetentionInDays without enabling log types")
+			case logging.LogGroupKMSKeyARN != "":
+				return errors.New("cannot set cloudWatch.clusterLogging.logGroupKMSKeyARN without enabling log types")
+			case len(logging.LogGroupTags) > 0:
+				return errors.New("cannot set cloudWatch.clusterLogging.logGroupTags without enabling log types")
+			}
 		}
 		return nil
 	}
@@ -304,6 +542,11 @@ func validateCloudWatchLogging(clusterConfig *ClusterConfig) error {
 			return errors.Errorf("log type %q (cloudWatch.clusterLogging.enableTypes[%d]) is unknown", logType, i)
 		}
 	}
+	if keyARN := clusterConfig.CloudWatch.ClusterLogging.LogGroupKMSKeyARN; keyARN != "" {
+		if _, err := arn.Parse(keyARN); err != nil {
+			return errors.Wrapf(err, "invalid value %q for cloudWatch.clusterLogging.logGroupKMSKeyARN", keyARN)
+		}
+	}
 	if logRetentionDays := clusterConfig.CloudWatch.ClusterLogging.LogRetentionInDays; logRetentionDays != 0 {
 		for _, v := range LogRetentionInDaysValues {
 			if v == logRetentionDays {
@@ -338,7 +581,7 @@ func (c *ClusterConfig) ValidateVPCConfig() error {
 		c.VPC.ExtraCIDRs = cidrs
 	}
 	if len(c.VPC.PublicAccessCIDRs) > 0 {
-		cidrs, err := validateCIDRs(c.VPC.PublicAccessCIDRs)
+		cidrs, err := validatePublicAccessCIDRs(c.VPC.PublicAccessCIDRs)
 		if err != nil {
 			return err
 		}
@@ -378,6 +621,10 @@ func (c *ClusterConfig) ValidateVPCConfig() error {
 		}
 	}
 
+	if err := c.validateVPCSubnetCreation(); err != nil {
+		return err
+	}
+
 	// manageSharedNodeSecurityGroupRules cannot be disabled if using eksctl managed security groups
 	if c.VPC.SharedNodeSecurityGroup == "" && IsDisabled(c.VPC.ManageSharedNodeSecurityGroupRules) {
 		return errors.New("vpc.manageSharedNodeSecurityGroupRules must be enabled when using eksctl-managed security groups")
@@ -412,6 +659,50 @@ func (c *ClusterConfig) ValidateVPCConfig() error {
 	return nil
 }
 
+func (c *ClusterConfig) validateVPCSubnetCreation() error {
+	createSubnets := c.VPC.CreateSubnets
+	if createSubnets == nil {
+		return nil
+	}
+	if c.VPC.ID == "" {
+		return errors.New("vpc.createSubnets can only be used with a pre-existing VPC set in vpc.id")
+	}
+	if c.HasAnySubnets() {
+		return errors.New("vpc.createSubnets cannot be used with vpc.subnets")
+	}
+	if c.IPv6Enabled() {
+		return errors.New("vpc.createSubnets is not supported with IPv6")
+	}
+	if len(createSubnets.PublicCIDRs) == 0 && len(createSubnets.PrivateCIDRs) == 0 {
+		return errors.New("at least one of vpc.createSubnets.publicCIDRs and vpc.createSubnets.privateCIDRs must be set")
+	}
+	if len(createSubnets.PublicCIDRs) > 0 && createSubnets.InternetGatewayID == "" {
+		return errors.New("vpc.createSubnets.internetGatewayID must be set when vpc.createSubnets.publicCIDRs is set")
+	}
+	if len(createSubnets.PrivateCIDRs) == 0 && createSubnets.NATGatewayID != "" {
+		return errors.New("vpc.createSubnets.natGatewayID can only be set when vpc.createSubnets.privateCIDRs is set")
+	}
+	if len(c.AvailabilityZones) == 0 {
+		return errors.New("availabilityZones must be set when using vpc.createSubnets")
+	}
+	validateSubnetCIDRs := func(cidrs []string, path string) error {
+		if len(cidrs) == 0 {
+			return nil
+		}
+		if len(cidrs) != len(c.AvailabilityZones) {
+			return fmt.Errorf("%s must have one CIDR per availability zone, got %d CIDRs for %d availability zones", path, len(cidrs), len(c.AvailabilityZones))
+		}
+		if _, err := validateCIDRs(cidrs); err != nil {
+			return fmt.Errorf("invalid %s: %w", path, err)
+		}
+		return nil
+	}
+	if err := validateSubnetCIDRs(createSubnets.PublicCIDRs, "vpc.createSubnets.publicCIDRs"); err != nil {
+		return err
+	}
+	return validateSubnetCIDRs(createSubnets.PrivateCIDRs, "vpc.createSubnets.privateCIDRs")
+}
+
 func (c *ClusterConfig) unsupportedVPCCNIAddonVersion() (bool, error) {
 	for _, addon := range c.Addons {
 		if addon.Name == VPCCNIAddon {
@@ -462,6 +753,45 @@ func (c *ClusterConfig) ipv6CidrsValid() error {
 	return fmt.Errorf("Ipv6Cidr and Ipv6Pool must both be configured to use a custom IPv6 CIDR and address pool")
 }
 
+// validateVPCCNIConfig validates the vpcCNI config of the vpc-cni addon against the rest of the cluster config
+func (c *ClusterConfig) validateVPCCNIConfig() error {
+	for _, addon := range c.Addons {
+		if addon.CanonicalName() != VPCCNIAddon || addon.VPCCNI == nil {
+			continue
+		}
+		if addon.VPCCNI.CustomNetworking && c.IPv6Enabled() {
+			return errors.New("vpcCNI.customNetworking is not supported with IPv6")
+		}
+		if addon.VPCCNI.PodENI && c.IAM != nil && IsDisabled(c.IAM.VPCResourceControllerPolicy) {
+			return errors.New("vpcCNI.podENI requires iam.vpcResourceControllerPolicy, which allows the cluster to manage the network interfaces of pods")
+		}
+		if addon.VPCCNI.PrefixDelegation {
+			c.warnPrefixDelegationMaxPods()
+		}
+	}
+	return nil
+}
+
+// warnPrefixDelegationMaxPods warns about the nodegroups whose nodes compute their max pods from the number of IP
+// addresses of their instance type, which ignores prefix delegation. EKS raises the max pods of managed nodegroups
+// itself, unless they use a custom AMI.
+func (c *ClusterConfig) warnPrefixDelegationMaxPods() {
+	warn := func(path string) {
+		logger.Warning("vpcCNI.prefixDelegation is enabled but %s.maxPodsPerNode is not set; its nodes will run no more pods "+
+			"than without prefix delegation, set maxPodsPerNode to the max pods of its instance type with prefix delegation", path)
+	}
+	for i, ng := range c.NodeGroups {
+		if ng.MaxPodsPerNode == 0 {
+			warn(fmt.Sprintf("nodeGroups[%d]", i))
+		}
+	}
+	for i, ng := range c.ManagedNodeGroups {
+		if ng.MaxPodsPerNode == 0 && ng.AMI != "" {
+			warn(fmt.Sprintf("managedNodeGroups[%d]", i))
+		}
+	}
+}
+
 // addonContainsManagedAddons finds managed addons in the config and returns those it couldn't find.
 func (c *ClusterConfig) addonContainsManagedAddons(addons []string) []string {
 	var missing []string
@@ -497,9 +827,12 @@ func (c *ClusterConfig) ValidateClusterEndpointConfig() error {
 
 // ValidatePrivateCluster validates the private cluster config
 func (c *ClusterConfig) ValidatePrivateCluster() error {
+	if !c.PrivateCluster.Enabled && c.PrivateCluster.AdminAccess != nil {
+		return errors.New("privateCluster.adminAccess is only valid for fully-private clusters")
+	}
 	if c.PrivateCluster.Enabled {
-		if c.VPC != nil && c.VPC.ID != "" && len(c.VPC.Subnets.Private) == 0 {
-			return errors.New("vpc.subnets.private must be specified in a fully-private cluster when a pre-existing VPC is supplied")
+		if c.VPC != nil && c.VPC.ID != "" && len(c.VPC.Subnets.Private) == 0 && (c.VPC.CreateSubnets == nil || len(c.VPC.CreateSubnets.PrivateCIDRs) == 0) {
+			return errors.New("vpc.subnets.private must be specified in a fully-private cluster when a pre-existing VPC is supplied, unless vpc.createSubnets.privateCIDRs is set")
 		}
 
 		if additionalEndpoints := c.PrivateCluster.AdditionalEndpointServices; len(additionalEndpoints) > 0 {
@@ -511,6 +844,10 @@ func (c *ClusterConfig) ValidatePrivateCluster() error {
 			}
 		}
 
+		if err := c.PrivateCluster.AdminAccess.validate(); err != nil {
+			return err
+		}
+
 		if c.VPC != nil && c.VPC.ClusterEndpoints == nil {
 			c.VPC.ClusterEndpoints = &ClusterEndpoints{}
 		}
@@ -526,6 +863,23 @@ func (c *ClusterConfig) ValidatePrivateCluster() error {
 	return nil
 }
 
+func (a *PrivateClusterAdminAccess) validate() error {
+	if a == nil {
+		return nil
+	}
+	switch a.Type {
+	case PrivateClusterAdminAccessInstanceConnectEndpoint:
+		if a.InstanceType != "" {
+			return fmt.Errorf("privateCluster.adminAccess.instanceType is only valid with type %s", PrivateClusterAdminAccessBastion)
+		}
+	case PrivateClusterAdminAccessBastion:
+	default:
+		return fmt.Errorf("invalid value %q for privateCluster.adminAccess.type; must be one of %s or %s", a.Type,
+			PrivateClusterAdminAccessInstanceConnectEndpoint, PrivateClusterAdminAccessBastion)
+	}
+	return nil
+}
+
 // validateKubernetesNetworkConfig validates the k8s network config
 func (c *ClusterConfig) validateKubernetesNetworkConfig() error {
 	if c.KubernetesNetworkConfig == nil {
@@ -703,6 +1057,12 @@ func validateNodeGroupBase(np NodePool, path string, controlPlaneOnOutposts bool
 		}
 	}
 
+	if ng.GPU != nil {
+		if err := validateNodeGroupGPU(np, instanceType, path); err != nil {
+			return err
+		}
+	}
+
 	if ng.CapacityReservation != nil {
 		if ng.CapacityReservation.CapacityReservationPreference != nil {
 			if ng.CapacityReservation.CapacityReservationTarget != nil {
@@ -724,6 +1084,56 @@ func validateNodeGroupBase(np NodePool, path string, controlPlaneOnOutposts bool
 	return nil
 }
 
+func validateNodeGroupGPU(np NodePool, instanceType, path string) error {
+	ng := np.BaseNodeGroup()
+	if ng.AMIFamily != NodeImageFamilyAmazonLinux2 && ng.AMIFamily != NodeImageFamilyAmazonLinux2023 && ng.AMIFamily != "" {
+		return fmt.Errorf("%s.gpu is only supported for %s and %s", path, NodeImageFamilyAmazonLinux2, NodeImageFamilyAmazonLinux2023)
+	}
+	// instance types chosen by the instance selector are only known later
+	if instanceType != "" && !instanceutils.IsNvidiaInstanceType(instanceType) {
+		return fmt.Errorf("%s.gpu requires an NVIDIA GPU instance type; got %q", path, instanceType)
+	}
+
+	gpu := ng.GPU
+	if gpu.TimeSlicing == nil && gpu.MIG == nil {
+		return fmt.Errorf("at least one of %[1]s.gpu.timeSlicing or %[1]s.gpu.mig must be set", path)
+	}
+	if gpu.TimeSlicing != nil && gpu.TimeSlicing.Replicas < 2 {
+		return fmt.Errorf("%s.gpu.timeSlicing.replicas must be at least 2", path)
+	}
+
+	mig := gpu.MIG
+	if mig == nil {
+		return nil
+	}
+	if instanceType != "" && !instanceutils.IsMIGInstanceType(instanceType) {
+		return fmt.Errorf("%s.gpu.mig is not supported for instance type %q", path, instanceType)
+	}
+	if mng, ok := np.(*ManagedNodeGroup); ok && mng.AMI != "" {
+		return fmt.Errorf("%s.gpu.mig is not supported for managed nodegroups with a custom AMI", path)
+	}
+	if len(mig.Profiles) == 0 {
+		return fmt.Errorf("%s.gpu.mig.profiles must be set", path)
+	}
+	for _, profile := range mig.Profiles {
+		if !migProfileRegex.MatchString(profile) {
+			return fmt.Errorf("invalid MIG profile %q in %s.gpu.mig.profiles; profiles must be of the form <slices>g.<memory>gb, e.g. 3g.20gb", profile, path)
+		}
+	}
+	switch mig.Strategy {
+	case "", MIGStrategyMixed:
+	case MIGStrategySingle:
+		for _, profile := range mig.Profiles {
+			if profile != mig.Profiles[0] {
+				return fmt.Errorf("%s.gpu.mig.profiles must all be the same with strategy %q", path, MIGStrategySingle)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid value %q for %s.gpu.mig.strategy; must be one of %s or %s", mig.Strategy, path, MIGStrategySingle, MIGStrategyMixed)
+	}
+	return nil
+}
+
 func validateVolumeOpts(ng *NodeGroupBase, path string, controlPlaneOnOutposts bool) error {
 	if ng.VolumeType != nil {
 		volumeType := *ng.VolumeType
@@ -776,10 +1186,16 @@ func validateIdentityProvider(idP IdentityProvider) error {
 }
 
 func validateIdentityProviders(idPs []IdentityProvider) error {
+	idPNames := nameSet{}
 	for k, idP := range idPs {
 		if err := validateIdentityProvider(idP); err != nil {
 			return errors.Wrapf(err, "identityProviders[%d] is invalid", k)
 		}
+		if oidc, ok := idP.Inner.(*OIDCIdentityProvider); ok {
+			if _, err := idPNames.checkUnique(fmt.Sprintf("identityProviders[%d].name", k), oidc.Name); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -978,6 +1394,29 @@ func ValidateNodeGroup(i int, ng *NodeGroup, cfg *ClusterConfig) error {
 	return nil
 }
 
+func validateIAMRoleARN(val string) error {
+	parsed, err := arn.Parse(val)
+	if err != nil {
+		return err
+	}
+	if parsed.Service != "iam" || !strings.HasPrefix(parsed.Resource, "role/") {
+		return fmt.Errorf("%q is not an IAM role ARN", val)
+	}
+	return nil
+}
+
+// validateIAMRolePath validates an IAM path, see
+// https://docs.aws.amazon.com/IAM/latest/APIReference/API_CreateRole.html
+func validateIAMRolePath(path string) error {
+	if len(path) > 512 {
+		return fmt.Errorf("%q is longer than 512 characters", path)
+	}
+	if !iamRolePathRegex.MatchString(path) {
+		return fmt.Errorf("%q must begin and end with a forward slash and contain only printable ASCII characters", path)
+	}
+	return nil
+}
+
 func validateOutpostARN(val string) error {
 	parsed, err := arn.Parse(val)
 	if err != nil {
@@ -1183,11 +1622,8 @@ func ValidateManagedNodeGroup(index int, ng *ManagedNodeGroup) error {
 	}
 
 	if ng.UpdateConfig != nil {
-		if ng.UpdateConfig.MaxUnavailable == nil && ng.UpdateConfig.MaxUnavailablePercentage == nil {
-			return fmt.Errorf("invalid UpdateConfig: maxUnavailable or maxUnavailablePercentage must be defined")
-		}
-		if ng.UpdateConfig.MaxUnavailable != nil && ng.UpdateConfig.MaxUnavailablePercentage != nil {
-			return fmt.Errorf("cannot use maxUnavailable=%d and maxUnavailablePercentage=%d at the same time", *ng.UpdateConfig.MaxUnavailable, *ng.UpdateConfig.MaxUnavailablePercentage)
+		if err := ValidateNodeGroupUpdateConfig(ng.UpdateConfig); err != nil {
+			return err
 		}
 		if aws.ToInt(ng.UpdateConfig.MaxUnavailable) > aws.ToInt(ng.MaxSize) {
 			return fmt.Errorf("maxUnavailable=%d cannot be greater than maxSize=%d", *ng.UpdateConfig.MaxUnavailable, *ng.MaxSize)
@@ -1555,6 +1991,23 @@ func validateCIDRs(cidrs []string) ([]string, error) {
 	return validCIDRs, nil
 }
 
+// validatePublicAccessCIDRs validates CIDRs like validateCIDRs, but also allows the IDs of managed prefix lists
+func validatePublicAccessCIDRs(cidrs []string) ([]string, error) {
+	var validCIDRs []string
+	for _, cidr := range cidrs {
+		if IsPrefixListID(cidr) {
+			validCIDRs = append(validCIDRs, cidr)
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q in vpc.publicAccessCIDRs; must be a CIDR or the ID of a managed prefix list: %w", cidr, err)
+		}
+		validCIDRs = append(validCIDRs, ipNet.String())
+	}
+	return validCIDRs, nil
+}
+
 func validateTaints(ngTaints []NodeGroupTaint) error {
 	for _, t := range ngTaints {
 		if err := taints.Validate(corev1.Taint{
@@ -1568,6 +2021,26 @@ func validateTaints(ngTaints []NodeGroupTaint) error {
 	return nil
 }
 
+// maxUnavailableNodes is the highest number of nodes of a managed nodegroup updated at once
+const maxUnavailableNodes = 100
+
+// ValidateNodeGroupUpdateConfig validates the update config of a managed nodegroup.
+func ValidateNodeGroupUpdateConfig(updateConfig *NodeGroupUpdateConfig) error {
+	if updateConfig.MaxUnavailable == nil && updateConfig.MaxUnavailablePercentage == nil {
+		return fmt.Errorf("invalid UpdateConfig: maxUnavailable or maxUnavailablePercentage must be defined")
+	}
+	if updateConfig.MaxUnavailable != nil && updateConfig.MaxUnavailablePercentage != nil {
+		return fmt.Errorf("cannot use maxUnavailable=%d and maxUnavailablePercentage=%d at the same time", *updateConfig.MaxUnavailable, *updateConfig.MaxUnavailablePercentage)
+	}
+	if updateConfig.MaxUnavailable != nil && (*updateConfig.MaxUnavailable < 1 || *updateConfig.MaxUnavailable > maxUnavailableNodes) {
+		return fmt.Errorf("maxUnavailable must be between 1 and %d, got %d", maxUnavailableNodes, *updateConfig.MaxUnavailable)
+	}
+	if updateConfig.MaxUnavailablePercentage != nil && (*updateConfig.MaxUnavailablePercentage < 1 || *updateConfig.MaxUnavailablePercentage > 100) {
+		return fmt.Errorf("maxUnavailablePercentage must be between 1 and 100, got %d", *updateConfig.MaxUnavailablePercentage)
+	}
+	return nil
+}
+
 // ReservedProfileNamePrefix defines the Fargate profile name prefix reserved
 // for AWS, and which therefore, cannot be used by users. AWS' API should
 // reject the creation of profiles starting with this prefix, but we eagerly
@@ -1593,11 +2066,41 @@ func (fp FargateProfile) Validate() error {
 	return nil
 }
 
+// ValidateFargateLogging validates the configuration of the Fargate log router.
+func ValidateFargateLogging(logging *FargateLogging) error {
+	if logging == nil {
+		return nil
+	}
+	switch logging.Destination {
+	case FargateLoggingCloudWatch:
+	case FargateLoggingFirehose:
+		if logging.DeliveryStream == "" {
+			return errors.New("fargateLogging.deliveryStream must be set when the destination is firehose")
+		}
+	case FargateLoggingOpenSearch:
+		if logging.OpenSearchEndpoint == "" {
+			return errors.New("fargateLogging.openSearchEndpoint must be set when the destination is opensearch")
+		}
+	default:
+		return fmt.Errorf("invalid fargateLogging.destination %q, valid values are: %s, %s, %s",
+			logging.Destination, FargateLoggingCloudWatch, FargateLoggingFirehose, FargateLoggingOpenSearch)
+	}
+	return nil
+}
+
 // Validate validates this FargateProfileSelector object.
 func (fps FargateProfileSelector) Validate() error {
 	if fps.Namespace == "" {
 		return errors.New("empty namespace")
 	}
+	if err := validateFargateSelectorNamespace(fps.Namespace); err != nil {
+		return err
+	}
+	for key := range fps.Labels {
+		if key == "" {
+			return errors.New("empty label key")
+		}
+	}
 	return nil
 }
 
@@ -1,10 +1,14 @@
 package v1alpha5_test
 
 import (
+	"bytes"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -626,6 +630,41 @@ var _ = Describe("ClusterConfig validation", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(HavePrefix("<namespace>/<name> of iam.serviceAccounts[4] \"/sa-1\" is not unique"))
 		})
+
+		It("should pass when iam.serviceAccounts[0].targetAccountRoleARN is a valid role ARN", func() {
+			cfg.IAM.WithOIDC = api.Enabled()
+
+			cfg.IAM.ServiceAccounts = []*api.ClusterIAMServiceAccount{{}}
+			cfg.IAM.ServiceAccounts[0].Name = "sa-1"
+			cfg.IAM.ServiceAccounts[0].AttachPolicyARNs = []string{"arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"}
+			cfg.IAM.ServiceAccounts[0].TargetAccountRoleARN = "arn:aws:iam::111122223333:role/eksctl-irsa-deployer"
+
+			Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
+		})
+
+		It("should fail when iam.serviceAccounts[0].targetAccountRoleARN is not a role ARN", func() {
+			cfg.IAM.WithOIDC = api.Enabled()
+
+			cfg.IAM.ServiceAccounts = []*api.ClusterIAMServiceAccount{{}}
+			cfg.IAM.ServiceAccounts[0].Name = "sa-1"
+			cfg.IAM.ServiceAccounts[0].AttachPolicyARNs = []string{"arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"}
+			cfg.IAM.ServiceAccounts[0].TargetAccountRoleARN = "arn:aws:iam::111122223333:user/deployer"
+
+			err = api.ValidateClusterConfig(cfg)
+			Expect(err).To(MatchError(ContainSubstring("iam.serviceAccounts[0].targetAccountRoleARN is invalid")))
+		})
+
+		It("should fail when iam.serviceAccounts[0] sets both targetAccountRoleARN and attachRoleARN", func() {
+			cfg.IAM.WithOIDC = api.Enabled()
+
+			cfg.IAM.ServiceAccounts = []*api.ClusterIAMServiceAccount{{}}
+			cfg.IAM.ServiceAccounts[0].Name = "sa-1"
+			cfg.IAM.ServiceAccounts[0].AttachRoleARN = "arn:aws:iam::123456789012:role/existing"
+			cfg.IAM.ServiceAccounts[0].TargetAccountRoleARN = "arn:aws:iam::111122223333:role/eksctl-irsa-deployer"
+
+			err = api.ValidateClusterConfig(cfg)
+			Expect(err).To(MatchError("iam.serviceAccounts[0].targetAccountRoleARN cannot be used in conjunction with iam.serviceAccounts[0].attachRoleARN"))
+		})
 	})
 
 	Describe("cloudWatch.clusterLogging", func() {
@@ -691,6 +730,36 @@ var _ = Describe("ClusterConfig validation", func() {
 			},
 			expectedErr: "cannot set cloudWatch.clusterLogging.logRetentionInDays without enabling log types",
 		}),
+
+		Entry("log group KMS key and tags", logRetentionEntry{
+			logging: &api.ClusterCloudWatchLogging{
+				EnableTypes:       []string{"api"},
+				LogGroupKMSKeyARN: "arn:aws:kms:us-west-2:000000000000:key/12345678-1234-1234-1234-123456789012",
+				LogGroupTags:      map[string]string{"team": "platform"},
+			},
+		}),
+
+		Entry("invalid log group KMS key", logRetentionEntry{
+			logging: &api.ClusterCloudWatchLogging{
+				EnableTypes:       []string{"api"},
+				LogGroupKMSKeyARN: "12345678-1234-1234-1234-123456789012",
+			},
+			expectedErr: `invalid value "12345678-1234-1234-1234-123456789012" for cloudWatch.clusterLogging.logGroupKMSKeyARN`,
+		}),
+
+		Entry("log group KMS key without enableTypes", logRetentionEntry{
+			logging: &api.ClusterCloudWatchLogging{
+				LogGroupKMSKeyARN: "arn:aws:kms:us-west-2:000000000000:key/12345678-1234-1234-1234-123456789012",
+			},
+			expectedErr: "cannot set cloudWatch.clusterLogging.logGroupKMSKeyARN without enabling log types",
+		}),
+
+		Entry("log group tags without enableTypes", logRetentionEntry{
+			logging: &api.ClusterCloudWatchLogging{
+				LogGroupTags: map[string]string{"team": "platform"},
+			},
+			expectedErr: "cannot set cloudWatch.clusterLogging.logGroupTags without enabling log types",
+		}),
 	)
 
 	type vpcHostnameTypeEntry struct {
@@ -743,6 +812,80 @@ var _ = Describe("ClusterConfig validation", func() {
 		}),
 	)
 
+	type vpcCreateSubnetsEntry struct {
+		vpcID             string
+		createSubnets     *api.VPCSubnetCreation
+		availabilityZones []string
+		expectedErr       string
+	}
+
+	DescribeTable("vpc.createSubnets", func(e vpcCreateSubnetsEntry) {
+		clusterConfig := api.NewClusterConfig()
+		clusterConfig.VPC.ID = e.vpcID
+		clusterConfig.VPC.CreateSubnets = e.createSubnets
+		clusterConfig.AvailabilityZones = e.availabilityZones
+		err := api.ValidateClusterConfig(clusterConfig)
+		if e.expectedErr != "" {
+			Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+	},
+		Entry("public and private subnets", vpcCreateSubnetsEntry{
+			vpcID: "vpc-1234",
+			createSubnets: &api.VPCSubnetCreation{
+				PublicCIDRs:       []string{"10.0.0.0/24", "10.0.1.0/24"},
+				PrivateCIDRs:      []string{"10.0.2.0/24", "10.0.3.0/24"},
+				InternetGatewayID: "igw-1234",
+				NATGatewayID:      "nat-1234",
+			},
+			availabilityZones: []string{"us-west-2a", "us-west-2b"},
+		}),
+
+		Entry("without a VPC ID", vpcCreateSubnetsEntry{
+			createSubnets: &api.VPCSubnetCreation{
+				PrivateCIDRs: []string{"10.0.2.0/24", "10.0.3.0/24"},
+			},
+			availabilityZones: []string{"us-west-2a", "us-west-2b"},
+			expectedErr:       "vpc.createSubnets can only be used with a pre-existing VPC set in vpc.id",
+		}),
+
+		Entry("public subnets without an internet gateway", vpcCreateSubnetsEntry{
+			vpcID: "vpc-1234",
+			createSubnets: &api.VPCSubnetCreation{
+				PublicCIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"},
+			},
+			availabilityZones: []string{"us-west-2a", "us-west-2b"},
+			expectedErr:       "vpc.createSubnets.internetGatewayID must be set when vpc.createSubnets.publicCIDRs is set",
+		}),
+
+		Entry("without availability zones", vpcCreateSubnetsEntry{
+			vpcID: "vpc-1234",
+			createSubnets: &api.VPCSubnetCreation{
+				PrivateCIDRs: []string{"10.0.2.0/24", "10.0.3.0/24"},
+			},
+			expectedErr: "availabilityZones must be set when using vpc.createSubnets",
+		}),
+
+		Entry("fewer CIDRs than availability zones", vpcCreateSubnetsEntry{
+			vpcID: "vpc-1234",
+			createSubnets: &api.VPCSubnetCreation{
+				PrivateCIDRs: []string{"10.0.2.0/24"},
+			},
+			availabilityZones: []string{"us-west-2a", "us-west-2b"},
+			expectedErr:       "vpc.createSubnets.privateCIDRs must have one CIDR per availability zone, got 1 CIDRs for 2 availability zones",
+		}),
+
+		Entry("invalid CIDR", vpcCreateSubnetsEntry{
+			vpcID: "vpc-1234",
+			createSubnets: &api.VPCSubnetCreation{
+				PrivateCIDRs: []string{"10.0.2.0/24", "10.0.3.0"},
+			},
+			availabilityZones: []string{"us-west-2a", "us-west-2b"},
+			expectedErr:       "invalid vpc.createSubnets.privateCIDRs",
+		}),
+	)
+
 	Describe("Cluster Endpoint access", func() {
 		var cfg *api.ClusterConfig
 
@@ -958,6 +1101,57 @@ var _ = Describe("ClusterConfig validation", func() {
 			cfg.VPC = vpc
 		})
 
+		Context("vpcCNI", func() {
+			It("rejects custom networking with IPv6", func() {
+				cfg.KubernetesNetworkConfig.IPFamily = api.IPV6Family
+				cfg.VPC.NAT = nil
+				cfg.Addons = append(cfg.Addons,
+					&api.Addon{Name: api.KubeProxyAddon},
+					&api.Addon{Name: api.CoreDNSAddon},
+					&api.Addon{Name: api.VPCCNIAddon, VPCCNI: &api.VPCCNIConfig{CustomNetworking: true}},
+				)
+				cfg.IAM = &api.ClusterIAM{
+					WithOIDC: api.Enabled(),
+				}
+				err = api.ValidateClusterConfig(cfg)
+				Expect(err).To(MatchError("vpcCNI.customNetworking is not supported with IPv6"))
+			})
+
+			It("rejects security groups for pods without the VPC resource controller policy", func() {
+				cfg.Addons = append(cfg.Addons, &api.Addon{Name: api.VPCCNIAddon, VPCCNI: &api.VPCCNIConfig{PodENI: true}})
+				cfg.IAM.VPCResourceControllerPolicy = api.Disabled()
+				err = api.ValidateClusterConfig(cfg)
+				Expect(err).To(MatchError(ContainSubstring("vpcCNI.podENI requires iam.vpcResourceControllerPolicy")))
+			})
+
+			It("warns about nodegroups that don't raise their max pods with prefix delegation", func() {
+				output := &bytes.Buffer{}
+				logger.Writer = output
+				DeferCleanup(func() {
+					logger.Writer = os.Stdout
+				})
+				cfg.Addons = append(cfg.Addons, &api.Addon{Name: api.VPCCNIAddon, VPCCNI: &api.VPCCNIConfig{PrefixDelegation: true}})
+				ng := cfg.NewNodeGroup()
+				ng.Name = "self-managed"
+				ng = cfg.NewNodeGroup()
+				ng.Name = "self-managed-max-pods"
+				ng.MaxPodsPerNode = 110
+				mng := api.NewManagedNodeGroup()
+				mng.Name = "managed"
+				cfg.ManagedNodeGroups = append(cfg.ManagedNodeGroups, mng)
+				mng = api.NewManagedNodeGroup()
+				mng.Name = "managed-custom-ami"
+				mng.AMI = "ami-123"
+				cfg.ManagedNodeGroups = append(cfg.ManagedNodeGroups, mng)
+
+				Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
+				Expect(output.String()).To(ContainSubstring("vpcCNI.prefixDelegation is enabled but nodeGroups[0].maxPodsPerNode is not set"))
+				Expect(output.String()).NotTo(ContainSubstring("nodeGroups[1].maxPodsPerNode"))
+				Expect(output.String()).NotTo(ContainSubstring("managedNodeGroups[0].maxPodsPerNode"))
+				Expect(output.String()).To(ContainSubstring("vpcCNI.prefixDelegation is enabled but managedNodeGroups[1].maxPodsPerNode is not set"))
+			})
+		})
+
 		Context("ipFamily", func() {
 			It("should not error default ipFamily setting", func() {
 				err = api.ValidateClusterConfig(cfg)
@@ -1252,6 +1446,21 @@ var _ = Describe("ClusterConfig validation", func() {
 					Expect(err).To(HaveOccurred())
 				})
 			})
+
+			When("public access cidrs has managed prefix lists", func() {
+				It("keeps the prefix list IDs", func() {
+					cfg.VPC.PublicAccessCIDRs = []string{"pl-0123456789abcdef0", "3.48.58.68/24"}
+					err = cfg.ValidateVPCConfig()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cfg.VPC.PublicAccessCIDRs).To(Equal([]string{"pl-0123456789abcdef0", "3.48.58.0/24"}))
+				})
+
+				It("returns an error for an invalid prefix list ID", func() {
+					cfg.VPC.PublicAccessCIDRs = []string{"pl-xyz"}
+					err = cfg.ValidateVPCConfig()
+					Expect(err).To(MatchError(ContainSubstring(`invalid value "pl-xyz" in vpc.publicAccessCIDRs`)))
+				})
+			})
 		})
 
 		Context("ipv6 CIDRs", func() {
@@ -1450,6 +1659,29 @@ var _ = Describe("ClusterConfig validation", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
+		When("adminAccess is set", func() {
+			It("accepts a bastion", func() {
+				cfg.PrivateCluster.AdminAccess = &api.PrivateClusterAdminAccess{Type: api.PrivateClusterAdminAccessBastion, InstanceType: "t3.small"}
+				Expect(cfg.ValidatePrivateCluster()).To(Succeed())
+			})
+			It("accepts an EC2 Instance Connect Endpoint", func() {
+				cfg.PrivateCluster.AdminAccess = &api.PrivateClusterAdminAccess{Type: api.PrivateClusterAdminAccessInstanceConnectEndpoint}
+				Expect(cfg.ValidatePrivateCluster()).To(Succeed())
+			})
+			It("fails when the cluster is not fully-private", func() {
+				cfg.PrivateCluster.Enabled = false
+				cfg.PrivateCluster.AdminAccess = &api.PrivateClusterAdminAccess{Type: api.PrivateClusterAdminAccessBastion}
+				Expect(cfg.ValidatePrivateCluster()).To(MatchError("privateCluster.adminAccess is only valid for fully-private clusters"))
+			})
+			It("fails when instanceType is set for an EC2 Instance Connect Endpoint", func() {
+				cfg.PrivateCluster.AdminAccess = &api.PrivateClusterAdminAccess{Type: api.PrivateClusterAdminAccessInstanceConnectEndpoint, InstanceType: "t3.small"}
+				Expect(cfg.ValidatePrivateCluster()).To(MatchError(ContainSubstring("privateCluster.adminAccess.instanceType is only valid with type Bastion")))
+			})
+			It("fails for an unknown type", func() {
+				cfg.PrivateCluster.AdminAccess = &api.PrivateClusterAdminAccess{Type: "VPN"}
+				Expect(cfg.ValidatePrivateCluster()).To(MatchError(ContainSubstring(`invalid value "VPN" for privateCluster.adminAccess.type`)))
+			})
+		})
 	})
 
 	type cpuCreditsEntry struct {
@@ -1848,7 +2080,51 @@ var _ = Describe("ClusterConfig validation", func() {
 				err := profile.Validate()
 				Expect(err).NotTo(HaveOccurred())
 			})
+
+			It("passes when the selectors hold wildcards", func() {
+				profile := api.FargateProfile{
+					Name: "default",
+					Selectors: []api.FargateProfileSelector{
+						{
+							Namespace: "prod-*",
+							Labels:    map[string]string{"app.kubernetes.io/*": "web-?"},
+						},
+					},
+				}
+				Expect(profile.Validate()).To(Succeed())
+			})
+
+			It("returns an error when the namespace of a selector is invalid", func() {
+				profile := api.FargateProfile{
+					Name: "default",
+					Selectors: []api.FargateProfileSelector{
+						{Namespace: "Prod_*"},
+					},
+				}
+				err := profile.Validate()
+				Expect(err).To(MatchError(ContainSubstring(`invalid profile selector at index #0: invalid namespace "Prod_*"`)))
+			})
 		})
+
+		DescribeTable("FargateProfileSelector.Matches", func(selector api.FargateProfileSelector, namespace string, labels map[string]string, expected bool) {
+			Expect(selector.Matches(namespace, labels)).To(Equal(expected))
+		},
+			Entry("exact namespace", api.FargateProfileSelector{Namespace: "default"}, "default", nil, true),
+			Entry("other namespace", api.FargateProfileSelector{Namespace: "default"}, "kube-system", nil, false),
+			Entry("namespace with *", api.FargateProfileSelector{Namespace: "prod-*"}, "prod-payments", nil, true),
+			Entry("namespace with * matching nothing", api.FargateProfileSelector{Namespace: "prod-*"}, "prod-", nil, true),
+			Entry("namespace with ?", api.FargateProfileSelector{Namespace: "team-?"}, "team-a", nil, true),
+			Entry("namespace with ? matching two characters", api.FargateProfileSelector{Namespace: "team-?"}, "team-ab", nil, false),
+			Entry("namespace with several *", api.FargateProfileSelector{Namespace: "*-prod-*"}, "payments-prod-eu", nil, true),
+			Entry("labels with wildcards", api.FargateProfileSelector{
+				Namespace: "*",
+				Labels:    map[string]string{"app.kubernetes.io/*": "web-*"},
+			}, "default", map[string]string{"app.kubernetes.io/name": "web-frontend"}, true),
+			Entry("missing label", api.FargateProfileSelector{
+				Namespace: "*",
+				Labels:    map[string]string{"app": "web"},
+			}, "default", map[string]string{"team": "a"}, false),
+		)
 	})
 
 	Describe("Bottlerocket node groups", func() {
@@ -2123,6 +2399,88 @@ var _ = Describe("ClusterConfig validation", func() {
 		})
 	})
 
+	type gpuSharingEntry struct {
+		updateNodeGroup func(*api.NodeGroupBase)
+		expectedErr     string
+	}
+
+	DescribeTable("nodeGroups[*].gpu", func(e gpuSharingEntry) {
+		cfg := api.NewClusterConfig()
+		ng := cfg.NewNodeGroup()
+		ng.Name = "gpu"
+		ng.AMIFamily = api.NodeImageFamilyAmazonLinux2
+		ng.InstanceType = "p4d.24xlarge"
+		ng.GPU = &api.NodeGroupGPU{}
+		e.updateNodeGroup(ng.NodeGroupBase)
+		err := api.ValidateNodeGroup(0, ng, cfg)
+		if e.expectedErr != "" {
+			Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+			return
+		}
+		Expect(err).NotTo(HaveOccurred())
+	},
+		Entry("time-slicing", gpuSharingEntry{
+			updateNodeGroup: func(ng *api.NodeGroupBase) {
+				ng.GPU.TimeSlicing = &api.GPUTimeSlicing{Replicas: 4}
+			},
+		}),
+		Entry("MIG with time-slicing", gpuSharingEntry{
+			updateNodeGroup: func(ng *api.NodeGroupBase) {
+				ng.GPU.MIG = &api.GPUMIG{Profiles: []string{"3g.20gb", "2g.10gb", "1g.5gb"}, Strategy: api.MIGStrategyMixed}
+				ng.GPU.TimeSlicing = &api.GPUTimeSlicing{Replicas: 2}
+			},
+		}),
+		Entry("neither time-slicing nor MIG", gpuSharingEntry{
+			updateNodeGroup: func(_ *api.NodeGroupBase) {},
+			expectedErr:     "at least one of nodeGroups[0].gpu.timeSlicing or nodeGroups[0].gpu.mig must be set",
+		}),
+		Entry("unsupported AMI family", gpuSharingEntry{
+			updateNodeGroup: func(ng *api.NodeGroupBase) {
+				ng.AMIFamily = api.NodeImageFamilyBottlerocket
+				ng.GPU.TimeSlicing = &api.GPUTimeSlicing{Replicas: 4}
+			},
+			expectedErr: "nodeGroups[0].gpu is only supported for AmazonLinux2 and AmazonLinux2023",
+		}),
+		Entry("non-GPU instance type", gpuSharingEntry{
+			updateNodeGroup: func(ng *api.NodeGroupBase) {
+				ng.InstanceType = "m5.large"
+				ng.GPU.TimeSlicing = &api.GPUTimeSlicing{Replicas: 4}
+			},
+			expectedErr: `nodeGroups[0].gpu requires an NVIDIA GPU instance type; got "m5.large"`,
+		}),
+		Entry("too few time-slicing replicas", gpuSharingEntry{
+			updateNodeGroup: func(ng *api.NodeGroupBase) {
+				ng.GPU.TimeSlicing = &api.GPUTimeSlicing{Replicas: 1}
+			},
+			expectedErr: "nodeGroups[0].gpu.timeSlicing.replicas must be at least 2",
+		}),
+		Entry("MIG on an instance type without MIG support", gpuSharingEntry{
+			updateNodeGroup: func(ng *api.NodeGroupBase) {
+				ng.InstanceType = "g5.xlarge"
+				ng.GPU.MIG = &api.GPUMIG{Profiles: []string{"3g.20gb"}}
+			},
+			expectedErr: `nodeGroups[0].gpu.mig is not supported for instance type "g5.xlarge"`,
+		}),
+		Entry("invalid MIG profile", gpuSharingEntry{
+			updateNodeGroup: func(ng *api.NodeGroupBase) {
+				ng.GPU.MIG = &api.GPUMIG{Profiles: []string{"half"}}
+			},
+			expectedErr: `invalid MIG profile "half"`,
+		}),
+		Entry("different MIG profiles with the single strategy", gpuSharingEntry{
+			updateNodeGroup: func(ng *api.NodeGroupBase) {
+				ng.GPU.MIG = &api.GPUMIG{Profiles: []string{"3g.20gb", "1g.5gb"}, Strategy: api.MIGStrategySingle}
+			},
+			expectedErr: `nodeGroups[0].gpu.mig.profiles must all be the same with strategy "single"`,
+		}),
+		Entry("invalid MIG strategy", gpuSharingEntry{
+			updateNodeGroup: func(ng *api.NodeGroupBase) {
+				ng.GPU.MIG = &api.GPUMIG{Profiles: []string{"3g.20gb"}, Strategy: "none"}
+			},
+			expectedErr: `invalid value "none" for nodeGroups[0].gpu.mig.strategy`,
+		}),
+	)
+
 	Describe("Windows node groups", func() {
 		It("returns an error with unsupported fields", func() {
 			doc := api.InlineDocument{
@@ -2313,6 +2671,408 @@ var _ = Describe("ClusterConfig validation", func() {
 		})
 	})
 
+	DescribeTable("metadata.ttl", func(ttl, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.TTL = ttl
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		}
+	},
+		Entry("unset", "", ""),
+		Entry("valid duration", "4h", ""),
+		Entry("invalid duration", "4 hours", `invalid metadata.ttl "4 hours"`),
+		Entry("negative duration", "-1h", `metadata.ttl must be a positive duration, got "-1h"`),
+	)
+
+	DescribeTable("iam.rolePath", func(rolePath, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.IAM.RolePath = rolePath
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		}
+	},
+		Entry("root path", "/", ""),
+		Entry("nested path", "/eksctl/clusters/", ""),
+		Entry("missing leading slash", "eksctl/", `iam.rolePath is invalid: "eksctl/" must begin and end with a forward slash`),
+		Entry("missing trailing slash", "/eksctl", `iam.rolePath is invalid: "/eksctl" must begin and end with a forward slash`),
+		Entry("too long", "/"+strings.Repeat("a", 512)+"/", "is longer than 512 characters"),
+	)
+
+	DescribeTable("autoModeConfig", func(updateConfig func(*api.ClusterConfig), expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.AutoModeConfig = &api.AutoModeConfig{
+			Enabled: api.Enabled(),
+		}
+		updateConfig(cfg)
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		}
+	},
+		Entry("default node pools", func(*api.ClusterConfig) {}, ""),
+		Entry("custom node role", func(c *api.ClusterConfig) {
+			c.AutoModeConfig.NodeRoleARN = "arn:aws:iam::123456789012:role/auto-mode-nodes"
+		}, ""),
+		Entry("no built-in node pools", func(c *api.ClusterConfig) {
+			c.AutoModeConfig.NodePools = &[]string{}
+		}, ""),
+		Entry("unknown node pool", func(c *api.ClusterConfig) {
+			c.AutoModeConfig.NodePools = &[]string{"gpu"}
+		}, `invalid value "gpu" for autoModeConfig.nodePools`),
+		Entry("duplicate node pool", func(c *api.ClusterConfig) {
+			c.AutoModeConfig.NodePools = &[]string{api.AutoModeNodePoolSystem, api.AutoModeNodePoolSystem}
+		}, `autoModeConfig.nodePools "system" is not unique`),
+		Entry("invalid node role", func(c *api.ClusterConfig) {
+			c.AutoModeConfig.NodeRoleARN = "arn:aws:iam::123456789012:instance-profile/auto-mode-nodes"
+		}, "autoModeConfig.nodeRoleARN is invalid"),
+		Entry("nodeGroups", func(c *api.ClusterConfig) {
+			ng := newNodeGroup()
+			ng.Name = "ng"
+			c.NodeGroups = []*api.NodeGroup{ng}
+		}, "nodeGroups and managedNodeGroups cannot be specified when Auto Mode is enabled"),
+		Entry("managedNodeGroups", func(c *api.ClusterConfig) {
+			mng := api.NewManagedNodeGroup()
+			mng.Name = "mng"
+			c.ManagedNodeGroups = []*api.ManagedNodeGroup{mng}
+		}, "nodeGroups and managedNodeGroups cannot be specified when Auto Mode is enabled"),
+		Entry("Karpenter", func(c *api.ClusterConfig) {
+			c.IAM.WithOIDC = api.Enabled()
+			c.Karpenter = &api.Karpenter{Version: "0.20.0"}
+		}, "karpenter cannot be installed when Auto Mode is enabled"),
+		Entry("CONFIG_MAP authentication mode", func(c *api.ClusterConfig) {
+			c.AccessConfig.AuthenticationMode = ekstypes.AuthenticationModeConfigMap
+		}, "accessConfig.authenticationMode must be set to either API_AND_CONFIG_MAP or API when Auto Mode is enabled"),
+		Entry("node pools set while disabled", func(c *api.ClusterConfig) {
+			c.AutoModeConfig.Enabled = api.Disabled()
+			c.AutoModeConfig.NodePools = &[]string{api.AutoModeNodePoolSystem}
+		}, "can only be set when autoModeConfig.enabled is true"),
+	)
+
+	DescribeTable("serviceEndpoints", func(endpoints *api.ServiceEndpoints, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.ServiceEndpoints = endpoints
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(expectedErr))
+		}
+	},
+		Entry("valid endpoints", &api.ServiceEndpoints{
+			EKS:            "https://eks.us-gov-west-1.amazonaws.com",
+			CloudFormation: "http://localhost:4566",
+		}, ""),
+		Entry("endpoint without scheme", &api.ServiceEndpoints{
+			EC2: "ec2.us-west-2.amazonaws.com",
+		}, `serviceEndpoints.ec2 must be an absolute http or https URL, got "ec2.us-west-2.amazonaws.com"`),
+		Entry("endpoint with unsupported scheme", &api.ServiceEndpoints{
+			STS: "ftp://sts.amazonaws.com",
+		}, `serviceEndpoints.sts must be an absolute http or https URL, got "ftp://sts.amazonaws.com"`),
+	)
+
+	DescribeTable("proxy", func(proxy *api.ProxyConfig, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.Proxy = proxy
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(expectedErr))
+		}
+	},
+		Entry("valid proxy", &api.ProxyConfig{
+			HTTPSProxy:       "http://proxy.corp.example.com:3128",
+			NoProxy:          []string{"10.0.0.0/8", ".internal"},
+			CABundle:         "/etc/pki/corp-ca.pem",
+			PropagateToNodes: api.Enabled(),
+		}, ""),
+		Entry("CA bundle only", &api.ProxyConfig{
+			CABundle: "/etc/pki/corp-ca.pem",
+		}, ""),
+		Entry("proxy without scheme", &api.ProxyConfig{
+			HTTPSProxy: "proxy.corp.example.com:3128",
+		}, `proxy.httpsProxy must be an absolute http or https URL, got "proxy.corp.example.com:3128"`),
+		Entry("propagating to nodes without a proxy", &api.ProxyConfig{
+			PropagateToNodes: api.Enabled(),
+		}, "proxy.httpsProxy must be set when proxy.propagateToNodes is enabled"),
+	)
+
+	DescribeTable("fargateLogging", func(logging *api.FargateLogging, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.FargateLogging = logging
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(expectedErr))
+		}
+	},
+		Entry("CloudWatch", &api.FargateLogging{Destination: api.FargateLoggingCloudWatch}, ""),
+		Entry("Firehose", &api.FargateLogging{Destination: api.FargateLoggingFirehose, DeliveryStream: "fargate-logs"}, ""),
+		Entry("Firehose without a delivery stream", &api.FargateLogging{Destination: api.FargateLoggingFirehose},
+			"fargateLogging.deliveryStream must be set when the destination is firehose"),
+		Entry("OpenSearch without an endpoint", &api.FargateLogging{Destination: api.FargateLoggingOpenSearch},
+			"fargateLogging.openSearchEndpoint must be set when the destination is opensearch"),
+		Entry("unknown destination", &api.FargateLogging{Destination: "s3"},
+			`invalid fargateLogging.destination "s3", valid values are: cloudwatch, firehose, opensearch`),
+	)
+
+	DescribeTable("integrations", func(integrations []api.IntegrationConfig, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.Integrations = integrations
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(expectedErr))
+		}
+	},
+		Entry("valid integrations", []api.IntegrationConfig{
+			{Name: "velero", Config: api.InlineDocument{"bucket": "backups"}},
+			{Name: "istio"},
+		}, ""),
+		Entry("integration without a name", []api.IntegrationConfig{
+			{Config: api.InlineDocument{"bucket": "backups"}},
+		}, "integrations[0].name must be set"),
+		Entry("integration configured twice", []api.IntegrationConfig{
+			{Name: "velero"},
+			{Name: "velero"},
+		}, `integrations[1]: integration "velero" is configured more than once`),
+	)
+
+	DescribeTable("backups", func(backups *api.Backups, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.Backups = backups
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		}
+	},
+		Entry("valid backups", &api.Backups{
+			Version:  "7.2.1",
+			Schedule: "0 3 * * *",
+			TTL:      "168h",
+		}, ""),
+		Entry("schedule macro", &api.Backups{
+			Version:  "7.2.1",
+			Schedule: "@daily",
+		}, ""),
+		Entry("missing version", &api.Backups{}, "backups.version must be set"),
+		Entry("invalid TTL", &api.Backups{
+			Version: "7.2.1",
+			TTL:     "30 days",
+		}, "backups.ttl must be a duration"),
+		Entry("invalid schedule", &api.Backups{
+			Version:  "7.2.1",
+			Schedule: "daily at 3",
+		}, `backups.schedule must be a cron expression with 5 fields, got "daily at 3"`),
+	)
+
+	It("requires the SSM parameter of organizationDefaults", func() {
+		cfg := api.NewClusterConfig()
+		cfg.OrganizationDefaults = &api.OrganizationDefaults{}
+		Expect(api.ValidateClusterConfig(cfg)).To(MatchError("organizationDefaults.ssmParameter must be set"))
+	})
+
+	DescribeTable("pullThroughCache", func(rules []api.PullThroughCacheRule, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.PullThroughCache = &api.PullThroughCache{Rules: rules}
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		}
+	},
+		Entry("valid rules", []api.PullThroughCacheRule{
+			{UpstreamRegistry: "quay"},
+			{UpstreamRegistry: "registry.example.com", ECRRepositoryPrefix: "example"},
+			{UpstreamRegistry: "docker-hub", CredentialARN: "arn:aws:secretsmanager:us-west-2:111122223333:secret:ecr-pullthroughcache/docker-hub-AbCdEf"},
+		}, ""),
+		Entry("no rules", nil, "pullThroughCache.rules must contain at least one rule"),
+		Entry("missing upstream registry", []api.PullThroughCacheRule{{}}, "pullThroughCache.rules[0].upstreamRegistry must be set"),
+		Entry("invalid prefix", []api.PullThroughCacheRule{
+			{UpstreamRegistry: "quay", ECRRepositoryPrefix: "Quay"},
+		}, `pullThroughCache.rules[0].ecrRepositoryPrefix "Quay" must be 2 to 30 lowercase letters`),
+		Entry("duplicate prefix", []api.PullThroughCacheRule{
+			{UpstreamRegistry: "quay"},
+			{UpstreamRegistry: "quay.io", ECRRepositoryPrefix: "quay"},
+		}, `pullThroughCache.rules[1].ecrRepositoryPrefix "quay" is used by more than one rule`),
+		Entry("missing credentials", []api.PullThroughCacheRule{
+			{UpstreamRegistry: "ghcr"},
+		}, "pullThroughCache.rules[0].credentialARN must be set for upstream registry ghcr.io"),
+		Entry("secret without the required prefix", []api.PullThroughCacheRule{
+			{UpstreamRegistry: "docker-hub", CredentialARN: "arn:aws:secretsmanager:us-west-2:111122223333:secret:docker-hub"},
+		}, `must reference a secret whose name starts with "ecr-pullthroughcache/"`),
+		Entry("Azure Container Registry without credentials", []api.PullThroughCacheRule{
+			{UpstreamRegistry: "myregistry.azurecr.io", ECRRepositoryPrefix: "azure"},
+		}, "pullThroughCache.rules[0].credentialARN must be set for upstream registry myregistry.azurecr.io"),
+	)
+
+	DescribeTable("pullThroughCache nodegroups", func(amiFamily string, managed bool, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.PullThroughCache = &api.PullThroughCache{Rules: []api.PullThroughCacheRule{{UpstreamRegistry: "quay"}}}
+		if managed {
+			ng := api.NewManagedNodeGroup()
+			ng.Name = "ng"
+			ng.AMIFamily = amiFamily
+			cfg.ManagedNodeGroups = append(cfg.ManagedNodeGroups, ng)
+		} else {
+			ng := cfg.NewNodeGroup()
+			ng.Name = "ng"
+			ng.AMIFamily = amiFamily
+		}
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(expectedErr))
+		}
+	},
+		Entry("Amazon Linux 2023", api.NodeImageFamilyAmazonLinux2023, false, ""),
+		Entry("Ubuntu", api.NodeImageFamilyUbuntu2204, true, ""),
+		Entry("Bottlerocket", api.NodeImageFamilyBottlerocket, false, "nodeGroups[0].amiFamily Bottlerocket is not supported with pullThroughCache, as its nodes can't be configured to pull through ECR"),
+		Entry("managed Windows", api.NodeImageFamilyWindowsServer2022CoreContainer, true, "managedNodeGroups[0].amiFamily WindowsServer2022CoreContainer is not supported with pullThroughCache, as its nodes can't be configured to pull through ECR"),
+	)
+
+	DescribeTable("monitoring", func(prometheus *api.PrometheusMonitoring, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.Monitoring = &api.Monitoring{Prometheus: prometheus}
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		}
+	},
+		Entry("managed scraper by default", &api.PrometheusMonitoring{WorkspaceAlias: "metrics"}, ""),
+		Entry("adot collector", &api.PrometheusMonitoring{
+			Collector:   api.PrometheusCollectorADOT,
+			ADOTVersion: "v0.40.0",
+		}, ""),
+		Entry("existing workspace", &api.PrometheusMonitoring{
+			WorkspaceARN: "arn:aws:aps:us-west-2:123456789012:workspace/ws-abcd-1234",
+		}, ""),
+		Entry("unknown collector", &api.PrometheusMonitoring{Collector: "prometheus"}, `invalid value "prometheus" for monitoring.prometheus.collector`),
+		Entry("adot without version", &api.PrometheusMonitoring{Collector: api.PrometheusCollectorADOT}, "monitoring.prometheus.adotVersion must be set with the adot collector"),
+		Entry("adot version with the managed scraper", &api.PrometheusMonitoring{ADOTVersion: "v0.40.0"}, "monitoring.prometheus.adotVersion can only be set with the adot collector"),
+		Entry("alias of an existing workspace", &api.PrometheusMonitoring{
+			WorkspaceARN:   "arn:aws:aps:us-west-2:123456789012:workspace/ws-abcd-1234",
+			WorkspaceAlias: "metrics",
+		}, "monitoring.prometheus.workspaceAlias cannot be set with an existing workspace"),
+		Entry("invalid workspace ARN", &api.PrometheusMonitoring{
+			WorkspaceARN: "arn:aws:s3:::metrics",
+		}, "monitoring.prometheus.workspaceARN must be the ARN of an AMP workspace"),
+	)
+
+	DescribeTable("kubeconfig", func(kc *api.KubeconfigConfig, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.Kubeconfig = kc
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		}
+	},
+		Entry("valid templates", &api.KubeconfigConfig{
+			ContextName:   "{{.ClusterName}}-{{.Region}}",
+			AliasContexts: []string{"{{.ClusterName}}", "prod"},
+			ExecEnv:       map[string]string{"AWS_PROFILE": "prod"},
+		}, ""),
+		Entry("unparsable context name", &api.KubeconfigConfig{ContextName: "{{.ClusterName"}, "kubeconfig.contextName: parsing context name template"),
+		Entry("unknown field", &api.KubeconfigConfig{ContextName: "{{.Account}}"}, "kubeconfig.contextName: executing context name template"),
+		Entry("empty alias", &api.KubeconfigConfig{AliasContexts: []string{" "}}, `kubeconfig.aliasContexts[0]: context name template " " renders an empty name`),
+		Entry("alias of the default context", &api.KubeconfigConfig{
+			AliasContexts: []string{"{{.Username}}@{{.ClusterName}}.{{.Region}}.eksctl.io"},
+		}, "names the same context as another context"),
+		Entry("invalid exec env", &api.KubeconfigConfig{ExecEnv: map[string]string{"A=B": "c"}}, `invalid environment variable name "A=B"`),
+	)
+
+	DescribeTable("cloudFormation.stackPolicy", func(stackPolicy api.InlineDocument, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.CloudFormation = &api.ClusterCloudFormation{StackPolicy: stackPolicy}
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(expectedErr))
+		}
+	},
+		Entry("no stack policy", nil, ""),
+		Entry("valid stack policy", api.InlineDocument{
+			"Statement": []interface{}{
+				map[string]interface{}{"Effect": "Allow", "Action": "Update:*", "Principal": "*", "Resource": "*"},
+				map[string]interface{}{"Effect": "Deny", "Action": "Update:Replace", "Principal": "*", "Resource": "LogicalResourceId/VPC"},
+			},
+		}, ""),
+		Entry("missing statements", api.InlineDocument{"Version": "2012-10-17"}, "cloudFormation.stackPolicy must contain a non-empty Statement list"),
+		Entry("invalid effect", api.InlineDocument{
+			"Statement": []interface{}{map[string]interface{}{"Effect": "deny", "Action": "Update:*"}},
+		}, "cloudFormation.stackPolicy.Statement[0].Effect must be either Allow or Deny"),
+	)
+
+	DescribeTable("cloudFormation.notificationARNs", func(notificationARNs []string, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.CloudFormation = &api.ClusterCloudFormation{NotificationARNs: notificationARNs}
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(expectedErr))
+		}
+	},
+		Entry("valid topics", []string{"arn:aws:sns:us-west-2:111122223333:cfn-events", "arn:aws:sns:us-west-2:111122223333:slack"}, ""),
+		Entry("not an SNS topic", []string{"arn:aws:sqs:us-west-2:111122223333:cfn-events"},
+			`cloudFormation.notificationARNs[0] "arn:aws:sqs:us-west-2:111122223333:cfn-events" is not the ARN of an SNS topic`),
+		Entry("duplicate topics", []string{"arn:aws:sns:us-west-2:111122223333:cfn-events", "arn:aws:sns:us-west-2:111122223333:cfn-events"},
+			`cloudFormation.notificationARNs[1] "arn:aws:sns:us-west-2:111122223333:cfn-events" is not unique`),
+		Entry("too many topics", []string{
+			"arn:aws:sns:us-west-2:111122223333:t1", "arn:aws:sns:us-west-2:111122223333:t2", "arn:aws:sns:us-west-2:111122223333:t3",
+			"arn:aws:sns:us-west-2:111122223333:t4", "arn:aws:sns:us-west-2:111122223333:t5", "arn:aws:sns:us-west-2:111122223333:t6",
+		}, "cloudFormation.notificationARNs must contain at most 5 topics"),
+	)
+
+	Describe("identityProviders", func() {
+		newOIDC := func(name string) api.IdentityProvider {
+			return api.FromIdentityProvider(&api.OIDCIdentityProvider{
+				Name:      name,
+				IssuerURL: "https://example.com",
+				ClientID:  "client",
+			})
+		}
+
+		It("accepts uniquely named providers", func() {
+			cfg := api.NewClusterConfig()
+			cfg.IdentityProviders = []api.IdentityProvider{newOIDC("idp-1"), newOIDC("idp-2")}
+			Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
+		})
+
+		It("rejects providers with duplicate names", func() {
+			cfg := api.NewClusterConfig()
+			cfg.IdentityProviders = []api.IdentityProvider{newOIDC("idp-1"), newOIDC("idp-1")}
+			Expect(api.ValidateClusterConfig(cfg)).To(MatchError(`identityProviders[1].name "idp-1" is not unique`))
+		})
+
+		It("rejects providers without a clientID", func() {
+			cfg := api.NewClusterConfig()
+			idp := newOIDC("idp-1")
+			idp.Inner.(*api.OIDCIdentityProvider).ClientID = ""
+			cfg.IdentityProviders = []api.IdentityProvider{idp}
+			Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring("identityProviders[0] is invalid: clientID must be set and non-empty")))
+		})
+	})
+
 	Describe("Validate SecretsEncryption", func() {
 		var cfg *api.ClusterConfig
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"regexp"
 
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 
@@ -137,6 +138,11 @@ type (
 		// VPCs](/usage/vpc-networking/#use-existing-vpc-other-custom-configuration).
 		// +optional
 		Subnets *ClusterSubnets `json:"subnets,omitempty"`
+		// CreateSubnets makes eksctl create subnets in the pre-existing VPC set in `id`,
+		// instead of using existing subnets. It cannot be used with `subnets`.
+		// See [creating subnets in an existing VPC](/usage/vpc-networking/#create-subnets-in-an-existing-vpc)
+		// +optional
+		CreateSubnets *VPCSubnetCreation `json:"createSubnets,omitempty"`
 
 		// LocalZoneSubnets represents subnets in local zones.
 		// This field is used internally and is not part of the ClusterConfig schema.
@@ -172,7 +178,8 @@ type (
 		// +optional
 		ClusterEndpoints *ClusterEndpoints `json:"clusterEndpoints,omitempty"`
 		// PublicAccessCIDRs are which CIDR blocks to allow access to public
-		// k8s API endpoint
+		// k8s API endpoint. Entries can also be the IDs of managed prefix lists (`pl-xxxx`),
+		// which are replaced with the CIDRs of their entries, see `eksctl utils sync-access-cidrs`
 		// +optional
 		PublicAccessCIDRs []string `json:"publicAccessCIDRs,omitempty"`
 		// ControlPlaneSubnetIDs configures the subnets for the control plane.
@@ -188,6 +195,25 @@ type (
 		Public  AZSubnetMapping `json:"public,omitempty"`
 	}
 
+	// VPCSubnetCreation holds the subnets to create in a pre-existing VPC, one public and one private
+	// subnet per availability zone
+	VPCSubnetCreation struct {
+		// PublicCIDRs are the CIDR blocks of the public subnets, in the order of `availabilityZones`
+		// +optional
+		PublicCIDRs []string `json:"publicCIDRs,omitempty"`
+		// PrivateCIDRs are the CIDR blocks of the private subnets, in the order of `availabilityZones`
+		// +optional
+		PrivateCIDRs []string `json:"privateCIDRs,omitempty"`
+		// InternetGatewayID is the ID of the internet gateway the public subnets route to,
+		// it is required when `publicCIDRs` is set
+		// +optional
+		InternetGatewayID string `json:"internetGatewayID,omitempty"`
+		// NATGatewayID is the ID of the NAT gateway the private subnets route to, if unset
+		// the private subnets have no route to the internet
+		// +optional
+		NATGatewayID string `json:"natGatewayID,omitempty"`
+	}
+
 	// SubnetTopology can be SubnetTopologyPrivate or SubnetTopologyPublic
 	SubnetTopology string
 	AZSubnetSpec   struct {
@@ -395,6 +421,23 @@ func (v *ClusterVPC) FindOutpostSubnetsARN() (outpostARN string, found bool) {
 
 }
 
+// IsPrefixListID returns true if s is the ID of a managed prefix list
+func IsPrefixListID(s string) bool {
+	return prefixListIDRegex.MatchString(s)
+}
+
+var prefixListIDRegex = regexp.MustCompile(`^pl-[0-9a-f]{8,17}$`)
+
+// HasPrefixLists returns true if any of the public access CIDRs are managed prefix lists
+func (v *ClusterVPC) HasPrefixLists() bool {
+	for _, cidr := range v.PublicAccessCIDRs {
+		if IsPrefixListID(cidr) {
+			return true
+		}
+	}
+	return false
+}
+
 // SubnetInfo returns a string containing VPC subnet information
 // Useful for error messages and logs
 func (c *ClusterConfig) SubnetInfo() string {
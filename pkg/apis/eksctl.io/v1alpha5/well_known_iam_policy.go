@@ -32,10 +32,48 @@ type WellKnownPolicies struct {
 	// efs-csi-controller. See [aws-efs-csi-driver
 	// docs](https://aws.amazon.com/blogs/containers/introducing-efs-csi-dynamic-provisioning).
 	EFSCSIController bool `json:"efsCSIController,inline"`
+	// FSxCSIController adds policies for using the
+	// fsx-csi-controller. See [aws-fsx-csi-driver
+	// docs](https://github.com/kubernetes-sigs/aws-fsx-csi-driver/blob/master/docs/install.md).
+	FSxCSIController bool `json:"fsxCSIController,inline"`
+	// CloudWatchAgent attaches the CloudWatchAgentServerPolicy managed policy
+	// used by the CloudWatch agent and Fluent Bit. See [Container Insights
+	// docs](https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/Container-Insights-prerequisites.html).
+	CloudWatchAgent bool `json:"cloudWatchAgent,inline"`
 }
 
 func (p *WellKnownPolicies) HasPolicy() bool {
-	return p.ImageBuilder || p.AutoScaler || p.AWSLoadBalancerController || p.ExternalDNS || p.CertManager || p.EBSCSIController || p.EFSCSIController
+	return p.ImageBuilder || p.AutoScaler || p.AWSLoadBalancerController || p.ExternalDNS || p.CertManager || p.EBSCSIController || p.EFSCSIController ||
+		p.FSxCSIController || p.CloudWatchAgent
+}
+
+// WellKnownPolicyTemplate describes one of the curated policies that can be attached with WellKnownPolicies
+type WellKnownPolicyTemplate struct {
+	// Name is the field name used in wellKnownPolicies
+	Name string `json:"name"`
+	// Version is incremented whenever the permissions granted by the policy change
+	Version int `json:"version"`
+	// Description summarises the permissions granted by the policy
+	Description string `json:"description"`
+}
+
+// wellKnownPolicyTemplates must contain an entry for every field of WellKnownPolicies;
+// bump the version of a template when changing the statements it renders to
+var wellKnownPolicyTemplates = []WellKnownPolicyTemplate{
+	{Name: "imageBuilder", Version: 1, Description: "full access to Amazon ECR (AmazonEC2ContainerRegistryPowerUser)"},
+	{Name: "autoScaler", Version: 1, Description: "permissions for cluster-autoscaler to describe and scale Auto Scaling groups"},
+	{Name: "awsLoadBalancerController", Version: 1, Description: "permissions for aws-load-balancer-controller to manage ELBs, target groups and security groups"},
+	{Name: "externalDNS", Version: 1, Description: "permissions for external-dns to manage Route 53 records"},
+	{Name: "certManager", Version: 1, Description: "permissions for cert-manager to solve Route 53 DNS01 challenges"},
+	{Name: "ebsCSIController", Version: 1, Description: "permissions for the EBS CSI driver controller to manage EBS volumes and snapshots"},
+	{Name: "efsCSIController", Version: 1, Description: "permissions for the EFS CSI driver controller to manage EFS access points"},
+	{Name: "fsxCSIController", Version: 1, Description: "permissions for the FSx CSI driver controller to manage FSx file systems"},
+	{Name: "cloudWatchAgent", Version: 1, Description: "permissions for the CloudWatch agent and Fluent Bit (CloudWatchAgentServerPolicy)"},
+}
+
+// WellKnownPolicyTemplates returns the curated policies that can be attached with WellKnownPolicies
+func WellKnownPolicyTemplates() []WellKnownPolicyTemplate {
+	return append([]WellKnownPolicyTemplate(nil), wellKnownPolicyTemplates...)
 }
 
 func (p *WellKnownPolicies) String() string { return "" }
@@ -29,6 +29,64 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ADOTAMPExporter) DeepCopyInto(out *ADOTAMPExporter) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ADOTAMPExporter.
+func (in *ADOTAMPExporter) DeepCopy() *ADOTAMPExporter {
+	if in == nil {
+		return nil
+	}
+	out := new(ADOTAMPExporter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ADOTCollector) DeepCopyInto(out *ADOTCollector) {
+	*out = *in
+	if in.AMP != nil {
+		in, out := &in.AMP, &out.AMP
+		*out = new(ADOTAMPExporter)
+		**out = **in
+	}
+	if in.XRay != nil {
+		in, out := &in.XRay, &out.XRay
+		*out = new(ADOTXRayExporter)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ADOTCollector.
+func (in *ADOTCollector) DeepCopy() *ADOTCollector {
+	if in == nil {
+		return nil
+	}
+	out := new(ADOTCollector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ADOTXRayExporter) DeepCopyInto(out *ADOTXRayExporter) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ADOTXRayExporter.
+func (in *ADOTXRayExporter) DeepCopy() *ADOTXRayExporter {
+	if in == nil {
+		return nil
+	}
+	out := new(ADOTXRayExporter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ARN) DeepCopyInto(out *ARN) {
 	*out = *in
@@ -211,6 +269,23 @@ func (in *Addon) DeepCopyInto(out *Addon) {
 			}
 		}
 	}
+	if in.VPCCNI != nil {
+		in, out := &in.VPCCNI, &out.VPCCNI
+		*out = new(VPCCNIConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Collector != nil {
+		in, out := &in.Collector, &out.Collector
+		*out = new(ADOTCollector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3Buckets != nil {
+		in, out := &in.S3Buckets, &out.S3Buckets
+		*out = make([]S3BucketAccess, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Publishers != nil {
 		in, out := &in.Publishers, &out.Publishers
 		*out = make([]string, len(*in))
@@ -255,6 +330,226 @@ func (in *AddonsConfig) DeepCopy() *AddonsConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCD) DeepCopyInto(out *ArgoCD) {
+	*out = *in
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(ArgoCDCredentials)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCD.
+func (in *ArgoCD) DeepCopy() *ArgoCD {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCD)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDCredentials) DeepCopyInto(out *ArgoCDCredentials) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDCredentials.
+func (in *ArgoCDCredentials) DeepCopy() *ArgoCDCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssumeRoleConfig) DeepCopyInto(out *AssumeRoleConfig) {
+	*out = *in
+	if in.SessionTags != nil {
+		in, out := &in.SessionTags, &out.SessionTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssumeRoleConfig.
+func (in *AssumeRoleConfig) DeepCopy() *AssumeRoleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AssumeRoleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoModeConfig) DeepCopyInto(out *AutoModeConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoModeConfig.
+func (in *AutoModeConfig) DeepCopy() *AutoModeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoModeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Backups) DeepCopyInto(out *Backups) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backups.
+func (in *Backups) DeepCopy() *Backups {
+	if in == nil {
+		return nil
+	}
+	out := new(Backups)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapClusterRoleBinding) DeepCopyInto(out *BootstrapClusterRoleBinding) {
+	*out = *in
+	if in.IAMRoleARNs != nil {
+		in, out := &in.IAMRoleARNs, &out.IAMRoleARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapClusterRoleBinding.
+func (in *BootstrapClusterRoleBinding) DeepCopy() *BootstrapClusterRoleBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapClusterRoleBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapLimitRange) DeepCopyInto(out *BootstrapLimitRange) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DefaultRequest != nil {
+		in, out := &in.DefaultRequest, &out.DefaultRequest
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapLimitRange.
+func (in *BootstrapLimitRange) DeepCopy() *BootstrapLimitRange {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapLimitRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapNamespace) DeepCopyInto(out *BootstrapNamespace) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(BootstrapLimitRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(PodSecurity)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapNamespace.
+func (in *BootstrapNamespace) DeepCopy() *BootstrapNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapPriorityClass) DeepCopyInto(out *BootstrapPriorityClass) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapPriorityClass.
+func (in *BootstrapPriorityClass) DeepCopy() *BootstrapPriorityClass {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapPriorityClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CapacityReservation) DeepCopyInto(out *CapacityReservation) {
 	*out = *in
@@ -307,6 +602,89 @@ func (in *CapacityReservationTarget) DeepCopy() *CapacityReservationTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManager) DeepCopyInto(out *CertManager) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManager.
+func (in *CertManager) DeepCopy() *CertManager {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBootstrap) DeepCopyInto(out *ClusterBootstrap) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]BootstrapNamespace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterRoleBindings != nil {
+		in, out := &in.ClusterRoleBindings, &out.ClusterRoleBindings
+		*out = make([]BootstrapClusterRoleBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PriorityClasses != nil {
+		in, out := &in.PriorityClasses, &out.PriorityClasses
+		*out = make([]BootstrapPriorityClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(PodSecurity)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBootstrap.
+func (in *ClusterBootstrap) DeepCopy() *ClusterBootstrap {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBootstrap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCloudFormation) DeepCopyInto(out *ClusterCloudFormation) {
+	*out = *in
+	if in.TerminationProtection != nil {
+		in, out := &in.TerminationProtection, &out.TerminationProtection
+		*out = new(bool)
+		**out = **in
+	}
+	in.StackPolicy.DeepCopyInto(&out.StackPolicy)
+	if in.NotificationARNs != nil {
+		in, out := &in.NotificationARNs, &out.NotificationARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCloudFormation.
+func (in *ClusterCloudFormation) DeepCopy() *ClusterCloudFormation {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCloudFormation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterCloudWatch) DeepCopyInto(out *ClusterCloudWatch) {
 	*out = *in
@@ -336,6 +714,13 @@ func (in *ClusterCloudWatchLogging) DeepCopyInto(out *ClusterCloudWatchLogging)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LogGroupTags != nil {
+		in, out := &in.LogGroupTags, &out.LogGroupTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -446,6 +831,11 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 			}
 		}
 	}
+	if in.FargateLogging != nil {
+		in, out := &in.FargateLogging, &out.FargateLogging
+		*out = new(FargateLogging)
+		**out = **in
+	}
 	if in.AvailabilityZones != nil {
 		in, out := &in.AvailabilityZones, &out.AvailabilityZones
 		*out = make([]string, len(*in))
@@ -461,6 +851,11 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 		*out = new(ClusterCloudWatch)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CloudFormation != nil {
+		in, out := &in.CloudFormation, &out.CloudFormation
+		*out = new(ClusterCloudFormation)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SecretsEncryption != nil {
 		in, out := &in.SecretsEncryption, &out.SecretsEncryption
 		*out = new(SecretsEncryption)
@@ -486,6 +881,63 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 		*out = new(Outpost)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AutoModeConfig != nil {
+		in, out := &in.AutoModeConfig, &out.AutoModeConfig
+		*out = new(AutoModeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = new(ServiceEndpoints)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Integrations != nil {
+		in, out := &in.Integrations, &out.Integrations
+		*out = make([]IntegrationConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = new(Backups)
+		**out = **in
+	}
+	if in.OrganizationDefaults != nil {
+		in, out := &in.OrganizationDefaults, &out.OrganizationDefaults
+		*out = new(OrganizationDefaults)
+		**out = **in
+	}
+	if in.PullThroughCache != nil {
+		in, out := &in.PullThroughCache, &out.PullThroughCache
+		*out = new(PullThroughCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kubeconfig != nil {
+		in, out := &in.Kubeconfig, &out.Kubeconfig
+		*out = new(KubeconfigConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterBootstrap != nil {
+		in, out := &in.ClusterBootstrap, &out.ClusterBootstrap
+		*out = new(ClusterBootstrap)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -855,6 +1307,11 @@ func (in *ClusterVPC) DeepCopyInto(out *ClusterVPC) {
 		*out = new(ClusterSubnets)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CreateSubnets != nil {
+		in, out := &in.CreateSubnets, &out.CreateSubnets
+		*out = new(VPCSubnetCreation)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LocalZoneSubnets != nil {
 		in, out := &in.LocalZoneSubnets, &out.LocalZoneSubnets
 		*out = new(ClusterSubnets)
@@ -938,6 +1395,37 @@ func (in CustomStringSlice) DeepCopy() CustomStringSlice {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNS) DeepCopyInto(out *DNS) {
+	*out = *in
+	if in.HostedZoneIDs != nil {
+		in, out := &in.HostedZoneIDs, &out.HostedZoneIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExternalDNS != nil {
+		in, out := &in.ExternalDNS, &out.ExternalDNS
+		*out = new(ExternalDNS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManager)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNS.
+func (in *DNS) DeepCopy() *DNS {
+	if in == nil {
+		return nil
+	}
+	out := new(DNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EndpointService) DeepCopyInto(out *EndpointService) {
 	*out = *in
@@ -954,6 +1442,43 @@ func (in *EndpointService) DeepCopy() *EndpointService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNS) DeepCopyInto(out *ExternalDNS) {
+	*out = *in
+	if in.DomainFilters != nil {
+		in, out := &in.DomainFilters, &out.DomainFilters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNS.
+func (in *ExternalDNS) DeepCopy() *ExternalDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FargateLogging) DeepCopyInto(out *FargateLogging) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FargateLogging.
+func (in *FargateLogging) DeepCopy() *FargateLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(FargateLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FargateProfile) DeepCopyInto(out *FargateProfile) {
 	*out = *in
@@ -1022,6 +1547,28 @@ func (in *Flux) DeepCopyInto(out *Flux) {
 			(*out)[key] = val
 		}
 	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ComponentsExtra != nil {
+		in, out := &in.ComponentsExtra, &out.ComponentsExtra
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]FluxPatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1057,6 +1604,80 @@ func (in FluxFlags) DeepCopy() FluxFlags {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxPatch) DeepCopyInto(out *FluxPatch) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(FluxPatchTarget)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxPatch.
+func (in *FluxPatch) DeepCopy() *FluxPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluxPatchTarget) DeepCopyInto(out *FluxPatchTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxPatchTarget.
+func (in *FluxPatchTarget) DeepCopy() *FluxPatchTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(FluxPatchTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUMIG) DeepCopyInto(out *GPUMIG) {
+	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUMIG.
+func (in *GPUMIG) DeepCopy() *GPUMIG {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUMIG)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUTimeSlicing) DeepCopyInto(out *GPUTimeSlicing) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUTimeSlicing.
+func (in *GPUTimeSlicing) DeepCopy() *GPUTimeSlicing {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUTimeSlicing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitOps) DeepCopyInto(out *GitOps) {
 	*out = *in
@@ -1065,6 +1686,11 @@ func (in *GitOps) DeepCopyInto(out *GitOps) {
 		*out = new(Flux)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ArgoCD != nil {
+		in, out := &in.ArgoCD, &out.ArgoCD
+		*out = new(ArgoCD)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1233,43 +1859,109 @@ func (in *InstanceSelector) DeepCopyInto(out *InstanceSelector) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceSelector.
-func (in *InstanceSelector) DeepCopy() *InstanceSelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceSelector.
+func (in *InstanceSelector) DeepCopy() *InstanceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationConfig) DeepCopyInto(out *IntegrationConfig) {
+	*out = *in
+	in.Config.DeepCopyInto(&out.Config)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationConfig.
+func (in *IntegrationConfig) DeepCopy() *IntegrationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Karpenter) DeepCopyInto(out *Karpenter) {
+	*out = *in
+	if in.CreateServiceAccount != nil {
+		in, out := &in.CreateServiceAccount, &out.CreateServiceAccount
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultInstanceProfile != nil {
+		in, out := &in.DefaultInstanceProfile, &out.DefaultInstanceProfile
+		*out = new(string)
+		**out = **in
+	}
+	if in.WithSpotInterruptionQueue != nil {
+		in, out := &in.WithSpotInterruptionQueue, &out.WithSpotInterruptionQueue
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Karpenter.
+func (in *Karpenter) DeepCopy() *Karpenter {
+	if in == nil {
+		return nil
+	}
+	out := new(Karpenter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigConfig) DeepCopyInto(out *KubeconfigConfig) {
+	*out = *in
+	if in.SetContext != nil {
+		in, out := &in.SetContext, &out.SetContext
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AliasContexts != nil {
+		in, out := &in.AliasContexts, &out.AliasContexts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExecEnv != nil {
+		in, out := &in.ExecEnv, &out.ExecEnv
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigConfig.
+func (in *KubeconfigConfig) DeepCopy() *KubeconfigConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(InstanceSelector)
+	out := new(KubeconfigConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Karpenter) DeepCopyInto(out *Karpenter) {
+func (in *KubeconfigContextData) DeepCopyInto(out *KubeconfigContextData) {
 	*out = *in
-	if in.CreateServiceAccount != nil {
-		in, out := &in.CreateServiceAccount, &out.CreateServiceAccount
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DefaultInstanceProfile != nil {
-		in, out := &in.DefaultInstanceProfile, &out.DefaultInstanceProfile
-		*out = new(string)
-		**out = **in
-	}
-	if in.WithSpotInterruptionQueue != nil {
-		in, out := &in.WithSpotInterruptionQueue, &out.WithSpotInterruptionQueue
-		*out = new(bool)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Karpenter.
-func (in *Karpenter) DeepCopy() *Karpenter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigContextData.
+func (in *KubeconfigContextData) DeepCopy() *KubeconfigContextData {
 	if in == nil {
 		return nil
 	}
-	out := new(Karpenter)
+	out := new(KubeconfigContextData)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1373,6 +2065,27 @@ func (in *MetricsCollection) DeepCopy() *MetricsCollection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Network) DeepCopyInto(out *Network) {
 	*out = *in
@@ -1628,6 +2341,11 @@ func (in *NodeGroupBase) DeepCopyInto(out *NodeGroupBase) {
 		*out = new(CapacityReservation)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(NodeGroupGPU)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1666,6 +2384,32 @@ func (in *NodeGroupBottlerocket) DeepCopy() *NodeGroupBottlerocket {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupGPU) DeepCopyInto(out *NodeGroupGPU) {
+	*out = *in
+	if in.TimeSlicing != nil {
+		in, out := &in.TimeSlicing, &out.TimeSlicing
+		*out = new(GPUTimeSlicing)
+		**out = **in
+	}
+	if in.MIG != nil {
+		in, out := &in.MIG, &out.MIG
+		*out = new(GPUMIG)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupGPU.
+func (in *NodeGroupGPU) DeepCopy() *NodeGroupGPU {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupGPU)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeGroupIAM) DeepCopyInto(out *NodeGroupIAM) {
 	*out = *in
@@ -1965,6 +2709,22 @@ func (in *OIDCIdentityProvider) DeepCopy() *OIDCIdentityProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationDefaults) DeepCopyInto(out *OrganizationDefaults) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationDefaults.
+func (in *OrganizationDefaults) DeepCopy() *OrganizationDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Outpost) DeepCopyInto(out *Outpost) {
 	*out = *in
@@ -2032,6 +2792,22 @@ func (in *PodIdentityAssociation) DeepCopy() *PodIdentityAssociation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurity) DeepCopyInto(out *PodSecurity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurity.
+func (in *PodSecurity) DeepCopy() *PodSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateCluster) DeepCopyInto(out *PrivateCluster) {
 	*out = *in
@@ -2040,6 +2816,11 @@ func (in *PrivateCluster) DeepCopyInto(out *PrivateCluster) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdminAccess != nil {
+		in, out := &in.AdminAccess, &out.AdminAccess
+		*out = new(PrivateClusterAdminAccess)
+		**out = **in
+	}
 	return
 }
 
@@ -2053,6 +2834,22 @@ func (in *PrivateCluster) DeepCopy() *PrivateCluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateClusterAdminAccess) DeepCopyInto(out *PrivateClusterAdminAccess) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateClusterAdminAccess.
+func (in *PrivateClusterAdminAccess) DeepCopy() *PrivateClusterAdminAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateClusterAdminAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Profile) DeepCopyInto(out *Profile) {
 	*out = *in
@@ -2073,6 +2870,15 @@ func (in *Profile) DeepCopy() *Profile {
 func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
 	*out = *in
 	out.Profile = in.Profile
+	if in.ServiceEndpoints != nil {
+		in, out := &in.ServiceEndpoints, &out.ServiceEndpoints
+		*out = new(ServiceEndpoints)
+		**out = **in
+	}
+	in.Proxy.DeepCopyInto(&out.Proxy)
+	in.AssumeRole.DeepCopyInto(&out.AssumeRole)
+	out.Retry = in.Retry
+	in.ResponseCache.DeepCopyInto(&out.ResponseCache)
 	return
 }
 
@@ -2086,6 +2892,150 @@ func (in *ProviderConfig) DeepCopy() *ProviderConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PropagateToNodes != nil {
+		in, out := &in.PropagateToNodes, &out.PropagateToNodes
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusMonitoring) DeepCopyInto(out *PrometheusMonitoring) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusMonitoring.
+func (in *PrometheusMonitoring) DeepCopy() *PrometheusMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullThroughCache) DeepCopyInto(out *PullThroughCache) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PullThroughCacheRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullThroughCache.
+func (in *PullThroughCache) DeepCopy() *PullThroughCache {
+	if in == nil {
+		return nil
+	}
+	out := new(PullThroughCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullThroughCacheRule) DeepCopyInto(out *PullThroughCacheRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullThroughCacheRule.
+func (in *PullThroughCacheRule) DeepCopy() *PullThroughCacheRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PullThroughCacheRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseCacheConfig) DeepCopyInto(out *ResponseCacheConfig) {
+	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseCacheConfig.
+func (in *ResponseCacheConfig) DeepCopy() *ResponseCacheConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseCacheConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryConfig) DeepCopyInto(out *RetryConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryConfig.
+func (in *RetryConfig) DeepCopy() *RetryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BucketAccess) DeepCopyInto(out *S3BucketAccess) {
+	*out = *in
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BucketAccess.
+func (in *S3BucketAccess) DeepCopy() *S3BucketAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BucketAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalingConfig) DeepCopyInto(out *ScalingConfig) {
 	*out = *in
@@ -2133,6 +3083,79 @@ func (in *SecretsEncryption) DeepCopy() *SecretsEncryption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceEndpoints) DeepCopyInto(out *ServiceEndpoints) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceEndpoints.
+func (in *ServiceEndpoints) DeepCopy() *ServiceEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCCNIConfig) DeepCopyInto(out *VPCCNIConfig) {
+	*out = *in
+	if in.WarmIPTarget != nil {
+		in, out := &in.WarmIPTarget, &out.WarmIPTarget
+		*out = new(int)
+		**out = **in
+	}
+	if in.MinimumIPTarget != nil {
+		in, out := &in.MinimumIPTarget, &out.MinimumIPTarget
+		*out = new(int)
+		**out = **in
+	}
+	if in.WarmPrefixTarget != nil {
+		in, out := &in.WarmPrefixTarget, &out.WarmPrefixTarget
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCCNIConfig.
+func (in *VPCCNIConfig) DeepCopy() *VPCCNIConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCCNIConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCSubnetCreation) DeepCopyInto(out *VPCSubnetCreation) {
+	*out = *in
+	if in.PublicCIDRs != nil {
+		in, out := &in.PublicCIDRs, &out.PublicCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateCIDRs != nil {
+		in, out := &in.PrivateCIDRs, &out.PrivateCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCSubnetCreation.
+func (in *VPCSubnetCreation) DeepCopy() *VPCSubnetCreation {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCSubnetCreation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeMapping) DeepCopyInto(out *VolumeMapping) {
 	*out = *in
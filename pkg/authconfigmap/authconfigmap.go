@@ -9,7 +9,9 @@ package authconfigmap
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -290,6 +292,19 @@ func ObjectMeta() metav1.ObjectMeta {
 // AddNodeGroup creates or adds a nodegroup IAM role in the auth
 // ConfigMap for the given nodegroup.
 func AddNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
+	return addNodeGroup(clientSet, ng, nil)
+}
+
+// EnsureNodeGroup adds the nodegroup IAM role to the auth ConfigMap
+// for the given nodegroup, unless the role is already mapped.
+func EnsureNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
+	roleARN := roleARNWithoutPath(ng.IAM.InstanceRoleARN)
+	return addNodeGroup(clientSet, ng, func(identity iam.Identity) bool {
+		return identity.ARN() == roleARN
+	})
+}
+
+func addNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup, exists func(iam.Identity) bool) error {
 	acm, err := NewFromClientSet(clientSet)
 	if err != nil {
 		return err
@@ -300,12 +315,12 @@ func AddNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
 		nodeGroupRoles = append([]string{roleNodeGroupWindows}, nodeGroupRoles...)
 	}
 
-	identity, err := iam.NewIdentity(ng.IAM.InstanceRoleARN, RoleNodeGroupUsername, nodeGroupRoles)
+	identity, err := iam.NewIdentity(roleARNWithoutPath(ng.IAM.InstanceRoleARN), RoleNodeGroupUsername, nodeGroupRoles)
 	if err != nil {
 		return err
 	}
 
-	if err := acm.AddIdentity(identity); err != nil {
+	if err := acm.AddIdentityIfNotPresent(identity, exists); err != nil {
 		return errors.Wrap(err, "adding nodegroup to auth ConfigMap")
 	}
 	if err := acm.Save(); err != nil {
@@ -315,6 +330,17 @@ func AddNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
 	return nil
 }
 
+// roleARNWithoutPath strips the path from a role ARN, as the authenticator
+// does not match role ARNs that include one, see iam.rolePath
+func roleARNWithoutPath(roleARN string) string {
+	parsed, err := arn.Parse(roleARN)
+	if err != nil || !strings.HasPrefix(parsed.Resource, "role/") {
+		return roleARN
+	}
+	parsed.Resource = "role/" + parsed.Resource[strings.LastIndex(parsed.Resource, "/")+1:]
+	return parsed.String()
+}
+
 // RemoveNodeGroup removes a nodegroup from the ConfigMap and
 // does a client update.
 func RemoveNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
@@ -322,7 +348,7 @@ func RemoveNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
 	if err != nil {
 		return err
 	}
-	if err := acm.RemoveIdentity(ng.IAM.InstanceRoleARN, false); err != nil {
+	if err := acm.RemoveIdentity(roleARNWithoutPath(ng.IAM.InstanceRoleARN), false); err != nil {
 		return errors.Wrap(err, "removing nodegroup from auth ConfigMap")
 	}
 	if err := acm.Save(); err != nil {
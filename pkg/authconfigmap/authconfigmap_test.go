@@ -9,11 +9,13 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	. "github.com/weaveworks/eksctl/pkg/authconfigmap"
 	"github.com/weaveworks/eksctl/pkg/iam"
 )
@@ -575,4 +577,38 @@ var _ = Describe("AuthConfigMap{}", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Describe("AddNodeGroup()", func() {
+		It("maps the instance role without its path", func() {
+			clientSet := fake.NewSimpleClientset()
+			ng := api.NewNodeGroup()
+			ng.IAM.InstanceRoleARN = "arn:aws:iam::122333:role/eksctl/NodeInstanceRole-ABCDEFGH"
+
+			Expect(AddNodeGroup(clientSet, ng)).To(Succeed())
+
+			cm, err := clientSet.CoreV1().ConfigMaps(ObjectNamespace).Get(context.Background(), ObjectName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cm.Data["mapRoles"]).To(ContainSubstring("rolearn: arn:aws:iam::122333:role/NodeInstanceRole-ABCDEFGH"))
+
+			Expect(RemoveNodeGroup(clientSet, ng)).To(Succeed())
+			cm, err = clientSet.CoreV1().ConfigMaps(ObjectNamespace).Get(context.Background(), ObjectName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cm.Data["mapRoles"]).NotTo(ContainSubstring("NodeInstanceRole-ABCDEFGH"))
+		})
+	})
+
+	Describe("EnsureNodeGroup()", func() {
+		It("maps the instance role only once", func() {
+			clientSet := fake.NewSimpleClientset()
+			ng := api.NewNodeGroup()
+			ng.IAM.InstanceRoleARN = "arn:aws:iam::122333:role/eksctl/NodeInstanceRole-ABCDEFGH"
+
+			Expect(EnsureNodeGroup(clientSet, ng)).To(Succeed())
+			Expect(EnsureNodeGroup(clientSet, ng)).To(Succeed())
+
+			cm, err := clientSet.CoreV1().ConfigMaps(ObjectNamespace).Get(context.Background(), ObjectName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Count(cm.Data["mapRoles"], "rolearn: arn:aws:iam::122333:role/NodeInstanceRole-ABCDEFGH")).To(Equal(1))
+		})
+	})
 })
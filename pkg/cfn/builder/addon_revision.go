@@ -0,0 +1,71 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+
+	cft "github.com/weaveworks/eksctl/pkg/cfn/template"
+)
+
+// addonRevisionMetadataKey is the template metadata key the addon revision is recorded under
+const addonRevisionMetadataKey = "AddonRevision"
+
+// AddonRevision is the version and configuration values an addon was deployed with
+type AddonRevision struct {
+	Version             string `json:"version"`
+	ConfigurationValues string `json:"configurationValues,omitempty"`
+}
+
+// AddonRevisionResourceSet holds the stack recording the previous revision of an addon in its template metadata.
+// CloudFormation stacks must have at least one resource, so the stack has a wait condition handle, which doesn't
+// create anything
+type AddonRevisionResourceSet struct {
+	template *cft.Template
+}
+
+// NewAddonRevisionResourceSet builds the stack recording revision as the previous revision of the addon
+func NewAddonRevisionResourceSet(addonName string, revision AddonRevision) *AddonRevisionResourceSet {
+	template := cft.NewTemplate()
+	template.Description = fmt.Sprintf("Previous revision of addon %q %s", addonName, templateDescriptionSuffix)
+	template.Metadata = map[string]interface{}{
+		addonRevisionMetadataKey: revision,
+	}
+	template.Resources["AddonRevisionHandle"] = cft.AnyResource{
+		Type:       "AWS::CloudFormation::WaitConditionHandle",
+		Properties: map[string]interface{}{},
+	}
+	return &AddonRevisionResourceSet{template: template}
+}
+
+// WithIAM returns false
+func (*AddonRevisionResourceSet) WithIAM() bool { return false }
+
+// WithNamedIAM returns false
+func (*AddonRevisionResourceSet) WithNamedIAM() bool { return false }
+
+// RenderJSON will render the addon revision stack as JSON
+func (rs *AddonRevisionResourceSet) RenderJSON() ([]byte, error) {
+	return rs.template.RenderJSON()
+}
+
+// GetAllOutputs is a no-op, the addon revision stack has no outputs
+func (*AddonRevisionResourceSet) GetAllOutputs(types.Stack) error {
+	return nil
+}
+
+// ParseAddonRevision returns the addon revision recorded in the template of an addon revision stack
+func ParseAddonRevision(templateBody string) (*AddonRevision, error) {
+	var template struct {
+		Metadata map[string]*AddonRevision
+	}
+	if err := json.Unmarshal([]byte(templateBody), &template); err != nil {
+		return nil, fmt.Errorf("parsing addon revision stack template: %w", err)
+	}
+	revision, ok := template.Metadata[addonRevisionMetadataKey]
+	if !ok || revision == nil {
+		return nil, fmt.Errorf("addon revision stack template has no %s metadata", addonRevisionMetadataKey)
+	}
+	return revision, nil
+}
@@ -0,0 +1,89 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+
+	gfneks "github.com/weaveworks/goformation/v4/cloudformation/eks"
+	gfniam "github.com/weaveworks/goformation/v4/cloudformation/iam"
+	gfnt "github.com/weaveworks/goformation/v4/cloudformation/types"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	cft "github.com/weaveworks/eksctl/pkg/cfn/template"
+)
+
+const cfnAutoModeNodeRoleResource = "AutoModeNodeRole"
+
+// addResourcesForAutoModeNodeRole creates the role assumed by nodes that Auto Mode launches,
+// unless one is supplied or no built-in node pools are enabled.
+func (c *ClusterResourceSet) addResourcesForAutoModeNodeRole() *gfnt.Value {
+	autoModeConfig := c.spec.AutoModeConfig
+	if autoModeConfig.NodeRoleARN != "" {
+		return gfnt.NewString(autoModeConfig.NodeRoleARN)
+	}
+	if !autoModeConfig.HasNodePools() {
+		return nil
+	}
+
+	c.rs.withIAM = true
+	role := &gfniam.Role{
+		AssumeRolePolicyDocument: cft.MakeAssumeRolePolicyDocumentForServices(MakeServiceRef("EC2")),
+		ManagedPolicyArns: gfnt.NewSlice(makePolicyARNs(
+			iamPolicyAmazonEKSWorkerNodeMinimalPolicy,
+			iamPolicyAmazonEC2ContainerRegistryPullOnly,
+		)...),
+	}
+	if c.spec.IAM.RolePath != "" {
+		role.Path = gfnt.NewString(c.spec.IAM.RolePath)
+	}
+	c.newResource(cfnAutoModeNodeRoleResource, role)
+	c.rs.defineOutputFromAtt(outputs.ClusterAutoModeNodeRoleARN, cfnAutoModeNodeRoleResource, "Arn", true, func(string) error {
+		return nil
+	})
+	return gfnt.MakeFnGetAttString(cfnAutoModeNodeRoleResource, "Arn")
+}
+
+// makeAutoModeCluster returns an AWS::EKS::Cluster resource with the compute, block storage and
+// load balancing capabilities of Auto Mode enabled. goformation does not model these properties,
+// so they are added to the properties of the rendered cluster.
+func makeAutoModeCluster(cluster *gfneks.Cluster, autoModeConfig *api.AutoModeConfig, nodeRoleARN *gfnt.Value) (*awsCloudFormationResource, error) {
+	clusterJSON, err := json.Marshal(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("serializing cluster resource: %w", err)
+	}
+	var resource struct {
+		Properties map[string]interface{}
+	}
+	if err := json.Unmarshal(clusterJSON, &resource); err != nil {
+		return nil, fmt.Errorf("deserializing cluster resource: %w", err)
+	}
+
+	computeConfig := map[string]interface{}{
+		"Enabled": true,
+	}
+	if nodeRoleARN != nil {
+		computeConfig["NodePools"] = *autoModeConfig.NodePools
+		computeConfig["NodeRoleArn"] = nodeRoleARN
+	}
+	resource.Properties["ComputeConfig"] = computeConfig
+	resource.Properties["StorageConfig"] = map[string]interface{}{
+		"BlockStorage": map[string]interface{}{
+			"Enabled": true,
+		},
+	}
+
+	kubernetesNetworkConfig, ok := resource.Properties["KubernetesNetworkConfig"].(map[string]interface{})
+	if !ok {
+		kubernetesNetworkConfig = map[string]interface{}{}
+	}
+	kubernetesNetworkConfig["ElasticLoadBalancing"] = map[string]interface{}{
+		"Enabled": true,
+	}
+	resource.Properties["KubernetesNetworkConfig"] = kubernetesNetworkConfig
+
+	return &awsCloudFormationResource{
+		Type:       cluster.AWSCloudFormationType(),
+		Properties: resource.Properties,
+	}, nil
+}
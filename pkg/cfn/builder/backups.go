@@ -0,0 +1,147 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	cft "github.com/weaveworks/eksctl/pkg/cfn/template"
+	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+)
+
+const (
+	// BackupsBucket is the name of the bucket resource and output of the backups stack.
+	BackupsBucket = "BackupsBucket"
+	// BackupsRole is the name of the Velero role resource and output of the backups stack.
+	BackupsRole = "VeleroRole"
+)
+
+// BackupsResourceSet holds the S3 bucket and the IAM role used by Velero to back up a cluster.
+type BackupsResourceSet struct {
+	template       *cft.Template
+	outputs        *outputs.CollectorSet
+	backups        *api.Backups
+	oidc           *iamoidc.OpenIDConnectManager
+	namespace      string
+	serviceAccount string
+
+	// BucketName is the name of the bucket backups are stored in, collected from the stack outputs.
+	BucketName string
+	// RoleARN is the ARN of the role assumed by Velero, collected from the stack outputs.
+	RoleARN string
+}
+
+// NewBackupsResourceSet returns a resource set for the backups of a cluster, with a role that can be
+// assumed by the Velero server service account.
+func NewBackupsResourceSet(backups *api.Backups, oidc *iamoidc.OpenIDConnectManager, namespace, serviceAccount string) *BackupsResourceSet {
+	rs := &BackupsResourceSet{
+		template:       cft.NewTemplate(),
+		backups:        backups,
+		oidc:           oidc,
+		namespace:      namespace,
+		serviceAccount: serviceAccount,
+	}
+	rs.outputs = outputs.NewCollectorSet(map[string]outputs.Collector{
+		BackupsBucket: func(v string) error {
+			rs.BucketName = v
+			return nil
+		},
+		BackupsRole: func(v string) error {
+			rs.RoleARN = v
+			return nil
+		},
+	})
+	return rs
+}
+
+// AddAllResources adds the bucket, unless an existing one is used, and the Velero role.
+func (rs *BackupsResourceSet) AddAllResources() error {
+	rs.template.Description = fmt.Sprintf("Velero backups %s", templateDescriptionSuffix)
+
+	var bucketName *cft.Value
+	if rs.backups.Bucket != "" {
+		bucketName = cft.NewString(rs.backups.Bucket)
+	} else {
+		bucketName = rs.template.NewResource(BackupsBucket, &cft.S3Bucket{
+			BucketEncryption: cft.MapOfInterfaces{
+				"ServerSideEncryptionConfiguration": []cft.MapOfInterfaces{{
+					"ServerSideEncryptionByDefault": cft.MapOfInterfaces{
+						"SSEAlgorithm": "AES256",
+					},
+				}},
+			},
+			PublicAccessBlockConfiguration: cft.MapOfInterfaces{
+				"BlockPublicAcls":       true,
+				"BlockPublicPolicy":     true,
+				"IgnorePublicAcls":      true,
+				"RestrictPublicBuckets": true,
+			},
+			VersioningConfiguration: cft.MapOfInterfaces{
+				"Status": "Enabled",
+			},
+		})
+		// backups must outlive the cluster they were taken from
+		rs.template.RetainResource(BackupsBucket)
+	}
+	rs.template.Outputs[BackupsBucket] = cft.Output{
+		Value: bucketName,
+	}
+
+	roleRef := rs.template.NewResource(BackupsRole, &cft.IAMRole{
+		AssumeRolePolicyDocument: rs.oidc.MakeAssumeRolePolicyDocumentWithServiceAccountConditions(rs.namespace, rs.serviceAccount),
+	})
+	bucketARN := cft.MakeFnSub(cft.NewValue(cft.AnythingSlice{"arn:${AWS::Partition}:s3:::${Bucket}", cft.MapOfInterfaces{"Bucket": bucketName}}))
+	objectsARN := cft.MakeFnSub(cft.NewValue(cft.AnythingSlice{"arn:${AWS::Partition}:s3:::${Bucket}/*", cft.MapOfInterfaces{"Bucket": bucketName}}))
+	rs.template.AttachPolicy("VeleroPolicy", roleRef, cft.MakePolicyDocument(
+		cft.MapOfInterfaces{
+			"Effect": "Allow",
+			"Action": []string{
+				"ec2:DescribeVolumes",
+				"ec2:DescribeSnapshots",
+				"ec2:CreateTags",
+				"ec2:CreateVolume",
+				"ec2:CreateSnapshot",
+				"ec2:DeleteSnapshot",
+			},
+			"Resource": "*",
+		},
+		cft.MapOfInterfaces{
+			"Effect": "Allow",
+			"Action": []string{
+				"s3:GetObject",
+				"s3:DeleteObject",
+				"s3:PutObject",
+				"s3:AbortMultipartUpload",
+				"s3:ListMultipartUploadParts",
+			},
+			"Resource": objectsARN,
+		},
+		cft.MapOfInterfaces{
+			"Effect":   "Allow",
+			"Action":   []string{"s3:ListBucket"},
+			"Resource": bucketARN,
+		},
+	))
+	rs.template.Outputs[BackupsRole] = cft.Output{
+		Value: cft.MakeFnGetAttString(BackupsRole + ".Arn"),
+	}
+	return nil
+}
+
+// RenderJSON returns the rendered JSON
+func (rs *BackupsResourceSet) RenderJSON() ([]byte, error) {
+	return rs.template.RenderJSON()
+}
+
+// WithIAM returns true
+func (*BackupsResourceSet) WithIAM() bool { return true }
+
+// WithNamedIAM returns false
+func (*BackupsResourceSet) WithNamedIAM() bool { return false }
+
+// GetAllOutputs collects the bucket name and role ARN
+func (rs *BackupsResourceSet) GetAllOutputs(stack types.Stack) error {
+	return rs.outputs.MustCollect(stack)
+}
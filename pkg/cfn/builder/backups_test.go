@@ -0,0 +1,82 @@
+package builder_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+)
+
+var _ = Describe("backups stack", func() {
+	var (
+		oidc    *iamoidc.OpenIDConnectManager
+		backups *api.Backups
+	)
+
+	BeforeEach(func() {
+		var err error
+		oidc, err = iamoidc.NewOpenIDConnectManager(nil, "456123987123", "https://oidc.eks.us-west-2.amazonaws.com/id/A39A2842863C47208955D753DE205E6E", "aws", nil)
+		Expect(err).NotTo(HaveOccurred())
+		oidc.ProviderARN = "arn:aws:iam::456123987123:oidc-provider/oidc.eks.us-west-2.amazonaws.com/id/A39A2842863C47208955D753DE205E6E"
+		backups = &api.Backups{Version: "7.1.0"}
+	})
+
+	renderTemplate := func() map[string]interface{} {
+		rs := builder.NewBackupsResourceSet(backups, oidc, "velero", "velero-server")
+		Expect(rs.AddAllResources()).To(Succeed())
+		Expect(rs.WithIAM()).To(BeTrue())
+		Expect(rs.WithNamedIAM()).To(BeFalse())
+		templateBody, err := rs.RenderJSON()
+		Expect(err).NotTo(HaveOccurred())
+		var template map[string]interface{}
+		Expect(json.Unmarshal(templateBody, &template)).To(Succeed())
+		return template
+	}
+
+	It("creates a retained, encrypted bucket and a role for the Velero service account", func() {
+		template := renderTemplate()
+		resources := template["Resources"].(map[string]interface{})
+
+		bucket := resources[builder.BackupsBucket].(map[string]interface{})
+		Expect(bucket["Type"]).To(Equal("AWS::S3::Bucket"))
+		Expect(bucket["DeletionPolicy"]).To(Equal("Retain"))
+		Expect(bucket["Properties"]).To(HaveKey("BucketEncryption"))
+		Expect(bucket["Properties"]).To(HaveKey("PublicAccessBlockConfiguration"))
+
+		role := resources[builder.BackupsRole].(map[string]interface{})
+		Expect(role["Type"]).To(Equal("AWS::IAM::Role"))
+		assumeRolePolicy, err := json.Marshal(role["Properties"].(map[string]interface{})["AssumeRolePolicyDocument"])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(assumeRolePolicy)).To(ContainSubstring("system:serviceaccount:velero:velero-server"))
+
+		policy, err := json.Marshal(resources["VeleroPolicy"])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(policy)).To(ContainSubstring(`"Bucket":{"Ref":"BackupsBucket"}`))
+		Expect(string(policy)).To(ContainSubstring("ec2:CreateSnapshot"))
+
+		Expect(template["Outputs"]).To(HaveKey(builder.BackupsBucket))
+		Expect(template["Outputs"]).To(HaveKey(builder.BackupsRole))
+	})
+
+	When("an existing bucket is set", func() {
+		BeforeEach(func() {
+			backups.Bucket = "my-backups"
+		})
+
+		It("does not create a bucket", func() {
+			template := renderTemplate()
+			Expect(template["Resources"]).NotTo(HaveKey(builder.BackupsBucket))
+
+			policy, err := json.Marshal(template["Resources"].(map[string]interface{})["VeleroPolicy"])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(policy)).To(ContainSubstring(`"Bucket":"my-backups"`))
+
+			bucketOutput := template["Outputs"].(map[string]interface{})[builder.BackupsBucket].(map[string]interface{})
+			Expect(bucketOutput["Value"]).To(Equal("my-backups"))
+		})
+	})
+})
@@ -18,6 +18,7 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/awsapi"
 	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	"github.com/weaveworks/eksctl/pkg/vpc"
 )
 
 // ClusterResourceSet stores the resource information of the cluster
@@ -85,12 +86,22 @@ func (c *ClusterResourceSet) AddAllResources(ctx context.Context) error {
 	}
 
 	c.addResourcesForIAM()
-	c.addResourcesForControlPlane(subnetDetails)
+	if err := c.addResourcesForControlPlane(ctx, subnetDetails); err != nil {
+		return err
+	}
+
+	if adminAccess := c.spec.PrivateCluster.AdminAccess; c.spec.PrivateCluster.Enabled && adminAccess != nil {
+		c.addResourcesForAdminAccess(adminAccess, vpcID, subnetDetails.Private, clusterSG.ClusterSharedNode)
+	}
 
 	if len(c.spec.FargateProfiles) > 0 {
 		c.addResourcesForFargate()
 	}
 
+	if c.spec.HasPullThroughCache() {
+		c.addResourcesForPullThroughCache()
+	}
+
 	c.rs.defineOutput(outputs.ClusterStackName, gfnt.RefStackName, false, func(v string) error {
 		if c.spec.Status == nil {
 			c.spec.Status = &api.ClusterStatus{}
@@ -265,12 +276,16 @@ func (c *ClusterResourceSet) newResource(name string, resource gfn.Resource) *gf
 	return c.rs.newResource(name, resource)
 }
 
-func (c *ClusterResourceSet) addResourcesForControlPlane(subnetDetails *SubnetDetails) {
+func (c *ClusterResourceSet) addResourcesForControlPlane(ctx context.Context, subnetDetails *SubnetDetails) error {
+	publicAccessCIDRs, err := vpc.ResolvePublicAccessCIDRs(ctx, c.ec2API, c.spec.VPC.PublicAccessCIDRs)
+	if err != nil {
+		return errors.Wrap(err, "resolving public access CIDRs")
+	}
 	clusterVPC := &gfneks.Cluster_ResourcesVpcConfig{
 		EndpointPublicAccess:  gfnt.NewBoolean(*c.spec.VPC.ClusterEndpoints.PublicAccess),
 		EndpointPrivateAccess: gfnt.NewBoolean(*c.spec.VPC.ClusterEndpoints.PrivateAccess),
 		SecurityGroupIds:      c.securityGroups,
-		PublicAccessCidrs:     gfnt.NewStringSlice(c.spec.VPC.PublicAccessCIDRs...),
+		PublicAccessCidrs:     gfnt.NewStringSlice(publicAccessCIDRs...),
 	}
 	if subnetIDs := c.spec.VPC.ControlPlaneSubnetIDs; len(subnetIDs) > 0 {
 		clusterVPC.SubnetIds = gfnt.NewStringSlice(subnetIDs...)
@@ -336,7 +351,15 @@ func (c *ClusterResourceSet) addResourcesForControlPlane(subnetDetails *SubnetDe
 	}
 	cluster.KubernetesNetworkConfig = kubernetesNetworkConfig
 
-	c.newResource("ControlPlane", &cluster)
+	if c.spec.IsAutoModeEnabled() {
+		autoModeCluster, err := makeAutoModeCluster(&cluster, c.spec.AutoModeConfig, c.addResourcesForAutoModeNodeRole())
+		if err != nil {
+			return err
+		}
+		c.newResource("ControlPlane", autoModeCluster)
+	} else {
+		c.newResource("ControlPlane", &cluster)
+	}
 
 	if c.spec.Status == nil {
 		c.spec.Status = &api.ClusterStatus{}
@@ -367,6 +390,7 @@ func (c *ClusterResourceSet) addResourcesForControlPlane(subnetDetails *SubnetDe
 		true, func(s string) error {
 			return nil
 		})
+	return nil
 }
 
 func makeCFNTags(clusterConfig *api.ClusterConfig) []gfncfn.Tag {
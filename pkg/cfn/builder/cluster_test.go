@@ -327,6 +327,69 @@ var _ = Describe("Cluster Template Builder", func() {
 			})
 		})
 
+		When("Auto Mode is enabled", func() {
+			BeforeEach(func() {
+				cfg.AutoModeConfig = &api.AutoModeConfig{
+					Enabled: api.Enabled(),
+				}
+				api.SetClusterConfigDefaults(cfg)
+			})
+
+			It("enables compute, block storage and load balancing in the control plane", func() {
+				controlPlane := clusterTemplate.Resources["ControlPlane"].Properties
+				Expect(controlPlane.ComputeConfig).NotTo(BeNil())
+				Expect(controlPlane.ComputeConfig.Enabled).To(BeTrue())
+				Expect(controlPlane.ComputeConfig.NodePools).To(Equal([]string{"general-purpose", "system"}))
+				Expect(controlPlane.ComputeConfig.NodeRoleArn).To(Equal(map[string]interface{}{
+					"Fn::GetAtt": []interface{}{"AutoModeNodeRole", "Arn"},
+				}))
+				Expect(controlPlane.StorageConfig.BlockStorage.Enabled).To(BeTrue())
+				Expect(controlPlane.KubernetesNetworkConfig.ElasticLoadBalancing.Enabled).To(BeTrue())
+				Expect(controlPlane.KubernetesNetworkConfig.ServiceIPv4CIDR).To(Equal("131.10.55.70/18"))
+				Expect(controlPlane.BootstrapSelfManagedAddons).To(BeFalse())
+			})
+
+			It("creates the node role and adds the Auto Mode policies to the service role", func() {
+				Expect(clusterTemplate.Resources).To(HaveKey("AutoModeNodeRole"))
+				Expect(clusterTemplate.Resources["AutoModeNodeRole"].Properties.ManagedPolicyArns).To(ConsistOf(
+					makePolicyARNRef("AmazonEKSWorkerNodeMinimalPolicy"),
+					makePolicyARNRef("AmazonEC2ContainerRegistryPullOnly"),
+				))
+				Expect(clusterTemplate.Outputs).To(HaveKey("AutoModeNodeRoleARN"))
+				Expect(clusterTemplate.Resources["ServiceRole"].Properties.ManagedPolicyArns).To(ContainElements(
+					makePolicyARNRef("AmazonEKSComputePolicy"),
+					makePolicyARNRef("AmazonEKSBlockStoragePolicy"),
+					makePolicyARNRef("AmazonEKSLoadBalancingPolicy"),
+					makePolicyARNRef("AmazonEKSNetworkingPolicy"),
+				))
+			})
+
+			When("nodeRoleARN is set", func() {
+				BeforeEach(func() {
+					cfg.AutoModeConfig.NodeRoleARN = "arn:aws:iam::123456789012:role/auto-mode-nodes"
+				})
+
+				It("uses the supplied role", func() {
+					Expect(clusterTemplate.Resources).NotTo(HaveKey("AutoModeNodeRole"))
+					Expect(clusterTemplate.Resources["ControlPlane"].Properties.ComputeConfig.NodeRoleArn).To(Equal("arn:aws:iam::123456789012:role/auto-mode-nodes"))
+				})
+			})
+
+			When("no built-in node pools are enabled", func() {
+				BeforeEach(func() {
+					cfg.AutoModeConfig.NodePools = &[]string{}
+				})
+
+				It("does not create a node role", func() {
+					Expect(clusterTemplate.Resources).NotTo(HaveKey("AutoModeNodeRole"))
+					computeConfig := clusterTemplate.Resources["ControlPlane"].Properties.ComputeConfig
+					Expect(computeConfig.Enabled).To(BeTrue())
+					Expect(computeConfig.NodePools).To(BeEmpty())
+					Expect(computeConfig.NodeRoleArn).To(BeNil())
+				})
+			})
+		})
+
 		When("SecretsEncryption is configured", func() {
 			BeforeEach(func() {
 				cfg.SecretsEncryption = &api.SecretsEncryption{
@@ -462,6 +525,45 @@ var _ = Describe("Cluster Template Builder", func() {
 					Expect(clusterTemplate.Resources).NotTo(HaveKey(ContainSubstring("VPCEndpoint")))
 				})
 			})
+
+			When("adminAccess is set to InstanceConnectEndpoint", func() {
+				BeforeEach(func() {
+					cfg.PrivateCluster.AdminAccess = &api.PrivateClusterAdminAccess{
+						Type: api.PrivateClusterAdminAccessInstanceConnectEndpoint,
+					}
+				})
+
+				It("adds an EC2 Instance Connect Endpoint that can reach nodes over SSH", func() {
+					Expect(clusterTemplate.Resources).To(HaveKey("InstanceConnectEndpoint"))
+					Expect(clusterTemplate.Resources["InstanceConnectEndpoint"].Type).To(Equal("AWS::EC2::InstanceConnectEndpoint"))
+					Expect(clusterTemplate.Resources).To(HaveKey("InstanceConnectEndpointSecurityGroup"))
+					Expect(clusterTemplate.Resources).To(HaveKey("IngressInstanceConnectEndpointToNodeSG"))
+					Expect(clusterTemplate.Resources).NotTo(HaveKey("Bastion"))
+					Expect(clusterTemplate.Outputs).To(HaveKey("InstanceConnectEndpointID"))
+				})
+			})
+
+			When("adminAccess is set to Bastion", func() {
+				BeforeEach(func() {
+					cfg.PrivateCluster.AdminAccess = &api.PrivateClusterAdminAccess{
+						Type:         api.PrivateClusterAdminAccessBastion,
+						InstanceType: api.DefaultBastionInstanceType,
+					}
+					provider.MockEC2().On("DescribeVpcEndpointServices", mock.Anything, mock.MatchedBy(func(e *ec2.DescribeVpcEndpointServicesInput) bool {
+						return len(e.ServiceNames) == 8
+					})).Return(&ec2.DescribeVpcEndpointServicesOutput{}, nil)
+				})
+
+				It("adds a bastion instance reachable through SSM", func() {
+					Expect(clusterTemplate.Resources).To(HaveKey("Bastion"))
+					Expect(clusterTemplate.Resources["Bastion"].Type).To(Equal("AWS::EC2::Instance"))
+					Expect(clusterTemplate.Resources).To(HaveKey("BastionInstanceProfile"))
+					Expect(clusterTemplate.Resources).To(HaveKey("BastionRole"))
+					Expect(clusterTemplate.Resources["BastionRole"].Properties.ManagedPolicyArns).To(HaveLen(1))
+					Expect(clusterTemplate.Resources).NotTo(HaveKey("InstanceConnectEndpoint"))
+					Expect(clusterTemplate.Outputs).To(HaveKey("BastionInstanceID"))
+				})
+			})
 		})
 
 		Context("when default config is used", func() {
@@ -579,6 +681,57 @@ var _ = Describe("Cluster Template Builder", func() {
 			It("should add resources for fargate", func() {
 				Expect(clusterTemplate.Resources).To(HaveKey("FargatePodExecutionRole"))
 			})
+
+			It("should not allow the pod execution role to write logs", func() {
+				Expect(clusterTemplate.Resources).NotTo(HaveKey("PolicyFargateLogging"))
+			})
+
+			Context("when logging is configured", func() {
+				BeforeEach(func() {
+					cfg.FargateLogging = &api.FargateLogging{Destination: api.FargateLoggingFirehose, DeliveryStream: "fargate-logs"}
+				})
+
+				It("should allow the pod execution role to write to the delivery stream", func() {
+					templateBody, err := crs.RenderJSON()
+					Expect(err).NotTo(HaveOccurred())
+					policy := gjson.GetBytes(templateBody, "Resources.PolicyFargateLogging.Properties")
+					Expect(policy.Get("Roles.0.Ref").String()).To(Equal("FargatePodExecutionRole"))
+					Expect(policy.Get("PolicyDocument.Statement.0.Action.0").String()).To(Equal("firehose:PutRecordBatch"))
+					Expect(policy.Get(`PolicyDocument.Statement.0.Resource.Fn::Sub`).String()).To(HaveSuffix(":deliverystream/fargate-logs"))
+				})
+			})
+		})
+
+		Context("when pull-through cache rules are configured", func() {
+			BeforeEach(func() {
+				cfg.PullThroughCache = &api.PullThroughCache{
+					Rules: []api.PullThroughCacheRule{
+						{UpstreamRegistry: "quay"},
+						{
+							UpstreamRegistry:    "docker-hub",
+							ECRRepositoryPrefix: "docker-hub-mirror",
+							CredentialARN:       "arn:aws:secretsmanager:us-west-2:111122223333:secret:ecr-pullthroughcache/docker-hub",
+						},
+					},
+				}
+			})
+
+			It("should add a pull-through cache rule for each upstream registry", func() {
+				templateBody, err := crs.RenderJSON()
+				Expect(err).NotTo(HaveOccurred())
+				quay := gjson.GetBytes(templateBody, "Resources.PullThroughCacheRuleQuay")
+				Expect(quay.Get("Type").String()).To(Equal("AWS::ECR::PullThroughCacheRule"))
+				Expect(quay.Get("Properties.EcrRepositoryPrefix").String()).To(Equal("quay"))
+				Expect(quay.Get("Properties.UpstreamRegistryUrl").String()).To(Equal("quay.io"))
+				Expect(quay.Get("Properties.UpstreamRegistry").String()).To(Equal("quay"))
+				Expect(quay.Get("Properties.CredentialArn").Exists()).To(BeFalse())
+
+				dockerHub := gjson.GetBytes(templateBody, "Resources.PullThroughCacheRuleDockerHubMirror")
+				Expect(dockerHub.Get("Properties.EcrRepositoryPrefix").String()).To(Equal("docker-hub-mirror"))
+				Expect(dockerHub.Get("Properties.UpstreamRegistryUrl").String()).To(Equal("registry-1.docker.io"))
+				Expect(dockerHub.Get("Properties.UpstreamRegistry").String()).To(Equal("docker-hub"))
+				Expect(dockerHub.Get("Properties.CredentialArn").String()).To(Equal("arn:aws:secretsmanager:us-west-2:111122223333:secret:ecr-pullthroughcache/docker-hub"))
+			})
 		})
 
 		Context("when the spec has insufficient subnets", func() {
@@ -0,0 +1,124 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	cft "github.com/weaveworks/eksctl/pkg/cfn/template"
+)
+
+const (
+	// EFSFileSystem is the name of the filesystem resource and output of the EFS stack.
+	EFSFileSystem = "EFSFileSystem"
+	// EFSSecurityGroup is the name of the security group resource of the mount targets of the EFS stack.
+	EFSSecurityGroup = "EFSSecurityGroup"
+
+	nfsPort = 2049
+)
+
+// EFSResourceSetOptions holds the configuration of the EFS stack.
+type EFSResourceSetOptions struct {
+	ClusterName string
+	VPCID       string
+	// CIDRs are the CIDR blocks allowed to mount the filesystem.
+	CIDRs []string
+	// IPv6CIDRs are the IPv6 CIDR blocks allowed to mount the filesystem, from which the pods of IPv6 clusters mount it.
+	IPv6CIDRs []string
+	// SubnetIDs are the subnets of the mount targets, at most one per availability zone.
+	SubnetIDs []string
+}
+
+// EFSResourceSet holds an EFS filesystem with a mount target in each of the given subnets.
+type EFSResourceSet struct {
+	template *cft.Template
+	outputs  *outputs.CollectorSet
+	options  EFSResourceSetOptions
+
+	// FileSystemID is the ID of the filesystem, collected from the stack outputs.
+	FileSystemID string
+}
+
+// NewEFSResourceSet returns a resource set for an EFS filesystem mounted by the nodes of a cluster.
+func NewEFSResourceSet(options EFSResourceSetOptions) *EFSResourceSet {
+	rs := &EFSResourceSet{
+		template: cft.NewTemplate(),
+		options:  options,
+	}
+	rs.outputs = outputs.NewCollectorSet(map[string]outputs.Collector{
+		EFSFileSystem: func(v string) error {
+			rs.FileSystemID = v
+			return nil
+		},
+	})
+	return rs
+}
+
+// AddAllResources adds the filesystem, the security group of its mount targets and the mount targets.
+func (rs *EFSResourceSet) AddAllResources() error {
+	rs.template.Description = fmt.Sprintf("EFS filesystem %s", templateDescriptionSuffix)
+	if len(rs.options.SubnetIDs) == 0 {
+		return fmt.Errorf("at least one subnet is required for the mount targets of the EFS filesystem")
+	}
+
+	var ingress []cft.MapOfInterfaces
+	for _, cidr := range rs.options.CIDRs {
+		ingress = append(ingress, cft.MapOfInterfaces{
+			"IpProtocol":  "tcp",
+			"FromPort":    nfsPort,
+			"ToPort":      nfsPort,
+			"CidrIp":      cidr,
+			"Description": "Allow NFS traffic from the VPC of the cluster",
+		})
+	}
+	for _, cidr := range rs.options.IPv6CIDRs {
+		ingress = append(ingress, cft.MapOfInterfaces{
+			"IpProtocol":  "tcp",
+			"FromPort":    nfsPort,
+			"ToPort":      nfsPort,
+			"CidrIpv6":    cidr,
+			"Description": "Allow NFS traffic from the VPC of the cluster",
+		})
+	}
+	securityGroup := rs.template.NewResource(EFSSecurityGroup, &cft.EC2SecurityGroup{
+		GroupDescription:     cft.NewString(fmt.Sprintf("Mount targets of the EFS filesystem of cluster %s", rs.options.ClusterName)),
+		VpcId:                cft.NewString(rs.options.VPCID),
+		SecurityGroupIngress: ingress,
+	})
+
+	fileSystem := rs.template.NewResource(EFSFileSystem, &cft.EFSFileSystem{
+		Encrypted: true,
+		FileSystemTags: []cft.MapOfInterfaces{
+			{"Key": "Name", "Value": fmt.Sprintf("eksctl-%s-efs", rs.options.ClusterName)},
+		},
+	})
+	// the stack is deleted along with the cluster, as its mount targets are in the subnets of the cluster,
+	// but the data on the filesystem is kept
+	rs.template.RetainResource(EFSFileSystem)
+	for i, subnetID := range rs.options.SubnetIDs {
+		rs.template.NewResource(fmt.Sprintf("EFSMountTarget%d", i), &cft.EFSMountTarget{
+			FileSystemId:   fileSystem,
+			SubnetId:       cft.NewString(subnetID),
+			SecurityGroups: []*cft.Value{securityGroup},
+		})
+	}
+	rs.template.Outputs[EFSFileSystem] = cft.Output{Value: fileSystem}
+	return nil
+}
+
+// RenderJSON returns the rendered JSON
+func (rs *EFSResourceSet) RenderJSON() ([]byte, error) {
+	return rs.template.RenderJSON()
+}
+
+// WithIAM returns false
+func (*EFSResourceSet) WithIAM() bool { return false }
+
+// WithNamedIAM returns false
+func (*EFSResourceSet) WithNamedIAM() bool { return false }
+
+// GetAllOutputs collects the ID of the filesystem
+func (rs *EFSResourceSet) GetAllOutputs(stack types.Stack) error {
+	return rs.outputs.MustCollect(stack)
+}
@@ -0,0 +1,57 @@
+package builder_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+var _ = Describe("EFS stack", func() {
+	It("creates an encrypted filesystem with a mount target in each subnet", func() {
+		rs := builder.NewEFSResourceSet(builder.EFSResourceSetOptions{
+			ClusterName: "shared",
+			VPCID:       "vpc-1",
+			CIDRs:       []string{"192.168.0.0/16", "100.64.0.0/16"},
+			IPv6CIDRs:   []string{"2600:1f14:abc:de00::/56"},
+			SubnetIDs:   []string{"subnet-1", "subnet-2"},
+		})
+		Expect(rs.AddAllResources()).To(Succeed())
+		templateBody, err := rs.RenderJSON()
+		Expect(err).NotTo(HaveOccurred())
+		var template map[string]interface{}
+		Expect(json.Unmarshal(templateBody, &template)).To(Succeed())
+		resources := template["Resources"].(map[string]interface{})
+		Expect(resources).To(HaveLen(4))
+
+		fileSystem := resources[builder.EFSFileSystem].(map[string]interface{})
+		Expect(fileSystem["Type"]).To(Equal("AWS::EFS::FileSystem"))
+		Expect(fileSystem["Properties"]).To(HaveKeyWithValue("Encrypted", true))
+		Expect(fileSystem["DeletionPolicy"]).To(Equal("Retain"))
+
+		securityGroup := resources[builder.EFSSecurityGroup].(map[string]interface{})
+		ingress := securityGroup["Properties"].(map[string]interface{})["SecurityGroupIngress"].([]interface{})
+		Expect(ingress).To(HaveLen(3))
+		Expect(ingress[0]).To(HaveKeyWithValue("CidrIp", "192.168.0.0/16"))
+		Expect(ingress[0]).To(HaveKeyWithValue("FromPort", float64(2049)))
+		Expect(ingress[2]).To(HaveKeyWithValue("CidrIpv6", "2600:1f14:abc:de00::/56"))
+		Expect(ingress[2]).To(HaveKeyWithValue("FromPort", float64(2049)))
+
+		mountTarget := resources["EFSMountTarget1"].(map[string]interface{})
+		Expect(mountTarget["Type"]).To(Equal("AWS::EFS::MountTarget"))
+		Expect(mountTarget["Properties"]).To(Equal(map[string]interface{}{
+			"FileSystemId":   map[string]interface{}{"Ref": builder.EFSFileSystem},
+			"SubnetId":       "subnet-2",
+			"SecurityGroups": []interface{}{map[string]interface{}{"Ref": builder.EFSSecurityGroup}},
+		}))
+
+		Expect(template["Outputs"]).To(HaveKey(builder.EFSFileSystem))
+	})
+
+	It("requires a subnet", func() {
+		rs := builder.NewEFSResourceSet(builder.EFSResourceSetOptions{ClusterName: "shared", VPCID: "vpc-1"})
+		Expect(rs.AddAllResources()).To(MatchError(ContainSubstring("at least one subnet")))
+	})
+})
@@ -96,6 +96,16 @@ type Properties struct {
 		AuthenticationMode                      string
 		BootstrapClusterCreatorAdminPermissions bool
 	}
+	ComputeConfig *struct {
+		Enabled     bool
+		NodePools   []string
+		NodeRoleArn interface{}
+	}
+	StorageConfig *struct {
+		BlockStorage struct {
+			Enabled bool
+		}
+	}
 	LaunchTemplate struct {
 		LaunchTemplateName map[string]interface{}
 		Version            map[string]interface{}
@@ -125,9 +135,12 @@ type Properties struct {
 }
 
 type KubernetesNetworkConfig struct {
-	ServiceIPv4CIDR string
-	ServiceIPv6CIDR interface{}
-	IPFamily        string
+	ServiceIPv4CIDR      string
+	ServiceIPv6CIDR      interface{}
+	IPFamily             string
+	ElasticLoadBalancing *struct {
+		Enabled bool
+	}
 }
 
 type ClusterLogging struct {
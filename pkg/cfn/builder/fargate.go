@@ -96,8 +96,15 @@ func addResourcesForFargate(rs *resourceSet, cfg *api.ClusterConfig) error {
 	if api.IsSetAndNonEmptyString(cfg.IAM.FargatePodExecutionRolePermissionsBoundary) {
 		role.PermissionsBoundary = gfnt.NewString(*cfg.IAM.FargatePodExecutionRolePermissionsBoundary)
 	}
+	if cfg.IAM.RolePath != "" {
+		role.Path = gfnt.NewString(cfg.IAM.RolePath)
+	}
 
 	rs.newResource(fargateRoleName, role)
+	if cfg.FargateLogging != nil {
+		// allow the log router built into Fargate to write to the destination of the logs
+		rs.attachAllowPolicy("PolicyFargateLogging", gfnt.MakeRef(fargateRoleName), fargateLoggingStatements(cfg.FargateLogging))
+	}
 	rs.defineOutputFromAtt(outputs.FargatePodExecutionRoleARN, fargateRoleName, "Arn", true, func(v string) error {
 		cfg.IAM.FargatePodExecutionRoleARN = &v
 		return nil
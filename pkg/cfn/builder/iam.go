@@ -23,6 +23,13 @@ const (
 	iamPolicyAmazonEKSVPCResourceController     = "AmazonEKSVPCResourceController"
 	iamPolicyAmazonEKSLocalOutpostClusterPolicy = "AmazonEKSLocalOutpostClusterPolicy"
 
+	iamPolicyAmazonEKSComputePolicy             = "AmazonEKSComputePolicy"
+	iamPolicyAmazonEKSBlockStoragePolicy        = "AmazonEKSBlockStoragePolicy"
+	iamPolicyAmazonEKSLoadBalancingPolicy       = "AmazonEKSLoadBalancingPolicy"
+	iamPolicyAmazonEKSNetworkingPolicy          = "AmazonEKSNetworkingPolicy"
+	iamPolicyAmazonEKSWorkerNodeMinimalPolicy   = "AmazonEKSWorkerNodeMinimalPolicy"
+	iamPolicyAmazonEC2ContainerRegistryPullOnly = "AmazonEC2ContainerRegistryPullOnly"
+
 	iamPolicyAmazonEKSWorkerNodePolicy           = "AmazonEKSWorkerNodePolicy"
 	iamPolicyAmazonEKSCNIPolicy                  = "AmazonEKS_CNI_Policy"
 	iamPolicyAmazonEC2ContainerRegistryPowerUser = "AmazonEC2ContainerRegistryPowerUser"
@@ -94,17 +101,25 @@ func (c *ClusterResourceSet) addResourcesForIAM() {
 		if !api.IsDisabled(c.spec.IAM.VPCResourceControllerPolicy) {
 			managedPolicyARNs = append(managedPolicyARNs, iamPolicyAmazonEKSVPCResourceController)
 		}
+		assumeRolePolicyDocument := cft.MakeAssumeRolePolicyDocumentForServices(MakeServiceRef("EKS"))
+		if c.spec.IsAutoModeEnabled() {
+			// Auto Mode tags the sessions it uses to manage compute, storage and load balancing
+			assumeRolePolicyDocument = cft.MakeAssumeRoleAndTagSessionPolicyDocumentForServices(MakeServiceRef("EKS"))
+			managedPolicyARNs = append(managedPolicyARNs, iamPolicyAmazonEKSComputePolicy, iamPolicyAmazonEKSBlockStoragePolicy,
+				iamPolicyAmazonEKSLoadBalancingPolicy, iamPolicyAmazonEKSNetworkingPolicy)
+		}
 		role = &gfniam.Role{
-			AssumeRolePolicyDocument: cft.MakeAssumeRolePolicyDocumentForServices(
-				MakeServiceRef("EKS"),
-			),
-			ManagedPolicyArns: gfnt.NewSlice(makePolicyARNs(managedPolicyARNs...)...),
+			AssumeRolePolicyDocument: assumeRolePolicyDocument,
+			ManagedPolicyArns:        gfnt.NewSlice(makePolicyARNs(managedPolicyARNs...)...),
 		}
 	}
 
 	if api.IsSetAndNonEmptyString(c.spec.IAM.ServiceRolePermissionsBoundary) {
 		role.PermissionsBoundary = gfnt.NewString(*c.spec.IAM.ServiceRolePermissionsBoundary)
 	}
+	if c.spec.IAM.RolePath != "" {
+		role.Path = gfnt.NewString(c.spec.IAM.RolePath)
+	}
 	c.newResource("ServiceRole", role)
 
 	c.rs.defineOutputFromAtt(outputs.ClusterServiceRoleARN, "ServiceRole", "Arn", true, func(v string) error {
@@ -171,7 +186,7 @@ func (n *NodeGroupResourceSet) addResourcesForIAM(ctx context.Context) error {
 		n.rs.withNamedIAM = true
 	}
 
-	if err := createRole(n.rs, n.options.ClusterConfig.IAM, nodeGroupIAM, false, n.options.ForceAddCNIPolicy); err != nil {
+	if err := createRole(n.rs, n.options.ClusterConfig.IAM, nodeGroupIAM, n.options.ClusterConfig.PullThroughCache, false, n.options.ForceAddCNIPolicy); err != nil {
 		return err
 	}
 
@@ -201,6 +216,7 @@ func NewIAMRoleResourceSetForServiceAccount(spec *api.ClusterIAMServiceAccount,
 		namespace:           spec.Namespace,
 		wellKnownPolicies:   spec.WellKnownPolicies,
 		roleName:            spec.RoleName,
+		rolePath:            spec.RolePath,
 		permissionsBoundary: spec.PermissionsBoundary,
 		description: fmt.Sprintf(
 			"IAM role for serviceaccount %q %s",
@@ -244,6 +260,7 @@ type IAMRoleResourceSet struct {
 	oidc                *iamoidc.OpenIDConnectManager
 	outputs             *outputs.CollectorSet
 	roleName            string
+	rolePath            string
 	wellKnownPolicies   api.WellKnownPolicies
 	attachPolicyARNs    []string
 	attachPolicy        api.InlineDocument
@@ -310,6 +327,7 @@ func (rs *IAMRoleResourceSet) AddAllResources() error {
 		AssumeRolePolicyDocument: rs.makeAssumeRolePolicyDocument(),
 		PermissionsBoundary:      rs.permissionsBoundary,
 		RoleName:                 rs.roleName,
+		Path:                     rs.rolePath,
 	}
 
 	for _, arn := range rs.attachPolicyARNs {
@@ -73,17 +73,38 @@ func createWellKnownPolicies(wellKnownPolicies api.WellKnownPolicies) ([]managed
 			customPolicyForRole{Name: "PolicyEFSCSIController", Statements: efsCSIControllerStatements()},
 		)
 	}
+	if wellKnownPolicies.FSxCSIController {
+		customPolicies = append(customPolicies,
+			[]customPolicyForRole{
+				{Name: "PolicyFSxCSIController", Statements: fsxStatements()},
+				{Name: "PolicyFSxCSIControllerServiceLinkRole", Statements: serviceLinkRoleStatements()},
+			}...,
+		)
+	}
+	if wellKnownPolicies.CloudWatchAgent {
+		managedPolicies = append(managedPolicies,
+			managedPolicyForRole{name: iamPolicyCloudWatchAgentServerPolicy},
+		)
+	}
 	return managedPolicies, customPolicies
 }
 
+// makeRolePath returns the path of roles created for nodes, which historically is always set
+func makeRolePath(clusterIAMConfig *api.ClusterIAM) *gfnt.Value {
+	if clusterIAMConfig.RolePath != "" {
+		return gfnt.NewString(clusterIAMConfig.RolePath)
+	}
+	return gfnt.NewString("/")
+}
+
 // createRole creates an IAM role with policies required for the worker nodes and addons
-func createRole(cfnTemplate cfnTemplate, clusterIAMConfig *api.ClusterIAM, iamConfig *api.NodeGroupIAM, managed, forceAddCNIPolicy bool) error {
+func createRole(cfnTemplate cfnTemplate, clusterIAMConfig *api.ClusterIAM, iamConfig *api.NodeGroupIAM, pullThroughCache *api.PullThroughCache, managed, forceAddCNIPolicy bool) error {
 	managedPolicyARNs, err := makeManagedPolicies(clusterIAMConfig, iamConfig, managed, forceAddCNIPolicy)
 	if err != nil {
 		return err
 	}
 	role := gfniam.Role{
-		Path:                     gfnt.NewString("/"),
+		Path:                     makeRolePath(clusterIAMConfig),
 		AssumeRolePolicyDocument: cft.MakeAssumeRolePolicyDocumentForServices(MakeServiceRef("EC2")),
 		ManagedPolicyArns:        managedPolicyARNs,
 	}
@@ -94,6 +115,8 @@ func createRole(cfnTemplate cfnTemplate, clusterIAMConfig *api.ClusterIAM, iamCo
 
 	if iamConfig.InstanceRolePermissionsBoundary != "" {
 		role.PermissionsBoundary = gfnt.NewString(iamConfig.InstanceRolePermissionsBoundary)
+	} else if clusterIAMConfig.WithPermissionsBoundary != "" {
+		role.PermissionsBoundary = gfnt.NewString(clusterIAMConfig.WithPermissionsBoundary)
 	}
 
 	refIR := cfnTemplate.newResource(cfnIAMInstanceRoleName, &role)
@@ -146,6 +169,10 @@ func createRole(cfnTemplate cfnTemplate, clusterIAMConfig *api.ClusterIAM, iamCo
 		cfnTemplate.attachAllowPolicy("PolicyXRay", refIR, xRayStatements())
 	}
 
+	if pullThroughCache != nil && len(pullThroughCache.Rules) > 0 {
+		cfnTemplate.attachAllowPolicy("PolicyPullThroughCache", refIR, pullThroughCacheStatements(pullThroughCache))
+	}
+
 	return nil
 }
 
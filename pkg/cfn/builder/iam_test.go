@@ -254,6 +254,30 @@ var _ = Describe("template builder for IAM", func() {
 			Expect(t).To(HaveOutputWithValue(outputs.IAMServiceAccountRoleName, `{ "Fn::GetAtt": "Role1.Arn" }`))
 		})
 
+		It("can construct an iamserviceaccount addon template with a role path", func() {
+			serviceAccount := &api.ClusterIAMServiceAccount{}
+
+			serviceAccount.Name = "sa-1"
+
+			serviceAccount.AttachPolicyARNs = []string{"arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"}
+
+			serviceAccount.RolePath = "/eksctl/"
+
+			appendServiceAccountToClusterConfig(cfg, serviceAccount)
+
+			rs := builder.NewIAMRoleResourceSetForServiceAccount(serviceAccount, oidc)
+
+			templateBody := []byte{}
+
+			Expect(rs).To(RenderWithoutErrors(&templateBody))
+
+			t := cft.NewTemplate()
+
+			Expect(t).To(LoadBytesWithoutErrors(templateBody))
+
+			Expect(t).To(HaveResourceWithPropertyValue(outputs.IAMServiceAccountRoleName, "Path", `"/eksctl/"`))
+		})
+
 		It("can construct an iamserviceaccount addon template with all the wellKnownPolicies", func() {
 			serviceAccount := &api.ClusterIAMServiceAccount{}
 
@@ -267,6 +291,8 @@ var _ = Describe("template builder for IAM", func() {
 				CertManager:               true,
 				EBSCSIController:          true,
 				EFSCSIController:          true,
+				FSxCSIController:          true,
+				CloudWatchAgent:           true,
 			}
 
 			appendServiceAccountToClusterConfig(cfg, serviceAccount)
@@ -283,7 +309,7 @@ var _ = Describe("template builder for IAM", func() {
 
 			Expect(t.Description).To(Equal("IAM role for serviceaccount \"default/sa-1\" [created and managed by eksctl]"))
 
-			Expect(t.Resources).To(HaveLen(10))
+			Expect(t.Resources).To(HaveLen(12))
 			Expect(t.Outputs).To(HaveLen(1))
 
 			Expect(t).To(HaveResource(outputs.IAMServiceAccountRoleName, "AWS::IAM::Role"))
@@ -292,8 +318,13 @@ var _ = Describe("template builder for IAM", func() {
 			Expect(t).To(HaveResourceWithPropertyValue(outputs.IAMServiceAccountRoleName, "ManagedPolicyArns", `[
               {
                 "Fn::Sub": "arn:${AWS::Partition}:iam::aws:policy/AmazonEC2ContainerRegistryPowerUser"
+		      },
+              {
+                "Fn::Sub": "arn:${AWS::Partition}:iam::aws:policy/CloudWatchAgentServerPolicy"
 		      }
             ]`))
+			Expect(t).To(HaveResource("PolicyFSxCSIController", "AWS::IAM::Policy"))
+			Expect(t).To(HaveResource("PolicyFSxCSIControllerServiceLinkRole", "AWS::IAM::Policy"))
 			Expect(t).To(HaveOutputWithValue(outputs.IAMServiceAccountRoleName, `{ "Fn::GetAtt": "Role1.Arn" }`))
 			Expect(t).To(HaveResourceWithPropertyValue("PolicyEBSCSIController", "PolicyDocument", expectedEbsPolicyDocument))
 		})
@@ -130,7 +130,7 @@ func (k *KarpenterResourceSet) addResourcesForKarpenter() error {
 	roleName := gfnt.NewString(fmt.Sprintf("eksctl-%s-%s", KarpenterNodeRoleName, k.clusterSpec.Metadata.Name))
 	role := gfniam.Role{
 		RoleName:                 roleName,
-		Path:                     gfnt.NewString("/"),
+		Path:                     makeRolePath(k.clusterSpec.IAM),
 		AssumeRolePolicyDocument: cft.MakeAssumeRolePolicyDocumentForServices(MakeServiceRef("EC2")),
 		ManagedPolicyArns:        gfnt.NewSlice(makePolicyARNs(sets.List(managedPolicyNames)...)...),
 	}
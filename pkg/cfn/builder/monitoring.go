@@ -0,0 +1,214 @@
+package builder
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	cft "github.com/weaveworks/eksctl/pkg/cfn/template"
+	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+)
+
+const (
+	// PrometheusWorkspace is the name of the AMP workspace resource of the monitoring stack.
+	PrometheusWorkspace = "PrometheusWorkspace"
+	// PrometheusWorkspaceARN is the name of the output holding the ARN of the workspace.
+	PrometheusWorkspaceARN = "PrometheusWorkspaceARN"
+	// PrometheusEndpoint is the name of the output holding the Prometheus endpoint of the workspace.
+	PrometheusEndpoint = "PrometheusEndpoint"
+	// PrometheusScraper is the name of the EKS managed scraper resource of the monitoring stack.
+	PrometheusScraper = "PrometheusScraper"
+	// PrometheusScraperRole is the name of the output holding the ARN of the role the scraper uses in the cluster.
+	PrometheusScraperRole = "PrometheusScraperRole"
+	// PrometheusCollectorRole is the name of the role resource and output of the ADOT collector.
+	PrometheusCollectorRole = "PrometheusCollectorRole"
+
+	// PrometheusScraperUsername is the Kubernetes user the EKS managed scraper is mapped to.
+	PrometheusScraperUsername = "aps-collector-user"
+)
+
+// PrometheusScraperSource is the network configuration of the cluster an EKS managed scraper collects the metrics of.
+type PrometheusScraperSource struct {
+	ClusterARN       string
+	SubnetIDs        []string
+	SecurityGroupIDs []string
+	// WithAccessEntry maps the role of the scraper to PrometheusScraperUsername with an access entry.
+	WithAccessEntry bool
+}
+
+// MonitoringResourceSetOptions holds the configuration of the monitoring stack.
+type MonitoringResourceSetOptions struct {
+	ClusterName         string
+	Prometheus          *api.PrometheusMonitoring
+	ScrapeConfiguration string
+	// ScraperSource is used with the managed-scraper collector.
+	ScraperSource PrometheusScraperSource
+	// OIDC, Namespace and ServiceAccount are used with the adot collector, to let the collector write to the workspace.
+	OIDC           *iamoidc.OpenIDConnectManager
+	Namespace      string
+	ServiceAccount string
+}
+
+// MonitoringResourceSet holds the AMP workspace of a cluster and the collector sending it the metrics of the cluster.
+type MonitoringResourceSet struct {
+	template *cft.Template
+	outputs  *outputs.CollectorSet
+	options  MonitoringResourceSetOptions
+
+	// WorkspaceARN is the ARN of the workspace, collected from the stack outputs.
+	WorkspaceARN string
+	// Endpoint is the Prometheus endpoint of the workspace, collected from the stack outputs.
+	Endpoint string
+	// ScraperRoleARN is the ARN of the role of the managed scraper, collected from the stack outputs.
+	ScraperRoleARN string
+	// CollectorRoleARN is the ARN of the role of the ADOT collector, collected from the stack outputs.
+	CollectorRoleARN string
+}
+
+// NewMonitoringResourceSet returns a resource set for the monitoring of a cluster with Amazon Managed Service for Prometheus.
+func NewMonitoringResourceSet(options MonitoringResourceSetOptions) *MonitoringResourceSet {
+	rs := &MonitoringResourceSet{
+		template: cft.NewTemplate(),
+		options:  options,
+	}
+	collectors := map[string]outputs.Collector{
+		PrometheusWorkspaceARN: func(v string) error {
+			rs.WorkspaceARN = v
+			return nil
+		},
+		PrometheusEndpoint: func(v string) error {
+			rs.Endpoint = v
+			return nil
+		},
+	}
+	if options.Prometheus.CollectorOrDefault() == api.PrometheusCollectorADOT {
+		collectors[PrometheusCollectorRole] = func(v string) error {
+			rs.CollectorRoleARN = v
+			return nil
+		}
+	} else {
+		collectors[PrometheusScraperRole] = func(v string) error {
+			rs.ScraperRoleARN = v
+			return nil
+		}
+	}
+	rs.outputs = outputs.NewCollectorSet(collectors)
+	return rs
+}
+
+// AddAllResources adds the workspace, unless an existing one is used, and the collector.
+func (rs *MonitoringResourceSet) AddAllResources() error {
+	rs.template.Description = fmt.Sprintf("Amazon Managed Service for Prometheus %s", templateDescriptionSuffix)
+	prometheus := rs.options.Prometheus
+
+	var workspaceARN, endpoint *cft.Value
+	if prometheus.WorkspaceARN != "" {
+		existingEndpoint, err := api.PrometheusWorkspaceEndpoint(prometheus.WorkspaceARN)
+		if err != nil {
+			return err
+		}
+		workspaceARN = cft.NewString(prometheus.WorkspaceARN)
+		endpoint = cft.NewString(existingEndpoint)
+	} else {
+		alias := prometheus.WorkspaceAlias
+		if alias == "" {
+			alias = rs.options.ClusterName
+		}
+		rs.template.NewResource(PrometheusWorkspace, &cft.APSWorkspace{
+			Alias: cft.NewString(alias),
+			Tags:  makeMonitoringTags(prometheus.Tags),
+		})
+		workspaceARN = cft.MakeFnGetAttString(PrometheusWorkspace + ".Arn")
+		endpoint = cft.MakeFnGetAttString(PrometheusWorkspace + ".PrometheusEndpoint")
+	}
+	rs.template.Outputs[PrometheusWorkspaceARN] = cft.Output{Value: workspaceARN}
+	rs.template.Outputs[PrometheusEndpoint] = cft.Output{Value: endpoint}
+
+	if prometheus.CollectorOrDefault() == api.PrometheusCollectorADOT {
+		rs.addCollectorRole(workspaceARN)
+		return nil
+	}
+	rs.addManagedScraper(workspaceARN)
+	return nil
+}
+
+func (rs *MonitoringResourceSet) addManagedScraper(workspaceARN *cft.Value) {
+	source := rs.options.ScraperSource
+	rs.template.NewResource(PrometheusScraper, &cft.APSScraper{
+		Alias: cft.NewString(rs.options.ClusterName),
+		ScrapeConfiguration: cft.MapOfInterfaces{
+			"ConfigurationBlob": base64.StdEncoding.EncodeToString([]byte(rs.options.ScrapeConfiguration)),
+		},
+		Source: cft.MapOfInterfaces{
+			"EksConfiguration": cft.MapOfInterfaces{
+				"ClusterArn":       source.ClusterARN,
+				"SubnetIds":        source.SubnetIDs,
+				"SecurityGroupIds": source.SecurityGroupIDs,
+			},
+		},
+		Destination: cft.MapOfInterfaces{
+			"AmpConfiguration": cft.MapOfInterfaces{
+				"WorkspaceArn": workspaceARN,
+			},
+		},
+		Tags: makeMonitoringTags(rs.options.Prometheus.Tags),
+	})
+	scraperRoleARN := cft.MakeFnGetAttString(PrometheusScraper + ".RoleArn")
+	if source.WithAccessEntry {
+		rs.template.NewResource("PrometheusScraperAccessEntry", &cft.EKSAccessEntry{
+			ClusterName:  cft.NewString(rs.options.ClusterName),
+			PrincipalArn: scraperRoleARN,
+			Username:     cft.NewString(PrometheusScraperUsername),
+		})
+	}
+	rs.template.Outputs[PrometheusScraperRole] = cft.Output{Value: scraperRoleARN}
+}
+
+func (rs *MonitoringResourceSet) addCollectorRole(workspaceARN *cft.Value) {
+	roleRef := rs.template.NewResource(PrometheusCollectorRole, &cft.IAMRole{
+		AssumeRolePolicyDocument: rs.options.OIDC.MakeAssumeRolePolicyDocumentWithServiceAccountConditions(rs.options.Namespace, rs.options.ServiceAccount),
+	})
+	rs.template.AttachPolicy("PrometheusRemoteWritePolicy", roleRef, cft.MakePolicyDocument(
+		cft.MapOfInterfaces{
+			"Effect":   "Allow",
+			"Action":   []string{"aps:RemoteWrite"},
+			"Resource": workspaceARN,
+		},
+	))
+	rs.template.Outputs[PrometheusCollectorRole] = cft.Output{
+		Value: cft.MakeFnGetAttString(PrometheusCollectorRole + ".Arn"),
+	}
+}
+
+func makeMonitoringTags(tags map[string]string) []cft.MapOfInterfaces {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var cfnTags []cft.MapOfInterfaces
+	for _, k := range keys {
+		cfnTags = append(cfnTags, cft.MapOfInterfaces{"Key": k, "Value": tags[k]})
+	}
+	return cfnTags
+}
+
+// RenderJSON returns the rendered JSON
+func (rs *MonitoringResourceSet) RenderJSON() ([]byte, error) {
+	return rs.template.RenderJSON()
+}
+
+// WithIAM returns true
+func (*MonitoringResourceSet) WithIAM() bool { return true }
+
+// WithNamedIAM returns false
+func (*MonitoringResourceSet) WithNamedIAM() bool { return false }
+
+// GetAllOutputs collects the workspace ARN and endpoint, and the role of the collector
+func (rs *MonitoringResourceSet) GetAllOutputs(stack types.Stack) error {
+	return rs.outputs.MustCollect(stack)
+}
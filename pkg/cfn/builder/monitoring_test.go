@@ -0,0 +1,132 @@
+package builder_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+)
+
+var _ = Describe("monitoring stack", func() {
+	var (
+		options builder.MonitoringResourceSetOptions
+	)
+
+	BeforeEach(func() {
+		options = builder.MonitoringResourceSetOptions{
+			ClusterName:         "monitored",
+			Prometheus:          &api.PrometheusMonitoring{Tags: map[string]string{"team": "platform"}},
+			ScrapeConfiguration: "scrape_configs: []\n",
+			ScraperSource: builder.PrometheusScraperSource{
+				ClusterARN:       "arn:aws:eks:us-west-2:456123987123:cluster/monitored",
+				SubnetIDs:        []string{"subnet-1", "subnet-2"},
+				SecurityGroupIDs: []string{"sg-1"},
+				WithAccessEntry:  true,
+			},
+			Namespace:      "adot-collector",
+			ServiceAccount: "adot-collector",
+		}
+	})
+
+	renderTemplate := func() map[string]interface{} {
+		rs := builder.NewMonitoringResourceSet(options)
+		Expect(rs.AddAllResources()).To(Succeed())
+		Expect(rs.WithIAM()).To(BeTrue())
+		Expect(rs.WithNamedIAM()).To(BeFalse())
+		templateBody, err := rs.RenderJSON()
+		Expect(err).NotTo(HaveOccurred())
+		var template map[string]interface{}
+		Expect(json.Unmarshal(templateBody, &template)).To(Succeed())
+		return template
+	}
+
+	It("creates a workspace and a managed scraper mapped with an access entry", func() {
+		template := renderTemplate()
+		resources := template["Resources"].(map[string]interface{})
+
+		workspace := resources[builder.PrometheusWorkspace].(map[string]interface{})
+		Expect(workspace["Type"]).To(Equal("AWS::APS::Workspace"))
+		workspaceProperties := workspace["Properties"].(map[string]interface{})
+		Expect(workspaceProperties["Alias"]).To(Equal("monitored"))
+		Expect(workspaceProperties["Tags"]).To(ConsistOf(map[string]interface{}{"Key": "team", "Value": "platform"}))
+
+		scraper, err := json.Marshal(resources[builder.PrometheusScraper])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(scraper)).To(ContainSubstring(`"Type":"AWS::APS::Scraper"`))
+		Expect(string(scraper)).To(ContainSubstring(`"ClusterArn":"arn:aws:eks:us-west-2:456123987123:cluster/monitored"`))
+		Expect(string(scraper)).To(ContainSubstring(`"SubnetIds":["subnet-1","subnet-2"]`))
+		Expect(string(scraper)).To(ContainSubstring(`"WorkspaceArn":{"Fn::GetAtt":["PrometheusWorkspace","Arn"]}`))
+		Expect(string(scraper)).To(ContainSubstring(`"ConfigurationBlob":"c2NyYXBlX2NvbmZpZ3M6IFtdCg=="`))
+
+		accessEntry, err := json.Marshal(resources["PrometheusScraperAccessEntry"])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(accessEntry)).To(ContainSubstring(`"PrincipalArn":{"Fn::GetAtt":["PrometheusScraper","RoleArn"]}`))
+		Expect(string(accessEntry)).To(ContainSubstring(`"Username":"aps-collector-user"`))
+
+		Expect(template["Outputs"]).To(HaveKey(builder.PrometheusWorkspaceARN))
+		Expect(template["Outputs"]).To(HaveKey(builder.PrometheusEndpoint))
+		Expect(template["Outputs"]).To(HaveKey(builder.PrometheusScraperRole))
+		Expect(template["Outputs"]).NotTo(HaveKey(builder.PrometheusCollectorRole))
+	})
+
+	When("the cluster does not support access entries", func() {
+		BeforeEach(func() {
+			options.ScraperSource.WithAccessEntry = false
+		})
+
+		It("does not create an access entry", func() {
+			template := renderTemplate()
+			Expect(template["Resources"]).NotTo(HaveKey("PrometheusScraperAccessEntry"))
+			Expect(template["Outputs"]).To(HaveKey(builder.PrometheusScraperRole))
+		})
+	})
+
+	When("an existing workspace is set", func() {
+		BeforeEach(func() {
+			options.Prometheus.WorkspaceARN = "arn:aws:aps:us-west-2:456123987123:workspace/ws-abcd-1234"
+		})
+
+		It("does not create a workspace and outputs its endpoint", func() {
+			template := renderTemplate()
+			Expect(template["Resources"]).NotTo(HaveKey(builder.PrometheusWorkspace))
+
+			outputs := template["Outputs"].(map[string]interface{})
+			Expect(outputs[builder.PrometheusWorkspaceARN].(map[string]interface{})["Value"]).To(Equal("arn:aws:aps:us-west-2:456123987123:workspace/ws-abcd-1234"))
+			Expect(outputs[builder.PrometheusEndpoint].(map[string]interface{})["Value"]).To(Equal("https://aps-workspaces.us-west-2.amazonaws.com/workspaces/ws-abcd-1234/"))
+		})
+	})
+
+	When("the collector is adot", func() {
+		BeforeEach(func() {
+			oidc, err := iamoidc.NewOpenIDConnectManager(nil, "456123987123", "https://oidc.eks.us-west-2.amazonaws.com/id/A39A2842863C47208955D753DE205E6E", "aws", nil)
+			Expect(err).NotTo(HaveOccurred())
+			oidc.ProviderARN = "arn:aws:iam::456123987123:oidc-provider/oidc.eks.us-west-2.amazonaws.com/id/A39A2842863C47208955D753DE205E6E"
+			options.OIDC = oidc
+			options.Prometheus.Collector = api.PrometheusCollectorADOT
+			options.Prometheus.ADOTVersion = "v0.40.0"
+		})
+
+		It("creates a role allowing the collector to write to the workspace instead of a scraper", func() {
+			template := renderTemplate()
+			resources := template["Resources"].(map[string]interface{})
+			Expect(resources).NotTo(HaveKey(builder.PrometheusScraper))
+			Expect(resources).NotTo(HaveKey("PrometheusScraperAccessEntry"))
+
+			role, err := json.Marshal(resources[builder.PrometheusCollectorRole])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(role)).To(ContainSubstring("system:serviceaccount:adot-collector:adot-collector"))
+
+			policy, err := json.Marshal(resources["PrometheusRemoteWritePolicy"])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(policy)).To(ContainSubstring("aps:RemoteWrite"))
+			Expect(string(policy)).To(ContainSubstring(`"Resource":{"Fn::GetAtt":["PrometheusWorkspace","Arn"]}`))
+
+			Expect(template["Outputs"]).To(HaveKey(builder.PrometheusCollectorRole))
+			Expect(template["Outputs"]).NotTo(HaveKey(builder.PrometheusScraperRole))
+		})
+	})
+})
@@ -476,6 +476,17 @@ func newLaunchTemplateData(ctx context.Context, n *NodeGroupResourceSet) (*gfnec
 		return nil, errors.Wrap(err, "couldn't build network interfaces for launch template data")
 	}
 
+	if len(ng.LocalZones) > 0 && !ng.PrivateNetworking {
+		inWavelengthZone, err := isInWavelengthZone(ctx, n.ec2API, n.options.ClusterConfig.Metadata.Region, ng.LocalZones)
+		if err != nil {
+			return nil, err
+		}
+		// public subnets in Wavelength Zones do not assign public IPs; instances need a carrier IP to reach the Internet
+		if inWavelengthZone {
+			launchTemplateData.NetworkInterfaces[0].AssociateCarrierIpAddress = gfnt.True()
+		}
+	}
+
 	if api.IsEnabled(ng.EFAEnabled) && ng.Placement == nil {
 		groupName := n.newResource("NodeGroupPlacementGroup", &gfnec2.PlacementGroup{
 			Strategy: gfnt.NewString("cluster"),
@@ -644,3 +655,16 @@ func metricsCollectionResource(asgMetricsCollection []api.MetricsCollection) []m
 	}
 	return metricsCollections
 }
+
+func isInWavelengthZone(ctx context.Context, ec2API awsapi.EC2, region string, zones []string) (bool, error) {
+	zoneTypes, err := vpc.DiscoverZoneTypes(ctx, ec2API, region)
+	if err != nil {
+		return false, err
+	}
+	for _, zone := range zones {
+		if zoneTypes[zone] == vpc.ZoneTypeWavelengthZone {
+			return true, nil
+		}
+	}
+	return false, nil
+}
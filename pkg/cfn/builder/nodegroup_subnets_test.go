@@ -464,9 +464,106 @@ var _ = Describe("AssignSubnets", func() {
 			},
 			expectedSubnetIDs: []string{"subnet-123"},
 		}),
+
+		Entry("self-managed nodegroup in a Wavelength Zone", assignSubnetsEntry{
+			np: &api.NodeGroup{
+				NodeGroupBase: &api.NodeGroupBase{
+					Name:         "wavelength",
+					InstanceType: "t3.medium",
+				},
+				LocalZones: []string{"us-west-2-wl1-las-wlz-1"},
+			},
+			updateClusterConfig: func(c *api.ClusterConfig) {
+				mockWavelengthZoneSubnets(c, "us-west-2-wl1-las-wlz-1")
+			},
+			updateEC2Mocks: func(e *mocksv2.EC2) {
+				mockWavelengthZoneInstanceSupport(e, "us-west-2-wl1-las-wlz-1", ec2types.InstanceTypeT3Medium)
+			},
+			customInstanceSupport: true,
+			expectedSubnetIDs:     []string{"subnet-public-us-west-2-wl1-las-wlz-1"},
+		}),
+
+		Entry("self-managed nodegroup in a Wavelength Zone that does not support its instance type", assignSubnetsEntry{
+			np: &api.NodeGroup{
+				NodeGroupBase: &api.NodeGroupBase{
+					Name:         "wavelength",
+					InstanceType: "m5.large",
+				},
+				LocalZones: []string{"us-west-2-wl1-las-wlz-1"},
+			},
+			updateClusterConfig: func(c *api.ClusterConfig) {
+				mockWavelengthZoneSubnets(c, "us-west-2-wl1-las-wlz-1")
+			},
+			updateEC2Mocks: func(e *mocksv2.EC2) {
+				mockWavelengthZoneInstanceSupport(e, "us-west-2-wl1-las-wlz-1", ec2types.InstanceTypeT3Medium)
+			},
+			customInstanceSupport: true,
+			expectedErr:           "cannot create nodegroup wavelength in Wavelength Zone us-west-2-wl1-las-wlz-1 as it does not support instance type(s) m5.large",
+		}),
+
+		Entry("self-managed nodegroup in both a Wavelength Zone and a Local Zone", assignSubnetsEntry{
+			np: &api.NodeGroup{
+				NodeGroupBase: &api.NodeGroupBase{
+					Name:         "wavelength",
+					InstanceType: "t3.medium",
+				},
+				LocalZones: []string{"us-west-2-wl1-las-wlz-1", "us-west-2-lax-1a"},
+			},
+			updateClusterConfig: func(c *api.ClusterConfig) {
+				mockWavelengthZoneSubnets(c, "us-west-2-wl1-las-wlz-1", "us-west-2-lax-1a")
+			},
+			updateEC2Mocks: func(e *mocksv2.EC2) {
+				mockWavelengthZoneInstanceSupport(e, "us-west-2-wl1-las-wlz-1", ec2types.InstanceTypeT3Medium)
+			},
+			customInstanceSupport: true,
+			expectedErr:           "cannot launch a nodegroup in both Wavelength Zones and other zones",
+		}),
 	)
 })
 
+func mockWavelengthZoneSubnets(cfg *api.ClusterConfig, zones ...string) {
+	cfg.VPC.Subnets = &api.ClusterSubnets{
+		Public:  api.NewAZSubnetMapping(),
+		Private: api.NewAZSubnetMapping(),
+	}
+	cfg.VPC.LocalZoneSubnets = &api.ClusterSubnets{
+		Public:  api.NewAZSubnetMapping(),
+		Private: api.NewAZSubnetMapping(),
+	}
+	for _, zone := range zones {
+		cfg.VPC.LocalZoneSubnets.Public[zone] = api.AZSubnetSpec{
+			ID: fmt.Sprintf("subnet-public-%s", zone),
+			AZ: zone,
+		}
+	}
+}
+
+func mockWavelengthZoneInstanceSupport(ec2Mock *mocksv2.EC2, wavelengthZone string, instanceType ec2types.InstanceType) {
+	ec2Mock.On("DescribeAvailabilityZones", mock.Anything, mock.Anything).
+		Return(&ec2.DescribeAvailabilityZonesOutput{
+			AvailabilityZones: []ec2types.AvailabilityZone{
+				{
+					ZoneType: aws.String("wavelength-zone"),
+					ZoneName: aws.String(wavelengthZone),
+				},
+				{
+					ZoneType: aws.String("local-zone"),
+					ZoneName: aws.String("us-west-2-lax-1a"),
+				},
+			},
+		}, nil)
+	ec2Mock.On("DescribeInstanceTypeOfferings", mock.Anything, mock.Anything, mock.Anything).
+		Return(&ec2.DescribeInstanceTypeOfferingsOutput{
+			InstanceTypeOfferings: []ec2types.InstanceTypeOffering{
+				{
+					InstanceType: instanceType,
+					Location:     aws.String(wavelengthZone),
+					LocationType: ec2types.LocationTypeAvailabilityZone,
+				},
+			},
+		}, nil)
+}
+
 func mockDescribeSubnets(ec2Mock *mocksv2.EC2, zoneName, vpcID string) {
 	mockDescribeSubnetsWithOutpost(ec2Mock, zoneName, vpcID, nil)
 }
@@ -0,0 +1,88 @@
+package builder
+
+import (
+	gfncfn "github.com/weaveworks/goformation/v4/cloudformation/cloudformation"
+	gfnec2 "github.com/weaveworks/goformation/v4/cloudformation/ec2"
+	gfniam "github.com/weaveworks/goformation/v4/cloudformation/iam"
+	gfnt "github.com/weaveworks/goformation/v4/cloudformation/types"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	cft "github.com/weaveworks/eksctl/pkg/cfn/template"
+)
+
+const (
+	cfnInstanceConnectEndpointResource   = "InstanceConnectEndpoint"
+	cfnInstanceConnectEndpointSGResource = "InstanceConnectEndpointSecurityGroup"
+	cfnBastionResource                   = "Bastion"
+	cfnBastionRoleResource               = "BastionRole"
+	cfnBastionInstanceProfileResource    = "BastionInstanceProfile"
+
+	// bastionImageID resolves the latest Amazon Linux 2023 AMI, which ships with the SSM agent
+	bastionImageID = "{{resolve:ssm:/aws/service/ami-amazon-linux-latest/al2023-ami-kernel-default-x86_64}}"
+)
+
+// addResourcesForAdminAccess adds the resources that let operators reach a fully-private cluster from within its VPC;
+// both the Instance Connect Endpoint and the bastion are placed in the first private subnet
+func (c *ClusterResourceSet) addResourcesForAdminAccess(adminAccess *api.PrivateClusterAdminAccess, vpcID *gfnt.Value, privateSubnets []SubnetResource, sharedNodeSG *gfnt.Value) {
+	subnet := privateSubnets[0].Subnet
+
+	switch adminAccess.Type {
+	case api.PrivateClusterAdminAccessInstanceConnectEndpoint:
+		refEndpointSG := c.newResource(cfnInstanceConnectEndpointSGResource, &gfnec2.SecurityGroup{
+			GroupDescription: gfnt.NewString("EC2 Instance Connect Endpoint for reaching nodes of the fully-private cluster"),
+			VpcId:            vpcID,
+		})
+		c.newResource("IngressInstanceConnectEndpointToNodeSG", &gfnec2.SecurityGroupIngress{
+			GroupId:               sharedNodeSG,
+			SourceSecurityGroupId: refEndpointSG,
+			Description:           gfnt.NewString("Allow SSH from the EC2 Instance Connect Endpoint"),
+			IpProtocol:            gfnt.NewString("tcp"),
+			FromPort:              sgPortSSH,
+			ToPort:                sgPortSSH,
+		})
+		refEndpoint := c.newResource(cfnInstanceConnectEndpointResource, &awsCloudFormationResource{
+			Type: "AWS::EC2::InstanceConnectEndpoint",
+			Properties: map[string]interface{}{
+				"SubnetId":         subnet,
+				"SecurityGroupIds": []*gfnt.Value{refEndpointSG},
+				"PreserveClientIp": false,
+			},
+		})
+		c.rs.defineOutputWithoutCollector(outputs.ClusterInstanceConnectEndpointID, refEndpoint, false)
+
+	case api.PrivateClusterAdminAccessBastion:
+		c.rs.withIAM = true
+		role := &gfniam.Role{
+			AssumeRolePolicyDocument: cft.MakeAssumeRolePolicyDocumentForServices(MakeServiceRef("EC2")),
+			ManagedPolicyArns:        gfnt.NewSlice(makePolicyARNs(iamPolicyAmazonSSMManagedInstanceCore)...),
+		}
+		if api.IsSetAndNonEmptyString(c.spec.IAM.ServiceRolePermissionsBoundary) {
+			role.PermissionsBoundary = gfnt.NewString(*c.spec.IAM.ServiceRolePermissionsBoundary)
+		}
+		if c.spec.IAM.RolePath != "" {
+			role.Path = gfnt.NewString(c.spec.IAM.RolePath)
+		}
+		refRole := c.newResource(cfnBastionRoleResource, role)
+		refInstanceProfile := c.newResource(cfnBastionInstanceProfileResource, &gfniam.InstanceProfile{
+			Path:  gfnt.NewString("/"),
+			Roles: gfnt.NewSlice(refRole),
+		})
+		// the shared node security group can reach the API server through the cluster security group
+		refBastion := c.newResource(cfnBastionResource, &awsCloudFormationResource{
+			Type: "AWS::EC2::Instance",
+			Properties: map[string]interface{}{
+				"ImageId":            bastionImageID,
+				"InstanceType":       adminAccess.InstanceType,
+				"SubnetId":           subnet,
+				"SecurityGroupIds":   []*gfnt.Value{sharedNodeSG},
+				"IamInstanceProfile": refInstanceProfile,
+				"MetadataOptions": map[string]string{
+					"HttpTokens": "required",
+				},
+				"Tags": []gfncfn.Tag{makeAutoNameTag(cfnBastionResource)},
+			},
+		})
+		c.rs.defineOutputWithoutCollector(outputs.ClusterBastionInstanceID, refBastion, false)
+	}
+}
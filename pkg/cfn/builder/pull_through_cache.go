@@ -0,0 +1,39 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// addResourcesForPullThroughCache adds an ECR pull-through cache rule for each configured upstream registry.
+// goformation does not model AWS::ECR::PullThroughCacheRule, so the resources are rendered as generic resources.
+func (c *ClusterResourceSet) addResourcesForPullThroughCache() {
+	for _, rule := range c.spec.PullThroughCache.Rules {
+		properties := map[string]interface{}{
+			"EcrRepositoryPrefix": rule.RepositoryPrefix(),
+			"UpstreamRegistryUrl": rule.UpstreamRegistryHost(),
+		}
+		if upstreamRegistry := rule.ECRUpstreamRegistry(); upstreamRegistry != "" {
+			properties["UpstreamRegistry"] = upstreamRegistry
+		}
+		if rule.CredentialARN != "" {
+			properties["CredentialArn"] = rule.CredentialARN
+		}
+		c.newResource(pullThroughCacheRuleResourceName(rule), &awsCloudFormationResource{
+			Type:       "AWS::ECR::PullThroughCacheRule",
+			Properties: properties,
+		})
+	}
+}
+
+func pullThroughCacheRuleResourceName(rule api.PullThroughCacheRule) string {
+	var name strings.Builder
+	for _, part := range strings.FieldsFunc(rule.RepositoryPrefix(), func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	}) {
+		name.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return fmt.Sprintf("PullThroughCacheRule%s", name.String())
+}
@@ -1,8 +1,11 @@
 package builder
 
 import (
+	"fmt"
+
 	gfnt "github.com/weaveworks/goformation/v4/cloudformation/types"
 
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	cft "github.com/weaveworks/eksctl/pkg/cfn/template"
 )
 
@@ -608,3 +611,55 @@ func efsCSIControllerStatements() []cft.MapOfInterfaces {
 		},
 	}
 }
+
+func pullThroughCacheStatements(pullThroughCache *api.PullThroughCache) []cft.MapOfInterfaces {
+	var repositories []*gfnt.Value
+	for _, rule := range pullThroughCache.Rules {
+		repositories = append(repositories, addARNPartitionPrefix(fmt.Sprintf("ecr:${%s}:${%s}:repository/%s/*", cft.Region, cft.AccountID, rule.RepositoryPrefix())))
+	}
+	return []cft.MapOfInterfaces{
+		{
+			"Effect":   effectAllow,
+			"Resource": repositories,
+			"Action": []string{
+				"ecr:CreateRepository",
+				"ecr:BatchImportUpstreamImage",
+			},
+		},
+	}
+}
+
+func fargateLoggingStatements(logging *api.FargateLogging) []cft.MapOfInterfaces {
+	switch logging.Destination {
+	case api.FargateLoggingFirehose:
+		return []cft.MapOfInterfaces{
+			{
+				"Effect":   effectAllow,
+				"Resource": addARNPartitionPrefix(fmt.Sprintf("firehose:${%s}:${%s}:deliverystream/%s", cft.Region, cft.AccountID, logging.DeliveryStream)),
+				"Action":   []string{"firehose:PutRecordBatch"},
+			},
+		}
+	case api.FargateLoggingOpenSearch:
+		return []cft.MapOfInterfaces{
+			{
+				"Effect":   effectAllow,
+				"Resource": resourceAll,
+				"Action":   []string{"es:ESHttp*"},
+			},
+		}
+	default:
+		return []cft.MapOfInterfaces{
+			{
+				"Effect":   effectAllow,
+				"Resource": resourceAll,
+				"Action": []string{
+					"logs:CreateLogGroup",
+					"logs:CreateLogStream",
+					"logs:DescribeLogStreams",
+					"logs:PutLogEvents",
+					"logs:PutRetentionPolicy",
+				},
+			},
+		}
+	}
+}
@@ -58,6 +58,11 @@ func (e *VPCEndpointResourceSet) AddResources(ctx context.Context) error {
 		return err
 	}
 	endpointServices := append(api.RequiredEndpointServices(e.clusterConfig.IsControlPlaneOnOutposts()), additionalServices...)
+	// a bastion is reached through SSM Session Manager, whose endpoints are otherwise only created for Outposts
+	if adminAccess := e.clusterConfig.PrivateCluster.AdminAccess; adminAccess != nil && adminAccess.Type == api.PrivateClusterAdminAccessBastion &&
+		!e.clusterConfig.IsControlPlaneOnOutposts() {
+		endpointServices = append(endpointServices, api.SSMEndpointServices()...)
+	}
 	endpointServiceDetails, err := e.buildVPCEndpointServices(ctx, endpointServices)
 	if err != nil {
 		return fmt.Errorf("error building endpoint service details: %w", err)
@@ -10,6 +10,8 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/pkg/errors"
+	gfncfn "github.com/weaveworks/goformation/v4/cloudformation/cloudformation"
+	gfnec2 "github.com/weaveworks/goformation/v4/cloudformation/ec2"
 	gfnt "github.com/weaveworks/goformation/v4/cloudformation/types"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
@@ -103,6 +105,11 @@ func (v *ExistingVPCResourceSet) checkIPv6CidrBlockAssociated(describeVPCOutput
 }
 
 func (v *ExistingVPCResourceSet) importExistingResources(ctx context.Context) error {
+	if v.clusterConfig.VPC.CreateSubnets != nil {
+		v.addSubnets()
+		return nil
+	}
+
 	if subnets := v.clusterConfig.VPC.Subnets.Private; subnets != nil {
 		var (
 			subnetRoutes map[string]string
@@ -133,6 +140,78 @@ func (v *ExistingVPCResourceSet) importExistingResources(ctx context.Context) er
 	return nil
 }
 
+// addSubnets adds the subnets configured in vpc.createSubnets, along with a route table for the public subnets
+// routing to the given internet gateway and one for the private subnets routing to the given NAT gateway, if any
+func (v *ExistingVPCResourceSet) addSubnets() {
+	createSubnets := v.clusterConfig.VPC.CreateSubnets
+
+	if subnets := v.clusterConfig.VPC.Subnets.Public; len(subnets) > 0 {
+		refPublicRT := v.rs.newResource("PublicRouteTable", &gfnec2.RouteTable{
+			VpcId: v.vpcID,
+		})
+		v.rs.newResource("PublicSubnetRoute", &gfnec2.Route{
+			RouteTableId:         refPublicRT,
+			DestinationCidrBlock: gfnt.NewString(InternetCIDR),
+			GatewayId:            gfnt.NewString(createSubnets.InternetGatewayID),
+		})
+		v.subnetDetails.Public = v.addSubnetsWithRouteTable(refPublicRT, api.SubnetTopologyPublic, subnets)
+	}
+
+	if subnets := v.clusterConfig.VPC.Subnets.Private; len(subnets) > 0 {
+		refPrivateRT := v.rs.newResource("PrivateRouteTable", &gfnec2.RouteTable{
+			VpcId: v.vpcID,
+		})
+		if createSubnets.NATGatewayID != "" {
+			v.rs.newResource("NATPrivateSubnetRoute", &gfnec2.Route{
+				RouteTableId:         refPrivateRT,
+				DestinationCidrBlock: gfnt.NewString(InternetCIDR),
+				NatGatewayId:         gfnt.NewString(createSubnets.NATGatewayID),
+			})
+		}
+		v.subnetDetails.Private = v.addSubnetsWithRouteTable(refPrivateRT, api.SubnetTopologyPrivate, subnets)
+	}
+}
+
+func (v *ExistingVPCResourceSet) addSubnetsWithRouteTable(refRT *gfnt.Value, topology api.SubnetTopology, subnets map[string]api.AZSubnetSpec) []SubnetResource {
+	var subnetResources []SubnetResource
+	for name, s := range subnets {
+		subnet := &gfnec2.Subnet{
+			AvailabilityZone: gfnt.NewString(s.AZ),
+			CidrBlock:        gfnt.NewString(s.CIDR.String()),
+			VpcId:            v.vpcID,
+		}
+		maybeSetHostnameType(v.clusterConfig.VPC, subnet)
+
+		switch topology {
+		case api.SubnetTopologyPrivate:
+			subnet.Tags = []gfncfn.Tag{{
+				Key:   gfnt.NewString("kubernetes.io/role/internal-elb"),
+				Value: gfnt.NewString("1"),
+			}}
+		case api.SubnetTopologyPublic:
+			subnet.Tags = []gfncfn.Tag{{
+				Key:   gfnt.NewString("kubernetes.io/role/elb"),
+				Value: gfnt.NewString("1"),
+			}}
+			subnet.MapPublicIpOnLaunch = gfnt.True()
+		}
+
+		subnetAlias := string(topology) + makeAZResourceName(name)
+		refSubnet := v.rs.newResource("Subnet"+subnetAlias, subnet)
+		v.rs.newResource("RouteTableAssociation"+subnetAlias, &gfnec2.SubnetRouteTableAssociation{
+			SubnetId:     refSubnet,
+			RouteTableId: refRT,
+		})
+
+		subnetResources = append(subnetResources, SubnetResource{
+			AvailabilityZone: s.AZ,
+			RouteTable:       refRT,
+			Subnet:           refSubnet,
+		})
+	}
+	return subnetResources
+}
+
 func makeSubnetResources(subnets map[string]api.AZSubnetSpec, subnetRoutes map[string]string) ([]SubnetResource, error) {
 	var subnetResources []SubnetResource
 	for _, network := range subnets {
@@ -19,6 +19,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/cfn/builder/fakes"
 	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
 	"github.com/weaveworks/eksctl/pkg/eks/mocksv2"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
 )
 
 var _ = Describe("Existing VPC", func() {
@@ -206,6 +207,68 @@ var _ = Describe("Existing VPC", func() {
 			})
 		})
 
+		Context("when vpc.createSubnets is set", func() {
+			BeforeEach(func() {
+				cfg.VPC.CreateSubnets = &api.VPCSubnetCreation{
+					PublicCIDRs:       []string{"192.168.0.0/20", "192.168.16.0/20"},
+					PrivateCIDRs:      []string{"192.168.32.0/20", "192.168.48.0/20"},
+					InternetGatewayID: "igw-1",
+					NATGatewayID:      "nat-1",
+				}
+				cfg.VPC.Subnets = &api.ClusterSubnets{
+					Public: api.AZSubnetMappingFromMap(map[string]api.AZSubnetSpec{
+						azA: {AZ: azA, CIDR: ipnet.MustParseCIDR("192.168.0.0/20")},
+						azB: {AZ: azB, CIDR: ipnet.MustParseCIDR("192.168.16.0/20")},
+					}),
+					Private: api.AZSubnetMappingFromMap(map[string]api.AZSubnetSpec{
+						azA: {AZ: azA, CIDR: ipnet.MustParseCIDR("192.168.32.0/20")},
+						azB: {AZ: azB, CIDR: ipnet.MustParseCIDR("192.168.48.0/20")},
+					}),
+				}
+			})
+
+			It("adds the subnets and their route tables to the stack", func() {
+				Expect(addErr).NotTo(HaveOccurred())
+				Expect(vpcID).To(Equal(gfnt.NewString("custom-vpc")))
+
+				By("routing the public subnets through the internet gateway")
+				Expect(vpcTemplate.Resources).To(HaveKey("PublicRouteTable"))
+				Expect(vpcTemplate.Resources).To(HaveKey("PublicSubnetRoute"))
+				Expect(vpcTemplate.Resources["PublicSubnetRoute"].Properties.GatewayID).To(Equal("igw-1"))
+
+				By("routing the private subnets through the NAT gateway")
+				Expect(vpcTemplate.Resources).To(HaveKey("PrivateRouteTable"))
+				Expect(vpcTemplate.Resources).To(HaveKey("NATPrivateSubnetRoute"))
+				Expect(vpcTemplate.Resources["NATPrivateSubnetRoute"].Properties.NatGatewayID).To(Equal("nat-1"))
+
+				By("creating a subnet per availability zone")
+				for _, az := range []string{azAFormatted, azBFormatted} {
+					Expect(vpcTemplate.Resources).To(HaveKey("SubnetPublic" + az))
+					Expect(vpcTemplate.Resources).To(HaveKey("RouteTableAssociationPublic" + az))
+					Expect(vpcTemplate.Resources).To(HaveKey("SubnetPrivate" + az))
+					Expect(vpcTemplate.Resources).To(HaveKey("RouteTableAssociationPrivate" + az))
+				}
+				Expect(vpcTemplate.Resources["SubnetPublic"+azAFormatted].Properties.CidrBlock).To(Equal("192.168.0.0/20"))
+				Expect(vpcTemplate.Resources["SubnetPublic"+azAFormatted].Properties.MapPublicIPOnLaunch).To(BeTrue())
+				Expect(vpcTemplate.Resources["SubnetPrivate"+azBFormatted].Properties.CidrBlock).To(Equal("192.168.48.0/20"))
+
+				Expect(subnetDetails.Public).To(HaveLen(2))
+				Expect(subnetDetails.Private).To(HaveLen(2))
+			})
+
+			When("no NAT gateway is given", func() {
+				BeforeEach(func() {
+					cfg.VPC.CreateSubnets.NATGatewayID = ""
+				})
+
+				It("does not add a route to the private route table", func() {
+					Expect(addErr).NotTo(HaveOccurred())
+					Expect(vpcTemplate.Resources).To(HaveKey("PrivateRouteTable"))
+					Expect(vpcTemplate.Resources).NotTo(HaveKey("NATPrivateSubnetRoute"))
+				})
+			})
+		})
+
 		Context("PrivateCluster is enabled", func() {
 			var rtOutput *ec2.DescribeRouteTablesOutput
 
@@ -31,6 +31,8 @@ type IPv4VPCResourceSet struct {
 	vpcID             *gfnt.Value
 	subnetDetails     *SubnetDetails
 	extendForOutposts bool
+	// wavelengthZones holds the zones in LocalZones that are Wavelength Zones
+	wavelengthZones map[string]struct{}
 }
 
 type SubnetResource struct {
@@ -63,7 +65,7 @@ func NewIPv4VPCResourceSet(rs *resourceSet, clusterConfig *api.ClusterConfig, ec
 }
 
 func (v *IPv4VPCResourceSet) CreateTemplate(ctx context.Context) (*gfnt.Value, *SubnetDetails, error) {
-	if err := v.addResources(); err != nil {
+	if err := v.addResources(ctx); err != nil {
 		return nil, nil, err
 	}
 	v.addOutputs(ctx)
@@ -71,7 +73,7 @@ func (v *IPv4VPCResourceSet) CreateTemplate(ctx context.Context) (*gfnt.Value, *
 }
 
 // AddResources adds all required resources
-func (v *IPv4VPCResourceSet) addResources() error {
+func (v *IPv4VPCResourceSet) addResources(ctx context.Context) error {
 	vpc := v.clusterConfig.VPC
 
 	v.vpcID = v.rs.newResource(cfnVPCResource, &gfnec2.VPC{
@@ -86,6 +88,10 @@ func (v *IPv4VPCResourceSet) addResources() error {
 		return nil
 	}
 
+	if err := v.discoverWavelengthZones(ctx); err != nil {
+		return err
+	}
+
 	refIG := v.rs.newResource("InternetGateway", &gfnec2.InternetGateway{})
 	vpcGA := "VPCGatewayAttachment"
 
@@ -127,9 +133,9 @@ func (v *IPv4VPCResourceSet) addResources() error {
 	v.subnetDetails.Private = v.addSubnets(nil, api.SubnetTopologyPrivate, vpc.Subnets.Private)
 	if vpc.LocalZoneSubnets != nil {
 		if len(vpc.LocalZoneSubnets.Public) > 0 {
-			v.subnetDetails.PublicLocalZone = v.addSubnets(refPublicRT, api.SubnetTopologyPublic, vpc.LocalZoneSubnets.Public)
+			v.subnetDetails.PublicLocalZone = v.addPublicLocalZoneSubnets(refPublicRT, vpc.LocalZoneSubnets.Public)
 		}
-		if len(vpc.LocalZoneSubnets.Public) > 0 {
+		if len(vpc.LocalZoneSubnets.Private) > 0 {
 			v.subnetDetails.PrivateLocalZone = v.addSubnets(nil, api.SubnetTopologyPrivate, vpc.LocalZoneSubnets.Private)
 		}
 	}
@@ -137,6 +143,64 @@ func (v *IPv4VPCResourceSet) addResources() error {
 	return nil
 }
 
+// discoverWavelengthZones finds which of the zones that subnets are created in for LocalZones are Wavelength Zones
+func (v *IPv4VPCResourceSet) discoverWavelengthZones(ctx context.Context) error {
+	if len(v.clusterConfig.LocalZones) == 0 {
+		return nil
+	}
+	zoneTypes, err := vpc.DiscoverZoneTypes(ctx, v.ec2API, v.clusterConfig.Metadata.Region)
+	if err != nil {
+		return err
+	}
+	v.wavelengthZones = map[string]struct{}{}
+	for _, zone := range v.clusterConfig.LocalZones {
+		if zoneTypes[zone] == vpc.ZoneTypeWavelengthZone {
+			v.wavelengthZones[zone] = struct{}{}
+		}
+	}
+	return nil
+}
+
+func (v *IPv4VPCResourceSet) isWavelengthZone(zone string) bool {
+	_, ok := v.wavelengthZones[zone]
+	return ok
+}
+
+// addPublicLocalZoneSubnets adds the public subnets for LocalZones. Internet gateways cannot be used
+// from Wavelength Zones, so public subnets in them route Internet traffic through a carrier gateway instead.
+func (v *IPv4VPCResourceSet) addPublicLocalZoneSubnets(refPublicRT *gfnt.Value, subnets map[string]api.AZSubnetSpec) []SubnetResource {
+	localZoneSubnets := map[string]api.AZSubnetSpec{}
+	wavelengthZoneSubnets := map[string]api.AZSubnetSpec{}
+	for name, s := range subnets {
+		if v.isWavelengthZone(s.AZ) {
+			wavelengthZoneSubnets[name] = s
+		} else {
+			localZoneSubnets[name] = s
+		}
+	}
+
+	subnetResources := v.addSubnets(refPublicRT, api.SubnetTopologyPublic, localZoneSubnets)
+	if len(wavelengthZoneSubnets) == 0 {
+		return subnetResources
+	}
+
+	refCarrierGateway := v.rs.newResource("CarrierGateway", &awsCloudFormationResource{
+		Type: "AWS::EC2::CarrierGateway",
+		Properties: map[string]interface{}{
+			"VpcId": v.vpcID,
+		},
+	})
+	refCarrierRT := v.rs.newResource("CarrierRouteTable", &gfnec2.RouteTable{
+		VpcId: v.vpcID,
+	})
+	v.rs.newResource("CarrierSubnetRoute", &gfnec2.Route{
+		RouteTableId:         refCarrierRT,
+		DestinationCidrBlock: gfnt.NewString(InternetCIDR),
+		CarrierGatewayId:     refCarrierGateway,
+	})
+	return append(subnetResources, v.addSubnets(refCarrierRT, api.SubnetTopologyPublic, wavelengthZoneSubnets)...)
+}
+
 func (s *SubnetDetails) ControlPlaneSubnetRefs() []*gfnt.Value {
 	privateSubnetRefs := s.PrivateSubnetRefs()
 	if s.controlPlaneOnOutposts && len(privateSubnetRefs) > 0 {
@@ -286,7 +350,10 @@ func (v *IPv4VPCResourceSet) addSubnets(refRT *gfnt.Value, topology api.SubnetTo
 				Key:   gfnt.NewString("kubernetes.io/role/elb"),
 				Value: gfnt.NewString("1"),
 			}}
-			subnet.MapPublicIpOnLaunch = gfnt.True()
+			// instances in Wavelength Zones are assigned carrier IPs by their launch template instead
+			if !v.isWavelengthZone(az) {
+				subnet.MapPublicIpOnLaunch = gfnt.True()
+			}
 		}
 
 		subnetAlias := string(topology) + nameAlias
@@ -397,11 +464,14 @@ func (v *IPv4VPCResourceSet) singleNAT() {
 			VpcId: v.vpcID,
 		})
 
-		v.rs.newResource("NATPrivateSubnetRoute"+subnetAZResourceName, &gfnec2.Route{
-			RouteTableId:         refRT,
-			DestinationCidrBlock: gfnt.NewString(InternetCIDR),
-			NatGatewayId:         refNG,
-		})
+		// NAT gateways are not a supported route target in Wavelength Zones
+		if !v.isWavelengthZone(subnetAlias) {
+			v.rs.newResource("NATPrivateSubnetRoute"+subnetAZResourceName, &gfnec2.Route{
+				RouteTableId:         refRT,
+				DestinationCidrBlock: gfnt.NewString(InternetCIDR),
+				NatGatewayId:         refNG,
+			})
+		}
 		v.rs.newResource("RouteTableAssociationPrivate"+subnetAZResourceName, &gfnec2.SubnetRouteTableAssociation{
 			SubnetId:     gfnt.MakeRef("SubnetPrivate" + subnetAZResourceName),
 			RouteTableId: refRT,
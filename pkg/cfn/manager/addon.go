@@ -6,3 +6,8 @@ import "fmt"
 func MakeAddonStackName(clusterName, addonName string) string {
 	return fmt.Sprintf("eksctl-%s-addon-%s", clusterName, addonName)
 }
+
+// MakeAddonRevisionStackName creates the name of the stack recording the previous revision of addonName.
+func MakeAddonRevisionStackName(clusterName, addonName string) string {
+	return fmt.Sprintf("eksctl-%s-addon-%s-revision", clusterName, addonName)
+}
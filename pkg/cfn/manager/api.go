@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/awsapi"
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
 	"github.com/weaveworks/eksctl/pkg/cfn/waiter"
+	"github.com/weaveworks/eksctl/pkg/utils/progress"
 	"github.com/weaveworks/eksctl/pkg/version"
 )
 
@@ -117,10 +119,27 @@ func NewStackCollection(provider api.ClusterProvider, spec *api.ClusterConfig) S
 
 // DoCreateStackRequest requests the creation of a CloudFormation stack
 func (c *StackCollection) DoCreateStackRequest(ctx context.Context, i *Stack, templateData TemplateData, tags, parameters map[string]string, withIAM bool, withNamedIAM bool) error {
+	return c.doCreateStackRequest(ctx, i, templateData, tags, parameters, withIAM, withNamedIAM, false)
+}
+
+func (c *StackCollection) doCreateStackRequest(ctx context.Context, i *Stack, templateData TemplateData, tags, parameters map[string]string, withIAM, withNamedIAM, terminationProtection bool) error {
 	input := &cloudformation.CreateStackInput{
 		StackName:       i.StackName,
 		DisableRollback: aws.Bool(c.disableRollback),
 	}
+	if terminationProtection {
+		input.EnableTerminationProtection = aws.Bool(true)
+	}
+	stackPolicy, err := c.stackPolicyBody()
+	if err != nil {
+		return err
+	}
+	if stackPolicy != "" {
+		input.StackPolicyBody = aws.String(stackPolicy)
+	}
+	if c.spec != nil {
+		input.NotificationARNs = c.spec.StackNotificationARNs()
+	}
 	input.Tags = append(input.Tags, c.sharedTags...)
 	for k, v := range tags {
 		input.Tags = append(input.Tags, newTag(k, v))
@@ -163,12 +182,35 @@ func (c *StackCollection) DoCreateStackRequest(ctx context.Context, i *Stack, te
 	return nil
 }
 
+func (c *StackCollection) stackPolicyBody() (string, error) {
+	if c.spec == nil {
+		return "", nil
+	}
+	return c.spec.StackPolicyBody()
+}
+
+// setStackPolicy sets the stack policy of the config file on an existing stack, so that it
+// applies to the changes about to be executed
+func (c *StackCollection) setStackPolicy(ctx context.Context, stackName string) error {
+	stackPolicy, err := c.stackPolicyBody()
+	if err != nil || stackPolicy == "" {
+		return err
+	}
+	if _, err := c.cloudformationAPI.SetStackPolicy(ctx, &cloudformation.SetStackPolicyInput{
+		StackName:       aws.String(stackName),
+		StackPolicyBody: aws.String(stackPolicy),
+	}); err != nil {
+		return errors.Wrapf(err, "setting the stack policy of CloudFormation stack %q", stackName)
+	}
+	return nil
+}
+
 // CreateStack with given name, stack builder instance and parameters;
 // any errors will be written to errs channel, when nil is written,
 // assume completion, do not expect more than one error value on the
 // channel, it's closed immediately after it is written to.
 func (c *StackCollection) CreateStack(ctx context.Context, stackName string, resourceSet builder.ResourceSetReader, tags, parameters map[string]string, errs chan error) error {
-	stack, err := c.createStackRequest(ctx, stackName, resourceSet, tags, parameters)
+	stack, err := c.createStackRequest(ctx, stackName, resourceSet, tags, parameters, false)
 	if err != nil {
 		return err
 	}
@@ -182,7 +224,7 @@ func (c *StackCollection) createClusterStack(ctx context.Context, stackName stri
 	clusterTags := map[string]string{
 		api.ClusterOIDCEnabledTag: strconv.FormatBool(api.IsEnabled(c.spec.IAM.WithOIDC)),
 	}
-	stack, err := c.createStackRequest(ctx, stackName, resourceSet, clusterTags, nil)
+	stack, err := c.createStackRequest(ctx, stackName, resourceSet, clusterTags, nil, c.spec.IsTerminationProtectionEnabled())
 	if err != nil {
 		return err
 	}
@@ -216,6 +258,10 @@ func (c *StackCollection) createClusterStack(ctx context.Context, stackName stri
 			errCh <- errors.Wrapf(err, "getting stack %q outputs", *stack.StackName)
 			return
 		}
+		progress.RecordResource("CloudFormation stack", *stack.StackName, aws.ToString(stack.StackId))
+		if c.spec.Status != nil && c.spec.Status.ARN != "" {
+			progress.RecordResource("EKS cluster", c.spec.Metadata.Name, c.spec.Status.ARN)
+		}
 
 		errCh <- nil
 	}()
@@ -223,14 +269,14 @@ func (c *StackCollection) createClusterStack(ctx context.Context, stackName stri
 	return nil
 }
 
-func (c *StackCollection) createStackRequest(ctx context.Context, stackName string, resourceSet builder.ResourceSetReader, tags, parameters map[string]string) (*Stack, error) {
+func (c *StackCollection) createStackRequest(ctx context.Context, stackName string, resourceSet builder.ResourceSetReader, tags, parameters map[string]string, terminationProtection bool) (*Stack, error) {
 	stack := &Stack{StackName: &stackName}
 	templateBody, err := resourceSet.RenderJSON()
 	if err != nil {
 		return nil, errors.Wrapf(err, "rendering template for %q stack", *stack.StackName)
 	}
 
-	if err := c.DoCreateStackRequest(ctx, stack, TemplateBody(templateBody), tags, parameters, resourceSet.WithIAM(), resourceSet.WithNamedIAM()); err != nil {
+	if err := c.doCreateStackRequest(ctx, stack, TemplateBody(templateBody), tags, parameters, resourceSet.WithIAM(), resourceSet.WithNamedIAM(), terminationProtection); err != nil {
 		return nil, err
 	}
 
@@ -367,6 +413,9 @@ func (c *StackCollection) updateStack(ctx context.Context, options UpdateStackOp
 		return err
 	}
 	logger.Debug("changes = %#v", changeSet.Changes)
+	if err := c.setStackPolicy(ctx, options.StackName); err != nil {
+		return err
+	}
 	if err := c.doExecuteChangeSet(ctx, options.StackName, options.ChangeSetName); err != nil {
 		logger.Warning("error executing Cloudformation changeSet %s in stack %s. Check the Cloudformation console for further details", options.ChangeSetName, options.StackName)
 		return err
@@ -631,6 +680,19 @@ func (c *StackCollection) DeleteStackSync(ctx context.Context, s *Stack) error {
 	return c.doWaitUntilStackIsDeleted(ctx, s)
 }
 
+// deleteStackIfExists deletes the stack named stackName, if it exists, and waits until it is deleted;
+// it reverts the tasks that create stacks
+func (c *StackCollection) deleteStackIfExists(ctx context.Context, stackName string) error {
+	stack, err := c.DescribeStack(ctx, &Stack{StackName: aws.String(stackName)})
+	if err != nil {
+		if IsStackDoesNotExistError(err) {
+			return nil
+		}
+		return err
+	}
+	return c.DeleteStackSync(ctx, stack)
+}
+
 func fmtStacksRegexForCluster(name string) string {
 	return fmt.Sprintf(ourStackRegexFmt, name)
 }
@@ -647,6 +709,25 @@ func (c *StackCollection) ListStacks(ctx context.Context) ([]*Stack, error) {
 	return stacks, nil
 }
 
+// listStacksNamed returns the existing stacks of the cluster among stackNames
+func (c *StackCollection) listStacksNamed(ctx context.Context, stackNames ...string) ([]*Stack, error) {
+	stacks, err := c.ListStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var named []*Stack
+	for _, s := range stacks {
+		if s.StackStatus == types.StackStatusDeleteComplete {
+			continue
+		}
+		if slices.Contains(stackNames, *s.StackName) {
+			named = append(named, s)
+		}
+	}
+	return named, nil
+}
+
 func (c *StackCollection) GetClusterStackIfExists(ctx context.Context) (*Stack, error) {
 	clusterStackNames, err := c.ListClusterStackNames(ctx)
 	if err != nil {
@@ -728,6 +809,9 @@ func (c *StackCollection) doCreateChangeSetRequest(ctx context.Context, stackNam
 		Tags:          append(tags, c.sharedTags...),
 		ChangeSetType: types.ChangeSetTypeUpdate,
 	}
+	if c.spec != nil {
+		input.NotificationARNs = c.spec.StackNotificationARNs()
+	}
 
 	switch data := templateData.(type) {
 	case TemplateBody:
@@ -259,6 +259,78 @@ var _ = Describe("StackCollection", func() {
 		// Metadata tag
 		Expect(createChangeSetInput.Tags).To(ContainElement(types.Tag{Key: aws.String("meta"), Value: aws.String("data")}))
 	})
+
+	It("publishes the events of the changeset to the notification ARNs", func() {
+		stackName := "eksctl-stack"
+		topicARN := "arn:aws:sns:us-west-2:111122223333:cfn-events"
+		p := mockprovider.NewMockProvider()
+		p.MockCloudFormation().On("CreateChangeSet", mock.Anything, mock.Anything).Return(nil, nil)
+		p.MockCloudFormation().On("DescribeChangeSet", mock.Anything, mock.Anything, mock.Anything).Return(&cfn.DescribeChangeSetOutput{
+			StackName:    &stackName,
+			StatusReason: aws.String("The submitted information didn't contain changes"),
+		}, nil)
+
+		spec := api.NewClusterConfig()
+		spec.CloudFormation = &api.ClusterCloudFormation{NotificationARNs: []string{topicARN}}
+		sm := NewStackCollection(p, spec)
+		Expect(sm.UpdateStack(context.Background(), UpdateStackOptions{
+			Stack:         &Stack{StackName: &stackName},
+			ChangeSetName: "eksctl-changeset",
+			Description:   "description",
+			TemplateData:  TemplateBody(""),
+		})).To(Succeed())
+
+		createChangeSetInput := p.MockCloudFormation().Calls[0].Arguments.Get(1).(*cfn.CreateChangeSetInput)
+		Expect(createChangeSetInput.NotificationARNs).To(Equal([]string{topicARN}))
+	})
+
+	It("sets the stack policy before executing the changeset", func() {
+		stackName := "eksctl-stack"
+		changeSetName := "eksctl-changeset"
+		p := mockprovider.NewMockProvider()
+		p.MockCloudFormation().On("DescribeStacks", mock.Anything, mock.Anything, mock.Anything).Return(&cfn.DescribeStacksOutput{Stacks: []types.Stack{{
+			StackName:   &stackName,
+			StackStatus: types.StackStatusCreateComplete,
+		}}}, nil)
+		p.MockCloudFormation().On("CreateChangeSet", mock.Anything, mock.Anything).Return(nil, nil)
+		p.MockCloudFormation().On("DescribeChangeSet", mock.Anything, mock.Anything, mock.Anything).Return(&cfn.DescribeChangeSetOutput{
+			StackName:     &stackName,
+			ChangeSetName: &changeSetName,
+			Status:        types.ChangeSetStatusCreateComplete,
+		}, nil)
+		p.MockCloudFormation().On("SetStackPolicy", mock.Anything, &cfn.SetStackPolicyInput{
+			StackName:       &stackName,
+			StackPolicyBody: aws.String(`{"Statement":[{"Action":"Update:Replace","Effect":"Deny","Principal":"*","Resource":"LogicalResourceId/VPC"}]}`),
+		}).Return(&cfn.SetStackPolicyOutput{}, nil)
+		p.MockCloudFormation().On("ExecuteChangeSet", mock.Anything, mock.Anything).Return(nil, nil)
+
+		spec := api.NewClusterConfig()
+		spec.CloudFormation = &api.ClusterCloudFormation{
+			StackPolicy: api.InlineDocument{
+				"Statement": []interface{}{
+					map[string]interface{}{
+						"Effect":    "Deny",
+						"Action":    "Update:Replace",
+						"Principal": "*",
+						"Resource":  "LogicalResourceId/VPC",
+					},
+				},
+			},
+		}
+		sm := NewStackCollection(p, spec)
+		Expect(sm.UpdateStack(context.Background(), UpdateStackOptions{
+			StackName:     stackName,
+			ChangeSetName: changeSetName,
+			Description:   "description",
+			TemplateData:  TemplateBody(""),
+		})).To(Succeed())
+
+		var calls []string
+		for _, call := range p.MockCloudFormation().Calls {
+			calls = append(calls, call.Method)
+		}
+		Expect(calls[len(calls)-2:]).To(Equal([]string{"SetStackPolicy", "ExecuteChangeSet"}))
+	})
 	When("wait is set to false", func() {
 		It("will skip the last wait sequence", func() {
 			clusterName := "cluster"
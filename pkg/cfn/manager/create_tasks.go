@@ -33,8 +33,8 @@ func (c *StackCollection) NewTasksToCreateCluster(ctx context.Context, nodeGroup
 		ctx:                  ctx,
 	})
 
-	if len(accessConfig.AccessEntries) > 0 {
-		taskTree.Append(accessEntryCreator.CreateTasks(ctx, accessConfig.AccessEntries))
+	if accessEntries := accessConfig.AccessEntriesToCreate(); len(accessEntries) > 0 {
+		taskTree.Append(accessEntryCreator.CreateTasks(ctx, accessEntries))
 	}
 
 	appendNodeGroupTasksTo := func(taskTree *tasks.TaskTree) {
@@ -58,6 +58,20 @@ func (c *StackCollection) NewTasksToDeleteClusterWithNodeGroups(
 		taskTree.Append(nodeGroupTasks)
 	}
 
+	// the managed scraper of the monitoring stack and the mount targets of the EFS stack use the subnets and
+	// security groups of the cluster, so their deletion is waited for regardless of wait
+	auxiliaryStacks, err := c.listStacksNamed(ctx, MakeMonitoringStackName(c.spec.Metadata.Name), MakeEFSStackName(c.spec.Metadata.Name))
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range auxiliaryStacks {
+		taskTree.Append(&taskWithStackSpec{
+			info:  fmt.Sprintf("delete stack %q", *s.StackName),
+			stack: s,
+			call:  c.DeleteStackBySpecSync,
+		})
+	}
+
 	if clusterOperable {
 		serviceAccountAndOIDCTasks, err := c.NewTasksToDeleteOIDCProviderWithIAMServiceAccounts(ctx, newOIDCManager, cluster, clientSetGetter, force)
 		if err != nil {
@@ -0,0 +1,8 @@
+package manager
+
+import "fmt"
+
+// MakeEFSStackName returns the name of the stack holding the EFS filesystem of a cluster and its mount targets
+func MakeEFSStackName(clusterName string) string {
+	return fmt.Sprintf("eksctl-%s-efs", clusterName)
+}
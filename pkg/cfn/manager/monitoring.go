@@ -0,0 +1,8 @@
+package manager
+
+import "fmt"
+
+// MakeMonitoringStackName returns the name of the stack holding the AMP workspace and the collector of a cluster
+func MakeMonitoringStackName(clusterName string) string {
+	return fmt.Sprintf("eksctl-%s-monitoring", clusterName)
+}
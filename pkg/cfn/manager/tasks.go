@@ -26,6 +26,11 @@ func (t *createClusterTask) Do(errorCh chan error) error {
 	return t.stackCollection.createClusterTask(t.ctx, errorCh, t.supportsManagedNodes)
 }
 
+// Revert implements tasks.Reverter
+func (t *createClusterTask) Revert(ctx context.Context) error {
+	return t.stackCollection.deleteStackIfExists(ctx, t.stackCollection.MakeClusterStackName())
+}
+
 type managedNodeGroupTask struct {
 	info              string
 	nodeGroup         *api.ManagedNodeGroup
@@ -41,6 +46,11 @@ func (t *managedNodeGroupTask) Do(errorCh chan error) error {
 	return t.stackCollection.createManagedNodeGroupTask(t.ctx, errorCh, t.nodeGroup, t.forceAddCNIPolicy, t.vpcImporter)
 }
 
+// Revert implements tasks.Reverter
+func (t *managedNodeGroupTask) Revert(ctx context.Context) error {
+	return t.stackCollection.deleteStackIfExists(ctx, t.stackCollection.makeNodeGroupStackName(t.nodeGroup.Name))
+}
+
 type managedNodeGroupTagsToASGPropagationTask struct {
 	info            string
 	nodeGroup       *api.ManagedNodeGroup
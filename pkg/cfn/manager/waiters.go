@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/utils/exitcode"
+	"github.com/weaveworks/eksctl/pkg/utils/progress"
 )
 
 // TroubleshootStackFailureCause identifies the cause of the stack's failure and prints the stack events
@@ -92,7 +95,7 @@ func (c *StackCollection) waitUntilStackIsCreated(ctx context.Context, i *Stack,
 	defer close(errs)
 
 	if err := c.DoWaitUntilStackIsCreated(ctx, i); err != nil {
-		errs <- err
+		errs <- exitcode.WithResource(err, *i.StackName)
 		return
 	}
 	s, err := c.DescribeStack(ctx, i)
@@ -104,6 +107,7 @@ func (c *StackCollection) waitUntilStackIsCreated(ctx context.Context, i *Stack,
 		errs <- errors.Wrapf(err, "getting stack %q outputs", *i.StackName)
 		return
 	}
+	progress.RecordResource("CloudFormation stack", *i.StackName, aws.ToString(s.StackId))
 	errs <- nil
 }
 
@@ -126,7 +130,7 @@ func (c *StackCollection) waitUntilStackIsDeleted(ctx context.Context, i *Stack,
 	defer close(errs)
 
 	if err := c.doWaitUntilStackIsDeleted(ctx, i); err != nil {
-		errs <- err
+		errs <- exitcode.WithResource(err, *i.StackName)
 		return
 	}
 	errs <- nil
@@ -24,6 +24,9 @@ const (
 	ClusterSubnetsPublicExtended  = ClusterSubnetsPublic + "Extended"
 	ClusterFullyPrivate           = "ClusterFullyPrivate"
 
+	ClusterBastionInstanceID         = "BastionInstanceID"
+	ClusterInstanceConnectEndpointID = "InstanceConnectEndpointID"
+
 	ClusterSubnetsPublicLegacy = "Subnets"
 
 	ClusterCertificateAuthorityData = "CertificateAuthorityData"
@@ -32,6 +35,7 @@ const (
 	ClusterStackName                = "ClusterStackName"
 	ClusterSharedNodeSecurityGroup  = "SharedNodeSecurityGroup"
 	ClusterServiceRoleARN           = "ServiceRoleARN"
+	ClusterAutoModeNodeRoleARN      = "AutoModeNodeRoleARN"
 	ClusterFeatureNATMode           = "FeatureNATMode"
 
 	// outputs from nodegroup stack
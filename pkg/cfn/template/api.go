@@ -22,14 +22,16 @@ type Template struct {
 	AWSTemplateFormatVersion string
 
 	Description string
+	Metadata    map[string]interface{} `json:",omitempty"`
 	Resources   map[string]AnyResource `json:",omitempty"`
 	Outputs     map[string]Output      `json:",omitempty"`
 }
 
 // AnyResource represents a generic CloudFormation resource
 type AnyResource struct {
-	Type       string
-	Properties interface{}
+	Type           string
+	Properties     interface{}
+	DeletionPolicy string `json:",omitempty"`
 }
 
 func (r *AnyResource) ToIAMRole() (IAMRole, error) {
@@ -85,6 +87,14 @@ func (t *Template) NewResource(name string, resource Resource) *Value {
 	return MakeRef(name)
 }
 
+// RetainResource sets the deletion policy of a resource to Retain, so that the resource
+// is kept when the stack is deleted
+func (t *Template) RetainResource(name string) {
+	resource := t.Resources[name]
+	resource.DeletionPolicy = "Retain"
+	t.Resources[name] = resource
+}
+
 // RenderJSON will serialise the template to JSON
 func (t *Template) RenderJSON() ([]byte, error) {
 	return json.Marshal(t)
@@ -0,0 +1,56 @@
+package template
+
+// APSWorkspace represents a CloudFormation AWS::APS::Workspace resource
+type APSWorkspace struct {
+	Alias *Value `json:",omitempty"`
+
+	Tags []MapOfInterfaces `json:",omitempty"`
+}
+
+// Type will return the full type name for the resource
+func (r *APSWorkspace) Type() string {
+	return "AWS::APS::Workspace"
+}
+
+// Properties will return the properties of the resource
+func (r *APSWorkspace) Properties() interface{} {
+	return r
+}
+
+// APSScraper represents a CloudFormation AWS::APS::Scraper resource
+type APSScraper struct {
+	Alias *Value `json:",omitempty"`
+
+	ScrapeConfiguration MapOfInterfaces
+	Source              MapOfInterfaces
+	Destination         MapOfInterfaces
+
+	Tags []MapOfInterfaces `json:",omitempty"`
+}
+
+// Type will return the full type name for the resource
+func (r *APSScraper) Type() string {
+	return "AWS::APS::Scraper"
+}
+
+// Properties will return the properties of the resource
+func (r *APSScraper) Properties() interface{} {
+	return r
+}
+
+// EKSAccessEntry represents a CloudFormation AWS::EKS::AccessEntry resource
+type EKSAccessEntry struct {
+	ClusterName  *Value
+	PrincipalArn *Value
+	Username     *Value `json:",omitempty"`
+}
+
+// Type will return the full type name for the resource
+func (r *EKSAccessEntry) Type() string {
+	return "AWS::EKS::AccessEntry"
+}
+
+// Properties will return the properties of the resource
+func (r *EKSAccessEntry) Properties() interface{} {
+	return r
+}
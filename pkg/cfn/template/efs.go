@@ -0,0 +1,52 @@
+package template
+
+// EFSFileSystem represents a CloudFormation AWS::EFS::FileSystem resource
+type EFSFileSystem struct {
+	Encrypted      bool              `json:",omitempty"`
+	FileSystemTags []MapOfInterfaces `json:",omitempty"`
+}
+
+// Type will return the full type name for the resource
+func (r *EFSFileSystem) Type() string {
+	return "AWS::EFS::FileSystem"
+}
+
+// Properties will return the properties of the resource
+func (r *EFSFileSystem) Properties() interface{} {
+	return r
+}
+
+// EFSMountTarget represents a CloudFormation AWS::EFS::MountTarget resource
+type EFSMountTarget struct {
+	FileSystemId   *Value
+	SubnetId       *Value
+	SecurityGroups []*Value
+}
+
+// Type will return the full type name for the resource
+func (r *EFSMountTarget) Type() string {
+	return "AWS::EFS::MountTarget"
+}
+
+// Properties will return the properties of the resource
+func (r *EFSMountTarget) Properties() interface{} {
+	return r
+}
+
+// EC2SecurityGroup represents a CloudFormation AWS::EC2::SecurityGroup resource
+type EC2SecurityGroup struct {
+	GroupDescription     *Value
+	VpcId                *Value
+	SecurityGroupIngress []MapOfInterfaces `json:",omitempty"`
+	Tags                 []Tag             `json:",omitempty"`
+}
+
+// Type will return the full type name for the resource
+func (r *EC2SecurityGroup) Type() string {
+	return "AWS::EC2::SecurityGroup"
+}
+
+// Properties will return the properties of the resource
+func (r *EC2SecurityGroup) Properties() interface{} {
+	return r
+}
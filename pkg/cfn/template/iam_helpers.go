@@ -33,6 +33,21 @@ func MakeAssumeRolePolicyDocumentForServices(services ...*gfn.Value) MapOfInterf
 	})
 }
 
+// MakeAssumeRoleAndTagSessionPolicyDocumentForServices constructs a trust policy for given services
+// that also allows them to tag the session
+func MakeAssumeRoleAndTagSessionPolicyDocumentForServices(services ...*gfn.Value) MapOfInterfaces {
+	return MakePolicyDocument(MapOfInterfaces{
+		"Effect": "Allow",
+		"Action": []string{
+			"sts:AssumeRole",
+			"sts:TagSession",
+		},
+		"Principal": map[string][]*gfn.Value{
+			"Service": services,
+		},
+	})
+}
+
 // MakeAssumeRolePolicyDocumentForServices constructs a trust policy for given services with given conditions
 func MakeAssumeRolePolicyDocumentForServicesWithConditions(condition MapOfInterfaces, services ...*gfn.Value) MapOfInterfaces {
 	return MakePolicyDocument(MapOfInterfaces{
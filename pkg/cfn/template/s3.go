@@ -0,0 +1,20 @@
+package template
+
+// S3Bucket represents a CloudFormation AWS::S3::Bucket resource
+type S3Bucket struct {
+	BucketName *Value `json:",omitempty"`
+
+	BucketEncryption               MapOfInterfaces `json:",omitempty"`
+	PublicAccessBlockConfiguration MapOfInterfaces `json:",omitempty"`
+	VersioningConfiguration        MapOfInterfaces `json:",omitempty"`
+}
+
+// Type will return the full type name for the resource
+func (r *S3Bucket) Type() string {
+	return "AWS::S3::Bucket"
+}
+
+// Properties will return the properties of the resource
+func (r *S3Bucket) Properties() interface{} {
+	return r
+}
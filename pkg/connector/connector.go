@@ -58,12 +58,13 @@ type ManifestList struct {
 // RegisterCluster registers the specified external cluster with EKS and returns a list of Kubernetes resources
 // for EKS Connector.
 func (c *EKSConnector) RegisterCluster(ctx context.Context, cluster ExternalCluster) (*ManifestList, error) {
-	cluster.Provider = strings.ToUpper(cluster.Provider)
-	if err := validateProvider(cluster.Provider); err != nil {
+	connectorProvider, err := ParseProvider(cluster.Provider)
+	if err != nil {
 		return nil, err
 	}
+	cluster.Provider = string(connectorProvider)
 
-	_, err := c.Provider.EKS().DescribeCluster(ctx, &eks.DescribeClusterInput{
+	_, err = c.Provider.EKS().DescribeCluster(ctx, &eks.DescribeClusterInput{
 		Name: aws.String(cluster.Name),
 	})
 
@@ -133,14 +134,23 @@ func ValidProviders() []ekstypes.ConnectorConfigProvider {
 	return providerConfig.Values()
 }
 
-func validateProvider(provider string) error {
+// GenericProvider is an alias for the OTHER provider, for clusters that don't run on one of the named providers.
+const GenericProvider = "generic"
+
+// ParseProvider returns the EKS Connector provider for name. Names are case-insensitive and may use hyphens
+// in place of underscores, e.g. `eks-anywhere` for EKS_ANYWHERE.
+func ParseProvider(name string) (ekstypes.ConnectorConfigProvider, error) {
+	if strings.EqualFold(name, GenericProvider) {
+		return ekstypes.ConnectorConfigProviderOther, nil
+	}
+	normalized := strings.ReplaceAll(strings.ToUpper(name), "-", "_")
 	validProviders := ValidProviders()
 	for _, p := range validProviders {
-		if string(p) == provider {
-			return nil
+		if string(p) == normalized {
+			return p, nil
 		}
 	}
-	return errors.Errorf("invalid provider %q; must be one of %s", provider, validProviders)
+	return "", errors.Errorf("invalid provider %q; must be one of %s or %s", name, validProviders, GenericProvider)
 }
 
 func (c *EKSConnector) registerCluster(ctx context.Context, cluster ExternalCluster, connectorRoleARN string) (*eks.RegisterClusterOutput, error) {
@@ -156,6 +156,23 @@ var _ = Describe("EKS Connector", func() {
 		}),
 	)
 
+	DescribeTable("ParseProvider", func(name string, expectedProvider ekstypes.ConnectorConfigProvider, expectedErr string) {
+		provider, err := connector.ParseProvider(name)
+		if expectedErr != "" {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			return
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider).To(Equal(expectedProvider))
+	},
+		Entry("upper case", "GKE", ekstypes.ConnectorConfigProviderGke, ""),
+		Entry("lower case", "aks", ekstypes.ConnectorConfigProviderAks, ""),
+		Entry("hyphenated", "eks-anywhere", ekstypes.ConnectorConfigProviderEksAnywhere, ""),
+		Entry("underscored", "EKS_ANYWHERE", ekstypes.ConnectorConfigProviderEksAnywhere, ""),
+		Entry("generic", "generic", ekstypes.ConnectorConfigProviderOther, ""),
+		Entry("unknown", "k3s", ekstypes.ConnectorConfigProvider(""), `invalid provider "k3s"`),
+	)
+
 	Describe("Register cluster failure", func() {
 
 		It("should suggest creating SLR if it does not exist", func() {
@@ -107,18 +107,24 @@ func filenameFromURL(u string) (string, error) {
 	return filename, nil
 }
 
-// WriteResources writes the EKS Connector resources to the current directory.
-func WriteResources(fs afero.Fs, manifestList *ManifestList) error {
-	wd, err := os.Getwd()
-	if err != nil {
-		return errors.Wrap(err, "error getting current directory")
+// WriteResources writes the EKS Connector resources to dir, or to the current directory if dir is empty.
+func WriteResources(fs afero.Fs, dir string, manifestList *ManifestList) error {
+	outDir := dir
+	if outDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return errors.Wrap(err, "error getting current directory")
+		}
+		outDir = wd
+	} else if err := fs.MkdirAll(outDir, 0755); err != nil {
+		return errors.Wrapf(err, "error creating directory %s", outDir)
 	}
 
 	writeFile := func(filename string, data []byte) error {
-		if err := afero.WriteFile(fs, path.Join(wd, filename), data, 0664); err != nil {
+		if err := afero.WriteFile(fs, path.Join(outDir, filename), data, 0664); err != nil {
 			return err
 		}
-		logger.Info("wrote file %s to %s", filename, wd)
+		logger.Info("wrote file %s to %s", filename, outDir)
 		return nil
 	}
 
@@ -127,7 +133,7 @@ func WriteResources(fs afero.Fs, manifestList *ManifestList) error {
 		if err := writeFile(m.Filename, m.Data); err != nil {
 			return errors.Wrapf(err, "error writing file %s", m.Filename)
 		}
-		filenames = append(filenames, m.Filename)
+		filenames = append(filenames, path.Join(dir, m.Filename))
 	}
 
 	logger.Warning(`note: %q and %q give full EKS Console access to IAM identity %q, edit if required; read %s for more info`,
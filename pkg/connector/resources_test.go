@@ -29,7 +29,7 @@ var _ = Describe("Writing manifests", func() {
 					Filename: "eks-connector-console-dashboard-full-access-group.yaml",
 				},
 			}
-			err := connector.WriteResources(fs, manifestList)
+			err := connector.WriteResources(fs, "", manifestList)
 			Expect(err).NotTo(HaveOccurred())
 
 			wd, err := os.Getwd()
@@ -54,5 +54,31 @@ var _ = Describe("Writing manifests", func() {
 				Expect(file).To(Equal(data))
 			}
 		})
+
+		It("should write the manifests to the specified directory", func() {
+			fs := afero.NewMemMapFs()
+			manifestList := &connector.ManifestList{
+				ConnectorResources: connector.ManifestFile{
+					Data:     []byte("connector"),
+					Filename: "eks-connector.yaml",
+				},
+				ClusterRoleResources: connector.ManifestFile{
+					Data:     []byte("clusterrole"),
+					Filename: "eks-connector-clusterrole.yaml",
+				},
+				ConsoleAccessResources: connector.ManifestFile{
+					Data:     []byte("console-dashboard-full-access-group"),
+					Filename: "eks-connector-console-dashboard-full-access-group.yaml",
+				},
+			}
+			Expect(connector.WriteResources(fs, "manifests/web", manifestList)).To(Succeed())
+
+			files, err := afero.ReadDir(fs, "manifests/web")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(3))
+			data, err := afero.ReadFile(fs, "manifests/web/eks-connector.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal([]byte("connector")))
+		})
 	})
 })
@@ -0,0 +1,199 @@
+package credentials
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/kris-nova/logger"
+	"github.com/spf13/afero"
+)
+
+// cliCacheRefreshWindow is how long before they expire cached credentials are refreshed. It matches the
+// expiry window eksctl uses for its credentials, so that credentials read from the cache are not immediately
+// considered expired.
+const cliCacheRefreshWindow = 30 * time.Minute
+
+// cliCacheEntry is the format in which the AWS CLI caches temporary credentials.
+type cliCacheEntry struct {
+	ProviderType string             `json:"ProviderType,omitempty"`
+	Credentials  cliCacheCredential `json:"Credentials"`
+}
+
+type cliCacheCredential struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	SessionToken    string `json:"SessionToken"`
+	Expiration      string `json:"Expiration"`
+}
+
+// GetCLICacheDir returns the directory in which the AWS CLI caches temporary credentials.
+func GetCLICacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".aws", "cli", "cache"), nil
+}
+
+// CLICacheKey returns the key under which the AWS CLI caches the credentials of an SSO profile, and the key
+// under which eksctl caches the credentials of a credential_process profile, which the AWS CLI does not cache.
+// It returns false for profiles whose credentials are not cached.
+func CLICacheKey(sc config.SharedConfig) (string, bool) {
+	var args map[string]string
+	switch {
+	case sc.SSOAccountID != "" && sc.SSORoleName != "":
+		args = map[string]string{
+			"accountId": sc.SSOAccountID,
+			"roleName":  sc.SSORoleName,
+		}
+		if sc.SSOSessionName != "" {
+			args["sessionName"] = sc.SSOSessionName
+		} else {
+			args["startUrl"] = sc.SSOStartURL
+		}
+	case sc.CredentialProcess != "":
+		args = map[string]string{
+			"credentialProcess": sc.CredentialProcess,
+		}
+	default:
+		return "", false
+	}
+	// like the AWS CLI, hash the arguments serialised with sorted keys and no whitespace
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", false
+	}
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:]), true
+}
+
+// CLICache is a file-based credentials cache that stores credentials in the format and location used by the
+// AWS CLI, satisfying the aws.CredentialsProvider interface.
+// It is meant to be wrapped with aws.CredentialsCache.
+type CLICache struct {
+	provider     aws.CredentialsProvider
+	providerType string
+	cacheFile    string
+	fs           afero.Fs
+	clock        Clock
+
+	creds *aws.Credentials
+	mu    sync.Mutex
+}
+
+// NewCLICache returns a *CLICache that caches the credentials of provider in cacheDir under key.
+func NewCLICache(provider aws.CredentialsProvider, providerType, key string, fs afero.Fs, clock Clock, cacheDir string) *CLICache {
+	return &CLICache{
+		provider:     provider,
+		providerType: providerType,
+		cacheFile:    filepath.Join(cacheDir, key+".json"),
+		fs:           fs,
+		clock:        clock,
+	}
+}
+
+// Retrieve implements aws.CredentialsProvider.
+func (c *CLICache) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.creds == nil {
+		creds, err := c.read()
+		if err != nil {
+			logger.Warning("error reading credentials cache: %v", err)
+		}
+		c.creds = creds
+	}
+	if c.creds != nil && c.creds.Expires.After(c.clock.Now().Round(0).Add(cliCacheRefreshWindow)) {
+		return *c.creds, nil
+	}
+
+	creds, err := c.provider.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+	c.creds = &creds
+	if !creds.CanExpire {
+		return creds, nil
+	}
+	if err := c.write(creds); err != nil {
+		logger.Warning("failed to update credentials cache: %v", err)
+	}
+	return creds, nil
+}
+
+func (c *CLICache) read() (*aws.Credentials, error) {
+	data, err := afero.ReadFile(c.fs, c.cacheFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var entry cliCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("unable to parse file %s: %w", c.cacheFile, err)
+	}
+	expires, err := time.Parse(time.RFC3339, entry.Credentials.Expiration)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse expiration in file %s: %w", c.cacheFile, err)
+	}
+	return &aws.Credentials{
+		AccessKeyID:     entry.Credentials.AccessKeyID,
+		SecretAccessKey: entry.Credentials.SecretAccessKey,
+		SessionToken:    entry.Credentials.SessionToken,
+		Source:          c.providerType,
+		CanExpire:       true,
+		Expires:         expires,
+	}, nil
+}
+
+func (c *CLICache) write(creds aws.Credentials) error {
+	data, err := json.Marshal(cliCacheEntry{
+		ProviderType: c.providerType,
+		Credentials: cliCacheCredential{
+			AccessKeyID:     creds.AccessKeyID,
+			SecretAccessKey: creds.SecretAccessKey,
+			SessionToken:    creds.SessionToken,
+			Expiration:      creds.Expires.UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.fs.MkdirAll(filepath.Dir(c.cacheFile), 0700); err != nil {
+		return fmt.Errorf("failed to create folder: %w", err)
+	}
+	// write to a temporary file first so that concurrent readers never see a partial file
+	tmpFile := c.cacheFile + ".tmp"
+	if err := afero.WriteFile(c.fs, tmpFile, data, 0600); err != nil {
+		return err
+	}
+	return c.fs.Rename(tmpFile, c.cacheFile)
+}
+
+var sharedProviders = struct {
+	sync.Mutex
+	providers map[string]aws.CredentialsProvider
+}{providers: map[string]aws.CredentialsProvider{}}
+
+// ShareProvider returns the provider shared under key, sharing provider if there is none yet, so that the
+// AWS configs eksctl builds for the same profile reuse the same credentials instead of obtaining new ones.
+func ShareProvider(key string, provider aws.CredentialsProvider) aws.CredentialsProvider {
+	sharedProviders.Lock()
+	defer sharedProviders.Unlock()
+	if shared, ok := sharedProviders.providers[key]; ok {
+		return shared
+	}
+	sharedProviders.providers[key] = provider
+	return provider
+}
@@ -0,0 +1,151 @@
+package credentials_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"github.com/weaveworks/eksctl/pkg/credentials"
+	"github.com/weaveworks/eksctl/pkg/credentials/fakes"
+)
+
+var _ = Describe("CLICacheKey", func() {
+	DescribeTable("computes the AWS CLI cache key", func(sc config.SharedConfig, expectedKey string) {
+		key, ok := credentials.CLICacheKey(sc)
+		if expectedKey == "" {
+			Expect(ok).To(BeFalse())
+			return
+		}
+		Expect(ok).To(BeTrue())
+		Expect(key).To(Equal(expectedKey))
+	},
+		Entry("legacy SSO profile", config.SharedConfig{
+			SSOAccountID: "123456789012",
+			SSORoleName:  "Admin",
+			SSOStartURL:  "https://my-sso.awsapps.com/start",
+		}, "f848b46584e3d651a393065c9467194305a53133"),
+		Entry("SSO profile with an sso-session", config.SharedConfig{
+			SSOAccountID:   "123456789012",
+			SSORoleName:    "Admin",
+			SSOSessionName: "my-sso",
+			SSOStartURL:    "https://my-sso.awsapps.com/start",
+		}, "c30b99aca80d8f5b3178e353f05b2abcc9a4ccd3"),
+		Entry("credential_process profile", config.SharedConfig{
+			CredentialProcess: "/usr/local/bin/get-creds --profile dev",
+		}, "ae5e2963ce1b4989b27fdbd47687c274cd6ca141"),
+		Entry("static credentials profile", config.SharedConfig{
+			Credentials: aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"},
+		}, ""),
+	)
+})
+
+var _ = Describe("CLICache", func() {
+	const (
+		cacheDir = "/home/user/.aws/cli/cache"
+		cacheKey = "f848b46584e3d651a393065c9467194305a53133"
+	)
+	var (
+		fs        afero.Fs
+		clock     *fakes.FakeClock
+		provider  *fakes.FakeProvider
+		cache     *credentials.CLICache
+		now       time.Time
+		cacheFile = filepath.Join(cacheDir, cacheKey+".json")
+	)
+
+	writeCacheFile := func(expiration time.Time) {
+		Expect(afero.WriteFile(fs, cacheFile, []byte(`{
+  "ProviderType": "sso",
+  "Credentials": {
+    "AccessKeyId": "cached-id",
+    "SecretAccessKey": "cached-secret",
+    "SessionToken": "cached-token",
+    "Expiration": "`+expiration.Format(time.RFC3339)+`"
+  }
+}`), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+		clock = &fakes.FakeClock{}
+		clock.NowReturns(now)
+		provider = &fakes.FakeProvider{}
+		provider.RetrieveReturns(aws.Credentials{
+			AccessKeyID:     "new-id",
+			SecretAccessKey: "new-secret",
+			SessionToken:    "new-token",
+			CanExpire:       true,
+			Expires:         now.Add(time.Hour),
+		}, nil)
+		cache = credentials.NewCLICache(provider, "sso", cacheKey, fs, clock, cacheDir)
+	})
+
+	It("uses credentials cached by the AWS CLI", func() {
+		writeCacheFile(now.Add(time.Hour))
+
+		creds, err := cache.Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds.AccessKeyID).To(Equal("cached-id"))
+		Expect(creds.SessionToken).To(Equal("cached-token"))
+		Expect(creds.Expires).To(BeTemporally("==", now.Add(time.Hour)))
+		Expect(provider.RetrieveCallCount()).To(Equal(0))
+	})
+
+	It("refreshes credentials that are about to expire and caches them in the AWS CLI format", func() {
+		writeCacheFile(now.Add(10 * time.Minute))
+
+		creds, err := cache.Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds.AccessKeyID).To(Equal("new-id"))
+		Expect(provider.RetrieveCallCount()).To(Equal(1))
+
+		data, err := afero.ReadFile(fs, cacheFile)
+		Expect(err).NotTo(HaveOccurred())
+		var entry map[string]interface{}
+		Expect(json.Unmarshal(data, &entry)).To(Succeed())
+		Expect(entry).To(Equal(map[string]interface{}{
+			"ProviderType": "sso",
+			"Credentials": map[string]interface{}{
+				"AccessKeyId":     "new-id",
+				"SecretAccessKey": "new-secret",
+				"SessionToken":    "new-token",
+				"Expiration":      "2024-01-01T13:00:00Z",
+			},
+		}))
+		exists, err := afero.Exists(fs, cacheFile+".tmp")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("only invokes the provider once while the credentials are valid", func() {
+		for i := 0; i < 3; i++ {
+			_, err := cache.Retrieve(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(provider.RetrieveCallCount()).To(Equal(1))
+	})
+
+	It("ignores a corrupt cache file", func() {
+		Expect(afero.WriteFile(fs, cacheFile, []byte("not json"), 0600)).To(Succeed())
+
+		creds, err := cache.Retrieve(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds.AccessKeyID).To(Equal("new-id"))
+	})
+
+	It("returns errors from the provider", func() {
+		provider.RetrieveReturns(aws.Credentials{}, errors.New("token has expired, run aws sso login"))
+
+		_, err := cache.Retrieve(context.Background())
+		Expect(err).To(MatchError(ContainSubstring("aws sso login")))
+	})
+})
@@ -0,0 +1,425 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
+
+	"github.com/weaveworks/eksctl/pkg/accessentry"
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/argocd"
+	"github.com/weaveworks/eksctl/pkg/actions/clusterbootstrap"
+	"github.com/weaveworks/eksctl/pkg/actions/flux"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	ekspkg "github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/iam"
+	"github.com/weaveworks/eksctl/pkg/utils/exitcode"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
+)
+
+const (
+	stepAccess        = "access"
+	stepResources     = "resources"
+	stepAddons        = "addons"
+	stepDevicePlugins = "device-plugins"
+	stepGitOps        = "gitops"
+)
+
+// allSteps are the bootstrap steps, in the order they run in
+var allSteps = []string{stepAccess, stepResources, stepAddons, stepDevicePlugins, stepGitOps}
+
+type bootstrapCmdParams struct {
+	clusterName               string
+	steps                     []string
+	installNeuronDevicePlugin bool
+	installNvidiaDevicePlugin bool
+}
+
+// Command sets up the `bootstrap` command, which re-runs the steps following the creation of the infrastructure
+// of a cluster
+func Command(cmd *cmdutils.Cmd) {
+	bootstrapCmdWithRunFunc(cmd, doBootstrap)
+}
+
+func bootstrapCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, params *bootstrapCmdParams) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+
+	cmd.SetDescription("bootstrap", "Bootstrap an existing cluster as configured in the config file",
+		dedent.Dedent(`Runs the steps `+"`eksctl create cluster`"+` runs once the infrastructure of the cluster is created:
+			access maps the self-managed nodegroups in the aws-auth ConfigMap and creates the access entries,
+			resources applies the namespaces, cluster role bindings and priority classes of clusterBootstrap,
+			addons creates the addons, device-plugins installs the Neuron and NVIDIA device plugins and
+			gitops installs Flux.
+
+			Every step skips what already exists, so that the command can be re-run until it succeeds, e.g. after
+			`+"`eksctl create cluster`"+` failed once the infrastructure of the cluster was created.
+		`),
+	)
+
+	params := &bootstrapCmdParams{}
+
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		if err := validateSteps(params.steps); err != nil {
+			return exitcode.WithCode(err, exitcode.ValidationError)
+		}
+		if err := cmdutils.NewBootstrapLoader(cmd, params.clusterName).Load(); err != nil {
+			return err
+		}
+		return runFunc(cmd, params)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVarP(&params.clusterName, "cluster", "c", "", "EKS cluster name, must match metadata.name of the config file")
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringSliceVar(&params.steps, "steps", allSteps, "steps to run, any of "+strings.Join(allSteps, ", "))
+		fs.BoolVar(&params.installNeuronDevicePlugin, "install-neuron-plugin", true, "install Neuron plugin for Inferentia and Trainium nodes")
+		fs.BoolVar(&params.installNvidiaDevicePlugin, "install-nvidia-plugin", true, "install Nvidia plugin for GPU nodes")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func validateSteps(steps []string) error {
+	if len(steps) == 0 {
+		return errors.New("--steps must not be empty")
+	}
+	for _, step := range steps {
+		if !isStep(step, allSteps) {
+			return fmt.Errorf("unknown step %q, must be one of %s", step, strings.Join(allSteps, ", "))
+		}
+	}
+	return nil
+}
+
+func isStep(step string, steps []string) bool {
+	for _, s := range steps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+type bootstrapper struct {
+	cmd          *cmdutils.Cmd
+	params       *bootstrapCmdParams
+	ctl          *ekspkg.ClusterProvider
+	stackManager manager.StackManager
+
+	clientSet    kubernetes.Interface
+	clientSetErr error
+}
+
+func doBootstrap(cmd *cmdutils.Cmd, params *bootstrapCmdParams) error {
+	cfg := cmd.ClusterConfig
+
+	if isStep(stepGitOps, params.steps) && cfg.HasGitOpsFluxConfigured() {
+		if _, err := exec.LookPath("flux"); err != nil {
+			return fmt.Errorf("flux binary is required when gitops configuration is set: %w", err)
+		}
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	cfg.Metadata.Version = ctl.ControlPlaneVersion()
+
+	b := &bootstrapper{
+		cmd:          cmd,
+		params:       params,
+		ctl:          ctl,
+		stackManager: ctl.NewStackManager(cfg),
+	}
+	stepFuncs := map[string]func(context.Context) error{
+		stepAccess:        b.bootstrapAccess,
+		stepResources:     b.bootstrapResources,
+		stepAddons:        b.bootstrapAddons,
+		stepDevicePlugins: b.bootstrapDevicePlugins,
+		stepGitOps:        b.bootstrapGitOps,
+	}
+	for _, step := range allSteps {
+		if !isStep(step, params.steps) {
+			continue
+		}
+		logger.Info("running bootstrap step %q for cluster %q", step, cfg.Metadata.Name)
+		if err := stepFuncs[step](ctx); err != nil {
+			logger.Info("once the error is fixed, re-run 'eksctl bootstrap --cluster=%s -f %s' to complete the bootstrap of the cluster",
+				cfg.Metadata.Name, cmd.ClusterConfigFile)
+			return fmt.Errorf("bootstrap step %q failed: %w", step, err)
+		}
+	}
+	logger.Success("cluster %q has been bootstrapped", cfg.Metadata.Name)
+	return nil
+}
+
+func (b *bootstrapper) kubernetesClientSet() (kubernetes.Interface, error) {
+	if b.clientSet == nil && b.clientSetErr == nil {
+		b.clientSet, b.clientSetErr = b.ctl.NewStdClientSet(b.cmd.ClusterConfig)
+	}
+	return b.clientSet, b.clientSetErr
+}
+
+// bootstrapAccess maps the instance roles of the self-managed nodegroups and the IAM roles of
+// clusterBootstrap.clusterRoleBindings in the aws-auth ConfigMap, and creates the access entries of the config file
+// that do not exist yet
+func (b *bootstrapper) bootstrapAccess(ctx context.Context) error {
+	cfg := b.cmd.ClusterConfig
+	authenticationMode := b.ctl.GetClusterState().AccessConfig.AuthenticationMode
+	if cfg.AccessConfig == nil {
+		cfg.AccessConfig = &api.AccessConfig{}
+	}
+	cfg.AccessConfig.AuthenticationMode = authenticationMode
+	cfg.AddClusterBootstrapIAMMappings()
+
+	if authenticationMode == ekstypes.AuthenticationModeConfigMap && cfg.ClusterBootstrap != nil && len(cfg.ClusterBootstrap.ClusterRoleBindings) > 0 {
+		if err := b.mapClusterBootstrapRoles(); err != nil {
+			return err
+		}
+	}
+
+	if authenticationMode == ekstypes.AuthenticationModeConfigMap && len(cfg.NodeGroups) > 0 {
+		clientSet, err := b.kubernetesClientSet()
+		if err != nil {
+			return err
+		}
+		ngManager := nodegroup.New(cfg, b.ctl, clientSet, nil)
+		for _, ng := range cfg.NodeGroups {
+			if ng.IAM == nil {
+				ng.IAM = &api.NodeGroupIAM{}
+			}
+			if ng.IAM.InstanceRoleARN == "" {
+				summary, err := ngManager.Get(ctx, ng.Name)
+				if err != nil {
+					return fmt.Errorf("finding the instance role of nodegroup %q: %w", ng.Name, err)
+				}
+				ng.IAM.InstanceRoleARN = summary.NodeInstanceRoleARN
+			}
+			if err := authconfigmap.EnsureNodeGroup(clientSet, ng); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !accessentry.IsEnabled(authenticationMode) || cfg.AccessConfig == nil || len(cfg.AccessConfig.AccessEntries) == 0 {
+		return nil
+	}
+	existing := map[string]bool{}
+	paginator := eks.NewListAccessEntriesPaginator(b.ctl.AWSProvider.EKS(), &eks.ListAccessEntriesInput{
+		ClusterName: &cfg.Metadata.Name,
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("listing access entries: %w", err)
+		}
+		for _, principalARN := range output.AccessEntries {
+			existing[principalARN] = true
+		}
+	}
+	var missing []api.AccessEntry
+	for _, ae := range cfg.AccessConfig.AccessEntries {
+		if existing[ae.PrincipalARN.String()] {
+			logger.Info("access entry for principal ARN %s already exists, skipping", ae.PrincipalARN)
+			continue
+		}
+		missing = append(missing, ae)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	creator := &accessentryactions.Creator{
+		ClusterName:  cfg.Metadata.Name,
+		StackCreator: b.stackManager,
+	}
+	return creator.Create(ctx, missing)
+}
+
+// mapClusterBootstrapRoles maps the IAM roles of clusterBootstrap.clusterRoleBindings in the aws-auth ConfigMap,
+// the roles that are already mapped are skipped
+func (b *bootstrapper) mapClusterBootstrapRoles() error {
+	cfg := b.cmd.ClusterConfig
+	clientSet, err := b.kubernetesClientSet()
+	if err != nil {
+		return err
+	}
+	acm, err := authconfigmap.NewFromClientSet(clientSet)
+	if err != nil {
+		return err
+	}
+	for _, binding := range cfg.ClusterBootstrap.ClusterRoleBindings {
+		for _, roleARN := range binding.IAMRoleARNs {
+			identity, err := iam.NewIdentity(roleARN, "", []string{binding.Group})
+			if err != nil {
+				return err
+			}
+			if err := acm.AddIdentityIfNotPresent(identity, func(existing iam.Identity) bool {
+				return existing.ARN() == roleARN && slices.Contains(existing.Groups(), binding.Group)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return acm.Save()
+}
+
+// bootstrapResources applies the namespaces, cluster role bindings and priority classes of clusterBootstrap
+func (b *bootstrapper) bootstrapResources(ctx context.Context) error {
+	cfg := b.cmd.ClusterConfig
+	if cfg.ClusterBootstrap == nil {
+		logger.Info("no clusterBootstrap configuration in the config file")
+		return nil
+	}
+	clientSet, err := b.kubernetesClientSet()
+	if err != nil {
+		return err
+	}
+	applier := &clusterbootstrap.Applier{ClientSet: clientSet}
+	return applier.Apply(ctx, cfg.ClusterBootstrap)
+}
+
+// bootstrapAddons creates the addons of the config file, the addons that already exist are skipped
+func (b *bootstrapper) bootstrapAddons(ctx context.Context) error {
+	cfg := b.cmd.ClusterConfig
+	if len(cfg.Addons) == 0 {
+		logger.Info("no addons in the config file")
+		return nil
+	}
+
+	oidc, err := b.ctl.NewOpenIDConnectManager(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	oidcProviderExists, err := oidc.CheckProviderExists(ctx)
+	if err != nil {
+		return err
+	}
+	addonManager, err := addon.New(cfg, b.ctl.AWSProvider.EKS(), b.stackManager, oidcProviderExists, oidc, b.kubernetesClientSet)
+	if err != nil {
+		return err
+	}
+	iamRoleCreator := &podidentityassociation.IAMRoleCreator{
+		ClusterName:  cfg.Metadata.Name,
+		StackCreator: b.stackManager,
+	}
+
+	// install the EKS Pod Identity Agent first, as other addons might require IAM permissions
+	for _, podIdentityAgent := range []bool{true, false} {
+		for _, a := range cfg.Addons {
+			if (a.CanonicalName() == api.PodIdentityAgentAddon) != podIdentityAgent {
+				continue
+			}
+			if err := addonManager.Create(ctx, a, iamRoleCreator, b.cmd.ProviderConfig.WaitTimeout); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bootstrapDevicePlugins installs the device plugins required by the nodegroups of the config file
+func (b *bootstrapper) bootstrapDevicePlugins(_ context.Context) error {
+	taskTree := b.ctl.ClusterTasksForNodeGroups(b.cmd.ClusterConfig, b.params.installNeuronDevicePlugin, b.params.installNvidiaDevicePlugin)
+	if taskTree.Len() == 0 {
+		return nil
+	}
+	logger.Info(taskTree.Describe())
+	if errs := taskTree.DoAllSync(); len(errs) > 0 {
+		var allErrs []string
+		for _, err := range errs {
+			allErrs = append(allErrs, err.Error())
+		}
+		return errors.New(strings.Join(allErrs, "\n"))
+	}
+	return nil
+}
+
+// bootstrapGitOps installs Flux, which is a no-op when it is already installed, or Argo CD
+func (b *bootstrapper) bootstrapGitOps(ctx context.Context) error {
+	cfg := b.cmd.ClusterConfig
+	if cfg.HasGitOpsArgoCDConfigured() {
+		return b.bootstrapArgoCD(ctx)
+	}
+	if !cfg.HasGitOpsFluxConfigured() {
+		logger.Info("no gitops configuration in the config file")
+		return nil
+	}
+
+	flags := cfg.GitOps.Flux.Flags
+	if _, ok := flags["kubeconfig"]; !ok {
+		if _, ok := flags["context"]; !ok {
+			kubeconfigFile, err := os.CreateTemp("", cfg.Metadata.Name)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := os.Remove(kubeconfigFile.Name()); err != nil {
+					logger.Critical("failed to remove temporary kubeconfig %s", kubeconfigFile.Name())
+				}
+			}()
+			kubectlConfig := kubeconfig.NewForKubectl(cfg, ekspkg.GetUsername(b.ctl.Status.IAMRoleARN), "", b.ctl.AWSProvider.Profile().Name)
+			if _, err := kubeconfig.Write(kubeconfigFile.Name(), *kubectlConfig, true); err != nil {
+				return err
+			}
+			if flags == nil {
+				flags = api.FluxFlags{}
+				cfg.GitOps.Flux.Flags = flags
+			}
+			flags["kubeconfig"] = kubeconfigFile.Name()
+		}
+	}
+
+	rawClient, err := b.ctl.NewRawClient(cfg)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := rawClient.NewDynamicClient()
+	if err != nil {
+		return err
+	}
+	installer, err := flux.New(rawClient.ClientSet(), dynamicClient, cfg.GitOps)
+	if err != nil {
+		return fmt.Errorf("could not initialise Flux installer: %w", err)
+	}
+	return installer.Run()
+}
+
+// bootstrapArgoCD installs or upgrades Argo CD and its app-of-apps Application
+func (b *bootstrapper) bootstrapArgoCD(ctx context.Context) error {
+	cfg := b.cmd.ClusterConfig
+	kubeConfigBytes, err := runtime.Encode(clientcmdlatest.Codec, kubeconfig.NewForKubectl(cfg, ekspkg.GetUsername(b.ctl.Status.IAMRoleARN), "", b.ctl.AWSProvider.Profile().Name))
+	if err != nil {
+		return fmt.Errorf("generating kubeconfig: %w", err)
+	}
+	installer, err := argocd.New(cfg, b.ctl.AWSProvider.SSM(), kubeConfigBytes)
+	if err != nil {
+		return fmt.Errorf("could not initialise Argo CD installer: %w", err)
+	}
+	return installer.Install(ctx)
+}
@@ -0,0 +1,11 @@
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestCtlBootstrap(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
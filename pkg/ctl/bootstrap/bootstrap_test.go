@@ -0,0 +1,80 @@
+package bootstrap
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/ctl/ctltest"
+)
+
+var _ = Describe("bootstrap", func() {
+	var configFile string
+
+	BeforeEach(func() {
+		cfg := &api.ClusterConfig{
+			TypeMeta: api.ClusterConfigTypeMeta(),
+			Metadata: &api.ClusterMeta{
+				Name:   "cluster-1",
+				Region: "us-west-2",
+			},
+		}
+		configFile = ctltest.CreateConfigFile(cfg)
+	})
+
+	AfterEach(func() {
+		Expect(os.Remove(configFile)).To(Succeed())
+	})
+
+	execute := func(args ...string) (*cmdutils.Cmd, *bootstrapCmdParams, error) {
+		var (
+			bootstrapCmd    *cmdutils.Cmd
+			bootstrapParams *bootstrapCmdParams
+		)
+		rootCmd := &cobra.Command{}
+		cmdutils.AddResourceCmd(cmdutils.NewGrouping(), rootCmd, func(cmd *cmdutils.Cmd) {
+			bootstrapCmdWithRunFunc(cmd, func(cmd *cmdutils.Cmd, params *bootstrapCmdParams) error {
+				bootstrapCmd, bootstrapParams = cmd, params
+				return nil
+			})
+		})
+		rootCmd.SetArgs(append([]string{"bootstrap"}, args...))
+		err := rootCmd.Execute()
+		return bootstrapCmd, bootstrapParams, err
+	}
+
+	It("runs all steps by default", func() {
+		cmd, params, err := execute("-f", configFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(params.steps).To(Equal(allSteps))
+		Expect(params.installNeuronDevicePlugin).To(BeTrue())
+		Expect(params.installNvidiaDevicePlugin).To(BeTrue())
+		Expect(cmd.ClusterConfig.Metadata.Name).To(Equal("cluster-1"))
+		Expect(cmd.ProviderConfig.Region).To(Equal("us-west-2"))
+	})
+
+	It("accepts --cluster matching the config file", func() {
+		_, params, err := execute("--cluster", "cluster-1", "-f", configFile, "--steps", "addons,gitops")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(params.steps).To(Equal([]string{"addons", "gitops"}))
+	})
+
+	It("rejects --cluster not matching the config file", func() {
+		_, _, err := execute("--cluster", "cluster-2", "-f", configFile)
+		Expect(err).To(MatchError(`--cluster=cluster-2 does not match metadata.name "cluster-1" of the config file`))
+	})
+
+	DescribeTable("invalid flags or arguments", func(expectedErr string, args ...string) {
+		_, _, err := execute(args...)
+		Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("no config file", "--config-file/-f must be set", "--cluster", "cluster-1"),
+		Entry("unknown step", `unknown step "nodegroups"`, "--steps", "nodegroups"),
+		Entry("name argument", `unknown command "cluster-1"`, "cluster-1"),
+	)
+})
@@ -2,6 +2,8 @@ package cmdutils
 
 import (
 	"fmt"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 )
 
 var addonFlagsIncompatibleWithConfigFile = []string{
@@ -38,6 +40,43 @@ func NewCreateOrUpgradeAddonLoader(cmd *Cmd) ClusterConfigLoader {
 	return l
 }
 
+// NewUpgradeAddonLoader will load config or use flags for 'eksctl upgrade addon'
+func NewUpgradeAddonLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	// the addons to upgrade can be passed along with the ClusterConfig file
+	l.flagsIncompatibleWithConfigFile.Delete("name")
+	l.validateWithConfigFile = func() error {
+		for _, a := range cmd.ClusterConfig.Addons {
+			if err := a.Validate(); err != nil {
+				return err
+			}
+		}
+		if err := api.ValidateAddonUpgradePolicy(cmd.ClusterConfig.AddonsConfig.AutoUpgradePolicy); err != nil {
+			return fmt.Errorf("invalid addonsConfig: %w", err)
+		}
+		return nil
+	}
+	l.validateWithoutConfigFile = func() error {
+		return validateCluster(cmd)
+	}
+	return l
+}
+
+// NewRollbackAddonLoader will use flags for 'eksctl rollback addon'
+func NewRollbackAddonLoader(cmd *Cmd, name string) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.validateWithoutConfigFile = func() error {
+		if err := validateCluster(cmd); err != nil {
+			return err
+		}
+		if name == "" {
+			return ErrMustBeSet("--name")
+		}
+		return nil
+	}
+	return l
+}
+
 func NewDeleteAddonLoader(cmd *Cmd) ClusterConfigLoader {
 	l := newCommonClusterConfigLoader(cmd)
 	l.flagsIncompatibleWithConfigFile.Insert(addonFlagsIncompatibleWithConfigFile...)
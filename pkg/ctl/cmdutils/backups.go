@@ -0,0 +1,43 @@
+package cmdutils
+
+import (
+	"errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var backupsFlagsIncompatibleWithConfigFile = []string{
+	"version",
+	"bucket",
+	"prefix",
+	"schedule",
+	"ttl",
+}
+
+// NewEnableBackupsLoader will load config or use flags for 'eksctl enable backups'.
+func NewEnableBackupsLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.flagsIncompatibleWithConfigFile.Insert(backupsFlagsIncompatibleWithConfigFile...)
+	l.validateWithConfigFile = func() error {
+		if cmd.ClusterConfig.Backups == nil {
+			return errors.New("backups must be set in the config file")
+		}
+		return api.ValidateBackups(cmd.ClusterConfig.Backups)
+	}
+	l.validateWithoutConfigFile = func() error {
+		if err := validateCluster(cmd); err != nil {
+			return err
+		}
+		return api.ValidateBackups(cmd.ClusterConfig.Backups)
+	}
+	return l
+}
+
+// NewBackupsLoader will load config or use flags for 'eksctl create backup' and 'eksctl create restore'.
+func NewBackupsLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.validateWithoutConfigFile = func() error {
+		return validateCluster(cmd)
+	}
+	return l
+}
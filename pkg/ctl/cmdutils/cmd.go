@@ -20,6 +20,9 @@ type Cmd struct {
 
 	Plan, Wait, Validate bool
 
+	// StackParallelism is the number of CloudFormation stacks to create, update or delete in parallel
+	StackParallelism int
+
 	NameArg string
 
 	ClusterConfigFile string
@@ -28,6 +31,9 @@ type Cmd struct {
 	ClusterConfig  *api.ClusterConfig
 
 	Include, Exclude []string
+
+	// Selector is a label selector targeting nodegroups by their labels or tags
+	Selector string
 }
 
 // NewCtl performs common defaulting and validation and constructs a new
@@ -130,6 +136,8 @@ func AddResourceCmd(flagGrouping *FlagGrouping, parentVerbCmd *cobra.Command, ne
 	c.FlagSetGroup = flagGrouping.New(c.CobraCommand)
 	newCmd(c)
 	c.FlagSetGroup.AddTo(c.CobraCommand)
+	addUserDefaults(c.CobraCommand)
+	registerCompletions(c, parentVerbCmd.Name())
 	parentVerbCmd.AddCommand(c.CobraCommand)
 }
 
@@ -13,6 +13,7 @@ import (
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/utils/file"
 	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
 	"github.com/weaveworks/eksctl/pkg/version"
 )
@@ -108,6 +109,21 @@ func GetNameArg(args []string) string {
 func AddCommonFlagsForAWS(cmd *Cmd, p *api.ProviderConfig, addCfnOptions bool) {
 	cmd.FlagSetGroup.InFlagSet("AWS client", func(fs *pflag.FlagSet) {
 		fs.StringVarP(&p.Profile.Name, "profile", "p", "", "AWS credentials profile to use (defaults to the value of the AWS_PROFILE environment variable)")
+		fs.BoolVar(&p.UseFIPSEndpoint, "fips", false, "use FIPS endpoints for AWS APIs")
+		fs.StringVar(&p.Proxy.HTTPSProxy, "https-proxy", "", "URL of the proxy to use for the AWS and Kubernetes APIs (overrides proxy.httpsProxy)")
+		fs.StringSliceVar(&p.Proxy.NoProxy, "no-proxy", nil, "hosts, domains and CIDRs to reach without the proxy (overrides proxy.noProxy)")
+		fs.StringVar(&p.Proxy.CABundle, "ca-bundle", "", "path to a PEM file of additional certificate authorities to trust (overrides proxy.caBundle)")
+		fs.StringVar(&p.AssumeRole.RoleARN, "assume-role-arn", "", "ARN of an IAM role to assume with the credentials of the AWS profile")
+		fs.StringVar(&p.AssumeRole.ExternalID, "external-id", "", "external ID to pass when assuming --assume-role-arn")
+		fs.StringVar(&p.AssumeRole.RoleSessionName, "role-session-name", "", "session name to use when assuming --assume-role-arn (defaults to eksctl-<timestamp>)")
+		fs.StringToStringVar(&p.AssumeRole.SessionTags, "session-tags", nil, `session tags to pass when assuming --assume-role-arn. List of comma separated KV pairs "k1=v1,k2=v2"`)
+		fs.StringVar(&p.AssumeRole.MFASerial, "mfa-serial", "", "serial number or ARN of the MFA device required to assume --assume-role-arn, the token code is prompted for")
+		fs.StringVar(&p.DebugRequests, "aws-debug-requests", "", "log every AWS API call with its service, operation, duration, attempts and request ID (valid options: text, json)")
+		fs.Lookup("aws-debug-requests").NoOptDefVal = "text"
+		fs.StringVar(&p.Retry.Mode, "aws-retry-mode", "", "retry mode of AWS API calls, adaptive mode also slows down calls to APIs that are throttling (valid options: standard, adaptive)")
+		fs.IntVar(&p.Retry.MaxAttempts, "aws-max-attempts", 0, "maximum number of attempts of an AWS API call, including the first one (default 13)")
+		fs.BoolVar(&p.ResponseCache.Disabled, "no-cache", false, "do not use cached responses of read-only AWS API calls, which are cached for a minute")
+		fs.Float64Var(&p.Retry.MaxRequestsPerSecond, "aws-max-requests-per-second", 0, "limit the rate of AWS API calls made by eksctl, including retries, e.g. to leave API capacity for other tools in the account (default unlimited)")
 		if addCfnOptions {
 			fs.StringVar(&p.CloudFormationRoleARN, "cfn-role-arn", "", "IAM role used by CloudFormation to call AWS API on your behalf")
 			fs.BoolVar(&p.CloudFormationDisableRollback, "cfn-disable-rollback", false, "for debugging: If a stack fails, do not roll it back. Be careful, this may lead to unintentional resource consumption!")
@@ -165,6 +181,11 @@ func AddRegionFlag(fs *pflag.FlagSet, p *api.ProviderConfig) {
 	fs.StringVarP(&p.Region, "region", "r", "", "AWS region. Defaults to the value set in your AWS config (~/.aws/config)")
 }
 
+// AddRequiredTagsFlag adds common --required-tags flag
+func AddRequiredTagsFlag(fs *pflag.FlagSet, requiredTags *[]string) {
+	fs.StringSliceVar(requiredTags, "required-tags", nil, "keys of the tags all resources must have, e.g. for cost allocation; refuses to create resources if any of them is missing")
+}
+
 // AddVersionFlag adds common --version flag
 func AddVersionFlag(fs *pflag.FlagSet, meta *api.ClusterMeta, extraUsageInfo string) {
 	usage := fmt.Sprintf("Kubernetes version (valid options: %s)", strings.Join(api.SupportedVersions(), ", "))
@@ -184,6 +205,15 @@ func AddWaitFlagWithFullDescription(fs *pflag.FlagSet, wait *bool, description s
 	fs.BoolVarP(wait, "wait", "w", *wait, description)
 }
 
+// DefaultStackParallelism is the default number of CloudFormation stacks created, updated or deleted in parallel,
+// it keeps the CloudFormation and IAM calls made while waiting for the stacks below the API rate limits of an account
+const DefaultStackParallelism = 20
+
+// AddStackParallelismFlag adds common --parallelism flag
+func AddStackParallelismFlag(fs *pflag.FlagSet, cmd *Cmd, resources string) {
+	fs.IntVar(&cmd.StackParallelism, "parallelism", DefaultStackParallelism, fmt.Sprintf("number of CloudFormation stacks of %s to process in parallel, 0 for no limit", resources))
+}
+
 // AddUpdateAuthConfigMap adds common --update-auth-configmap flag
 func AddUpdateAuthConfigMap(fs *pflag.FlagSet, description string) *bool {
 	return fs.Bool("update-auth-configmap", true, description)
@@ -202,10 +232,30 @@ func AddCommonFlagsForKubeconfig(fs *pflag.FlagSet, outputPath, authenticatorRol
 	fs.BoolVar(autoPath, "auto-kubeconfig", false, fmt.Sprintf("save kubeconfig file by cluster name, e.g. %q", kubeconfig.AutoPath(exampleName)))
 }
 
+// ApplyKubeconfigConfig sets the kubeconfig path and whether to set the current context from the kubeconfig
+// section of the config file, unless they are set by flags
+func ApplyKubeconfigConfig(cmd *Cmd, outputPath *string, setContext *bool) {
+	kc := cmd.ClusterConfig.Kubeconfig
+	if kc == nil {
+		return
+	}
+	if kc.Path != "" && !cmd.CobraCommand.Flag("kubeconfig").Changed && !cmd.CobraCommand.Flag("auto-kubeconfig").Changed {
+		*outputPath = file.ExpandPath(kc.Path)
+	}
+	if kc.SetContext != nil && !cmd.CobraCommand.Flag("set-kubeconfig-context").Changed {
+		*setContext = *kc.SetContext
+	}
+}
+
+// CommandLine returns the eksctl command being run, as typed by the user
+func CommandLine() string {
+	return strings.Join(append([]string{"eksctl"}, os.Args[1:]...), " ")
+}
+
 // AddCommonFlagsForGetCmd adds common flags for get commands.
 func AddCommonFlagsForGetCmd(fs *pflag.FlagSet, chunkSize *int, outputMode *printers.Type) {
 	fs.IntVar(chunkSize, "chunk-size", 100, "return large lists in chunks rather than all at once, pass 0 to disable")
-	fs.StringVarP(outputMode, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+	fs.StringVarP(outputMode, "output", "o", "table", "specifies the output format (valid option: table, json, yaml, custom-columns=<spec>, jsonpath=<template>)")
 }
 
 // AddStringToStringVarPFlag is a wrapper that prefixes the description of the flag for consistency
@@ -0,0 +1,211 @@
+package cmdutils
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/kris-nova/logger"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/inventory"
+)
+
+// completionTimeout bounds the AWS API calls made to complete a flag, so that the shell never hangs
+const completionTimeout = 5 * time.Second
+
+type completionFunc = func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)
+
+// registerCompletions completes the region as well as the names of the clusters, nodegroups and addons
+// the command operates on; the names are listed with the AWS APIs, whose responses are cached for a minute
+func registerCompletions(c *Cmd, verb string) {
+	cmd := c.CobraCommand
+	if cmd.Flags().Lookup("region") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("region", completeRegions)
+	}
+	if cmd.Flags().Lookup("cluster") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("cluster", c.completeClusterNames)
+	}
+
+	var completeNames completionFunc
+	switch cmd.Name() {
+	case "cluster":
+		if verb != "create" {
+			completeNames = c.completeClusterNames
+		}
+	case "nodegroup":
+		if verb != "create" {
+			completeNames = c.completeNodeGroupNames
+		}
+	case "addon":
+		if verb == "create" {
+			completeNames = c.completeAvailableAddonNames
+		} else {
+			completeNames = c.completeAddonNames
+		}
+	}
+	if completeNames == nil {
+		return
+	}
+	if cmd.Flags().Lookup("name") != nil {
+		_ = cmd.RegisterFlagCompletionFunc("name", completeNames)
+	}
+	if cmd.ValidArgsFunction == nil {
+		cmd.ValidArgsFunction = func(cobraCmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return completeNames(cobraCmd, args, toComplete)
+		}
+	}
+}
+
+func completeRegions(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return api.SupportedRegions(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeClusterNames completes the clusters in the local inventory along with the clusters in the region
+func (c *Cmd) completeClusterNames(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	names := sets.New[string](inventoryClusterNames()...)
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	ctl, err := c.newCompletionProvider(ctx)
+	if err != nil {
+		return sets.List(names), cobra.ShellCompDirectiveNoFileComp
+	}
+	paginator := awseks.NewListClustersPaginator(ctl.AWSProvider.EKS(), &awseks.ListClustersInput{})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			break
+		}
+		names.Insert(out.Clusters...)
+	}
+	return sets.List(names), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeNodeGroupNames completes the managed and unmanaged nodegroups of the cluster set with --cluster
+func (c *Cmd) completeNodeGroupNames(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if c.clusterName() == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	ctl, err := c.newCompletionProvider(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	names := sets.New[string]()
+	paginator := awseks.NewListNodegroupsPaginator(ctl.AWSProvider.EKS(), &awseks.ListNodegroupsInput{
+		ClusterName: aws.String(c.clusterName()),
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		names.Insert(out.Nodegroups...)
+	}
+	stacks, err := ctl.NewStackManager(c.ClusterConfig).ListNodeGroupStacksWithStatuses(ctx)
+	if err == nil {
+		for _, s := range stacks {
+			names.Insert(s.NodeGroupName)
+		}
+	}
+	return sets.List(names), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAddonNames completes the addons installed in the cluster set with --cluster
+func (c *Cmd) completeAddonNames(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if c.clusterName() == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	ctl, err := c.newCompletionProvider(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var names []string
+	paginator := awseks.NewListAddonsPaginator(ctl.AWSProvider.EKS(), &awseks.ListAddonsInput{
+		ClusterName: aws.String(c.clusterName()),
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		names = append(names, out.Addons...)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAvailableAddonNames completes the addons that can be installed, for the Kubernetes version set with --version if any
+func (c *Cmd) completeAvailableAddonNames(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	ctl, err := c.newCompletionProvider(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	input := &awseks.DescribeAddonVersionsInput{}
+	if c.ClusterConfig != nil && c.ClusterConfig.Metadata.Version != "" {
+		input.KubernetesVersion = aws.String(c.ClusterConfig.Metadata.Version)
+	}
+	names := sets.New[string]()
+	paginator := awseks.NewDescribeAddonVersionsPaginator(ctl.AWSProvider.EKS(), input)
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		for _, addon := range out.Addons {
+			if addon.AddonName != nil {
+				names.Insert(*addon.AddonName)
+			}
+		}
+	}
+	return sets.List(names), cobra.ShellCompDirectiveNoFileComp
+}
+
+func (c *Cmd) clusterName() string {
+	if c.ClusterConfig == nil {
+		return ""
+	}
+	return c.ClusterConfig.Metadata.Name
+}
+
+// newCompletionProvider returns a provider for the flags parsed so far, caching the responses of the calls made to
+// complete names; log messages are discarded as the shell would take them for completions
+func (c *Cmd) newCompletionProvider(ctx context.Context) (*eks.ClusterProvider, error) {
+	logger.Writer = io.Discard
+	pc := c.ProviderConfig
+	pc.ResponseCache.Operations = eks.CompletionCachedOperations
+	return eks.New(ctx, &pc, nil)
+}
+
+// inventoryClusterNames returns the clusters in the local inventory, which does not need to call AWS APIs
+func inventoryClusterNames() []string {
+	if !inventory.Enabled() {
+		return nil
+	}
+	filename, err := inventory.GetFilePath()
+	if err != nil {
+		return nil
+	}
+	clusters, err := inventory.New(afero.NewOsFs(), filename, time.Now).List()
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, c := range clusters {
+		names = append(names, c.Name)
+	}
+	return names
+}
@@ -0,0 +1,47 @@
+package cmdutils
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("completion", func() {
+	complete := func(verb, resource string, args ...string) []string {
+		rootCmd := &cobra.Command{Use: "eksctl"}
+		verbCmd := NewVerbCmd(verb, "", "")
+		rootCmd.AddCommand(verbCmd)
+		AddResourceCmd(NewGrouping(), verbCmd, func(cmd *Cmd) {
+			cmd.ClusterConfig = api.NewClusterConfig()
+			cmd.SetDescription(resource, "", "")
+			cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+				AddRegionFlag(fs, &cmd.ProviderConfig)
+				fs.StringVarP(&cmd.ClusterConfig.Metadata.Name, "name", "n", "", "")
+			})
+			cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+				return nil
+			}
+		})
+		out := &bytes.Buffer{}
+		rootCmd.SetOut(out)
+		rootCmd.SetArgs(append([]string{cobra.ShellCompRequestCmd, verb, resource}, args...))
+		Expect(rootCmd.Execute()).To(Succeed())
+		return strings.Split(strings.TrimSpace(out.String()), "\n")
+	}
+
+	It("completes the region with the supported regions", func() {
+		completions := complete("get", "cluster", "--region", "")
+		Expect(completions).To(ContainElements(api.SupportedRegions()))
+	})
+
+	It("does not complete the name of a cluster being created", func() {
+		completions := complete("create", "cluster", "--name", "")
+		Expect(completions).To(Equal([]string{":0"}))
+	})
+})
@@ -1,6 +1,7 @@
 package cmdutils
 
 import (
+	"context"
 	"encoding/csv"
 	"fmt"
 	"io"
@@ -20,6 +21,8 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/orgdefaults"
+	"github.com/weaveworks/eksctl/pkg/utils/exitcode"
 	"github.com/weaveworks/eksctl/pkg/utils/names"
 	utilstrings "github.com/weaveworks/eksctl/pkg/utils/strings"
 )
@@ -66,6 +69,7 @@ var (
 		"install-nvidia-plugin",
 		"profile",
 		"timeout",
+		waitForReadyNodesFlagName,
 	}
 
 	commonNGFlagsIncompatibleWithConfigFile = []string{
@@ -109,8 +113,13 @@ func newCommonClusterConfigLoader(cmd *Cmd) *commonClusterConfigLoader {
 	}
 }
 
-// Load ClusterConfig or use flags
+// Load ClusterConfig or use flags; errors are validation errors unless they come from AWS API calls made
+// while loading, e.g. to resolve the account ID or the VPC, which keep their own exit code
 func (l *commonClusterConfigLoader) Load() error {
+	return exitcode.WithDefaultCode(l.load(), exitcode.ValidationError)
+}
+
+func (l *commonClusterConfigLoader) load() error {
 	if err := api.Register(); err != nil {
 		return err
 	}
@@ -152,10 +161,54 @@ func (l *commonClusterConfigLoader) Load() error {
 		return ErrMustBeSet("metadata.region")
 	}
 	l.ProviderConfig.Region = meta.Region
+	l.ProviderConfig.ServiceEndpoints = l.ClusterConfig.ServiceEndpoints
+	if proxy := l.ClusterConfig.Proxy; proxy != nil {
+		if l.ProviderConfig.Proxy.HTTPSProxy == "" {
+			l.ProviderConfig.Proxy.HTTPSProxy = proxy.HTTPSProxy
+		}
+		if len(l.ProviderConfig.Proxy.NoProxy) == 0 {
+			l.ProviderConfig.Proxy.NoProxy = proxy.NoProxy
+		}
+		if l.ProviderConfig.Proxy.CABundle == "" {
+			l.ProviderConfig.Proxy.CABundle = proxy.CABundle
+		}
+	}
 
 	return l.validateWithConfigFile()
 }
 
+// withOrganizationDefaults makes the loader apply the organization defaults and guardrails once the config is loaded,
+// and check that the config has requiredTags. They are only applied by the loaders of create commands, so that they
+// never stop users from inspecting or deleting existing resources, e.g. in a region that is no longer allowed.
+func withOrganizationDefaults(l *commonClusterConfigLoader, requiredTags []string) ClusterConfigLoader {
+	for _, validate := range []*func() error{&l.validateWithConfigFile, &l.validateWithoutConfigFile} {
+		validateFunc := *validate
+		*validate = func() error {
+			if err := validateFunc(); err != nil {
+				return err
+			}
+			if err := applyOrganizationDefaults(l.Cmd); err != nil {
+				return err
+			}
+			return orgdefaults.CheckRequiredTags(l.ClusterConfig, requiredTags)
+		}
+	}
+	return l
+}
+
+func applyOrganizationDefaults(cmd *Cmd) error {
+	if orgdefaults.SSMParameter(cmd.ClusterConfig) == "" {
+		return nil
+	}
+	ctx := context.TODO()
+	// the provider also resolves the region when it is not set
+	ctl, err := eks.New(ctx, &cmd.ProviderConfig, cmd.ClusterConfig)
+	if err != nil {
+		return err
+	}
+	return orgdefaults.FetchAndApply(ctx, ctl.AWSProvider.SSM(), cmd.ClusterConfig)
+}
+
 func findChangedFlag(cmd *cobra.Command, flagNames []string) (string, bool) {
 	for _, f := range flagNames {
 		if flag := cmd.Flag(f); flag != nil && flag.Changed {
@@ -207,6 +260,8 @@ func NewCreateClusterLoader(cmd *Cmd, ngFilter *filter.NodeGroupFilter, ng *api.
 
 	clusterFlagsIncompatibleWithConfigFile := []string{
 		"tags",
+		"ttl",
+		"spot-only",
 		"zones",
 		"fargate",
 		"vpc-private-subnets",
@@ -214,11 +269,16 @@ func NewCreateClusterLoader(cmd *Cmd, ngFilter *filter.NodeGroupFilter, ng *api.
 		"vpc-cidr",
 		"vpc-nat-mode",
 		"vpc-from-kops-cluster",
+		"without-default-addons",
 	}
 
 	l.flagsIncompatibleWithConfigFile.Insert(append(clusterFlagsIncompatibleWithConfigFile, commonNGFlagsIncompatibleWithConfigFile...)...)
 
 	l.flagsIncompatibleWithoutConfigFile.Insert("install-vpc-controllers")
+	if params.FromSnapshot != "" {
+		// the snapshot is loaded as the config file, and the cluster created from it can be renamed or moved with flags
+		l.flagsIncompatibleWithConfigFile.Delete("name", "region")
+	}
 
 	validateDryRun := func() error {
 		if !params.DryRun {
@@ -304,6 +364,14 @@ func NewCreateClusterLoader(cmd *Cmd, ngFilter *filter.NodeGroupFilter, ng *api.
 					return ErrMustBeSet("gitops.flux.flags")
 				}
 			}
+
+			if err := api.ValidateGitOps(clusterConfig.GitOps); err != nil {
+				return err
+			}
+		}
+
+		if err := api.ValidateClusterBootstrap(clusterConfig.ClusterBootstrap); err != nil {
+			return err
 		}
 
 		for _, addon := range clusterConfig.Addons {
@@ -344,6 +412,9 @@ func NewCreateClusterLoader(cmd *Cmd, ngFilter *filter.NodeGroupFilter, ng *api.
 			}
 		}
 
+		if err := validateWaitForReadyNodes(params.WaitForReadyNodes); err != nil {
+			return err
+		}
 		return validateDryRun()
 	}
 
@@ -369,10 +440,16 @@ func NewCreateClusterLoader(cmd *Cmd, ngFilter *filter.NodeGroupFilter, ng *api.
 			return err
 		}
 
+		if err := validateSpotOnlyFlags(l.CobraCommand, params); err != nil {
+			return err
+		}
+
 		// prevent creation of invalid config object with irrelevant nodegroup
 		// that may or may not be constructed correctly
 		if !params.WithoutNodeGroup {
-			if params.Managed {
+			if params.SpotOnly {
+				applySpotOnlyPreset(l.ClusterConfig, ng, params.Managed)
+			} else if params.Managed {
 				l.ClusterConfig.ManagedNodeGroups = []*api.ManagedNodeGroup{makeManagedNodegroup(ng, params.CreateManagedNGOptions)}
 			} else {
 				l.ClusterConfig.NodeGroups = []*api.NodeGroup{ng}
@@ -390,6 +467,11 @@ func NewCreateClusterLoader(cmd *Cmd, ngFilter *filter.NodeGroupFilter, ng *api.
 			}
 		}
 
+		if l.ClusterConfig.AddonsConfig.DisableDefaultAddons && (l.ClusterConfig.HasNodes() || l.ClusterConfig.IsFargateEnabled()) {
+			return errors.New("--without-default-addons cannot be used with nodegroups or Fargate, as nodes cannot become ready without a CNI plugin; " +
+				"please rerun the command with --without-nodegroup and create nodegroups after installing a CNI plugin")
+		}
+
 		for _, ng := range l.ClusterConfig.NodeGroups {
 			// generate nodegroup name or use flag
 			ng.Name = names.ForNodeGroup(ng.Name, "")
@@ -406,10 +488,20 @@ func NewCreateClusterLoader(cmd *Cmd, ngFilter *filter.NodeGroupFilter, ng *api.
 			normalizeBaseNodeGroup(ng, l.CobraCommand)
 		}
 
+		if err := validateWaitForReadyNodes(params.WaitForReadyNodes); err != nil {
+			return err
+		}
 		return validateDryRun()
 	}
 
-	return l
+	return withOrganizationDefaults(l, params.RequiredTags)
+}
+
+func validateWaitForReadyNodes(waitForReadyNodes int) error {
+	if waitForReadyNodes < -1 {
+		return fmt.Errorf("invalid value %d for --%s; must be -1 (the minimum size of the nodegroup) or greater", waitForReadyNodes, waitForReadyNodesFlagName)
+	}
+	return nil
 }
 
 func validateAuthConfigMapFlag(cmd *cobra.Command, options *NodeGroupOptions) error {
@@ -457,12 +549,12 @@ func validateDryRunOptions(cmd *cobra.Command, incompatibleFlags []string) error
 
 // validateBareCluster validates a cluster for unsupported fields if VPC CNI is disabled.
 func validateBareCluster(clusterConfig *api.ClusterConfig) error {
-	if !clusterConfig.AddonsConfig.DisableDefaultAddons || slices.ContainsFunc(clusterConfig.Addons, func(addon *api.Addon) bool {
+	if clusterConfig.IsAutoModeEnabled() || !clusterConfig.AddonsConfig.DisableDefaultAddons || slices.ContainsFunc(clusterConfig.Addons, func(addon *api.Addon) bool {
 		return addon.Name == api.VPCCNIAddon
 	}) {
 		return nil
 	}
-	if clusterConfig.HasNodes() || clusterConfig.IsFargateEnabled() || clusterConfig.Karpenter != nil || clusterConfig.HasGitOpsFluxConfigured() ||
+	if clusterConfig.HasNodes() || clusterConfig.IsFargateEnabled() || clusterConfig.Karpenter != nil || clusterConfig.HasGitOpsFluxConfigured() || clusterConfig.HasGitOpsArgoCDConfigured() ||
 		(clusterConfig.IAM != nil && ((len(clusterConfig.IAM.ServiceAccounts) > 0) || len(clusterConfig.IAM.PodIdentityAssociations) > 0)) {
 		return errors.New("fields nodeGroups, managedNodeGroups, fargateProfiles, karpenter, gitops, iam.serviceAccounts, " +
 			"and iam.podIdentityAssociations are not supported during cluster creation in a cluster without VPC CNI; please remove these fields " +
@@ -471,7 +563,10 @@ func validateBareCluster(clusterConfig *api.ClusterConfig) error {
 	return nil
 }
 
-const updateAuthConfigMapFlagName = "update-auth-configmap"
+const (
+	updateAuthConfigMapFlagName = "update-auth-configmap"
+	waitForReadyNodesFlagName   = "wait-for-ready-nodes"
+)
 
 // NewCreateNodeGroupLoader will load config or use flags for 'eksctl create nodegroup'
 func NewCreateNodeGroupLoader(cmd *Cmd, ng *api.NodeGroup, ngFilter *filter.NodeGroupFilter, options *NodeGroupOptions) ClusterConfigLoader {
@@ -501,6 +596,9 @@ func NewCreateNodeGroupLoader(cmd *Cmd, ng *api.NodeGroup, ngFilter *filter.Node
 		if err := validateAuthConfigMapFlag(l.CobraCommand, options); err != nil {
 			return err
 		}
+		if err := validateWaitForReadyNodes(options.WaitForReadyNodes); err != nil {
+			return err
+		}
 		return validateDryRun()
 	}
 
@@ -549,10 +647,13 @@ func NewCreateNodeGroupLoader(cmd *Cmd, ng *api.NodeGroup, ngFilter *filter.Node
 				}
 			}
 		}
+		if err := validateWaitForReadyNodes(options.WaitForReadyNodes); err != nil {
+			return err
+		}
 		return validateDryRun()
 	}
 
-	return l
+	return withOrganizationDefaults(l, options.RequiredTags)
 }
 
 func validateUnsetNodeGroups(clusterConfig *api.ClusterConfig) error {
@@ -648,7 +749,16 @@ func NewDeleteAndDrainNodeGroupLoader(cmd *Cmd, ng *api.NodeGroup, ngFilter *fil
 		if err := validateUnsetNodeGroups(l.ClusterConfig); err != nil {
 			return err
 		}
-		return ngFilter.AppendGlobs(l.Include, l.Exclude, l.ClusterConfig.GetAllNodeGroupNames())
+		if err := ngFilter.AppendGlobs(l.Include, l.Exclude, l.ClusterConfig.GetAllNodeGroupNames()); err != nil {
+			return err
+		}
+		if l.Selector == "" {
+			return nil
+		}
+		if flag := l.CobraCommand.Flag("only-missing"); flag != nil && flag.Changed {
+			return fmt.Errorf("--%s cannot be used with --only-missing", nodeGroupSelectorFlag)
+		}
+		return ApplyNodeGroupSelector(l.Selector, l.ClusterConfig, ngFilter)
 	}
 
 	l.flagsIncompatibleWithoutConfigFile.Insert(
@@ -664,11 +774,15 @@ func NewDeleteAndDrainNodeGroupLoader(cmd *Cmd, ng *api.NodeGroup, ngFilter *fil
 			return ErrFlagAndArg("--name", ng.Name, l.NameArg)
 		}
 
+		if err := ValidateNodeGroupSelector(cmd, ng.Name); err != nil {
+			return err
+		}
+
 		if l.NameArg != "" {
 			ng.Name = l.NameArg
 		}
 
-		if ng.Name == "" {
+		if ng.Name == "" && l.Selector == "" {
 			return ErrMustBeSet("--name")
 		}
 
@@ -678,7 +792,9 @@ func NewDeleteAndDrainNodeGroupLoader(cmd *Cmd, ng *api.NodeGroup, ngFilter *fil
 			}
 		}
 
-		ngFilter.AppendIncludeNames(ng.Name)
+		if ng.Name != "" {
+			ngFilter.AppendIncludeNames(ng.Name)
+		}
 
 		l.Plan = false
 
@@ -702,6 +818,45 @@ func NewUtilsEnableLoggingLoader(cmd *Cmd) ClusterConfigLoader {
 	return l
 }
 
+// NewUtilsUpdateTagsLoader will load config for 'eksctl utils update-tags', which needs the tags of the nodegroups
+// as well as metadata.tags, so that it does not remove the tags set on the nodegroups
+func NewUtilsUpdateTagsLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file/-f")
+	}
+
+	return l
+}
+
+// NewBootstrapLoader will load config for 'eksctl bootstrap'; --cluster may be set alongside the config file,
+// in which case it must match metadata.name, so that the config of another cluster is never applied by mistake
+func NewBootstrapLoader(cmd *Cmd, clusterName string) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.flagsIncompatibleWithConfigFile.Delete("cluster")
+
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file/-f")
+	}
+	l.validateWithConfigFile = func() error {
+		if clusterName != "" && clusterName != cmd.ClusterConfig.Metadata.Name {
+			return fmt.Errorf("--cluster=%s does not match metadata.name %q of the config file", clusterName, cmd.ClusterConfig.Metadata.Name)
+		}
+		for _, a := range cmd.ClusterConfig.Addons {
+			if err := a.Validate(); err != nil {
+				return err
+			}
+		}
+		if err := api.ValidateClusterBootstrap(cmd.ClusterConfig.ClusterBootstrap); err != nil {
+			return err
+		}
+		return api.ValidateGitOps(cmd.ClusterConfig.GitOps)
+	}
+
+	return l
+}
+
 // NewUtilsEnableEndpointAccessLoader will load config or use flags for 'eksctl utils update-cluster-endpoints'.
 func NewUtilsEnableEndpointAccessLoader(cmd *Cmd, privateAccess, publicAccess bool) ClusterConfigLoader {
 	l := newCommonClusterConfigLoader(cmd)
@@ -762,6 +917,31 @@ func NewUtilsAssociateIAMOIDCProviderLoader(cmd *Cmd) ClusterConfigLoader {
 	return l
 }
 
+// NewSyncAccessCIDRsLoader loads config or uses flags for `eksctl utils sync-access-cidrs`
+func NewSyncAccessCIDRsLoader(cmd *Cmd, publicAccessCIDRs []string) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.flagsIncompatibleWithConfigFile.Insert("public-access-cidrs")
+
+	l.validateWithConfigFile = func() error {
+		if l.ClusterConfig.VPC == nil || len(l.ClusterConfig.VPC.PublicAccessCIDRs) == 0 {
+			return errors.New("field vpc.publicAccessCIDRs is required")
+		}
+		return nil
+	}
+
+	l.validateWithoutConfigFile = func() error {
+		if err := l.validateMetadataWithoutConfigFile(); err != nil {
+			return err
+		}
+		if len(publicAccessCIDRs) == 0 {
+			return ErrMustBeSet("--public-access-cidrs")
+		}
+		l.ClusterConfig.VPC.PublicAccessCIDRs = publicAccessCIDRs
+		return nil
+	}
+	return l
+}
+
 // NewUtilsPublicAccessCIDRsLoader loads config or uses flags for `eksctl utils set-public-access-cidrs <cidrs>`
 func NewUtilsPublicAccessCIDRsLoader(cmd *Cmd) ClusterConfigLoader {
 	l := newCommonClusterConfigLoader(cmd)
@@ -803,6 +983,7 @@ func NewCreateIAMServiceAccountLoader(cmd *Cmd, saFilter *filter.IAMServiceAccou
 
 	l.flagsIncompatibleWithConfigFile.Insert(
 		"policy-arn",
+		"target-account",
 	)
 
 	l.validateWithConfigFile = func() error {
@@ -847,6 +1028,10 @@ func NewCreateIAMServiceAccountLoader(cmd *Cmd, saFilter *filter.IAMServiceAccou
 			return fmt.Errorf("cannot provide --attach-role-arn and specify polices to attach")
 		}
 
+		if serviceAccount.AttachRoleARN != "" && serviceAccount.TargetAccountRoleARN != "" {
+			return fmt.Errorf("cannot provide --target-account when --attach-role-arn is configured")
+		}
+
 		return nil
 	}
 
@@ -894,6 +1079,10 @@ func NewDeleteIAMServiceAccountLoader(cmd *Cmd, sa *api.ClusterIAMServiceAccount
 		return saFilter.AppendGlobs(l.Include, l.Exclude, l.ClusterConfig.IAM.ServiceAccounts)
 	}
 
+	l.flagsIncompatibleWithConfigFile.Insert(
+		"target-account",
+	)
+
 	l.flagsIncompatibleWithoutConfigFile.Insert(
 		"approve",
 	)
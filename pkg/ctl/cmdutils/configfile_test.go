@@ -402,6 +402,8 @@ var _ = Describe("cmdutils configfile", func() {
 				{"15-managed-nodes.yaml", 4, true, true},
 				{"15-managed-nodes.yaml", 4, false, true},
 				{"20-bottlerocket.yaml", 2, false, false},
+				{"33-local-zones.yaml", 2, true, false},
+				{"33-local-zones.yaml", 2, false, false},
 			}
 
 			for _, loaderTest := range loaderParams {
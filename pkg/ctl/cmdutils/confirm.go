@@ -0,0 +1,70 @@
+package cmdutils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+
+	"github.com/weaveworks/eksctl/pkg/utils/exitcode"
+)
+
+// InteractionOptions controls whether destructive commands ask for confirmation
+type InteractionOptions struct {
+	// AssumeYes answers yes to all confirmation prompts
+	AssumeYes bool
+	// NonInteractive makes commands fail instead of asking for confirmation
+	NonInteractive bool
+}
+
+var (
+	interaction InteractionOptions
+
+	confirmIn  io.Reader = os.Stdin
+	confirmOut io.Writer = os.Stderr
+	isTerminal           = func() bool {
+		return term.IsTerminal(int(os.Stdin.Fd()))
+	}
+)
+
+// AddInteractionFlags adds the global --yes and --non-interactive flags
+func AddInteractionFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&interaction.AssumeYes, "yes", "y", false, "answer yes to all confirmation prompts")
+	fs.BoolVar(&interaction.NonInteractive, "non-interactive", false, "fail instead of asking for confirmation, e.g. in CI; use with --yes to confirm")
+}
+
+// Confirm asks whether to go ahead with the action described by question, which should follow the list of
+// resources the action affects. It returns true without asking when --yes is set, and fails when --non-interactive
+// is set. When stdin is not a terminal, nobody can answer, so it returns whenNotInteractive without asking,
+// which lets commands keep their behaviour in scripts.
+func Confirm(question string, whenNotInteractive bool) (bool, error) {
+	switch {
+	case interaction.AssumeYes:
+		return true, nil
+	case interaction.NonInteractive:
+		return false, exitcode.WithCode(fmt.Errorf("%s requires confirmation and --non-interactive is set, pass --yes to confirm", question), exitcode.ValidationError)
+	case !isTerminal():
+		return whenNotInteractive, nil
+	}
+
+	fmt.Fprintf(confirmOut, "%s? [y/N]: ", question)
+	answer, err := bufio.NewReader(confirmIn).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("reading confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// ErrNotConfirmed is returned when the user did not confirm a destructive action
+func ErrNotConfirmed(action string) error {
+	return fmt.Errorf("not confirmed, did not %s", action)
+}
@@ -0,0 +1,65 @@
+package cmdutils
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Confirm", func() {
+	var (
+		out      *bytes.Buffer
+		terminal bool
+	)
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		terminal = true
+		confirmOut = out
+		isTerminal = func() bool {
+			return terminal
+		}
+	})
+
+	AfterEach(func() {
+		interaction = InteractionOptions{}
+	})
+
+	DescribeTable("answers", func(answer string, expected bool) {
+		confirmIn = strings.NewReader(answer)
+		confirmed, err := Confirm(`delete cluster "test"`, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(confirmed).To(Equal(expected))
+		Expect(out.String()).To(Equal(`delete cluster "test"? [y/N]: `))
+	},
+		Entry("yes", "yes\n", true),
+		Entry("y", "Y\n", true),
+		Entry("no", "n\n", false),
+		Entry("empty", "\n", false),
+		Entry("end of input", "", false),
+	)
+
+	It("does not ask when --yes is set", func() {
+		interaction.AssumeYes = true
+		confirmed, err := Confirm(`delete cluster "test"`, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(confirmed).To(BeTrue())
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	It("fails when --non-interactive is set", func() {
+		interaction.NonInteractive = true
+		_, err := Confirm(`delete cluster "test"`, true)
+		Expect(err).To(MatchError(`delete cluster "test" requires confirmation and --non-interactive is set, pass --yes to confirm`))
+	})
+
+	It("does not ask when stdin is not a terminal", func() {
+		terminal = false
+		confirmed, err := Confirm(`delete nodegroup "ng-1"`, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(confirmed).To(BeTrue())
+		Expect(out.String()).To(BeEmpty())
+	})
+})
@@ -4,6 +4,8 @@ import (
 	"io"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils/progress"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 )
 
 // CreateClusterCmdParams groups CLI options for the create cluster command.
@@ -15,12 +17,18 @@ type CreateClusterCmdParams struct {
 	SetContext                  bool
 	AvailabilityZones           []string
 	InstallWindowsVPCController bool
+	EnableTerminationProtection bool
 
 	KopsClusterNameForVPC string
 	Subnets               map[api.SubnetTopology]*[]string
 	WithoutNodeGroup      bool
 	Fargate               bool
+	SpotOnly              bool
 	DryRun                bool
+	Interactive           bool
+	RenderCFNOnly         bool
+	RenderDir             string
+	FromSnapshot          string
 	CreateNGOptions
 	CreateManagedNGOptions
 
@@ -34,6 +42,7 @@ type NodeGroupOptions struct {
 	UpdateAuthConfigMap     *bool
 	SkipOutdatedAddonsCheck bool
 	SubnetIDs               []string
+	InfraEngine             string
 }
 
 // CreateManagedNGOptions holds options for creating a managed nodegroup
@@ -49,4 +58,21 @@ type CreateNGOptions struct {
 	InstallNvidiaDevicePlugin bool
 	DryRun                    bool
 	NodeGroupParallelism      int
+	WaitForReadyNodes         int
+	ShowTaskGraph             string
+	Progress                  string
+	RequiredTags              []string
+}
+
+// TaskGraphFormat returns the format of the task graph requested with --show-task-graph, if any
+func (o CreateNGOptions) TaskGraphFormat() (tasks.GraphFormat, error) {
+	if o.ShowTaskGraph == "" {
+		return "", nil
+	}
+	return tasks.ParseGraphFormat(o.ShowTaskGraph)
+}
+
+// ProgressMode returns the mode requested with --progress
+func (o CreateNGOptions) ProgressMode() (progress.Mode, error) {
+	return progress.ParseMode(o.Progress)
 }
@@ -0,0 +1,32 @@
+package cmdutils
+
+import (
+	"errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var dnsFlagsIncompatibleWithConfigFile = []string{
+	"hosted-zone-ids",
+	"external-dns",
+	"cert-manager",
+}
+
+// NewEnableDNSLoader will load config or use flags for 'eksctl enable dns'.
+func NewEnableDNSLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.flagsIncompatibleWithConfigFile.Insert(dnsFlagsIncompatibleWithConfigFile...)
+	l.validateWithConfigFile = func() error {
+		if !cmd.ClusterConfig.HasDNS() {
+			return errors.New("dns.externalDNS or dns.certManager must be set in the config file")
+		}
+		return api.ValidateDNS(cmd.ClusterConfig.DNS)
+	}
+	l.validateWithoutConfigFile = func() error {
+		if err := validateCluster(cmd); err != nil {
+			return err
+		}
+		return api.ValidateDNS(cmd.ClusterConfig.DNS)
+	}
+	return l
+}
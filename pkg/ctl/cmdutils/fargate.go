@@ -167,3 +167,32 @@ func NewDeleteFargateProfileLoader(cmd *Cmd, options *fargate.Options) ClusterCo
 	}
 	return l
 }
+
+// NewUpdateFargateProfileLoader will load config for
+// 'eksctl update fargateprofile'
+func NewUpdateFargateProfileLoader(cmd *Cmd, options *fargate.Options) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	// The name of the profile to update can be passed along with the ClusterConfig file:
+	l.flagsIncompatibleWithConfigFile = flagsIncompatibleWithConfigFileExcept(fargateProfileName)
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file/-f")
+	}
+	l.validateWithConfigFile = func() error {
+		if err := validateNameFlagAndArg(cmd, options); err != nil {
+			return err
+		}
+		if err := validateFargateProfiles(l); err != nil {
+			return err
+		}
+		if options.ProfileName == "" {
+			return nil
+		}
+		for _, profile := range cmd.ClusterConfig.FargateProfiles {
+			if profile.Name == options.ProfileName {
+				return nil
+			}
+		}
+		return fmt.Errorf("no Fargate profile %q in the config file", options.ProfileName)
+	}
+	return l
+}
@@ -103,7 +103,9 @@ func (f *IAMServiceAccountFilter) SetDeleteFilter(ctx context.Context, lister se
 	for _, localServiceAccount := range *serviceAccounts {
 		localServiceAccountName := localServiceAccount.NameString()
 		local.Insert(localServiceAccountName)
-		if !remote.Has(localServiceAccountName) {
+		// the stacks of iamserviceaccounts with a targetAccountRoleARN are in the account of that role
+		inTargetAccount := localServiceAccount.TargetAccountRoleARN != ""
+		if !remote.Has(localServiceAccountName) && !inTargetAccount {
 			logger.Info("iamserviceaccounts %q present in the given config, but missing in the cluster", localServiceAccountName)
 			f.AppendExcludeNames(localServiceAccountName)
 		} else if includeOnlyMissing {
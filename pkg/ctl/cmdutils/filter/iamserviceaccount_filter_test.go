@@ -71,6 +71,21 @@ var _ = Describe("iamserviceaccount filter", func() {
 			))
 		})
 
+		It("keeps the iamserviceaccounts whose stacks are in a target account", func() {
+			cfg.IAM.ServiceAccounts[3].TargetAccountRoleARN = "arn:aws:iam::111122223333:role/irsa-deployer"
+			mockLister := newMockServiceAccountLister(
+				"sa/dev1",
+				"sa/dev2",
+				"sa/dev3",
+			)
+			err := filter.SetDeleteFilter(context.Background(), mockLister, false, cfg)
+			Expect(err).NotTo(HaveOccurred())
+
+			included, excluded := filter.MatchAll(cfg.IAM.ServiceAccounts)
+			Expect(sets.List(included)).To(ConsistOf("sa/dev1", "sa/dev2", "sa/dev3", "sa/test1"))
+			Expect(sets.List(excluded)).To(ConsistOf("sa/test2", "sa/test3"))
+		})
+
 		It("exclude existing stacks works correctly", func() {
 			mockLister := newMockServiceAccountLister(
 				"sa/dev1",
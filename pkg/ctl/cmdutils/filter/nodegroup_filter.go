@@ -35,6 +35,8 @@ type NodeGroupFilter struct {
 	onlyRemote       bool
 	localNodegroups  sets.Set[string]
 	remoteNodegroups sets.Set[string]
+	// selectedNodegroups restricts the filter to the nodegroups matched by a selector, when set
+	selectedNodegroups sets.Set[string]
 }
 
 // NewNodeGroupFilter creates a new NodeGroupFilter struct
@@ -111,6 +113,12 @@ func (f *NodeGroupFilter) SetOnlyRemote(ctx context.Context, eksAPI awsapi.EKS,
 	return nil
 }
 
+// SetSelected restricts the filter to the given nodegroups, e.g. the ones matching a label selector.
+// Nodegroups that are not selected are excluded regardless of the inclusion rules
+func (f *NodeGroupFilter) SetSelected(names ...string) {
+	f.selectedNodegroups = sets.New[string](names...)
+}
+
 // SetExcludeAll sets the ExcludeAll flag in the filter so that no nodegroups are matched
 func (f *NodeGroupFilter) SetExcludeAll(excludeAll bool) {
 	f.delegate.ExcludeAll = excludeAll
@@ -232,6 +240,11 @@ func (f *NodeGroupFilter) matchAll(allNames sets.Set[string]) (sets.Set[string],
 
 	matching, notMatching := f.delegate.doMatchAll(sets.List(allNames))
 
+	if f.selectedNodegroups != nil {
+		matching = matching.Intersection(f.selectedNodegroups)
+		notMatching = allNames.Difference(matching)
+	}
+
 	if f.onlyLocal {
 		// From the ones that match, pick only the local ones
 		included := matching.Intersection(f.onlyLocalNodegroups())
@@ -252,6 +265,10 @@ func (f *NodeGroupFilter) matchAll(allNames sets.Set[string]) (sets.Set[string],
 // Match decides whether the given nodegroup is considered included by this filter. It takes into account not only the
 // inclusion and exclusion rules (globs) but also the modifiers onlyRemote and onlyLocal.
 func (f *NodeGroupFilter) Match(ngName string) bool {
+	if f.selectedNodegroups != nil && !f.selectedNodegroups.Has(ngName) {
+		return false
+	}
+
 	if f.onlyRemote {
 		if !f.onlyRemoteNodegroups().Has(ngName) {
 			return false
@@ -7,6 +7,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
 
@@ -69,6 +70,21 @@ var _ = Describe("nodegroup filter", func() {
 			Expect(excluded.HasAll("test-ng2a", "test-ng2b", "test-ng3a", "test-ng3b", "non-existing-in-cluster")).To(BeTrue())
 		})
 
+		It("should only match selected nodegroups that are also included by the rules", func() {
+			err := filter.AppendIncludeGlobs(getNodeGroupNames(cfg), "test-ng1?", "test-ng2?")
+			Expect(err).NotTo(HaveOccurred())
+			filter.SetSelected("test-ng1a", "test-ng2b", "test-ng3a")
+
+			Expect(filter.Match("test-ng1a")).To(BeTrue())
+			Expect(filter.Match("test-ng2b")).To(BeTrue())
+			Expect(filter.Match("test-ng1b")).To(BeFalse())
+			Expect(filter.Match("test-ng3a")).To(BeFalse())
+
+			included, excluded := filter.matchAll(filter.collectNames(cfg.NodeGroups))
+			Expect(sets.List(included)).To(Equal([]string{"test-ng1a", "test-ng2b"}))
+			Expect(sets.List(excluded)).To(Equal([]string{"test-ng1b", "test-ng2a", "test-ng3a", "test-ng3b"}))
+		})
+
 		It("only-missing (only-remote) works correctly", func() {
 			mockLister := newMockStackLister(
 				"test-ng1a",
@@ -20,13 +20,8 @@ func NewGitOpsConfigLoader(cmd *Cmd) *GitOpsConfigLoader {
 	}
 
 	l.validateWithConfigFile = func() error {
-		meta := l.cmd.ClusterConfig.Metadata
-		if meta.Name == "" {
-			return ErrMustBeSet("metadata.name")
-		}
-
-		if meta.Region == "" {
-			return ErrMustBeSet("metadata.region")
+		if err := validateGitOpsMetadata(l.cmd.ClusterConfig.Metadata); err != nil {
+			return err
 		}
 
 		if l.cmd.ClusterConfig.GitOps == nil || l.cmd.ClusterConfig.GitOps.Flux == nil {
@@ -42,12 +37,46 @@ func NewGitOpsConfigLoader(cmd *Cmd) *GitOpsConfigLoader {
 			return ErrMustBeSet("gitops.flux.flags")
 		}
 
-		return nil
+		return api.ValidateGitOps(l.cmd.ClusterConfig.GitOps)
+	}
+
+	return l
+}
+
+// NewArgoCDConfigLoader creates a new ClusterConfigLoader which handles
+// loading of ClusterConfigFile for `enable argocd`.
+func NewArgoCDConfigLoader(cmd *Cmd) *GitOpsConfigLoader {
+	l := &GitOpsConfigLoader{
+		cmd: cmd,
+	}
+
+	l.validateWithConfigFile = func() error {
+		if err := validateGitOpsMetadata(l.cmd.ClusterConfig.Metadata); err != nil {
+			return err
+		}
+
+		if !l.cmd.ClusterConfig.HasGitOpsArgoCDConfigured() {
+			return ErrMustBeSet("gitops.argocd")
+		}
+
+		return api.ValidateGitOps(l.cmd.ClusterConfig.GitOps)
 	}
 
 	return l
 }
 
+func validateGitOpsMetadata(meta *api.ClusterMeta) error {
+	if meta.Name == "" {
+		return ErrMustBeSet("metadata.name")
+	}
+
+	if meta.Region == "" {
+		return ErrMustBeSet("metadata.region")
+	}
+
+	return nil
+}
+
 // Load ClusterConfig or use CLI flags.
 func (l *GitOpsConfigLoader) Load() error {
 	if err := api.Register(); err != nil {
@@ -0,0 +1,31 @@
+package cmdutils
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers/helm"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
+)
+
+// NewHelmInstaller returns a Helm installer for charts installed in namespace with the credentials of the user
+func NewHelmInstaller(ctl *eks.ClusterProvider, cfg *api.ClusterConfig, namespace string) (providers.HelmInstaller, error) {
+	kubeConfigBytes, err := runtime.Encode(clientcmdlatest.Codec, kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), "", ctl.AWSProvider.Profile().Name))
+	if err != nil {
+		return nil, fmt.Errorf("generating kubeconfig: %w", err)
+	}
+	helmInstaller, err := helm.NewInstaller(helm.Options{
+		Namespace:        namespace,
+		RESTClientGetter: kubernetes.NewRESTClientGetter(namespace, string(kubeConfigBytes)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return helmInstaller, nil
+}
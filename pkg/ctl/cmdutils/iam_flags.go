@@ -21,3 +21,9 @@ func AddIAMIdentityMappingARNFlags(fs *pflag.FlagSet, cmd *Cmd, arn *string, ver
 	fs.StringVar(arn, "role", "", "")
 	_ = fs.MarkDeprecated("role", "use --arn")
 }
+
+// AddIAMServiceAccountTargetAccountFlag adds the --target-account flag, which takes the ARN of the role
+// assumed to manage the roles of iamserviceaccounts in another AWS account
+func AddIAMServiceAccountTargetAccountFlag(fs *pflag.FlagSet, roleARN *string, verb string) {
+	fs.StringVar(roleARN, "target-account", "", fmt.Sprintf("ARN of a role to assume in order to %s the IAM roles in another AWS account", verb))
+}
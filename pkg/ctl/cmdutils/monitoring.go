@@ -0,0 +1,73 @@
+package cmdutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/weaveworks/eksctl/pkg/actions/monitoring"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+var monitoringFlagsIncompatibleWithConfigFile = []string{
+	"collector",
+	"workspace-arn",
+	"adot-version",
+}
+
+// NewEnableMonitoringLoader will load config or use flags for 'eksctl enable monitoring'.
+func NewEnableMonitoringLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.flagsIncompatibleWithConfigFile.Insert(monitoringFlagsIncompatibleWithConfigFile...)
+	l.validateWithConfigFile = func() error {
+		if !cmd.ClusterConfig.HasPrometheus() {
+			return errors.New("monitoring.prometheus must be set in the config file")
+		}
+		return api.ValidateMonitoring(cmd.ClusterConfig.Monitoring)
+	}
+	l.validateWithoutConfigFile = func() error {
+		if err := validateCluster(cmd); err != nil {
+			return err
+		}
+		return api.ValidateMonitoring(cmd.ClusterConfig.Monitoring)
+	}
+	return l
+}
+
+// NewMonitoringInstaller returns the installer of the monitoring of cfg, with the clients its collector needs.
+func NewMonitoringInstaller(ctx context.Context, ctl *eks.ClusterProvider, cfg *api.ClusterConfig) (*monitoring.Installer, error) {
+	installer := &monitoring.Installer{
+		StackManager:       ctl.NewStackManager(cfg),
+		ClusterConfig:      cfg,
+		Cluster:            ctl.GetClusterState(),
+		AccessEntryEnabled: ctl.IsAccessEntryEnabled(),
+	}
+	if cfg.Monitoring.Prometheus.CollectorOrDefault() == api.PrometheusCollectorADOT {
+		oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		oidcProviderExists, err := oidc.CheckProviderExists(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !oidcProviderExists {
+			return nil, fmt.Errorf("no IAM OIDC provider associated with cluster, try 'eksctl utils associate-iam-oidc-provider --region=%s --cluster=%s'", cfg.Metadata.Region, cfg.Metadata.Name)
+		}
+
+		helmInstaller, err := NewHelmInstaller(ctl, cfg, monitoring.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		installer.OIDC = oidc
+		installer.HelmInstaller = helmInstaller
+	} else {
+		rawClient, err := ctl.NewRawClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		installer.RawClient = rawClient
+	}
+	return installer, nil
+}
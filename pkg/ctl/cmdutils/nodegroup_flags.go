@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/pflag"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils/progress"
 )
 
 // AddCommonCreateNodeGroupFlags adds common flags for creating a nodegroup
@@ -65,6 +66,9 @@ func AddCommonCreateNodeGroupAddonsFlags(fs *pflag.FlagSet, ng *api.NodeGroup, o
 	fs.BoolVarP(&options.InstallNeuronDevicePlugin, "install-neuron-plugin", "", true, "install Neuron plugin for Inferentia and Trainium nodes")
 	fs.BoolVarP(&options.InstallNvidiaDevicePlugin, "install-nvidia-plugin", "", true, "install Nvidia plugin for GPU nodes")
 	fs.IntVarP(&options.NodeGroupParallelism, "nodegroup-parallelism", "", 8, "Number of self-managed or managed nodegroups to create in parallel")
+	fs.IntVar(&options.WaitForReadyNodes, waitForReadyNodesFlagName, -1, "Number of Ready nodes to wait for in each nodegroup; -1 waits for the minimum size of the nodegroup and 0 skips waiting")
+	fs.StringVar(&options.ShowTaskGraph, "show-task-graph", "", "print the graph of the tasks to run and their dependencies before running them (valid options: dot, mermaid)")
+	fs.StringVar(&options.Progress, "progress", string(progress.ModeAuto), "display the tasks in flight and a summary of the created resources; auto only does so when writing to a terminal (valid options: auto, always, never)")
 }
 
 // AddInstanceSelectorOptions adds flags for EC2 instance selector
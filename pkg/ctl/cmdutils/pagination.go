@@ -0,0 +1,73 @@
+package cmdutils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/pflag"
+)
+
+// PaginationOptions holds the options for returning a list one page at a time
+type PaginationOptions struct {
+	// Limit is the maximum number of items to return, 0 means all items
+	Limit int
+	// Continue is the token returned along with the previous page
+	Continue string
+}
+
+// Enabled returns true if a page of items is requested rather than all of them
+func (o PaginationOptions) Enabled() bool {
+	return o.Limit > 0 || o.Continue != ""
+}
+
+// Page is a page of a list, along with the token for fetching the next page
+type Page[T any] struct {
+	Items []T `json:"items"`
+	// NextToken is set if there are more items, pass it to --continue to get them
+	NextToken string `json:"nextToken,omitempty"`
+}
+
+// AddPaginationFlags adds the --limit and --continue flags
+func AddPaginationFlags(fs *pflag.FlagSet, options *PaginationOptions) {
+	fs.IntVar(&options.Limit, "limit", 0, "maximum number of items to return, along with a token for listing the next ones with --continue; 0 returns all items")
+	fs.StringVar(&options.Continue, "continue", "", "token returned by a previous call with --limit, to list the next items")
+}
+
+// Paginate returns the page of items requested by options. Pages are ordered by the key of the items,
+// and tokens hold the key of the last item of the page, so that pages remain consistent when items
+// are added or removed between calls.
+func Paginate[T any](items []T, key func(T) string, options PaginationOptions) (Page[T], error) {
+	if options.Limit < 0 {
+		return Page[T]{}, fmt.Errorf("--limit must be a positive number, got %d", options.Limit)
+	}
+	if !options.Enabled() {
+		return Page[T]{Items: items}, nil
+	}
+
+	var after string
+	if options.Continue != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(options.Continue)
+		if err != nil || len(decoded) == 0 {
+			return Page[T]{}, fmt.Errorf("invalid value %q for --continue", options.Continue)
+		}
+		after = string(decoded)
+	}
+
+	sorted := make([]T, 0, len(items))
+	for _, item := range items {
+		if after == "" || key(item) > after {
+			sorted = append(sorted, item)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return key(sorted[i]) < key(sorted[j])
+	})
+
+	page := Page[T]{Items: sorted}
+	if options.Limit > 0 && len(sorted) > options.Limit {
+		page.Items = sorted[:options.Limit]
+		page.NextToken = base64.RawURLEncoding.EncodeToString([]byte(key(page.Items[options.Limit-1])))
+	}
+	return page, nil
+}
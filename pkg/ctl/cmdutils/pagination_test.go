@@ -0,0 +1,49 @@
+package cmdutils_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("Paginate", func() {
+	items := []string{"ng-3", "ng-1", "ng-4", "ng-2", "ng-5"}
+	key := func(s string) string { return s }
+
+	It("returns all items when no page is requested", func() {
+		page, err := cmdutils.Paginate(items, key, cmdutils.PaginationOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page.Items).To(Equal(items))
+		Expect(page.NextToken).To(BeEmpty())
+	})
+
+	It("returns consecutive pages until all items have been listed", func() {
+		options := cmdutils.PaginationOptions{Limit: 2}
+		var pages [][]string
+		for {
+			page, err := cmdutils.Paginate(items, key, options)
+			Expect(err).NotTo(HaveOccurred())
+			pages = append(pages, page.Items)
+			if page.NextToken == "" {
+				break
+			}
+			options.Continue = page.NextToken
+		}
+		Expect(pages).To(Equal([][]string{{"ng-1", "ng-2"}, {"ng-3", "ng-4"}, {"ng-5"}}))
+	})
+
+	It("returns the remaining items when continuing without a limit", func() {
+		page, err := cmdutils.Paginate(items, key, cmdutils.PaginationOptions{Limit: 3})
+		Expect(err).NotTo(HaveOccurred())
+
+		page, err = cmdutils.Paginate(items, key, cmdutils.PaginationOptions{Continue: page.NextToken})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page.Items).To(Equal([]string{"ng-4", "ng-5"}))
+	})
+
+	It("rejects an invalid token", func() {
+		_, err := cmdutils.Paginate(items, key, cmdutils.PaginationOptions{Continue: "not a token!"})
+		Expect(err).To(MatchError(`invalid value "not a token!" for --continue`))
+	})
+})
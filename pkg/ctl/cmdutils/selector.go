@@ -0,0 +1,105 @@
+package cmdutils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
+)
+
+const nodeGroupSelectorFlag = "selector"
+
+// AddNodeGroupSelectorFlag adds the flag to target nodegroups by their labels or tags instead of their name
+func AddNodeGroupSelectorFlag(fs *pflag.FlagSet, selector *string) {
+	fs.StringVar(selector, nodeGroupSelectorFlag, "",
+		"target the nodegroups whose Kubernetes labels or tags match a label selector, e.g.: 'role=batch,env!=prod'")
+}
+
+// ValidateNodeGroupSelector checks that the selector is not used together with a nodegroup name
+func ValidateNodeGroupSelector(cmd *Cmd, ngName string) error {
+	if cmd.Selector == "" {
+		return nil
+	}
+	if ngName != "" || cmd.NameArg != "" {
+		return fmt.Errorf("--%s cannot be used with --name or a nodegroup name argument", nodeGroupSelectorFlag)
+	}
+	_, err := parseNodeGroupSelector(cmd.Selector)
+	return err
+}
+
+func parseNodeGroupSelector(selector string) (labels.Selector, error) {
+	s, err := labels.Parse(selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing --%s %q", nodeGroupSelectorFlag, selector)
+	}
+	return s, nil
+}
+
+// SelectNodeGroupsFromConfig returns the names of the nodegroups in the config file whose labels
+// or tags match the selector
+func SelectNodeGroupsFromConfig(selector string, cfg *api.ClusterConfig) ([]string, error) {
+	s, err := parseNodeGroupSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, ng := range cfg.AllNodeGroups() {
+		// labels take precedence over tags with the same key
+		set := labels.Merge(ng.Tags, ng.Labels)
+		if s.Matches(set) {
+			names = append(names, ng.Name)
+		}
+	}
+	return names, logSelectedNodeGroups(selector, names)
+}
+
+// SelectNodeGroupsFromNodes returns the names of the nodegroups that have nodes with Kubernetes labels
+// matching the selector; nodegroups without any nodes cannot be selected this way
+func SelectNodeGroupsFromNodes(ctx context.Context, clientSet kubernetes.Interface, selector string) ([]string, error) {
+	if _, err := parseNodeGroupSelector(selector); err != nil {
+		return nil, err
+	}
+	nodes, err := clientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing nodes")
+	}
+	names := sets.New[string]()
+	for _, node := range nodes.Items {
+		name := node.Labels[api.NodeGroupNameLabel]
+		if name == "" {
+			name = node.Labels[api.EKSNodeGroupNameLabel]
+		}
+		if name != "" {
+			names.Insert(name)
+		}
+	}
+	return sets.List(names), logSelectedNodeGroups(selector, sets.List(names))
+}
+
+// ApplyNodeGroupSelector restricts the filter to the nodegroups in the config file matching the selector
+func ApplyNodeGroupSelector(selector string, cfg *api.ClusterConfig, ngFilter *filter.NodeGroupFilter) error {
+	names, err := SelectNodeGroupsFromConfig(selector, cfg)
+	if err != nil {
+		return err
+	}
+	ngFilter.SetSelected(names...)
+	return nil
+}
+
+func logSelectedNodeGroups(selector string, names []string) error {
+	if len(names) == 0 {
+		return fmt.Errorf("no nodegroups match selector %q", selector)
+	}
+	logger.Info("%d nodegroup(s) match selector %q: %s", len(names), selector, strings.Join(names, ", "))
+	return nil
+}
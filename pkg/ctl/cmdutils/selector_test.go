@@ -0,0 +1,85 @@
+package cmdutils
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("nodegroup selector", func() {
+	Context("SelectNodeGroupsFromConfig", func() {
+		var cfg *api.ClusterConfig
+
+		BeforeEach(func() {
+			cfg = api.NewClusterConfig()
+			ng := cfg.NewNodeGroup()
+			ng.Name = "batch"
+			ng.Labels = map[string]string{"role": "batch"}
+			mng := api.NewManagedNodeGroup()
+			mng.Name = "batch-prod"
+			mng.Labels = map[string]string{"role": "batch"}
+			mng.Tags = map[string]string{"env": "prod"}
+			cfg.ManagedNodeGroups = append(cfg.ManagedNodeGroups, mng)
+			web := cfg.NewNodeGroup()
+			web.Name = "web"
+			web.Tags = map[string]string{"role": "web", "env": "prod"}
+		})
+
+		It("matches nodegroups by their labels and tags", func() {
+			names, err := SelectNodeGroupsFromConfig("role=batch", cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(ConsistOf("batch", "batch-prod"))
+
+			names, err = SelectNodeGroupsFromConfig("env=prod", cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(ConsistOf("batch-prod", "web"))
+
+			names, err = SelectNodeGroupsFromConfig("role=batch,env!=prod", cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(ConsistOf("batch"))
+		})
+
+		It("fails when no nodegroups match", func() {
+			_, err := SelectNodeGroupsFromConfig("role=gpu", cfg)
+			Expect(err).To(MatchError(`no nodegroups match selector "role=gpu"`))
+		})
+
+		It("fails on an invalid selector", func() {
+			_, err := SelectNodeGroupsFromConfig("role==", cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`parsing --selector "role=="`))
+		})
+	})
+
+	Context("SelectNodeGroupsFromNodes", func() {
+		newNode := func(name string, labels map[string]string) *corev1.Node {
+			return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+		}
+
+		It("returns the nodegroups of the matching nodes", func() {
+			clientSet := fake.NewSimpleClientset(
+				newNode("node-1", map[string]string{"role": "batch", api.NodeGroupNameLabel: "batch"}),
+				newNode("node-2", map[string]string{"role": "batch", api.NodeGroupNameLabel: "batch"}),
+				newNode("node-3", map[string]string{"role": "batch", api.EKSNodeGroupNameLabel: "managed-batch"}),
+				newNode("node-4", map[string]string{"role": "web", api.NodeGroupNameLabel: "web"}),
+				newNode("node-5", map[string]string{"role": "batch"}),
+			)
+			names, err := SelectNodeGroupsFromNodes(context.Background(), clientSet, "role=batch")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(Equal([]string{"batch", "managed-batch"}))
+		})
+
+		It("fails when no nodes match", func() {
+			clientSet := fake.NewSimpleClientset(newNode("node-1", map[string]string{"role": "web", api.NodeGroupNameLabel: "web"}))
+			_, err := SelectNodeGroupsFromNodes(context.Background(), clientSet, "role=batch")
+			Expect(err).To(MatchError(`no nodegroups match selector "role=batch"`))
+		})
+	})
+})
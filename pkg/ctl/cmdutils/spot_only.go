@@ -0,0 +1,67 @@
+package cmdutils
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/spf13/cobra"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils/names"
+)
+
+const (
+	// spotOnlyOnDemandBaseCapacity is the number of on-demand nodes kept as a fallback
+	// for when spot capacity is unavailable
+	spotOnlyOnDemandBaseCapacity = 1
+
+	spotOnlyDefaultVCPUs  = 2
+	spotOnlyDefaultMemory = "8"
+)
+
+// validateSpotOnlyFlags ensures flags that conflict with the instance types and capacity chosen by --spot-only are not set
+func validateSpotOnlyFlags(cmd *cobra.Command, params *CreateClusterCmdParams) error {
+	if !params.SpotOnly {
+		return nil
+	}
+	if flagName, found := findChangedFlag(cmd, []string{"without-nodegroup", "fargate", "spot", "instance-types", "node-type"}); found {
+		return fmt.Errorf("--%s cannot be used with --spot-only", flagName)
+	}
+	return nil
+}
+
+// applySpotOnlyPreset configures the initial nodegroups following spot best practices: instance types are selected
+// by attributes to diversify across instance families, spot capacity is allocated from the pools least likely to be
+// interrupted, instances at risk of interruption are replaced proactively and a small on-demand base capacity
+// keeps the cluster schedulable when spot capacity is unavailable
+func applySpotOnlyPreset(clusterConfig *api.ClusterConfig, ng *api.NodeGroup, managed bool) {
+	if ng.InstanceSelector.VCPUs == 0 && ng.InstanceSelector.Memory == "" {
+		ng.InstanceSelector.VCPUs = spotOnlyDefaultVCPUs
+		ng.InstanceSelector.Memory = spotOnlyDefaultMemory
+	}
+
+	if !managed {
+		ng.InstancesDistribution = &api.NodeGroupInstancesDistribution{
+			OnDemandBaseCapacity:                aws.Int(spotOnlyOnDemandBaseCapacity),
+			OnDemandPercentageAboveBaseCapacity: aws.Int(0),
+			SpotAllocationStrategy:              aws.String(api.SpotAllocationStrategyCapacityOptimized),
+			CapacityRebalance:                   true,
+		}
+		clusterConfig.NodeGroups = []*api.NodeGroup{ng}
+		return
+	}
+
+	// managed nodegroups use capacity-optimized allocation and capacity rebalancing for spot instances,
+	// but cannot mix spot and on-demand capacity, so the on-demand base capacity is a separate nodegroup
+	baseName := names.ForNodeGroup(ng.Name, "")
+	spot := makeManagedNodegroup(ng, CreateManagedNGOptions{Spot: true})
+	spot.Name = baseName + "-spot"
+	onDemand := makeManagedNodegroup(ng, CreateManagedNGOptions{})
+	onDemand.Name = baseName + "-on-demand"
+	onDemand.ScalingConfig = &api.ScalingConfig{
+		DesiredCapacity: aws.Int(spotOnlyOnDemandBaseCapacity),
+		MinSize:         aws.Int(spotOnlyOnDemandBaseCapacity),
+		MaxSize:         aws.Int(spotOnlyOnDemandBaseCapacity),
+	}
+	clusterConfig.ManagedNodeGroups = []*api.ManagedNodeGroup{spot, onDemand}
+}
@@ -0,0 +1,37 @@
+package cmdutils
+
+import (
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// NewUpgradeNodeGroupLoader will load config or use flags for 'eksctl upgrade nodegroup'
+func NewUpgradeNodeGroupLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.flagsIncompatibleWithConfigFile.Insert(
+		"max-unavailable",
+		"max-unavailable-percentage",
+	)
+	l.flagsIncompatibleWithConfigFile.Delete("name")
+
+	l.validateWithConfigFile = func() error {
+		for _, ng := range l.ClusterConfig.ManagedNodeGroups {
+			if ng.UpdateConfig == nil {
+				continue
+			}
+			if err := api.ValidateNodeGroupUpdateConfig(ng.UpdateConfig); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	l.validateWithoutConfigFile = func() error {
+		if l.ClusterConfig.Metadata.Name == "" {
+			return ErrMustBeSet(ClusterNameFlag(cmd))
+		}
+		return nil
+	}
+
+	return l
+}
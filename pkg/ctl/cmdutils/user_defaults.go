@@ -0,0 +1,121 @@
+package cmdutils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+// EnvUserDefaultsFile is the environment variable for overriding the path of the user defaults file
+const EnvUserDefaultsFile = "EKSCTL_USER_DEFAULTS_FILE"
+
+// UserDefaults holds the default values of common flags, so that they need not be passed on every invocation
+type UserDefaults struct {
+	// Region is the default of --region
+	Region string `json:"region,omitempty"`
+	// Profile is the default of --profile, the AWS_PROFILE environment variable takes precedence
+	Profile string `json:"profile,omitempty"`
+	// Tags is the default of --tags
+	Tags map[string]string `json:"tags,omitempty"`
+	// Timeout is the default of --timeout, e.g. 40m
+	Timeout string `json:"timeout,omitempty"`
+	// Output is the default of --output
+	Output string `json:"output,omitempty"`
+}
+
+// getUserDefaultsFilePath returns the path of the user defaults file
+func getUserDefaultsFilePath() (string, error) {
+	if filename := os.Getenv(EnvUserDefaultsFile); filename != "" {
+		return filename, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".eksctl", "config.yaml"), nil
+}
+
+// LoadUserDefaults reads the user defaults file, a missing file holds no defaults
+func LoadUserDefaults(filename string) (*UserDefaults, error) {
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return &UserDefaults{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading user defaults file %q", filename)
+	}
+	var defaults UserDefaults
+	if err := yaml.UnmarshalStrict(data, &defaults); err != nil {
+		return nil, errors.Wrapf(err, "parsing user defaults file %q", filename)
+	}
+	if defaults.Timeout != "" {
+		if _, err := time.ParseDuration(defaults.Timeout); err != nil {
+			return nil, errors.Wrapf(err, "parsing timeout of user defaults file %q", filename)
+		}
+	}
+	return &defaults, nil
+}
+
+// flagValues returns the values of the defaults keyed by the name of the flag they apply to
+func (d *UserDefaults) flagValues() map[string]string {
+	values := map[string]string{
+		"region":  d.Region,
+		"profile": d.Profile,
+		"timeout": d.Timeout,
+		"output":  d.Output,
+	}
+	if len(d.Tags) > 0 {
+		var tags []string
+		for k, v := range d.Tags {
+			tags = append(tags, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(tags)
+		values["tags"] = strings.Join(tags, ",")
+	}
+	return values
+}
+
+// Apply sets the flags of fs that were neither passed on the command line nor set otherwise, e.g. from
+// the environment, to their default values; values from config files are applied later, so they take precedence
+func (d *UserDefaults) Apply(fs *pflag.FlagSet) error {
+	for name, value := range d.flagValues() {
+		if value == "" {
+			continue
+		}
+		flag := fs.Lookup(name)
+		if flag == nil || flag.Changed || flag.Value.String() != flag.DefValue {
+			continue
+		}
+		if err := flag.Value.Set(value); err != nil {
+			return errors.Wrapf(err, "invalid default value %q for --%s", value, name)
+		}
+	}
+	return nil
+}
+
+// addUserDefaults applies the user defaults to the flags of cmd once they have been parsed
+func addUserDefaults(cmd *cobra.Command) {
+	AddPreRun(cmd, func(cobraCmd *cobra.Command, _ []string) {
+		filename, err := getUserDefaultsFilePath()
+		if err != nil {
+			logger.Debug("not using user defaults: %v", err)
+			return
+		}
+		defaults, err := LoadUserDefaults(filename)
+		if err == nil {
+			err = defaults.Apply(cobraCmd.Flags())
+		}
+		if err != nil {
+			logger.Warning("ignoring user defaults: %v", err)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package cmdutils
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("user defaults", func() {
+	var filename string
+
+	BeforeEach(func() {
+		filename = filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		GinkgoT().Setenv(EnvUserDefaultsFile, filename)
+		GinkgoT().Setenv("AWS_PROFILE", "")
+		Expect(os.Unsetenv("AWS_PROFILE")).To(Succeed())
+	})
+
+	run := func(args ...string) *Cmd {
+		var cmd *Cmd
+		verbCmd := NewVerbCmd("create", "", "")
+		AddResourceCmd(NewGrouping(), verbCmd, func(c *Cmd) {
+			cmd = c
+			c.ClusterConfig = api.NewClusterConfig()
+			c.SetDescription("cluster", "", "")
+			c.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+				AddRegionFlag(fs, &c.ProviderConfig)
+				AddTimeoutFlag(fs, &c.ProviderConfig.WaitTimeout)
+				AddStringToStringVarPFlag(fs, &c.ClusterConfig.Metadata.Tags, "tags", "", map[string]string{}, "Used to tag the AWS resources")
+			})
+			AddCommonFlagsForAWS(c, &c.ProviderConfig, false)
+			c.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+				return nil
+			}
+		})
+		verbCmd.SetArgs(append([]string{"cluster"}, args...))
+		Expect(verbCmd.Execute()).To(Succeed())
+		return cmd
+	}
+
+	It("applies the defaults beneath the flags", func() {
+		Expect(os.WriteFile(filename, []byte(`
+region: eu-west-1
+profile: dev
+timeout: 40m
+tags:
+  team: platform
+`), 0600)).To(Succeed())
+
+		cmd := run()
+		Expect(cmd.ProviderConfig.Region).To(Equal("eu-west-1"))
+		Expect(cmd.ProviderConfig.Profile.Name).To(Equal("dev"))
+		Expect(cmd.ProviderConfig.WaitTimeout).To(Equal(40 * time.Minute))
+		Expect(cmd.ClusterConfig.Metadata.Tags).To(Equal(map[string]string{"team": "platform"}))
+
+		cmd = run("--region", "us-west-2", "--timeout", "5m")
+		Expect(cmd.ProviderConfig.Region).To(Equal("us-west-2"))
+		Expect(cmd.ProviderConfig.WaitTimeout).To(Equal(5 * time.Minute))
+	})
+
+	It("lets AWS_PROFILE take precedence over the default profile", func() {
+		Expect(os.WriteFile(filename, []byte("profile: dev\n"), 0600)).To(Succeed())
+		GinkgoT().Setenv("AWS_PROFILE", "prod")
+		cmd := run()
+		Expect(cmd.ProviderConfig.Profile.Name).To(Equal("prod"))
+	})
+
+	It("does not require the file to exist", func() {
+		cmd := run()
+		Expect(cmd.ProviderConfig.Region).To(BeEmpty())
+	})
+
+	It("rejects unknown keys and invalid timeouts", func() {
+		Expect(os.WriteFile(filename, []byte("regoin: eu-west-1\n"), 0600)).To(Succeed())
+		_, err := LoadUserDefaults(filename)
+		Expect(err).To(MatchError(ContainSubstring("parsing user defaults file")))
+
+		Expect(os.WriteFile(filename, []byte("timeout: forever\n"), 0600)).To(Succeed())
+		_, err = LoadUserDefaults(filename)
+		Expect(err).To(MatchError(ContainSubstring("parsing timeout of user defaults file")))
+	})
+})
@@ -3,23 +3,28 @@ package cmdutils
 import (
 	"context"
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
-// KubernetesClientAndConfigFrom returns a Kubernetes client set and REST
-// configuration object for the currently configured cluster.
-func KubernetesClientAndConfigFrom(cmd *Cmd) (kubernetes.Interface, error) {
+// KubernetesClientAndConfigFrom returns a Kubernetes client set and a dynamic
+// client for the currently configured cluster.
+func KubernetesClientAndConfigFrom(cmd *Cmd) (kubernetes.Interface, dynamic.Interface, error) {
 	ctl, err := cmd.NewProviderForExistingCluster(context.TODO())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	cfg := cmd.ClusterConfig
 	if ok, err := ctl.CanOperate(cfg); !ok {
-		return nil, err
+		return nil, nil, err
 	}
-	k8sClientSet, err := ctl.NewStdClientSet(cfg)
+	rawClient, err := ctl.NewRawClient(cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return k8sClientSet, nil
+	dynamicClient, err := rawClient.NewDynamicClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	return rawClient.ClientSet(), dynamicClient, nil
 }
@@ -94,6 +94,12 @@ func createAddonCmd(cmd *cmdutils.Cmd) {
 			return err
 		}
 
+		for _, a := range cmd.ClusterConfig.Addons {
+			if err := addonManager.ValidateConfigurationValues(ctx, a); err != nil {
+				return err
+			}
+		}
+
 		iamRoleCreator := &podidentityassociation.IAMRoleCreator{
 			ClusterName:  cmd.ClusterConfig.Metadata.Name,
 			StackCreator: stackManager,
@@ -0,0 +1,127 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/backups"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
+)
+
+func createBackupCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"backup",
+		"Create a backup of the cluster with Velero",
+		"Runs 'velero backup create' against the cluster. Backups must be enabled with 'eksctl enable backups' first. Arguments after '--' are passed to velero",
+	)
+	cmd.CobraCommand.Example = "  eksctl create backup nightly --cluster my-cluster -- --include-namespaces default"
+
+	cmd.CobraCommand.RunE = func(c *cobra.Command, args []string) error {
+		name, extraArgs, err := splitVeleroArgs(c, args)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			return errors.New("backup name must be set")
+		}
+		if err := cmdutils.NewBackupsLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return runVelero(cmd, append([]string{"backup", "create", name}, extraArgs...))
+	}
+
+	addBackupsGeneralFlags(cmd)
+}
+
+func createRestoreCmd(cmd *cmdutils.Cmd) {
+	var fromBackup string
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"restore",
+		"Restore a backup of the cluster with Velero",
+		"Runs 'velero restore create' against the cluster. Arguments after '--' are passed to velero",
+	)
+	cmd.CobraCommand.Example = "  eksctl create restore --cluster my-cluster --from-backup nightly"
+
+	cmd.CobraCommand.RunE = func(c *cobra.Command, args []string) error {
+		name, extraArgs, err := splitVeleroArgs(c, args)
+		if err != nil {
+			return err
+		}
+		if fromBackup == "" {
+			return errors.New("--from-backup must be set")
+		}
+		if err := cmdutils.NewBackupsLoader(cmd).Load(); err != nil {
+			return err
+		}
+		veleroArgs := []string{"restore", "create"}
+		if name != "" {
+			veleroArgs = append(veleroArgs, name)
+		}
+		veleroArgs = append(veleroArgs, "--from-backup", fromBackup)
+		return runVelero(cmd, append(veleroArgs, extraArgs...))
+	}
+
+	cmd.FlagSetGroup.InFlagSet("Restore", func(fs *pflag.FlagSet) {
+		fs.StringVar(&fromBackup, "from-backup", "", "name of the backup to restore")
+	})
+	addBackupsGeneralFlags(cmd)
+}
+
+func addBackupsGeneralFlags(cmd *cmdutils.Cmd) {
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+// splitVeleroArgs returns the name argument and the arguments after '--', which are passed to velero.
+func splitVeleroArgs(c *cobra.Command, args []string) (string, []string, error) {
+	nameArgs, extraArgs := args, []string(nil)
+	if dash := c.ArgsLenAtDash(); dash >= 0 {
+		nameArgs, extraArgs = args[:dash], args[dash:]
+	}
+	if len(nameArgs) > 1 {
+		return "", nil, errors.New("only one argument is allowed to be used as a name, pass velero arguments after '--'")
+	}
+	return cmdutils.GetNameArg(nameArgs), extraArgs, nil
+}
+
+func runVelero(cmd *cmdutils.Cmd, args []string) error {
+	ctx := context.TODO()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cmd.ClusterConfig); !ok {
+		return err
+	}
+
+	kubeCfgPath, err := os.CreateTemp("", cmd.ClusterConfig.Metadata.Name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.Remove(kubeCfgPath.Name()); err != nil {
+			logger.Critical("failed to remove temporary kubeconfig %s", kubeCfgPath.Name())
+		}
+	}()
+	logger.Debug("writing temporary kubeconfig to %s", kubeCfgPath.Name())
+	kubectlConfig := kubeconfig.NewForKubectl(cmd.ClusterConfig, eks.GetUsername(ctl.Status.IAMRoleARN), "", ctl.AWSProvider.Profile().Name)
+	if _, err := kubeconfig.Write(kubeCfgPath.Name(), *kubectlConfig, true); err != nil {
+		return err
+	}
+	return backups.RunVelero(ctx, kubeCfgPath.Name(), args)
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/amazon-ec2-instance-selector/v2/pkg/selector"
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -22,21 +24,29 @@ import (
 	"github.com/weaveworks/eksctl/pkg/accessentry"
 	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
 	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/argocd"
+	"github.com/weaveworks/eksctl/pkg/actions/clusterbootstrap"
 	"github.com/weaveworks/eksctl/pkg/actions/flux"
 	"github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
 	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+	"github.com/weaveworks/eksctl/pkg/integrations"
+	"github.com/weaveworks/eksctl/pkg/inventory"
 	"github.com/weaveworks/eksctl/pkg/kops"
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
 	"github.com/weaveworks/eksctl/pkg/outposts"
 	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/utils/exitcode"
 	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
 	"github.com/weaveworks/eksctl/pkg/utils/names"
 	"github.com/weaveworks/eksctl/pkg/utils/nodes"
+	"github.com/weaveworks/eksctl/pkg/utils/progress"
 	"github.com/weaveworks/eksctl/pkg/vpc"
 )
 
@@ -96,6 +106,17 @@ func createClusterCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.C
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
+		if params.FromSnapshot != "" {
+			if err := loadClusterSnapshot(cmd, params); err != nil {
+				return err
+			}
+		}
+		if params.Interactive {
+			create, err := runClusterWizard(cmd, params)
+			if err != nil || !create {
+				return err
+			}
+		}
 		ngFilter := filter.NewNodeGroupFilter()
 		if err := cmdutils.NewCreateClusterLoader(cmd, ngFilter, ng, params).Load(); err != nil {
 			return err
@@ -104,6 +125,9 @@ func createClusterCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.C
 		if err != nil {
 			return err
 		}
+		if params.RenderCFNOnly {
+			return renderClusterTemplates(cmd, ngFilter, params)
+		}
 		return runFunc(cmd, ngFilter, params)
 	}
 
@@ -113,15 +137,23 @@ func createClusterCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.C
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", fmt.Sprintf("EKS cluster name (generated if unspecified, e.g. %q)", exampleClusterName))
 		cmdutils.AddStringToStringVarPFlag(fs, &cfg.Metadata.Tags, "tags", "", map[string]string{}, "Used to tag the AWS resources")
+		cmdutils.AddRequiredTagsFlag(fs, &params.RequiredTags)
+		fs.StringVar(&cfg.Metadata.TTL, "ttl", "", "how long the cluster is meant to live for, e.g. 4h; expired clusters are deleted by `eksctl gc --expired`")
+		fs.BoolVar(&params.EnableTerminationProtection, "enable-termination-protection", false, "enable termination protection on the cluster stack, `eksctl delete cluster` then requires --disable-protection")
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		fs.BoolVar(cfg.IAM.WithOIDC, "with-oidc", false, "Enable the IAM OIDC provider")
 		fs.StringSliceVar(&params.AvailabilityZones, "zones", nil, "(auto-select if unspecified)")
 		cmdutils.AddVersionFlag(fs, cfg.Metadata, "")
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+		cmdutils.AddStackParallelismFlag(fs, cmd, "nodegroups, iamserviceaccounts and addons")
 		fs.BoolVarP(&params.InstallWindowsVPCController, "install-vpc-controllers", "", false, "Install VPC controller that's required for Windows workloads")
 		fs.BoolVarP(&params.Fargate, "fargate", "", false, "Create a Fargate profile scheduling pods in the default and kube-system namespaces onto Fargate")
 		fs.BoolVarP(&params.DryRun, "dry-run", "", false, "Dry-run mode that skips cluster creation and outputs a ClusterConfig")
+		fs.StringVar(&params.FromSnapshot, "from-snapshot", "", "create a cluster equivalent to the one archived by `eksctl utils snapshot-cluster` at a file path or s3://<bucket>/<key>; --name and --region rename and move the cluster")
+		fs.BoolVar(&params.Interactive, "interactive", false, "build the ClusterConfig by answering questions, then review it before the cluster is created")
+		fs.BoolVar(&params.RenderCFNOnly, "render-cfn-only", false, "write the CloudFormation templates of the cluster, nodegroups and IAM service accounts to --render-dir without calling the AWS API")
+		fs.StringVar(&params.RenderDir, "render-dir", "cfn-templates", "directory to write the CloudFormation templates to when --render-cfn-only is set")
 
 		_ = fs.MarkDeprecated("install-vpc-controllers", vpcControllerInfoMessage)
 	})
@@ -129,11 +161,13 @@ func createClusterCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.C
 	cmd.FlagSetGroup.InFlagSet("Initial nodegroup", func(fs *pflag.FlagSet) {
 		fs.StringVar(&ng.Name, "nodegroup-name", "", fmt.Sprintf("name of the nodegroup (generated if unspecified, e.g. %q)", exampleNodeGroupName))
 		fs.BoolVar(&params.WithoutNodeGroup, "without-nodegroup", false, "if set, initial nodegroup will not be created")
+		fs.BoolVar(&params.SpotOnly, "spot-only", false, "create cost-optimized spot nodegroups diversified across instance families, with a small on-demand base capacity")
 		cmdutils.AddCommonCreateNodeGroupFlags(fs, cmd, ng, &params.CreateManagedNGOptions)
 	})
 
 	cmd.FlagSetGroup.InFlagSet("Cluster and nodegroup add-ons", func(fs *pflag.FlagSet) {
 		cmdutils.AddCommonCreateNodeGroupAddonsFlags(fs, ng, &params.CreateNGOptions)
+		fs.BoolVar(&cfg.AddonsConfig.DisableDefaultAddons, "without-default-addons", false, "if set, VPC CNI, CoreDNS and kube-proxy will not be installed, e.g. to install Cilium or Calico instead; requires --without-nodegroup")
 	})
 
 	cmd.FlagSetGroup.InFlagSet("VPC networking", func(fs *pflag.FlagSet) {
@@ -165,6 +199,20 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 	if meta.Name != "" && api.IsInvalidNameArg(meta.Name) {
 		return api.ErrInvalidName(meta.Name)
 	}
+	if params.EnableTerminationProtection {
+		if cfg.CloudFormation == nil {
+			cfg.CloudFormation = &api.ClusterCloudFormation{}
+		}
+		cfg.CloudFormation.TerminationProtection = api.Enabled()
+	}
+	taskGraphFormat, err := params.TaskGraphFormat()
+	if err != nil {
+		return err
+	}
+	progressMode, err := params.ProgressMode()
+	if err != nil {
+		return err
+	}
 	printer := printers.NewJSONPrinter()
 
 	if params.DryRun {
@@ -198,6 +246,7 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 		logger.Warning("security group rules may be added by eksctl; see vpc.manageSharedNodeSecurityGroupRules to disable this behavior")
 	}
 
+	cmdutils.ApplyKubeconfigConfig(cmd, &params.KubeconfigPath, &params.SetContext)
 	if params.AutoKubeconfigPath {
 		if params.KubeconfigPath != kubeconfig.DefaultPath() {
 			return fmt.Errorf("--kubeconfig and --auto-kubeconfig %s", cmdutils.IncompatibleFlags)
@@ -249,6 +298,23 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 			return fmt.Errorf("flux binary is required when gitops configuration is set: %w", err)
 		}
 	}
+	// the pod execution role created with the cluster is allowed to write to the destination of the logs
+	fargate.WarnLoggingPermissions(cfg, !api.IsSetAndNonEmptyString(cfg.IAM.FargatePodExecutionRoleARN))
+	if cfg.HasPrometheus() && cfg.Monitoring.Prometheus.CollectorOrDefault() == api.PrometheusCollectorADOT && !api.IsEnabled(cfg.IAM.WithOIDC) {
+		return errors.New("iam.withOIDC must be enabled to send the metrics of the cluster with the ADOT collector")
+	}
+
+	var integrationRegistry *integrations.Registry
+	if len(cfg.Integrations) > 0 {
+		var discoveryErr error
+		integrationRegistry, discoveryErr = integrations.Default()
+		if err := integrations.CheckInstalled(integrationRegistry, cfg); err != nil {
+			if discoveryErr != nil {
+				return fmt.Errorf("%w\n%v", err, discoveryErr)
+			}
+			return err
+		}
+	}
 
 	if params.InstallWindowsVPCController {
 		if !eks.SupportsWindowsWorkloads(kubeNodeGroups) {
@@ -314,10 +380,20 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 		return cmdutils.PrintDryRunConfig(cfg, cmd.CobraCommand.OutOrStdout())
 	}
 
+	stopProgress := progress.Start(progressMode, os.Stdout)
+	defer stopProgress()
+
 	if err := nodeGroupService.Normalize(ctx, nodePools, cfg); err != nil {
 		return err
 	}
 
+	if err := meta.SetExpiryTag(time.Now()); err != nil {
+		return err
+	}
+	if meta.TTL != "" {
+		logger.Info("cluster will expire at %s; run `eksctl gc --expired` to delete expired clusters", meta.Tags[api.ClusterExpiresAtTag])
+	}
+
 	logger.Info("using Kubernetes version %s", meta.Version)
 	logger.Info("creating %s", cfg.LogString())
 
@@ -330,6 +406,19 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 	}
 
 	stackManager := ctl.NewStackManager(cfg)
+
+	// validate the configuration values of the addons before any stack is created, as they are only created
+	// once the cluster is
+	addonManager, err := addon.New(cfg, ctl.AWSProvider.EKS(), stackManager, api.IsEnabled(cfg.IAM.WithOIDC), nil, nil)
+	if err != nil {
+		return err
+	}
+	for _, a := range cfg.Addons {
+		if err := addonManager.ValidateNewAddonConfigurationValues(ctx, a); err != nil {
+			return err
+		}
+	}
+
 	if cmd.ClusterConfigFile == "" {
 		logMsg := func(resource string) {
 			logger.Info("will create 2 separate CloudFormation stacks for cluster itself and the initial %s", resource)
@@ -360,14 +449,24 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 	if len(autoDefaultAddons) > 0 {
 		logger.Info("default addons %s were not specified, will install them as EKS addons", strings.Join(autoDefaultAddons, ", "))
 	}
+	cfg.AddClusterBootstrapIAMMappings()
 	postClusterCreationTasks := ctl.CreateExtraClusterConfigTasks(ctx, cfg, preNodegroupAddons, updateVPCCNITask)
 
-	taskTree := stackManager.NewTasksToCreateCluster(ctx, cfg.NodeGroups, cfg.ManagedNodeGroups, cfg.AccessConfig, makeAccessEntryCreator(cfg.Metadata.Name, stackManager), params.NodeGroupParallelism, postClusterCreationTasks)
+	// --parallelism also caps the nodegroup stacks created at the same time, which --nodegroup-parallelism limits
+	nodeGroupParallelism := params.NodeGroupParallelism
+	if cmd.StackParallelism > 0 && (nodeGroupParallelism == 0 || cmd.StackParallelism < nodeGroupParallelism) {
+		nodeGroupParallelism = cmd.StackParallelism
+	}
+	taskTree := stackManager.NewTasksToCreateCluster(ctx, cfg.NodeGroups, cfg.ManagedNodeGroups, cfg.AccessConfig, makeAccessEntryCreator(cfg.Metadata.Name, stackManager), nodeGroupParallelism, postClusterCreationTasks)
+	taskTree.LimitParallelism(cmd.StackParallelism)
 
 	logger.Info(taskTree.Describe())
+	if err := taskTree.WriteGraph(cmd.CobraCommand.OutOrStdout(), taskGraphFormat); err != nil {
+		return err
+	}
 	if errs := taskTree.DoAllSync(); len(errs) > 0 {
 		logger.Warning("%d error(s) occurred and cluster hasn't been created properly, you may wish to check CloudFormation console", len(errs))
-		logger.Info("to cleanup resources, run 'eksctl delete cluster --region=%s --name=%s'", meta.Region, meta.Name)
+		logger.Info("to cleanup resources, run 'eksctl delete cluster --region=%s --name=%s --approve'", meta.Region, meta.Name)
 		for _, err := range errs {
 			ufe := &api.UnsupportedFeatureError{}
 			if errors.As(err, &ufe) {
@@ -375,7 +474,7 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 			}
 			logger.Critical("%s\n", err.Error())
 		}
-		return fmt.Errorf("failed to create cluster %q", meta.Name)
+		return exitcode.WithCause(fmt.Errorf("failed to create cluster %q", meta.Name), errs)
 	}
 
 	logger.Info("waiting for the control plane to become ready")
@@ -386,7 +485,11 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 		var kubeconfigContextName string
 
 		if params.WriteKubeconfig {
-			kubectlConfig := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), params.AuthenticatorRoleARN, ctl.AWSProvider.Profile().Name)
+			username := eks.GetUsername(ctl.Status.IAMRoleARN)
+			kubectlConfig := kubeconfig.NewForKubectl(cfg, username, params.AuthenticatorRoleARN, ctl.AWSProvider.Profile().Name)
+			if err := kubeconfig.Customize(kubectlConfig, cfg.Kubeconfig, cfg.Metadata, username); err != nil {
+				return err
+			}
 			kubeconfigContextName = kubectlConfig.CurrentContext
 
 			params.KubeconfigPath, err = kubeconfig.Write(params.KubeconfigPath, *kubectlConfig, params.SetContext)
@@ -402,15 +505,19 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 		ngTasks := ctl.ClusterTasksForNodeGroups(cfg, params.InstallNeuronDevicePlugin, params.InstallNvidiaDevicePlugin)
 
 		logger.Info(ngTasks.Describe())
+		if err := ngTasks.WriteGraph(cmd.CobraCommand.OutOrStdout(), taskGraphFormat); err != nil {
+			return err
+		}
 		if errs := ngTasks.DoAllSync(); len(errs) > 0 {
 			logger.Warning("%d error(s) occurred and post actions have failed, you may wish to check CloudFormation console", len(errs))
-			logger.Info("to cleanup resources, run 'eksctl delete cluster --region=%s --name=%s'", meta.Region, meta.Name)
+			logBootstrapHint(meta)
 			for _, err := range errs {
 				logger.Critical("%s\n", err.Error())
 			}
-			return fmt.Errorf("failed to create cluster %q", meta.Name)
+			return exitcode.WithCause(fmt.Errorf("failed to create cluster %q", meta.Name), errs)
 		}
 		logger.Success("all EKS cluster resources for %q have been created", meta.Name)
+		nodegroup.RecordCreation(ctx, ctl.AWSProvider, cfg, cmdutils.CommandLine())
 
 		makeClientSet := clientSetCreator(ctl, cfg)
 		{
@@ -438,14 +545,14 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 				}
 
 				for _, ng := range cfg.NodeGroups {
-					if err := eks.WaitForNodes(ngCtx, clientSet, ng); err != nil {
+					if err := eks.WaitForReadyNodes(ngCtx, clientSet, ng, params.WaitForReadyNodes); err != nil {
 						return err
 					}
 				}
 				logger.Success("created %d nodegroup(s) in cluster %q", len(cfg.NodeGroups), cfg.Metadata.Name)
 
 				for _, ng := range cfg.ManagedNodeGroups {
-					if err := eks.WaitForNodes(ngCtx, clientSet, ng); err != nil {
+					if err := eks.WaitForReadyNodes(ngCtx, clientSet, ng, params.WaitForReadyNodes); err != nil {
 						return err
 					}
 				}
@@ -455,6 +562,7 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 		if postNodegroupAddons != nil && postNodegroupAddons.Len() > 0 {
 			if errs := postNodegroupAddons.DoAllSync(); len(errs) > 0 {
 				logger.Warning("%d error(s) occurred while creating addons", len(errs))
+				logBootstrapHint(meta)
 				for _, err := range errs {
 					logger.Critical("%s\n", err.Error())
 				}
@@ -462,6 +570,17 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 			}
 		}
 
+		if cfg.ClusterBootstrap != nil {
+			clientSet, err := makeClientSet()
+			if err != nil {
+				return fmt.Errorf("applying clusterBootstrap: %w", err)
+			}
+			applier := &clusterbootstrap.Applier{ClientSet: clientSet}
+			if err := applier.Apply(ctx, cfg.ClusterBootstrap); err != nil {
+				return fmt.Errorf("applying clusterBootstrap: %w", err)
+			}
+		}
+
 		if len(cfg.IAM.PodIdentityAssociations) > 0 {
 			clientSet, err := makeClientSet()
 			if err != nil {
@@ -493,12 +612,38 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 			}
 		}
 
+		if cfg.HasPrometheus() {
+			// the managed scraper uses the cluster security group, which is only known once the cluster is active
+			if err := ctl.RefreshClusterStatus(ctx, cfg); err != nil {
+				return fmt.Errorf("enabling monitoring: %w", err)
+			}
+			installer, err := cmdutils.NewMonitoringInstaller(ctx, ctl, cfg)
+			if err != nil {
+				return fmt.Errorf("enabling monitoring: %w", err)
+			}
+			endpoint, err := installer.Enable(ctx)
+			if err != nil {
+				return fmt.Errorf("enabling monitoring: %w", err)
+			}
+			logger.Success("the metrics of cluster %q are sent to Amazon Managed Service for Prometheus, endpoint: %s", cfg.Metadata.Name, endpoint)
+		}
+
+		if integrationRegistry != nil {
+			if err := integrations.ApplyConfig(ctx, integrationRegistry, cfg); err != nil {
+				return err
+			}
+		}
+
 		if cfg.HasGitOpsFluxConfigured() {
-			clientSet, err := makeClientSet()
+			rawClient, err := ctl.NewRawClient(cfg)
 			if err != nil {
 				return fmt.Errorf("error installing Flux: %w", err)
 			}
-			installer, err := flux.New(clientSet, cfg.GitOps)
+			dynamicClient, err := rawClient.NewDynamicClient()
+			if err != nil {
+				return fmt.Errorf("error installing Flux: %w", err)
+			}
+			installer, err := flux.New(rawClient.ClientSet(), dynamicClient, cfg.GitOps)
 			logger.Info("gitops configuration detected, setting installer to Flux v2")
 			if err != nil {
 				return errors.Wrapf(err, "could not initialise Flux installer")
@@ -512,6 +657,22 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 			return nil
 		}
 
+		if cfg.HasGitOpsArgoCDConfigured() {
+			config := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), params.AuthenticatorRoleARN, ctl.AWSProvider.Profile().Name)
+			kubeConfigBytes, err := runtime.Encode(clientcmdlatest.Codec, config)
+			if err != nil {
+				return errors.Wrap(err, "generating kubeconfig")
+			}
+			logger.Info("gitops configuration detected, installing Argo CD")
+			installer, err := argocd.New(cfg, ctl.AWSProvider.SSM(), kubeConfigBytes)
+			if err != nil {
+				return errors.Wrapf(err, "could not initialise Argo CD installer")
+			}
+			if err := installer.Install(ctx); err != nil {
+				return err
+			}
+		}
+
 		env, err := ctl.GetCredentialsEnv(ctx)
 		if err != nil {
 			return err
@@ -532,6 +693,9 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 		}
 	}
 
+	inventory.Update(func(i *inventory.Inventory) error {
+		return i.AddCluster(meta.Name, meta.Region, cfg.GetAllNodeGroupNames())
+	})
 	logger.Success("%s is ready", meta.LogString())
 
 	return printer.LogObj(logger.Debug, "cfg.json = \\\n%s\n", cfg)
@@ -557,6 +721,21 @@ func installKarpenter(ctx context.Context, ctl *eks.ClusterProvider, cfg *api.Cl
 func createOrImportVPC(ctx context.Context, cmd *cmdutils.Cmd, cfg *api.ClusterConfig, params *cmdutils.CreateClusterCmdParams, ctl *eks.ClusterProvider) error {
 	customNetworkingNotice := "custom VPC/subnets will be used; if resulting cluster doesn't function as expected, make sure to review the configuration of VPC/subnets"
 
+	if cfg.VPC.CreateSubnets != nil {
+		// create subnets in the existing VPC given by vpc.id
+		if params.DryRun {
+			return nil
+		}
+		if err := vpc.SetSubnetsForExistingVPC(ctx, ctl.AWSProvider.EC2(), cfg); err != nil {
+			return err
+		}
+		if err := cfg.CanUseForPrivateNodeGroups(); err != nil {
+			return err
+		}
+		logger.Info("subnets will be created in existing VPC %q", cfg.VPC.ID)
+		return nil
+	}
+
 	subnetsGiven := cfg.HasAnySubnets() // this will be false when neither flags nor config has any subnets
 	if !subnetsGiven && params.KopsClusterNameForVPC == "" {
 		if !cfg.IsControlPlaneOnOutposts() {
@@ -664,6 +843,12 @@ func createOrImportVPC(ctx context.Context, cmd *cmdutils.Cmd, cfg *api.ClusterC
 	return nil
 }
 
+// logBootstrapHint tells how to complete the bootstrap of a cluster whose infrastructure has been created
+func logBootstrapHint(meta *api.ClusterMeta) {
+	logger.Info("the infrastructure of the cluster has been created; once the errors are fixed, run 'eksctl bootstrap --cluster=%s -f <config file>' to complete its bootstrap, or 'eksctl delete cluster --region=%s --name=%s --approve' to cleanup resources",
+		meta.Name, meta.Region, meta.Name)
+}
+
 func clientSetCreator(ctl *eks.ClusterProvider, cfg *api.ClusterConfig) func() (kubernetes.Interface, error) {
 	var (
 		err       error
@@ -0,0 +1,42 @@
+package create
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/actions/render"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
+)
+
+// renderClusterTemplates writes the CloudFormation templates that `create cluster` would deploy,
+// without calling the AWS API.
+func renderClusterTemplates(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params *cmdutils.CreateClusterCmdParams) error {
+	if params.DryRun {
+		return fmt.Errorf("--render-cfn-only and --dry-run %s", cmdutils.IncompatibleFlags)
+	}
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Region == "" {
+		cfg.Metadata.Region = cmd.ProviderConfig.Region
+	}
+	if len(params.AvailabilityZones) > 0 {
+		cfg.AvailabilityZones = params.AvailabilityZones
+	}
+	if err := cmd.InitializeClusterConfig(); err != nil {
+		return err
+	}
+	cmdutils.ApplyFilter(cfg, ngFilter)
+
+	files, err := render.New(cfg, params.RenderDir).Render(context.TODO())
+	if err != nil {
+		return fmt.Errorf("rendering CloudFormation templates: %w", err)
+	}
+	for _, file := range files {
+		logger.Info("wrote %s", file)
+	}
+	logger.Warning("the templates reference the placeholders %s, %s, %s and %s for values that are only known once the cluster exists",
+		render.PlaceholderAccountID, render.PlaceholderOIDCIssuerID, render.PlaceholderClusterEndpoint, render.PlaceholderCertificateAuthority)
+	return nil
+}
@@ -0,0 +1,83 @@
+package create
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/actions/snapshot"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+// loadClusterSnapshot reads the snapshot at --from-snapshot and passes its ClusterConfig to the loader as the config
+// file, renamed and moved to the region given with --name and --region
+func loadClusterSnapshot(cmd *cmdutils.Cmd, params *cmdutils.CreateClusterCmdParams) error {
+	if cmd.ClusterConfigFile != "" {
+		return fmt.Errorf("--from-snapshot and --config-file %s", cmdutils.IncompatibleFlags)
+	}
+	if params.Interactive {
+		return fmt.Errorf("--from-snapshot and --interactive %s", cmdutils.IncompatibleFlags)
+	}
+
+	ctx := context.Background()
+	var ctl *eks.ClusterProvider
+	newCtl := func() (*eks.ClusterProvider, error) {
+		if ctl != nil {
+			return ctl, nil
+		}
+		var err error
+		ctl, err = cmd.NewCtl()
+		return ctl, err
+	}
+
+	var awsConfig aws.Config
+	if strings.HasPrefix(params.FromSnapshot, "s3://") {
+		ctl, err := newCtl()
+		if err != nil {
+			return err
+		}
+		awsConfig = ctl.AWSProvider.AWSConfig()
+	}
+	data, err := snapshot.Read(ctx, awsConfig, params.FromSnapshot)
+	if err != nil {
+		return err
+	}
+	s, err := snapshot.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+
+	// the access entries of the snapshot are moved to the account of the new cluster, which NewCtl resolves
+	var account string
+	if accessConfig := s.ClusterConfig.AccessConfig; s.Account != "" && accessConfig != nil && len(accessConfig.AccessEntries) > 0 {
+		if _, err := newCtl(); err != nil {
+			return err
+		}
+		account = cmd.ClusterConfig.Metadata.AccountID
+	}
+
+	name := cmd.ClusterConfig.Metadata.Name
+	if cmd.NameArg != "" {
+		name = cmd.NameArg
+	}
+	clusterConfig := s.ClusterConfigFor(name, cmd.ProviderConfig.Region, account)
+	logger.Info("creating cluster %q in region %q from the snapshot of cluster %q in region %q taken at %s",
+		clusterConfig.Metadata.Name, clusterConfig.Metadata.Region, s.ClusterName, s.Region, s.CreatedAt.Format("2006-01-02T15:04:05Z"))
+	if s.Auth != nil && len(s.Auth.AuthConfigMap) > 0 {
+		logger.Warning("the aws-auth ConfigMap of the snapshot is not restored as it maps the node roles of cluster %q; "+
+			"map the IAM users and roles of the snapshot with `eksctl create iamidentitymapping` or access entries", s.ClusterName)
+	}
+
+	var config bytes.Buffer
+	if err := cmdutils.PrintDryRunConfig(clusterConfig, &config); err != nil {
+		return err
+	}
+	cmd.ClusterConfigFile = "-"
+	params.ConfigReader = &config
+	return nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	k8sclient "k8s.io/client-go/kubernetes"
@@ -17,6 +20,8 @@ import (
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"github.com/aws/aws-sdk-go-v2/service/outposts"
 	outpoststypes "github.com/aws/aws-sdk-go-v2/service/outposts/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/aws/smithy-go"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -36,6 +41,7 @@ import (
 	accessentryfakes "github.com/weaveworks/eksctl/pkg/actions/accessentry/fakes"
 	karpenteractions "github.com/weaveworks/eksctl/pkg/actions/karpenter"
 	karpenterfakes "github.com/weaveworks/eksctl/pkg/actions/karpenter/fakes"
+	"github.com/weaveworks/eksctl/pkg/actions/snapshot"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
@@ -53,6 +59,174 @@ import (
 const outpostARN = "arn:aws:outposts:us-west-2:1234:outpost/op-1234"
 
 var _ = Describe("create cluster", func() {
+	Describe("--interactive", func() {
+		var (
+			runFuncCalls  int
+			clusterConfig *api.ClusterConfig
+		)
+
+		runWizard := func(answers []string, args ...string) (string, error) {
+			originalIn := wizardIn
+			wizardIn = strings.NewReader(strings.Join(answers, "\n") + "\n")
+			DeferCleanup(func() {
+				wizardIn = originalIn
+			})
+
+			cmd := newMockEmptyCmd(append([]string{"cluster", "--interactive"}, args...)...)
+			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+				createClusterCmdWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params *cmdutils.CreateClusterCmdParams) error {
+					runFuncCalls++
+					clusterConfig = cmd.ClusterConfig
+					return nil
+				})
+			})
+			return cmd.execute()
+		}
+
+		BeforeEach(func() {
+			runFuncCalls = 0
+			clusterConfig = nil
+		})
+
+		It("creates the cluster from the answers", func() {
+			out, err := runWizard([]string{
+				"my_cluster", // invalid, asked again
+				"my-cluster",
+				"us-east-1",
+				"", // default version
+				"", // new VPC
+				"", // default CIDR
+				"HighlyAvailable",
+				"", // create a nodegroup
+				"", // ng-1
+				"m5.large, m5a.large",
+				"1",
+				"", // desired defaults to 2
+				"3",
+				"aws-ebs-csi-driver",
+				"y",
+				"", // create the cluster
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("invalid answer"))
+			Expect(out).To(ContainSubstring("name: my-cluster"))
+			Expect(runFuncCalls).To(Equal(1))
+
+			Expect(clusterConfig.Metadata.Name).To(Equal("my-cluster"))
+			Expect(clusterConfig.Metadata.Region).To(Equal("us-east-1"))
+			Expect(clusterConfig.Metadata.Version).To(Equal(api.DefaultVersion))
+			Expect(*clusterConfig.VPC.NAT.Gateway).To(Equal(api.ClusterHighlyAvailableNAT))
+			Expect(clusterConfig.ManagedNodeGroups).To(HaveLen(1))
+			ng := clusterConfig.ManagedNodeGroups[0]
+			Expect(ng.Name).To(Equal("ng-1"))
+			Expect(ng.InstanceTypes).To(Equal([]string{"m5.large", "m5a.large"}))
+			Expect(*ng.MinSize).To(Equal(1))
+			Expect(*ng.DesiredCapacity).To(Equal(2))
+			Expect(*ng.MaxSize).To(Equal(3))
+			Expect(clusterConfig.Addons).To(ConsistOf(HaveField("Name", api.AWSEBSCSIDriverAddon)))
+			Expect(*clusterConfig.IAM.WithOIDC).To(BeTrue())
+		})
+
+		It("selects instance types by vCPUs and memory when no instance type is given", func() {
+			_, err := runWizard([]string{
+				"my-cluster", "", "", "", "", "", "", "",
+				"",  // no instance types
+				"4", // vCPUs
+				"16",
+				"", "", "", "", "", "",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(runFuncCalls).To(Equal(1))
+			Expect(clusterConfig.ManagedNodeGroups[0].InstanceSelector).To(Equal(&api.InstanceSelector{
+				VCPUs:  4,
+				Memory: "16",
+			}))
+		})
+
+		It("does not create the cluster when the user does not confirm", func() {
+			_, err := runWizard([]string{
+				"my-cluster", "", "", "", "", "",
+				"n", // no nodegroup
+				"", "",
+				"n", // do not create the cluster
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(runFuncCalls).To(BeZero())
+		})
+
+		It("fails when the input ends before all questions are answered", func() {
+			_, err := runWizard([]string{"my-cluster"})
+			Expect(err).To(MatchError(ContainSubstring("no answer given, the interactive input ended")))
+		})
+
+		It("cannot be used with a config file", func() {
+			_, err := runWizard(nil, "--config-file", "../../../examples/01-simple-cluster.yaml")
+			Expect(err).To(MatchError(ContainSubstring("--interactive and --config-file cannot be used at the same time")))
+		})
+	})
+
+	Describe("--from-snapshot", func() {
+		var (
+			runFuncCalls  int
+			clusterConfig *api.ClusterConfig
+			snapshotFile  string
+		)
+
+		run := func(args ...string) error {
+			cmd := newMockEmptyCmd(append([]string{"cluster", "--from-snapshot", snapshotFile}, args...)...)
+			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+				createClusterCmdWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params *cmdutils.CreateClusterCmdParams) error {
+					runFuncCalls++
+					clusterConfig = cmd.ClusterConfig
+					return nil
+				})
+			})
+			_, err := cmd.execute()
+			return err
+		}
+
+		BeforeEach(func() {
+			runFuncCalls = 0
+			clusterConfig = nil
+			data, err := snapshot.Marshal(&snapshot.Snapshot{
+				Version:     snapshot.Version,
+				ClusterName: "source",
+				Region:      "us-west-2",
+				ClusterConfig: &api.ClusterConfig{
+					TypeMeta: api.ClusterConfigTypeMeta(),
+					Metadata: &api.ClusterMeta{Name: "source", Region: "us-west-2", Version: "1.30"},
+					ManagedNodeGroups: []*api.ManagedNodeGroup{
+						{NodeGroupBase: &api.NodeGroupBase{Name: "mng-1"}, InstanceTypes: []string{"m5.large"}},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			snapshotFile = filepath.Join(GinkgoT().TempDir(), "snapshot.yaml")
+			Expect(os.WriteFile(snapshotFile, data, 0600)).To(Succeed())
+		})
+
+		It("creates the cluster of the snapshot", func() {
+			Expect(run()).To(Succeed())
+			Expect(runFuncCalls).To(Equal(1))
+			Expect(clusterConfig.Metadata.Name).To(Equal("source"))
+			Expect(clusterConfig.Metadata.Region).To(Equal("us-west-2"))
+			Expect(clusterConfig.ManagedNodeGroups).To(ConsistOf(HaveField("Name", "mng-1")))
+		})
+
+		It("renames and moves the cluster", func() {
+			Expect(run("--name", "copy", "--region", "eu-west-1")).To(Succeed())
+			Expect(runFuncCalls).To(Equal(1))
+			Expect(clusterConfig.Metadata.Name).To(Equal("copy"))
+			Expect(clusterConfig.Metadata.Region).To(Equal("eu-west-1"))
+		})
+
+		It("cannot be used with a config file", func() {
+			err := run("--config-file", "../../../examples/01-simple-cluster.yaml")
+			Expect(err).To(MatchError(ContainSubstring("--from-snapshot and --config-file cannot be used at the same time")))
+			Expect(runFuncCalls).To(BeZero())
+		})
+	})
+
 	Describe("un-managed node group", func() {
 		It("understands ssh access arguments correctly", func() {
 			commandArgs := []string{"cluster", "--managed=false", "--ssh-access=false", "--ssh-public-key=dummy-key"}
@@ -69,6 +243,44 @@ var _ = Describe("create cluster", func() {
 			Expect(err).To(Not(HaveOccurred()))
 			Expect(count).To(Equal(1))
 		})
+		It("disables the default addons with --without-default-addons", func() {
+			commandArgs := []string{"cluster", "--managed=false", "--without-default-addons", "--without-nodegroup"}
+			cmd := newMockEmptyCmd(commandArgs...)
+			count := 0
+			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+				createClusterCmdWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params *cmdutils.CreateClusterCmdParams) error {
+					Expect(cmd.ClusterConfig.AddonsConfig.DisableDefaultAddons).To(BeTrue())
+					Expect(cmd.ClusterConfig.NodeGroups).To(BeEmpty())
+					count++
+					return nil
+				})
+			})
+			_, err := cmd.execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+		It("configures a diversified spot nodegroup with --spot-only", func() {
+			commandArgs := []string{"cluster", "--managed=false", "--spot-only", "--nodegroup-name=ng"}
+			cmd := newMockEmptyCmd(commandArgs...)
+			count := 0
+			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+				createClusterCmdWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params *cmdutils.CreateClusterCmdParams) error {
+					Expect(cmd.ClusterConfig.NodeGroups).To(HaveLen(1))
+					ng := cmd.ClusterConfig.NodeGroups[0]
+					Expect(ng.InstanceSelector.VCPUs).To(Equal(2))
+					Expect(ng.InstanceSelector.Memory).To(Equal("8"))
+					Expect(*ng.InstancesDistribution.OnDemandBaseCapacity).To(Equal(1))
+					Expect(*ng.InstancesDistribution.OnDemandPercentageAboveBaseCapacity).To(Equal(0))
+					Expect(*ng.InstancesDistribution.SpotAllocationStrategy).To(Equal(api.SpotAllocationStrategyCapacityOptimized))
+					Expect(ng.InstancesDistribution.CapacityRebalance).To(BeTrue())
+					count++
+					return nil
+				})
+			})
+			_, err := cmd.execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
 		DescribeTable("create cluster successfully",
 			func(args ...string) {
 				commandArgs := append([]string{"cluster"}, args...)
@@ -90,6 +302,8 @@ var _ = Describe("create cluster", func() {
 			Entry("with cluster name as argument", "clusterName"),
 			Entry("with cluster name with hyphen as flag", "--name", "my-cluster-name-is-fine10"),
 			Entry("with cluster name with hyphen as argument", "my-Cluster-name-is-fine10"),
+			Entry("with ttl flag", "--ttl", "4h"),
+			Entry("with parallelism flag", "--parallelism", "5"),
 			// vpc networking flags
 			Entry("with vpc-cidr flag", "--vpc-cidr", "10.0.0.0/20"),
 			Entry("with vpc-private-subnets flag", "--vpc-private-subnets", "10.0.0.0/24"),
@@ -143,10 +357,39 @@ var _ = Describe("create cluster", func() {
 				args:  []string{"cluster", "--invalid", "dummy"},
 				error: "unknown flag: --invalid",
 			}),
+			Entry("with without-default-addons flag and a nodegroup", invalidParamsCase{
+				args:  []string{"--without-default-addons"},
+				error: "--without-default-addons cannot be used with nodegroups or Fargate",
+			}),
 		)
 	})
 
 	Describe("managed node group", func() {
+		It("configures spot and on-demand base capacity nodegroups with --spot-only", func() {
+			commandArgs := []string{"cluster", "--spot-only", "--nodegroup-name=ng", "--instance-selector-vcpus=4"}
+			cmd := newMockEmptyCmd(commandArgs...)
+			count := 0
+			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+				createClusterCmdWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params *cmdutils.CreateClusterCmdParams) error {
+					Expect(cmd.ClusterConfig.NodeGroups).To(BeEmpty())
+					Expect(cmd.ClusterConfig.ManagedNodeGroups).To(HaveLen(2))
+					spot, onDemand := cmd.ClusterConfig.ManagedNodeGroups[0], cmd.ClusterConfig.ManagedNodeGroups[1]
+					Expect(spot.Name).To(Equal("ng-spot"))
+					Expect(spot.Spot).To(BeTrue())
+					Expect(spot.InstanceSelector.VCPUs).To(Equal(4))
+					Expect(onDemand.Name).To(Equal("ng-on-demand"))
+					Expect(onDemand.Spot).To(BeFalse())
+					Expect(*onDemand.DesiredCapacity).To(Equal(1))
+					Expect(*onDemand.MaxSize).To(Equal(1))
+					count++
+					return nil
+				})
+			})
+			_, err := cmd.execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+		})
+
 		DescribeTable("create cluster successfully",
 			func(args ...string) {
 				commandArgs := append([]string{"cluster"}, args...)
@@ -219,6 +462,14 @@ var _ = Describe("create cluster", func() {
 				args:  []string{"--name=test", "--enable-ssm=false"},
 				error: "SSM agent is now built into EKS AMIs and cannot be disabled",
 			}),
+			Entry("with --spot-only and --spot", invalidParamsCase{
+				args:  []string{"--spot-only", "--spot"},
+				error: "--spot cannot be used with --spot-only",
+			}),
+			Entry("with --spot-only and --without-nodegroup", invalidParamsCase{
+				args:  []string{"--spot-only", "--without-nodegroup"},
+				error: "--without-nodegroup cannot be used with --spot-only",
+			}),
 			Entry("with node zones without zones", invalidParamsCase{
 				args:  []string{"--zones=zone1,zone2", "--node-zones=zone3"},
 				error: "validation for --zones and --node-zones failed: node-zones [zone3] must be a subset of zones [zone1 zone2]; \"zone3\" was not found in zones",
@@ -244,6 +495,10 @@ var _ = Describe("create cluster", func() {
 		if ce.updateMocks != nil {
 			ce.updateMocks(p)
 		}
+		// the creation of the nodegroups is recorded in their history
+		p.MockSSM().On("GetParameter", mock.Anything, mock.Anything).Return(nil, &ssmtypes.ParameterNotFound{})
+		p.MockSSM().On("PutParameter", mock.Anything, mock.Anything).Return(&ssm.PutParameterOutput{}, nil)
+		p.MockEKS().On("DescribeNodegroup", mock.Anything, mock.Anything).Return(nil, errors.New("nodegroup not found"))
 
 		// default setting for KubeProvider
 		fk := &fakes.FakeKubeProvider{}
@@ -19,6 +19,9 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 		createAddonCmd,
 		createAccessEntryCmd,
 		createPodIdentityAssociationCmd,
+		createBackupCmd,
+		createRestoreCmd,
+		createEFSCmd,
 	}
 	for _, cmdFunc := range cmdFuncs {
 		cmdutils.AddResourceCmd(flagGrouping, verbCmd, cmdFunc)
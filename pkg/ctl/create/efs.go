@@ -0,0 +1,104 @@
+package create
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/efs"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func createEFSCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"efs",
+		"Create an EFS filesystem for the cluster",
+		"Creates an encrypted EFS filesystem with a mount target in each availability zone of the cluster, reachable over NFS from the VPC of the cluster, "+
+			"installs the aws-efs-csi-driver addon with an IAM role for its service account, and creates a StorageClass provisioning volumes on the filesystem",
+	)
+
+	var (
+		storageClassName    string
+		defaultStorageClass bool
+	)
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		if cmd.ClusterConfig.Metadata.Name == "" {
+			return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+		}
+		return doCreateEFS(cmd, storageClassName, defaultStorageClass)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("EFS", func(fs *pflag.FlagSet) {
+		fs.StringVar(&storageClassName, "storage-class", efs.DefaultStorageClassName, "name of the StorageClass provisioning volumes on the filesystem")
+		fs.BoolVar(&defaultStorageClass, "default-storage-class", false, "make the StorageClass the default StorageClass of the cluster")
+	})
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doCreateEFS(cmd *cmdutils.Cmd, storageClassName string, defaultStorageClass bool) error {
+	ctx := context.TODO()
+	cfg := cmd.ClusterConfig
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	oidcProviderExists, err := oidc.CheckProviderExists(ctx)
+	if err != nil {
+		return err
+	}
+	if !oidcProviderExists {
+		return fmt.Errorf("no IAM OIDC provider associated with cluster, try 'eksctl utils associate-iam-oidc-provider --region=%s --cluster=%s'", cfg.Metadata.Region, cfg.Metadata.Name)
+	}
+
+	// the addon version is resolved for the Kubernetes version of the cluster
+	cfg.Metadata.Version = ctl.ControlPlaneVersion()
+	stackManager := ctl.NewStackManager(cfg)
+	addonManager, err := addon.New(cfg, ctl.AWSProvider.EKS(), stackManager, true, oidc, func() (kubernetes.Interface, error) {
+		return clientSet, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	creator := &efs.Creator{
+		StackManager:        stackManager,
+		ClusterConfig:       cfg,
+		Cluster:             ctl.GetClusterState(),
+		EC2:                 ctl.AWSProvider.EC2(),
+		Addons:              addonManager,
+		ClientSet:           clientSet,
+		StorageClassName:    storageClassName,
+		DefaultStorageClass: defaultStorageClass,
+		WaitTimeout:         cmd.ProviderConfig.WaitTimeout,
+	}
+	fileSystemID, err := creator.Create(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Success("created EFS filesystem %q for cluster %q, volumes are provisioned with StorageClass %q", fileSystemID, cfg.Metadata.Name, storageClassName)
+	return nil
+}
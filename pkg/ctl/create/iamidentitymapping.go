@@ -3,10 +3,13 @@ package create
 import (
 	"context"
 
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
 	"github.com/lithammer/dedent"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
 	mappingactions "github.com/weaveworks/eksctl/pkg/actions/iamidentitymapping"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
@@ -35,6 +38,7 @@ func createIAMIdentityMappingCmd(cmd *cmdutils.Cmd) {
 	cmd.FlagSetGroup.InFlagSet("IAMIdentityMapping", func(fs *pflag.FlagSet) {
 		fs.StringVar(&identityMapping.Account, "account", "", "Account ID to automatically map to its username")
 		cmdutils.AddIAMIdentityMappingARNFlags(fs, cmd, &identityMapping.ARN, "create")
+		fs.StringVar(&identityMapping.SSOPermissionSet, "sso-permission-set", "", "Name of an IAM Identity Center permission set whose role in the cluster's account to map, instead of --arn")
 		fs.StringVar(&identityMapping.Username, "username", "", "User name within Kubernetes to map to IAM role")
 		fs.StringSliceVar(&identityMapping.Groups, "group", []string{}, "Groups within Kubernetes to which IAM role is mapped")
 		fs.StringVar(&identityMapping.ServiceName, "service-name", "", "Service name; valid value: emr-containers")
@@ -83,18 +87,44 @@ func doCreateIAMIdentityMapping(cmd *cmdutils.Cmd) error {
 		return err
 	}
 
-	m, err := mappingactions.New(cfg, clientSet, rawClient, cmd.ProviderConfig.Region)
+	m, err := mappingactions.New(cfg, clientSet, rawClient, ctl.AWSProvider.IAM(), cmd.ProviderConfig.Region)
 	if err != nil {
 		return err
 	}
 
+	authMode := ctl.GetClusterState().AccessConfig.AuthenticationMode
 	for _, mapping := range cmd.ClusterConfig.IAMIdentityMappings {
 		if err := mapping.Validate(); err != nil {
 			return err
 		}
+		// the aws-auth ConfigMap is not used in API authentication mode, so permission set roles get an access entry instead
+		if mapping.SSOPermissionSet != "" && authMode == ekstypes.AuthenticationModeApi {
+			if err := createSSOAccessEntry(ctx, m, mapping, &accessentryactions.Creator{
+				ClusterName:  cfg.Metadata.Name,
+				StackCreator: ctl.NewStackManager(cfg),
+			}); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := m.Create(ctx, mapping); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+func createSSOAccessEntry(ctx context.Context, m *mappingactions.Manager, mapping *api.IAMIdentityMapping, creator accessentryactions.CreatorInterface) error {
+	roleARN, err := m.ResolveSSOPermissionSetRoleARN(ctx, mapping.SSOPermissionSet)
+	if err != nil {
+		return err
+	}
+	logger.Info("cluster authentication mode is %s, creating access entry for role %s of IAM Identity Center permission set %q", ekstypes.AuthenticationModeApi, roleARN, mapping.SSOPermissionSet)
+	return creator.Create(ctx, []api.AccessEntry{
+		{
+			PrincipalARN:       api.MustParseARN(roleARN),
+			KubernetesGroups:   mapping.Groups,
+			KubernetesUsername: mapping.Username,
+		},
+	})
+}
@@ -14,7 +14,7 @@ var _ = Describe("create iamserviceaccount", func() {
 			cmd := newMockEmptyCmd(commandArgs...)
 			count := 0
 			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
-				createIAMServiceAccountCmdWithRunFunc(cmd, func(cmd *cmdutils.Cmd, _, _ bool) error {
+				createIAMServiceAccountCmdWithRunFunc(cmd, func(cmd *cmdutils.Cmd, _, _ bool, _ int) error {
 					Expect(cmd.ClusterConfig.Metadata.Name).To(Equal("clusterName"))
 					Expect(cmd.ClusterConfig.IAM.ServiceAccounts[0].Name).To(Equal("serviceAccountName"))
 					Expect(cmd.ClusterConfig.IAM.ServiceAccounts[0].AttachPolicyARNs).To(ContainElement("dummyPolicyArn"))
@@ -28,6 +28,8 @@ var _ = Describe("create iamserviceaccount", func() {
 		},
 		Entry("with all required flags", "--cluster", "clusterName", "--name", "serviceAccountName", "--attach-policy-arn", "dummyPolicyArn"),
 		Entry("with optional flags", "--cluster", "clusterName", "--name", "serviceAccountName", "--attach-policy-arn", "dummyPolicyArn", "--override-existing-serviceaccounts", "--role-name", "custom-role-name"),
+		Entry("with --target-account", "--cluster", "clusterName", "--name", "serviceAccountName", "--attach-policy-arn", "dummyPolicyArn", "--target-account", "arn:aws:iam::111122223333:role/irsa-deployer"),
+		Entry("with --parallelism", "--cluster", "clusterName", "--name", "serviceAccountName", "--attach-policy-arn", "dummyPolicyArn", "--parallelism", "10"),
 	)
 
 	DescribeTable("invalid flags or arguments",
@@ -61,6 +63,14 @@ var _ = Describe("create iamserviceaccount", func() {
 			args:  []string{"iamserviceaccount", "--cluster", "clusterName", "serviceAccountName", "--attach-policy-arn", "123", "--attach-role-arn", "123"},
 			error: "cannot provide --attach-role-arn and specify polices to attach",
 		}),
+		Entry("with --attach-role-arn and --target-account", invalidParamsCase{
+			args:  []string{"iamserviceaccount", "--cluster", "clusterName", "serviceAccountName", "--attach-role-arn", "123", "--target-account", "arn:aws:iam::111122223333:role/irsa-deployer"},
+			error: "cannot provide --target-account when --attach-role-arn is configured",
+		}),
+		Entry("with negative --parallelism", invalidParamsCase{
+			args:  []string{"iamserviceaccount", "--cluster", "clusterName", "--name", "serviceAccountName", "--attach-policy-arn", "123", "--parallelism", "-1"},
+			error: "--parallelism must not be negative",
+		}),
 		Entry("with invalid flags", invalidParamsCase{
 			args:  []string{"iamserviceaccount", "--invalid", "dummy"},
 			error: "unknown flag: --invalid",
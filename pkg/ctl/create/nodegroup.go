@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 
 	"github.com/aws/amazon-ec2-instance-selector/v2/pkg/selector"
@@ -19,7 +20,9 @@ import (
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/inventory"
 	"github.com/weaveworks/eksctl/pkg/utils/names"
+	"github.com/weaveworks/eksctl/pkg/utils/progress"
 )
 
 func createNodeGroupCmd(cmd *cmdutils.Cmd) {
@@ -28,6 +31,22 @@ func createNodeGroupCmd(cmd *cmdutils.Cmd) {
 			return api.ErrInvalidName(ng.Name)
 		}
 
+		taskGraphFormat, err := options.TaskGraphFormat()
+		if err != nil {
+			return err
+		}
+		progressMode, err := options.ProgressMode()
+		if err != nil {
+			return err
+		}
+
+		infraEngine := nodegroup.InfraEngine(options.InfraEngine)
+		switch infraEngine {
+		case nodegroup.InfraEngineCloudFormation, nodegroup.InfraEngineSDK:
+		default:
+			return fmt.Errorf("invalid value %q for --infra-engine, valid options are %q and %q", options.InfraEngine, nodegroup.InfraEngineCloudFormation, nodegroup.InfraEngineSDK)
+		}
+
 		if options.SubnetIDs != nil {
 			ng.Subnets = append(ng.Subnets, options.SubnetIDs...)
 		}
@@ -72,7 +91,11 @@ func createNodeGroupCmd(cmd *cmdutils.Cmd) {
 		}
 
 		manager := nodegroup.New(cmd.ClusterConfig, ctl, clientSet, instanceSelector)
-		return manager.Create(ctx, nodegroup.CreateOpts{
+		if !options.DryRun {
+			stopProgress := progress.Start(progressMode, os.Stdout)
+			defer stopProgress()
+		}
+		if err := manager.Create(ctx, nodegroup.CreateOpts{
 			InstallNeuronDevicePlugin: options.InstallNeuronDevicePlugin,
 			InstallNvidiaDevicePlugin: options.InstallNvidiaDevicePlugin,
 			UpdateAuthConfigMap:       options.UpdateAuthConfigMap,
@@ -83,7 +106,29 @@ func createNodeGroupCmd(cmd *cmdutils.Cmd) {
 			SkipOutdatedAddonsCheck: options.SkipOutdatedAddonsCheck,
 			ConfigFileProvided:      cmd.ClusterConfigFile != "",
 			Parallelism:             options.NodeGroupParallelism,
-		}, ngFilter)
+			WaitForReadyNodes:       options.WaitForReadyNodes,
+			TaskGraph: nodegroup.TaskGraphSettings{
+				Format:    taskGraphFormat,
+				OutStream: cmd.CobraCommand.OutOrStdout(),
+			},
+			InfraEngine: infraEngine,
+		}, ngFilter); err != nil {
+			return err
+		}
+
+		if !options.DryRun {
+			meta := cmd.ClusterConfig.Metadata
+			var created []string
+			for _, name := range cmd.ClusterConfig.GetAllNodeGroupNames() {
+				if ngFilter.Match(name) {
+					created = append(created, name)
+				}
+			}
+			inventory.Update(func(i *inventory.Inventory) error {
+				return i.AddNodeGroups(meta.Name, meta.Region, created)
+			})
+		}
+		return nil
 	})
 }
 
@@ -108,6 +153,7 @@ func createNodeGroupCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc runFn) {
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		cmdutils.AddClusterFlag(fs, cfg.Metadata)
 		cmdutils.AddStringToStringVarPFlag(fs, &cfg.Metadata.Tags, "tags", "", map[string]string{}, "Used to tag the AWS resources")
+		cmdutils.AddRequiredTagsFlag(fs, &options.RequiredTags)
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		cmdutils.AddVersionFlag(fs, cfg.Metadata, `for nodegroups "auto" and "latest" can be used to automatically inherit version from the control plane or force latest`)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
@@ -117,6 +163,7 @@ func createNodeGroupCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc runFn) {
 		cmdutils.AddSubnetIDs(fs, &options.SubnetIDs, "Define an optional list of subnet IDs to create the nodegroup in")
 		fs.BoolVarP(&options.DryRun, "dry-run", "", false, "Dry-run mode that skips nodegroup creation and outputs a ClusterConfig")
 		fs.BoolVarP(&options.SkipOutdatedAddonsCheck, "skip-outdated-addons-check", "", false, "whether the creation of ARM nodegroups should proceed when the cluster addons are outdated")
+		fs.StringVar(&options.InfraEngine, "infra-engine", string(nodegroup.InfraEngineCloudFormation), "engine used to provision the resources of managed nodegroups; \"sdk\" calls AWS APIs directly instead of creating CloudFormation stacks (experimental, valid options: cloudformation, sdk)")
 	})
 
 	cmd.FlagSetGroup.InFlagSet("New nodegroup", func(fs *pflag.FlagSet) {
@@ -59,6 +59,7 @@ var _ = Describe("create nodegroup", func() {
 			Entry("with appmesh-access flag", "--appmesh-access", "true"),
 			Entry("with alb-ingress-access flag", "--alb-ingress-access", "true"),
 			Entry("with subnet-ids flag", "--subnet-ids", "id1,id2,id3"),
+			Entry("with wait-for-ready-nodes flag", "--wait-for-ready-nodes", "1"),
 		)
 
 		DescribeTable("invalid flags or arguments",
@@ -93,6 +94,10 @@ var _ = Describe("create nodegroup", func() {
 				args:  []string{"--cluster", "clusterName", "--name", "eksctl-ng_k8s_nodegroup1"},
 				error: "validation for eksctl-ng_k8s_nodegroup1 failed, name must satisfy regular expression pattern: [a-zA-Z][-a-zA-Z0-9]*",
 			}),
+			Entry("with negative wait-for-ready-nodes flag", invalidParamsCase{
+				args:  []string{"--cluster", "clusterName", "--wait-for-ready-nodes", "-2"},
+				error: "invalid value -2 for --wait-for-ready-nodes",
+			}),
 		)
 	})
 
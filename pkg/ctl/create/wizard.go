@@ -0,0 +1,349 @@
+package create
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+	"github.com/weaveworks/eksctl/pkg/utils/names"
+)
+
+const (
+	vpcChoiceNew      = "new"
+	vpcChoiceExisting = "existing"
+)
+
+// suggestedAddons are offered by the wizard on top of the default addons installed in every cluster
+var suggestedAddons = []string{
+	api.PodIdentityAgentAddon,
+	api.AWSEBSCSIDriverAddon,
+	api.AWSEFSCSIDriverAddon,
+}
+
+// wizardIn is where the wizard reads answers from, it is replaced in tests
+var wizardIn io.Reader = os.Stdin
+
+// runClusterWizard asks the user for the settings of the cluster, prints the resulting ClusterConfig and asks
+// whether to create the cluster. When the user confirms, the ClusterConfig is loaded as if it was passed with
+// --config-file, and runClusterWizard returns true.
+func runClusterWizard(cmd *cmdutils.Cmd, params *cmdutils.CreateClusterCmdParams) (bool, error) {
+	if cmd.ClusterConfigFile != "" {
+		return false, fmt.Errorf("--interactive and --config-file %s", cmdutils.IncompatibleFlags)
+	}
+	if params.DryRun {
+		return false, fmt.Errorf("--interactive and --dry-run %s", cmdutils.IncompatibleFlags)
+	}
+
+	out := cmd.CobraCommand.OutOrStdout()
+	w := &clusterWizard{
+		in:  bufio.NewReader(wizardIn),
+		out: out,
+	}
+	clusterConfig, err := w.run(cmd.ProviderConfig.Region)
+	if err != nil {
+		return false, err
+	}
+
+	var config bytes.Buffer
+	if err := cmdutils.PrintDryRunConfig(clusterConfig, &config); err != nil {
+		return false, err
+	}
+	fmt.Fprintf(out, "\n%s\n", config.String())
+
+	create, err := w.askYesNo("Create the cluster with this config", true)
+	if err != nil {
+		return false, err
+	}
+	if !create {
+		logger.Info("cluster not created, save the config above to a file and run `eksctl create cluster -f <file>` to create it later")
+		return false, nil
+	}
+	cmd.ClusterConfigFile = "-"
+	params.ConfigReader = &config
+	return true, nil
+}
+
+// clusterWizard builds a ClusterConfig from the answers to a series of questions
+type clusterWizard struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func (w *clusterWizard) run(region string) (*api.ClusterConfig, error) {
+	clusterConfig := &api.ClusterConfig{
+		TypeMeta: api.ClusterConfigTypeMeta(),
+		Metadata: &api.ClusterMeta{},
+	}
+	meta := clusterConfig.Metadata
+
+	var err error
+	if meta.Name, err = w.ask("Cluster name", names.ForCluster("", ""), validateWizardName); err != nil {
+		return nil, err
+	}
+	if region == "" {
+		region = api.DefaultRegion
+	}
+	if meta.Region, err = w.choose("Region", api.SupportedRegions(), region); err != nil {
+		return nil, err
+	}
+	if meta.Version, err = w.choose("Kubernetes version", api.SupportedVersions(), api.DefaultVersion); err != nil {
+		return nil, err
+	}
+
+	if clusterConfig.VPC, err = w.askVPC(); err != nil {
+		return nil, err
+	}
+
+	withNodeGroup, err := w.askYesNo("Create a managed nodegroup", true)
+	if err != nil {
+		return nil, err
+	}
+	if withNodeGroup {
+		ng, err := w.askManagedNodeGroup()
+		if err != nil {
+			return nil, err
+		}
+		clusterConfig.ManagedNodeGroups = []*api.ManagedNodeGroup{ng}
+	}
+
+	if clusterConfig.Addons, err = w.askAddons(); err != nil {
+		return nil, err
+	}
+	withOIDC, err := w.askYesNo("Enable the IAM OIDC provider, for IAM roles for service accounts", false)
+	if err != nil {
+		return nil, err
+	}
+	if withOIDC {
+		clusterConfig.IAM = &api.ClusterIAM{
+			WithOIDC: api.Enabled(),
+		}
+	}
+	return clusterConfig, nil
+}
+
+func (w *clusterWizard) askVPC() (*api.ClusterVPC, error) {
+	choice, err := w.choose("Create a new VPC or use the subnets of an existing VPC", []string{vpcChoiceNew, vpcChoiceExisting}, vpcChoiceNew)
+	if err != nil {
+		return nil, err
+	}
+
+	if choice == vpcChoiceNew {
+		defaultCIDR := api.DefaultCIDR()
+		cidr, err := w.ask("VPC CIDR", defaultCIDR.String(), func(answer string) error {
+			_, err := ipnet.ParseCIDR(answer)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		natMode, err := w.choose("NAT gateway mode", []string{api.ClusterSingleNAT, api.ClusterHighlyAvailableNAT, api.ClusterDisableNAT}, api.ClusterSingleNAT)
+		if err != nil {
+			return nil, err
+		}
+		return &api.ClusterVPC{
+			Network: api.Network{
+				CIDR: ipnet.MustParseCIDR(cidr),
+			},
+			NAT: &api.ClusterNAT{
+				Gateway: &natMode,
+			},
+		}, nil
+	}
+
+	subnets := &api.ClusterSubnets{
+		Private: api.NewAZSubnetMapping(),
+		Public:  api.NewAZSubnetMapping(),
+	}
+	for _, s := range []struct {
+		topology string
+		mapping  api.AZSubnetMapping
+	}{
+		{topology: "private", mapping: subnets.Private},
+		{topology: "public", mapping: subnets.Public},
+	} {
+		answer, err := w.ask(fmt.Sprintf("IDs of the %s subnets, comma separated", s.topology), "", nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range splitList(answer) {
+			s.mapping[id] = api.AZSubnetSpec{ID: id}
+		}
+	}
+	if len(subnets.Private) == 0 && len(subnets.Public) == 0 {
+		return nil, errors.New("at least one subnet is required to use an existing VPC")
+	}
+	return &api.ClusterVPC{
+		Subnets: subnets,
+	}, nil
+}
+
+func (w *clusterWizard) askManagedNodeGroup() (*api.ManagedNodeGroup, error) {
+	ng := &api.ManagedNodeGroup{
+		NodeGroupBase: &api.NodeGroupBase{
+			ScalingConfig: &api.ScalingConfig{},
+		},
+	}
+
+	var err error
+	if ng.Name, err = w.ask("Nodegroup name", "ng-1", validateWizardName); err != nil {
+		return nil, err
+	}
+	instanceTypes, err := w.ask("Instance types, comma separated, or empty to select instance types by vCPUs and memory", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if ng.InstanceTypes = splitList(instanceTypes); len(ng.InstanceTypes) == 0 {
+		vCPUs, err := w.askInt("vCPUs per node", 2, 1)
+		if err != nil {
+			return nil, err
+		}
+		memory, err := w.askInt("Memory per node, in GiB", 4, 1)
+		if err != nil {
+			return nil, err
+		}
+		ng.InstanceSelector = &api.InstanceSelector{
+			VCPUs:  vCPUs,
+			Memory: strconv.Itoa(memory),
+		}
+		fmt.Fprintf(w.out, "eksctl will select the instance types with %d vCPUs and %d GiB of memory offered in the availability zones of the cluster\n", vCPUs, memory)
+	}
+
+	minSize, err := w.askInt("Minimum number of nodes", 2, 0)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := w.askInt("Desired number of nodes", max(minSize, 2), minSize)
+	if err != nil {
+		return nil, err
+	}
+	maxSize, err := w.askInt("Maximum number of nodes", max(desired, 1), max(desired, 1))
+	if err != nil {
+		return nil, err
+	}
+	ng.DesiredCapacity, ng.MinSize, ng.MaxSize = &desired, &minSize, &maxSize
+	return ng, nil
+}
+
+func (w *clusterWizard) askAddons() ([]*api.Addon, error) {
+	question := fmt.Sprintf("Addons to install besides %s, %s and %s, comma separated, e.g. %s",
+		api.VPCCNIAddon, api.KubeProxyAddon, api.CoreDNSAddon, strings.Join(suggestedAddons, ","))
+	answer, err := w.ask(question, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var addons []*api.Addon
+	for _, name := range splitList(answer) {
+		addons = append(addons, &api.Addon{Name: name})
+	}
+	return addons, nil
+}
+
+// ask asks question until the answer passes validate, an empty answer selects defaultAnswer
+func (w *clusterWizard) ask(question, defaultAnswer string, validate func(string) error) (string, error) {
+	for {
+		if defaultAnswer != "" {
+			fmt.Fprintf(w.out, "%s [%s]: ", question, defaultAnswer)
+		} else {
+			fmt.Fprintf(w.out, "%s: ", question)
+		}
+		line, err := w.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				return "", errors.New("no answer given, the interactive input ended")
+			}
+			return "", fmt.Errorf("reading answer: %w", err)
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = defaultAnswer
+		}
+		if validate == nil {
+			return answer, nil
+		}
+		if err := validate(answer); err != nil {
+			fmt.Fprintf(w.out, "invalid answer: %v\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+func (w *clusterWizard) choose(question string, options []string, defaultOption string) (string, error) {
+	return w.ask(fmt.Sprintf("%s (%s)", question, strings.Join(options, ", ")), defaultOption, func(answer string) error {
+		if !slices.Contains(options, answer) {
+			return fmt.Errorf("%q is not one of the options", answer)
+		}
+		return nil
+	})
+}
+
+func (w *clusterWizard) askInt(question string, defaultAnswer, minValue int) (int, error) {
+	answer, err := w.ask(question, strconv.Itoa(defaultAnswer), func(answer string) error {
+		value, err := strconv.Atoi(answer)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", answer)
+		}
+		if value < minValue {
+			return fmt.Errorf("must be at least %d", minValue)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(answer)
+}
+
+func (w *clusterWizard) askYesNo(question string, defaultAnswer bool) (bool, error) {
+	options := "y/N"
+	if defaultAnswer {
+		options = "Y/n"
+	}
+	answer, err := w.ask(fmt.Sprintf("%s? (%s)", question, options), "", func(answer string) error {
+		switch strings.ToLower(answer) {
+		case "", "y", "yes", "n", "no":
+			return nil
+		default:
+			return fmt.Errorf("answer yes or no")
+		}
+	})
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	default:
+		return defaultAnswer, nil
+	}
+}
+
+func validateWizardName(name string) error {
+	if name == "" || api.IsInvalidNameArg(name) {
+		return api.ErrInvalidName(name)
+	}
+	return nil
+}
+
+func splitList(answer string) []string {
+	var items []string
+	for _, item := range strings.Split(answer, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
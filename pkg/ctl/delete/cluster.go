@@ -2,40 +2,71 @@ package delete
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/inventory"
 	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/utils/exitcode"
 )
 
 func deleteClusterCmd(cmd *cmdutils.Cmd) {
-	deleteClusterWithRunFunc(cmd, func(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction bool, podEvictionWaitPeriod time.Duration, parallel int) error {
-		return doDeleteCluster(cmd, force, disableNodegroupEviction, podEvictionWaitPeriod, parallel)
+	deleteClusterWithRunFunc(cmd, func(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction bool, podEvictionWaitPeriod time.Duration, parallel int, deleteKubernetesResources []string, disableProtection, forceCleanup bool) error {
+		return doDeleteCluster(cmd, force, disableNodegroupEviction, podEvictionWaitPeriod, parallel, deleteKubernetesResources, disableProtection, forceCleanup)
 	})
 }
 
-func deleteClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction bool, podEvictionWaitPeriod time.Duration, parallel int) error) {
+func deleteClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction bool, podEvictionWaitPeriod time.Duration, parallel int, deleteKubernetesResources []string, disableProtection, forceCleanup bool) error) {
 	cfg := api.NewClusterConfig()
 	cmd.ClusterConfig = cfg
 
-	cmd.SetDescription("cluster", "Delete a cluster", "")
+	cmd.SetDescription("cluster", "Delete a cluster", dedent.Dedent(`Delete a cluster.
+
+		Lists the resources that will be deleted along with the cluster, and the resources created by
+		Kubernetes workloads that will be left behind. The cluster is only deleted when --approve is given or
+		the deletion is confirmed at the prompt, otherwise the command fails; --approve=false only previews the deletion.
+
+		With --delete-kubernetes-resources, Services of type LoadBalancer and Ingresses, and optionally
+		PersistentVolumeClaims backed by EBS or EFS, are deleted before any node is drained, so that the
+		controllers running on the nodes can delete the AWS resources that back them.
+
+		A cluster whose stack has termination protection enabled, e.g. with --enable-termination-protection,
+		is only deleted with --disable-protection, which disables the protection before deleting the cluster.
+
+		With --force-cleanup, when the cluster stack fails to be deleted because of resources left behind in the VPC
+		by Kubernetes controllers, i.e. load balancers, network interfaces and security groups tagged with the name of
+		the cluster, these resources are deleted and the deletion of the cluster stack is retried.
+	`))
 
 	var (
-		force                    bool
-		disableNodegroupEviction bool
-		podEvictionWaitPeriod    time.Duration
-		parallel                 int
+		force                     bool
+		disableNodegroupEviction  bool
+		podEvictionWaitPeriod     time.Duration
+		parallel                  int
+		deleteKubernetesResources []string
+		disableProtection         bool
+		forceCleanup              bool
 	)
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return runFunc(cmd, force, disableNodegroupEviction, podEvictionWaitPeriod, parallel)
+		if err := cluster.ValidateKubernetesResourceKinds(deleteKubernetesResources); err != nil {
+			return err
+		}
+		return runFunc(cmd, force, disableNodegroupEviction, podEvictionWaitPeriod, parallel, deleteKubernetesResources, disableProtection, forceCleanup)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -49,15 +80,22 @@ func deleteClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd,
 		defaultPodEvictionWaitPeriod, _ := time.ParseDuration("10s")
 		fs.DurationVar(&podEvictionWaitPeriod, "pod-eviction-wait-period", defaultPodEvictionWaitPeriod, "Duration to wait after failing to evict a pod")
 		fs.IntVar(&parallel, "parallel", 1, "Number of nodes to drain in parallel. Max 25")
+		cmdutils.AddStackParallelismFlag(fs, cmd, "nodegroups and iamserviceaccounts")
+		fs.StringSliceVar(&deleteKubernetesResources, "delete-kubernetes-resources", nil,
+			fmt.Sprintf("Kubernetes resources backed by AWS resources to delete before draining nodes, valid values are: %s", strings.Join(cluster.KubernetesResourceKinds, ", ")))
+		fs.Lookup("delete-kubernetes-resources").NoOptDefVal = cluster.KubernetesResourcesLoadBalancers
+		fs.BoolVar(&disableProtection, "disable-protection", false, "disable termination protection on the cluster stack, required to delete a protected cluster")
+		fs.BoolVar(&forceCleanup, "force-cleanup", false, "delete the load balancers, network interfaces and security groups left behind in the VPC by Kubernetes controllers when they keep the VPC from being deleted")
 
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddApproveFlag(fs, cmd)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 	})
 
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, true)
 }
 
-func doDeleteCluster(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction bool, podEvictionWaitPeriod time.Duration, parallel int) error {
+func doDeleteCluster(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction bool, podEvictionWaitPeriod time.Duration, parallel int, deleteKubernetesResources []string, disableProtection, forceCleanup bool) error {
 	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
 		return err
 	}
@@ -80,17 +118,116 @@ func doDeleteCluster(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction boo
 		}
 	}
 
-	logger.Info("deleting EKS cluster %q", meta.Name)
-	if err := printer.LogObj(logger.Debug, "cfg.json = \\\n%s\n", cfg); err != nil {
+	deleteOptions := cluster.DeleteOptions{
+		WaitInterval:             20 * time.Second,
+		PodEvictionWaitPeriod:    podEvictionWaitPeriod,
+		Wait:                     cmd.Wait,
+		Force:                    force,
+		ForceCleanup:             forceCleanup,
+		DisableNodegroupEviction: disableNodegroupEviction,
+		Parallel:                 parallel,
+		StackParallelism:         cmd.StackParallelism,
+	}
+	cluster, err := cluster.New(ctx, cfg, ctl)
+	if err != nil {
 		return err
 	}
 
-	cluster, err := cluster.New(ctx, cfg, ctl)
+	protectedStack, err := protectedClusterStack(ctx, ctl.NewStackManager(cfg), disableProtection)
 	if err != nil {
 		return err
 	}
 
+	// the report is informational, listing some resources needs permissions or a reachable API server
+	// that deleting the cluster does not need, so failing to build it must not block the deletion
+	report, err := cluster.DeletionReport(ctx)
+	if err != nil {
+		logger.Warning("unable to list the resources of cluster %q, continuing without them: %v", meta.Name, err)
+	} else {
+		report.Log()
+	}
+
+	if err := confirmClusterDeletion(cmd, meta.Name); err != nil {
+		return err
+	}
+
+	if protectedStack != "" {
+		cmdutils.LogIntendedAction(cmd.Plan, "disable termination protection on stack %q", protectedStack)
+	}
+
+	if cmd.Plan {
+		cmdutils.LogPlanModeWarning(true)
+		return nil
+	}
+
+	logger.Info("deleting EKS cluster %q", meta.Name)
+	if err := printer.LogObj(logger.Debug, "cfg.json = \\\n%s\n", cfg); err != nil {
+		return err
+	}
+
+	if protectedStack != "" {
+		if _, err := ctl.AWSProvider.CloudFormation().UpdateTerminationProtection(ctx, &cloudformation.UpdateTerminationProtectionInput{
+			StackName:                   aws.String(protectedStack),
+			EnableTerminationProtection: aws.Bool(false),
+		}); err != nil {
+			return fmt.Errorf("disabling termination protection on stack %q: %w", protectedStack, err)
+		}
+		logger.Info("disabled termination protection on stack %q", protectedStack)
+	}
+
+	if len(deleteKubernetesResources) > 0 {
+		if err := cluster.DeleteKubernetesResources(ctx, deleteKubernetesResources); err != nil {
+			if !force {
+				return fmt.Errorf("deleting Kubernetes resources: %w", err)
+			}
+			logger.Warning("failed to delete Kubernetes resources; force = true so proceeding: %v", err)
+		}
+	}
+
 	// ProviderConfig.WaitTimeout is not respected by cluster.Delete, which means the operation will never time out.
 	// When this is fixed, a deadline-based Context can be used here.
-	return cluster.Delete(ctx, 20*time.Second, podEvictionWaitPeriod, cmd.Wait, force, disableNodegroupEviction, parallel)
+	if err := cluster.Delete(ctx, deleteOptions); err != nil {
+		return err
+	}
+	if err := nodegroup.DeleteClusterHistory(ctx, ctl.AWSProvider.SSM(), meta.Name); err != nil {
+		logger.Warning(err.Error())
+	}
+
+	inventory.Update(func(i *inventory.Inventory) error {
+		return i.RemoveCluster(meta.Name, meta.Region)
+	})
+	return nil
+}
+
+// confirmClusterDeletion asks for confirmation unless --approve was given. An explicit --approve=false only
+// previews the deletion, but when --approve was omitted and the deletion was not confirmed, e.g. because stdin is not
+// a terminal, it fails so that scripts written before --approve was required do not silently skip the deletion.
+func confirmClusterDeletion(cmd *cmdutils.Cmd, clusterName string) error {
+	if !cmd.Plan || cmd.CobraCommand.Flag("approve").Changed {
+		return nil
+	}
+	confirmed, err := cmdutils.Confirm(fmt.Sprintf("delete cluster %q and the resources listed above", clusterName), false)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		logger.Critical("cluster %q was NOT deleted", clusterName)
+		return exitcode.WithCode(fmt.Errorf("deleting cluster %q requires --approve (or --yes), use --approve=false to only preview the deletion", clusterName), exitcode.ValidationError)
+	}
+	cmd.Plan = false
+	return nil
+}
+
+// protectedClusterStack returns the name of the cluster stack if termination protection is enabled on it, and fails
+// unless disableProtection is set, so that protected clusters are not deleted by accident
+func protectedClusterStack(ctx context.Context, stackManager manager.StackManager, disableProtection bool) (string, error) {
+	protectedStack, err := cluster.ProtectedClusterStack(ctx, stackManager)
+	if err != nil || protectedStack == "" {
+		return "", err
+	}
+	if !disableProtection {
+		return "", exitcode.WithCode(fmt.Errorf("termination protection is enabled on stack %q of the cluster; "+
+			"use --disable-protection to disable it and delete the cluster", protectedStack), exitcode.ValidationError)
+	}
+	return protectedStack, nil
 }
@@ -1,11 +1,18 @@
 package delete
 
 import (
+	"context"
+	"os"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/term"
 
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 )
 
@@ -19,7 +26,7 @@ var _ = Describe("delete cluster", func() {
 			cmd := newMockEmptyCmd(args...)
 			count := 0
 			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
-				deleteClusterWithRunFunc(cmd, func(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction bool, podEvictionWaitPeriod time.Duration, parallel int) error {
+				deleteClusterWithRunFunc(cmd, func(cmd *cmdutils.Cmd, force bool, disableNodegroupEviction bool, podEvictionWaitPeriod time.Duration, parallel int, _ []string, _ bool, _ bool) error {
 					Expect(cmd.ClusterConfig.Metadata.Name).To(Equal(clusterName))
 					Expect(force).To(Equal(forceExpected))
 					Expect(disableNodegroupEviction).To(Equal(disableNodegroupEvictionExpected))
@@ -36,4 +43,124 @@ var _ = Describe("delete cluster", func() {
 		Entry("with valid cluster name and disableNodeGroupEviction flag", false, true, "cluster", "--name", clusterName, "--disable-nodegroup-eviction"),
 		Entry("with valid cluster name, force & disableNodeGroupEviction flags", true, true, "cluster", "--name", clusterName, "--force", "--disable-nodegroup-eviction"),
 	)
+
+	DescribeTable("should only delete the cluster when approved",
+		func(planExpected bool, args ...string) {
+			cmd := newMockEmptyCmd(args...)
+			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+				deleteClusterWithRunFunc(cmd, func(cmd *cmdutils.Cmd, _ bool, _ bool, _ time.Duration, _ int, _ []string, _ bool, _ bool) error {
+					Expect(cmd.Plan).To(Equal(planExpected))
+					return nil
+				})
+			})
+			_, err := cmd.execute()
+			Expect(err).NotTo(HaveOccurred())
+		},
+		Entry("without --approve", true, "cluster", "--name", clusterName),
+		Entry("with --approve", false, "cluster", "--name", clusterName, "--approve"),
+	)
+
+	DescribeTable("should fail instead of silently previewing when --approve is omitted",
+		func(expectedErr string, args ...string) {
+			if term.IsTerminal(int(os.Stdin.Fd())) {
+				Skip("stdin is a terminal, the command would ask for confirmation")
+			}
+			cmd := newMockEmptyCmd(args...)
+			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+				deleteClusterWithRunFunc(cmd, func(cmd *cmdutils.Cmd, _ bool, _ bool, _ time.Duration, _ int, _ []string, _ bool, _ bool) error {
+					return confirmClusterDeletion(cmd, clusterName)
+				})
+			})
+			_, err := cmd.execute()
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("without --approve", `deleting cluster "clusterName" requires --approve`, "cluster", "--name", clusterName),
+		Entry("with --approve=false", "", "cluster", "--name", clusterName, "--approve=false"),
+		Entry("with --approve", "", "cluster", "--name", clusterName, "--approve"),
+	)
+
+	DescribeTable("should pass the Kubernetes resources to delete",
+		func(expectedKinds []string, args ...string) {
+			cmd := newMockEmptyCmd(args...)
+			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+				deleteClusterWithRunFunc(cmd, func(_ *cmdutils.Cmd, _ bool, _ bool, _ time.Duration, _ int, deleteKubernetesResources []string, _ bool, _ bool) error {
+					Expect(deleteKubernetesResources).To(Equal(expectedKinds))
+					return nil
+				})
+			})
+			_, err := cmd.execute()
+			Expect(err).NotTo(HaveOccurred())
+		},
+		Entry("without the flag", nil, "cluster", "--name", clusterName),
+		Entry("with the flag and no value", []string{"load-balancers"}, "cluster", "--name", clusterName, "--delete-kubernetes-resources"),
+		Entry("with load balancers and volumes", []string{"load-balancers", "volumes"}, "cluster", "--name", clusterName, "--delete-kubernetes-resources=load-balancers,volumes"),
+	)
+
+	DescribeTable("should pass whether to disable termination protection",
+		func(expected bool, args ...string) {
+			cmd := newMockEmptyCmd(args...)
+			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+				deleteClusterWithRunFunc(cmd, func(_ *cmdutils.Cmd, _ bool, _ bool, _ time.Duration, _ int, _ []string, disableProtection bool, _ bool) error {
+					Expect(disableProtection).To(Equal(expected))
+					return nil
+				})
+			})
+			_, err := cmd.execute()
+			Expect(err).NotTo(HaveOccurred())
+		},
+		Entry("without the flag", false, "cluster", "--name", clusterName),
+		Entry("with the flag", true, "cluster", "--name", clusterName, "--disable-protection"),
+	)
+
+	DescribeTable("should pass whether to clean up the resources blocking the deletion of the VPC",
+		func(expected bool, args ...string) {
+			cmd := newMockEmptyCmd(args...)
+			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+				deleteClusterWithRunFunc(cmd, func(_ *cmdutils.Cmd, _ bool, _ bool, _ time.Duration, _ int, _ []string, _ bool, forceCleanup bool) error {
+					Expect(forceCleanup).To(Equal(expected))
+					return nil
+				})
+			})
+			_, err := cmd.execute()
+			Expect(err).NotTo(HaveOccurred())
+		},
+		Entry("without the flag", false, "cluster", "--name", clusterName),
+		Entry("with the flag", true, "cluster", "--name", clusterName, "--force-cleanup"),
+	)
+
+	DescribeTable("protectedClusterStack",
+		func(protection *bool, disableProtection bool, expectedStack, expectedErr string) {
+			stackManager := &fakes.FakeStackManager{}
+			stackManager.GetClusterStackIfExistsReturns(&manager.Stack{
+				StackName:                   aws.String("eksctl-clusterName-cluster"),
+				EnableTerminationProtection: protection,
+			}, nil)
+			stack, err := protectedClusterStack(context.Background(), stackManager, disableProtection)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stack).To(Equal(expectedStack))
+		},
+		Entry("unprotected stack", nil, false, "", ""),
+		Entry("protected stack without --disable-protection", aws.Bool(true), false, "", `termination protection is enabled on stack "eksctl-clusterName-cluster"`),
+		Entry("protected stack with --disable-protection", aws.Bool(true), true, "eksctl-clusterName-cluster", ""),
+	)
+
+	It("rejects unknown Kubernetes resource kinds", func() {
+		cmd := newMockEmptyCmd("cluster", "--name", clusterName, "--delete-kubernetes-resources=pods")
+		cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+			deleteClusterWithRunFunc(cmd, func(_ *cmdutils.Cmd, _ bool, _ bool, _ time.Duration, _ int, _ []string, _ bool, _ bool) error {
+				Fail("unexpected call to delete the cluster")
+				return nil
+			})
+		})
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(`invalid Kubernetes resource kind "pods", valid values are: load-balancers, volumes`))
+	})
 })
@@ -44,9 +44,11 @@ func deleteIAMServiceAccountCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *
 		cmdutils.AddClusterFlag(fs, cfg.Metadata)
 		fs.StringVar(&serviceAccount.Name, "name", "", "name of the iamserviceaccount to delete")
 		fs.StringVar(&serviceAccount.Namespace, "namespace", "default", "namespace where to delete the iamserviceaccount")
+		cmdutils.AddIAMServiceAccountTargetAccountFlag(fs, &serviceAccount.TargetAccountRoleARN, "delete")
 
 		cmdutils.AddIAMServiceAccountFilterFlags(fs, &cmd.Include, &cmd.Exclude)
 		fs.BoolVar(&onlyMissing, "only-missing", false, "Only delete iamserviceaccounts that are not defined in the given config file")
+		cmdutils.AddStackParallelismFlag(fs, cmd, "iamserviceaccounts")
 		cmdutils.AddApproveFlag(fs, cmd)
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
@@ -112,10 +114,41 @@ func doDeleteIAMServiceAccount(cmd *cmdutils.Cmd, serviceAccount *api.ClusterIAM
 
 	saSubset, _ := saFilter.MatchAll(cfg.IAM.ServiceAccounts)
 
-	irsaManager := irsa.New(cfg.Metadata.Name, stackManager, oidc, clientSet)
+	irsaManager := irsa.New(cfg.Metadata.Name, stackManager, oidc, clientSet).
+		WithParallelism(cmd.StackParallelism).
+		WithTargetAccounts(func(roleARN string) irsa.TargetAccount {
+			return ctl.NewIRSATargetAccount(cfg, roleARN)
+		})
 
 	if err := printer.LogObj(logger.Debug, "cfg.json = \\\n%s\n", cfg); err != nil {
 		return err
 	}
-	return irsaManager.Delete(ctx, sets.List(saSubset), cmd.Plan, cmd.Wait)
+
+	// the roles of the iamserviceaccounts with a targetAccountRoleARN live in the account of that role
+	targetAccountRoles := map[string]string{}
+	for _, sa := range cfg.IAM.ServiceAccounts {
+		if sa.TargetAccountRoleARN != "" {
+			targetAccountRoles[sa.NameString()] = sa.TargetAccountRoleARN
+		}
+	}
+	var serviceAccounts []string
+	targetAccountServiceAccounts := map[string][]string{}
+	for _, name := range sets.List(saSubset) {
+		if roleARN, ok := targetAccountRoles[name]; ok {
+			targetAccountServiceAccounts[roleARN] = append(targetAccountServiceAccounts[roleARN], name)
+		} else {
+			serviceAccounts = append(serviceAccounts, name)
+		}
+	}
+	if len(serviceAccounts) > 0 || len(targetAccountServiceAccounts) == 0 {
+		if err := irsaManager.Delete(ctx, serviceAccounts, cmd.Plan, cmd.Wait); err != nil {
+			return err
+		}
+	}
+	for _, roleARN := range sets.List(sets.KeySet(targetAccountServiceAccounts)) {
+		if err := irsaManager.DeleteInTargetAccount(ctx, roleARN, targetAccountServiceAccounts[roleARN], cmd.Plan, cmd.Wait); err != nil {
+			return err
+		}
+	}
+	return nil
 }
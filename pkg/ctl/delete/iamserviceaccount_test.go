@@ -35,6 +35,7 @@ var _ = Describe("delete iamserviceaccount", func() {
 		Entry("with namespace flag", "--cluster", "clusterName", "--name", "serviceAccountName", "--namespace", "dev"),
 		Entry("with only-missing flag", "--cluster", "clusterName", "--name", "serviceAccountName", "--only-missing"),
 		Entry("with approve flag", "--cluster", "clusterName", "--name", "serviceAccountName", "--approve"),
+		Entry("with target-account flag", "--cluster", "clusterName", "--name", "serviceAccountName", "--target-account", "arn:aws:iam::111122223333:role/irsa-deployer"),
 	)
 
 	DescribeTable("invalid flags or arguments",
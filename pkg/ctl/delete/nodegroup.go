@@ -2,9 +2,13 @@ package delete
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/kris-nova/logger"
@@ -16,16 +20,20 @@ import (
 	"github.com/weaveworks/eksctl/pkg/authconfigmap"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
+	"github.com/weaveworks/eksctl/pkg/inventory"
 )
 
 type deleteNodeGroupOptions struct {
-	updateAuthConfigMap   *bool
-	deleteNodeGroupDrain  bool
-	onlyMissing           bool
-	maxGracePeriod        time.Duration
-	podEvictionWaitPeriod time.Duration
-	disableEviction       bool
-	parallel              int
+	updateAuthConfigMap       *bool
+	deleteNodeGroupDrain      bool
+	onlyMissing               bool
+	maxGracePeriod            time.Duration
+	podEvictionWaitPeriod     time.Duration
+	gracePeriod               time.Duration
+	waitForDeployments        []string
+	waitForDeploymentsTimeout time.Duration
+	disableEviction           bool
+	parallel                  int
 }
 
 func deleteNodeGroupCmd(cmd *cmdutils.Cmd) {
@@ -55,6 +63,7 @@ func deleteNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cm
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddApproveFlag(fs, cmd)
 		cmdutils.AddNodeGroupFilterFlags(fs, &cmd.Include, &cmd.Exclude)
+		cmdutils.AddNodeGroupSelectorFlag(fs, &cmd.Selector)
 		fs.BoolVar(&options.onlyMissing, "only-missing", false, "Only delete nodegroups that are not defined in the given config file")
 		options.updateAuthConfigMap = cmdutils.AddUpdateAuthConfigMap(fs, "Remove nodegroup IAM role from aws-auth configmap")
 		fs.BoolVar(&options.deleteNodeGroupDrain, "drain", true, "Drain and cordon all nodes in the nodegroup before deletion")
@@ -62,8 +71,12 @@ func deleteNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cm
 		fs.DurationVar(&options.maxGracePeriod, "max-grace-period", defaultMaxGracePeriod, "Maximum pods termination grace period")
 		defaultPodEvictionWaitPeriod, _ := time.ParseDuration("10s")
 		fs.DurationVar(&options.podEvictionWaitPeriod, "pod-eviction-wait-period", defaultPodEvictionWaitPeriod, "Duration to wait after failing to evict a pod")
+		fs.DurationVar(&options.gracePeriod, "grace-period", 0, "Duration to wait after cordoning the nodes before draining them, e.g. to let load balancers deregister them")
+		fs.StringSliceVar(&options.waitForDeployments, "wait-for-deployments", nil, "Deployments, as <namespace>/<name>, that must be available again on other nodes before the nodegroup is deleted")
+		fs.DurationVar(&options.waitForDeploymentsTimeout, "wait-for-deployments-timeout", 10*time.Minute, "Maximum time to wait for the deployments in --wait-for-deployments to be available")
 		fs.BoolVar(&options.disableEviction, "disable-eviction", false, "Force drain to use delete, even if eviction is supported. This will bypass checking PodDisruptionBudgets, use with caution.")
 		fs.IntVar(&options.parallel, "parallel", 1, "Number of nodes to drain in parallel. Max 25")
+		cmdutils.AddStackParallelismFlag(fs, cmd, "nodegroups")
 
 		cmd.Wait = false
 		cmdutils.AddWaitFlag(fs, &cmd.Wait, "deletion of all resources")
@@ -73,6 +86,22 @@ func deleteNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cm
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, true)
 }
 
+// parseDeployments parses deployments given as <namespace>/<name>, or as <name> in the default namespace
+func parseDeployments(deployments []string) ([]types.NamespacedName, error) {
+	var names []types.NamespacedName
+	for _, d := range deployments {
+		namespace, name, found := strings.Cut(d, "/")
+		if !found {
+			namespace, name = metav1.NamespaceDefault, d
+		}
+		if namespace == "" || name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid deployment %q, expected <namespace>/<name>", d)
+		}
+		names = append(names, types.NamespacedName{Namespace: namespace, Name: name})
+	}
+	return names, nil
+}
+
 type authConfigMapUpdater struct {
 	clientSet kubernetes.Interface
 }
@@ -88,6 +117,17 @@ func doDeleteNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, options deleteNodeG
 		return err
 	}
 
+	if !options.deleteNodeGroupDrain && (options.gracePeriod > 0 || len(options.waitForDeployments) > 0) {
+		return errors.New("--grace-period and --wait-for-deployments cannot be used with --drain=false")
+	}
+	if options.waitForDeploymentsTimeout <= 0 {
+		return errors.New("--wait-for-deployments-timeout must be a positive duration")
+	}
+	waitForDeployments, err := parseDeployments(options.waitForDeployments)
+	if err != nil {
+		return err
+	}
+
 	cfg := cmd.ClusterConfig
 
 	ctx := context.Background()
@@ -115,6 +155,16 @@ func doDeleteNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, options deleteNodeG
 				return err
 			}
 		}
+	} else if cmd.Selector != "" {
+		names, err := cmdutils.SelectNodeGroupsFromNodes(ctx, clientSet, cmd.Selector)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if err := cmdutils.PopulateNodegroup(ctx, stackManager, name, cfg, ctl.AWSProvider); err != nil {
+				return err
+			}
+		}
 	} else {
 		err := cmdutils.PopulateNodegroup(ctx, stackManager, ng.Name, cfg, ctl.AWSProvider)
 		if err != nil {
@@ -138,16 +188,32 @@ func doDeleteNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, options deleteNodeG
 	}
 	allNodeGroups := cmdutils.ToKubeNodeGroups(cfg.NodeGroups, cfg.ManagedNodeGroups)
 
+	if !cmd.Plan && !cmd.CobraCommand.Flag("approve").Changed && len(allNodeGroups) > 0 {
+		for _, ng := range allNodeGroups {
+			logger.Info("nodegroup %q will be deleted", ng.NameString())
+		}
+		confirmed, err := cmdutils.Confirm(fmt.Sprintf("delete %d nodegroup(s) from cluster %q", len(allNodeGroups), cfg.Metadata.Name), true)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return cmdutils.ErrNotConfirmed("delete nodegroups")
+		}
+	}
+
 	if options.deleteNodeGroupDrain {
 		cmdutils.LogIntendedAction(cmd.Plan, "drain %d nodegroup(s) in cluster %q", len(allNodeGroups), cfg.Metadata.Name)
 
 		drainInput := &nodegroup.DrainInput{
-			NodeGroups:            allNodeGroups,
-			Plan:                  cmd.Plan,
-			MaxGracePeriod:        options.maxGracePeriod,
-			PodEvictionWaitPeriod: options.podEvictionWaitPeriod,
-			DisableEviction:       options.disableEviction,
-			Parallel:              options.parallel,
+			NodeGroups:                allNodeGroups,
+			Plan:                      cmd.Plan,
+			MaxGracePeriod:            options.maxGracePeriod,
+			PodEvictionWaitPeriod:     options.podEvictionWaitPeriod,
+			GracePeriod:               options.gracePeriod,
+			WaitForDeployments:        waitForDeployments,
+			WaitForDeploymentsTimeout: options.waitForDeploymentsTimeout,
+			DisableEviction:           options.disableEviction,
+			Parallel:                  options.parallel,
 		}
 		drainCtx, cancel := context.WithTimeout(ctx, cmd.ProviderConfig.WaitTimeout)
 		defer cancel()
@@ -170,15 +236,28 @@ func doDeleteNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, options deleteNodeG
 		AuthConfigMapUpdater: &authConfigMapUpdater{
 			clientSet: clientSet,
 		},
+		StacklessEngines: nodegroup.NewStacklessEngines(cfg, ctl.AWSProvider),
 	}
 	if err := deleter.Delete(ctx, cfg.NodeGroups, cfg.ManagedNodeGroups, nodegroup.DeleteOptions{
 		Wait:                cmd.Wait,
 		Plan:                cmd.Plan,
 		UpdateAuthConfigMap: !api.IsDisabled(options.updateAuthConfigMap),
+		Parallelism:         cmd.StackParallelism,
 	}); err != nil {
 		return err
 	}
 
+	if !cmd.Plan {
+		inventory.Update(func(i *inventory.Inventory) error {
+			return i.RemoveNodeGroups(cfg.Metadata.Name, cfg.Metadata.Region, cfg.GetAllNodeGroupNames())
+		})
+		for _, name := range cfg.GetAllNodeGroupNames() {
+			if err := nodegroup.DeleteHistory(ctx, ctl.AWSProvider.SSM(), cfg.Metadata.Name, name); err != nil {
+				logger.Warning(err.Error())
+			}
+		}
+	}
+
 	cmdutils.LogCompletedAction(cmd.Plan, "deleted %d nodegroup(s) from cluster %q", len(allNodeGroups), cfg.Metadata.Name)
 	cmdutils.LogPlanModeWarning(cmd.Plan && len(allNodeGroups) > 0)
 	return nil
@@ -61,5 +61,25 @@ var _ = Describe("delete", func() {
 			args:  []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--parallel", "26"},
 			error: fmt.Errorf("Error: --parallel value must be of range 1-25"),
 		}),
+		Entry("setting --grace-period without draining", invalidParamsCase{
+			args:  []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--drain=false", "--grace-period", "1m"},
+			error: fmt.Errorf("Error: --grace-period and --wait-for-deployments cannot be used with --drain=false"),
+		}),
+		Entry("setting an invalid deployment in --wait-for-deployments", invalidParamsCase{
+			args:  []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--wait-for-deployments", "default/app/web"},
+			error: fmt.Errorf(`Error: invalid deployment "default/app/web", expected <namespace>/<name>`),
+		}),
+		Entry("setting a non-positive --wait-for-deployments-timeout", invalidParamsCase{
+			args:  []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--wait-for-deployments", "apps/web", "--wait-for-deployments-timeout", "0s"},
+			error: fmt.Errorf("Error: --wait-for-deployments-timeout must be a positive duration"),
+		}),
+		Entry("setting --selector and --name at the same time", invalidParamsCase{
+			args:  []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--selector", "role=batch"},
+			error: fmt.Errorf("Error: --selector cannot be used with --name or a nodegroup name argument"),
+		}),
+		Entry("setting an invalid --selector", invalidParamsCase{
+			args:  []string{"nodegroup", "--cluster", "dummy", "--selector", "role=="},
+			error: fmt.Errorf(`Error: parsing --selector "role=="`),
+		}),
 	)
 })
@@ -35,6 +35,15 @@ func deregisterClusterCmd(cmd *cmdutils.Cmd) {
 }
 
 func deregisterCluster(cmd *cmdutils.Cmd, clusterName string) error {
+	logger.Info("cluster %q will be deregistered from EKS, along with the IAM role of its EKS Connector if eksctl created it", clusterName)
+	confirmed, err := cmdutils.Confirm(fmt.Sprintf("deregister cluster %q", clusterName), true)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return cmdutils.ErrNotConfirmed("deregister cluster")
+	}
+
 	ctx := context.Background()
 	clusterProvider, err := eks.New(ctx, &cmd.ProviderConfig, nil)
 	if err != nil {
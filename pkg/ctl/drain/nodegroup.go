@@ -49,6 +49,7 @@ func drainNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddApproveFlag(fs, cmd)
 		cmdutils.AddNodeGroupFilterFlags(fs, &cmd.Include, &cmd.Exclude)
+		cmdutils.AddNodeGroupSelectorFlag(fs, &cmd.Selector)
 		fs.BoolVar(&onlyMissing, "only-missing", false, "Only drain nodegroups that are not defined in the given config file")
 		fs.BoolVar(&undo, "undo", false, "Uncordon the nodegroup")
 		defaultMaxGracePeriod, _ := time.ParseDuration("10m")
@@ -100,6 +101,16 @@ func doDrainNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, undo, onlyMissing bo
 				return err
 			}
 		}
+	} else if cmd.Selector != "" {
+		names, err := cmdutils.SelectNodeGroupsFromNodes(ctx, clientSet, cmd.Selector)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if err := cmdutils.PopulateNodegroup(ctx, stackManager, name, cfg, ctl.AWSProvider); err != nil {
+				return err
+			}
+		}
 	} else {
 		err := cmdutils.PopulateNodegroup(ctx, stackManager, ng.Name, cfg, ctl.AWSProvider)
 		if err != nil {
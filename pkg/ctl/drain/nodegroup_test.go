@@ -60,5 +60,13 @@ var _ = Describe("drain node group", func() {
 			args:  []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--parallel", "26"},
 			error: fmt.Errorf("Error: --parallel value must be of range 1-25"),
 		}),
+		Entry("setting --selector and --name at the same time", invalidParamsCase{
+			args:  []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--selector", "role=batch"},
+			error: fmt.Errorf("Error: --selector cannot be used with --name or a nodegroup name argument"),
+		}),
+		Entry("setting an invalid --selector", invalidParamsCase{
+			args:  []string{"nodegroup", "--cluster", "dummy", "--selector", "role=="},
+			error: fmt.Errorf(`Error: parsing --selector "role=="`),
+		}),
 	)
 })
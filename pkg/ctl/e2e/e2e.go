@@ -0,0 +1,104 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/e2e"
+)
+
+type e2eCmdParams struct {
+	options e2e.Options
+	report  string
+}
+
+// Command sets up the hidden `e2e` command, which exercises the eksctl binary against a real AWS account
+func Command(cmd *cmdutils.Cmd) {
+	e2eCmdWithRunFunc(cmd, doE2E)
+}
+
+func e2eCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, params *e2eCmdParams) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+
+	cmd.SetDescription("e2e", "Run the end-to-end checks against a real AWS account",
+		"Creates a disposable cluster, scales, optionally upgrades, and deletes it with this eksctl binary, "+
+			"and writes the result of each step to a JUnit report. The cluster is tagged with "+e2e.Tag+" and expires after "+e2e.TTL+", "+
+			"so that `eksctl gc --expired` deletes it should the cleanup fail. Meant for distributions validating their builds; "+
+			"it creates billable resources.")
+	cmd.CobraCommand.Hidden = true
+
+	params := &e2eCmdParams{}
+
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		params.options.Region = cmd.ProviderConfig.Region
+		if params.options.Region == "" {
+			return errors.New("--region must be set")
+		}
+		if params.options.Nodes < 1 {
+			return errors.New("--nodes must be at least 1")
+		}
+		if params.options.NamePrefix == "" {
+			return errors.New("--name-prefix must not be empty")
+		}
+		return runFunc(cmd, params)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVar(&params.options.NamePrefix, "name-prefix", "eksctl-e2e", "prefix of the name of the disposable cluster, a random suffix is appended to it")
+		fs.StringVar(&params.options.Version, "version", "", "Kubernetes version of the cluster (the default version if unspecified)")
+		fs.BoolVar(&params.options.Upgrade, "upgrade", false, "upgrade the cluster and its nodegroup to the next Kubernetes version")
+		fs.IntVar(&params.options.Nodes, "nodes", 1, "number of nodes to create the nodegroup with, it is then scaled up by one")
+		fs.StringVar(&params.report, "report", "eksctl-e2e.xml", "path of the JUnit report")
+	})
+}
+
+func doE2E(cmd *cmdutils.Cmd, params *e2eCmdParams) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("finding the eksctl binary: %w", err)
+	}
+
+	clusterName := params.options.ClusterName()
+	logger.Info("running the end-to-end checks with disposable cluster %q in region %q", clusterName, params.options.Region)
+	runner := &e2e.Runner{
+		Run: func(ctx context.Context, args []string) (string, error) {
+			output := &bytes.Buffer{}
+			c := exec.CommandContext(ctx, executable, append(args, "--color", "false")...)
+			c.Stdout = io.MultiWriter(output, os.Stdout)
+			c.Stderr = io.MultiWriter(output, os.Stderr)
+			err := c.Run()
+			return output.String(), err
+		},
+	}
+	suite := runner.RunSteps(context.Background(), "eksctl", e2e.Steps(clusterName, params.options))
+
+	f, err := os.Create(params.report)
+	if err != nil {
+		return fmt.Errorf("creating JUnit report: %w", err)
+	}
+	defer f.Close()
+	if err := e2e.WriteJUnit(f, suite); err != nil {
+		return fmt.Errorf("writing JUnit report: %w", err)
+	}
+	logger.Info("wrote JUnit report to %q", params.report)
+
+	if suite.Failed() {
+		return fmt.Errorf("%d of %d end-to-end steps failed; if the cleanup failed, delete cluster %q with `eksctl delete cluster` or `eksctl gc --expired`",
+			suite.Failures, suite.Tests, clusterName)
+	}
+	logger.Success("all %d end-to-end steps succeeded", suite.Tests)
+	return nil
+}
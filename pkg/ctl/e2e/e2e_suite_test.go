@@ -0,0 +1,11 @@
+package e2e
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestCtlE2E(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
@@ -0,0 +1,49 @@
+package e2e
+
+import (
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("e2e", func() {
+	execute := func(args ...string) (*e2eCmdParams, *cobra.Command, error) {
+		var e2eParams *e2eCmdParams
+		rootCmd := &cobra.Command{}
+		cmdutils.AddResourceCmd(cmdutils.NewGrouping(), rootCmd, func(cmd *cmdutils.Cmd) {
+			e2eCmdWithRunFunc(cmd, func(cmd *cmdutils.Cmd, params *e2eCmdParams) error {
+				e2eParams = params
+				return nil
+			})
+		})
+		rootCmd.SetArgs(append([]string{"e2e"}, args...))
+		err := rootCmd.Execute()
+		return e2eParams, rootCmd, err
+	}
+
+	It("is hidden and uses the defaults", func() {
+		params, rootCmd, err := execute("--region", "us-west-2")
+		Expect(err).NotTo(HaveOccurred())
+		e2eCmd, _, err := rootCmd.Find([]string{"e2e"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e2eCmd.Hidden).To(BeTrue())
+		Expect(params.options.Region).To(Equal("us-west-2"))
+		Expect(params.options.NamePrefix).To(Equal("eksctl-e2e"))
+		Expect(params.options.Nodes).To(Equal(1))
+		Expect(params.options.Upgrade).To(BeFalse())
+		Expect(params.report).To(Equal("eksctl-e2e.xml"))
+	})
+
+	It("requires a region", func() {
+		_, _, err := execute()
+		Expect(err).To(MatchError("--region must be set"))
+	})
+
+	It("requires at least one node", func() {
+		_, _, err := execute("--region", "us-west-2", "--nodes", "0")
+		Expect(err).To(MatchError("--nodes must be at least 1"))
+	})
+})
@@ -0,0 +1,69 @@
+package enable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
+
+	"github.com/weaveworks/eksctl/pkg/actions/argocd"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
+)
+
+func enableArgoCD(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"argocd",
+		"Set up GitOps with Argo CD - deploys Argo CD and an app-of-apps Application syncing the cluster from a Git repo",
+		"",
+	)
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if cmd.NameArg != "" {
+			return cmdutils.ErrUnsupportedNameArg()
+		}
+		if err := cmdutils.NewArgoCDConfigLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return doEnableArgoCD(cmd)
+	}
+}
+
+func doEnableArgoCD(cmd *cmdutils.Cmd) error {
+	ctx := context.TODO()
+	cfg := cmd.ClusterConfig
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	kubeConfigBytes, err := runtime.Encode(clientcmdlatest.Codec, kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), "", ctl.AWSProvider.Profile().Name))
+	if err != nil {
+		return fmt.Errorf("generating kubeconfig: %w", err)
+	}
+	installer, err := argocd.New(cfg, ctl.AWSProvider.SSM(), kubeConfigBytes)
+	if err != nil {
+		return err
+	}
+	if err := installer.Install(ctx); err != nil {
+		return err
+	}
+	logger.Success("installed Argo CD in cluster %q, syncing %s", cfg.Metadata.Name, cfg.GitOps.ArgoCD.RepoURL)
+	return nil
+}
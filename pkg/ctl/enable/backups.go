@@ -0,0 +1,106 @@
+package enable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
+
+	"github.com/weaveworks/eksctl/pkg/actions/backups"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers/helm"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
+)
+
+func enableBackups(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.ClusterConfig.Backups = &api.Backups{}
+	cmd.SetDescription(
+		"backups",
+		"Set up backups of the cluster with Velero",
+		"Creates an S3 bucket, unless an existing one is given, and an IAM role for Velero, then installs Velero to back up the cluster to the bucket",
+	)
+
+	cmd.FlagSetGroup.InFlagSet("Backups", func(fs *pflag.FlagSet) {
+		backupsConfig := cmd.ClusterConfig.Backups
+		fs.StringVar(&backupsConfig.Version, "version", "", "version of the Velero Helm chart")
+		fs.StringVar(&backupsConfig.Bucket, "bucket", "", "name of an existing S3 bucket to store backups in (defaults to creating a bucket)")
+		fs.StringVar(&backupsConfig.Prefix, "prefix", "", "path in the bucket under which backups are stored (defaults to the cluster name)")
+		fs.StringVar(&backupsConfig.Schedule, "schedule", "", `cron expression for a scheduled backup of all namespaces, e.g. "0 3 * * *"`)
+		fs.StringVar(&backupsConfig.TTL, "ttl", "", fmt.Sprintf("how long scheduled backups are kept (defaults to %s)", backups.DefaultTTL))
+	})
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if cmd.NameArg != "" {
+			return cmdutils.ErrUnsupportedNameArg()
+		}
+		if err := cmdutils.NewEnableBackupsLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return doEnableBackups(cmd)
+	}
+}
+
+func doEnableBackups(cmd *cmdutils.Cmd) error {
+	ctx := context.TODO()
+	cfg := cmd.ClusterConfig
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	oidcProviderExists, err := oidc.CheckProviderExists(ctx)
+	if err != nil {
+		return err
+	}
+	if !oidcProviderExists {
+		return fmt.Errorf("no IAM OIDC provider associated with cluster, try 'eksctl utils associate-iam-oidc-provider --region=%s --cluster=%s'", cfg.Metadata.Region, cfg.Metadata.Name)
+	}
+
+	kubeConfigBytes, err := runtime.Encode(clientcmdlatest.Codec, kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), "", ctl.AWSProvider.Profile().Name))
+	if err != nil {
+		return fmt.Errorf("generating kubeconfig: %w", err)
+	}
+	helmInstaller, err := helm.NewInstaller(helm.Options{
+		Namespace:        backups.Namespace,
+		RESTClientGetter: kubernetes.NewRESTClientGetter(backups.Namespace, string(kubeConfigBytes)),
+	})
+	if err != nil {
+		return err
+	}
+
+	installer := &backups.Installer{
+		StackManager:  ctl.NewStackManager(cfg),
+		OIDC:          oidc,
+		HelmInstaller: helmInstaller,
+		ClusterConfig: cfg,
+	}
+	if err := installer.Enable(ctx); err != nil {
+		return err
+	}
+	logger.Success("backups are enabled for cluster %q, use 'eksctl create backup' to take a backup", cfg.Metadata.Name)
+	return nil
+}
@@ -0,0 +1,98 @@
+package enable
+
+import (
+	"context"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/dns"
+	"github.com/weaveworks/eksctl/pkg/actions/irsa"
+	"github.com/weaveworks/eksctl/pkg/addons"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func enableDNS(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.ClusterConfig.DNS = &api.DNS{}
+	cmd.SetDescription(
+		"dns",
+		"Install external-dns and cert-manager for Route53 hosted zones",
+		"Creates IAM roles allowed to change the records of the given Route53 hosted zones only, and installs external-dns and cert-manager with them",
+	)
+
+	var installExternalDNS, installCertManager bool
+	cmd.FlagSetGroup.InFlagSet("DNS", func(fs *pflag.FlagSet) {
+		fs.StringSliceVar(&cmd.ClusterConfig.DNS.HostedZoneIDs, "hosted-zone-ids", nil, "IDs of the Route53 hosted zones external-dns and cert-manager can change")
+		fs.BoolVar(&installExternalDNS, "external-dns", false, "install external-dns")
+		fs.BoolVar(&installCertManager, "cert-manager", false, "install cert-manager")
+	})
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if cmd.NameArg != "" {
+			return cmdutils.ErrUnsupportedNameArg()
+		}
+		if installExternalDNS {
+			cmd.ClusterConfig.DNS.ExternalDNS = &api.ExternalDNS{}
+		}
+		if installCertManager {
+			cmd.ClusterConfig.DNS.CertManager = &api.CertManager{}
+		}
+		if err := cmdutils.NewEnableDNSLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return doEnableDNS(cmd)
+	}
+}
+
+func doEnableDNS(cmd *cmdutils.Cmd) error {
+	ctx := context.TODO()
+	cfg := cmd.ClusterConfig
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	stackManager := ctl.NewStackManager(cfg)
+
+	installer := &dns.Installer{
+		ClusterConfig: cfg,
+		IRSA:          addons.NewIRSAHelper(oidc, stackManager, irsa.New(cfg.Metadata.Name, stackManager, oidc, clientSet), cfg.Metadata.Name),
+	}
+	if cfg.DNS.ExternalDNS != nil {
+		if installer.ExternalDNSHelmInstaller, err = cmdutils.NewHelmInstaller(ctl, cfg, dns.ExternalDNSNamespace); err != nil {
+			return err
+		}
+	}
+	if cfg.DNS.CertManager != nil {
+		if installer.CertManagerHelmInstaller, err = cmdutils.NewHelmInstaller(ctl, cfg, dns.CertManagerNamespace); err != nil {
+			return err
+		}
+	}
+	if err := installer.Install(ctx); err != nil {
+		return err
+	}
+	logger.Success("installed the DNS integrations of cluster %q for hosted zones %v", cfg.Metadata.Name, cfg.DNS.HostedZoneIDs)
+	return nil
+}
@@ -11,5 +11,9 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	verbCmd := cmdutils.NewVerbCmd("enable", "Enable features in a cluster", "")
 
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, enableFlux2)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, enableArgoCD)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, enableBackups)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, enableMonitoring)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, enableDNS)
 	return verbCmd
 }
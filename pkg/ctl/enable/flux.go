@@ -12,6 +12,7 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	fluxclient "github.com/weaveworks/eksctl/pkg/flux"
 	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
 	"github.com/weaveworks/eksctl/pkg/version"
 )
@@ -28,8 +29,10 @@ func configureAndRun(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd) error) {
 		"",
 	)
 
+	var exportDir string
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringVar(&exportDir, "export-dir", "", "write the files Flux bootstrap would commit to the flux-system directory of the repository to this directory, and a template of its secret to stdout, without accessing the cluster or the repository")
 	})
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
@@ -43,6 +46,9 @@ func configureAndRun(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd) error) {
 			return err
 		}
 
+		if exportDir != "" {
+			return flux2Export(cmd, exportDir)
+		}
 		return runFunc(cmd)
 	}
 }
@@ -77,12 +83,12 @@ func flux2Install(cmd *cmdutils.Cmd) error {
 		cmd.ClusterConfig.GitOps.Flux.Flags["kubeconfig"] = kubeCfgPath.Name()
 	}
 
-	k8sClientSet, err := cmdutils.KubernetesClientAndConfigFrom(cmd)
+	k8sClientSet, dynamicClient, err := cmdutils.KubernetesClientAndConfigFrom(cmd)
 	if err != nil {
 		return err
 	}
 
-	installer, err := flux.New(k8sClientSet, cmd.ClusterConfig.GitOps)
+	installer, err := flux.New(k8sClientSet, dynamicClient, cmd.ClusterConfig.GitOps)
 	if err != nil {
 		return err
 	}
@@ -90,6 +96,20 @@ func flux2Install(cmd *cmdutils.Cmd) error {
 	return installer.Run()
 }
 
+func flux2Export(cmd *cmdutils.Cmd, dir string) error {
+	// log to stderr, so that the secret template can be redirected to a file
+	logger.Writer = os.Stderr
+	client, err := fluxclient.NewClient(cmd.ClusterConfig.GitOps.Flux)
+	if err != nil {
+		return err
+	}
+	if err := client.Export(dir, cmd.CobraCommand.OutOrStdout()); err != nil {
+		return err
+	}
+	logger.Success("exported the Flux manifests of cluster %s to %s", cmd.ClusterConfig.Metadata.Name, dir)
+	return nil
+}
+
 func kubeconfAndContextNotSet(flags map[string]string) bool {
 	_, cfg := flags["kubeconfig"]
 	_, ctx := flags["context"]
@@ -0,0 +1,75 @@
+package enable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func enableMonitoring(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.ClusterConfig.Monitoring = &api.Monitoring{
+		Prometheus: &api.PrometheusMonitoring{},
+	}
+	cmd.SetDescription(
+		"monitoring",
+		"Send the metrics of the cluster to Amazon Managed Service for Prometheus",
+		"Creates an AMP workspace, unless an existing one is given, and an EKS managed scraper or the ADOT collector to send the metrics of the cluster to it",
+	)
+
+	cmd.FlagSetGroup.InFlagSet("Monitoring", func(fs *pflag.FlagSet) {
+		prometheus := cmd.ClusterConfig.Monitoring.Prometheus
+		fs.StringVar(&prometheus.Collector, "collector", "", fmt.Sprintf("collector of the metrics, %q or %q (defaults to %q)",
+			api.PrometheusCollectorManagedScraper, api.PrometheusCollectorADOT, api.PrometheusCollectorManagedScraper))
+		fs.StringVar(&prometheus.WorkspaceARN, "workspace-arn", "", "ARN of an existing AMP workspace (defaults to creating a workspace)")
+		fs.StringVar(&prometheus.ADOTVersion, "adot-version", "", "version of the ADOT collector image, required with the adot collector")
+	})
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if cmd.NameArg != "" {
+			return cmdutils.ErrUnsupportedNameArg()
+		}
+		if err := cmdutils.NewEnableMonitoringLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return doEnableMonitoring(cmd)
+	}
+}
+
+func doEnableMonitoring(cmd *cmdutils.Cmd) error {
+	ctx := context.TODO()
+	cfg := cmd.ClusterConfig
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	installer, err := cmdutils.NewMonitoringInstaller(ctx, ctl, cfg)
+	if err != nil {
+		return err
+	}
+	endpoint, err := installer.Enable(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Success("the metrics of cluster %q are sent to Amazon Managed Service for Prometheus, endpoint: %s", cfg.Metadata.Name, endpoint)
+	return nil
+}
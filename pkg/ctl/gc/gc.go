@@ -0,0 +1,153 @@
+package gc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+type gcCmdParams struct {
+	options            cluster.GCOptions
+	chunkSize          int
+	clusterParallelism int
+}
+
+// Command sets up the `gc` command, which deletes eksctl-owned clusters that are no longer needed
+func Command(cmd *cmdutils.Cmd) {
+	gcCmdWithRunFunc(cmd, doGC)
+}
+
+func gcCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, params *gcCmdParams) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+
+	cmd.SetDescription("gc", "Delete eksctl-owned clusters that are no longer needed", "")
+
+	params := &gcCmdParams{}
+
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		if params.options.IsEmpty() {
+			return errors.New("at least one of --expired, --selector or --older-than must be set")
+		}
+		if params.options.OlderThan < 0 {
+			return errors.New("--older-than must be a positive duration")
+		}
+		if params.clusterParallelism < 1 {
+			return errors.New("--cluster-parallelism must be at least 1")
+		}
+		params.options.Now = time.Now()
+		return runFunc(cmd, params)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.BoolVar(&params.options.Expired, "expired", false, "select clusters that are past the expiry time set with `eksctl create cluster --ttl`")
+		cmdutils.AddStringToStringVarPFlag(fs, &params.options.Selector, "selector", "", nil, "select clusters that have all of the given tags")
+		fs.DurationVar(&params.options.OlderThan, "older-than", 0, "select clusters that were created at least this long ago, e.g. 24h")
+		fs.IntVar(&params.clusterParallelism, "cluster-parallelism", 4, "number of clusters to delete in parallel")
+		cmdutils.AddStackParallelismFlag(fs, cmd, "nodegroups and iamserviceaccounts of each cluster")
+		fs.IntVar(&params.chunkSize, "chunk-size", 100, "return large lists in chunks rather than all at once, pass 0 to disable")
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmd.Wait = false
+		cmdutils.AddWaitFlag(fs, &cmd.Wait, "deletion of all resources")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, true)
+}
+
+func doGC(cmd *cmdutils.Cmd, params *gcCmdParams) error {
+	ctl, err := cmd.NewCtl()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	candidates, err := cluster.FindGCCandidates(ctx, ctl.AWSProvider, params.chunkSize, params.options)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		logger.Info("no clusters to delete in region %q", ctl.AWSProvider.Region())
+		return nil
+	}
+
+	taskTree := &tasks.TaskTree{
+		Parallel: true,
+		Limit:    params.clusterParallelism,
+		PlanMode: cmd.Plan,
+	}
+	for _, c := range candidates {
+		c := c
+		taskTree.Append(&tasks.GenericTask{
+			Description: describeCandidate(c),
+			Doer: func() error {
+				if err := deleteCluster(ctx, *cmd, c.Name); err != nil {
+					return fmt.Errorf("failed to delete cluster %q: %w", c.Name, err)
+				}
+				logger.Success("deleted cluster %q", c.Name)
+				return nil
+			},
+		})
+	}
+
+	cmdutils.LogIntendedAction(cmd.Plan, "delete %d cluster(s) in region %q", len(candidates), ctl.AWSProvider.Region())
+	logger.Info(taskTree.Describe())
+	if errs := taskTree.DoAllSync(); len(errs) > 0 {
+		for _, err := range errs {
+			logger.Critical("%s\n", err.Error())
+		}
+		return fmt.Errorf("failed to delete %d of %d cluster(s)", len(errs), len(candidates))
+	}
+	cmdutils.LogPlanModeWarning(cmd.Plan)
+	return nil
+}
+
+func describeCandidate(c cluster.GCCandidate) string {
+	desc := fmt.Sprintf("delete cluster %q (created at %s", c.Name, c.CreatedAt.Format(time.RFC3339))
+	if !c.ExpiresAt.IsZero() {
+		desc += fmt.Sprintf(", expired at %s", c.ExpiresAt.Format(time.RFC3339))
+	}
+	return desc + ")"
+}
+
+// deleteCluster deletes a single cluster; cmd is passed by value as clusters are deleted in parallel
+func deleteCluster(ctx context.Context, cmd cmdutils.Cmd, clusterName string) error {
+	cfg := api.NewClusterConfig()
+	cfg.Metadata.Name = clusterName
+	cmd.ClusterConfig = cfg
+
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	c, err := cluster.New(ctx, cfg, ctl)
+	if err != nil {
+		return err
+	}
+	// nothing is deleted from protected clusters, deleting the nodegroups before failing on the cluster stack would only break them
+	protectedStack, err := cluster.ProtectedClusterStack(ctx, ctl.NewStackManager(cfg))
+	if err != nil {
+		return err
+	}
+	if protectedStack != "" {
+		return fmt.Errorf("not deleting cluster %q as termination protection is enabled on its stack, use `eksctl delete cluster --disable-protection` to delete it", clusterName)
+	}
+	return c.Delete(ctx, cluster.DeleteOptions{
+		WaitInterval:          20 * time.Second,
+		PodEvictionWaitPeriod: 10 * time.Second,
+		Wait:                  cmd.Wait,
+		Parallel:              1,
+		StackParallelism:      cmd.StackParallelism,
+	})
+}
@@ -0,0 +1,11 @@
+package gc
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestCtlGC(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
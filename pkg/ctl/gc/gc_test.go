@@ -0,0 +1,72 @@
+package gc
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("gc", func() {
+	execute := func(args ...string) (*cmdutils.Cmd, *gcCmdParams, error) {
+		var (
+			gcCmd    *cmdutils.Cmd
+			gcParams *gcCmdParams
+		)
+		rootCmd := &cobra.Command{}
+		cmdutils.AddResourceCmd(cmdutils.NewGrouping(), rootCmd, func(cmd *cmdutils.Cmd) {
+			gcCmdWithRunFunc(cmd, func(cmd *cmdutils.Cmd, params *gcCmdParams) error {
+				gcCmd, gcParams = cmd, params
+				return nil
+			})
+		})
+		rootCmd.SetArgs(append([]string{"gc"}, args...))
+		err := rootCmd.Execute()
+		return gcCmd, gcParams, err
+	}
+
+	It("selects expired clusters in plan mode by default", func() {
+		cmd, params, err := execute("--expired", "--region", "us-west-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(params.options.Expired).To(BeTrue())
+		Expect(params.options.Now).NotTo(BeZero())
+		Expect(params.chunkSize).To(Equal(100))
+		Expect(params.clusterParallelism).To(Equal(4))
+		Expect(cmd.StackParallelism).To(Equal(cmdutils.DefaultStackParallelism))
+		Expect(cmd.Plan).To(BeTrue())
+		Expect(cmd.ProviderConfig.Region).To(Equal("us-west-2"))
+	})
+
+	It("selects clusters by tags and age", func() {
+		cmd, params, err := execute("--selector", "purpose=ci,team=platform", "--older-than", "24h", "--cluster-parallelism", "8", "--parallelism", "40", "--approve")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(params.options.Expired).To(BeFalse())
+		Expect(params.options.Selector).To(Equal(map[string]string{"purpose": "ci", "team": "platform"}))
+		Expect(params.options.OlderThan).To(Equal(24 * time.Hour))
+		Expect(params.clusterParallelism).To(Equal(8))
+		Expect(cmd.StackParallelism).To(Equal(40))
+		Expect(cmd.Plan).To(BeFalse())
+	})
+
+	It("deletes clusters when --approve is set", func() {
+		cmd, _, err := execute("--expired", "--approve", "--wait")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Plan).To(BeFalse())
+		Expect(cmd.Wait).To(BeTrue())
+	})
+
+	DescribeTable("invalid flags or arguments", func(expectedErr string, args ...string) {
+		_, _, err := execute(args...)
+		Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("no selection flags", "at least one of --expired, --selector or --older-than must be set"),
+		Entry("negative --older-than", "--older-than must be a positive duration", "--older-than", "-1h"),
+		Entry("--cluster-parallelism below 1", "--cluster-parallelism must be at least 1", "--expired", "--cluster-parallelism", "0"),
+		Entry("removed --parallel flag", "unknown flag: --parallel", "--expired", "--parallel", "2"),
+		Entry("name argument", `unknown command "my-cluster"`, "my-cluster", "--expired"),
+	)
+})
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -12,7 +13,10 @@ import (
 	"github.com/weaveworks/eksctl/pkg/accessentry"
 	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/iam"
 	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
@@ -36,7 +40,7 @@ func getAccessEntryCmd(cmd *cmdutils.Cmd) {
 		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
-		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
+		cmdutils.AddCommonFlagsForGetCmd(fs, &cmd.ProviderConfig.PageSize, &params.output)
 	})
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 
@@ -76,6 +80,14 @@ func doGetAccessEntry(cmd *cmdutils.Cmd, principalARN api.ARN, params *getCmdPar
 		return fmt.Errorf("failed to retrieve access entries for cluster %s: %w", cmd.ClusterConfig.Metadata.Name, err)
 	}
 
+	if principalARN.IsZero() && clusterProvider.GetClusterState().AccessConfig.AuthenticationMode == ekstypes.AuthenticationModeApiAndConfigMap {
+		if identities, err := getAuthConfigMapIdentities(clusterProvider, cmd.ClusterConfig); err != nil {
+			logger.Debug("not comparing the access entries with the aws-auth ConfigMap: %v", err)
+		} else {
+			accessentryactions.WarnAuthConflicts(cmd.ClusterConfig.Metadata.Name, accessentryactions.FindAuthConflicts(identities, summaries))
+		}
+	}
+
 	printer, err := printers.NewPrinter(params.output)
 	if err != nil {
 		return err
@@ -89,6 +101,18 @@ func doGetAccessEntry(cmd *cmdutils.Cmd, principalARN api.ARN, params *getCmdPar
 	return printer.PrintObjWithKind("accessentries", summaries, os.Stdout)
 }
 
+func getAuthConfigMapIdentities(clusterProvider *eks.ClusterProvider, cfg *api.ClusterConfig) ([]iam.Identity, error) {
+	clientSet, err := clusterProvider.NewStdClientSet(cfg)
+	if err != nil {
+		return nil, err
+	}
+	acm, err := authconfigmap.NewFromClientSet(clientSet)
+	if err != nil {
+		return nil, err
+	}
+	return acm.GetIdentities()
+}
+
 func addAccessEntrySummaryTableColumns(printer *printers.TablePrinter) {
 	printer.AddColumn("PRINCIPAL ARN", func(s accessentryactions.Summary) string {
 		return s.PrincipalARN
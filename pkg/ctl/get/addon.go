@@ -2,7 +2,9 @@ package get
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"slices"
 	"strings"
@@ -30,30 +32,59 @@ func getAddonCmd(cmd *cmdutils.Cmd) {
 		"addons",
 	)
 
-	var a api.Addon
+	var (
+		a       api.Addon
+		options getAddonOptions
+	)
 	cmd.FlagSetGroup.InFlagSet("Addon", func(fs *pflag.FlagSet) {
 		fs.StringVar(&a.Name, "name", "", "Addon name")
+		fs.BoolVar(&options.showConfigDiff, "show-config-diff", false, "Show how the version and configuration values of the deployed addons differ from the config file")
+		fs.BoolVar(&options.available, "available", false, "List the addons available for the cluster, including third-party and AWS Marketplace addons, instead of the installed ones")
+		fs.StringSliceVar(&a.Publishers, "publisher", nil, "List the available addons of these publishers only (can be comma separated list e.g., --publisher \"publisherA, publisherB\")")
+		fs.StringSliceVar(&a.Owners, "owner", nil, "List the available addons of these owners only, e.g. aws-marketplace (can be comma separated list)")
+		fs.StringSliceVar(&a.Types, "type", nil, "List the available addons of these types only (can be comma separated list)")
 	})
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
-		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
+		cmdutils.AddCommonFlagsForGetCmd(fs, &cmd.ProviderConfig.PageSize, &params.output)
+		cmdutils.AddPaginationFlags(fs, &params.pagination)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 	})
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return getAddon(cmd, &a, params)
+		return getAddon(cmd, &a, params, options)
 	}
 }
 
-func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams) error {
+type getAddonOptions struct {
+	showConfigDiff bool
+	available      bool
+}
+
+func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams, options getAddonOptions) error {
 	if err := cmdutils.NewGetAddonsLoader(cmd).Load(); err != nil {
 		return err
 	}
+	if options.available {
+		if a.Name != "" || options.showConfigDiff {
+			return errors.New("--available cannot be used with --name or --show-config-diff")
+		}
+	} else if len(a.Publishers) > 0 || len(a.Owners) > 0 || len(a.Types) > 0 {
+		return errors.New("--publisher, --owner and --type can only be used with --available")
+	}
+	if options.showConfigDiff {
+		if cmd.ClusterConfigFile == "" {
+			return errors.New("--show-config-diff requires a config file")
+		}
+		if params.output != printers.TableType {
+			return errors.New("--show-config-diff can only be used with the table output")
+		}
+	}
 	if params.output != printers.TableType {
 		//log warnings and errors to stdout
 		logger.Writer = os.Stderr
@@ -84,6 +115,10 @@ func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams) error {
 		return err
 	}
 
+	if options.available {
+		return getAvailableAddons(ctx, cmd, addonManager, a, params)
+	}
+
 	var summaries []addon.Summary
 	if a.Name == "" {
 		summaries, err = addonManager.GetAll(ctx)
@@ -116,7 +151,13 @@ func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams) error {
 		addAddonSummaryTableColumns(tablePrinter)
 	}
 
-	if err := printer.PrintObjWithKind("addons", summaries, cmd.CobraCommand.OutOrStdout()); err != nil {
+	page, err := cmdutils.Paginate(summaries, func(s addon.Summary) string {
+		return s.Name
+	}, params.pagination)
+	if err != nil {
+		return err
+	}
+	if err := printPage(printer, "addons", page, params, cmd.CobraCommand.OutOrStdout()); err != nil {
 		return err
 	}
 
@@ -127,6 +168,74 @@ func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams) error {
 		}
 	}
 
+	if options.showConfigDiff {
+		return printConfigDiffs(ctx, addonManager, cmd.ClusterConfig.Addons, a.Name, cmd.CobraCommand.OutOrStdout())
+	}
+	return nil
+}
+
+// getAvailableAddons lists the addons that can be installed on the cluster
+func getAvailableAddons(ctx context.Context, cmd *cmdutils.Cmd, addonManager *addon.Manager, filter *api.Addon, params *getCmdParams) error {
+	available, err := addonManager.ListAvailable(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(available, func(a addon.AvailableAddon) bool {
+		return a.MarketplaceProductURL != ""
+	}) {
+		logger.Info("AWS Marketplace addons require the account to be subscribed to them before they can be created")
+	}
+
+	printer, err := printers.NewPrinter(params.output)
+	if err != nil {
+		return err
+	}
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addAvailableAddonTableColumns(tablePrinter)
+	}
+
+	page, err := cmdutils.Paginate(available, func(a addon.AvailableAddon) string {
+		return a.Name
+	}, params.pagination)
+	if err != nil {
+		return err
+	}
+	return printPage(printer, "addons", page, params, cmd.CobraCommand.OutOrStdout())
+}
+
+// printConfigDiffs prints how the deployed addons differ from the addons in the config file
+func printConfigDiffs(ctx context.Context, addonManager *addon.Manager, addons []*api.Addon, name string, out io.Writer) error {
+	for _, a := range addons {
+		if name != "" && a.Name != name {
+			continue
+		}
+		diff, err := addonManager.DiffConfig(ctx, a)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+		switch {
+		case diff == nil:
+			fmt.Fprintf(out, "addon %q is not installed\n", a.Name)
+		case !diff.HasChanges():
+			fmt.Fprintf(out, "addon %q matches the config file\n", a.Name)
+		default:
+			fmt.Fprintf(out, "addon %q differs from the config file (deployed -> config):\n", a.Name)
+			if diff.DeployedVersion != diff.DesiredVersion {
+				fmt.Fprintf(out, "  version: %s -> %s\n", diff.DeployedVersion, diff.DesiredVersion)
+			}
+			for _, change := range diff.ValueChanges {
+				switch {
+				case change.Deployed == "":
+					fmt.Fprintf(out, "  + %s: %s\n", change.Path, change.Desired)
+				case change.Desired == "":
+					fmt.Fprintf(out, "  - %s: %s\n", change.Path, change.Deployed)
+				default:
+					fmt.Fprintf(out, "  ~ %s: %s -> %s\n", change.Path, change.Deployed, change.Desired)
+				}
+			}
+		}
+	}
 	return nil
 }
 
@@ -160,3 +269,24 @@ func addAddonSummaryTableColumns(printer *printers.TablePrinter) {
 		return strings.Join(roleARNs, ",")
 	})
 }
+
+func addAvailableAddonTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("NAME", func(a addon.AvailableAddon) string {
+		return a.Name
+	})
+	printer.AddColumn("TYPE", func(a addon.AvailableAddon) string {
+		return a.Type
+	})
+	printer.AddColumn("PUBLISHER", func(a addon.AvailableAddon) string {
+		return a.Publisher
+	})
+	printer.AddColumn("OWNER", func(a addon.AvailableAddon) string {
+		return a.Owner
+	})
+	printer.AddColumn("LATEST VERSION", func(a addon.AvailableAddon) string {
+		return a.LatestVersion
+	})
+	printer.AddColumn("MARKETPLACE PRODUCT", func(a addon.AvailableAddon) string {
+		return a.MarketplaceProductURL
+	})
+}
@@ -32,5 +32,13 @@ var _ = Describe("get addon", func() {
 			expectedErr: "Error: cannot use --cluster when --config-file/-f is set",
 			args:        []string{"--cluster", "test", "--config-file", "../../../examples/01-simple-cluster.yaml"},
 		}),
+		Entry("setting --publisher without --available", getAddonEntry{
+			expectedErr: "Error: --publisher, --owner and --type can only be used with --available",
+			args:        []string{"--cluster", "test", "--publisher", "upbound"},
+		}),
+		Entry("setting --available and --name at the same time", getAddonEntry{
+			expectedErr: "Error: --available cannot be used with --name or --show-config-diff",
+			args:        []string{"--cluster", "test", "--available", "--name", "kube-proxy"},
+		}),
 	)
 })
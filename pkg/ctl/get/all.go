@@ -0,0 +1,189 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	"github.com/weaveworks/eksctl/pkg/actions/irsa"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+	"github.com/weaveworks/eksctl/pkg/iam"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+// clusterObjects are the resources of a cluster printed by `get all`
+type clusterObjects struct {
+	Cluster             *cluster.Status                 `json:"cluster"`
+	NodeGroups          []*nodegroup.Summary            `json:"nodeGroups"`
+	FargateProfiles     []*api.FargateProfile           `json:"fargateProfiles"`
+	Addons              []addon.Summary                 `json:"addons"`
+	IAMServiceAccounts  []*api.ClusterIAMServiceAccount `json:"iamServiceAccounts"`
+	IAMIdentityMappings []iam.Identity                  `json:"iamIdentityMappings"`
+	AccessEntries       []accessentryactions.Summary    `json:"accessEntries"`
+}
+
+func getAllCmd(cmd *cmdutils.Cmd) {
+	getAllWithRunFunc(cmd, doGetAll)
+}
+
+func getAllWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, params *getCmdParams) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	params := &getCmdParams{}
+
+	cmd.SetDescription(
+		"all",
+		"Get all the resources of a cluster",
+		"Lists the cluster, its nodegroups, Fargate profiles, addons, IAM service accounts, IAM identity mappings and access entries",
+	)
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddCommonFlagsForGetCmd(fs, &cmd.ProviderConfig.PageSize, &params.output)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return runFunc(cmd, params)
+	}
+}
+
+func doGetAll(cmd *cmdutils.Cmd, params *getCmdParams) error {
+	if params.output != printers.TableType {
+		//log warnings and errors to stderr
+		logger.Writer = os.Stderr
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := cmd.ClusterConfig
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	// the cluster was described when creating the provider, every section reuses that description
+	clusterState := ctl.GetClusterState()
+	objects := clusterObjects{
+		Cluster: &cluster.Status{Cluster: clusterState},
+	}
+	stackManager := ctl.NewStackManager(cfg)
+
+	// a section that cannot be listed, e.g. for lack of permissions, is skipped so that the others are still printed
+	collect := func(kind string, list func() error) {
+		if err := list(); err != nil {
+			logger.Warning("unable to get the %s of cluster %q: %v", kind, cfg.Metadata.Name, err)
+		}
+	}
+
+	clientSet, clientSetErr := ctl.NewStdClientSet(cfg)
+	collect("nodegroups", func() (err error) {
+		if clientSetErr != nil {
+			return clientSetErr
+		}
+		objects.NodeGroups, err = nodegroup.New(cfg, ctl, clientSet, nil).GetAll(ctx)
+		return err
+	})
+	collect("Fargate profiles", func() (err error) {
+		manager := fargate.NewFromProvider(cfg.Metadata.Name, ctl.AWSProvider, stackManager)
+		objects.FargateProfiles, err = manager.ReadProfiles(ctx)
+		return err
+	})
+	collect("addons", func() error {
+		addonManager, err := addon.New(cfg, ctl.AWSProvider.EKS(), stackManager, api.IsEnabled(cfg.IAM.WithOIDC), nil, nil)
+		if err != nil {
+			return err
+		}
+		objects.Addons, err = addonManager.GetAll(ctx)
+		return err
+	})
+	collect("IAM service accounts", func() (err error) {
+		objects.IAMServiceAccounts, err = irsa.New(cfg.Metadata.Name, stackManager, nil, nil).Get(ctx, irsa.GetOptions{})
+		return err
+	})
+	if clusterState.AccessConfig == nil || clusterState.AccessConfig.AuthenticationMode != ekstypes.AuthenticationModeApi {
+		collect("IAM identity mappings", func() error {
+			if clientSetErr != nil {
+				return clientSetErr
+			}
+			acm, err := authconfigmap.NewFromClientSet(clientSet)
+			if err != nil {
+				return err
+			}
+			objects.IAMIdentityMappings, err = acm.GetIdentities()
+			return err
+		})
+	}
+	if ctl.IsAccessEntryEnabled() {
+		collect("access entries", func() (err error) {
+			objects.AccessEntries, err = accessentryactions.NewGetter(cfg.Metadata.Name, ctl.AWSProvider.EKS()).Get(ctx, api.ARN{})
+			return err
+		})
+	}
+
+	return printClusterObjects(objects, params.output, cmd.CobraCommand.OutOrStdout())
+}
+
+// printClusterObjects prints the resources of a cluster as a single document, or as one table per kind of resource
+func printClusterObjects(objects clusterObjects, output printers.Type, w io.Writer) error {
+	if output != printers.TableType {
+		printer, err := printers.NewPrinter(output)
+		if err != nil {
+			return err
+		}
+		return printer.PrintObjWithKind("all", objects, w)
+	}
+
+	sections := []struct {
+		kind       string
+		items      interface{}
+		addColumns func(*printers.TablePrinter)
+	}{
+		{"clusters", []*cluster.Status{objects.Cluster}, addGetClusterSummaryTableColumns},
+		{"nodegroups", objects.NodeGroups, addSummaryTableColumns},
+		{"fargateprofiles", objects.FargateProfiles, nil},
+		{"addons", objects.Addons, addAddonSummaryTableColumns},
+		{"iamserviceaccounts", objects.IAMServiceAccounts, addIAMServiceAccountSummaryTableColumns},
+		{"iamidentitymappings", objects.IAMIdentityMappings, addIAMIdentityMappingTableColumns},
+		{"accessentries", objects.AccessEntries, addAccessEntrySummaryTableColumns},
+	}
+	for i, section := range sections {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s:\n", section.kind)
+		if section.addColumns == nil {
+			if err := fargate.PrintProfiles(objects.FargateProfiles, w, output); err != nil {
+				return err
+			}
+			continue
+		}
+		printer := printers.NewTablePrinter().(*printers.TablePrinter)
+		section.addColumns(printer)
+		if err := printer.PrintObjWithKind(section.kind, section.items, w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package get
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+var _ = Describe("get all", func() {
+	Describe("flags", func() {
+		newCmd := func(args ...string) *cmdutils.Cmd {
+			var allCmd *cmdutils.Cmd
+			grouping := cmdutils.NewGrouping()
+			parentCmd := cmdutils.NewVerbCmd("get", "", "")
+			cmdutils.AddResourceCmd(grouping, parentCmd, func(cmd *cmdutils.Cmd) {
+				getAllWithRunFunc(cmd, func(cmd *cmdutils.Cmd, _ *getCmdParams) error {
+					allCmd = cmd
+					return nil
+				})
+			})
+			parentCmd.SetArgs(append([]string{"all"}, args...))
+			Expect(parentCmd.Execute()).To(Succeed())
+			return allCmd
+		}
+
+		It("accepts the cluster name as a flag", func() {
+			Expect(newCmd("--cluster", "my-cluster").ClusterConfig.Metadata.Name).To(Equal("my-cluster"))
+		})
+
+		It("accepts the cluster name as an argument", func() {
+			Expect(newCmd("my-cluster").ClusterConfig.Metadata.Name).To(Equal("my-cluster"))
+		})
+	})
+
+	Describe("printClusterObjects", func() {
+		var objects clusterObjects
+
+		BeforeEach(func() {
+			objects = clusterObjects{
+				Cluster: &cluster.Status{Cluster: &ekstypes.Cluster{
+					Name:    aws.String("my-cluster"),
+					Version: aws.String(api.Version1_30),
+					Status:  ekstypes.ClusterStatusActive,
+				}},
+				NodeGroups: []*nodegroup.Summary{
+					{Cluster: "my-cluster", Name: "ng-1", Status: "ACTIVE", NodeGroupType: api.NodeGroupTypeManaged},
+				},
+				Addons: []addon.Summary{
+					{Name: "vpc-cni", Version: "v1.18.0", Status: "ACTIVE"},
+				},
+			}
+		})
+
+		It("prints a table per kind of resource", func() {
+			var out bytes.Buffer
+			Expect(printClusterObjects(objects, printers.TableType, &out)).To(Succeed())
+			Expect(out.String()).To(SatisfyAll(
+				ContainSubstring("clusters:\n"),
+				ContainSubstring("my-cluster"),
+				ContainSubstring("nodegroups:\n"),
+				ContainSubstring("ng-1"),
+				ContainSubstring("No fargateprofiles found"),
+				ContainSubstring("vpc-cni"),
+				ContainSubstring("No iamserviceaccounts found"),
+				ContainSubstring("No accessentries found"),
+			))
+		})
+
+		It("prints a single document for JSON output", func() {
+			var out bytes.Buffer
+			Expect(printClusterObjects(objects, printers.JSONType, &out)).To(Succeed())
+			var document map[string]interface{}
+			Expect(json.Unmarshal(out.Bytes(), &document)).To(Succeed())
+			Expect(document).To(HaveKey("cluster"))
+			Expect(document["nodeGroups"]).To(HaveLen(1))
+			Expect(document["addons"]).To(HaveLen(1))
+		})
+	})
+})
@@ -7,14 +7,17 @@ import (
 	"strings"
 	"time"
 
-	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/weaveworks/eksctl/pkg/actions/cluster"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/inventory"
 	"github.com/weaveworks/eksctl/pkg/printers"
 
 	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
@@ -22,26 +25,55 @@ import (
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 )
 
+// watchInterval is how often get cluster --watch describes the cluster.
+var watchInterval = 30 * time.Second
+
 func getClusterCmd(cmd *cmdutils.Cmd) {
 	cfg := api.NewClusterConfig()
 	cmd.ClusterConfig = cfg
 
-	var listAllRegions bool
+	var (
+		listAllRegions bool
+		listLocal      bool
+		listInventory  bool
+		watch          bool
+		fleet          cluster.InventoryOptions
+	)
 
 	params := &getCmdParams{}
 
-	cmd.SetDescription("cluster", "Get cluster(s)", "", "clusters")
+	cmd.SetDescription("cluster", "Get cluster(s)", dedent.Dedent(`Get cluster(s).
+
+		When a cluster name is given, the output includes the platform version of the cluster, the health issues
+		reported by EKS and the updates that are in progress, including platform version updates initiated by EKS.
+		With --watch, the cluster is printed again whenever its state changes until it is no longer being updated.
+
+		With --inventory or --role-per-account, the output is an inventory of the clusters including their
+		Kubernetes version, whether EKS still supports it in standard or extended support, and their number of
+		nodegroups. --role-per-account assumes the given role in each account of the organization, or in the
+		accounts given with --accounts, to inventory a fleet of clusters.
+	`), "clusters")
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return doGetCluster(cmd, params, listAllRegions)
+		if listLocal {
+			return doGetLocalClusters(cmd, params, listAllRegions)
+		}
+		fleet.AllRegions = listAllRegions
+		return doGetCluster(cmd, params, fleet, listInventory, watch)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name")
 		fs.BoolVarP(&listAllRegions, "all-regions", "A", false, "List clusters across all supported regions")
+		fs.BoolVar(&watch, "watch", false, "Watch the cluster while it is being updated, requires a cluster name")
+		fs.BoolVar(&listInventory, "inventory", false, "List the Kubernetes version, its support status and the number of nodegroups of the clusters")
+		fs.StringVar(&fleet.RolePerAccount, "role-per-account", "", "name of the IAM role to assume in each account to list its clusters, e.g. OrganizationAccountAccessRole")
+		fs.StringSliceVar(&fleet.Accounts, "accounts", nil, "IDs of the accounts to list with --role-per-account (defaults to the active accounts of the organization)")
+		fs.BoolVar(&listLocal, "local", false, fmt.Sprintf("List clusters from the local inventory instead of calling AWS APIs (requires %s=true)", inventory.EnableInventoryEnvName))
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
-		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
+		cmdutils.AddCommonFlagsForGetCmd(fs, &cmd.ProviderConfig.PageSize, &params.output)
+		cmdutils.AddPaginationFlags(fs, &params.pagination)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 	})
@@ -49,10 +81,14 @@ func getClusterCmd(cmd *cmdutils.Cmd) {
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 }
 
-func doGetCluster(cmd *cmdutils.Cmd, params *getCmdParams, listAllRegions bool) error {
+func doGetCluster(cmd *cmdutils.Cmd, params *getCmdParams, fleet cluster.InventoryOptions, listInventory, watch bool) error {
+	listAllRegions := fleet.AllRegions
 	if err := cmdutils.NewGetClusterLoader(cmd).Load(); err != nil {
 		return err
 	}
+	if len(fleet.Accounts) > 0 && fleet.RolePerAccount == "" {
+		return cmdutils.ErrMustBeSet("--role-per-account")
+	}
 	cfg := cmd.ClusterConfig
 	regionGiven := cfg.Metadata.Region != "" // eks.New resets this field, so we need to check if it was set in the first place
 
@@ -80,6 +116,9 @@ func doGetCluster(cmd *cmdutils.Cmd, params *getCmdParams, listAllRegions bool)
 	if cfg.Metadata.Name != "" && listAllRegions {
 		return fmt.Errorf("--all-regions is for listing all clusters, it must be used without cluster name flag/argument")
 	}
+	if cfg.Metadata.Name != "" && fleet.RolePerAccount != "" {
+		return fmt.Errorf("--role-per-account is for listing all clusters, it must be used without cluster name flag/argument")
+	}
 
 	if params.output != printers.TableType {
 		// log warnings and errors to stdout
@@ -88,10 +127,91 @@ func doGetCluster(cmd *cmdutils.Cmd, params *getCmdParams, listAllRegions bool)
 
 	ctx := context.Background()
 	if cfg.Metadata.Name == "" {
+		if watch {
+			return fmt.Errorf("--watch requires a cluster name")
+		}
+		if listInventory || fleet.RolePerAccount != "" {
+			fleet.ChunkSize = cmd.ProviderConfig.PageSize
+			return getAndPrintFleet(ctx, cmd, ctl, params, fleet)
+		}
 		return getAndPrinterClusters(ctx, cmd, ctl, params, listAllRegions)
 	}
 
-	return getAndPrintCluster(ctx, cmd, cfg, ctl, params)
+	if watch {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cmd.ProviderConfig.WaitTimeout)
+		defer cancel()
+	}
+	return getAndPrintCluster(ctx, cmd, cfg, ctl, params, watch)
+}
+
+func doGetLocalClusters(cmd *cmdutils.Cmd, params *getCmdParams, listAllRegions bool) error {
+	if err := cmdutils.NewGetClusterLoader(cmd).Load(); err != nil {
+		return err
+	}
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name != "" && cmd.NameArg != "" {
+		return cmdutils.ErrClusterFlagAndArg(cmd, cfg.Metadata.Name, cmd.NameArg)
+	}
+	if cmd.NameArg != "" {
+		cfg.Metadata.Name = cmd.NameArg
+	}
+
+	if params.output != printers.TableType {
+		logger.Writer = os.Stderr
+	}
+	if !inventory.Enabled() {
+		logger.Warning("local inventory is not enabled, set %s=true to record clusters managed from this machine", inventory.EnableInventoryEnvName)
+	}
+
+	filename, err := inventory.GetFilePath()
+	if err != nil {
+		return err
+	}
+	allClusters, err := inventory.New(afero.NewOsFs(), filename, time.Now).List()
+	if err != nil {
+		return err
+	}
+	clusters := []inventory.Cluster{}
+	for _, c := range allClusters {
+		if cfg.Metadata.Name != "" && c.Name != cfg.Metadata.Name {
+			continue
+		}
+		if region := cmd.ProviderConfig.Region; !listAllRegions && region != "" && c.Region != region {
+			continue
+		}
+		clusters = append(clusters, c)
+	}
+
+	printer, err := printers.NewPrinter(params.output)
+	if err != nil {
+		return err
+	}
+	if params.output == printers.TableType {
+		addGetLocalClustersTableColumns(printer.(*printers.TablePrinter))
+	}
+	return printer.PrintObjWithKind("clusters", clusters, cmd.CobraCommand.OutOrStdout())
+}
+
+func addGetLocalClustersTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("NAME", func(c inventory.Cluster) string {
+		return c.Name
+	})
+	printer.AddColumn("REGION", func(c inventory.Cluster) string {
+		return c.Region
+	})
+	printer.AddColumn("NODEGROUPS", func(c inventory.Cluster) string {
+		if len(c.NodeGroups) == 0 {
+			return "-"
+		}
+		return strings.Join(c.NodeGroups, ",")
+	})
+	printer.AddColumn("LAST OPERATION", func(c inventory.Cluster) string {
+		if c.LastOperation == "" {
+			return "-"
+		}
+		return fmt.Sprintf("%s (%s)", c.LastOperation, c.LastOperationAt.Format(time.RFC3339))
+	})
 }
 
 func getAndPrinterClusters(ctx context.Context, cmd *cmdutils.Cmd, ctl *eks.ClusterProvider, params *getCmdParams, listAllRegions bool) error {
@@ -104,12 +224,18 @@ func getAndPrinterClusters(ctx context.Context, cmd *cmdutils.Cmd, ctl *eks.Clus
 		addGetClustersSummaryTableColumns(printer.(*printers.TablePrinter))
 	}
 
-	clusters, err := cluster.GetClusters(ctx, ctl.AWSProvider, listAllRegions, params.chunkSize)
+	clusters, err := cluster.GetClusters(ctx, ctl.AWSProvider, listAllRegions, cmd.ProviderConfig.PageSize)
 	if err != nil {
 		return err
 	}
 
-	return printer.PrintObjWithKind("clusters", clusters, cmd.CobraCommand.OutOrStdout())
+	page, err := cmdutils.Paginate(clusters, func(c cluster.Description) string {
+		return c.Region + "/" + c.Name
+	}, params.pagination)
+	if err != nil {
+		return err
+	}
+	return printPage(printer, "clusters", page, params, cmd.CobraCommand.OutOrStdout())
 }
 
 func addGetClustersSummaryTableColumns(printer *printers.TablePrinter) {
@@ -124,56 +250,175 @@ func addGetClustersSummaryTableColumns(printer *printers.TablePrinter) {
 	})
 }
 
-func getAndPrintCluster(ctx context.Context, cmd *cmdutils.Cmd, cfg *api.ClusterConfig, ctl *eks.ClusterProvider, params *getCmdParams) error {
+func getAndPrintFleet(ctx context.Context, cmd *cmdutils.Cmd, ctl *eks.ClusterProvider, params *getCmdParams, options cluster.InventoryOptions) error {
 	printer, err := printers.NewPrinter(params.output)
 	if err != nil {
 		return err
 	}
-
 	if params.output == printers.TableType {
-		addGetClusterSummaryTableColumns(printer.(*printers.TablePrinter))
+		addGetFleetTableColumns(printer.(*printers.TablePrinter), options.RolePerAccount != "")
 	}
 
-	cluster, err := ctl.GetCluster(ctx, cfg.Metadata.Name)
+	clusters, err := cluster.GetInventory(ctx, ctl.AWSProvider, options)
 	if err != nil {
 		return err
 	}
+	for _, c := range clusters {
+		if c.Error != "" {
+			logger.Warning("cluster %q in region %q: %s", c.Name, c.Region, c.Error)
+		}
+	}
 
-	return printer.PrintObjWithKind("clusters", []*ekstypes.Cluster{cluster}, cmd.CobraCommand.OutOrStdout())
+	page, err := cmdutils.Paginate(clusters, func(c cluster.InventoryEntry) string {
+		return c.Account + "/" + c.Region + "/" + c.Name
+	}, params.pagination)
+	if err != nil {
+		return err
+	}
+	return printPage(printer, "clusters", page, params, cmd.CobraCommand.OutOrStdout())
+}
+
+func addGetFleetTableColumns(printer *printers.TablePrinter, withAccount bool) {
+	if withAccount {
+		printer.AddColumn("ACCOUNT", func(c cluster.InventoryEntry) string {
+			return c.Account
+		})
+	}
+	printer.AddColumn("NAME", func(c cluster.InventoryEntry) string {
+		return c.Name
+	})
+	printer.AddColumn("REGION", func(c cluster.InventoryEntry) string {
+		return c.Region
+	})
+	printer.AddColumn("EKSCTL CREATED", func(c cluster.InventoryEntry) api.EKSCTLCreated {
+		return c.Owned
+	})
+	printer.AddColumn("VERSION", func(c cluster.InventoryEntry) string {
+		return valueOrDash(c.Version)
+	})
+	printer.AddColumn("SUPPORT", func(c cluster.InventoryEntry) string {
+		return valueOrDash(c.SupportStatus)
+	})
+	printer.AddColumn("STATUS", func(c cluster.InventoryEntry) string {
+		return valueOrDash(c.Status)
+	})
+	printer.AddColumn("NODEGROUPS", func(c cluster.InventoryEntry) int {
+		return c.NodeGroups
+	})
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func getAndPrintCluster(ctx context.Context, cmd *cmdutils.Cmd, cfg *api.ClusterConfig, ctl *eks.ClusterProvider, params *getCmdParams, watch bool) error {
+	printer, err := printers.NewPrinter(params.output)
+	if err != nil {
+		return err
+	}
+
+	if params.output == printers.TableType {
+		addGetClusterSummaryTableColumns(printer.(*printers.TablePrinter))
+	}
+
+	var lastState string
+	for {
+		status, err := cluster.GetStatus(ctx, ctl.AWSProvider.EKS(), cfg.Metadata.Name)
+		if err != nil {
+			return err
+		}
+
+		if state := clusterState(status); state != lastState {
+			lastState = state
+			if err := printer.PrintObjWithKind("clusters", []*cluster.Status{status}, cmd.CobraCommand.OutOrStdout()); err != nil {
+				return err
+			}
+			for _, issue := range status.HealthIssues() {
+				logger.Warning("cluster health issue %s: %s (resources: %s)", issue.Code, aws.ToString(issue.Message), strings.Join(issue.ResourceIds, ", "))
+			}
+		}
+
+		if !watch || !status.Updating() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for cluster %q to finish updating", cfg.Metadata.Name)
+		case <-time.After(watchInterval):
+		}
+	}
+}
+
+// clusterState summarises the fields of the cluster that --watch reports changes of.
+func clusterState(status *cluster.Status) string {
+	var updates []string
+	for _, u := range status.PendingUpdates {
+		updates = append(updates, aws.ToString(u.Id))
+	}
+	return fmt.Sprintf("%s/%s/%s/%d/%s", status.Cluster.Status, aws.ToString(status.Version), aws.ToString(status.PlatformVersion),
+		len(status.HealthIssues()), strings.Join(updates, ","))
 }
 
 func addGetClusterSummaryTableColumns(printer *printers.TablePrinter) {
-	printer.AddColumn("NAME", func(c *ekstypes.Cluster) string {
+	printer.AddColumn("NAME", func(c *cluster.Status) string {
 		if c.Name == nil {
 			return "-"
 		}
 		return *c.Name
 	})
-	printer.AddColumn("VERSION", func(c *ekstypes.Cluster) string {
+	printer.AddColumn("VERSION", func(c *cluster.Status) string {
 		if c.Version == nil {
 			return "-"
 		}
 		return *c.Version
 	})
-	printer.AddColumn("STATUS", func(c *ekstypes.Cluster) string {
-		if c.Status == "" {
+	printer.AddColumn("PLATFORM VERSION", func(c *cluster.Status) string {
+		if c.PlatformVersion == nil {
+			return "-"
+		}
+		return *c.PlatformVersion
+	})
+	printer.AddColumn("STATUS", func(c *cluster.Status) string {
+		if c.Cluster.Status == "" {
+			return "-"
+		}
+		return string(c.Cluster.Status)
+	})
+	printer.AddColumn("HEALTH", func(c *cluster.Status) string {
+		if c.Health == nil {
 			return "-"
 		}
-		return string(c.Status)
+		if len(c.Health.Issues) == 0 {
+			return "OK"
+		}
+		return fmt.Sprintf("%d issue(s)", len(c.Health.Issues))
+	})
+	printer.AddColumn("UPDATES", func(c *cluster.Status) string {
+		if len(c.PendingUpdates) == 0 {
+			return "-"
+		}
+		var updates []string
+		for _, u := range c.PendingUpdates {
+			updates = append(updates, string(u.Type))
+		}
+		return strings.Join(updates, ",")
 	})
-	printer.AddColumn("CREATED", func(c *ekstypes.Cluster) string {
+	printer.AddColumn("CREATED", func(c *cluster.Status) string {
 		if c.CreatedAt == nil {
 			return "-"
 		}
 		return c.CreatedAt.Format(time.RFC3339)
 	})
-	printer.AddColumn("VPC", func(c *ekstypes.Cluster) string {
+	printer.AddColumn("VPC", func(c *cluster.Status) string {
 		if c.ResourcesVpcConfig == nil {
 			return "-"
 		}
 		return *c.ResourcesVpcConfig.VpcId
 	})
-	printer.AddColumn("SUBNETS", func(c *ekstypes.Cluster) string {
+	printer.AddColumn("SUBNETS", func(c *cluster.Status) string {
 		if c.ResourcesVpcConfig == nil || c.ResourcesVpcConfig.SubnetIds == nil {
 			return "-"
 		}
@@ -183,7 +428,7 @@ func addGetClusterSummaryTableColumns(printer *printers.TablePrinter) {
 		}
 		return strings.Join(sets.List(subnets), ",")
 	})
-	printer.AddColumn("SECURITYGROUPS", func(c *ekstypes.Cluster) string {
+	printer.AddColumn("SECURITYGROUPS", func(c *cluster.Status) string {
 		if c.ResourcesVpcConfig == nil || c.ResourcesVpcConfig.SecurityGroupIds == nil {
 			return "-"
 		}
@@ -194,7 +439,7 @@ func addGetClusterSummaryTableColumns(printer *printers.TablePrinter) {
 		return strings.Join(sets.List(groups), ",")
 	})
 
-	printer.AddColumn("PROVIDER", func(c *ekstypes.Cluster) string {
+	printer.AddColumn("PROVIDER", func(c *cluster.Status) string {
 		if c.ConnectorConfig != nil {
 			return *c.ConnectorConfig.Provider
 		}
@@ -2,9 +2,14 @@ package get
 
 import (
 	"os"
+	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"github.com/weaveworks/eksctl/pkg/inventory"
 )
 
 var _ = Describe("get", func() {
@@ -23,6 +28,26 @@ var _ = Describe("get", func() {
 			_, err = cmd.execute()
 			Expect(err).To(MatchError(ContainSubstring("Error: cannot use --name when --config-file/-f is set")))
 		})
+		It("--accounts without --role-per-account", func() {
+			cmd := newMockCmd("cluster", "--accounts", "111122223333")
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring("--role-per-account must be set")))
+		})
+		It("lists clusters from the local inventory with --local", func() {
+			dir := GinkgoT().TempDir()
+			GinkgoT().Setenv(inventory.InventoryFilenameEnvName, filepath.Join(dir, "inventory.json"))
+			GinkgoT().Setenv(inventory.EnableInventoryEnvName, "true")
+			inv := inventory.New(afero.NewOsFs(), filepath.Join(dir, "inventory.json"), time.Now)
+			Expect(inv.AddCluster("cluster-1", "us-west-2", []string{"ng-1"})).To(Succeed())
+			Expect(inv.AddCluster("cluster-2", "eu-west-1", nil)).To(Succeed())
+
+			cmd := newMockCmd("cluster", "--local", "--region", "us-west-2")
+			out, err := cmd.execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("cluster-1"))
+			Expect(out).To(ContainSubstring("ng-1"))
+			Expect(out).NotTo(ContainSubstring("cluster-2"))
+		})
 	})
 })
 
@@ -53,7 +53,8 @@ func configureGetFargateProfileCmd(cmd *cmdutils.Cmd) *options {
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
-		cmdutils.AddCommonFlagsForGetCmd(fs, &options.chunkSize, &options.output)
+		cmdutils.AddCommonFlagsForGetCmd(fs, &cmd.ProviderConfig.PageSize, &options.output)
+		cmdutils.AddPaginationFlags(fs, &options.pagination)
 	})
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 	return &options
@@ -79,7 +80,24 @@ func doGetFargateProfile(cmd *cmdutils.Cmd, options *options) error {
 	if err != nil {
 		return err
 	}
-	return fargate.PrintProfiles(profiles, cmd.CobraCommand.OutOrStdout(), options.output)
+	page, err := cmdutils.Paginate(profiles, func(p *api.FargateProfile) string {
+		return p.Name
+	}, options.pagination)
+	if err != nil {
+		return err
+	}
+	if options.output == printers.TableType {
+		if err := fargate.PrintProfiles(page.Items, cmd.CobraCommand.OutOrStdout(), options.output); err != nil {
+			return err
+		}
+		logNextPage("fargateprofiles", page.NextToken)
+		return nil
+	}
+	printer, err := printers.NewPrinter(options.output)
+	if err != nil {
+		return err
+	}
+	return printPage(printer, "fargateprofiles", page, &options.getCmdParams, cmd.CobraCommand.OutOrStdout())
 }
 
 func getProfiles(ctx context.Context, manager *fargate.Client, name string) ([]*api.FargateProfile, error) {
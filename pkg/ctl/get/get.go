@@ -1,31 +1,71 @@
 package get
 
 import (
+	"io"
+
+	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
 
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
 	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
 type getCmdParams struct {
-	chunkSize int
-	output    printers.Type
+	output     printers.Type
+	pagination cmdutils.PaginationOptions
 }
 
 // Command will create the `get` commands
 func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	verbCmd := cmdutils.NewVerbCmd("get", "Get resource(s)", "")
 
-	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getClusterCmd)
-	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getNodeGroupCmd)
-	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getIdentityProvider)
-	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getIAMServiceAccountCmd)
-	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getIAMIdentityMappingCmd)
-	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getLabelsCmd)
-	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getFargateProfile)
-	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getAddonCmd)
-	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getPodIdentityAssociationCmd)
-	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getAccessEntryCmd)
+	cmdFuncs := []func(*cmdutils.Cmd){
+		getClusterCmd,
+		getNodeGroupCmd,
+		getIdentityProvider,
+		getIAMServiceAccountCmd,
+		getIAMIdentityMappingCmd,
+		getLabelsCmd,
+		getFargateProfile,
+		getAddonCmd,
+		getPodIdentityAssociationCmd,
+		getAccessEntryCmd,
+		getIAMPolicyTemplatesCmd,
+		getResourcesCmd,
+		getAllCmd,
+	}
+	for _, cmdFunc := range cmdFuncs {
+		cmdutils.AddResourceCmd(flagGrouping, verbCmd, withResponseCache(cmdFunc))
+	}
 
 	return verbCmd
 }
+
+// printPage prints a page of items. When a page is requested with --limit, the JSON and YAML outputs hold
+// the items along with the token for the next page, other outputs only hold the items and the token is logged.
+func printPage[T any](printer printers.OutputPrinter, kind string, page cmdutils.Page[T], params *getCmdParams, w io.Writer) error {
+	if params.pagination.Enabled() && (params.output == printers.JSONType || params.output == printers.YAMLType) {
+		return printer.PrintObjWithKind(kind, page, w)
+	}
+	if err := printer.PrintObjWithKind(kind, page.Items, w); err != nil {
+		return err
+	}
+	logNextPage(kind, page.NextToken)
+	return nil
+}
+
+func logNextPage(kind, nextToken string) {
+	if nextToken != "" {
+		logger.Info("more %s are available, rerun the command with --continue=%s to list them", kind, nextToken)
+	}
+}
+
+// withResponseCache caches the responses of the read-only AWS API calls of get commands, so that repeated
+// invocations, e.g. in CI, do not call the APIs every time
+func withResponseCache(cmdFunc func(*cmdutils.Cmd)) func(*cmdutils.Cmd) {
+	return func(cmd *cmdutils.Cmd) {
+		cmd.ProviderConfig.ResponseCache.Operations = eks.ReadOnlyCachedOperations
+		cmdFunc(cmd)
+	}
+}
@@ -0,0 +1,57 @@
+package get
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func getIAMPolicyTemplatesCmd(cmd *cmdutils.Cmd) {
+	params := &getCmdParams{}
+
+	cmd.SetDescription(
+		"iam-policy-templates",
+		"List the curated IAM policies that can be attached with wellKnownPolicies",
+		"",
+		"iam-policy-template",
+	)
+
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return getIAMPolicyTemplates(cmd, params)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVarP(&params.output, "output", "o", "table", "specifies the output format (valid option: table, json, yaml, custom-columns=<spec>, jsonpath=<template>)")
+	})
+}
+
+func getIAMPolicyTemplates(cmd *cmdutils.Cmd, params *getCmdParams) error {
+	printer, err := printers.NewPrinter(params.output)
+	if err != nil {
+		return err
+	}
+
+	if params.output == printers.TableType {
+		addIAMPolicyTemplateTableColumns(printer.(*printers.TablePrinter))
+	}
+
+	return printer.PrintObjWithKind("IAM policy templates", api.WellKnownPolicyTemplates(), cmd.CobraCommand.OutOrStdout())
+}
+
+func addIAMPolicyTemplateTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("NAME", func(t api.WellKnownPolicyTemplate) string {
+		return t.Name
+	})
+	printer.AddColumn("VERSION", func(t api.WellKnownPolicyTemplate) string {
+		return strconv.Itoa(t.Version)
+	})
+	printer.AddColumn("DESCRIPTION", func(t api.WellKnownPolicyTemplate) string {
+		return t.Description
+	})
+}
@@ -0,0 +1,32 @@
+package get
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("get", func() {
+	Describe("iam-policy-templates", func() {
+		It("lists the curated policies", func() {
+			cmd := newMockCmd("iam-policy-templates")
+			out, err := cmd.execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("NAME"))
+			Expect(out).To(ContainSubstring("externalDNS"))
+			Expect(out).To(ContainSubstring("fsxCSIController"))
+		})
+
+		It("prints JSON", func() {
+			cmd := newMockCmd("iam-policy-templates", "-o", "json")
+			out, err := cmd.execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring(`"name": "cloudWatchAgent"`))
+		})
+
+		It("fails when name argument is used", func() {
+			cmd := newMockCmd("iam-policy-templates", "externalDNS")
+			_, err := cmd.execute()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
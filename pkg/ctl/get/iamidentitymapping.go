@@ -6,10 +6,12 @@ import (
 	"os"
 	"strings"
 
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/authconfigmap"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
@@ -36,7 +38,7 @@ func getIAMIdentityMappingCmd(cmd *cmdutils.Cmd) {
 		cmdutils.AddIAMIdentityMappingARNFlags(fs, cmd, &arn, "get")
 		cmdutils.AddClusterFlagWithDeprecated(fs, cfg.Metadata)
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
-		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
+		cmdutils.AddCommonFlagsForGetCmd(fs, &cmd.ProviderConfig.PageSize, &params.output)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 	})
@@ -79,6 +81,14 @@ func doGetIAMIdentityMapping(cmd *cmdutils.Cmd, params *getCmdParams, arn string
 	if err != nil {
 		return err
 	}
+	if ctl.GetClusterState().AccessConfig.AuthenticationMode == ekstypes.AuthenticationModeApiAndConfigMap {
+		accessEntries, err := accessentryactions.NewGetter(cfg.Metadata.Name, ctl.AWSProvider.EKS()).Get(context.Background(), api.ARN{})
+		if err != nil {
+			logger.Debug("not comparing the aws-auth ConfigMap with the access entries: %v", err)
+		} else {
+			accessentryactions.WarnAuthConflicts(cfg.Metadata.Name, accessentryactions.FindAuthConflicts(identities, accessEntries))
+		}
+	}
 
 	if arn != "" {
 		var selectedIdentities []iam.Identity
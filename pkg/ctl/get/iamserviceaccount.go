@@ -19,7 +19,7 @@ func getIAMServiceAccountCmd(cmd *cmdutils.Cmd) {
 	cfg := api.NewClusterConfig()
 	cmd.ClusterConfig = cfg
 
-	var name, namespace string
+	var name, namespace, targetAccountRoleARN string
 	cfg.IAM.WithOIDC = api.Enabled()
 
 	params := &getCmdParams{}
@@ -29,7 +29,7 @@ func getIAMServiceAccountCmd(cmd *cmdutils.Cmd) {
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
 		return doGetIAMServiceAccount(cmd, IAMServiceAccountOptions{
-			GetOptions:   irsa.GetOptions{Name: name, Namespace: namespace},
+			GetOptions:   irsa.GetOptions{Name: name, Namespace: namespace, TargetAccountRoleARN: targetAccountRoleARN},
 			getCmdParams: params,
 		})
 	}
@@ -38,11 +38,12 @@ func getIAMServiceAccountCmd(cmd *cmdutils.Cmd) {
 		cmdutils.AddClusterFlag(fs, cfg.Metadata)
 		fs.StringVar(&namespace, "namespace", "", "namespace to look for iamserviceaccount")
 		fs.StringVar(&name, "name", "", "name of iamserviceaccount to get")
+		cmdutils.AddIAMServiceAccountTargetAccountFlag(fs, &targetAccountRoleARN, "get")
 
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 
-		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
+		cmdutils.AddCommonFlagsForGetCmd(fs, &cmd.ProviderConfig.PageSize, &params.output)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 	})
 
@@ -77,7 +78,10 @@ func doGetIAMServiceAccount(cmd *cmdutils.Cmd, options IAMServiceAccountOptions)
 	}
 
 	stackManager := ctl.NewStackManager(cfg)
-	irsaManager := irsa.New(cfg.Metadata.Name, stackManager, nil, nil)
+	irsaManager := irsa.New(cfg.Metadata.Name, stackManager, nil, nil).
+		WithTargetAccounts(func(roleARN string) irsa.TargetAccount {
+			return ctl.NewIRSATargetAccount(cfg, roleARN)
+		})
 	serviceAccounts, err := irsaManager.Get(ctx, options.GetOptions)
 
 	if err != nil {
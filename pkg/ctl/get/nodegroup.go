@@ -17,6 +17,7 @@ import (
 	"github.com/spf13/pflag"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/printers"
 )
@@ -27,19 +28,22 @@ func getNodeGroupCmd(cmd *cmdutils.Cmd) {
 	cmd.ClusterConfig = cfg
 
 	params := &getCmdParams{}
+	var history bool
 
 	cmd.SetDescription("nodegroup", "Get nodegroup(s)", "", "ng", "nodegroups")
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return doGetNodeGroup(cmd, ng, params)
+		return doGetNodeGroup(cmd, ng, params, history)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		cmdutils.AddClusterFlag(fs, cfg.Metadata)
 		fs.StringVarP(&ng.Name, "name", "n", "", "Name of the nodegroup")
+		fs.BoolVar(&history, "history", false, "list the upgrades eksctl made to the nodegroup, with the versions and AMI of its nodes and the command that made them")
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
-		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
+		cmdutils.AddCommonFlagsForGetCmd(fs, &cmd.ProviderConfig.PageSize, &params.output)
+		cmdutils.AddPaginationFlags(fs, &params.pagination)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 	})
@@ -47,10 +51,13 @@ func getNodeGroupCmd(cmd *cmdutils.Cmd) {
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 }
 
-func doGetNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, params *getCmdParams) error {
+func doGetNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, params *getCmdParams, history bool) error {
 	if err := cmdutils.NewGetNodegroupLoader(cmd, ng).Load(); err != nil {
 		return err
 	}
+	if history && ng.Name == "" {
+		return errors.New("--history requires --name")
+	}
 
 	if params.output != printers.TableType {
 		//log warnings and errors to stderr
@@ -64,6 +71,9 @@ func doGetNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, params *getCmdParams)
 	}
 
 	cfg := cmd.ClusterConfig
+	if history {
+		return getNodeGroupHistory(ctx, cmd, ctl.AWSProvider.SSM(), ng.Name, params)
+	}
 	if ok, err := ctl.CanOperate(cfg); !ok {
 		return err
 	}
@@ -111,7 +121,49 @@ func doGetNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, params *getCmdParams)
 		addSummaryTableColumns(printer.(*printers.TablePrinter))
 	}
 
-	return printer.PrintObjWithKind("nodegroups", summaries, cmd.CobraCommand.OutOrStdout())
+	page, err := cmdutils.Paginate(summaries, func(s *nodegroup.Summary) string {
+		return s.Name
+	}, params.pagination)
+	if err != nil {
+		return err
+	}
+	return printPage(printer, "nodegroups", page, params, cmd.CobraCommand.OutOrStdout())
+}
+
+func getNodeGroupHistory(ctx context.Context, cmd *cmdutils.Cmd, ssmAPI awsapi.SSM, nodeGroupName string, params *getCmdParams) error {
+	history, err := nodegroup.GetHistory(ctx, ssmAPI, cmd.ClusterConfig.Metadata.Name, nodeGroupName)
+	if err != nil {
+		return err
+	}
+	printer, err := printers.NewPrinter(params.output)
+	if err != nil {
+		return err
+	}
+	if params.output == printers.TableType {
+		addHistoryTableColumns(printer.(*printers.TablePrinter))
+	}
+	return printer.PrintObjWithKind("upgrades", history, cmd.CobraCommand.OutOrStdout())
+}
+
+func addHistoryTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("TIME", func(e nodegroup.HistoryEntry) string {
+		return e.Time.Format(time.RFC3339)
+	})
+	printer.AddColumn("KUBERNETES VERSION", func(e nodegroup.HistoryEntry) string {
+		return e.KubernetesVersion
+	})
+	printer.AddColumn("RELEASE VERSION", func(e nodegroup.HistoryEntry) string {
+		return e.ReleaseVersion
+	})
+	printer.AddColumn("LAUNCH TEMPLATE VERSION", func(e nodegroup.HistoryEntry) string {
+		return e.LaunchTemplateVersion
+	})
+	printer.AddColumn("AMI", func(e nodegroup.HistoryEntry) string {
+		return e.AMI
+	})
+	printer.AddColumn("COMMAND", func(e nodegroup.HistoryEntry) string {
+		return e.Command
+	})
 }
 
 func addSummaryTableColumns(printer *printers.TablePrinter) {
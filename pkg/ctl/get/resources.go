@@ -0,0 +1,155 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+// clusterResource is an AWS resource owned by eksctl, as a resource of one of the cluster's stacks
+type clusterResource struct {
+	Stack      string `json:"stack"`
+	Type       string `json:"type"`
+	LogicalID  string `json:"logicalID"`
+	PhysicalID string `json:"physicalID"`
+	Status     string `json:"status"`
+}
+
+type stackLister interface {
+	ListStacks(ctx context.Context) ([]*manager.Stack, error)
+}
+
+func getResourcesCmd(cmd *cmdutils.Cmd) {
+	getResourcesWithRunFunc(cmd, doGetResources)
+}
+
+func getResourcesWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, params *getCmdParams) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	params := &getCmdParams{}
+
+	cmd.SetDescription(
+		"resources",
+		"Get the AWS resources owned by eksctl for a cluster",
+		"Lists the resources of all the CloudFormation stacks eksctl created for a cluster",
+		"resource",
+	)
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddCommonFlagsForGetCmd(fs, &cmd.ProviderConfig.PageSize, &params.output)
+		cmdutils.AddPaginationFlags(fs, &params.pagination)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return runFunc(cmd, params)
+	}
+}
+
+func doGetResources(cmd *cmdutils.Cmd, params *getCmdParams) error {
+	if params.output != printers.TableType {
+		//log warnings and errors to stderr
+		logger.Writer = os.Stderr
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("getting the resources owned by eksctl for cluster %q", cmd.ClusterConfig.Metadata.Name)
+	resources, err := listClusterResources(ctx, ctl.NewStackManager(cmd.ClusterConfig), ctl.AWSProvider.CloudFormation())
+	if err != nil {
+		return err
+	}
+	if len(resources) == 0 {
+		logger.Info("no resources owned by eksctl were found for cluster %q", cmd.ClusterConfig.Metadata.Name)
+		return nil
+	}
+
+	printer, err := printers.NewPrinter(params.output)
+	if err != nil {
+		return err
+	}
+	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+		addResourceTableColumns(tablePrinter)
+	}
+
+	page, err := cmdutils.Paginate(resources, func(r clusterResource) string {
+		return r.Stack + "/" + r.LogicalID
+	}, params.pagination)
+	if err != nil {
+		return err
+	}
+	return printPage(printer, "resources", page, params, cmd.CobraCommand.OutOrStdout())
+}
+
+// listClusterResources returns the resources of all stacks owned by eksctl for the cluster, ordered by stack
+func listClusterResources(ctx context.Context, stackManager stackLister, cfnAPI awsapi.CloudFormation) ([]clusterResource, error) {
+	stacks, err := stackManager.ListStacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []clusterResource
+	for _, s := range stacks {
+		stackName := aws.ToString(s.StackName)
+		paginator := cloudformation.NewListStackResourcesPaginator(cfnAPI, &cloudformation.ListStackResourcesInput{
+			StackName: s.StackName,
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("listing resources of stack %q: %w", stackName, err)
+			}
+			for _, r := range output.StackResourceSummaries {
+				resources = append(resources, clusterResource{
+					Stack:      stackName,
+					Type:       aws.ToString(r.ResourceType),
+					LogicalID:  aws.ToString(r.LogicalResourceId),
+					PhysicalID: aws.ToString(r.PhysicalResourceId),
+					Status:     string(r.ResourceStatus),
+				})
+			}
+		}
+	}
+	return resources, nil
+}
+
+func addResourceTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("STACK", func(r clusterResource) string {
+		return r.Stack
+	})
+	printer.AddColumn("TYPE", func(r clusterResource) string {
+		return r.Type
+	})
+	printer.AddColumn("LOGICAL ID", func(r clusterResource) string {
+		return r.LogicalID
+	})
+	printer.AddColumn("PHYSICAL ID", func(r clusterResource) string {
+		return r.PhysicalID
+	})
+	printer.AddColumn("STATUS", func(r clusterResource) string {
+		return r.Status
+	})
+}
@@ -0,0 +1,113 @@
+package get
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("get resources", func() {
+	Describe("flags", func() {
+		newCmd := func(args ...string) *cmdutils.Cmd {
+			var resourcesCmd *cmdutils.Cmd
+			grouping := cmdutils.NewGrouping()
+			parentCmd := cmdutils.NewVerbCmd("get", "", "")
+			cmdutils.AddResourceCmd(grouping, parentCmd, func(cmd *cmdutils.Cmd) {
+				getResourcesWithRunFunc(cmd, func(cmd *cmdutils.Cmd, _ *getCmdParams) error {
+					resourcesCmd = cmd
+					return nil
+				})
+			})
+			parentCmd.SetArgs(append([]string{"resources"}, args...))
+			Expect(parentCmd.Execute()).To(Succeed())
+			return resourcesCmd
+		}
+
+		It("accepts the cluster name as a flag", func() {
+			Expect(newCmd("--cluster", "my-cluster").ClusterConfig.Metadata.Name).To(Equal("my-cluster"))
+		})
+
+		It("accepts the cluster name from a config file", func() {
+			Expect(newCmd("-f", "../../../examples/01-simple-cluster.yaml").ClusterConfig.Metadata.Name).To(Equal("cluster-1"))
+		})
+	})
+
+	Describe("listClusterResources", func() {
+		var (
+			p            *mockprovider.MockProvider
+			stackManager *fakes.FakeStackManager
+		)
+
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+			stackManager = &fakes.FakeStackManager{}
+			stackManager.ListStacksReturns([]*cfntypes.Stack{
+				{StackName: aws.String("eksctl-my-cluster-cluster")},
+				{StackName: aws.String("eksctl-my-cluster-nodegroup-ng-1")},
+			}, nil)
+		})
+
+		It("lists the resources of every stack owned by the cluster", func() {
+			p.MockCloudFormation().On("ListStackResources", mock.Anything, &cloudformation.ListStackResourcesInput{
+				StackName: aws.String("eksctl-my-cluster-cluster"),
+			}, mock.Anything).Return(&cloudformation.ListStackResourcesOutput{
+				StackResourceSummaries: []cfntypes.StackResourceSummary{
+					{
+						LogicalResourceId:  aws.String("ControlPlane"),
+						PhysicalResourceId: aws.String("my-cluster"),
+						ResourceType:       aws.String("AWS::EKS::Cluster"),
+						ResourceStatus:     cfntypes.ResourceStatusCreateComplete,
+					},
+				},
+			}, nil)
+			p.MockCloudFormation().On("ListStackResources", mock.Anything, &cloudformation.ListStackResourcesInput{
+				StackName: aws.String("eksctl-my-cluster-nodegroup-ng-1"),
+			}, mock.Anything).Return(&cloudformation.ListStackResourcesOutput{
+				StackResourceSummaries: []cfntypes.StackResourceSummary{
+					{
+						LogicalResourceId:  aws.String("NodeInstanceRole"),
+						PhysicalResourceId: aws.String("eksctl-my-cluster-nodegroup-ng-1-NodeInstanceRole"),
+						ResourceType:       aws.String("AWS::IAM::Role"),
+						ResourceStatus:     cfntypes.ResourceStatusUpdateComplete,
+					},
+				},
+			}, nil)
+
+			resources, err := listClusterResources(context.Background(), stackManager, p.MockCloudFormation())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(Equal([]clusterResource{
+				{
+					Stack:      "eksctl-my-cluster-cluster",
+					Type:       "AWS::EKS::Cluster",
+					LogicalID:  "ControlPlane",
+					PhysicalID: "my-cluster",
+					Status:     "CREATE_COMPLETE",
+				},
+				{
+					Stack:      "eksctl-my-cluster-nodegroup-ng-1",
+					Type:       "AWS::IAM::Role",
+					LogicalID:  "NodeInstanceRole",
+					PhysicalID: "eksctl-my-cluster-nodegroup-ng-1-NodeInstanceRole",
+					Status:     "UPDATE_COMPLETE",
+				},
+			}))
+		})
+
+		It("returns an error when the resources of a stack cannot be listed", func() {
+			p.MockCloudFormation().On("ListStackResources", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+
+			_, err := listClusterResources(context.Background(), stackManager, p.MockCloudFormation())
+			Expect(err).To(MatchError(`listing resources of stack "eksctl-my-cluster-cluster": access denied`))
+		})
+	})
+})
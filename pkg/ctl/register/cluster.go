@@ -18,16 +18,20 @@ import (
 func registerClusterCmd(cmd *cmdutils.Cmd) {
 	cmd.SetDescription("cluster", "Register a non-EKS Kubernetes cluster", "")
 
-	var cluster connector.ExternalCluster
+	var (
+		cluster      connector.ExternalCluster
+		manifestsDir string
+	)
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
-		return registerCluster(cmd, cluster)
+		return registerCluster(cmd, cluster, manifestsDir)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		fs.StringVar(&cluster.Name, "name", "", "EKS cluster name")
-		fs.StringVar(&cluster.Provider, "provider", "", fmt.Sprintf("Kubernetes provider name (one of %v)", connector.ValidProviders()))
+		fs.StringVar(&cluster.Provider, "provider", "", fmt.Sprintf("Kubernetes provider name, case-insensitive (one of %v or %s)", connector.ValidProviders(), connector.GenericProvider))
 		fs.StringVar(&cluster.ConnectorRoleARN, "role-arn", "", "EKS Connector role ARN")
+		fs.StringVar(&manifestsDir, "manifests-dir", "", "directory to write the EKS Connector manifests to (defaults to the current directory)")
 
 		requiredFlags := []string{"name", "provider"}
 		for _, f := range requiredFlags {
@@ -40,7 +44,7 @@ func registerClusterCmd(cmd *cmdutils.Cmd) {
 
 }
 
-func registerCluster(cmd *cmdutils.Cmd, cluster connector.ExternalCluster) error {
+func registerCluster(cmd *cmdutils.Cmd, cluster connector.ExternalCluster, manifestsDir string) error {
 	ctx := context.Background()
 	clusterProvider, err := eks.New(ctx, &cmd.ProviderConfig, nil)
 	if err != nil {
@@ -63,6 +67,5 @@ func registerCluster(cmd *cmdutils.Cmd, cluster connector.ExternalCluster) error
 
 	logger.Info("registered cluster %q successfully", cluster.Name)
 
-	// TODO consider providing a manifests-dir argument to allow writing EKS Connector resources to a specific directory.
-	return connector.WriteResources(afero.NewOsFs(), resourceList)
+	return connector.WriteResources(afero.NewOsFs(), manifestsDir, resourceList)
 }
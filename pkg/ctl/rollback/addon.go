@@ -0,0 +1,89 @@
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func rollbackAddonCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"addon",
+		"Roll back an addon to its previous revision",
+		dedent.Dedent(`Restores the version and configuration values an addon was deployed with before it was last
+			updated or upgraded by eksctl. The revision rolled back from is recorded in turn, so rolling back
+			again restores it.
+		`),
+	)
+
+	var name string
+	var wait bool
+	cmd.FlagSetGroup.InFlagSet("Addon", func(fs *pflag.FlagSet) {
+		fs.StringVar(&name, "name", "", "Addon name")
+		fs.BoolVar(&wait, "wait", false, "Wait for the addon rollback to complete")
+	})
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if name != "" && cmd.NameArg != "" {
+			return cmdutils.ErrFlagAndArg("--name", name, cmd.NameArg)
+		}
+		if cmd.NameArg != "" {
+			name = cmd.NameArg
+		}
+		if err := cmdutils.NewRollbackAddonLoader(cmd, name).Load(); err != nil {
+			return err
+		}
+		return doRollbackAddon(cmd, name, wait)
+	}
+}
+
+func doRollbackAddon(cmd *cmdutils.Cmd, name string, wait bool) error {
+	ctx := context.Background()
+	cfg := cmd.ClusterConfig
+	clusterProvider, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	output, err := clusterProvider.AWSProvider.EKS().DescribeCluster(ctx, &awseks.DescribeClusterInput{
+		Name: &cfg.Metadata.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch cluster %q version: %v", cfg.Metadata.Name, err)
+	}
+	cfg.Metadata.Version = *output.Cluster.Version
+
+	addonManager, err := addon.New(cfg, clusterProvider.AWSProvider.EKS(), clusterProvider.NewStackManager(cfg), false, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	var waitTimeout time.Duration
+	if wait {
+		waitTimeout = cmd.ProviderConfig.WaitTimeout
+	}
+	if err := addonManager.Rollback(ctx, name, waitTimeout); err != nil {
+		return err
+	}
+	logger.Success("rolled back addon %q", name)
+	return nil
+}
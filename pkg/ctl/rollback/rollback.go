@@ -0,0 +1,15 @@
+package rollback
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+// Command creates the `rollback` commands
+func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
+	verbCmd := cmdutils.NewVerbCmd("rollback", "Roll back resource(s) to their previous revision", "")
+
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, rollbackAddonCmd)
+
+	return verbCmd
+}
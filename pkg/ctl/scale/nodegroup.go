@@ -11,6 +11,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
 )
 
 func scaleNodeGroupCmd(cmd *cmdutils.Cmd) {
@@ -35,6 +36,7 @@ func scaleNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd
 		cmdutils.AddClusterFlag(fs, cfg.Metadata)
 		fs.StringVarP(&ng.Name, "name", "n", "", "Name of the nodegroup to scale")
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddNodeGroupSelectorFlag(fs, &cmd.Selector)
 
 		desiredCapacity := fs.IntP("nodes", "N", -1, "desired number of nodes (required)")
 		maxCapacity := fs.IntP("nodes-max", "M", -1, "maximum number of nodes")
@@ -61,6 +63,13 @@ func scaleNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd
 }
 
 func doScaleNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroupBase) error {
+	if cmd.Selector != "" {
+		if err := cmdutils.ValidateNodeGroupSelector(cmd, ng.Name); err != nil {
+			return err
+		}
+		return scaleSelectedNodegroups(cmd, ng)
+	}
+
 	if ng.Name == "" && cmd.NameArg == "" {
 		if err := cmdutils.NewScaleAllNodeGroupLoader(cmd).Load(); err != nil {
 			return err
@@ -74,6 +83,47 @@ func doScaleNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroupBase) error {
 	return scaleNodegroup(cmd, ng)
 }
 
+// scaleSelectedNodegroups scales the nodegroups matching the selector, using the labels and tags in the config file
+// if one is given, and the labels of the cluster's nodes otherwise
+func scaleSelectedNodegroups(cmd *cmdutils.Cmd, ng *api.NodeGroupBase) error {
+	if cmd.ClusterConfigFile != "" {
+		if err := cmdutils.NewScaleAllNodeGroupLoader(cmd).Load(); err != nil {
+			return err
+		}
+		ngFilter := filter.NewNodeGroupFilter()
+		if err := cmdutils.ApplyNodeGroupSelector(cmd.Selector, cmd.ClusterConfig, ngFilter); err != nil {
+			return err
+		}
+		cmdutils.ApplyFilter(cmd.ClusterConfig, ngFilter)
+		return scaleAllNodegroups(cmd)
+	}
+
+	if err := cmdutils.NewScaleNodeGroupLoader(cmd, ng).Load(); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cmd.ClusterConfig)
+	if err != nil {
+		return err
+	}
+	names, err := cmdutils.SelectNodeGroupsFromNodes(ctx, clientSet, cmd.Selector)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		selected := *ng
+		selected.Name = name
+		if err := scaleNodegroup(cmd, &selected); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func scaleAllNodegroups(cmd *cmdutils.Cmd) error {
 	allNg := cmd.ClusterConfig.AllNodeGroups()
 	for _, ng := range allNg {
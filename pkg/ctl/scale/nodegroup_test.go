@@ -95,6 +95,14 @@ var _ = Describe("scale", func() {
 				args:  []string{"nodegroup", "-f", "../cmdutils/test_data/scale-ng-test.yaml", "--cluster", "dummyCluster"},
 				error: fmt.Errorf("Error: cannot use --cluster when --config-file/-f is set"),
 			}),
+			Entry("with selector and name argument", invalidParamsCase{
+				args:  []string{"nodegroup", "ng", "--cluster", "dummy", "--nodes", "2", "--selector", "role=batch"},
+				error: fmt.Errorf("Error: --selector cannot be used with --name or a nodegroup name argument"),
+			}),
+			Entry("with config file and a selector matching no nodegroups", invalidParamsCase{
+				args:  []string{"nodegroup", "-f", "../cmdutils/test_data/scale-ng-test.yaml", "--selector", "role=batch"},
+				error: fmt.Errorf(`Error: no nodegroups match selector "role=batch"`),
+			}),
 		)
 	})
 })
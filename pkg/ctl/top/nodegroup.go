@@ -0,0 +1,149 @@
+package top
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func topNodeGroupCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	ng := api.NewNodeGroup()
+	cmd.ClusterConfig = cfg
+
+	var output printers.Type
+
+	cmd.SetDescription("nodegroup", "Display CPU and memory requests, allocatable resources and usage of nodegroup(s)",
+		"Usage is read from the resource metrics API, which requires metrics-server to be installed in the cluster", "ng", "nodegroups")
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doTopNodeGroup(cmd, ng, output)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		fs.StringVarP(&ng.Name, "name", "n", "", "Name of the nodegroup")
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doTopNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, output printers.Type) error {
+	if err := cmdutils.NewGetNodegroupLoader(cmd, ng).Load(); err != nil {
+		return err
+	}
+
+	if output != printers.TableType {
+		//log warnings and errors to stderr
+		logger.Writer = os.Stderr
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg := cmd.ClusterConfig
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	utilizations, err := nodegroup.New(cfg, ctl, clientSet, nil).Top(ctx, nodegroup.NewMetricsServerLister(clientSet))
+	if err != nil {
+		return err
+	}
+	if ng.Name != "" {
+		var filtered []*nodegroup.Utilization
+		for _, u := range utilizations {
+			if u.Name == ng.Name {
+				filtered = append(filtered, u)
+			}
+		}
+		utilizations = filtered
+	}
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+
+	if output == printers.TableType {
+		if len(utilizations) == 0 {
+			if ng.Name == "" {
+				return errors.Errorf("No nodegroups found")
+			}
+			return errors.Errorf("nodegroup with name %v not found", ng.Name)
+		}
+		addUtilizationTableColumns(printer.(*printers.TablePrinter))
+	}
+
+	return printer.PrintObjWithKind("nodegroups", utilizations, cmd.CobraCommand.OutOrStdout())
+}
+
+func addUtilizationTableColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("CLUSTER", func(u *nodegroup.Utilization) string {
+		return u.Cluster
+	})
+	printer.AddColumn("NODEGROUP", func(u *nodegroup.Utilization) string {
+		return u.Name
+	})
+	printer.AddColumn("INSTANCES", func(u *nodegroup.Utilization) int {
+		return u.Instances
+	})
+	printer.AddColumn("NODES", func(u *nodegroup.Utilization) int {
+		return u.Nodes
+	})
+	printer.AddColumn("CPU ALLOCATABLE", func(u *nodegroup.Utilization) string {
+		return u.CPUAllocatable.String()
+	})
+	printer.AddColumn("CPU REQUESTS", func(u *nodegroup.Utilization) string {
+		return formatShare(&u.CPURequests, u.CPUAllocatable)
+	})
+	printer.AddColumn("CPU USAGE", func(u *nodegroup.Utilization) string {
+		return formatShare(u.CPUUsage, u.CPUAllocatable)
+	})
+	printer.AddColumn("MEMORY ALLOCATABLE", func(u *nodegroup.Utilization) string {
+		return u.MemoryAllocatable.String()
+	})
+	printer.AddColumn("MEMORY REQUESTS", func(u *nodegroup.Utilization) string {
+		return formatShare(&u.MemoryRequests, u.MemoryAllocatable)
+	})
+	printer.AddColumn("MEMORY USAGE", func(u *nodegroup.Utilization) string {
+		return formatShare(u.MemoryUsage, u.MemoryAllocatable)
+	})
+}
+
+// formatShare formats a quantity along with its percentage of the allocatable quantity
+func formatShare(quantity *resource.Quantity, allocatable resource.Quantity) string {
+	if quantity == nil {
+		return "-"
+	}
+	if allocatable.IsZero() {
+		return quantity.String()
+	}
+	return fmt.Sprintf("%s (%d%%)", quantity.String(), quantity.MilliValue()*100/allocatable.MilliValue())
+}
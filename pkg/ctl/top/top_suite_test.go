@@ -0,0 +1,11 @@
+package top
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestCtlTop(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
@@ -0,0 +1,62 @@
+package top
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("top", func() {
+	DescribeTable("invalid flags or arguments",
+		func(args []string, expectedErr string) {
+			cmd := newDefaultCmd(args...)
+			_, err := cmd.execute()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(expectedErr))
+		},
+		Entry("with invalid-resource", []string{"invalid-resource"}, `Error: unknown command "invalid-resource" for "top"`),
+		Entry("without a cluster name", []string{"nodegroup"}, "--cluster must be set"),
+		Entry("with a name flag and argument", []string{"nodegroup", "--cluster", "cluster", "--name", "ng-1", "ng-2"}, `--name=ng-1 and argument ng-2 cannot be used at the same time`),
+	)
+
+	DescribeTable("formatShare",
+		func(quantity *resource.Quantity, allocatable, expected string) {
+			Expect(formatShare(quantity, resource.MustParse(allocatable))).To(Equal(expected))
+		},
+		Entry("without usage", nil, "4", "-"),
+		Entry("with CPU", resource.NewMilliQuantity(750, resource.DecimalSI), "4", "750m (18%)"),
+		Entry("with memory", resource.NewQuantity(2<<30, resource.BinarySI), "8Gi", "2Gi (25%)"),
+		Entry("without allocatable resources", resource.NewMilliQuantity(750, resource.DecimalSI), "0", "750m"),
+	)
+})
+
+func newDefaultCmd(args ...string) *mockVerbCmd {
+	flagGrouping := cmdutils.NewGrouping()
+	cmd := Command(flagGrouping)
+	cmd.SetArgs(args)
+	return &mockVerbCmd{
+		parentCmd: cmd,
+	}
+}
+
+type mockVerbCmd struct {
+	parentCmd *cobra.Command
+}
+
+func (c mockVerbCmd) execute() (string, error) {
+	outBuf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	c.parentCmd.SetOut(outBuf)
+	c.parentCmd.SetErr(errBuf)
+	err := c.parentCmd.Execute()
+	if err != nil {
+		err = errors.New(errBuf.String())
+	}
+	return outBuf.String(), err
+}
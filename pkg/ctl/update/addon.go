@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
@@ -91,12 +92,20 @@ func updateAddon(cmd *cmdutils.Cmd, force, wait bool) error {
 	logger.Info("Kubernetes version %q in use by cluster %q", *output.Cluster.Version, cmd.ClusterConfig.Metadata.Name)
 	cmd.ClusterConfig.Metadata.Version = *output.Cluster.Version
 
-	addonManager, err := addon.New(cmd.ClusterConfig, clusterProvider.AWSProvider.EKS(), stackManager, oidcProviderExists, oidc, nil)
+	addonManager, err := addon.New(cmd.ClusterConfig, clusterProvider.AWSProvider.EKS(), stackManager, oidcProviderExists, oidc, func() (kubernetes.Interface, error) {
+		return clusterProvider.NewStdClientSet(cmd.ClusterConfig)
+	})
 
 	if err != nil {
 		return err
 	}
 
+	for _, a := range cmd.ClusterConfig.Addons {
+		if err := addonManager.ValidateConfigurationValues(ctx, a); err != nil {
+			return err
+		}
+	}
+
 	piaUpdater := &addon.PodIdentityAssociationUpdater{
 		ClusterName: cmd.ClusterConfig.Metadata.Name,
 		IAMRoleCreator: &podidentityassociation.IAMRoleCreator{
@@ -114,7 +123,9 @@ func updateAddon(cmd *cmdutils.Cmd, force, wait bool) error {
 		if force { //force is specified at cmdline level
 			a.Force = true
 		}
-		if err := addonManager.Update(ctx, a, piaUpdater, cmd.ProviderConfig.WaitTimeout); err != nil {
+		if err := addonManager.WithRevision(ctx, a.Name, func() error {
+			return addonManager.Update(ctx, a, piaUpdater, cmd.ProviderConfig.WaitTimeout)
+		}); err != nil {
 			return err
 		}
 	}
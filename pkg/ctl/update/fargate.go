@@ -0,0 +1,106 @@
+package update
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+)
+
+func updateFargateProfile(cmd *cmdutils.Cmd) {
+	updateFargateProfileWithRunFunc(cmd, doUpdateFargateProfile)
+}
+
+func updateFargateProfileWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, opts *fargate.Options) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"fargateprofile",
+		"Update Fargate profiles",
+		dedent.Dedent(`Recreates the Fargate profiles of the config file whose selectors, pod execution role, subnets or tags
+			changed, as Fargate profiles cannot be changed. A temporary profile with the new configuration is created
+			before the existing profile is deleted and recreated, so that pods can be scheduled on Fargate throughout
+			the update.
+		`),
+	)
+
+	var opts fargate.Options
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewUpdateFargateProfileLoader(cmd, &opts).Load(); err != nil {
+			return err
+		}
+		return runFunc(cmd, &opts)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("Fargate", func(fs *pflag.FlagSet) {
+		cmdutils.AddFlagsForFargate(fs, &opts)
+	})
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doUpdateFargateProfile(cmd *cmdutils.Cmd, opts *fargate.Options) error {
+	cfg := cmd.ClusterConfig
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	client := fargate.NewFromProvider(cfg.Metadata.Name, ctl.AWSProvider, ctl.NewStackManager(cfg))
+	existingNames, err := client.ListProfiles(ctx)
+	if err != nil {
+		return err
+	}
+	existingProfiles := map[string]bool{}
+	for _, name := range existingNames {
+		existingProfiles[name] = true
+	}
+
+	for _, profile := range cfg.FargateProfiles {
+		if opts.ProfileName != "" && profile.Name != opts.ProfileName {
+			continue
+		}
+		if !existingProfiles[profile.Name] {
+			logger.Warning("Fargate profile %q does not exist, create it with 'eksctl create fargateprofile'", profile.Name)
+			continue
+		}
+		if existingProfiles[fargate.TemporaryProfileName(profile.Name)] {
+			return errors.Errorf("temporary Fargate profile %q of a previous update of Fargate profile %q exists, delete it before updating the profile", fargate.TemporaryProfileName(profile.Name), profile.Name)
+		}
+		existing, err := client.ReadProfile(ctx, profile.Name)
+		if err != nil {
+			return err
+		}
+		changes := fargate.ProfileChanges(existing, profile)
+		if len(changes) == 0 {
+			logger.Info("Fargate profile %q is up to date", profile.Name)
+			continue
+		}
+		logger.Info("Fargate profile %q will be recreated, as its %s changed", profile.Name, strings.Join(changes, ", "))
+		if cmd.Plan {
+			continue
+		}
+		if err := client.UpdateProfile(ctx, existing, profile); err != nil {
+			return err
+		}
+		logger.Success("updated Fargate profile %q", profile.Name)
+	}
+	cmdutils.LogPlanModeWarning(cmd.Plan)
+	return nil
+}
@@ -0,0 +1,149 @@
+package upgrade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+type upgradeAddonOptions struct {
+	name string
+	all  bool
+}
+
+func upgradeAddonCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"addon",
+		"Upgrade addons to the versions allowed by their upgrade policies",
+		dedent.Dedent(`Upgrades an addon, or all addons of the cluster with --all, to the version its autoUpgradePolicy allows
+			for the Kubernetes version of the cluster: the latest compatible version (latest), the latest compatible
+			version with the same major and minor version (patch-only), or the version set in the config file (pinned).
+			Addons are upgraded one at a time, in dependency order.
+		`),
+	)
+
+	var options upgradeAddonOptions
+	cmd.FlagSetGroup.InFlagSet("Addon", func(fs *pflag.FlagSet) {
+		fs.StringVar(&options.name, "name", "", "Addon name")
+		fs.BoolVar(&options.all, "all", false, "Upgrade all addons of the cluster")
+	})
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if options.name != "" && cmd.NameArg != "" {
+			return cmdutils.ErrFlagAndArg("--name", options.name, cmd.NameArg)
+		}
+		if cmd.NameArg != "" {
+			options.name = cmd.NameArg
+		}
+		if options.name == "" && !options.all {
+			return errors.New("either --name or --all must be set")
+		}
+		if options.name != "" && options.all {
+			return errors.New("--name and --all cannot be used together")
+		}
+		if err := cmdutils.NewUpgradeAddonLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return doUpgradeAddon(cmd, options)
+	}
+}
+
+func doUpgradeAddon(cmd *cmdutils.Cmd, options upgradeAddonOptions) error {
+	ctx := context.Background()
+	cfg := cmd.ClusterConfig
+	clusterProvider, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	output, err := clusterProvider.AWSProvider.EKS().DescribeCluster(ctx, &awseks.DescribeClusterInput{
+		Name: &cfg.Metadata.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch cluster %q version: %v", cfg.Metadata.Name, err)
+	}
+	logger.Info("Kubernetes version %q in use by cluster %q", *output.Cluster.Version, cfg.Metadata.Name)
+	cfg.Metadata.Version = *output.Cluster.Version
+
+	oidc, err := clusterProvider.NewOpenIDConnectManager(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	oidcProviderExists, err := oidc.CheckProviderExists(ctx)
+	if err != nil {
+		return err
+	}
+
+	stackManager := clusterProvider.NewStackManager(cfg)
+	addonManager, err := addon.New(cfg, clusterProvider.AWSProvider.EKS(), stackManager, oidcProviderExists, oidc, func() (kubernetes.Interface, error) {
+		return clusterProvider.NewStdClientSet(cfg)
+	})
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	if options.name != "" {
+		names = []string{options.name}
+	}
+	upgrades, err := addonManager.PlanUpgrades(ctx, names)
+	if err != nil {
+		return err
+	}
+	if len(upgrades) == 0 {
+		logger.Info("all addons are up to date")
+		return nil
+	}
+	for _, upgrade := range upgrades {
+		logger.Info("addon %q will be upgraded from version %s to %s (%s)", upgrade.Name, upgrade.CurrentVersion, upgrade.TargetVersion, upgrade.Policy)
+	}
+	if cmd.Plan {
+		cmdutils.LogPlanModeWarning(true)
+		return nil
+	}
+
+	piaUpdater := &addon.PodIdentityAssociationUpdater{
+		ClusterName: cfg.Metadata.Name,
+		IAMRoleCreator: &podidentityassociation.IAMRoleCreator{
+			ClusterName:  cfg.Metadata.Name,
+			StackCreator: stackManager,
+		},
+		IAMRoleUpdater: &podidentityassociation.IAMRoleUpdater{
+			StackUpdater: stackManager,
+		},
+		EKSPodIdentityDescriber: clusterProvider.AWSProvider.EKS(),
+		StackDeleter:            stackManager,
+	}
+	for _, upgrade := range upgrades {
+		if err := addonManager.WithRevision(ctx, upgrade.Name, func() error {
+			return addonManager.Upgrade(ctx, []addon.PlannedUpgrade{upgrade}, piaUpdater, cmd.ProviderConfig.WaitTimeout)
+		}); err != nil {
+			return err
+		}
+	}
+	logger.Success("upgraded %d addon(s)", len(upgrades))
+	return nil
+}
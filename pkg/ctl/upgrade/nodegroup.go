@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/amazon-ec2-instance-selector/v2/pkg/selector"
 
+	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
@@ -30,15 +31,35 @@ func upgradeNodeGroupCmd(cmd *cmdutils.Cmd) {
 
 	cmd.FlagSetGroup.InFlagSet("Nodegroup", func(fs *pflag.FlagSet) {
 		fs.StringVar(&options.NodegroupName, "name", "", "Nodegroup name")
+		cmdutils.AddNodeGroupSelectorFlag(fs, &cmd.Selector)
 		fs.StringVar(&options.LaunchTemplateVersion, "launch-template-version", "", "Launch template version")
 		fs.StringVar(&options.KubernetesVersion, "kubernetes-version", "", "Kubernetes version")
 		fs.BoolVar(&options.ForceUpgrade, "force-upgrade", false, "Force the update if the existing node group's pods are unable to be drained due to a pod disruption budget issue")
+		fs.DurationVar(&options.PodEvictionGrace, "pod-eviction-grace", 0, "with --force-upgrade, evict pods respecting pod disruption budgets for this long before force-evicting the pods still blocking the upgrade")
 		fs.StringVar(&options.ReleaseVersion, "release-version", "", "AMI version of the EKS optimized AMI to use")
+		fs.StringVar(&options.AMI, "ami", "", "custom AMI to upgrade a nodegroup that uses a custom AMI to, creating a new launch template version")
+		fs.BoolVar(&options.RollbackAMI, "rollback-ami", false, "upgrade a nodegroup that uses a custom AMI back to the AMI it used before the last upgrade with --ami")
 		fs.BoolVar(&options.Wait, "wait", true, "nodegroup upgrade to complete")
+
+		maxUnavailable := fs.Int("max-unavailable", 0, "maximum number of nodes unavailable at once during the upgrade")
+		maxUnavailablePercentage := fs.Int("max-unavailable-percentage", 0, "maximum percentage of nodes unavailable at once during the upgrade")
+
+		cmdutils.AddPreRun(cmd.CobraCommand, func(cobraCmd *cobra.Command, args []string) {
+			if f := cobraCmd.Flag("max-unavailable"); f.Changed {
+				options.UpdateConfig = &api.NodeGroupUpdateConfig{MaxUnavailable: maxUnavailable}
+			}
+			if f := cobraCmd.Flag("max-unavailable-percentage"); f.Changed {
+				if options.UpdateConfig == nil {
+					options.UpdateConfig = &api.NodeGroupUpdateConfig{}
+				}
+				options.UpdateConfig.MaxUnavailablePercentage = maxUnavailablePercentage
+			}
+		})
 	})
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		// found with experimentation
 		cmdutils.AddTimeoutFlagWithValue(fs, &cmd.ProviderConfig.WaitTimeout, upgradeNodegroupTimeout)
@@ -49,20 +70,24 @@ func upgradeNodeGroupCmd(cmd *cmdutils.Cmd) {
 }
 
 func upgradeNodeGroup(cmd *cmdutils.Cmd, options nodegroup.UpgradeOptions) error {
-	cfg := cmd.ClusterConfig
-	if cfg.Metadata.Name == "" {
-		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+	if err := cmdutils.NewUpgradeNodeGroupLoader(cmd).Load(); err != nil {
+		return err
 	}
+	cfg := cmd.ClusterConfig
 
 	if options.NodegroupName != "" && cmd.NameArg != "" {
 		return cmdutils.ErrFlagAndArg("--name", options.NodegroupName, cmd.NameArg)
 	}
 
+	if err := cmdutils.ValidateNodeGroupSelector(cmd, options.NodegroupName); err != nil {
+		return err
+	}
+
 	if cmd.NameArg != "" {
 		options.NodegroupName = cmd.NameArg
 	}
 
-	if options.NodegroupName == "" {
+	if options.NodegroupName == "" && cmd.Selector == "" {
 		return cmdutils.ErrMustBeSet("name")
 	}
 
@@ -85,5 +110,47 @@ func upgradeNodeGroup(cmd *cmdutils.Cmd, options nodegroup.UpgradeOptions) error
 	if err != nil {
 		return err
 	}
-	return nodegroup.New(cfg, ctl, clientSet, instanceSelector).Upgrade(ctx, options)
+	manager := nodegroup.New(cfg, ctl, clientSet, instanceSelector)
+	if cmd.Selector == "" {
+		options.UpdateConfig = nodeGroupUpdateConfig(cmd, options, options.NodegroupName)
+		return upgradeAndRecord(ctx, manager, options)
+	}
+
+	names, err := cmdutils.SelectNodeGroupsFromNodes(ctx, clientSet, cmd.Selector)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		options.NodegroupName = name
+		options.UpdateConfig = nodeGroupUpdateConfig(cmd, options, name)
+		if err := upgradeAndRecord(ctx, manager, options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upgradeAndRecord upgrades a nodegroup and records the upgrade in its history, shown by `eksctl get nodegroup --history`
+func upgradeAndRecord(ctx context.Context, manager *nodegroup.Manager, options nodegroup.UpgradeOptions) error {
+	if err := manager.Upgrade(ctx, options); err != nil {
+		return err
+	}
+	if err := manager.RecordUpgrade(ctx, options, cmdutils.CommandLine()); err != nil {
+		logger.Warning("failed to record the upgrade in the history of nodegroup %q: %v", options.NodegroupName, err)
+	}
+	return nil
+}
+
+// nodeGroupUpdateConfig returns the update config to apply to the named nodegroup, taken from its
+// entry in the config file when one is used, or from the flags otherwise
+func nodeGroupUpdateConfig(cmd *cmdutils.Cmd, options nodegroup.UpgradeOptions, name string) *api.NodeGroupUpdateConfig {
+	if cmd.ClusterConfigFile == "" {
+		return options.UpdateConfig
+	}
+	for _, ng := range cmd.ClusterConfig.ManagedNodeGroups {
+		if ng.Name == name {
+			return ng.UpdateConfig
+		}
+	}
+	return nil
 }
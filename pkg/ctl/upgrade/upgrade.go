@@ -11,6 +11,7 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, upgradeCluster)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, upgradeNodeGroupCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, upgradeAddonCmd)
 
 	return verbCmd
 }
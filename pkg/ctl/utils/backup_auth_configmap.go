@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func backupAuthConfigMapCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("backup-auth-configmap", "Back up the aws-auth ConfigMap and access entries of a cluster",
+		"Writes the aws-auth ConfigMap and access entries to a file, which can be restored with `eksctl utils restore-auth-configmap`; use --output-file=- to write to stdout, e.g. to upload the backup to S3 with `aws s3 cp - s3://<bucket>/<key>`")
+
+	var outputFile string
+	cmd.FlagSetGroup.InFlagSet("Backup", func(fs *pflag.FlagSet) {
+		fs.StringVar(&outputFile, "output-file", "", "path of the backup file, or - for stdout (defaults to <cluster>-auth-backup-<timestamp>.yaml)")
+	})
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doBackupAuthConfigMap(cmd, outputFile)
+	}
+}
+
+func doBackupAuthConfigMap(cmd *cmdutils.Cmd, outputFile string) error {
+	cfg := cmd.ClusterConfig
+	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	backupManager := &accessentryactions.AuthBackupManager{
+		ClusterName: cfg.Metadata.Name,
+		AuthMode:    ctl.GetClusterState().AccessConfig.AuthenticationMode,
+		ClientSet:   clientSet,
+		Getter:      accessentryactions.NewGetter(cfg.Metadata.Name, ctl.AWSProvider.EKS()),
+	}
+	backup, err := backupManager.BackupAuth(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := accessentryactions.MarshalAuthBackup(backup)
+	if err != nil {
+		return err
+	}
+
+	if outputFile == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if outputFile == "" {
+		outputFile = fmt.Sprintf("%s-auth-backup-%s.yaml", cfg.Metadata.Name, backup.CreatedAt.Format("20060102T150405Z"))
+	}
+	if err := os.WriteFile(outputFile, data, 0600); err != nil {
+		return fmt.Errorf("writing auth backup: %w", err)
+	}
+	logger.Success("saved aws-auth ConfigMap and %d access entries of cluster %q to %q", len(backup.AccessEntries), cfg.Metadata.Name, outputFile)
+	return nil
+}
@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/clusterautoscaler"
+	"github.com/weaveworks/eksctl/pkg/actions/irsa"
+	"github.com/weaveworks/eksctl/pkg/addons"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func installClusterAutoscalerCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("install-cluster-autoscaler", "Install Cluster Autoscaler in a cluster",
+		dedent.Dedent(`Creates the IAM role of Cluster Autoscaler for its service account, adds the missing auto-discovery
+			tags to the Auto Scaling groups of the nodegroups created by eksctl, and installs the Cluster Autoscaler
+			release matching the Kubernetes minor version of the cluster.
+			Running it again updates Cluster Autoscaler, e.g. after upgrading the cluster.
+		`),
+	)
+
+	var imageTag string
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doInstallClusterAutoscaler(cmd, imageTag)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVar(&imageTag, "version", "", "version of Cluster Autoscaler, e.g. v1.30.2 (defaults to the first release for the Kubernetes minor version of the cluster)")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doInstallClusterAutoscaler(cmd *cmdutils.Cmd, imageTag string) error {
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	helmInstaller, err := cmdutils.NewHelmInstaller(ctl, cfg, clusterautoscaler.Namespace)
+	if err != nil {
+		return err
+	}
+	stackManager := ctl.NewStackManager(cfg)
+
+	installer := &clusterautoscaler.Installer{
+		ClusterConfig:     cfg,
+		KubernetesVersion: ctl.ControlPlaneVersion(),
+		StackManager:      stackManager,
+		ASG:               ctl.AWSProvider.ASG(),
+		IRSA:              addons.NewIRSAHelper(oidc, stackManager, irsa.New(cfg.Metadata.Name, stackManager, oidc, clientSet), cfg.Metadata.Name),
+		HelmInstaller:     helmInstaller,
+		ImageTag:          imageTag,
+	}
+	if err := installer.Install(ctx); err != nil {
+		return err
+	}
+	logger.Success("installed Cluster Autoscaler in cluster %q", cfg.Metadata.Name)
+	return nil
+}
+
+func auditClusterAutoscalerTagsCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("audit-cluster-autoscaler-tags", "Audit the Cluster Autoscaler discovery tags of the nodegroups of a cluster",
+		"Lists the Auto Scaling groups of the nodegroups created by eksctl along with the Cluster Autoscaler auto-discovery tags they lack. "+
+			"Missing tags are added by 'eksctl utils install-cluster-autoscaler'.",
+	)
+
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doAuditClusterAutoscalerTags(cmd)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doAuditClusterAutoscalerTags(cmd *cmdutils.Cmd) error {
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	installer := &clusterautoscaler.Installer{
+		ClusterConfig: cfg,
+		StackManager:  ctl.NewStackManager(cfg),
+		ASG:           ctl.AWSProvider.ASG(),
+	}
+	audit, err := installer.AuditTags(ctx)
+	if err != nil {
+		return err
+	}
+	if len(audit) == 0 {
+		logger.Info("no nodegroups created by eksctl found in cluster %q", cfg.Metadata.Name)
+		return nil
+	}
+
+	printer := printers.NewTablePrinter().(*printers.TablePrinter)
+	printer.AddColumn("NODEGROUP", func(t clusterautoscaler.NodeGroupTags) string {
+		return t.NodeGroup
+	})
+	printer.AddColumn("AUTOSCALING GROUP", func(t clusterautoscaler.NodeGroupTags) string {
+		return t.AutoScalingGroup
+	})
+	printer.AddColumn("MISSING TAGS", func(t clusterautoscaler.NodeGroupTags) string {
+		if len(t.MissingTags) == 0 {
+			return "-"
+		}
+		return strings.Join(t.MissingTags, ",")
+	})
+	return printer.PrintObjWithKind("nodegroups", audit, cmd.CobraCommand.OutOrStdout())
+}
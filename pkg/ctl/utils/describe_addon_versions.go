@@ -2,7 +2,10 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/kris-nova/logger"
 
@@ -14,6 +17,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/actions/addon"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
 func describeAddonVersionsCmd(cmd *cmdutils.Cmd) {
@@ -26,6 +30,8 @@ func describeAddonVersionsCmd(cmd *cmdutils.Cmd) {
 
 	var addonName, k8sVersion string
 	var types, owners, publishers []string
+	var output printers.Type
+	var showPodIdentity bool
 	cmd.ClusterConfig.Addons = []*api.Addon{{}}
 	cmd.FlagSetGroup.InFlagSet("Addon", func(fs *pflag.FlagSet) {
 		fs.StringVar(&addonName, "name", "", "Addon name")
@@ -40,16 +46,25 @@ func describeAddonVersionsCmd(cmd *cmdutils.Cmd) {
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+		fs.StringVarP(&output, "output", "o", printers.JSONType, "specifies the output format (valid option: json, table); "+
+			"table marks default versions and versions not available in the next Kubernetes version and, with --cluster and --name, shows the upgrade path from the installed version")
+		fs.BoolVar(&showPodIdentity, "show-pod-identity", false, "fetch the configuration of each version to show whether it supports EKS Pod Identity, requires --name and --output table")
 	})
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return describeAddonVersions(cmd, addonName, k8sVersion, cmd.ClusterConfig.Metadata.Name, types, owners, publishers)
+		if output != printers.JSONType && output != printers.TableType {
+			return fmt.Errorf("unsupported output format %q, valid options: json, table", output)
+		}
+		if showPodIdentity && (addonName == "" || output != printers.TableType) {
+			return errors.New("--show-pod-identity requires --name and --output table")
+		}
+		return describeAddonVersions(cmd, addonName, k8sVersion, cmd.ClusterConfig.Metadata.Name, types, owners, publishers, output, showPodIdentity)
 	}
 }
 
-func describeAddonVersions(cmd *cmdutils.Cmd, addonName, k8sVersion, clusterName string, types, owners, publishers []string) error {
+func describeAddonVersions(cmd *cmdutils.Cmd, addonName, k8sVersion, clusterName string, types, owners, publishers []string, output printers.Type, showPodIdentity bool) error {
 	clusterProvider, err := cmd.NewCtl()
 	if err != nil {
 		return err
@@ -84,6 +99,10 @@ func describeAddonVersions(cmd *cmdutils.Cmd, addonName, k8sVersion, clusterName
 		return err
 	}
 
+	if output == printers.TableType {
+		return printAddonVersionSummaries(ctx, cmd, addonManager, &api.Addon{Name: addonName, Types: types, Owners: owners, Publishers: publishers}, clusterName, showPodIdentity)
+	}
+
 	var summary string
 	switch addonName {
 	case "":
@@ -102,3 +121,66 @@ func describeAddonVersions(cmd *cmdutils.Cmd, addonName, k8sVersion, clusterName
 
 	return nil
 }
+
+func printAddonVersionSummaries(ctx context.Context, cmd *cmdutils.Cmd, addonManager *addon.Manager, a *api.Addon, clusterName string, showPodIdentity bool) error {
+	summaries, err := addonManager.SummarizeVersions(ctx, a, showPodIdentity)
+	if err != nil {
+		return err
+	}
+
+	printer := printers.NewTablePrinter().(*printers.TablePrinter)
+	addAddonVersionSummaryColumns(printer, showPodIdentity)
+	if err := printer.PrintObjWithKind("addon versions", summaries, cmd.CobraCommand.OutOrStdout()); err != nil {
+		return err
+	}
+
+	if a.Name == "" || clusterName == "" {
+		return nil
+	}
+	installed, err := addonManager.Get(ctx, a)
+	if err != nil {
+		logger.Debug("not computing upgrade path: %v", err)
+		return nil
+	}
+	var versions []string
+	for _, s := range summaries {
+		versions = append(versions, s.Version)
+	}
+	path, err := addon.UpgradePath(installed.Version, versions)
+	if err != nil {
+		return err
+	}
+	if len(path) == 0 {
+		logger.Info("addon %q is at the latest version %s", a.Name, installed.Version)
+		return nil
+	}
+	logger.Info("upgrade path for addon %q from installed version %s: %s", a.Name, installed.Version, strings.Join(path, " -> "))
+	return nil
+}
+
+func addAddonVersionSummaryColumns(printer *printers.TablePrinter, showPodIdentity bool) {
+	printer.AddColumn("NAME", func(s addon.VersionSummary) string {
+		return s.AddonName
+	})
+	printer.AddColumn("VERSION", func(s addon.VersionSummary) string {
+		return s.Version
+	})
+	printer.AddColumn("DEFAULT", func(s addon.VersionSummary) string {
+		return strconv.FormatBool(s.Default)
+	})
+	printer.AddColumn("NOT AVAILABLE IN NEXT K8S VERSION", func(s addon.VersionSummary) string {
+		return strconv.FormatBool(s.UnavailableInNextKubernetesVersion)
+	})
+	printer.AddColumn("REQUIRES IAM PERMISSIONS", func(s addon.VersionSummary) string {
+		return strconv.FormatBool(s.RequiresIAMPermissions)
+	})
+	if !showPodIdentity {
+		return
+	}
+	printer.AddColumn("POD IDENTITY", func(s addon.VersionSummary) string {
+		if s.SupportsPodIdentity == nil {
+			return "-"
+		}
+		return strconv.FormatBool(*s.SupportsPodIdentity)
+	})
+}
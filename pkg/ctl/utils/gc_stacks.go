@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/cluster"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func gcStacksCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+
+	cmd.SetDescription("gc-stacks", "Delete the CloudFormation stacks left behind by deleted clusters and nodegroups",
+		dedent.Dedent(`Finds the CloudFormation stacks created by eksctl in a region whose cluster no longer exists, e.g.
+			after a failed cluster creation, or whose nodegroup was deleted without eksctl, and deletes them.
+			Stacks whose status is in progress are left alone, and stacks with termination protection enabled
+			are listed but not deleted.
+		`),
+	)
+
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doGCStacks(cmd)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmd.Wait = false
+		cmdutils.AddWaitFlag(fs, &cmd.Wait, "deletion of all stacks")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doGCStacks(cmd *cmdutils.Cmd) error {
+	ctl, err := cmd.NewCtl()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	orphans, err := cluster.FindOrphanedStacks(ctx, ctl.AWSProvider)
+	if err != nil {
+		return err
+	}
+	if len(orphans) == 0 {
+		logger.Info("no orphaned stacks in region %q", ctl.AWSProvider.Region())
+		return nil
+	}
+	for _, o := range orphans {
+		cmdutils.LogIntendedAction(cmd.Plan, "delete %s", cluster.DescribeOrphanedStack(o))
+	}
+	if cmd.Plan {
+		cmdutils.LogPlanModeWarning(true)
+		return nil
+	}
+	if err := cluster.DeleteOrphanedStacks(ctx, ctl.AWSProvider, orphans, cmd.Wait); err != nil {
+		return err
+	}
+	logger.Success("deleted the orphaned stacks in region %q", ctl.AWSProvider.Region())
+	return nil
+}
@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/graviton"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func gravitonAdvisorCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("graviton-advisor", "Recommend Graviton instance types for the nodegroups of a cluster",
+		dedent.Dedent(`Inspects the images of the pods running on each x86 nodegroup for arm64 manifests, and suggests the
+			equivalent Graviton instance type with its price delta. Images are read from their registry with the
+			credentials of the Docker configuration; images that cannot be inspected are reported as unknown.
+			With --output-patch, writes a config file creating a Graviton nodegroup of a single node next to each
+			nodegroup whose images all support arm64, to be created with 'eksctl create nodegroup --config-file'.
+		`),
+	)
+
+	var outputPatch string
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doGravitonAdvisor(cmd, outputPatch)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVar(&outputPatch, "output-patch", "", "path of a config file creating Graviton canary nodegroups, or - for stdout")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doGravitonAdvisor(cmd *cmdutils.Cmd, outputPatch string) error {
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	advisor := &graviton.Advisor{
+		ClusterName: cfg.Metadata.Name,
+		Region:      ctl.AWSProvider.Region(),
+		ClientSet:   clientSet,
+		EKS:         ctl.AWSProvider.EKS(),
+		EC2:         ctl.AWSProvider.EC2(),
+		Pricing:     graviton.NewPricingAPI(ctl.AWSProvider.AWSConfig()),
+		Images:      graviton.RegistryInspector{},
+	}
+	recommendations, err := advisor.Advise(ctx)
+	if err != nil {
+		return err
+	}
+	if len(recommendations) == 0 {
+		logger.Info("no nodegroups of cluster %q run on x86 instances", cfg.Metadata.Name)
+		return nil
+	}
+
+	printer := printers.NewTablePrinter().(*printers.TablePrinter)
+	addGravitonRecommendationColumns(printer)
+	if err := printer.PrintObjWithKind("recommendations", recommendations, cmd.CobraCommand.OutOrStdout()); err != nil {
+		return err
+	}
+	for _, r := range recommendations {
+		if len(r.IncompatibleImages) > 0 {
+			logger.Warning("nodegroup %q runs images without an arm64 variant: %s", r.NodeGroup, strings.Join(r.IncompatibleImages, ", "))
+		}
+		if len(r.UnknownImages) > 0 {
+			logger.Warning("nodegroup %q runs images that could not be inspected: %s", r.NodeGroup, strings.Join(r.UnknownImages, ", "))
+		}
+	}
+
+	if outputPatch == "" {
+		return nil
+	}
+	patch := graviton.CanaryConfig(cfg.Metadata, recommendations)
+	if len(patch.NodeGroups) == 0 && len(patch.ManagedNodeGroups) == 0 {
+		logger.Warning("no nodegroups are ready for Graviton, not writing %q", outputPatch)
+		return nil
+	}
+	var data bytes.Buffer
+	if err := printers.NewYAMLPrinter().PrintObj(patch, &data); err != nil {
+		return err
+	}
+	if outputPatch == "-" {
+		_, err := os.Stdout.Write(data.Bytes())
+		return err
+	}
+	if err := os.WriteFile(outputPatch, data.Bytes(), 0600); err != nil {
+		return fmt.Errorf("writing Graviton canary nodegroups: %w", err)
+	}
+	logger.Success("saved %d Graviton canary nodegroup(s) to %q", len(patch.NodeGroups)+len(patch.ManagedNodeGroups), outputPatch)
+	return nil
+}
+
+func addGravitonRecommendationColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("NODEGROUP", func(r graviton.Recommendation) string {
+		return r.NodeGroup
+	})
+	printer.AddColumn("INSTANCE TYPE", func(r graviton.Recommendation) string {
+		return r.InstanceType
+	})
+	printer.AddColumn("GRAVITON", func(r graviton.Recommendation) string {
+		if r.GravitonInstanceType == "" {
+			return "-"
+		}
+		return r.GravitonInstanceType
+	})
+	printer.AddColumn("PRICE DELTA", func(r graviton.Recommendation) string {
+		delta, ok := r.PriceDelta()
+		if !ok {
+			return "-"
+		}
+		return fmt.Sprintf("%+.1f%%", delta)
+	})
+	printer.AddColumn("STATUS", func(r graviton.Recommendation) string {
+		switch {
+		case r.GravitonInstanceType == "":
+			return "no equivalent"
+		case len(r.IncompatibleImages) > 0:
+			return fmt.Sprintf("%d incompatible image(s)", len(r.IncompatibleImages))
+		case len(r.UnknownImages) > 0:
+			return fmt.Sprintf("%d unknown image(s)", len(r.UnknownImages))
+		default:
+			return "ready"
+		}
+	})
+}
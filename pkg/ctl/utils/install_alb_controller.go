@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/albcontroller"
+	"github.com/weaveworks/eksctl/pkg/actions/irsa"
+	"github.com/weaveworks/eksctl/pkg/addons"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func installALBControllerCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("install-alb-controller", "Install the AWS Load Balancer Controller in a cluster",
+		dedent.Dedent(`Creates the IAM role of the AWS Load Balancer Controller for its service account, tags the subnets
+			of the cluster for the discovery of internet-facing and internal load balancers, and installs the
+			controller chart at a version compatible with the Kubernetes version of the cluster.
+			Running it again updates the controller.
+		`),
+	)
+
+	var chartVersion string
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doInstallALBController(cmd, chartVersion)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVar(&chartVersion, "version", "", "version of the aws-load-balancer-controller chart (defaults to the version compatible with the cluster)")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doInstallALBController(cmd *cmdutils.Cmd, chartVersion string) error {
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	stackManager := ctl.NewStackManager(cfg)
+
+	helmInstaller, err := cmdutils.NewHelmInstaller(ctl, cfg, albcontroller.Namespace)
+	if err != nil {
+		return err
+	}
+
+	installer := &albcontroller.Installer{
+		ClusterConfig: cfg,
+		Cluster:       ctl.GetClusterState(),
+		EC2:           ctl.AWSProvider.EC2(),
+		IRSA:          addons.NewIRSAHelper(oidc, stackManager, irsa.New(cfg.Metadata.Name, stackManager, oidc, clientSet), cfg.Metadata.Name),
+		HelmInstaller: helmInstaller,
+		ChartVersion:  chartVersion,
+	}
+	if err := installer.Install(ctx); err != nil {
+		return err
+	}
+	logger.Success("installed the AWS Load Balancer Controller in cluster %q", cfg.Metadata.Name)
+	return nil
+}
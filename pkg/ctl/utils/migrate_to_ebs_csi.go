@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/ebs"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func migrateToEBSCSICmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("migrate-to-ebs-csi", "Install the EBS CSI driver and a gp3 StorageClass",
+		dedent.Dedent(`Installs the aws-ebs-csi-driver addon with an IAM role for its service account, creates a gp3
+			StorageClass, optionally making it the default StorageClass, and lists the PersistentVolumes still
+			provisioned by the in-tree kubernetes.io/aws-ebs provisioner.
+		`),
+	)
+
+	var (
+		storageClassName    string
+		defaultStorageClass bool
+	)
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doMigrateToEBSCSI(cmd, storageClassName, defaultStorageClass)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVar(&storageClassName, "storage-class", ebs.DefaultStorageClassName, "name of the gp3 StorageClass")
+		fs.BoolVar(&defaultStorageClass, "default-storage-class", false, "make the gp3 StorageClass the default StorageClass of the cluster")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doMigrateToEBSCSI(cmd *cmdutils.Cmd, storageClassName string, defaultStorageClass bool) error {
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	oidcProviderExists, err := oidc.CheckProviderExists(ctx)
+	if err != nil {
+		return err
+	}
+	if !oidcProviderExists {
+		return fmt.Errorf("no IAM OIDC provider associated with cluster, try 'eksctl utils associate-iam-oidc-provider --region=%s --cluster=%s'", cfg.Metadata.Region, cfg.Metadata.Name)
+	}
+
+	// the addon version is resolved for the Kubernetes version of the cluster
+	cfg.Metadata.Version = ctl.ControlPlaneVersion()
+	addonManager, err := addon.New(cfg, ctl.AWSProvider.EKS(), ctl.NewStackManager(cfg), true, oidc, func() (kubernetes.Interface, error) {
+		return clientSet, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	migrator := &ebs.Migrator{
+		Addons:              addonManager,
+		ClientSet:           clientSet,
+		StorageClassName:    storageClassName,
+		DefaultStorageClass: defaultStorageClass,
+		WaitTimeout:         cmd.ProviderConfig.WaitTimeout,
+	}
+	volumes, err := migrator.Migrate(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Success("installed the EBS CSI driver in cluster %q, volumes are provisioned with StorageClass %q", cfg.Metadata.Name, storageClassName)
+	if len(volumes) == 0 {
+		logger.Info("no PersistentVolumes are provisioned by the in-tree EBS provisioner")
+		return nil
+	}
+
+	logger.Warning("%d PersistentVolume(s) are provisioned by the in-tree EBS provisioner, move their data to volumes of StorageClass %q to manage them with the EBS CSI driver", len(volumes), storageClassName)
+	printer := printers.NewTablePrinter().(*printers.TablePrinter)
+	printer.AddColumn("PERSISTENTVOLUME", func(v ebs.InTreeVolume) string {
+		return v.Name
+	})
+	printer.AddColumn("CLAIM", func(v ebs.InTreeVolume) string {
+		return v.Claim
+	})
+	printer.AddColumn("STORAGECLASS", func(v ebs.InTreeVolume) string {
+		return v.StorageClass
+	})
+	printer.AddColumn("VOLUME ID", func(v ebs.InTreeVolume) string {
+		return v.VolumeID
+	})
+	printer.AddColumn("CAPACITY", func(v ebs.InTreeVolume) string {
+		return v.Capacity
+	})
+	return printer.PrintObjWithKind("persistentvolumes", volumes, cmd.CobraCommand.OutOrStdout())
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/utils/exitcode"
+)
+
+func reconcileAuthCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("reconcile-auth", "Converge the aws-auth ConfigMap and the access entries of a cluster",
+		dedent.Dedent(`Finds the principals mapped both in the aws-auth ConfigMap and by an access entry with differing
+			permissions, i.e. Kubernetes groups, username, cluster admin access or node access, in a cluster whose
+			authentication mode is API_AND_CONFIG_MAP, and updates one of them to match the other one, as chosen
+			with --source-of-truth.
+		`),
+	)
+
+	var sourceOfTruth string
+	cmd.FlagSetGroup.InFlagSet("Reconcile auth", func(fs *pflag.FlagSet) {
+		fs.StringVar(&sourceOfTruth, "source-of-truth", "", fmt.Sprintf("permissions to converge to, either %s, which updates the aws-auth ConfigMap, or %s, which updates the access entries",
+			accessentryactions.SourceOfTruthAccessEntries, accessentryactions.SourceOfTruthAWSAuth))
+	})
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+		cmdutils.AddApproveFlag(fs, cmd)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doReconcileAuth(cmd, accessentryactions.SourceOfTruth(sourceOfTruth))
+	}
+}
+
+func doReconcileAuth(cmd *cmdutils.Cmd, sourceOfTruth accessentryactions.SourceOfTruth) error {
+	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+		return err
+	}
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+	}
+	switch sourceOfTruth {
+	case accessentryactions.SourceOfTruthAccessEntries, accessentryactions.SourceOfTruthAWSAuth:
+	case "":
+		return exitcode.WithCode(cmdutils.ErrMustBeSet("--source-of-truth"), exitcode.ValidationError)
+	default:
+		return exitcode.WithCode(fmt.Errorf("invalid --source-of-truth %q, must be either %s or %s", sourceOfTruth,
+			accessentryactions.SourceOfTruthAccessEntries, accessentryactions.SourceOfTruthAWSAuth), exitcode.ValidationError)
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	if authenticationMode := ctl.GetClusterState().AccessConfig.AuthenticationMode; authenticationMode != ekstypes.AuthenticationModeApiAndConfigMap {
+		logger.Info("cluster %q uses authentication mode %s, only one authentication system is in use, nothing to reconcile", cfg.Metadata.Name, authenticationMode)
+		return nil
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	reconciler := accessentryactions.NewAuthReconciler(cfg.Metadata.Name, ctl.AWSProvider.EKS(), clientSet,
+		accessentryactions.NewGetter(cfg.Metadata.Name, ctl.AWSProvider.EKS()))
+	conflicts, err := reconciler.FindConflicts(ctx)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		logger.Success("the aws-auth ConfigMap and the access entries of cluster %q grant the same permissions", cfg.Metadata.Name)
+		return nil
+	}
+
+	target := "access entry"
+	if sourceOfTruth == accessentryactions.SourceOfTruthAccessEntries {
+		target = "aws-auth ConfigMap mapping"
+	}
+	for _, c := range conflicts {
+		cmdutils.LogIntendedAction(cmd.Plan, "update %s of %s", target, c)
+	}
+	if cmd.Plan {
+		cmdutils.LogPlanModeWarning(true)
+		return nil
+	}
+	if err := reconciler.Reconcile(ctx, conflicts, sourceOfTruth); err != nil {
+		return err
+	}
+	logger.Success("reconciled the permissions of %d principal(s) in cluster %q", len(conflicts), cfg.Metadata.Name)
+	return nil
+}
@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/quota"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func requestQuotaIncreaseCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("request-quota-increase", "Request the increase of the service quotas a cluster needs",
+		dedent.Dedent(`Computes the on-demand vCPUs, VPCs and Elastic IP addresses the cluster of the config file needs
+			at the maximum size of its nodegroups, and compares them with the current usage and quotas of the region.
+			With --approve, files a Service Quotas increase request for each exceeded quota, reusing the pending
+			requests; with --watch, waits until the requests are approved or rejected.
+		`),
+	)
+
+	var watch bool
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doRequestQuotaIncrease(cmd, watch)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddApproveFlag(fs, cmd)
+		fs.BoolVarP(&watch, "watch", "w", false, "wait until the increase requests are approved or rejected, requires --approve")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doRequestQuotaIncrease(cmd *cmdutils.Cmd, watch bool) error {
+	if cmd.ClusterConfigFile == "" {
+		return cmdutils.ErrMustBeSet("--config-file/-f <file>")
+	}
+	if watch && cmd.Plan {
+		return errors.New("--watch requires --approve")
+	}
+	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+		return err
+	}
+	cfg := cmd.ClusterConfig
+
+	ctx := context.Background()
+	ctl, err := cmd.NewCtl()
+	if err != nil {
+		return err
+	}
+
+	checker := &quota.Checker{
+		EC2:           ctl.AWSProvider.EC2(),
+		ServiceQuotas: quota.NewServiceQuotasAPI(ctl.AWSProvider.AWSConfig()),
+	}
+	requirements, err := checker.Check(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if err := printQuotaRequirements(cmd, requirements); err != nil {
+		return err
+	}
+
+	var exceeded int
+	for _, r := range requirements {
+		if r.Exceeded() {
+			exceeded++
+		}
+	}
+	if exceeded == 0 {
+		logger.Success("the quotas of region %q are enough for cluster %q", cfg.Metadata.Region, cfg.Metadata.Name)
+		return nil
+	}
+	if cmd.Plan {
+		logger.Warning("%d quota(s) are exceeded, rerun with --approve to request their increase", exceeded)
+		return nil
+	}
+
+	requests, err := checker.RequestIncreases(ctx, requirements)
+	if err != nil {
+		return err
+	}
+	if !watch {
+		logger.Info("rerun with --watch to wait until the increase requests are approved or rejected")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+	requests, err = checker.Watch(ctx, requests)
+	if err != nil {
+		return err
+	}
+	var rejected []string
+	for _, r := range requests {
+		if !r.Approved() {
+			rejected = append(rejected, fmt.Sprintf("%q (%s)", r.Name, r.Status))
+		}
+	}
+	if len(rejected) > 0 {
+		return fmt.Errorf("quota increase requests were not approved: %v", rejected)
+	}
+	logger.Success("all quota increase requests were approved")
+	return nil
+}
+
+func printQuotaRequirements(cmd *cmdutils.Cmd, requirements []quota.Requirement) error {
+	printer := printers.NewTablePrinter().(*printers.TablePrinter)
+	printer.AddColumn("QUOTA", func(r quota.Requirement) string {
+		return r.Name
+	})
+	printer.AddColumn("USAGE", func(r quota.Requirement) string {
+		return fmt.Sprintf("%v", r.Usage)
+	})
+	printer.AddColumn("REQUIRED", func(r quota.Requirement) string {
+		return fmt.Sprintf("%v", r.Required)
+	})
+	printer.AddColumn("LIMIT", func(r quota.Requirement) string {
+		return fmt.Sprintf("%v", r.Limit)
+	})
+	printer.AddColumn("STATUS", func(r quota.Requirement) string {
+		if r.Exceeded() {
+			return fmt.Sprintf("exceeded, needs %v", r.DesiredValue())
+		}
+		return "ok"
+	})
+	return printer.PrintObjWithKind("quotas", requirements, cmd.CobraCommand.OutOrStdout())
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func restoreAuthConfigMapCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("restore-auth-configmap", "Restore the aws-auth ConfigMap and access entries of a cluster from a backup",
+		"Replaces the aws-auth ConfigMap with the one in a backup written by `eksctl utils backup-auth-configmap` and recreates access entries that no longer exist; use --backup-file=- to read the backup from stdin, e.g. from S3 with `aws s3 cp s3://<bucket>/<key> -`")
+
+	var backupFile string
+	cmd.FlagSetGroup.InFlagSet("Restore", func(fs *pflag.FlagSet) {
+		fs.StringVar(&backupFile, "backup-file", "", "path of the backup file, or - for stdin")
+	})
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddApproveFlag(fs, cmd)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doRestoreAuthConfigMap(cmd, backupFile)
+	}
+}
+
+func doRestoreAuthConfigMap(cmd *cmdutils.Cmd, backupFile string) error {
+	cfg := cmd.ClusterConfig
+	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+		return err
+	}
+	if backupFile == "" {
+		return cmdutils.ErrMustBeSet("--backup-file")
+	}
+
+	backup, err := readAuthBackup(backupFile)
+	if err != nil {
+		return err
+	}
+	if backup.ClusterName != cfg.Metadata.Name {
+		return fmt.Errorf("backup was taken from cluster %q, not %q", backup.ClusterName, cfg.Metadata.Name)
+	}
+
+	cmdutils.LogIntendedAction(cmd.Plan, "restore aws-auth ConfigMap and %d access entries of cluster %q from backup taken at %s",
+		len(backup.AccessEntries), cfg.Metadata.Name, backup.CreatedAt)
+	if cmd.Plan {
+		cmdutils.LogPlanModeWarning(true)
+		return nil
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	backupManager := &accessentryactions.AuthBackupManager{
+		ClusterName: cfg.Metadata.Name,
+		AuthMode:    ctl.GetClusterState().AccessConfig.AuthenticationMode,
+		ClientSet:   clientSet,
+		Getter:      accessentryactions.NewGetter(cfg.Metadata.Name, ctl.AWSProvider.EKS()),
+		Creator: &accessentryactions.Creator{
+			ClusterName:  cfg.Metadata.Name,
+			StackCreator: ctl.NewStackManager(cfg),
+		},
+	}
+	if err := backupManager.RestoreAuth(ctx, backup); err != nil {
+		return err
+	}
+	cmdutils.LogCompletedAction(false, "restored aws-auth ConfigMap and access entries of cluster %q", cfg.Metadata.Name)
+	return nil
+}
+
+func readAuthBackup(backupFile string) (*accessentryactions.AuthBackup, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if backupFile == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(backupFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading auth backup: %w", err)
+	}
+	return accessentryactions.UnmarshalAuthBackup(data)
+}
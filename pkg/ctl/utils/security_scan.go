@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/securityscan"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func securityScanCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("security-scan", "Scan the security posture of a cluster",
+		dedent.Dedent(`Runs built-in checks against a cluster: API server endpoint open to 0.0.0.0/0, secrets not envelope
+			encrypted, IAM identities mapped to system:masters or whole accounts in aws-auth, nodes allowing IMDSv1,
+			nodes with a public IP address, and addons not running their latest version. The report is scored
+			out of 100, weighting each check by its severity; checks that cannot be run are reported as unknown
+			and not scored.
+		`),
+	)
+
+	var output printers.Type
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doSecurityScan(cmd, output)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVarP(&output, "output", "o", printers.TableType, "specifies the output format (valid option: table, json, yaml)")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doSecurityScan(cmd *cmdutils.Cmd, output printers.Type) error {
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+	}
+	if output != printers.TableType {
+		//log warnings and errors to stderr
+		logger.Writer = os.Stderr
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	addonManager, err := addon.New(cfg, ctl.AWSProvider.EKS(), ctl.NewStackManager(cfg), api.IsEnabled(cfg.IAM.WithOIDC), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	scanner := &securityscan.Scanner{
+		ClusterName: cfg.Metadata.Name,
+		Cluster:     ctl.GetClusterState(),
+		ClientSet:   clientSet,
+		EC2:         ctl.AWSProvider.EC2(),
+		Addons:      addonManager,
+	}
+	report := scanner.Scan(ctx)
+
+	if output != printers.TableType {
+		printer, err := printers.NewPrinter(output)
+		if err != nil {
+			return err
+		}
+		return printer.PrintObjWithKind("report", report, cmd.CobraCommand.OutOrStdout())
+	}
+
+	printer := printers.NewTablePrinter().(*printers.TablePrinter)
+	addSecurityFindingColumns(printer)
+	if err := printer.PrintObjWithKind("findings", report.Findings, cmd.CobraCommand.OutOrStdout()); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.CobraCommand.OutOrStdout(), "\nscore: %d/100\n", report.Score)
+	for _, f := range report.Findings {
+		if f.Status == securityscan.StatusUnknown {
+			logger.Warning("unable to run check %q: %s", f.Check, f.Message)
+		}
+	}
+	return nil
+}
+
+func addSecurityFindingColumns(printer *printers.TablePrinter) {
+	printer.AddColumn("CHECK", func(f securityscan.Finding) string {
+		return f.Check
+	})
+	printer.AddColumn("SEVERITY", func(f securityscan.Finding) string {
+		return string(f.Severity)
+	})
+	printer.AddColumn("STATUS", func(f securityscan.Finding) string {
+		return string(f.Status)
+	})
+	printer.AddColumn("RESOURCES", func(f securityscan.Finding) string {
+		if len(f.Resources) == 0 {
+			return "-"
+		}
+		return strings.Join(f.Resources, ",")
+	})
+}
@@ -64,6 +64,7 @@ func doUpdatePublicAccessCIDRs(cmd *cmdutils.Cmd) error {
 		ClusterMeta: cfg.Metadata,
 		Cluster:     ctl.Status.ClusterInfo.Cluster,
 		PlanMode:    cmd.Plan,
+		EC2API:      ctl.AWSProvider.EC2(),
 	}
 	return vpcHelper.UpdateClusterVPCConfig(ctx, cfg.VPC)
 }
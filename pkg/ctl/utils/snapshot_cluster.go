@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	"github.com/weaveworks/eksctl/pkg/actions/snapshot"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func snapshotClusterCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("snapshot-cluster", "Archive a cluster so that an equivalent cluster can be created from it",
+		dedent.Dedent(`Writes the ClusterConfig of a cluster, the templates of its CloudFormation stacks, its aws-auth ConfigMap and
+			access entries, and the versions of its addons to a snapshot file. An equivalent cluster can be created
+			from the snapshot, in another region or account, with 'eksctl create cluster --from-snapshot'.
+			The VPC, KMS keys and IAM roles of the cluster are not part of the snapshot.
+		`),
+	)
+
+	var location string
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doSnapshotCluster(cmd, location)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVar(&location, "to", "", "where to write the snapshot: a file path, s3://<bucket>/<key>, or - for stdout")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doSnapshotCluster(cmd *cmdutils.Cmd, location string) error {
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+	}
+	if location == "" {
+		return cmdutils.ErrMustBeSet("--to")
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	snapshotter := &snapshot.Snapshotter{
+		ClusterName:  cfg.Metadata.Name,
+		Region:       ctl.AWSProvider.Region(),
+		EKS:          ctl.AWSProvider.EKS(),
+		StackManager: ctl.NewStackManager(cfg),
+		NodeGroups:   nodegroup.New(cfg, ctl, clientSet, nil),
+		Auth: &accessentryactions.AuthBackupManager{
+			ClusterName: cfg.Metadata.Name,
+			AuthMode:    ctl.GetClusterState().AccessConfig.AuthenticationMode,
+			ClientSet:   clientSet,
+			Getter:      accessentryactions.NewGetter(cfg.Metadata.Name, ctl.AWSProvider.EKS()),
+		},
+		ClientSet: clientSet,
+	}
+	s, err := snapshotter.Take(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := snapshot.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := snapshot.Write(ctx, ctl.AWSProvider.AWSConfig(), location, data); err != nil {
+		return fmt.Errorf("writing snapshot of cluster %q: %w", cfg.Metadata.Name, err)
+	}
+	if location != "-" {
+		logger.Success("saved snapshot of cluster %q with %d nodegroup(s) and %d addon(s) to %q", cfg.Metadata.Name,
+			len(s.ClusterConfig.NodeGroups)+len(s.ClusterConfig.ManagedNodeGroups), len(s.ClusterConfig.Addons), location)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func syncAccessCIDRsCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("sync-access-cidrs", "Sync public access CIDRs with managed prefix lists",
+		dedent.Dedent(`Resolves the managed prefix lists (pl-xxxx) in the public access CIDRs to the CIDRs of their entries,
+			and updates the public access CIDRs of the cluster if they have changed.
+
+			Run this command periodically to keep the public access CIDRs in sync with centrally maintained prefix lists.
+		`),
+	)
+
+	var publicAccessCIDRs []string
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewSyncAccessCIDRsLoader(cmd, publicAccessCIDRs).Load(); err != nil {
+			return err
+		}
+		return doSyncAccessCIDRs(cmd)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("Public Access CIDRs", func(fs *pflag.FlagSet) {
+		fs.StringSliceVar(&publicAccessCIDRs, "public-access-cidrs", nil, "CIDR blocks and managed prefix list IDs that EKS uses to create a security group on the public endpoint")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doSyncAccessCIDRs(cmd *cmdutils.Cmd) error {
+	cfg := cmd.ClusterConfig
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Info("using region %s", cfg.Metadata.Region)
+
+	if ok, err := ctl.CanUpdate(cfg); !ok {
+		return err
+	}
+	if !cfg.VPC.HasPrefixLists() {
+		logger.Warning("the public access CIDRs do not contain any managed prefix lists")
+	}
+
+	// only public access CIDRs are synced
+	cfg.VPC.ClusterEndpoints = nil
+	cfg.VPC.ControlPlaneSubnetIDs = nil
+	cfg.VPC.ControlPlaneSecurityGroupIDs = nil
+	vpcHelper := &VPCHelper{
+		VPCUpdater:  ctl,
+		ClusterMeta: cfg.Metadata,
+		Cluster:     ctl.Status.ClusterInfo.Cluster,
+		PlanMode:    cmd.Plan,
+		EC2API:      ctl.AWSProvider.EC2(),
+	}
+	return vpcHelper.UpdateClusterVPCConfig(ctx, cfg.VPC)
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/tunnel"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
+)
+
+type tunnelOptions struct {
+	nodeGroupName  string
+	instanceID     string
+	localPort      int
+	kubeconfigPath string
+	setContext     bool
+}
+
+func tunnelCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("tunnel", "Forward a local port to the private API server endpoint of a cluster through SSM",
+		"Starts an SSM port-forwarding session through the bastion created with privateCluster.adminAccess, or an instance of a nodegroup, "+
+			"and points the kubeconfig at the local end of the tunnel until it is closed with Ctrl+C. Requires the session-manager-plugin")
+
+	var options tunnelOptions
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doTunnel(cmd, options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("Tunnel", func(fs *pflag.FlagSet) {
+		fs.StringVar(&options.nodeGroupName, "nodegroup", "", "forward traffic through a running instance of this nodegroup instead of the bastion")
+		fs.StringVar(&options.instanceID, "instance-id", "", "forward traffic through this instance instead of the bastion")
+		fs.IntVar(&options.localPort, "local-port", 8443, "local port to listen on")
+		fs.StringVar(&options.kubeconfigPath, "kubeconfig", kubeconfig.DefaultPath(), "path to write the kubeconfig to while the tunnel is open")
+		fs.BoolVar(&options.setContext, "set-kubeconfig-context", true, "if true then current-context will be set in kubeconfig; if a context is already set then it will be overwritten")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doTunnel(cmd *cmdutils.Cmd, options tunnelOptions) error {
+	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+		return err
+	}
+
+	cfg := cmd.ClusterConfig
+	if options.nodeGroupName != "" && options.instanceID != "" {
+		return fmt.Errorf("--nodegroup and --instance-id %s", cmdutils.IncompatibleFlags)
+	}
+	if options.localPort <= 0 || options.localPort > 65535 {
+		return fmt.Errorf("invalid value %d for --local-port", options.localPort)
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	t := &tunnel.Tunnel{
+		ClusterName:    cfg.Metadata.Name,
+		Region:         ctl.AWSProvider.Region(),
+		Profile:        ctl.AWSProvider.Profile().Name,
+		EC2API:         ctl.AWSProvider.EC2(),
+		SSMAPI:         ctl.AWSProvider.SSM(),
+		StackDescriber: ctl.NewStackManager(cfg),
+	}
+
+	target := options.instanceID
+	if target == "" {
+		if target, err = t.FindTargetInstance(ctx, options.nodeGroupName); err != nil {
+			return err
+		}
+	}
+
+	kubectlConfig := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), "", ctl.AWSProvider.Profile().Name)
+	tunnelConfig := kubectlConfig.DeepCopy()
+	if err := tunnel.UseTunnel(tunnelConfig, options.localPort); err != nil {
+		return err
+	}
+	filename, err := kubeconfig.Write(options.kubeconfigPath, *tunnelConfig, options.setContext)
+	if err != nil {
+		return errors.Wrap(err, "writing kubeconfig")
+	}
+	logger.Info("kubeconfig %q points at the tunnel, it will be restored when the tunnel is closed", filename)
+	defer func() {
+		if _, err := kubeconfig.Write(options.kubeconfigPath, *kubectlConfig, false); err != nil {
+			logger.Warning("failed to restore kubeconfig %q: %v", filename, err)
+			return
+		}
+		logger.Info("restored kubeconfig %q", filename)
+	}()
+
+	// Ctrl+C stops the session-manager-plugin; ignore the signal here so that the kubeconfig is restored
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	return t.Run(ctx, target, cfg.Status.Endpoint, options.localPort)
+}
@@ -105,16 +105,21 @@ func doEnableLogging(cmd *cmdutils.Cmd, logTypesToEnable []string, logTypesToDis
 		cmdutils.LogIntendedAction(cmd.Plan, "update CloudWatch logging for cluster %q in %q (%s & %s)",
 			meta.Name, meta.Region, describeTypesToEnable, describeTypesToDisable,
 		)
-		if period := cfg.CloudWatch.ClusterLogging.LogRetentionInDays; period > 0 {
-			cmdutils.LogIntendedAction(cmd.Plan, "update CloudWatch logging for log retention period set to %d",
-				period,
-			)
-		}
+		logIntendedLogGroupUpdate(cmd, cfg.CloudWatch.ClusterLogging)
 		if !cmd.Plan {
 			if err := ctl.UpdateClusterConfigForLogging(ctx, cfg); err != nil {
 				return err
 			}
 		}
+	} else if cfg.CloudWatch.ClusterLogging.HasLogGroupSettings() {
+		// the log types are unchanged, the settings of the log group may still need updating
+		updateRequired = true
+		logIntendedLogGroupUpdate(cmd, cfg.CloudWatch.ClusterLogging)
+		if !cmd.Plan {
+			if err := ctl.ConfigureClusterLogGroup(ctx, cfg); err != nil {
+				return err
+			}
+		}
 	} else {
 		logger.Success("CloudWatch logging for cluster %q in %q is already up-to-date", meta.Name, meta.Region)
 	}
@@ -124,6 +129,20 @@ func doEnableLogging(cmd *cmdutils.Cmd, logTypesToEnable []string, logTypesToDis
 	return nil
 }
 
+func logIntendedLogGroupUpdate(cmd *cmdutils.Cmd, logging *api.ClusterCloudWatchLogging) {
+	if period := logging.LogRetentionInDays; period > 0 {
+		cmdutils.LogIntendedAction(cmd.Plan, "update CloudWatch logging for log retention period set to %d",
+			period,
+		)
+	}
+	if keyARN := logging.LogGroupKMSKeyARN; keyARN != "" {
+		cmdutils.LogIntendedAction(cmd.Plan, "encrypt CloudWatch logs with KMS key %q", keyARN)
+	}
+	if len(logging.LogGroupTags) > 0 {
+		cmdutils.LogIntendedAction(cmd.Plan, "tag CloudWatch log group with %v", logging.LogGroupTags)
+	}
+}
+
 func validateLoggingFlags(toEnable, toDisable []string) error {
 	// At least enable-types or disable-types should be provided
 	if len(toEnable) == 0 && len(toDisable) == 0 {
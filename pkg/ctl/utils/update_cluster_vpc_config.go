@@ -44,7 +44,7 @@ func updateClusterVPCConfigWithHandler(cmd *cmdutils.Cmd, handler func(cmd *cmdu
 		fs.BoolVar(&options.PublicAccess, "public-access", false, "access for public clients")
 	})
 	cmd.FlagSetGroup.InFlagSet("Public Access CIDRs", func(fs *pflag.FlagSet) {
-		fs.StringSliceVar(&options.PublicAccessCIDRs, "public-access-cidrs", nil, "CIDR blocks that EKS uses to create a security group on the public endpoint")
+		fs.StringSliceVar(&options.PublicAccessCIDRs, "public-access-cidrs", nil, "CIDR blocks and managed prefix list IDs that EKS uses to create a security group on the public endpoint")
 	})
 	cmd.FlagSetGroup.InFlagSet("Control plane subnets and security groups", func(fs *pflag.FlagSet) {
 		fs.StringSliceVar(&options.ControlPlaneSubnetIDs, "control-plane-subnet-ids", nil, "Subnet IDs for the control plane")
@@ -76,6 +76,7 @@ func doUpdateClusterVPCConfig(cmd *cmdutils.Cmd) error {
 		ClusterMeta: cfg.Metadata,
 		Cluster:     ctl.Status.ClusterInfo.Cluster,
 		PlanMode:    cmd.Plan,
+		EC2API:      ctl.AWSProvider.EC2(),
 	}
 
 	return vpcHelper.UpdateClusterVPCConfig(ctx, cfg.VPC)
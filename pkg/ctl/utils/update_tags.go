@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/tags"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func updateTagsCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("update-tags", "Update the tags of the resources of a cluster to match the config file",
+		dedent.Dedent(`Compares metadata.tags and the tags of the nodegroups in the config file with the tags of the EKS cluster,
+			its nodegroups and their autoscaling groups, and the CloudFormation stacks of the cluster, then adds, updates
+			and removes tags so that they match. Nothing is recreated; the CloudFormation stacks keep their templates.
+
+			Tags set by eksctl, Kubernetes or AWS, e.g. alpha.eksctl.io/*, kubernetes.io/* or aws:*, are never removed.
+		`),
+	)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewUtilsUpdateTagsLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return doUpdateTags(cmd)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doUpdateTags(cmd *cmdutils.Cmd) error {
+	cfg := cmd.ClusterConfig
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	updater := tags.New(cfg, ctl.NewStackManager(cfg), ctl.AWSProvider.EKS(), ctl.AWSProvider.ASG(),
+		ctl.AWSProvider.CloudFormation(), ctl.AWSProvider.WaitTimeout())
+	changes, err := updater.Plan(ctx)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		logger.Success("the tags of cluster %q are already up-to-date", cfg.Metadata.Name)
+		return nil
+	}
+	for _, change := range changes {
+		cmdutils.LogIntendedAction(cmd.Plan, "update tags of %s", change)
+	}
+	if cmd.Plan {
+		cmdutils.LogPlanModeWarning(true)
+		return nil
+	}
+	if err := updater.Apply(ctx, changes); err != nil {
+		return err
+	}
+	logger.Success("updated the tags of %d resource(s) of cluster %q", len(changes), cfg.Metadata.Name)
+	return nil
+}
@@ -22,6 +22,8 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, installWindowsVPCController)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateClusterEndpointsCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, publicAccessCIDRsCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, syncAccessCIDRsCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateTagsCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateClusterVPCConfigCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, enableSecretsEncryptionCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, schemaCmd)
@@ -30,6 +32,19 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, describeAddonConfigurationCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, migrateToPodIdentityCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, migrateAccessEntryCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, reconcileAuthCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, gcStacksCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, gravitonAdvisorCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, securityScanCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, installALBControllerCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, installClusterAutoscalerCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, auditClusterAutoscalerTagsCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, migrateToEBSCSICmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, snapshotClusterCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, backupAuthConfigMapCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, restoreAuthConfigMapCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, tunnelCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, requestQuotaIncreaseCmd)
 
 	return verbCmd
 }
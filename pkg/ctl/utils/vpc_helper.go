@@ -14,7 +14,9 @@ import (
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/vpc"
 )
 
 // A VPCConfigUpdater updates a cluster's VPC config.
@@ -32,6 +34,8 @@ type VPCHelper struct {
 	Cluster *ekstypes.Cluster
 	// PlanMode configures the plan mode.
 	PlanMode bool
+	// EC2API resolves managed prefix lists in public access CIDRs.
+	EC2API awsapi.EC2
 }
 
 // UpdateClusterVPCConfig updates the cluster endpoints and public access CIDRs.
@@ -45,7 +49,7 @@ func (v *VPCHelper) UpdateClusterVPCConfig(ctx context.Context, vpc *api.Cluster
 		}
 	}
 	if vpc.PublicAccessCIDRs != nil {
-		if err := v.updatePublicAccessCIDRs(ctx, vpc); err != nil {
+		if err := v.updatePublicAccessCIDRs(ctx, vpc.PublicAccessCIDRs); err != nil {
 			return err
 		}
 	}
@@ -138,8 +142,12 @@ func (v *VPCHelper) updateEndpointAccess(ctx context.Context, desired api.Cluste
 	return nil
 }
 
-func (v *VPCHelper) updatePublicAccessCIDRs(ctx context.Context, vpc *api.ClusterVPC) error {
-	if cidrsEqual(v.Cluster.ResourcesVpcConfig.PublicAccessCidrs, vpc.PublicAccessCIDRs) {
+func (v *VPCHelper) updatePublicAccessCIDRs(ctx context.Context, publicAccessCIDRs []string) error {
+	cidrs, err := vpc.ResolvePublicAccessCIDRs(ctx, v.EC2API, publicAccessCIDRs)
+	if err != nil {
+		return fmt.Errorf("resolving public access CIDRs: %w", err)
+	}
+	if cidrsEqual(v.Cluster.ResourcesVpcConfig.PublicAccessCidrs, cidrs) {
 		logger.Success("public access CIDRs for cluster %q in %q are already up-to-date",
 			v.ClusterMeta.Name, v.ClusterMeta.Region)
 		return nil
@@ -148,21 +156,21 @@ func (v *VPCHelper) updatePublicAccessCIDRs(ctx context.Context, vpc *api.Cluste
 	logger.Info("current public access CIDRs: %v", v.Cluster.ResourcesVpcConfig.PublicAccessCidrs)
 	cmdutils.LogIntendedAction(
 		v.PlanMode, "update public access CIDRs for cluster %q in %q to: %v",
-		v.ClusterMeta.Name, v.ClusterMeta.Region, vpc.PublicAccessCIDRs)
+		v.ClusterMeta.Name, v.ClusterMeta.Region, cidrs)
 
 	if v.PlanMode {
 		return nil
 	}
 
 	if err := v.updateVPCConfig(ctx, &ekstypes.VpcConfigRequest{
-		PublicAccessCidrs: vpc.PublicAccessCIDRs,
+		PublicAccessCidrs: cidrs,
 	}); err != nil {
 		return fmt.Errorf("error updating CIDRs for public access: %w", err)
 	}
 	cmdutils.LogCompletedAction(
 		false,
 		"public access CIDRs for cluster %q in %q have been updated to: %v",
-		v.ClusterMeta.Name, v.ClusterMeta.Region, vpc.PublicAccessCIDRs)
+		v.ClusterMeta.Name, v.ClusterMeta.Region, cidrs)
 	return nil
 }
 
@@ -6,6 +6,8 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	. "github.com/onsi/ginkgo/v2"
@@ -16,13 +18,15 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 
 	"github.com/weaveworks/eksctl/pkg/ctl/utils"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
 )
 
 type vpcHelperEntry struct {
-	clusterVPC *ekstypes.VpcConfigResponse
-	vpc        *api.ClusterVPC
-	outposts   bool
-	planMode   bool
+	clusterVPC  *ekstypes.VpcConfigResponse
+	vpc         *api.ClusterVPC
+	outposts    bool
+	planMode    bool
+	prefixLists map[string][]string
 
 	expectedUpdates []*eks.UpdateClusterConfigInput
 	expectedErr     string
@@ -45,11 +49,22 @@ var _ = DescribeTable("VPCHelper", func(e vpcHelperEntry) {
 			OutpostArns: []string{"arn:aws:outposts:us-west-2:1234:outpost/op-1234"},
 		}
 	}
+	provider := mockprovider.NewMockProvider()
+	for prefixListID, cidrs := range e.prefixLists {
+		var entries []ec2types.PrefixListEntry
+		for _, cidr := range cidrs {
+			entries = append(entries, ec2types.PrefixListEntry{Cidr: aws.String(cidr)})
+		}
+		provider.MockEC2().On("GetManagedPrefixListEntries", mock.Anything, &ec2.GetManagedPrefixListEntriesInput{
+			PrefixListId: aws.String(prefixListID),
+		}, mock.Anything).Return(&ec2.GetManagedPrefixListEntriesOutput{Entries: entries}, nil)
+	}
 	vpcHelper := &utils.VPCHelper{
 		VPCUpdater:  &vpcUpdater,
 		ClusterMeta: clusterMeta,
 		Cluster:     cluster,
 		PlanMode:    e.planMode,
+		EC2API:      provider.EC2(),
 	}
 	err := vpcHelper.UpdateClusterVPCConfig(context.Background(), e.vpc)
 	if e.expectedErr != "" {
@@ -133,6 +148,43 @@ var _ = DescribeTable("VPCHelper", func(e vpcHelperEntry) {
 		},
 	}),
 
+	Entry("cluster public access CIDRs do not match the entries of prefix lists", vpcHelperEntry{
+		clusterVPC: &ekstypes.VpcConfigResponse{
+			EndpointPublicAccess:  true,
+			EndpointPrivateAccess: false,
+			PublicAccessCidrs:     []string{"1.1.1.1/32"},
+		},
+		vpc: &api.ClusterVPC{
+			PublicAccessCIDRs: []string{"pl-11111111", "3.3.3.3/32"},
+		},
+		prefixLists: map[string][]string{
+			"pl-11111111": {"1.1.1.1/32", "2.2.2.2/32"},
+		},
+
+		expectedUpdates: []*eks.UpdateClusterConfigInput{
+			{
+				Name: aws.String("test"),
+				ResourcesVpcConfig: &ekstypes.VpcConfigRequest{
+					PublicAccessCidrs: []string{"1.1.1.1/32", "2.2.2.2/32", "3.3.3.3/32"},
+				},
+			},
+		},
+	}),
+
+	Entry("cluster public access CIDRs match the entries of prefix lists", vpcHelperEntry{
+		clusterVPC: &ekstypes.VpcConfigResponse{
+			EndpointPublicAccess:  true,
+			EndpointPrivateAccess: false,
+			PublicAccessCidrs:     []string{"2.2.2.2/32", "1.1.1.1/32"},
+		},
+		vpc: &api.ClusterVPC{
+			PublicAccessCIDRs: []string{"pl-11111111"},
+		},
+		prefixLists: map[string][]string{
+			"pl-11111111": {"1.1.1.1/32", "2.2.2.2/32"},
+		},
+	}),
+
 	Entry("cluster public access CIDRs match desired config but out of order", vpcHelperEntry{
 		clusterVPC: &ekstypes.VpcConfigResponse{
 			EndpointPublicAccess:  true,
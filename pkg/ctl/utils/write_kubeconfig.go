@@ -66,6 +66,8 @@ func doWriteKubeconfigCmd(cmd *cmdutils.Cmd, outputPath, roleARN string, setCont
 		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
 	}
 
+	cmdutils.ApplyKubeconfigConfig(cmd, &outputPath, &setContext)
+
 	if autoPath {
 		if outputPath != kubeconfig.DefaultPath() {
 			return fmt.Errorf("--kubeconfig and --auto-kubeconfig %s", cmdutils.IncompatibleFlags)
@@ -82,7 +84,11 @@ func doWriteKubeconfigCmd(cmd *cmdutils.Cmd, outputPath, roleARN string, setCont
 		return err
 	}
 
-	kubectlConfig := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), roleARN, ctl.AWSProvider.Profile().Name)
+	username := eks.GetUsername(ctl.Status.IAMRoleARN)
+	kubectlConfig := kubeconfig.NewForKubectl(cfg, username, roleARN, ctl.AWSProvider.Profile().Name)
+	if err := kubeconfig.Customize(kubectlConfig, cfg.Kubeconfig, cfg.Metadata, username); err != nil {
+		return err
+	}
 	filename, err := kubeconfig.Write(outputPath, *kubectlConfig, setContext)
 	if err != nil {
 		return errors.Wrap(err, "writing kubeconfig")
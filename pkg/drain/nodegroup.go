@@ -44,11 +44,14 @@ type NodeGroupDrainer struct {
 	ng                    eks.KubeNodeGroup
 	nodeDrainWaitPeriod   time.Duration
 	podEvictionWaitPeriod time.Duration
+	gracePeriod           time.Duration
 	undo                  bool
 	parallel              int
 }
 
-func NewNodeGroupDrainer(clientSet kubernetes.Interface, ng eks.KubeNodeGroup, maxGracePeriod, nodeDrainWaitPeriod time.Duration, podEvictionWaitPeriod time.Duration, undo, disableEviction bool, parallel int) NodeGroupDrainer {
+// NewNodeGroupDrainer returns a drainer for ng. When gracePeriod is set, the nodes are cordoned and left to soak
+// for gracePeriod before their pods are evicted.
+func NewNodeGroupDrainer(clientSet kubernetes.Interface, ng eks.KubeNodeGroup, maxGracePeriod, nodeDrainWaitPeriod time.Duration, podEvictionWaitPeriod, gracePeriod time.Duration, undo, disableEviction bool, parallel int) NodeGroupDrainer {
 	ignoreDaemonSets := []metav1.ObjectMeta{
 		{
 			Namespace: "kube-system",
@@ -81,6 +84,7 @@ func NewNodeGroupDrainer(clientSet kubernetes.Interface, ng eks.KubeNodeGroup, m
 		ng:                    ng,
 		nodeDrainWaitPeriod:   nodeDrainWaitPeriod,
 		podEvictionWaitPeriod: podEvictionWaitPeriod,
+		gracePeriod:           gracePeriod,
 		undo:                  undo,
 		parallel:              parallel,
 	}
@@ -110,7 +114,10 @@ func (n *NodeGroupDrainer) Drain(ctx context.Context, sem *semaphore.Weighted) e
 
 	drainedNodes := cmap.New()
 
-	var evictErr error
+	var (
+		evictErr error
+		soaked   = n.gracePeriod == 0
+	)
 	// loop until all nodes are drained to handle accidental scale-up
 	// or any other changes in the ASG
 	for {
@@ -127,6 +134,18 @@ func (n *NodeGroupDrainer) Drain(ctx context.Context, sem *semaphore.Weighted) e
 			}
 			n.toggleCordon(true, nodes)
 
+			if !soaked {
+				logger.Info("waiting %s after cordoning nodegroup %q before draining it", n.gracePeriod, n.ng.NameString())
+				select {
+				case <-ctx.Done():
+					return fmt.Errorf("timed out waiting for the grace period of nodegroup %q to elapse", n.ng.NameString())
+				case <-time.After(n.gracePeriod):
+				}
+				soaked = true
+				// nodes added during the grace period are cordoned in the next iteration
+				continue
+			}
+
 			newPendingNodes := sets.New[string]()
 
 			for _, node := range nodes.Items {
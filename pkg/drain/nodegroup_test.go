@@ -83,7 +83,7 @@ var _ = Describe("Drain", func() {
 		})
 
 		It("does not error", func() {
-			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second*10, time.Second, 0, false, false, 1)
+			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second*10, time.Second, 0, 0, false, false, 1)
 			nodeGroupDrainer.SetDrainer(fakeEvictor)
 
 			err := nodeGroupDrainer.Drain(ctx, sem)
@@ -99,6 +99,43 @@ var _ = Describe("Drain", func() {
 		})
 	})
 
+	When("a grace period is set", func() {
+		BeforeEach(func() {
+			fakeEvictor.GetPodsForEvictionReturns(&evictor.PodDeleteList{}, nil)
+
+			_, err := fakeClientSet.CoreV1().Nodes().Create(context.Background(), &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName,
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("cordons the nodes and waits for the grace period before draining them", func() {
+			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, 0, 0, 500*time.Millisecond, false, false, 1)
+			nodeGroupDrainer.SetDrainer(fakeEvictor)
+
+			start := time.Now()
+			Expect(nodeGroupDrainer.Drain(ctx, sem)).To(Succeed())
+			Expect(time.Since(start)).To(BeNumerically(">=", 500*time.Millisecond))
+			Expect(fakeEvictor.GetPodsForEvictionCallCount()).To(Equal(1))
+
+			node, err := fakeClientSet.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(node.Spec.Unschedulable).To(BeTrue())
+		})
+
+		It("errors when the grace period outlasts the timeout", func() {
+			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, 0, 0, time.Minute, false, false, 1)
+			nodeGroupDrainer.SetDrainer(fakeEvictor)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			Expect(nodeGroupDrainer.Drain(ctx, sem)).To(MatchError(`timed out waiting for the grace period of nodegroup "node-1" to elapse`))
+			Expect(fakeEvictor.GetPodsForEvictionCallCount()).To(BeZero())
+		})
+	})
+
 	When("the nodes never drain successfully", func() {
 		var pod corev1.Pod
 
@@ -127,7 +164,7 @@ var _ = Describe("Drain", func() {
 		})
 
 		It("times out and errors", func() {
-			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, 0, time.Second, 0, false, false, 1)
+			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, 0, time.Second, 0, 0, false, false, 1)
 			nodeGroupDrainer.SetDrainer(fakeEvictor)
 
 			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -144,7 +181,7 @@ var _ = Describe("Drain", func() {
 		})
 
 		It("errors", func() {
-			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, time.Second, 0, false, false, 1)
+			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, time.Second, 0, 0, false, false, 1)
 			nodeGroupDrainer.SetDrainer(fakeEvictor)
 
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
@@ -190,7 +227,7 @@ var _ = Describe("Drain", func() {
 		})
 
 		It("does not error", func() {
-			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, time.Second, time.Second*0, false, true, 1)
+			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, time.Second, time.Second*0, 0, false, true, 1)
 			nodeGroupDrainer.SetDrainer(fakeEvictor)
 
 			Expect(nodeGroupDrainer.Drain(ctx, sem)).To(Succeed())
@@ -220,7 +257,7 @@ var _ = Describe("Drain", func() {
 		})
 
 		It("uncordons all the nodes", func() {
-			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, time.Second, time.Second*0, true, false, 1)
+			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, time.Second, time.Second*0, 0, true, false, 1)
 			nodeGroupDrainer.SetDrainer(fakeEvictor)
 
 			err := nodeGroupDrainer.Drain(ctx, sem)
@@ -274,7 +311,7 @@ var _ = Describe("Drain", func() {
 		})
 
 		It("does not error", func() {
-			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, time.Second, 0, false, false, 1)
+			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, time.Second, 0, 0, false, false, 1)
 			nodeGroupDrainer.SetDrainer(fakeEvictor)
 
 			Expect(nodeGroupDrainer.Drain(ctx, sem)).To(Succeed())
@@ -324,7 +361,7 @@ var _ = Describe("Drain", func() {
 		})
 
 		It("returns an error", func() {
-			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, time.Second, 0, false, false, 1)
+			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, time.Second, 0, 0, false, false, 1)
 			nodeGroupDrainer.SetDrainer(fakeEvictor)
 
 			err := nodeGroupDrainer.Drain(ctx, sem)
@@ -388,7 +425,7 @@ var _ = Describe("Drain", func() {
 		})
 
 		It("it attempts to drain all pods", func() {
-			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, time.Second, time.Second*0, false, false, 1)
+			nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Second, time.Second, time.Second*0, 0, false, false, 1)
 			nodeGroupDrainer.SetDrainer(fakeEvictor)
 
 			_ = nodeGroupDrainer.Drain(ctx, sem)
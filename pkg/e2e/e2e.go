@@ -0,0 +1,136 @@
+// Package e2e runs the end-to-end checks of `eksctl e2e`: it creates, scales, upgrades and deletes a disposable
+// cluster with the eksctl binary under test, and reports the result of each step in JUnit format, so that
+// distributions packaging eksctl can validate their builds against a real AWS account.
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/utils/names"
+)
+
+const (
+	// NodeGroupName is the name of the nodegroup of the disposable cluster
+	NodeGroupName = "ng-e2e"
+	// Tag is added to the disposable cluster, so that leftovers can be found and deleted
+	Tag = "eksctl.io/e2e"
+	// TTL is the lifetime of the disposable cluster, after which `eksctl gc --expired` deletes it
+	// should the cleanup have failed
+	TTL = "4h"
+)
+
+// Options are the options of a run
+type Options struct {
+	// NamePrefix is the prefix of the name of the cluster, a random suffix is appended to it
+	NamePrefix string
+	Region     string
+	// Version is the Kubernetes version of the cluster, the default version if empty
+	Version string
+	// Upgrade upgrades the cluster and its nodegroup to the next Kubernetes version
+	Upgrade bool
+	// Nodes is the number of nodes the nodegroup is created with, it is scaled up by one
+	Nodes int
+}
+
+// ClusterName returns the name of a new disposable cluster
+func (o Options) ClusterName() string {
+	return fmt.Sprintf("%s-%s", o.NamePrefix, names.RandomName(6, "abcdef0123456789"))
+}
+
+// Step is a step of a run
+type Step struct {
+	Name string
+	// Args are the arguments eksctl is run with
+	Args []string
+	// Cleanup steps run even if an earlier step failed
+	Cleanup bool
+}
+
+// Steps returns the steps exercising clusterName
+func Steps(clusterName string, o Options) []Step {
+	region := []string{"--region", o.Region}
+	createArgs := append([]string{"create", "cluster",
+		"--name", clusterName,
+		"--nodegroup-name", NodeGroupName,
+		"--nodes", strconv.Itoa(o.Nodes),
+		"--tags", Tag + "=true",
+		"--ttl", TTL,
+	}, region...)
+	if o.Version != "" {
+		createArgs = append(createArgs, "--version", o.Version)
+	}
+	scaledNodes := strconv.Itoa(o.Nodes + 1)
+
+	steps := []Step{
+		{Name: "create cluster", Args: createArgs},
+		{Name: "get cluster", Args: append([]string{"get", "cluster", "--name", clusterName}, region...)},
+		{Name: "get nodegroup", Args: append([]string{"get", "nodegroup", "--cluster", clusterName, "--name", NodeGroupName}, region...)},
+		{Name: "scale nodegroup", Args: append([]string{"scale", "nodegroup", "--cluster", clusterName, "--name", NodeGroupName,
+			"--nodes", scaledNodes, "--nodes-max", scaledNodes, "--wait"}, region...)},
+	}
+	if o.Upgrade {
+		steps = append(steps,
+			Step{Name: "upgrade cluster", Args: append([]string{"upgrade", "cluster", "--name", clusterName, "--approve"}, region...)},
+			Step{Name: "upgrade nodegroup", Args: append([]string{"upgrade", "nodegroup", "--cluster", clusterName, "--name", NodeGroupName}, region...)},
+		)
+	}
+	return append(steps, Step{
+		Name:    "delete cluster",
+		Args:    append([]string{"delete", "cluster", "--name", clusterName, "--wait"}, region...),
+		Cleanup: true,
+	})
+}
+
+// Runner runs the steps
+type Runner struct {
+	// Run runs eksctl with args and returns its output
+	Run func(ctx context.Context, args []string) (string, error)
+	// Now returns the current time, it is time.Now if nil
+	Now func() time.Time
+}
+
+// RunSteps runs steps in order and returns their results as a test suite. Once a step fails the steps that
+// follow it are skipped, except for the cleanup steps, which always run.
+func (r *Runner) RunSteps(ctx context.Context, suiteName string, steps []Step) *TestSuite {
+	now := r.Now
+	if now == nil {
+		now = time.Now
+	}
+	suite := &TestSuite{Name: suiteName}
+	start := now()
+	failed := false
+	for _, step := range steps {
+		testCase := TestCase{Name: step.Name, Classname: suiteName}
+		if failed && !step.Cleanup {
+			logger.Info("skipping %q as an earlier step failed", step.Name)
+			testCase.Skipped = &Skipped{Message: "an earlier step failed"}
+			suite.add(testCase)
+			continue
+		}
+
+		logger.Info("running %q", step.Name)
+		stepStart := now()
+		output, err := r.Run(ctx, step.Args)
+		testCase.Time = seconds(now().Sub(stepStart))
+		testCase.SystemOut = output
+		if err != nil {
+			failed = true
+			logger.Critical("step %q failed: %v", step.Name, err)
+			testCase.Failure = &Failure{Message: err.Error(), Text: output}
+		} else {
+			logger.Success("step %q succeeded", step.Name)
+		}
+		suite.add(testCase)
+	}
+	suite.Time = seconds(now().Sub(start))
+	return suite
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
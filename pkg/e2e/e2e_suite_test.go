@@ -0,0 +1,11 @@
+package e2e_test
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestE2E(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
@@ -0,0 +1,85 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/e2e"
+)
+
+var _ = Describe("e2e", func() {
+	var (
+		options e2e.Options
+		ran     []string
+		now     time.Time
+	)
+
+	BeforeEach(func() {
+		options = e2e.Options{NamePrefix: "eksctl-e2e", Region: "us-west-2", Nodes: 1}
+		ran = nil
+		now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	})
+
+	newRunner := func(failingStep string) *e2e.Runner {
+		return &e2e.Runner{
+			Run: func(_ context.Context, args []string) (string, error) {
+				step := strings.Join(args[:2], " ")
+				ran = append(ran, step)
+				now = now.Add(time.Minute)
+				if step == failingStep {
+					return "stack failed", errors.New("exit status 5")
+				}
+				return "ok", nil
+			},
+			Now: func() time.Time { return now },
+		}
+	}
+
+	It("names clusters with a random suffix", func() {
+		name := options.ClusterName()
+		Expect(name).To(MatchRegexp(`^eksctl-e2e-[a-f0-9]{6}$`))
+		Expect(options.ClusterName()).NotTo(Equal(name))
+	})
+
+	It("tags the cluster and only upgrades it when asked to", func() {
+		steps := e2e.Steps("eksctl-e2e-abc123", options)
+		Expect(steps[0].Args).To(ContainElements("--tags", e2e.Tag+"=true", "--ttl", e2e.TTL))
+		Expect(steps[len(steps)-1].Cleanup).To(BeTrue())
+		Expect(steps).NotTo(ContainElement(HaveField("Name", "upgrade cluster")))
+
+		options.Upgrade = true
+		options.Version = "1.29"
+		steps = e2e.Steps("eksctl-e2e-abc123", options)
+		Expect(steps[0].Args).To(ContainElements("--version", "1.29"))
+		Expect(steps).To(ContainElement(HaveField("Name", "upgrade cluster")))
+	})
+
+	It("runs all steps", func() {
+		suite := newRunner("").RunSteps(context.Background(), "eksctl", e2e.Steps("eksctl-e2e-abc123", options))
+		Expect(ran).To(Equal([]string{"create cluster", "get cluster", "get nodegroup", "scale nodegroup", "delete cluster"}))
+		Expect(suite.Failed()).To(BeFalse())
+		Expect(suite.Tests).To(Equal(5))
+		Expect(suite.Time).To(Equal("300.000"))
+	})
+
+	It("skips the remaining steps but still cleans up when a step fails", func() {
+		suite := newRunner("get cluster").RunSteps(context.Background(), "eksctl", e2e.Steps("eksctl-e2e-abc123", options))
+		Expect(ran).To(Equal([]string{"create cluster", "get cluster", "delete cluster"}))
+		Expect(suite.Failed()).To(BeTrue())
+		Expect(suite.Failures).To(Equal(1))
+		Expect(suite.Skipped).To(Equal(2))
+
+		out := &bytes.Buffer{}
+		Expect(e2e.WriteJUnit(out, suite)).To(Succeed())
+		Expect(out.String()).To(HavePrefix(`<?xml version="1.0" encoding="UTF-8"?>`))
+		Expect(out.String()).To(ContainSubstring(`<testsuite name="eksctl" tests="5" failures="1" skipped="2" time="180.000">`))
+		Expect(out.String()).To(ContainSubstring(`<failure message="exit status 5">stack failed</failure>`))
+		Expect(out.String()).To(ContainSubstring(`<skipped message="an earlier step failed"></skipped>`))
+	})
+})
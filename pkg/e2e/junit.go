@@ -0,0 +1,68 @@
+package e2e
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// TestSuite is a JUnit test suite
+type TestSuite struct {
+	XMLName   xml.Name   `xml:"testsuite"`
+	Name      string     `xml:"name,attr"`
+	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
+	Skipped   int        `xml:"skipped,attr"`
+	Time      string     `xml:"time,attr"`
+	TestCases []TestCase `xml:"testcase"`
+}
+
+// TestCase is a JUnit test case
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	Classname string   `xml:"classname,attr"`
+	Time      string   `xml:"time,attr"`
+	Failure   *Failure `xml:"failure,omitempty"`
+	Skipped   *Skipped `xml:"skipped,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+}
+
+// Failure is the failure of a JUnit test case
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Skipped marks a JUnit test case as skipped
+type Skipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// Failed reports whether any test case of the suite failed
+func (s *TestSuite) Failed() bool {
+	return s.Failures > 0
+}
+
+func (s *TestSuite) add(testCase TestCase) {
+	s.Tests++
+	switch {
+	case testCase.Failure != nil:
+		s.Failures++
+	case testCase.Skipped != nil:
+		s.Skipped++
+	}
+	s.TestCases = append(s.TestCases, testCase)
+}
+
+// WriteJUnit writes suite to w as a JUnit XML report
+func WriteJUnit(w io.Writer, suite *TestSuite) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suite); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	awsv2 "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
@@ -52,6 +53,7 @@ type KubernetesProvider struct {
 	WaitTimeout time.Duration
 	RoleARN     string
 	Signer      api.STSPresigner
+	Proxy       api.ProxyConfig
 }
 
 // KubeProvider is an interface with helper funcs for k8s and EKS that are part of ClusterProvider
@@ -157,6 +159,7 @@ func newHelper(
 		WaitTimeout: spec.WaitTimeout,
 		RoleARN:     c.Status.IAMRoleARN,
 		Signer:      provider.STSPresigner(),
+		Proxy:       spec.Proxy,
 	}
 	c.KubeProvider = kubeProvider
 
@@ -194,17 +197,63 @@ func newAWSProvider(spec *api.ProviderConfig, configurationLoader AWSConfigurati
 		spec.Region = cfg.Region
 	}
 
-	provider.ServicesV2 = &ServicesV2{
-		config: cfg,
+	provider.setUpClients(cfg)
+	return provider, nil
+}
+
+// setUpClients sets up the AWS clients of the provider from cfg, honouring the endpoint overrides
+// and retry settings of its spec
+func (p *ProviderServices) setUpClients(cfg awsv2.Config) {
+	var endpoints api.ServiceEndpoints
+	if p.spec.ServiceEndpoints != nil {
+		endpoints = *p.spec.ServiceEndpoints
+	}
+	p.ServicesV2 = &ServicesV2{
+		config:    cfg,
+		endpoints: endpoints,
+		retry:     p.spec.Retry,
 	}
 
-	provider.asg = autoscaling.NewFromConfig(cfg)
-	provider.cloudwatchlogs = cloudwatchlogs.NewFromConfig(cfg)
-	provider.cloudtrail = cloudtrail.NewFromConfig(cfg, func(o *cloudtrail.Options) {
-		o.BaseEndpoint = getBaseEndpoint(cloudtrail.ServiceID, "AWS_CLOUDTRAIL_ENDPOINT")
+	p.asg = autoscaling.NewFromConfig(cfg)
+	p.cloudwatchlogs = cloudwatchlogs.NewFromConfig(cfg)
+	p.cloudtrail = cloudtrail.NewFromConfig(cfg, func(o *cloudtrail.Options) {
+		o.BaseEndpoint = getBaseEndpoint(cloudtrail.ServiceID, endpoints.CloudTrail, "AWS_CLOUDTRAIL_ENDPOINT")
 	})
+}
 
-	return provider, nil
+// NewProviderForRole returns a provider whose AWS clients use credentials obtained by
+// assuming roleARN with the credentials of the given provider, e.g. to manage resources
+// that live in an AWS account other than the cluster's. The returned provider keeps the
+// settings of the given one, such as endpoint overrides and retry settings, except for the
+// CloudFormation service role, which belongs to the cluster's account.
+func NewProviderForRole(provider api.ClusterProvider, roleARN string) api.ClusterProvider {
+	spec := &api.ProviderConfig{
+		CloudFormationDisableRollback: provider.CloudFormationDisableRollback(),
+		Region:                        provider.Region(),
+		Profile:                       provider.Profile(),
+		WaitTimeout:                   provider.WaitTimeout(),
+	}
+	if p, ok := provider.(*ProviderServices); ok {
+		specCopy := *p.spec
+		spec = &specCopy
+	}
+	// CloudFormation in the target account cannot assume a service role of the cluster's account,
+	// so stacks there are created with the permissions of the assumed role
+	spec.CloudFormationRoleARN = ""
+
+	var stsEndpoint string
+	if spec.ServiceEndpoints != nil {
+		stsEndpoint = spec.ServiceEndpoints.STS
+	}
+	cfg := provider.AWSConfig().Copy()
+	stsClient := sts.NewFromConfig(cfg, func(o *sts.Options) {
+		o.BaseEndpoint = getBaseEndpoint(sts.ServiceID, stsEndpoint, "AWS_STS_ENDPOINT")
+	})
+	cfg.Credentials = awsv2.NewCredentialsCache(stscreds.NewAssumeRoleProvider(stsClient, roleARN))
+
+	assumed := &ProviderServices{spec: spec}
+	assumed.setUpClients(cfg)
+	return assumed
 }
 
 // ParseConfig parses data into a ClusterConfig
@@ -447,7 +496,7 @@ func CheckInstanceAvailability(ctx context.Context, spec *api.ClusterConfig, ec2
 	return nil
 }
 
-// ValidateLocalZones validates that the specified local zones exist.
+// ValidateLocalZones validates that the specified local zones exist. Wavelength Zones are accepted as well.
 func ValidateLocalZones(ctx context.Context, ec2API awsapi.EC2, localZones []string, region string) error {
 	output, err := ec2API.DescribeAvailabilityZones(ctx, &ec2.DescribeAvailabilityZonesInput{
 		ZoneNames: localZones,
@@ -469,7 +518,7 @@ func ValidateLocalZones(ctx context.Context, ec2API awsapi.EC2, localZones []str
 		return fmt.Errorf("failed to find all local zones; expected to find %d available local zones but found only %d", len(localZones), len(output.AvailabilityZones))
 	}
 	for _, z := range output.AvailabilityZones {
-		if *z.ZoneType != "local-zone" {
+		if zoneType := *z.ZoneType; zoneType != "local-zone" && zoneType != "wavelength-zone" {
 			return fmt.Errorf("non local-zone %q specified in localZones", *z.ZoneName)
 		}
 	}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
@@ -130,6 +132,106 @@ var _ = Describe("eksctl API", func() {
 		Entry("creates the AWS provider successfully", newAWSProviderEntry{}),
 	)
 
+	It("keeps the provider settings but the CloudFormation service role when assuming a role", func() {
+		fakeConfigurationLoader := fakes.FakeAWSConfigurationLoader{}
+		fakeConfigurationLoader.LoadDefaultConfigReturns(aws.Config{
+			Region: api.DefaultRegion,
+		}, nil)
+		awsProvider, err := eks.NewAWSProvider(&api.ProviderConfig{
+			CloudFormationRoleARN:         "arn:aws:iam::111122223333:role/cfn-service-role",
+			CloudFormationDisableRollback: true,
+			WaitTimeout:                   time.Hour,
+			ServiceEndpoints:              &api.ServiceEndpoints{CloudFormation: "https://cloudformation.example.com"},
+		}, &fakeConfigurationLoader)
+		Expect(err).NotTo(HaveOccurred())
+
+		assumed := eks.NewProviderForRole(awsProvider, "arn:aws:iam::444455556666:role/irsa-creator")
+		Expect(assumed.CloudFormationRoleARN()).To(BeEmpty())
+		Expect(assumed.CloudFormationDisableRollback()).To(BeTrue())
+		Expect(assumed.WaitTimeout()).To(Equal(time.Hour))
+		Expect(assumed.Region()).To(Equal(api.DefaultRegion))
+		Expect(assumed.AWSConfig().Credentials).To(BeAssignableToTypeOf(&aws.CredentialsCache{}))
+		Expect(assumed.ASG()).NotTo(BeNil())
+		Expect(assumed.CloudTrail()).NotTo(BeNil())
+		Expect(assumed.CloudFormation()).NotTo(BeNil())
+	})
+
+	It("retries CloudFormation calls with the configured retry settings", func() {
+		fakeConfigurationLoader := fakes.FakeAWSConfigurationLoader{}
+		fakeConfigurationLoader.LoadDefaultConfigReturns(aws.Config{
+			Region: api.DefaultRegion,
+		}, nil)
+		awsProvider, err := eks.NewAWSProvider(&api.ProviderConfig{
+			Retry: api.RetryConfig{Mode: api.RetryModeStandard, MaxAttempts: 7},
+		}, &fakeConfigurationLoader)
+		Expect(err).NotTo(HaveOccurred())
+
+		retryer := awsProvider.CloudFormation().(*cloudformation.Client).Options().Retryer
+		Expect(retryer).To(BeAssignableToTypeOf(&eks.RetryerV2{}))
+		Expect(retryer.MaxAttempts()).To(Equal(7))
+	})
+
+	It("selects FIPS endpoints when requested", func() {
+		fakeConfigurationLoader := fakes.FakeAWSConfigurationLoader{}
+		fakeConfigurationLoader.LoadDefaultConfigReturns(aws.Config{
+			Region: api.DefaultRegion,
+		}, nil)
+
+		_, err := eks.NewAWSProvider(&api.ProviderConfig{UseFIPSEndpoint: true}, &fakeConfigurationLoader)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, options := fakeConfigurationLoader.LoadDefaultConfigArgsForCall(0)
+		lo := &config.LoadOptions{}
+		for _, loadOptionsFunc := range options {
+			Expect(loadOptionsFunc(lo)).NotTo(HaveOccurred())
+		}
+		Expect(lo.UseFIPSEndpoint).To(Equal(aws.FIPSEndpointStateEnabled))
+	})
+
+	DescribeTable("assuming a role", func(assumeRole api.AssumeRoleConfig, expectedErr string) {
+		fakeConfigurationLoader := fakes.FakeAWSConfigurationLoader{}
+		fakeConfigurationLoader.LoadDefaultConfigReturns(aws.Config{
+			Region:      api.DefaultRegion,
+			Credentials: awscredentials.NewStaticCredentialsProvider("key", "secret", ""),
+		}, nil)
+
+		provider, err := eks.NewAWSProvider(&api.ProviderConfig{AssumeRole: assumeRole}, &fakeConfigurationLoader)
+		if expectedErr != "" {
+			Expect(err).To(MatchError(expectedErr))
+			return
+		}
+		Expect(err).NotTo(HaveOccurred())
+		if assumeRole.RoleARN != "" {
+			Expect(provider.CredentialsProvider()).To(BeAssignableToTypeOf(&aws.CredentialsCache{}))
+		} else {
+			Expect(provider.CredentialsProvider()).To(BeAssignableToTypeOf(awscredentials.StaticCredentialsProvider{}))
+		}
+	},
+		Entry("without a role", api.AssumeRoleConfig{}, ""),
+		Entry("with a role", api.AssumeRoleConfig{
+			RoleARN:     "arn:aws:iam::123456789012:role/admin",
+			ExternalID:  "external-id",
+			SessionTags: map[string]string{"team": "platform"},
+		}, ""),
+		Entry("with an invalid role ARN", api.AssumeRoleConfig{RoleARN: "admin"}, `--assume-role-arn must be an IAM role ARN, got "admin"`),
+		Entry("with an external ID but no role", api.AssumeRoleConfig{ExternalID: "external-id"},
+			"--external-id, --role-session-name, --session-tags and --mfa-serial can only be used with --assume-role-arn"),
+	)
+
+	DescribeTable("resolving service endpoints", func(configuredEndpoint, envEndpoint string, expectedEndpoint *string) {
+		const envName = "EKSCTL_TEST_SERVICE_ENDPOINT"
+		if envEndpoint != "" {
+			Expect(os.Setenv(envName, envEndpoint)).To(Succeed())
+			DeferCleanup(os.Unsetenv, envName)
+		}
+		Expect(eks.GetBaseEndpoint("EKS", configuredEndpoint, envName)).To(Equal(expectedEndpoint))
+	},
+		Entry("no endpoint set", "", "", nil),
+		Entry("endpoint set in the environment", "", "http://localhost:4566", aws.String("http://localhost:4566")),
+		Entry("endpoint set in the config file", "https://eks.internal", "", aws.String("https://eks.internal")),
+		Entry("config file takes precedence over the environment", "https://eks.internal", "http://localhost:4566", aws.String("https://eks.internal")),
+	)
+
 	DescribeTable("creating the EKS provider", func(e newClusterProviderEntry) {
 		clusterConfig := api.NewClusterConfig()
 		clusterConfig.Metadata = &api.ClusterMeta{}
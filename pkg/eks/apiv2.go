@@ -2,17 +2,24 @@ package eks
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	middlewarev2 "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	ststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
 	"github.com/aws/smithy-go/middleware"
 	"github.com/gofrs/flock"
 	"github.com/kris-nova/logger"
 	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/credentials"
@@ -34,6 +41,16 @@ func (cl ConfigurationLoader) LoadDefaultConfig(ctx context.Context, optFns ...f
 }
 
 func newV2Config(pc *api.ProviderConfig, credentialsCacheFilePath string, configurationLoader AWSConfigurationLoader) (aws.Config, error) {
+	if err := validateAssumeRoleConfig(pc.AssumeRole); err != nil {
+		return aws.Config{}, err
+	}
+	if err := validateRequestLogFormat(pc.DebugRequests); err != nil {
+		return aws.Config{}, err
+	}
+	if err := validateRetryConfig(pc.Retry); err != nil {
+		return aws.Config{}, err
+	}
+
 	var options []func(options *config.LoadOptions) error
 
 	if pc.Region != "" {
@@ -50,9 +67,56 @@ func newV2Config(pc *api.ProviderConfig, credentialsCacheFilePath string, config
 		options = append(options, config.WithSharedConfigProfile(pc.Profile.Name))
 	}
 
+	if pc.UseFIPSEndpoint {
+		options = append(options, config.WithUseFIPSEndpoint(aws.FIPSEndpointStateEnabled))
+	}
+
+	if pc.DebugRequests != "" {
+		options = append(options, config.WithAPIOptions([]func(*middleware.Stack) error{
+			addRequestLogger(pc.DebugRequests, os.Stderr, time.Now),
+		}))
+	}
+
+	if !pc.ResponseCache.Disabled {
+		if cacheDir, err := getResponseCacheDir(); err != nil {
+			logger.Debug("not caching AWS responses: %v", err)
+		} else {
+			operations := pc.ResponseCache.Operations
+			if operations == nil {
+				operations = AMIResolutionCachedOperations
+			}
+			// the session token tells apart the temporary credentials of the same access key, e.g. of different roles
+			identity := strings.Join([]string{pc.Profile.Name, os.Getenv("AWS_PROFILE"), os.Getenv("AWS_ACCESS_KEY_ID"),
+				os.Getenv("AWS_SESSION_TOKEN"), pc.AssumeRole.RoleARN}, "\x00")
+			options = append(options, config.WithAPIOptions([]func(*middleware.Stack) error{
+				addResponseCache(afero.NewOsFs(), cacheDir, identity, operations, time.Now),
+			}))
+		}
+	}
+
+	if pc.PageSize > 0 {
+		options = append(options, config.WithAPIOptions([]func(*middleware.Stack) error{
+			addPageSize(pc.PageSize),
+		}))
+	}
+
+	if pc.Retry.MaxRequestsPerSecond > 0 {
+		options = append(options, config.WithAPIOptions([]func(*middleware.Stack) error{
+			addRateLimiter(pc.Retry.MaxRequestsPerSecond),
+		}))
+	}
+
+	if pc.Proxy.HTTPSProxy != "" || pc.Proxy.CABundle != "" {
+		httpClient, err := newProxyHTTPClient(pc.Proxy)
+		if err != nil {
+			return aws.Config{}, err
+		}
+		options = append(options, config.WithHTTPClient(httpClient))
+	}
+
 	cfg, err := configurationLoader.LoadDefaultConfig(context.TODO(), append(options,
 		config.WithRetryer(func() aws.Retryer {
-			return NewRetryerV2()
+			return NewRetryerV2WithConfig(pc.Retry)
 		}),
 		config.WithAssumeRoleCredentialOptions(func(o *stscreds.AssumeRoleOptions) {
 			o.TokenProvider = stscreds.StdinTokenProvider
@@ -75,7 +139,17 @@ func newV2Config(pc *api.ProviderConfig, credentialsCacheFilePath string, config
 	if err != nil {
 		return cfg, err
 	}
-	if credentialsCacheFilePath != "" {
+	// honour retry_mode and max_attempts from the AWS config file and environment unless set with flags,
+	// the retryer reads pc.Retry when clients are created
+	if pc.Retry.Mode == "" && cfg.RetryMode != "" {
+		pc.Retry.Mode = string(cfg.RetryMode)
+	}
+	if pc.Retry.MaxAttempts == 0 && cfg.RetryMaxAttempts > 0 {
+		pc.Retry.MaxAttempts = cfg.RetryMaxAttempts
+	}
+	if provider, ok := cliCachedCredentials(cfg, pc, credentialsCacheFilePath != ""); ok {
+		cfg.Credentials = provider
+	} else if credentialsCacheFilePath != "" {
 		fileCache, err := credentials.NewFileCacheV2(cfg.Credentials, pc.Profile.Name, afero.NewOsFs(), func(path string) credentials.Flock {
 			return flock.New(path)
 		}, &credentials.RealClock{}, credentialsCacheFilePath)
@@ -84,5 +158,102 @@ func newV2Config(pc *api.ProviderConfig, credentialsCacheFilePath string, config
 		}
 		cfg.Credentials = aws.NewCredentialsCache(fileCache)
 	}
+	if pc.AssumeRole.RoleARN != "" {
+		cfg.Credentials = newAssumeRoleCredentials(cfg, pc)
+	}
 	return cfg, nil
 }
+
+// cliCachedCredentials returns the credentials of a credential_process or SSO profile, shared by all AWS configs
+// eksctl builds for the profile so that they are only obtained once per process. When enableFileCache is set,
+// they are also cached across invocations, in the AWS CLI credentials cache.
+func cliCachedCredentials(cfg aws.Config, pc *api.ProviderConfig, enableFileCache bool) (aws.CredentialsProvider, bool) {
+	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
+		return nil, false
+	}
+	profile := pc.Profile.Name
+	if profile == "" {
+		profile = os.Getenv("AWS_PROFILE")
+	}
+	if profile == "" {
+		profile = "default"
+	}
+	sharedConfig, err := config.LoadSharedConfigProfile(context.TODO(), profile)
+	if err != nil {
+		return nil, false
+	}
+	key, ok := credentials.CLICacheKey(sharedConfig)
+	if !ok {
+		return nil, false
+	}
+	provider := cfg.Credentials
+	if enableFileCache {
+		cacheDir, err := credentials.GetCLICacheDir()
+		if err != nil {
+			logger.Warning("unable to locate the AWS CLI credentials cache: %v", err)
+			return nil, false
+		}
+		providerType := "process"
+		if sharedConfig.CredentialProcess == "" {
+			providerType = "sso"
+		}
+		cliCache := credentials.NewCLICache(cfg.Credentials, providerType, key, afero.NewOsFs(), &credentials.RealClock{}, cacheDir)
+		provider = aws.NewCredentialsCache(cliCache, func(o *aws.CredentialsCacheOptions) {
+			o.ExpiryWindow = 30 * time.Minute
+			o.ExpiryWindowJitterFrac = 0
+		})
+	}
+	return credentials.ShareProvider(key, provider), true
+}
+
+// validateAssumeRoleConfig validates the settings for assuming a role.
+func validateAssumeRoleConfig(ar api.AssumeRoleConfig) error {
+	if ar.RoleARN == "" {
+		if ar.ExternalID != "" || ar.RoleSessionName != "" || len(ar.SessionTags) > 0 || ar.MFASerial != "" {
+			return errors.New("--external-id, --role-session-name, --session-tags and --mfa-serial can only be used with --assume-role-arn")
+		}
+		return nil
+	}
+	if !arn.IsARN(ar.RoleARN) {
+		return fmt.Errorf("--assume-role-arn must be an IAM role ARN, got %q", ar.RoleARN)
+	}
+	return nil
+}
+
+// newAssumeRoleCredentials returns credentials for the role in pc.AssumeRole, obtained with the credentials in cfg.
+func newAssumeRoleCredentials(cfg aws.Config, pc *api.ProviderConfig) aws.CredentialsProvider {
+	var stsEndpoint string
+	if pc.ServiceEndpoints != nil {
+		stsEndpoint = pc.ServiceEndpoints.STS
+	}
+	stsClient := sts.NewFromConfig(cfg, func(o *sts.Options) {
+		o.BaseEndpoint = getBaseEndpoint(sts.ServiceID, stsEndpoint, "AWS_STS_ENDPOINT")
+	})
+
+	ar := pc.AssumeRole
+	provider := stscreds.NewAssumeRoleProvider(stsClient, ar.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.Duration = 60 * time.Minute
+		if ar.ExternalID != "" {
+			o.ExternalID = aws.String(ar.ExternalID)
+		}
+		if ar.RoleSessionName != "" {
+			o.RoleSessionName = ar.RoleSessionName
+		} else {
+			o.RoleSessionName = fmt.Sprintf("eksctl-%d", time.Now().UnixNano())
+		}
+		if ar.MFASerial != "" {
+			o.SerialNumber = aws.String(ar.MFASerial)
+			o.TokenProvider = stscreds.StdinTokenProvider
+		}
+		for _, key := range sets.List(sets.KeySet(ar.SessionTags)) {
+			o.Tags = append(o.Tags, ststypes.Tag{
+				Key:   aws.String(key),
+				Value: aws.String(ar.SessionTags[key]),
+			})
+		}
+	})
+	logger.Debug("assuming role %q", ar.RoleARN)
+	return aws.NewCredentialsCache(provider, func(o *aws.CredentialsCacheOptions) {
+		o.ExpiryWindow = 30 * time.Minute
+	})
+}
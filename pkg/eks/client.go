@@ -35,6 +35,7 @@ type Client struct {
 	Config *clientcmdapi.Config
 
 	rawConfig *restclient.Config
+	proxy     api.ProxyConfig
 }
 
 // NewClient creates a new client config.
@@ -42,6 +43,7 @@ func (c *KubernetesProvider) NewClient(clusterInfo kubeconfig.ClusterInfo) (*Cli
 	config := kubeconfig.NewForUser(clusterInfo, GetUsername(c.RoleARN))
 	client := &Client{
 		Config: config,
+		proxy:  c.Proxy,
 	}
 	tokenSource := &auth.TokenSource{
 		ClusterID:      clusterInfo.ID(),
@@ -66,6 +68,16 @@ func (c *Client) new(tokenSource oauth2.TokenSource) (*Client, error) {
 		return nil, errors.Wrap(err, "failed to create API client configuration from client config")
 	}
 	rawConfig.WrapTransport = transport.TokenSourceWrapTransport(transport.NewCachedTokenSource(tokenSource))
+	if proxy := proxyFunc(c.proxy); proxy != nil {
+		rawConfig.Proxy = proxy
+	}
+	if c.proxy.CABundle != "" {
+		caBundle, err := readCABundle(c.proxy.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		rawConfig.CAData = append(append(rawConfig.CAData, '\n'), caBundle...)
+	}
 
 	c.rawConfig = rawConfig
 	c.rawConfig.QPS = float32(25)
@@ -165,13 +177,22 @@ func UpdateAuthConfigMap(nodeGroups []*api.NodeGroup, clientSet kubernetes.Inter
 	return nil
 }
 
-// WaitForNodes waits till the nodes are ready
+// WaitForNodes waits till the minimum number of nodes of the nodegroup are ready
 func WaitForNodes(ctx context.Context, clientSet kubernetes.Interface, ng KubeNodeGroup) error {
-	minSize := ng.Size()
-	if minSize == 0 {
+	return WaitForReadyNodes(ctx, clientSet, ng, -1)
+}
+
+// WaitForReadyNodes waits till at least count nodes of the nodegroup are ready, logging each node
+// as it joins the cluster and becomes ready; a negative count waits for the minimum size of the nodegroup
+func WaitForReadyNodes(ctx context.Context, clientSet kubernetes.Interface, ng KubeNodeGroup, count int) error {
+	if count < 0 {
+		count = ng.Size()
+	}
+	if count == 0 {
 		return nil
 	}
 
+	joinedNodes := sets.New[string]()
 	readyNodes := sets.New[string]()
 	watcher, err := clientSet.CoreV1().Nodes().Watch(context.TODO(), ng.ListOptions())
 	if err != nil {
@@ -184,8 +205,8 @@ func WaitForNodes(ctx context.Context, clientSet kubernetes.Interface, ng KubeNo
 		return errors.Wrap(err, "listing nodes")
 	}
 
-	logger.Info("waiting for at least %d node(s) to become ready in %q", minSize, ng.NameString())
-	for {
+	logger.Info("waiting for at least %d node(s) to become ready in %q", count, ng.NameString())
+	for counter < count {
 		select {
 		case event, ok := <-watcher.ResultChan():
 			logger.Debug("event = %#v", event)
@@ -205,21 +226,22 @@ func WaitForNodes(ctx context.Context, clientSet kubernetes.Interface, ng KubeNo
 			if event.Object != nil && event.Type != watch.Deleted {
 				if node, ok := event.Object.(*corev1.Node); ok {
 					if isNodeReady(node) {
-						readyNodes.Insert(node.Name)
+						if !readyNodes.Has(node.Name) {
+							readyNodes.Insert(node.Name)
+							logger.Info("node %q is ready in %q (%d/%d)", node.Name, ng.NameString(), readyNodes.Len(), count)
+						}
 						counter = readyNodes.Len()
-						logger.Debug("node %q is ready in %q", node.Name, ng.NameString())
 					} else {
-						logger.Debug("node %q seen in %q, but not ready yet", node.Name, ng.NameString())
+						if !joinedNodes.Has(node.Name) {
+							logger.Info("node %q has joined %q, waiting for it to become ready", node.Name, ng.NameString())
+						}
 						logger.Debug("node = %#v", *node)
 					}
+					joinedNodes.Insert(node.Name)
 				}
 			}
 		case <-ctx.Done():
-			return fmt.Errorf("timed out waiting for at least %d nodes to join the cluster and become ready in %q: %w", minSize, ng.NameString(), ctx.Err())
-		}
-
-		if counter >= minSize {
-			break
+			return fmt.Errorf("timed out waiting for at least %d nodes to join the cluster and become ready in %q: %w", count, ng.NameString(), ctx.Err())
 		}
 	}
 
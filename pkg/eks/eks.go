@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
@@ -13,6 +14,7 @@ import (
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 
+	"github.com/weaveworks/eksctl/pkg/actions/irsa"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
@@ -178,12 +180,47 @@ func (c *ClusterProvider) NewOpenIDConnectManager(ctx context.Context, spec *api
 		*c.Status.ClusterInfo.Cluster.Identity.Oidc.Issuer, parsedARN.Partition, sharedTags(c.Status.ClusterInfo.Cluster))
 }
 
-func sharedTags(cluster *ekstypes.Cluster) map[string]string {
-	return map[string]string{
-		api.ClusterNameTag:   *cluster.Name,
-		api.EksctlVersionTag: version.GetVersion(),
+// NewOpenIDConnectManagerForRole constructs a new IAM OIDC manager for the cluster's issuer in the AWS account
+// that owns roleARN; provider must be able to call IAM in that account, see NewProviderForRole
+func (c *ClusterProvider) NewOpenIDConnectManagerForRole(ctx context.Context, spec *api.ClusterConfig, provider api.ClusterProvider, roleARN string) (*iamoidc.OpenIDConnectManager, error) {
+	// make sure the cluster's issuer is known before building anything for the target account
+	if _, err := c.NewOpenIDConnectManager(ctx, spec); err != nil {
+		return nil, err
+	}
+
+	parsedARN, err := arn.Parse(roleARN)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid target account role ARN: %q", roleARN)
+	}
+
+	return iamoidc.NewOpenIDConnectManager(provider.IAM(), parsedARN.AccountID,
+		*c.Status.ClusterInfo.Cluster.Identity.Oidc.Issuer, parsedARN.Partition, sharedTags(c.Status.ClusterInfo.Cluster))
+}
+
+// NewIRSATargetAccount returns the clients needed to create iamserviceaccount roles
+// in the AWS account that owns roleARN
+func (c *ClusterProvider) NewIRSATargetAccount(spec *api.ClusterConfig, roleARN string) irsa.TargetAccount {
+	provider := NewProviderForRole(c.AWSProvider, roleARN)
+	return irsa.TargetAccount{
+		RoleARN:      roleARN,
+		StackManager: manager.NewStackCollection(provider, spec),
+		NewOIDCManager: func(ctx context.Context) (*iamoidc.OpenIDConnectManager, error) {
+			return c.NewOpenIDConnectManagerForRole(ctx, spec, provider, roleARN)
+		},
 	}
+}
 
+func sharedTags(cluster *ekstypes.Cluster) map[string]string {
+	tags := map[string]string{}
+	// the tags of the cluster include metadata.tags; those with the reserved aws: prefix cannot be set on other resources
+	for k, v := range cluster.Tags {
+		if !strings.HasPrefix(k, "aws:") {
+			tags[k] = v
+		}
+	}
+	tags[api.ClusterNameTag] = *cluster.Name
+	tags[api.EksctlVersionTag] = version.GetVersion()
+	return tags
 }
 
 // LoadClusterVPC loads the VPC configuration.
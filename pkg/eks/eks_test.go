@@ -221,6 +221,18 @@ var _ = Describe("EKS API wrapper", func() {
 		})
 	})
 
+	It("tags the OIDC provider with the tags of the cluster", func() {
+		cluster := testutils.NewFakeCluster("testcluster", ekstypes.ClusterStatusActive)
+		cluster.Tags = map[string]string{
+			"cost-center":                   "123",
+			"aws:cloudformation:stack-name": "eksctl-testcluster-cluster",
+		}
+		tags := sharedTags(cluster)
+		Expect(tags).To(HaveKeyWithValue("cost-center", "123"))
+		Expect(tags).To(HaveKeyWithValue(api.ClusterNameTag, "testcluster"))
+		Expect(tags).NotTo(HaveKey("aws:cloudformation:stack-name"))
+	})
+
 	type platformVersionCase struct {
 		platformVersion string
 		expectedVersion int
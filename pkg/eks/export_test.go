@@ -1,6 +1,13 @@
 package eks
 
 var (
-	NewHelper      = newHelper
-	NewAWSProvider = newAWSProvider
+	NewHelper        = newHelper
+	NewAWSProvider   = newAWSProvider
+	GetBaseEndpoint  = getBaseEndpoint
+	AddRequestLogger = addRequestLogger
+	AddRateLimiter   = addRateLimiter
+	AddResponseCache = addResponseCache
+	AddPageSize      = addPageSize
+
+	ValidateRetryConfig = validateRetryConfig
 )
@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/weaveworks/eksctl/pkg/fargate"
 	"github.com/weaveworks/eksctl/pkg/fargate/coredns"
 	"github.com/weaveworks/eksctl/pkg/utils/apierrors"
 	"github.com/weaveworks/eksctl/pkg/utils/retry"
@@ -54,6 +55,9 @@ func (t *fargateProfilesTask) Do(errCh chan error) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to get ClientSet")
 	}
+	if err := fargate.ConfigureLogging(t.ctx, t.spec, clientSet); err != nil {
+		return errors.Wrap(err, "failed to configure Fargate logging")
+	}
 	if err := ScheduleCoreDNSOnFargateIfRelevant(t.spec, t.clusterProvider, clientSet); err != nil {
 		return errors.Wrap(err, "failed to schedule core-dns on fargate")
 	}
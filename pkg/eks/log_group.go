@@ -0,0 +1,104 @@
+package eks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// ClusterLogGroupName returns the name of the log group EKS sends the control plane logs of a cluster to, see
+// https://docs.aws.amazon.com/eks/latest/userguide/control-plane-logs.html
+func ClusterLogGroupName(clusterName string) string {
+	return fmt.Sprintf("/aws/eks/%s/cluster", clusterName)
+}
+
+// ConfigureClusterLogGroup applies the retention, KMS key and tags of cloudWatch.clusterLogging to the log group of the
+// control plane logs, creating the log group if EKS has not created it yet
+func (c *ClusterProvider) ConfigureClusterLogGroup(ctx context.Context, cfg *api.ClusterConfig) error {
+	if cfg.CloudWatch == nil || !cfg.CloudWatch.ClusterLogging.HasLogGroupSettings() {
+		return nil
+	}
+	logging := cfg.CloudWatch.ClusterLogging
+	logGroupName := ClusterLogGroupName(cfg.Metadata.Name)
+	cwl := c.AWSProvider.CloudWatchLogs()
+
+	logGroup, err := describeLogGroup(ctx, c, logGroupName)
+	if err != nil {
+		return err
+	}
+	if logGroup == nil {
+		input := &cloudwatchlogs.CreateLogGroupInput{
+			LogGroupName: aws.String(logGroupName),
+			Tags:         logging.LogGroupTags,
+		}
+		if logging.LogGroupKMSKeyARN != "" {
+			input.KmsKeyId = aws.String(logging.LogGroupKMSKeyARN)
+		}
+		if _, err := cwl.CreateLogGroup(ctx, input); err != nil {
+			var alreadyExists *cwltypes.ResourceAlreadyExistsException
+			if !errors.As(err, &alreadyExists) {
+				return fmt.Errorf("creating log group %q: %w", logGroupName, err)
+			}
+			// EKS created the log group in the meantime
+			if logGroup, err = describeLogGroup(ctx, c, logGroupName); err != nil {
+				return err
+			}
+		} else {
+			logger.Info("created CloudWatch log group %q", logGroupName)
+		}
+	}
+
+	if logGroup != nil {
+		if keyARN := logging.LogGroupKMSKeyARN; keyARN != "" && aws.ToString(logGroup.KmsKeyId) != keyARN {
+			if _, err := cwl.AssociateKmsKey(ctx, &cloudwatchlogs.AssociateKmsKeyInput{
+				LogGroupName: aws.String(logGroupName),
+				KmsKeyId:     aws.String(keyARN),
+			}); err != nil {
+				return fmt.Errorf("associating KMS key %q with log group %q: %w", keyARN, logGroupName, err)
+			}
+			logger.Info("encrypting the logs of log group %q with KMS key %q", logGroupName, keyARN)
+		}
+		if len(logging.LogGroupTags) > 0 {
+			if _, err := cwl.TagResource(ctx, &cloudwatchlogs.TagResourceInput{
+				ResourceArn: logGroup.LogGroupArn,
+				Tags:        logging.LogGroupTags,
+			}); err != nil {
+				return fmt.Errorf("tagging log group %q: %w", logGroupName, err)
+			}
+		}
+	}
+
+	if retention := logging.LogRetentionInDays; retention != 0 && (logGroup == nil || aws.ToInt32(logGroup.RetentionInDays) != int32(retention)) {
+		if _, err := cwl.PutRetentionPolicy(ctx, &cloudwatchlogs.PutRetentionPolicyInput{
+			LogGroupName:    aws.String(logGroupName),
+			RetentionInDays: aws.Int32(int32(retention)),
+		}); err != nil {
+			return fmt.Errorf("error updating log retention settings: %w", err)
+		}
+		logger.Info("set log retention to %d days for CloudWatch logging", retention)
+	}
+	return nil
+}
+
+// describeLogGroup returns the log group with the given name, or nil if it does not exist
+func describeLogGroup(ctx context.Context, c *ClusterProvider, logGroupName string) (*cwltypes.LogGroup, error) {
+	output, err := c.AWSProvider.CloudWatchLogs().DescribeLogGroups(ctx, &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(logGroupName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing log group %q: %w", logGroupName, err)
+	}
+	for _, logGroup := range output.LogGroups {
+		if aws.ToString(logGroup.LogGroupName) == logGroupName {
+			return &logGroup, nil
+		}
+	}
+	return nil, nil
+}
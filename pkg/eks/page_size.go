@@ -0,0 +1,47 @@
+package eks
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// maxEKSPageSize is the largest number of items the EKS list operations return per call.
+const maxEKSPageSize = 100
+
+// addPageSize returns an API option that sets the MaxResults of the inputs of EKS list operations to pageSize,
+// unless the caller has set it. The page size is capped at the maximum EKS accepts.
+func addPageSize(pageSize int) func(*middleware.Stack) error {
+	if pageSize > maxEKSPageSize {
+		pageSize = maxEKSPageSize
+	}
+	maxResults := reflect.ValueOf(int32(pageSize))
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("eksctlPageSize", func(
+			ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+		) (middleware.InitializeOutput, middleware.Metadata, error) {
+			if awsmiddleware.GetServiceID(ctx) != "EKS" || !strings.HasPrefix(awsmiddleware.GetOperationName(ctx), "List") {
+				return next.HandleInitialize(ctx, in)
+			}
+			input := reflect.ValueOf(in.Parameters)
+			if input.Kind() != reflect.Pointer || input.IsNil() || input.Elem().Kind() != reflect.Struct {
+				return next.HandleInitialize(ctx, in)
+			}
+			field := input.Elem().FieldByName("MaxResults")
+			if !field.IsValid() || field.Type() != reflect.TypeOf((*int32)(nil)) || !field.IsNil() {
+				return next.HandleInitialize(ctx, in)
+			}
+			// set the page size on a copy of the input, so as not to modify the caller's input
+			inputCopy := reflect.New(input.Elem().Type())
+			inputCopy.Elem().Set(input.Elem())
+			pageSize := reflect.New(maxResults.Type())
+			pageSize.Elem().Set(maxResults)
+			inputCopy.Elem().FieldByName("MaxResults").Set(pageSize)
+			in.Parameters = inputCopy.Interface()
+			return next.HandleInitialize(ctx, in)
+		}), middleware.After)
+	}
+}
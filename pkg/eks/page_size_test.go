@@ -0,0 +1,70 @@
+package eks_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/smithy-go/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+var _ = Describe("AWS page size", func() {
+	var (
+		maxResults []string
+		client     *awseks.Client
+	)
+
+	newClient := func(pageSize int) *awseks.Client {
+		return awseks.New(awseks.Options{
+			Region:      "us-west-2",
+			Credentials: awscredentials.NewStaticCredentialsProvider("key", "secret", ""),
+			APIOptions: []func(*middleware.Stack) error{
+				eks.AddPageSize(pageSize),
+			},
+			HTTPClient: httpClientFunc(func(r *http.Request) (*http.Response, error) {
+				maxResults = append(maxResults, r.URL.Query().Get("maxResults"))
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"nodegroups": []}`)),
+				}, nil
+			}),
+		})
+	}
+
+	BeforeEach(func() {
+		maxResults = nil
+		client = newClient(20)
+	})
+
+	It("sets the page size of list operations", func() {
+		input := &awseks.ListNodegroupsInput{ClusterName: aws.String("cluster")}
+		_, err := client.ListNodegroups(context.Background(), input)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(maxResults).To(Equal([]string{"20"}))
+		Expect(input.MaxResults).To(BeNil())
+	})
+
+	It("does not override the page size set by the caller", func() {
+		_, err := client.ListNodegroups(context.Background(), &awseks.ListNodegroupsInput{
+			ClusterName: aws.String("cluster"),
+			MaxResults:  aws.Int32(5),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(maxResults).To(Equal([]string{"5"}))
+	})
+
+	It("caps the page size at the maximum EKS accepts", func() {
+		_, err := newClient(500).ListNodegroups(context.Background(), &awseks.ListNodegroupsInput{ClusterName: aws.String("cluster")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(maxResults).To(Equal([]string{"100"}))
+	})
+})
@@ -0,0 +1,71 @@
+package eks
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"golang.org/x/net/http/httpproxy"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// proxyFunc returns a function that sends HTTPS requests through proxy.HTTPSProxy, except for the hosts
+// matched by proxy.NoProxy, or nil if no proxy is configured.
+func proxyFunc(proxy api.ProxyConfig) func(*http.Request) (*url.URL, error) {
+	if proxy.HTTPSProxy == "" {
+		return nil
+	}
+	proxyURL := (&httpproxy.Config{
+		HTTPSProxy: proxy.HTTPSProxy,
+		NoProxy:    strings.Join(proxy.NoProxy, ","),
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyURL(req.URL)
+	}
+}
+
+// readCABundle reads the PEM-encoded certificate authorities in path.
+func readCABundle(path string) ([]byte, error) {
+	caBundle, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA bundle: %w", err)
+	}
+	return caBundle, nil
+}
+
+// newProxyHTTPClient returns an HTTP client for the AWS SDK that honours the proxy and
+// trusts the CA bundle in addition to the system certificate authorities.
+func newProxyHTTPClient(proxy api.ProxyConfig) (*awshttp.BuildableClient, error) {
+	client := awshttp.NewBuildableClient()
+	if proxy.HTTPSProxy != "" {
+		client = client.WithTransportOptions(func(tr *http.Transport) {
+			tr.Proxy = proxyFunc(proxy)
+		})
+	}
+	if proxy.CABundle != "" {
+		caBundle, err := readCABundle(proxy.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %q", proxy.CABundle)
+		}
+		client = client.WithTransportOptions(func(tr *http.Transport) {
+			if tr.TLSClientConfig == nil {
+				tr.TLSClientConfig = &tls.Config{}
+			}
+			tr.TLSClientConfig.RootCAs = rootCAs
+		})
+	}
+	return client, nil
+}
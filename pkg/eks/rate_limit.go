@@ -0,0 +1,26 @@
+package eks
+
+import (
+	"context"
+	"math"
+
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+)
+
+// addRateLimiter returns an API option that limits the rate of attempts of AWS API calls to requestsPerSecond.
+// The limiter is shared by all clients the option is applied to, so that it is a budget for the whole process.
+func addRateLimiter(requestsPerSecond float64) func(*middleware.Stack) error {
+	limiter := rate.NewLimiter(rate.Limit(requestsPerSecond), int(math.Max(1, math.Ceil(requestsPerSecond))))
+	return func(stack *middleware.Stack) error {
+		// finalize middlewares added after the retry middleware are run for every attempt
+		return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("eksctlRateLimiter", func(
+			ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler,
+		) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			if err := limiter.Wait(ctx); err != nil {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+			}
+			return next.HandleFinalize(ctx, in)
+		}), middleware.After)
+	}
+}
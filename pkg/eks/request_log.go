@@ -0,0 +1,122 @@
+package eks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/kris-nova/logger"
+)
+
+// Formats for logging AWS API requests.
+const (
+	RequestLogFormatText = "text"
+	RequestLogFormatJSON = "json"
+)
+
+// requestLogEntry describes an AWS API call.
+type requestLogEntry struct {
+	Time       time.Time `json:"time"`
+	Service    string    `json:"service"`
+	Operation  string    `json:"operation"`
+	Region     string    `json:"region,omitempty"`
+	DurationMS int64     `json:"durationMs"`
+	Attempts   int       `json:"attempts"`
+	RequestID  string    `json:"requestId,omitempty"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	ErrorCode  string    `json:"errorCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func (e requestLogEntry) String() string {
+	fields := []string{
+		fmt.Sprintf("service=%s", e.Service),
+		fmt.Sprintf("operation=%s", e.Operation),
+		fmt.Sprintf("duration=%dms", e.DurationMS),
+		fmt.Sprintf("attempts=%d", e.Attempts),
+	}
+	if e.RequestID != "" {
+		fields = append(fields, fmt.Sprintf("requestID=%s", e.RequestID))
+	}
+	if e.StatusCode != 0 {
+		fields = append(fields, fmt.Sprintf("status=%d", e.StatusCode))
+	}
+	if e.ErrorCode != "" {
+		fields = append(fields, fmt.Sprintf("error=%s", e.ErrorCode))
+	}
+	return strings.Join(fields, " ")
+}
+
+// validateRequestLogFormat validates the value of --aws-debug-requests.
+func validateRequestLogFormat(format string) error {
+	switch format {
+	case "", RequestLogFormatText, RequestLogFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid value %q for --aws-debug-requests, valid values are: %s, %s", format, RequestLogFormatText, RequestLogFormatJSON)
+	}
+}
+
+// addRequestLogger returns an API option that logs every AWS API call once it completes, including its retries.
+// In the text format calls are logged with the logger, in the JSON format a JSON document is written to out per call.
+func addRequestLogger(format string, out io.Writer, now func() time.Time) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("eksctlRequestLogger", func(
+			ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+		) (middleware.InitializeOutput, middleware.Metadata, error) {
+			start := now()
+			output, metadata, err := next.HandleInitialize(ctx, in)
+
+			entry := requestLogEntry{
+				Time:       start.UTC(),
+				Service:    awsmiddleware.GetServiceID(ctx),
+				Operation:  awsmiddleware.GetOperationName(ctx),
+				Region:     awsmiddleware.GetRegion(ctx),
+				DurationMS: now().Sub(start).Milliseconds(),
+				Attempts:   1,
+			}
+			if attempts, ok := retry.GetAttemptResults(metadata); ok && len(attempts.Results) > 0 {
+				entry.Attempts = len(attempts.Results)
+			}
+			entry.RequestID, _ = awsmiddleware.GetRequestIDMetadata(metadata)
+			if rawResponse, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response); ok {
+				entry.StatusCode = rawResponse.StatusCode
+			}
+			if err != nil {
+				entry.Error = err.Error()
+				var responseErr *awshttp.ResponseError
+				if errors.As(err, &responseErr) {
+					entry.StatusCode = responseErr.HTTPStatusCode()
+					if entry.RequestID == "" {
+						entry.RequestID = responseErr.ServiceRequestID()
+					}
+				}
+				var apiErr smithy.APIError
+				if errors.As(err, &apiErr) {
+					entry.ErrorCode = apiErr.ErrorCode()
+				}
+			}
+
+			if format == RequestLogFormatJSON {
+				if encodeErr := json.NewEncoder(out).Encode(entry); encodeErr != nil {
+					logger.Debug("failed to log AWS request: %v", encodeErr)
+				}
+			} else if err != nil {
+				logger.Warning("AWS request failed: %s", entry)
+			} else {
+				logger.Info("AWS request: %s", entry)
+			}
+			return output, metadata, err
+		}), middleware.After)
+	}
+}
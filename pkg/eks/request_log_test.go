@@ -0,0 +1,106 @@
+package eks_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+type httpClientFunc func(*http.Request) (*http.Response, error)
+
+func (f httpClientFunc) Do(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+var _ = Describe("AWS request logging", func() {
+	var (
+		out         *bytes.Buffer
+		stsClient   *sts.Client
+		statusCodes []int
+	)
+
+	newResponse := func(statusCode int, body string) *http.Response {
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{"X-Amzn-Requestid": []string{"request-id"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		calls := 0
+		now := func() time.Time {
+			calls++
+			return start.Add(time.Duration(calls-1) * 1500 * time.Millisecond)
+		}
+		stsClient = sts.New(sts.Options{
+			Region:      "us-west-2",
+			Credentials: awscredentials.NewStaticCredentialsProvider("key", "secret", ""),
+			Retryer: retry.NewStandard(func(o *retry.StandardOptions) {
+				o.MaxAttempts = 3
+				o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) {
+					return 0, nil
+				})
+			}),
+			APIOptions: []func(*middleware.Stack) error{eks.AddRequestLogger(eks.RequestLogFormatJSON, out, now)},
+			HTTPClient: httpClientFunc(func(*http.Request) (*http.Response, error) {
+				statusCode := statusCodes[0]
+				statusCodes = statusCodes[1:]
+				if statusCode != http.StatusOK {
+					return newResponse(statusCode, `<ErrorResponse><Error><Type>Sender</Type><Code>Throttling</Code><Message>Rate exceeded</Message></Error><RequestId>request-id</RequestId></ErrorResponse>`), nil
+				}
+				return newResponse(statusCode, `<GetCallerIdentityResponse><GetCallerIdentityResult><Account>123456789012</Account></GetCallerIdentityResult></GetCallerIdentityResponse>`), nil
+			}),
+		})
+	})
+
+	decodeEntry := func() map[string]interface{} {
+		var entry map[string]interface{}
+		Expect(json.Unmarshal(out.Bytes(), &entry)).To(Succeed())
+		return entry
+	}
+
+	It("logs the service, operation, duration, attempts and request ID of a call", func() {
+		statusCodes = []int{http.StatusBadRequest, http.StatusOK}
+		_, err := stsClient.GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(decodeEntry()).To(Equal(map[string]interface{}{
+			"time":       "2024-01-01T00:00:00Z",
+			"service":    "STS",
+			"operation":  "GetCallerIdentity",
+			"region":     "us-west-2",
+			"durationMs": float64(1500),
+			"attempts":   float64(2),
+			"requestId":  "request-id",
+			"statusCode": float64(http.StatusOK),
+		}))
+	})
+
+	It("logs the error code of failed calls", func() {
+		statusCodes = []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusBadRequest}
+		_, err := stsClient.GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
+		Expect(err).To(HaveOccurred())
+
+		entry := decodeEntry()
+		Expect(entry).To(HaveKeyWithValue("attempts", float64(3)))
+		Expect(entry).To(HaveKeyWithValue("statusCode", float64(http.StatusBadRequest)))
+		Expect(entry).To(HaveKeyWithValue("errorCode", "Throttling"))
+		Expect(entry).To(HaveKeyWithValue("requestId", "request-id"))
+	})
+})
@@ -0,0 +1,179 @@
+package eks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/kris-nova/logger"
+	"github.com/spf13/afero"
+)
+
+const (
+	// EnvResponseCacheDir is the environment variable for overriding the directory of the response cache.
+	EnvResponseCacheDir = "EKSCTL_RESPONSE_CACHE_DIR"
+
+	responseCacheTTL = time.Minute
+)
+
+var (
+	// AMIResolutionCachedOperations are the operations used for resolving AMIs, which are cached by default.
+	AMIResolutionCachedOperations = []string{"EC2.DescribeImages"}
+	// ReadOnlyCachedOperations are the operations cached by read-only commands, e.g. `eksctl get nodegroup`.
+	ReadOnlyCachedOperations = []string{"EKS.DescribeCluster", "CloudFormation.DescribeStacks", "EC2.DescribeImages"}
+	// CompletionCachedOperations are the operations cached when completing the names of clusters, nodegroups and addons.
+	CompletionCachedOperations = []string{
+		"STS.GetCallerIdentity", "EKS.ListClusters", "EKS.ListNodegroups", "EKS.ListAddons", "EKS.DescribeAddonVersions",
+		"CloudFormation.ListStacks", "CloudFormation.DescribeStacks",
+	}
+)
+
+// cacheableOperations returns empty outputs of the operations that can be cached, to decode cached responses into.
+var cacheableOperations = map[string]func() interface{}{
+	"EKS.DescribeCluster":           func() interface{} { return &awseks.DescribeClusterOutput{} },
+	"EKS.ListClusters":              func() interface{} { return &awseks.ListClustersOutput{} },
+	"EKS.ListNodegroups":            func() interface{} { return &awseks.ListNodegroupsOutput{} },
+	"EKS.ListAddons":                func() interface{} { return &awseks.ListAddonsOutput{} },
+	"EKS.DescribeAddonVersions":     func() interface{} { return &awseks.DescribeAddonVersionsOutput{} },
+	"CloudFormation.DescribeStacks": func() interface{} { return &cloudformation.DescribeStacksOutput{} },
+	"CloudFormation.ListStacks":     func() interface{} { return &cloudformation.ListStacksOutput{} },
+	"EC2.DescribeImages":            func() interface{} { return &ec2.DescribeImagesOutput{} },
+	"STS.GetCallerIdentity":         func() interface{} { return &sts.GetCallerIdentityOutput{} },
+}
+
+type skipResponseCacheKey struct{}
+
+// WithoutResponseCache returns a context whose AWS API calls neither use nor update the response cache,
+// e.g. for calls polling the status of a resource, whose responses must be fresh.
+func WithoutResponseCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipResponseCacheKey{}, true)
+}
+
+type cachedResponse struct {
+	Expires time.Time       `json:"expires"`
+	Output  json.RawMessage `json:"output"`
+}
+
+// getResponseCacheDir returns the directory the responses of AWS API calls are cached in.
+func getResponseCacheDir() (string, error) {
+	if dir := os.Getenv(EnvResponseCacheDir); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".eksctl", "cache", "responses"), nil
+}
+
+// addResponseCache returns an API option that caches the responses of operations in dir for responseCacheTTL.
+// Responses are keyed by identity, which identifies the credentials in use, and by the region and input of the call.
+func addResponseCache(fs afero.Fs, dir, identity string, operations []string, now func() time.Time) func(*middleware.Stack) error {
+	cached := map[string]bool{}
+	for _, op := range operations {
+		cached[op] = true
+	}
+	var evictOnce sync.Once
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("eksctlResponseCache", func(
+			ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+		) (middleware.InitializeOutput, middleware.Metadata, error) {
+			operation := awsmiddleware.GetServiceID(ctx) + "." + awsmiddleware.GetOperationName(ctx)
+			newOutput, ok := cacheableOperations[operation]
+			if skip, _ := ctx.Value(skipResponseCacheKey{}).(bool); !ok || !cached[operation] || skip {
+				return next.HandleInitialize(ctx, in)
+			}
+			evictOnce.Do(func() {
+				evictExpiredResponses(fs, dir, now())
+			})
+			input, err := json.Marshal(in.Parameters)
+			if err != nil {
+				return next.HandleInitialize(ctx, in)
+			}
+			key := sha256.Sum256([]byte(identity + "\x00" + awsmiddleware.GetRegion(ctx) + "\x00" + operation + "\x00" + string(input)))
+			path := filepath.Join(dir, hex.EncodeToString(key[:])+".json")
+
+			if data, err := afero.ReadFile(fs, path); err == nil {
+				var response cachedResponse
+				output := newOutput()
+				if err := json.Unmarshal(data, &response); err == nil && now().Before(response.Expires) {
+					if err := json.Unmarshal(response.Output, output); err == nil {
+						logger.Debug("using cached response of %s", operation)
+						return middleware.InitializeOutput{Result: output}, middleware.Metadata{}, nil
+					}
+				}
+				_ = fs.Remove(path)
+			}
+
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			if err != nil {
+				return out, metadata, err
+			}
+			if err := writeCachedResponse(fs, path, out.Result, now().Add(responseCacheTTL)); err != nil {
+				logger.Debug("failed to cache response of %s: %v", operation, err)
+			}
+			return out, metadata, nil
+		}), middleware.Before)
+	}
+}
+
+// evictExpiredResponses removes the responses cached in dir that have expired, or cannot be read,
+// so that the responses of calls that are not repeated do not accumulate
+func evictExpiredResponses(fs afero.Fs, dir string, now time.Time) {
+	files, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		var response cachedResponse
+		if data, err := afero.ReadFile(fs, path); err == nil && json.Unmarshal(data, &response) == nil && now.Before(response.Expires) {
+			continue
+		}
+		if err := fs.Remove(path); err != nil {
+			logger.Debug("failed to evict cached response %s: %v", path, err)
+		}
+	}
+}
+
+func writeCachedResponse(fs afero.Fs, path string, output interface{}, expires time.Time) error {
+	outputData, err := json.Marshal(output)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(cachedResponse{Expires: expires, Output: outputData})
+	if err != nil {
+		return err
+	}
+	if err := fs.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmpFile, err := afero.TempFile(fs, filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fs.Remove(tmpFile.Name()) }()
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return fs.Rename(tmpFile.Name(), path)
+}
@@ -0,0 +1,105 @@
+package eks_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/aws/smithy-go/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+var _ = Describe("AWS response cache", func() {
+	var (
+		fs        afero.Fs
+		now       time.Time
+		requests  int
+		newClient func(operations []string) *awseks.Client
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		requests = 0
+		newClient = func(operations []string) *awseks.Client {
+			return awseks.New(awseks.Options{
+				Region:      "us-west-2",
+				Credentials: awscredentials.NewStaticCredentialsProvider("key", "secret", ""),
+				APIOptions: []func(*middleware.Stack) error{
+					eks.AddResponseCache(fs, "/cache", "profile", operations, func() time.Time { return now }),
+				},
+				HTTPClient: httpClientFunc(func(r *http.Request) (*http.Response, error) {
+					requests++
+					name := strings.TrimPrefix(r.URL.Path, "/clusters/")
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{"cluster": {"name": "` + name + `", "status": "ACTIVE", "createdAt": 1704067200}}`)),
+					}, nil
+				}),
+			})
+		}
+	})
+
+	describeCluster := func(client *awseks.Client, name string) *awseks.DescribeClusterOutput {
+		output, err := client.DescribeCluster(context.Background(), &awseks.DescribeClusterInput{Name: aws.String(name)})
+		Expect(err).NotTo(HaveOccurred())
+		return output
+	}
+
+	It("serves repeated calls from the cache until the response expires", func() {
+		client := newClient(eks.ReadOnlyCachedOperations)
+		first := describeCluster(client, "cluster-1")
+		Expect(requests).To(Equal(1))
+
+		cached := describeCluster(newClient(eks.ReadOnlyCachedOperations), "cluster-1")
+		Expect(requests).To(Equal(1))
+		Expect(*cached.Cluster.Name).To(Equal("cluster-1"))
+		Expect(cached.Cluster.Status).To(Equal(first.Cluster.Status))
+		Expect(cached.Cluster.CreatedAt.Equal(*first.Cluster.CreatedAt)).To(BeTrue())
+
+		describeCluster(client, "cluster-2")
+		Expect(requests).To(Equal(2))
+
+		now = now.Add(2 * time.Minute)
+		describeCluster(client, "cluster-1")
+		Expect(requests).To(Equal(3))
+	})
+
+	It("does not cache operations that are not enabled", func() {
+		client := newClient(eks.AMIResolutionCachedOperations)
+		describeCluster(client, "cluster-1")
+		describeCluster(client, "cluster-1")
+		Expect(requests).To(Equal(2))
+	})
+	It("does not use the cache for calls made with WithoutResponseCache", func() {
+		client := newClient(eks.ReadOnlyCachedOperations)
+		describeCluster(client, "cluster-1")
+		_, err := client.DescribeCluster(eks.WithoutResponseCache(context.Background()), &awseks.DescribeClusterInput{Name: aws.String("cluster-1")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(Equal(2))
+	})
+
+	It("evicts the expired responses", func() {
+		describeCluster(newClient(eks.ReadOnlyCachedOperations), "cluster-1")
+		describeCluster(newClient(eks.ReadOnlyCachedOperations), "cluster-2")
+		files, err := afero.ReadDir(fs, "/cache")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(2))
+
+		now = now.Add(2 * time.Minute)
+		describeCluster(newClient(eks.ReadOnlyCachedOperations), "cluster-3")
+		files, err = afero.ReadDir(fs, "/cache")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+	})
+})
@@ -1,7 +1,9 @@
 package eks
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -9,6 +11,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/smithy-go"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 )
 
 const (
@@ -22,15 +26,49 @@ type RetryerV2 struct {
 
 // NewRetryerV2 returns a new *RetryerV2
 func NewRetryerV2() *RetryerV2 {
-	standard := retry.AddWithMaxAttempts(retry.NewStandard(func(o *retry.StandardOptions) {
-		o.MaxAttempts = maxRetries
-	}), maxRetries)
+	return NewRetryerV2WithConfig(api.RetryConfig{})
+}
+
+// NewRetryerV2WithConfig returns a new *RetryerV2 using the retry mode and maximum number of attempts in retryConfig,
+// defaulting to the standard mode and maxRetries attempts.
+func NewRetryerV2WithConfig(retryConfig api.RetryConfig) *RetryerV2 {
+	return newRetryerV2(retryConfig)
+}
+
+// newRetryerV2 returns a new *RetryerV2 for retryConfig, applying optFns to the options of the standard retryer
+func newRetryerV2(retryConfig api.RetryConfig, optFns ...func(*retry.StandardOptions)) *RetryerV2 {
+	maxAttempts := maxRetries
+	if retryConfig.MaxAttempts > 0 {
+		maxAttempts = retryConfig.MaxAttempts
+	}
+	standardOptions := append([]func(*retry.StandardOptions){
+		func(o *retry.StandardOptions) {
+			o.MaxAttempts = maxAttempts
+		},
+	}, optFns...)
+
+	var retryer aws.Retryer
+	if retryConfig.Mode == api.RetryModeAdaptive {
+		retryer = retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+			o.StandardOptions = append(o.StandardOptions, standardOptions...)
+		})
+	} else {
+		retryer = retry.NewStandard(standardOptions...)
+	}
 
 	return &RetryerV2{
-		Retryer: standard,
+		Retryer: retry.AddWithMaxAttempts(retryer, maxAttempts),
 	}
 }
 
+// GetAttemptToken implements aws.RetryerV2, so that the client-side rate limiting of the adaptive mode applies.
+func (r *RetryerV2) GetAttemptToken(ctx context.Context) (func(error) error, error) {
+	if retryerV2, ok := r.Retryer.(aws.RetryerV2); ok {
+		return retryerV2.GetAttemptToken(ctx)
+	}
+	return r.Retryer.GetInitialToken(), nil
+}
+
 // IsErrorRetryable implements aws.Retryer
 func (r *RetryerV2) IsErrorRetryable(err error) bool {
 	if !r.Retryer.IsErrorRetryable(err) {
@@ -53,3 +91,20 @@ func isErrorRetryable(err error) bool {
 	}
 	return true
 }
+
+// validateRetryConfig validates the retry settings passed with --aws-retry-mode, --aws-max-attempts and
+// --aws-max-requests-per-second.
+func validateRetryConfig(retryConfig api.RetryConfig) error {
+	switch retryConfig.Mode {
+	case "", api.RetryModeStandard, api.RetryModeAdaptive:
+	default:
+		return fmt.Errorf("invalid value %q for --aws-retry-mode, valid values are: %s, %s", retryConfig.Mode, api.RetryModeStandard, api.RetryModeAdaptive)
+	}
+	if retryConfig.MaxAttempts < 0 {
+		return errors.New("--aws-max-attempts must be greater than 0")
+	}
+	if retryConfig.MaxRequestsPerSecond < 0 {
+		return errors.New("--aws-max-requests-per-second must not be negative")
+	}
+	return nil
+}
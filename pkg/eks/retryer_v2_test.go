@@ -0,0 +1,83 @@
+package eks_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+var _ = Describe("Retryer", func() {
+	It("defaults to the standard mode with 13 attempts", func() {
+		retryer := eks.NewRetryerV2()
+		Expect(retryer.MaxAttempts()).To(Equal(13))
+		_, err := retryer.GetAttemptToken(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("uses the configured maximum number of attempts", func() {
+		retryer := eks.NewRetryerV2WithConfig(api.RetryConfig{MaxAttempts: 5})
+		Expect(retryer.MaxAttempts()).To(Equal(5))
+	})
+
+	It("keeps the client-side rate limiting of the adaptive mode", func() {
+		var retryer aws.Retryer = eks.NewRetryerV2WithConfig(api.RetryConfig{Mode: api.RetryModeAdaptive, MaxAttempts: 20})
+		Expect(retryer.MaxAttempts()).To(Equal(20))
+		_, ok := retryer.(aws.RetryerV2)
+		Expect(ok).To(BeTrue())
+	})
+
+	DescribeTable("validating the retry settings", func(retryConfig api.RetryConfig, expectedErr string) {
+		err := eks.ValidateRetryConfig(retryConfig)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		}
+	},
+		Entry("defaults", api.RetryConfig{}, ""),
+		Entry("adaptive mode", api.RetryConfig{Mode: api.RetryModeAdaptive, MaxAttempts: 20, MaxRequestsPerSecond: 2.5}, ""),
+		Entry("unknown mode", api.RetryConfig{Mode: "legacy"}, `invalid value "legacy" for --aws-retry-mode`),
+		Entry("negative attempts", api.RetryConfig{MaxAttempts: -1}, "--aws-max-attempts must be greater than 0"),
+		Entry("negative rate", api.RetryConfig{MaxRequestsPerSecond: -1}, "--aws-max-requests-per-second must not be negative"),
+	)
+})
+
+var _ = Describe("AWS request rate limiting", func() {
+	It("limits the rate of calls across clients", func() {
+		rateLimiter := eks.AddRateLimiter(20)
+		newClient := func() *sts.Client {
+			return sts.New(sts.Options{
+				Region:      "us-west-2",
+				Credentials: awscredentials.NewStaticCredentialsProvider("key", "secret", ""),
+				APIOptions:  []func(*middleware.Stack) error{rateLimiter},
+				HTTPClient: httpClientFunc(func(*http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`<GetCallerIdentityResponse><GetCallerIdentityResult><Account>123456789012</Account></GetCallerIdentityResult></GetCallerIdentityResponse>`)),
+					}, nil
+				}),
+			})
+		}
+		clients := []*sts.Client{newClient(), newClient()}
+
+		start := time.Now()
+		// the first 20 calls use the burst of the limiter, the next 10 are limited to 20 per second
+		for i := 0; i < 30; i++ {
+			_, err := clients[i%2].GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(time.Since(start)).To(BeNumerically(">=", 450*time.Millisecond))
+	})
+})
@@ -25,7 +25,9 @@ import (
 // ServicesV2 implements api.ServicesV2.
 // The SDK clients are initialized lazily and guarded by a mutex.
 type ServicesV2 struct {
-	config aws.Config
+	config    aws.Config
+	endpoints api.ServiceEndpoints
+	retry     api.RetryConfig
 
 	// mu guards initialization of SDK clients.
 	// All service methods should ensure that their initialization is guarded by mu.
@@ -48,7 +50,7 @@ func (s *ServicesV2) STS() awsapi.STS {
 	defer s.mu.Unlock()
 	if s.sts == nil {
 		s.sts = sts.NewFromConfig(s.config, func(o *sts.Options) {
-			o.BaseEndpoint = getBaseEndpoint(sts.ServiceID, "AWS_STS_ENDPOINT")
+			o.BaseEndpoint = getBaseEndpoint(sts.ServiceID, s.endpoints.STS, "AWS_STS_ENDPOINT")
 			// Disable retryer for STS
 			// (see https://github.com/eksctl-io/eksctl/issues/705)
 			o.Retryer = aws.NopRetryer{}
@@ -79,16 +81,17 @@ func (s *ServicesV2) CloudFormation() awsapi.CloudFormation {
 	defer s.mu.Unlock()
 	if s.cloudformation == nil {
 		s.cloudformation = cloudformation.NewFromConfig(s.config, func(o *cloudformation.Options) {
-			o.BaseEndpoint = getBaseEndpoint(cloudformation.ServiceID, "AWS_CLOUDFORMATION_ENDPOINT")
+			o.BaseEndpoint = getBaseEndpoint(cloudformation.ServiceID, s.endpoints.CloudFormation, "AWS_CLOUDFORMATION_ENDPOINT")
 			// Use adaptive mode for retrying CloudFormation requests to mimic
-			// the logic used for AWS SDK v1.
-			o.Retryer = retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
-				o.StandardOptions = []func(*retry.StandardOptions){
-					func(so *retry.StandardOptions) {
-						so.MaxAttempts = maxRetries
-						so.RateLimiter = ratelimit.None
-					},
-				}
+			// the logic used for AWS SDK v1, unless a retry mode is configured.
+			retryConfig := s.retry
+			if retryConfig.Mode == "" {
+				retryConfig.Mode = api.RetryModeAdaptive
+			}
+			// Stack operations are polled for a long time, so retries must not be
+			// limited by the retry quota of the client.
+			o.Retryer = newRetryerV2(retryConfig, func(so *retry.StandardOptions) {
+				so.RateLimiter = ratelimit.None
 			})
 		})
 	}
@@ -101,7 +104,7 @@ func (s *ServicesV2) ELB() awsapi.ELB {
 	defer s.mu.Unlock()
 	if s.elasticloadbalancing == nil {
 		s.elasticloadbalancing = elasticloadbalancing.NewFromConfig(s.config, func(o *elasticloadbalancing.Options) {
-			o.BaseEndpoint = getBaseEndpoint(elasticloadbalancing.ServiceID, "AWS_ELB_ENDPOINT")
+			o.BaseEndpoint = getBaseEndpoint(elasticloadbalancing.ServiceID, s.endpoints.ELB, "AWS_ELB_ENDPOINT")
 		})
 	}
 	return s.elasticloadbalancing
@@ -113,7 +116,7 @@ func (s *ServicesV2) ELBV2() awsapi.ELBV2 {
 	defer s.mu.Unlock()
 	if s.elasticloadbalancingV2 == nil {
 		s.elasticloadbalancingV2 = elasticloadbalancingv2.NewFromConfig(s.config, func(o *elasticloadbalancingv2.Options) {
-			o.BaseEndpoint = getBaseEndpoint(elasticloadbalancingv2.ServiceID, "AWS_ELBV2_ENDPOINT")
+			o.BaseEndpoint = getBaseEndpoint(elasticloadbalancingv2.ServiceID, s.endpoints.ELBV2, "AWS_ELBV2_ENDPOINT")
 		})
 	}
 	return s.elasticloadbalancingV2
@@ -135,7 +138,7 @@ func (s *ServicesV2) IAM() awsapi.IAM {
 	defer s.mu.Unlock()
 	if s.iam == nil {
 		s.iam = iam.NewFromConfig(s.config, func(o *iam.Options) {
-			o.BaseEndpoint = getBaseEndpoint(iam.ServiceID, "AWS_IAM_ENDPOINT")
+			o.BaseEndpoint = getBaseEndpoint(iam.ServiceID, s.endpoints.IAM, "AWS_IAM_ENDPOINT")
 		})
 	}
 	return s.iam
@@ -147,7 +150,7 @@ func (s *ServicesV2) EC2() awsapi.EC2 {
 	defer s.mu.Unlock()
 	if s.ec2 == nil {
 		s.ec2 = ec2.NewFromConfig(s.config, func(o *ec2.Options) {
-			o.BaseEndpoint = getBaseEndpoint(ec2.ServiceID, "AWS_EC2_ENDPOINT")
+			o.BaseEndpoint = getBaseEndpoint(ec2.ServiceID, s.endpoints.EC2, "AWS_EC2_ENDPOINT")
 		})
 	}
 	return s.ec2
@@ -159,7 +162,7 @@ func (s *ServicesV2) EKS() awsapi.EKS {
 	defer s.mu.Unlock()
 	if s.eks == nil {
 		s.eks = eks.NewFromConfig(s.config, func(o *eks.Options) {
-			o.BaseEndpoint = getBaseEndpoint(eks.ServiceID, "AWS_EKS_ENDPOINT")
+			o.BaseEndpoint = getBaseEndpoint(eks.ServiceID, s.endpoints.EKS, "AWS_EKS_ENDPOINT")
 		})
 	}
 	return s.eks
@@ -183,8 +186,14 @@ func (s *ServicesV2) CredentialsProvider() aws.CredentialsProvider {
 	return s.config.Credentials
 }
 
-func getBaseEndpoint(serviceID, endpoint string) *string {
-	if endpoint, ok := os.LookupEnv(endpoint); ok {
+// getBaseEndpoint returns the endpoint configured for a service, falling back to the value of
+// the environment variable envName.
+func getBaseEndpoint(serviceID, configuredEndpoint, envName string) *string {
+	if configuredEndpoint != "" {
+		logger.Debug("Setting %s endpoint to %s", serviceID, configuredEndpoint)
+		return aws.String(configuredEndpoint)
+	}
+	if endpoint, ok := os.LookupEnv(envName); ok {
 		logger.Debug(
 			"Setting %s endpoint to %s", serviceID, endpoint)
 		return aws.String(endpoint)
@@ -5,9 +5,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
-
 	"github.com/weaveworks/eksctl/pkg/windows"
 
 	"github.com/kris-nova/logger"
@@ -210,25 +207,14 @@ func (c *ClusterProvider) CreateExtraClusterConfigTasks(ctx context.Context, cfg
 		}
 	}
 
-	if cfg.HasClusterCloudWatchLogging() {
-		if logRetentionDays := cfg.CloudWatch.ClusterLogging.LogRetentionInDays; logRetentionDays != 0 {
-			newTasks.Append(&clusterConfigTask{
-				info: "update CloudWatch log retention",
-				spec: cfg,
-				call: func(clusterConfig *api.ClusterConfig) error {
-					_, err := c.AWSProvider.CloudWatchLogs().PutRetentionPolicy(ctx, &cloudwatchlogs.PutRetentionPolicyInput{
-						// The format for log group name is documented here: https://docs.aws.amazon.com/eks/latest/userguide/control-plane-logs.html
-						LogGroupName:    aws.String(fmt.Sprintf("/aws/eks/%s/cluster", cfg.Metadata.Name)),
-						RetentionInDays: aws.Int32(int32(logRetentionDays)),
-					})
-					if err != nil {
-						return errors.Wrap(err, "error updating log retention settings")
-					}
-					logger.Info("set log retention to %d days for CloudWatch logging", logRetentionDays)
-					return nil
-				},
-			})
-		}
+	if cfg.HasClusterCloudWatchLogging() && cfg.CloudWatch.ClusterLogging.HasLogGroupSettings() {
+		newTasks.Append(&clusterConfigTask{
+			info: "configure CloudWatch log group",
+			spec: cfg,
+			call: func(clusterConfig *api.ClusterConfig) error {
+				return c.ConfigureClusterLogGroup(ctx, clusterConfig)
+			},
+		})
 	}
 
 	if cfg.IsFargateEnabled() {
@@ -259,7 +245,7 @@ func (c *ClusterProvider) CreateExtraClusterConfigTasks(ctx context.Context, cfg
 				if err != nil {
 					return errors.Wrap(err, "error creating rawClient")
 				}
-				m, err := iamidentitymapping.New(cfg, clientSet, rawClient, cfg.Metadata.Region)
+				m, err := iamidentitymapping.New(cfg, clientSet, rawClient, c.AWSProvider.IAM(), cfg.Metadata.Region)
 				if err != nil {
 					return errors.Wrap(err, "error initialising iamidentitymapping")
 				}
@@ -334,19 +320,19 @@ func (c *ClusterProvider) ClusterTasksForNodeGroups(cfg *api.ClusterConfig, inst
 	for _, ng := range cfg.NodeGroups {
 		clusterRequiresNeuronDevicePlugin = clusterRequiresNeuronDevicePlugin ||
 			api.HasInstanceType(ng, instanceutils.IsNeuronInstanceType)
-		// Only AL2 requires the NVIDIA device plugin
+		// Only AL2 requires the NVIDIA device plugin, unless GPUs are shared
 		clusterRequiresNvidiaDevicePlugin = clusterRequiresNvidiaDevicePlugin ||
 			(api.HasInstanceType(ng, instanceutils.IsNvidiaInstanceType) &&
-				ng.GetAMIFamily() == api.NodeImageFamilyAmazonLinux2)
+				(ng.GetAMIFamily() == api.NodeImageFamilyAmazonLinux2 || ng.GPU != nil))
 		efaEnabled = efaEnabled || api.IsEnabled(ng.EFAEnabled)
 	}
 	for _, ng := range cfg.ManagedNodeGroups {
 		clusterRequiresNeuronDevicePlugin = clusterRequiresNeuronDevicePlugin ||
 			api.HasInstanceTypeManaged(ng, instanceutils.IsNeuronInstanceType)
-		// Only AL2 requires the NVIDIA device plugin
+		// Only AL2 requires the NVIDIA device plugin, unless GPUs are shared
 		clusterRequiresNvidiaDevicePlugin = clusterRequiresNvidiaDevicePlugin ||
 			(api.HasInstanceTypeManaged(ng, instanceutils.IsNvidiaInstanceType) &&
-				ng.GetAMIFamily() == api.NodeImageFamilyAmazonLinux2)
+				(ng.GetAMIFamily() == api.NodeImageFamilyAmazonLinux2 || ng.GPU != nil))
 		efaEnabled = efaEnabled || api.IsEnabled(ng.EFAEnabled)
 	}
 	if clusterRequiresNeuronDevicePlugin {
@@ -418,11 +404,18 @@ func (c *ClusterProvider) appendCreateTasksForIAMServiceAccounts(ctx context.Con
 	// as this is non-CloudFormation context, we need to construct a new stackManager,
 	// given a clientSet getter and OpenIDConnectManager reference we can build out
 	// the list of tasks for each of the service accounts that need to be created
+	serviceAccounts, targetAccountRoles, targetAccountGroups := irsa.PartitionByTargetAccount(cfg.IAM.ServiceAccounts)
+
 	newTasks := c.NewStackManager(cfg).NewTasksToCreateIAMServiceAccounts(
-		cfg.IAM.ServiceAccounts,
+		serviceAccounts,
 		oidcPlaceholder,
 		clientSet,
 	)
+	// roles in other accounts are trusted by the OIDC provider created there, but the
+	// cluster's issuer is only known once the OIDC provider above has been associated
+	for _, roleARN := range targetAccountRoles {
+		newTasks.Append(irsa.NewTasksToCreateTargetAccountIAMServiceAccounts(ctx, c.NewIRSATargetAccount(cfg, roleARN), targetAccountGroups[roleARN], clientSet))
+	}
 	newTasks.IsSubTask = true
 	tasks.Append(newTasks)
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	. "github.com/onsi/ginkgo/v2"
@@ -168,6 +169,9 @@ var _ = Describe("EKS API wrapper", func() {
 			Expect(sentClusterLogging[1].Types).To(Equal([]ekstypes.LogType{"api", "audit", "scheduler"}))
 		})
 		It("should update logRetentionInDays in case if it's greater than 0", func() {
+			p.MockCloudWatchLogs().On("DescribeLogGroups", mock.Anything, mock.Anything).Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+				LogGroups: []cwltypes.LogGroup{{LogGroupName: aws.String(ClusterLogGroupName(cfg.Metadata.Name))}},
+			}, nil)
 			p.MockCloudWatchLogs().On("PutRetentionPolicy", mock.Anything, &cloudwatchlogs.PutRetentionPolicyInput{
 				LogGroupName:    aws.String(fmt.Sprintf("/aws/eks/%s/cluster", cfg.Metadata.Name)),
 				RetentionInDays: aws.Int32(int32(30)),
@@ -179,5 +183,55 @@ var _ = Describe("EKS API wrapper", func() {
 			Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
 			Expect(ctl.UpdateClusterConfigForLogging(context.Background(), cfg)).To(Succeed())
 		})
+
+		It("should associate the KMS key with and tag the existing log group", func() {
+			const keyARN = "arn:aws:kms:us-west-2:000000000000:key/12345678-1234-1234-1234-123456789012"
+			logGroupARN := aws.String("arn:aws:logs:us-west-2:000000000000:log-group:/aws/eks/testcluster/cluster")
+			p.MockCloudWatchLogs().On("DescribeLogGroups", mock.Anything, mock.Anything).Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+				LogGroups: []cwltypes.LogGroup{{
+					LogGroupName:    aws.String(ClusterLogGroupName(cfg.Metadata.Name)),
+					LogGroupArn:     logGroupARN,
+					RetentionInDays: aws.Int32(30),
+				}},
+			}, nil)
+			p.MockCloudWatchLogs().On("AssociateKmsKey", mock.Anything, &cloudwatchlogs.AssociateKmsKeyInput{
+				LogGroupName: aws.String(ClusterLogGroupName(cfg.Metadata.Name)),
+				KmsKeyId:     aws.String(keyARN),
+			}).Return(&cloudwatchlogs.AssociateKmsKeyOutput{}, nil)
+			p.MockCloudWatchLogs().On("TagResource", mock.Anything, &cloudwatchlogs.TagResourceInput{
+				ResourceArn: logGroupARN,
+				Tags:        map[string]string{"team": "platform"},
+			}).Return(&cloudwatchlogs.TagResourceOutput{}, nil)
+			cfg.CloudWatch.ClusterLogging.EnableTypes = []string{"api"}
+			cfg.CloudWatch.ClusterLogging.LogRetentionInDays = 30
+			cfg.CloudWatch.ClusterLogging.LogGroupKMSKeyARN = keyARN
+			cfg.CloudWatch.ClusterLogging.LogGroupTags = map[string]string{"team": "platform"}
+
+			api.SetClusterConfigDefaults(cfg)
+			Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
+			Expect(ctl.ConfigureClusterLogGroup(context.Background(), cfg)).To(Succeed())
+			p.MockCloudWatchLogs().AssertNotCalled(GinkgoT(), "PutRetentionPolicy", mock.Anything, mock.Anything)
+		})
+
+		It("should create the log group when EKS has not created it yet", func() {
+			const keyARN = "arn:aws:kms:us-west-2:000000000000:key/12345678-1234-1234-1234-123456789012"
+			p.MockCloudWatchLogs().On("DescribeLogGroups", mock.Anything, mock.Anything).Return(&cloudwatchlogs.DescribeLogGroupsOutput{}, nil)
+			p.MockCloudWatchLogs().On("CreateLogGroup", mock.Anything, &cloudwatchlogs.CreateLogGroupInput{
+				LogGroupName: aws.String(ClusterLogGroupName(cfg.Metadata.Name)),
+				KmsKeyId:     aws.String(keyARN),
+				Tags:         map[string]string{"team": "platform"},
+			}).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil)
+			p.MockCloudWatchLogs().On("PutRetentionPolicy", mock.Anything, &cloudwatchlogs.PutRetentionPolicyInput{
+				LogGroupName:    aws.String(ClusterLogGroupName(cfg.Metadata.Name)),
+				RetentionInDays: aws.Int32(int32(7)),
+			}).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil)
+			cfg.CloudWatch.ClusterLogging.EnableTypes = []string{"api"}
+			cfg.CloudWatch.ClusterLogging.LogRetentionInDays = 7
+			cfg.CloudWatch.ClusterLogging.LogGroupKMSKeyARN = keyARN
+			cfg.CloudWatch.ClusterLogging.LogGroupTags = map[string]string{"team": "platform"}
+
+			Expect(ctl.ConfigureClusterLogGroup(context.Background(), cfg)).To(Succeed())
+			p.MockCloudWatchLogs().AssertExpectations(GinkgoT())
+		})
 	})
 })
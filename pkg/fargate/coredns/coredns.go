@@ -43,7 +43,7 @@ func IsSchedulableOnFargate(profiles []*api.FargateProfile) bool {
 }
 
 func selectsCoreDNS(selector api.FargateProfileSelector) bool {
-	return selector.Namespace == Namespace && len(selector.Labels) == 0
+	return selector.MatchesNamespace(Namespace) && len(selector.Labels) == 0
 }
 
 // IsScheduledOnFargate checks if EKS' coredns is scheduled onto Fargate.
@@ -0,0 +1,153 @@
+package fargate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	// LoggingNamespace is the namespace the log router built into Fargate reads its configuration from
+	LoggingNamespace = "aws-observability"
+	// LoggingConfigMapName is the name of the ConfigMap holding the configuration of the log router
+	LoggingConfigMapName = "aws-logging"
+
+	defaultLogStreamPrefix = "fargate-"
+	defaultOpenSearchIndex = "fargate"
+)
+
+// MakeLoggingConfigMap returns the aws-logging ConfigMap sending the logs of the pods running on Fargate
+// to the destination configured in clusterConfig.FargateLogging
+func MakeLoggingConfigMap(clusterConfig *api.ClusterConfig) *corev1.ConfigMap {
+	logging := clusterConfig.FargateLogging
+	region := clusterConfig.Metadata.Region
+
+	var output []string
+	switch logging.Destination {
+	case api.FargateLoggingFirehose:
+		output = []string{
+			"Name kinesis_firehose",
+			"Match *",
+			"region " + region,
+			"delivery_stream " + logging.DeliveryStream,
+		}
+	case api.FargateLoggingOpenSearch:
+		index := logging.OpenSearchIndex
+		if index == "" {
+			index = defaultOpenSearchIndex
+		}
+		output = []string{
+			"Name es",
+			"Match *",
+			"Host " + logging.OpenSearchEndpoint,
+			"Port 443",
+			"Index " + index,
+			"AWS_Auth On",
+			"AWS_Region " + region,
+			"tls On",
+		}
+	default:
+		logGroupName := logging.LogGroupName
+		if logGroupName == "" {
+			logGroupName = fmt.Sprintf("/aws/eks/%s/fargate", clusterConfig.Metadata.Name)
+		}
+		logStreamPrefix := logging.LogStreamPrefix
+		if logStreamPrefix == "" {
+			logStreamPrefix = defaultLogStreamPrefix
+		}
+		output = []string{
+			"Name cloudwatch_logs",
+			"Match *",
+			"region " + region,
+			"log_group_name " + logGroupName,
+			"log_stream_prefix " + logStreamPrefix,
+			"auto_create_group true",
+		}
+	}
+
+	data := map[string]string{
+		"output.conf": "[OUTPUT]\n    " + strings.Join(output, "\n    ") + "\n",
+	}
+	if logging.Filters != "" {
+		data["filters.conf"] = logging.Filters
+	}
+	if logging.Parsers != "" {
+		data["parsers.conf"] = logging.Parsers
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LoggingConfigMapName,
+			Namespace: LoggingNamespace,
+		},
+		Data: data,
+	}
+}
+
+// WarnLoggingPermissions warns about the pod execution roles eksctl does not allow to write to the destination of the
+// logs: the roles set in the podExecutionRoleARN of the profiles, and the default pod execution role unless eksctl
+// creates it along with the logging policy
+func WarnLoggingPermissions(clusterConfig *api.ClusterConfig, defaultRoleHasPolicy bool) {
+	if clusterConfig.FargateLogging == nil {
+		return
+	}
+	var roles []string
+	seen := map[string]bool{}
+	addRole := func(role string) {
+		if !seen[role] {
+			seen[role] = true
+			roles = append(roles, role)
+		}
+	}
+	for _, profile := range clusterConfig.FargateProfiles {
+		switch {
+		case profile.PodExecutionRoleARN != "":
+			addRole(profile.PodExecutionRoleARN)
+		case !defaultRoleHasPolicy && api.IsSetAndNonEmptyString(clusterConfig.IAM.FargatePodExecutionRoleARN):
+			addRole(*clusterConfig.IAM.FargatePodExecutionRoleARN)
+		case !defaultRoleHasPolicy:
+			addRole("the default Fargate pod execution role")
+		}
+	}
+	for _, role := range roles {
+		logger.Warning("eksctl does not allow %s to send logs to %s, grant it the permissions listed in "+
+			"https://docs.aws.amazon.com/eks/latest/userguide/fargate-logging.html", role, clusterConfig.FargateLogging.Destination)
+	}
+}
+
+// ConfigureLogging creates the aws-observability namespace and creates or updates the aws-logging ConfigMap,
+// if logging is configured; pods only pick up the configuration when they start
+func ConfigureLogging(ctx context.Context, clusterConfig *api.ClusterConfig, clientSet kubernetes.Interface) error {
+	if clusterConfig.FargateLogging == nil {
+		return nil
+	}
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   LoggingNamespace,
+			Labels: map[string]string{LoggingNamespace: "enabled"},
+		},
+	}
+	if _, err := clientSet.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "creating namespace %q", LoggingNamespace)
+	}
+
+	configMap := MakeLoggingConfigMap(clusterConfig)
+	configMaps := clientSet.CoreV1().ConfigMaps(LoggingNamespace)
+	_, err := configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return errors.Wrapf(err, "applying ConfigMap %s/%s", LoggingNamespace, LoggingConfigMapName)
+	}
+	logger.Info("configured Fargate to send logs to %s", clusterConfig.FargateLogging.Destination)
+	return nil
+}
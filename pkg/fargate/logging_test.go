@@ -0,0 +1,129 @@
+package fargate_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kris-nova/logger"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+)
+
+var _ = Describe("Fargate logging", func() {
+	var clusterConfig *api.ClusterConfig
+
+	BeforeEach(func() {
+		clusterConfig = api.NewClusterConfig()
+		clusterConfig.Metadata.Name = "test-cluster"
+		clusterConfig.Metadata.Region = "us-west-2"
+	})
+
+	DescribeTable("renders the output of the log router",
+		func(logging *api.FargateLogging, expectedOutput string) {
+			clusterConfig.FargateLogging = logging
+			configMap := fargate.MakeLoggingConfigMap(clusterConfig)
+			Expect(configMap.Data).To(Equal(map[string]string{"output.conf": expectedOutput}))
+		},
+		Entry("CloudWatch with defaults", &api.FargateLogging{Destination: api.FargateLoggingCloudWatch}, `[OUTPUT]
+    Name cloudwatch_logs
+    Match *
+    region us-west-2
+    log_group_name /aws/eks/test-cluster/fargate
+    log_stream_prefix fargate-
+    auto_create_group true
+`),
+		Entry("Firehose", &api.FargateLogging{Destination: api.FargateLoggingFirehose, DeliveryStream: "fargate-logs"}, `[OUTPUT]
+    Name kinesis_firehose
+    Match *
+    region us-west-2
+    delivery_stream fargate-logs
+`),
+		Entry("OpenSearch", &api.FargateLogging{
+			Destination:        api.FargateLoggingOpenSearch,
+			OpenSearchEndpoint: "search-logs.us-west-2.es.amazonaws.com",
+			OpenSearchIndex:    "pods",
+		}, `[OUTPUT]
+    Name es
+    Match *
+    Host search-logs.us-west-2.es.amazonaws.com
+    Port 443
+    Index pods
+    AWS_Auth On
+    AWS_Region us-west-2
+    tls On
+`),
+	)
+
+	It("creates the namespace and updates an existing ConfigMap", func() {
+		clusterConfig.FargateLogging = &api.FargateLogging{
+			Destination: api.FargateLoggingCloudWatch,
+			Filters:     "[FILTER]\n    Name grep\n    Match *\n    Exclude log healthz\n",
+		}
+		clientSet := fake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: fargate.LoggingConfigMapName, Namespace: fargate.LoggingNamespace},
+			Data:       map[string]string{"output.conf": "outdated"},
+		})
+		Expect(fargate.ConfigureLogging(context.Background(), clusterConfig, clientSet)).To(Succeed())
+
+		namespace, err := clientSet.CoreV1().Namespaces().Get(context.Background(), fargate.LoggingNamespace, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespace.Labels).To(HaveKeyWithValue("aws-observability", "enabled"))
+
+		configMap, err := clientSet.CoreV1().ConfigMaps(fargate.LoggingNamespace).Get(context.Background(), fargate.LoggingConfigMapName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configMap.Data["output.conf"]).To(ContainSubstring("Name cloudwatch_logs"))
+		Expect(configMap.Data).To(HaveKeyWithValue("filters.conf", clusterConfig.FargateLogging.Filters))
+	})
+
+	It("does nothing when logging is not configured", func() {
+		clientSet := fake.NewSimpleClientset()
+		Expect(fargate.ConfigureLogging(context.Background(), clusterConfig, clientSet)).To(Succeed())
+		namespaces, err := clientSet.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaces.Items).To(BeEmpty())
+	})
+
+	Describe("WarnLoggingPermissions", func() {
+		var output *bytes.Buffer
+
+		BeforeEach(func() {
+			output = &bytes.Buffer{}
+			originalWriter := logger.Writer
+			logger.Writer = output
+			DeferCleanup(func() {
+				logger.Writer = originalWriter
+			})
+			clusterConfig.FargateLogging = &api.FargateLogging{Destination: api.FargateLoggingCloudWatch}
+			clusterConfig.FargateProfiles = []*api.FargateProfile{
+				{Name: "fp-default"},
+				{Name: "fp-custom", PodExecutionRoleARN: "arn:aws:iam::111122223333:role/custom"},
+			}
+		})
+
+		It("warns about the roles set in podExecutionRoleARN", func() {
+			fargate.WarnLoggingPermissions(clusterConfig, true)
+			Expect(output.String()).To(ContainSubstring("eksctl does not allow arn:aws:iam::111122223333:role/custom to send logs to cloudwatch"))
+			Expect(output.String()).NotTo(ContainSubstring("default Fargate pod execution role"))
+		})
+
+		It("warns about the default role when it is not created with the logging policy", func() {
+			clusterConfig.IAM.FargatePodExecutionRoleARN = aws.String("arn:aws:iam::111122223333:role/existing")
+			fargate.WarnLoggingPermissions(clusterConfig, false)
+			Expect(output.String()).To(ContainSubstring("eksctl does not allow arn:aws:iam::111122223333:role/existing to send logs"))
+			Expect(output.String()).To(ContainSubstring("eksctl does not allow arn:aws:iam::111122223333:role/custom to send logs"))
+		})
+
+		It("does not warn when logging is not configured", func() {
+			clusterConfig.FargateLogging = nil
+			fargate.WarnLoggingPermissions(clusterConfig, false)
+			Expect(output.String()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,124 @@
+package fargate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// temporaryProfileSuffix is appended to the name of the profile holding the new configuration of a Fargate profile
+// while the profile is recreated.
+const temporaryProfileSuffix = "-update"
+
+// TemporaryProfileName returns the name of the temporary profile used to update the provided profile.
+func TemporaryProfileName(profileName string) string {
+	return profileName + temporaryProfileSuffix
+}
+
+// ProfileChanges returns the fields of the existing Fargate profile which differ from the provided profile. The pod
+// execution role ARN, subnets and tags which are not set in the provided profile are left unchanged.
+func ProfileChanges(existing, profile *api.FargateProfile) []string {
+	var changes []string
+	if !selectorsEqual(existing.Selectors, profile.Selectors) {
+		changes = append(changes, "selectors")
+	}
+	if profile.PodExecutionRoleARN != "" && profile.PodExecutionRoleARN != existing.PodExecutionRoleARN {
+		changes = append(changes, "podExecutionRoleARN")
+	}
+	if len(profile.Subnets) > 0 && !stringSetsEqual(profile.Subnets, existing.Subnets) {
+		changes = append(changes, "subnets")
+	}
+	if len(profile.Tags) > 0 && !reflect.DeepEqual(profile.Tags, existing.Tags) {
+		changes = append(changes, "tags")
+	}
+	return changes
+}
+
+// UpdateProfile replaces the existing Fargate profile with the provided profile of the same name, as Fargate
+// profiles cannot be changed. To avoid a time during which pods cannot be scheduled on Fargate, a temporary profile
+// with the new configuration is created before the existing profile is deleted, and is only deleted once the profile
+// has been recreated. EKS creates or deletes one Fargate profile of a cluster at a time, so each step waits for the
+// previous one to complete.
+func (c *Client) UpdateProfile(ctx context.Context, existing, profile *api.FargateProfile) error {
+	updated := *profile
+	if updated.PodExecutionRoleARN == "" {
+		updated.PodExecutionRoleARN = existing.PodExecutionRoleARN
+	}
+	if len(updated.Subnets) == 0 {
+		updated.Subnets = existing.Subnets
+	}
+	if len(updated.Tags) == 0 {
+		updated.Tags = existing.Tags
+	}
+
+	temporary := updated
+	temporary.Name = TemporaryProfileName(profile.Name)
+	logger.Info("creating temporary Fargate profile %q with the new configuration of Fargate profile %q", temporary.Name, profile.Name)
+	if err := c.CreateProfile(ctx, &temporary, true); err != nil {
+		return err
+	}
+
+	logger.Info("deleting Fargate profile %q", profile.Name)
+	if err := c.DeleteProfile(ctx, profile.Name, true); err != nil {
+		return errors.Wrapf(err, "failed to update Fargate profile %q, temporary Fargate profile %q schedules its pods in the meantime", profile.Name, temporary.Name)
+	}
+
+	logger.Info("recreating Fargate profile %q", profile.Name)
+	if err := c.CreateProfile(ctx, &updated, true); err != nil {
+		return errors.Wrapf(err, "failed to update Fargate profile %q, temporary Fargate profile %q schedules its pods in the meantime", profile.Name, temporary.Name)
+	}
+
+	logger.Info("deleting temporary Fargate profile %q", temporary.Name)
+	if err := c.DeleteProfile(ctx, temporary.Name, true); err != nil {
+		return errors.Wrapf(err, "updated Fargate profile %q, but failed to delete temporary Fargate profile %q", profile.Name, temporary.Name)
+	}
+	return nil
+}
+
+func selectorsEqual(a, b []api.FargateProfileSelector) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sorted := func(selectors []api.FargateProfileSelector) []api.FargateProfileSelector {
+		out := make([]api.FargateProfileSelector, len(selectors))
+		copy(out, selectors)
+		sort.Slice(out, func(i, j int) bool {
+			return fmt.Sprint(out[i].Namespace, out[i].Labels) < fmt.Sprint(out[j].Namespace, out[j].Labels)
+		})
+		return out
+	}
+	a, b = sorted(a), sorted(b)
+	for i := range a {
+		if a[i].Namespace != b[i].Namespace || len(a[i].Labels) != len(b[i].Labels) {
+			return false
+		}
+		for k, v := range a[i].Labels {
+			if b[i].Labels[k] != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func stringSetsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := map[string]bool{}
+	for _, s := range a {
+		set[s] = true
+	}
+	for _, s := range b {
+		if !set[s] {
+			return false
+		}
+	}
+	return true
+}
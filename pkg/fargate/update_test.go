@@ -0,0 +1,117 @@
+package fargate_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks/mocksv2"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+	"github.com/weaveworks/eksctl/pkg/utils/retry"
+)
+
+var _ = Describe("Fargate profile update", func() {
+	existingProfile := func() *api.FargateProfile {
+		return &api.FargateProfile{
+			Name:                "default",
+			Selectors:           []api.FargateProfileSelector{{Namespace: "default"}, {Namespace: "kube-system"}},
+			PodExecutionRoleARN: "arn:aws:iam::123456789012:role/fargate",
+			Subnets:             []string{"subnet-1", "subnet-2"},
+		}
+	}
+
+	Describe("ProfileChanges", func() {
+		It("ignores the order of selectors and subnets, and the fields which are not set", func() {
+			profile := &api.FargateProfile{
+				Name:      "default",
+				Selectors: []api.FargateProfileSelector{{Namespace: "kube-system"}, {Namespace: "default"}},
+			}
+			Expect(fargate.ProfileChanges(existingProfile(), profile)).To(BeEmpty())
+			profile.Subnets = []string{"subnet-2", "subnet-1"}
+			Expect(fargate.ProfileChanges(existingProfile(), profile)).To(BeEmpty())
+		})
+
+		It("returns the changed fields", func() {
+			profile := &api.FargateProfile{
+				Name:                "default",
+				Selectors:           []api.FargateProfileSelector{{Namespace: "prod-*"}},
+				PodExecutionRoleARN: "arn:aws:iam::123456789012:role/fargate-prod",
+				Tags:                map[string]string{"env": "prod"},
+			}
+			Expect(fargate.ProfileChanges(existingProfile(), profile)).To(Equal([]string{"selectors", "podExecutionRoleARN", "tags"}))
+		})
+	})
+
+	Describe("UpdateProfile", func() {
+		It("creates a temporary profile with the new configuration before recreating the profile", func() {
+			mockClient := &mocksv2.EKS{}
+			createdProfile := func(name string) interface{} {
+				return mock.MatchedBy(func(input *eks.CreateFargateProfileInput) bool {
+					return *input.FargateProfileName == name &&
+						*input.Selectors[0].Namespace == "prod-*" &&
+						*input.PodExecutionRoleArn == "arn:aws:iam::123456789012:role/fargate" &&
+						len(input.Subnets) == 2
+				})
+			}
+			mockClient.Mock.On("CreateFargateProfile", mock.Anything, createdProfile("default-update")).Return(&eks.CreateFargateProfileOutput{}, nil).Once()
+			mockDescribeFargateProfile(mockClient, "default-update", "ACTIVE")
+			mockDeleteFargateProfile(mockClient, "default")
+			mockListFargateProfiles(mockClient, "default-update")
+			mockClient.Mock.On("CreateFargateProfile", mock.Anything, createdProfile("default")).Return(&eks.CreateFargateProfileOutput{}, nil).Once()
+			mockDescribeFargateProfile(mockClient, "default", "ACTIVE")
+			mockDeleteFargateProfile(mockClient, "default-update")
+			mockListFargateProfiles(mockClient, "default")
+
+			retryPolicy := &retry.ConstantBackoff{Time: 0, TimeUnit: time.Second, MaxRetries: 1}
+			client := fargate.NewWithRetryPolicy(clusterName, mockClient, retryPolicy, nil)
+			profile := &api.FargateProfile{
+				Name:      "default",
+				Selectors: []api.FargateProfileSelector{{Namespace: "prod-*"}},
+			}
+			Expect(client.UpdateProfile(context.Background(), existingProfile(), profile)).To(Succeed())
+
+			var calls []string
+			for _, call := range mockClient.Calls {
+				switch input := call.Arguments[1].(type) {
+				case *eks.CreateFargateProfileInput:
+					calls = append(calls, "create "+*input.FargateProfileName)
+				case *eks.DeleteFargateProfileInput:
+					calls = append(calls, "delete "+*input.FargateProfileName)
+				}
+			}
+			Expect(calls).To(Equal([]string{"create default-update", "delete default", "create default", "delete default-update"}))
+		})
+
+		It("keeps the temporary profile when the profile cannot be recreated", func() {
+			mockClient := &mocksv2.EKS{}
+			mockClient.Mock.On("CreateFargateProfile", mock.Anything, mock.MatchedBy(func(input *eks.CreateFargateProfileInput) bool {
+				return *input.FargateProfileName == "default-update"
+			})).Return(&eks.CreateFargateProfileOutput{}, nil).Once()
+			mockDescribeFargateProfile(mockClient, "default-update", "ACTIVE")
+			mockDeleteFargateProfile(mockClient, "default")
+			mockListFargateProfiles(mockClient, "default-update")
+			mockClient.Mock.On("CreateFargateProfile", mock.Anything, mock.MatchedBy(func(input *eks.CreateFargateProfileInput) bool {
+				return *input.FargateProfileName == "default"
+			})).Return(nil, &ekstypes.ResourceLimitExceededException{Message: aws.String("too many profiles")}).Once()
+
+			retryPolicy := &retry.ConstantBackoff{Time: 0, TimeUnit: time.Second, MaxRetries: 1}
+			client := fargate.NewWithRetryPolicy(clusterName, mockClient, retryPolicy, nil)
+			err := client.UpdateProfile(context.Background(), existingProfile(), &api.FargateProfile{
+				Name:      "default",
+				Selectors: []api.FargateProfileSelector{{Namespace: "prod-*"}},
+			})
+			Expect(err).To(MatchError(ContainSubstring(`failed to update Fargate profile "default", temporary Fargate profile "default-update" schedules its pods in the meantime`)))
+			mockClient.AssertNotCalled(GinkgoT(), "DeleteFargateProfile", mock.Anything, &eks.DeleteFargateProfileInput{
+				ClusterName:        aws.String(clusterName),
+				FargateProfileName: aws.String("default-update"),
+			})
+		})
+	})
+})
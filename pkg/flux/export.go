@@ -0,0 +1,215 @@
+package flux
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultNamespace = "flux-system"
+	defaultBranch    = "main"
+	// syncName is the name of the GitRepository, Kustomization and Secret created by Flux bootstrap
+	syncName = "flux-system"
+
+	componentsFile    = "gotk-components.yaml"
+	syncFile          = "gotk-sync.yaml"
+	kustomizationFile = "kustomization.yaml"
+)
+
+// defaultHostnames are the hostnames of the repositories of the Git providers Flux bootstrap supports by default
+var defaultHostnames = map[string]string{
+	"github": "github.com",
+	"gitlab": "gitlab.com",
+}
+
+// Export writes the files Flux bootstrap would commit to the flux-system directory of the repository to dir, and
+// a template of the secret holding the credentials of the repository to secretWriter, without accessing the cluster
+// or the repository. The secret is kept out of dir, as it must not be committed.
+func (c *Client) Export(dir string, secretWriter io.Writer) error {
+	if _, err := exec.LookPath(fluxBin); err != nil {
+		return errors.New("flux not found, required")
+	}
+	if err := c.checkFluxVersion(); err != nil {
+		return err
+	}
+
+	namespace := c.flag("namespace", defaultNamespace)
+	url, err := c.repositoryURL()
+	if err != nil {
+		return err
+	}
+
+	args := append([]string{"install", "--export", "--namespace", namespace}, c.componentArgs()...)
+	logger.Debug(fmt.Sprintf("running flux %v ", args))
+	components, err := c.executor.ExecWithOut(fluxBin, args...)
+	if err != nil {
+		return fmt.Errorf("rendering the Flux components: %w", err)
+	}
+
+	metadata := map[string]interface{}{
+		"name":      syncName,
+		"namespace": namespace,
+	}
+	sync, err := marshalManifests(c.syncManifests(metadata, url)...)
+	if err != nil {
+		return err
+	}
+	kustomization, err := marshalManifests(map[string]interface{}{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  []string{componentsFile, syncFile},
+	})
+	if err != nil {
+		return err
+	}
+	secret, err := marshalManifests(secretTemplate(metadata, url, c.flag("username", "git")))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating directory %s: %w", dir, err)
+	}
+	for name, data := range map[string][]byte{
+		componentsFile:    components,
+		syncFile:          sync,
+		kustomizationFile: kustomization,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+	}
+	_, err = secretWriter.Write(secret)
+	return err
+}
+
+// marshalManifests returns the YAML documents of manifests
+func marshalManifests(manifests ...map[string]interface{}) ([]byte, error) {
+	var out []byte
+	for _, manifest := range manifests {
+		data, err := yaml.Marshal(manifest)
+		if err != nil {
+			return nil, err
+		}
+		out = append(append(out, "---\n"...), data...)
+	}
+	return out, nil
+}
+
+// flag returns the value of a Flux bootstrap flag, or its default value
+func (c *Client) flag(name, defaultValue string) string {
+	if value, ok := c.opts.Flags[name]; ok && value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// repositoryURL returns the URL Flux syncs the repository from, as set by Flux bootstrap
+func (c *Client) repositoryURL() (string, error) {
+	if c.opts.GitProvider == "git" {
+		url := c.flag("url", "")
+		if url == "" {
+			return "", errors.New("gitops.flux.flags.url must be set")
+		}
+		return url, nil
+	}
+	hostname := c.flag("hostname", defaultHostnames[c.opts.GitProvider])
+	if hostname == "" {
+		return "", fmt.Errorf("exporting the manifests of Flux bootstrap %s requires gitops.flux.flags.hostname to be set", c.opts.GitProvider)
+	}
+	owner, repository := c.flag("owner", ""), c.flag("repository", "")
+	if owner == "" || repository == "" {
+		return "", errors.New("gitops.flux.flags.owner and gitops.flux.flags.repository must be set")
+	}
+	if c.tokenAuth() {
+		return fmt.Sprintf("https://%s/%s/%s", hostname, owner, repository), nil
+	}
+	return fmt.Sprintf("ssh://git@%s/%s/%s", hostname, owner, repository), nil
+}
+
+// tokenAuth reports whether the repository is synced over HTTPS with the token of the Git provider
+func (c *Client) tokenAuth() bool {
+	if c.flag("token-auth", "false") == "true" {
+		return true
+	}
+	for _, arg := range c.opts.ExtraArgs {
+		if arg == "--token-auth" || arg == "--token-auth=true" {
+			return true
+		}
+	}
+	return false
+}
+
+// syncManifests returns the GitRepository and Kustomization syncing the cluster
+func (c *Client) syncManifests(metadata map[string]interface{}, url string) []map[string]interface{} {
+	path := c.flag("path", "")
+	if !strings.HasPrefix(path, "./") {
+		path = "./" + strings.TrimPrefix(path, "/")
+	}
+	kustomizationSpec := map[string]interface{}{
+		"interval": "10m0s",
+		"path":     path,
+		"prune":    true,
+		"sourceRef": map[string]interface{}{
+			"kind": "GitRepository",
+			"name": syncName,
+		},
+	}
+	if len(c.opts.Patches) > 0 {
+		kustomizationSpec["patches"] = c.opts.Patches
+	}
+
+	return []map[string]interface{}{
+		{
+			"apiVersion": "source.toolkit.fluxcd.io/v1",
+			"kind":       "GitRepository",
+			"metadata":   metadata,
+			"spec": map[string]interface{}{
+				"interval": "1m0s",
+				"ref": map[string]interface{}{
+					"branch": c.flag("branch", defaultBranch),
+				},
+				"secretRef": map[string]interface{}{
+					"name": syncName,
+				},
+				"url": url,
+			},
+		},
+		{
+			"apiVersion": "kustomize.toolkit.fluxcd.io/v1",
+			"kind":       "Kustomization",
+			"metadata":   metadata,
+			"spec":       kustomizationSpec,
+		},
+	}
+}
+
+// secretTemplate returns the secret of the GitRepository, with placeholders for the credentials of the repository
+func secretTemplate(metadata map[string]interface{}, url, username string) map[string]interface{} {
+	secretData := map[string]interface{}{
+		"identity":     "<SSH private key>",
+		"identity.pub": "<SSH public key>",
+		"known_hosts":  "<SSH known hosts of the Git server>",
+	}
+	if strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://") {
+		secretData = map[string]interface{}{
+			"username": username,
+			"password": "<token or password>",
+		}
+	}
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   metadata,
+		"type":       "Opaque",
+		"stringData": secretData,
+	}
+}
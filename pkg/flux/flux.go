@@ -14,7 +14,7 @@ import (
 
 const (
 	fluxBin             = "flux"
-	minSupportedVersion = "0.32.0"
+	minSupportedVersion = "2.0.0"
 )
 
 type Client struct {
@@ -56,9 +56,30 @@ func (c *Client) Bootstrap() error {
 		args = append(args, fmt.Sprintf("--%s", k), v)
 	}
 
+	args = append(args, c.componentArgs()...)
+	args = append(args, c.opts.ExtraArgs...)
+
 	return c.runFluxCmd(args...)
 }
 
+// componentArgs returns the flags selecting the Flux controllers and the registry of their images
+func (c *Client) componentArgs() []string {
+	var args []string
+	if len(c.opts.Components) > 0 {
+		args = append(args, "--components", strings.Join(c.opts.Components, ","))
+	}
+	if len(c.opts.ComponentsExtra) > 0 {
+		args = append(args, "--components-extra", strings.Join(c.opts.ComponentsExtra, ","))
+	}
+	if c.opts.Registry != "" {
+		args = append(args, "--registry", c.opts.Registry)
+	}
+	if c.opts.ImagePullSecret != "" {
+		args = append(args, "--image-pull-secret", c.opts.ImagePullSecret)
+	}
+	return args
+}
+
 func (c *Client) runFluxCmd(args ...string) error {
 	logger.Debug(fmt.Sprintf("running flux %v ", args))
 	return c.executor.Exec(fluxBin, args...)
@@ -1,6 +1,7 @@
 package flux_test
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"path/filepath"
@@ -31,7 +32,7 @@ var _ = Describe("Flux", func() {
 		fluxClient, err = flux.NewClient(opts)
 		Expect(err).NotTo(HaveOccurred())
 		fluxClient.SetExecutor(fakeExecutor)
-		fakeExecutor.ExecWithOutReturns([]byte("flux version 2.0.0-rc.1\n"), nil)
+		fakeExecutor.ExecWithOutReturns([]byte("flux version 2.0.0\n"), nil)
 
 		binDir, err := os.MkdirTemp("", "bin")
 		Expect(err).NotTo(HaveOccurred())
@@ -90,13 +91,13 @@ var _ = Describe("Flux", func() {
 		})
 
 		Context("checking the flux version", func() {
-			When("the flux version is < 0.32.0", func() {
+			When("the flux version is < 2.0.0", func() {
 				BeforeEach(func() {
-					fakeExecutor.ExecWithOutReturns([]byte("flux version 0.31.5\n"), nil)
+					fakeExecutor.ExecWithOutReturns([]byte("flux version 0.41.2\n"), nil)
 				})
 
 				It("returns an error saying older versions are not supported", func() {
-					Expect(fluxClient.PreFlight()).To(MatchError(ContainSubstring("found flux version 0.31.5, eksctl requires >= 0.32.0")))
+					Expect(fluxClient.PreFlight()).To(MatchError(ContainSubstring("found flux version 0.41.2, eksctl requires >= 2.0.0")))
 				})
 			})
 
@@ -162,6 +163,27 @@ var _ = Describe("Flux", func() {
 			})
 		})
 
+		When("components, registry and extra args are set", func() {
+			BeforeEach(func() {
+				opts.ComponentsExtra = []string{"image-reflector-controller", "image-automation-controller"}
+				opts.Registry = "registry.example.com/fluxcd"
+				opts.ImagePullSecret = "registry-credentials"
+				opts.ExtraArgs = []string{"--token-auth", "--ca-file=/etc/ssl/git.pem"}
+			})
+
+			It("appends them to the command args", func() {
+				Expect(fluxClient.Bootstrap()).To(Succeed())
+				Expect(fakeExecutor.ExecCallCount()).To(Equal(1))
+				_, receivedArgs := fakeExecutor.ExecArgsForCall(0)
+				Expect(receivedArgs).To(Equal(append(standardArgs,
+					"--components-extra", "image-reflector-controller,image-automation-controller",
+					"--registry", "registry.example.com/fluxcd",
+					"--image-pull-secret", "registry-credentials",
+					"--token-auth", "--ca-file=/etc/ssl/git.pem",
+				)))
+			})
+		})
+
 		When("execution fails", func() {
 			BeforeEach(func() {
 				fakeExecutor.ExecReturns(errors.New("omg"))
@@ -173,4 +195,83 @@ var _ = Describe("Flux", func() {
 			})
 		})
 	})
+
+	Context("Export", func() {
+		var (
+			dir    string
+			secret *bytes.Buffer
+		)
+
+		readFile := func(name string) string {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			Expect(err).NotTo(HaveOccurred())
+			return string(data)
+		}
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "flux-system")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+			secret = &bytes.Buffer{}
+			opts.Flags = api.FluxFlags{
+				"owner":      "org",
+				"repository": "fleet",
+				"path":       "clusters/cluster-1",
+			}
+			opts.ComponentsExtra = []string{"image-reflector-controller"}
+			fakeExecutor.ExecWithOutReturnsOnCall(1, []byte("---\napiVersion: v1\nkind: Namespace\n"), nil)
+		})
+
+		It("writes the files of the flux-system directory without running bootstrap", func() {
+			Expect(fluxClient.Export(dir, secret)).To(Succeed())
+			Expect(fakeExecutor.ExecCallCount()).To(Equal(0))
+			Expect(fakeExecutor.ExecWithOutCallCount()).To(Equal(2))
+			_, receivedArgs := fakeExecutor.ExecWithOutArgsForCall(1)
+			Expect(receivedArgs).To(Equal([]string{"install", "--export", "--namespace", "flux-system", "--components-extra", "image-reflector-controller"}))
+
+			entries, err := os.ReadDir(dir)
+			Expect(err).NotTo(HaveOccurred())
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			Expect(names).To(ConsistOf("gotk-components.yaml", "gotk-sync.yaml", "kustomization.yaml"))
+
+			Expect(readFile("gotk-components.yaml")).To(Equal("---\napiVersion: v1\nkind: Namespace\n"))
+			sync := readFile("gotk-sync.yaml")
+			Expect(sync).To(ContainSubstring("url: ssh://git@github.com/org/fleet"))
+			Expect(sync).To(ContainSubstring("path: ./clusters/cluster-1"))
+			Expect(sync).NotTo(ContainSubstring("kind: Secret"))
+			Expect(readFile("kustomization.yaml")).To(ContainSubstring("resources:\n- gotk-components.yaml\n- gotk-sync.yaml\n"))
+
+			Expect(secret.String()).To(ContainSubstring("kind: Secret"))
+			Expect(secret.String()).To(ContainSubstring("identity: <SSH private key>"))
+		})
+
+		When("the repository is synced with a token", func() {
+			BeforeEach(func() {
+				opts.ExtraArgs = []string{"--token-auth"}
+			})
+
+			It("renders an HTTPS repository and a password secret template", func() {
+				Expect(fluxClient.Export(dir, secret)).To(Succeed())
+				Expect(readFile("gotk-sync.yaml")).To(ContainSubstring("url: https://github.com/org/fleet"))
+				Expect(secret.String()).To(ContainSubstring("password: <token or password>"))
+			})
+		})
+
+		When("the owner of the repository is not set", func() {
+			BeforeEach(func() {
+				delete(opts.Flags, "owner")
+			})
+
+			It("returns an error without writing any file", func() {
+				Expect(fluxClient.Export(dir, secret)).To(MatchError("gitops.flux.flags.owner and gitops.flux.flags.repository must be set"))
+				entries, err := os.ReadDir(dir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(BeEmpty())
+			})
+		})
+	})
 })
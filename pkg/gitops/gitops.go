@@ -5,6 +5,7 @@ import (
 
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
+	"k8s.io/client-go/dynamic"
 	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -21,7 +22,11 @@ type FluxInstaller interface {
 
 // Setup sets up gitops in a repository for a cluster.
 func Setup(kubeconfigPath string, k8sRestConfig *rest.Config, k8sClientSet kubeclient.Interface, cfg *api.ClusterConfig, timeout time.Duration) error {
-	installer, err := flux.New(k8sClientSet, cfg.GitOps)
+	dynamicClient, err := dynamic.NewForConfig(k8sRestConfig)
+	if err != nil {
+		return errors.Wrapf(err, "could not create dynamic client")
+	}
+	installer, err := flux.New(k8sClientSet, dynamicClient, cfg.GitOps)
 	logger.Info("gitops configuration detected, setting installer to Flux v2")
 	if err != nil {
 		return errors.Wrapf(err, "could not initialise Flux installer")
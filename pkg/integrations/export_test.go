@@ -0,0 +1,11 @@
+package integrations
+
+func SetCurrentKubeContext(kubeContext string) func() {
+	previous := currentKubeContext
+	currentKubeContext = func() string {
+		return kubeContext
+	}
+	return func() {
+		currentKubeContext = previous
+	}
+}
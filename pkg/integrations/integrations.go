@@ -0,0 +1,466 @@
+// Package integrations lets third parties extend eksctl with subcommands and ClusterConfig handlers
+// without changing the eksctl command tree.
+//
+// An integration is either an executable named eksctl-<name> found on PATH, like kubectl plugins,
+// or a declarative manifest in the integrations directory that describes the command to run.
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	// ExecutablePrefix is the prefix of the executables discovered on PATH as integrations.
+	ExecutablePrefix = "eksctl-"
+
+	// DirEnvName defines an environment property to configure the directory holding integration manifests.
+	DirEnvName = "EKSCTL_INTEGRATIONS_DIR"
+
+	// ApplyConfigCommand is the subcommand an integration is invoked with to apply its ClusterConfig section.
+	// The ApplyConfigRequest is written to its standard input as JSON.
+	ApplyConfigCommand = "apply-config"
+
+	// ClusterNameEnvName is set for integrations to the name of the cluster eksctl is operating on, if known.
+	ClusterNameEnvName = "EKSCTL_CLUSTER_NAME"
+	// RegionEnvName is set for integrations to the region of the cluster, if known.
+	RegionEnvName = "EKSCTL_REGION"
+	// ProfileEnvName is set for integrations to the AWS credentials profile, if known.
+	ProfileEnvName = "EKSCTL_PROFILE"
+	// ExecutableEnvName is set for integrations to the path of the eksctl executable, so they can call back into eksctl.
+	ExecutableEnvName = "EKSCTL_BIN"
+)
+
+// An Integration extends eksctl with a subcommand and, optionally, a handler for its section of the ClusterConfig.
+type Integration interface {
+	// Name is the name of the subcommand added for the integration.
+	Name() string
+	// Description is a short description of the integration, shown in help.
+	Description() string
+	// Run runs the integration's subcommand with args.
+	Run(ctx context.Context, args []string) error
+	// ApplyConfig applies the integration's configuration for a cluster.
+	ApplyConfig(ctx context.Context, request ApplyConfigRequest) error
+}
+
+// ApplyConfigRequest is passed to an integration to apply its configuration for a cluster.
+type ApplyConfigRequest struct {
+	ClusterConfig *api.ClusterConfig `json:"clusterConfig"`
+	Config        api.InlineDocument `json:"config,omitempty"`
+}
+
+// Context is the context passed to integrations in environment variables.
+type Context struct {
+	ClusterName string
+	Region      string
+	Profile     string
+}
+
+// currentKubeContext returns the name of the current kubeconfig context, it is replaced in tests
+var currentKubeContext = func() string {
+	config, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+	if err != nil {
+		logger.Debug("ignoring kubeconfig while looking up the context of integrations: %v", err)
+		return ""
+	}
+	return config.CurrentContext
+}
+
+// ContextFromArgs returns the context of an integration subcommand run with args. The --cluster, --region and
+// --profile flags are read from args, which are still passed to the integration unchanged. When not set in args,
+// the region and profile default to the AWS environment variables, and the cluster and region to those of the
+// current kubeconfig context, if it was written by eksctl.
+func ContextFromArgs(args []string) Context {
+	var c Context
+	flags := map[string]*string{
+		"--cluster": &c.ClusterName,
+		"-c":        &c.ClusterName,
+		"--region":  &c.Region,
+		"-r":        &c.Region,
+		"--profile": &c.Profile,
+		"-p":        &c.Profile,
+	}
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(args[i], "=")
+		field, ok := flags[name]
+		if !ok {
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				break
+			}
+			i++
+			value = args[i]
+		}
+		*field = value
+	}
+
+	if c.Region == "" {
+		c.Region = firstNonEmpty(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"))
+	}
+	if c.Profile == "" {
+		c.Profile = os.Getenv("AWS_PROFILE")
+	}
+	if c.ClusterName == "" {
+		if name, region, ok := parseEksctlKubeContext(currentKubeContext()); ok {
+			c.ClusterName = name
+			if c.Region == "" {
+				c.Region = region
+			}
+		}
+	}
+	return c
+}
+
+// Env returns the environment variables for c, unset fields are omitted.
+func (c Context) Env() []string {
+	var env []string
+	for _, v := range []struct{ name, value string }{
+		{ClusterNameEnvName, c.ClusterName},
+		{RegionEnvName, c.Region},
+		{ProfileEnvName, c.Profile},
+	} {
+		if v.value != "" {
+			env = append(env, v.name+"="+v.value)
+		}
+	}
+	if executable, err := os.Executable(); err == nil {
+		env = append(env, ExecutableEnvName+"="+executable)
+	}
+	return env
+}
+
+// parseEksctlKubeContext returns the cluster name and region of a kubeconfig context written by eksctl,
+// named <user>@<cluster>.<region>.eksctl.io
+func parseEksctlKubeContext(kubeContext string) (string, string, bool) {
+	_, clusterContext, found := strings.Cut(kubeContext, "@")
+	if !found {
+		return "", "", false
+	}
+	parts := strings.Split(strings.TrimSuffix(clusterContext, ".eksctl.io"), ".")
+	if len(parts) != 2 || !strings.HasSuffix(clusterContext, ".eksctl.io") {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Manifest declares an integration backed by arbitrary commands.
+type Manifest struct {
+	// Name of the integration.
+	Name string `json:"name"`
+	// Description of the integration.
+	Description string `json:"description,omitempty"`
+	// Command run for the integration's subcommand, with the subcommand's arguments appended.
+	Command []string `json:"command"`
+	// ApplyConfigCommand is run to apply the integration's configuration. If unset, the integration
+	// does not support being configured in a ClusterConfig file.
+	ApplyConfigCommand []string `json:"applyConfigCommand,omitempty"`
+}
+
+// A Registry holds the available integrations.
+type Registry struct {
+	integrations map[string]Integration
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		integrations: map[string]Integration{},
+	}
+}
+
+// Register adds an integration to the registry. Registering two integrations with the same name is an error.
+func (r *Registry) Register(integration Integration) error {
+	name := integration.Name()
+	if _, ok := r.integrations[name]; ok {
+		return fmt.Errorf("integration %q is already registered", name)
+	}
+	r.integrations[name] = integration
+	return nil
+}
+
+// Get returns the integration named name.
+func (r *Registry) Get(name string) (Integration, bool) {
+	integration, ok := r.integrations[name]
+	return integration, ok
+}
+
+// List returns the registered integrations sorted by name.
+func (r *Registry) List() []Integration {
+	var integrations []Integration
+	for _, integration := range r.integrations {
+		integrations = append(integrations, integration)
+	}
+	sort.Slice(integrations, func(i, j int) bool {
+		return integrations[i].Name() < integrations[j].Name()
+	})
+	return integrations
+}
+
+var (
+	defaultRegistry     *Registry
+	defaultRegistryErr  error
+	defaultRegistryOnce sync.Once
+)
+
+// Default returns the integrations discovered on PATH and in the integrations directory. Discovery
+// happens once per process. Integrations that fail to load are reported in the error, the others
+// are still returned.
+func Default() (*Registry, error) {
+	defaultRegistryOnce.Do(func() {
+		var manifestDir string
+		manifestDir, defaultRegistryErr = GetDir()
+		if defaultRegistryErr != nil {
+			defaultRegistry = NewRegistry()
+			return
+		}
+		defaultRegistry, defaultRegistryErr = Discover(afero.NewOsFs(), os.Getenv("PATH"), manifestDir)
+	})
+	return defaultRegistry, defaultRegistryErr
+}
+
+// GetDir gets the directory holding integration manifests.
+func GetDir() (string, error) {
+	if dir := os.Getenv(DirEnvName); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".eksctl", "integrations"), nil
+}
+
+// Discover finds the eksctl-<name> executables in the directories of pathList and the manifests
+// in manifestDir. An executable earlier in pathList shadows later ones, and manifests take
+// precedence over executables.
+func Discover(fs afero.Fs, pathList, manifestDir string) (*Registry, error) {
+	registry := NewRegistry()
+	var loadErrs []string
+
+	manifests, err := afero.Glob(fs, filepath.Join(manifestDir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range manifests {
+		integration, err := loadManifest(fs, path)
+		if err == nil {
+			err = registry.Register(integration)
+		}
+		if err != nil {
+			loadErrs = append(loadErrs, fmt.Sprintf("%s: %v", path, err))
+		}
+	}
+
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		entries, err := afero.ReadDir(fs, dir)
+		if err != nil {
+			logger.Debug("skipping %q while discovering integrations: %v", dir, err)
+			continue
+		}
+		for _, entry := range entries {
+			name := strings.TrimPrefix(entry.Name(), ExecutablePrefix)
+			if entry.IsDir() || name == entry.Name() || name == "" || entry.Mode()&0111 == 0 {
+				continue
+			}
+			if _, ok := registry.Get(name); ok {
+				continue
+			}
+			_ = registry.Register(&execIntegration{
+				name: name,
+				path: filepath.Join(dir, entry.Name()),
+			})
+		}
+	}
+
+	if len(loadErrs) > 0 {
+		return registry, fmt.Errorf("failed to load integrations:\n%s", strings.Join(loadErrs, "\n"))
+	}
+	return registry, nil
+}
+
+func loadManifest(fs afero.Fs, path string) (Integration, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := yaml.UnmarshalStrict(data, &manifest); err != nil {
+		return nil, errors.Wrap(err, "parsing integration manifest")
+	}
+	if manifest.Name == "" {
+		return nil, errors.New("name must be set")
+	}
+	if len(manifest.Command) == 0 {
+		return nil, errors.New("command must be set")
+	}
+	return &manifestIntegration{manifest: manifest}, nil
+}
+
+// AddCommands adds a subcommand to rootCmd for each integration in registry. Integrations never
+// replace built-in commands.
+func AddCommands(rootCmd *cobra.Command, registry *Registry) {
+	builtIn := map[string]struct{}{}
+	for _, cmd := range rootCmd.Commands() {
+		builtIn[cmd.Name()] = struct{}{}
+		for _, alias := range cmd.Aliases {
+			builtIn[alias] = struct{}{}
+		}
+	}
+	for _, integration := range registry.List() {
+		if _, ok := builtIn[integration.Name()]; ok {
+			logger.Debug("ignoring integration %q as it conflicts with a built-in command", integration.Name())
+			continue
+		}
+		integration := integration
+		rootCmd.AddCommand(&cobra.Command{
+			Use:                integration.Name(),
+			Short:              integration.Description(),
+			DisableFlagParsing: true,
+			SilenceUsage:       true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return integration.Run(cmd.Context(), args)
+			},
+		})
+	}
+}
+
+// ApplyConfig applies the integrations configured in cfg.
+func ApplyConfig(ctx context.Context, registry *Registry, cfg *api.ClusterConfig) error {
+	for _, integrationConfig := range cfg.Integrations {
+		integration, ok := registry.Get(integrationConfig.Name)
+		if !ok {
+			return fmt.Errorf("integration %q is not installed", integrationConfig.Name)
+		}
+		logger.Info("applying configuration of integration %q", integration.Name())
+		if err := integration.ApplyConfig(ctx, ApplyConfigRequest{
+			ClusterConfig: cfg,
+			Config:        integrationConfig.Config,
+		}); err != nil {
+			return fmt.Errorf("applying configuration of integration %q: %w", integration.Name(), err)
+		}
+	}
+	return nil
+}
+
+// CheckInstalled returns an error if an integration configured in cfg is not in registry.
+func CheckInstalled(registry *Registry, cfg *api.ClusterConfig) error {
+	var missing []string
+	for _, integrationConfig := range cfg.Integrations {
+		if _, ok := registry.Get(integrationConfig.Name); !ok {
+			missing = append(missing, integrationConfig.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("integrations %s are configured but not installed; install an %s<name> executable on PATH or a manifest in the integrations directory", strings.Join(missing, ", "), ExecutablePrefix)
+	}
+	return nil
+}
+
+type execIntegration struct {
+	name string
+	path string
+}
+
+func (e *execIntegration) Name() string {
+	return e.name
+}
+
+func (e *execIntegration) Description() string {
+	return fmt.Sprintf("Run the %s integration (%s)", e.name, e.path)
+}
+
+func (e *execIntegration) Run(ctx context.Context, args []string) error {
+	return run(ctx, []string{e.path}, args, nil, ContextFromArgs(args))
+}
+
+func (e *execIntegration) ApplyConfig(ctx context.Context, request ApplyConfigRequest) error {
+	return applyConfig(ctx, []string{e.path, ApplyConfigCommand}, request)
+}
+
+type manifestIntegration struct {
+	manifest Manifest
+}
+
+func (m *manifestIntegration) Name() string {
+	return m.manifest.Name
+}
+
+func (m *manifestIntegration) Description() string {
+	if m.manifest.Description != "" {
+		return m.manifest.Description
+	}
+	return fmt.Sprintf("Run the %s integration", m.manifest.Name)
+}
+
+func (m *manifestIntegration) Run(ctx context.Context, args []string) error {
+	return run(ctx, m.manifest.Command, args, nil, ContextFromArgs(args))
+}
+
+func (m *manifestIntegration) ApplyConfig(ctx context.Context, request ApplyConfigRequest) error {
+	if len(m.manifest.ApplyConfigCommand) == 0 {
+		return errors.New("integration does not support configuration")
+	}
+	return applyConfig(ctx, m.manifest.ApplyConfigCommand, request)
+}
+
+func applyConfig(ctx context.Context, command []string, request ApplyConfigRequest) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	var integrationContext Context
+	if request.ClusterConfig != nil && request.ClusterConfig.Metadata != nil {
+		integrationContext = Context{
+			ClusterName: request.ClusterConfig.Metadata.Name,
+			Region:      request.ClusterConfig.Metadata.Region,
+		}
+	}
+	return run(ctx, command, nil, bytes.NewReader(data), integrationContext)
+}
+
+func run(ctx context.Context, command, args []string, stdin *bytes.Reader, integrationContext Context) error {
+	cmd := exec.CommandContext(ctx, command[0], append(command[1:len(command):len(command)], args...)...)
+	cmd.Env = append(os.Environ(), integrationContext.Env()...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if stdin != nil {
+		cmd.Stdin = stdin
+	} else {
+		cmd.Stdin = os.Stdin
+	}
+	return cmd.Run()
+}
@@ -0,0 +1,11 @@
+package integrations_test
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestIntegrations(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
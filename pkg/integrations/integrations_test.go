@@ -0,0 +1,183 @@
+package integrations_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/integrations"
+)
+
+var _ = Describe("Integrations", func() {
+	var (
+		binDir, otherBinDir, manifestDir, outputFile string
+	)
+
+	writeFile := func(path, content string, perm os.FileMode) {
+		Expect(os.WriteFile(path, []byte(content), perm)).To(Succeed())
+	}
+
+	discover := func() *integrations.Registry {
+		registry, err := integrations.Discover(afero.NewOsFs(), binDir+string(os.PathListSeparator)+otherBinDir, manifestDir)
+		Expect(err).NotTo(HaveOccurred())
+		return registry
+	}
+
+	get := func(registry *integrations.Registry, name string) integrations.Integration {
+		integration, ok := registry.Get(name)
+		Expect(ok).To(BeTrue())
+		return integration
+	}
+
+	names := func(registry *integrations.Registry) []string {
+		var names []string
+		for _, integration := range registry.List() {
+			names = append(names, integration.Name())
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		binDir = GinkgoT().TempDir()
+		otherBinDir = GinkgoT().TempDir()
+		manifestDir = GinkgoT().TempDir()
+		outputFile = filepath.Join(GinkgoT().TempDir(), "output")
+
+		writeFile(filepath.Join(binDir, "eksctl-velero"), "#!/bin/sh\necho \"$@\" > "+outputFile+"\n", 0755)
+		writeFile(filepath.Join(otherBinDir, "eksctl-velero"), "#!/bin/sh\nexit 1\n", 0755)
+		writeFile(filepath.Join(otherBinDir, "eksctl-istio"), "#!/bin/sh\ncat > "+outputFile+"\n", 0755)
+		writeFile(filepath.Join(otherBinDir, "eksctl-notes.txt"), "not an integration", 0644)
+		writeFile(filepath.Join(otherBinDir, "kubectl"), "#!/bin/sh\n", 0755)
+		Expect(os.Mkdir(filepath.Join(otherBinDir, "eksctl-dir"), 0755)).To(Succeed())
+	})
+
+	Describe("Discover", func() {
+		It("discovers eksctl- executables on PATH", func() {
+			registry := discover()
+			Expect(names(registry)).To(Equal([]string{"istio", "velero"}))
+		})
+
+		It("prefers executables earlier on PATH", func() {
+			Expect(get(discover(), "velero").Description()).To(ContainSubstring(binDir))
+		})
+
+		It("prefers manifests over executables", func() {
+			writeFile(filepath.Join(manifestDir, "velero.yaml"), `
+name: velero
+description: Back up and restore cluster resources
+command: ["velero"]
+`, 0644)
+			Expect(get(discover(), "velero").Description()).To(Equal("Back up and restore cluster resources"))
+		})
+
+		It("reports invalid manifests and still returns the other integrations", func() {
+			writeFile(filepath.Join(manifestDir, "broken.yaml"), "name: broken\n", 0644)
+			registry, err := integrations.Discover(afero.NewOsFs(), otherBinDir, manifestDir)
+			Expect(err).To(MatchError(ContainSubstring("broken.yaml: command must be set")))
+			Expect(names(registry)).To(Equal([]string{"istio", "velero"}))
+		})
+	})
+
+	Describe("AddCommands", func() {
+		It("adds a subcommand that runs the integration with its arguments", func() {
+			rootCmd := &cobra.Command{Use: "eksctl"}
+			rootCmd.AddCommand(&cobra.Command{Use: "istio", Run: func(*cobra.Command, []string) {}})
+			integrations.AddCommands(rootCmd, discover())
+
+			var commands []string
+			for _, cmd := range rootCmd.Commands() {
+				commands = append(commands, cmd.Name())
+			}
+			Expect(commands).To(ConsistOf("istio", "velero"))
+
+			rootCmd.SetArgs([]string{"velero", "backup", "create", "--include-namespaces", "default"})
+			Expect(rootCmd.Execute()).To(Succeed())
+			Expect(os.ReadFile(outputFile)).To(BeEquivalentTo("backup create --include-namespaces default\n"))
+		})
+	})
+
+	Describe("ContextFromArgs", func() {
+		BeforeEach(func() {
+			DeferCleanup(integrations.SetCurrentKubeContext(""))
+			for _, env := range []string{"AWS_REGION", "AWS_DEFAULT_REGION", "AWS_PROFILE"} {
+				GinkgoT().Setenv(env, "")
+			}
+		})
+
+		It("reads the cluster, region and profile flags", func() {
+			Expect(integrations.ContextFromArgs([]string{"backup", "--cluster", "my-cluster", "--region=eu-west-1", "-p", "dev"})).To(Equal(integrations.Context{
+				ClusterName: "my-cluster",
+				Region:      "eu-west-1",
+				Profile:     "dev",
+			}))
+		})
+
+		It("ignores the arguments after --", func() {
+			Expect(integrations.ContextFromArgs([]string{"--", "--cluster", "my-cluster"})).To(Equal(integrations.Context{}))
+		})
+
+		It("defaults to the AWS environment variables and the current eksctl kubeconfig context", func() {
+			GinkgoT().Setenv("AWS_DEFAULT_REGION", "us-east-2")
+			GinkgoT().Setenv("AWS_PROFILE", "prod")
+			DeferCleanup(integrations.SetCurrentKubeContext("admin@my-cluster.eu-north-1.eksctl.io"))
+			Expect(integrations.ContextFromArgs([]string{"backup"})).To(Equal(integrations.Context{
+				ClusterName: "my-cluster",
+				Region:      "us-east-2",
+				Profile:     "prod",
+			}))
+		})
+
+		It("ignores kubeconfig contexts not written by eksctl", func() {
+			DeferCleanup(integrations.SetCurrentKubeContext("arn:aws:eks:us-west-2:123456789012:cluster/my-cluster"))
+			Expect(integrations.ContextFromArgs(nil)).To(Equal(integrations.Context{}))
+		})
+
+		It("is passed to the integration in environment variables", func() {
+			writeFile(filepath.Join(binDir, "eksctl-env"), "#!/bin/sh\necho \"$EKSCTL_CLUSTER_NAME $EKSCTL_REGION\" > "+outputFile+"\n", 0755)
+			Expect(get(discover(), "env").Run(context.Background(), []string{"--cluster", "my-cluster", "--region", "eu-west-1"})).To(Succeed())
+			Expect(os.ReadFile(outputFile)).To(BeEquivalentTo("my-cluster eu-west-1\n"))
+		})
+	})
+
+	Describe("ApplyConfig", func() {
+		var cfg *api.ClusterConfig
+
+		BeforeEach(func() {
+			cfg = api.NewClusterConfig()
+			cfg.Metadata.Name = "my-cluster"
+			cfg.Integrations = []api.IntegrationConfig{
+				{Name: "istio", Config: api.InlineDocument{"profile": "minimal"}},
+			}
+		})
+
+		It("passes the cluster and integration configuration to the integration", func() {
+			registry := discover()
+			Expect(integrations.CheckInstalled(registry, cfg)).To(Succeed())
+			Expect(integrations.ApplyConfig(context.Background(), registry, cfg)).To(Succeed())
+
+			data, err := os.ReadFile(outputFile)
+			Expect(err).NotTo(HaveOccurred())
+			var request integrations.ApplyConfigRequest
+			Expect(json.Unmarshal(data, &request)).To(Succeed())
+			Expect(request.ClusterConfig.Metadata.Name).To(Equal("my-cluster"))
+			Expect(request.Config).To(Equal(api.InlineDocument{"profile": "minimal"}))
+		})
+
+		It("fails if a configured integration is not installed", func() {
+			cfg.Integrations = append(cfg.Integrations, api.IntegrationConfig{Name: "linkerd"})
+			Expect(integrations.CheckInstalled(discover(), cfg)).To(MatchError(ContainSubstring("integrations linkerd are configured but not installed")))
+		})
+
+		It("fails for manifests without an apply command", func() {
+			writeFile(filepath.Join(manifestDir, "istio.yaml"), "name: istio\ncommand: [istioctl]\n", 0644)
+			Expect(integrations.ApplyConfig(context.Background(), discover(), cfg)).To(MatchError(`applying configuration of integration "istio": integration does not support configuration`))
+		})
+	})
+})
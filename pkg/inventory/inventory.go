@@ -0,0 +1,263 @@
+// Package inventory maintains a local record of the clusters and nodegroups managed from this machine,
+// so that they can be listed without calling AWS APIs.
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/kris-nova/logger"
+	"github.com/spf13/afero"
+)
+
+const (
+	// EnableInventoryEnvName defines an environment property to enable the local inventory.
+	EnableInventoryEnvName = "EKSCTL_ENABLE_INVENTORY"
+	// InventoryFilenameEnvName defines an environment property to configure where the inventory file should live.
+	InventoryFilenameEnvName = "EKSCTL_INVENTORY_FILENAME"
+)
+
+// Operations recorded in the inventory.
+const (
+	OperationCreateCluster   = "create cluster"
+	OperationCreateNodeGroup = "create nodegroup"
+	OperationDeleteNodeGroup = "delete nodegroup"
+)
+
+// Cluster is a cluster recorded in the inventory.
+type Cluster struct {
+	Name            string    `json:"name"`
+	Region          string    `json:"region"`
+	NodeGroups      []string  `json:"nodeGroups,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+	LastOperation   string    `json:"lastOperation"`
+	LastOperationAt time.Time `json:"lastOperationAt"`
+}
+
+type inventoryFile struct {
+	Clusters []*Cluster `json:"clusters"`
+}
+
+func (f *inventoryFile) find(name, region string) (int, *Cluster) {
+	for i, c := range f.Clusters {
+		if c.Name == name && c.Region == region {
+			return i, c
+		}
+	}
+	return -1, nil
+}
+
+// An Inventory reads and writes the inventory file.
+type Inventory struct {
+	fs       afero.Fs
+	filename string
+	now      func() time.Time
+}
+
+// New creates an Inventory backed by filename.
+func New(fs afero.Fs, filename string, now func() time.Time) *Inventory {
+	return &Inventory{
+		fs:       fs,
+		filename: filename,
+		now:      now,
+	}
+}
+
+// Enabled reports whether the local inventory is enabled.
+func Enabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(EnableInventoryEnvName))
+	return enabled
+}
+
+// GetFilePath gets the filename to use for the inventory.
+func GetFilePath() (string, error) {
+	if filename := os.Getenv(InventoryFilenameEnvName); filename != "" {
+		return filename, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".eksctl", "inventory.json"), nil
+}
+
+// Update applies update to the local inventory if it is enabled. Failing to update the inventory
+// must not fail the operation being recorded, so errors are only logged.
+func Update(update func(*Inventory) error) {
+	if !Enabled() {
+		return
+	}
+	filename, err := GetFilePath()
+	if err == nil {
+		err = update(New(afero.NewOsFs(), filename, time.Now))
+	}
+	if err != nil {
+		logger.Warning("failed to update local inventory: %v", err)
+	}
+}
+
+// List returns the clusters in the inventory, sorted by region and name.
+func (i *Inventory) List() ([]Cluster, error) {
+	f, err := i.read()
+	if err != nil {
+		return nil, err
+	}
+	clusters := make([]Cluster, 0, len(f.Clusters))
+	for _, c := range f.Clusters {
+		clusters = append(clusters, *c)
+	}
+	sort.Slice(clusters, func(a, b int) bool {
+		if clusters[a].Region != clusters[b].Region {
+			return clusters[a].Region < clusters[b].Region
+		}
+		return clusters[a].Name < clusters[b].Name
+	})
+	return clusters, nil
+}
+
+// AddCluster records a newly created cluster along with its nodegroups.
+func (i *Inventory) AddCluster(name, region string, nodeGroups []string) error {
+	return i.modify(func(f *inventoryFile) {
+		now := i.now()
+		cluster := &Cluster{
+			Name:            name,
+			Region:          region,
+			CreatedAt:       now,
+			LastOperation:   OperationCreateCluster,
+			LastOperationAt: now,
+		}
+		cluster.NodeGroups = addNames(nil, nodeGroups)
+		if idx, _ := f.find(name, region); idx >= 0 {
+			f.Clusters[idx] = cluster
+			return
+		}
+		f.Clusters = append(f.Clusters, cluster)
+	})
+}
+
+// RemoveCluster removes a deleted cluster.
+func (i *Inventory) RemoveCluster(name, region string) error {
+	return i.modify(func(f *inventoryFile) {
+		if idx, _ := f.find(name, region); idx >= 0 {
+			f.Clusters = append(f.Clusters[:idx], f.Clusters[idx+1:]...)
+		}
+	})
+}
+
+// AddNodeGroups records nodegroups created in a cluster. Clusters that are not in the inventory,
+// e.g. because they were created from a different machine, are added.
+func (i *Inventory) AddNodeGroups(clusterName, region string, nodeGroups []string) error {
+	return i.modify(func(f *inventoryFile) {
+		_, cluster := f.find(clusterName, region)
+		if cluster == nil {
+			cluster = &Cluster{Name: clusterName, Region: region}
+			f.Clusters = append(f.Clusters, cluster)
+		}
+		cluster.NodeGroups = addNames(cluster.NodeGroups, nodeGroups)
+		cluster.LastOperation = OperationCreateNodeGroup
+		cluster.LastOperationAt = i.now()
+	})
+}
+
+// RemoveNodeGroups removes nodegroups deleted from a cluster.
+func (i *Inventory) RemoveNodeGroups(clusterName, region string, nodeGroups []string) error {
+	return i.modify(func(f *inventoryFile) {
+		_, cluster := f.find(clusterName, region)
+		if cluster == nil {
+			return
+		}
+		remove := map[string]struct{}{}
+		for _, ng := range nodeGroups {
+			remove[ng] = struct{}{}
+		}
+		var remaining []string
+		for _, ng := range cluster.NodeGroups {
+			if _, ok := remove[ng]; !ok {
+				remaining = append(remaining, ng)
+			}
+		}
+		cluster.NodeGroups = remaining
+		cluster.LastOperation = OperationDeleteNodeGroup
+		cluster.LastOperationAt = i.now()
+	})
+}
+
+func addNames(names, toAdd []string) []string {
+	for _, n := range toAdd {
+		found := false
+		for _, existing := range names {
+			if existing == n {
+				found = true
+				break
+			}
+		}
+		if !found {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (i *Inventory) modify(update func(*inventoryFile)) error {
+	if err := i.fs.MkdirAll(filepath.Dir(i.filename), 0700); err != nil {
+		return fmt.Errorf("creating inventory folder: %w", err)
+	}
+	unlock, err := i.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, err := i.read()
+	if err != nil {
+		return err
+	}
+	update(f)
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return afero.WriteFile(i.fs, i.filename, data, 0600)
+}
+
+func (i *Inventory) read() (*inventoryFile, error) {
+	f := &inventoryFile{}
+	data, err := afero.ReadFile(i.fs, i.filename)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading inventory file: %w", err)
+	}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("parsing inventory file %s: %w", i.filename, err)
+	}
+	return f, nil
+}
+
+// lock takes an exclusive lock on the inventory so that concurrent eksctl processes do not lose each other's updates.
+// Locking is only possible on the OS filesystem, so it is skipped otherwise.
+func (i *Inventory) lock() (func(), error) {
+	if _, ok := i.fs.(*afero.OsFs); !ok {
+		return func() {}, nil
+	}
+	lock := flock.New(i.filename + ".lock")
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
+	defer cancel()
+	if ok, err := lock.TryLockContext(ctx, 250*time.Millisecond); !ok {
+		return nil, fmt.Errorf("unable to lock inventory file %s: %v", i.filename, err)
+	}
+	return func() {
+		if err := lock.Unlock(); err != nil {
+			logger.Warning("unable to unlock inventory file %s: %v", i.filename, err)
+		}
+	}, nil
+}
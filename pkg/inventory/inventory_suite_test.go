@@ -0,0 +1,11 @@
+package inventory_test
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestInventory(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
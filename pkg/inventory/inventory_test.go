@@ -0,0 +1,87 @@
+package inventory_test
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"github.com/weaveworks/eksctl/pkg/inventory"
+)
+
+var _ = Describe("Inventory", func() {
+	const filename = "/home/user/.eksctl/inventory.json"
+
+	var (
+		fs  afero.Fs
+		now time.Time
+		inv *inventory.Inventory
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		inv = inventory.New(fs, filename, func() time.Time { return now })
+	})
+
+	It("returns no clusters when the inventory file does not exist", func() {
+		clusters, err := inv.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(BeEmpty())
+	})
+
+	It("records clusters and nodegroups", func() {
+		Expect(inv.AddCluster("cluster-2", "us-west-2", []string{"ng-2", "ng-1"})).To(Succeed())
+		Expect(inv.AddCluster("cluster-1", "us-west-2", nil)).To(Succeed())
+		Expect(inv.AddCluster("cluster-1", "eu-west-1", nil)).To(Succeed())
+
+		now = now.Add(time.Hour)
+		Expect(inv.AddNodeGroups("cluster-2", "us-west-2", []string{"ng-3", "ng-1"})).To(Succeed())
+
+		clusters, err := inv.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(HaveLen(3))
+		Expect(clusters[0].Name).To(Equal("cluster-1"))
+		Expect(clusters[0].Region).To(Equal("eu-west-1"))
+		Expect(clusters[1].Name).To(Equal("cluster-1"))
+		Expect(clusters[1].Region).To(Equal("us-west-2"))
+		Expect(clusters[2]).To(Equal(inventory.Cluster{
+			Name:            "cluster-2",
+			Region:          "us-west-2",
+			NodeGroups:      []string{"ng-1", "ng-2", "ng-3"},
+			CreatedAt:       now.Add(-time.Hour),
+			LastOperation:   inventory.OperationCreateNodeGroup,
+			LastOperationAt: now,
+		}))
+
+		info, err := fs.Stat(filename)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	It("removes nodegroups and clusters", func() {
+		Expect(inv.AddCluster("cluster", "us-west-2", []string{"ng-1", "ng-2"})).To(Succeed())
+		Expect(inv.RemoveNodeGroups("cluster", "us-west-2", []string{"ng-1"})).To(Succeed())
+
+		clusters, err := inv.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters[0].NodeGroups).To(Equal([]string{"ng-2"}))
+		Expect(clusters[0].LastOperation).To(Equal(inventory.OperationDeleteNodeGroup))
+
+		Expect(inv.RemoveCluster("cluster", "us-west-2")).To(Succeed())
+		clusters, err = inv.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(BeEmpty())
+	})
+
+	It("adds clusters that are not in the inventory when recording nodegroups", func() {
+		Expect(inv.AddNodeGroups("cluster", "us-west-2", []string{"ng-1"})).To(Succeed())
+
+		clusters, err := inv.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(HaveLen(1))
+		Expect(clusters[0].NodeGroups).To(Equal([]string{"ng-1"}))
+	})
+})
@@ -19,7 +19,9 @@ type URLGetter interface {
 
 // InstallChartOpts defines parameters for InstallChart.
 type InstallChartOpts struct {
-	ChartName       string
+	ChartName string
+	// RepoURL is the URL of the chart repository, for charts not in an OCI registry.
+	RepoURL         string
 	CreateNamespace bool
 	Namespace       string
 	ReleaseName     string
@@ -55,6 +55,7 @@ func (i *Installer) InstallChart(ctx context.Context, opts providers.InstallChar
 	client.Version = opts.Version
 	client.CreateNamespace = opts.CreateNamespace
 	client.Timeout = 10 * time.Minute
+	client.ChartPathOptions.RepoURL = opts.RepoURL
 
 	chartPath, err := client.ChartPathOptions.LocateChart(opts.ChartName, i.Settings)
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -163,6 +164,11 @@ func (c *RawClient) ServerVersion() (string, error) {
 // ClientSet returns the underlying ClientSet
 func (c *RawClient) ClientSet() Interface { return c.clientSet }
 
+// NewDynamicClient returns a dynamic client for the resources, e.g. custom resources, that have no typed client
+func (c *RawClient) NewDynamicClient() (dynamic.Interface, error) {
+	return dynamic.NewForConfig(c.config)
+}
+
 // NewHelperFor construct a raw client helper instance for a give gvk
 // (it's based on k8s.io/kubernetes/pkg/kubectl/cmd/util/factory_client_access.go)
 func (c *RawClient) NewHelperFor(gvk schema.GroupVersionKind) (*resource.Helper, error) {
@@ -0,0 +1,58 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultStorageClassAnnotation marks the StorageClass used by PersistentVolumeClaims that do not name one
+const DefaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// MaybeCreateStorageClass creates the StorageClass unless a StorageClass with the same name already exists, as the
+// provisioner and parameters of a StorageClass cannot be updated
+func MaybeCreateStorageClass(ctx context.Context, clientSet Interface, storageClass *storagev1.StorageClass) error {
+	_, err := clientSet.StorageV1().StorageClasses().Create(ctx, storageClass, metav1.CreateOptions{})
+	switch {
+	case err == nil:
+		logger.Info("created StorageClass %q", storageClass.Name)
+		return nil
+	case apierrors.IsAlreadyExists(err):
+		logger.Info("StorageClass %q already exists", storageClass.Name)
+		return nil
+	default:
+		return fmt.Errorf("creating StorageClass %q: %w", storageClass.Name, err)
+	}
+}
+
+// SetDefaultStorageClass marks the StorageClass with the given name as the default one, and unmarks the others
+func SetDefaultStorageClass(ctx context.Context, clientSet Interface, name string) error {
+	storageClasses, err := clientSet.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing StorageClasses: %w", err)
+	}
+	found := false
+	for _, sc := range storageClasses.Items {
+		isDefault := sc.Name == name
+		found = found || isDefault
+		if (sc.Annotations[DefaultStorageClassAnnotation] == "true") == isDefault {
+			continue
+		}
+		if sc.Annotations == nil {
+			sc.Annotations = map[string]string{}
+		}
+		sc.Annotations[DefaultStorageClassAnnotation] = fmt.Sprint(isDefault)
+		if _, err := clientSet.StorageV1().StorageClasses().Update(ctx, &sc, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("updating StorageClass %q: %w", sc.Name, err)
+		}
+	}
+	if !found {
+		return fmt.Errorf("StorageClass %q not found", name)
+	}
+	logger.Info("StorageClass %q is the default StorageClass", name)
+	return nil
+}
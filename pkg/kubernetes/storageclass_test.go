@@ -0,0 +1,56 @@
+package kubernetes_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+var _ = Describe("StorageClass helpers", func() {
+	var clientSet *fake.Clientset
+
+	newStorageClass := func(name string, isDefault bool) *storagev1.StorageClass {
+		sc := &storagev1.StorageClass{
+			ObjectMeta:  metav1.ObjectMeta{Name: name},
+			Provisioner: "ebs.csi.aws.com",
+		}
+		if isDefault {
+			sc.Annotations = map[string]string{DefaultStorageClassAnnotation: "true"}
+		}
+		return sc
+	}
+
+	isDefault := func(name string) bool {
+		sc, err := clientSet.StorageV1().StorageClasses().Get(context.Background(), name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return sc.Annotations[DefaultStorageClassAnnotation] == "true"
+	}
+
+	BeforeEach(func() {
+		clientSet = fake.NewSimpleClientset(newStorageClass("gp2", true))
+	})
+
+	It("creates a StorageClass unless it exists", func() {
+		Expect(MaybeCreateStorageClass(context.Background(), clientSet, newStorageClass("gp3", false))).To(Succeed())
+		Expect(MaybeCreateStorageClass(context.Background(), clientSet, newStorageClass("gp2", false))).To(Succeed())
+		Expect(isDefault("gp2")).To(BeTrue())
+		Expect(isDefault("gp3")).To(BeFalse())
+	})
+
+	It("moves the default StorageClass", func() {
+		Expect(MaybeCreateStorageClass(context.Background(), clientSet, newStorageClass("gp3", false))).To(Succeed())
+		Expect(SetDefaultStorageClass(context.Background(), clientSet, "gp3")).To(Succeed())
+		Expect(isDefault("gp3")).To(BeTrue())
+		Expect(isDefault("gp2")).To(BeFalse())
+	})
+
+	It("fails for unknown StorageClasses", func() {
+		Expect(SetDefaultStorageClass(context.Background(), clientSet, "efs-sc")).To(MatchError(`StorageClass "efs-sc" not found`))
+	})
+})
@@ -32,6 +32,9 @@ func (b *AmazonLinux2) UserData() (string, error) {
 	if api.IsEnabled(b.ng.EFAEnabled) {
 		scripts = append(scripts, script{name: "efa.al2.sh", contents: assets.EfaAl2Sh})
 	}
+	if b.ng.GPU != nil && b.ng.GPU.MIG != nil {
+		scripts = append(scripts, script{name: "mig.sh", contents: makeMIGScript(b.ng.GPU.MIG)})
+	}
 
 	body, err := linuxConfig(b.clusterConfig, al2BootScript, assets.BootstrapAl2Sh, b.clusterDNS, b.ng, scripts...)
 	if err != nil {
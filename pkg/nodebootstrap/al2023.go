@@ -33,6 +33,9 @@ func NewManagedAL2023Bootstrapper(cfg *api.ClusterConfig, mng *api.ManagedNodeGr
 	if api.IsEnabled(mng.EFAEnabled) {
 		al2023.cloudboot = append(al2023.cloudboot, assets.EfaManagedAL2023Boothook)
 	}
+	if mng.GPU != nil && mng.GPU.MIG != nil {
+		al2023.scripts = append(al2023.scripts, makeMIGScript(mng.GPU.MIG))
+	}
 	return al2023
 }
 
@@ -41,16 +44,23 @@ func NewAL2023Bootstrapper(cfg *api.ClusterConfig, ng *api.NodeGroup, clusterDNS
 	if api.IsEnabled(ng.EFAEnabled) {
 		al2023.scripts = append(al2023.scripts, assets.EfaAl2023Sh)
 	}
+	if ng.GPU != nil && ng.GPU.MIG != nil {
+		al2023.scripts = append(al2023.scripts, makeMIGScript(ng.GPU.MIG))
+	}
 	return al2023
 }
 
 func newAL2023Bootstrapper(cfg *api.ClusterConfig, np api.NodePool, clusterDNS string) *AL2023 {
-	return &AL2023{
+	al2023 := &AL2023{
 		cfg:        cfg,
 		nodePool:   np,
 		clusterDNS: clusterDNS,
 		scripts:    []string{assets.AL2023XTablesLock},
 	}
+	if propagateProxy(cfg) {
+		al2023.scripts = append(al2023.scripts, makeProxyScript(cfg))
+	}
+	return al2023
 }
 
 func (m *AL2023) UserData() (string, error) {
@@ -58,12 +68,20 @@ func (m *AL2023) UserData() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("generating node config: %w", err)
 	}
-	if len(m.scripts) == 0 && len(m.cloudboot) == 0 && nodeConfig == nil {
+	scripts := m.scripts
+	if m.cfg.HasPullThroughCache() {
+		registryMirrorScript, err := makeRegistryMirrorScript(m.cfg)
+		if err != nil {
+			return "", err
+		}
+		scripts = append(scripts[:len(scripts):len(scripts)], registryMirrorScript)
+	}
+	if len(scripts) == 0 && len(m.cloudboot) == 0 && nodeConfig == nil {
 		return "", nil
 	}
 
 	var buf bytes.Buffer
-	if err := createMimeMessage(&buf, m.scripts, m.cloudboot, nodeConfig, m.UserDataMimeBoundary); err != nil {
+	if err := createMimeMessage(&buf, scripts, m.cloudboot, nodeConfig, m.UserDataMimeBoundary); err != nil {
 		return "", err
 	}
 	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
@@ -56,6 +56,14 @@ var _ = DescribeTable("Unmanaged AL2023", func(e al2023Entry) {
 		},
 		expectedUserData: wrapMIMEParts(xTablesLock + efaScript + nodeConfig),
 	}),
+	Entry("MIG profiles", al2023Entry{
+		overrideNodegroupSettings: func(np api.NodePool) {
+			np.BaseNodeGroup().GPU = &api.NodeGroupGPU{
+				MIG: &api.GPUMIG{Profiles: []string{"3g.20gb", "3g.20gb"}},
+			}
+		},
+		expectedUserData: wrapMIMEParts(xTablesLock + migScript + nodeConfig),
+	}),
 )
 
 var _ = DescribeTable("Managed AL2023", func(e al2023Entry) {
@@ -91,6 +99,14 @@ var _ = DescribeTable("Managed AL2023", func(e al2023Entry) {
 		},
 		expectedUserData: wrapMIMEParts(xTablesLock + efaCloudhook),
 	}),
+	Entry("native AMI && MIG profiles", al2023Entry{
+		overrideNodegroupSettings: func(np api.NodePool) {
+			np.BaseNodeGroup().GPU = &api.NodeGroupGPU{
+				MIG: &api.GPUMIG{Profiles: []string{"4g.40gb", "2g.20gb", "1g.10gb"}},
+			}
+		},
+		expectedUserData: wrapMIMEParts(xTablesLock + strings.ReplaceAll(migScript, "3g.20gb,3g.20gb", "4g.40gb,2g.20gb,1g.10gb")),
+	}),
 	Entry("custom AMI", al2023Entry{
 		overrideNodegroupSettings: func(np api.NodePool) {
 			np.BaseNodeGroup().AMI = "ami-xxxx"
@@ -295,6 +311,23 @@ Content-Type: charset="us-ascii"
 %s
 `, assets.EfaAl2023Sh)
 
+	migScript = `--//
+Content-Type: text/x-shellscript
+Content-Type: charset="us-ascii"
+
+#!/bin/bash
+
+set -o errexit
+set -o pipefail
+set -o nounset
+
+nvidia-smi -mig 1
+nvidia-smi mig -dci || true
+nvidia-smi mig -dgi || true
+nvidia-smi mig -cgi 3g.20gb,3g.20gb -C
+
+`
+
 	nodeConfig = `--//
 Content-Type: application/node.eks.aws
 
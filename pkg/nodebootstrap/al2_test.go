@@ -68,6 +68,58 @@ var _ = Describe("AmazonLinux2 User Data", func() {
 		})
 	})
 
+	When("the proxy is propagated to nodes", func() {
+		BeforeEach(func() {
+			clusterConfig.Proxy = &api.ProxyConfig{
+				HTTPSProxy:       "http://proxy.corp.example.com:3128",
+				NoProxy:          []string{".internal"},
+				PropagateToNodes: api.Enabled(),
+			}
+			clusterConfig.Status.KubernetesNetworkConfig = &api.KubernetesNetworkConfig{ServiceIPv4CIDR: "10.100.0.0/16"}
+			bootstrapper = newBootstrapper(clusterConfig, ng)
+		})
+
+		It("adds the proxy script to the userdata", func() {
+			userData, err := bootstrapper.UserData()
+			Expect(err).NotTo(HaveOccurred())
+
+			cloudCfg := decode(userData)
+			Expect(cloudCfg.WriteFiles[2].Path).To(Equal("/var/lib/cloud/scripts/eksctl/proxy.sh"))
+			Expect(cloudCfg.WriteFiles[2].Permissions).To(Equal("0755"))
+			Expect(cloudCfg.WriteFiles[2].Content).To(ContainSubstring(`Environment="HTTPS_PROXY=http://proxy.corp.example.com:3128" "https_proxy=http://proxy.corp.example.com:3128" "NO_PROXY=localhost,127.0.0.1,169.254.169.254,.svc,.cluster.local,192.168.0.0/16,10.100.0.0/16,.internal" "no_proxy=localhost,127.0.0.1,169.254.169.254,.svc,.cluster.local,192.168.0.0/16,10.100.0.0/16,.internal"`))
+		})
+	})
+
+	When("pull-through cache rules are configured", func() {
+		BeforeEach(func() {
+			clusterConfig.Metadata.AccountID = "111122223333"
+			clusterConfig.Metadata.Region = "us-west-2"
+			clusterConfig.PullThroughCache = &api.PullThroughCache{
+				Rules: []api.PullThroughCacheRule{
+					{UpstreamRegistry: "docker-hub", CredentialARN: "arn:aws:secretsmanager:us-west-2:111122223333:secret:ecr-pullthroughcache/docker-hub"},
+					{UpstreamRegistry: "quay.io", ECRRepositoryPrefix: "quay-mirror"},
+				},
+			}
+			bootstrapper = newBootstrapper(clusterConfig, ng)
+		})
+
+		It("configures containerd to pull the upstream registries through ECR", func() {
+			userData, err := bootstrapper.UserData()
+			Expect(err).NotTo(HaveOccurred())
+
+			cloudCfg := decode(userData)
+			Expect(cloudCfg.WriteFiles[2].Path).To(Equal("/var/lib/cloud/scripts/eksctl/registry-mirrors.sh"))
+			Expect(cloudCfg.WriteFiles[2].Content).To(ContainSubstring(`token="$(aws ecr get-login-password --region us-west-2)"`))
+			Expect(cloudCfg.WriteFiles[2].Content).To(ContainSubstring(`[host."https://111122223333.dkr.ecr.us-west-2.amazonaws.com/v2/$3"]
+  capabilities = ["pull", "resolve"]
+  override_path = true
+  [host."https://111122223333.dkr.ecr.us-west-2.amazonaws.com/v2/$3".header]
+    Authorization = "${authorization}"`))
+			Expect(cloudCfg.WriteFiles[2].Content).To(ContainSubstring("write_mirror docker.io registry-1.docker.io docker-hub\nwrite_mirror quay.io quay.io quay-mirror\nSCRIPT"))
+			Expect(cloudCfg.WriteFiles[2].Content).To(ContainSubstring("systemctl enable --now eksctl-registry-mirrors.timer"))
+		})
+	})
+
 	type bootScriptEntry struct {
 		clusterConfig    *api.ClusterConfig
 		ng               *api.NodeGroup
@@ -149,6 +149,16 @@ func bottlerocketSettingsTOML(spec *api.ClusterConfig, ng *api.NodeGroupBase, tr
 		insertWithoutComment,
 		spec.Metadata.Name)
 
+	// Don't override user's explicit proxy settings if they provided them in config.
+	if propagateProxy(spec) && !tree.Has("settings.network.https-proxy") {
+		tree.SetWithComment("settings.network.https-proxy", "HTTPS Proxy",
+			insertWithoutComment,
+			spec.Proxy.HTTPSProxy)
+		tree.SetWithComment("settings.network.no-proxy", "Hosts Reached Without The Proxy",
+			insertWithoutComment,
+			makeNoProxy(spec))
+	}
+
 	// Don't override user's explicit setting if they provided it in config.
 	if !tree.Has("settings.host-containers.admin.enabled") {
 		// Provide value only if given, with `enabled`
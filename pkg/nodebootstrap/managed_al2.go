@@ -21,6 +21,10 @@ type ManagedAL2 struct {
 	ng *api.ManagedNodeGroup
 	// UserDataMimeBoundary sets the MIME boundary for user data
 	UserDataMimeBoundary string
+	// ProxyScript, if set, configures the proxy of containerd and kubelet
+	ProxyScript string
+	// RegistryMirrorScript, if set, configures containerd to pull through the ECR pull-through cache
+	RegistryMirrorScript string
 }
 
 // NewManagedAL2Bootstrapper creates a new ManagedAL2 bootstrapper
@@ -44,10 +48,22 @@ func (m *ManagedAL2) UserData() (string, error) {
 		cloudboot []string
 	)
 
+	if m.ProxyScript != "" {
+		scripts = append(scripts, m.ProxyScript)
+	}
+
+	if m.RegistryMirrorScript != "" {
+		scripts = append(scripts, m.RegistryMirrorScript)
+	}
+
 	if len(ng.PreBootstrapCommands) > 0 {
 		scripts = append(scripts, ng.PreBootstrapCommands...)
 	}
 
+	if ng.GPU != nil && ng.GPU.MIG != nil {
+		scripts = append(scripts, makeMIGScript(ng.GPU.MIG))
+	}
+
 	if ng.OverrideBootstrapCommand != nil {
 		scripts = append(scripts, *ng.OverrideBootstrapCommand)
 	} else if ng.MaxPodsPerNode != 0 {
@@ -77,7 +77,18 @@ func NewManagedBootstrapper(clusterConfig *api.ClusterConfig, ng *api.ManagedNod
 	case api.NodeImageFamilyAmazonLinux2023:
 		return NewManagedAL2023Bootstrapper(clusterConfig, ng, clusterDNS), nil
 	case api.NodeImageFamilyAmazonLinux2:
-		return NewManagedAL2Bootstrapper(ng), nil
+		al2 := NewManagedAL2Bootstrapper(ng)
+		if propagateProxy(clusterConfig) {
+			al2.ProxyScript = makeProxyScript(clusterConfig)
+		}
+		if clusterConfig.HasPullThroughCache() {
+			registryMirrorScript, err := makeRegistryMirrorScript(clusterConfig)
+			if err != nil {
+				return nil, err
+			}
+			al2.RegistryMirrorScript = registryMirrorScript
+		}
+		return al2, nil
 	case api.NodeImageFamilyBottlerocket:
 		return NewManagedBottlerocketBootstrapper(clusterConfig, ng), nil
 	case api.NodeImageFamilyUbuntu1804, api.NodeImageFamilyUbuntu2004, api.NodeImageFamilyUbuntu2204, api.NodeImageFamilyUbuntuPro2204:
@@ -86,6 +97,160 @@ func NewManagedBootstrapper(clusterConfig *api.ClusterConfig, ng *api.ManagedNod
 	return nil, nil
 }
 
+// makeMIGScript returns a script that enables MIG mode on all GPUs and creates the
+// GPU instances in mig.Profiles, along with their default compute instances, on each GPU
+func makeMIGScript(mig *api.GPUMIG) string {
+	return fmt.Sprintf(`#!/bin/bash
+
+set -o errexit
+set -o pipefail
+set -o nounset
+
+nvidia-smi -mig 1
+nvidia-smi mig -dci || true
+nvidia-smi mig -dgi || true
+nvidia-smi mig -cgi %s -C
+`, strings.Join(mig.Profiles, ","))
+}
+
+// nodeNoProxy lists the addresses nodes always reach directly: the node itself, the instance metadata service
+// and the domains of Kubernetes services.
+var nodeNoProxy = []string{"localhost", "127.0.0.1", "169.254.169.254", ".svc", ".cluster.local"}
+
+// makeNoProxy returns the addresses nodes reach without the proxy: nodeNoProxy, the CIDRs of the VPC and of the
+// Kubernetes services, followed by proxy.noProxy.
+func makeNoProxy(clusterConfig *api.ClusterConfig) []string {
+	noProxy := append([]string{}, nodeNoProxy...)
+	if clusterConfig.VPC != nil && clusterConfig.VPC.CIDR != nil {
+		noProxy = append(noProxy, clusterConfig.VPC.CIDR.String())
+	}
+	networkConfig := clusterConfig.KubernetesNetworkConfig
+	if clusterConfig.Status != nil && clusterConfig.Status.KubernetesNetworkConfig != nil {
+		networkConfig = clusterConfig.Status.KubernetesNetworkConfig
+	}
+	if networkConfig != nil && networkConfig.ServiceIPv4CIDR != "" {
+		noProxy = append(noProxy, networkConfig.ServiceIPv4CIDR)
+	}
+	return append(noProxy, clusterConfig.Proxy.NoProxy...)
+}
+
+// propagateProxy reports whether the proxy settings of clusterConfig should be applied to nodes.
+func propagateProxy(clusterConfig *api.ClusterConfig) bool {
+	return clusterConfig.Proxy != nil && api.IsEnabled(clusterConfig.Proxy.PropagateToNodes)
+}
+
+// makeProxyScript returns a script that configures containerd and kubelet, as well as login shells,
+// to send HTTPS requests through proxy.HTTPSProxy
+func makeProxyScript(clusterConfig *api.ClusterConfig) string {
+	proxy := clusterConfig.Proxy
+	noProxy := strings.Join(makeNoProxy(clusterConfig), ",")
+	return fmt.Sprintf(`#!/bin/bash
+
+set -o errexit
+set -o pipefail
+set -o nounset
+
+for unit in containerd kubelet; do
+  mkdir -p "/etc/systemd/system/${unit}.service.d"
+  cat > "/etc/systemd/system/${unit}.service.d/http-proxy.conf" <<EOF
+[Service]
+Environment="HTTPS_PROXY=%[1]s" "https_proxy=%[1]s" "NO_PROXY=%[2]s" "no_proxy=%[2]s"
+EOF
+done
+
+cat >> /etc/environment <<EOF
+HTTPS_PROXY=%[1]s
+https_proxy=%[1]s
+NO_PROXY=%[2]s
+no_proxy=%[2]s
+EOF
+
+systemctl daemon-reload
+`, proxy.HTTPSProxy, noProxy)
+}
+
+// makeRegistryMirrorScript returns a script that configures containerd to pull images of the upstream
+// registries of the ECR pull-through cache rules through the private ECR registry of the account.
+// containerd does not authenticate to mirrors itself, so the mirrors are written with the Authorization
+// header of an ECR token, which a systemd timer refreshes well before the token expires after 12 hours.
+// containerd falls back to the upstream registry if the pull through ECR fails.
+func makeRegistryMirrorScript(clusterConfig *api.ClusterConfig) (string, error) {
+	registryHost, err := clusterConfig.ECRRegistryHost()
+	if err != nil {
+		return "", err
+	}
+	var mirrors strings.Builder
+	for _, rule := range clusterConfig.PullThroughCache.Rules {
+		upstream := rule.UpstreamRegistryHost()
+		namespace := upstream
+		if upstream == "registry-1.docker.io" {
+			namespace = "docker.io"
+		}
+		fmt.Fprintf(&mirrors, "write_mirror %s %s %s\n", namespace, upstream, rule.RepositoryPrefix())
+	}
+	return fmt.Sprintf(`#!/bin/bash
+
+set -o errexit
+set -o pipefail
+set -o nounset
+
+cat > /usr/local/bin/eksctl-registry-mirrors.sh <<'SCRIPT'
+#!/bin/bash
+
+set -o errexit
+set -o pipefail
+set -o nounset
+
+umask 077
+token="$(aws ecr get-login-password --region %[1]s)"
+authorization="Basic $(printf 'AWS:%%s' "${token}" | base64 --wrap=0)"
+
+write_mirror() {
+  mkdir -p "/etc/containerd/certs.d/$1"
+  cat > "/etc/containerd/certs.d/$1/hosts.toml.tmp" <<EOF
+server = "https://$2"
+
+[host."https://%[2]s/v2/$3"]
+  capabilities = ["pull", "resolve"]
+  override_path = true
+  [host."https://%[2]s/v2/$3".header]
+    Authorization = "${authorization}"
+EOF
+  mv "/etc/containerd/certs.d/$1/hosts.toml.tmp" "/etc/containerd/certs.d/$1/hosts.toml"
+}
+
+%[3]sSCRIPT
+chmod 0700 /usr/local/bin/eksctl-registry-mirrors.sh
+
+cat > /etc/systemd/system/eksctl-registry-mirrors.service <<EOF
+[Unit]
+Description=Refresh the ECR authorization of the containerd registry mirrors
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+Type=oneshot
+ExecStart=/usr/local/bin/eksctl-registry-mirrors.sh
+EOF
+
+cat > /etc/systemd/system/eksctl-registry-mirrors.timer <<EOF
+[Unit]
+Description=Refresh the ECR authorization of the containerd registry mirrors every 6 hours
+
+[Timer]
+OnActiveSec=6h
+OnUnitActiveSec=6h
+
+[Install]
+WantedBy=timers.target
+EOF
+
+/usr/local/bin/eksctl-registry-mirrors.sh
+systemctl daemon-reload
+systemctl enable --now eksctl-registry-mirrors.timer
+`, clusterConfig.Metadata.Region, registryHost, mirrors.String()), nil
+}
+
 // GetClusterDNS returns the DNS address to use
 func GetClusterDNS(clusterConfig *api.ClusterConfig) (string, error) {
 	networkConfig := clusterConfig.Status.KubernetesNetworkConfig
@@ -114,6 +279,16 @@ func linuxConfig(clusterConfig *api.ClusterConfig, bootScriptName, bootScriptCon
 	if len(scripts) == 0 {
 		scripts = []script{}
 	}
+	if clusterConfig.HasPullThroughCache() {
+		registryMirrorScript, err := makeRegistryMirrorScript(clusterConfig)
+		if err != nil {
+			return "", err
+		}
+		scripts = append([]script{{name: "registry-mirrors.sh", contents: registryMirrorScript}}, scripts...)
+	}
+	if propagateProxy(clusterConfig) {
+		scripts = append([]script{{name: "proxy.sh", contents: makeProxyScript(clusterConfig)}}, scripts...)
+	}
 
 	if ng.OverrideBootstrapCommand != nil {
 		config.AddShellCommand(*ng.OverrideBootstrapCommand)
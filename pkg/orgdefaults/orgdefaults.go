@@ -0,0 +1,153 @@
+// Package orgdefaults applies the defaults and guardrails of an AWS account, e.g. as set up by the account
+// vending process of AWS Control Tower, to a ClusterConfig.
+package orgdefaults
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/kris-nova/logger"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+// EnvSSMParameter is the environment variable holding the SSM parameter of the defaults, used when
+// organizationDefaults is not set in the config file.
+const EnvSSMParameter = "EKSCTL_ORGANIZATION_DEFAULTS_SSM_PARAMETER"
+
+// Defaults holds the defaults of an AWS account. They are stored as YAML or JSON.
+type Defaults struct {
+	// AllowedRegions are the regions clusters can be created in. All regions are allowed if empty.
+	AllowedRegions []string `json:"allowedRegions,omitempty"`
+	// Tags are added to all resources. They take precedence over the tags in the ClusterConfig.
+	Tags map[string]string `json:"tags,omitempty"`
+	// RequiredTags are the keys of the tags all resources must have, e.g. for cost allocation.
+	// Resources are not created if any of them is missing from the tags of the ClusterConfig.
+	RequiredTags []string `json:"requiredTags,omitempty"`
+	// AllowedInstanceFamilies are the instance families, e.g. `m5` or `c6g`, nodegroups can use.
+	// All instance families are allowed if empty.
+	AllowedInstanceFamilies []string `json:"allowedInstanceFamilies,omitempty"`
+}
+
+// SSMParameter returns the SSM parameter of the defaults of clusterConfig, falling back to EnvSSMParameter.
+func SSMParameter(clusterConfig *api.ClusterConfig) string {
+	if clusterConfig.OrganizationDefaults != nil {
+		return clusterConfig.OrganizationDefaults.SSMParameter
+	}
+	return os.Getenv(EnvSSMParameter)
+}
+
+// Fetch reads the defaults from parameter.
+func Fetch(ctx context.Context, ssmAPI awsapi.SSM, parameter string) (*Defaults, error) {
+	output, err := ssmAPI.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(parameter),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading organization defaults from SSM parameter %q: %w", parameter, err)
+	}
+	if output.Parameter == nil || output.Parameter.Value == nil {
+		return nil, fmt.Errorf("SSM parameter %q has no value", parameter)
+	}
+	var defaults Defaults
+	if err := yaml.UnmarshalStrict([]byte(*output.Parameter.Value), &defaults); err != nil {
+		return nil, fmt.Errorf("parsing organization defaults in SSM parameter %q: %w", parameter, err)
+	}
+	return &defaults, nil
+}
+
+// Apply merges defaults into clusterConfig and checks that it complies with them.
+func Apply(defaults *Defaults, clusterConfig *api.ClusterConfig) error {
+	region := clusterConfig.Metadata.Region
+	if len(defaults.AllowedRegions) > 0 && !slices.Contains(defaults.AllowedRegions, region) {
+		return fmt.Errorf("region %q is not allowed by the organization defaults, allowed regions are: %s", region, strings.Join(defaults.AllowedRegions, ", "))
+	}
+
+	if len(defaults.Tags) > 0 && clusterConfig.Metadata.Tags == nil {
+		clusterConfig.Metadata.Tags = map[string]string{}
+	}
+	for k, v := range defaults.Tags {
+		if existing, ok := clusterConfig.Metadata.Tags[k]; ok && existing != v {
+			logger.Warning("overriding tag %s=%s with %s=%s from the organization defaults", k, existing, k, v)
+		}
+		clusterConfig.Metadata.Tags[k] = v
+	}
+	if err := CheckRequiredTags(clusterConfig, defaults.RequiredTags); err != nil {
+		return err
+	}
+
+	if len(defaults.AllowedInstanceFamilies) == 0 {
+		return nil
+	}
+	checkInstanceTypes := func(nodeGroupName string, instanceTypes ...string) error {
+		for _, instanceType := range instanceTypes {
+			if instanceType == "" || instanceType == "mixed" {
+				continue
+			}
+			if family := InstanceFamily(instanceType); !slices.Contains(defaults.AllowedInstanceFamilies, family) {
+				return fmt.Errorf("instance type %q of nodegroup %q is not allowed by the organization defaults, allowed instance families are: %s",
+					instanceType, nodeGroupName, strings.Join(defaults.AllowedInstanceFamilies, ", "))
+			}
+		}
+		return nil
+	}
+	for _, ng := range clusterConfig.NodeGroups {
+		if err := checkInstanceTypes(ng.Name, ng.InstanceType); err != nil {
+			return err
+		}
+		if ng.InstancesDistribution != nil {
+			if err := checkInstanceTypes(ng.Name, ng.InstancesDistribution.InstanceTypes...); err != nil {
+				return err
+			}
+		}
+	}
+	for _, ng := range clusterConfig.ManagedNodeGroups {
+		if err := checkInstanceTypes(ng.Name, append([]string{ng.InstanceType}, ng.InstanceTypes...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckRequiredTags checks that the tags of clusterConfig, which are added to all resources, have a non-empty value
+// for each of requiredTags. Nodegroups inherit these tags, so their own tags cannot make up for a missing one.
+func CheckRequiredTags(clusterConfig *api.ClusterConfig, requiredTags []string) error {
+	var missing []string
+	for _, key := range requiredTags {
+		if clusterConfig.Metadata.Tags[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return fmt.Errorf("refusing to create resources without the required tags: %s; set them in metadata.tags or with --tags", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// InstanceFamily returns the family of instanceType, e.g. `m5` for `m5.large`.
+func InstanceFamily(instanceType string) string {
+	family, _, _ := strings.Cut(instanceType, ".")
+	return family
+}
+
+// FetchAndApply applies the organization defaults of clusterConfig, if any.
+func FetchAndApply(ctx context.Context, ssmAPI awsapi.SSM, clusterConfig *api.ClusterConfig) error {
+	parameter := SSMParameter(clusterConfig)
+	if parameter == "" {
+		return nil
+	}
+	logger.Info("applying organization defaults from SSM parameter %q", parameter)
+	defaults, err := Fetch(ctx, ssmAPI, parameter)
+	if err != nil {
+		return err
+	}
+	return Apply(defaults, clusterConfig)
+}
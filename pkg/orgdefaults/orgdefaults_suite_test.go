@@ -0,0 +1,11 @@
+package orgdefaults_test
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestOrgDefaults(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
@@ -0,0 +1,125 @@
+package orgdefaults_test
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/orgdefaults"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Organization defaults", func() {
+	var (
+		provider      *mockprovider.MockProvider
+		clusterConfig *api.ClusterConfig
+	)
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		clusterConfig = api.NewClusterConfig()
+		clusterConfig.Metadata.Name = "cluster"
+		clusterConfig.Metadata.Region = "eu-west-1"
+		clusterConfig.Metadata.Tags = map[string]string{"team": "payments", "cost-center": "123"}
+		clusterConfig.OrganizationDefaults = &api.OrganizationDefaults{SSMParameter: "/platform/eks-defaults"}
+	})
+
+	mockParameter := func(value string) {
+		provider.MockSSM().On("GetParameter", mock.Anything, &ssm.GetParameterInput{
+			Name:           aws.String("/platform/eks-defaults"),
+			WithDecryption: aws.Bool(true),
+		}).Return(&ssm.GetParameterOutput{
+			Parameter: &ssmtypes.Parameter{
+				Value: aws.String(value),
+			},
+		}, nil)
+	}
+
+	It("merges the mandatory tags", func() {
+		mockParameter(`
+allowedRegions: [eu-west-1, eu-central-1]
+tags:
+  cost-center: "456"
+  environment: production
+`)
+		Expect(orgdefaults.FetchAndApply(context.Background(), provider.SSM(), clusterConfig)).To(Succeed())
+		Expect(clusterConfig.Metadata.Tags).To(Equal(map[string]string{
+			"team":        "payments",
+			"cost-center": "456",
+			"environment": "production",
+		}))
+	})
+
+	It("rejects regions that are not allowed", func() {
+		mockParameter(`{"allowedRegions": ["eu-central-1"]}`)
+		err := orgdefaults.FetchAndApply(context.Background(), provider.SSM(), clusterConfig)
+		Expect(err).To(MatchError(ContainSubstring(`region "eu-west-1" is not allowed`)))
+	})
+
+	It("rejects instance types of families that are not allowed", func() {
+		mockParameter(`allowedInstanceFamilies: [m5, c6g]`)
+		clusterConfig.ManagedNodeGroups = []*api.ManagedNodeGroup{
+			{NodeGroupBase: &api.NodeGroupBase{Name: "mng"}, InstanceTypes: []string{"m5.large", "c6g.xlarge"}},
+		}
+		ng := api.NewNodeGroup()
+		ng.Name = "ng"
+		ng.InstanceType = "mixed"
+		ng.InstancesDistribution = &api.NodeGroupInstancesDistribution{InstanceTypes: []string{"m5.large", "p3.2xlarge"}}
+		clusterConfig.NodeGroups = []*api.NodeGroup{ng}
+
+		err := orgdefaults.FetchAndApply(context.Background(), provider.SSM(), clusterConfig)
+		Expect(err).To(MatchError(ContainSubstring(`instance type "p3.2xlarge" of nodegroup "ng" is not allowed`)))
+	})
+
+	It("rejects configs without the required tags", func() {
+		mockParameter(`
+requiredTags: [cost-center, owner, environment]
+tags:
+  environment: production
+`)
+		err := orgdefaults.FetchAndApply(context.Background(), provider.SSM(), clusterConfig)
+		Expect(err).To(MatchError(ContainSubstring("without the required tags: owner")))
+	})
+
+	It("accepts configs with the required tags", func() {
+		Expect(orgdefaults.CheckRequiredTags(clusterConfig, []string{"team", "cost-center"})).To(Succeed())
+		clusterConfig.Metadata.Tags["team"] = ""
+		Expect(orgdefaults.CheckRequiredTags(clusterConfig, []string{"team", "cost-center"})).To(MatchError(ContainSubstring("tags: team")))
+	})
+
+	It("rejects unknown fields", func() {
+		mockParameter(`allowedRegion: eu-west-1`)
+		err := orgdefaults.FetchAndApply(context.Background(), provider.SSM(), clusterConfig)
+		Expect(err).To(MatchError(ContainSubstring("parsing organization defaults")))
+	})
+
+	It("returns an error when the parameter cannot be read", func() {
+		provider.MockSSM().On("GetParameter", mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+		err := orgdefaults.FetchAndApply(context.Background(), provider.SSM(), clusterConfig)
+		Expect(err).To(MatchError(ContainSubstring("access denied")))
+	})
+
+	When("no organization defaults are set", func() {
+		It("does nothing", func() {
+			clusterConfig.OrganizationDefaults = nil
+			Expect(os.Unsetenv(orgdefaults.EnvSSMParameter)).To(Succeed())
+			Expect(orgdefaults.FetchAndApply(context.Background(), provider.SSM(), clusterConfig)).To(Succeed())
+			Expect(provider.MockSSM().Calls).To(BeEmpty())
+		})
+	})
+
+	It("reads the parameter from the environment", func() {
+		clusterConfig.OrganizationDefaults = nil
+		Expect(os.Setenv(orgdefaults.EnvSSMParameter, "/platform/eks-defaults")).To(Succeed())
+		DeferCleanup(os.Unsetenv, orgdefaults.EnvSSMParameter)
+		Expect(orgdefaults.SSMParameter(clusterConfig)).To(Equal("/platform/eks-defaults"))
+	})
+})
@@ -0,0 +1,143 @@
+package printers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const noneValue = "<none>"
+
+type customColumn struct {
+	header string
+	parser *jsonpath.JSONPath
+}
+
+// CustomColumnsPrinter is a printer that outputs an object formatted
+// as a table whose columns are defined by JSONPath expressions
+type CustomColumnsPrinter struct {
+	columns []customColumn
+}
+
+// NewCustomColumnsPrinter creates a new CustomColumnsPrinter from a spec in the
+// format 'HEADER1:.path.to.field1,HEADER2:.path.to.field2'.
+func NewCustomColumnsPrinter(spec string) (OutputPrinter, error) {
+	if spec == "" {
+		return nil, errors.New("custom-columns format specified but no custom columns given")
+	}
+
+	var columns []customColumn
+	for _, part := range strings.Split(spec, ",") {
+		header, expr, found := strings.Cut(part, ":")
+		if !found || header == "" {
+			return nil, fmt.Errorf("unexpected custom-columns spec %q, expected <header>:<json-path-expr>", part)
+		}
+		template, err := relaxedJSONPathExpression(expr)
+		if err != nil {
+			return nil, err
+		}
+		parser := jsonpath.New(header).AllowMissingKeys(true)
+		if err := parser.Parse(template); err != nil {
+			return nil, errors.Wrapf(err, "parsing expression %q for column %q", expr, header)
+		}
+		columns = append(columns, customColumn{header: header, parser: parser})
+	}
+
+	return &CustomColumnsPrinter{columns: columns}, nil
+}
+
+// PrintObj will print the passed object formatted as a table
+// of custom columns to the supplied writer.
+func (c *CustomColumnsPrinter) PrintObj(obj interface{}, writer io.Writer) error {
+	return c.PrintObjWithKind("objects", obj, writer)
+}
+
+// PrintObjWithKind will print the passed object formatted as a table
+// of custom columns to the supplied writer. A slice is printed with one
+// row per item, any other object as a single row.
+func (c *CustomColumnsPrinter) PrintObjWithKind(kind string, obj interface{}, writer io.Writer) error {
+	data, err := toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	var rows []interface{}
+	switch items := data.(type) {
+	case []interface{}:
+		rows = items
+	case nil:
+	default:
+		rows = []interface{}{items}
+	}
+
+	if len(rows) == 0 {
+		_, err := fmt.Fprintf(writer, "No %s found\n", strings.ToLower(kind))
+		return err
+	}
+
+	w := tabwriter.NewWriter(writer, 10, 4, 3, ' ', 0)
+	headers := make([]string, 0, len(c.columns))
+	for _, column := range c.columns {
+		headers = append(headers, column.header)
+	}
+	if _, err := fmt.Fprintln(w, strings.Join(headers, "\t")); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		fields := make([]string, 0, len(c.columns))
+		for _, column := range c.columns {
+			value, err := columnValue(column, row)
+			if err != nil {
+				return err
+			}
+			fields = append(fields, value)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(fields, "\t")); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// LogObj will print the passed object formatted as a table of
+// custom columns to the logger.
+func (c *CustomColumnsPrinter) LogObj(log logger.LoggerFunc, msgFmt string, obj interface{}) error {
+	b := &bytes.Buffer{}
+	if err := c.PrintObj(obj, b); err != nil {
+		return err
+	}
+
+	log(msgFmt, strings.ReplaceAll(b.String(), "%", "%%"))
+
+	return nil
+}
+
+func columnValue(column customColumn, row interface{}) (string, error) {
+	results, err := column.parser.FindResults(row)
+	if err != nil {
+		return "", errors.Wrapf(err, "evaluating column %q", column.header)
+	}
+
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			if value.Kind() == reflect.Interface && value.IsNil() {
+				continue
+			}
+			values = append(values, fmt.Sprint(value.Interface()))
+		}
+	}
+	if len(values) == 0 {
+		return noneValue, nil
+	}
+	return strings.Join(values, ","), nil
+}
@@ -0,0 +1,87 @@
+package printers_test
+
+import (
+	"bytes"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/weaveworks/eksctl/pkg/printers"
+)
+
+var _ = Describe("Custom Columns Printer", func() {
+	clusters := []ekstypes.Cluster{
+		{
+			Name:   aws.String("test-cluster-1"),
+			Status: ekstypes.ClusterStatusActive,
+			ResourcesVpcConfig: &ekstypes.VpcConfigResponse{
+				VpcId:     aws.String("vpc-1234"),
+				SubnetIds: []string{"sub1", "sub2"},
+			},
+		},
+		{
+			Name:   aws.String("test-cluster-2"),
+			Status: ekstypes.ClusterStatusCreating,
+		},
+	}
+
+	It("should be returned by NewPrinter for a custom-columns output type", func() {
+		printer, err := NewPrinter("custom-columns=NAME:.Name")
+		Expect(err).NotTo(HaveOccurred())
+		_ = printer.(*CustomColumnsPrinter)
+	})
+
+	type printEntry struct {
+		spec           string
+		obj            interface{}
+		expectedOutput string
+	}
+
+	DescribeTable("printing objects", func(e printEntry) {
+		printer, err := NewCustomColumnsPrinter(e.spec)
+		Expect(err).NotTo(HaveOccurred())
+
+		var out bytes.Buffer
+		Expect(printer.PrintObjWithKind("clusters", e.obj, &out)).To(Succeed())
+		Expect(out.String()).To(Equal(e.expectedOutput))
+	},
+		Entry("one row per item", printEntry{
+			spec: "NAME:.Name,STATUS:.Status",
+			obj:  clusters,
+			expectedOutput: "NAME             STATUS\n" +
+				"test-cluster-1   ACTIVE\n" +
+				"test-cluster-2   CREATING\n",
+		}),
+		Entry("nested, multi-valued and missing fields", printEntry{
+			spec: "NAME:Name,VPC:{.ResourcesVpcConfig.VpcId},SUBNETS:.ResourcesVpcConfig.SubnetIds[*]",
+			obj:  clusters,
+			expectedOutput: "NAME             VPC        SUBNETS\n" +
+				"test-cluster-1   vpc-1234   sub1,sub2\n" +
+				"test-cluster-2   <none>     <none>\n",
+		}),
+		Entry("a single object", printEntry{
+			spec: "NAME:.Name",
+			obj:  &clusters[0],
+			expectedOutput: "NAME\n" +
+				"test-cluster-1\n",
+		}),
+		Entry("an empty slice", printEntry{
+			spec:           "NAME:.Name",
+			obj:            []ekstypes.Cluster{},
+			expectedOutput: "No clusters found\n",
+		}),
+	)
+
+	DescribeTable("invalid specs", func(spec, expectedErr string) {
+		_, err := NewCustomColumnsPrinter(spec)
+		Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("empty spec", "", "no custom columns given"),
+		Entry("missing expression", "NAME", `unexpected custom-columns spec "NAME"`),
+		Entry("missing header", ":.Name", `unexpected custom-columns spec ":.Name"`),
+		Entry("invalid expression", "NAME:.Name[", "parsing expression"),
+	)
+})
@@ -0,0 +1,100 @@
+package printers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// JSONPathPrinter is a printer that outputs the result of evaluating a
+// JSONPath template against an object
+type JSONPathPrinter struct {
+	template string
+	parser   *jsonpath.JSONPath
+}
+
+// NewJSONPathPrinter creates a new JSONPathPrinter for the supplied template,
+// e.g. '{[*].Name}'.
+func NewJSONPathPrinter(template string) (OutputPrinter, error) {
+	if template == "" {
+		return nil, errors.New("jsonpath template must not be empty")
+	}
+	parser := jsonpath.New("jsonpath").AllowMissingKeys(true)
+	if err := parser.Parse(template); err != nil {
+		return nil, errors.Wrapf(err, "parsing jsonpath template %q", template)
+	}
+	return &JSONPathPrinter{template: template, parser: parser}, nil
+}
+
+// PrintObj will print the result of evaluating the template against
+// the passed object to the supplied writer.
+func (j *JSONPathPrinter) PrintObj(obj interface{}, writer io.Writer) error {
+	data, err := toUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	if err := j.parser.Execute(writer, data); err != nil {
+		return errors.Wrapf(err, "executing jsonpath template %q", j.template)
+	}
+	return nil
+}
+
+// PrintObjWithKind will print the result of evaluating the template against
+// the passed object to the supplied writer. This printer ignores kind argument.
+func (j *JSONPathPrinter) PrintObjWithKind(kind string, obj interface{}, writer io.Writer) error {
+	return j.PrintObj(obj, writer)
+}
+
+// LogObj will print the result of evaluating the template against
+// the passed object to the logger.
+func (j *JSONPathPrinter) LogObj(log logger.LoggerFunc, msgFmt string, obj interface{}) error {
+	b := &bytes.Buffer{}
+	if err := j.PrintObj(obj, b); err != nil {
+		return err
+	}
+
+	log(msgFmt, strings.ReplaceAll(b.String(), "%", "%%"))
+
+	return nil
+}
+
+// toUnstructured converts obj into the generic representation of its JSON
+// encoding, so that JSONPath expressions refer to the same field names
+// as the JSON output.
+func toUnstructured(obj interface{}) (interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+var jsonPathExpressionRegex = regexp.MustCompile(`^\{\.?([^{}]+)\}$|^\.?([^{}]+)$`)
+
+// relaxedJSONPathExpression accepts expressions such as '.Name', 'Name' or '{.Name}'
+// and returns them in the '{.Name}' form understood by the jsonpath package.
+func relaxedJSONPathExpression(expr string) (string, error) {
+	if expr == "" {
+		return "", errors.New("jsonpath expression must not be empty")
+	}
+	submatches := jsonPathExpressionRegex.FindStringSubmatch(expr)
+	if submatches == nil {
+		return "", fmt.Errorf("unexpected path string %q, expected a 'name1.name2' or '.name1.name2' or '{name1.name2}' or '{.name1.name2}'", expr)
+	}
+	path := submatches[1]
+	if path == "" {
+		path = submatches[2]
+	}
+	return fmt.Sprintf("{.%s}", path), nil
+}
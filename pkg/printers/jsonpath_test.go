@@ -0,0 +1,56 @@
+package printers_test
+
+import (
+	"bytes"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/weaveworks/eksctl/pkg/printers"
+)
+
+var _ = Describe("JSONPath Printer", func() {
+	clusters := []*ekstypes.Cluster{
+		{
+			Name:    aws.String("test-cluster-1"),
+			Version: aws.String("1.30"),
+		},
+		{
+			Name:    aws.String("test-cluster-2"),
+			Version: aws.String("1.31"),
+		},
+	}
+
+	It("should be returned by NewPrinter for a jsonpath output type", func() {
+		printer, err := NewPrinter("jsonpath={[*].Name}")
+		Expect(err).NotTo(HaveOccurred())
+		_ = printer.(*JSONPathPrinter)
+	})
+
+	DescribeTable("printing objects", func(template string, obj interface{}, expectedOutput string) {
+		printer, err := NewJSONPathPrinter(template)
+		Expect(err).NotTo(HaveOccurred())
+
+		var out bytes.Buffer
+		Expect(printer.PrintObjWithKind("clusters", obj, &out)).To(Succeed())
+		Expect(out.String()).To(Equal(expectedOutput))
+	},
+		Entry("a field of every item", "{[*].Name}", clusters, "test-cluster-1 test-cluster-2"),
+		Entry("a range over items", `{range [*]}{.Name}{"\t"}{.Version}{"\n"}{end}`, clusters, "test-cluster-1\t1.30\ntest-cluster-2\t1.31\n"),
+		Entry("a field of a single object", "{.Version}", clusters[0], "1.30"),
+		Entry("a missing field", "{.Missing}", clusters[0], ""),
+	)
+
+	It("should reject an invalid template", func() {
+		_, err := NewJSONPathPrinter("{.Name")
+		Expect(err).To(MatchError(ContainSubstring("parsing jsonpath template")))
+	})
+
+	It("should reject an unknown output type", func() {
+		_, err := NewPrinter("go-template={{.Name}}")
+		Expect(err).To(MatchError(ContainSubstring(`but got "go-template={{.Name}}"`)))
+	})
+})
@@ -3,6 +3,7 @@ package printers
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/kris-nova/logger"
 )
@@ -17,6 +18,10 @@ const (
 	JSONType = Type("json")
 	// TableType represents a printer of Table type.
 	TableType = Type("table")
+	// CustomColumnsType represents a printer of custom columns, used as 'custom-columns=<spec>'.
+	CustomColumnsType = Type("custom-columns")
+	// JSONPathType represents a printer of a JSONPath template, used as 'jsonpath=<template>'.
+	JSONPathType = Type("jsonpath")
 )
 
 // OutputPrinter is the interface that printer must implement. This allows
@@ -31,6 +36,17 @@ type OutputPrinter interface {
 func NewPrinter(printerType Type) (OutputPrinter, error) {
 	var printer OutputPrinter
 
+	if format, spec, found := strings.Cut(printerType, "="); found {
+		switch format {
+		case CustomColumnsType:
+			return NewCustomColumnsPrinter(spec)
+		case JSONPathType:
+			return NewJSONPathPrinter(spec)
+		default:
+			return nil, errInvalidPrinterType(printerType)
+		}
+	}
+
 	switch printerType {
 	case YAMLType:
 		printer = NewYAMLPrinter()
@@ -46,5 +62,5 @@ func NewPrinter(printerType Type) (OutputPrinter, error) {
 }
 
 func errInvalidPrinterType(printerType Type) error {
-	return fmt.Errorf("unknown output printer type: expected {%q,%q,%q} but got %q", YAMLType, JSONType, TableType, printerType)
+	return fmt.Errorf("unknown output printer type: expected {%q,%q,%q,%q,%q} but got %q", YAMLType, JSONType, TableType, CustomColumnsType+"=<spec>", JSONPathType+"=<template>", printerType)
 }
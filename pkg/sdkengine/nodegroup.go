@@ -0,0 +1,486 @@
+// Package sdkengine provisions the resources of managed nodegroups with direct AWS API calls instead of
+// CloudFormation stacks. The resources it creates are tagged so that they can be found and deleted later on.
+package sdkengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	instanceutils "github.com/weaveworks/eksctl/pkg/utils/instance"
+	"github.com/weaveworks/eksctl/pkg/utils/progress"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+const (
+	// EngineTag is the tag set on the resources created by the SDK engine
+	EngineTag = "alpha.eksctl.io/infra-engine"
+	// EngineName is the value of EngineTag
+	EngineName = "sdk"
+
+	maxRoleNameLength = 64
+)
+
+const nodeAssumeRolePolicyTemplate = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "Service": [
+          %q
+        ]
+      },
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}`
+
+// ManagedNodeGroupEngine creates and deletes managed nodegroups and their IAM roles through the EKS and IAM APIs
+type ManagedNodeGroupEngine struct {
+	ClusterConfig *api.ClusterConfig
+	EKSAPI        awsapi.EKS
+	IAMAPI        awsapi.IAM
+	EC2API        awsapi.EC2
+	WaitTimeout   time.Duration
+	// AttachCNIPolicy attaches AmazonEKS_CNI_Policy to the node role, for clusters where aws-node does not use IRSA
+	AttachCNIPolicy bool
+}
+
+// ValidateManagedNodeGroup returns an error if the nodegroup uses settings that require a launch template
+// or a CloudFormation resource, which the SDK engine does not create.
+func ValidateManagedNodeGroup(ng *api.ManagedNodeGroup) error {
+	unsupported := func(field string) error {
+		return fmt.Errorf("managedNodeGroups[%s].%s is not supported by the sdk infra engine", ng.Name, field)
+	}
+	switch {
+	case ng.AMI != "":
+		return unsupported("ami")
+	case len(ng.PreBootstrapCommands) > 0:
+		return unsupported("preBootstrapCommands")
+	case ng.OverrideBootstrapCommand != nil:
+		return unsupported("overrideBootstrapCommand")
+	case ng.MaxPodsPerNode > 0:
+		return unsupported("maxPodsPerNode")
+	case len(ng.AdditionalVolumes) > 0:
+		return unsupported("additionalVolumes")
+	case api.IsEnabled(ng.VolumeEncrypted), ng.VolumeKmsKeyID != nil:
+		return unsupported("volumeEncrypted")
+	case ng.SecurityGroups != nil && len(ng.SecurityGroups.AttachIDs) > 0:
+		return unsupported("securityGroups.attachIDs")
+	case ng.Placement != nil:
+		return unsupported("placement")
+	case api.IsEnabled(ng.EFAEnabled):
+		return unsupported("efaEnabled")
+	case ng.CapacityReservation != nil:
+		return unsupported("capacityReservation")
+	case api.IsEnabled(ng.EnableDetailedMonitoring):
+		return unsupported("enableDetailedMonitoring")
+	case ng.InstanceName != "", ng.InstancePrefix != "":
+		return unsupported("instanceName")
+	case ng.OutpostARN != "":
+		return unsupported("outpostARN")
+	}
+	if ng.IAM != nil {
+		if len(ng.IAM.AttachPolicy) > 0 {
+			return unsupported("iam.attachPolicy")
+		}
+		addonPolicies := ng.IAM.WithAddonPolicies
+		for _, policy := range []*bool{addonPolicies.AutoScaler, addonPolicies.ExternalDNS, addonPolicies.CertManager,
+			addonPolicies.AppMesh, addonPolicies.AppMeshPreview, addonPolicies.EBS, addonPolicies.FSX, addonPolicies.EFS,
+			addonPolicies.AWSLoadBalancerController, addonPolicies.DeprecatedALBIngress, addonPolicies.XRay} {
+			if api.IsEnabled(policy) {
+				return unsupported("iam.withAddonPolicies")
+			}
+		}
+	}
+	return nil
+}
+
+// Create creates the IAM role of the nodegroup, unless an existing one is given, and the nodegroup,
+// and waits for the nodegroup to become active. The role is deleted again if the nodegroup cannot be created.
+func (e *ManagedNodeGroupEngine) Create(ctx context.Context, ng *api.ManagedNodeGroup) error {
+	var (
+		roleName string
+		roleARN  = ng.IAM.InstanceRoleARN
+	)
+	if roleARN == "" {
+		var err error
+		if roleName, roleARN, err = e.createNodeRole(ctx, ng); err != nil {
+			return fmt.Errorf("creating IAM role for managed nodegroup %q: %w", ng.Name, err)
+		}
+	}
+
+	output, err := e.createNodegroup(ctx, ng, roleARN)
+	if err != nil {
+		if roleName != "" {
+			e.cleanupRole(ctx, roleName)
+		}
+		return err
+	}
+	if output.Nodegroup != nil {
+		progress.RecordResource("EKS managed nodegroup", ng.Name, aws.ToString(output.Nodegroup.NodegroupArn))
+	}
+	logger.Info("waiting for managed nodegroup %q to become active", ng.Name)
+	waiter := awseks.NewNodegroupActiveWaiter(e.EKSAPI)
+	return waiter.Wait(ctx, &awseks.DescribeNodegroupInput{
+		ClusterName:   aws.String(e.ClusterConfig.Metadata.Name),
+		NodegroupName: aws.String(ng.Name),
+	}, e.WaitTimeout)
+}
+
+// IsManaged returns true if the nodegroup was created by the SDK engine
+func (e *ManagedNodeGroupEngine) IsManaged(ctx context.Context, name string) (bool, error) {
+	output, err := e.EKSAPI.DescribeNodegroup(ctx, &awseks.DescribeNodegroupInput{
+		ClusterName:   aws.String(e.ClusterConfig.Metadata.Name),
+		NodegroupName: aws.String(name),
+	})
+	if err != nil {
+		var notFound *ekstypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return output.Nodegroup.Tags[EngineTag] == EngineName, nil
+}
+
+// Delete deletes the nodegroup, waits for its deletion and deletes its IAM role if it was created by the SDK engine
+func (e *ManagedNodeGroupEngine) Delete(ctx context.Context, name string) error {
+	clusterName := e.ClusterConfig.Metadata.Name
+	output, err := e.EKSAPI.DeleteNodegroup(ctx, &awseks.DeleteNodegroupInput{
+		ClusterName:   aws.String(clusterName),
+		NodegroupName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("deleting managed nodegroup %q: %w", name, err)
+	}
+	waiter := awseks.NewNodegroupDeletedWaiter(e.EKSAPI)
+	if err := waiter.Wait(ctx, &awseks.DescribeNodegroupInput{
+		ClusterName:   aws.String(clusterName),
+		NodegroupName: aws.String(name),
+	}, e.WaitTimeout); err != nil {
+		return fmt.Errorf("waiting for managed nodegroup %q to be deleted: %w", name, err)
+	}
+
+	roleName := roleNameFromARN(aws.ToString(output.Nodegroup.NodeRole))
+	role, err := e.IAMAPI.GetRole(ctx, &iam.GetRoleInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		var noSuchEntity *iamtypes.NoSuchEntityException
+		if errors.As(err, &noSuchEntity) {
+			return nil
+		}
+		return err
+	}
+	if !hasTag(role.Role.Tags, EngineTag, EngineName) || !hasTag(role.Role.Tags, api.NodeGroupNameTag, name) {
+		logger.Debug("leaving IAM role %q of managed nodegroup %q, as it was not created by the sdk infra engine", roleName, name)
+		return nil
+	}
+	return e.deleteRole(ctx, roleName)
+}
+
+func (e *ManagedNodeGroupEngine) createNodegroup(ctx context.Context, ng *api.ManagedNodeGroup, roleARN string) (*awseks.CreateNodegroupOutput, error) {
+	input, err := e.makeCreateNodegroupInput(ctx, ng, roleARN)
+	if err != nil {
+		return nil, err
+	}
+	output, err := e.EKSAPI.CreateNodegroup(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("creating managed nodegroup %q: %w", ng.Name, err)
+	}
+	return output, nil
+}
+
+// createNodeRole creates the node role and attaches its policies, deleting the role if the policies cannot be attached
+func (e *ManagedNodeGroupEngine) createNodeRole(ctx context.Context, ng *api.ManagedNodeGroup) (roleName, roleARN string, err error) {
+	roleName = ng.IAM.InstanceRoleName
+	if roleName == "" {
+		roleName = makeRoleName(e.ClusterConfig.Metadata.Name, ng.Name)
+	}
+	input := &iam.CreateRoleInput{
+		RoleName:                 aws.String(roleName),
+		AssumeRolePolicyDocument: aws.String(e.makeAssumeRolePolicy()),
+		Tags:                     e.makeRoleTags(ng),
+	}
+	if ng.IAM.InstanceRolePermissionsBoundary != "" {
+		input.PermissionsBoundary = aws.String(ng.IAM.InstanceRolePermissionsBoundary)
+	}
+	logger.Info("creating IAM role %q for managed nodegroup %q", roleName, ng.Name)
+	output, err := e.IAMAPI.CreateRole(ctx, input)
+	if err != nil {
+		return "", "", err
+	}
+	if err := e.attachPolicies(ctx, ng, roleName); err != nil {
+		e.cleanupRole(ctx, roleName)
+		return "", "", err
+	}
+	return roleName, aws.ToString(output.Role.Arn), nil
+}
+
+func (e *ManagedNodeGroupEngine) attachPolicies(ctx context.Context, ng *api.ManagedNodeGroup, roleName string) error {
+	if err := iam.NewRoleExistsWaiter(e.IAMAPI).Wait(ctx, &iam.GetRoleInput{RoleName: aws.String(roleName)}, e.WaitTimeout); err != nil {
+		return err
+	}
+	for _, policyARN := range e.makePolicyARNs(ng) {
+		if _, err := e.IAMAPI.AttachRolePolicy(ctx, &iam.AttachRolePolicyInput{
+			RoleName:  aws.String(roleName),
+			PolicyArn: aws.String(policyARN),
+		}); err != nil {
+			return fmt.Errorf("attaching policy %q: %w", policyARN, err)
+		}
+	}
+	return nil
+}
+
+// cleanupRole deletes a role created for a nodegroup that could not be created, logging the failure to do so
+// as the error creating the nodegroup is the one returned
+func (e *ManagedNodeGroupEngine) cleanupRole(ctx context.Context, roleName string) {
+	if err := e.deleteRole(ctx, roleName); err != nil {
+		logger.Warning("failed to delete IAM role %q, it has to be deleted manually: %v", roleName, err)
+	}
+}
+
+func (e *ManagedNodeGroupEngine) deleteRole(ctx context.Context, roleName string) error {
+	paginator := iam.NewListAttachedRolePoliciesPaginator(e.IAMAPI, &iam.ListAttachedRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, policy := range output.AttachedPolicies {
+			if _, err := e.IAMAPI.DetachRolePolicy(ctx, &iam.DetachRolePolicyInput{
+				RoleName:  aws.String(roleName),
+				PolicyArn: policy.PolicyArn,
+			}); err != nil {
+				return fmt.Errorf("detaching policy %q from IAM role %q: %w", aws.ToString(policy.PolicyArn), roleName, err)
+			}
+		}
+	}
+	logger.Info("deleting IAM role %q", roleName)
+	if _, err := e.IAMAPI.DeleteRole(ctx, &iam.DeleteRoleInput{RoleName: aws.String(roleName)}); err != nil {
+		return fmt.Errorf("deleting IAM role %q: %w", roleName, err)
+	}
+	return nil
+}
+
+// makeAssumeRolePolicy returns the trust policy of the node role, with the EC2 principal of the cluster's partition
+func (e *ManagedNodeGroupEngine) makeAssumeRolePolicy() string {
+	return fmt.Sprintf(nodeAssumeRolePolicyTemplate, api.Partitions.ServicePrincipalForRegion("EC2", e.ClusterConfig.Metadata.Region))
+}
+
+// makePolicyARNs returns the managed policies attached to the node role, the same ones as with CloudFormation
+func (e *ManagedNodeGroupEngine) makePolicyARNs(ng *api.ManagedNodeGroup) []string {
+	partition := api.Partitions.ForRegion(e.ClusterConfig.Metadata.Region)
+	policyARN := func(name string) string {
+		return fmt.Sprintf("arn:%s:iam::aws:policy/%s", partition, name)
+	}
+	policyARNs := append([]string{}, ng.IAM.AttachPolicyARNs...)
+	if len(ng.IAM.AttachPolicyARNs) == 0 {
+		policyARNs = append(policyARNs,
+			policyARN("AmazonEKSWorkerNodePolicy"),
+			policyARN("AmazonEC2ContainerRegistryReadOnly"),
+			policyARN("AmazonSSMManagedInstanceCore"),
+		)
+		if e.AttachCNIPolicy {
+			policyARNs = append(policyARNs, policyARN("AmazonEKS_CNI_Policy"))
+		}
+	}
+	if api.IsEnabled(ng.IAM.WithAddonPolicies.ImageBuilder) {
+		policyARNs = append(policyARNs, policyARN("AmazonEC2ContainerRegistryPowerUser"))
+	}
+	if api.IsEnabled(ng.IAM.WithAddonPolicies.CloudWatch) {
+		policyARNs = append(policyARNs, policyARN("CloudWatchAgentServerPolicy"))
+	}
+	return policyARNs
+}
+
+func (e *ManagedNodeGroupEngine) makeCreateNodegroupInput(ctx context.Context, ng *api.ManagedNodeGroup, roleARN string) (*awseks.CreateNodegroupInput, error) {
+	subnets, err := e.selectSubnets(ctx, ng)
+	if err != nil {
+		return nil, err
+	}
+	tags := map[string]string{}
+	for k, v := range e.ClusterConfig.Metadata.Tags {
+		tags[k] = v
+	}
+	for k, v := range ng.Tags {
+		tags[k] = v
+	}
+	tags[api.ClusterNameTag] = e.ClusterConfig.Metadata.Name
+	tags[api.NodeGroupNameTag] = ng.Name
+	tags[EngineTag] = EngineName
+
+	input := &awseks.CreateNodegroupInput{
+		ClusterName:   aws.String(e.ClusterConfig.Metadata.Name),
+		NodegroupName: aws.String(ng.Name),
+		NodeRole:      aws.String(roleARN),
+		Subnets:       subnets,
+		Labels:        ng.Labels,
+		Tags:          tags,
+		ScalingConfig: &ekstypes.NodegroupScalingConfig{
+			MinSize:     int32Ptr(ng.MinSize),
+			MaxSize:     int32Ptr(ng.MaxSize),
+			DesiredSize: int32Ptr(ng.DesiredCapacity),
+		},
+	}
+	if ng.LaunchTemplate != nil {
+		input.LaunchTemplate = &ekstypes.LaunchTemplateSpecification{
+			Id:      aws.String(ng.LaunchTemplate.ID),
+			Version: ng.LaunchTemplate.Version,
+		}
+	} else {
+		input.InstanceTypes = ng.InstanceTypeList()
+		input.DiskSize = int32Ptr(ng.VolumeSize)
+		if ng.SSH != nil && api.IsEnabled(ng.SSH.Allow) && ng.SSH.PublicKeyName != nil {
+			input.RemoteAccess = &ekstypes.RemoteAccessConfig{
+				Ec2SshKey:            ng.SSH.PublicKeyName,
+				SourceSecurityGroups: ng.SSH.SourceSecurityGroupIDs,
+			}
+		}
+	}
+	if ng.AMIFamily != "" && ng.LaunchTemplate == nil {
+		amiType, err := amiTypeFor(ng)
+		if err != nil {
+			return nil, err
+		}
+		input.AmiType = amiType
+	}
+	if ng.Spot {
+		input.CapacityType = ekstypes.CapacityTypesSpot
+	}
+	if ng.ReleaseVersion != "" {
+		input.ReleaseVersion = aws.String(ng.ReleaseVersion)
+	}
+	if ng.UpdateConfig != nil {
+		input.UpdateConfig = &ekstypes.NodegroupUpdateConfig{
+			MaxUnavailable:           int32Ptr(ng.UpdateConfig.MaxUnavailable),
+			MaxUnavailablePercentage: int32Ptr(ng.UpdateConfig.MaxUnavailablePercentage),
+		}
+	}
+	for _, taint := range ng.Taints {
+		effect, err := taintEffect(taint.Effect)
+		if err != nil {
+			return nil, err
+		}
+		input.Taints = append(input.Taints, ekstypes.Taint{
+			Key:    aws.String(taint.Key),
+			Value:  aws.String(taint.Value),
+			Effect: effect,
+		})
+	}
+	return input, nil
+}
+
+func (e *ManagedNodeGroupEngine) selectSubnets(ctx context.Context, ng *api.ManagedNodeGroup) ([]string, error) {
+	if len(ng.AvailabilityZones) > 0 || len(ng.Subnets) > 0 {
+		return vpc.SelectNodeGroupSubnets(ctx, ng, e.ClusterConfig, e.EC2API)
+	}
+	subnetMapping := e.ClusterConfig.VPC.Subnets.Public
+	if ng.PrivateNetworking {
+		subnetMapping = e.ClusterConfig.VPC.Subnets.Private
+	}
+	var subnetIDs []string
+	for _, subnet := range subnetMapping {
+		subnetIDs = append(subnetIDs, subnet.ID)
+	}
+	if len(subnetIDs) == 0 {
+		return nil, fmt.Errorf("no subnets found for managed nodegroup %q", ng.Name)
+	}
+	sort.Strings(subnetIDs)
+	return subnetIDs, nil
+}
+
+func (e *ManagedNodeGroupEngine) makeRoleTags(ng *api.ManagedNodeGroup) []iamtypes.Tag {
+	tags := []iamtypes.Tag{
+		{Key: aws.String(api.ClusterNameTag), Value: aws.String(e.ClusterConfig.Metadata.Name)},
+		{Key: aws.String(api.NodeGroupNameTag), Value: aws.String(ng.Name)},
+		{Key: aws.String(EngineTag), Value: aws.String(EngineName)},
+	}
+	return tags
+}
+
+func amiTypeFor(ng *api.ManagedNodeGroup) (ekstypes.AMITypes, error) {
+	arm := false
+	for _, instanceType := range ng.InstanceTypeList() {
+		arm = arm || instanceutils.IsARMInstanceType(instanceType)
+	}
+	switch ng.AMIFamily {
+	case api.NodeImageFamilyAmazonLinux2023:
+		if arm {
+			return ekstypes.AMITypesAl2023Arm64Standard, nil
+		}
+		return ekstypes.AMITypesAl2023X8664Standard, nil
+	case api.NodeImageFamilyAmazonLinux2:
+		if arm {
+			return ekstypes.AMITypesAl2Arm64, nil
+		}
+		return ekstypes.AMITypesAl2X8664, nil
+	case api.NodeImageFamilyBottlerocket:
+		if arm {
+			return ekstypes.AMITypesBottlerocketArm64, nil
+		}
+		return ekstypes.AMITypesBottlerocketX8664, nil
+	default:
+		return "", fmt.Errorf("managedNodeGroups[%s].amiFamily %q is not supported by the sdk infra engine", ng.Name, ng.AMIFamily)
+	}
+}
+
+func taintEffect(effect corev1.TaintEffect) (ekstypes.TaintEffect, error) {
+	switch effect {
+	case corev1.TaintEffectNoSchedule:
+		return ekstypes.TaintEffectNoSchedule, nil
+	case corev1.TaintEffectPreferNoSchedule:
+		return ekstypes.TaintEffectPreferNoSchedule, nil
+	case corev1.TaintEffectNoExecute:
+		return ekstypes.TaintEffectNoExecute, nil
+	default:
+		return "", fmt.Errorf("unexpected taint effect: %v", effect)
+	}
+}
+
+func makeRoleName(clusterName, nodeGroupName string) string {
+	name := fmt.Sprintf("eksctl-%s-%s-NodeRole", clusterName, nodeGroupName)
+	if len(name) > maxRoleNameLength {
+		name = name[:maxRoleNameLength]
+	}
+	return name
+}
+
+func roleNameFromARN(roleARN string) string {
+	for i := len(roleARN) - 1; i >= 0; i-- {
+		if roleARN[i] == '/' {
+			return roleARN[i+1:]
+		}
+	}
+	return roleARN
+}
+
+func hasTag(tags []iamtypes.Tag, key, value string) bool {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == key && aws.ToString(tag.Value) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func int32Ptr(i *int) *int32 {
+	if i == nil {
+		return nil
+	}
+	return aws.Int32(int32(*i))
+}
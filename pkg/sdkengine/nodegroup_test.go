@@ -0,0 +1,210 @@
+package sdkengine_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/sdkengine"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("ManagedNodeGroupEngine", func() {
+	var (
+		p      *mockprovider.MockProvider
+		cfg    *api.ClusterConfig
+		engine *sdkengine.ManagedNodeGroupEngine
+	)
+
+	const roleARN = "arn:aws:iam::123456789012:role/eksctl-my-cluster-mng-1-NodeRole"
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Metadata.Region = "us-west-2"
+		cfg.VPC.Subnets = &api.ClusterSubnets{
+			Public: api.AZSubnetMapping{
+				"us-west-2b": api.AZSubnetSpec{ID: "subnet-b"},
+				"us-west-2a": api.AZSubnetSpec{ID: "subnet-a"},
+			},
+		}
+		engine = &sdkengine.ManagedNodeGroupEngine{
+			ClusterConfig:   cfg,
+			EKSAPI:          p.MockEKS(),
+			IAMAPI:          p.MockIAM(),
+			EC2API:          p.MockEC2(),
+			WaitTimeout:     time.Minute,
+			AttachCNIPolicy: true,
+		}
+	})
+
+	Describe("Create", func() {
+		var (
+			ng                 *api.ManagedNodeGroup
+			createNodegroupErr error
+		)
+
+		BeforeEach(func() {
+			createNodegroupErr = nil
+			ng = api.NewManagedNodeGroup()
+			ng.Name = "mng-1"
+			ng.AMIFamily = api.NodeImageFamilyAmazonLinux2023
+			ng.InstanceType = "m5.large"
+			ng.MinSize, ng.MaxSize, ng.DesiredCapacity = aws.Int(1), aws.Int(3), aws.Int(2)
+			ng.Spot = true
+
+			p.MockIAM().On("CreateRole", mock.Anything, mock.Anything).Return(&iam.CreateRoleOutput{
+				Role: &iamtypes.Role{Arn: aws.String(roleARN)},
+			}, nil)
+			p.MockIAM().On("GetRole", mock.Anything, mock.Anything, mock.Anything).Return(&iam.GetRoleOutput{
+				Role: &iamtypes.Role{Arn: aws.String(roleARN)},
+			}, nil)
+			p.MockIAM().On("AttachRolePolicy", mock.Anything, mock.Anything).Return(&iam.AttachRolePolicyOutput{}, nil)
+			p.MockEKS().On("CreateNodegroup", mock.Anything, mock.Anything).Return(&awseks.CreateNodegroupOutput{}, func(context.Context, *awseks.CreateNodegroupInput, ...func(*awseks.Options)) error {
+				return createNodegroupErr
+			})
+			p.MockEKS().On("DescribeNodegroup", mock.Anything, mock.Anything, mock.Anything).Return(&awseks.DescribeNodegroupOutput{
+				Nodegroup: &ekstypes.Nodegroup{Status: ekstypes.NodegroupStatusActive},
+			}, nil)
+		})
+
+		It("creates a tagged IAM role and the nodegroup", func() {
+			Expect(engine.Create(context.Background(), ng)).To(Succeed())
+
+			createRoleInput := p.MockIAM().Calls[0].Arguments[1].(*iam.CreateRoleInput)
+			Expect(*createRoleInput.RoleName).To(Equal("eksctl-my-cluster-mng-1-NodeRole"))
+			Expect(createRoleInput.Tags).To(ContainElement(iamtypes.Tag{Key: aws.String(sdkengine.EngineTag), Value: aws.String(sdkengine.EngineName)}))
+			Expect(*createRoleInput.AssumeRolePolicyDocument).To(ContainSubstring(`"ec2.amazonaws.com"`))
+
+			var attachedPolicies []string
+			for _, call := range p.MockIAM().Calls {
+				if call.Method == "AttachRolePolicy" {
+					attachedPolicies = append(attachedPolicies, *call.Arguments[1].(*iam.AttachRolePolicyInput).PolicyArn)
+				}
+			}
+			Expect(attachedPolicies).To(ConsistOf(
+				"arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy",
+				"arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly",
+				"arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore",
+				"arn:aws:iam::aws:policy/AmazonEKS_CNI_Policy",
+			))
+
+			input := p.MockEKS().Calls[0].Arguments[1].(*awseks.CreateNodegroupInput)
+			Expect(*input.NodeRole).To(Equal(roleARN))
+			Expect(input.Subnets).To(Equal([]string{"subnet-a", "subnet-b"}))
+			Expect(input.InstanceTypes).To(Equal([]string{"m5.large"}))
+			Expect(input.AmiType).To(Equal(ekstypes.AMITypesAl2023X8664Standard))
+			Expect(input.CapacityType).To(Equal(ekstypes.CapacityTypesSpot))
+			Expect(*input.ScalingConfig.DesiredSize).To(Equal(int32(2)))
+			Expect(input.Tags).To(HaveKeyWithValue(sdkengine.EngineTag, sdkengine.EngineName))
+			Expect(input.Tags).To(HaveKeyWithValue(api.NodeGroupNameTag, "mng-1"))
+		})
+
+		It("uses the partition's EC2 principal and policies in China regions", func() {
+			cfg.Metadata.Region = api.RegionCNNorth1
+			Expect(engine.Create(context.Background(), ng)).To(Succeed())
+
+			createRoleInput := p.MockIAM().Calls[0].Arguments[1].(*iam.CreateRoleInput)
+			Expect(*createRoleInput.AssumeRolePolicyDocument).To(ContainSubstring(`"ec2.amazonaws.com.cn"`))
+			p.MockIAM().AssertCalled(GinkgoT(), "AttachRolePolicy", mock.Anything, &iam.AttachRolePolicyInput{
+				RoleName:  aws.String("eksctl-my-cluster-mng-1-NodeRole"),
+				PolicyArn: aws.String("arn:aws-cn:iam::aws:policy/AmazonEKSWorkerNodePolicy"),
+			})
+		})
+
+		It("deletes the IAM role it created when the nodegroup cannot be created", func() {
+			createNodegroupErr = &ekstypes.InvalidParameterException{Message: aws.String("invalid instance type")}
+			p.MockIAM().On("ListAttachedRolePolicies", mock.Anything, mock.Anything, mock.Anything).Return(&iam.ListAttachedRolePoliciesOutput{
+				AttachedPolicies: []iamtypes.AttachedPolicy{{PolicyArn: aws.String("arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy")}},
+			}, nil)
+			p.MockIAM().On("DetachRolePolicy", mock.Anything, mock.Anything).Return(&iam.DetachRolePolicyOutput{}, nil)
+			p.MockIAM().On("DeleteRole", mock.Anything, mock.Anything).Return(&iam.DeleteRoleOutput{}, nil)
+
+			err := engine.Create(context.Background(), ng)
+			Expect(err).To(MatchError(ContainSubstring("creating managed nodegroup \"mng-1\"")))
+			p.MockIAM().AssertCalled(GinkgoT(), "DeleteRole", mock.Anything, &iam.DeleteRoleInput{RoleName: aws.String("eksctl-my-cluster-mng-1-NodeRole")})
+		})
+
+		It("uses an existing instance role", func() {
+			ng.IAM.InstanceRoleARN = "arn:aws:iam::123456789012:role/existing"
+			Expect(engine.Create(context.Background(), ng)).To(Succeed())
+
+			p.MockIAM().AssertNotCalled(GinkgoT(), "CreateRole", mock.Anything, mock.Anything)
+			input := p.MockEKS().Calls[0].Arguments[1].(*awseks.CreateNodegroupInput)
+			Expect(*input.NodeRole).To(Equal("arn:aws:iam::123456789012:role/existing"))
+		})
+	})
+
+	Describe("Delete", func() {
+		BeforeEach(func() {
+			p.MockEKS().On("DeleteNodegroup", mock.Anything, mock.Anything).Return(&awseks.DeleteNodegroupOutput{
+				Nodegroup: &ekstypes.Nodegroup{NodeRole: aws.String(roleARN)},
+			}, nil)
+			p.MockEKS().On("DescribeNodegroup", mock.Anything, mock.Anything, mock.Anything).Return(nil, &ekstypes.ResourceNotFoundException{})
+		})
+
+		It("deletes the IAM role created by the engine", func() {
+			p.MockIAM().On("GetRole", mock.Anything, mock.Anything).Return(&iam.GetRoleOutput{
+				Role: &iamtypes.Role{
+					Tags: []iamtypes.Tag{
+						{Key: aws.String(sdkengine.EngineTag), Value: aws.String(sdkengine.EngineName)},
+						{Key: aws.String(api.NodeGroupNameTag), Value: aws.String("mng-1")},
+					},
+				},
+			}, nil)
+			p.MockIAM().On("ListAttachedRolePolicies", mock.Anything, mock.Anything, mock.Anything).Return(&iam.ListAttachedRolePoliciesOutput{
+				AttachedPolicies: []iamtypes.AttachedPolicy{{PolicyArn: aws.String("arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy")}},
+			}, nil)
+			p.MockIAM().On("DetachRolePolicy", mock.Anything, mock.Anything).Return(&iam.DetachRolePolicyOutput{}, nil)
+			p.MockIAM().On("DeleteRole", mock.Anything, mock.Anything).Return(&iam.DeleteRoleOutput{}, nil)
+
+			Expect(engine.Delete(context.Background(), "mng-1")).To(Succeed())
+			p.MockIAM().AssertCalled(GinkgoT(), "DeleteRole", mock.Anything, &iam.DeleteRoleInput{RoleName: aws.String("eksctl-my-cluster-mng-1-NodeRole")})
+		})
+
+		It("leaves IAM roles not created by the engine", func() {
+			p.MockIAM().On("GetRole", mock.Anything, mock.Anything).Return(&iam.GetRoleOutput{
+				Role: &iamtypes.Role{},
+			}, nil)
+
+			Expect(engine.Delete(context.Background(), "mng-1")).To(Succeed())
+			p.MockIAM().AssertNotCalled(GinkgoT(), "DeleteRole", mock.Anything, mock.Anything)
+		})
+	})
+
+	DescribeTable("ValidateManagedNodeGroup", func(updateNodeGroup func(*api.ManagedNodeGroup), expectedErr string) {
+		ng := api.NewManagedNodeGroup()
+		ng.Name = "mng-1"
+		updateNodeGroup(ng)
+		err := sdkengine.ValidateManagedNodeGroup(ng)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+			return
+		}
+		Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("default nodegroup", func(*api.ManagedNodeGroup) {}, ""),
+		Entry("custom AMI", func(ng *api.ManagedNodeGroup) {
+			ng.AMI = "ami-123"
+		}, "managedNodeGroups[mng-1].ami is not supported by the sdk infra engine"),
+		Entry("preBootstrapCommands", func(ng *api.ManagedNodeGroup) {
+			ng.PreBootstrapCommands = []string{"echo hello"}
+		}, "preBootstrapCommands"),
+		Entry("addon policy requiring an inline policy", func(ng *api.ManagedNodeGroup) {
+			ng.IAM.WithAddonPolicies.AutoScaler = aws.Bool(true)
+		}, "iam.withAddonPolicies"),
+		Entry("CloudWatch addon policy", func(ng *api.ManagedNodeGroup) {
+			ng.IAM.WithAddonPolicies.CloudWatch = aws.Bool(true)
+		}, ""),
+	)
+})
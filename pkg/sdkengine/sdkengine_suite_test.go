@@ -0,0 +1,11 @@
+package sdkengine_test
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestSDKEngine(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
@@ -0,0 +1,258 @@
+// Package exitcode classifies the errors returned by eksctl commands into exit codes, so that scripts and CI
+// pipelines can branch on the kind of failure, and reports them in a machine-readable format.
+package exitcode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/aws/smithy-go"
+)
+
+// Code is the exit code of eksctl
+type Code int
+
+const (
+	// OK is returned when the command succeeded
+	OK Code = 0
+	// Generic is returned for errors that do not fall in any other category
+	Generic Code = 1
+	// ValidationError is returned when the flags or the config file are invalid
+	ValidationError Code = 2
+	// AuthError is returned when the AWS credentials are missing, expired or lack permissions
+	AuthError Code = 3
+	// QuotaExceeded is returned when an AWS service quota or limit was reached
+	QuotaExceeded Code = 4
+	// StackFailure is returned when a CloudFormation stack failed to reach the desired state
+	StackFailure Code = 5
+	// Timeout is returned when eksctl gave up waiting for a resource
+	Timeout Code = 6
+)
+
+var reasons = map[Code]string{
+	Generic:         "Error",
+	ValidationError: "ValidationError",
+	AuthError:       "AuthError",
+	QuotaExceeded:   "QuotaExceeded",
+	StackFailure:    "StackFailure",
+	Timeout:         "Timeout",
+}
+
+var hints = map[Code][]string{
+	ValidationError: {
+		"check the flags and the config file against the schema, see `eksctl utils schema`",
+	},
+	AuthError: {
+		"check that valid AWS credentials are configured, e.g. with `aws sts get-caller-identity`",
+		"check that the IAM identity has the permissions listed in https://eksctl.io/usage/minimum-iam-policies/",
+	},
+	QuotaExceeded: {
+		"request a quota increase with `eksctl utils request-quota-increase`, or delete unused resources",
+	},
+	StackFailure: {
+		"check the stack events in the CloudFormation console, or rerun the command with -v 4",
+		"delete the failed stacks before retrying, e.g. with `eksctl delete cluster`",
+	},
+	Timeout: {
+		"increase the wait timeout with --timeout",
+	},
+}
+
+// Reason returns the name of the code, as used in error reports
+func (c Code) Reason() string {
+	if reason, ok := reasons[c]; ok {
+		return reason
+	}
+	return reasons[Generic]
+}
+
+var (
+	authErrorCodes = []string{
+		"AccessDenied",
+		"AccessDeniedException",
+		"UnauthorizedOperation",
+		"UnrecognizedClientException",
+		"InvalidClientTokenId",
+		"ExpiredToken",
+		"ExpiredTokenException",
+		"SignatureDoesNotMatch",
+	}
+	quotaErrorCodes = []string{
+		"LimitExceeded",
+		"LimitExceededException",
+		"ResourceLimitExceeded",
+		"ServiceQuotaExceededException",
+		"VcpuLimitExceeded",
+		"AddressLimitExceeded",
+		"VpcLimitExceeded",
+		"InstanceLimitExceeded",
+	}
+)
+
+// Error is an error with an exit code and, optionally, the resource that failed
+type Error struct {
+	Code     Code
+	Resource string
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithCode sets the exit code of err, it returns nil if err is nil
+func WithCode(err error, code Code) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// WithDefaultCode sets the exit code of err to code unless err is already classified otherwise, e.g. as an
+// AuthError from the AWS API, it returns nil if err is nil
+func WithDefaultCode(err error, code Code) error {
+	if Classify(err) != Generic {
+		return err
+	}
+	return WithCode(err, code)
+}
+
+// WithResource records resource as the resource that failed with err, it returns nil if err is nil
+func WithResource(err error, resource string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Resource: resource, Err: err}
+}
+
+// WithCause sets the exit code and the failed resource of err to those of the first of causes that is not
+// a generic error. It is meant for errors summarising the errors of several tasks, which are only logged.
+func WithCause(err error, causes []error) error {
+	if err == nil {
+		return nil
+	}
+	for _, cause := range causes {
+		if code := Classify(cause); code != Generic && code != OK {
+			return &Error{Code: code, Resource: FailedResource(cause), Err: err}
+		}
+	}
+	return err
+}
+
+// Classify returns the exit code for err. Codes set explicitly with WithCode take precedence over
+// the codes inferred from AWS API errors and waiter errors.
+func Classify(err error) Code {
+	if err == nil {
+		return OK
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if codeErr, ok := e.(*Error); ok && codeErr.Code != OK {
+			return codeErr.Code
+		}
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case slices.Contains(authErrorCodes, apiErr.ErrorCode()):
+			return AuthError
+		case slices.Contains(quotaErrorCodes, apiErr.ErrorCode()):
+			return QuotaExceeded
+		}
+	}
+
+	msg := err.Error()
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), strings.Contains(msg, "exceeded max wait time"):
+		return Timeout
+	case strings.Contains(msg, "waiter state transitioned to Failure"), strings.Contains(msg, "ROLLBACK_COMPLETE"):
+		return StackFailure
+	case strings.Contains(msg, "failed to retrieve credentials"), strings.Contains(msg, "no EC2 IMDS role found"):
+		return AuthError
+	}
+	return Generic
+}
+
+// FailedResource returns the resource recorded with WithResource, if any
+func FailedResource(err error) string {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if codeErr, ok := e.(*Error); ok && codeErr.Resource != "" {
+			return codeErr.Resource
+		}
+	}
+	return ""
+}
+
+// Report is the machine-readable form of an error
+type Report struct {
+	Code     Code     `json:"code"`
+	Reason   string   `json:"reason"`
+	Message  string   `json:"message"`
+	Resource string   `json:"resource,omitempty"`
+	Hints    []string `json:"hints,omitempty"`
+}
+
+// NewReport returns the report for err
+func NewReport(err error) Report {
+	code := Classify(err)
+	return Report{
+		Code:     code,
+		Reason:   code.Reason(),
+		Message:  err.Error(),
+		Resource: FailedResource(err),
+		Hints:    hints[code],
+	}
+}
+
+// Format is the format errors are written in
+type Format string
+
+const (
+	// FormatText writes errors as text, as cobra does
+	FormatText Format = "text"
+	// FormatJSON writes errors as a JSON Report
+	FormatJSON Format = "json"
+)
+
+// ParseFormat returns the Format named by format
+func ParseFormat(format string) (Format, error) {
+	switch f := Format(format); f {
+	case FormatText, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid value %q for --error-format, valid options are %q and %q", format, FormatText, FormatJSON)
+	}
+}
+
+// FormatFromArgs returns the value of --error-format in args, or FormatText if it is not set. It reads the
+// arguments before cobra parses them, so that errors parsing the flags are written in the requested format too.
+func FormatFromArgs(args []string) string {
+	const flag = "--error-format"
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return string(FormatText)
+		case arg == flag && i+1 < len(args):
+			return args[i+1]
+		case strings.HasPrefix(arg, flag+"="):
+			return strings.TrimPrefix(arg, flag+"=")
+		}
+	}
+	return string(FormatText)
+}
+
+// WriteJSON writes the report for err to w as JSON
+func WriteJSON(w io.Writer, err error) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(NewReport(err))
+}
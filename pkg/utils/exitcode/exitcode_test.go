@@ -0,0 +1,85 @@
+package exitcode_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+	"github.com/weaveworks/eksctl/pkg/utils/exitcode"
+)
+
+func TestExitCode(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
+
+var _ = Describe("exit codes", func() {
+	DescribeTable("Classify", func(err error, expectedCode exitcode.Code) {
+		Expect(exitcode.Classify(err)).To(Equal(expectedCode))
+	},
+		Entry("no error", nil, exitcode.OK),
+		Entry("generic error", errors.New("oops"), exitcode.Generic),
+		Entry("explicit code", fmt.Errorf("loading config: %w", exitcode.WithCode(errors.New("invalid"), exitcode.ValidationError)), exitcode.ValidationError),
+		Entry("access denied", &smithy.GenericAPIError{Code: "AccessDeniedException"}, exitcode.AuthError),
+		Entry("expired token", fmt.Errorf("describing cluster: %w", &smithy.GenericAPIError{Code: "ExpiredToken"}), exitcode.AuthError),
+		Entry("limit exceeded", &smithy.GenericAPIError{Code: "VpcLimitExceeded"}, exitcode.QuotaExceeded),
+		Entry("deadline exceeded", fmt.Errorf("waiting: %w", context.DeadlineExceeded), exitcode.Timeout),
+		Entry("waiter timeout", errors.New("exceeded max wait time for StackCreateComplete waiter"), exitcode.Timeout),
+		Entry("stack failure", exitcode.WithResource(errors.New("waiter state transitioned to Failure"), "eksctl-test-cluster"), exitcode.StackFailure),
+	)
+
+	It("reports the code and failed resource of the first classified cause", func() {
+		causes := []error{
+			errors.New("oops"),
+			exitcode.WithResource(errors.New("waiter state transitioned to Failure"), "eksctl-test-nodegroup-ng-1"),
+		}
+		err := exitcode.WithCause(errors.New("failed to create cluster \"test\""), causes)
+		Expect(exitcode.Classify(err)).To(Equal(exitcode.StackFailure))
+		Expect(exitcode.FailedResource(err)).To(Equal("eksctl-test-nodegroup-ng-1"))
+	})
+
+	It("leaves the error unchanged when all causes are generic", func() {
+		err := errors.New("failed")
+		Expect(exitcode.WithCause(err, []error{errors.New("oops")})).To(BeIdenticalTo(err))
+	})
+
+	It("writes the error as JSON", func() {
+		var out bytes.Buffer
+		err := exitcode.WithResource(errors.New("exceeded max wait time for StackCreateComplete waiter"), "eksctl-test-cluster")
+		Expect(exitcode.WriteJSON(&out, err)).To(Succeed())
+		Expect(out.String()).To(MatchJSON(`{
+			"code": 6,
+			"reason": "Timeout",
+			"message": "exceeded max wait time for StackCreateComplete waiter",
+			"resource": "eksctl-test-cluster",
+			"hints": ["increase the wait timeout with --timeout"]
+		}`))
+	})
+
+	It("keeps the code of errors already classified", func() {
+		err := exitcode.WithDefaultCode(fmt.Errorf("loading VPC: %w", &smithy.GenericAPIError{Code: "AccessDenied"}), exitcode.ValidationError)
+		Expect(exitcode.Classify(err)).To(Equal(exitcode.AuthError))
+		Expect(exitcode.Classify(exitcode.WithDefaultCode(errors.New("invalid"), exitcode.ValidationError))).To(Equal(exitcode.ValidationError))
+		Expect(exitcode.WithDefaultCode(nil, exitcode.ValidationError)).To(BeNil())
+	})
+
+	DescribeTable("FormatFromArgs", func(args []string, expectedFormat string) {
+		Expect(exitcode.FormatFromArgs(args)).To(Equal(expectedFormat))
+	},
+		Entry("not set", []string{"create", "cluster"}, "text"),
+		Entry("with a separate value", []string{"create", "cluster", "--error-format", "json", "--bad-flag"}, "json"),
+		Entry("with an inline value", []string{"--error-format=json", "create", "cluster"}, "json"),
+		Entry("after the end of the flags", []string{"utils", "--", "--error-format=json"}, "text"),
+	)
+
+	It("rejects unknown formats", func() {
+		_, err := exitcode.ParseFormat("xml")
+		Expect(err).To(MatchError(`invalid value "xml" for --error-format, valid options are "text" and "json"`))
+	})
+})
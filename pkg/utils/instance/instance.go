@@ -54,6 +54,12 @@ func IsNvidiaInstanceType(instanceType string) bool {
 		strings.HasPrefix(instanceType, "g6")
 }
 
+// IsMIGInstanceType returns true if the instance type has NVIDIA GPUs that support Multi-Instance GPU
+func IsMIGInstanceType(instanceType string) bool {
+	return strings.HasPrefix(instanceType, "p4d") ||
+		strings.HasPrefix(instanceType, "p5")
+}
+
 // IsInferentiaInstanceType returns true if the instance type requires AWS Neuron
 func IsInferentiaInstanceType(instanceType string) bool {
 	return strings.HasPrefix(instanceType, "inf1")
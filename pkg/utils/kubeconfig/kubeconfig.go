@@ -8,6 +8,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/gofrs/flock"
@@ -244,6 +245,68 @@ func AppendAuthenticator(config *clientcmdapi.Config, cluster ClusterInfo, authe
 	}
 }
 
+// Customize names the context of config after kc, the kubeconfig section of the config file, adds its alias
+// contexts and passes its environment variables to the authenticator
+func Customize(config *clientcmdapi.Config, kc *api.KubeconfigConfig, meta *api.ClusterMeta, username string) error {
+	if kc == nil {
+		return nil
+	}
+	currentContext := config.Contexts[config.CurrentContext]
+	authInfo := config.AuthInfos[config.CurrentContext]
+	if currentContext == nil || authInfo == nil {
+		return fmt.Errorf("kubeconfig has no context %q", config.CurrentContext)
+	}
+
+	if authInfo.Exec != nil {
+		for _, name := range sortedKeys(kc.ExecEnv) {
+			setExecEnv(authInfo.Exec, name, kc.ExecEnv[name])
+		}
+	}
+
+	data := api.KubeconfigContextData{ClusterName: meta.Name, Region: meta.Region, Username: username}
+	contextName := config.CurrentContext
+	if kc.ContextName != "" {
+		name, err := api.RenderKubeconfigContextName(kc.ContextName, data)
+		if err != nil {
+			return err
+		}
+		delete(config.Contexts, contextName)
+		delete(config.AuthInfos, contextName)
+		contextName = name
+		config.Contexts[contextName] = &clientcmdapi.Context{Cluster: currentContext.Cluster, AuthInfo: contextName}
+		config.AuthInfos[contextName] = authInfo
+		config.CurrentContext = contextName
+	}
+
+	for _, alias := range kc.AliasContexts {
+		name, err := api.RenderKubeconfigContextName(alias, data)
+		if err != nil {
+			return err
+		}
+		config.Contexts[name] = &clientcmdapi.Context{Cluster: currentContext.Cluster, AuthInfo: contextName}
+	}
+	return nil
+}
+
+func setExecEnv(execConfig *clientcmdapi.ExecConfig, name, value string) {
+	for i, env := range execConfig.Env {
+		if env.Name == name {
+			execConfig.Env[i].Value = value
+			return
+		}
+	}
+	execConfig.Env = append(execConfig.Env, clientcmdapi.ExecEnvVar{Name: name, Value: value})
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // AWSAuthenticatorVersionFormat is the format in which aws-iam-authenticator displays version information:
 // {"Version":"0.5.5","Commit":"85e50980d9d916ae95882176c18f14ae145f916f"}
 type AWSAuthenticatorVersionFormat struct {
@@ -427,13 +490,15 @@ func isValidConfig(p, name string) error {
 	// we want to make sure we only delete config files that haven't be modified by the user
 	// checking context name is a good start, we might want ot do deeper checks later, e.g. checksum,
 	// as we don't want to delete any files by accident that didn't belong to us
-	ctxFmtErr := fmt.Errorf("unable to verify ownership of config %q, unexpected contex name %q", p, clientConfig.CurrentContext)
+	// the cluster of the context is checked rather than the name of the context, which may be customized
+	// in the kubeconfig section of the config file
+	ctxFmtErr := fmt.Errorf("unable to verify ownership of config %q, unexpected context %q", p, clientConfig.CurrentContext)
 
-	ctx := strings.Split(clientConfig.CurrentContext, "@")
-	if len(ctx) != 2 {
+	ctx, ok := clientConfig.Contexts[clientConfig.CurrentContext]
+	if !ok {
 		return ctxFmtErr
 	}
-	if strings.HasPrefix(ctx[1], name+".") && strings.HasSuffix(ctx[1], ".eksctl.io") {
+	if strings.HasPrefix(ctx.Cluster, name+".") && strings.HasSuffix(ctx.Cluster, ".eksctl.io") {
 		return nil
 	}
 	return ctxFmtErr
@@ -510,24 +575,24 @@ func deleteClusterInfo(existing *clientcmdapi.Config, meta *api.ClusterMeta) boo
 		isChanged = true
 	}
 
-	var currentContextName string
+	// the cluster may have several contexts, e.g. the alias contexts of the kubeconfig section of the config file
+	removedContexts := map[string]bool{}
 	for name, context := range existing.Contexts {
 		if context.Cluster == clusterName {
 			delete(existing.Contexts, name)
 			logger.Debug("removed context for %q from kubeconfig", name)
 			isChanged = true
-			if _, ok := existing.AuthInfos[name]; ok {
-				delete(existing.AuthInfos, name)
-				logger.Debug("removed user for %q from kubeconfig", name)
+			if _, ok := existing.AuthInfos[context.AuthInfo]; ok {
+				delete(existing.AuthInfos, context.AuthInfo)
+				logger.Debug("removed user for %q from kubeconfig", context.AuthInfo)
 			}
-			currentContextName = name
-			break
+			removedContexts[name] = true
 		}
 	}
 
-	if existing.CurrentContext == currentContextName {
+	if removedContexts[existing.CurrentContext] {
+		logger.Debug("reset current-context %q in kubeconfig", existing.CurrentContext)
 		existing.CurrentContext = ""
-		logger.Debug("reset current-context in kubeconfig to %q", currentContextName)
 		isChanged = true
 	}
 
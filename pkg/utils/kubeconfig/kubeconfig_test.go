@@ -478,6 +478,51 @@ var _ = Describe("Kubeconfig", func() {
 		})
 	})
 
+	Context("Customize", func() {
+		var (
+			config *clientcmdapi.Config
+			meta   *eksctlapi.ClusterMeta
+		)
+		BeforeEach(func() {
+			meta = &eksctlapi.ClusterMeta{Name: "prod", Region: "us-west-2"}
+			config = kubeconfig.NewBuilder(meta, &eksctlapi.ClusterStatus{Endpoint: "https://127.0.0.1"}, "admin").Build()
+			config.AuthInfos[config.CurrentContext] = &clientcmdapi.AuthInfo{
+				Exec: &clientcmdapi.ExecConfig{
+					Command: kubeconfig.AWSEKSAuthenticator,
+					Env:     []clientcmdapi.ExecEnvVar{{Name: "AWS_PROFILE", Value: "default"}},
+				},
+			}
+		})
+
+		It("leaves the config unchanged without a kubeconfig section", func() {
+			Expect(kubeconfig.Customize(config, nil, meta, "admin")).To(Succeed())
+			Expect(config.CurrentContext).To(Equal("admin@prod.us-west-2.eksctl.io"))
+		})
+
+		It("renames the context, adds the alias contexts and sets the exec environment", func() {
+			Expect(kubeconfig.Customize(config, &eksctlapi.KubeconfigConfig{
+				ContextName:   "{{.ClusterName}}-{{.Region}}",
+				AliasContexts: []string{"{{.ClusterName}}"},
+				ExecEnv:       map[string]string{"AWS_PROFILE": "prod", "AWS_REGION": "us-west-2"},
+			}, meta, "admin")).To(Succeed())
+
+			Expect(config.CurrentContext).To(Equal("prod-us-west-2"))
+			Expect(config.Contexts).To(HaveLen(2))
+			Expect(config.Contexts["prod-us-west-2"]).To(Equal(&clientcmdapi.Context{Cluster: "prod.us-west-2.eksctl.io", AuthInfo: "prod-us-west-2"}))
+			Expect(config.Contexts["prod"]).To(Equal(&clientcmdapi.Context{Cluster: "prod.us-west-2.eksctl.io", AuthInfo: "prod-us-west-2"}))
+			Expect(config.AuthInfos).To(HaveLen(1))
+			Expect(config.AuthInfos["prod-us-west-2"].Exec.Env).To(Equal([]clientcmdapi.ExecEnvVar{
+				{Name: "AWS_PROFILE", Value: "prod"},
+				{Name: "AWS_REGION", Value: "us-west-2"},
+			}))
+		})
+
+		It("returns an error for an invalid template", func() {
+			err := kubeconfig.Customize(config, &eksctlapi.KubeconfigConfig{ContextName: "{{.Account}}"}, meta, "admin")
+			Expect(err).To(MatchError(ContainSubstring("executing context name template")))
+		})
+	})
+
 	type checkAllCommandsEntry struct {
 		kubeconfigPath                  string
 		mockKubernetesVersionManager    func() (mockManagerFunc func() kubectl.KubernetesVersionManager, assertFakeManagerCalls func())
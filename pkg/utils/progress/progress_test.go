@@ -0,0 +1,101 @@
+package progress_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/utils/progress"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+var _ = Describe("Progress", func() {
+	Describe("Renderer", func() {
+		var (
+			out      *bytes.Buffer
+			renderer *progress.Renderer
+		)
+
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+			renderer = progress.NewRenderer(out, 60)
+			renderer.Start()
+		})
+
+		AfterEach(func() {
+			renderer.Stop()
+		})
+
+		It("writes a line for each completed task", func() {
+			taskTree := &tasks.TaskTree{Parallel: true}
+			taskTree.Append(
+				&tasks.GenericTask{Description: "create nodegroup ng-1", Doer: func() error { return nil }},
+				&tasks.GenericTask{Description: "create addon vpc-cni", Doer: func() error { return errors.New("failed") }},
+			)
+			Expect(taskTree.DoAllSync()).To(HaveLen(1))
+			renderer.Stop()
+
+			Expect(out.String()).To(ContainSubstring("✔ create nodegroup ng-1 (took 0s)"))
+			Expect(out.String()).To(ContainSubstring("✖ create addon vpc-cni (failed after 0s)"))
+		})
+
+		It("shows the tasks in flight and truncates long descriptions", func() {
+			completed := renderer.TaskStarted("create a task with a description longer than the line length of the renderer")
+			Expect(out.String()).To(ContainSubstring("create a task with a description longer than the line len…"))
+			completed(nil)
+		})
+
+		It("writes logs above the tasks in flight", func() {
+			completed := renderer.TaskStarted("create cluster")
+			_, err := renderer.Write([]byte("a log line\n"))
+			Expect(err).NotTo(HaveOccurred())
+			completed(nil)
+
+			lastLog := strings.LastIndex(out.String(), "a log line")
+			Expect(lastLog).To(BeNumerically(">", strings.Index(out.String(), "create cluster (0s)")))
+			Expect(lastLog).To(BeNumerically("<", strings.Index(out.String(), "✔ create cluster")))
+		})
+	})
+
+	Describe("PrintSummary", func() {
+		BeforeEach(func() {
+			progress.ResetResources()
+		})
+
+		It("prints nothing when no resources were created", func() {
+			out := &bytes.Buffer{}
+			Expect(progress.PrintSummary(out)).To(Succeed())
+			Expect(out.String()).To(BeEmpty())
+		})
+
+		It("prints a table of the created resources", func() {
+			progress.RecordResource("CloudFormation stack", "eksctl-cluster-cluster", "arn:aws:cloudformation:us-west-2:123456789012:stack/eksctl-cluster-cluster/1")
+			progress.RecordResource("EKS addon", "vpc-cni", "arn:aws:eks:us-west-2:123456789012:addon/cluster/vpc-cni/1")
+
+			out := &bytes.Buffer{}
+			Expect(progress.PrintSummary(out)).To(Succeed())
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[0]).To(MatchRegexp(`^KIND\s+NAME\s+ID$`))
+			Expect(lines[1]).To(MatchRegexp(`^CloudFormation stack\s+eksctl-cluster-cluster\s+arn:aws:cloudformation:`))
+			Expect(lines[2]).To(MatchRegexp(`^EKS addon\s+vpc-cni\s+arn:aws:eks:`))
+		})
+	})
+
+	DescribeTable("ParseMode", func(mode string, expectedErr bool) {
+		_, err := progress.ParseMode(mode)
+		if expectedErr {
+			Expect(err).To(HaveOccurred())
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+	},
+		Entry("auto", "auto", false),
+		Entry("always", "always", false),
+		Entry("never", "never", false),
+		Entry("invalid", "sometimes", true),
+	)
+})
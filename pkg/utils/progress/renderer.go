@@ -0,0 +1,222 @@
+// Package progress displays the progress of the tasks run by eksctl and reports the resources they created.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"golang.org/x/term"
+
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+// Mode controls whether the progress of tasks is rendered
+type Mode string
+
+const (
+	// ModeAuto renders progress when writing to a terminal, unless debug logs are enabled
+	ModeAuto Mode = "auto"
+	// ModeAlways always renders progress
+	ModeAlways Mode = "always"
+	// ModeNever only logs, as eksctl used to
+	ModeNever Mode = "never"
+
+	refreshInterval   = 100 * time.Millisecond
+	defaultLineLength = 120
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// ParseMode returns the Mode named by mode
+func ParseMode(mode string) (Mode, error) {
+	switch m := Mode(mode); m {
+	case ModeAuto, ModeAlways, ModeNever:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid value %q for --progress, valid options are %q, %q and %q", mode, ModeAuto, ModeAlways, ModeNever)
+	}
+}
+
+// Start renders the progress of the tasks run from now on to out, if mode and out allow it.
+// The returned function stops rendering and reports the resources created in the meantime,
+// as a table if progress was rendered and as log lines otherwise.
+func Start(mode Mode, out *os.File) (stop func()) {
+	ResetResources()
+	if !enabled(mode, out) {
+		return LogSummary
+	}
+	r := NewRenderer(out, lineLength(out))
+	r.Start()
+	return func() {
+		r.Stop()
+		if err := PrintSummary(out); err != nil {
+			logger.Warning("failed to print the created resources: %v", err)
+		}
+	}
+}
+
+func enabled(mode Mode, out *os.File) bool {
+	switch mode {
+	case ModeAlways:
+		return true
+	case ModeAuto:
+		return term.IsTerminal(int(out.Fd())) && logger.Level < 4
+	default:
+		return false
+	}
+}
+
+func lineLength(out *os.File) int {
+	if width, _, err := term.GetSize(int(out.Fd())); err == nil && width > 0 {
+		return width
+	}
+	return defaultLineLength
+}
+
+type inFlightTask struct {
+	description string
+	started     time.Time
+}
+
+// Renderer displays the tasks in flight below the logs, each with a spinner and its elapsed time,
+// and writes a line for each task once it has completed
+type Renderer struct {
+	out        io.Writer
+	lineLength int
+	now        func() time.Time
+
+	mu         sync.Mutex
+	inFlight   []*inFlightTask
+	drawnLines int
+	frame      int
+	logWriter  io.Writer
+	stopCh     chan struct{}
+	stoppedCh  chan struct{}
+	stopOnce   sync.Once
+}
+
+// NewRenderer returns a Renderer writing to out, truncating lines to lineLength characters
+func NewRenderer(out io.Writer, lineLength int) *Renderer {
+	return &Renderer{
+		out:        out,
+		lineLength: lineLength,
+		now:        time.Now,
+		stopCh:     make(chan struct{}),
+		stoppedCh:  make(chan struct{}),
+	}
+}
+
+// Start observes the tasks run by all task trees and redirects the logs through the renderer,
+// so that they are written above the tasks in flight
+func (r *Renderer) Start() {
+	r.mu.Lock()
+	r.logWriter = logger.Writer
+	logger.Writer = r
+	r.mu.Unlock()
+	tasks.SetObserver(r)
+
+	go func() {
+		defer close(r.stoppedCh)
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.mu.Lock()
+				r.frame++
+				r.redraw()
+				r.mu.Unlock()
+			case <-r.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops rendering, clears the tasks in flight and restores the logs, it must be called after Start
+func (r *Renderer) Stop() {
+	r.stopOnce.Do(func() {
+		tasks.SetObserver(nil)
+		close(r.stopCh)
+		<-r.stoppedCh
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.clear()
+		logger.Writer = r.logWriter
+	})
+}
+
+// TaskStarted implements tasks.Observer
+func (r *Renderer) TaskStarted(description string) func(error) {
+	task := &inFlightTask{
+		description: strings.Join(strings.Fields(description), " "),
+		started:     r.now(),
+	}
+	r.mu.Lock()
+	r.inFlight = append(r.inFlight, task)
+	r.redraw()
+	r.mu.Unlock()
+
+	return func(err error) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for i, t := range r.inFlight {
+			if t == task {
+				r.inFlight = append(r.inFlight[:i], r.inFlight[i+1:]...)
+				break
+			}
+		}
+		elapsed := r.now().Sub(task.started).Round(time.Second)
+		r.clear()
+		if err != nil {
+			fmt.Fprintln(r.out, r.truncate(fmt.Sprintf("✖ %s (failed after %s)", task.description, elapsed)))
+		} else {
+			fmt.Fprintln(r.out, r.truncate(fmt.Sprintf("✔ %s (took %s)", task.description, elapsed)))
+		}
+		r.draw()
+	}
+}
+
+// Write writes log lines above the tasks in flight
+func (r *Renderer) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clear()
+	n, err := r.out.Write(p)
+	r.draw()
+	return n, err
+}
+
+func (r *Renderer) redraw() {
+	r.clear()
+	r.draw()
+}
+
+// clear erases the lines of the tasks in flight, the cursor is left at the start of the first one
+func (r *Renderer) clear() {
+	for ; r.drawnLines > 0; r.drawnLines-- {
+		fmt.Fprint(r.out, "\x1b[1A\x1b[2K")
+	}
+}
+
+func (r *Renderer) draw() {
+	spinner := spinnerFrames[r.frame%len(spinnerFrames)]
+	for _, t := range r.inFlight {
+		elapsed := r.now().Sub(t.started).Round(time.Second)
+		fmt.Fprintln(r.out, r.truncate(fmt.Sprintf("%s %s (%s)", spinner, t.description, elapsed)))
+		r.drawnLines++
+	}
+}
+
+func (r *Renderer) truncate(line string) string {
+	runes := []rune(line)
+	if r.lineLength <= 1 || len(runes) <= r.lineLength {
+		return line
+	}
+	return string(runes[:r.lineLength-1]) + "…"
+}
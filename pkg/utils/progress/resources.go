@@ -0,0 +1,70 @@
+package progress
+
+import (
+	"io"
+	"sync"
+
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+// Resource is an AWS resource created by eksctl
+type Resource struct {
+	Kind string
+	Name string
+	// ID is the ID or ARN of the resource
+	ID string
+}
+
+var recorder struct {
+	sync.Mutex
+	resources []Resource
+}
+
+// RecordResource records a resource created by the current command, to be reported once the command completes
+func RecordResource(kind, name, id string) {
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.resources = append(recorder.resources, Resource{Kind: kind, Name: name, ID: id})
+}
+
+// Resources returns the resources recorded so far, in the order they were created
+func Resources() []Resource {
+	recorder.Lock()
+	defer recorder.Unlock()
+	return append([]Resource(nil), recorder.resources...)
+}
+
+// ResetResources forgets the resources recorded so far
+func ResetResources() {
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.resources = nil
+}
+
+// PrintSummary writes a table of the recorded resources to w, it writes nothing if no resources were recorded
+func PrintSummary(w io.Writer) error {
+	resources := Resources()
+	if len(resources) == 0 {
+		return nil
+	}
+	printer := printers.NewTablePrinter().(*printers.TablePrinter)
+	printer.AddColumn("KIND", func(r Resource) string {
+		return r.Kind
+	})
+	printer.AddColumn("NAME", func(r Resource) string {
+		return r.Name
+	})
+	printer.AddColumn("ID", func(r Resource) string {
+		return r.ID
+	})
+	return printer.PrintObjWithKind("resources", resources, w)
+}
+
+// LogSummary logs the recorded resources, one line per resource
+func LogSummary() {
+	for _, r := range Resources() {
+		logger.Info("created %s %q (%s)", r.Kind, r.Name, r.ID)
+	}
+}
@@ -0,0 +1,158 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kris-nova/logger"
+)
+
+// Progress describes a task of a tree that started or completed
+type Progress struct {
+	// Phase is the description of the task
+	Phase string
+	// Percent is the share of the tasks of the tree that have completed, from 0 to 100
+	Percent int
+	// Message tells whether the task started, completed or failed
+	Message string
+}
+
+// ProgressFunc is called as the tasks of a tree start and complete, possibly concurrently
+type ProgressFunc func(Progress)
+
+// Reverter is implemented by tasks that can undo their changes, e.g. by deleting the stack they created
+type Reverter interface {
+	Revert(ctx context.Context) error
+}
+
+// RunOptions configures DoAllSyncContext
+type RunOptions struct {
+	// OnProgress, if set, is called as tasks start and complete
+	OnProgress ProgressFunc
+	// RollbackOnCancel reverts the started tasks that implement Reverter, in reverse order of start,
+	// once the context is cancelled
+	RollbackOnCancel bool
+}
+
+// DoAllSyncContext runs the tree like DoAllSync, reporting the progress of its tasks to options.OnProgress. Once ctx
+// is done, no further task is started and ctx.Err() is returned along with the errors of the tasks; tasks in flight
+// are aborted by ctx if they were created with it, e.g. the tasks waiting for CloudFormation stacks.
+func (t *TaskTree) DoAllSyncContext(ctx context.Context, options RunOptions) []error {
+	run := &contextRun{
+		ctx:     ctx,
+		options: options,
+		total:   countTasks(t),
+	}
+	errs := run.wrap(t).DoAllSync()
+	if ctx.Err() == nil {
+		return errs
+	}
+	errs = append(errs, ctx.Err())
+	if options.RollbackOnCancel {
+		errs = append(errs, run.revert(context.WithoutCancel(ctx))...)
+	}
+	return errs
+}
+
+func countTasks(t *TaskTree) int {
+	count := 0
+	for _, task := range t.Tasks {
+		if subTree, ok := task.(*TaskTree); ok {
+			count += countTasks(subTree)
+		} else {
+			count++
+		}
+	}
+	return count
+}
+
+type contextRun struct {
+	ctx     context.Context
+	options RunOptions
+	total   int
+
+	mu        sync.Mutex
+	completed int
+	reverters []Reverter
+}
+
+// wrap returns a copy of t whose tasks are only started while the context is not done
+func (r *contextRun) wrap(t *TaskTree) *TaskTree {
+	wrapped := *t
+	wrapped.Tasks = make([]Task, len(t.Tasks))
+	for i, task := range t.Tasks {
+		if subTree, ok := task.(*TaskTree); ok {
+			wrapped.Tasks[i] = r.wrap(subTree)
+		} else {
+			wrapped.Tasks[i] = &contextTask{task: task, run: r}
+		}
+	}
+	return &wrapped
+}
+
+func (r *contextRun) report(phase, message string, done bool) {
+	r.mu.Lock()
+	if done {
+		r.completed++
+	}
+	percent := 100
+	if r.total > 0 {
+		percent = r.completed * 100 / r.total
+	}
+	r.mu.Unlock()
+	if r.options.OnProgress != nil {
+		r.options.OnProgress(Progress{Phase: phase, Percent: percent, Message: message})
+	}
+}
+
+func (r *contextRun) revert(ctx context.Context) []error {
+	r.mu.Lock()
+	reverters := r.reverters
+	r.reverters = nil
+	r.mu.Unlock()
+
+	var errs []error
+	for i := len(reverters) - 1; i >= 0; i-- {
+		desc := reverters[i].(Task).Describe()
+		logger.Info("rolling back task: %s", desc)
+		r.report(desc, "rolling back", false)
+		if err := reverters[i].Revert(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("rolling back task %q: %w", desc, err))
+		}
+	}
+	return errs
+}
+
+type contextTask struct {
+	task Task
+	run  *contextRun
+}
+
+func (t *contextTask) Describe() string { return t.task.Describe() }
+
+func (t *contextTask) Do(errCh chan error) error {
+	desc := t.task.Describe()
+	if err := t.run.ctx.Err(); err != nil {
+		close(errCh)
+		return fmt.Errorf("not running task %q: %w", desc, err)
+	}
+	// the reverter is registered before the task runs, so that a task aborted by the cancellation midway,
+	// e.g. while its stack is being created, is rolled back as well
+	if reverter, ok := t.task.(Reverter); ok {
+		t.run.mu.Lock()
+		t.run.reverters = append(t.run.reverters, reverter)
+		t.run.mu.Unlock()
+	}
+	t.run.report(desc, "started", false)
+	go func() {
+		defer close(errCh)
+		if err := runTask(t.task); err != nil {
+			t.run.report(desc, fmt.Sprintf("failed: %v", err), true)
+			errCh <- err
+			return
+		}
+		t.run.report(desc, "completed", true)
+	}()
+	return nil
+}
@@ -0,0 +1,98 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type revertibleTask struct {
+	GenericTask
+	reverted *[]string
+}
+
+func (t *revertibleTask) Revert(_ context.Context) error {
+	*t.reverted = append(*t.reverted, t.Description)
+	return nil
+}
+
+var _ = Describe("DoAllSyncContext", func() {
+	It("reports the progress of the tasks", func() {
+		var (
+			mu       sync.Mutex
+			progress []Progress
+		)
+		taskTree := &TaskTree{Parallel: false}
+		subTree := &TaskTree{Parallel: true, IsSubTask: true}
+		for _, desc := range []string{"t1", "t2"} {
+			subTree.Append(&GenericTask{Description: desc, Doer: func() error { return nil }})
+		}
+		taskTree.Append(subTree, &GenericTask{Description: "t3", Doer: func() error { return nil }})
+
+		errs := taskTree.DoAllSyncContext(context.Background(), RunOptions{
+			OnProgress: func(p Progress) {
+				mu.Lock()
+				defer mu.Unlock()
+				progress = append(progress, p)
+			},
+		})
+		Expect(errs).To(BeEmpty())
+		Expect(progress).To(HaveLen(6))
+		Expect(progress[len(progress)-2]).To(Equal(Progress{Phase: "t3", Percent: 66, Message: "started"}))
+		Expect(progress[len(progress)-1]).To(Equal(Progress{Phase: "t3", Percent: 100, Message: "completed"}))
+	})
+
+	It("stops starting tasks and rolls back the completed ones once the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var ran, reverted []string
+		newTask := func(desc string, doer func() error) *revertibleTask {
+			return &revertibleTask{
+				GenericTask: GenericTask{Description: desc, Doer: func() error {
+					ran = append(ran, desc)
+					return doer()
+				}},
+				reverted: &reverted,
+			}
+		}
+		taskTree := &TaskTree{Parallel: false}
+		taskTree.Append(
+			newTask("create stack 1", func() error { return nil }),
+			newTask("create stack 2", func() error {
+				cancel()
+				return nil
+			}),
+			newTask("create stack 3", func() error { return nil }),
+		)
+
+		errs := taskTree.DoAllSyncContext(ctx, RunOptions{RollbackOnCancel: true})
+		Expect(ran).To(Equal([]string{"create stack 1", "create stack 2"}))
+		Expect(reverted).To(Equal([]string{"create stack 2", "create stack 1"}))
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0]).To(MatchError(ContainSubstring(`not running task "create stack 3"`)))
+		Expect(errors.Is(errs[1], context.Canceled)).To(BeTrue())
+	})
+
+	It("rolls back the task aborted by the cancellation", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var reverted []string
+		taskTree := &TaskTree{Parallel: false}
+		taskTree.Append(&revertibleTask{
+			GenericTask: GenericTask{Description: "create stack 1", Doer: func() error {
+				cancel()
+				return ctx.Err()
+			}},
+			reverted: &reverted,
+		})
+
+		errs := taskTree.DoAllSyncContext(ctx, RunOptions{RollbackOnCancel: true})
+		Expect(reverted).To(Equal([]string{"create stack 1"}))
+		Expect(errs).NotTo(BeEmpty())
+	})
+})
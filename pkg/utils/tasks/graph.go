@@ -0,0 +1,141 @@
+package tasks
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GraphFormat is the format a task graph is rendered in
+type GraphFormat string
+
+const (
+	// GraphFormatDOT renders the task graph in the Graphviz DOT language
+	GraphFormatDOT GraphFormat = "dot"
+	// GraphFormatMermaid renders the task graph as a Mermaid flowchart
+	GraphFormatMermaid GraphFormat = "mermaid"
+)
+
+// ParseGraphFormat returns the GraphFormat named by format
+func ParseGraphFormat(format string) (GraphFormat, error) {
+	switch f := GraphFormat(strings.ToLower(format)); f {
+	case GraphFormatDOT, GraphFormatMermaid:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid task graph format %q, valid formats are %q and %q", format, GraphFormatDOT, GraphFormatMermaid)
+	}
+}
+
+// Graph renders the tasks of the tree as a directed graph in which an edge from a task to another
+// means the latter only starts once the former has completed. Tasks of parallel trees are grouped
+// together, along with the maximum number of them that run at the same time.
+func (t *TaskTree) Graph(format GraphFormat) (string, error) {
+	if _, err := ParseGraphFormat(string(format)); err != nil {
+		return "", err
+	}
+	g := &graphWriter{format: format}
+	g.writeTree(t, nil, 1)
+
+	var out strings.Builder
+	if format == GraphFormatDOT {
+		out.WriteString("digraph tasks {\n  rankdir=LR;\n  node [shape=box];\n")
+	} else {
+		out.WriteString("flowchart LR\n")
+	}
+	out.WriteString(g.body.String())
+	for _, edge := range g.edges {
+		if format == GraphFormatDOT {
+			fmt.Fprintf(&out, "  %s -> %s;\n", edge[0], edge[1])
+		} else {
+			fmt.Fprintf(&out, "  %s --> %s\n", edge[0], edge[1])
+		}
+	}
+	if format == GraphFormatDOT {
+		out.WriteString("}\n")
+	}
+	return out.String(), nil
+}
+
+type graphWriter struct {
+	format GraphFormat
+	body   strings.Builder
+	edges  [][2]string
+	nodes  int
+	groups int
+}
+
+// writeTree writes the tasks of the tree, each depending on the given predecessors if it has no
+// predecessor within the tree, and returns the tasks the next tasks have to wait for
+func (g *graphWriter) writeTree(t *TaskTree, predecessors []string, depth int) []string {
+	if t.Len() == 0 {
+		return predecessors
+	}
+	if !t.Parallel || t.Len() == 1 {
+		for _, task := range t.Tasks {
+			predecessors = g.writeTask(task, predecessors, depth)
+		}
+		return predecessors
+	}
+
+	g.groups++
+	label := fmt.Sprintf("%d parallel tasks", t.Len())
+	if t.Limit > 0 && t.Limit < t.Len() {
+		label += fmt.Sprintf(", at most %d at a time", t.Limit)
+	}
+	indent := strings.Repeat("  ", depth)
+	if g.format == GraphFormatDOT {
+		fmt.Fprintf(&g.body, "%ssubgraph cluster_%d {\n%s  label=%s;\n", indent, g.groups, indent, dotQuote(label))
+	} else {
+		fmt.Fprintf(&g.body, "%ssubgraph group%d [%s]\n", indent, g.groups, mermaidQuote(label))
+	}
+	var exits []string
+	for _, task := range t.Tasks {
+		exits = append(exits, g.writeTask(task, predecessors, depth+1)...)
+	}
+	if g.format == GraphFormatDOT {
+		fmt.Fprintf(&g.body, "%s}\n", indent)
+	} else {
+		fmt.Fprintf(&g.body, "%send\n", indent)
+	}
+	return exits
+}
+
+func (g *graphWriter) writeTask(task Task, predecessors []string, depth int) []string {
+	if tree, ok := task.(*TaskTree); ok {
+		return g.writeTree(tree, predecessors, depth)
+	}
+	g.nodes++
+	id := fmt.Sprintf("task%d", g.nodes)
+	label := strings.Join(strings.Fields(task.Describe()), " ")
+	indent := strings.Repeat("  ", depth)
+	if g.format == GraphFormatDOT {
+		fmt.Fprintf(&g.body, "%s%s [label=%s];\n", indent, id, dotQuote(label))
+	} else {
+		fmt.Fprintf(&g.body, "%s%s[%s]\n", indent, id, mermaidQuote(label))
+	}
+	for _, predecessor := range predecessors {
+		g.edges = append(g.edges, [2]string{predecessor, id})
+	}
+	return []string{id}
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
+
+// WriteGraph writes the graph of the tree to w in the given format, it writes nothing if no format is given
+func (t *TaskTree) WriteGraph(w io.Writer, format GraphFormat) error {
+	if format == "" {
+		return nil
+	}
+	graph, err := t.Graph(format)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, graph)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/kris-nova/logger"
 	"golang.org/x/sync/errgroup"
@@ -67,6 +68,22 @@ func (t *TaskTree) Len() int {
 	return len(t.Tasks)
 }
 
+// LimitParallelism limits the number of tasks run at the same time by each parallel tree of
+// the tree that is not limited yet; a limit of 0 leaves them unlimited
+func (t *TaskTree) LimitParallelism(limit int) {
+	if t == nil {
+		return
+	}
+	if t.Parallel && t.Limit == 0 {
+		t.Limit = limit
+	}
+	for _, task := range t.Tasks {
+		if subTree, ok := task.(*TaskTree); ok {
+			subTree.LimitParallelism(limit)
+		}
+	}
+}
+
 // Describe collects all tasks which have been added to the task tree.
 // This is a lazy tree which does not track its nodes in any form. This function
 // is recursively called from the rest of the task Describes and eventually
@@ -195,25 +212,61 @@ func (t *TaskTree) DoAllSync() []error {
 	return allErrs
 }
 
+// Observer is notified of the tasks being run, e.g. to display their progress
+type Observer interface {
+	// TaskStarted is called when a task that is not a tree of tasks starts,
+	// it returns the function to call once the task has completed
+	TaskStarted(description string) (completed func(err error))
+}
+
+var (
+	observerMu sync.RWMutex
+	observer   Observer
+)
+
+// SetObserver sets the observer notified of the tasks run by all task trees, nil removes it
+func SetObserver(o Observer) {
+	observerMu.Lock()
+	defer observerMu.Unlock()
+	observer = o
+}
+
+func notifyTaskStarted(task Task, desc string) func(error) {
+	observerMu.RLock()
+	defer observerMu.RUnlock()
+	if _, isTree := task.(*TaskTree); isTree || observer == nil {
+		return func(error) {}
+	}
+	return observer.TaskStarted(desc)
+}
+
 func doSingleTask(allErrs chan error, task Task) bool {
 	desc := task.Describe()
 	logger.Debug("started task: %s", desc)
-	errs := make(chan error)
-	if err := task.Do(errs); err != nil {
-		allErrs <- err
-		return false
-	}
-	if err := <-errs; err != nil {
+	start := time.Now()
+	completed := notifyTaskStarted(task, desc)
+	if err := runTask(task); err != nil {
+		completed(err)
 		allErrs <- err
 		return false
 	}
-	logger.Debug("completed task: %s", desc)
+	completed(nil)
+	logger.Debug("completed task: %s (took %s)", desc, time.Since(start).Round(time.Second))
 	return true
 }
 
+func runTask(task Task) error {
+	errs := make(chan error)
+	if err := task.Do(errs); err != nil {
+		return err
+	}
+	return <-errs
+}
+
 func doParallelTasks(allErrs chan error, tasks []Task) {
 	wg := &sync.WaitGroup{}
 	wg.Add(len(tasks))
+	logger.Debug("starting %d parallel tasks, which do not depend on each other", len(tasks))
 	for t := range tasks {
 		go func(t int) {
 			defer wg.Done()
@@ -230,6 +283,7 @@ func doParallelTasks(allErrs chan error, tasks []Task) {
 func runInErrorGroup(tasks []Task, limit int, errs chan error) {
 	var eg errgroup.Group
 	eg.SetLimit(limit)
+	logger.Debug("starting %d parallel tasks, which do not depend on each other, at most %d at a time", len(tasks), limit)
 	for _, t := range tasks {
 		t := t
 		eg.Go(func() error {
@@ -247,6 +301,9 @@ func runInErrorGroup(tasks []Task, limit int, errs chan error) {
 
 func doSequentialTasks(allErrs chan error, tasks []Task) {
 	for t := range tasks {
+		if t > 0 {
+			logger.Debug("task %q depends on the completion of the previous sequential task %q", tasks[t].Describe(), tasks[t-1].Describe())
+		}
 		if ok := doSingleTask(allErrs, tasks[t]); !ok {
 			logger.Debug("failed task: %s (will not run other sequential tasks)", tasks[t].Describe())
 			break
@@ -623,3 +623,75 @@ var _ = Describe("TaskTree", func() {
 		})
 	})
 })
+
+var _ = Describe("TaskTree graph", func() {
+	newTaskTree := func() *TaskTree {
+		nodeGroups := &TaskTree{Parallel: true, IsSubTask: true, Limit: 1}
+		nodeGroups.Append(&TaskWithoutParams{Info: "create nodegroup \"ng-1\""}, &TaskWithoutParams{Info: "create nodegroup \"ng-2\""})
+		tasks := &TaskTree{}
+		tasks.Append(&TaskWithoutParams{Info: "create cluster control plane"})
+		tasks.Append(nodeGroups)
+		tasks.Append(&TaskWithoutParams{Info: "wait for nodes"})
+		return tasks
+	}
+
+	It("should render DOT", func() {
+		graph, err := newTaskTree().Graph(GraphFormatDOT)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(graph).To(Equal(`digraph tasks {
+  rankdir=LR;
+  node [shape=box];
+  task1 [label="create cluster control plane"];
+  subgraph cluster_1 {
+    label="2 parallel tasks, at most 1 at a time";
+    task2 [label="create nodegroup \"ng-1\""];
+    task3 [label="create nodegroup \"ng-2\""];
+  }
+  task4 [label="wait for nodes"];
+  task1 -> task2;
+  task1 -> task3;
+  task2 -> task4;
+  task3 -> task4;
+}
+`))
+	})
+
+	It("should render Mermaid", func() {
+		graph, err := newTaskTree().Graph(GraphFormatMermaid)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(graph).To(Equal(`flowchart LR
+  task1["create cluster control plane"]
+  subgraph group1 ["2 parallel tasks, at most 1 at a time"]
+    task2["create nodegroup #quot;ng-1#quot;"]
+    task3["create nodegroup #quot;ng-2#quot;"]
+  end
+  task4["wait for nodes"]
+  task1 --> task2
+  task1 --> task3
+  task2 --> task4
+  task3 --> task4
+`))
+	})
+
+	It("should reject unknown formats", func() {
+		_, err := newTaskTree().Graph("svg")
+		Expect(err).To(MatchError(ContainSubstring(`invalid task graph format "svg"`)))
+	})
+})
+
+var _ = Describe("TaskTree parallelism", func() {
+	It("should limit the parallel trees that are not limited yet", func() {
+		limited := &TaskTree{Parallel: true, Limit: 2}
+		unlimited := &TaskTree{Parallel: true}
+		sequential := &TaskTree{}
+		sequential.Append(unlimited)
+		tree := &TaskTree{Parallel: true}
+		tree.Append(limited, sequential, &TaskWithoutParams{Info: "t1"})
+
+		tree.LimitParallelism(5)
+		Expect(tree.Limit).To(Equal(5))
+		Expect(limited.Limit).To(Equal(2))
+		Expect(sequential.Limit).To(Equal(0))
+		Expect(unlimited.Limit).To(Equal(5))
+	})
+})
@@ -0,0 +1,95 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+)
+
+// SetSubnetsForExistingVPC defines the subnets eksctl creates in the pre-existing VPC, as configured by
+// vpc.createSubnets, after checking that their CIDRs are within the CIDR blocks of the VPC.
+// Public and private subnets are keyed by availability zone, in the order of spec.AvailabilityZones.
+func SetSubnetsForExistingVPC(ctx context.Context, ec2API awsapi.EC2, spec *api.ClusterConfig) error {
+	vpc, err := describeVPC(ctx, ec2API, spec.VPC.ID)
+	if err != nil {
+		return fmt.Errorf("describing VPC %q: %w", spec.VPC.ID, err)
+	}
+	if spec.VPC.CIDR == nil {
+		if spec.VPC.CIDR, err = ipnet.ParseCIDR(aws.ToString(vpc.CidrBlock)); err != nil {
+			return err
+		}
+	}
+	vpcCIDRs, err := associatedCIDRs(vpc)
+	if err != nil {
+		return err
+	}
+
+	// subnets are routed through the gateways given in vpc.createSubnets, eksctl does not create a NAT gateway
+	disableNAT := api.ClusterDisableNAT
+	spec.VPC.NAT = &api.ClusterNAT{
+		Gateway: &disableNAT,
+	}
+
+	spec.VPC.Subnets = &api.ClusterSubnets{
+		Public:  api.NewAZSubnetMapping(),
+		Private: api.NewAZSubnetMapping(),
+	}
+	setSubnets := func(subnetMapping api.AZSubnetMapping, cidrs []string) error {
+		for i, cidr := range cidrs {
+			subnetCIDR, err := ipnet.ParseCIDR(cidr)
+			if err != nil {
+				return err
+			}
+			if !containedInAny(&subnetCIDR.IPNet, vpcCIDRs) {
+				return fmt.Errorf("subnet CIDR %q is not within the CIDR blocks of VPC %q", cidr, spec.VPC.ID)
+			}
+			az := spec.AvailabilityZones[i]
+			subnetMapping[az] = api.AZSubnetSpec{
+				AZ:   az,
+				CIDR: subnetCIDR,
+			}
+		}
+		return nil
+	}
+	if err := setSubnets(spec.VPC.Subnets.Public, spec.VPC.CreateSubnets.PublicCIDRs); err != nil {
+		return err
+	}
+	return setSubnets(spec.VPC.Subnets.Private, spec.VPC.CreateSubnets.PrivateCIDRs)
+}
+
+func associatedCIDRs(vpc ec2types.Vpc) ([]*net.IPNet, error) {
+	cidrBlocks := []string{aws.ToString(vpc.CidrBlock)}
+	for _, assoc := range vpc.CidrBlockAssociationSet {
+		if assoc.CidrBlockState != nil && assoc.CidrBlockState.State == ec2types.VpcCidrBlockStateCodeAssociated {
+			cidrBlocks = append(cidrBlocks, aws.ToString(assoc.CidrBlock))
+		}
+	}
+
+	var cidrs []*net.IPNet
+	for _, cidrBlock := range cidrBlocks {
+		_, cidr, err := net.ParseCIDR(cidrBlock)
+		if err != nil {
+			return nil, fmt.Errorf("parsing CIDR block %q of VPC %q: %w", cidrBlock, aws.ToString(vpc.VpcId), err)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs, nil
+}
+
+func containedInAny(subnet *net.IPNet, cidrs []*net.IPNet) bool {
+	subnetOnes, _ := subnet.Mask.Size()
+	for _, cidr := range cidrs {
+		ones, _ := cidr.Mask.Size()
+		if cidr.Contains(subnet.IP) && subnetOnes >= ones {
+			return true
+		}
+	}
+	return false
+}
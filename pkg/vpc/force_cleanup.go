@@ -0,0 +1,343 @@
+package vpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/smithy-go"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	kubernetesClusterTagPrefix = "kubernetes.io/cluster/"
+	elbv2ClusterTagKey         = "elbv2.k8s.aws/cluster"
+	vpcCNIClusterTagKey        = "cluster.k8s.amazonaws.com/name"
+	cloudFormationStackTagKey  = "aws:cloudformation:stack-name"
+
+	// maxDescribeTagsResources is the number of load balancers whose tags can be described at once
+	maxDescribeTagsResources = 20
+)
+
+// ForceCleanupRetryInterval is the time to wait before deleting again the security groups whose load balancer or
+// network interfaces are still being deleted
+var ForceCleanupRetryInterval = 10 * time.Second
+
+// ForceCleanup deletes the resources that Kubernetes controllers, e.g. the AWS Load Balancer Controller, the
+// in-tree cloud provider and the VPC CNI, left behind in the VPC of a cluster and that keep the VPC from
+// being deleted: load balancers, available network interfaces and security groups. Only resources tagged with
+// the name of the cluster are deleted, and never those that belong to a CloudFormation stack. The cleanup gives up
+// after the wait timeout of the provider.
+func ForceCleanup(ctx context.Context, provider api.ClusterProvider, spec *api.ClusterConfig) error {
+	if spec.VPC == nil || spec.VPC.ID == "" {
+		return errors.New("the VPC of the cluster is unknown")
+	}
+	ctx, cancel := context.WithTimeout(ctx, provider.WaitTimeout())
+	defer cancel()
+	clusterName, vpcID := spec.Metadata.Name, spec.VPC.ID
+	logger.Info("cleaning up the resources left behind in VPC %q by the controllers of cluster %q", vpcID, clusterName)
+
+	if err := deleteClassicLoadBalancers(ctx, provider, clusterName, vpcID); err != nil {
+		return err
+	}
+	if err := deleteLoadBalancersV2(ctx, provider, clusterName, vpcID); err != nil {
+		return err
+	}
+
+	securityGroups, err := findClusterSecurityGroups(ctx, provider, clusterName, vpcID)
+	if err != nil {
+		return err
+	}
+	if err := revokeSecurityGroupReferences(ctx, provider, vpcID, securityGroups); err != nil {
+		return err
+	}
+
+	// load balancers take a while to release their network interfaces, which keeps their security groups in use
+	for {
+		if err := deleteAvailableNetworkInterfaces(ctx, provider, spec); err != nil {
+			return err
+		}
+		securityGroups, err = deleteSecurityGroups(ctx, provider, securityGroups)
+		if err != nil || len(securityGroups) == 0 {
+			return err
+		}
+		logger.Info("waiting for %d security group(s) to no longer be in use", len(securityGroups))
+		timer := time.NewTimer(ForceCleanupRetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("timed out deleting security groups %s: %w", strings.Join(securityGroupIDs(securityGroups), ", "), ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func deleteClassicLoadBalancers(ctx context.Context, provider api.ClusterProvider, clusterName, vpcID string) error {
+	var names []string
+	paginator := elasticloadbalancing.NewDescribeLoadBalancersPaginator(provider.ELB(), &elasticloadbalancing.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("listing classic load balancers: %w", err)
+		}
+		for _, lb := range output.LoadBalancerDescriptions {
+			if aws.ToString(lb.VPCId) == vpcID {
+				names = append(names, aws.ToString(lb.LoadBalancerName))
+			}
+		}
+	}
+
+	for _, chunk := range chunks(names) {
+		output, err := provider.ELB().DescribeTags(ctx, &elasticloadbalancing.DescribeTagsInput{LoadBalancerNames: chunk})
+		if err != nil {
+			return fmt.Errorf("describing the tags of classic load balancers: %w", err)
+		}
+		for _, desc := range output.TagDescriptions {
+			tags := map[string]string{}
+			for _, tag := range desc.Tags {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			if !ownedByCluster(tags, clusterName) {
+				continue
+			}
+			logger.Info("deleting classic load balancer %q", aws.ToString(desc.LoadBalancerName))
+			if _, err := provider.ELB().DeleteLoadBalancer(ctx, &elasticloadbalancing.DeleteLoadBalancerInput{
+				LoadBalancerName: desc.LoadBalancerName,
+			}); err != nil {
+				return fmt.Errorf("deleting classic load balancer %q: %w", aws.ToString(desc.LoadBalancerName), err)
+			}
+		}
+	}
+	return nil
+}
+
+func deleteLoadBalancersV2(ctx context.Context, provider api.ClusterProvider, clusterName, vpcID string) error {
+	var arns []string
+	paginator := elasticloadbalancingv2.NewDescribeLoadBalancersPaginator(provider.ELBV2(), &elasticloadbalancingv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("listing load balancers: %w", err)
+		}
+		for _, lb := range output.LoadBalancers {
+			if aws.ToString(lb.VpcId) == vpcID {
+				arns = append(arns, aws.ToString(lb.LoadBalancerArn))
+			}
+		}
+	}
+
+	for _, chunk := range chunks(arns) {
+		output, err := provider.ELBV2().DescribeTags(ctx, &elasticloadbalancingv2.DescribeTagsInput{ResourceArns: chunk})
+		if err != nil {
+			return fmt.Errorf("describing the tags of load balancers: %w", err)
+		}
+		for _, desc := range output.TagDescriptions {
+			tags := map[string]string{}
+			for _, tag := range desc.Tags {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			if !ownedByCluster(tags, clusterName) {
+				continue
+			}
+			logger.Info("deleting load balancer %q", aws.ToString(desc.ResourceArn))
+			if _, err := provider.ELBV2().DeleteLoadBalancer(ctx, &elasticloadbalancingv2.DeleteLoadBalancerInput{
+				LoadBalancerArn: desc.ResourceArn,
+			}); err != nil {
+				return fmt.Errorf("deleting load balancer %q: %w", aws.ToString(desc.ResourceArn), err)
+			}
+		}
+	}
+	return nil
+}
+
+func findClusterSecurityGroups(ctx context.Context, provider api.ClusterProvider, clusterName, vpcID string) ([]ec2types.SecurityGroup, error) {
+	var securityGroups []ec2types.SecurityGroup
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(provider.EC2(), &ec2.DescribeSecurityGroupsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcID},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing the security groups of VPC %q: %w", vpcID, err)
+		}
+		for _, sg := range output.SecurityGroups {
+			tags := map[string]string{}
+			for _, tag := range sg.Tags {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			if _, ok := tags[cloudFormationStackTagKey]; ok || !ownedByCluster(tags, clusterName) {
+				continue
+			}
+			securityGroups = append(securityGroups, sg)
+		}
+	}
+	return securityGroups, nil
+}
+
+// revokeSecurityGroupReferences revokes the ingress rules of the other security groups in the VPC that allow
+// traffic from securityGroups, e.g. the rules the AWS Load Balancer Controller adds to the node security groups,
+// as a security group cannot be deleted while it is referenced
+func revokeSecurityGroupReferences(ctx context.Context, provider api.ClusterProvider, vpcID string, securityGroups []ec2types.SecurityGroup) error {
+	if len(securityGroups) == 0 {
+		return nil
+	}
+	deleted := map[string]bool{}
+	for _, sg := range securityGroups {
+		deleted[aws.ToString(sg.GroupId)] = true
+	}
+
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(provider.EC2(), &ec2.DescribeSecurityGroupsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcID},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("listing the security groups of VPC %q: %w", vpcID, err)
+		}
+		for _, sg := range output.SecurityGroups {
+			var revoked []ec2types.IpPermission
+			for _, permission := range sg.IpPermissions {
+				for _, pair := range permission.UserIdGroupPairs {
+					if !deleted[aws.ToString(pair.GroupId)] {
+						continue
+					}
+					revoked = append(revoked, ec2types.IpPermission{
+						IpProtocol:       permission.IpProtocol,
+						FromPort:         permission.FromPort,
+						ToPort:           permission.ToPort,
+						UserIdGroupPairs: []ec2types.UserIdGroupPair{{GroupId: pair.GroupId}},
+					})
+				}
+			}
+			if len(revoked) == 0 {
+				continue
+			}
+			logger.Info("revoking %d ingress rule(s) of security group %q that reference deleted security groups", len(revoked), aws.ToString(sg.GroupId))
+			if _, err := provider.EC2().RevokeSecurityGroupIngress(ctx, &ec2.RevokeSecurityGroupIngressInput{
+				GroupId:       sg.GroupId,
+				IpPermissions: revoked,
+			}); err != nil {
+				return fmt.Errorf("revoking the ingress rules of security group %q: %w", aws.ToString(sg.GroupId), err)
+			}
+		}
+	}
+	return nil
+}
+
+// deleteAvailableNetworkInterfaces deletes the network interfaces that are no longer attached and that either
+// belong to the security groups of eksctl or were created by the VPC CNI for the cluster
+func deleteAvailableNetworkInterfaces(ctx context.Context, provider api.ClusterProvider, spec *api.ClusterConfig) error {
+	eniIDs, err := findDanglingENIs(ctx, provider.EC2(), spec)
+	if err != nil {
+		return err
+	}
+	paginator := ec2.NewDescribeNetworkInterfacesPaginator(provider.EC2(), &ec2.DescribeNetworkInterfacesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{spec.VPC.ID},
+			},
+			{
+				Name:   aws.String("status"),
+				Values: []string{"available"},
+			},
+			{
+				Name:   aws.String("tag:" + vpcCNIClusterTagKey),
+				Values: []string{spec.Metadata.Name},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("listing the network interfaces of VPC %q: %w", spec.VPC.ID, err)
+		}
+		for _, eni := range output.NetworkInterfaces {
+			eniIDs = append(eniIDs, aws.ToString(eni.NetworkInterfaceId))
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, id := range eniIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		logger.Info("deleting network interface %q", id)
+		if _, err := provider.EC2().DeleteNetworkInterface(ctx, &ec2.DeleteNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String(id),
+		}); err != nil && !isErrorCode(err, "InvalidNetworkInterfaceID.NotFound") {
+			return fmt.Errorf("deleting network interface %q: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// deleteSecurityGroups deletes securityGroups and returns those that are still in use
+func deleteSecurityGroups(ctx context.Context, provider api.ClusterProvider, securityGroups []ec2types.SecurityGroup) ([]ec2types.SecurityGroup, error) {
+	var inUse []ec2types.SecurityGroup
+	for _, sg := range securityGroups {
+		_, err := provider.EC2().DeleteSecurityGroup(ctx, &ec2.DeleteSecurityGroupInput{GroupId: sg.GroupId})
+		switch {
+		case err == nil:
+			logger.Info("deleted security group %q (%s)", aws.ToString(sg.GroupName), aws.ToString(sg.GroupId))
+		case isErrorCode(err, "DependencyViolation"):
+			inUse = append(inUse, sg)
+		case isErrorCode(err, "InvalidGroup.NotFound"):
+		default:
+			return nil, fmt.Errorf("deleting security group %q: %w", aws.ToString(sg.GroupId), err)
+		}
+	}
+	return inUse, nil
+}
+
+func ownedByCluster(tags map[string]string, clusterName string) bool {
+	if _, ok := tags[kubernetesClusterTagPrefix+clusterName]; ok {
+		return true
+	}
+	return tags[elbv2ClusterTagKey] == clusterName
+}
+
+func chunks(items []string) [][]string {
+	var result [][]string
+	for len(items) > maxDescribeTagsResources {
+		result = append(result, items[:maxDescribeTagsResources])
+		items = items[maxDescribeTagsResources:]
+	}
+	if len(items) > 0 {
+		result = append(result, items)
+	}
+	return result
+}
+
+func securityGroupIDs(securityGroups []ec2types.SecurityGroup) []string {
+	var ids []string
+	for _, sg := range securityGroups {
+		ids = append(ids, aws.ToString(sg.GroupId))
+	}
+	return ids
+}
+
+func isErrorCode(err error, code string) bool {
+	var ae smithy.APIError
+	return errors.As(err, &ae) && ae.ErrorCode() == code
+}
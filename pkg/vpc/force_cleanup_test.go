@@ -0,0 +1,143 @@
+package vpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("ForceCleanup", func() {
+	const (
+		clusterName = "my-cluster"
+		vpcID       = "vpc-123"
+		lbARN       = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/k8s-default-web/1"
+	)
+
+	var (
+		provider *mockprovider.MockProvider
+		spec     *api.ClusterConfig
+	)
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+		spec = api.NewClusterConfig()
+		spec.Metadata.Name = clusterName
+		spec.VPC.ID = vpcID
+		retryInterval := ForceCleanupRetryInterval
+		ForceCleanupRetryInterval = time.Millisecond
+		DeferCleanup(func() {
+			ForceCleanupRetryInterval = retryInterval
+		})
+
+		provider.MockELB().On("DescribeLoadBalancers", mock.Anything, mock.Anything, mock.Anything).Return(&elasticloadbalancing.DescribeLoadBalancersOutput{
+			LoadBalancerDescriptions: []elbtypes.LoadBalancerDescription{
+				{LoadBalancerName: aws.String("a1b2c3"), VPCId: aws.String(vpcID)},
+				{LoadBalancerName: aws.String("other-vpc"), VPCId: aws.String("vpc-456")},
+			},
+		}, nil)
+		provider.MockELB().On("DescribeTags", mock.Anything, &elasticloadbalancing.DescribeTagsInput{
+			LoadBalancerNames: []string{"a1b2c3"},
+		}).Return(&elasticloadbalancing.DescribeTagsOutput{
+			TagDescriptions: []elbtypes.TagDescription{{
+				LoadBalancerName: aws.String("a1b2c3"),
+				Tags:             []elbtypes.Tag{{Key: aws.String("kubernetes.io/cluster/" + clusterName), Value: aws.String("owned")}},
+			}},
+		}, nil)
+		provider.MockELB().On("DeleteLoadBalancer", mock.Anything, mock.Anything).Return(&elasticloadbalancing.DeleteLoadBalancerOutput{}, nil)
+
+		provider.MockELBV2().On("DescribeLoadBalancers", mock.Anything, mock.Anything, mock.Anything).Return(&elasticloadbalancingv2.DescribeLoadBalancersOutput{
+			LoadBalancers: []elbv2types.LoadBalancer{{LoadBalancerArn: aws.String(lbARN), VpcId: aws.String(vpcID)}},
+		}, nil)
+		provider.MockELBV2().On("DescribeTags", mock.Anything, &elasticloadbalancingv2.DescribeTagsInput{
+			ResourceArns: []string{lbARN},
+		}).Return(&elasticloadbalancingv2.DescribeTagsOutput{
+			TagDescriptions: []elbv2types.TagDescription{{
+				ResourceArn: aws.String(lbARN),
+				Tags:        []elbv2types.Tag{{Key: aws.String("elbv2.k8s.aws/cluster"), Value: aws.String(clusterName)}},
+			}},
+		}, nil)
+		provider.MockELBV2().On("DeleteLoadBalancer", mock.Anything, mock.Anything).Return(&elasticloadbalancingv2.DeleteLoadBalancerOutput{}, nil)
+
+		provider.MockEC2().On("DescribeSecurityGroups", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{
+			SecurityGroups: []ec2types.SecurityGroup{
+				{
+					GroupId:   aws.String("sg-lb"),
+					GroupName: aws.String("k8s-traffic-mycluster"),
+					Tags:      []ec2types.Tag{{Key: aws.String("elbv2.k8s.aws/cluster"), Value: aws.String(clusterName)}},
+				},
+				{
+					GroupId:   aws.String("sg-nodes"),
+					GroupName: aws.String("eksctl-my-cluster-cluster-ClusterSharedNodeSecurityGroup"),
+					Tags: []ec2types.Tag{
+						{Key: aws.String("kubernetes.io/cluster/" + clusterName), Value: aws.String("owned")},
+						{Key: aws.String("aws:cloudformation:stack-name"), Value: aws.String("eksctl-my-cluster-cluster")},
+					},
+					IpPermissions: []ec2types.IpPermission{{
+						IpProtocol:       aws.String("tcp"),
+						FromPort:         aws.Int32(80),
+						ToPort:           aws.Int32(80),
+						UserIdGroupPairs: []ec2types.UserIdGroupPair{{GroupId: aws.String("sg-lb")}},
+					}},
+				},
+			},
+		}, nil)
+		provider.MockEC2().On("RevokeSecurityGroupIngress", mock.Anything, mock.Anything).Return(&ec2.RevokeSecurityGroupIngressOutput{}, nil)
+		provider.MockEC2().On("DescribeNetworkInterfaces", mock.Anything, mock.Anything, mock.Anything).Return(&ec2.DescribeNetworkInterfacesOutput{}, nil)
+	})
+
+	It("deletes the load balancers and security groups of the cluster", func() {
+		provider.MockEC2().On("DeleteSecurityGroup", mock.Anything, mock.Anything).Return(nil, &smithy.GenericAPIError{Code: "DependencyViolation"}).Once()
+		provider.MockEC2().On("DeleteSecurityGroup", mock.Anything, mock.Anything).Return(&ec2.DeleteSecurityGroupOutput{}, nil).Once()
+
+		Expect(ForceCleanup(context.Background(), provider, spec)).To(Succeed())
+
+		provider.MockELB().AssertCalled(GinkgoT(), "DeleteLoadBalancer", mock.Anything, &elasticloadbalancing.DeleteLoadBalancerInput{LoadBalancerName: aws.String("a1b2c3")})
+		provider.MockELBV2().AssertCalled(GinkgoT(), "DeleteLoadBalancer", mock.Anything, &elasticloadbalancingv2.DeleteLoadBalancerInput{LoadBalancerArn: aws.String(lbARN)})
+		provider.MockEC2().AssertCalled(GinkgoT(), "RevokeSecurityGroupIngress", mock.Anything, &ec2.RevokeSecurityGroupIngressInput{
+			GroupId: aws.String("sg-nodes"),
+			IpPermissions: []ec2types.IpPermission{{
+				IpProtocol:       aws.String("tcp"),
+				FromPort:         aws.Int32(80),
+				ToPort:           aws.Int32(80),
+				UserIdGroupPairs: []ec2types.UserIdGroupPair{{GroupId: aws.String("sg-lb")}},
+			}},
+		})
+		provider.MockEC2().AssertNumberOfCalls(GinkgoT(), "DeleteSecurityGroup", 2)
+		provider.MockEC2().AssertCalled(GinkgoT(), "DeleteSecurityGroup", mock.Anything, &ec2.DeleteSecurityGroupInput{GroupId: aws.String("sg-lb")})
+		provider.MockEC2().AssertNotCalled(GinkgoT(), "DeleteSecurityGroup", mock.Anything, &ec2.DeleteSecurityGroupInput{GroupId: aws.String("sg-nodes")})
+	})
+
+	It("gives up once the context is done", func() {
+		provider.MockEC2().On("DeleteSecurityGroup", mock.Anything, mock.Anything).Return(nil, &smithy.GenericAPIError{Code: "DependencyViolation"})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		Expect(ForceCleanup(ctx, provider, spec)).To(MatchError(ContainSubstring("timed out deleting security groups sg-lb")))
+	})
+
+	It("gives up after the wait timeout", func() {
+		provider.MockEC2().On("DeleteSecurityGroup", mock.Anything, mock.Anything).Return(nil, &smithy.GenericAPIError{Code: "DependencyViolation"})
+		provider.SetWaitTimeout(50 * time.Millisecond)
+
+		Expect(ForceCleanup(context.Background(), provider, spec)).To(MatchError(ContainSubstring("timed out deleting security groups sg-lb")))
+	})
+
+	It("fails when the VPC is unknown", func() {
+		spec.VPC.ID = ""
+		Expect(ForceCleanup(context.Background(), provider, spec)).To(MatchError("the VPC of the cluster is unknown"))
+	})
+})
@@ -0,0 +1,51 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+// ResolvePublicAccessCIDRs replaces the IDs of managed prefix lists in cidrs with the CIDRs of their entries,
+// dropping duplicates. ec2API is only used if cidrs contains prefix lists.
+func ResolvePublicAccessCIDRs(ctx context.Context, ec2API awsapi.EC2, cidrs []string) ([]string, error) {
+	var resolved []string
+	seen := map[string]struct{}{}
+	add := func(cidr string) {
+		if _, ok := seen[cidr]; !ok {
+			seen[cidr] = struct{}{}
+			resolved = append(resolved, cidr)
+		}
+	}
+
+	for _, cidr := range cidrs {
+		if !api.IsPrefixListID(cidr) {
+			add(cidr)
+			continue
+		}
+		var entries int
+		paginator := ec2.NewGetManagedPrefixListEntriesPaginator(ec2API, &ec2.GetManagedPrefixListEntriesInput{
+			PrefixListId: aws.String(cidr),
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("getting entries of prefix list %q: %w", cidr, err)
+			}
+			for _, entry := range output.Entries {
+				add(aws.ToString(entry.Cidr))
+				entries++
+			}
+		}
+		// an empty list of public access CIDRs allows access from anywhere
+		if entries == 0 {
+			return nil, fmt.Errorf("prefix list %q has no entries", cidr)
+		}
+	}
+	return resolved, nil
+}
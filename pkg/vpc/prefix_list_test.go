@@ -0,0 +1,65 @@
+package vpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("ResolvePublicAccessCIDRs", func() {
+	var provider *mockprovider.MockProvider
+
+	mockPrefixList := func(prefixListID string, cidrs ...string) {
+		var entries []ec2types.PrefixListEntry
+		for _, cidr := range cidrs {
+			entries = append(entries, ec2types.PrefixListEntry{Cidr: aws.String(cidr)})
+		}
+		provider.MockEC2().On("GetManagedPrefixListEntries", mock.Anything, &ec2.GetManagedPrefixListEntriesInput{
+			PrefixListId: aws.String(prefixListID),
+		}, mock.Anything).Return(&ec2.GetManagedPrefixListEntriesOutput{
+			Entries: entries,
+		}, nil)
+	}
+
+	BeforeEach(func() {
+		provider = mockprovider.NewMockProvider()
+	})
+
+	It("returns CIDRs without calling the EC2 API", func() {
+		cidrs, err := ResolvePublicAccessCIDRs(context.Background(), provider.EC2(), []string{"10.0.0.0/8"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cidrs).To(Equal([]string{"10.0.0.0/8"}))
+		provider.MockEC2().AssertNotCalled(GinkgoT(), "GetManagedPrefixListEntries", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("replaces prefix lists with their entries", func() {
+		mockPrefixList("pl-11111111", "192.0.2.0/24", "198.51.100.0/24")
+		mockPrefixList("pl-22222222", "198.51.100.0/24", "203.0.113.0/24")
+
+		cidrs, err := ResolvePublicAccessCIDRs(context.Background(), provider.EC2(), []string{"10.0.0.0/8", "pl-11111111", "pl-22222222"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cidrs).To(Equal([]string{"10.0.0.0/8", "192.0.2.0/24", "198.51.100.0/24", "203.0.113.0/24"}))
+	})
+
+	It("returns an error if a prefix list has no entries", func() {
+		mockPrefixList("pl-11111111")
+
+		_, err := ResolvePublicAccessCIDRs(context.Background(), provider.EC2(), []string{"pl-11111111"})
+		Expect(err).To(MatchError(`prefix list "pl-11111111" has no entries`))
+	})
+
+	It("returns an error if the entries cannot be fetched", func() {
+		provider.MockEC2().On("GetManagedPrefixListEntries", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+
+		_, err := ResolvePublicAccessCIDRs(context.Background(), provider.EC2(), []string{"pl-11111111"})
+		Expect(err).To(MatchError(ContainSubstring(`getting entries of prefix list "pl-11111111": access denied`)))
+	})
+})
@@ -792,7 +792,7 @@ func SelectNodeGroupSubnets(ctx context.Context, np api.NodePool, clusterConfig
 			return fmt.Errorf("unexpected error finding zone type for zone %q", zone)
 		}
 		if nodes.IsManaged(np) {
-			if zoneType == ZoneTypeLocalZone {
+			if zoneType == ZoneTypeLocalZone || zoneType == ZoneTypeWavelengthZone {
 				return fmt.Errorf("managed nodegroups cannot be launched in local zones: %q", ng.Name)
 			}
 			return nil
@@ -822,6 +822,9 @@ func SelectNodeGroupSubnets(ctx context.Context, np api.NodePool, clusterConfig
 		if !ok {
 			return fmt.Errorf("unexpected error finding zone type for zone %q", zone)
 		}
+		if zoneType == ZoneTypeWavelengthZone {
+			return validateWavelengthZoneInstanceSupport(ctx, ec2API, np, zone)
+		}
 		// only validate instance support for availability zones
 		if zoneType == ZoneTypeAvailabilityZone &&
 			slice.Contains(clusterConfig.AvailabilityZones, zone) && // for now, we won't validate support for user specified new zones
@@ -834,6 +837,18 @@ func SelectNodeGroupSubnets(ctx context.Context, np api.NodePool, clusterConfig
 
 	var subnetIDs []string
 	if len(zones) > 0 {
+		if err := validateNoMixedWavelengthZones(zones, func() (map[string]ZoneType, error) {
+			if zoneTypeMapping == nil {
+				output, err := DiscoverZoneTypes(ctx, ec2API, clusterConfig.Metadata.Region)
+				if err != nil {
+					return nil, fmt.Errorf("error discovering zone types: %w", err)
+				}
+				zoneTypeMapping = &output
+			}
+			return *zoneTypeMapping, nil
+		}); err != nil {
+			return nil, err
+		}
 		var err error
 		if subnetIDs, err = selectNodeGroupZoneSubnets(zones, subnetMapping, validateZoneInstanceSupport); err != nil {
 			return nil, fmt.Errorf("could not find %s subnets for zones %q %s: %w", getNetworkType(ng), zones, makeErrorDesc(), err)
@@ -861,6 +876,52 @@ func SelectNodeGroupSubnets(ctx context.Context, np api.NodePool, clusterConfig
 	return subnetIDs, nil
 }
 
+// validateNoMixedWavelengthZones ensures that a nodegroup is not launched in both Wavelength Zones and other zones,
+// as only instances in Wavelength Zones can be assigned a carrier IP.
+func validateNoMixedWavelengthZones(zones []string, getZoneTypes func() (map[string]ZoneType, error)) error {
+	if len(zones) < 2 {
+		return nil
+	}
+	zoneTypes, err := getZoneTypes()
+	if err != nil {
+		return err
+	}
+	wavelengthZones := 0
+	for _, zone := range zones {
+		if zoneTypes[zone] == ZoneTypeWavelengthZone {
+			wavelengthZones++
+		}
+	}
+	if wavelengthZones > 0 && wavelengthZones != len(zones) {
+		return fmt.Errorf("cannot launch a nodegroup in both Wavelength Zones and other zones %q", zones)
+	}
+	return nil
+}
+
+// validateWavelengthZoneInstanceSupport validates that all instance types of a nodegroup are offered in a Wavelength Zone,
+// which only supports a small set of instance types.
+func validateWavelengthZoneInstanceSupport(ctx context.Context, ec2API awsapi.EC2, np api.NodePool, zone string) error {
+	instanceTypes := nodes.CollectUniqueInstanceTypes([]api.NodePool{np})
+	if len(instanceTypes) == 0 {
+		return nil
+	}
+	offerings, err := az.GetInstanceTypeOfferings(ctx, ec2API, instanceTypes, []string{zone})
+	if err != nil {
+		return err
+	}
+	var unsupported []string
+	for _, instanceType := range instanceTypes {
+		if _, ok := offerings[zone][instanceType]; !ok {
+			unsupported = append(unsupported, instanceType)
+		}
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("cannot create nodegroup %s in Wavelength Zone %s as it does not support instance type(s) %s",
+			np.BaseNodeGroup().Name, zone, strings.Join(unsupported, ","))
+	}
+	return nil
+}
+
 func selectNodeGroupZoneSubnets(nodeGroupZones []string,
 	subnetMapping api.AZSubnetMapping,
 	validateSubnetZoneInstanceSupport func(zone string) error) ([]string, error) {
@@ -18,6 +18,7 @@ type ZoneType int
 const (
 	ZoneTypeAvailabilityZone ZoneType = iota
 	ZoneTypeLocalZone
+	ZoneTypeWavelengthZone
 )
 
 // DiscoverZoneTypes returns a map of zone names to zone type.
@@ -44,6 +45,8 @@ func DiscoverZoneTypes(ctx context.Context, ec2API awsapi.EC2, region string) (m
 			zoneTypeMapping[*z.ZoneName] = ZoneTypeAvailabilityZone
 		case "local-zone":
 			zoneTypeMapping[*z.ZoneName] = ZoneTypeLocalZone
+		case "wavelength-zone":
+			zoneTypeMapping[*z.ZoneName] = ZoneTypeWavelengthZone
 		}
 	}
 	return zoneTypeMapping, nil